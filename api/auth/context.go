@@ -8,8 +8,14 @@ import (
 	"github.com/MichaelMure/git-bug/entity"
 )
 
-// identityCtxKey is a unique context key, accessible only in this package.
-var identityCtxKey = &struct{}{}
+// ctxKey is the type of the context keys of this package, accessible only in
+// this package.
+type ctxKey int
+
+const (
+	identityCtxKey ctxKey = iota
+	scopeCtxKey
+)
 
 // CtxWithUser attaches an Identity to a context.
 func CtxWithUser(ctx context.Context, userId entity.Id) context.Context {
@@ -26,3 +32,25 @@ func UserFromCtx(ctx context.Context, r *cache.RepoCache) (*cache.IdentityCache,
 	}
 	return r.ResolveIdentity(id)
 }
+
+// UserIdFromCtx retrieves the id of the identity attached to the context,
+// without resolving it.
+func UserIdFromCtx(ctx context.Context) (entity.Id, bool) {
+	id, ok := ctx.Value(identityCtxKey).(entity.Id)
+	return id, ok
+}
+
+// CtxWithScope restrict what the user of a context is allowed to do.
+func CtxWithScope(ctx context.Context, scope Scope) context.Context {
+	return context.WithValue(ctx, scopeCtxKey, scope)
+}
+
+// ScopeFromCtx retrieves what the user of a context is allowed to do.
+// Without a scope attached, the user can only read.
+func ScopeFromCtx(ctx context.Context) Scope {
+	scope, ok := ctx.Value(scopeCtxKey).(Scope)
+	if !ok {
+		return ScopeRead
+	}
+	return scope
+}
@@ -4,3 +4,9 @@ import "errors"
 
 // ErrNotAuthenticated is returned to the client if the user requests an action requiring authentication, and they are not authenticated.
 var ErrNotAuthenticated = errors.New("not authenticated or read-only")
+
+// ErrTokenNotExist is returned when removing a token that doesn't exist.
+var ErrTokenNotExist = errors.New("token doesn't exist")
+
+// ErrReadOnlyToken is returned to the client if the user requests a modification with a read scoped token.
+var ErrReadOnlyToken = errors.New("read-only token")
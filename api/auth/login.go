@@ -0,0 +1,72 @@
+package auth
+
+import (
+	"html/template"
+	"net/http"
+)
+
+var loginTemplate = template.Must(template.New("login").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>git-bug: log in</title>
+</head>
+<body style="font-family: sans-serif; max-width: 24em; margin: 4em auto">
+<h1>git-bug</h1>
+{{if .Failed}}<p style="color: #c00">Invalid token.</p>{{end}}
+<form method="post">
+<p><label>Token<br><input type="password" name="token" autofocus style="width: 100%"></label></p>
+<p><button type="submit">Log in</button></p>
+</form>
+</body>
+</html>
+`))
+
+// NewLoginHandler serve a form to log in the web UI with a token, stored in
+// a cookie for the next requests. basePath is the URL path the web UI is
+// served under.
+func NewLoginHandler(tokens []Token, basePath string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			_ = loginTemplate.Execute(w, struct{ Failed bool }{})
+
+		case http.MethodPost:
+			secret := r.PostFormValue("token")
+			if _, ok := matchToken(tokens, secret); !ok {
+				w.Header().Set("Content-Type", "text/html; charset=utf-8")
+				w.WriteHeader(http.StatusUnauthorized)
+				_ = loginTemplate.Execute(w, struct{ Failed bool }{true})
+				return
+			}
+
+			http.SetCookie(w, &http.Cookie{
+				Name:     TokenCookie,
+				Value:    secret,
+				Path:     basePath,
+				HttpOnly: true,
+				Secure:   r.TLS != nil,
+				SameSite: http.SameSiteStrictMode,
+			})
+			http.Redirect(w, r, basePath, http.StatusSeeOther)
+
+		default:
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+}
+
+// NewLogoutHandler remove the cookie of a user logged in the web UI
+func NewLogoutHandler(basePath string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.SetCookie(w, &http.Cookie{
+			Name:     TokenCookie,
+			Value:    "",
+			Path:     basePath,
+			MaxAge:   -1,
+			HttpOnly: true,
+		})
+		http.Redirect(w, r, basePath, http.StatusSeeOther)
+	})
+}
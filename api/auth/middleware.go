@@ -3,6 +3,7 @@ package auth
 import (
 	"net/http"
 
+	"github.com/MichaelMure/git-bug/cache"
 	"github.com/MichaelMure/git-bug/entity"
 )
 
@@ -10,6 +11,24 @@ func Middleware(fixedUserId entity.Id) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			ctx := CtxWithUser(r.Context(), fixedUserId)
+			ctx = CtxWithScope(ctx, ScopeWrite)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// RepoUserMiddleware authenticate the requests as the user identity of a
+// repository. It is read at each request, as it can be changed while serving.
+func RepoUserMiddleware(repo *cache.RepoCache) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			user, err := repo.GetUserIdentity()
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			ctx := CtxWithUser(r.Context(), user.Id())
+			ctx = CtxWithScope(ctx, ScopeWrite)
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
 	}
@@ -0,0 +1,232 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+// the tokens are stored in the git config, each one with the identity it
+// authenticate and what it allow to do:
+// git-bug.webui.token.<name>.hash = <sha256 of the token>
+// git-bug.webui.token.<name>.identity = <identity id>
+// git-bug.webui.token.<name>.scope = read|write
+// A token can also be stored in clear with the secret key instead of hash.
+const tokenConfigKeyPrefix = "git-bug.webui.token."
+
+// TokenCookie is the cookie holding the token of a user logged in the web UI
+const TokenCookie = "git-bug-token"
+
+// Scope is what a token allow its user to do
+type Scope string
+
+const (
+	// ScopeRead only allow to read the data
+	ScopeRead Scope = "read"
+	// ScopeWrite allow to read and modify the data
+	ScopeWrite Scope = "write"
+)
+
+// Token is a static secret authenticating a user as an identity
+type Token struct {
+	Name string
+	// the secret in clear, for the tokens written by hand in the config
+	Secret string
+	// the hex encoded sha256 of the secret, for the generated tokens
+	Hash     string
+	Identity entity.Id
+	Scope    Scope
+}
+
+// NewToken generate a random token, and return it with its secret. Only the
+// hash of the secret is kept in the token, the secret can't be retrieved
+// afterward.
+func NewToken(name string, identity entity.Id, scope Scope) (Token, string, error) {
+	raw := make([]byte, 32)
+	_, err := rand.Read(raw)
+	if err != nil {
+		return Token{}, "", err
+	}
+	secret := hex.EncodeToString(raw)
+
+	return Token{
+		Name:     name,
+		Hash:     hashSecret(secret),
+		Identity: identity,
+		Scope:    scope,
+	}, secret, nil
+}
+
+func hashSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// StoreToken write a token in the config, replacing the one with the same
+// name if any
+func StoreToken(config repository.Config, token Token) error {
+	if strings.ContainsAny(token.Name, ". ") || token.Name == "" {
+		return fmt.Errorf("invalid token name \"%s\"", token.Name)
+	}
+
+	err := RemoveToken(config, token.Name)
+	if err != nil && err != ErrTokenNotExist {
+		return err
+	}
+
+	prefix := tokenConfigKeyPrefix + token.Name + "."
+	if token.Secret != "" {
+		err = config.StoreString(prefix+"secret", token.Secret)
+	} else {
+		err = config.StoreString(prefix+"hash", token.Hash)
+	}
+	if err != nil {
+		return err
+	}
+	err = config.StoreString(prefix+"identity", token.Identity.String())
+	if err != nil {
+		return err
+	}
+	return config.StoreString(prefix+"scope", string(token.Scope))
+}
+
+// RemoveToken remove a token from the config
+func RemoveToken(config repository.Config, name string) error {
+	tokens, err := ReadTokens(config)
+	if err != nil {
+		return err
+	}
+	for _, token := range tokens {
+		if token.Name == name {
+			return config.RemoveAll(tokenConfigKeyPrefix + name + ".")
+		}
+	}
+	return ErrTokenNotExist
+}
+
+// ReadTokens read the tokens stored in the config
+func ReadTokens(config repository.ConfigRead) ([]Token, error) {
+	pairs, err := config.ReadAll(tokenConfigKeyPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	byName := make(map[string]*Token)
+	var result []*Token
+
+	for key, value := range pairs {
+		key = strings.TrimPrefix(key, tokenConfigKeyPrefix)
+		split := strings.Split(key, ".")
+		if len(split) != 2 {
+			return nil, fmt.Errorf("invalid token config key %s", key)
+		}
+
+		token, ok := byName[split[0]]
+		if !ok {
+			token = &Token{Name: split[0], Scope: ScopeWrite}
+			byName[split[0]] = token
+			result = append(result, token)
+		}
+
+		switch split[1] {
+		case "secret":
+			token.Secret = value
+		case "hash":
+			token.Hash = value
+		case "identity":
+			token.Identity = entity.Id(value)
+		case "scope":
+			token.Scope = Scope(value)
+		default:
+			return nil, fmt.Errorf("invalid token config key %s", key)
+		}
+	}
+
+	tokens := make([]Token, len(result))
+	for i, token := range result {
+		if token.Secret == "" && token.Hash == "" {
+			return nil, fmt.Errorf("token %s: missing secret", token.Name)
+		}
+		if token.Scope != ScopeRead && token.Scope != ScopeWrite {
+			return nil, fmt.Errorf("token %s: invalid scope %s", token.Name, token.Scope)
+		}
+		if err := token.Identity.Validate(); err != nil {
+			return nil, fmt.Errorf("token %s: invalid identity: %v", token.Name, err)
+		}
+		tokens[i] = *token
+	}
+
+	return tokens, nil
+}
+
+// matchToken return the token matching a secret, if any
+func matchToken(tokens []Token, secret string) (Token, bool) {
+	hash := hashSecret(secret)
+
+	var found Token
+	for _, token := range tokens {
+		// compare all the tokens in constant time, to not leak the secrets
+		match := subtle.ConstantTimeCompare([]byte(token.Hash), []byte(hash))
+		if token.Secret != "" {
+			match = subtle.ConstantTimeCompare([]byte(token.Secret), []byte(secret))
+		}
+		if match == 1 {
+			found = token
+		}
+	}
+	return found, found.Name != ""
+}
+
+// serveWithToken serve a request as the identity authenticated by a token,
+// if the scope of the token allow it
+func serveWithToken(w http.ResponseWriter, r *http.Request, next http.Handler, token Token) {
+	// the GraphQL queries can also be sent with a POST
+	readOnly := r.Method == http.MethodGet || r.Method == http.MethodHead ||
+		r.Method == http.MethodOptions || strings.HasSuffix(r.URL.Path, "/graphql")
+	if token.Scope == ScopeRead && !readOnly {
+		http.Error(w, "read-only token", http.StatusForbidden)
+		return
+	}
+
+	ctx := CtxWithUser(r.Context(), token.Identity)
+	next.ServeHTTP(w, r.WithContext(CtxWithScope(ctx, token.Scope)))
+}
+
+// TokenMiddleware authenticate the requests with a token, given as a bearer
+// token or in the cookie set by the login handler. The other requests are
+// anonymous, and thus read-only. With a read scoped token, only the GET
+// requests are allowed, the GraphQL mutations are refused by the GraphQL
+// handler.
+func TokenMiddleware(tokens []Token) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if header := r.Header.Get("Authorization"); header != "" {
+				secret := strings.TrimPrefix(header, "Bearer ")
+				token, ok := matchToken(tokens, secret)
+				if secret == header || !ok {
+					http.Error(w, "invalid token", http.StatusUnauthorized)
+					return
+				}
+				serveWithToken(w, r, next, token)
+				return
+			}
+
+			// an outdated cookie is simply ignored
+			if cookie, err := r.Cookie(TokenCookie); err == nil {
+				if token, ok := matchToken(tokens, cookie.Value); ok {
+					serveWithToken(w, r, next, token)
+					return
+				}
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+func TestReadTokens(t *testing.T) {
+	id := entity.Id(strings.Repeat("a", 64))
+
+	config := repository.NewMemConfig()
+	require.NoError(t, config.StoreString("git-bug.webui.token.ci.secret", "s3cr3t"))
+	require.NoError(t, config.StoreString("git-bug.webui.token.ci.identity", id.String()))
+
+	tokens, err := ReadTokens(config)
+	require.NoError(t, err)
+	assert.Equal(t, []Token{{Name: "ci", Secret: "s3cr3t", Identity: id, Scope: ScopeWrite}}, tokens)
+
+	require.NoError(t, config.StoreString("git-bug.webui.token.broken.secret", "other"))
+	_, err = ReadTokens(config)
+	assert.Error(t, err)
+}
+
+func TestStoreToken(t *testing.T) {
+	id := entity.Id(strings.Repeat("a", 64))
+	config := repository.NewMemConfig()
+
+	token, secret, err := NewToken("ci", id, ScopeRead)
+	require.NoError(t, err)
+	assert.NotEqual(t, secret, token.Hash)
+	require.NoError(t, StoreToken(config, token))
+
+	stored, err := config.ReadAll("git-bug.webui.token.ci.")
+	require.NoError(t, err)
+	for _, value := range stored {
+		assert.NotEqual(t, secret, value)
+	}
+
+	tokens, err := ReadTokens(config)
+	require.NoError(t, err)
+	assert.Equal(t, []Token{token}, tokens)
+
+	found, ok := matchToken(tokens, secret)
+	assert.True(t, ok)
+	assert.Equal(t, token, found)
+	_, ok = matchToken(tokens, "wrong")
+	assert.False(t, ok)
+
+	assert.Error(t, StoreToken(config, Token{Name: "in.valid", Hash: "x", Identity: id, Scope: ScopeRead}))
+
+	require.NoError(t, RemoveToken(config, "ci"))
+	assert.Equal(t, ErrTokenNotExist, RemoveToken(config, "ci"))
+	tokens, err = ReadTokens(config)
+	require.NoError(t, err)
+	assert.Empty(t, tokens)
+}
+
+func TestTokenMiddleware(t *testing.T) {
+	id := entity.Id(strings.Repeat("a", 64))
+	readOnly, secret, err := NewToken("reader", id, ScopeRead)
+	require.NoError(t, err)
+	tokens := []Token{{Name: "ci", Secret: "s3cr3t", Identity: id, Scope: ScopeWrite}, readOnly}
+
+	var user entity.Id
+	var scope Scope
+	handler := TokenMiddleware(tokens)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, _ = r.Context().Value(identityCtxKey).(entity.Id)
+		scope = ScopeFromCtx(r.Context())
+	}))
+
+	var tests = []struct {
+		name   string
+		method string
+		path   string
+		header string
+		cookie string
+		status int
+		user   entity.Id
+		scope  Scope
+	}{
+		{"anonymous", http.MethodGet, "/graphql", "", "", http.StatusOK, "", ScopeRead},
+		{"bearer", http.MethodGet, "/graphql", "Bearer s3cr3t", "", http.StatusOK, id, ScopeWrite},
+		{"invalid bearer", http.MethodGet, "/graphql", "Bearer wrong", "", http.StatusUnauthorized, "", ""},
+		{"not a bearer", http.MethodGet, "/graphql", "s3cr3t", "", http.StatusUnauthorized, "", ""},
+		{"cookie", http.MethodGet, "/graphql", "", "s3cr3t", http.StatusOK, id, ScopeWrite},
+		{"outdated cookie", http.MethodGet, "/graphql", "", "wrong", http.StatusOK, "", ScopeRead},
+		{"read scope", http.MethodPost, "/graphql", "Bearer " + secret, "", http.StatusOK, id, ScopeRead},
+		{"read scope upload", http.MethodPost, "/upload", "Bearer " + secret, "", http.StatusForbidden, "", ""},
+	}
+
+	for _, tc := range tests {
+		user = ""
+		scope = ""
+
+		r := httptest.NewRequest(tc.method, tc.path, nil)
+		if tc.header != "" {
+			r.Header.Set("Authorization", tc.header)
+		}
+		if tc.cookie != "" {
+			r.AddCookie(&http.Cookie{Name: TokenCookie, Value: tc.cookie})
+		}
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+
+		assert.Equal(t, tc.status, w.Code, tc.name)
+		assert.Equal(t, tc.user, user, tc.name)
+		assert.Equal(t, tc.scope, scope, tc.name)
+	}
+}
@@ -0,0 +1,114 @@
+package graphql
+
+import (
+	"context"
+	"strings"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/vektah/gqlparser/ast"
+	"github.com/vektah/gqlparser/gqlerror"
+
+	"github.com/MichaelMure/git-bug/api/graphql/graph"
+	"github.com/MichaelMure/git-bug/api/graphql/models"
+)
+
+// defaultPageSize is the number of elements a connection queried without
+// first or last is assumed to return when estimating the complexity.
+const defaultPageSize = 100
+
+// connectionComplexity count the complexity of the selected fields once for
+// each of the elements the connection can return.
+func connectionComplexity(childComplexity int, first *int, last *int) int {
+	count := defaultPageSize
+	if first != nil && *first < count {
+		count = *first
+	}
+	if last != nil && *last < count {
+		count = *last
+	}
+	if count < 1 {
+		count = 1
+	}
+	return 1 + count*childComplexity
+}
+
+func paginatedComplexity(childComplexity int, after *string, before *string, first *int, last *int) int {
+	return connectionComplexity(childComplexity, first, last)
+}
+
+// complexityRoot estimate the complexity of the connections from their size,
+// so that a query can't ask for every comment of every bug.
+func complexityRoot() graph.ComplexityRoot {
+	var c graph.ComplexityRoot
+
+	c.Bug.Actors = paginatedComplexity
+	c.Bug.Comments = paginatedComplexity
+	c.Bug.Operations = paginatedComplexity
+	c.Bug.Participants = paginatedComplexity
+	c.Bug.Timeline = paginatedComplexity
+
+	c.Repository.AllBugs = func(childComplexity int, after *string, before *string, first *int, last *int, query *string, filter *models.BugFilter, orderBy *models.BugOrder) int {
+		return connectionComplexity(childComplexity, first, last)
+	}
+	c.Repository.AllIdentities = paginatedComplexity
+	c.Repository.ValidLabels = paginatedComplexity
+
+	return c
+}
+
+// depthLimit is a gqlgen extension refusing the operations nesting their
+// fields deeper than a limit.
+type depthLimit struct {
+	max int
+}
+
+var _ interface {
+	graphql.HandlerExtension
+	graphql.OperationContextMutator
+} = depthLimit{}
+
+func (depthLimit) ExtensionName() string {
+	return "DepthLimit"
+}
+
+func (depthLimit) Validate(schema graphql.ExecutableSchema) error {
+	return nil
+}
+
+func (d depthLimit) MutateOperationContext(ctx context.Context, rc *graphql.OperationContext) *gqlerror.Error {
+	if rc.Operation == nil {
+		return nil
+	}
+	depth := selectionDepth(rc.Operation.SelectionSet)
+	if depth > d.max {
+		return gqlerror.Errorf("operation has depth %d, which exceeds the limit of %d", depth, d.max)
+	}
+	return nil
+}
+
+// selectionDepth return the maximum nesting of fields of a selection set.
+// The introspection fields are ignored, to keep the tools like the
+// playground working.
+func selectionDepth(set ast.SelectionSet) int {
+	max := 0
+	for _, selection := range set {
+		var depth int
+		switch selection := selection.(type) {
+		case *ast.Field:
+			if strings.HasPrefix(selection.Name, "__") {
+				continue
+			}
+			depth = 1 + selectionDepth(selection.SelectionSet)
+		case *ast.InlineFragment:
+			depth = selectionDepth(selection.SelectionSet)
+		case *ast.FragmentSpread:
+			if selection.Definition != nil {
+				depth = selectionDepth(selection.Definition.SelectionSet)
+			}
+		}
+		if depth > max {
+			max = depth
+		}
+	}
+	return max
+}
@@ -1,14 +1,21 @@
 package graphql
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/99designs/gqlgen/client"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/MichaelMure/git-bug/api/auth"
 	"github.com/MichaelMure/git-bug/api/graphql/models"
+	"github.com/MichaelMure/git-bug/bug"
 	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/entity"
 	"github.com/MichaelMure/git-bug/misc/random_bugs"
 	"github.com/MichaelMure/git-bug/repository"
 )
@@ -23,7 +30,7 @@ func TestQueries(t *testing.T) {
 	_, err := mrc.RegisterDefaultRepository(repo)
 	require.NoError(t, err)
 
-	handler := NewHandler(mrc)
+	handler := NewHandler(mrc, DefaultOptions)
 
 	c := client.New(handler)
 
@@ -218,3 +225,601 @@ func TestQueries(t *testing.T) {
 	err = c.Post(query, &resp)
 	assert.NoError(t, err)
 }
+
+func TestLimits(t *testing.T) {
+	repo := repository.CreateGoGitTestRepo(false)
+	defer repository.CleanupTestRepos(repo)
+
+	random_bugs.FillRepoWithSeed(repo, 2, 42)
+
+	mrc := cache.NewMultiRepoCache()
+	_, err := mrc.RegisterDefaultRepository(repo)
+	require.NoError(t, err)
+
+	c := client.New(NewHandler(mrc, Options{MaxComplexity: 1000, MaxDepth: 6}))
+
+	var resp interface{}
+
+	err = c.Post(`query { repository { allBugs(first: 10) { nodes { comments(first: 10) { nodes { message } } } } } }`, &resp)
+	require.NoError(t, err)
+
+	// every comment of every bug
+	err = c.Post(`query { repository { allBugs { nodes { comments { nodes { message } } } } } }`, &resp)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "complexity")
+
+	err = c.Post(`query { repository { allBugs(first: 1) { nodes { comments(first: 1) { nodes { author { name } } } } } } }`, &resp)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "depth")
+}
+
+func TestAllowedQueries(t *testing.T) {
+	repo := repository.CreateGoGitTestRepo(false)
+	defer repository.CleanupTestRepos(repo)
+
+	mrc := cache.NewMultiRepoCache()
+	_, err := mrc.RegisterDefaultRepository(repo)
+	require.NoError(t, err)
+
+	allowed := `query { repository { allBugs { totalCount } } }`
+
+	c := client.New(NewHandler(mrc, Options{
+		AllowedQueries: map[string]string{"count": allowed},
+	}))
+
+	var resp interface{}
+
+	err = c.Post(allowed, &resp)
+	require.NoError(t, err)
+
+	err = c.Post(`query { repository { allIdentities { totalCount } } }`, &resp)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not allowed")
+}
+
+func TestResponseCache(t *testing.T) {
+	repo := repository.CreateGoGitTestRepo(false)
+	defer repository.CleanupTestRepos(repo)
+
+	mrc := cache.NewMultiRepoCache()
+	repoCache, err := mrc.RegisterDefaultRepository(repo)
+	require.NoError(t, err)
+
+	iden, err := repoCache.NewIdentity("René Descartes", "rene@descartes.fr")
+	require.NoError(t, err)
+	err = repoCache.SetUserIdentity(iden)
+	require.NoError(t, err)
+
+	c := client.New(NewHandler(mrc, Options{CacheTTL: time.Hour}))
+
+	var resp struct {
+		Repository struct {
+			AllBugs struct {
+				TotalCount int
+			}
+		}
+	}
+	query := `query { repository { allBugs { totalCount } } }`
+
+	err = c.Post(query, &resp)
+	require.NoError(t, err)
+	require.Equal(t, 0, resp.Repository.AllBugs.TotalCount)
+
+	// the cached response is not served after a change of the data
+	_, _, err = repoCache.NewBug("title", "message")
+	require.NoError(t, err)
+
+	err = c.Post(query, &resp)
+	require.NoError(t, err)
+	require.Equal(t, 1, resp.Repository.AllBugs.TotalCount)
+}
+
+// asUser authenticate a request as the given identity, with the write scope
+func asUser(id entity.Id) client.Option {
+	return func(bd *client.Request) {
+		ctx := auth.CtxWithUser(bd.HTTP.Context(), id)
+		ctx = auth.CtxWithScope(ctx, auth.ScopeWrite)
+		bd.HTTP = bd.HTTP.WithContext(ctx)
+	}
+}
+
+func TestRevertOperation(t *testing.T) {
+	repo := repository.CreateGoGitTestRepo(false)
+	defer repository.CleanupTestRepos(repo)
+
+	mrc := cache.NewMultiRepoCache()
+	repoCache, err := mrc.RegisterDefaultRepository(repo)
+	require.NoError(t, err)
+
+	iden, err := repoCache.NewIdentity("René Descartes", "rene@descartes.fr")
+	require.NoError(t, err)
+	err = repoCache.SetUserIdentity(iden)
+	require.NoError(t, err)
+
+	b, _, err := repoCache.NewBug("title", "message")
+	require.NoError(t, err)
+	closeOp, err := b.Close()
+	require.NoError(t, err)
+	require.NoError(t, b.Commit())
+
+	c := client.New(NewHandler(mrc, DefaultOptions))
+
+	var resp struct {
+		RevertOperation struct {
+			Bug struct {
+				Status string
+			}
+			Operation struct {
+				Typename string `json:"__typename"`
+				Status   string
+			}
+		}
+	}
+	mutation := `
+		mutation($prefix: String!, $operation: String!) {
+			revertOperation(input: {prefix: $prefix, operation: $operation}) {
+				bug { status }
+				operation {
+					__typename
+					... on SetStatusOperation { status }
+				}
+			}
+		}`
+
+	err = c.Post(mutation, &resp,
+		client.Var("prefix", b.Id().Human()),
+		client.Var("operation", closeOp.Id().Human()),
+		asUser(iden.Id()),
+	)
+	require.NoError(t, err)
+	require.Equal(t, "OPEN", resp.RevertOperation.Bug.Status)
+	require.Equal(t, "SetStatusOperation", resp.RevertOperation.Operation.Typename)
+	require.Equal(t, "OPEN", resp.RevertOperation.Operation.Status)
+	require.Equal(t, bug.OpenStatus, b.Snapshot().Status)
+
+	// the creation can't be reverted
+	err = c.Post(mutation, &resp,
+		client.Var("prefix", b.Id().Human()),
+		client.Var("operation", b.Snapshot().Operations[0].Id().Human()),
+		asUser(iden.Id()),
+	)
+	require.Error(t, err)
+
+	// the anonymous users can't revert
+	err = c.Post(mutation, &resp,
+		client.Var("prefix", b.Id().Human()),
+		client.Var("operation", closeOp.Id().Human()),
+	)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), auth.ErrNotAuthenticated.Error())
+}
+
+func TestReplyToComment(t *testing.T) {
+	repo := repository.CreateGoGitTestRepo(false)
+	defer repository.CleanupTestRepos(repo)
+
+	mrc := cache.NewMultiRepoCache()
+	repoCache, err := mrc.RegisterDefaultRepository(repo)
+	require.NoError(t, err)
+
+	iden, err := repoCache.NewIdentity("René Descartes", "rene@descartes.fr")
+	require.NoError(t, err)
+	err = repoCache.SetUserIdentity(iden)
+	require.NoError(t, err)
+
+	b, _, err := repoCache.NewBug("title", "message")
+	require.NoError(t, err)
+	description := b.Snapshot().Comments[0].Id()
+
+	c := client.New(NewHandler(mrc, DefaultOptions))
+
+	var resp struct {
+		AddComment struct {
+			Operation struct {
+				ReplyTo *string
+			}
+		}
+	}
+	mutation := `
+		mutation($prefix: String!, $replyTo: String) {
+			addComment(input: {prefix: $prefix, message: "answer", replyTo: $replyTo}) {
+				operation { replyTo }
+			}
+		}`
+
+	err = c.Post(mutation, &resp,
+		client.Var("prefix", b.Id().Human()),
+		client.Var("replyTo", description.String()),
+		asUser(iden.Id()),
+	)
+	require.NoError(t, err)
+	require.NotNil(t, resp.AddComment.Operation.ReplyTo)
+	require.Equal(t, description.String(), *resp.AddComment.Operation.ReplyTo)
+
+	var timeline struct {
+		Repository struct {
+			Bug struct {
+				Timeline struct {
+					Nodes []struct {
+						Typename string `json:"__typename"`
+						Message  string
+						ReplyTo  *string
+					}
+				}
+			}
+		}
+	}
+	err = c.Post(`
+		query($prefix: String!) {
+			repository {
+				bug(prefix: $prefix) {
+					timeline {
+						nodes {
+							__typename
+							... on AddCommentTimelineItem { message replyTo }
+						}
+					}
+				}
+			}
+		}`, &timeline, client.Var("prefix", b.Id().Human()))
+	require.NoError(t, err)
+
+	nodes := timeline.Repository.Bug.Timeline.Nodes
+	require.Len(t, nodes, 2)
+	require.Equal(t, "answer", nodes[1].Message)
+	require.NotNil(t, nodes[1].ReplyTo)
+	require.Equal(t, description.String(), *nodes[1].ReplyTo)
+
+	// a comment can't answer to an unknown comment
+	err = c.Post(mutation, &resp,
+		client.Var("prefix", b.Id().Human()),
+		client.Var("replyTo", entity.UnsetId.String()),
+		asUser(iden.Id()),
+	)
+	require.Error(t, err)
+}
+
+func TestMinimizeAndPinComment(t *testing.T) {
+	repo := repository.CreateGoGitTestRepo(false)
+	defer repository.CleanupTestRepos(repo)
+
+	mrc := cache.NewMultiRepoCache()
+	repoCache, err := mrc.RegisterDefaultRepository(repo)
+	require.NoError(t, err)
+
+	iden, err := repoCache.NewIdentity("René Descartes", "rene@descartes.fr")
+	require.NoError(t, err)
+	err = repoCache.SetUserIdentity(iden)
+	require.NoError(t, err)
+
+	b, _, err := repoCache.NewBug("title", "message")
+	require.NoError(t, err)
+	comment, err := b.AddComment("comment")
+	require.NoError(t, err)
+	require.NoError(t, b.Commit())
+
+	c := client.New(NewHandler(mrc, DefaultOptions))
+
+	var resp struct {
+		MinimizeComment struct {
+			Operation struct {
+				Reason string
+			}
+		}
+		PinComment struct {
+			Operation struct {
+				Unpin bool
+			}
+		}
+	}
+	minimize := `
+		mutation($prefix: String!, $target: String!, $reason: String) {
+			minimizeComment(input: {prefix: $prefix, target: $target, reason: $reason}) {
+				operation { reason }
+			}
+		}`
+	pin := `
+		mutation($prefix: String!, $target: String!, $unpin: Boolean) {
+			pinComment(input: {prefix: $prefix, target: $target, unpin: $unpin}) {
+				operation { unpin }
+			}
+		}`
+
+	var timeline struct {
+		Repository struct {
+			Bug struct {
+				Timeline struct {
+					Nodes []struct {
+						Minimized *string
+						Pinned    bool
+					}
+				}
+			}
+		}
+	}
+	query := `
+		query($prefix: String!) {
+			repository {
+				bug(prefix: $prefix) {
+					timeline {
+						nodes {
+							... on AddCommentTimelineItem { minimized pinned }
+						}
+					}
+				}
+			}
+		}`
+
+	err = c.Post(minimize, &resp,
+		client.Var("prefix", b.Id().Human()),
+		client.Var("target", comment.Id().Human()),
+		client.Var("reason", "resolved"),
+		asUser(iden.Id()),
+	)
+	require.NoError(t, err)
+	require.Equal(t, "resolved", resp.MinimizeComment.Operation.Reason)
+
+	err = c.Post(pin, &resp,
+		client.Var("prefix", b.Id().Human()),
+		client.Var("target", comment.Id().Human()),
+		asUser(iden.Id()),
+	)
+	require.NoError(t, err)
+	require.False(t, resp.PinComment.Operation.Unpin)
+
+	err = c.Post(query, &timeline, client.Var("prefix", b.Id().Human()))
+	require.NoError(t, err)
+	nodes := timeline.Repository.Bug.Timeline.Nodes
+	require.Len(t, nodes, 2)
+	require.NotNil(t, nodes[1].Minimized)
+	require.Equal(t, "resolved", *nodes[1].Minimized)
+	require.True(t, nodes[1].Pinned)
+
+	// restore and unpin
+	err = c.Post(minimize, &resp,
+		client.Var("prefix", b.Id().Human()),
+		client.Var("target", comment.Id().Human()),
+		asUser(iden.Id()),
+	)
+	require.NoError(t, err)
+	err = c.Post(pin, &resp,
+		client.Var("prefix", b.Id().Human()),
+		client.Var("target", comment.Id().Human()),
+		client.Var("unpin", true),
+		asUser(iden.Id()),
+	)
+	require.NoError(t, err)
+	require.True(t, resp.PinComment.Operation.Unpin)
+
+	err = c.Post(query, &timeline, client.Var("prefix", b.Id().Human()))
+	require.NoError(t, err)
+	nodes = timeline.Repository.Bug.Timeline.Nodes
+	require.Nil(t, nodes[1].Minimized)
+	require.False(t, nodes[1].Pinned)
+
+	// the reason is checked
+	err = c.Post(minimize, &resp,
+		client.Var("prefix", b.Id().Human()),
+		client.Var("target", comment.Id().Human()),
+		client.Var("reason", "boring"),
+		asUser(iden.Id()),
+	)
+	require.Error(t, err)
+}
+
+func TestSetExtendedStatus(t *testing.T) {
+	repo := repository.CreateGoGitTestRepo(false)
+	defer repository.CleanupTestRepos(repo)
+
+	err := repo.LocalConfig().StoreString("git-bug.statuses", "triaged:open,wontfix:closed")
+	require.NoError(t, err)
+
+	mrc := cache.NewMultiRepoCache()
+	repoCache, err := mrc.RegisterDefaultRepository(repo)
+	require.NoError(t, err)
+
+	iden, err := repoCache.NewIdentity("René Descartes", "rene@descartes.fr")
+	require.NoError(t, err)
+	err = repoCache.SetUserIdentity(iden)
+	require.NoError(t, err)
+
+	b, _, err := repoCache.NewBug("title", "message")
+	require.NoError(t, err)
+
+	c := client.New(NewHandler(mrc, DefaultOptions))
+
+	var statuses struct {
+		Repository struct {
+			ExtendedStatuses []struct {
+				Name   string
+				Status string
+			}
+		}
+	}
+	err = c.Post(`query { repository { extendedStatuses { name status } } }`, &statuses)
+	require.NoError(t, err)
+	require.Len(t, statuses.Repository.ExtendedStatuses, 2)
+	require.Equal(t, "wontfix", statuses.Repository.ExtendedStatuses[1].Name)
+	require.Equal(t, "CLOSED", statuses.Repository.ExtendedStatuses[1].Status)
+
+	var resp struct {
+		SetExtendedStatus struct {
+			Bug struct {
+				Status         string
+				ExtendedStatus *string
+			}
+			Operation struct {
+				Status   string
+				Extended *string
+			}
+		}
+	}
+	mutation := `
+		mutation($prefix: String!, $status: String!) {
+			setExtendedStatus(input: {prefix: $prefix, status: $status}) {
+				bug { status extendedStatus }
+				operation { status extended }
+			}
+		}`
+
+	err = c.Post(mutation, &resp,
+		client.Var("prefix", b.Id().Human()),
+		client.Var("status", "wontfix"),
+		asUser(iden.Id()),
+	)
+	require.NoError(t, err)
+	require.Equal(t, "CLOSED", resp.SetExtendedStatus.Bug.Status)
+	require.NotNil(t, resp.SetExtendedStatus.Bug.ExtendedStatus)
+	require.Equal(t, "wontfix", *resp.SetExtendedStatus.Bug.ExtendedStatus)
+	require.NotNil(t, resp.SetExtendedStatus.Operation.Extended)
+	require.Equal(t, "wontfix", *resp.SetExtendedStatus.Operation.Extended)
+
+	// only the statuses of the repository can be used
+	err = c.Post(mutation, &resp,
+		client.Var("prefix", b.Id().Human()),
+		client.Var("status", "unknown"),
+		asUser(iden.Id()),
+	)
+	require.Error(t, err)
+}
+
+func TestNewBugFromTemplate(t *testing.T) {
+	repo := repository.CreateGoGitTestRepo(false)
+	defer repository.CleanupTestRepos(repo)
+
+	// the templates are at the root of the working tree
+	dir := filepath.Join(filepath.Dir(repo.GetPath()), ".git-bug", "templates")
+	require.NoError(t, os.MkdirAll(dir, 0755))
+	err := ioutil.WriteFile(filepath.Join(dir, "crash.md"), []byte("---\ntitle: \"[crash] \"\nlabels: crash\n---\n## Stacktrace\n"), 0644)
+	require.NoError(t, err)
+
+	mrc := cache.NewMultiRepoCache()
+	repoCache, err := mrc.RegisterDefaultRepository(repo)
+	require.NoError(t, err)
+
+	iden, err := repoCache.NewIdentity("René Descartes", "rene@descartes.fr")
+	require.NoError(t, err)
+	err = repoCache.SetUserIdentity(iden)
+	require.NoError(t, err)
+
+	c := client.New(NewHandler(mrc, DefaultOptions))
+
+	var templates struct {
+		Repository struct {
+			Templates []struct {
+				Name    string
+				Title   string
+				Message string
+				Labels  []string
+			}
+		}
+	}
+	err = c.Post(`query { repository { templates { name title message labels } } }`, &templates)
+	require.NoError(t, err)
+	require.Len(t, templates.Repository.Templates, 1)
+	require.Equal(t, "crash", templates.Repository.Templates[0].Name)
+	require.Equal(t, "[crash] ", templates.Repository.Templates[0].Title)
+	require.Equal(t, "## Stacktrace", templates.Repository.Templates[0].Message)
+	require.Equal(t, []string{"crash"}, templates.Repository.Templates[0].Labels)
+
+	var resp struct {
+		NewBug struct {
+			Bug struct {
+				Labels []struct {
+					Name string
+				}
+			}
+		}
+	}
+	err = c.Post(`mutation { newBug(input: {title: "[crash] on start", message: "boom", template: "crash"}) { bug { labels { name } } } }`, &resp,
+		asUser(iden.Id()),
+	)
+	require.NoError(t, err)
+	require.Len(t, resp.NewBug.Bug.Labels, 1)
+	require.Equal(t, "crash", resp.NewBug.Bug.Labels[0].Name)
+}
+
+func TestBoards(t *testing.T) {
+	repo := repository.CreateGoGitTestRepo(false)
+	defer repository.CleanupTestRepos(repo)
+
+	mrc := cache.NewMultiRepoCache()
+	repoCache, err := mrc.RegisterDefaultRepository(repo)
+	require.NoError(t, err)
+
+	iden, err := repoCache.NewIdentity("René Descartes", "rene@descartes.fr")
+	require.NoError(t, err)
+	err = repoCache.SetUserIdentity(iden)
+	require.NoError(t, err)
+
+	b1, _, err := repoCache.NewBug("first", "message")
+	require.NoError(t, err)
+	b2, _, err := repoCache.NewBug("second", "message")
+	require.NoError(t, err)
+
+	c := client.New(NewHandler(mrc, DefaultOptions))
+
+	type board struct {
+		Id      string
+		Title   string
+		Columns []struct {
+			Name  string
+			Cards []struct {
+				Title string
+			}
+		}
+	}
+
+	var created struct {
+		NewBoard struct {
+			Board board
+		}
+	}
+	err = c.Post(`mutation { newBoard(input: {title: "triage", columns: ["todo", "done"]}) { board { id title columns { name cards { title } } } } }`, &created, asUser(iden.Id()))
+	require.NoError(t, err)
+	require.Equal(t, "triage", created.NewBoard.Board.Title)
+	require.Len(t, created.NewBoard.Board.Columns, 2)
+
+	move := `mutation($board: String!, $bug: String!, $column: String!, $position: Int) {
+		moveBoardCard(input: {prefix: $board, bug: $bug, column: $column, position: $position}) { board { id } }
+	}`
+	var moved interface{}
+	err = c.Post(move, &moved,
+		client.Var("board", created.NewBoard.Board.Id),
+		client.Var("bug", b1.Id().Human()),
+		client.Var("column", "todo"),
+		asUser(iden.Id()),
+	)
+	require.NoError(t, err)
+	err = c.Post(move, &moved,
+		client.Var("board", created.NewBoard.Board.Id),
+		client.Var("bug", b2.Id().Human()),
+		client.Var("column", "todo"),
+		client.Var("position", 0),
+		asUser(iden.Id()),
+	)
+	require.NoError(t, err)
+
+	var resp struct {
+		Repository struct {
+			AllBoards []board
+		}
+	}
+	err = c.Post(`query { repository { allBoards { id title columns { name cards { title } } } } }`, &resp)
+	require.NoError(t, err)
+	require.Len(t, resp.Repository.AllBoards, 1)
+	todo := resp.Repository.AllBoards[0].Columns[0]
+	require.Equal(t, "todo", todo.Name)
+	require.Len(t, todo.Cards, 2)
+	require.Equal(t, "second", todo.Cards[0].Title)
+	require.Equal(t, "first", todo.Cards[1].Title)
+
+	// a missing column is refused
+	err = c.Post(move, &moved,
+		client.Var("board", created.NewBoard.Board.Id),
+		client.Var("bug", b1.Id().Human()),
+		client.Var("column", "unknown"),
+		asUser(iden.Id()),
+	)
+	require.Error(t, err)
+}
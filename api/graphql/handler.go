@@ -4,11 +4,19 @@
 package graphql
 
 import (
+	"context"
 	"io"
 	"net/http"
+	"time"
 
+	"github.com/99designs/gqlgen/graphql"
 	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/99designs/gqlgen/graphql/handler/extension"
+	"github.com/99designs/gqlgen/graphql/handler/lru"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+	"github.com/vektah/gqlparser/ast"
 
+	"github.com/MichaelMure/git-bug/api/auth"
 	"github.com/MichaelMure/git-bug/api/graphql/graph"
 	"github.com/MichaelMure/git-bug/api/graphql/resolvers"
 	"github.com/MichaelMure/git-bug/cache"
@@ -20,13 +28,81 @@ type Handler struct {
 	io.Closer
 }
 
-func NewHandler(mrc *cache.MultiRepoCache) Handler {
+// Options configure the GraphQL handler. The zero value of a limit disable
+// it.
+type Options struct {
+	// MaxComplexity is the maximum complexity of an operation, where each
+	// field count for one, multiplied by the number of elements of the
+	// connections it is in.
+	MaxComplexity int
+	// MaxDepth is the maximum nesting of the fields of an operation.
+	MaxDepth int
+
+	// AllowedQueries, if not nil, restrict the accepted queries to these
+	// ones, indexed by their id. Otherwise, any query is accepted and the
+	// clients can register them to send only their hash afterward.
+	AllowedQueries map[string]string
+
+	// CacheTTL is how long the responses of the queries are kept, as long
+	// as the data doesn't change. 0 disable the caching.
+	CacheTTL time.Duration
+}
+
+// DefaultOptions have limits large enough for the web UI, but refuse the
+// queries walking the whole repository in one go.
+var DefaultOptions = Options{
+	MaxComplexity: 20000,
+	MaxDepth:      15,
+}
+
+func NewHandler(mrc *cache.MultiRepoCache, opts Options) Handler {
 	rootResolver := resolvers.NewRootResolver(mrc)
-	config := graph.Config{Resolvers: rootResolver}
-	h := handler.NewDefaultServer(graph.NewExecutableSchema(config))
+	config := graph.Config{
+		Resolvers:  rootResolver,
+		Complexity: complexityRoot(),
+	}
+
+	h := handler.New(graph.NewExecutableSchema(config))
+	h.AddTransport(transport.Websocket{KeepAlivePingInterval: 10 * time.Second})
+	h.AddTransport(transport.Options{})
+	h.AddTransport(transport.GET{})
+	h.AddTransport(transport.POST{})
+	h.AddTransport(transport.MultipartForm{})
+	h.SetQueryCache(lru.New(1000))
+	h.Use(extension.Introspection{})
+
+	if opts.AllowedQueries != nil {
+		h.Use(newAllowedQueries(opts.AllowedQueries))
+	} else {
+		h.Use(extension.AutomaticPersistedQuery{Cache: lru.New(1000)})
+	}
+	if opts.MaxComplexity > 0 {
+		h.Use(extension.FixedComplexityLimit(opts.MaxComplexity))
+	}
+	if opts.MaxDepth > 0 {
+		h.Use(depthLimit{max: opts.MaxDepth})
+	}
+
+	h.AroundOperations(enforceScope)
+	if opts.CacheTTL > 0 {
+		h.AroundOperations(newResponseCache(mrc, opts.CacheTTL).aroundOperations)
+	}
 
 	return Handler{
 		Handler: h,
 		Closer:  rootResolver,
 	}
 }
+
+// enforceScope refuse the mutations to the anonymous users and to the users
+// authenticated with a read scoped token
+func enforceScope(ctx context.Context, next graphql.OperationHandler) graphql.ResponseHandler {
+	op := graphql.GetOperationContext(ctx).Operation
+	if op != nil && op.Operation == ast.Mutation && auth.ScopeFromCtx(ctx) == auth.ScopeRead {
+		if _, ok := auth.UserIdFromCtx(ctx); !ok {
+			return graphql.OneShot(graphql.ErrorResponse(ctx, auth.ErrNotAuthenticated.Error()))
+		}
+		return graphql.OneShot(graphql.ErrorResponse(ctx, auth.ErrReadOnlyToken.Error()))
+	}
+	return next(ctx)
+}
@@ -0,0 +1,24 @@
+package models
+
+import (
+	"github.com/MichaelMure/git-bug/board"
+	"github.com/MichaelMure/git-bug/cache"
+)
+
+// Board is the snapshot of a board, with the repository to resolve the bugs
+// of its cards in
+type Board struct {
+	Repo *cache.RepoCache
+	board.Snapshot
+}
+
+func NewBoard(repo *cache.RepoCache, snap board.Snapshot) *Board {
+	return &Board{Repo: repo, Snapshot: snap}
+}
+
+// BoardColumn is a column of a board, with the repository to resolve the
+// bugs of its cards in
+type BoardColumn struct {
+	Repo *cache.RepoCache
+	board.Column
+}
@@ -0,0 +1,124 @@
+package models
+
+import (
+	"sync"
+
+	"github.com/MichaelMure/git-bug/cache"
+)
+
+// Bridge is a bridge configured for a repository
+type Bridge struct {
+	Repo          *cache.RepoCache
+	Name          string
+	Target        string
+	Configuration []*BridgeConfigEntry
+}
+
+// BridgeSync is a pull or a push of a bridge, updated in the background as
+// the events of the synchronization are received
+type BridgeSync struct {
+	ID   string
+	Kind BridgeSyncKind
+
+	// a pointer, as the generated code has marshalers taking the value
+	mu      *sync.Mutex
+	running bool
+	events  []string
+	count   int
+	errors  []string
+
+	// signaled on each change, see Changes
+	listeners map[chan struct{}]struct{}
+}
+
+func NewBridgeSync(id string, kind BridgeSyncKind) *BridgeSync {
+	return &BridgeSync{
+		ID:      id,
+		Kind:    kind,
+		mu:      &sync.Mutex{},
+		running: true,
+	}
+}
+
+func (bs *BridgeSync) Running() bool {
+	bs.mu.Lock()
+	defer bs.mu.Unlock()
+	return bs.running
+}
+
+func (bs *BridgeSync) Events() []string {
+	bs.mu.Lock()
+	defer bs.mu.Unlock()
+	return append([]string(nil), bs.events...)
+}
+
+func (bs *BridgeSync) Count() int {
+	bs.mu.Lock()
+	defer bs.mu.Unlock()
+	return bs.count
+}
+
+func (bs *BridgeSync) Errors() []string {
+	bs.mu.Lock()
+	defer bs.mu.Unlock()
+	return append([]string(nil), bs.errors...)
+}
+
+// AddEvent record an event of the synchronization, counted or not
+func (bs *BridgeSync) AddEvent(event string, counted bool) {
+	bs.mu.Lock()
+	defer bs.mu.Unlock()
+	bs.events = append(bs.events, event)
+	if counted {
+		bs.count++
+	}
+	bs.notify()
+}
+
+// AddError record an error of the synchronization
+func (bs *BridgeSync) AddError(err string) {
+	bs.mu.Lock()
+	defer bs.mu.Unlock()
+	bs.events = append(bs.events, err)
+	bs.errors = append(bs.errors, err)
+	bs.notify()
+}
+
+// Done mark the synchronization as finished
+func (bs *BridgeSync) Done() {
+	bs.mu.Lock()
+	defer bs.mu.Unlock()
+	bs.running = false
+	bs.notify()
+}
+
+// Changes return a channel signaled when the synchronization change, and a
+// function to unsubscribe. Successive changes can be merged in a single
+// signal.
+func (bs *BridgeSync) Changes() (<-chan struct{}, func()) {
+	ch := make(chan struct{}, 1)
+
+	bs.mu.Lock()
+	defer bs.mu.Unlock()
+	if bs.listeners == nil {
+		bs.listeners = make(map[chan struct{}]struct{})
+	}
+	bs.listeners[ch] = struct{}{}
+
+	return ch, func() {
+		bs.mu.Lock()
+		defer bs.mu.Unlock()
+		delete(bs.listeners, ch)
+	}
+}
+
+// notify signal the listeners without blocking, the lock must be held
+func (bs *BridgeSync) notify() {
+	for ch := range bs.listeners {
+		select {
+		case ch <- struct{}{}:
+		default:
+			// already signaled
+		}
+	}
+}
@@ -6,7 +6,9 @@ import (
 	"fmt"
 	"io"
 	"strconv"
+	"time"
 
+	"github.com/99designs/gqlgen/graphql"
 	"github.com/MichaelMure/git-bug/bug"
 	"github.com/MichaelMure/git-bug/repository"
 )
@@ -27,6 +29,8 @@ type AddCommentInput struct {
 	Message string `json:"message"`
 	// The collection of file's hash required for the first message.
 	Files []repository.Hash `json:"files"`
+	// The identifier of the comment to answer to, if any.
+	ReplyTo *string `json:"replyTo"`
 }
 
 type AddCommentPayload struct {
@@ -38,6 +42,56 @@ type AddCommentPayload struct {
 	Operation *bug.AddCommentOperation `json:"operation"`
 }
 
+// A file referenced by the comments of a bug, stored as a git blob.
+type Attachment struct {
+	Hash repository.Hash `json:"hash"`
+	// The size of the file, in bytes.
+	Size int `json:"size"`
+	// The media type of the file, detected from its content.
+	ContentType string `json:"contentType"`
+}
+
+// A configuration value of a bridge.
+type BridgeConfigEntry struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+type BridgePullInput struct {
+	// A unique identifier for the client performing the mutation.
+	ClientMutationID *string `json:"clientMutationId"`
+	// "The name of the repository. If not set, the default repository is used.
+	RepoRef *string `json:"repoRef"`
+	// The name of the bridge.
+	Name string `json:"name"`
+}
+
+type BridgePushInput struct {
+	// A unique identifier for the client performing the mutation.
+	ClientMutationID *string `json:"clientMutationId"`
+	// "The name of the repository. If not set, the default repository is used.
+	RepoRef *string `json:"repoRef"`
+	// The name of the bridge.
+	Name string `json:"name"`
+}
+
+type BridgeSyncPayload struct {
+	// A unique identifier for the client performing the mutation.
+	ClientMutationID *string `json:"clientMutationId"`
+	// The started synchronization, to follow its progress.
+	Sync *BridgeSync `json:"sync"`
+}
+
+// A change of a bug, made through the API or by another process.
+type BugChangeEvent struct {
+	// The kind of change.
+	Type ChangeType `json:"type"`
+	// The identifier of the changed bug.
+	ID string `json:"id"`
+	// The changed bug, or null if it has been removed.
+	Bug BugWrapper `json:"bug"`
+}
+
 // The connection type for Bug.
 type BugConnection struct {
 	// A list of edges.
@@ -57,6 +111,54 @@ type BugEdge struct {
 	Node BugWrapper `json:"node"`
 }
 
+// Structured filters of the bugs, equivalent to the qualifiers of a query.
+// Like in a query, several statuses or authors match any of them, the other
+// filters all need to match.
+type BugFilter struct {
+	// Match any of the given statuses.
+	Status []Status `json:"status"`
+	// Match the bugs having all the given labels, or glob patterns of labels like prio/*.
+	Labels []string `json:"labels"`
+	// Match the bugs authored by any of the given identities, by name, login or id prefix. "me" is the user identity.
+	Author []string `json:"author"`
+	// Match the bugs assigned to all the given identities, by name, login or id prefix. "me" is the user identity.
+	Assignee []string `json:"assignee"`
+	// Match the bugs created after the given time.
+	CreatedAfter *time.Time `json:"createdAfter"`
+	// Match the bugs created before the given time.
+	CreatedBefore *time.Time `json:"createdBefore"`
+	// Match the bugs edited after the given time.
+	EditedAfter *time.Time `json:"editedAfter"`
+	// Match the bugs edited before the given time.
+	EditedBefore *time.Time `json:"editedBefore"`
+}
+
+// The ordering of a list of bugs.
+type BugOrder struct {
+	Field     BugOrderField  `json:"field"`
+	Direction OrderDirection `json:"direction"`
+}
+
+type ChangeAssigneesInput struct {
+	// A unique identifier for the client performing the mutation.
+	ClientMutationID *string `json:"clientMutationId"`
+	// "The name of the repository. If not set, the default repository is used.
+	RepoRef *string `json:"repoRef"`
+	// The bug ID's prefix.
+	Prefix string `json:"prefix"`
+	// The id prefixes of the identities to assign.
+	Added []string `json:"added"`
+	// The id prefixes of the identities to unassign.
+	Removed []string `json:"removed"`
+}
+
+type ChangeAssigneesPayload struct {
+	// A unique identifier for the client performing the mutation.
+	ClientMutationID *string `json:"clientMutationId"`
+	// The affected bug.
+	Bug BugWrapper `json:"bug"`
+}
+
 type ChangeLabelInput struct {
 	// A unique identifier for the client performing the mutation.
 	ClientMutationID *string `json:"clientMutationId"`
@@ -111,6 +213,38 @@ type CommentEdge struct {
 	Node   *bug.Comment `json:"node"`
 }
 
+type CreateIdentityInput struct {
+	// A unique identifier for the client performing the mutation.
+	ClientMutationID *string `json:"clientMutationId"`
+	// "The name of the repository. If not set, the default repository is used.
+	RepoRef *string `json:"repoRef"`
+	// The name of the person.
+	Name string `json:"name"`
+	// The email of the person.
+	Email string `json:"email"`
+	// An url to an avatar.
+	AvatarURL *string `json:"avatarUrl"`
+}
+
+type CreateIdentityPayload struct {
+	// A unique identifier for the client performing the mutation.
+	ClientMutationID *string `json:"clientMutationId"`
+	// The created identity.
+	Identity IdentityWrapper `json:"identity"`
+}
+
+// A change of an entity of a repository, made through the API or by another process.
+type EntityChangeEvent struct {
+	// The kind of change.
+	Type ChangeType `json:"type"`
+	// The name of the repository, or null for the default one.
+	RepoRef *string `json:"repoRef"`
+	// The kind of the changed entity.
+	Entity EntityType `json:"entity"`
+	// The identifier of the changed entity.
+	ID string `json:"id"`
+}
+
 type IdentityConnection struct {
 	Edges      []*IdentityEdge   `json:"edges"`
 	Nodes      []IdentityWrapper `json:"nodes"`
@@ -135,6 +269,68 @@ type LabelEdge struct {
 	Node   bug.Label `json:"node"`
 }
 
+type MinimizeCommentInput struct {
+	// A unique identifier for the client performing the mutation.
+	ClientMutationID *string `json:"clientMutationId"`
+	// "The name of the repository. If not set, the default repository is used.
+	RepoRef *string `json:"repoRef"`
+	// The bug ID's prefix.
+	Prefix string `json:"prefix"`
+	// The prefix of the identifier of the comment.
+	Target string `json:"target"`
+	// Why the comment is minimized: "resolved" or "outdated". If not set, the comment is restored.
+	Reason *string `json:"reason"`
+}
+
+type MinimizeCommentPayload struct {
+	// A unique identifier for the client performing the mutation.
+	ClientMutationID *string `json:"clientMutationId"`
+	// The affected bug.
+	Bug BugWrapper `json:"bug"`
+	// The resulting operation.
+	Operation *bug.MinimizeCommentOperation `json:"operation"`
+}
+
+type MoveBoardCardInput struct {
+	// A unique identifier for the client performing the mutation.
+	ClientMutationID *string `json:"clientMutationId"`
+	// "The name of the repository. If not set, the default repository is used.
+	RepoRef *string `json:"repoRef"`
+	// The board ID's prefix.
+	Prefix string `json:"prefix"`
+	// The bug ID's prefix.
+	Bug string `json:"bug"`
+	// The name of the column.
+	Column string `json:"column"`
+	// The position in the column, starting at 0. If not set, the bug is put at the end of the column.
+	Position *int `json:"position"`
+}
+
+type MoveBoardCardPayload struct {
+	// A unique identifier for the client performing the mutation.
+	ClientMutationID *string `json:"clientMutationId"`
+	// The affected board.
+	Board *Board `json:"board"`
+}
+
+type NewBoardInput struct {
+	// A unique identifier for the client performing the mutation.
+	ClientMutationID *string `json:"clientMutationId"`
+	// "The name of the repository. If not set, the default repository is used.
+	RepoRef *string `json:"repoRef"`
+	// The title of the new board.
+	Title string `json:"title"`
+	// The names of the columns, in order. If not set, the default columns are used.
+	Columns []string `json:"columns"`
+}
+
+type NewBoardPayload struct {
+	// A unique identifier for the client performing the mutation.
+	ClientMutationID *string `json:"clientMutationId"`
+	// The created board.
+	Board *Board `json:"board"`
+}
+
 type NewBugInput struct {
 	// A unique identifier for the client performing the mutation.
 	ClientMutationID *string `json:"clientMutationId"`
@@ -146,6 +342,8 @@ type NewBugInput struct {
 	Message string `json:"message"`
 	// The collection of file's hash required for the first message.
 	Files []repository.Hash `json:"files"`
+	// The name of a template of the repository, whose labels and custom fields are applied to the new bug.
+	Template *string `json:"template"`
 }
 
 type NewBugPayload struct {
@@ -201,6 +399,217 @@ type PageInfo struct {
 	EndCursor string `json:"endCursor"`
 }
 
+type PinCommentInput struct {
+	// A unique identifier for the client performing the mutation.
+	ClientMutationID *string `json:"clientMutationId"`
+	// "The name of the repository. If not set, the default repository is used.
+	RepoRef *string `json:"repoRef"`
+	// The bug ID's prefix.
+	Prefix string `json:"prefix"`
+	// The prefix of the identifier of the comment.
+	Target string `json:"target"`
+	// True to unpin the comment instead of pinning it.
+	Unpin *bool `json:"unpin"`
+}
+
+type PinCommentPayload struct {
+	// A unique identifier for the client performing the mutation.
+	ClientMutationID *string `json:"clientMutationId"`
+	// The affected bug.
+	Bug BugWrapper `json:"bug"`
+	// The resulting operation.
+	Operation *bug.PinCommentOperation `json:"operation"`
+}
+
+// A typed link from a bug to another one.
+type Relation struct {
+	Type RelationType `json:"type"`
+	// The identifier of the bug carrying the relation.
+	Source string `json:"source"`
+	// The identifier of the bug the relation points to.
+	Target string `json:"target"`
+}
+
+// Bugs and the relations linking them.
+type RelationGraph struct {
+	Nodes []BugWrapper `json:"nodes"`
+	Edges []*Relation  `json:"edges"`
+}
+
+type RemoveBoardCardInput struct {
+	// A unique identifier for the client performing the mutation.
+	ClientMutationID *string `json:"clientMutationId"`
+	// "The name of the repository. If not set, the default repository is used.
+	RepoRef *string `json:"repoRef"`
+	// The board ID's prefix.
+	Prefix string `json:"prefix"`
+	// The bug ID's prefix.
+	Bug string `json:"bug"`
+}
+
+type RemoveBoardCardPayload struct {
+	// A unique identifier for the client performing the mutation.
+	ClientMutationID *string `json:"clientMutationId"`
+	// The affected board.
+	Board *Board `json:"board"`
+}
+
+type RemoveSavedQueryInput struct {
+	// A unique identifier for the client performing the mutation.
+	ClientMutationID *string `json:"clientMutationId"`
+	// "The name of the repository. If not set, the default repository is used.
+	RepoRef *string `json:"repoRef"`
+	// The name of the query.
+	Name string `json:"name"`
+}
+
+type RemoveSavedQueryPayload struct {
+	// A unique identifier for the client performing the mutation.
+	ClientMutationID *string `json:"clientMutationId"`
+	// The name of the removed query.
+	Name string `json:"name"`
+}
+
+type RenameLabelInput struct {
+	// A unique identifier for the client performing the mutation.
+	ClientMutationID *string `json:"clientMutationId"`
+	// "The name of the repository. If not set, the default repository is used.
+	RepoRef *string `json:"repoRef"`
+	// The current name of the label.
+	Name string `json:"name"`
+	// The new name of the label.
+	NewName string `json:"newName"`
+}
+
+type RenameLabelPayload struct {
+	// A unique identifier for the client performing the mutation.
+	ClientMutationID *string `json:"clientMutationId"`
+	// The renamed label.
+	Label *bug.LabelDefinition `json:"label"`
+	// The number of bugs where the label has been replaced.
+	ChangedBugs int `json:"changedBugs"`
+}
+
+type RevertOperationInput struct {
+	// A unique identifier for the client performing the mutation.
+	ClientMutationID *string `json:"clientMutationId"`
+	// "The name of the repository. If not set, the default repository is used.
+	RepoRef *string `json:"repoRef"`
+	// The bug ID's prefix.
+	Prefix string `json:"prefix"`
+	// The prefix of the ID of the operation to revert.
+	Operation string `json:"operation"`
+}
+
+type RevertOperationPayload struct {
+	// A unique identifier for the client performing the mutation.
+	ClientMutationID *string `json:"clientMutationId"`
+	// The affected bug.
+	Bug BugWrapper `json:"bug"`
+	// The resulting operation, compensating the reverted one.
+	Operation bug.Operation `json:"operation"`
+}
+
+type SaveQueryInput struct {
+	// A unique identifier for the client performing the mutation.
+	ClientMutationID *string `json:"clientMutationId"`
+	// "The name of the repository. If not set, the default repository is used.
+	RepoRef *string `json:"repoRef"`
+	// The name of the query, made of letters, digits, - and _.
+	Name string `json:"name"`
+	// The query. It can't refer to another saved query.
+	Query string `json:"query"`
+}
+
+type SaveQueryPayload struct {
+	// A unique identifier for the client performing the mutation.
+	ClientMutationID *string `json:"clientMutationId"`
+	// The saved query.
+	Query *SavedQuery `json:"query"`
+}
+
+// A query saved by name, shared with the command line.
+type SavedQuery struct {
+	Name  string `json:"name"`
+	Query string `json:"query"`
+}
+
+type SetActiveIdentityInput struct {
+	// A unique identifier for the client performing the mutation.
+	ClientMutationID *string `json:"clientMutationId"`
+	// "The name of the repository. If not set, the default repository is used.
+	RepoRef *string `json:"repoRef"`
+	// The identity's id prefix.
+	Prefix string `json:"prefix"`
+}
+
+type SetActiveIdentityPayload struct {
+	// A unique identifier for the client performing the mutation.
+	ClientMutationID *string `json:"clientMutationId"`
+	// The new identity of the user.
+	Identity IdentityWrapper `json:"identity"`
+}
+
+type SetExtendedStatusInput struct {
+	// A unique identifier for the client performing the mutation.
+	ClientMutationID *string `json:"clientMutationId"`
+	// "The name of the repository. If not set, the default repository is used.
+	RepoRef *string `json:"repoRef"`
+	// The bug ID's prefix.
+	Prefix string `json:"prefix"`
+	// The name of the extended status.
+	Status string `json:"status"`
+}
+
+type SetExtendedStatusPayload struct {
+	// A unique identifier for the client performing the mutation.
+	ClientMutationID *string `json:"clientMutationId"`
+	// The affected bug.
+	Bug BugWrapper `json:"bug"`
+	// The resulting operation.
+	Operation *bug.SetStatusOperation `json:"operation"`
+}
+
+type SetLabelDefinitionInput struct {
+	// A unique identifier for the client performing the mutation.
+	ClientMutationID *string `json:"clientMutationId"`
+	// "The name of the repository. If not set, the default repository is used.
+	RepoRef *string `json:"repoRef"`
+	// The name of the label.
+	Name string `json:"name"`
+	// The color of the label, as #rrggbb. If not set, the current color is kept.
+	Color *string `json:"color"`
+	// The description of the label. If not set, the current description is kept.
+	Description *string `json:"description"`
+	// Archive or restore the label. If not set, the label is left as is.
+	Archived *bool `json:"archived"`
+}
+
+type SetLabelDefinitionPayload struct {
+	// A unique identifier for the client performing the mutation.
+	ClientMutationID *string `json:"clientMutationId"`
+	// The created or updated label.
+	Label *bug.LabelDefinition `json:"label"`
+}
+
+type SetMilestoneInput struct {
+	// A unique identifier for the client performing the mutation.
+	ClientMutationID *string `json:"clientMutationId"`
+	// "The name of the repository. If not set, the default repository is used.
+	RepoRef *string `json:"repoRef"`
+	// The bug ID's prefix.
+	Prefix string `json:"prefix"`
+	// The milestone. An empty milestone removes the milestone of the bug.
+	Milestone string `json:"milestone"`
+}
+
+type SetMilestonePayload struct {
+	// A unique identifier for the client performing the mutation.
+	ClientMutationID *string `json:"clientMutationId"`
+	// The affected bug.
+	Bug BugWrapper `json:"bug"`
+}
+
 type SetTitleInput struct {
 	// A unique identifier for the client performing the mutation.
 	ClientMutationID *string `json:"clientMutationId"`
@@ -235,6 +644,268 @@ type TimelineItemEdge struct {
 	Node   bug.TimelineItem `json:"node"`
 }
 
+type UpdateProfileInput struct {
+	// A unique identifier for the client performing the mutation.
+	ClientMutationID *string `json:"clientMutationId"`
+	// "The name of the repository. If not set, the default repository is used.
+	RepoRef *string `json:"repoRef"`
+	// The new name. If not set, the current name is kept.
+	Name *string `json:"name"`
+	// The new email. If not set, the current email is kept.
+	Email *string `json:"email"`
+	// The new url of the avatar. If not set, the current url is kept.
+	AvatarURL *string `json:"avatarUrl"`
+}
+
+type UpdateProfilePayload struct {
+	// A unique identifier for the client performing the mutation.
+	ClientMutationID *string `json:"clientMutationId"`
+	// The updated identity of the user.
+	Identity IdentityWrapper `json:"identity"`
+}
+
+type UploadFileInput struct {
+	// A unique identifier for the client performing the mutation.
+	ClientMutationID *string `json:"clientMutationId"`
+	// "The name of the repository. If not set, the default repository is used.
+	RepoRef *string `json:"repoRef"`
+	// The file, sent as a multipart request.
+	File graphql.Upload `json:"file"`
+}
+
+type UploadFilePayload struct {
+	// A unique identifier for the client performing the mutation.
+	ClientMutationID *string `json:"clientMutationId"`
+	// The stored file, to reference by its hash in the files of a comment.
+	Attachment *Attachment `json:"attachment"`
+}
+
+// The verification of a signature against the keys registered on the
+// identities of the authors.
+type AuthorSignatureStatus string
+
+const (
+	// The authors had no registered key when the operations were made.
+	AuthorSignatureStatusNoKey AuthorSignatureStatus = "NO_KEY"
+	// The commit is signed with a registered key of the authors.
+	AuthorSignatureStatusVerified AuthorSignatureStatus = "VERIFIED"
+	// An author had a registered key, but the commit is not signed.
+	AuthorSignatureStatusUnsigned AuthorSignatureStatus = "UNSIGNED"
+	// The commit is signed with an unregistered key, or the signature is bad.
+	AuthorSignatureStatusMismatch AuthorSignatureStatus = "MISMATCH"
+)
+
+var AllAuthorSignatureStatus = []AuthorSignatureStatus{
+	AuthorSignatureStatusNoKey,
+	AuthorSignatureStatusVerified,
+	AuthorSignatureStatusUnsigned,
+	AuthorSignatureStatusMismatch,
+}
+
+func (e AuthorSignatureStatus) IsValid() bool {
+	switch e {
+	case AuthorSignatureStatusNoKey, AuthorSignatureStatusVerified, AuthorSignatureStatusUnsigned, AuthorSignatureStatusMismatch:
+		return true
+	}
+	return false
+}
+
+func (e AuthorSignatureStatus) String() string {
+	return string(e)
+}
+
+func (e *AuthorSignatureStatus) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = AuthorSignatureStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid AuthorSignatureStatus", str)
+	}
+	return nil
+}
+
+func (e AuthorSignatureStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type BridgeSyncKind string
+
+const (
+	BridgeSyncKindPull BridgeSyncKind = "PULL"
+	BridgeSyncKindPush BridgeSyncKind = "PUSH"
+)
+
+var AllBridgeSyncKind = []BridgeSyncKind{
+	BridgeSyncKindPull,
+	BridgeSyncKindPush,
+}
+
+func (e BridgeSyncKind) IsValid() bool {
+	switch e {
+	case BridgeSyncKindPull, BridgeSyncKindPush:
+		return true
+	}
+	return false
+}
+
+func (e BridgeSyncKind) String() string {
+	return string(e)
+}
+
+func (e *BridgeSyncKind) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = BridgeSyncKind(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid BridgeSyncKind", str)
+	}
+	return nil
+}
+
+func (e BridgeSyncKind) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type BugOrderField string
+
+const (
+	BugOrderFieldID       BugOrderField = "ID"
+	BugOrderFieldCreation BugOrderField = "CREATION"
+	BugOrderFieldEdit     BugOrderField = "EDIT"
+	BugOrderFieldComments BugOrderField = "COMMENTS"
+	BugOrderFieldPriority BugOrderField = "PRIORITY"
+	BugOrderFieldDueDate  BugOrderField = "DUE_DATE"
+)
+
+var AllBugOrderField = []BugOrderField{
+	BugOrderFieldID,
+	BugOrderFieldCreation,
+	BugOrderFieldEdit,
+	BugOrderFieldComments,
+	BugOrderFieldPriority,
+	BugOrderFieldDueDate,
+}
+
+func (e BugOrderField) IsValid() bool {
+	switch e {
+	case BugOrderFieldID, BugOrderFieldCreation, BugOrderFieldEdit, BugOrderFieldComments, BugOrderFieldPriority, BugOrderFieldDueDate:
+		return true
+	}
+	return false
+}
+
+func (e BugOrderField) String() string {
+	return string(e)
+}
+
+func (e *BugOrderField) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = BugOrderField(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid BugOrderField", str)
+	}
+	return nil
+}
+
+func (e BugOrderField) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type ChangeType string
+
+const (
+	ChangeTypeAdded   ChangeType = "ADDED"
+	ChangeTypeUpdated ChangeType = "UPDATED"
+	ChangeTypeRemoved ChangeType = "REMOVED"
+)
+
+var AllChangeType = []ChangeType{
+	ChangeTypeAdded,
+	ChangeTypeUpdated,
+	ChangeTypeRemoved,
+}
+
+func (e ChangeType) IsValid() bool {
+	switch e {
+	case ChangeTypeAdded, ChangeTypeUpdated, ChangeTypeRemoved:
+		return true
+	}
+	return false
+}
+
+func (e ChangeType) String() string {
+	return string(e)
+}
+
+func (e *ChangeType) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ChangeType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ChangeType", str)
+	}
+	return nil
+}
+
+func (e ChangeType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// The kind of entity stored in a repository.
+type EntityType string
+
+const (
+	EntityTypeBug      EntityType = "BUG"
+	EntityTypeIDEntity EntityType = "IDENTITY"
+)
+
+var AllEntityType = []EntityType{
+	EntityTypeBug,
+	EntityTypeIDEntity,
+}
+
+func (e EntityType) IsValid() bool {
+	switch e {
+	case EntityTypeBug, EntityTypeIDEntity:
+		return true
+	}
+	return false
+}
+
+func (e EntityType) String() string {
+	return string(e)
+}
+
+func (e *EntityType) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = EntityType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid EntityType", str)
+	}
+	return nil
+}
+
+func (e EntityType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
 type LabelChangeStatus string
 
 const (
@@ -282,6 +953,139 @@ func (e LabelChangeStatus) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
 
+type OrderDirection string
+
+const (
+	OrderDirectionAsc  OrderDirection = "ASC"
+	OrderDirectionDesc OrderDirection = "DESC"
+)
+
+var AllOrderDirection = []OrderDirection{
+	OrderDirectionAsc,
+	OrderDirectionDesc,
+}
+
+func (e OrderDirection) IsValid() bool {
+	switch e {
+	case OrderDirectionAsc, OrderDirectionDesc:
+		return true
+	}
+	return false
+}
+
+func (e OrderDirection) String() string {
+	return string(e)
+}
+
+func (e *OrderDirection) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = OrderDirection(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid OrderDirection", str)
+	}
+	return nil
+}
+
+func (e OrderDirection) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// The kind of link from a bug to another one.
+type RelationType string
+
+const (
+	RelationTypeDuplicateOf RelationType = "DUPLICATE_OF"
+	RelationTypeRelatedTo   RelationType = "RELATED_TO"
+	RelationTypeCausedBy    RelationType = "CAUSED_BY"
+)
+
+var AllRelationType = []RelationType{
+	RelationTypeDuplicateOf,
+	RelationTypeRelatedTo,
+	RelationTypeCausedBy,
+}
+
+func (e RelationType) IsValid() bool {
+	switch e {
+	case RelationTypeDuplicateOf, RelationTypeRelatedTo, RelationTypeCausedBy:
+		return true
+	}
+	return false
+}
+
+func (e RelationType) String() string {
+	return string(e)
+}
+
+func (e *RelationType) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = RelationType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid RelationType", str)
+	}
+	return nil
+}
+
+func (e RelationType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// The verification of a signature by git, with the keyring of the user.
+type SignatureStatus string
+
+const (
+	SignatureStatusUnsigned     SignatureStatus = "UNSIGNED"
+	SignatureStatusGood         SignatureStatus = "GOOD"
+	SignatureStatusUntrusted    SignatureStatus = "UNTRUSTED"
+	SignatureStatusUnverifiable SignatureStatus = "UNVERIFIABLE"
+	SignatureStatusBad          SignatureStatus = "BAD"
+)
+
+var AllSignatureStatus = []SignatureStatus{
+	SignatureStatusUnsigned,
+	SignatureStatusGood,
+	SignatureStatusUntrusted,
+	SignatureStatusUnverifiable,
+	SignatureStatusBad,
+}
+
+func (e SignatureStatus) IsValid() bool {
+	switch e {
+	case SignatureStatusUnsigned, SignatureStatusGood, SignatureStatusUntrusted, SignatureStatusUnverifiable, SignatureStatusBad:
+		return true
+	}
+	return false
+}
+
+func (e SignatureStatus) String() string {
+	return string(e)
+}
+
+func (e *SignatureStatus) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = SignatureStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid SignatureStatus", str)
+	}
+	return nil
+}
+
+func (e SignatureStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
 type Status string
 
 const (
@@ -1,12 +1,14 @@
 package models
 
 import (
+	"net/http"
 	"sync"
 	"time"
 
 	"github.com/MichaelMure/git-bug/bug"
 	"github.com/MichaelMure/git-bug/cache"
 	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/repository"
 )
 
 // BugWrapper is an interface used by the GraphQL resolvers to handle a bug.
@@ -16,6 +18,7 @@ type BugWrapper interface {
 	Id() entity.Id
 	LastEdit() time.Time
 	Status() bug.Status
+	ExtendedStatus() string
 	Title() string
 	Comments() ([]bug.Comment, error)
 	Labels() []bug.Label
@@ -25,6 +28,8 @@ type BugWrapper interface {
 	CreatedAt() time.Time
 	Timeline() ([]bug.TimelineItem, error)
 	Operations() ([]bug.Operation, error)
+	Attachments() ([]*Attachment, error)
+	Signatures() ([]*bug.PackSignature, error)
 
 	IsAuthored()
 }
@@ -88,6 +93,10 @@ func (lb *lazyBug) Status() bug.Status {
 	return lb.excerpt.Status
 }
 
+func (lb *lazyBug) ExtendedStatus() string {
+	return lb.excerpt.ExtendedStatus
+}
+
 func (lb *lazyBug) Title() string {
 	return lb.excerpt.Title
 }
@@ -152,14 +161,27 @@ func (lb *lazyBug) Operations() ([]bug.Operation, error) {
 	return lb.snap.Operations, nil
 }
 
+func (lb *lazyBug) Attachments() ([]*Attachment, error) {
+	err := lb.load()
+	if err != nil {
+		return nil, err
+	}
+	return attachments(lb.cache, lb.snap.Comments)
+}
+
+func (lb *lazyBug) Signatures() ([]*bug.PackSignature, error) {
+	return signatures(lb.cache, lb.excerpt.Id)
+}
+
 var _ BugWrapper = &loadedBug{}
 
 type loadedBug struct {
 	*bug.Snapshot
+	cache *cache.RepoCache
 }
 
-func NewLoadedBug(snap *bug.Snapshot) *loadedBug {
-	return &loadedBug{Snapshot: snap}
+func NewLoadedBug(cache *cache.RepoCache, snap *bug.Snapshot) *loadedBug {
+	return &loadedBug{Snapshot: snap, cache: cache}
 }
 
 func (l *loadedBug) LastEdit() time.Time {
@@ -170,6 +192,10 @@ func (l *loadedBug) Status() bug.Status {
 	return l.Snapshot.Status
 }
 
+func (l *loadedBug) ExtendedStatus() string {
+	return l.Snapshot.ExtendedStatus
+}
+
 func (l *loadedBug) Title() string {
 	return l.Snapshot.Title
 }
@@ -213,3 +239,61 @@ func (l *loadedBug) Timeline() ([]bug.TimelineItem, error) {
 func (l *loadedBug) Operations() ([]bug.Operation, error) {
 	return l.Snapshot.Operations, nil
 }
+
+func (l *loadedBug) Attachments() ([]*Attachment, error) {
+	return attachments(l.cache, l.Snapshot.Comments)
+}
+
+func (l *loadedBug) Signatures() ([]*bug.PackSignature, error) {
+	return signatures(l.cache, l.Snapshot.Id())
+}
+
+// attachments describe the files referenced by the comments, in order of
+// appearance
+func attachments(repo *cache.RepoCache, comments []bug.Comment) ([]*Attachment, error) {
+	seen := make(map[repository.Hash]bool)
+	var result []*Attachment
+
+	for _, comment := range comments {
+		for _, hash := range comment.Files {
+			if seen[hash] {
+				continue
+			}
+			seen[hash] = true
+
+			// TODO: the whole file is read only to know its size and type
+			data, err := repo.ReadData(hash)
+			if err != nil {
+				return nil, err
+			}
+
+			result = append(result, &Attachment{
+				Hash:        hash,
+				Size:        len(data),
+				ContentType: http.DetectContentType(data),
+			})
+		}
+	}
+
+	return result, nil
+}
+
+// signatures verify the signature of each commit of a bug
+func signatures(repo *cache.RepoCache, id entity.Id) ([]*bug.PackSignature, error) {
+	b, err := repo.ResolveBug(id)
+	if err != nil {
+		return nil, err
+	}
+
+	packs, err := b.Signatures()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*bug.PackSignature, len(packs))
+	for i := range packs {
+		result[i] = &packs[i]
+	}
+
+	return result, nil
+}
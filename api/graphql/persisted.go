@@ -0,0 +1,86 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/vektah/gqlparser/gqlerror"
+)
+
+// ReadAllowedQueries read a JSON file holding an object of queries indexed
+// by their id, like the manifests generated by the persisted queries tools.
+func ReadAllowedQueries(path string) (map[string]string, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var queries map[string]string
+	err = json.Unmarshal(data, &queries)
+	if err != nil {
+		return nil, fmt.Errorf("invalid allowed queries file %s: %v", path, err)
+	}
+
+	return queries, nil
+}
+
+// allowedQueries is a gqlgen extension only accepting a known set of
+// queries. The clients either send a query in full, or only its id with the
+// automatic persisted queries protocol.
+type allowedQueries struct {
+	byId  map[string]string
+	texts map[string]struct{}
+}
+
+var _ interface {
+	graphql.HandlerExtension
+	graphql.OperationParameterMutator
+} = allowedQueries{}
+
+func newAllowedQueries(queries map[string]string) allowedQueries {
+	a := allowedQueries{
+		byId:  queries,
+		texts: make(map[string]struct{}, len(queries)),
+	}
+	for _, query := range queries {
+		a.texts[query] = struct{}{}
+	}
+	return a
+}
+
+func (allowedQueries) ExtensionName() string {
+	return "AllowedQueries"
+}
+
+func (allowedQueries) Validate(schema graphql.ExecutableSchema) error {
+	return nil
+}
+
+func (a allowedQueries) MutateOperationParameters(ctx context.Context, rawParams *graphql.RawParams) *gqlerror.Error {
+	if rawParams.Query != "" {
+		if _, ok := a.texts[rawParams.Query]; !ok {
+			return gqlerror.Errorf("query not allowed")
+		}
+		return nil
+	}
+
+	extension, _ := rawParams.Extensions["persistedQuery"].(map[string]interface{})
+	id, _ := extension["sha256Hash"].(string)
+	if id == "" {
+		// no query at all, reported when parsing it
+		return nil
+	}
+
+	query, ok := a.byId[id]
+	if !ok {
+		// same error as the automatic persisted queries, so that a client
+		// fall back to sending the query in full
+		return gqlerror.Errorf("PersistedQueryNotFound")
+	}
+	rawParams.Query = query
+
+	return nil
+}
@@ -0,0 +1,59 @@
+package resolvers
+
+import (
+	"context"
+	"time"
+
+	"github.com/MichaelMure/git-bug/api/graphql/graph"
+	"github.com/MichaelMure/git-bug/api/graphql/models"
+)
+
+var _ graph.BoardResolver = &boardResolver{}
+
+type boardResolver struct{}
+
+func (boardResolver) ID(_ context.Context, obj *models.Board) (string, error) {
+	return obj.Id().String(), nil
+}
+
+func (boardResolver) HumanID(_ context.Context, obj *models.Board) (string, error) {
+	return obj.Id().Human(), nil
+}
+
+func (boardResolver) Author(_ context.Context, obj *models.Board) (models.IdentityWrapper, error) {
+	return models.NewLoadedIdentity(obj.Snapshot.Author), nil
+}
+
+func (boardResolver) CreatedAt(_ context.Context, obj *models.Board) (*time.Time, error) {
+	return &obj.CreateTime, nil
+}
+
+func (boardResolver) LastEdit(_ context.Context, obj *models.Board) (*time.Time, error) {
+	t := obj.EditTime()
+	return &t, nil
+}
+
+func (boardResolver) Columns(_ context.Context, obj *models.Board) ([]*models.BoardColumn, error) {
+	result := make([]*models.BoardColumn, len(obj.Snapshot.Columns))
+	for i, column := range obj.Snapshot.Columns {
+		result[i] = &models.BoardColumn{Repo: obj.Repo, Column: column}
+	}
+	return result, nil
+}
+
+var _ graph.BoardColumnResolver = &boardColumnResolver{}
+
+type boardColumnResolver struct{}
+
+func (boardColumnResolver) Cards(_ context.Context, obj *models.BoardColumn) ([]models.BugWrapper, error) {
+	result := make([]models.BugWrapper, 0, len(obj.Cards))
+	for _, id := range obj.Cards {
+		excerpt, err := obj.Repo.ResolveBugExcerpt(id)
+		if err != nil {
+			// a bug removed, or not pulled yet
+			continue
+		}
+		result = append(result, models.NewLazyBug(obj.Repo, excerpt))
+	}
+	return result, nil
+}
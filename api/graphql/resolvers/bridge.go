@@ -0,0 +1,219 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/MichaelMure/git-bug/api/graphql/graph"
+	"github.com/MichaelMure/git-bug/api/graphql/models"
+	"github.com/MichaelMure/git-bug/bridge"
+	"github.com/MichaelMure/git-bug/bridge/core"
+	"github.com/MichaelMure/git-bug/bridge/core/auth"
+	"github.com/MichaelMure/git-bug/cache"
+)
+
+var _ graph.BridgeResolver = &bridgeResolver{}
+
+type bridgeResolver struct {
+	syncs *bridgeSyncs
+}
+
+func (bridgeResolver) Credentials(_ context.Context, obj *models.Bridge) (int, error) {
+	creds, err := auth.List(obj.Repo, auth.WithTarget(obj.Target))
+	if err != nil {
+		return 0, err
+	}
+	return len(creds), nil
+}
+
+func (r bridgeResolver) LastSync(_ context.Context, obj *models.Bridge) (*models.BridgeSync, error) {
+	return r.syncs.last(obj.Repo, obj.Name), nil
+}
+
+// loadBridge read the configuration of a bridge of a repository
+func loadBridge(repo *cache.RepoCache, name string) (*models.Bridge, error) {
+	prefix := fmt.Sprintf("git-bug.bridge.%s.", name)
+	pairs, err := repo.LocalConfig().ReadAll(prefix)
+	if err != nil {
+		return nil, err
+	}
+	if len(pairs) == 0 {
+		return nil, nil
+	}
+
+	result := &models.Bridge{
+		Repo: repo,
+		Name: name,
+	}
+	for key, value := range pairs {
+		key = strings.TrimPrefix(key, prefix)
+		if key == core.ConfigKeyTarget {
+			result.Target = value
+		}
+		result.Configuration = append(result.Configuration, &models.BridgeConfigEntry{
+			Key:   key,
+			Value: value,
+		})
+	}
+	sort.Slice(result.Configuration, func(i, j int) bool {
+		return result.Configuration[i].Key < result.Configuration[j].Key
+	})
+
+	return result, nil
+}
+
+// loadBridges read the configuration of all the bridges of a repository
+func loadBridges(repo *cache.RepoCache) ([]*models.Bridge, error) {
+	names, err := bridge.ConfiguredBridges(repo)
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(names)
+
+	result := make([]*models.Bridge, 0, len(names))
+	for _, name := range names {
+		b, err := loadBridge(repo, name)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, b)
+	}
+	return result, nil
+}
+
+// bridgeSyncs keep track of the pulls and pushes started from the API, to
+// report their progress
+type bridgeSyncs struct {
+	mu     sync.Mutex
+	nextId int
+	// the last synchronization of each bridge, by repository and bridge name
+	byBridge map[string]*models.BridgeSync
+}
+
+func newBridgeSyncs() *bridgeSyncs {
+	return &bridgeSyncs{
+		byBridge: make(map[string]*models.BridgeSync),
+	}
+}
+
+func bridgeSyncKey(repo *cache.RepoCache, name string) string {
+	return repo.Name() + "/" + name
+}
+
+func (bs *bridgeSyncs) last(repo *cache.RepoCache, name string) *models.BridgeSync {
+	bs.mu.Lock()
+	defer bs.mu.Unlock()
+	return bs.byBridge[bridgeSyncKey(repo, name)]
+}
+
+// byId find a synchronization among the last one of each bridge
+func (bs *bridgeSyncs) byId(id string) *models.BridgeSync {
+	bs.mu.Lock()
+	defer bs.mu.Unlock()
+	for _, s := range bs.byBridge {
+		if s.ID == id {
+			return s
+		}
+	}
+	return nil
+}
+
+// start register a new synchronization of a bridge, unless one is already
+// running
+func (bs *bridgeSyncs) start(repo *cache.RepoCache, name string, kind models.BridgeSyncKind) (*models.BridgeSync, error) {
+	bs.mu.Lock()
+	defer bs.mu.Unlock()
+
+	key := bridgeSyncKey(repo, name)
+	if current, ok := bs.byBridge[key]; ok && current.Running() {
+		return nil, fmt.Errorf("a synchronization of the bridge %s is already running", name)
+	}
+
+	bs.nextId++
+	s := models.NewBridgeSync(strconv.Itoa(bs.nextId), kind)
+	bs.byBridge[key] = s
+	return s, nil
+}
+
+// pull import in the background the new data of the remote bug tracker
+func (bs *bridgeSyncs) pull(repo *cache.RepoCache, name string) (*models.BridgeSync, error) {
+	b, err := bridge.LoadBridge(repo, name)
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := bs.start(repo, name, models.BridgeSyncKindPull)
+	if err != nil {
+		return nil, err
+	}
+
+	// the synchronization outlive the request that started it
+	events, err := b.ImportAll(context.Background())
+	if err != nil {
+		s.AddError(err.Error())
+		s.Done()
+		return s, nil
+	}
+
+	go func() {
+		defer s.Done()
+		for result := range events {
+			switch result.Event {
+			case core.ImportEventNothing:
+				// filtered
+			case core.ImportEventError:
+				s.AddError(result.String())
+			case core.ImportEventBug, core.ImportEventIdentity:
+				s.AddEvent(result.String(), true)
+			default:
+				s.AddEvent(result.String(), false)
+			}
+		}
+	}()
+
+	return s, nil
+}
+
+// push export in the background the new data to the remote bug tracker
+func (bs *bridgeSyncs) push(repo *cache.RepoCache, name string) (*models.BridgeSync, error) {
+	b, err := bridge.LoadBridge(repo, name)
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := bs.start(repo, name, models.BridgeSyncKindPush)
+	if err != nil {
+		return nil, err
+	}
+
+	// the synchronization outlive the request that started it
+	events, err := b.ExportAll(context.Background(), time.Time{})
+	if err != nil {
+		s.AddError(err.Error())
+		s.Done()
+		return s, nil
+	}
+
+	go func() {
+		defer s.Done()
+		for result := range events {
+			switch result.Event {
+			case core.ExportEventNothing:
+				// filtered
+			case core.ExportEventError:
+				s.AddError(result.String())
+			case core.ExportEventBug:
+				s.AddEvent(result.String(), true)
+			default:
+				s.AddEvent(result.String(), false)
+			}
+		}
+	}()
+
+	return s, nil
+}
@@ -25,6 +25,10 @@ func (bugResolver) Status(_ context.Context, obj models.BugWrapper) (models.Stat
 	return convertStatus(obj.Status())
 }
 
+func (bugResolver) ExtendedStatus(_ context.Context, obj models.BugWrapper) (*string, error) {
+	return optionalString(obj.ExtendedStatus()), nil
+}
+
 func (bugResolver) Comments(_ context.Context, obj models.BugWrapper, after *string, before *string, first *int, last *int) (*models.CommentConnection, error) {
 	input := models.ConnectionInput{
 		Before: before,
@@ -188,3 +192,11 @@ func (bugResolver) Participants(_ context.Context, obj models.BugWrapper, after
 
 	return connections.IdentityCon(participants, edger, conMaker, input)
 }
+
+var _ graph.ExtendedStatusResolver = &extendedStatusResolver{}
+
+type extendedStatusResolver struct{}
+
+func (extendedStatusResolver) Status(_ context.Context, obj *bug.ExtendedStatus) (models.Status, error) {
+	return convertStatus(obj.Status)
+}
@@ -23,6 +23,19 @@ func (labelResolver) Color(ctx context.Context, obj *bug.Label) (*color.RGBA, er
 	return &rgba, nil
 }
 
+var _ graph.LabelDefinitionResolver = &labelDefinitionResolver{}
+
+type labelDefinitionResolver struct{}
+
+func (labelDefinitionResolver) Name(ctx context.Context, obj *bug.LabelDefinition) (string, error) {
+	return obj.Name.String(), nil
+}
+
+func (labelDefinitionResolver) Color(ctx context.Context, obj *bug.LabelDefinition) (*color.RGBA, error) {
+	rgba := obj.Color.RGBA()
+	return &rgba, nil
+}
+
 var _ graph.LabelChangeResultResolver = &labelChangeResultResolver{}
 
 type labelChangeResultResolver struct{}
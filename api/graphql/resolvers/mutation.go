@@ -2,6 +2,9 @@ package resolvers
 
 import (
 	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
 	"time"
 
 	"github.com/MichaelMure/git-bug/api/auth"
@@ -9,12 +12,16 @@ import (
 	"github.com/MichaelMure/git-bug/api/graphql/models"
 	"github.com/MichaelMure/git-bug/bug"
 	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/identity"
+	"github.com/MichaelMure/git-bug/query"
 )
 
 var _ graph.MutationResolver = &mutationResolver{}
 
 type mutationResolver struct {
 	cache *cache.MultiRepoCache
+	syncs *bridgeSyncs
 }
 
 func (r mutationResolver) getRepo(ref *string) (*cache.RepoCache, error) {
@@ -49,14 +56,31 @@ func (r mutationResolver) NewBug(ctx context.Context, input models.NewBugInput)
 		return nil, err
 	}
 
-	b, op, err := repo.NewBugRaw(author, time.Now().Unix(), input.Title, input.Message, input.Files, nil)
+	var tmpl *bug.Template
+	if input.Template != nil {
+		tmpl, err = repo.ResolveTemplate(*input.Template)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	unixTime := time.Now().Unix()
+
+	b, op, err := repo.NewBugRaw(author, unixTime, input.Title, input.Message, input.Files, nil)
 	if err != nil {
 		return nil, err
 	}
 
+	if tmpl != nil {
+		err = b.ApplyTemplateRaw(author, unixTime, tmpl)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return &models.NewBugPayload{
 		ClientMutationID: input.ClientMutationID,
-		Bug:              models.NewLoadedBug(b.Snapshot()),
+		Bug:              models.NewLoadedBug(repo, b.Snapshot()),
 		Operation:        op,
 	}, nil
 }
@@ -72,7 +96,12 @@ func (r mutationResolver) AddComment(ctx context.Context, input models.AddCommen
 		return nil, err
 	}
 
-	op, err := b.AddCommentRaw(author, time.Now().Unix(), input.Message, input.Files, nil)
+	var op *bug.AddCommentOperation
+	if input.ReplyTo != nil {
+		op, err = b.AddReplyRaw(author, time.Now().Unix(), entity.Id(*input.ReplyTo), input.Message, input.Files, nil)
+	} else {
+		op, err = b.AddCommentRaw(author, time.Now().Unix(), input.Message, input.Files, nil)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -84,7 +113,7 @@ func (r mutationResolver) AddComment(ctx context.Context, input models.AddCommen
 
 	return &models.AddCommentPayload{
 		ClientMutationID: input.ClientMutationID,
-		Bug:              models.NewLoadedBug(b.Snapshot()),
+		Bug:              models.NewLoadedBug(repo, b.Snapshot()),
 		Operation:        op,
 	}, nil
 }
@@ -117,12 +146,96 @@ func (r mutationResolver) ChangeLabels(ctx context.Context, input *models.Change
 
 	return &models.ChangeLabelPayload{
 		ClientMutationID: input.ClientMutationID,
-		Bug:              models.NewLoadedBug(b.Snapshot()),
+		Bug:              models.NewLoadedBug(repo, b.Snapshot()),
 		Operation:        op,
 		Results:          resultsPtr,
 	}, nil
 }
 
+func (r mutationResolver) ChangeAssignees(ctx context.Context, input models.ChangeAssigneesInput) (*models.ChangeAssigneesPayload, error) {
+	repo, b, err := r.getBug(input.RepoRef, input.Prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	author, err := auth.UserFromCtx(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	snap := b.Snapshot()
+
+	// only keep the actual changes
+	resolve := func(prefixes []string, assigned bool) ([]*cache.IdentityCache, error) {
+		var result []*cache.IdentityCache
+		for _, prefix := range prefixes {
+			i, err := repo.ResolveIdentityPrefix(prefix)
+			if err != nil {
+				return nil, err
+			}
+			if snap.HasAssignee(i.Id()) != assigned {
+				result = append(result, i)
+			}
+		}
+		return result, nil
+	}
+
+	added, err := resolve(input.Added, true)
+	if err != nil {
+		return nil, err
+	}
+	removed, err := resolve(input.Removed, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(added) > 0 || len(removed) > 0 {
+		_, err = b.AssignRaw(author, time.Now().Unix(), added, removed, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		err = b.Commit()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &models.ChangeAssigneesPayload{
+		ClientMutationID: input.ClientMutationID,
+		Bug:              models.NewLoadedBug(repo, b.Snapshot()),
+	}, nil
+}
+
+func (r mutationResolver) SetMilestone(ctx context.Context, input models.SetMilestoneInput) (*models.SetMilestonePayload, error) {
+	repo, b, err := r.getBug(input.RepoRef, input.Prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	author, err := auth.UserFromCtx(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	if b.Snapshot().Milestone != input.Milestone {
+		_, err = b.SetMilestoneRaw(author, time.Now().Unix(), input.Milestone, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		err = b.Commit()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &models.SetMilestonePayload{
+		ClientMutationID: input.ClientMutationID,
+		Bug:              models.NewLoadedBug(repo, b.Snapshot()),
+	}, nil
+}
+
 func (r mutationResolver) OpenBug(ctx context.Context, input models.OpenBugInput) (*models.OpenBugPayload, error) {
 	repo, b, err := r.getBug(input.RepoRef, input.Prefix)
 	if err != nil {
@@ -146,7 +259,7 @@ func (r mutationResolver) OpenBug(ctx context.Context, input models.OpenBugInput
 
 	return &models.OpenBugPayload{
 		ClientMutationID: input.ClientMutationID,
-		Bug:              models.NewLoadedBug(b.Snapshot()),
+		Bug:              models.NewLoadedBug(repo, b.Snapshot()),
 		Operation:        op,
 	}, nil
 }
@@ -174,7 +287,35 @@ func (r mutationResolver) CloseBug(ctx context.Context, input models.CloseBugInp
 
 	return &models.CloseBugPayload{
 		ClientMutationID: input.ClientMutationID,
-		Bug:              models.NewLoadedBug(b.Snapshot()),
+		Bug:              models.NewLoadedBug(repo, b.Snapshot()),
+		Operation:        op,
+	}, nil
+}
+
+func (r mutationResolver) SetExtendedStatus(ctx context.Context, input models.SetExtendedStatusInput) (*models.SetExtendedStatusPayload, error) {
+	repo, b, err := r.getBug(input.RepoRef, input.Prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	author, err := auth.UserFromCtx(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	op, err := b.SetExtendedStatusRaw(author, time.Now().Unix(), input.Status, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	err = b.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.SetExtendedStatusPayload{
+		ClientMutationID: input.ClientMutationID,
+		Bug:              models.NewLoadedBug(repo, b.Snapshot()),
 		Operation:        op,
 	}, nil
 }
@@ -202,7 +343,541 @@ func (r mutationResolver) SetTitle(ctx context.Context, input models.SetTitleInp
 
 	return &models.SetTitlePayload{
 		ClientMutationID: input.ClientMutationID,
-		Bug:              models.NewLoadedBug(b.Snapshot()),
+		Bug:              models.NewLoadedBug(repo, b.Snapshot()),
+		Operation:        op,
+	}, nil
+}
+
+func (r mutationResolver) RevertOperation(ctx context.Context, input models.RevertOperationInput) (*models.RevertOperationPayload, error) {
+	repo, b, err := r.getBug(input.RepoRef, input.Prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	author, err := auth.UserFromCtx(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	target, err := b.ResolveOperationPrefix(input.Operation)
+	if err != nil {
+		return nil, err
+	}
+
+	op, err := b.RevertRaw(author, time.Now().Unix(), target, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	err = b.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.RevertOperationPayload{
+		ClientMutationID: input.ClientMutationID,
+		Bug:              models.NewLoadedBug(repo, b.Snapshot()),
 		Operation:        op,
 	}, nil
 }
+
+func (r mutationResolver) MinimizeComment(ctx context.Context, input models.MinimizeCommentInput) (*models.MinimizeCommentPayload, error) {
+	repo, b, err := r.getBug(input.RepoRef, input.Prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	author, err := auth.UserFromCtx(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	target, err := b.ResolveOperationPrefix(input.Target)
+	if err != nil {
+		return nil, err
+	}
+
+	// no reason restore the comment
+	var op *bug.MinimizeCommentOperation
+	if input.Reason != nil {
+		op, err = b.MinimizeCommentRaw(author, time.Now().Unix(), target, bug.MinimizeReason(*input.Reason), nil)
+	} else {
+		op, err = b.UnminimizeCommentRaw(author, time.Now().Unix(), target, nil)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = b.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.MinimizeCommentPayload{
+		ClientMutationID: input.ClientMutationID,
+		Bug:              models.NewLoadedBug(repo, b.Snapshot()),
+		Operation:        op,
+	}, nil
+}
+
+func (r mutationResolver) PinComment(ctx context.Context, input models.PinCommentInput) (*models.PinCommentPayload, error) {
+	repo, b, err := r.getBug(input.RepoRef, input.Prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	author, err := auth.UserFromCtx(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	target, err := b.ResolveOperationPrefix(input.Target)
+	if err != nil {
+		return nil, err
+	}
+
+	var op *bug.PinCommentOperation
+	if input.Unpin != nil && *input.Unpin {
+		op, err = b.UnpinCommentRaw(author, time.Now().Unix(), target, nil)
+	} else {
+		op, err = b.PinCommentRaw(author, time.Now().Unix(), target, nil)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = b.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.PinCommentPayload{
+		ClientMutationID: input.ClientMutationID,
+		Bug:              models.NewLoadedBug(repo, b.Snapshot()),
+		Operation:        op,
+	}, nil
+}
+
+func (r mutationResolver) SetLabelDefinition(ctx context.Context, input models.SetLabelDefinitionInput) (*models.SetLabelDefinitionPayload, error) {
+	repo, err := r.getRepo(input.RepoRef)
+	if err != nil {
+		return nil, err
+	}
+
+	// the registry is not authored, but only the users can change it
+	_, err = auth.UserFromCtx(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	registry, err := repo.LabelRegistry()
+	if err != nil {
+		return nil, err
+	}
+
+	name := bug.Label(input.Name)
+	def, ok := registry[name]
+	if !ok {
+		taxonomy, err := repo.LabelTaxonomy()
+		if err != nil {
+			return nil, err
+		}
+		if err := taxonomy.ValidateLabels([]string{name.String()}); err != nil {
+			return nil, err
+		}
+
+		def = bug.LabelDefinition{
+			Name:  name,
+			Color: name.Color(),
+		}
+	}
+
+	if input.Color != nil {
+		def.Color, err = bug.ParseLabelColor(*input.Color)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if input.Description != nil {
+		def.Description = *input.Description
+	}
+	if input.Archived != nil {
+		def.Archived = *input.Archived
+	}
+
+	err = repo.SetLabelDefinition(def)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.SetLabelDefinitionPayload{
+		ClientMutationID: input.ClientMutationID,
+		Label:            &def,
+	}, nil
+}
+
+func (r mutationResolver) RenameLabel(ctx context.Context, input models.RenameLabelInput) (*models.RenameLabelPayload, error) {
+	repo, err := r.getRepo(input.RepoRef)
+	if err != nil {
+		return nil, err
+	}
+
+	author, err := auth.UserFromCtx(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	changed, err := repo.RenameLabelRaw(author, time.Now().Unix(), bug.Label(input.Name), bug.Label(input.NewName))
+	if err != nil {
+		return nil, err
+	}
+
+	registry, err := repo.LabelRegistry()
+	if err != nil {
+		return nil, err
+	}
+	def := registry[bug.Label(input.NewName)]
+
+	return &models.RenameLabelPayload{
+		ClientMutationID: input.ClientMutationID,
+		Label:            &def,
+		ChangedBugs:      changed,
+	}, nil
+}
+
+func (r mutationResolver) BridgePull(ctx context.Context, input models.BridgePullInput) (*models.BridgeSyncPayload, error) {
+	repo, err := r.getRepo(input.RepoRef)
+	if err != nil {
+		return nil, err
+	}
+
+	// only the authenticated users can trigger a synchronization
+	_, err = auth.UserFromCtx(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	sync, err := r.syncs.pull(repo, input.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.BridgeSyncPayload{
+		ClientMutationID: input.ClientMutationID,
+		Sync:             sync,
+	}, nil
+}
+
+func (r mutationResolver) BridgePush(ctx context.Context, input models.BridgePushInput) (*models.BridgeSyncPayload, error) {
+	repo, err := r.getRepo(input.RepoRef)
+	if err != nil {
+		return nil, err
+	}
+
+	// only the authenticated users can trigger a synchronization
+	_, err = auth.UserFromCtx(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	sync, err := r.syncs.push(repo, input.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.BridgeSyncPayload{
+		ClientMutationID: input.ClientMutationID,
+		Sync:             sync,
+	}, nil
+}
+
+func (r mutationResolver) SaveQuery(ctx context.Context, input models.SaveQueryInput) (*models.SaveQueryPayload, error) {
+	repo, err := r.getRepo(input.RepoRef)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = auth.UserFromCtx(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	err = query.SaveQuery(repo.LocalConfig(), input.Name, input.Query)
+	if err != nil {
+		return nil, queryError(err)
+	}
+
+	return &models.SaveQueryPayload{
+		ClientMutationID: input.ClientMutationID,
+		Query:            &models.SavedQuery{Name: input.Name, Query: input.Query},
+	}, nil
+}
+
+func (r mutationResolver) RemoveSavedQuery(ctx context.Context, input models.RemoveSavedQueryInput) (*models.RemoveSavedQueryPayload, error) {
+	repo, err := r.getRepo(input.RepoRef)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = auth.UserFromCtx(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	saved, err := query.ReadSavedQueries(repo.LocalConfig())
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := saved[input.Name]; !ok {
+		return nil, fmt.Errorf("no saved query named %s", input.Name)
+	}
+
+	err = query.RemoveSavedQuery(repo.LocalConfig(), input.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.RemoveSavedQueryPayload{
+		ClientMutationID: input.ClientMutationID,
+		Name:             input.Name,
+	}, nil
+}
+
+func (r mutationResolver) CreateIdentity(ctx context.Context, input models.CreateIdentityInput) (*models.CreateIdentityPayload, error) {
+	repo, err := r.getRepo(input.RepoRef)
+	if err != nil {
+		return nil, err
+	}
+
+	// only the users can create identities
+	_, err = auth.UserFromCtx(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	var avatarUrl string
+	if input.AvatarURL != nil {
+		avatarUrl = *input.AvatarURL
+	}
+
+	id, err := repo.NewIdentityFull(input.Name, input.Email, "", avatarUrl)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.CreateIdentityPayload{
+		ClientMutationID: input.ClientMutationID,
+		Identity:         models.NewLoadedIdentity(id.Identity),
+	}, nil
+}
+
+func (r mutationResolver) UpdateProfile(ctx context.Context, input models.UpdateProfileInput) (*models.UpdateProfilePayload, error) {
+	repo, err := r.getRepo(input.RepoRef)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := auth.UserFromCtx(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Name != nil && *input.Name == "" {
+		return nil, fmt.Errorf("the name can't be empty")
+	}
+
+	err = user.Mutate(func(mutator identity.Mutator) identity.Mutator {
+		if input.Name != nil {
+			mutator.Name = *input.Name
+		}
+		if input.Email != nil {
+			mutator.Email = *input.Email
+		}
+		if input.AvatarURL != nil {
+			mutator.AvatarUrl = *input.AvatarURL
+		}
+		return mutator
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = user.CommitAsNeeded()
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.UpdateProfilePayload{
+		ClientMutationID: input.ClientMutationID,
+		Identity:         models.NewLoadedIdentity(user.Identity),
+	}, nil
+}
+
+func (r mutationResolver) SetActiveIdentity(ctx context.Context, input models.SetActiveIdentityInput) (*models.SetActiveIdentityPayload, error) {
+	repo, err := r.getRepo(input.RepoRef)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := auth.UserFromCtx(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	// the identity of the repository is shared with the command line, only
+	// its user can change it
+	current, err := repo.GetUserIdentity()
+	if err != nil {
+		return nil, err
+	}
+	if current.Id() != user.Id() {
+		return nil, fmt.Errorf("only the user identity of the repository can change it")
+	}
+
+	i, err := repo.ResolveIdentityPrefix(input.Prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	err = repo.SetUserIdentity(i)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.SetActiveIdentityPayload{
+		ClientMutationID: input.ClientMutationID,
+		Identity:         models.NewLoadedIdentity(i.Identity),
+	}, nil
+}
+
+func (r mutationResolver) NewBoard(ctx context.Context, input models.NewBoardInput) (*models.NewBoardPayload, error) {
+	repo, err := r.getRepo(input.RepoRef)
+	if err != nil {
+		return nil, err
+	}
+
+	author, err := auth.UserFromCtx(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := repo.NewBoardRaw(author, time.Now().Unix(), input.Title, input.Columns)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.NewBoardPayload{
+		ClientMutationID: input.ClientMutationID,
+		Board:            models.NewBoard(repo, b.Snapshot()),
+	}, nil
+}
+
+func (r mutationResolver) getBoardCard(repoRef *string, boardPrefix string, bugPrefix string) (*cache.RepoCache, *cache.BoardCache, entity.Id, error) {
+	repo, err := r.getRepo(repoRef)
+	if err != nil {
+		return nil, nil, "", err
+	}
+
+	b, err := repo.ResolveBoardPrefix(boardPrefix)
+	if err != nil {
+		return nil, nil, "", err
+	}
+
+	excerpt, err := repo.ResolveBugExcerptPrefix(bugPrefix)
+	if err != nil {
+		return nil, nil, "", err
+	}
+
+	return repo, b, excerpt.Id, nil
+}
+
+func (r mutationResolver) MoveBoardCard(ctx context.Context, input models.MoveBoardCardInput) (*models.MoveBoardCardPayload, error) {
+	repo, b, bugId, err := r.getBoardCard(input.RepoRef, input.Prefix, input.Bug)
+	if err != nil {
+		return nil, err
+	}
+
+	author, err := auth.UserFromCtx(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	position := -1
+	if input.Position != nil {
+		position = *input.Position
+	}
+
+	_, err = b.MoveCardRaw(author, time.Now().Unix(), bugId, input.Column, position)
+	if err != nil {
+		return nil, err
+	}
+
+	err = b.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.MoveBoardCardPayload{
+		ClientMutationID: input.ClientMutationID,
+		Board:            models.NewBoard(repo, b.Snapshot()),
+	}, nil
+}
+
+func (r mutationResolver) RemoveBoardCard(ctx context.Context, input models.RemoveBoardCardInput) (*models.RemoveBoardCardPayload, error) {
+	repo, b, bugId, err := r.getBoardCard(input.RepoRef, input.Prefix, input.Bug)
+	if err != nil {
+		return nil, err
+	}
+
+	author, err := auth.UserFromCtx(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = b.RemoveCardRaw(author, time.Now().Unix(), bugId)
+	if err != nil {
+		return nil, err
+	}
+
+	err = b.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.RemoveBoardCardPayload{
+		ClientMutationID: input.ClientMutationID,
+		Board:            models.NewBoard(repo, b.Snapshot()),
+	}, nil
+}
+
+func (r mutationResolver) UploadFile(ctx context.Context, input models.UploadFileInput) (*models.UploadFilePayload, error) {
+	repo, err := r.getRepo(input.RepoRef)
+	if err != nil {
+		return nil, err
+	}
+
+	// only the users can store files
+	_, err = auth.UserFromCtx(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := ioutil.ReadAll(input.File.File)
+	if err != nil {
+		return nil, err
+	}
+
+	hash, err := repo.StoreData(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.UploadFilePayload{
+		ClientMutationID: input.ClientMutationID,
+		Attachment: &models.Attachment{
+			Hash:        hash,
+			Size:        len(data),
+			ContentType: http.DetectContentType(data),
+		},
+	}, nil
+}
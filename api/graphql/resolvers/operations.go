@@ -8,6 +8,7 @@ import (
 	"github.com/MichaelMure/git-bug/api/graphql/graph"
 	"github.com/MichaelMure/git-bug/api/graphql/models"
 	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/identity"
 )
 
 var _ graph.CreateOperationResolver = createOperationResolver{}
@@ -44,6 +45,10 @@ func (addCommentOperationResolver) Date(_ context.Context, obj *bug.AddCommentOp
 	return &t, nil
 }
 
+func (addCommentOperationResolver) ReplyTo(_ context.Context, obj *bug.AddCommentOperation) (*string, error) {
+	return optionalString(obj.ReplyTo.String()), nil
+}
+
 var _ graph.EditCommentOperationResolver = editCommentOperationResolver{}
 
 type editCommentOperationResolver struct{}
@@ -103,6 +108,10 @@ func (setStatusOperationResolver) Status(_ context.Context, obj *bug.SetStatusOp
 	return convertStatus(obj.Status)
 }
 
+func (setStatusOperationResolver) Extended(_ context.Context, obj *bug.SetStatusOperation) (*string, error) {
+	return optionalString(obj.Extended), nil
+}
+
 var _ graph.SetTitleOperationResolver = setTitleOperationResolver{}
 
 type setTitleOperationResolver struct{}
@@ -120,6 +129,303 @@ func (setTitleOperationResolver) Date(_ context.Context, obj *bug.SetTitleOperat
 	return &t, nil
 }
 
+var _ graph.SetFieldOperationResolver = setFieldOperationResolver{}
+
+type setFieldOperationResolver struct{}
+
+func (setFieldOperationResolver) ID(_ context.Context, obj *bug.SetFieldOperation) (string, error) {
+	return obj.Id().String(), nil
+}
+
+func (setFieldOperationResolver) Author(_ context.Context, obj *bug.SetFieldOperation) (models.IdentityWrapper, error) {
+	return models.NewLoadedIdentity(obj.Author), nil
+}
+
+func (setFieldOperationResolver) Date(_ context.Context, obj *bug.SetFieldOperation) (*time.Time, error) {
+	t := obj.Time()
+	return &t, nil
+}
+
+var _ graph.AddTimeSpentOperationResolver = addTimeSpentOperationResolver{}
+
+type addTimeSpentOperationResolver struct{}
+
+func (addTimeSpentOperationResolver) ID(_ context.Context, obj *bug.AddTimeSpentOperation) (string, error) {
+	return obj.Id().String(), nil
+}
+
+func (addTimeSpentOperationResolver) Author(_ context.Context, obj *bug.AddTimeSpentOperation) (models.IdentityWrapper, error) {
+	return models.NewLoadedIdentity(obj.Author), nil
+}
+
+func (addTimeSpentOperationResolver) Date(_ context.Context, obj *bug.AddTimeSpentOperation) (*time.Time, error) {
+	t := obj.Time()
+	return &t, nil
+}
+
+func (addTimeSpentOperationResolver) Duration(_ context.Context, obj *bug.AddTimeSpentOperation) (int, error) {
+	return int(obj.Duration / time.Second), nil
+}
+
+var _ graph.SetEstimateOperationResolver = setEstimateOperationResolver{}
+
+type setEstimateOperationResolver struct{}
+
+func (setEstimateOperationResolver) ID(_ context.Context, obj *bug.SetEstimateOperation) (string, error) {
+	return obj.Id().String(), nil
+}
+
+func (setEstimateOperationResolver) Author(_ context.Context, obj *bug.SetEstimateOperation) (models.IdentityWrapper, error) {
+	return models.NewLoadedIdentity(obj.Author), nil
+}
+
+func (setEstimateOperationResolver) Date(_ context.Context, obj *bug.SetEstimateOperation) (*time.Time, error) {
+	t := obj.Time()
+	return &t, nil
+}
+
+func (setEstimateOperationResolver) Duration(_ context.Context, obj *bug.SetEstimateOperation) (int, error) {
+	return int(obj.Duration / time.Second), nil
+}
+
+var _ graph.SetChecklistItemOperationResolver = setChecklistItemOperationResolver{}
+
+type setChecklistItemOperationResolver struct{}
+
+func (setChecklistItemOperationResolver) ID(_ context.Context, obj *bug.SetChecklistItemOperation) (string, error) {
+	return obj.Id().String(), nil
+}
+
+func (setChecklistItemOperationResolver) Author(_ context.Context, obj *bug.SetChecklistItemOperation) (models.IdentityWrapper, error) {
+	return models.NewLoadedIdentity(obj.Author), nil
+}
+
+func (setChecklistItemOperationResolver) Date(_ context.Context, obj *bug.SetChecklistItemOperation) (*time.Time, error) {
+	t := obj.Time()
+	return &t, nil
+}
+
+func (setChecklistItemOperationResolver) Target(_ context.Context, obj *bug.SetChecklistItemOperation) (string, error) {
+	return obj.Target.String(), nil
+}
+
+var _ graph.RelateOperationResolver = relateOperationResolver{}
+
+type relateOperationResolver struct{}
+
+func (relateOperationResolver) ID(_ context.Context, obj *bug.RelateOperation) (string, error) {
+	return obj.Id().String(), nil
+}
+
+func (relateOperationResolver) Author(_ context.Context, obj *bug.RelateOperation) (models.IdentityWrapper, error) {
+	return models.NewLoadedIdentity(obj.Author), nil
+}
+
+func (relateOperationResolver) Date(_ context.Context, obj *bug.RelateOperation) (*time.Time, error) {
+	t := obj.Time()
+	return &t, nil
+}
+
+func (relateOperationResolver) Relation(_ context.Context, obj *bug.RelateOperation) (models.RelationType, error) {
+	return convertRelationType(obj.Relation)
+}
+
+func (relateOperationResolver) Target(_ context.Context, obj *bug.RelateOperation) (string, error) {
+	return obj.Target.String(), nil
+}
+
+var _ graph.SubscribeOperationResolver = subscribeOperationResolver{}
+
+type subscribeOperationResolver struct{}
+
+func (subscribeOperationResolver) ID(_ context.Context, obj *bug.SubscribeOperation) (string, error) {
+	return obj.Id().String(), nil
+}
+
+func (subscribeOperationResolver) Author(_ context.Context, obj *bug.SubscribeOperation) (models.IdentityWrapper, error) {
+	return models.NewLoadedIdentity(obj.Author), nil
+}
+
+func (subscribeOperationResolver) Date(_ context.Context, obj *bug.SubscribeOperation) (*time.Time, error) {
+	t := obj.Time()
+	return &t, nil
+}
+
+var _ graph.RedactOperationResolver = redactOperationResolver{}
+
+type redactOperationResolver struct{}
+
+func (redactOperationResolver) ID(_ context.Context, obj *bug.RedactOperation) (string, error) {
+	return obj.Id().String(), nil
+}
+
+func (redactOperationResolver) Author(_ context.Context, obj *bug.RedactOperation) (models.IdentityWrapper, error) {
+	return models.NewLoadedIdentity(obj.Author), nil
+}
+
+func (redactOperationResolver) Date(_ context.Context, obj *bug.RedactOperation) (*time.Time, error) {
+	t := obj.Time()
+	return &t, nil
+}
+
+func (redactOperationResolver) Target(_ context.Context, obj *bug.RedactOperation) (string, error) {
+	return obj.Target.String(), nil
+}
+
+var _ graph.MinimizeCommentOperationResolver = minimizeCommentOperationResolver{}
+
+type minimizeCommentOperationResolver struct{}
+
+func (minimizeCommentOperationResolver) ID(_ context.Context, obj *bug.MinimizeCommentOperation) (string, error) {
+	return obj.Id().String(), nil
+}
+
+func (minimizeCommentOperationResolver) Author(_ context.Context, obj *bug.MinimizeCommentOperation) (models.IdentityWrapper, error) {
+	return models.NewLoadedIdentity(obj.Author), nil
+}
+
+func (minimizeCommentOperationResolver) Date(_ context.Context, obj *bug.MinimizeCommentOperation) (*time.Time, error) {
+	t := obj.Time()
+	return &t, nil
+}
+
+func (minimizeCommentOperationResolver) Target(_ context.Context, obj *bug.MinimizeCommentOperation) (string, error) {
+	return obj.Target.String(), nil
+}
+
+func (minimizeCommentOperationResolver) Reason(_ context.Context, obj *bug.MinimizeCommentOperation) (string, error) {
+	return string(obj.Reason), nil
+}
+
+var _ graph.PinCommentOperationResolver = pinCommentOperationResolver{}
+
+type pinCommentOperationResolver struct{}
+
+func (pinCommentOperationResolver) ID(_ context.Context, obj *bug.PinCommentOperation) (string, error) {
+	return obj.Id().String(), nil
+}
+
+func (pinCommentOperationResolver) Author(_ context.Context, obj *bug.PinCommentOperation) (models.IdentityWrapper, error) {
+	return models.NewLoadedIdentity(obj.Author), nil
+}
+
+func (pinCommentOperationResolver) Date(_ context.Context, obj *bug.PinCommentOperation) (*time.Time, error) {
+	t := obj.Time()
+	return &t, nil
+}
+
+func (pinCommentOperationResolver) Target(_ context.Context, obj *bug.PinCommentOperation) (string, error) {
+	return obj.Target.String(), nil
+}
+
+var _ graph.CodeRefOperationResolver = codeRefOperationResolver{}
+
+type codeRefOperationResolver struct{}
+
+func (codeRefOperationResolver) ID(_ context.Context, obj *bug.CodeRefOperation) (string, error) {
+	return obj.Id().String(), nil
+}
+
+func (codeRefOperationResolver) Author(_ context.Context, obj *bug.CodeRefOperation) (models.IdentityWrapper, error) {
+	return models.NewLoadedIdentity(obj.Author), nil
+}
+
+func (codeRefOperationResolver) Date(_ context.Context, obj *bug.CodeRefOperation) (*time.Time, error) {
+	t := obj.Time()
+	return &t, nil
+}
+
+func (codeRefOperationResolver) Kind(_ context.Context, obj *bug.CodeRefOperation) (string, error) {
+	return obj.Ref.Kind.String(), nil
+}
+
+func (codeRefOperationResolver) Role(_ context.Context, obj *bug.CodeRefOperation) (string, error) {
+	return obj.Ref.Role.String(), nil
+}
+
+func (codeRefOperationResolver) Target(_ context.Context, obj *bug.CodeRefOperation) (string, error) {
+	return obj.Ref.Target, nil
+}
+
+func (codeRefOperationResolver) Line(_ context.Context, obj *bug.CodeRefOperation) (int, error) {
+	return obj.Ref.Line, nil
+}
+
+var _ graph.AssignOperationResolver = assignOperationResolver{}
+
+type assignOperationResolver struct{}
+
+func (assignOperationResolver) ID(_ context.Context, obj *bug.AssignOperation) (string, error) {
+	return obj.Id().String(), nil
+}
+
+func (assignOperationResolver) Author(_ context.Context, obj *bug.AssignOperation) (models.IdentityWrapper, error) {
+	return models.NewLoadedIdentity(obj.Author), nil
+}
+
+func (assignOperationResolver) Date(_ context.Context, obj *bug.AssignOperation) (*time.Time, error) {
+	t := obj.Time()
+	return &t, nil
+}
+
+func (assignOperationResolver) Added(_ context.Context, obj *bug.AssignOperation) ([]models.IdentityWrapper, error) {
+	return convertIdentities(obj.Added), nil
+}
+
+func (assignOperationResolver) Removed(_ context.Context, obj *bug.AssignOperation) ([]models.IdentityWrapper, error) {
+	return convertIdentities(obj.Removed), nil
+}
+
+var _ graph.SetMilestoneOperationResolver = setMilestoneOperationResolver{}
+
+type setMilestoneOperationResolver struct{}
+
+func (setMilestoneOperationResolver) ID(_ context.Context, obj *bug.SetMilestoneOperation) (string, error) {
+	return obj.Id().String(), nil
+}
+
+func (setMilestoneOperationResolver) Author(_ context.Context, obj *bug.SetMilestoneOperation) (models.IdentityWrapper, error) {
+	return models.NewLoadedIdentity(obj.Author), nil
+}
+
+func (setMilestoneOperationResolver) Date(_ context.Context, obj *bug.SetMilestoneOperation) (*time.Time, error) {
+	t := obj.Time()
+	return &t, nil
+}
+
+var _ graph.SetDueDateOperationResolver = setDueDateOperationResolver{}
+
+type setDueDateOperationResolver struct{}
+
+func (setDueDateOperationResolver) ID(_ context.Context, obj *bug.SetDueDateOperation) (string, error) {
+	return obj.Id().String(), nil
+}
+
+func (setDueDateOperationResolver) Author(_ context.Context, obj *bug.SetDueDateOperation) (models.IdentityWrapper, error) {
+	return models.NewLoadedIdentity(obj.Author), nil
+}
+
+func (setDueDateOperationResolver) Date(_ context.Context, obj *bug.SetDueDateOperation) (*time.Time, error) {
+	t := obj.Time()
+	return &t, nil
+}
+
+func (setDueDateOperationResolver) DueDate(_ context.Context, obj *bug.SetDueDateOperation) (*time.Time, error) {
+	if obj.DueDate == 0 {
+		return nil, nil
+	}
+	t := time.Unix(obj.DueDate, 0)
+	return &t, nil
+}
+
+func convertIdentities(identities []identity.Interface) []models.IdentityWrapper {
+	result := make([]models.IdentityWrapper, len(identities))
+	for i, id := range identities {
+		result[i] = models.NewLoadedIdentity(id)
+	}
+	return result
+}
+
 func convertStatus(status bug.Status) (models.Status, error) {
 	switch status {
 	case bug.OpenStatus:
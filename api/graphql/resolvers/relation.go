@@ -0,0 +1,110 @@
+package resolvers
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/MichaelMure/git-bug/api/graphql/models"
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/entity"
+)
+
+func convertRelationType(typ bug.RelationType) (models.RelationType, error) {
+	switch typ {
+	case bug.DuplicateOfRelation:
+		return models.RelationTypeDuplicateOf, nil
+	case bug.RelatedToRelation:
+		return models.RelationTypeRelatedTo, nil
+	case bug.CausedByRelation:
+		return models.RelationTypeCausedBy, nil
+	}
+
+	return "", fmt.Errorf("unknown relation type")
+}
+
+// relationGraph build the graph of the bugs linked by a relation. If root is
+// set, only the bugs connected to it, whatever the direction of the
+// relations, are kept.
+func relationGraph(repo *cache.RepoCache, root *cache.BugExcerpt) (*models.RelationGraph, error) {
+	excerpts := make(map[entity.Id]*cache.BugExcerpt)
+	for _, id := range repo.AllBugsIds() {
+		excerpt, err := repo.ResolveBugExcerpt(id)
+		if err != nil {
+			return nil, err
+		}
+		excerpts[id] = excerpt
+	}
+
+	// the relations to a bug not in the cache are ignored
+	var edges []*models.Relation
+	neighbors := make(map[entity.Id][]entity.Id)
+	for _, excerpt := range excerpts {
+		for _, relation := range excerpt.Relations {
+			if _, ok := excerpts[relation.Target]; !ok {
+				continue
+			}
+			typ, err := convertRelationType(relation.Type)
+			if err != nil {
+				return nil, err
+			}
+			edges = append(edges, &models.Relation{
+				Type:   typ,
+				Source: excerpt.Id.String(),
+				Target: relation.Target.String(),
+			})
+			neighbors[excerpt.Id] = append(neighbors[excerpt.Id], relation.Target)
+			neighbors[relation.Target] = append(neighbors[relation.Target], excerpt.Id)
+		}
+	}
+
+	kept := make(map[entity.Id]bool)
+	if root != nil {
+		// walk the graph from the root
+		kept[root.Id] = true
+		queue := []entity.Id{root.Id}
+		for len(queue) > 0 {
+			id := queue[0]
+			queue = queue[1:]
+			for _, neighbor := range neighbors[id] {
+				if !kept[neighbor] {
+					kept[neighbor] = true
+					queue = append(queue, neighbor)
+				}
+			}
+		}
+	} else {
+		for id := range neighbors {
+			kept[id] = true
+		}
+	}
+
+	ids := make([]string, 0, len(kept))
+	for id := range kept {
+		ids = append(ids, id.String())
+	}
+	sort.Strings(ids)
+
+	nodes := make([]models.BugWrapper, len(ids))
+	for i, id := range ids {
+		nodes[i] = models.NewLazyBug(repo, excerpts[entity.Id(id)])
+	}
+
+	keptEdges := make([]*models.Relation, 0, len(edges))
+	for _, edge := range edges {
+		if kept[entity.Id(edge.Source)] {
+			keptEdges = append(keptEdges, edge)
+		}
+	}
+	sort.Slice(keptEdges, func(i, j int) bool {
+		if keptEdges[i].Source != keptEdges[j].Source {
+			return keptEdges[i].Source < keptEdges[j].Source
+		}
+		return keptEdges[i].Target < keptEdges[j].Target
+	})
+
+	return &models.RelationGraph{
+		Nodes: nodes,
+		Edges: keptEdges,
+	}, nil
+}
@@ -2,12 +2,17 @@ package resolvers
 
 import (
 	"context"
+	"sort"
+	"time"
+
+	"github.com/vektah/gqlparser/gqlerror"
 
 	"github.com/MichaelMure/git-bug/api/auth"
 	"github.com/MichaelMure/git-bug/api/graphql/connections"
 	"github.com/MichaelMure/git-bug/api/graphql/graph"
 	"github.com/MichaelMure/git-bug/api/graphql/models"
 	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/cache"
 	"github.com/MichaelMure/git-bug/entity"
 	"github.com/MichaelMure/git-bug/query"
 )
@@ -21,7 +26,7 @@ func (repoResolver) Name(_ context.Context, obj *models.Repository) (*string, er
 	return &name, nil
 }
 
-func (repoResolver) AllBugs(_ context.Context, obj *models.Repository, after *string, before *string, first *int, last *int, queryStr *string) (*models.BugConnection, error) {
+func (repoResolver) AllBugs(_ context.Context, obj *models.Repository, after *string, before *string, first *int, last *int, queryStr *string, filter *models.BugFilter, orderBy *models.BugOrder) (*models.BugConnection, error) {
 	input := models.ConnectionInput{
 		Before: before,
 		After:  after,
@@ -31,15 +36,27 @@ func (repoResolver) AllBugs(_ context.Context, obj *models.Repository, after *st
 
 	var q *query.Query
 	if queryStr != nil {
-		query2, err := query.Parse(*queryStr)
+		saved, err := query.ReadSavedQueries(obj.Repo.LocalConfig())
 		if err != nil {
 			return nil, err
 		}
+		query2, err := query.ParseWithSaved(*queryStr, saved)
+		if err != nil {
+			return nil, queryError(err)
+		}
 		q = query2
 	} else {
 		q = query.NewQuery()
 	}
 
+	if filter != nil {
+		applyBugFilter(q, filter)
+	}
+	if orderBy != nil {
+		q.OrderBy = convertBugOrderField(orderBy.Field)
+		q.OrderDirection = convertOrderDirection(orderBy.Direction)
+	}
+
 	// Simply pass a []string with the ids to the pagination algorithm
 	source := obj.Repo.QueryBugs(q)
 
@@ -91,6 +108,19 @@ func (repoResolver) Bug(_ context.Context, obj *models.Repository, prefix string
 	return models.NewLazyBug(obj.Repo, excerpt), nil
 }
 
+func (repoResolver) RelationGraph(_ context.Context, obj *models.Repository, prefix *string) (*models.RelationGraph, error) {
+	var root *cache.BugExcerpt
+	if prefix != nil {
+		excerpt, err := obj.Repo.ResolveBugExcerptPrefix(*prefix)
+		if err != nil {
+			return nil, err
+		}
+		root = excerpt
+	}
+
+	return relationGraph(obj.Repo, root)
+}
+
 func (repoResolver) AllIdentities(_ context.Context, obj *models.Repository, after *string, before *string, first *int, last *int) (*models.IdentityConnection, error) {
 	input := models.ConnectionInput{
 		Before: before,
@@ -186,3 +216,195 @@ func (repoResolver) ValidLabels(_ context.Context, obj *models.Repository, after
 
 	return connections.LabelCon(obj.Repo.ValidLabels(), edger, conMaker, input)
 }
+
+func (repoResolver) LabelRegistry(_ context.Context, obj *models.Repository) ([]*bug.LabelDefinition, error) {
+	registry, err := obj.Repo.LabelRegistry()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*bug.LabelDefinition, 0, len(registry))
+	for _, name := range registry.Names() {
+		def := registry[name]
+		result = append(result, &def)
+	}
+
+	return result, nil
+}
+
+func (repoResolver) Milestones(_ context.Context, obj *models.Repository) ([]string, error) {
+	registry, err := obj.Repo.MilestoneRegistry()
+	if err != nil {
+		return nil, err
+	}
+	return registry.Names(), nil
+}
+
+func (repoResolver) Bridges(_ context.Context, obj *models.Repository) ([]*models.Bridge, error) {
+	return loadBridges(obj.Repo)
+}
+
+func (repoResolver) Bridge(_ context.Context, obj *models.Repository, name string) (*models.Bridge, error) {
+	return loadBridge(obj.Repo, name)
+}
+
+func (repoResolver) SavedQueries(_ context.Context, obj *models.Repository) ([]*models.SavedQuery, error) {
+	saved, err := query.ReadSavedQueries(obj.Repo.LocalConfig())
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(saved))
+	for name := range saved {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	result := make([]*models.SavedQuery, len(names))
+	for i, name := range names {
+		result[i] = &models.SavedQuery{Name: name, Query: saved[name]}
+	}
+
+	return result, nil
+}
+
+func (repoResolver) ExtendedStatuses(_ context.Context, obj *models.Repository) ([]*bug.ExtendedStatus, error) {
+	set, err := obj.Repo.StatusSet()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*bug.ExtendedStatus, len(set))
+	for i := range set {
+		result[i] = &set[i]
+	}
+	return result, nil
+}
+
+func (repoResolver) Templates(_ context.Context, obj *models.Repository) ([]*bug.Template, error) {
+	templates, err := obj.Repo.Templates()
+	if err != nil {
+		return nil, err
+	}
+	if templates == nil {
+		templates = []*bug.Template{}
+	}
+	return templates, nil
+}
+
+func (repoResolver) AllBoards(_ context.Context, obj *models.Repository) ([]*models.Board, error) {
+	ids, err := obj.Repo.AllBoardIds()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*models.Board, 0, len(ids))
+	for _, id := range ids {
+		b, err := obj.Repo.ResolveBoard(id)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, models.NewBoard(obj.Repo, b.Snapshot()))
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Title < result[j].Title
+	})
+
+	return result, nil
+}
+
+func (repoResolver) Board(_ context.Context, obj *models.Repository, prefix string) (*models.Board, error) {
+	b, err := obj.Repo.ResolveBoardPrefix(prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	return models.NewBoard(obj.Repo, b.Snapshot()), nil
+}
+
+// applyBugFilter add the structured filters of the API to a query
+func applyBugFilter(q *query.Query, filter *models.BugFilter) {
+	for _, status := range filter.Status {
+		switch status {
+		case models.StatusOpen:
+			q.Status = append(q.Status, bug.OpenStatus)
+		case models.StatusClosed:
+			q.Status = append(q.Status, bug.ClosedStatus)
+		}
+	}
+
+	q.Label = append(q.Label, filter.Labels...)
+	q.Author = append(q.Author, filter.Author...)
+	q.Assignee = append(q.Assignee, filter.Assignee...)
+
+	dates := []struct {
+		time   *time.Time
+		field  query.DateField
+		before bool
+	}{
+		{filter.CreatedAfter, query.DateCreated, false},
+		{filter.CreatedBefore, query.DateCreated, true},
+		{filter.EditedAfter, query.DateEdited, false},
+		{filter.EditedBefore, query.DateEdited, true},
+	}
+	for _, date := range dates {
+		if date.time != nil {
+			q.Date = append(q.Date, query.DateFilter{
+				Field:  date.field,
+				Before: date.before,
+				Time:   *date.time,
+			})
+		}
+	}
+}
+
+func convertBugOrderField(field models.BugOrderField) query.OrderBy {
+	switch field {
+	case models.BugOrderFieldID:
+		return query.OrderById
+	case models.BugOrderFieldEdit:
+		return query.OrderByEdit
+	case models.BugOrderFieldComments:
+		return query.OrderByComments
+	case models.BugOrderFieldPriority:
+		return query.OrderByPriority
+	case models.BugOrderFieldDueDate:
+		return query.OrderByDueDate
+	default:
+		return query.OrderByCreation
+	}
+}
+
+func convertOrderDirection(direction models.OrderDirection) query.OrderDirection {
+	if direction == models.OrderDirectionAsc {
+		return query.OrderAscending
+	}
+	return query.OrderDescending
+}
+
+// queryError expose the location of an error in a query in the extensions
+// of the GraphQL error, for the clients to show it
+func queryError(err error) error {
+	perr, ok := err.(*query.ParseError)
+	if !ok {
+		return err
+	}
+
+	suggestions := perr.Suggestions
+	if suggestions == nil {
+		suggestions = []string{}
+	}
+
+	return &gqlerror.Error{
+		Message: perr.Error(),
+		Extensions: map[string]interface{}{
+			"code":        "QUERY_PARSE_ERROR",
+			"query":       perr.Query,
+			"offset":      perr.Offset,
+			"token":       perr.Token,
+			"reason":      perr.Message,
+			"suggestions": suggestions,
+		},
+	}
+}
@@ -10,11 +10,13 @@ var _ graph.ResolverRoot = &RootResolver{}
 
 type RootResolver struct {
 	*cache.MultiRepoCache
+	syncs *bridgeSyncs
 }
 
 func NewRootResolver(mrc *cache.MultiRepoCache) *RootResolver {
 	return &RootResolver{
 		MultiRepoCache: mrc,
+		syncs:          newBridgeSyncs(),
 	}
 }
 
@@ -27,6 +29,14 @@ func (r RootResolver) Query() graph.QueryResolver {
 func (r RootResolver) Mutation() graph.MutationResolver {
 	return &mutationResolver{
 		cache: r.MultiRepoCache,
+		syncs: r.syncs,
+	}
+}
+
+func (r RootResolver) Subscription() graph.SubscriptionResolver {
+	return &subscriptionResolver{
+		cache: r.MultiRepoCache,
+		syncs: r.syncs,
 	}
 }
 
@@ -34,6 +44,12 @@ func (RootResolver) Repository() graph.RepositoryResolver {
 	return &repoResolver{}
 }
 
+func (r RootResolver) Bridge() graph.BridgeResolver {
+	return &bridgeResolver{
+		syncs: r.syncs,
+	}
+}
+
 func (RootResolver) Bug() graph.BugResolver {
 	return &bugResolver{}
 }
@@ -50,6 +66,10 @@ func (RootResolver) Label() graph.LabelResolver {
 	return &labelResolver{}
 }
 
+func (RootResolver) LabelDefinition() graph.LabelDefinitionResolver {
+	return &labelDefinitionResolver{}
+}
+
 func (r RootResolver) Identity() graph.IdentityResolver {
 	return &identityResolver{}
 }
@@ -102,6 +122,74 @@ func (RootResolver) SetTitleOperation() graph.SetTitleOperationResolver {
 	return &setTitleOperationResolver{}
 }
 
+func (RootResolver) SetFieldOperation() graph.SetFieldOperationResolver {
+	return &setFieldOperationResolver{}
+}
+
+func (RootResolver) AddTimeSpentOperation() graph.AddTimeSpentOperationResolver {
+	return &addTimeSpentOperationResolver{}
+}
+
+func (RootResolver) SetEstimateOperation() graph.SetEstimateOperationResolver {
+	return &setEstimateOperationResolver{}
+}
+
+func (RootResolver) SetChecklistItemOperation() graph.SetChecklistItemOperationResolver {
+	return &setChecklistItemOperationResolver{}
+}
+
+func (RootResolver) RelateOperation() graph.RelateOperationResolver {
+	return &relateOperationResolver{}
+}
+
+func (RootResolver) SubscribeOperation() graph.SubscribeOperationResolver {
+	return &subscribeOperationResolver{}
+}
+
+func (RootResolver) RedactOperation() graph.RedactOperationResolver {
+	return &redactOperationResolver{}
+}
+
+func (RootResolver) MinimizeCommentOperation() graph.MinimizeCommentOperationResolver {
+	return &minimizeCommentOperationResolver{}
+}
+
+func (RootResolver) PinCommentOperation() graph.PinCommentOperationResolver {
+	return &pinCommentOperationResolver{}
+}
+
+func (RootResolver) CodeRefOperation() graph.CodeRefOperationResolver {
+	return &codeRefOperationResolver{}
+}
+
+func (RootResolver) AssignOperation() graph.AssignOperationResolver {
+	return &assignOperationResolver{}
+}
+
+func (RootResolver) SetMilestoneOperation() graph.SetMilestoneOperationResolver {
+	return &setMilestoneOperationResolver{}
+}
+
+func (RootResolver) SetDueDateOperation() graph.SetDueDateOperationResolver {
+	return &setDueDateOperationResolver{}
+}
+
 func (r RootResolver) LabelChangeResult() graph.LabelChangeResultResolver {
 	return &labelChangeResultResolver{}
 }
+
+func (RootResolver) Signature() graph.SignatureResolver {
+	return &signatureResolver{}
+}
+
+func (RootResolver) ExtendedStatus() graph.ExtendedStatusResolver {
+	return &extendedStatusResolver{}
+}
+
+func (RootResolver) Board() graph.BoardResolver {
+	return &boardResolver{}
+}
+
+func (RootResolver) BoardColumn() graph.BoardColumnResolver {
+	return &boardColumnResolver{}
+}
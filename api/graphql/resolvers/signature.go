@@ -0,0 +1,65 @@
+package resolvers
+
+import (
+	"context"
+
+	"github.com/MichaelMure/git-bug/api/graphql/graph"
+	"github.com/MichaelMure/git-bug/api/graphql/models"
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+var _ graph.SignatureResolver = &signatureResolver{}
+
+type signatureResolver struct{}
+
+func (signatureResolver) Status(ctx context.Context, obj *bug.PackSignature) (models.SignatureStatus, error) {
+	switch obj.Status {
+	case repository.SignatureGood:
+		return models.SignatureStatusGood, nil
+	case repository.SignatureUntrusted:
+		return models.SignatureStatusUntrusted, nil
+	case repository.SignatureUnverifiable:
+		return models.SignatureStatusUnverifiable, nil
+	case repository.SignatureBad:
+		return models.SignatureStatusBad, nil
+	}
+
+	return models.SignatureStatusUnsigned, nil
+}
+
+func (signatureResolver) Signer(ctx context.Context, obj *bug.PackSignature) (*string, error) {
+	return optionalString(obj.Signer), nil
+}
+
+func (signatureResolver) Key(ctx context.Context, obj *bug.PackSignature) (*string, error) {
+	return optionalString(obj.Key), nil
+}
+
+func (signatureResolver) AuthorStatus(ctx context.Context, obj *bug.PackSignature) (models.AuthorSignatureStatus, error) {
+	switch obj.AuthorStatus {
+	case bug.AuthorVerified:
+		return models.AuthorSignatureStatusVerified, nil
+	case bug.AuthorUnsigned:
+		return models.AuthorSignatureStatusUnsigned, nil
+	case bug.AuthorMismatch:
+		return models.AuthorSignatureStatusMismatch, nil
+	}
+
+	return models.AuthorSignatureStatusNoKey, nil
+}
+
+func (signatureResolver) AuthorKey(ctx context.Context, obj *bug.PackSignature) (*string, error) {
+	return optionalString(obj.AuthorKey), nil
+}
+
+func (signatureResolver) OperationCount(ctx context.Context, obj *bug.PackSignature) (int, error) {
+	return len(obj.Operations), nil
+}
+
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
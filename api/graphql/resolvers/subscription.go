@@ -0,0 +1,226 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/MichaelMure/git-bug/api/graphql/graph"
+	"github.com/MichaelMure/git-bug/api/graphql/models"
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/query"
+)
+
+var _ graph.SubscriptionResolver = &subscriptionResolver{}
+
+type subscriptionResolver struct {
+	cache *cache.MultiRepoCache
+	syncs *bridgeSyncs
+}
+
+func (r subscriptionResolver) getRepo(ref *string) (*cache.RepoCache, error) {
+	if ref != nil {
+		return r.cache.ResolveRepo(*ref)
+	}
+
+	return r.cache.DefaultRepo()
+}
+
+func (r subscriptionResolver) BugChanges(ctx context.Context, repoRef *string, prefix *string, queryStr *string) (<-chan *models.BugChangeEvent, error) {
+	repo, err := r.getRepo(repoRef)
+	if err != nil {
+		return nil, err
+	}
+
+	var q *query.Query
+	if queryStr != nil {
+		saved, err := query.ReadSavedQueries(repo.LocalConfig())
+		if err != nil {
+			return nil, err
+		}
+		q, err = query.ParseWithSaved(*queryStr, saved)
+		if err != nil {
+			return nil, queryError(err)
+		}
+	}
+
+	var id entity.Id
+	if prefix != nil {
+		excerpt, err := repo.ResolveBugExcerptPrefix(*prefix)
+		if err != nil {
+			return nil, err
+		}
+		id = excerpt.Id
+	}
+
+	changes, unsubscribe := repo.Changes()
+	result := make(chan *models.BugChangeEvent)
+
+	go func() {
+		defer unsubscribe()
+		defer close(result)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case change, ok := <-changes:
+				if !ok {
+					return
+				}
+				if change.Target != "bugs" || (id != "" && change.Id != id) {
+					continue
+				}
+
+				event := &models.BugChangeEvent{
+					Type: changeType(change.Typ),
+					ID:   change.Id.String(),
+				}
+				if change.Typ != cache.ChangeEventRemoved {
+					match, err := repo.MatchBug(q, change.Id)
+					if err != nil || !match {
+						// removed in the meantime, or filtered out
+						continue
+					}
+					excerpt, err := repo.ResolveBugExcerpt(change.Id)
+					if err != nil {
+						// removed in the meantime
+						continue
+					}
+					event.Bug = models.NewLazyBug(repo, excerpt)
+				}
+
+				select {
+				case result <- event:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return result, nil
+}
+
+func (r subscriptionResolver) EntityChanges(ctx context.Context, repoRef *string) (<-chan *models.EntityChangeEvent, error) {
+	refs := r.cache.Names()
+	if repoRef != nil {
+		refs = []string{*repoRef}
+	}
+
+	repos := make([]*cache.RepoCache, len(refs))
+	for i, ref := range refs {
+		repo, err := r.cache.ResolveRepo(ref)
+		if err != nil {
+			return nil, err
+		}
+		repos[i] = repo
+	}
+
+	result := make(chan *models.EntityChangeEvent)
+	var wg sync.WaitGroup
+
+	for i, repo := range repos {
+		var ref *string
+		if refs[i] != "" {
+			ref = &refs[i]
+		}
+
+		changes, unsubscribe := repo.Changes()
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+			defer unsubscribe()
+
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case change, ok := <-changes:
+					if !ok {
+						return
+					}
+
+					event := &models.EntityChangeEvent{
+						Type:    changeType(change.Typ),
+						RepoRef: ref,
+						Entity:  entityType(change.Target),
+						ID:      change.Id.String(),
+					}
+
+					select {
+					case result <- event:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}
+		}()
+	}
+
+	// the firehose is closed once every repository is done
+	go func() {
+		wg.Wait()
+		close(result)
+	}()
+
+	return result, nil
+}
+
+func (r subscriptionResolver) BridgeSyncProgress(ctx context.Context, id string) (<-chan *models.BridgeSync, error) {
+	s := r.syncs.byId(id)
+	if s == nil {
+		return nil, fmt.Errorf("unknown synchronization %s", id)
+	}
+
+	changes, unsubscribe := s.Changes()
+	result := make(chan *models.BridgeSync)
+
+	go func() {
+		defer unsubscribe()
+		defer close(result)
+
+		// the current state first, then again on each change until the end
+		for {
+			running := s.Running()
+
+			select {
+			case result <- s:
+			case <-ctx.Done():
+				return
+			}
+
+			if !running {
+				return
+			}
+
+			select {
+			case <-changes:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return result, nil
+}
+
+func entityType(target string) models.EntityType {
+	if target == "identities" {
+		return models.EntityTypeIDEntity
+	}
+	return models.EntityTypeBug
+}
+
+func changeType(typ cache.ChangeEventType) models.ChangeType {
+	switch typ {
+	case cache.ChangeEventAdded:
+		return models.ChangeTypeAdded
+	case cache.ChangeEventRemoved:
+		return models.ChangeTypeRemoved
+	default:
+		return models.ChangeTypeUpdated
+	}
+}
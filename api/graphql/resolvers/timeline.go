@@ -40,6 +40,14 @@ func (addCommentTimelineItemResolver) LastEdit(_ context.Context, obj *bug.AddCo
 	return &t, nil
 }
 
+func (addCommentTimelineItemResolver) ReplyTo(_ context.Context, obj *bug.AddCommentTimelineItem) (*string, error) {
+	return optionalString(obj.ReplyTo.String()), nil
+}
+
+func (addCommentTimelineItemResolver) Minimized(_ context.Context, obj *bug.AddCommentTimelineItem) (*string, error) {
+	return optionalString(string(obj.Minimized)), nil
+}
+
 var _ graph.CreateTimelineItemResolver = createTimelineItemResolver{}
 
 type createTimelineItemResolver struct{}
@@ -62,6 +70,10 @@ func (createTimelineItemResolver) LastEdit(_ context.Context, obj *bug.CreateTim
 	return &t, nil
 }
 
+func (createTimelineItemResolver) Minimized(_ context.Context, obj *bug.CreateTimelineItem) (*string, error) {
+	return optionalString(string(obj.Minimized)), nil
+}
+
 var _ graph.LabelChangeTimelineItemResolver = labelChangeTimelineItem{}
 
 type labelChangeTimelineItem struct{}
@@ -100,6 +112,10 @@ func (setStatusTimelineItem) Status(_ context.Context, obj *bug.SetStatusTimelin
 	return convertStatus(obj.Status)
 }
 
+func (setStatusTimelineItem) Extended(_ context.Context, obj *bug.SetStatusTimelineItem) (*string, error) {
+	return optionalString(obj.Extended), nil
+}
+
 var _ graph.SetTitleTimelineItemResolver = setTitleTimelineItem{}
 
 type setTitleTimelineItem struct{}
@@ -0,0 +1,102 @@
+package graphql
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/vektah/gqlparser/ast"
+
+	"github.com/MichaelMure/git-bug/api/auth"
+	"github.com/MichaelMure/git-bug/cache"
+)
+
+// the maximum number of responses kept in the cache
+const responseCacheSize = 1000
+
+// responseCache keep the responses of the queries for a short time. As the
+// generation of the cache is part of the key, a response is never served
+// after the data changed.
+type responseCache struct {
+	mrc *cache.MultiRepoCache
+	ttl time.Duration
+	lru *lru.Cache
+}
+
+type cachedResponse struct {
+	response *graphql.Response
+	expires  time.Time
+}
+
+func newResponseCache(mrc *cache.MultiRepoCache, ttl time.Duration) *responseCache {
+	l, _ := lru.New(responseCacheSize)
+	return &responseCache{mrc: mrc, ttl: ttl, lru: l}
+}
+
+// aroundOperations serve the queries from the cache when possible, and
+// record their responses otherwise.
+func (rc *responseCache) aroundOperations(ctx context.Context, next graphql.OperationHandler) graphql.ResponseHandler {
+	oc := graphql.GetOperationContext(ctx)
+	if oc.Operation == nil {
+		return next(ctx)
+	}
+
+	if oc.Operation.Operation == ast.Mutation {
+		// not everything a mutation can change is covered by the generation,
+		// like the labels or the saved queries
+		responses := next(ctx)
+		return func(ctx context.Context) *graphql.Response {
+			defer rc.lru.Purge()
+			return responses(ctx)
+		}
+	}
+
+	if oc.Operation.Operation != ast.Query {
+		return next(ctx)
+	}
+
+	key, err := rc.key(ctx, oc)
+	if err != nil {
+		return next(ctx)
+	}
+
+	if value, ok := rc.lru.Get(key); ok {
+		cached := value.(cachedResponse)
+		if time.Now().Before(cached.expires) {
+			return graphql.OneShot(cached.response)
+		}
+		rc.lru.Remove(key)
+	}
+
+	responses := next(ctx)
+	return func(ctx context.Context) *graphql.Response {
+		response := responses(ctx)
+		if response != nil && len(response.Errors) == 0 {
+			rc.lru.Add(key, cachedResponse{
+				response: response,
+				expires:  time.Now().Add(rc.ttl),
+			})
+		}
+		return response
+	}
+}
+
+// key identify a query, for a given user, scope and state of the data
+func (rc *responseCache) key(ctx context.Context, oc *graphql.OperationContext) (string, error) {
+	variables, err := json.Marshal(oc.Variables)
+	if err != nil {
+		return "", err
+	}
+
+	userId, _ := auth.UserIdFromCtx(ctx)
+	scope := auth.ScopeFromCtx(ctx)
+
+	h := sha256.New()
+	_, _ = fmt.Fprintf(h, "%d\n%s\n%s\n%s\n%s\n%s", rc.mrc.Generation(), userId, scope, oc.OperationName, variables, oc.RawQuery)
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,393 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             (unknown)
+// source: gitbug.proto
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// GitBugClient is the client API for GitBug service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type GitBugClient interface {
+	// ListBugs return the bugs matching a query, in its order
+	ListBugs(ctx context.Context, in *ListBugsRequest, opts ...grpc.CallOption) (*ListBugsResponse, error)
+	// GetBug return a bug with its comments
+	GetBug(ctx context.Context, in *GetBugRequest, opts ...grpc.CallOption) (*Bug, error)
+	CreateBug(ctx context.Context, in *CreateBugRequest, opts ...grpc.CallOption) (*Bug, error)
+	AddComment(ctx context.Context, in *AddCommentRequest, opts ...grpc.CallOption) (*Bug, error)
+	SetTitle(ctx context.Context, in *SetTitleRequest, opts ...grpc.CallOption) (*Bug, error)
+	SetStatus(ctx context.Context, in *SetStatusRequest, opts ...grpc.CallOption) (*Bug, error)
+	ChangeLabels(ctx context.Context, in *ChangeLabelsRequest, opts ...grpc.CallOption) (*Bug, error)
+	// WatchChanges stream the changes of the entities as they happen, made
+	// through the daemon or by another process like a pull
+	WatchChanges(ctx context.Context, in *WatchChangesRequest, opts ...grpc.CallOption) (GitBug_WatchChangesClient, error)
+}
+
+type gitBugClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewGitBugClient(cc grpc.ClientConnInterface) GitBugClient {
+	return &gitBugClient{cc}
+}
+
+func (c *gitBugClient) ListBugs(ctx context.Context, in *ListBugsRequest, opts ...grpc.CallOption) (*ListBugsResponse, error) {
+	out := new(ListBugsResponse)
+	err := c.cc.Invoke(ctx, "/gitbug.v1.GitBug/ListBugs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gitBugClient) GetBug(ctx context.Context, in *GetBugRequest, opts ...grpc.CallOption) (*Bug, error) {
+	out := new(Bug)
+	err := c.cc.Invoke(ctx, "/gitbug.v1.GitBug/GetBug", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gitBugClient) CreateBug(ctx context.Context, in *CreateBugRequest, opts ...grpc.CallOption) (*Bug, error) {
+	out := new(Bug)
+	err := c.cc.Invoke(ctx, "/gitbug.v1.GitBug/CreateBug", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gitBugClient) AddComment(ctx context.Context, in *AddCommentRequest, opts ...grpc.CallOption) (*Bug, error) {
+	out := new(Bug)
+	err := c.cc.Invoke(ctx, "/gitbug.v1.GitBug/AddComment", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gitBugClient) SetTitle(ctx context.Context, in *SetTitleRequest, opts ...grpc.CallOption) (*Bug, error) {
+	out := new(Bug)
+	err := c.cc.Invoke(ctx, "/gitbug.v1.GitBug/SetTitle", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gitBugClient) SetStatus(ctx context.Context, in *SetStatusRequest, opts ...grpc.CallOption) (*Bug, error) {
+	out := new(Bug)
+	err := c.cc.Invoke(ctx, "/gitbug.v1.GitBug/SetStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gitBugClient) ChangeLabels(ctx context.Context, in *ChangeLabelsRequest, opts ...grpc.CallOption) (*Bug, error) {
+	out := new(Bug)
+	err := c.cc.Invoke(ctx, "/gitbug.v1.GitBug/ChangeLabels", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gitBugClient) WatchChanges(ctx context.Context, in *WatchChangesRequest, opts ...grpc.CallOption) (GitBug_WatchChangesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &GitBug_ServiceDesc.Streams[0], "/gitbug.v1.GitBug/WatchChanges", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &gitBugWatchChangesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type GitBug_WatchChangesClient interface {
+	Recv() (*Change, error)
+	grpc.ClientStream
+}
+
+type gitBugWatchChangesClient struct {
+	grpc.ClientStream
+}
+
+func (x *gitBugWatchChangesClient) Recv() (*Change, error) {
+	m := new(Change)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// GitBugServer is the server API for GitBug service.
+// All implementations must embed UnimplementedGitBugServer
+// for forward compatibility
+type GitBugServer interface {
+	// ListBugs return the bugs matching a query, in its order
+	ListBugs(context.Context, *ListBugsRequest) (*ListBugsResponse, error)
+	// GetBug return a bug with its comments
+	GetBug(context.Context, *GetBugRequest) (*Bug, error)
+	CreateBug(context.Context, *CreateBugRequest) (*Bug, error)
+	AddComment(context.Context, *AddCommentRequest) (*Bug, error)
+	SetTitle(context.Context, *SetTitleRequest) (*Bug, error)
+	SetStatus(context.Context, *SetStatusRequest) (*Bug, error)
+	ChangeLabels(context.Context, *ChangeLabelsRequest) (*Bug, error)
+	// WatchChanges stream the changes of the entities as they happen, made
+	// through the daemon or by another process like a pull
+	WatchChanges(*WatchChangesRequest, GitBug_WatchChangesServer) error
+	mustEmbedUnimplementedGitBugServer()
+}
+
+// UnimplementedGitBugServer must be embedded to have forward compatible implementations.
+type UnimplementedGitBugServer struct {
+}
+
+func (UnimplementedGitBugServer) ListBugs(context.Context, *ListBugsRequest) (*ListBugsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListBugs not implemented")
+}
+func (UnimplementedGitBugServer) GetBug(context.Context, *GetBugRequest) (*Bug, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBug not implemented")
+}
+func (UnimplementedGitBugServer) CreateBug(context.Context, *CreateBugRequest) (*Bug, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateBug not implemented")
+}
+func (UnimplementedGitBugServer) AddComment(context.Context, *AddCommentRequest) (*Bug, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddComment not implemented")
+}
+func (UnimplementedGitBugServer) SetTitle(context.Context, *SetTitleRequest) (*Bug, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetTitle not implemented")
+}
+func (UnimplementedGitBugServer) SetStatus(context.Context, *SetStatusRequest) (*Bug, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetStatus not implemented")
+}
+func (UnimplementedGitBugServer) ChangeLabels(context.Context, *ChangeLabelsRequest) (*Bug, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ChangeLabels not implemented")
+}
+func (UnimplementedGitBugServer) WatchChanges(*WatchChangesRequest, GitBug_WatchChangesServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchChanges not implemented")
+}
+func (UnimplementedGitBugServer) mustEmbedUnimplementedGitBugServer() {}
+
+// UnsafeGitBugServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to GitBugServer will
+// result in compilation errors.
+type UnsafeGitBugServer interface {
+	mustEmbedUnimplementedGitBugServer()
+}
+
+func RegisterGitBugServer(s grpc.ServiceRegistrar, srv GitBugServer) {
+	s.RegisterService(&GitBug_ServiceDesc, srv)
+}
+
+func _GitBug_ListBugs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBugsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GitBugServer).ListBugs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gitbug.v1.GitBug/ListBugs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GitBugServer).ListBugs(ctx, req.(*ListBugsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GitBug_GetBug_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBugRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GitBugServer).GetBug(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gitbug.v1.GitBug/GetBug",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GitBugServer).GetBug(ctx, req.(*GetBugRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GitBug_CreateBug_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateBugRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GitBugServer).CreateBug(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gitbug.v1.GitBug/CreateBug",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GitBugServer).CreateBug(ctx, req.(*CreateBugRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GitBug_AddComment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddCommentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GitBugServer).AddComment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gitbug.v1.GitBug/AddComment",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GitBugServer).AddComment(ctx, req.(*AddCommentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GitBug_SetTitle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetTitleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GitBugServer).SetTitle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gitbug.v1.GitBug/SetTitle",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GitBugServer).SetTitle(ctx, req.(*SetTitleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GitBug_SetStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GitBugServer).SetStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gitbug.v1.GitBug/SetStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GitBugServer).SetStatus(ctx, req.(*SetStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GitBug_ChangeLabels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChangeLabelsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GitBugServer).ChangeLabels(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gitbug.v1.GitBug/ChangeLabels",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GitBugServer).ChangeLabels(ctx, req.(*ChangeLabelsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GitBug_WatchChanges_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchChangesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GitBugServer).WatchChanges(m, &gitBugWatchChangesServer{stream})
+}
+
+type GitBug_WatchChangesServer interface {
+	Send(*Change) error
+	grpc.ServerStream
+}
+
+type gitBugWatchChangesServer struct {
+	grpc.ServerStream
+}
+
+func (x *gitBugWatchChangesServer) Send(m *Change) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// GitBug_ServiceDesc is the grpc.ServiceDesc for GitBug service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var GitBug_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "gitbug.v1.GitBug",
+	HandlerType: (*GitBugServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListBugs",
+			Handler:    _GitBug_ListBugs_Handler,
+		},
+		{
+			MethodName: "GetBug",
+			Handler:    _GitBug_GetBug_Handler,
+		},
+		{
+			MethodName: "CreateBug",
+			Handler:    _GitBug_CreateBug_Handler,
+		},
+		{
+			MethodName: "AddComment",
+			Handler:    _GitBug_AddComment_Handler,
+		},
+		{
+			MethodName: "SetTitle",
+			Handler:    _GitBug_SetTitle_Handler,
+		},
+		{
+			MethodName: "SetStatus",
+			Handler:    _GitBug_SetStatus_Handler,
+		},
+		{
+			MethodName: "ChangeLabels",
+			Handler:    _GitBug_ChangeLabels_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchChanges",
+			Handler:       _GitBug_WatchChanges_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "gitbug.proto",
+}
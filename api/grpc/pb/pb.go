@@ -0,0 +1,5 @@
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative gitbug.proto
+
+// Package pb contains the protobuf definitions of the gRPC API, and the code
+// generated from them.
+package pb
@@ -0,0 +1,270 @@
+// Package grpc contains the gRPC service of git-bug, for the integrations
+// preferring strongly typed clients over the GraphQL API.
+package grpc
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/MichaelMure/git-bug/api/grpc/pb"
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/identity"
+	"github.com/MichaelMure/git-bug/query"
+)
+
+// NewServer create a gRPC server serving the GitBug service for a repository
+func NewServer(repo *cache.RepoCache) *grpc.Server {
+	s := grpc.NewServer()
+	pb.RegisterGitBugServer(s, &server{repo: repo})
+	return s
+}
+
+type server struct {
+	pb.UnimplementedGitBugServer
+	repo *cache.RepoCache
+}
+
+func (s *server) ListBugs(_ context.Context, req *pb.ListBugsRequest) (*pb.ListBugsResponse, error) {
+	q := query.NewQuery()
+	if req.Query != "" {
+		saved, err := query.ReadSavedQueries(s.repo.LocalConfig())
+		if err != nil {
+			return nil, err
+		}
+		q, err = query.ParseWithSaved(req.Query, saved)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+
+	ids := s.repo.QueryBugs(q)
+
+	resp := &pb.ListBugsResponse{TotalCount: int32(len(ids))}
+	if req.Limit > 0 && int(req.Limit) < len(ids) {
+		ids = ids[:req.Limit]
+	}
+
+	for _, id := range ids {
+		excerpt, err := s.repo.ResolveBugExcerpt(id)
+		if err != nil {
+			return nil, err
+		}
+		resp.Bugs = append(resp.Bugs, s.bugSummary(excerpt))
+	}
+
+	return resp, nil
+}
+
+func (s *server) GetBug(_ context.Context, req *pb.GetBugRequest) (*pb.Bug, error) {
+	b, err := s.resolveBug(req.Prefix)
+	if err != nil {
+		return nil, err
+	}
+	return convertBug(b.Snapshot()), nil
+}
+
+func (s *server) CreateBug(_ context.Context, req *pb.CreateBugRequest) (*pb.Bug, error) {
+	b, _, err := s.repo.NewBug(req.Title, req.Message)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return convertBug(b.Snapshot()), nil
+}
+
+func (s *server) AddComment(_ context.Context, req *pb.AddCommentRequest) (*pb.Bug, error) {
+	return s.update(req.Prefix, func(b *cache.BugCache) error {
+		_, err := b.AddComment(req.Message)
+		return err
+	})
+}
+
+func (s *server) SetTitle(_ context.Context, req *pb.SetTitleRequest) (*pb.Bug, error) {
+	return s.update(req.Prefix, func(b *cache.BugCache) error {
+		_, err := b.SetTitle(req.Title)
+		return err
+	})
+}
+
+func (s *server) SetStatus(_ context.Context, req *pb.SetStatusRequest) (*pb.Bug, error) {
+	return s.update(req.Prefix, func(b *cache.BugCache) error {
+		var err error
+		switch req.Status {
+		case pb.Status_STATUS_OPEN:
+			_, err = b.Open()
+		case pb.Status_STATUS_CLOSED:
+			_, err = b.Close()
+		default:
+			return status.Error(codes.InvalidArgument, "unknown status")
+		}
+		return err
+	})
+}
+
+func (s *server) ChangeLabels(_ context.Context, req *pb.ChangeLabelsRequest) (*pb.Bug, error) {
+	return s.update(req.Prefix, func(b *cache.BugCache) error {
+		_, _, err := b.ChangeLabels(req.Added, req.Removed)
+		return err
+	})
+}
+
+func (s *server) WatchChanges(_ *pb.WatchChangesRequest, stream pb.GitBug_WatchChangesServer) error {
+	changes, unsubscribe := s.repo.Changes()
+	defer unsubscribe()
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case change, ok := <-changes:
+			if !ok {
+				return nil
+			}
+			err := stream.Send(convertChange(change))
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// update apply a modification to a bug and commit it
+func (s *server) update(prefix string, f func(b *cache.BugCache) error) (*pb.Bug, error) {
+	b, err := s.resolveBug(prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	err = f(b)
+	if err != nil {
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+
+	err = b.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return convertBug(b.Snapshot()), nil
+}
+
+func (s *server) resolveBug(prefix string) (*cache.BugCache, error) {
+	b, err := s.repo.ResolveBugPrefix(prefix)
+	switch {
+	case err == bug.ErrBugNotExist:
+		return nil, status.Error(codes.NotFound, err.Error())
+	case entity.IsErrMultipleMatch(err):
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	case err != nil:
+		return nil, err
+	}
+	return b, nil
+}
+
+func (s *server) bugSummary(excerpt *cache.BugExcerpt) *pb.BugSummary {
+	summary := &pb.BugSummary{
+		Id:           excerpt.Id.String(),
+		HumanId:      excerpt.Id.Human(),
+		Title:        excerpt.Title,
+		Status:       convertStatus(excerpt.Status),
+		CreatedAt:    timestamppb.New(time.Unix(excerpt.CreateUnixTime, 0)),
+		EditedAt:     timestamppb.New(time.Unix(excerpt.EditUnixTime, 0)),
+		CommentCount: int32(excerpt.LenComments),
+	}
+	for _, label := range excerpt.Labels {
+		summary.Labels = append(summary.Labels, label.String())
+	}
+
+	author, err := s.repo.ResolveIdentityExcerpt(excerpt.AuthorId)
+	if err == nil {
+		summary.Author = &pb.Identity{
+			Id:          author.Id.String(),
+			HumanId:     author.Id.Human(),
+			Name:        author.Name,
+			Login:       author.Login,
+			DisplayName: author.DisplayName(),
+		}
+	}
+
+	return summary
+}
+
+func convertBug(snap *bug.Snapshot) *pb.Bug {
+	result := &pb.Bug{
+		Id:        snap.Id().String(),
+		HumanId:   snap.Id().Human(),
+		Title:     snap.Title,
+		Status:    convertStatus(snap.Status),
+		Author:    convertIdentity(snap.Author),
+		CreatedAt: timestamppb.New(snap.CreateTime),
+		EditedAt:  timestamppb.New(snap.EditTime()),
+	}
+	for _, label := range snap.Labels {
+		result.Labels = append(result.Labels, label.String())
+	}
+	for _, comment := range snap.Comments {
+		c := &pb.Comment{
+			Id:        comment.Id().String(),
+			Author:    convertIdentity(comment.Author),
+			Message:   comment.Message,
+			CreatedAt: timestamppb.New(comment.UnixTime.Time()),
+		}
+		for _, file := range comment.Files {
+			c.Files = append(c.Files, file.String())
+		}
+		result.Comments = append(result.Comments, c)
+	}
+	return result
+}
+
+func convertIdentity(i identity.Interface) *pb.Identity {
+	return &pb.Identity{
+		Id:          i.Id().String(),
+		HumanId:     i.Id().Human(),
+		Name:        i.Name(),
+		Login:       i.Login(),
+		DisplayName: i.DisplayName(),
+	}
+}
+
+func convertStatus(s bug.Status) pb.Status {
+	switch s {
+	case bug.OpenStatus:
+		return pb.Status_STATUS_OPEN
+	case bug.ClosedStatus:
+		return pb.Status_STATUS_CLOSED
+	default:
+		return pb.Status_STATUS_UNSPECIFIED
+	}
+}
+
+func convertChange(change cache.ChangeEvent) *pb.Change {
+	result := &pb.Change{Id: change.Id.String()}
+
+	switch change.Typ {
+	case cache.ChangeEventAdded:
+		result.Type = pb.ChangeType_CHANGE_TYPE_ADDED
+	case cache.ChangeEventUpdated:
+		result.Type = pb.ChangeType_CHANGE_TYPE_UPDATED
+	case cache.ChangeEventRemoved:
+		result.Type = pb.ChangeType_CHANGE_TYPE_REMOVED
+	}
+
+	switch change.Target {
+	case "bugs":
+		result.Entity = pb.EntityType_ENTITY_TYPE_BUG
+	case "identities":
+		result.Entity = pb.EntityType_ENTITY_TYPE_IDENTITY
+	}
+
+	return result
+}
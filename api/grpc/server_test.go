@@ -0,0 +1,58 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/MichaelMure/git-bug/api/grpc/pb"
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+func TestServer(t *testing.T) {
+	repo := repository.CreateGoGitTestRepo(false)
+	defer repository.CleanupTestRepos(repo)
+
+	repoCache, err := cache.NewRepoCache(repo)
+	require.NoError(t, err)
+	defer repoCache.Close()
+
+	iden, err := repoCache.NewIdentity("René Descartes", "rene@descartes.fr")
+	require.NoError(t, err)
+	require.NoError(t, repoCache.SetUserIdentity(iden))
+
+	s := &server{repo: repoCache}
+	ctx := context.Background()
+
+	created, err := s.CreateBug(ctx, &pb.CreateBugRequest{Title: "title", Message: "message"})
+	require.NoError(t, err)
+	assert.Equal(t, pb.Status_STATUS_OPEN, created.Status)
+	assert.Equal(t, "René Descartes", created.Author.Name)
+
+	updated, err := s.AddComment(ctx, &pb.AddCommentRequest{Prefix: created.HumanId, Message: "comment"})
+	require.NoError(t, err)
+	require.Len(t, updated.Comments, 2)
+	assert.Equal(t, "comment", updated.Comments[1].Message)
+
+	updated, err = s.SetStatus(ctx, &pb.SetStatusRequest{Prefix: created.HumanId, Status: pb.Status_STATUS_CLOSED})
+	require.NoError(t, err)
+	assert.Equal(t, pb.Status_STATUS_CLOSED, updated.Status)
+
+	list, err := s.ListBugs(ctx, &pb.ListBugsRequest{Query: "status:closed"})
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, list.TotalCount)
+	require.Len(t, list.Bugs, 1)
+	assert.Equal(t, created.Id, list.Bugs[0].Id)
+	assert.EqualValues(t, 2, list.Bugs[0].CommentCount)
+
+	_, err = s.GetBug(ctx, &pb.GetBugRequest{Prefix: "ffffff"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = s.ListBugs(ctx, &pb.ListBugsRequest{Query: "foo:bar"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
@@ -0,0 +1,48 @@
+package http
+
+import (
+	"net/http"
+	"strings"
+)
+
+// NewCORSMiddleware allow the web pages of the given origins, like
+// "https://example.com", to use the API. "*" allow any origin. The
+// credentials are given with a bearer token, as the cookie of the web UI is
+// not sent to other sites.
+func NewCORSMiddleware(origins []string) func(http.Handler) http.Handler {
+	allowed := make(map[string]struct{}, len(origins))
+	for _, origin := range origins {
+		allowed[strings.TrimSuffix(origin, "/")] = struct{}{}
+	}
+	_, anyOrigin := allowed["*"]
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			origin := r.Header.Get("Origin")
+			if origin == "" {
+				next.ServeHTTP(rw, r)
+				return
+			}
+
+			rw.Header().Add("Vary", "Origin")
+
+			if _, ok := allowed[origin]; !ok && !anyOrigin {
+				next.ServeHTTP(rw, r)
+				return
+			}
+
+			rw.Header().Set("Access-Control-Allow-Origin", origin)
+
+			// preflight request
+			if r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != "" {
+				rw.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
+				rw.Header().Set("Access-Control-Allow-Headers", "Authorization, Content-Type")
+				rw.Header().Set("Access-Control-Max-Age", "600")
+				rw.WriteHeader(http.StatusNoContent)
+				return
+			}
+
+			next.ServeHTTP(rw, r)
+		})
+	}
+}
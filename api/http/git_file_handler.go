@@ -2,6 +2,7 @@ package http
 
 import (
 	"bytes"
+	"mime"
 	"net/http"
 	"time"
 
@@ -16,6 +17,9 @@ import (
 // Expected gorilla/mux parameters:
 //   - "repo" : the ref of the repo or "" for the default one
 //   - "hash" : the git hash of the file to retrieve
+//
+// With the "download" query parameter, the browser is told to save the file
+// instead of displaying it.
 type gitFileHandler struct {
 	mrc *cache.MultiRepoCache
 }
@@ -57,5 +61,33 @@ func (gfh *gitFileHandler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	contentType := http.DetectContentType(data)
+	rw.Header().Set("Content-Type", contentType)
+
+	// the files are uploaded by the users: a HTML file should not be able to
+	// run scripts in the origin of the web UI
+	rw.Header().Set("Content-Security-Policy", "sandbox")
+
+	if _, ok := r.URL.Query()["download"]; ok {
+		rw.Header().Set("Content-Disposition",
+			mime.FormatMediaType("attachment", map[string]string{"filename": fileName(hash, contentType)}))
+	}
+
 	http.ServeContent(rw, r, "", time.Now(), bytes.NewReader(data))
 }
+
+// fileName give a name to a file, from its hash and an extension matching its
+// type, as the name of the file is not stored
+func fileName(hash repository.Hash, contentType string) string {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return hash.String()
+	}
+
+	extensions, err := mime.ExtensionsByType(mediaType)
+	if err != nil || len(extensions) == 0 {
+		return hash.String()
+	}
+
+	return hash.String() + extensions[0]
+}
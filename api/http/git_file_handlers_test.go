@@ -2,6 +2,7 @@ package http
 
 import (
 	"bytes"
+	"fmt"
 	"image"
 	"image/png"
 	"mime/multipart"
@@ -57,7 +58,8 @@ func TestGitFileHandlers(t *testing.T) {
 	r.Header.Add("Content-Type", writer.FormDataContentType())
 
 	// Simulate auth
-	r = r.WithContext(auth.CtxWithUser(r.Context(), author.Id()))
+	ctx := auth.CtxWithUser(r.Context(), author.Id())
+	r = r.WithContext(auth.CtxWithScope(ctx, auth.ScopeWrite))
 
 	// Handler's params
 	r = mux.SetURLVars(r, map[string]string{
@@ -67,7 +69,7 @@ func TestGitFileHandlers(t *testing.T) {
 	uploadHandler.ServeHTTP(w, r)
 
 	assert.Equal(t, http.StatusOK, w.Code)
-	assert.Equal(t, `{"hash":"3426a1488292d8f3f3c59ca679681336542b986f"}`, w.Body.String())
+	assert.Equal(t, fmt.Sprintf(`{"hash":"3426a1488292d8f3f3c59ca679681336542b986f","size":%d,"contentType":"image/png"}`, data.Len()), w.Body.String())
 	// DOWNLOAD
 
 	downloadHandler := NewGitFileHandler(mrc)
@@ -88,4 +90,20 @@ func TestGitFileHandlers(t *testing.T) {
 	assert.Equal(t, http.StatusOK, w.Code)
 
 	assert.Equal(t, data.Bytes(), w.Body.Bytes())
+	assert.Equal(t, "image/png", w.Header().Get("Content-Type"))
+	assert.Empty(t, w.Header().Get("Content-Disposition"))
+
+	// DOWNLOAD AS ATTACHMENT
+
+	w = httptest.NewRecorder()
+	r, _ = http.NewRequest("GET", "/?download", nil)
+	r = r.WithContext(auth.CtxWithUser(r.Context(), author.Id()))
+	r = mux.SetURLVars(r, map[string]string{
+		"repo": "",
+		"hash": "3426a1488292d8f3f3c59ca679681336542b986f",
+	})
+
+	downloadHandler.ServeHTTP(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `attachment; filename=3426a1488292d8f3f3c59ca679681336542b986f.png`, w.Header().Get("Content-Disposition"))
 }
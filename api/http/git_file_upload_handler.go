@@ -49,6 +49,10 @@ func (gufh *gitUploadFileHandler) ServeHTTP(rw http.ResponseWriter, r *http.Requ
 		http.Error(rw, fmt.Sprintf("loading identity: %v", err), http.StatusInternalServerError)
 		return
 	}
+	if auth.ScopeFromCtx(r.Context()) == auth.ScopeRead {
+		http.Error(rw, auth.ErrReadOnlyToken.Error(), http.StatusForbidden)
+		return
+	}
 
 	// 100MB (github limit)
 	var maxUploadSize int64 = 100 * 1000 * 1000
@@ -70,13 +74,8 @@ func (gufh *gitUploadFileHandler) ServeHTTP(rw http.ResponseWriter, r *http.Requ
 		return
 	}
 
-	filetype := http.DetectContentType(fileBytes)
-	if filetype != "image/jpeg" && filetype != "image/jpg" &&
-		filetype != "image/gif" && filetype != "image/png" {
-		http.Error(rw, "invalid file type", http.StatusBadRequest)
-		return
-	}
-
+	// any kind of file can be attached, they are served with their detected
+	// type in a sandbox
 	hash, err := repo.StoreData(fileBytes)
 	if err != nil {
 		http.Error(rw, err.Error(), http.StatusInternalServerError)
@@ -84,10 +83,16 @@ func (gufh *gitUploadFileHandler) ServeHTTP(rw http.ResponseWriter, r *http.Requ
 	}
 
 	type response struct {
-		Hash string `json:"hash"`
+		Hash        string `json:"hash"`
+		Size        int    `json:"size"`
+		ContentType string `json:"contentType"`
 	}
 
-	resp := response{Hash: string(hash)}
+	resp := response{
+		Hash:        string(hash),
+		Size:        len(fileBytes),
+		ContentType: http.DetectContentType(fileBytes),
+	}
 
 	js, err := json.Marshal(resp)
 	if err != nil {
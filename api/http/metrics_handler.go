@@ -0,0 +1,27 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/MichaelMure/git-bug/cache"
+)
+
+// implement a http.Handler that will serve the metrics of the cache in the
+// Prometheus text format.
+type metricsHandler struct {
+	collector *cache.MetricsCollector
+}
+
+func NewMetricsHandler(collector *cache.MetricsCollector) http.Handler {
+	return &metricsHandler{collector: collector}
+}
+
+func (mh *metricsHandler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	rw.Header().Set("Content-Type", "text/plain; version=0.0.4")
+
+	err := mh.collector.WritePrometheus(rw)
+	if err != nil {
+		http.Error(rw, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
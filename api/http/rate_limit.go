@@ -0,0 +1,110 @@
+package http
+
+import (
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// idle buckets are forgotten after this delay, as they would be full again
+const rateLimitIdle = 10 * time.Minute
+
+// bucket is a token bucket, refilled continuously at a fixed rate up to its
+// capacity. Each request take one token.
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// RateLimiter limit the number of requests of each client, identified by
+// their network address, with a token bucket.
+type RateLimiter struct {
+	rate  float64
+	burst int
+
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+}
+
+// NewRateLimiter create a RateLimiter allowing on average rate requests per
+// second to each client, and bursts of up to burst requests.
+func NewRateLimiter(rate float64, burst int) *RateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &RateLimiter{
+		rate:    rate,
+		burst:   burst,
+		buckets: make(map[string]*bucket),
+	}
+}
+
+// Allow take a token from the bucket of the client, and return whether it
+// can proceed. If not, it also return the delay before the next token.
+func (rl *RateLimiter) Allow(client string) (bool, time.Duration) {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	now := time.Now()
+	rl.sweep(now)
+
+	b, ok := rl.buckets[client]
+	if !ok {
+		b = &bucket{tokens: float64(rl.burst), last: now}
+		rl.buckets[client] = b
+	}
+
+	b.tokens = math.Min(float64(rl.burst), b.tokens+now.Sub(b.last).Seconds()*rl.rate)
+	b.last = now
+
+	if b.tokens < 1 {
+		wait := time.Duration((1 - b.tokens) / rl.rate * float64(time.Second))
+		return false, wait
+	}
+
+	b.tokens--
+	return true, 0
+}
+
+// sweep remove the idle buckets, to not grow indefinitely with the clients
+func (rl *RateLimiter) sweep(now time.Time) {
+	if now.Sub(rl.lastSweep) < rateLimitIdle {
+		return
+	}
+	rl.lastSweep = now
+
+	for client, b := range rl.buckets {
+		if now.Sub(b.last) > rateLimitIdle {
+			delete(rl.buckets, client)
+		}
+	}
+}
+
+// Middleware refuse the requests over the limit with a 429 Too Many Requests
+func (rl *RateLimiter) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		ok, wait := rl.Allow(clientAddr(r))
+		if !ok {
+			seconds := int(math.Ceil(wait.Seconds()))
+			rw.Header().Set("Retry-After", strconv.Itoa(seconds))
+			http.Error(rw, "rate limit exceeded", http.StatusTooManyRequests)
+			return
+		}
+		next.ServeHTTP(rw, r)
+	})
+}
+
+// clientAddr return the host of the client, without the port that change for
+// each connection. The clients connecting on a unix socket all share the same
+// address.
+func clientAddr(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
@@ -0,0 +1,40 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimiter(t *testing.T) {
+	rl := NewRateLimiter(0.01, 2)
+
+	ok, _ := rl.Allow("a")
+	require.True(t, ok)
+	ok, _ = rl.Allow("a")
+	require.True(t, ok)
+	ok, wait := rl.Allow("a")
+	require.False(t, ok)
+	assert.True(t, wait > 0)
+
+	// the other clients have their own bucket
+	ok, _ = rl.Allow("b")
+	require.True(t, ok)
+
+	handler := rl.Middleware(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {}))
+
+	r := httptest.NewRequest(http.MethodGet, "/graphql", nil)
+	r.RemoteAddr = "a:1234"
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.NotEmpty(t, w.Header().Get("Retry-After"))
+
+	r.RemoteAddr = "c:1234"
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
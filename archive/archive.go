@@ -0,0 +1,322 @@
+// Package archive implement a portable, self-contained dump of the bugs and
+// identities of a repository.
+//
+// The archive hold the raw git objects of the entities, so that once imported
+// in another repository, the entities, their operations and their attachments
+// keep the exact same ids.
+package archive
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+// Version is the format version of the archives produced by Export
+const Version = 1
+
+const bugsRefPattern = "refs/bugs/"
+const bugsRemoteRefPattern = "refs/remotes/%s/bugs/"
+const identityRefPattern = "refs/identities/"
+const identityRemoteRefPattern = "refs/remotes/%s/identities/"
+
+// Archive is the serialized form of a set of entities
+type Archive struct {
+	Version    int              `json:"version"`
+	Identities []ArchivedEntity `json:"identities"`
+	Bugs       []ArchivedEntity `json:"bugs"`
+	Objects    Objects          `json:"objects"`
+}
+
+// ArchivedEntity is an entity with its commits, in chronological order
+type ArchivedEntity struct {
+	Id      entity.Id         `json:"id"`
+	Commits []repository.Hash `json:"commits"`
+}
+
+// Head return the last commit of the entity
+func (ae ArchivedEntity) Head() repository.Hash {
+	return ae.Commits[len(ae.Commits)-1]
+}
+
+// Objects hold the git objects referenced by the archived entities
+type Objects struct {
+	Commits map[repository.Hash][]byte              `json:"commits"`
+	Trees   map[repository.Hash][]ArchivedTreeEntry `json:"trees"`
+	Blobs   map[repository.Hash][]byte              `json:"blobs"`
+}
+
+// ArchivedTreeEntry is a git tree entry
+type ArchivedTreeEntry struct {
+	Type string          `json:"type"`
+	Hash repository.Hash `json:"hash"`
+	Name string          `json:"name"`
+}
+
+func newArchive() *Archive {
+	return &Archive{
+		Version: Version,
+		Objects: Objects{
+			Commits: make(map[repository.Hash][]byte),
+			Trees:   make(map[repository.Hash][]ArchivedTreeEntry),
+			Blobs:   make(map[repository.Hash][]byte),
+		},
+	}
+}
+
+// Export write in w an archive of all the identities of the repository and
+// of the given bugs, or of all of them if bugIds is empty.
+// Draft bugs are not exported.
+func Export(repo repository.RepoData, w io.Writer, bugIds []entity.Id) (*Archive, error) {
+	a := newArchive()
+
+	identityRefs, err := repo.ListRefs(identityRefPattern)
+	if err != nil {
+		return nil, err
+	}
+	for _, ref := range identityRefs {
+		e, err := a.addEntity(repo, ref)
+		if err != nil {
+			return nil, errors.Wrapf(err, "can't export identity %s", ref)
+		}
+		a.Identities = append(a.Identities, e)
+	}
+
+	var bugRefs []string
+	if len(bugIds) == 0 {
+		bugRefs, err = repo.ListRefs(bugsRefPattern)
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, id := range bugIds {
+		bugRefs = append(bugRefs, bugsRefPattern+id.String())
+	}
+	for _, ref := range bugRefs {
+		e, err := a.addEntity(repo, ref)
+		if err != nil {
+			return nil, errors.Wrapf(err, "can't export bug %s", ref)
+		}
+		a.Bugs = append(a.Bugs, e)
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(a); err != nil {
+		return nil, err
+	}
+
+	return a, nil
+}
+
+func (a *Archive) addEntity(repo repository.RepoData, ref string) (ArchivedEntity, error) {
+	refSplit := strings.Split(ref, "/")
+	id := entity.Id(refSplit[len(refSplit)-1])
+
+	if err := id.Validate(); err != nil {
+		return ArchivedEntity{}, errors.Wrap(err, "invalid ref")
+	}
+
+	commits, err := repo.ListCommits(ref)
+	if err != nil {
+		return ArchivedEntity{}, err
+	}
+
+	for _, hash := range commits {
+		if _, ok := a.Objects.Commits[hash]; ok {
+			continue
+		}
+
+		raw, err := repo.ReadRawCommit(hash)
+		if err != nil {
+			return ArchivedEntity{}, err
+		}
+		a.Objects.Commits[hash] = raw
+
+		treeHash, err := repo.GetTreeHash(hash)
+		if err != nil {
+			return ArchivedEntity{}, err
+		}
+
+		if err := a.addTree(repo, treeHash); err != nil {
+			return ArchivedEntity{}, err
+		}
+	}
+
+	return ArchivedEntity{Id: id, Commits: commits}, nil
+}
+
+func (a *Archive) addTree(repo repository.RepoData, hash repository.Hash) error {
+	if _, ok := a.Objects.Trees[hash]; ok {
+		return nil
+	}
+
+	entries, err := repo.ReadTree(hash)
+	if err != nil {
+		return err
+	}
+
+	archived := make([]ArchivedTreeEntry, len(entries))
+
+	for i, entry := range entries {
+		archived[i] = ArchivedTreeEntry{Hash: entry.Hash, Name: entry.Name}
+
+		switch entry.ObjectType {
+		case repository.Blob:
+			archived[i].Type = "blob"
+			if _, ok := a.Objects.Blobs[entry.Hash]; ok {
+				continue
+			}
+			data, err := repo.ReadData(entry.Hash)
+			if err != nil {
+				return err
+			}
+			a.Objects.Blobs[entry.Hash] = data
+
+		case repository.Tree:
+			archived[i].Type = "tree"
+			if err := a.addTree(repo, entry.Hash); err != nil {
+				return err
+			}
+
+		default:
+			return fmt.Errorf("unknown object type for %s", entry.Name)
+		}
+	}
+
+	a.Objects.Trees[hash] = archived
+
+	return nil
+}
+
+// Import read an archive from r and store its objects in the repository.
+// The entities are made available under the refs of the given remote, ready
+// to be merged like after a fetch.
+func Import(repo repository.RepoData, r io.Reader, remote string) (*Archive, error) {
+	var a Archive
+
+	if err := json.NewDecoder(r).Decode(&a); err != nil {
+		return nil, errors.Wrap(err, "can't read the archive")
+	}
+
+	if a.Version != Version {
+		return nil, fmt.Errorf("unsupported archive version %d, expected %d", a.Version, Version)
+	}
+
+	for hash, data := range a.Objects.Blobs {
+		stored, err := repo.StoreData(data)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkHash(hash, stored); err != nil {
+			return nil, err
+		}
+	}
+
+	storedTrees := make(map[repository.Hash]bool)
+	for hash := range a.Objects.Trees {
+		if err := a.storeTree(repo, hash, storedTrees); err != nil {
+			return nil, err
+		}
+	}
+
+	importEntities := func(entities []ArchivedEntity, refPattern string) error {
+		for _, e := range entities {
+			if err := e.Id.Validate(); err != nil {
+				return errors.Wrap(err, "invalid entity id")
+			}
+			if len(e.Commits) == 0 {
+				return fmt.Errorf("entity %s has no commit", e.Id)
+			}
+
+			// parents first
+			for _, hash := range e.Commits {
+				raw, ok := a.Objects.Commits[hash]
+				if !ok {
+					return fmt.Errorf("commit %s is missing from the archive", hash)
+				}
+				stored, err := repo.StoreRawCommit(raw)
+				if err != nil {
+					return err
+				}
+				if err := checkHash(hash, stored); err != nil {
+					return err
+				}
+			}
+
+			err := repo.UpdateRef(fmt.Sprintf(refPattern, remote)+e.Id.String(), e.Head())
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if err := importEntities(a.Identities, identityRemoteRefPattern); err != nil {
+		return nil, err
+	}
+	if err := importEntities(a.Bugs, bugsRemoteRefPattern); err != nil {
+		return nil, err
+	}
+
+	return &a, nil
+}
+
+// storeTree store a tree after its subtrees, as git refuse trees pointing to
+// missing objects
+func (a *Archive) storeTree(repo repository.RepoData, hash repository.Hash, stored map[repository.Hash]bool) error {
+	if stored[hash] {
+		return nil
+	}
+
+	archived, ok := a.Objects.Trees[hash]
+	if !ok {
+		return fmt.Errorf("tree %s is missing from the archive", hash)
+	}
+
+	entries := make([]repository.TreeEntry, len(archived))
+
+	for i, entry := range archived {
+		entries[i] = repository.TreeEntry{Hash: entry.Hash, Name: entry.Name}
+
+		switch entry.Type {
+		case "blob":
+			if _, ok := a.Objects.Blobs[entry.Hash]; !ok {
+				return fmt.Errorf("blob %s is missing from the archive", entry.Hash)
+			}
+			entries[i].ObjectType = repository.Blob
+		case "tree":
+			if err := a.storeTree(repo, entry.Hash, stored); err != nil {
+				return err
+			}
+			entries[i].ObjectType = repository.Tree
+		default:
+			return fmt.Errorf("unknown object type %s for %s", entry.Type, entry.Name)
+		}
+	}
+
+	result, err := repo.StoreTree(entries)
+	if err != nil {
+		return err
+	}
+	if err := checkHash(hash, result); err != nil {
+		return err
+	}
+
+	stored[hash] = true
+
+	return nil
+}
+
+func checkHash(expected repository.Hash, actual repository.Hash) error {
+	if expected != actual {
+		return fmt.Errorf("corrupted archive: object %s stored as %s", expected, actual)
+	}
+	return nil
+}
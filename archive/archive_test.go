@@ -0,0 +1,75 @@
+package archive
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/identity"
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+func TestExportImport(t *testing.T) {
+	repoA := repository.NewMockRepoForTest()
+	repoB := repository.NewMockRepoForTest()
+
+	rene := identity.NewIdentity("René Descartes", "rene@descartes.fr")
+	err := rene.Commit(repoA)
+	require.NoError(t, err)
+
+	bug1, _, err := bug.Create(rene, time.Now().Unix(), "bug1", "message")
+	require.NoError(t, err)
+	_, err = bug.AddComment(bug1, rene, time.Now().Unix(), "comment")
+	require.NoError(t, err)
+	require.NoError(t, bug1.Commit(repoA))
+
+	bug2, _, err := bug.Create(rene, time.Now().Unix(), "bug2", "message")
+	require.NoError(t, err)
+	require.NoError(t, bug2.Commit(repoA))
+
+	var buf bytes.Buffer
+	a, err := Export(repoA, &buf, []entity.Id{bug1.Id()})
+	require.NoError(t, err)
+	require.Len(t, a.Identities, 1)
+	require.Len(t, a.Bugs, 1)
+	require.Equal(t, bug1.Id(), a.Bugs[0].Id)
+
+	a, err = Import(repoB, &buf, "archive")
+	require.NoError(t, err)
+	require.Len(t, a.Bugs, 1)
+
+	for result := range identity.MergeAll(repoB, "archive") {
+		require.NoError(t, result.Err)
+		require.Equal(t, entity.MergeStatusNew, result.Status)
+	}
+	for result := range bug.MergeAll(repoB, "archive") {
+		require.NoError(t, result.Err)
+		require.Equal(t, entity.MergeStatusNew, result.Status)
+	}
+
+	imported, err := bug.ReadLocal(repoB, bug1.Id())
+	require.NoError(t, err)
+	require.Equal(t, bug1.Id(), imported.Id())
+
+	// the operations keep their ids
+	snapA := bug1.Compile()
+	snapB := imported.Compile()
+	require.Len(t, snapB.Operations, len(snapA.Operations))
+	for i := range snapA.Operations {
+		require.Equal(t, snapA.Operations[i].Id(), snapB.Operations[i].Id())
+	}
+
+	_, err = bug.ReadLocal(repoB, bug2.Id())
+	require.Error(t, err)
+}
+
+func TestImportVersion(t *testing.T) {
+	repo := repository.NewMockRepoForTest()
+
+	_, err := Import(repo, bytes.NewBufferString(`{"version": 42}`), "archive")
+	require.Error(t, err)
+}
@@ -0,0 +1,390 @@
+// Package board contains the kanban board data model and low-level related functions
+package board
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/identity"
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+const boardsRefPattern = "refs/boards/"
+const boardsRemoteRefPattern = "refs/remotes/%s/boards/"
+
+const opsEntryName = "ops"
+
+var ErrBoardNotExist = errors.New("board doesn't exist")
+
+func NewErrMultipleMatchBoard(matching []entity.Id) *entity.ErrMultipleMatch {
+	return entity.NewErrMultipleMatch("board", matching)
+}
+
+var _ entity.Interface = &Board{}
+
+// Board hold the data of a kanban board: an ordered list of columns, each
+// holding an ordered list of bugs.
+// Like a bug, a board is a chain of OperationPack stored as git commits.
+type Board struct {
+	// Id used as unique identifier
+	id entity.Id
+
+	lastCommit repository.Hash
+
+	// all the committed operations
+	packs []OperationPack
+
+	// a temporary pack of operations used for convenience to pile up new operations
+	// before a commit
+	staging OperationPack
+}
+
+// NewBoard create a new Board
+func NewBoard() *Board {
+	// No id yet
+	return &Board{}
+}
+
+// ReadLocal will read a local board from its hash
+func ReadLocal(repo repository.Repo, identityResolver identity.Resolver, id entity.Id) (*Board, error) {
+	ref := boardsRefPattern + id.String()
+	return read(repo, identityResolver, ref)
+}
+
+// ReadRemote will read a remote board from its hash
+func ReadRemote(repo repository.Repo, identityResolver identity.Resolver, remote string, id entity.Id) (*Board, error) {
+	ref := fmt.Sprintf(boardsRemoteRefPattern, remote) + id.String()
+	return read(repo, identityResolver, ref)
+}
+
+// read will read and parse a Board from git
+func read(repo repository.Repo, identityResolver identity.Resolver, ref string) (*Board, error) {
+	refSplit := strings.Split(ref, "/")
+	id := entity.Id(refSplit[len(refSplit)-1])
+
+	if err := id.Validate(); err != nil {
+		return nil, errors.Wrap(err, "invalid ref ")
+	}
+
+	hashes, err := repo.ListCommits(ref)
+	if err != nil {
+		return nil, ErrBoardNotExist
+	}
+
+	board := Board{id: id}
+
+	for _, hash := range hashes {
+		entries, err := repo.ReadTree(hash)
+		if err != nil {
+			return nil, errors.Wrap(err, "can't list git tree entries")
+		}
+
+		board.lastCommit = hash
+
+		var opsEntry repository.TreeEntry
+		opsFound := false
+		for _, entry := range entries {
+			if entry.Name == opsEntryName {
+				opsEntry = entry
+				opsFound = true
+			}
+		}
+		if !opsFound {
+			return nil, errors.New("invalid tree, missing the ops entry")
+		}
+
+		data, err := repo.ReadData(opsEntry.Hash)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read git blob data")
+		}
+
+		opp := &OperationPack{}
+		err = json.Unmarshal(data, &opp)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to decode OperationPack json")
+		}
+
+		// tag the pack with the commit hash
+		opp.commitHash = hash
+
+		board.packs = append(board.packs, *opp)
+	}
+
+	// Make sure that the identities are properly loaded
+	for _, pack := range board.packs {
+		for _, op := range pack.Operations {
+			base := op.base()
+			if stub, ok := base.Author.(*identity.IdentityStub); ok {
+				i, err := identityResolver.ResolveIdentity(stub.Id())
+				if err != nil {
+					return nil, err
+				}
+				base.Author = i
+			}
+		}
+	}
+
+	return &board, nil
+}
+
+// ListLocalIds list all the available local board ids
+func ListLocalIds(repo repository.Repo) ([]entity.Id, error) {
+	refs, err := repo.ListRefs(boardsRefPattern)
+	if err != nil {
+		return nil, err
+	}
+
+	return refsToIds(refs), nil
+}
+
+func refsToIds(refs []string) []entity.Id {
+	ids := make([]entity.Id, len(refs))
+
+	for i, ref := range refs {
+		split := strings.Split(ref, "/")
+		ids[i] = entity.Id(split[len(split)-1])
+	}
+
+	return ids
+}
+
+// Validate check if the Board data is valid
+func (board *Board) Validate() error {
+	// non-empty
+	if len(board.packs) == 0 && board.staging.IsEmpty() {
+		return fmt.Errorf("board has no operations")
+	}
+
+	// check if each pack and operations are valid
+	for _, pack := range board.packs {
+		if err := pack.Validate(); err != nil {
+			return err
+		}
+	}
+
+	// check if staging is valid if needed
+	if !board.staging.IsEmpty() {
+		if err := board.staging.Validate(); err != nil {
+			return errors.Wrap(err, "staging")
+		}
+	}
+
+	// The very first Op should be a CreateOp
+	firstOp := board.FirstOp()
+	if firstOp == nil || firstOp.base().OperationType != CreateOp {
+		return fmt.Errorf("first operation should be a Create op")
+	}
+
+	// Check that there is no more CreateOp op
+	createCount := 0
+	for _, op := range board.operations() {
+		if op.base().OperationType == CreateOp {
+			createCount++
+		}
+	}
+	if createCount != 1 {
+		return fmt.Errorf("only one Create op allowed")
+	}
+
+	return nil
+}
+
+// Append an operation into the staging area, to be committed later
+func (board *Board) Append(op Operation) {
+	board.staging.Append(op)
+}
+
+// NeedCommit indicate if the in-memory state changed and need to be commit in the repository
+func (board *Board) NeedCommit() bool {
+	return !board.staging.IsEmpty()
+}
+
+// Commit write the staging area in Git and move the operations to the packs
+func (board *Board) Commit(repo repository.Repo) error {
+	if !board.NeedCommit() {
+		return fmt.Errorf("can't commit a board with no pending operation")
+	}
+
+	if err := board.Validate(); err != nil {
+		return errors.Wrap(err, "can't commit a board with invalid data")
+	}
+
+	// Write the Ops as a Git blob containing the serialized array
+	hash, err := board.staging.Write(repo)
+	if err != nil {
+		return err
+	}
+
+	// Make a Git tree referencing this blob
+	hash, err = repo.StoreTree([]repository.TreeEntry{
+		{ObjectType: repository.Blob, Hash: hash, Name: opsEntryName},
+	})
+	if err != nil {
+		return err
+	}
+
+	// Write a Git commit referencing the tree, with the previous commit as parent
+	if board.lastCommit != "" {
+		hash, err = repo.StoreCommitWithParent(hash, board.lastCommit)
+	} else {
+		hash, err = repo.StoreCommit(hash)
+	}
+	if err != nil {
+		return err
+	}
+
+	board.lastCommit = hash
+
+	// if it was the first commit, use the commit hash as board id
+	if board.id == "" {
+		board.id = entity.Id(hash)
+	}
+
+	// Create or update the Git reference for this board
+	err = repo.UpdateRef(boardsRefPattern+board.id.String(), hash)
+	if err != nil {
+		return err
+	}
+
+	board.staging.commitHash = hash
+	board.packs = append(board.packs, board.staging)
+	board.staging = OperationPack{}
+
+	return nil
+}
+
+// Merge a different version of the same board by rebasing operations of this
+// board that are not present in the other on top of the chain of operations
+// of the other version.
+func (board *Board) Merge(repo repository.Repo, other *Board) (bool, error) {
+	if board.id != other.id {
+		return false, errors.New("merging unrelated boards is not supported")
+	}
+
+	if len(other.staging.Operations) > 0 {
+		return false, errors.New("merging a board with a non-empty staging is not supported")
+	}
+
+	if board.lastCommit == "" || other.lastCommit == "" {
+		return false, errors.New("can't merge a board that has never been stored")
+	}
+
+	ancestor, err := repo.FindCommonAncestor(board.lastCommit, other.lastCommit)
+	if err != nil {
+		return false, errors.Wrap(err, "can't find common ancestor")
+	}
+
+	ancestorIndex := 0
+	newPacks := make([]OperationPack, 0, len(board.packs))
+
+	// Find the root of the rebase
+	for i, pack := range board.packs {
+		newPacks = append(newPacks, pack)
+
+		if pack.commitHash == ancestor {
+			ancestorIndex = i
+			break
+		}
+	}
+
+	if len(other.packs) == ancestorIndex+1 {
+		// Nothing to rebase, return early
+		return false, nil
+	}
+
+	// get other board's extra packs
+	for i := ancestorIndex + 1; i < len(other.packs); i++ {
+		newPack := other.packs[i].Clone()
+
+		newPacks = append(newPacks, newPack)
+		board.lastCommit = newPack.commitHash
+	}
+
+	// rebase our extra packs
+	for i := ancestorIndex + 1; i < len(board.packs); i++ {
+		pack := board.packs[i]
+
+		// get the referenced git tree
+		treeHash, err := repo.GetTreeHash(pack.commitHash)
+		if err != nil {
+			return false, err
+		}
+
+		// create a new commit with the correct ancestor
+		hash, err := repo.StoreCommitWithParent(treeHash, board.lastCommit)
+		if err != nil {
+			return false, err
+		}
+
+		// replace the pack
+		newPack := pack.Clone()
+		newPack.commitHash = hash
+		newPacks = append(newPacks, newPack)
+
+		// update the board
+		board.lastCommit = hash
+	}
+
+	board.packs = newPacks
+
+	// Update the git ref
+	err = repo.UpdateRef(boardsRefPattern+board.id.String(), board.lastCommit)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// Id return the Board identifier
+func (board *Board) Id() entity.Id {
+	if board.id == "" {
+		// simply panic as it would be a coding error
+		// (using an id of a board not stored yet)
+		panic("no id yet")
+	}
+	return board.id
+}
+
+// operations return all the operations, committed or not, in order
+func (board *Board) operations() []Operation {
+	var result []Operation
+	for _, pack := range board.packs {
+		result = append(result, pack.Operations...)
+	}
+	return append(result, board.staging.Operations...)
+}
+
+// FirstOp lookup for the very first operation of the board.
+// For a valid Board, this operation should be a CreateOp
+func (board *Board) FirstOp() Operation {
+	for _, pack := range board.packs {
+		for _, op := range pack.Operations {
+			return op
+		}
+	}
+
+	if !board.staging.IsEmpty() {
+		return board.staging.Operations[0]
+	}
+
+	return nil
+}
+
+// Compile a board in a easily usable snapshot
+func (board *Board) Compile() Snapshot {
+	snap := Snapshot{
+		id: board.id,
+	}
+
+	for _, op := range board.operations() {
+		op.Apply(&snap)
+		snap.Operations = append(snap.Operations, op)
+	}
+
+	return snap
+}
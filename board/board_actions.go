@@ -0,0 +1,127 @@
+package board
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/identity"
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+// Fetch retrieve updates from a remote
+// This does not change the local boards state
+func Fetch(repo repository.Repo, remote string) (string, error) {
+	// "refs/boards/*:refs/remotes/<remote>>/boards/*"
+	remoteRefSpec := fmt.Sprintf(boardsRemoteRefPattern, remote)
+	fetchRefSpec := fmt.Sprintf("%s*:%s*", boardsRefPattern, remoteRefSpec)
+
+	return repo.FetchRefs(remote, fetchRefSpec)
+}
+
+// Push update a remote with the local changes
+func Push(repo repository.Repo, remote string) (string, error) {
+	// "refs/boards/*:refs/boards/*"
+	refspec := fmt.Sprintf("%s*:%s*", boardsRefPattern, boardsRefPattern)
+
+	return repo.PushRefs(remote, refspec)
+}
+
+// MergeAll will merge all the available remote boards:
+//
+//   - If the remote has new commit, the local board is updated to match the same history
+//     (fast-forward update)
+//   - if the local board has new commits but the remote don't, nothing is changed
+//   - if both local and remote board have new commits (that is, we have a concurrent edition),
+//     new local commits are rewritten at the head of the remote history (that is, a rebase)
+func MergeAll(repo repository.Repo, remote string) <-chan entity.MergeResult {
+	out := make(chan entity.MergeResult)
+
+	identityResolver := identity.NewSimpleResolver(repo)
+
+	go func() {
+		defer close(out)
+
+		remoteRefSpec := fmt.Sprintf(boardsRemoteRefPattern, remote)
+		remoteRefs, err := repo.ListRefs(remoteRefSpec)
+		if err != nil {
+			out <- entity.MergeResult{Err: err}
+			return
+		}
+
+		for _, remoteRef := range remoteRefs {
+			refSplit := strings.Split(remoteRef, "/")
+			id := entity.Id(refSplit[len(refSplit)-1])
+
+			if err := id.Validate(); err != nil {
+				out <- entity.NewMergeInvalidStatus(id, errors.Wrap(err, "invalid ref").Error())
+				continue
+			}
+
+			// the remote ref didn't move since the last merge, there is no
+			// need to read and check the board again
+			upToDate, err := repository.SameRefTip(repo, boardsRefPattern+id.String(), remoteRef)
+			if err != nil {
+				out <- entity.NewMergeError(err, id)
+				continue
+			}
+			if upToDate {
+				out <- entity.NewMergeStatus(entity.MergeStatusNothing, id, nil)
+				continue
+			}
+
+			remoteBoard, err := read(repo, identityResolver, remoteRef)
+			if err != nil {
+				out <- entity.NewMergeInvalidStatus(id, errors.Wrap(err, "remote board is not readable").Error())
+				continue
+			}
+
+			// Check for error in remote data
+			if err := remoteBoard.Validate(); err != nil {
+				out <- entity.NewMergeInvalidStatus(id, errors.Wrap(err, "remote board is invalid").Error())
+				continue
+			}
+
+			localRef := boardsRefPattern + remoteBoard.Id().String()
+			localExist, err := repo.RefExist(localRef)
+			if err != nil {
+				out <- entity.NewMergeError(err, id)
+				continue
+			}
+
+			// the board is not local yet, simply create the reference
+			if !localExist {
+				err := repo.CopyRef(remoteRef, localRef)
+				if err != nil {
+					out <- entity.NewMergeError(err, id)
+					return
+				}
+
+				out <- entity.NewMergeStatus(entity.MergeStatusNew, id, remoteBoard)
+				continue
+			}
+
+			localBoard, err := read(repo, identityResolver, localRef)
+			if err != nil {
+				out <- entity.NewMergeError(errors.Wrap(err, "local board is not readable"), id)
+				return
+			}
+
+			updated, err := localBoard.Merge(repo, remoteBoard)
+			if err != nil {
+				out <- entity.NewMergeInvalidStatus(id, errors.Wrap(err, "merge failed").Error())
+				return
+			}
+
+			if updated {
+				out <- entity.NewMergeStatus(entity.MergeStatusUpdated, id, localBoard)
+			} else {
+				out <- entity.NewMergeStatus(entity.MergeStatusNothing, id, localBoard)
+			}
+		}
+	}()
+
+	return out
+}
@@ -0,0 +1,140 @@
+package board
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/identity"
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+func TestBoardCommitLoad(t *testing.T) {
+	repo := repository.NewMockRepoForTest()
+
+	rene := identity.NewIdentity("René Descartes", "rene@descartes.fr")
+	err := rene.Commit(repo)
+	require.NoError(t, err)
+
+	unix := time.Now().Unix()
+
+	b, _, err := Create(rene, unix, "triage", []string{"todo", "doing", "done"})
+	require.NoError(t, err)
+
+	_, _, err = Create(rene, unix, "triage", nil)
+	require.Error(t, err)
+
+	bug1 := entity.Id("1111111111111111111111111111111111111111111111111111111111111111")
+	bug2 := entity.Id("2222222222222222222222222222222222222222222222222222222222222222")
+
+	_, err = MoveCard(b, rene, unix, bug1, "todo", -1)
+	require.NoError(t, err)
+	_, err = MoveCard(b, rene, unix, bug2, "todo", 0)
+	require.NoError(t, err)
+	_, err = MoveCard(b, rene, unix, bug1, "unknown", 0)
+	require.Error(t, err)
+
+	snap := b.Compile()
+	require.Equal(t, []entity.Id{bug2, bug1}, snap.Columns[0].Cards)
+
+	err = b.Commit(repo)
+	require.NoError(t, err)
+
+	_, err = MoveCard(b, rene, unix, bug2, "doing", -1)
+	require.NoError(t, err)
+	_, err = SetColumns(b, rene, unix, []string{"doing", "done"})
+	require.NoError(t, err)
+	_, err = SetTitle(b, rene, unix, "sprint")
+	require.NoError(t, err)
+
+	err = b.Commit(repo)
+	require.NoError(t, err)
+
+	read, err := ReadLocal(repo, identity.NewSimpleResolver(repo), b.Id())
+	require.NoError(t, err)
+
+	snap = read.Compile()
+	require.Equal(t, "sprint", snap.Title)
+	// bug1 was in the removed column
+	require.Equal(t, []Column{
+		{Name: "doing", Cards: []entity.Id{bug2}},
+		{Name: "done"},
+	}, snap.Columns)
+
+	_, err = RemoveCard(read, rene, unix, bug1)
+	require.Error(t, err)
+	_, err = RemoveCard(read, rene, unix, bug2)
+	require.NoError(t, err)
+	snap = read.Compile()
+	require.Empty(t, snap.Columns[0].Cards)
+
+	ids, err := ListLocalIds(repo)
+	require.NoError(t, err)
+	require.Equal(t, []entity.Id{b.Id()}, ids)
+}
+
+func TestBoardMerge(t *testing.T) {
+	repoA, repoB, remote := repository.SetupGoGitReposAndRemote()
+	defer repository.CleanupTestRepos(repoA, repoB, remote)
+
+	rene := identity.NewIdentity("René Descartes", "rene@descartes.fr")
+	err := rene.Commit(repoA)
+	require.NoError(t, err)
+	_, err = identity.Push(repoA, "origin")
+	require.NoError(t, err)
+	_, err = identity.Fetch(repoB, "origin")
+	require.NoError(t, err)
+	for result := range identity.MergeAll(repoB, "origin") {
+		require.NoError(t, result.Err)
+	}
+
+	unix := time.Now().Unix()
+	bug1 := entity.Id("1111111111111111111111111111111111111111111111111111111111111111")
+	bug2 := entity.Id("2222222222222222222222222222222222222222222222222222222222222222")
+
+	boardA, _, err := Create(rene, unix, "triage", []string{"todo", "done"})
+	require.NoError(t, err)
+	err = boardA.Commit(repoA)
+	require.NoError(t, err)
+
+	_, err = Push(repoA, "origin")
+	require.NoError(t, err)
+	_, err = Fetch(repoB, "origin")
+	require.NoError(t, err)
+	for result := range MergeAll(repoB, "origin") {
+		require.NoError(t, result.Err)
+		require.Equal(t, entity.MergeStatusNew, result.Status)
+	}
+
+	boardB, err := ReadLocal(repoB, identity.NewSimpleResolver(repoB), boardA.Id())
+	require.NoError(t, err)
+
+	// concurrent edition
+	_, err = MoveCard(boardA, rene, unix, bug1, "todo", -1)
+	require.NoError(t, err)
+	err = boardA.Commit(repoA)
+	require.NoError(t, err)
+
+	_, err = MoveCard(boardB, rene, unix, bug2, "done", -1)
+	require.NoError(t, err)
+	err = boardB.Commit(repoB)
+	require.NoError(t, err)
+
+	_, err = Push(repoA, "origin")
+	require.NoError(t, err)
+	_, err = Fetch(repoB, "origin")
+	require.NoError(t, err)
+	for result := range MergeAll(repoB, "origin") {
+		require.NoError(t, result.Err)
+		require.Equal(t, entity.MergeStatusUpdated, result.Status)
+	}
+
+	merged, err := ReadLocal(repoB, identity.NewSimpleResolver(repoB), boardA.Id())
+	require.NoError(t, err)
+
+	snap := merged.Compile()
+	require.Equal(t, []entity.Id{bug1}, snap.Columns[0].Cards)
+	require.Equal(t, []entity.Id{bug2}, snap.Columns[1].Cards)
+}
@@ -0,0 +1,133 @@
+package board
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/MichaelMure/git-bug/identity"
+	"github.com/MichaelMure/git-bug/util/text"
+)
+
+var _ Operation = &CreateOperation{}
+
+// CreateOperation define the initial creation of a board
+type CreateOperation struct {
+	OpBase
+	Title   string   `json:"title"`
+	Columns []string `json:"columns"`
+}
+
+func (op *CreateOperation) base() *OpBase {
+	return &op.OpBase
+}
+
+func (op *CreateOperation) Apply(snapshot *Snapshot) {
+	snapshot.Title = op.Title
+	snapshot.Author = op.Author
+	snapshot.CreateTime = op.Time()
+
+	snapshot.Columns = make([]Column, len(op.Columns))
+	for i, name := range op.Columns {
+		snapshot.Columns[i] = Column{Name: name}
+	}
+}
+
+func (op *CreateOperation) Validate() error {
+	if err := opBaseValidate(op, CreateOp); err != nil {
+		return err
+	}
+
+	if err := validateTitle(op.Title); err != nil {
+		return err
+	}
+
+	if len(op.Columns) == 0 {
+		return fmt.Errorf("a board need at least one column")
+	}
+
+	return validateColumns(op.Columns)
+}
+
+// UnmarshalJSON is a two step JSON unmarshaling
+// This workaround is necessary to avoid the inner OpBase.MarshalJSON
+// overriding the outer op's MarshalJSON
+func (op *CreateOperation) UnmarshalJSON(data []byte) error {
+	// Unmarshal OpBase and the op separately
+
+	base := OpBase{}
+	err := json.Unmarshal(data, &base)
+	if err != nil {
+		return err
+	}
+
+	aux := struct {
+		Title   string   `json:"title"`
+		Columns []string `json:"columns"`
+	}{}
+
+	err = json.Unmarshal(data, &aux)
+	if err != nil {
+		return err
+	}
+
+	op.OpBase = base
+	op.Title = aux.Title
+	op.Columns = aux.Columns
+
+	return nil
+}
+
+func NewCreateOp(author identity.Interface, unixTime int64, title string, columns []string) *CreateOperation {
+	return &CreateOperation{
+		OpBase:  newOpBase(CreateOp, author, unixTime),
+		Title:   title,
+		Columns: columns,
+	}
+}
+
+// Convenience function to create a new board
+func Create(author identity.Interface, unixTime int64, title string, columns []string) (*Board, *CreateOperation, error) {
+	newBoard := NewBoard()
+	createOp := NewCreateOp(author, unixTime, title, columns)
+
+	if err := createOp.Validate(); err != nil {
+		return nil, createOp, err
+	}
+
+	newBoard.Append(createOp)
+
+	return newBoard, createOp, nil
+}
+
+func validateTitle(title string) error {
+	if text.Empty(title) {
+		return fmt.Errorf("title is empty")
+	}
+
+	if strings.Contains(title, "\n") {
+		return fmt.Errorf("title should be a single line")
+	}
+
+	if !text.Safe(title) {
+		return fmt.Errorf("title should be fully printable")
+	}
+
+	return nil
+}
+
+func validateColumnName(name string) error {
+	if text.Empty(name) {
+		return fmt.Errorf("column name is empty")
+	}
+
+	if strings.Contains(name, "\n") {
+		return fmt.Errorf("column name should be a single line")
+	}
+
+	if !text.Safe(name) {
+		return fmt.Errorf("column name should be fully printable")
+	}
+
+	return nil
+}
@@ -0,0 +1,113 @@
+package board
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/identity"
+)
+
+var _ Operation = &MoveCardOperation{}
+
+// MoveCardOperation will put a bug in a column of the board at a given
+// position, adding it to the board if it wasn't already.
+type MoveCardOperation struct {
+	OpBase
+	Bug    entity.Id `json:"bug"`
+	Column string    `json:"column"`
+	// Position is the index in the column, -1 meaning the end of the column
+	Position int `json:"position"`
+}
+
+func (op *MoveCardOperation) base() *OpBase {
+	return &op.OpBase
+}
+
+func (op *MoveCardOperation) Apply(snapshot *Snapshot) {
+	column := snapshot.SearchColumn(op.Column)
+
+	// the column might have been removed concurrently, in which case the
+	// card simply stay where it was
+	if column < 0 {
+		return
+	}
+
+	snapshot.removeCard(op.Bug)
+	snapshot.insertCard(column, op.Position, op.Bug)
+}
+
+func (op *MoveCardOperation) Validate() error {
+	if err := opBaseValidate(op, MoveCardOp); err != nil {
+		return err
+	}
+
+	if err := op.Bug.Validate(); err != nil {
+		return fmt.Errorf("invalid bug id: %v", err)
+	}
+
+	if err := validateColumnName(op.Column); err != nil {
+		return err
+	}
+
+	if op.Position < -1 {
+		return fmt.Errorf("invalid position %d", op.Position)
+	}
+
+	return nil
+}
+
+// UnmarshalJSON is a two step JSON unmarshaling
+// This workaround is necessary to avoid the inner OpBase.MarshalJSON
+// overriding the outer op's MarshalJSON
+func (op *MoveCardOperation) UnmarshalJSON(data []byte) error {
+	// Unmarshal OpBase and the op separately
+
+	base := OpBase{}
+	err := json.Unmarshal(data, &base)
+	if err != nil {
+		return err
+	}
+
+	aux := struct {
+		Bug      entity.Id `json:"bug"`
+		Column   string    `json:"column"`
+		Position int       `json:"position"`
+	}{}
+
+	err = json.Unmarshal(data, &aux)
+	if err != nil {
+		return err
+	}
+
+	op.OpBase = base
+	op.Bug = aux.Bug
+	op.Column = aux.Column
+	op.Position = aux.Position
+
+	return nil
+}
+
+func NewMoveCardOp(author identity.Interface, unixTime int64, bugId entity.Id, column string, position int) *MoveCardOperation {
+	return &MoveCardOperation{
+		OpBase:   newOpBase(MoveCardOp, author, unixTime),
+		Bug:      bugId,
+		Column:   column,
+		Position: position,
+	}
+}
+
+// Convenience function to apply the operation
+func MoveCard(b *Board, author identity.Interface, unixTime int64, bugId entity.Id, column string, position int) (*MoveCardOperation, error) {
+	snap := b.Compile()
+	if snap.SearchColumn(column) < 0 {
+		return nil, fmt.Errorf("unknown column %s", column)
+	}
+
+	op := NewMoveCardOp(author, unixTime, bugId, column, position)
+	if err := op.Validate(); err != nil {
+		return nil, err
+	}
+	b.Append(op)
+	return op, nil
+}
@@ -0,0 +1,86 @@
+package board
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/identity"
+)
+
+var _ Operation = &RemoveCardOperation{}
+
+// RemoveCardOperation will take a bug out of the board
+type RemoveCardOperation struct {
+	OpBase
+	Bug entity.Id `json:"bug"`
+}
+
+func (op *RemoveCardOperation) base() *OpBase {
+	return &op.OpBase
+}
+
+func (op *RemoveCardOperation) Apply(snapshot *Snapshot) {
+	snapshot.removeCard(op.Bug)
+}
+
+func (op *RemoveCardOperation) Validate() error {
+	if err := opBaseValidate(op, RemoveCardOp); err != nil {
+		return err
+	}
+
+	if err := op.Bug.Validate(); err != nil {
+		return fmt.Errorf("invalid bug id: %v", err)
+	}
+
+	return nil
+}
+
+// UnmarshalJSON is a two step JSON unmarshaling
+// This workaround is necessary to avoid the inner OpBase.MarshalJSON
+// overriding the outer op's MarshalJSON
+func (op *RemoveCardOperation) UnmarshalJSON(data []byte) error {
+	// Unmarshal OpBase and the op separately
+
+	base := OpBase{}
+	err := json.Unmarshal(data, &base)
+	if err != nil {
+		return err
+	}
+
+	aux := struct {
+		Bug entity.Id `json:"bug"`
+	}{}
+
+	err = json.Unmarshal(data, &aux)
+	if err != nil {
+		return err
+	}
+
+	op.OpBase = base
+	op.Bug = aux.Bug
+
+	return nil
+}
+
+func NewRemoveCardOp(author identity.Interface, unixTime int64, bugId entity.Id) *RemoveCardOperation {
+	return &RemoveCardOperation{
+		OpBase: newOpBase(RemoveCardOp, author, unixTime),
+		Bug:    bugId,
+	}
+}
+
+// Convenience function to apply the operation
+func RemoveCard(b *Board, author identity.Interface, unixTime int64, bugId entity.Id) (*RemoveCardOperation, error) {
+	snap := b.Compile()
+	if _, _, found := snap.SearchCard(bugId); !found {
+		return nil, fmt.Errorf("bug %s is not on the board", bugId.Human())
+	}
+
+	op := NewRemoveCardOp(author, unixTime, bugId)
+	if err := op.Validate(); err != nil {
+		return nil, err
+	}
+	b.Append(op)
+	return op, nil
+}
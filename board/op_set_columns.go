@@ -0,0 +1,90 @@
+package board
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/MichaelMure/git-bug/identity"
+)
+
+var _ Operation = &SetColumnsOperation{}
+
+// SetColumnsOperation will replace the list of columns of a board. Cards of
+// a column that still exist are kept, cards of a removed column are dropped.
+type SetColumnsOperation struct {
+	OpBase
+	Columns []string `json:"columns"`
+}
+
+func (op *SetColumnsOperation) base() *OpBase {
+	return &op.OpBase
+}
+
+func (op *SetColumnsOperation) Apply(snapshot *Snapshot) {
+	columns := make([]Column, len(op.Columns))
+
+	for i, name := range op.Columns {
+		columns[i] = Column{Name: name}
+		if previous := snapshot.SearchColumn(name); previous >= 0 {
+			columns[i].Cards = snapshot.Columns[previous].Cards
+		}
+	}
+
+	snapshot.Columns = columns
+}
+
+func (op *SetColumnsOperation) Validate() error {
+	if err := opBaseValidate(op, SetColumnsOp); err != nil {
+		return err
+	}
+
+	if len(op.Columns) == 0 {
+		return fmt.Errorf("a board need at least one column")
+	}
+
+	return validateColumns(op.Columns)
+}
+
+// UnmarshalJSON is a two step JSON unmarshaling
+// This workaround is necessary to avoid the inner OpBase.MarshalJSON
+// overriding the outer op's MarshalJSON
+func (op *SetColumnsOperation) UnmarshalJSON(data []byte) error {
+	// Unmarshal OpBase and the op separately
+
+	base := OpBase{}
+	err := json.Unmarshal(data, &base)
+	if err != nil {
+		return err
+	}
+
+	aux := struct {
+		Columns []string `json:"columns"`
+	}{}
+
+	err = json.Unmarshal(data, &aux)
+	if err != nil {
+		return err
+	}
+
+	op.OpBase = base
+	op.Columns = aux.Columns
+
+	return nil
+}
+
+func NewSetColumnsOp(author identity.Interface, unixTime int64, columns []string) *SetColumnsOperation {
+	return &SetColumnsOperation{
+		OpBase:  newOpBase(SetColumnsOp, author, unixTime),
+		Columns: columns,
+	}
+}
+
+// Convenience function to apply the operation
+func SetColumns(b *Board, author identity.Interface, unixTime int64, columns []string) (*SetColumnsOperation, error) {
+	op := NewSetColumnsOp(author, unixTime, columns)
+	if err := op.Validate(); err != nil {
+		return nil, err
+	}
+	b.Append(op)
+	return op, nil
+}
@@ -0,0 +1,112 @@
+package board
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/MichaelMure/git-bug/identity"
+)
+
+// OperationType is an operation type identifier
+type OperationType int
+
+const (
+	_ OperationType = iota
+	CreateOp
+	SetTitleOp
+	SetColumnsOp
+	MoveCardOp
+	RemoveCardOp
+)
+
+// Operation define the interface to fulfill for an edit operation of a Board
+type Operation interface {
+	// base return the OpBase of the Operation, for package internal use
+	base() *OpBase
+	// Time return the time when the operation was added
+	Time() time.Time
+	// Apply the operation to a Snapshot to create the final state
+	Apply(snapshot *Snapshot)
+	// Validate check if the operation is valid
+	Validate() error
+	// GetAuthor return the author identity
+	GetAuthor() identity.Interface
+}
+
+// OpBase implement the common code for all operations
+type OpBase struct {
+	OperationType OperationType      `json:"type"`
+	Author        identity.Interface `json:"author"`
+	UnixTime      int64              `json:"timestamp"`
+}
+
+// newOpBase is the constructor for an OpBase
+func newOpBase(opType OperationType, author identity.Interface, unixTime int64) OpBase {
+	return OpBase{
+		OperationType: opType,
+		Author:        author,
+		UnixTime:      unixTime,
+	}
+}
+
+func (op *OpBase) UnmarshalJSON(data []byte) error {
+	aux := struct {
+		OperationType OperationType   `json:"type"`
+		Author        json.RawMessage `json:"author"`
+		UnixTime      int64           `json:"timestamp"`
+	}{}
+
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	// delegate the decoding of the identity
+	author, err := identity.UnmarshalJSON(aux.Author)
+	if err != nil {
+		return err
+	}
+
+	op.OperationType = aux.OperationType
+	op.Author = author
+	op.UnixTime = aux.UnixTime
+
+	return nil
+}
+
+func (op *OpBase) base() *OpBase {
+	return op
+}
+
+// Time return the time when the operation was added
+func (op *OpBase) Time() time.Time {
+	return time.Unix(op.UnixTime, 0)
+}
+
+// GetAuthor return author identity
+func (op *OpBase) GetAuthor() identity.Interface {
+	return op.Author
+}
+
+// Validate check the OpBase for errors
+func opBaseValidate(op Operation, opType OperationType) error {
+	if op.base().OperationType != opType {
+		return fmt.Errorf("incorrect operation type (expected: %v, actual: %v)", opType, op.base().OperationType)
+	}
+
+	if op.Time().Unix() == 0 {
+		return fmt.Errorf("time not set")
+	}
+
+	if op.base().Author == nil {
+		return fmt.Errorf("author not set")
+	}
+
+	if err := op.base().Author.Validate(); err != nil {
+		return errors.Wrap(err, "author")
+	}
+
+	return nil
+}
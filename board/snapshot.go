@@ -0,0 +1,107 @@
+package board
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/identity"
+)
+
+// Column is a named list of bugs, in order
+type Column struct {
+	Name  string
+	Cards []entity.Id
+}
+
+// Snapshot is a compiled form of the Board data structure used for storage and merge
+type Snapshot struct {
+	id entity.Id
+
+	Title      string
+	Columns    []Column
+	Author     identity.Interface
+	CreateTime time.Time
+
+	Operations []Operation
+}
+
+// Return the Board identifier
+func (snap *Snapshot) Id() entity.Id {
+	return snap.id
+}
+
+// EditTime return the last time the board was modified
+func (snap *Snapshot) EditTime() time.Time {
+	if len(snap.Operations) == 0 {
+		return time.Unix(0, 0)
+	}
+
+	return snap.Operations[len(snap.Operations)-1].Time()
+}
+
+// SearchColumn return the index of the column with the given name, or -1
+func (snap *Snapshot) SearchColumn(name string) int {
+	for i, column := range snap.Columns {
+		if column.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// SearchCard return the position of a bug on the board, if present
+func (snap *Snapshot) SearchCard(bugId entity.Id) (column int, position int, found bool) {
+	for i, c := range snap.Columns {
+		for j, card := range c.Cards {
+			if card == bugId {
+				return i, j, true
+			}
+		}
+	}
+	return -1, -1, false
+}
+
+// removeCard take a card out of the board, if present
+func (snap *Snapshot) removeCard(bugId entity.Id) {
+	column, position, found := snap.SearchCard(bugId)
+	if !found {
+		return
+	}
+
+	cards := snap.Columns[column].Cards
+	snap.Columns[column].Cards = append(cards[:position:position], cards[position+1:]...)
+}
+
+// insertCard put a card in a column at the given position, clamped to the
+// bounds of the column
+func (snap *Snapshot) insertCard(column int, position int, bugId entity.Id) {
+	cards := snap.Columns[column].Cards
+
+	if position < 0 || position > len(cards) {
+		position = len(cards)
+	}
+
+	result := make([]entity.Id, 0, len(cards)+1)
+	result = append(result, cards[:position]...)
+	result = append(result, bugId)
+	result = append(result, cards[position:]...)
+
+	snap.Columns[column].Cards = result
+}
+
+func validateColumns(columns []string) error {
+	seen := make(map[string]struct{})
+
+	for _, column := range columns {
+		if err := validateColumnName(column); err != nil {
+			return err
+		}
+		if _, ok := seen[column]; ok {
+			return fmt.Errorf("duplicated column %s", column)
+		}
+		seen[column] = struct{}{}
+	}
+
+	return nil
+}
@@ -30,6 +30,13 @@ func LoginMetaKey(target string) (string, error) {
 	return core.LoginMetaKey(target)
 }
 
+// RemoteBug return the target of the bridge that imported or exported a bug,
+// along with the id and the web URL of the bug in the remote bug-tracker,
+// from the metadata of the bug creation.
+func RemoteBug(metadata map[string]string) (target string, id string, url string, ok bool) {
+	return core.RemoteBug(metadata)
+}
+
 // Instantiate a new Bridge for a repo, from the given target and name
 func NewBridge(repo *cache.RepoCache, target string, name string) (*core.Bridge, error) {
 	return core.NewBridge(repo, target, name)
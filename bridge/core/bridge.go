@@ -20,6 +20,24 @@ import (
 var ErrImportNotSupported = errors.New("import is not supported")
 var ErrExportNotSupported = errors.New("export is not supported")
 
+// ErrAuthentication is returned when a bridge can't authenticate with the
+// remote bug tracker, for instance for a lack of credential
+type ErrAuthentication struct {
+	Err error
+}
+
+func NewErrAuthentication(err error) *ErrAuthentication {
+	return &ErrAuthentication{Err: err}
+}
+
+func (e ErrAuthentication) Error() string {
+	return fmt.Sprintf("authentication failed: %v", e.Err)
+}
+
+func (e ErrAuthentication) Unwrap() error {
+	return e.Err
+}
+
 const (
 	ConfigKeyTarget = "target"
 
@@ -87,6 +105,19 @@ func LoginMetaKey(target string) (string, error) {
 	return metaKey, nil
 }
 
+// RemoteBug return the target of the bridge that imported or exported a bug,
+// along with the id and the web URL of the bug in the remote bug-tracker,
+// from the metadata of the bug creation.
+func RemoteBug(metadata map[string]string) (string, string, string, bool) {
+	for _, target := range Targets() {
+		impl := reflect.New(bridgeImpl[target]).Interface().(BridgeImpl)
+		if id, url, ok := impl.RemoteBug(metadata); ok {
+			return target, id, url, true
+		}
+	}
+	return "", "", "", false
+}
+
 // Instantiate a new Bridge for a repo, from the given target and name
 func NewBridge(repo *cache.RepoCache, target string, name string) (*Bridge, error) {
 	implType, ok := bridgeImpl[target]
@@ -201,11 +232,17 @@ func RemoveBridge(repo repository.RepoConfig, name string) error {
 	return repo.LocalConfig().RemoveAll(keyPrefix)
 }
 
-// Configure run the target specific configuration process
-func (b *Bridge) Configure(params BridgeParams) error {
+// Configure run the target specific configuration process. If interactive is
+// false, the configuration fails instead of prompting for missing parameters.
+// Target return the target of the bridge (e.g.: "github")
+func (b *Bridge) Target() string {
+	return b.impl.Target()
+}
+
+func (b *Bridge) Configure(params BridgeParams, interactive bool) error {
 	validateParams(params, b.impl)
 
-	conf, err := b.impl.Configure(b.repo, params)
+	conf, err := b.impl.Configure(b.repo, params, interactive)
 	if err != nil {
 		return err
 	}
@@ -400,5 +437,33 @@ func (b *Bridge) ExportAll(ctx context.Context, since time.Time) (<-chan ExportR
 		return nil, err
 	}
 
-	return exporter.ExportAll(ctx, b.repo, since)
+	stateful, ok := exporter.(StatefulExporter)
+	if !ok {
+		return exporter.ExportAll(ctx, b.repo, since)
+	}
+
+	state, err := b.repo.ExportState(b.Name)
+	if err != nil {
+		return nil, err
+	}
+	stateful.SetExportState(state)
+
+	results, err := exporter.ExportAll(ctx, b.repo, since)
+	if err != nil {
+		return nil, err
+	}
+
+	// save the export state once the export is done
+	out := make(chan ExportResult)
+	go func() {
+		defer close(out)
+		for result := range results {
+			out <- result
+		}
+		if err := state.Write(); err != nil {
+			out <- NewExportError(errors.Wrap(err, "writing the export state"), "")
+		}
+	}()
+
+	return out, nil
 }
@@ -1,8 +1,11 @@
 package core
 
 import (
+	"crypto/sha256"
 	"fmt"
+	"sort"
 
+	"github.com/MichaelMure/git-bug/cache"
 	"github.com/MichaelMure/git-bug/entity"
 )
 
@@ -145,3 +148,65 @@ func NewExportTitleEdition(id entity.Id) ExportResult {
 		Event: ExportEventTitleEdition,
 	}
 }
+
+// ExportTracked run the export of a bug with the given function, forwarding
+// its results. If no error happened, the bug is marked as exported in the
+// export state, if any, with the remote id returned by the function.
+func ExportTracked(state *cache.ExportState, id entity.Id, out chan<- ExportResult, export func(out chan<- ExportResult) string) {
+	if state == nil {
+		export(out)
+		return
+	}
+
+	results := make(chan ExportResult)
+	var remoteId string
+
+	go func() {
+		defer close(results)
+		remoteId = export(results)
+	}()
+
+	failed := false
+	for result := range results {
+		if result.Event == ExportEventError {
+			failed = true
+		}
+		out <- result
+	}
+
+	if failed {
+		return
+	}
+
+	err := state.MarkExported(id, remoteId)
+	if err != nil {
+		out <- NewExportWarning(err, id)
+	}
+}
+
+// ExportContext compute a fingerprint of what influence an export beside the
+// bugs themselves: the configuration of the bridge and the identities having
+// credentials. It's meant to be given to ExportState.SetContext.
+func ExportContext(conf Configuration, identities []entity.Id) string {
+	keys := make([]string, 0, len(conf))
+	for key := range conf {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	ids := make([]string, len(identities))
+	for i, id := range identities {
+		ids[i] = id.String()
+	}
+	sort.Strings(ids)
+
+	h := sha256.New()
+	for _, key := range keys {
+		_, _ = fmt.Fprintf(h, "%s=%s\n", key, conf[key])
+	}
+	for _, id := range ids {
+		_, _ = fmt.Fprintf(h, "%s\n", id)
+	}
+
+	return fmt.Sprintf("%x", h.Sum(nil))
+}
@@ -24,6 +24,14 @@ const (
 	ImportEventTitleEdition
 	// Bug's labels changed
 	ImportEventLabelChange
+	// Bug's custom field changed
+	ImportEventFieldChange
+	// Bug's time spent or estimate changed
+	ImportEventTimeTracking
+	// Bug's relations changed
+	ImportEventRelationChange
+	// Someone subscribed to a Bug
+	ImportEventSubscription
 	// Nothing happened on a Bug
 	ImportEventNothing
 
@@ -62,6 +70,14 @@ func (er ImportResult) String() string {
 		return fmt.Sprintf("changed title: %s", er.ID)
 	case ImportEventLabelChange:
 		return fmt.Sprintf("changed label: %s", er.ID)
+	case ImportEventFieldChange:
+		return fmt.Sprintf("changed field: %s", er.ID)
+	case ImportEventTimeTracking:
+		return fmt.Sprintf("time tracking: %s", er.ID)
+	case ImportEventRelationChange:
+		return fmt.Sprintf("changed relation: %s", er.ID)
+	case ImportEventSubscription:
+		return fmt.Sprintf("new subscription: %s", er.ID)
 	case ImportEventIdentity:
 		return fmt.Sprintf("new identity: %s", er.ID)
 	case ImportEventNothing:
@@ -152,6 +168,34 @@ func NewImportLabelChange(id entity.Id) ImportResult {
 	}
 }
 
+func NewImportFieldChange(id entity.Id) ImportResult {
+	return ImportResult{
+		ID:    id,
+		Event: ImportEventFieldChange,
+	}
+}
+
+func NewImportTimeTracking(id entity.Id) ImportResult {
+	return ImportResult{
+		ID:    id,
+		Event: ImportEventTimeTracking,
+	}
+}
+
+func NewImportRelationChange(id entity.Id) ImportResult {
+	return ImportResult{
+		ID:    id,
+		Event: ImportEventRelationChange,
+	}
+}
+
+func NewImportSubscription(id entity.Id) ImportResult {
+	return ImportResult{
+		ID:    id,
+		Event: ImportEventSubscription,
+	}
+}
+
 func NewImportTitleEdition(id entity.Id) ImportResult {
 	return ImportResult{
 		ID:    id,
@@ -20,8 +20,9 @@ type BridgeImpl interface {
 	NewExporter() Exporter
 
 	// Configure handle the user interaction and return a key/value configuration
-	// for future use
-	Configure(repo *cache.RepoCache, params BridgeParams) (Configuration, error)
+	// for future use. If interactive is false, the missing parameters are
+	// reported with an ErrMissingParam instead of being prompted.
+	Configure(repo *cache.RepoCache, params BridgeParams, interactive bool) (Configuration, error)
 
 	// The set of the BridgeParams fields supported
 	ValidParams() map[string]interface{}
@@ -33,6 +34,11 @@ type BridgeImpl interface {
 	// on the user identity. The corresponding value is used to match identities and
 	// credentials.
 	LoginMetaKey() string
+
+	// RemoteBug return the id and the web URL of a bug in the remote bug-tracker,
+	// from the metadata of its creation, if it has been imported or exported by
+	// this bridge. The URL is empty if it can't be known.
+	RemoteBug(metadata map[string]string) (id string, url string, ok bool)
 }
 
 type Importer interface {
@@ -44,3 +50,11 @@ type Exporter interface {
 	Init(ctx context.Context, repo *cache.RepoCache, conf Configuration) error
 	ExportAll(ctx context.Context, repo *cache.RepoCache, since time.Time) (<-chan ExportResult, error)
 }
+
+// StatefulExporter is an Exporter able to use the export state maintained by
+// the cache for its bridge, to only visit the bugs changed since the last
+// export. The state is given before ExportAll and saved once it's done.
+type StatefulExporter interface {
+	Exporter
+	SetExportState(state *cache.ExportState)
+}
@@ -14,6 +14,20 @@ type BridgeParams struct {
 	Project    string // name of the repo or project key      (Github,       , Jira, Launchpad)
 }
 
+// ErrMissingParam is returned by the configuration of a bridge in
+// non-interactive mode, instead of prompting for a missing parameter
+type ErrMissingParam struct {
+	Flag string
+}
+
+func NewErrMissingParam(flag string) *ErrMissingParam {
+	return &ErrMissingParam{Flag: flag}
+}
+
+func (e ErrMissingParam) Error() string {
+	return fmt.Sprintf("%s is required in non-interactive mode", e.Flag)
+}
+
 func (BridgeParams) fieldWarning(field string, target string) string {
 	switch field {
 	case "URL":
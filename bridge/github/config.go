@@ -38,7 +38,7 @@ func (g *Github) ValidParams() map[string]interface{} {
 	}
 }
 
-func (g *Github) Configure(repo *cache.RepoCache, params core.BridgeParams) (core.Configuration, error) {
+func (g *Github) Configure(repo *cache.RepoCache, params core.BridgeParams, interactive bool) (core.Configuration, error) {
 	var err error
 	var owner string
 	var project string
@@ -56,6 +56,8 @@ func (g *Github) Configure(repo *cache.RepoCache, params core.BridgeParams) (cor
 		if err != nil {
 			return nil, err
 		}
+	case !interactive:
+		return nil, core.NewErrMissingParam("--url or --owner and --project")
 	default:
 		// terminal prompt
 		owner, project, err = promptURL(repo)
@@ -95,6 +97,8 @@ func (g *Github) Configure(repo *cache.RepoCache, params core.BridgeParams) (cor
 		}
 		token.SetMetadata(auth.MetaKeyLogin, login)
 		cred = token
+	case !interactive:
+		return nil, core.NewErrMissingParam("--token or --credential")
 	default:
 		if params.Login == "" {
 			login, err = promptLogin()
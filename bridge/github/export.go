@@ -27,6 +27,8 @@ var (
 	ErrMissingIdentityToken = errors.New("missing identity token")
 )
 
+var _ core.StatefulExporter = &githubExporter{}
+
 // githubExporter implement the Exporter interface
 type githubExporter struct {
 	conf core.Configuration
@@ -53,6 +55,14 @@ type githubExporter struct {
 
 	// cache labels used to speed up exporting labels events
 	cachedLabels map[string]string
+
+	// the bugs already exported, if the export state is used
+	exportState *cache.ExportState
+}
+
+// SetExportState implement the StatefulExporter interface
+func (ge *githubExporter) SetExportState(state *cache.ExportState) {
+	ge.exportState = state
 }
 
 // Init .
@@ -107,7 +117,8 @@ func (ge *githubExporter) cacheAllClient(repo *cache.RepoCache) error {
 	}
 
 	if ge.defaultClient == nil {
-		return fmt.Errorf("no token found for the default login \"%s\"", ge.conf[confKeyDefaultLogin])
+		return core.NewErrAuthentication(
+			fmt.Errorf("no token found for the default login \"%s\"", ge.conf[confKeyDefaultLogin]))
 	}
 
 	return nil
@@ -154,6 +165,10 @@ func (ge *githubExporter) ExportAll(ctx context.Context, repo *cache.RepoCache,
 		}
 
 		allBugsIds := repo.AllBugsIds()
+		if ge.exportState != nil {
+			ge.exportState.SetContext(core.ExportContext(ge.conf, allIdentitiesIds))
+			allBugsIds = ge.exportState.DirtyBugs()
+		}
 
 		for _, id := range allBugsIds {
 			b, err := repo.ResolveBug(id)
@@ -169,6 +184,12 @@ func (ge *githubExporter) ExportAll(ctx context.Context, repo *cache.RepoCache,
 				return
 
 			default:
+				// drafts stay local until published
+				if b.IsDraft() {
+					out <- core.NewExportNothing(b.Id(), "draft bug")
+					continue
+				}
+
 				snapshot := b.Snapshot()
 
 				// ignore issues created before since date
@@ -180,7 +201,11 @@ func (ge *githubExporter) ExportAll(ctx context.Context, repo *cache.RepoCache,
 
 				if snapshot.HasAnyActor(allIdentitiesIds...) {
 					// try to export the bug and it associated events
-					ge.exportBug(ctx, b, out)
+					core.ExportTracked(ge.exportState, b.Id(), out, func(out chan<- core.ExportResult) string {
+						ge.exportBug(ctx, b, out)
+						remoteId, _ := b.Snapshot().GetCreateMetadata(metaKeyGithubId)
+						return remoteId
+					})
 				}
 			}
 		}
@@ -3,6 +3,7 @@ package github
 
 import (
 	"context"
+	"path"
 	"time"
 
 	"github.com/shurcooL/githubv4"
@@ -39,6 +40,15 @@ func (g *Github) LoginMetaKey() string {
 	return metaKeyGithubLogin
 }
 
+func (*Github) RemoteBug(metadata map[string]string) (string, string, bool) {
+	url, ok := metadata[metaKeyGithubUrl]
+	if !ok {
+		return "", "", false
+	}
+	// the url end with the issue number
+	return "#" + path.Base(url), url, true
+}
+
 func (*Github) NewImporter() core.Importer {
 	return &githubImporter{}
 }
@@ -12,6 +12,7 @@ import (
 	"github.com/MichaelMure/git-bug/bug"
 	"github.com/MichaelMure/git-bug/cache"
 	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/identity"
 	"github.com/MichaelMure/git-bug/util/text"
 )
 
@@ -42,7 +43,7 @@ func (gi *githubImporter) Init(_ context.Context, repo *cache.RepoCache, conf co
 	}
 
 	if len(creds) == 0 {
-		return ErrMissingIdentityToken
+		return core.NewErrAuthentication(ErrMissingIdentityToken)
 	}
 
 	gi.client = buildClient(creds[0].(*auth.Token))
@@ -82,6 +83,13 @@ func (gi *githubImporter) ImportAll(ctx context.Context, repo *cache.RepoCache,
 				}
 			}
 
+			err = gi.ensureSubscription(repo, b, issue)
+			if err != nil {
+				err = fmt.Errorf("subscription: %v", err)
+				out <- core.NewImportError(err, "")
+				return
+			}
+
 			if !b.NeedCommit() {
 				out <- core.NewImportNothing(b.Id(), "no imported operation")
 			} else if err := b.Commit(); err != nil {
@@ -361,8 +369,89 @@ func (gi *githubImporter) ensureTimelineItem(repo *cache.RepoCache, b *cache.Bug
 
 		gi.out <- core.NewImportTitleEdition(op.Id())
 		return nil
+
+	case "MarkedAsDuplicateEvent":
+		return gi.ensureDuplicate(repo, b, item.MarkedAsDuplicateEvent.actorEvent, item.MarkedAsDuplicateEvent.Canonical, false)
+
+	case "UnmarkedAsDuplicateEvent":
+		return gi.ensureDuplicate(repo, b, item.UnmarkedAsDuplicateEvent.actorEvent, item.UnmarkedAsDuplicateEvent.Canonical, true)
+	}
+
+	return nil
+}
+
+func (gi *githubImporter) ensureDuplicate(repo *cache.RepoCache, b *cache.BugCache, event actorEvent, canonical duplicateTarget, removed bool) error {
+	id := parseId(event.Id)
+	_, err := b.ResolveOperationWithMetadata(metaKeyGithubId, id)
+	if err != cache.ErrNoMatchingOp {
+		return err
+	}
+
+	if canonical.Issue.Id == nil {
+		gi.out <- core.NewImportNothing(b.Id(), "duplicate of a pull request or an unknown issue")
+		return nil
+	}
+
+	canonicalBug, err := repo.ResolveBugExcerptMatcher(func(excerpt *cache.BugExcerpt) bool {
+		return excerpt.CreateMetadata[core.MetaKeyOrigin] == target &&
+			excerpt.CreateMetadata[metaKeyGithubId] == parseId(canonical.Issue.Id)
+	})
+	if err == bug.ErrBugNotExist {
+		// the canonical issue is in another repository or not imported yet
+		gi.out <- core.NewImportNothing(b.Id(), "canonical issue of the duplicate not imported")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	author, err := gi.ensurePerson(repo, event.Actor)
+	if err != nil {
+		return err
+	}
+
+	var op *bug.RelateOperation
+	if removed {
+		op, err = b.UnrelateRaw(author, event.CreatedAt.Unix(), bug.DuplicateOfRelation, canonicalBug.Id,
+			map[string]string{metaKeyGithubId: id})
+	} else {
+		op, err = b.RelateRaw(author, event.CreatedAt.Unix(), bug.DuplicateOfRelation, canonicalBug.Id,
+			map[string]string{metaKeyGithubId: id})
+	}
+	if err != nil {
+		return err
+	}
+
+	gi.out <- core.NewImportRelationChange(op.Id())
+	return nil
+}
+
+// ensureSubscription subscribe the user of the bridge to the bug when they
+// watch the issue on Github. Other people subscriptions are not exposed by the API.
+func (gi *githubImporter) ensureSubscription(repo *cache.RepoCache, b *cache.BugCache, issue issueTimeline) error {
+	if issue.ViewerSubscription == nil || *issue.ViewerSubscription != githubv4.SubscriptionStateSubscribed {
+		return nil
+	}
+
+	viewer, err := repo.ResolveIdentityImmutableMetadata(metaKeyGithubLogin, gi.conf[confKeyDefaultLogin])
+	if err == identity.ErrIdentityNotExist {
+		// the user of the bridge never interacted with the project
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if b.Snapshot().HasSubscriber(viewer.Id()) {
+		return nil
+	}
+
+	op, err := b.SubscribeRaw(viewer, time.Now().Unix(), nil)
+	if err != nil {
+		return err
 	}
 
+	gi.out <- core.NewImportSubscription(op.Id())
 	return nil
 }
 
@@ -94,6 +94,24 @@ type timelineItem struct {
 		CurrentTitle  githubv4.String
 		PreviousTitle githubv4.String
 	} `graphql:"... on RenamedTitleEvent"`
+
+	// Relations
+	MarkedAsDuplicateEvent struct {
+		actorEvent
+		Canonical duplicateTarget
+	} `graphql:"... on MarkedAsDuplicateEvent"`
+	UnmarkedAsDuplicateEvent struct {
+		actorEvent
+		Canonical duplicateTarget
+	} `graphql:"... on UnmarkedAsDuplicateEvent"`
+}
+
+// duplicateTarget is the canonical issue of a duplicate. Pull requests are
+// ignored as they are not imported.
+type duplicateTarget struct {
+	Issue struct {
+		Id githubv4.ID
+	} `graphql:"... on Issue"`
 }
 
 type issueTimeline struct {
@@ -102,6 +120,9 @@ type issueTimeline struct {
 	Body  githubv4.String
 	Url   githubv4.URI
 
+	// subscription of the user of the bridge
+	ViewerSubscription *githubv4.SubscriptionState
+
 	TimelineItems struct {
 		Edges []struct {
 			Cursor githubv4.String
@@ -32,13 +32,15 @@ func (g *Gitlab) ValidParams() map[string]interface{} {
 	}
 }
 
-func (g *Gitlab) Configure(repo *cache.RepoCache, params core.BridgeParams) (core.Configuration, error) {
+func (g *Gitlab) Configure(repo *cache.RepoCache, params core.BridgeParams, interactive bool) (core.Configuration, error) {
 	var err error
 	var baseUrl string
 
 	switch {
 	case params.BaseURL != "":
 		baseUrl = params.BaseURL
+	case !interactive:
+		baseUrl = defaultBaseURL
 	default:
 		baseUrl, err = input.PromptDefault("Gitlab server URL", "URL", defaultBaseURL, input.Required, input.IsURL)
 		if err != nil {
@@ -52,6 +54,8 @@ func (g *Gitlab) Configure(repo *cache.RepoCache, params core.BridgeParams) (cor
 	switch {
 	case params.URL != "":
 		projectURL = params.URL
+	case !interactive:
+		return nil, core.NewErrMissingParam("--url")
 	default:
 		// terminal prompt
 		projectURL, err = promptProjectURL(repo, baseUrl)
@@ -87,6 +91,8 @@ func (g *Gitlab) Configure(repo *cache.RepoCache, params core.BridgeParams) (cor
 		token.SetMetadata(auth.MetaKeyLogin, login)
 		token.SetMetadata(auth.MetaKeyBaseURL, baseUrl)
 		cred = token
+	case !interactive:
+		return nil, core.NewErrMissingParam("--token or --credential")
 	default:
 		if params.Login == "" {
 			// TODO: validate username
@@ -22,6 +22,8 @@ var (
 	ErrMissingIdentityToken = errors.New("missing identity token")
 )
 
+var _ core.StatefulExporter = &gitlabExporter{}
+
 // gitlabExporter implement the Exporter interface
 type gitlabExporter struct {
 	conf core.Configuration
@@ -35,6 +37,14 @@ type gitlabExporter struct {
 	// cache identifiers used to speed up exporting operations
 	// cleared for each bug
 	cachedOperationIDs map[string]string
+
+	// the bugs already exported, if the export state is used
+	exportState *cache.ExportState
+}
+
+// SetExportState implement the StatefulExporter interface
+func (ge *gitlabExporter) SetExportState(state *cache.ExportState) {
+	ge.exportState = state
 }
 
 // Init .
@@ -115,6 +125,10 @@ func (ge *gitlabExporter) ExportAll(ctx context.Context, repo *cache.RepoCache,
 		}
 
 		allBugsIds := repo.AllBugsIds()
+		if ge.exportState != nil {
+			ge.exportState.SetContext(core.ExportContext(ge.conf, allIdentitiesIds))
+			allBugsIds = ge.exportState.DirtyBugs()
+		}
 
 		for _, id := range allBugsIds {
 			select {
@@ -127,6 +141,12 @@ func (ge *gitlabExporter) ExportAll(ctx context.Context, repo *cache.RepoCache,
 					return
 				}
 
+				// drafts stay local until published
+				if b.IsDraft() {
+					out <- core.NewExportNothing(b.Id(), "draft bug")
+					continue
+				}
+
 				snapshot := b.Snapshot()
 
 				// ignore issues created before since date
@@ -138,7 +158,11 @@ func (ge *gitlabExporter) ExportAll(ctx context.Context, repo *cache.RepoCache,
 
 				if snapshot.HasAnyActor(allIdentitiesIds...) {
 					// try to export the bug and it associated events
-					ge.exportBug(ctx, b, out)
+					core.ExportTracked(ge.exportState, b.Id(), out, func(out chan<- core.ExportResult) string {
+						ge.exportBug(ctx, b, out)
+						remoteId, _ := b.Snapshot().GetCreateMetadata(metaKeyGitlabId)
+						return remoteId
+					})
 				}
 			}
 		}
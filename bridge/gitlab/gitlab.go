@@ -21,6 +21,9 @@ const (
 	confKeyProjectID     = "project-id"
 	confKeyGitlabBaseUrl = "base-url"
 	confKeyDefaultLogin  = "default-login"
+	// JSON object mapping gitlab issue attributes (milestone, weight,
+	// due_date) to git-bug custom fields
+	confKeyFieldMap = "field-map"
 
 	defaultBaseURL = "https://gitlab.com/"
 	defaultTimeout = 60 * time.Second
@@ -38,6 +41,14 @@ func (g *Gitlab) LoginMetaKey() string {
 	return metaKeyGitlabLogin
 }
 
+func (Gitlab) RemoteBug(metadata map[string]string) (string, string, bool) {
+	id, ok := metadata[metaKeyGitlabId]
+	if !ok {
+		return "", "", false
+	}
+	return "#" + id, metadata[metaKeyGitlabUrl], true
+}
+
 func (Gitlab) NewImporter() core.Importer {
 	return &gitlabImporter{}
 }
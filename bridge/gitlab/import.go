@@ -2,6 +2,7 @@ package gitlab
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strconv"
 	"time"
@@ -45,7 +46,7 @@ func (gi *gitlabImporter) Init(_ context.Context, repo *cache.RepoCache, conf co
 	}
 
 	if len(creds) == 0 {
-		return ErrMissingIdentityToken
+		return core.NewErrAuthentication(ErrMissingIdentityToken)
 	}
 
 	gi.client, err = buildClient(conf[confKeyGitlabBaseUrl], creds[0].(*auth.Token))
@@ -78,10 +79,10 @@ func (gi *gitlabImporter) ImportAll(ctx context.Context, repo *cache.RepoCache,
 				return
 			}
 
-			// Loop over all notes
+			// Loop over all notes, discussion by discussion
 			for gi.iterator.NextNote() {
 				note := gi.iterator.NoteValue()
-				if err := gi.ensureNote(repo, b, note); err != nil {
+				if err := gi.ensureNote(repo, b, note, gi.iterator.NoteReplyTo()); err != nil {
 					err := fmt.Errorf("note creation: %v", err)
 					out <- core.NewImportError(err, entity.Id(strconv.Itoa(note.ID)))
 					return
@@ -98,6 +99,12 @@ func (gi *gitlabImporter) ImportAll(ctx context.Context, repo *cache.RepoCache,
 				}
 			}
 
+			if err := gi.ensureFields(repo, b, issue); err != nil {
+				err := fmt.Errorf("field update: %v", err)
+				out <- core.NewImportError(err, "")
+				return
+			}
+
 			if !b.NeedCommit() {
 				out <- core.NewImportNothing(b.Id(), "no imported operation")
 			} else if err := b.Commit(); err != nil {
@@ -169,7 +176,71 @@ func (gi *gitlabImporter) ensureIssue(repo *cache.RepoCache, issue *gitlab.Issue
 	return b, nil
 }
 
-func (gi *gitlabImporter) ensureNote(repo *cache.RepoCache, b *cache.BugCache, note *gitlab.Note) error {
+// ensureFields update the git-bug custom fields mapped to gitlab issue attributes.
+// As gitlab doesn't provide the history of those attributes, only the current
+// value is imported, attributed to the issue author.
+func (gi *gitlabImporter) ensureFields(repo *cache.RepoCache, b *cache.BugCache, issue *gitlab.Issue) error {
+	fieldMap := make(map[string]string)
+
+	if mapStr, ok := gi.conf[confKeyFieldMap]; ok {
+		if err := json.Unmarshal([]byte(mapStr), &fieldMap); err != nil {
+			return err
+		}
+	}
+
+	if len(fieldMap) == 0 {
+		return nil
+	}
+
+	values := make(map[string]string)
+	if issue.Milestone != nil {
+		values["milestone"] = issue.Milestone.Title
+	}
+	if issue.Weight != 0 {
+		values["weight"] = strconv.Itoa(issue.Weight)
+	}
+	if issue.DueDate != nil {
+		values["due_date"] = issue.DueDate.String()
+	}
+
+	author, err := gi.ensurePerson(repo, issue.Author.ID)
+	if err != nil {
+		return err
+	}
+
+	snap := b.Snapshot()
+
+	for attribute, fieldName := range fieldMap {
+		value := values[attribute]
+		if snap.Fields[fieldName] == value {
+			continue
+		}
+
+		op, err := b.SetFieldRaw(
+			author,
+			issue.UpdatedAt.Unix(),
+			fieldName,
+			value,
+			map[string]string{
+				metaKeyGitlabId: fmt.Sprintf("%s-%s-%d", parseID(issue.IID), attribute, issue.UpdatedAt.Unix()),
+			},
+		)
+		if err != nil {
+			// the value might not fit the git-bug schema, this is not worth
+			// failing the whole import
+			gi.out <- core.NewImportWarning(fmt.Errorf("field %s: %v", attribute, err), b.Id())
+			continue
+		}
+
+		gi.out <- core.NewImportFieldChange(op.Id())
+	}
+
+	return nil
+}
+
+// ensureNote import a note, replyTo being the first note of its discussion
+// when the note is an answer in a thread
+func (gi *gitlabImporter) ensureNote(repo *cache.RepoCache, b *cache.BugCache, note *gitlab.Note, replyTo *gitlab.Note) error {
 	gitlabID := parseID(note.ID)
 
 	id, errResolve := b.ResolveOperationWithMetadata(metaKeyGitlabId, gitlabID)
@@ -254,17 +325,18 @@ func (gi *gitlabImporter) ensureNote(repo *cache.RepoCache, b *cache.BugCache, n
 
 		// if we didn't import the comment
 		if errResolve == cache.ErrNoMatchingOp {
+			metadata := map[string]string{
+				metaKeyGitlabId: gitlabID,
+			}
 
-			// add comment operation
-			op, err := b.AddCommentRaw(
-				author,
-				note.CreatedAt.Unix(),
-				cleanText,
-				nil,
-				map[string]string{
-					metaKeyGitlabId: gitlabID,
-				},
-			)
+			// add comment operation, answering to the comment starting the
+			// thread if it was imported
+			var op *bug.AddCommentOperation
+			if target, ok := gi.threadTarget(b, replyTo); ok {
+				op, err = b.AddReplyRaw(author, note.CreatedAt.Unix(), target, cleanText, nil, metadata)
+			} else {
+				op, err = b.AddCommentRaw(author, note.CreatedAt.Unix(), cleanText, nil, metadata)
+			}
 			if err != nil {
 				return err
 			}
@@ -319,6 +391,54 @@ func (gi *gitlabImporter) ensureNote(repo *cache.RepoCache, b *cache.BugCache, n
 
 		gi.out <- core.NewImportTitleEdition(op.Id())
 
+	case NOTE_TIME_SPENT:
+		if errResolve == nil {
+			return nil
+		}
+
+		duration, err := parseDuration(body)
+		if err != nil {
+			return err
+		}
+
+		op, err := b.AddTimeSpentRaw(
+			author,
+			note.CreatedAt.Unix(),
+			duration,
+			map[string]string{
+				metaKeyGitlabId: gitlabID,
+			},
+		)
+		if err != nil {
+			return err
+		}
+
+		gi.out <- core.NewImportTimeTracking(op.Id())
+
+	case NOTE_TIME_ESTIMATE:
+		if errResolve == nil {
+			return nil
+		}
+
+		duration, err := parseDuration(body)
+		if err != nil {
+			return err
+		}
+
+		op, err := b.SetEstimateRaw(
+			author,
+			note.CreatedAt.Unix(),
+			duration,
+			map[string]string{
+				metaKeyGitlabId: gitlabID,
+			},
+		)
+		if err != nil {
+			return err
+		}
+
+		gi.out <- core.NewImportTimeTracking(op.Id())
+
 	case NOTE_UNKNOWN,
 		NOTE_ASSIGNED,
 		NOTE_UNASSIGNED,
@@ -340,6 +460,26 @@ func (gi *gitlabImporter) ensureNote(repo *cache.RepoCache, b *cache.BugCache, n
 	return nil
 }
 
+// threadTarget find the comment a note answers to, that is the comment
+// imported from the first note of its discussion. A discussion started by a
+// system note has no comment to answer to.
+func (gi *gitlabImporter) threadTarget(b *cache.BugCache, replyTo *gitlab.Note) (entity.Id, bool) {
+	if replyTo == nil {
+		return "", false
+	}
+
+	id, err := b.ResolveOperationWithMetadata(metaKeyGitlabId, parseID(replyTo.ID))
+	if err != nil {
+		return "", false
+	}
+
+	if _, err := b.Snapshot().SearchComment(id); err != nil {
+		return "", false
+	}
+
+	return id, true
+}
+
 func (gi *gitlabImporter) ensureLabelEvent(repo *cache.RepoCache, b *cache.BugCache, labelEvent *gitlab.LabelEvent) error {
 	_, err := b.ResolveOperationWithMetadata(metaKeyGitlabId, parseID(labelEvent.ID))
 	if err != cache.ErrNoMatchingOp {
@@ -1,7 +1,10 @@
 package gitlab
 
 import (
+	"fmt"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/xanzy/go-gitlab"
 )
@@ -25,6 +28,8 @@ const (
 	NOTE_REMOVED_MILESTONE
 	NOTE_MENTIONED_IN_ISSUE
 	NOTE_MENTIONED_IN_MERGE_REQUEST
+	NOTE_TIME_SPENT
+	NOTE_TIME_ESTIMATE
 	NOTE_UNKNOWN
 )
 
@@ -60,6 +65,10 @@ func (nt NoteType) String() string {
 		return "note mentioned in issue"
 	case NOTE_MENTIONED_IN_MERGE_REQUEST:
 		return "note mentioned in merge request"
+	case NOTE_TIME_SPENT:
+		return "note time spent"
+	case NOTE_TIME_ESTIMATE:
+		return "note time estimate"
 	case NOTE_UNKNOWN:
 		return "note unknown"
 	default:
@@ -132,6 +141,22 @@ func GetNoteType(n *gitlab.Note) (NoteType, string) {
 		return NOTE_MENTIONED_IN_MERGE_REQUEST, ""
 	}
 
+	if strings.HasPrefix(n.Body, "added ") && strings.Contains(n.Body, " of time spent") {
+		return NOTE_TIME_SPENT, getTimeSpent(n.Body)
+	}
+
+	if strings.HasPrefix(n.Body, "subtracted ") && strings.Contains(n.Body, " of time spent") {
+		return NOTE_TIME_SPENT, "-" + getTimeSpent(n.Body)
+	}
+
+	if strings.HasPrefix(n.Body, "changed time estimate to ") {
+		return NOTE_TIME_ESTIMATE, strings.TrimPrefix(n.Body, "changed time estimate to ")
+	}
+
+	if n.Body == "removed time estimate" {
+		return NOTE_TIME_ESTIMATE, "0m"
+	}
+
 	return NOTE_UNKNOWN, ""
 }
 
@@ -145,3 +170,65 @@ func getNewTitle(diff string) string {
 	newTitle = strings.Replace(newTitle, "+}", "", -1)
 	return strings.TrimSuffix(newTitle, "**")
 }
+
+// getTimeSpent extract the duration from a time tracking note
+// examples: "added 1h 30m of time spent"
+//           "subtracted 2d of time spent at 2020-10-01"
+func getTimeSpent(body string) string {
+	body = strings.TrimPrefix(body, "added ")
+	body = strings.TrimPrefix(body, "subtracted ")
+	return strings.Split(body, " of time spent")[0]
+}
+
+// parseDuration parse a gitlab human readable duration like "1w 2d 3h 4m 5s".
+// Gitlab default conversion rates are used: 1mo = 4w, 1w = 5d and 1d = 8h.
+// A leading "-" negate the duration.
+// because Gitlab
+func parseDuration(str string) (time.Duration, error) {
+	units := []struct {
+		suffix   string
+		duration time.Duration
+	}{
+		// longest suffixes first, so that "mo" is not parsed as "m"
+		{"mo", 4 * 5 * 8 * time.Hour},
+		{"w", 5 * 8 * time.Hour},
+		{"d", 8 * time.Hour},
+		{"h", time.Hour},
+		{"m", time.Minute},
+		{"s", time.Second},
+	}
+
+	str = strings.TrimSpace(str)
+
+	negative := strings.HasPrefix(str, "-")
+	str = strings.TrimPrefix(str, "-")
+
+	fields := strings.Fields(str)
+	if len(fields) == 0 {
+		return 0, fmt.Errorf("empty duration")
+	}
+
+	var result time.Duration
+
+FieldLoop:
+	for _, field := range fields {
+		for _, unit := range units {
+			if !strings.HasSuffix(field, unit.suffix) {
+				continue
+			}
+			n, err := strconv.Atoi(strings.TrimSuffix(field, unit.suffix))
+			if err != nil {
+				continue
+			}
+			result += time.Duration(n) * unit.duration
+			continue FieldLoop
+		}
+		return 0, fmt.Errorf("invalid duration %s", str)
+	}
+
+	if negative {
+		result = -result
+	}
+
+	return result, nil
+}
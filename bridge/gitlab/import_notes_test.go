@@ -2,6 +2,7 @@ package gitlab
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -54,3 +55,37 @@ func TestGetNewTitle(t *testing.T) {
 		})
 	}
 }
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		input string
+		want  time.Duration
+		err   bool
+	}{
+		{input: "1h 30m", want: 90 * time.Minute},
+		{input: "2d", want: 16 * time.Hour},
+		{input: "1w 1d", want: 48 * time.Hour},
+		{input: "1mo", want: 160 * time.Hour},
+		{input: "-45m", want: -45 * time.Minute},
+		{input: "10s", want: 10 * time.Second},
+		{input: "", err: true},
+		{input: "1x", err: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			duration, err := parseDuration(tt.input)
+			if tt.err {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, duration)
+		})
+	}
+}
+
+func TestGetTimeSpent(t *testing.T) {
+	assert.Equal(t, "1h 30m", getTimeSpent("added 1h 30m of time spent"))
+	assert.Equal(t, "2d", getTimeSpent("subtracted 2d of time spent at 2020-10-01"))
+}
@@ -115,6 +115,12 @@ func (i *Iterator) NoteValue() *gitlab.Note {
 	return i.note.Value()
 }
 
+// NoteReplyTo return the note answered to by the current note, that is the
+// first note of its discussion, or nil if it start a discussion
+func (i *Iterator) NoteReplyTo() *gitlab.Note {
+	return i.note.ReplyTo()
+}
+
 func (i *Iterator) NextLabelEvent() bool {
 	if i.err != nil {
 		return false
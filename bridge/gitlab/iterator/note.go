@@ -6,12 +6,17 @@ import (
 	"github.com/xanzy/go-gitlab"
 )
 
+// noteIterator iterate over the notes of an issue, discussion by discussion,
+// to know the threads of replies
 type noteIterator struct {
 	issue    int
 	page     int
 	lastPage bool
 	index    int
 	cache    []*gitlab.Note
+	// the note answered to by each note of the cache, nil for the notes
+	// starting a discussion
+	replyTo []*gitlab.Note
 }
 
 func newNoteIterator() *noteIterator {
@@ -39,24 +44,35 @@ func (in *noteIterator) Value() *gitlab.Note {
 	return in.cache[in.index]
 }
 
+func (in *noteIterator) ReplyTo() *gitlab.Note {
+	return in.replyTo[in.index]
+}
+
 func (in *noteIterator) getNext(ctx context.Context, conf config) (bool, error) {
-	if in.lastPage {
-		return false, nil
+	for !in.lastPage {
+		more, err := in.getPage(ctx, conf)
+		if err != nil || !more {
+			return false, err
+		}
+		// a page can have only empty discussions
+		if len(in.cache) > 0 {
+			return true, nil
+		}
 	}
+	return false, nil
+}
 
+func (in *noteIterator) getPage(ctx context.Context, conf config) (bool, error) {
 	ctx, cancel := context.WithTimeout(ctx, conf.timeout)
 	defer cancel()
 
-	notes, resp, err := conf.gc.Notes.ListIssueNotes(
+	// the discussions are sorted by creation date
+	discussions, resp, err := conf.gc.Discussions.ListIssueDiscussions(
 		conf.project,
 		in.issue,
-		&gitlab.ListIssueNotesOptions{
-			ListOptions: gitlab.ListOptions{
-				Page:    in.page,
-				PerPage: conf.capacity,
-			},
-			Sort:    gitlab.String("asc"),
-			OrderBy: gitlab.String("created_at"),
+		&gitlab.ListIssueDiscussionsOptions{
+			Page:    in.page,
+			PerPage: conf.capacity,
 		},
 		gitlab.WithContext(ctx),
 	)
@@ -70,11 +86,23 @@ func (in *noteIterator) getNext(ctx context.Context, conf config) (bool, error)
 		in.lastPage = true
 	}
 
-	if len(notes) == 0 {
+	if len(discussions) == 0 {
 		return false, nil
 	}
 
-	in.cache = notes
+	in.cache = in.cache[:0]
+	in.replyTo = in.replyTo[:0]
+	for _, discussion := range discussions {
+		// in a thread, all the notes answer to the first one
+		for i, note := range discussion.Notes {
+			in.cache = append(in.cache, note)
+			if i == 0 || discussion.IndividualNote {
+				in.replyTo = append(in.replyTo, nil)
+			} else {
+				in.replyTo = append(in.replyTo, discussion.Notes[0])
+			}
+		}
+	}
 	in.index = 0
 	in.page++
 
@@ -87,4 +115,5 @@ func (in *noteIterator) Reset(issue int) {
 	in.page = 1
 	in.lastPage = false
 	in.cache = nil
+	in.replyTo = nil
 }
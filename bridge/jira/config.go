@@ -37,10 +37,13 @@ func (*Jira) ValidParams() map[string]interface{} {
 }
 
 // Configure sets up the bridge configuration
-func (j *Jira) Configure(repo *cache.RepoCache, params core.BridgeParams) (core.Configuration, error) {
+func (j *Jira) Configure(repo *cache.RepoCache, params core.BridgeParams, interactive bool) (core.Configuration, error) {
 	var err error
 
 	baseURL := params.BaseURL
+	if baseURL == "" && !interactive {
+		return nil, core.NewErrMissingParam("--base-url")
+	}
 	if baseURL == "" {
 		// terminal prompt
 		baseURL, err = input.Prompt("JIRA server URL", "URL", input.Required, input.IsURL)
@@ -50,6 +53,9 @@ func (j *Jira) Configure(repo *cache.RepoCache, params core.BridgeParams) (core.
 	}
 
 	project := params.Project
+	if project == "" && !interactive {
+		return nil, core.NewErrMissingParam("--project")
+	}
 	if project == "" {
 		project, err = input.Prompt("JIRA project key", "project", input.Required)
 		if err != nil {
@@ -57,12 +63,16 @@ func (j *Jira) Configure(repo *cache.RepoCache, params core.BridgeParams) (core.
 		}
 	}
 
-	fmt.Println(credTypeText)
-	credTypeInput, err := input.PromptChoice("Authentication mechanism", []string{"SESSION", "TOKEN"})
-	if err != nil {
-		return nil, err
+	// the session mechanism is used by default in non-interactive mode
+	credType := "SESSION"
+	if interactive {
+		fmt.Println(credTypeText)
+		credTypeInput, err := input.PromptChoice("Authentication mechanism", []string{"SESSION", "TOKEN"})
+		if err != nil {
+			return nil, err
+		}
+		credType = []string{"SESSION", "TOKEN"}[credTypeInput]
 	}
-	credType := []string{"SESSION", "TOKEN"}[credTypeInput]
 
 	var login string
 	var cred auth.Credential
@@ -78,6 +88,8 @@ func (j *Jira) Configure(repo *cache.RepoCache, params core.BridgeParams) (core.
 			return nil, fmt.Errorf("credential doesn't have a login")
 		}
 		login = l
+	case !interactive:
+		return nil, core.NewErrMissingParam("--credential")
 	default:
 		if params.Login == "" {
 			// TODO: validate username
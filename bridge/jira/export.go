@@ -63,7 +63,7 @@ func (je *jiraExporter) Init(ctx context.Context, repo *cache.RepoCache, conf co
 	}
 
 	if len(je.identityClient) == 0 {
-		return fmt.Errorf("no credentials for this bridge")
+		return core.NewErrAuthentication(fmt.Errorf("no credentials for this bridge"))
 	}
 
 	var client *Client
@@ -161,6 +161,12 @@ func (je *jiraExporter) ExportAll(ctx context.Context, repo *cache.RepoCache, si
 				return
 
 			default:
+				// drafts stay local until published
+				if b.IsDraft() {
+					out <- core.NewExportNothing(b.Id(), "draft bug")
+					continue
+				}
+
 				snapshot := b.Snapshot()
 
 				// ignore issues whose last modification date is before the query date
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/http"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -67,7 +68,7 @@ func (ji *jiraImporter) Init(ctx context.Context, repo *cache.RepoCache, conf co
 
 end:
 	if cred == nil {
-		return fmt.Errorf("no credential for this bridge")
+		return core.NewErrAuthentication(fmt.Errorf("no credential for this bridge"))
 	}
 
 	// TODO(josh)[da52062]: Validate token and if it is expired then prompt for
@@ -423,6 +424,11 @@ func (ji *jiraImporter) ensureChange(repo *cache.RepoCache, b *cache.BugCache, e
 		return err
 	}
 
+	fieldMap, err := getFieldMap(ji.conf)
+	if err != nil {
+		return err
+	}
+
 	// NOTE(josh): first do an initial scan and see if any of the changed items
 	// matches the current potential operation. If it does, then we know that this
 	// entire changelog entry was created in response to that git-bug operation.
@@ -600,10 +606,85 @@ func (ji *jiraImporter) ensureChange(repo *cache.RepoCache, b *cache.BugCache, e
 
 			ji.out <- core.NewImportCommentEdition(op.Id())
 
+		case "timespent":
+			// NOTE: JIRA report the total time spent in seconds, we import the difference
+			from, _ := strconv.ParseInt(item.From, 10, 64)
+			to, err := strconv.ParseInt(item.To, 10, 64)
+			if err != nil {
+				return err
+			}
+			if to == from {
+				break
+			}
+
+			op, err := b.AddTimeSpentRaw(
+				author,
+				entry.Created.Unix(),
+				time.Duration(to-from)*time.Second,
+				map[string]string{
+					metaKeyJiraId:        entry.ID,
+					metaKeyJiraDerivedId: derivedID,
+				},
+			)
+			if err != nil {
+				return err
+			}
+
+			ji.out <- core.NewImportTimeTracking(op.Id())
+
+		case "timeoriginalestimate":
+			// NOTE: an empty value means the estimate has been removed
+			var seconds int64
+			if item.To != "" {
+				seconds, err = strconv.ParseInt(item.To, 10, 64)
+				if err != nil {
+					return err
+				}
+			}
+
+			op, err := b.SetEstimateRaw(
+				author,
+				entry.Created.Unix(),
+				time.Duration(seconds)*time.Second,
+				map[string]string{
+					metaKeyJiraId:        entry.ID,
+					metaKeyJiraDerivedId: derivedID,
+				},
+			)
+			if err != nil {
+				return err
+			}
+
+			ji.out <- core.NewImportTimeTracking(op.Id())
+
 		default:
-			ji.out <- core.NewImportWarning(
-				fmt.Errorf(
-					"Unhandled changelog event %s", item.Field), "")
+			fieldName, hasMap := fieldMap[item.Field]
+			if !hasMap {
+				ji.out <- core.NewImportWarning(
+					fmt.Errorf(
+						"Unhandled changelog event %s", item.Field), "")
+				break
+			}
+
+			op, err := b.SetFieldRaw(
+				author,
+				entry.Created.Unix(),
+				fieldName,
+				item.ToString,
+				map[string]string{
+					metaKeyJiraId:        entry.ID,
+					metaKeyJiraDerivedId: derivedID,
+				},
+			)
+			if err != nil {
+				// the value might not fit the git-bug schema, this is not worth
+				// failing the whole import
+				ji.out <- core.NewImportWarning(
+					fmt.Errorf("field %s: %v", item.Field, err), "")
+				break
+			}
+
+			ji.out <- core.NewImportFieldChange(op.Id())
 		}
 
 		// Other Examples:
@@ -656,6 +737,19 @@ func getStatusMapReverse(conf core.Configuration) (map[string]string, error) {
 	return outMap, err
 }
 
+// getFieldMap return the mapping from JIRA field names to git-bug custom fields
+func getFieldMap(conf core.Configuration) (map[string]string, error) {
+	fieldMap := make(map[string]string)
+
+	mapStr, hasConf := conf[confKeyFieldMap]
+	if !hasConf {
+		return fieldMap, nil
+	}
+
+	err := json.Unmarshal([]byte(mapStr), &fieldMap)
+	return fieldMap, err
+}
+
 func removeEmpty(values []string) []string {
 	output := make([]string, 0, len(values))
 	for _, value := range values {
@@ -5,6 +5,7 @@ import (
 	"context"
 	"fmt"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/MichaelMure/git-bug/bridge/core"
@@ -34,6 +35,8 @@ const (
 	confKeyCreateDefaults = "create-issue-defaults"
 	// if set, the bridge fill this JIRA field with the `git-bug` id when exporting
 	confKeyCreateGitBug = "create-issue-gitbug-id"
+	// JSON object mapping JIRA field names to git-bug custom fields
+	confKeyFieldMap = "field-map"
 
 	defaultTimeout = 60 * time.Second
 )
@@ -52,6 +55,21 @@ func (*Jira) LoginMetaKey() string {
 	return metaKeyJiraLogin
 }
 
+// RemoteBug returns the key of the issue, or its id for the exported bugs
+func (*Jira) RemoteBug(metadata map[string]string) (string, string, bool) {
+	key, ok := metadata[metaKeyJiraKey]
+	if !ok {
+		id, ok := metadata[metaKeyJiraId]
+		return id, "", ok
+	}
+
+	baseUrl, ok := metadata[metaKeyJiraBaseUrl]
+	if !ok {
+		return key, "", true
+	}
+	return key, strings.TrimSuffix(baseUrl, "/") + "/browse/" + key, true
+}
+
 // NewImporter returns the jira importer
 func (*Jira) NewImporter() core.Importer {
 	return &jiraImporter{}
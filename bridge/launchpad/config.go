@@ -20,7 +20,7 @@ func (Launchpad) ValidParams() map[string]interface{} {
 	}
 }
 
-func (l *Launchpad) Configure(repo *cache.RepoCache, params core.BridgeParams) (core.Configuration, error) {
+func (l *Launchpad) Configure(repo *cache.RepoCache, params core.BridgeParams, interactive bool) (core.Configuration, error) {
 	var err error
 	var project string
 
@@ -30,6 +30,8 @@ func (l *Launchpad) Configure(repo *cache.RepoCache, params core.BridgeParams) (
 	case params.URL != "":
 		// get project name from url
 		project, err = splitURL(params.URL)
+	case !interactive:
+		return nil, core.NewErrMissingParam("--url or --project")
 	default:
 		// get project name from terminal prompt
 		project, err = input.Prompt("Launchpad project name", "project name", input.Required)
@@ -30,6 +30,14 @@ func (Launchpad) LoginMetaKey() string {
 	return metaKeyLaunchpadLogin
 }
 
+func (*Launchpad) RemoteBug(metadata map[string]string) (string, string, bool) {
+	id, ok := metadata[metaKeyLaunchpadID]
+	if !ok {
+		return "", "", false
+	}
+	return "#" + id, "https://bugs.launchpad.net/bugs/" + id, true
+}
+
 func (*Launchpad) NewImporter() core.Importer {
 	return &launchpadImporter{}
 }
@@ -15,6 +15,7 @@ import (
 )
 
 const bugsRefPattern = "refs/bugs/"
+const draftsRefPattern = "refs/drafts/bugs/"
 const bugsRemoteRefPattern = "refs/remotes/%s/bugs/"
 
 const opsEntryName = "ops"
@@ -65,6 +66,13 @@ type Bug struct {
 	// a temporary pack of operations used for convenience to pile up new operations
 	// before a commit
 	staging OperationPack
+
+	// a draft bug is stored in a separate ref namespace that is never pushed,
+	// until it get published
+	draft bool
+
+	// the identities a confidential bug is encrypted for, if any
+	recipients []entity.Id
 }
 
 // NewBug create a new Bug
@@ -74,14 +82,28 @@ func NewBug() *Bug {
 	return &Bug{}
 }
 
+// NewDraftBug create a new Bug that won't be pushed until published
+func NewDraftBug() *Bug {
+	return &Bug{draft: true}
+}
+
 // ReadLocal will read a local bug from its hash
 func ReadLocal(repo repository.ClockedRepo, id entity.Id) (*Bug, error) {
-	ref := bugsRefPattern + id.String()
-	return read(repo, identity.NewSimpleResolver(repo), ref)
+	return ReadLocalWithResolver(repo, identity.NewSimpleResolver(repo), id)
 }
 
 // ReadLocalWithResolver will read a local bug from its hash
 func ReadLocalWithResolver(repo repository.ClockedRepo, identityResolver identity.Resolver, id entity.Id) (*Bug, error) {
+	draftRef := draftsRefPattern + id.String()
+
+	isDraft, err := repo.RefExist(draftRef)
+	if err != nil {
+		return nil, err
+	}
+	if isDraft {
+		return read(repo, identityResolver, draftRef)
+	}
+
 	ref := bugsRefPattern + id.String()
 	return read(repo, identityResolver, ref)
 }
@@ -117,6 +139,7 @@ func read(repo repository.ClockedRepo, identityResolver identity.Resolver, ref s
 	bug := Bug{
 		id:       id,
 		editTime: 0,
+		draft:    strings.HasPrefix(ref, draftsRefPattern),
 	}
 
 	// Load each OperationPack
@@ -132,6 +155,8 @@ func read(repo repository.ClockedRepo, identityResolver identity.Resolver, ref s
 		opsFound := false
 		var rootEntry repository.TreeEntry
 		rootFound := false
+		var recipientsEntry repository.TreeEntry
+		recipientsFound := false
 		var createTime uint64
 		var editTime uint64
 
@@ -145,6 +170,10 @@ func read(repo repository.ClockedRepo, identityResolver identity.Resolver, ref s
 				rootEntry = entry
 				rootFound = true
 			}
+			if entry.Name == recipientsEntryName {
+				recipientsEntry = entry
+				recipientsFound = true
+			}
 			if strings.HasPrefix(entry.Name, createClockEntryPrefix) {
 				n, err := fmt.Sscanf(entry.Name, createClockEntryPattern, &createTime)
 				if err != nil {
@@ -203,6 +232,18 @@ func read(repo repository.ClockedRepo, identityResolver identity.Resolver, ref s
 			return nil, errors.Wrap(err, "failed to read git blob data")
 		}
 
+		if recipientsFound {
+			bug.recipients, err = readRecipients(repo, recipientsEntry.Hash)
+			if err != nil {
+				return nil, err
+			}
+
+			data, err = readEncrypted(data)
+			if err != nil {
+				return nil, err
+			}
+		}
+
 		opp := &OperationPack{}
 		err = json.Unmarshal(data, &opp)
 
@@ -212,6 +253,7 @@ func read(repo repository.ClockedRepo, identityResolver identity.Resolver, ref s
 
 		// tag the pack with the commit hash
 		opp.commitHash = hash
+		opp.editTime = lamport.Time(editTime)
 
 		bug.packs = append(bug.packs, *opp)
 	}
@@ -229,16 +271,18 @@ func read(repo repository.ClockedRepo, identityResolver identity.Resolver, ref s
 func RemoveBug(repo repository.ClockedRepo, id entity.Id) error {
 	var fullMatches []string
 
-	refs, err := repo.ListRefs(bugsRefPattern + id.String())
-	if err != nil {
-		return err
-	}
-	if len(refs) > 1 {
-		return NewErrMultipleMatchBug(refsToIds(refs))
-	}
-	if len(refs) == 1 {
-		// we have the bug locally
-		fullMatches = append(fullMatches, refs[0])
+	for _, prefix := range []string{bugsRefPattern, draftsRefPattern} {
+		refs, err := repo.ListRefs(prefix + id.String())
+		if err != nil {
+			return err
+		}
+		if len(refs) > 1 {
+			return NewErrMultipleMatchBug(refsToIds(refs))
+		}
+		if len(refs) == 1 {
+			// we have the bug locally
+			fullMatches = append(fullMatches, refs[0])
+		}
 	}
 
 	remotes, err := repo.GetRemotes()
@@ -253,7 +297,7 @@ func RemoveBug(repo repository.ClockedRepo, id entity.Id) error {
 			return err
 		}
 		if len(remoteRefs) > 1 {
-			return NewErrMultipleMatchBug(refsToIds(refs))
+			return NewErrMultipleMatchBug(refsToIds(remoteRefs))
 		}
 		if len(remoteRefs) == 1 {
 			// found the bug in a remote
@@ -280,14 +324,14 @@ type StreamedBug struct {
 	Err error
 }
 
-// ReadAllLocal read and parse all local bugs
+// ReadAllLocal read and parse all local bugs, drafts included
 func ReadAllLocal(repo repository.ClockedRepo) <-chan StreamedBug {
-	return readAll(repo, identity.NewSimpleResolver(repo), bugsRefPattern)
+	return readAll(repo, identity.NewSimpleResolver(repo), bugsRefPattern, draftsRefPattern)
 }
 
-// ReadAllLocalWithResolver read and parse all local bugs
+// ReadAllLocalWithResolver read and parse all local bugs, drafts included
 func ReadAllLocalWithResolver(repo repository.ClockedRepo, identityResolver identity.Resolver) <-chan StreamedBug {
-	return readAll(repo, identityResolver, bugsRefPattern)
+	return readAll(repo, identityResolver, bugsRefPattern, draftsRefPattern)
 }
 
 // ReadAllRemote read and parse all remote bugs for a given remote
@@ -302,42 +346,76 @@ func ReadAllRemoteWithResolver(repo repository.ClockedRepo, identityResolver ide
 	return readAll(repo, identityResolver, refPrefix)
 }
 
-// Read and parse all available bug with a given ref prefix
-func readAll(repo repository.ClockedRepo, identityResolver identity.Resolver, refPrefix string) <-chan StreamedBug {
+// Read and parse all available bug with the given ref prefixes
+func readAll(repo repository.ClockedRepo, identityResolver identity.Resolver, refPrefixes ...string) <-chan StreamedBug {
 	out := make(chan StreamedBug)
 
 	go func() {
 		defer close(out)
 
-		refs, err := repo.ListRefs(refPrefix)
-		if err != nil {
-			out <- StreamedBug{Err: err}
-			return
-		}
-
-		for _, ref := range refs {
-			b, err := read(repo, identityResolver, ref)
-
+		for _, refPrefix := range refPrefixes {
+			refs, err := repo.ListRefs(refPrefix)
 			if err != nil {
 				out <- StreamedBug{Err: err}
 				return
 			}
 
-			out <- StreamedBug{Bug: b}
+			for _, ref := range refs {
+				b, err := read(repo, identityResolver, ref)
+
+				// confidential bugs we can't read are simply not visible
+				if err == ErrBugEncrypted {
+					continue
+				}
+
+				if err != nil {
+					out <- StreamedBug{Err: err}
+					return
+				}
+
+				out <- StreamedBug{Bug: b}
+			}
 		}
 	}()
 
 	return out
 }
 
-// ListLocalIds list all the available local bug ids
+// ListLocalIds list all the available local bug ids, drafts included
 func ListLocalIds(repo repository.Repo) ([]entity.Id, error) {
-	refs, err := repo.ListRefs(bugsRefPattern)
-	if err != nil {
-		return nil, err
+	var result []entity.Id
+
+	for _, prefix := range []string{bugsRefPattern, draftsRefPattern} {
+		refs, err := repo.ListRefs(prefix)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, refsToIds(refs)...)
 	}
 
-	return refsToIds(refs), nil
+	return result, nil
+}
+
+// ListLocalRefTips return the commit each local bug ref point to, drafts
+// included. It allow to detect cheaply which bugs changed.
+func ListLocalRefTips(repo repository.Repo) (map[entity.Id]repository.Hash, error) {
+	result := make(map[entity.Id]repository.Hash)
+
+	for _, prefix := range []string{bugsRefPattern, draftsRefPattern} {
+		refs, err := repo.ListRefs(prefix)
+		if err != nil {
+			return nil, err
+		}
+		for _, ref := range refs {
+			hash, err := repo.ResolveRef(ref)
+			if err != nil {
+				return nil, err
+			}
+			result[refToId(ref)] = hash
+		}
+	}
+
+	return result, nil
 }
 
 func refsToIds(refs []string) []entity.Id {
@@ -426,7 +504,13 @@ func (bug *Bug) Commit(repo repository.ClockedRepo) error {
 	}
 
 	// Write the Ops as a Git blob containing the serialized array
-	hash, err := bug.staging.Write(repo)
+	var hash repository.Hash
+	var err error
+	if bug.IsConfidential() {
+		hash, err = bug.writeEncrypted(repo)
+	} else {
+		hash, err = bug.staging.Write(repo)
+	}
 	if err != nil {
 		return err
 	}
@@ -443,6 +527,20 @@ func (bug *Bug) Commit(repo repository.ClockedRepo) error {
 		{ObjectType: repository.Blob, Hash: bug.rootPack, Name: rootEntryName},
 	}
 
+	// For a confidential bug, store who can read it so that anyone adding
+	// operations can encrypt them for the same recipients
+	if bug.IsConfidential() {
+		recipientsHash, err := bug.writeRecipients(repo)
+		if err != nil {
+			return err
+		}
+		tree = append(tree, repository.TreeEntry{
+			ObjectType: repository.Blob,
+			Hash:       recipientsHash,
+			Name:       recipientsEntryName,
+		})
+	}
+
 	// Reference, if any, all the files required by the ops
 	// Git will check that they actually exist in the storage and will make sure
 	// to push/pull them as needed.
@@ -478,6 +576,7 @@ func (bug *Bug) Commit(repo repository.ClockedRepo) error {
 	if err != nil {
 		return err
 	}
+	bug.staging.editTime = bug.editTime
 
 	tree = append(tree, repository.TreeEntry{
 		ObjectType: repository.Blob,
@@ -508,12 +607,7 @@ func (bug *Bug) Commit(repo repository.ClockedRepo) error {
 	}
 
 	// Write a Git commit referencing the tree, with the previous commit as parent
-	if bug.lastCommit != "" {
-		hash, err = repo.StoreCommitWithParent(hash, bug.lastCommit)
-	} else {
-		hash, err = repo.StoreCommit(hash)
-	}
-
+	hash, err = storePackCommit(repo, hash, bug.lastCommit, bug.staging)
 	if err != nil {
 		return err
 	}
@@ -528,8 +622,7 @@ func (bug *Bug) Commit(repo repository.ClockedRepo) error {
 	// Create or update the Git reference for this bug
 	// When pushing later, the remote will ensure that this ref update
 	// is fast-forward, that is no data has been overwritten
-	ref := fmt.Sprintf("%s%s", bugsRefPattern, bug.id)
-	err = repo.UpdateRef(ref, hash)
+	err = repo.UpdateRef(bug.ref(), hash)
 
 	if err != nil {
 		return err
@@ -542,6 +635,46 @@ func (bug *Bug) Commit(repo repository.ClockedRepo) error {
 	return nil
 }
 
+// ref return the Git reference of the bug, depending on it being a draft
+func (bug *Bug) ref() string {
+	if bug.draft {
+		return draftsRefPattern + bug.id.String()
+	}
+	return bugsRefPattern + bug.id.String()
+}
+
+// IsDraft return true if the bug has not been published yet
+func (bug *Bug) IsDraft() bool {
+	return bug.draft
+}
+
+// Publish move a draft bug to the regular bug namespace, so that it get
+// pushed like any other bug. As the history is untouched, the id is kept.
+func (bug *Bug) Publish(repo repository.ClockedRepo) error {
+	if !bug.draft {
+		return fmt.Errorf("bug %s is not a draft", bug.id.Human())
+	}
+
+	if bug.NeedCommit() {
+		return fmt.Errorf("can't publish a bug with pending operations")
+	}
+
+	if bug.lastCommit == "" {
+		return fmt.Errorf("can't publish a bug never committed")
+	}
+
+	draftRef := bug.ref()
+
+	err := repo.UpdateRef(bugsRefPattern+bug.id.String(), bug.lastCommit)
+	if err != nil {
+		return err
+	}
+
+	bug.draft = false
+
+	return repo.RemoveRef(draftRef)
+}
+
 func (bug *Bug) CommitAsNeeded(repo repository.ClockedRepo) error {
 	if !bug.NeedCommit() {
 		return nil
@@ -644,7 +777,7 @@ func (bug *Bug) Merge(repo repository.Repo, other Interface) (bool, error) {
 		}
 
 		// create a new commit with the correct ancestor
-		hash, err := repo.StoreCommitWithParent(treeHash, bug.lastCommit)
+		hash, err := storePackCommit(repo, treeHash, bug.lastCommit, pack)
 
 		if err != nil {
 			return false, err
@@ -726,11 +859,28 @@ func (bug *Bug) LastOp() Operation {
 	return lastPack.Operations[len(lastPack.Operations)-1]
 }
 
+// OperationCount return the number of operations of the bug, committed
+// or not
+// LastCommit return the hash of the last commit of the bug, or an empty hash
+// if it was never committed
+func (bug *Bug) LastCommit() repository.Hash {
+	return bug.lastCommit
+}
+
+func (bug *Bug) OperationCount() int {
+	count := len(bug.staging.Operations)
+	for _, pack := range bug.packs {
+		count += len(pack.Operations)
+	}
+	return count
+}
+
 // Compile a bug in a easily usable snapshot
 func (bug *Bug) Compile() Snapshot {
 	snap := Snapshot{
-		id:     bug.id,
-		Status: OpenStatus,
+		id:         bug.id,
+		Status:     OpenStatus,
+		Recipients: bug.recipients,
 	}
 
 	it := NewOperationIterator(bug)
@@ -743,3 +893,41 @@ func (bug *Bug) Compile() Snapshot {
 
 	return snap
 }
+
+// CompileOnTop update in place a snapshot compiled from a previous version
+// of the same bug, by applying only the operations it doesn't have yet.
+// It returns false and leave the snapshot untouched if the operations of the
+// snapshot are not the beginning of the history of the bug, for instance
+// after a merge rewrote it, in which case the bug need to be compiled again.
+func (bug *Bug) CompileOnTop(snap *Snapshot) bool {
+	if snap.id != bug.id {
+		return false
+	}
+
+	var missing []Operation
+	i := 0
+
+	it := NewOperationIterator(bug)
+	for it.Next() {
+		op := it.Value()
+		if i < len(snap.Operations) {
+			if snap.Operations[i].Id() != op.Id() {
+				return false
+			}
+		} else {
+			missing = append(missing, op)
+		}
+		i++
+	}
+
+	if i < len(snap.Operations) {
+		return false
+	}
+
+	for _, op := range missing {
+		op.Apply(snap)
+		snap.Operations = append(snap.Operations, op)
+	}
+
+	return true
+}
@@ -50,11 +50,14 @@ func Pull(repo repository.ClockedRepo, remote string) error {
 
 // MergeAll will merge all the available remote bug:
 //
-// - If the remote has new commit, the local bug is updated to match the same history
-//   (fast-forward update)
-// - if the local bug has new commits but the remote don't, nothing is changed
-// - if both local and remote bug have new commits (that is, we have a concurrent edition),
-//   new local commits are rewritten at the head of the remote history (that is, a rebase)
+//   - If the remote has new commit, the local bug is updated to match the same history
+//     (fast-forward update)
+//   - if the local bug has new commits but the remote don't, nothing is changed
+//   - if both local and remote bug have new commits (that is, we have a concurrent edition),
+//     new local commits are rewritten at the head of the remote history (that is, a rebase)
+//
+// Remote bugs with new operations violating the repository Policy or
+// LabelTaxonomy are quarantined: they are reported and left untouched in the remote refs.
 func MergeAll(repo repository.ClockedRepo, remote string) <-chan entity.MergeResult {
 	out := make(chan entity.MergeResult)
 
@@ -66,6 +69,18 @@ func MergeAll(repo repository.ClockedRepo, remote string) <-chan entity.MergeRes
 	go func() {
 		defer close(out)
 
+		policy, err := ReadPolicy(repo.LocalConfig())
+		if err != nil {
+			out <- entity.MergeResult{Err: errors.Wrap(err, "can't read the policy")}
+			return
+		}
+
+		taxonomy, err := ReadLabelTaxonomy(repo.LocalConfig())
+		if err != nil {
+			out <- entity.MergeResult{Err: errors.Wrap(err, "can't read the label taxonomy")}
+			return
+		}
+
 		remoteRefSpec := fmt.Sprintf(bugsRemoteRefPattern, remote)
 		remoteRefs, err := repo.ListRefs(remoteRefSpec)
 
@@ -83,6 +98,18 @@ func MergeAll(repo repository.ClockedRepo, remote string) <-chan entity.MergeRes
 				continue
 			}
 
+			// the remote ref didn't move since the last merge, there is no
+			// need to read and check the bug again
+			upToDate, err := repository.SameRefTip(repo, bugsRefPattern+id.String(), remoteRef)
+			if err != nil {
+				out <- entity.NewMergeError(err, id)
+				continue
+			}
+			if upToDate {
+				out <- entity.NewMergeStatus(entity.MergeStatusNothing, id, nil)
+				continue
+			}
+
 			remoteBug, err := read(repo, identityResolver, remoteRef)
 
 			if err != nil {
@@ -96,6 +123,12 @@ func MergeAll(repo repository.ClockedRepo, remote string) <-chan entity.MergeRes
 				continue
 			}
 
+			// Refuse tampered data
+			if err := remoteBug.VerifySignatures(repo); err != nil {
+				out <- entity.NewMergeInvalidStatus(id, errors.Wrap(err, "remote bug is not trusted").Error())
+				continue
+			}
+
 			localRef := bugsRefPattern + remoteBug.Id().String()
 			localExist, err := repo.RefExist(localRef)
 
@@ -106,6 +139,15 @@ func MergeAll(repo repository.ClockedRepo, remote string) <-chan entity.MergeRes
 
 			// the bug is not local yet, simply create the reference
 			if !localExist {
+				if err := policy.CheckBug(remoteBug, nil); err != nil {
+					out <- entity.NewMergeQuarantinedStatus(id, err.Error())
+					continue
+				}
+				if err := taxonomy.CheckBug(remoteBug, nil); err != nil {
+					out <- entity.NewMergeQuarantinedStatus(id, err.Error())
+					continue
+				}
+
 				err := repo.CopyRef(remoteRef, localRef)
 
 				if err != nil {
@@ -124,6 +166,18 @@ func MergeAll(repo repository.ClockedRepo, remote string) <-chan entity.MergeRes
 				return
 			}
 
+			// only the new remote operations are checked, local ones have
+			// already been accepted
+			known := operationIds(localBug)
+			if err := policy.CheckBug(remoteBug, known); err != nil {
+				out <- entity.NewMergeQuarantinedStatus(id, err.Error())
+				continue
+			}
+			if err := taxonomy.CheckBug(remoteBug, known); err != nil {
+				out <- entity.NewMergeQuarantinedStatus(id, err.Error())
+				continue
+			}
+
 			updated, err := localBug.Merge(repo, remoteBug)
 
 			if err != nil {
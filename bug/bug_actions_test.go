@@ -7,6 +7,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/MichaelMure/git-bug/entity"
 	"github.com/MichaelMure/git-bug/identity"
 	"github.com/MichaelMure/git-bug/repository"
 )
@@ -65,6 +66,13 @@ func TestPushPull(t *testing.T) {
 	if len(bugs) != 2 {
 		t.Fatal("Unexpected number of bugs")
 	}
+
+	// nothing moved, the bugs are not read again
+	for result := range MergeAll(repoA, "origin") {
+		require.NoError(t, result.Err)
+		require.Equal(t, entity.MergeStatusNothing, result.Status)
+		require.Nil(t, result.Entity)
+	}
 }
 
 func allBugs(t testing.TB, bugs <-chan StreamedBug) []*Bug {
@@ -1,12 +1,16 @@
 package bug
 
 import (
+	"bytes"
 	"fmt"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/openpgp"
+	"golang.org/x/crypto/openpgp/armor"
 
+	"github.com/MichaelMure/git-bug/entity"
 	"github.com/MichaelMure/git-bug/identity"
 	"github.com/MichaelMure/git-bug/repository"
 )
@@ -112,6 +116,47 @@ func TestBugCommitLoad(t *testing.T) {
 	equivalentBug(t, bug1, bug3)
 }
 
+func TestBugCompileOnTop(t *testing.T) {
+	repo := repository.NewMockRepoForTest()
+
+	rene := identity.NewIdentity("René Descartes", "rene@descartes.fr")
+	err := rene.Commit(repo)
+	require.NoError(t, err)
+
+	bug1 := NewBug()
+	bug1.Append(NewCreateOp(rene, time.Now().Unix(), "title", "message", nil))
+	bug1.Append(NewSetTitleOp(rene, time.Now().Unix(), "title2", "title"))
+	require.NoError(t, bug1.Commit(repo))
+
+	previous, err := ReadLocal(repo, bug1.Id())
+	require.NoError(t, err)
+	snap := previous.Compile()
+
+	bug1.Append(NewAddCommentOp(rene, time.Now().Unix(), "message2", nil))
+	bug1.Append(NewSetStatusOp(rene, time.Now().Unix(), ClosedStatus))
+	require.NoError(t, bug1.Commit(repo))
+
+	bug2, err := ReadLocal(repo, bug1.Id())
+	require.NoError(t, err)
+
+	require.True(t, bug2.CompileOnTop(&snap))
+	require.Equal(t, bug2.Compile(), snap)
+
+	// already up to date
+	require.True(t, bug2.CompileOnTop(&snap))
+	require.Equal(t, bug2.Compile(), snap)
+
+	// a snapshot that is not a prefix of the history is left untouched
+	other := NewBug()
+	other.Append(NewCreateOp(rene, time.Now().Unix(), "other", "message", nil))
+	require.NoError(t, other.Commit(repo))
+
+	otherSnap := other.Compile()
+	otherSnap.id = bug2.id
+	require.False(t, bug2.CompileOnTop(&otherSnap))
+	require.Len(t, otherSnap.Operations, 1)
+}
+
 func equivalentBug(t *testing.T, expected, actual *Bug) {
 	require.Equal(t, len(expected.packs), len(actual.packs))
 
@@ -184,3 +229,212 @@ func TestBugRemove(t *testing.T) {
 	require.NoError(t, err)
 	require.Len(t, ids, 100)
 }
+
+func TestBugDraftPublish(t *testing.T) {
+	repo := repository.CreateGoGitTestRepo(false)
+	remote := repository.CreateGoGitTestRepo(true)
+	defer repository.CleanupTestRepos(repo, remote)
+
+	err := repo.AddRemote("remote", "file://"+remote.GetPath())
+	require.NoError(t, err)
+
+	rene := identity.NewIdentity("René Descartes", "rene@descartes.fr")
+	err = rene.Commit(repo)
+	require.NoError(t, err)
+
+	draft, _, err := CreateDraftWithFiles(rene, time.Now().Unix(), "title", "message", nil)
+	require.NoError(t, err)
+	require.True(t, draft.IsDraft())
+
+	// a bug never committed can't be published
+	require.Error(t, draft.Publish(repo))
+
+	err = draft.Commit(repo)
+	require.NoError(t, err)
+
+	read, err := ReadLocal(repo, draft.Id())
+	require.NoError(t, err)
+	require.True(t, read.IsDraft())
+
+	ids, err := ListLocalIds(repo)
+	require.NoError(t, err)
+	require.Equal(t, []entity.Id{draft.Id()}, ids)
+
+	// drafts are not pushed
+	_, err = Push(repo, "remote")
+	require.NoError(t, err)
+	remoteIds, err := ListLocalIds(remote)
+	require.NoError(t, err)
+	require.Empty(t, remoteIds)
+
+	err = draft.Publish(repo)
+	require.NoError(t, err)
+	require.False(t, draft.IsDraft())
+
+	// publishing twice is an error
+	require.Error(t, draft.Publish(repo))
+
+	read, err = ReadLocal(repo, draft.Id())
+	require.NoError(t, err)
+	require.False(t, read.IsDraft())
+	equivalentBug(t, draft, read)
+
+	_, err = Push(repo, "remote")
+	require.NoError(t, err)
+	remoteIds, err = ListLocalIds(remote)
+	require.NoError(t, err)
+	require.Equal(t, []entity.Id{draft.Id()}, remoteIds)
+}
+
+func TestBugSignatures(t *testing.T) {
+	repo := repository.NewMockRepoForTest()
+
+	rene := identity.NewIdentity("René Descartes", "rene@descartes.fr")
+	err := rene.Commit(repo)
+	require.NoError(t, err)
+
+	b, _, err := Create(rene, time.Now().Unix(), "title", "message")
+	require.NoError(t, err)
+	err = b.Commit(repo)
+	require.NoError(t, err)
+
+	_, err = AddComment(b, rene, time.Now().Unix(), "message2")
+	require.NoError(t, err)
+
+	// staged operations are not signed yet
+	signatures, err := b.Signatures(repo)
+	require.NoError(t, err)
+	require.Len(t, signatures, 1)
+
+	err = b.Commit(repo)
+	require.NoError(t, err)
+
+	signatures, err = b.Signatures(repo)
+	require.NoError(t, err)
+	require.Len(t, signatures, 2)
+	for _, signature := range signatures {
+		require.Equal(t, repository.SignatureNone, signature.Status)
+		require.Len(t, signature.Operations, 1)
+	}
+
+	require.NoError(t, b.VerifySignatures(repo))
+}
+
+func TestBugAuthorSignatures(t *testing.T) {
+	repo := repository.NewMockRepoForTest()
+
+	rene := identity.NewIdentity("René Descartes", "rene@descartes.fr")
+	err := rene.Commit(repo)
+	require.NoError(t, err)
+
+	b, _, err := Create(rene, time.Now().Unix(), "title", "message")
+	require.NoError(t, err)
+	err = b.Commit(repo)
+	require.NoError(t, err)
+
+	// register a key after the first commit
+	pgpEntity, err := openpgp.NewEntity("René Descartes", "", "rene@descartes.fr", nil)
+	require.NoError(t, err)
+	var pubKey bytes.Buffer
+	w, err := armor.Encode(&pubKey, openpgp.PublicKeyType, nil)
+	require.NoError(t, err)
+	require.NoError(t, pgpEntity.Serialize(w))
+	require.NoError(t, w.Close())
+
+	key, err := identity.NewKeyFromArmored(pubKey.String())
+	require.NoError(t, err)
+	rene.Mutate(func(orig identity.Mutator) identity.Mutator {
+		orig.Keys = []*identity.Key{key}
+		return orig
+	})
+	err = rene.Commit(repo)
+	require.NoError(t, err)
+
+	require.NoError(t, b.VerifySignatures(repo))
+
+	// the mock repo doesn't sign, so the new commit is not signed by its author
+	_, err = AddComment(b, rene, time.Now().Unix(), "message2")
+	require.NoError(t, err)
+	err = b.Commit(repo)
+	require.NoError(t, err)
+
+	signatures, err := b.Signatures(repo)
+	require.NoError(t, err)
+	require.Len(t, signatures, 2)
+	require.Equal(t, AuthorNoKey, signatures[0].AuthorStatus)
+	require.Equal(t, AuthorUnsigned, signatures[1].AuthorStatus)
+
+	require.Error(t, b.VerifySignatures(repo))
+}
+
+func TestPackSigningKey(t *testing.T) {
+	repo := repository.NewMockRepoForTest()
+
+	newKey := func() *identity.Key {
+		pgpEntity, err := openpgp.NewEntity("René Descartes", "", "rene@descartes.fr", nil)
+		require.NoError(t, err)
+		var pubKey bytes.Buffer
+		w, err := armor.Encode(&pubKey, openpgp.PublicKeyType, nil)
+		require.NoError(t, err)
+		require.NoError(t, pgpEntity.Serialize(w))
+		require.NoError(t, w.Close())
+		key, err := identity.NewKeyFromArmored(pubKey.String())
+		require.NoError(t, err)
+		return key
+	}
+	oldKey, currentKey := newKey(), newKey()
+
+	rene := identity.NewIdentity("René Descartes", "rene@descartes.fr")
+	rene.Mutate(func(orig identity.Mutator) identity.Mutator {
+		orig.Keys = []*identity.Key{oldKey, currentKey}
+		return orig
+	})
+	require.NoError(t, rene.Commit(repo))
+	require.NoError(t, identity.SetUserIdentity(repo, rene))
+
+	isaac := identity.NewIdentity("Isaac Newton", "isaac@newton.uk")
+	isaac.Mutate(func(orig identity.Mutator) identity.Mutator {
+		orig.Keys = []*identity.Key{newKey()}
+		return orig
+	})
+	require.NoError(t, isaac.Commit(repo))
+
+	private := make(map[string]bool)
+	defer func(orig func(string) bool) { hasPrivateKey = orig }(hasPrivateKey)
+	hasPrivateKey = func(keyId string) bool {
+		return private[keyId]
+	}
+
+	packOf := func(author identity.Interface) OperationPack {
+		op := NewAddCommentOp(author, time.Now().Unix(), "message", nil)
+		return OperationPack{Operations: []Operation{op}, editTime: 10}
+	}
+
+	// without a private key, the commit is not signed
+	_, sign := packSigningKey(repo, packOf(rene))
+	require.False(t, sign)
+
+	// the most recent usable key of the local user is used
+	private[oldKey.SigningKeyId()] = true
+	keyId, sign := packSigningKey(repo, packOf(rene))
+	require.True(t, sign)
+	require.Equal(t, oldKey.SigningKeyId(), keyId)
+
+	private[currentKey.SigningKeyId()] = true
+	keyId, sign = packSigningKey(repo, packOf(rene))
+	require.True(t, sign)
+	require.Equal(t, currentKey.SigningKeyId(), keyId)
+
+	// the operations of another author are not signed by the local user
+	for _, key := range isaac.Keys() {
+		private[key.SigningKeyId()] = true
+	}
+	_, sign = packSigningKey(repo, packOf(isaac))
+	require.False(t, sign)
+
+	// unless the signing is enabled in git, with the key configured there
+	require.NoError(t, repo.LocalConfig().StoreBool("commit.gpgsign", true))
+	keyId, sign = packSigningKey(repo, packOf(isaac))
+	require.True(t, sign)
+	require.Equal(t, "", keyId)
+}
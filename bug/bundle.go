@@ -0,0 +1,65 @@
+package bug
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+// BundleRefs return the refs of the given local bugs to put in a git bundle,
+// or the refs of all of them if ids is nil. Like with Push, the drafts are
+// left out.
+func BundleRefs(repo repository.Repo, ids []entity.Id) ([]string, error) {
+	if ids == nil {
+		return repo.ListRefs(bugsRefPattern)
+	}
+
+	var refs []string
+	for _, id := range ids {
+		exist, err := repo.RefExist(bugsRefPattern + id.String())
+		if err != nil {
+			return nil, err
+		}
+		if exist {
+			refs = append(refs, bugsRefPattern+id.String())
+		}
+	}
+	return refs, nil
+}
+
+// TrackBundledRefs point the remote-tracking refs of a remote to the bugs of
+// a git bundle, as a Fetch would do, to merge them with MergeAll
+func TrackBundledRefs(repo repository.Repo, remote string, refs map[string]repository.Hash) error {
+	remoteRefSpec := fmt.Sprintf(bugsRemoteRefPattern, remote)
+
+	for ref, hash := range refs {
+		if !strings.HasPrefix(ref, bugsRefPattern) {
+			continue
+		}
+		err := repo.UpdateRef(remoteRefSpec+strings.TrimPrefix(ref, bugsRefPattern), hash)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RemoveRemoteRefs remove all the remote-tracking refs of a remote
+func RemoveRemoteRefs(repo repository.Repo, remote string) error {
+	refs, err := repo.ListRefs(fmt.Sprintf(bugsRemoteRefPattern, remote))
+	if err != nil {
+		return err
+	}
+
+	for _, ref := range refs {
+		err = repo.RemoveRef(ref)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
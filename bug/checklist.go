@@ -0,0 +1,72 @@
+package bug
+
+import (
+	"regexp"
+	"strings"
+)
+
+// a markdown task list item, like "- [ ] do something" or "* [x] done"
+var checklistItemRegexp = regexp.MustCompile(`^(\s*[-*+]\s+\[)([ xX])(\]\s+)(.*)$`)
+
+// ChecklistItem is a markdown checkbox found in a comment
+type ChecklistItem struct {
+	Text    string
+	Checked bool
+}
+
+// ParseChecklist return the checkboxes found in a message, in order
+func ParseChecklist(message string) []ChecklistItem {
+	var result []ChecklistItem
+
+	for _, line := range strings.Split(message, "\n") {
+		matches := checklistItemRegexp.FindStringSubmatch(line)
+		if matches == nil {
+			continue
+		}
+		result = append(result, ChecklistItem{
+			Text:    strings.TrimSpace(matches[4]),
+			Checked: matches[2] != " ",
+		})
+	}
+
+	return result
+}
+
+// setChecklistItem rewrite the checkbox at the given index of a message. It
+// returns false if there is no such checkbox.
+func setChecklistItem(message string, item int, checked bool) (string, bool) {
+	lines := strings.Split(message, "\n")
+
+	i := 0
+	for n, line := range lines {
+		matches := checklistItemRegexp.FindStringSubmatch(line)
+		if matches == nil {
+			continue
+		}
+		if i == item {
+			mark := " "
+			if checked {
+				mark = "x"
+			}
+			lines[n] = matches[1] + mark + matches[3] + matches[4]
+			return strings.Join(lines, "\n"), true
+		}
+		i++
+	}
+
+	return message, false
+}
+
+// ChecklistProgress return the number of checked items and the total number
+// of items of all the checklists of the bug's comments
+func (snap *Snapshot) ChecklistProgress() (done int, total int) {
+	for _, comment := range snap.Comments {
+		for _, item := range ParseChecklist(comment.Message) {
+			total++
+			if item.Checked {
+				done++
+			}
+		}
+	}
+	return done, total
+}
@@ -0,0 +1,208 @@
+package bug
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/MichaelMure/git-bug/util/text"
+)
+
+// CodeRefKind is the kind of code location a bug can reference
+type CodeRefKind int
+
+const (
+	_ CodeRefKind = iota
+	CommitCodeRef
+	// a file, optionally with a line
+	FileCodeRef
+	BranchCodeRef
+)
+
+func (k CodeRefKind) String() string {
+	switch k {
+	case CommitCodeRef:
+		return "commit"
+	case FileCodeRef:
+		return "file"
+	case BranchCodeRef:
+		return "branch"
+	default:
+		return "unknown code reference"
+	}
+}
+
+func CodeRefKindFromString(str string) (CodeRefKind, error) {
+	cleaned := strings.ToLower(strings.TrimSpace(str))
+
+	switch cleaned {
+	case "commit":
+		return CommitCodeRef, nil
+	case "file":
+		return FileCodeRef, nil
+	case "branch":
+		return BranchCodeRef, nil
+	default:
+		return 0, fmt.Errorf("unknown code reference kind %s", str)
+	}
+}
+
+func (k CodeRefKind) Validate() error {
+	if k < CommitCodeRef || k > BranchCodeRef {
+		return fmt.Errorf("invalid")
+	}
+
+	return nil
+}
+
+// CodeRefRole qualify how a code location relate to a bug
+type CodeRefRole int
+
+const (
+	_ CodeRefRole = iota
+	MentionedCodeRef
+	// the bug has been introduced by this code
+	IntroducedByCodeRef
+	// the bug has been fixed by this code
+	FixedByCodeRef
+)
+
+func (r CodeRefRole) String() string {
+	switch r {
+	case MentionedCodeRef:
+		return "mentioned"
+	case IntroducedByCodeRef:
+		return "introduced-by"
+	case FixedByCodeRef:
+		return "fixed-by"
+	default:
+		return "unknown role"
+	}
+}
+
+func CodeRefRoleFromString(str string) (CodeRefRole, error) {
+	cleaned := strings.ToLower(strings.TrimSpace(str))
+
+	switch cleaned {
+	case "mentioned", "":
+		return MentionedCodeRef, nil
+	case "introduced-by", "introduced":
+		return IntroducedByCodeRef, nil
+	case "fixed-by", "fixed":
+		return FixedByCodeRef, nil
+	default:
+		return 0, fmt.Errorf("unknown code reference role %s", str)
+	}
+}
+
+func (r CodeRefRole) Validate() error {
+	if r < MentionedCodeRef || r > FixedByCodeRef {
+		return fmt.Errorf("invalid")
+	}
+
+	return nil
+}
+
+// CodeRef is a typed link from a bug to a location in the code: a commit, a
+// file (and line) or a branch
+type CodeRef struct {
+	Kind CodeRefKind `json:"kind"`
+	Role CodeRefRole `json:"role"`
+	// Target is the commit hash, the file path or the branch name
+	Target string `json:"target"`
+	// Line is the line in the file, starting at 1. 0 means the whole file.
+	Line int `json:"line,omitempty"`
+}
+
+// ParseCodeRef build a CodeRef from its human form: a commit hash, a
+// path[:line] or a branch name, depending on the kind
+func ParseCodeRef(kind CodeRefKind, role CodeRefRole, raw string) (CodeRef, error) {
+	ref := CodeRef{Kind: kind, Role: role, Target: strings.TrimSpace(raw)}
+
+	if kind == FileCodeRef {
+		if i := strings.LastIndex(ref.Target, ":"); i >= 0 {
+			// only split if what follow is a line number
+			if line, err := strconv.Atoi(ref.Target[i+1:]); err == nil {
+				ref.Target = ref.Target[:i]
+				ref.Line = line
+			}
+		}
+	}
+
+	if kind == CommitCodeRef {
+		ref.Target = strings.ToLower(ref.Target)
+	}
+
+	return ref, ref.Validate()
+}
+
+func (r CodeRef) Validate() error {
+	if err := r.Kind.Validate(); err != nil {
+		return fmt.Errorf("kind %v", err)
+	}
+
+	if err := r.Role.Validate(); err != nil {
+		return fmt.Errorf("role %v", err)
+	}
+
+	if text.Empty(r.Target) {
+		return fmt.Errorf("empty target")
+	}
+
+	if strings.Contains(r.Target, "\n") || !text.Safe(r.Target) {
+		return fmt.Errorf("target should be a single printable line")
+	}
+
+	switch r.Kind {
+	case CommitCodeRef:
+		if len(r.Target) < 7 || len(r.Target) > 64 {
+			return fmt.Errorf("commit hash should have between 7 and 64 characters")
+		}
+		for _, c := range r.Target {
+			if !(c >= '0' && c <= '9') && !(c >= 'a' && c <= 'f') {
+				return fmt.Errorf("commit hash should be hexadecimal")
+			}
+		}
+	case FileCodeRef:
+		if r.Line < 0 {
+			return fmt.Errorf("negative line")
+		}
+	case BranchCodeRef:
+		if strings.ContainsAny(r.Target, " \t") {
+			return fmt.Errorf("branch name should not contain spaces")
+		}
+	}
+
+	if r.Kind != FileCodeRef && r.Line != 0 {
+		return fmt.Errorf("only a file reference can have a line")
+	}
+
+	return nil
+}
+
+func (r CodeRef) String() string {
+	return fmt.Sprintf("%s %s", r.Role, r.Location())
+}
+
+// Location return a short human form of the referenced code, without the role
+func (r CodeRef) Location() string {
+	target := r.Target
+	if r.Kind == CommitCodeRef && len(target) > 10 {
+		target = target[:10]
+	}
+	if r.Line > 0 {
+		target = fmt.Sprintf("%s:%d", target, r.Line)
+	}
+	return fmt.Sprintf("%s %s", r.Kind, target)
+}
+
+// CodeRefsWithRole return the code references of the given role
+func (snap *Snapshot) CodeRefsWithRole(role CodeRefRole) []CodeRef {
+	var result []CodeRef
+	for _, r := range snap.CodeRefs {
+		if r.Role == role {
+			result = append(result, r)
+		}
+	}
+	return result
+}
@@ -15,6 +15,16 @@ type Comment struct {
 	Author  identity.Interface
 	Message string
 	Files   []repository.Hash
+	// ReplyTo is the id of the comment this comment is answering to, if any
+	ReplyTo entity.Id
+	// Redaction is set if the content of the comment has been removed
+	Redaction *Redaction
+	// Minimized is set if the comment has been collapsed, as resolved or outdated
+	Minimized MinimizeReason
+	// Pinned is true if the comment should be displayed at the top of the bug
+	Pinned bool
+	// CoAuthors are the additional authors of the comment, if any
+	CoAuthors []identity.Interface
 
 	// Creation time of the comment.
 	// Should be used only for human display, never for ordering as we can't rely on it in a distributed system.
@@ -31,6 +41,11 @@ func (c Comment) Id() entity.Id {
 	return c.id
 }
 
+// Authors return the author of the comment followed by its co-authors
+func (c Comment) Authors() []identity.Interface {
+	return append([]identity.Interface{c.Author}, c.CoAuthors...)
+}
+
 // FormatTimeRel format the UnixTime of the comment for human consumption
 func (c Comment) FormatTimeRel() string {
 	return humanize.Time(c.UnixTime.Time())
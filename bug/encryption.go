@@ -0,0 +1,174 @@
+package bug
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/openpgp"
+	// the hash assumed for the keys not stating their preferred ones
+	_ "golang.org/x/crypto/ripemd160"
+
+	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/identity"
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+const recipientsEntryName = "recipients"
+
+// ErrBugEncrypted is returned when reading a confidential bug without the
+// secret key of one of its recipients
+var ErrBugEncrypted = errors.New("bug is encrypted and can't be decrypted with the available keys")
+
+// decrypt a confidential payload. By default, it goes through gpg to use the
+// secret keys of the user, where they already live (gpg-agent, smartcard ...).
+var decrypt = func(data []byte) ([]byte, error) {
+	var stdout, stderr bytes.Buffer
+
+	cmd := exec.Command("gpg", "--batch", "--quiet", "--decrypt")
+	cmd.Stdin = bytes.NewReader(data)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("gpg: %s", strings.TrimSpace(stderr.String()))
+	}
+
+	return stdout.Bytes(), nil
+}
+
+// SetRecipients make the bug confidential: the operations will be encrypted
+// for the public keys of the given identities, so that only them can read it.
+// This can only be done before the first commit, as anything already stored
+// in clear would stay readable.
+func (bug *Bug) SetRecipients(recipients []identity.Interface) error {
+	if bug.lastCommit != "" {
+		return fmt.Errorf("a bug already stored can't be made confidential")
+	}
+
+	if len(recipients) == 0 {
+		return fmt.Errorf("a confidential bug need at least one recipient")
+	}
+
+	ids := make([]entity.Id, len(recipients))
+	for i, recipient := range recipients {
+		if len(encryptionKeys(recipient)) == 0 {
+			return fmt.Errorf("identity %s has no OpenPGP public key to encrypt for", recipient.DisplayName())
+		}
+		ids[i] = recipient.Id()
+	}
+
+	bug.recipients = ids
+
+	return nil
+}
+
+// encryptionKeys return the keys of an identity a bug can be encrypted for.
+// SSH keys can only sign, only the OpenPGP keys are kept.
+func encryptionKeys(id identity.Interface) []*identity.Key {
+	var keys []*identity.Key
+	for _, key := range id.Keys() {
+		if !key.IsSSH() {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// IsConfidential return true if the bug operations are encrypted
+func (bug *Bug) IsConfidential() bool {
+	return len(bug.recipients) > 0
+}
+
+// Recipients return the identities able to read a confidential bug
+func (bug *Bug) Recipients() []entity.Id {
+	return bug.recipients
+}
+
+// writeEncrypted serialize the staging OperationPack, encrypt it for the
+// recipients and store it as a git blob
+func (bug *Bug) writeEncrypted(repo repository.ClockedRepo) (repository.Hash, error) {
+	data, err := bug.staging.serialize()
+	if err != nil {
+		return "", err
+	}
+
+	var to openpgp.EntityList
+	for _, id := range bug.recipients {
+		recipient, err := identity.ReadLocal(repo, id)
+		if err != nil {
+			return "", errors.Wrapf(err, "can't read recipient %s", id.Human())
+		}
+
+		keys := encryptionKeys(recipient)
+		if len(keys) == 0 {
+			return "", fmt.Errorf("identity %s has no OpenPGP public key to encrypt for", recipient.DisplayName())
+		}
+
+		for _, key := range keys {
+			entities, err := openpgp.ReadArmoredKeyRing(strings.NewReader(key.PubKey))
+			if err != nil {
+				return "", errors.Wrapf(err, "invalid public key %s", key.Fingerprint)
+			}
+			to = append(to, entities...)
+		}
+	}
+
+	var buf bytes.Buffer
+	w, err := openpgp.Encrypt(&buf, to, nil, nil, nil)
+	if err != nil {
+		return "", err
+	}
+	if _, err := w.Write(data); err != nil {
+		return "", err
+	}
+	if err := w.Close(); err != nil {
+		return "", err
+	}
+
+	return repo.StoreData(buf.Bytes())
+}
+
+// writeRecipients store the list of recipients as a git blob
+func (bug *Bug) writeRecipients(repo repository.ClockedRepo) (repository.Hash, error) {
+	data, err := json.Marshal(bug.recipients)
+	if err != nil {
+		return "", err
+	}
+
+	return repo.StoreData(data)
+}
+
+// readRecipients read the list of recipients of a confidential bug
+func readRecipients(repo repository.ClockedRepo, hash repository.Hash) ([]entity.Id, error) {
+	data, err := repo.ReadData(hash)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read git blob data")
+	}
+
+	var recipients []entity.Id
+	if err := json.Unmarshal(data, &recipients); err != nil {
+		return nil, errors.Wrap(err, "failed to decode the recipients")
+	}
+
+	for _, id := range recipients {
+		if err := id.Validate(); err != nil {
+			return nil, errors.Wrap(err, "invalid recipient")
+		}
+	}
+
+	return recipients, nil
+}
+
+// readEncrypted read and decrypt a confidential payload
+func readEncrypted(data []byte) ([]byte, error) {
+	plain, err := decrypt(data)
+	if err != nil {
+		return nil, ErrBugEncrypted
+	}
+
+	return plain, nil
+}
@@ -0,0 +1,129 @@
+package bug
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/openpgp"
+	"golang.org/x/crypto/openpgp/armor"
+	"golang.org/x/crypto/ssh"
+
+	"github.com/MichaelMure/git-bug/identity"
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+func TestConfidentialBug(t *testing.T) {
+	repo := repository.NewMockRepoForTest()
+
+	entity, err := openpgp.NewEntity("René Descartes", "", "rene@descartes.fr", nil)
+	require.NoError(t, err)
+
+	var pubKey bytes.Buffer
+	w, err := armor.Encode(&pubKey, openpgp.PublicKeyType, nil)
+	require.NoError(t, err)
+	require.NoError(t, entity.Serialize(w))
+	require.NoError(t, w.Close())
+
+	sshPub, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	sshKey, err := ssh.NewPublicKey(sshPub)
+	require.NoError(t, err)
+	sshIdentityKey, err := identity.NewKeyFromSSH(string(ssh.MarshalAuthorizedKey(sshKey)))
+	require.NoError(t, err)
+
+	// rene has both an SSH key to sign and an OpenPGP key to encrypt
+	rene := identity.NewIdentity("René Descartes", "rene@descartes.fr")
+	rene.Mutate(func(orig identity.Mutator) identity.Mutator {
+		orig.Keys = []*identity.Key{sshIdentityKey, {
+			Fingerprint: fmt.Sprintf("%X", entity.PrimaryKey.Fingerprint),
+			PubKey:      pubKey.String(),
+		}}
+		return orig
+	})
+	err = rene.Commit(repo)
+	require.NoError(t, err)
+
+	isaac := identity.NewIdentity("Isaac Newton", "isaac@newton.uk")
+	err = isaac.Commit(repo)
+	require.NoError(t, err)
+
+	// an SSH key can't be used to encrypt
+	blaise := identity.NewIdentity("Blaise Pascal", "blaise@pascal.fr")
+	blaise.Mutate(func(orig identity.Mutator) identity.Mutator {
+		orig.Keys = []*identity.Key{sshIdentityKey}
+		return orig
+	})
+	err = blaise.Commit(repo)
+	require.NoError(t, err)
+
+	// decrypt with the in-memory secret key instead of gpg
+	defer func(orig func([]byte) ([]byte, error)) { decrypt = orig }(decrypt)
+	decrypt = func(data []byte) ([]byte, error) {
+		md, err := openpgp.ReadMessage(bytes.NewReader(data), openpgp.EntityList{entity}, nil, nil)
+		if err != nil {
+			return nil, err
+		}
+		return ioutil.ReadAll(md.UnverifiedBody)
+	}
+
+	b, _, err := Create(rene, time.Now().Unix(), "secret", "a security issue")
+	require.NoError(t, err)
+
+	// recipients need a public key
+	require.Error(t, b.SetRecipients(nil))
+	require.Error(t, b.SetRecipients([]identity.Interface{isaac}))
+	require.Error(t, b.SetRecipients([]identity.Interface{rene, blaise}))
+
+	err = b.SetRecipients([]identity.Interface{rene})
+	require.NoError(t, err)
+	require.True(t, b.IsConfidential())
+
+	err = b.Commit(repo)
+	require.NoError(t, err)
+
+	// can't be changed once stored
+	require.Error(t, b.SetRecipients([]identity.Interface{rene}))
+
+	// the payload is not stored in clear
+	treeEntries, err := repo.ReadTree(b.lastCommit)
+	require.NoError(t, err)
+	for _, entry := range treeEntries {
+		if entry.Name == opsEntryName {
+			data, err := repo.ReadData(entry.Hash)
+			require.NoError(t, err)
+			require.NotContains(t, string(data), "a security issue")
+		}
+	}
+
+	_, err = AddComment(b, rene, time.Now().Unix(), "more details")
+	require.NoError(t, err)
+	err = b.Commit(repo)
+	require.NoError(t, err)
+
+	read, err := ReadLocal(repo, b.Id())
+	require.NoError(t, err)
+	require.Equal(t, b.Recipients(), read.Recipients())
+	snap := read.Compile()
+	require.True(t, snap.IsConfidential())
+	require.Equal(t, "a security issue", read.Compile().Comments[0].Message)
+	require.Equal(t, "more details", read.Compile().Comments[1].Message)
+
+	// without the secret key, the bug is hidden
+	decrypt = func(data []byte) ([]byte, error) {
+		return nil, errors.New("no secret key")
+	}
+
+	_, err = ReadLocal(repo, b.Id())
+	require.Equal(t, ErrBugEncrypted, err)
+
+	for streamed := range ReadAllLocal(repo) {
+		require.NoError(t, streamed.Err)
+	}
+}
@@ -0,0 +1,288 @@
+package bug
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/MichaelMure/git-bug/repository"
+	"github.com/MichaelMure/git-bug/util/text"
+)
+
+// the schema is stored in the repository config as:
+// git-bug.field.<name>.type = <type>
+// git-bug.field.<name>.values = <value1>,<value2>
+const fieldConfigKeyPrefix = "git-bug.field."
+
+// FieldDateLayout is the expected layout for the value of a FieldDate
+const FieldDateLayout = "2006-01-02"
+
+// FieldType is the type of the value of a custom field
+type FieldType int
+
+const (
+	_ FieldType = iota
+	FieldString
+	FieldEnum
+	FieldNumber
+	FieldDate
+)
+
+func (ft FieldType) String() string {
+	switch ft {
+	case FieldString:
+		return "string"
+	case FieldEnum:
+		return "enum"
+	case FieldNumber:
+		return "number"
+	case FieldDate:
+		return "date"
+	default:
+		return "unknown field type"
+	}
+}
+
+func FieldTypeFromString(str string) (FieldType, error) {
+	cleaned := strings.ToLower(strings.TrimSpace(str))
+
+	switch cleaned {
+	case "string":
+		return FieldString, nil
+	case "enum":
+		return FieldEnum, nil
+	case "number":
+		return FieldNumber, nil
+	case "date":
+		return FieldDate, nil
+	default:
+		return 0, fmt.Errorf("unknown field type")
+	}
+}
+
+func (ft FieldType) Validate() error {
+	if ft < FieldString || ft > FieldDate {
+		return fmt.Errorf("invalid")
+	}
+
+	return nil
+}
+
+// FieldDefinition describe a custom field that can be set on a bug
+type FieldDefinition struct {
+	Name string
+	Type FieldType
+	// Values hold the allowed values for a FieldEnum
+	Values []string
+}
+
+func (fd FieldDefinition) Validate() error {
+	if err := ValidateFieldName(fd.Name); err != nil {
+		return err
+	}
+
+	if err := fd.Type.Validate(); err != nil {
+		return fmt.Errorf("field %s: type %v", fd.Name, err)
+	}
+
+	if fd.Type == FieldEnum && len(fd.Values) == 0 {
+		return fmt.Errorf("field %s: an enum needs at least one value", fd.Name)
+	}
+
+	if fd.Type != FieldEnum && len(fd.Values) > 0 {
+		return fmt.Errorf("field %s: only an enum can have a set of values", fd.Name)
+	}
+
+	for _, value := range fd.Values {
+		if err := validateFieldValueText(value); err != nil {
+			return fmt.Errorf("field %s: enum value %v", fd.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// ValidateValue check that the given value is legal for this field. An empty
+// value is always legal, as it unset the field.
+func (fd FieldDefinition) ValidateValue(value string) error {
+	if value == "" {
+		return nil
+	}
+
+	if err := validateFieldValueText(value); err != nil {
+		return err
+	}
+
+	switch fd.Type {
+	case FieldString:
+		return nil
+
+	case FieldEnum:
+		for _, v := range fd.Values {
+			if v == value {
+				return nil
+			}
+		}
+		return fmt.Errorf("%s is not one of %s", value, strings.Join(fd.Values, ", "))
+
+	case FieldNumber:
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return fmt.Errorf("%s is not a number", value)
+		}
+		return nil
+
+	case FieldDate:
+		if _, err := time.Parse(FieldDateLayout, value); err != nil {
+			return fmt.Errorf("%s is not a date (expected YYYY-MM-DD)", value)
+		}
+		return nil
+
+	default:
+		return fmt.Errorf("unknown field type")
+	}
+}
+
+// FieldSchema is the set of custom fields defined for a repository
+type FieldSchema map[string]FieldDefinition
+
+// Definition return the definition of the given field
+func (fs FieldSchema) Definition(name string) (FieldDefinition, error) {
+	def, ok := fs[name]
+	if !ok {
+		return FieldDefinition{}, fmt.Errorf("field %s is not defined in the schema", name)
+	}
+	return def, nil
+}
+
+// ValidateValue check that the given field is defined and that the value
+// is legal for it
+func (fs FieldSchema) ValidateValue(name string, value string) error {
+	def, err := fs.Definition(name)
+	if err != nil {
+		return err
+	}
+
+	if err := def.ValidateValue(value); err != nil {
+		return fmt.Errorf("field %s: %v", name, err)
+	}
+
+	return nil
+}
+
+// Names return the sorted list of the defined fields
+func (fs FieldSchema) Names() []string {
+	result := make([]string, 0, len(fs))
+	for name := range fs {
+		result = append(result, name)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// ValidateFieldName check that a field name is usable
+func ValidateFieldName(name string) error {
+	if text.Empty(name) {
+		return fmt.Errorf("empty field name")
+	}
+
+	// the name is used as a config key and in the query language
+	for _, r := range name {
+		if !(r == '-' || r == '_' ||
+			(r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9')) {
+			return fmt.Errorf("field name %s should only contain letters, digits, - and _", name)
+		}
+	}
+
+	return nil
+}
+
+func validateFieldValueText(value string) error {
+	if strings.Contains(value, "\n") {
+		return fmt.Errorf("value should be a single line")
+	}
+
+	if !text.Safe(value) {
+		return fmt.Errorf("value is not fully printable")
+	}
+
+	return nil
+}
+
+// ReadFieldSchema read the custom field schema from the repository configuration
+func ReadFieldSchema(config repository.ConfigRead) (FieldSchema, error) {
+	pairs, err := config.ReadAll(fieldConfigKeyPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	schema := make(FieldSchema)
+
+	for key, value := range pairs {
+		key = strings.TrimPrefix(key, fieldConfigKeyPrefix)
+		split := strings.Split(key, ".")
+		if len(split) != 2 {
+			return nil, fmt.Errorf("invalid field config key %s", key)
+		}
+		name := split[0]
+
+		def := schema[name]
+		def.Name = name
+
+		switch split[1] {
+		case "type":
+			def.Type, err = FieldTypeFromString(value)
+			if err != nil {
+				return nil, fmt.Errorf("field %s: %v", name, err)
+			}
+		case "values":
+			def.Values = nil
+			for _, v := range strings.Split(value, ",") {
+				v = strings.TrimSpace(v)
+				if v != "" {
+					def.Values = append(def.Values, v)
+				}
+			}
+		default:
+			return nil, fmt.Errorf("field %s: unknown config key %s", name, split[1])
+		}
+
+		schema[name] = def
+	}
+
+	for _, def := range schema {
+		if err := def.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	return schema, nil
+}
+
+// StoreFieldDefinition add or replace a custom field in the repository configuration
+func StoreFieldDefinition(config repository.Config, def FieldDefinition) error {
+	if err := def.Validate(); err != nil {
+		return err
+	}
+
+	// cleanup a previous definition, if any
+	_ = RemoveFieldDefinition(config, def.Name)
+
+	err := config.StoreString(fieldConfigKeyPrefix+def.Name+".type", def.Type.String())
+	if err != nil {
+		return err
+	}
+
+	if len(def.Values) > 0 {
+		return config.StoreString(fieldConfigKeyPrefix+def.Name+".values", strings.Join(def.Values, ","))
+	}
+
+	return nil
+}
+
+// RemoveFieldDefinition remove a custom field from the repository configuration.
+// Values already set on bugs are kept untouched.
+func RemoveFieldDefinition(config repository.Config, name string) error {
+	return config.RemoveAll(fieldConfigKeyPrefix + name + ".")
+}
@@ -0,0 +1,135 @@
+package bug
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FrontMatter is the content of the front-matter of a markdown file, as used
+// by the templates and the files describing a new bug. Only a small subset of
+// YAML is supported: "key: value" lines, and blocks of indented "name: value"
+// lines under a key.
+type FrontMatter struct {
+	Values map[string]string
+	Blocks map[string]map[string]string
+}
+
+// SplitFrontMatter separate the front-matter lines from the content of a
+// markdown file. It returns nil lines if there is no front-matter.
+func SplitFrontMatter(raw string) ([]string, string, error) {
+	raw = strings.Replace(raw, "\r\n", "\n", -1)
+	lines := strings.Split(raw, "\n")
+
+	if len(lines) == 0 || strings.TrimSpace(lines[0]) != "---" {
+		return nil, raw, nil
+	}
+
+	for i := 1; i < len(lines); i++ {
+		if strings.TrimSpace(lines[i]) == "---" {
+			return lines[1:i], strings.Join(lines[i+1:], "\n"), nil
+		}
+	}
+
+	return nil, "", fmt.Errorf("unterminated front-matter")
+}
+
+// ParseFrontMatter parse the lines of a front-matter. The keys listed in
+// blocks hold a block of indented lines instead of a value.
+func ParseFrontMatter(lines []string, blocks ...string) (*FrontMatter, error) {
+	fm := &FrontMatter{
+		Values: make(map[string]string),
+		Blocks: make(map[string]map[string]string),
+	}
+
+	isBlock := make(map[string]bool)
+	for _, block := range blocks {
+		isBlock[block] = true
+	}
+
+	currentBlock := ""
+
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+
+		split := strings.SplitN(trimmed, ":", 2)
+		if len(split) != 2 {
+			return nil, fmt.Errorf("invalid front-matter line \"%s\"", trimmed)
+		}
+		key := strings.TrimSpace(split[0])
+		value := unquoteFrontMatter(strings.TrimSpace(split[1]))
+
+		// indented lines are the entries of the current block
+		if line[0] == ' ' || line[0] == '\t' {
+			if currentBlock == "" {
+				return nil, fmt.Errorf("unexpected indented line \"%s\"", trimmed)
+			}
+			if fm.Blocks[currentBlock] == nil {
+				fm.Blocks[currentBlock] = make(map[string]string)
+			}
+			fm.Blocks[currentBlock][key] = value
+			continue
+		}
+
+		currentBlock = ""
+
+		if isBlock[key] {
+			if value != "" {
+				return nil, fmt.Errorf("%s should be an indented block of \"name: value\"", key)
+			}
+			currentBlock = key
+			continue
+		}
+
+		fm.Values[key] = value
+	}
+
+	return fm, nil
+}
+
+// List split a value written either as "a, b" or as "[a, b]"
+func (fm *FrontMatter) List(key string) []string {
+	value := strings.TrimSuffix(strings.TrimPrefix(fm.Values[key], "["), "]")
+
+	var result []string
+	for _, item := range strings.Split(value, ",") {
+		item = unquoteFrontMatter(strings.TrimSpace(item))
+		if item != "" {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// CheckKeys fail if the front-matter hold a key that is not in the given list
+func (fm *FrontMatter) CheckKeys(known ...string) error {
+	isKnown := make(map[string]bool)
+	for _, key := range known {
+		isKnown[key] = true
+	}
+
+	for key := range fm.Values {
+		if !isKnown[key] {
+			return fmt.Errorf("unknown front-matter key %s", key)
+		}
+	}
+	for key := range fm.Blocks {
+		if !isKnown[key] {
+			return fmt.Errorf("unknown front-matter key %s", key)
+		}
+	}
+
+	return nil
+}
+
+func unquoteFrontMatter(value string) string {
+	if len(value) >= 2 {
+		first, last := value[0], value[len(value)-1]
+		if (first == '"' && last == '"') || (first == '\'' && last == '\'') {
+			return value[1 : len(value)-1]
+		}
+	}
+	return value
+}
@@ -0,0 +1,39 @@
+package bug
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFrontMatter(t *testing.T) {
+	raw := "---\r\ntitle: \"[crash] \"\r\nlabels: [bug, 'triage']\r\nmetadata:\r\n  ci-job: 42\r\n---\r\n\r\nbody\r\n"
+
+	header, content, err := SplitFrontMatter(raw)
+	require.NoError(t, err)
+	assert.Equal(t, "\nbody\n", content)
+
+	fm, err := ParseFrontMatter(header, "metadata")
+	require.NoError(t, err)
+	assert.Equal(t, "[crash] ", fm.Values["title"])
+	assert.Equal(t, []string{"bug", "triage"}, fm.List("labels"))
+	assert.Empty(t, fm.List("missing"))
+	assert.Equal(t, map[string]string{"ci-job": "42"}, fm.Blocks["metadata"])
+
+	assert.NoError(t, fm.CheckKeys("title", "labels", "metadata"))
+	assert.Error(t, fm.CheckKeys("title", "labels"))
+
+	// no front-matter
+	header, content, err = SplitFrontMatter("just a message\n")
+	require.NoError(t, err)
+	assert.Nil(t, header)
+	assert.Equal(t, "just a message\n", content)
+
+	_, _, err = SplitFrontMatter("---\ntitle: foo\n")
+	assert.Error(t, err)
+	_, err = ParseFrontMatter([]string{"metadata: foo"}, "metadata")
+	assert.Error(t, err)
+	_, err = ParseFrontMatter([]string{"  foo: bar"}, "metadata")
+	assert.Error(t, err)
+}
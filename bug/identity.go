@@ -22,6 +22,32 @@ func (bug *Bug) EnsureIdentities(resolver identity.Resolver) error {
 
 			base.Author = i
 		}
+
+		for j, coAuthor := range base.CoAuthors {
+			if stub, ok := coAuthor.(*identity.IdentityStub); ok {
+				i, err := resolver.ResolveIdentity(stub.Id())
+				if err != nil {
+					return err
+				}
+
+				base.CoAuthors[j] = i
+			}
+		}
+
+		if op, ok := op.(*AssignOperation); ok {
+			for _, list := range [][]identity.Interface{op.Added, op.Removed} {
+				for j, assignee := range list {
+					if stub, ok := assignee.(*identity.IdentityStub); ok {
+						i, err := resolver.ResolveIdentity(stub.Id())
+						if err != nil {
+							return err
+						}
+
+						list[j] = i
+					}
+				}
+			}
+		}
 	}
 	return nil
 }
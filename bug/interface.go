@@ -43,6 +43,9 @@ type Interface interface {
 
 	// EditLamportTime return the Lamport time of the last edit
 	EditLamportTime() lamport.Time
+
+	// IsDraft return true if the bug has not been published yet
+	IsDraft() bool
 }
 
 func bugFromInterface(bug Interface) *Bug {
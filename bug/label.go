@@ -75,6 +75,14 @@ func (lc LabelColor) RGBA() color.RGBA {
 	return color.RGBA(lc)
 }
 
+// IsLight tell if the color is light, and so if a dark text is more
+// readable over it
+func (lc LabelColor) IsLight() bool {
+	// perceived brightness, from https://www.w3.org/TR/AERT/#color-contrast
+	brightness := (299*int(lc.R) + 587*int(lc.G) + 114*int(lc.B)) / 1000
+	return brightness > 128
+}
+
 func (lc LabelColor) Term256() Term256 {
 	red := Term256(lc.R) * 6 / 256
 	green := Term256(lc.G) * 6 / 256
@@ -92,6 +100,14 @@ func (t Term256) Escape() string {
 	return fmt.Sprintf("\x1b[38;5;%dm", t)
 }
 
+// EscapeBg return the escape sequence setting the color as background
+func (t Term256) EscapeBg() string {
+	if fcolor.NoColor {
+		return ""
+	}
+	return fmt.Sprintf("\x1b[48;5;%dm", t)
+}
+
 func (t Term256) Unescape() string {
 	if fcolor.NoColor {
 		return ""
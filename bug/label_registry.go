@@ -0,0 +1,177 @@
+package bug
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+// the registry is stored in the repository config as:
+// git-bug.label.<name>.color = #rrggbb
+// git-bug.label.<name>.description = <description>
+// git-bug.label.<name>.archived = true
+const labelRegistryConfigKeyPrefix = "git-bug.label."
+
+// LabelDefinition describe a label registered for a repository
+type LabelDefinition struct {
+	Name        Label
+	Color       LabelColor
+	Description string
+	// Archived labels are kept for the bugs already carrying them, but are
+	// not proposed anymore
+	Archived bool
+}
+
+func (ld LabelDefinition) Validate() error {
+	if err := ld.Name.Validate(); err != nil {
+		return fmt.Errorf("label %s: %v", ld.Name, err)
+	}
+
+	if strings.Contains(ld.Description, "\n") {
+		return fmt.Errorf("label %s: description should be a single line", ld.Name)
+	}
+
+	return nil
+}
+
+// LabelRegistry is the set of labels defined for a repository
+type LabelRegistry map[Label]LabelDefinition
+
+// Names return the sorted names of the registered labels
+func (lr LabelRegistry) Names() []Label {
+	result := make([]Label, 0, len(lr))
+	for name := range lr {
+		result = append(result, name)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i] < result[j]
+	})
+	return result
+}
+
+// Color return the color of a label, either the registered one or the one
+// computed from its name
+func (lr LabelRegistry) Color(label Label) LabelColor {
+	if def, ok := lr[label]; ok {
+		return def.Color
+	}
+	return label.Color()
+}
+
+// Hex return the color in the #rrggbb notation
+func (lc LabelColor) Hex() string {
+	return fmt.Sprintf("#%02x%02x%02x", lc.R, lc.G, lc.B)
+}
+
+// ParseLabelColor parse a color in the #rrggbb notation, the # being optional
+func ParseLabelColor(str string) (LabelColor, error) {
+	cleaned := strings.TrimPrefix(strings.TrimSpace(str), "#")
+	if len(cleaned) != 6 {
+		return LabelColor{}, fmt.Errorf("invalid color %s, expected #rrggbb", str)
+	}
+
+	value, err := strconv.ParseUint(cleaned, 16, 32)
+	if err != nil {
+		return LabelColor{}, fmt.Errorf("invalid color %s, expected #rrggbb", str)
+	}
+
+	return LabelColor{
+		R: uint8(value >> 16),
+		G: uint8(value >> 8),
+		B: uint8(value),
+		A: 255,
+	}, nil
+}
+
+// ReadLabelRegistry read the label registry from the repository configuration
+func ReadLabelRegistry(config repository.ConfigRead) (LabelRegistry, error) {
+	pairs, err := config.ReadAll(labelRegistryConfigKeyPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	registry := make(LabelRegistry)
+
+	for key, value := range pairs {
+		key = strings.TrimPrefix(key, labelRegistryConfigKeyPrefix)
+
+		// the label name can contain dots, the property can't
+		split := strings.LastIndex(key, ".")
+		if split <= 0 {
+			return nil, fmt.Errorf("invalid label config key %s", key)
+		}
+		name := Label(key[:split])
+
+		def, ok := registry[name]
+		if !ok {
+			def.Name = name
+			def.Color = name.Color()
+		}
+
+		switch key[split+1:] {
+		case "color":
+			def.Color, err = ParseLabelColor(value)
+			if err != nil {
+				return nil, fmt.Errorf("label %s: %v", name, err)
+			}
+		case "description":
+			def.Description = value
+		case "archived":
+			def.Archived, err = strconv.ParseBool(value)
+			if err != nil {
+				return nil, fmt.Errorf("label %s: invalid archived value %s", name, value)
+			}
+		default:
+			return nil, fmt.Errorf("label %s: unknown config key %s", name, key[split+1:])
+		}
+
+		registry[name] = def
+	}
+
+	for _, def := range registry {
+		if err := def.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	return registry, nil
+}
+
+// StoreLabelDefinition add or replace a label in the repository configuration
+func StoreLabelDefinition(config repository.Config, def LabelDefinition) error {
+	if err := def.Validate(); err != nil {
+		return err
+	}
+
+	// cleanup a previous definition, if any
+	_ = RemoveLabelDefinition(config, def.Name)
+
+	prefix := labelRegistryConfigKeyPrefix + def.Name.String()
+
+	err := config.StoreString(prefix+".color", def.Color.Hex())
+	if err != nil {
+		return err
+	}
+
+	if def.Description != "" {
+		err = config.StoreString(prefix+".description", def.Description)
+		if err != nil {
+			return err
+		}
+	}
+
+	if def.Archived {
+		return config.StoreBool(prefix+".archived", true)
+	}
+
+	return nil
+}
+
+// RemoveLabelDefinition remove a label from the repository configuration.
+// The bugs carrying this label are left untouched.
+func RemoveLabelDefinition(config repository.Config, name Label) error {
+	return config.RemoveAll(labelRegistryConfigKeyPrefix + name.String())
+}
@@ -0,0 +1,70 @@
+package bug
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+func TestParseLabelColor(t *testing.T) {
+	c, err := ParseLabelColor("#d73a4a")
+	require.NoError(t, err)
+	require.Equal(t, LabelColor{R: 0xd7, G: 0x3a, B: 0x4a, A: 255}, c)
+	require.Equal(t, "#d73a4a", c.Hex())
+
+	c, err = ParseLabelColor("00FF10")
+	require.NoError(t, err)
+	require.Equal(t, LabelColor{R: 0, G: 255, B: 16, A: 255}, c)
+
+	_, err = ParseLabelColor("#fff")
+	require.Error(t, err)
+	_, err = ParseLabelColor("#gggggg")
+	require.Error(t, err)
+}
+
+func TestLabelRegistry(t *testing.T) {
+	repo := repository.NewMockRepoForTest()
+	config := repo.LocalConfig()
+
+	registry, err := ReadLabelRegistry(config)
+	require.NoError(t, err)
+	require.Empty(t, registry)
+
+	// the taxonomy doesn't end up in the registry
+	require.NoError(t, config.StoreString("git-bug.labels.allowed", "kind/*"))
+
+	err = StoreLabelDefinition(config, LabelDefinition{
+		Name:        "kind/bug",
+		Color:       LabelColor{R: 0xd7, G: 0x3a, B: 0x4a, A: 255},
+		Description: "Something isn't working",
+	})
+	require.NoError(t, err)
+
+	err = StoreLabelDefinition(config, LabelDefinition{
+		Name:     "v1.0",
+		Color:    Label("v1.0").Color(),
+		Archived: true,
+	})
+	require.NoError(t, err)
+
+	err = StoreLabelDefinition(config, LabelDefinition{Name: "multi\nline"})
+	require.Error(t, err)
+
+	registry, err = ReadLabelRegistry(config)
+	require.NoError(t, err)
+	require.Equal(t, []Label{"kind/bug", "v1.0"}, registry.Names())
+	require.Equal(t, "Something isn't working", registry["kind/bug"].Description)
+	require.False(t, registry["kind/bug"].Archived)
+	require.True(t, registry["v1.0"].Archived)
+
+	require.Equal(t, "#d73a4a", registry.Color("kind/bug").Hex())
+	require.Equal(t, Label("other").Color(), registry.Color("other"))
+
+	require.NoError(t, RemoveLabelDefinition(config, "kind/bug"))
+
+	registry, err = ReadLabelRegistry(config)
+	require.NoError(t, err)
+	require.Equal(t, []Label{"v1.0"}, registry.Names())
+}
@@ -0,0 +1,121 @@
+package bug
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+// the taxonomy is stored in the repository config as:
+// git-bug.labels.allowed = <pattern>,<pattern>
+const labelTaxonomyConfigKey = "git-bug.labels.allowed"
+
+// ForcedLabelsMetaKey is the metadata key marking a LabelChangeOperation
+// that purposefully bypass the label taxonomy
+const ForcedLabelsMetaKey = "forced-labels"
+
+// LabelTaxonomy is the set of patterns restricting the labels that can be
+// added to the bugs of a repository, for example "kind/*" or "prio/P[0-3]".
+// The patterns use the syntax of path.Match. An empty taxonomy allow any label.
+type LabelTaxonomy []string
+
+// ReadLabelTaxonomy read the label taxonomy from the repository configuration
+func ReadLabelTaxonomy(config repository.ConfigRead) (LabelTaxonomy, error) {
+	raw, err := config.ReadString(labelTaxonomyConfigKey)
+	if err == repository.ErrNoConfigEntry {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var taxonomy LabelTaxonomy
+
+	for _, pattern := range strings.Split(raw, ",") {
+		pattern = strings.TrimSpace(pattern)
+		if pattern == "" {
+			continue
+		}
+
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid label pattern %s", pattern)
+		}
+
+		taxonomy = append(taxonomy, pattern)
+	}
+
+	return taxonomy, nil
+}
+
+// Allowed return true if the label match one of the patterns of the taxonomy
+func (lt LabelTaxonomy) Allowed(label Label) bool {
+	if len(lt) == 0 {
+		return true
+	}
+
+	for _, pattern := range lt {
+		if ok, _ := path.Match(pattern, label.String()); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ValidateLabels check that all the given labels are allowed by the taxonomy
+func (lt LabelTaxonomy) ValidateLabels(labels []string) error {
+	for _, label := range labels {
+		if !lt.Allowed(Label(label)) {
+			return fmt.Errorf("label %s doesn't match the label taxonomy (%s)",
+				label, strings.Join(lt, ", "))
+		}
+	}
+
+	return nil
+}
+
+// CheckOperation verify that the labels added by an operation are allowed.
+// Removing a label is always allowed, as well as forced changes.
+func (lt LabelTaxonomy) CheckOperation(op *LabelChangeOperation) error {
+	if _, forced := op.GetMetadata(ForcedLabelsMetaKey); forced {
+		return nil
+	}
+
+	for _, label := range op.Added {
+		if !lt.Allowed(label) {
+			return fmt.Errorf("operation %s add the label %s not allowed by the label taxonomy",
+				op.Id().Human(), label)
+		}
+	}
+
+	return nil
+}
+
+// CheckBug verify that the label changes of a bug are allowed by the taxonomy.
+// Operations already known (typically, already accepted locally) are skipped.
+func (lt LabelTaxonomy) CheckBug(bug *Bug, known map[entity.Id]struct{}) error {
+	if len(lt) == 0 {
+		return nil
+	}
+
+	it := NewOperationIterator(bug)
+	for it.Next() {
+		op, ok := it.Value().(*LabelChangeOperation)
+		if !ok {
+			continue
+		}
+
+		if _, ok := known[op.Id()]; ok {
+			continue
+		}
+
+		if err := lt.CheckOperation(op); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
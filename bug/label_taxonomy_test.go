@@ -0,0 +1,65 @@
+package bug
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/MichaelMure/git-bug/identity"
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+func TestReadLabelTaxonomy(t *testing.T) {
+	repo := repository.NewMockRepoForTest()
+	config := repo.LocalConfig()
+
+	taxonomy, err := ReadLabelTaxonomy(config)
+	require.NoError(t, err)
+	require.Empty(t, taxonomy)
+	require.True(t, taxonomy.Allowed("anything"))
+
+	require.NoError(t, config.StoreString("git-bug.labels.allowed", "kind/*, prio/P[0-3],good-first-issue"))
+
+	taxonomy, err = ReadLabelTaxonomy(config)
+	require.NoError(t, err)
+	require.Equal(t, LabelTaxonomy{"kind/*", "prio/P[0-3]", "good-first-issue"}, taxonomy)
+
+	require.True(t, taxonomy.Allowed("kind/bug"))
+	require.True(t, taxonomy.Allowed("prio/P2"))
+	require.True(t, taxonomy.Allowed("good-first-issue"))
+	require.False(t, taxonomy.Allowed("prio/P4"))
+	require.False(t, taxonomy.Allowed("bug"))
+
+	require.NoError(t, taxonomy.ValidateLabels([]string{"kind/bug", "prio/P0"}))
+	require.Error(t, taxonomy.ValidateLabels([]string{"kind/bug", "wontfix"}))
+
+	require.NoError(t, config.StoreString("git-bug.labels.allowed", "prio/P[0-3"))
+	_, err = ReadLabelTaxonomy(config)
+	require.Error(t, err)
+}
+
+func TestLabelTaxonomyCheckBug(t *testing.T) {
+	repo := repository.NewMockRepoForTest()
+	rene := identity.NewIdentity("René Descartes", "rene@descartes.fr")
+	err := rene.Commit(repo)
+	require.NoError(t, err)
+
+	unix := time.Now().Unix()
+	taxonomy := LabelTaxonomy{"kind/*"}
+
+	b, _, err := Create(rene, unix, "title", "message")
+	require.NoError(t, err)
+	_, _, err = ChangeLabels(b, rene, unix, []string{"kind/bug"}, nil)
+	require.NoError(t, err)
+	require.NoError(t, taxonomy.CheckBug(b, nil))
+
+	known := operationIds(b)
+
+	op, err := ForceChangeLabels(b, rene, unix, []string{"wontfix"}, []string{"kind/bug"})
+	require.NoError(t, err)
+	require.Error(t, taxonomy.CheckBug(b, known))
+
+	op.SetMetadata(ForcedLabelsMetaKey, "true")
+	require.NoError(t, taxonomy.CheckBug(b, known))
+}
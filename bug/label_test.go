@@ -33,3 +33,8 @@ func TestLabelRGBAEqual(t *testing.T) {
 
 	require.Equal(t, color1, color2)
 }
+
+func TestLabelColorIsLight(t *testing.T) {
+	require.True(t, LabelColor{R: 255, G: 235, B: 59, A: 255}.IsLight())
+	require.False(t, LabelColor{R: 63, G: 81, B: 181, A: 255}.IsLight())
+}
@@ -0,0 +1,125 @@
+package bug
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/MichaelMure/git-bug/repository"
+	"github.com/MichaelMure/git-bug/util/text"
+)
+
+// the registry is stored in the repository config as:
+// git-bug.milestone.<name>.due = YYYY-MM-DD
+// git-bug.milestone.<name>.description = <description>
+const milestoneRegistryConfigKeyPrefix = "git-bug.milestone."
+
+// MilestoneDefinition describe a milestone registered for a repository
+type MilestoneDefinition struct {
+	Name string
+	// DueDate is the planned date of the milestone, zero if unplanned
+	DueDate     time.Time
+	Description string
+}
+
+func (md MilestoneDefinition) Validate() error {
+	if text.Empty(md.Name) {
+		return fmt.Errorf("empty milestone name")
+	}
+
+	if strings.Contains(md.Name, "\n") || !text.Safe(md.Name) {
+		return fmt.Errorf("milestone %s: name should be a single printable line", md.Name)
+	}
+
+	if md.Name != strings.TrimSpace(md.Name) {
+		return fmt.Errorf("milestone %s: name has leading or trailing spaces", md.Name)
+	}
+
+	if strings.Contains(md.Description, "\n") {
+		return fmt.Errorf("milestone %s: description should be a single line", md.Name)
+	}
+
+	return nil
+}
+
+// MilestoneRegistry is the set of milestones defined for a repository
+type MilestoneRegistry map[string]MilestoneDefinition
+
+// Names return the sorted names of the registered milestones
+func (mr MilestoneRegistry) Names() []string {
+	result := make([]string, 0, len(mr))
+	for name := range mr {
+		result = append(result, name)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// ReadMilestoneRegistry read the milestone registry from the repository configuration
+func ReadMilestoneRegistry(config repository.ConfigRead) (MilestoneRegistry, error) {
+	pairs, err := config.ReadAll(milestoneRegistryConfigKeyPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	registry := make(MilestoneRegistry)
+
+	for key, value := range pairs {
+		key = strings.TrimPrefix(key, milestoneRegistryConfigKeyPrefix)
+
+		// the milestone name can contain dots, the property can't
+		split := strings.LastIndex(key, ".")
+		if split <= 0 {
+			return nil, fmt.Errorf("invalid milestone config key %s", key)
+		}
+		name := key[:split]
+
+		def := registry[name]
+		def.Name = name
+
+		switch key[split+1:] {
+		case "due":
+			def.DueDate, err = time.ParseInLocation(FieldDateLayout, value, time.Local)
+			if err != nil {
+				return nil, fmt.Errorf("milestone %s: invalid due date %s", name, value)
+			}
+		case "description":
+			def.Description = value
+		default:
+			return nil, fmt.Errorf("milestone %s: unknown config key %s", name, key[split+1:])
+		}
+
+		registry[name] = def
+	}
+
+	for _, def := range registry {
+		if err := def.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	return registry, nil
+}
+
+// StoreMilestoneDefinition add or replace a milestone in the repository configuration
+func StoreMilestoneDefinition(config repository.Config, def MilestoneDefinition) error {
+	if err := def.Validate(); err != nil {
+		return err
+	}
+
+	// cleanup a previous definition, if any
+	_ = config.RemoveAll(milestoneRegistryConfigKeyPrefix + def.Name)
+
+	prefix := milestoneRegistryConfigKeyPrefix + def.Name
+
+	if !def.DueDate.IsZero() {
+		err := config.StoreString(prefix+".due", def.DueDate.Format(FieldDateLayout))
+		if err != nil {
+			return err
+		}
+	}
+
+	// a milestone without any property still need to be stored
+	return config.StoreString(prefix+".description", def.Description)
+}
@@ -0,0 +1,38 @@
+package bug
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+func TestMilestoneRegistry(t *testing.T) {
+	repo := repository.NewMockRepoForTest()
+	config := repo.LocalConfig()
+
+	registry, err := ReadMilestoneRegistry(config)
+	require.NoError(t, err)
+	require.Empty(t, registry)
+
+	due := time.Date(2024, 10, 1, 0, 0, 0, 0, time.Local)
+
+	require.NoError(t, StoreMilestoneDefinition(config, MilestoneDefinition{
+		Name:        "v1.0",
+		DueDate:     due,
+		Description: "first stable release",
+	}))
+	require.NoError(t, StoreMilestoneDefinition(config, MilestoneDefinition{Name: "backlog"}))
+
+	require.Error(t, StoreMilestoneDefinition(config, MilestoneDefinition{Name: " padded "}))
+	require.Error(t, StoreMilestoneDefinition(config, MilestoneDefinition{Name: ""}))
+
+	registry, err = ReadMilestoneRegistry(config)
+	require.NoError(t, err)
+	require.Equal(t, []string{"backlog", "v1.0"}, registry.Names())
+	require.True(t, registry["v1.0"].DueDate.Equal(due))
+	require.Equal(t, "first stable release", registry["v1.0"].Description)
+	require.True(t, registry["backlog"].DueDate.IsZero())
+}
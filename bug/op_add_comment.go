@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"fmt"
 
+	"github.com/pkg/errors"
+
 	"github.com/MichaelMure/git-bug/entity"
 	"github.com/MichaelMure/git-bug/identity"
 	"github.com/MichaelMure/git-bug/repository"
@@ -19,6 +21,8 @@ type AddCommentOperation struct {
 	Message string `json:"message"`
 	// TODO: change for a map[string]util.hash to store the filename ?
 	Files []repository.Hash `json:"files"`
+	// ReplyTo is the optional id of the comment this comment is answering to
+	ReplyTo entity.Id `json:"reply_to,omitempty"`
 }
 
 // Sign-post method for gqlgen
@@ -35,13 +39,19 @@ func (op *AddCommentOperation) Id() entity.Id {
 func (op *AddCommentOperation) Apply(snapshot *Snapshot) {
 	snapshot.addActor(op.Author)
 	snapshot.addParticipant(op.Author)
+	for _, coAuthor := range op.CoAuthors {
+		snapshot.addActor(coAuthor)
+		snapshot.addParticipant(coAuthor)
+	}
 
 	comment := Comment{
-		id:       op.Id(),
-		Message:  op.Message,
-		Author:   op.Author,
-		Files:    op.Files,
-		UnixTime: timestamp.Timestamp(op.UnixTime),
+		id:        op.Id(),
+		Message:   op.Message,
+		Author:    op.Author,
+		CoAuthors: op.CoAuthors,
+		Files:     op.Files,
+		ReplyTo:   op.ReplyTo,
+		UnixTime:  timestamp.Timestamp(op.UnixTime),
 	}
 
 	snapshot.Comments = append(snapshot.Comments, comment)
@@ -66,6 +76,12 @@ func (op *AddCommentOperation) Validate() error {
 		return fmt.Errorf("message is not fully printable")
 	}
 
+	if op.ReplyTo != "" {
+		if err := op.ReplyTo.Validate(); err != nil {
+			return errors.Wrap(err, "reply to")
+		}
+	}
+
 	return nil
 }
 
@@ -84,6 +100,7 @@ func (op *AddCommentOperation) UnmarshalJSON(data []byte) error {
 	aux := struct {
 		Message string            `json:"message"`
 		Files   []repository.Hash `json:"files"`
+		ReplyTo entity.Id         `json:"reply_to"`
 	}{}
 
 	err = json.Unmarshal(data, &aux)
@@ -94,6 +111,7 @@ func (op *AddCommentOperation) UnmarshalJSON(data []byte) error {
 	op.OpBase = base
 	op.Message = aux.Message
 	op.Files = aux.Files
+	op.ReplyTo = aux.ReplyTo
 
 	return nil
 }
@@ -130,3 +148,31 @@ func AddCommentWithFiles(b Interface, author identity.Interface, unixTime int64,
 	b.Append(addCommentOp)
 	return addCommentOp, nil
 }
+
+// Convenience function to apply the operation, crediting additional authors
+func AddCommentWithCoAuthors(b Interface, author identity.Interface, coAuthors []identity.Interface, unixTime int64, message string, files []repository.Hash) (*AddCommentOperation, error) {
+	addCommentOp := NewAddCommentOp(author, unixTime, message, files)
+	addCommentOp.SetCoAuthors(coAuthors)
+	if err := addCommentOp.Validate(); err != nil {
+		return nil, err
+	}
+	b.Append(addCommentOp)
+	return addCommentOp, nil
+}
+
+// Convenience function to apply the operation. The comment is added as an
+// answer to the comment with the given id.
+func ReplyToComment(b Interface, author identity.Interface, unixTime int64, replyTo entity.Id, message string, files []repository.Hash) (*AddCommentOperation, error) {
+	snap := b.Compile()
+	if _, err := snap.SearchComment(replyTo); err != nil {
+		return nil, err
+	}
+
+	addCommentOp := NewAddCommentOp(author, unixTime, message, files)
+	addCommentOp.ReplyTo = replyTo
+	if err := addCommentOp.Validate(); err != nil {
+		return nil, err
+	}
+	b.Append(addCommentOp)
+	return addCommentOp, nil
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/MichaelMure/git-bug/entity"
 	"github.com/MichaelMure/git-bug/identity"
 	"github.com/MichaelMure/git-bug/repository"
 )
@@ -37,3 +38,99 @@ func TestAddCommentSerialize(t *testing.T) {
 
 	assert.Equal(t, before, &after)
 }
+
+func TestAddCommentThreads(t *testing.T) {
+	repo := repository.NewMockRepoForTest()
+	rene := identity.NewIdentity("René Descartes", "rene@descartes.fr")
+	err := rene.Commit(repo)
+	require.NoError(t, err)
+
+	unix := time.Now().Unix()
+
+	b, createOp, err := Create(rene, unix, "title", "message")
+	require.NoError(t, err)
+
+	first, err := AddComment(b, rene, unix, "first")
+	require.NoError(t, err)
+
+	reply1, err := ReplyToComment(b, rene, unix, createOp.Id(), "reply to the bug", nil)
+	require.NoError(t, err)
+
+	nested, err := ReplyToComment(b, rene, unix, reply1.Id(), "nested", nil)
+	require.NoError(t, err)
+
+	reply2, err := ReplyToComment(b, rene, unix+1, createOp.Id(), "another reply", nil)
+	require.NoError(t, err)
+
+	_, err = ReplyToComment(b, rene, unix, "unknown", "lost", nil)
+	require.Error(t, err)
+
+	// serialization
+	data, err := json.Marshal(nested)
+	require.NoError(t, err)
+	var after AddCommentOperation
+	err = json.Unmarshal(data, &after)
+	require.NoError(t, err)
+	require.Equal(t, reply1.Id(), after.ReplyTo)
+
+	snap := b.Compile()
+
+	require.Len(t, snap.Replies(createOp.Id()), 2)
+
+	threads := snap.Threads()
+	require.Len(t, threads, 5)
+
+	expected := []struct {
+		id    entity.Id
+		depth int
+	}{
+		{createOp.Id(), 0},
+		{reply1.Id(), 1},
+		{nested.Id(), 2},
+		{reply2.Id(), 1},
+		{first.Id(), 0},
+	}
+
+	for i, e := range expected {
+		assert.Equal(t, e.id, threads[i].Id())
+		assert.Equal(t, e.depth, threads[i].Depth)
+	}
+}
+
+func TestAddCommentCoAuthors(t *testing.T) {
+	repo := repository.NewMockRepoForTest()
+	rene := identity.NewIdentity("René Descartes", "rene@descartes.fr")
+	require.NoError(t, rene.Commit(repo))
+	isaac := identity.NewIdentity("Isaac Newton", "isaac@newton.uk")
+	require.NoError(t, isaac.Commit(repo))
+
+	unix := time.Now().Unix()
+
+	b, _, err := Create(rene, unix, "title", "message")
+	require.NoError(t, err)
+
+	op, err := AddCommentWithCoAuthors(b, rene, []identity.Interface{isaac}, unix, "paired on this", nil)
+	require.NoError(t, err)
+
+	_, err = AddCommentWithCoAuthors(b, rene, []identity.Interface{rene}, unix, "alone", nil)
+	require.Error(t, err)
+
+	snap := b.Compile()
+	require.Equal(t, []identity.Interface{rene, isaac}, snap.Comments[1].Authors())
+	require.Equal(t, []identity.Interface{rene, isaac}, snap.Actors)
+	require.Equal(t, []identity.Interface{rene, isaac}, snap.Participants)
+
+	// serialization
+	data, err := json.Marshal(op)
+	require.NoError(t, err)
+	var after AddCommentOperation
+	err = json.Unmarshal(data, &after)
+	require.NoError(t, err)
+	require.Len(t, after.CoAuthors, 1)
+	require.Equal(t, isaac.Id(), after.CoAuthors[0].Id())
+
+	// co-authors are not supported on other operations
+	setTitle := NewSetTitleOp(rene, unix, "title2", "title")
+	setTitle.SetCoAuthors([]identity.Interface{isaac})
+	require.Error(t, setTitle.Validate())
+}
@@ -0,0 +1,99 @@
+package bug
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/identity"
+)
+
+var _ Operation = &AddTimeSpentOperation{}
+
+// AddTimeSpentOperation record some time spent by the author working on a bug.
+// A negative duration can be used to correct a previous entry.
+type AddTimeSpentOperation struct {
+	OpBase
+	Duration time.Duration `json:"duration"`
+}
+
+// Sign-post method for gqlgen
+func (op *AddTimeSpentOperation) IsOperation() {}
+
+func (op *AddTimeSpentOperation) base() *OpBase {
+	return &op.OpBase
+}
+
+func (op *AddTimeSpentOperation) Id() entity.Id {
+	return idOperation(op)
+}
+
+func (op *AddTimeSpentOperation) Apply(snapshot *Snapshot) {
+	snapshot.TimeSpent += op.Duration
+
+	if snapshot.TimeSpentBy == nil {
+		snapshot.TimeSpentBy = make(map[entity.Id]time.Duration)
+	}
+	snapshot.TimeSpentBy[op.Author.Id()] += op.Duration
+	snapshot.addActor(op.Author)
+}
+
+func (op *AddTimeSpentOperation) Validate() error {
+	if err := opBaseValidate(op, AddTimeSpentOp); err != nil {
+		return err
+	}
+
+	if op.Duration == 0 {
+		return fmt.Errorf("no time spent")
+	}
+
+	return nil
+}
+
+// UnmarshalJSON is a two step JSON unmarshaling
+// This workaround is necessary to avoid the inner OpBase.MarshalJSON
+// overriding the outer op's MarshalJSON
+func (op *AddTimeSpentOperation) UnmarshalJSON(data []byte) error {
+	// Unmarshal OpBase and the op separately
+
+	base := OpBase{}
+	err := json.Unmarshal(data, &base)
+	if err != nil {
+		return err
+	}
+
+	aux := struct {
+		Duration time.Duration `json:"duration"`
+	}{}
+
+	err = json.Unmarshal(data, &aux)
+	if err != nil {
+		return err
+	}
+
+	op.OpBase = base
+	op.Duration = aux.Duration
+
+	return nil
+}
+
+// Sign post method for gqlgen
+func (op *AddTimeSpentOperation) IsAuthored() {}
+
+func NewAddTimeSpentOp(author identity.Interface, unixTime int64, duration time.Duration) *AddTimeSpentOperation {
+	return &AddTimeSpentOperation{
+		OpBase:   newOpBase(AddTimeSpentOp, author, unixTime),
+		Duration: duration,
+	}
+}
+
+// Convenience function to apply the operation
+func AddTimeSpent(b Interface, author identity.Interface, unixTime int64, duration time.Duration) (*AddTimeSpentOperation, error) {
+	op := NewAddTimeSpentOp(author, unixTime, duration)
+	if err := op.Validate(); err != nil {
+		return nil, err
+	}
+	b.Append(op)
+	return op, nil
+}
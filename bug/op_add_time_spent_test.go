@@ -0,0 +1,66 @@
+package bug
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/identity"
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+func TestAddTimeSpentSerialize(t *testing.T) {
+	repo := repository.NewMockRepoForTest()
+	rene := identity.NewIdentity("René Descartes", "rene@descartes.fr")
+	err := rene.Commit(repo)
+	require.NoError(t, err)
+
+	unix := time.Now().Unix()
+	before := NewAddTimeSpentOp(rene, unix, 90*time.Minute)
+
+	data, err := json.Marshal(before)
+	assert.NoError(t, err)
+
+	var after AddTimeSpentOperation
+	err = json.Unmarshal(data, &after)
+	assert.NoError(t, err)
+
+	// enforce creating the ID
+	before.Id()
+
+	// Replace the identity stub with the real thing
+	assert.Equal(t, rene.Id(), after.base().Author.Id())
+	after.Author = rene
+
+	assert.Equal(t, before, &after)
+}
+
+func TestTimeTrackingApply(t *testing.T) {
+	repo := repository.NewMockRepoForTest()
+	rene := identity.NewIdentity("René Descartes", "rene@descartes.fr")
+	err := rene.Commit(repo)
+	require.NoError(t, err)
+	isaac := identity.NewIdentity("Isaac Newton", "isaac@newton.uk")
+	err = isaac.Commit(repo)
+	require.NoError(t, err)
+
+	unix := time.Now().Unix()
+
+	snapshot := Snapshot{}
+
+	NewAddTimeSpentOp(rene, unix, 2*time.Hour).Apply(&snapshot)
+	NewAddTimeSpentOp(isaac, unix, 30*time.Minute).Apply(&snapshot)
+	NewAddTimeSpentOp(rene, unix, -time.Hour).Apply(&snapshot)
+	NewSetEstimateOp(rene, unix, 4*time.Hour).Apply(&snapshot)
+
+	assert.Equal(t, 90*time.Minute, snapshot.TimeSpent)
+	assert.Equal(t, map[entity.Id]time.Duration{
+		rene.Id():  time.Hour,
+		isaac.Id(): 30 * time.Minute,
+	}, snapshot.TimeSpentBy)
+	assert.Equal(t, 4*time.Hour, snapshot.Estimate)
+}
@@ -0,0 +1,147 @@
+package bug
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/identity"
+)
+
+var _ Operation = &AssignOperation{}
+
+// AssignOperation will add or remove assignees of a bug
+type AssignOperation struct {
+	OpBase
+	Added   []identity.Interface `json:"added,omitempty"`
+	Removed []identity.Interface `json:"removed,omitempty"`
+}
+
+// Sign-post method for gqlgen
+func (op *AssignOperation) IsOperation() {}
+
+func (op *AssignOperation) base() *OpBase {
+	return &op.OpBase
+}
+
+func (op *AssignOperation) Id() entity.Id {
+	return idOperation(op)
+}
+
+func (op *AssignOperation) Apply(snapshot *Snapshot) {
+	snapshot.addActor(op.Author)
+
+	for _, added := range op.Added {
+		snapshot.addAssignee(added)
+	}
+
+	for _, removed := range op.Removed {
+		snapshot.removeAssignee(removed)
+	}
+}
+
+func (op *AssignOperation) Validate() error {
+	if err := opBaseValidate(op, AssignOp); err != nil {
+		return err
+	}
+
+	if len(op.Added)+len(op.Removed) <= 0 {
+		return fmt.Errorf("no assignee change")
+	}
+
+	seen := make(map[entity.Id]struct{})
+
+	for _, i := range op.Added {
+		if i == nil {
+			return fmt.Errorf("nil added assignee")
+		}
+		if err := i.Validate(); err != nil {
+			return errors.Wrap(err, "added assignee")
+		}
+		if _, ok := seen[i.Id()]; ok {
+			return fmt.Errorf("duplicated assignee %s", i.Id().Human())
+		}
+		seen[i.Id()] = struct{}{}
+	}
+
+	for _, i := range op.Removed {
+		if i == nil {
+			return fmt.Errorf("nil removed assignee")
+		}
+		if err := i.Validate(); err != nil {
+			return errors.Wrap(err, "removed assignee")
+		}
+		if _, ok := seen[i.Id()]; ok {
+			return fmt.Errorf("duplicated assignee %s", i.Id().Human())
+		}
+		seen[i.Id()] = struct{}{}
+	}
+
+	return nil
+}
+
+// UnmarshalJSON is a two step JSON unmarshaling
+// This workaround is necessary to avoid the inner OpBase.MarshalJSON
+// overriding the outer op's MarshalJSON
+func (op *AssignOperation) UnmarshalJSON(data []byte) error {
+	// Unmarshal OpBase and the op separately
+
+	base := OpBase{}
+	err := json.Unmarshal(data, &base)
+	if err != nil {
+		return err
+	}
+
+	aux := struct {
+		Added   []json.RawMessage `json:"added"`
+		Removed []json.RawMessage `json:"removed"`
+	}{}
+
+	err = json.Unmarshal(data, &aux)
+	if err != nil {
+		return err
+	}
+
+	op.OpBase = base
+
+	for _, raw := range aux.Added {
+		i, err := identity.UnmarshalJSON(raw)
+		if err != nil {
+			return err
+		}
+		op.Added = append(op.Added, i)
+	}
+
+	for _, raw := range aux.Removed {
+		i, err := identity.UnmarshalJSON(raw)
+		if err != nil {
+			return err
+		}
+		op.Removed = append(op.Removed, i)
+	}
+
+	return nil
+}
+
+// Sign post method for gqlgen
+func (op *AssignOperation) IsAuthored() {}
+
+func NewAssignOp(author identity.Interface, unixTime int64, added, removed []identity.Interface) *AssignOperation {
+	return &AssignOperation{
+		OpBase:  newOpBase(AssignOp, author, unixTime),
+		Added:   added,
+		Removed: removed,
+	}
+}
+
+// Convenience function to apply the operation
+func Assign(b Interface, author identity.Interface, unixTime int64, added, removed []identity.Interface) (*AssignOperation, error) {
+	op := NewAssignOp(author, unixTime, added, removed)
+	if err := op.Validate(); err != nil {
+		return nil, err
+	}
+	b.Append(op)
+	return op, nil
+}
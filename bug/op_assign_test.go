@@ -0,0 +1,76 @@
+package bug
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MichaelMure/git-bug/identity"
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+func TestAssignSerialize(t *testing.T) {
+	repo := repository.NewMockRepoForTest()
+	rene := identity.NewIdentity("René Descartes", "rene@descartes.fr")
+	err := rene.Commit(repo)
+	require.NoError(t, err)
+	isaac := identity.NewIdentity("Isaac Newton", "isaac@newton.uk")
+	err = isaac.Commit(repo)
+	require.NoError(t, err)
+
+	unix := time.Now().Unix()
+	before := NewAssignOp(rene, unix, []identity.Interface{isaac}, []identity.Interface{rene})
+
+	data, err := json.Marshal(before)
+	assert.NoError(t, err)
+
+	var after AssignOperation
+	err = json.Unmarshal(data, &after)
+	assert.NoError(t, err)
+
+	// enforce creating the ID
+	before.Id()
+
+	// Replace the identity stubs with the real thing
+	assert.Equal(t, rene.Id(), after.base().Author.Id())
+	after.Author = rene
+	require.Len(t, after.Added, 1)
+	assert.Equal(t, isaac.Id(), after.Added[0].Id())
+	after.Added[0] = isaac
+	require.Len(t, after.Removed, 1)
+	assert.Equal(t, rene.Id(), after.Removed[0].Id())
+	after.Removed[0] = rene
+
+	assert.Equal(t, before, &after)
+}
+
+func TestAssignApply(t *testing.T) {
+	snapshot := Snapshot{}
+
+	repo := repository.NewMockRepoForTest()
+	rene := identity.NewIdentity("René Descartes", "rene@descartes.fr")
+	err := rene.Commit(repo)
+	require.NoError(t, err)
+	isaac := identity.NewIdentity("Isaac Newton", "isaac@newton.uk")
+	err = isaac.Commit(repo)
+	require.NoError(t, err)
+
+	unix := time.Now().Unix()
+
+	NewAssignOp(rene, unix, []identity.Interface{rene, isaac}, nil).Apply(&snapshot)
+	NewAssignOp(rene, unix, []identity.Interface{isaac}, nil).Apply(&snapshot)
+
+	assert.Len(t, snapshot.Assignees, 2)
+	assert.True(t, snapshot.HasAssignee(rene.Id()))
+	assert.True(t, snapshot.HasAssignee(isaac.Id()))
+	assert.False(t, snapshot.HasActor(isaac.Id()))
+
+	NewAssignOp(isaac, unix, nil, []identity.Interface{rene}).Apply(&snapshot)
+
+	assert.Len(t, snapshot.Assignees, 1)
+	assert.False(t, snapshot.HasAssignee(rene.Id()))
+	assert.True(t, snapshot.HasAssignee(isaac.Id()))
+}
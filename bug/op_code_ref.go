@@ -0,0 +1,120 @@
+package bug
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+
+	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/identity"
+)
+
+var _ Operation = &CodeRefOperation{}
+
+// CodeRefOperation will add or remove a typed reference to a location in the
+// code: a commit, a file or a branch
+type CodeRefOperation struct {
+	OpBase
+	Ref     CodeRef `json:"ref"`
+	Removed bool    `json:"removed,omitempty"`
+}
+
+// Sign-post method for gqlgen
+func (op *CodeRefOperation) IsOperation() {}
+
+func (op *CodeRefOperation) base() *OpBase {
+	return &op.OpBase
+}
+
+func (op *CodeRefOperation) Id() entity.Id {
+	return idOperation(op)
+}
+
+func (op *CodeRefOperation) Apply(snapshot *Snapshot) {
+	snapshot.addActor(op.Author)
+
+	for i, r := range snapshot.CodeRefs {
+		if r == op.Ref {
+			if op.Removed {
+				snapshot.CodeRefs = append(snapshot.CodeRefs[:i], snapshot.CodeRefs[i+1:]...)
+			}
+			return
+		}
+	}
+
+	if !op.Removed {
+		snapshot.CodeRefs = append(snapshot.CodeRefs, op.Ref)
+	}
+}
+
+func (op *CodeRefOperation) Validate() error {
+	if err := opBaseValidate(op, CodeRefOp); err != nil {
+		return err
+	}
+
+	if err := op.Ref.Validate(); err != nil {
+		return errors.Wrap(err, "code reference")
+	}
+
+	return nil
+}
+
+// UnmarshalJSON is a two step JSON unmarshaling
+// This workaround is necessary to avoid the inner OpBase.MarshalJSON
+// overriding the outer op's MarshalJSON
+func (op *CodeRefOperation) UnmarshalJSON(data []byte) error {
+	// Unmarshal OpBase and the op separately
+
+	base := OpBase{}
+	err := json.Unmarshal(data, &base)
+	if err != nil {
+		return err
+	}
+
+	aux := struct {
+		Ref     CodeRef `json:"ref"`
+		Removed bool    `json:"removed"`
+	}{}
+
+	err = json.Unmarshal(data, &aux)
+	if err != nil {
+		return err
+	}
+
+	op.OpBase = base
+	op.Ref = aux.Ref
+	op.Removed = aux.Removed
+
+	return nil
+}
+
+// Sign post method for gqlgen
+func (op *CodeRefOperation) IsAuthored() {}
+
+func NewCodeRefOp(author identity.Interface, unixTime int64, ref CodeRef, removed bool) *CodeRefOperation {
+	return &CodeRefOperation{
+		OpBase:  newOpBase(CodeRefOp, author, unixTime),
+		Ref:     ref,
+		Removed: removed,
+	}
+}
+
+// Convenience function to apply the operation
+func AddCodeRef(b Interface, author identity.Interface, unixTime int64, ref CodeRef) (*CodeRefOperation, error) {
+	op := NewCodeRefOp(author, unixTime, ref, false)
+	if err := op.Validate(); err != nil {
+		return nil, err
+	}
+	b.Append(op)
+	return op, nil
+}
+
+// Convenience function to apply the operation
+func RemoveCodeRef(b Interface, author identity.Interface, unixTime int64, ref CodeRef) (*CodeRefOperation, error) {
+	op := NewCodeRefOp(author, unixTime, ref, true)
+	if err := op.Validate(); err != nil {
+		return nil, err
+	}
+	b.Append(op)
+	return op, nil
+}
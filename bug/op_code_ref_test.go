@@ -0,0 +1,92 @@
+package bug
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MichaelMure/git-bug/identity"
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+func TestCodeRefSerialize(t *testing.T) {
+	repo := repository.NewMockRepoForTest()
+	rene := identity.NewIdentity("René Descartes", "rene@descartes.fr")
+	err := rene.Commit(repo)
+	require.NoError(t, err)
+
+	unix := time.Now().Unix()
+	ref := CodeRef{Kind: FileCodeRef, Role: IntroducedByCodeRef, Target: "bug/bug.go", Line: 42}
+	before := NewCodeRefOp(rene, unix, ref, true)
+
+	data, err := json.Marshal(before)
+	assert.NoError(t, err)
+
+	var after CodeRefOperation
+	err = json.Unmarshal(data, &after)
+	assert.NoError(t, err)
+
+	// enforce creating the ID
+	before.Id()
+
+	// Replace the identity stub with the real thing
+	assert.Equal(t, rene.Id(), after.base().Author.Id())
+	after.Author = rene
+
+	assert.Equal(t, before, &after)
+}
+
+func TestParseCodeRef(t *testing.T) {
+	ref, err := ParseCodeRef(FileCodeRef, MentionedCodeRef, "bug/bug.go:42")
+	require.NoError(t, err)
+	require.Equal(t, CodeRef{Kind: FileCodeRef, Role: MentionedCodeRef, Target: "bug/bug.go", Line: 42}, ref)
+
+	ref, err = ParseCodeRef(FileCodeRef, MentionedCodeRef, "bug/bug.go")
+	require.NoError(t, err)
+	require.Equal(t, 0, ref.Line)
+
+	ref, err = ParseCodeRef(CommitCodeRef, FixedByCodeRef, "ABCDEF1234")
+	require.NoError(t, err)
+	require.Equal(t, "abcdef1234", ref.Target)
+	require.Equal(t, "fixed-by commit abcdef1234", ref.String())
+
+	_, err = ParseCodeRef(CommitCodeRef, FixedByCodeRef, "main")
+	require.Error(t, err)
+
+	_, err = ParseCodeRef(BranchCodeRef, MentionedCodeRef, "my branch")
+	require.Error(t, err)
+}
+
+func TestCodeRefApply(t *testing.T) {
+	repo := repository.NewMockRepoForTest()
+	rene := identity.NewIdentity("René Descartes", "rene@descartes.fr")
+	err := rene.Commit(repo)
+	require.NoError(t, err)
+
+	unix := time.Now().Unix()
+
+	b, _, err := Create(rene, unix, "title", "message")
+	require.NoError(t, err)
+
+	introduced := CodeRef{Kind: CommitCodeRef, Role: IntroducedByCodeRef, Target: "0123456789abcdef"}
+	fixed := CodeRef{Kind: CommitCodeRef, Role: FixedByCodeRef, Target: "fedcba9876543210"}
+	branch := CodeRef{Kind: BranchCodeRef, Role: MentionedCodeRef, Target: "fix-crash"}
+
+	for _, ref := range []CodeRef{introduced, fixed, branch, fixed} {
+		_, err = AddCodeRef(b, rene, unix, ref)
+		require.NoError(t, err)
+	}
+
+	snap := b.Compile()
+	require.Equal(t, []CodeRef{introduced, fixed, branch}, snap.CodeRefs)
+	require.Equal(t, []CodeRef{fixed}, snap.CodeRefsWithRole(FixedByCodeRef))
+
+	_, err = RemoveCodeRef(b, rene, unix, branch)
+	require.NoError(t, err)
+
+	snap = b.Compile()
+	require.Equal(t, []CodeRef{introduced, fixed}, snap.CodeRefs)
+}
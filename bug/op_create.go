@@ -36,14 +36,19 @@ func (op *CreateOperation) Id() entity.Id {
 func (op *CreateOperation) Apply(snapshot *Snapshot) {
 	snapshot.addActor(op.Author)
 	snapshot.addParticipant(op.Author)
+	for _, coAuthor := range op.CoAuthors {
+		snapshot.addActor(coAuthor)
+		snapshot.addParticipant(coAuthor)
+	}
 
 	snapshot.Title = op.Title
 
 	comment := Comment{
-		id:       op.Id(),
-		Message:  op.Message,
-		Author:   op.Author,
-		UnixTime: timestamp.Timestamp(op.UnixTime),
+		id:        op.Id(),
+		Message:   op.Message,
+		Author:    op.Author,
+		CoAuthors: op.CoAuthors,
+		UnixTime:  timestamp.Timestamp(op.UnixTime),
 	}
 
 	snapshot.Comments = []Comment{comment}
@@ -142,7 +147,16 @@ func Create(author identity.Interface, unixTime int64, title, message string) (*
 }
 
 func CreateWithFiles(author identity.Interface, unixTime int64, title, message string, files []repository.Hash) (*Bug, *CreateOperation, error) {
-	newBug := NewBug()
+	return create(NewBug(), author, unixTime, title, message, files)
+}
+
+// CreateDraftWithFiles is the same as CreateWithFiles, but the resulting bug
+// is a draft that won't be pushed until published.
+func CreateDraftWithFiles(author identity.Interface, unixTime int64, title, message string, files []repository.Hash) (*Bug, *CreateOperation, error) {
+	return create(NewDraftBug(), author, unixTime, title, message, files)
+}
+
+func create(newBug *Bug, author identity.Interface, unixTime int64, title, message string, files []repository.Hash) (*Bug, *CreateOperation, error) {
 	createOp := NewCreateOp(author, unixTime, title, message, files)
 
 	if err := createOp.Validate(); err != nil {
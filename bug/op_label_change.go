@@ -14,6 +14,9 @@ import (
 
 var _ Operation = &LabelChangeOperation{}
 
+// ErrNoLabelChange is returned when changing the labels would have no effect
+var ErrNoLabelChange = errors.New("no label added or removed")
+
 // LabelChangeOperation define a Bug operation to add or remove labels
 type LabelChangeOperation struct {
 	OpBase
@@ -200,7 +203,7 @@ func ChangeLabels(b Interface, author identity.Interface, unixTime int64, add, r
 	}
 
 	if len(added) == 0 && len(removed) == 0 {
-		return results, nil, fmt.Errorf("no label added or removed")
+		return results, nil, ErrNoLabelChange
 	}
 
 	labelOp := NewLabelChangeOperation(author, unixTime, added, removed)
@@ -0,0 +1,161 @@
+package bug
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/identity"
+)
+
+var _ Operation = &MinimizeCommentOperation{}
+
+// MinimizeReason explain why a comment has been minimized
+type MinimizeReason string
+
+const (
+	MinimizeResolved MinimizeReason = "resolved"
+	MinimizeOutdated MinimizeReason = "outdated"
+)
+
+func (r MinimizeReason) Validate() error {
+	switch r {
+	case MinimizeResolved, MinimizeOutdated:
+		return nil
+	default:
+		return fmt.Errorf("unknown minimize reason %s", r)
+	}
+}
+
+// MinimizeCommentOperation will mark a comment as minimized, that is resolved
+// or outdated, so that it's displayed collapsed. The content of the comment
+// is unchanged. An empty reason expand the comment back.
+type MinimizeCommentOperation struct {
+	OpBase
+	Target entity.Id      `json:"target"`
+	Reason MinimizeReason `json:"reason,omitempty"`
+}
+
+// Sign-post method for gqlgen
+func (op *MinimizeCommentOperation) IsOperation() {}
+
+func (op *MinimizeCommentOperation) base() *OpBase {
+	return &op.OpBase
+}
+
+func (op *MinimizeCommentOperation) Id() entity.Id {
+	return idOperation(op)
+}
+
+func (op *MinimizeCommentOperation) Apply(snapshot *Snapshot) {
+	snapshot.addActor(op.Author)
+
+	for _, item := range snapshot.Timeline {
+		if item.Id() != op.Target {
+			continue
+		}
+
+		switch item := item.(type) {
+		case *CreateTimelineItem:
+			item.Minimized = op.Reason
+		case *AddCommentTimelineItem:
+			item.Minimized = op.Reason
+		}
+		break
+	}
+
+	for i := range snapshot.Comments {
+		if snapshot.Comments[i].Id() == op.Target {
+			snapshot.Comments[i].Minimized = op.Reason
+			break
+		}
+	}
+}
+
+func (op *MinimizeCommentOperation) Validate() error {
+	if err := opBaseValidate(op, MinimizeCommentOp); err != nil {
+		return err
+	}
+
+	if err := op.Target.Validate(); err != nil {
+		return errors.Wrap(err, "target hash is invalid")
+	}
+
+	if op.Reason != "" {
+		if err := op.Reason.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// UnmarshalJSON is a two step JSON unmarshaling
+// This workaround is necessary to avoid the inner OpBase.MarshalJSON
+// overriding the outer op's MarshalJSON
+func (op *MinimizeCommentOperation) UnmarshalJSON(data []byte) error {
+	// Unmarshal OpBase and the op separately
+
+	base := OpBase{}
+	err := json.Unmarshal(data, &base)
+	if err != nil {
+		return err
+	}
+
+	aux := struct {
+		Target entity.Id      `json:"target"`
+		Reason MinimizeReason `json:"reason,omitempty"`
+	}{}
+
+	err = json.Unmarshal(data, &aux)
+	if err != nil {
+		return err
+	}
+
+	op.OpBase = base
+	op.Target = aux.Target
+	op.Reason = aux.Reason
+
+	return nil
+}
+
+// Sign post method for gqlgen
+func (op *MinimizeCommentOperation) IsAuthored() {}
+
+func NewMinimizeCommentOp(author identity.Interface, unixTime int64, target entity.Id, reason MinimizeReason) *MinimizeCommentOperation {
+	return &MinimizeCommentOperation{
+		OpBase: newOpBase(MinimizeCommentOp, author, unixTime),
+		Target: target,
+		Reason: reason,
+	}
+}
+
+// Convenience function to apply the operation
+func MinimizeComment(b Interface, author identity.Interface, unixTime int64, target entity.Id, reason MinimizeReason) (*MinimizeCommentOperation, error) {
+	if err := reason.Validate(); err != nil {
+		return nil, err
+	}
+
+	return minimizeComment(b, author, unixTime, target, reason)
+}
+
+// Convenience function to apply the operation
+func UnminimizeComment(b Interface, author identity.Interface, unixTime int64, target entity.Id) (*MinimizeCommentOperation, error) {
+	return minimizeComment(b, author, unixTime, target, "")
+}
+
+func minimizeComment(b Interface, author identity.Interface, unixTime int64, target entity.Id, reason MinimizeReason) (*MinimizeCommentOperation, error) {
+	snap := b.Compile()
+	if _, err := snap.SearchComment(target); err != nil {
+		return nil, err
+	}
+
+	minimizeOp := NewMinimizeCommentOp(author, unixTime, target, reason)
+	if err := minimizeOp.Validate(); err != nil {
+		return nil, err
+	}
+	b.Append(minimizeOp)
+	return minimizeOp, nil
+}
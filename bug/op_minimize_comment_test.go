@@ -0,0 +1,75 @@
+package bug
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MichaelMure/git-bug/identity"
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+func TestMinimizeCommentSerialize(t *testing.T) {
+	repo := repository.NewMockRepoForTest()
+	rene := identity.NewIdentity("René Descartes", "rene@descartes.fr")
+	err := rene.Commit(repo)
+	require.NoError(t, err)
+
+	unix := time.Now().Unix()
+	before := NewMinimizeCommentOp(rene, unix, "123", MinimizeOutdated)
+
+	data, err := json.Marshal(before)
+	assert.NoError(t, err)
+
+	var after MinimizeCommentOperation
+	err = json.Unmarshal(data, &after)
+	assert.NoError(t, err)
+
+	// enforce creating the ID
+	before.Id()
+
+	// Replace the identity stub with the real thing
+	assert.Equal(t, rene.Id(), after.base().Author.Id())
+	after.Author = rene
+
+	assert.Equal(t, before, &after)
+}
+
+func TestMinimizeComment(t *testing.T) {
+	repo := repository.NewMockRepoForTest()
+	rene := identity.NewIdentity("René Descartes", "rene@descartes.fr")
+	err := rene.Commit(repo)
+	require.NoError(t, err)
+
+	unix := time.Now().Unix()
+
+	b, _, err := Create(rene, unix, "title", "message")
+	require.NoError(t, err)
+
+	comment, err := AddComment(b, rene, unix, "is it fixed?")
+	require.NoError(t, err)
+
+	_, err = MinimizeComment(b, rene, unix, comment.Id(), "spam")
+	require.Error(t, err)
+
+	_, err = MinimizeComment(b, rene, unix, "unknown", MinimizeResolved)
+	require.Error(t, err)
+
+	_, err = MinimizeComment(b, rene, unix, comment.Id(), MinimizeResolved)
+	require.NoError(t, err)
+
+	snap := b.Compile()
+	require.Equal(t, MinimizeResolved, snap.Comments[1].Minimized)
+	require.Equal(t, "is it fixed?", snap.Comments[1].Message)
+	require.True(t, snap.Timeline[1].(*AddCommentTimelineItem).IsMinimized())
+
+	_, err = UnminimizeComment(b, rene, unix, comment.Id())
+	require.NoError(t, err)
+
+	snap = b.Compile()
+	require.Empty(t, snap.Comments[1].Minimized)
+	require.False(t, snap.Timeline[1].(*AddCommentTimelineItem).IsMinimized())
+}
@@ -0,0 +1,132 @@
+package bug
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+
+	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/identity"
+)
+
+var _ Operation = &PinCommentOperation{}
+
+// PinCommentOperation will pin a comment, so that it's displayed at the top
+// of the bug, or unpin it.
+type PinCommentOperation struct {
+	OpBase
+	Target entity.Id `json:"target"`
+	Unpin  bool      `json:"unpin,omitempty"`
+}
+
+// Sign-post method for gqlgen
+func (op *PinCommentOperation) IsOperation() {}
+
+func (op *PinCommentOperation) base() *OpBase {
+	return &op.OpBase
+}
+
+func (op *PinCommentOperation) Id() entity.Id {
+	return idOperation(op)
+}
+
+func (op *PinCommentOperation) Apply(snapshot *Snapshot) {
+	snapshot.addActor(op.Author)
+
+	for _, item := range snapshot.Timeline {
+		if item.Id() != op.Target {
+			continue
+		}
+
+		switch item := item.(type) {
+		case *CreateTimelineItem:
+			item.Pinned = !op.Unpin
+		case *AddCommentTimelineItem:
+			item.Pinned = !op.Unpin
+		}
+		break
+	}
+
+	for i := range snapshot.Comments {
+		if snapshot.Comments[i].Id() == op.Target {
+			snapshot.Comments[i].Pinned = !op.Unpin
+			break
+		}
+	}
+}
+
+func (op *PinCommentOperation) Validate() error {
+	if err := opBaseValidate(op, PinCommentOp); err != nil {
+		return err
+	}
+
+	if err := op.Target.Validate(); err != nil {
+		return errors.Wrap(err, "target hash is invalid")
+	}
+
+	return nil
+}
+
+// UnmarshalJSON is a two step JSON unmarshaling
+// This workaround is necessary to avoid the inner OpBase.MarshalJSON
+// overriding the outer op's MarshalJSON
+func (op *PinCommentOperation) UnmarshalJSON(data []byte) error {
+	// Unmarshal OpBase and the op separately
+
+	base := OpBase{}
+	err := json.Unmarshal(data, &base)
+	if err != nil {
+		return err
+	}
+
+	aux := struct {
+		Target entity.Id `json:"target"`
+		Unpin  bool      `json:"unpin,omitempty"`
+	}{}
+
+	err = json.Unmarshal(data, &aux)
+	if err != nil {
+		return err
+	}
+
+	op.OpBase = base
+	op.Target = aux.Target
+	op.Unpin = aux.Unpin
+
+	return nil
+}
+
+// Sign post method for gqlgen
+func (op *PinCommentOperation) IsAuthored() {}
+
+func NewPinCommentOp(author identity.Interface, unixTime int64, target entity.Id, unpin bool) *PinCommentOperation {
+	return &PinCommentOperation{
+		OpBase: newOpBase(PinCommentOp, author, unixTime),
+		Target: target,
+		Unpin:  unpin,
+	}
+}
+
+// Convenience function to apply the operation
+func PinComment(b Interface, author identity.Interface, unixTime int64, target entity.Id) (*PinCommentOperation, error) {
+	return pinComment(b, author, unixTime, target, false)
+}
+
+// Convenience function to apply the operation
+func UnpinComment(b Interface, author identity.Interface, unixTime int64, target entity.Id) (*PinCommentOperation, error) {
+	return pinComment(b, author, unixTime, target, true)
+}
+
+func pinComment(b Interface, author identity.Interface, unixTime int64, target entity.Id, unpin bool) (*PinCommentOperation, error) {
+	snap := b.Compile()
+	if _, err := snap.SearchComment(target); err != nil {
+		return nil, err
+	}
+
+	pinOp := NewPinCommentOp(author, unixTime, target, unpin)
+	if err := pinOp.Validate(); err != nil {
+		return nil, err
+	}
+	b.Append(pinOp)
+	return pinOp, nil
+}
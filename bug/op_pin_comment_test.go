@@ -0,0 +1,72 @@
+package bug
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MichaelMure/git-bug/identity"
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+func TestPinCommentSerialize(t *testing.T) {
+	repo := repository.NewMockRepoForTest()
+	rene := identity.NewIdentity("René Descartes", "rene@descartes.fr")
+	err := rene.Commit(repo)
+	require.NoError(t, err)
+
+	unix := time.Now().Unix()
+	before := NewPinCommentOp(rene, unix, "123", true)
+
+	data, err := json.Marshal(before)
+	assert.NoError(t, err)
+
+	var after PinCommentOperation
+	err = json.Unmarshal(data, &after)
+	assert.NoError(t, err)
+
+	// enforce creating the ID
+	before.Id()
+
+	// Replace the identity stub with the real thing
+	assert.Equal(t, rene.Id(), after.base().Author.Id())
+	after.Author = rene
+
+	assert.Equal(t, before, &after)
+}
+
+func TestPinComment(t *testing.T) {
+	repo := repository.NewMockRepoForTest()
+	rene := identity.NewIdentity("René Descartes", "rene@descartes.fr")
+	err := rene.Commit(repo)
+	require.NoError(t, err)
+
+	unix := time.Now().Unix()
+
+	b, _, err := Create(rene, unix, "title", "message")
+	require.NoError(t, err)
+
+	comment, err := AddComment(b, rene, unix, "workaround: restart it")
+	require.NoError(t, err)
+
+	_, err = PinComment(b, rene, unix, "unknown")
+	require.Error(t, err)
+
+	_, err = PinComment(b, rene, unix, comment.Id())
+	require.NoError(t, err)
+
+	snap := b.Compile()
+	require.Len(t, snap.PinnedComments(), 1)
+	require.Equal(t, comment.Id(), snap.PinnedComments()[0].Id())
+	require.True(t, snap.Timeline[1].(*AddCommentTimelineItem).Pinned)
+
+	_, err = UnpinComment(b, rene, unix, comment.Id())
+	require.NoError(t, err)
+
+	snap = b.Compile()
+	require.Empty(t, snap.PinnedComments())
+	require.False(t, snap.Timeline[1].(*AddCommentTimelineItem).Pinned)
+}
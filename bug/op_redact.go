@@ -0,0 +1,155 @@
+package bug
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/identity"
+	"github.com/MichaelMure/git-bug/util/text"
+	"github.com/MichaelMure/git-bug/util/timestamp"
+)
+
+var _ Operation = &RedactOperation{}
+
+// RedactOperation will remove the content of a comment, including its edition
+// history, from the compiled state of the bug.
+// Note that the content is still present in the past operations stored in git.
+type RedactOperation struct {
+	OpBase
+	Target entity.Id `json:"target"`
+	Reason string    `json:"reason"`
+}
+
+// Redaction record who removed the content of a comment, and why
+type Redaction struct {
+	Author   identity.Interface
+	Reason   string
+	UnixTime timestamp.Timestamp
+}
+
+// Sign-post method for gqlgen
+func (op *RedactOperation) IsOperation() {}
+
+func (op *RedactOperation) base() *OpBase {
+	return &op.OpBase
+}
+
+func (op *RedactOperation) Id() entity.Id {
+	return idOperation(op)
+}
+
+func (op *RedactOperation) Apply(snapshot *Snapshot) {
+	snapshot.addActor(op.Author)
+
+	redaction := &Redaction{
+		Author:   op.Author,
+		Reason:   op.Reason,
+		UnixTime: timestamp.Timestamp(op.UnixTime),
+	}
+
+	for _, item := range snapshot.Timeline {
+		if item.Id() != op.Target {
+			continue
+		}
+
+		switch item := item.(type) {
+		case *CreateTimelineItem:
+			item.Redact(redaction)
+		case *AddCommentTimelineItem:
+			item.Redact(redaction)
+		}
+		break
+	}
+
+	for i := range snapshot.Comments {
+		if snapshot.Comments[i].Id() == op.Target {
+			snapshot.Comments[i].Message = ""
+			snapshot.Comments[i].Files = nil
+			snapshot.Comments[i].Redaction = redaction
+			break
+		}
+	}
+}
+
+func (op *RedactOperation) Validate() error {
+	if err := opBaseValidate(op, RedactOp); err != nil {
+		return err
+	}
+
+	if err := op.Target.Validate(); err != nil {
+		return errors.Wrap(err, "target hash is invalid")
+	}
+
+	if text.Empty(op.Reason) {
+		return fmt.Errorf("reason is empty")
+	}
+
+	if strings.Contains(op.Reason, "\n") {
+		return fmt.Errorf("reason should be a single line")
+	}
+
+	if !text.Safe(op.Reason) {
+		return fmt.Errorf("reason is not fully printable")
+	}
+
+	return nil
+}
+
+// UnmarshalJSON is a two step JSON unmarshaling
+// This workaround is necessary to avoid the inner OpBase.MarshalJSON
+// overriding the outer op's MarshalJSON
+func (op *RedactOperation) UnmarshalJSON(data []byte) error {
+	// Unmarshal OpBase and the op separately
+
+	base := OpBase{}
+	err := json.Unmarshal(data, &base)
+	if err != nil {
+		return err
+	}
+
+	aux := struct {
+		Target entity.Id `json:"target"`
+		Reason string    `json:"reason"`
+	}{}
+
+	err = json.Unmarshal(data, &aux)
+	if err != nil {
+		return err
+	}
+
+	op.OpBase = base
+	op.Target = aux.Target
+	op.Reason = aux.Reason
+
+	return nil
+}
+
+// Sign post method for gqlgen
+func (op *RedactOperation) IsAuthored() {}
+
+func NewRedactOp(author identity.Interface, unixTime int64, target entity.Id, reason string) *RedactOperation {
+	return &RedactOperation{
+		OpBase: newOpBase(RedactOp, author, unixTime),
+		Target: target,
+		Reason: reason,
+	}
+}
+
+// Convenience function to apply the operation
+func Redact(b Interface, author identity.Interface, unixTime int64, target entity.Id, reason string) (*RedactOperation, error) {
+	snap := b.Compile()
+	if _, err := snap.SearchComment(target); err != nil {
+		return nil, err
+	}
+
+	redactOp := NewRedactOp(author, unixTime, target, reason)
+	if err := redactOp.Validate(); err != nil {
+		return nil, err
+	}
+	b.Append(redactOp)
+	return redactOp, nil
+}
@@ -0,0 +1,83 @@
+package bug
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MichaelMure/git-bug/identity"
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+func TestRedactSerialize(t *testing.T) {
+	repo := repository.NewMockRepoForTest()
+	rene := identity.NewIdentity("René Descartes", "rene@descartes.fr")
+	err := rene.Commit(repo)
+	require.NoError(t, err)
+
+	unix := time.Now().Unix()
+	before := NewRedactOp(rene, unix, "123", "personal data")
+
+	data, err := json.Marshal(before)
+	assert.NoError(t, err)
+
+	var after RedactOperation
+	err = json.Unmarshal(data, &after)
+	assert.NoError(t, err)
+
+	// enforce creating the ID
+	before.Id()
+
+	// Replace the identity stub with the real thing
+	assert.Equal(t, rene.Id(), after.base().Author.Id())
+	after.Author = rene
+
+	assert.Equal(t, before, &after)
+}
+
+func TestRedact(t *testing.T) {
+	repo := repository.NewMockRepoForTest()
+	rene := identity.NewIdentity("René Descartes", "rene@descartes.fr")
+	err := rene.Commit(repo)
+	require.NoError(t, err)
+
+	unix := time.Now().Unix()
+
+	b, _, err := Create(rene, unix, "title", "message")
+	require.NoError(t, err)
+
+	comment, err := AddComment(b, rene, unix, "my phone number is ...")
+	require.NoError(t, err)
+
+	_, err = EditComment(b, rene, unix, comment.Id(), "my phone number is really ...")
+	require.NoError(t, err)
+
+	_, err = Redact(b, rene, unix, comment.Id(), "")
+	require.Error(t, err)
+
+	_, err = Redact(b, rene, unix, "unknown", "personal data")
+	require.Error(t, err)
+
+	_, err = Redact(b, rene, unix, comment.Id(), "personal data")
+	require.NoError(t, err)
+
+	snap := b.Compile()
+
+	require.Equal(t, "message", snap.Comments[0].Message)
+	require.Nil(t, snap.Comments[0].Redaction)
+
+	require.Empty(t, snap.Comments[1].Message)
+	require.NotNil(t, snap.Comments[1].Redaction)
+	require.Equal(t, "personal data", snap.Comments[1].Redaction.Reason)
+	require.Equal(t, rene.Id(), snap.Comments[1].Redaction.Author.Id())
+
+	item := snap.Timeline[1].(*AddCommentTimelineItem)
+	require.True(t, item.Redacted())
+	require.Empty(t, item.Message)
+	for _, step := range item.History {
+		require.Empty(t, step.Message)
+	}
+}
@@ -0,0 +1,129 @@
+package bug
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+
+	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/identity"
+)
+
+var _ Operation = &RelateOperation{}
+
+// RelateOperation will add or remove a typed relation to another bug
+type RelateOperation struct {
+	OpBase
+	Relation RelationType `json:"relation"`
+	Target   entity.Id    `json:"target"`
+	Removed  bool         `json:"removed,omitempty"`
+}
+
+// Sign-post method for gqlgen
+func (op *RelateOperation) IsOperation() {}
+
+func (op *RelateOperation) base() *OpBase {
+	return &op.OpBase
+}
+
+func (op *RelateOperation) Id() entity.Id {
+	return idOperation(op)
+}
+
+func (op *RelateOperation) Apply(snapshot *Snapshot) {
+	snapshot.addActor(op.Author)
+
+	relation := Relation{Type: op.Relation, Target: op.Target}
+
+	for i, r := range snapshot.Relations {
+		if r == relation {
+			if op.Removed {
+				snapshot.Relations = append(snapshot.Relations[:i], snapshot.Relations[i+1:]...)
+			}
+			return
+		}
+	}
+
+	if !op.Removed {
+		snapshot.Relations = append(snapshot.Relations, relation)
+	}
+}
+
+func (op *RelateOperation) Validate() error {
+	if err := opBaseValidate(op, RelateOp); err != nil {
+		return err
+	}
+
+	if err := op.Relation.Validate(); err != nil {
+		return errors.Wrap(err, "relation")
+	}
+
+	if err := op.Target.Validate(); err != nil {
+		return errors.Wrap(err, "target")
+	}
+
+	return nil
+}
+
+// UnmarshalJSON is a two step JSON unmarshaling
+// This workaround is necessary to avoid the inner OpBase.MarshalJSON
+// overriding the outer op's MarshalJSON
+func (op *RelateOperation) UnmarshalJSON(data []byte) error {
+	// Unmarshal OpBase and the op separately
+
+	base := OpBase{}
+	err := json.Unmarshal(data, &base)
+	if err != nil {
+		return err
+	}
+
+	aux := struct {
+		Relation RelationType `json:"relation"`
+		Target   entity.Id    `json:"target"`
+		Removed  bool         `json:"removed"`
+	}{}
+
+	err = json.Unmarshal(data, &aux)
+	if err != nil {
+		return err
+	}
+
+	op.OpBase = base
+	op.Relation = aux.Relation
+	op.Target = aux.Target
+	op.Removed = aux.Removed
+
+	return nil
+}
+
+// Sign post method for gqlgen
+func (op *RelateOperation) IsAuthored() {}
+
+func NewRelateOp(author identity.Interface, unixTime int64, relation RelationType, target entity.Id, removed bool) *RelateOperation {
+	return &RelateOperation{
+		OpBase:   newOpBase(RelateOp, author, unixTime),
+		Relation: relation,
+		Target:   target,
+		Removed:  removed,
+	}
+}
+
+// Convenience function to apply the operation
+func Relate(b Interface, author identity.Interface, unixTime int64, relation RelationType, target entity.Id) (*RelateOperation, error) {
+	op := NewRelateOp(author, unixTime, relation, target, false)
+	if err := op.Validate(); err != nil {
+		return nil, err
+	}
+	b.Append(op)
+	return op, nil
+}
+
+// Convenience function to apply the operation
+func Unrelate(b Interface, author identity.Interface, unixTime int64, relation RelationType, target entity.Id) (*RelateOperation, error) {
+	op := NewRelateOp(author, unixTime, relation, target, true)
+	if err := op.Validate(); err != nil {
+		return nil, err
+	}
+	b.Append(op)
+	return op, nil
+}
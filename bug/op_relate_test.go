@@ -0,0 +1,77 @@
+package bug
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/identity"
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+func TestRelateSerialize(t *testing.T) {
+	repo := repository.NewMockRepoForTest()
+	rene := identity.NewIdentity("René Descartes", "rene@descartes.fr")
+	err := rene.Commit(repo)
+	require.NoError(t, err)
+
+	unix := time.Now().Unix()
+	before := NewRelateOp(rene, unix, DuplicateOfRelation, "123", true)
+
+	data, err := json.Marshal(before)
+	assert.NoError(t, err)
+
+	var after RelateOperation
+	err = json.Unmarshal(data, &after)
+	assert.NoError(t, err)
+
+	// enforce creating the ID
+	before.Id()
+
+	// Replace the identity stub with the real thing
+	assert.Equal(t, rene.Id(), after.base().Author.Id())
+	after.Author = rene
+
+	assert.Equal(t, before, &after)
+}
+
+func TestRelateApply(t *testing.T) {
+	snapshot := Snapshot{}
+
+	repo := repository.NewMockRepoForTest()
+	rene := identity.NewIdentity("René Descartes", "rene@descartes.fr")
+	err := rene.Commit(repo)
+	require.NoError(t, err)
+
+	unix := time.Now().Unix()
+
+	target1 := NewCreateOp(rene, unix, "target 1", "message", nil).Id()
+	target2 := NewCreateOp(rene, unix, "target 2", "message", nil).Id()
+
+	NewRelateOp(rene, unix, DuplicateOfRelation, target1, false).Apply(&snapshot)
+	NewRelateOp(rene, unix, RelatedToRelation, target1, false).Apply(&snapshot)
+	NewRelateOp(rene, unix, RelatedToRelation, target2, false).Apply(&snapshot)
+	// already there
+	NewRelateOp(rene, unix, RelatedToRelation, target2, false).Apply(&snapshot)
+
+	assert.Equal(t, []Relation{
+		{Type: DuplicateOfRelation, Target: target1},
+		{Type: RelatedToRelation, Target: target1},
+		{Type: RelatedToRelation, Target: target2},
+	}, snapshot.Relations)
+
+	NewRelateOp(rene, unix, RelatedToRelation, target1, true).Apply(&snapshot)
+	// not there
+	NewRelateOp(rene, unix, CausedByRelation, target1, true).Apply(&snapshot)
+
+	assert.Equal(t, []Relation{
+		{Type: DuplicateOfRelation, Target: target1},
+		{Type: RelatedToRelation, Target: target2},
+	}, snapshot.Relations)
+
+	assert.Equal(t, []entity.Id{target2}, snapshot.RelatedTo(RelatedToRelation))
+}
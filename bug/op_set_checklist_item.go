@@ -0,0 +1,137 @@
+package bug
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/identity"
+)
+
+var _ Operation = &SetChecklistItemOperation{}
+
+// SetChecklistItemOperation will check or uncheck a markdown checkbox of a
+// comment, without going through a full comment edition.
+type SetChecklistItemOperation struct {
+	OpBase
+	// Target is the id of the comment holding the checklist
+	Target entity.Id `json:"target"`
+	// Item is the index of the checkbox in the comment, starting at 0
+	Item    int  `json:"item"`
+	Checked bool `json:"checked"`
+}
+
+// Sign-post method for gqlgen
+func (op *SetChecklistItemOperation) IsOperation() {}
+
+func (op *SetChecklistItemOperation) base() *OpBase {
+	return &op.OpBase
+}
+
+func (op *SetChecklistItemOperation) Id() entity.Id {
+	return idOperation(op)
+}
+
+func (op *SetChecklistItemOperation) Apply(snapshot *Snapshot) {
+	snapshot.addActor(op.Author)
+
+	for i := range snapshot.Comments {
+		if snapshot.Comments[i].Id() != op.Target {
+			continue
+		}
+
+		message, ok := setChecklistItem(snapshot.Comments[i].Message, op.Item, op.Checked)
+		if !ok {
+			// the checkbox doesn't exist (anymore), this is a no-op
+			return
+		}
+		snapshot.Comments[i].Message = message
+		break
+	}
+
+	// keep the timeline in sync, without adding an history step as the
+	// comment hasn't been edited
+	for _, item := range snapshot.Timeline {
+		if item.Id() != op.Target {
+			continue
+		}
+		switch item := item.(type) {
+		case *CreateTimelineItem:
+			item.Message, _ = setChecklistItem(item.Message, op.Item, op.Checked)
+		case *AddCommentTimelineItem:
+			item.Message, _ = setChecklistItem(item.Message, op.Item, op.Checked)
+		}
+		break
+	}
+}
+
+func (op *SetChecklistItemOperation) Validate() error {
+	if err := opBaseValidate(op, SetChecklistItemOp); err != nil {
+		return err
+	}
+
+	if err := op.Target.Validate(); err != nil {
+		return errors.Wrap(err, "target hash is invalid")
+	}
+
+	if op.Item < 0 {
+		return fmt.Errorf("item index should be positive")
+	}
+
+	return nil
+}
+
+// UnmarshalJSON is a two step JSON unmarshaling
+// This workaround is necessary to avoid the inner OpBase.MarshalJSON
+// overriding the outer op's MarshalJSON
+func (op *SetChecklistItemOperation) UnmarshalJSON(data []byte) error {
+	// Unmarshal OpBase and the op separately
+
+	base := OpBase{}
+	err := json.Unmarshal(data, &base)
+	if err != nil {
+		return err
+	}
+
+	aux := struct {
+		Target  entity.Id `json:"target"`
+		Item    int       `json:"item"`
+		Checked bool      `json:"checked"`
+	}{}
+
+	err = json.Unmarshal(data, &aux)
+	if err != nil {
+		return err
+	}
+
+	op.OpBase = base
+	op.Target = aux.Target
+	op.Item = aux.Item
+	op.Checked = aux.Checked
+
+	return nil
+}
+
+// Sign post method for gqlgen
+func (op *SetChecklistItemOperation) IsAuthored() {}
+
+func NewSetChecklistItemOp(author identity.Interface, unixTime int64, target entity.Id, item int, checked bool) *SetChecklistItemOperation {
+	return &SetChecklistItemOperation{
+		OpBase:  newOpBase(SetChecklistItemOp, author, unixTime),
+		Target:  target,
+		Item:    item,
+		Checked: checked,
+	}
+}
+
+// Convenience function to apply the operation
+func SetChecklistItem(b Interface, author identity.Interface, unixTime int64, target entity.Id, item int, checked bool) (*SetChecklistItemOperation, error) {
+	op := NewSetChecklistItemOp(author, unixTime, target, item, checked)
+	if err := op.Validate(); err != nil {
+		return nil, err
+	}
+	b.Append(op)
+	return op, nil
+}
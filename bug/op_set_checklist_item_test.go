@@ -0,0 +1,81 @@
+package bug
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MichaelMure/git-bug/identity"
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+func TestSetChecklistItemSerialize(t *testing.T) {
+	repo := repository.NewMockRepoForTest()
+	rene := identity.NewIdentity("René Descartes", "rene@descartes.fr")
+	err := rene.Commit(repo)
+	require.NoError(t, err)
+
+	unix := time.Now().Unix()
+	before := NewSetChecklistItemOp(rene, unix, "123", 2, true)
+
+	data, err := json.Marshal(before)
+	assert.NoError(t, err)
+
+	var after SetChecklistItemOperation
+	err = json.Unmarshal(data, &after)
+	assert.NoError(t, err)
+
+	// enforce creating the ID
+	before.Id()
+
+	// Replace the identity stub with the real thing
+	assert.Equal(t, rene.Id(), after.base().Author.Id())
+	after.Author = rene
+
+	assert.Equal(t, before, &after)
+}
+
+func TestSetChecklistItemApply(t *testing.T) {
+	snapshot := Snapshot{}
+
+	repo := repository.NewMockRepoForTest()
+	rene := identity.NewIdentity("René Descartes", "rene@descartes.fr")
+	err := rene.Commit(repo)
+	require.NoError(t, err)
+
+	unix := time.Now().Unix()
+
+	create := NewCreateOp(rene, unix, "title", "- [ ] first\n- [x] second\n* [ ] third", nil)
+	create.Apply(&snapshot)
+
+	id := create.Id()
+	require.NoError(t, id.Validate())
+
+	done, total := snapshot.ChecklistProgress()
+	assert.Equal(t, 1, done)
+	assert.Equal(t, 3, total)
+
+	NewSetChecklistItemOp(rene, unix, id, 0, true).Apply(&snapshot)
+	NewSetChecklistItemOp(rene, unix, id, 1, false).Apply(&snapshot)
+	NewSetChecklistItemOp(rene, unix, id, 2, true).Apply(&snapshot)
+
+	// unknown item, no-op
+	NewSetChecklistItemOp(rene, unix, id, 10, true).Apply(&snapshot)
+
+	assert.Equal(t, "- [x] first\n- [ ] second\n* [x] third", snapshot.Comments[0].Message)
+	assert.Equal(t, "- [x] first\n- [ ] second\n* [x] third", snapshot.Timeline[0].(*CreateTimelineItem).Message)
+	assert.Len(t, snapshot.Timeline[0].(*CreateTimelineItem).History, 1)
+
+	assert.Equal(t, []ChecklistItem{
+		{Text: "first", Checked: true},
+		{Text: "second", Checked: false},
+		{Text: "third", Checked: true},
+	}, ParseChecklist(snapshot.Comments[0].Message))
+
+	done, total = snapshot.ChecklistProgress()
+	assert.Equal(t, 2, done)
+	assert.Equal(t, 3, total)
+}
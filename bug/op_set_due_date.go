@@ -0,0 +1,98 @@
+package bug
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/identity"
+)
+
+var _ Operation = &SetDueDateOperation{}
+
+// SetDueDateOperation will change the date a bug is due for, as a unix
+// timestamp. A zero date remove the due date.
+type SetDueDateOperation struct {
+	OpBase
+	DueDate int64 `json:"due_date"`
+}
+
+// Sign-post method for gqlgen
+func (op *SetDueDateOperation) IsOperation() {}
+
+func (op *SetDueDateOperation) base() *OpBase {
+	return &op.OpBase
+}
+
+func (op *SetDueDateOperation) Id() entity.Id {
+	return idOperation(op)
+}
+
+func (op *SetDueDateOperation) Apply(snapshot *Snapshot) {
+	if op.DueDate == 0 {
+		snapshot.DueDate = time.Time{}
+	} else {
+		snapshot.DueDate = time.Unix(op.DueDate, 0)
+	}
+	snapshot.addActor(op.Author)
+}
+
+func (op *SetDueDateOperation) Validate() error {
+	if err := opBaseValidate(op, SetDueDateOp); err != nil {
+		return err
+	}
+
+	if op.DueDate < 0 {
+		return fmt.Errorf("negative due date")
+	}
+
+	return nil
+}
+
+// UnmarshalJSON is a two step JSON unmarshaling
+// This workaround is necessary to avoid the inner OpBase.MarshalJSON
+// overriding the outer op's MarshalJSON
+func (op *SetDueDateOperation) UnmarshalJSON(data []byte) error {
+	// Unmarshal OpBase and the op separately
+
+	base := OpBase{}
+	err := json.Unmarshal(data, &base)
+	if err != nil {
+		return err
+	}
+
+	aux := struct {
+		DueDate int64 `json:"due_date"`
+	}{}
+
+	err = json.Unmarshal(data, &aux)
+	if err != nil {
+		return err
+	}
+
+	op.OpBase = base
+	op.DueDate = aux.DueDate
+
+	return nil
+}
+
+// Sign post method for gqlgen
+func (op *SetDueDateOperation) IsAuthored() {}
+
+func NewSetDueDateOp(author identity.Interface, unixTime int64, dueDate int64) *SetDueDateOperation {
+	return &SetDueDateOperation{
+		OpBase:  newOpBase(SetDueDateOp, author, unixTime),
+		DueDate: dueDate,
+	}
+}
+
+// Convenience function to apply the operation
+func SetDueDate(b Interface, author identity.Interface, unixTime int64, dueDate int64) (*SetDueDateOperation, error) {
+	op := NewSetDueDateOp(author, unixTime, dueDate)
+	if err := op.Validate(); err != nil {
+		return nil, err
+	}
+	b.Append(op)
+	return op, nil
+}
@@ -0,0 +1,94 @@
+package bug
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/identity"
+)
+
+var _ Operation = &SetEstimateOperation{}
+
+// SetEstimateOperation will change the estimated time needed to resolve a bug.
+// A zero duration remove the estimate.
+type SetEstimateOperation struct {
+	OpBase
+	Duration time.Duration `json:"duration"`
+}
+
+// Sign-post method for gqlgen
+func (op *SetEstimateOperation) IsOperation() {}
+
+func (op *SetEstimateOperation) base() *OpBase {
+	return &op.OpBase
+}
+
+func (op *SetEstimateOperation) Id() entity.Id {
+	return idOperation(op)
+}
+
+func (op *SetEstimateOperation) Apply(snapshot *Snapshot) {
+	snapshot.Estimate = op.Duration
+	snapshot.addActor(op.Author)
+}
+
+func (op *SetEstimateOperation) Validate() error {
+	if err := opBaseValidate(op, SetEstimateOp); err != nil {
+		return err
+	}
+
+	if op.Duration < 0 {
+		return fmt.Errorf("negative estimate")
+	}
+
+	return nil
+}
+
+// UnmarshalJSON is a two step JSON unmarshaling
+// This workaround is necessary to avoid the inner OpBase.MarshalJSON
+// overriding the outer op's MarshalJSON
+func (op *SetEstimateOperation) UnmarshalJSON(data []byte) error {
+	// Unmarshal OpBase and the op separately
+
+	base := OpBase{}
+	err := json.Unmarshal(data, &base)
+	if err != nil {
+		return err
+	}
+
+	aux := struct {
+		Duration time.Duration `json:"duration"`
+	}{}
+
+	err = json.Unmarshal(data, &aux)
+	if err != nil {
+		return err
+	}
+
+	op.OpBase = base
+	op.Duration = aux.Duration
+
+	return nil
+}
+
+// Sign post method for gqlgen
+func (op *SetEstimateOperation) IsAuthored() {}
+
+func NewSetEstimateOp(author identity.Interface, unixTime int64, duration time.Duration) *SetEstimateOperation {
+	return &SetEstimateOperation{
+		OpBase:   newOpBase(SetEstimateOp, author, unixTime),
+		Duration: duration,
+	}
+}
+
+// Convenience function to apply the operation
+func SetEstimate(b Interface, author identity.Interface, unixTime int64, duration time.Duration) (*SetEstimateOperation, error) {
+	op := NewSetEstimateOp(author, unixTime, duration)
+	if err := op.Validate(); err != nil {
+		return nil, err
+	}
+	b.Append(op)
+	return op, nil
+}
@@ -0,0 +1,118 @@
+package bug
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+
+	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/identity"
+)
+
+var _ Operation = &SetFieldOperation{}
+
+// SetFieldOperation will change the value of a custom field of a bug.
+// An empty value unset the field.
+//
+// The value is checked against the repository FieldSchema in SetField, as the
+// schema is not available to the operation itself.
+type SetFieldOperation struct {
+	OpBase
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// Sign-post method for gqlgen
+func (op *SetFieldOperation) IsOperation() {}
+
+func (op *SetFieldOperation) base() *OpBase {
+	return &op.OpBase
+}
+
+func (op *SetFieldOperation) Id() entity.Id {
+	return idOperation(op)
+}
+
+func (op *SetFieldOperation) Apply(snapshot *Snapshot) {
+	snapshot.addActor(op.Author)
+
+	if op.Value == "" {
+		delete(snapshot.Fields, op.Name)
+		return
+	}
+
+	if snapshot.Fields == nil {
+		snapshot.Fields = make(map[string]string)
+	}
+	snapshot.Fields[op.Name] = op.Value
+}
+
+func (op *SetFieldOperation) Validate() error {
+	if err := opBaseValidate(op, SetFieldOp); err != nil {
+		return err
+	}
+
+	if err := ValidateFieldName(op.Name); err != nil {
+		return errors.Wrap(err, "name")
+	}
+
+	if err := validateFieldValueText(op.Value); err != nil {
+		return errors.Wrap(err, "value")
+	}
+
+	return nil
+}
+
+// UnmarshalJSON is a two step JSON unmarshaling
+// This workaround is necessary to avoid the inner OpBase.MarshalJSON
+// overriding the outer op's MarshalJSON
+func (op *SetFieldOperation) UnmarshalJSON(data []byte) error {
+	// Unmarshal OpBase and the op separately
+
+	base := OpBase{}
+	err := json.Unmarshal(data, &base)
+	if err != nil {
+		return err
+	}
+
+	aux := struct {
+		Name  string `json:"name"`
+		Value string `json:"value"`
+	}{}
+
+	err = json.Unmarshal(data, &aux)
+	if err != nil {
+		return err
+	}
+
+	op.OpBase = base
+	op.Name = aux.Name
+	op.Value = aux.Value
+
+	return nil
+}
+
+// Sign post method for gqlgen
+func (op *SetFieldOperation) IsAuthored() {}
+
+func NewSetFieldOp(author identity.Interface, unixTime int64, name string, value string) *SetFieldOperation {
+	return &SetFieldOperation{
+		OpBase: newOpBase(SetFieldOp, author, unixTime),
+		Name:   name,
+		Value:  value,
+	}
+}
+
+// Convenience function to apply the operation
+func SetField(b Interface, author identity.Interface, unixTime int64, schema FieldSchema, name string, value string) (*SetFieldOperation, error) {
+	if err := schema.ValidateValue(name, value); err != nil {
+		return nil, err
+	}
+
+	op := NewSetFieldOp(author, unixTime, name, value)
+	if err := op.Validate(); err != nil {
+		return nil, err
+	}
+	b.Append(op)
+	return op, nil
+}
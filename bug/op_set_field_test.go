@@ -0,0 +1,84 @@
+package bug
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MichaelMure/git-bug/identity"
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+func TestSetFieldSerialize(t *testing.T) {
+	repo := repository.NewMockRepoForTest()
+	rene := identity.NewIdentity("René Descartes", "rene@descartes.fr")
+	err := rene.Commit(repo)
+	require.NoError(t, err)
+
+	unix := time.Now().Unix()
+	before := NewSetFieldOp(rene, unix, "env", "prod")
+
+	data, err := json.Marshal(before)
+	assert.NoError(t, err)
+
+	var after SetFieldOperation
+	err = json.Unmarshal(data, &after)
+	assert.NoError(t, err)
+
+	// enforce creating the ID
+	before.Id()
+
+	// Replace the identity stub with the real thing
+	assert.Equal(t, rene.Id(), after.base().Author.Id())
+	after.Author = rene
+
+	assert.Equal(t, before, &after)
+}
+
+func TestSetFieldApply(t *testing.T) {
+	repo := repository.NewMockRepoForTest()
+	rene := identity.NewIdentity("René Descartes", "rene@descartes.fr")
+	err := rene.Commit(repo)
+	require.NoError(t, err)
+
+	unix := time.Now().Unix()
+
+	snapshot := Snapshot{}
+
+	NewSetFieldOp(rene, unix, "env", "prod").Apply(&snapshot)
+	assert.Equal(t, map[string]string{"env": "prod"}, snapshot.Fields)
+
+	NewSetFieldOp(rene, unix, "env", "").Apply(&snapshot)
+	assert.Empty(t, snapshot.Fields)
+}
+
+func TestFieldSchemaValidateValue(t *testing.T) {
+	schema := FieldSchema{
+		"env":      {Name: "env", Type: FieldEnum, Values: []string{"prod", "staging"}},
+		"version":  {Name: "version", Type: FieldString},
+		"estimate": {Name: "estimate", Type: FieldNumber},
+		"due":      {Name: "due", Type: FieldDate},
+	}
+
+	for _, def := range schema {
+		require.NoError(t, def.Validate())
+	}
+
+	assert.NoError(t, schema.ValidateValue("env", "prod"))
+	assert.NoError(t, schema.ValidateValue("env", ""))
+	assert.Error(t, schema.ValidateValue("env", "dev"))
+	assert.NoError(t, schema.ValidateValue("version", "1.2.3"))
+	assert.Error(t, schema.ValidateValue("version", "multi\nline"))
+	assert.NoError(t, schema.ValidateValue("estimate", "4.5"))
+	assert.Error(t, schema.ValidateValue("estimate", "four"))
+	assert.NoError(t, schema.ValidateValue("due", "2020-10-01"))
+	assert.Error(t, schema.ValidateValue("due", "tomorrow"))
+	assert.Error(t, schema.ValidateValue("unknown", "value"))
+
+	assert.Error(t, FieldDefinition{Name: "env", Type: FieldEnum}.Validate())
+	assert.Error(t, FieldDefinition{Name: "has space", Type: FieldString}.Validate())
+	assert.Error(t, FieldDefinition{Name: "x", Type: FieldString, Values: []string{"a"}}.Validate())
+}
@@ -0,0 +1,103 @@
+package bug
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/identity"
+	"github.com/MichaelMure/git-bug/util/text"
+)
+
+var _ Operation = &SetMilestoneOperation{}
+
+// SetMilestoneOperation will change the milestone a bug is planned for.
+// An empty milestone remove the bug from its milestone.
+type SetMilestoneOperation struct {
+	OpBase
+	Milestone string `json:"milestone"`
+}
+
+// Sign-post method for gqlgen
+func (op *SetMilestoneOperation) IsOperation() {}
+
+func (op *SetMilestoneOperation) base() *OpBase {
+	return &op.OpBase
+}
+
+func (op *SetMilestoneOperation) Id() entity.Id {
+	return idOperation(op)
+}
+
+func (op *SetMilestoneOperation) Apply(snapshot *Snapshot) {
+	snapshot.Milestone = op.Milestone
+	snapshot.addActor(op.Author)
+}
+
+func (op *SetMilestoneOperation) Validate() error {
+	if err := opBaseValidate(op, SetMilestoneOp); err != nil {
+		return err
+	}
+
+	if strings.Contains(op.Milestone, "\n") {
+		return fmt.Errorf("milestone should be a single line")
+	}
+
+	if !text.Safe(op.Milestone) {
+		return fmt.Errorf("milestone should be fully printable")
+	}
+
+	if op.Milestone != strings.TrimSpace(op.Milestone) {
+		return fmt.Errorf("milestone has leading or trailing spaces")
+	}
+
+	return nil
+}
+
+// UnmarshalJSON is a two step JSON unmarshaling
+// This workaround is necessary to avoid the inner OpBase.MarshalJSON
+// overriding the outer op's MarshalJSON
+func (op *SetMilestoneOperation) UnmarshalJSON(data []byte) error {
+	// Unmarshal OpBase and the op separately
+
+	base := OpBase{}
+	err := json.Unmarshal(data, &base)
+	if err != nil {
+		return err
+	}
+
+	aux := struct {
+		Milestone string `json:"milestone"`
+	}{}
+
+	err = json.Unmarshal(data, &aux)
+	if err != nil {
+		return err
+	}
+
+	op.OpBase = base
+	op.Milestone = aux.Milestone
+
+	return nil
+}
+
+// Sign post method for gqlgen
+func (op *SetMilestoneOperation) IsAuthored() {}
+
+func NewSetMilestoneOp(author identity.Interface, unixTime int64, milestone string) *SetMilestoneOperation {
+	return &SetMilestoneOperation{
+		OpBase:    newOpBase(SetMilestoneOp, author, unixTime),
+		Milestone: milestone,
+	}
+}
+
+// Convenience function to apply the operation
+func SetMilestone(b Interface, author identity.Interface, unixTime int64, milestone string) (*SetMilestoneOperation, error) {
+	op := NewSetMilestoneOp(author, unixTime, milestone)
+	if err := op.Validate(); err != nil {
+		return nil, err
+	}
+	b.Append(op)
+	return op, nil
+}
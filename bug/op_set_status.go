@@ -16,6 +16,8 @@ var _ Operation = &SetStatusOperation{}
 type SetStatusOperation struct {
 	OpBase
 	Status Status `json:"status"`
+	// Extended is the optional repository defined status refining Status
+	Extended string `json:"extended,omitempty"`
 }
 
 // Sign-post method for gqlgen
@@ -31,6 +33,7 @@ func (op *SetStatusOperation) Id() entity.Id {
 
 func (op *SetStatusOperation) Apply(snapshot *Snapshot) {
 	snapshot.Status = op.Status
+	snapshot.ExtendedStatus = op.Extended
 	snapshot.addActor(op.Author)
 
 	item := &SetStatusTimelineItem{
@@ -38,6 +41,7 @@ func (op *SetStatusOperation) Apply(snapshot *Snapshot) {
 		Author:   op.Author,
 		UnixTime: timestamp.Timestamp(op.UnixTime),
 		Status:   op.Status,
+		Extended: op.Extended,
 	}
 
 	snapshot.Timeline = append(snapshot.Timeline, item)
@@ -52,6 +56,12 @@ func (op *SetStatusOperation) Validate() error {
 		return errors.Wrap(err, "status")
 	}
 
+	if op.Extended != "" {
+		if err := ValidateExtendedStatusName(op.Extended); err != nil {
+			return errors.Wrap(err, "extended status")
+		}
+	}
+
 	return nil
 }
 
@@ -68,7 +78,8 @@ func (op *SetStatusOperation) UnmarshalJSON(data []byte) error {
 	}
 
 	aux := struct {
-		Status Status `json:"status"`
+		Status   Status `json:"status"`
+		Extended string `json:"extended,omitempty"`
 	}{}
 
 	err = json.Unmarshal(data, &aux)
@@ -78,6 +89,7 @@ func (op *SetStatusOperation) UnmarshalJSON(data []byte) error {
 
 	op.OpBase = base
 	op.Status = aux.Status
+	op.Extended = aux.Extended
 
 	return nil
 }
@@ -92,11 +104,20 @@ func NewSetStatusOp(author identity.Interface, unixTime int64, status Status) *S
 	}
 }
 
+func NewSetExtendedStatusOp(author identity.Interface, unixTime int64, status ExtendedStatus) *SetStatusOperation {
+	return &SetStatusOperation{
+		OpBase:   newOpBase(SetStatusOp, author, unixTime),
+		Status:   status.Status,
+		Extended: status.Name,
+	}
+}
+
 type SetStatusTimelineItem struct {
 	id       entity.Id
 	Author   identity.Interface
 	UnixTime timestamp.Timestamp
 	Status   Status
+	Extended string
 }
 
 func (s SetStatusTimelineItem) Id() entity.Id {
@@ -125,3 +146,17 @@ func Close(b Interface, author identity.Interface, unixTime int64) (*SetStatusOp
 	b.Append(op)
 	return op, nil
 }
+
+// Convenience function to apply the operation
+func SetExtendedStatus(b Interface, author identity.Interface, unixTime int64, status ExtendedStatus) (*SetStatusOperation, error) {
+	if err := status.Validate(); err != nil {
+		return nil, err
+	}
+
+	op := NewSetExtendedStatusOp(author, unixTime, status)
+	if err := op.Validate(); err != nil {
+		return nil, err
+	}
+	b.Append(op)
+	return op, nil
+}
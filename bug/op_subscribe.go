@@ -0,0 +1,99 @@
+package bug
+
+import (
+	"encoding/json"
+
+	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/identity"
+)
+
+var _ Operation = &SubscribeOperation{}
+
+// SubscribeOperation will subscribe its author to a bug, so that they can
+// watch it without commenting. Unsubscribe reverse it.
+type SubscribeOperation struct {
+	OpBase
+	Unsubscribe bool `json:"unsubscribe,omitempty"`
+}
+
+// Sign-post method for gqlgen
+func (op *SubscribeOperation) IsOperation() {}
+
+func (op *SubscribeOperation) base() *OpBase {
+	return &op.OpBase
+}
+
+func (op *SubscribeOperation) Id() entity.Id {
+	return idOperation(op)
+}
+
+func (op *SubscribeOperation) Apply(snapshot *Snapshot) {
+	snapshot.addActor(op.Author)
+
+	if op.Unsubscribe {
+		snapshot.removeSubscriber(op.Author)
+	} else {
+		snapshot.addSubscriber(op.Author)
+	}
+}
+
+func (op *SubscribeOperation) Validate() error {
+	return opBaseValidate(op, SubscribeOp)
+}
+
+// UnmarshalJSON is a two step JSON unmarshaling
+// This workaround is necessary to avoid the inner OpBase.MarshalJSON
+// overriding the outer op's MarshalJSON
+func (op *SubscribeOperation) UnmarshalJSON(data []byte) error {
+	// Unmarshal OpBase and the op separately
+
+	base := OpBase{}
+	err := json.Unmarshal(data, &base)
+	if err != nil {
+		return err
+	}
+
+	aux := struct {
+		Unsubscribe bool `json:"unsubscribe"`
+	}{}
+
+	err = json.Unmarshal(data, &aux)
+	if err != nil {
+		return err
+	}
+
+	op.OpBase = base
+	op.Unsubscribe = aux.Unsubscribe
+
+	return nil
+}
+
+// Sign post method for gqlgen
+func (op *SubscribeOperation) IsAuthored() {}
+
+func NewSubscribeOp(author identity.Interface, unixTime int64, unsubscribe bool) *SubscribeOperation {
+	return &SubscribeOperation{
+		OpBase:      newOpBase(SubscribeOp, author, unixTime),
+		Unsubscribe: unsubscribe,
+	}
+}
+
+// Convenience function to apply the operation
+func Subscribe(b Interface, author identity.Interface, unixTime int64) (*SubscribeOperation, error) {
+	op := NewSubscribeOp(author, unixTime, false)
+	if err := op.Validate(); err != nil {
+		return nil, err
+	}
+	b.Append(op)
+	return op, nil
+}
+
+// Convenience function to apply the operation
+func Unsubscribe(b Interface, author identity.Interface, unixTime int64) (*SubscribeOperation, error) {
+	op := NewSubscribeOp(author, unixTime, true)
+	if err := op.Validate(); err != nil {
+		return nil, err
+	}
+	b.Append(op)
+	return op, nil
+}
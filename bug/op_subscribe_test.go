@@ -0,0 +1,68 @@
+package bug
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MichaelMure/git-bug/identity"
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+func TestSubscribeSerialize(t *testing.T) {
+	repo := repository.NewMockRepoForTest()
+	rene := identity.NewIdentity("René Descartes", "rene@descartes.fr")
+	err := rene.Commit(repo)
+	require.NoError(t, err)
+
+	unix := time.Now().Unix()
+	before := NewSubscribeOp(rene, unix, true)
+
+	data, err := json.Marshal(before)
+	assert.NoError(t, err)
+
+	var after SubscribeOperation
+	err = json.Unmarshal(data, &after)
+	assert.NoError(t, err)
+
+	// enforce creating the ID
+	before.Id()
+
+	// Replace the identity stub with the real thing
+	assert.Equal(t, rene.Id(), after.base().Author.Id())
+	after.Author = rene
+
+	assert.Equal(t, before, &after)
+}
+
+func TestSubscribeApply(t *testing.T) {
+	snapshot := Snapshot{}
+
+	repo := repository.NewMockRepoForTest()
+	rene := identity.NewIdentity("René Descartes", "rene@descartes.fr")
+	err := rene.Commit(repo)
+	require.NoError(t, err)
+	isaac := identity.NewIdentity("Isaac Newton", "isaac@newton.uk")
+	err = isaac.Commit(repo)
+	require.NoError(t, err)
+
+	unix := time.Now().Unix()
+
+	NewSubscribeOp(rene, unix, false).Apply(&snapshot)
+	NewSubscribeOp(isaac, unix, false).Apply(&snapshot)
+	NewSubscribeOp(rene, unix, false).Apply(&snapshot)
+
+	assert.Len(t, snapshot.Subscribers, 2)
+	assert.True(t, snapshot.HasSubscriber(rene.Id()))
+	assert.True(t, snapshot.HasSubscriber(isaac.Id()))
+	assert.False(t, snapshot.HasParticipant(rene.Id()))
+
+	NewSubscribeOp(rene, unix, true).Apply(&snapshot)
+
+	assert.Len(t, snapshot.Subscribers, 1)
+	assert.False(t, snapshot.HasSubscriber(rene.Id()))
+	assert.True(t, snapshot.HasSubscriber(isaac.Id()))
+}
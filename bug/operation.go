@@ -26,6 +26,19 @@ const (
 	EditCommentOp
 	NoOpOp
 	SetMetadataOp
+	SetFieldOp
+	AddTimeSpentOp
+	SetEstimateOp
+	SetChecklistItemOp
+	RelateOp
+	SubscribeOp
+	RedactOp
+	MinimizeCommentOp
+	PinCommentOp
+	CodeRefOp
+	AssignOp
+	SetMilestoneOp
+	SetDueDateOp
 )
 
 // Operation define the interface to fulfill for an edit operation of a Bug
@@ -50,6 +63,8 @@ type Operation interface {
 	AllMetadata() map[string]string
 	// GetAuthor return the author identity
 	GetAuthor() identity.Interface
+	// GetCoAuthors return the additional authors of the operation, if any
+	GetCoAuthors() []identity.Interface
 
 	// sign-post method for gqlgen
 	IsOperation()
@@ -87,6 +102,9 @@ func idOperation(op Operation) entity.Id {
 type OpBase struct {
 	OperationType OperationType      `json:"type"`
 	Author        identity.Interface `json:"author"`
+	// CoAuthors are the optional additional authors of the operation,
+	// like git's Co-authored-by. Only supported on create and comment operations.
+	CoAuthors []identity.Interface `json:"co_authors,omitempty"`
 	// TODO: part of the data model upgrade, this should eventually be a timestamp + lamport
 	UnixTime int64             `json:"timestamp"`
 	Metadata map[string]string `json:"metadata,omitempty"`
@@ -114,6 +132,7 @@ func (op *OpBase) UnmarshalJSON(data []byte) error {
 	aux := struct {
 		OperationType OperationType     `json:"type"`
 		Author        json.RawMessage   `json:"author"`
+		CoAuthors     []json.RawMessage `json:"co_authors,omitempty"`
 		UnixTime      int64             `json:"timestamp"`
 		Metadata      map[string]string `json:"metadata,omitempty"`
 	}{}
@@ -128,6 +147,14 @@ func (op *OpBase) UnmarshalJSON(data []byte) error {
 		return err
 	}
 
+	for _, raw := range aux.CoAuthors {
+		coAuthor, err := identity.UnmarshalJSON(raw)
+		if err != nil {
+			return err
+		}
+		op.CoAuthors = append(op.CoAuthors, coAuthor)
+	}
+
 	op.OperationType = aux.OperationType
 	op.Author = author
 	op.UnixTime = aux.UnixTime
@@ -164,6 +191,29 @@ func opBaseValidate(op Operation, opType OperationType) error {
 		return errors.Wrap(err, "author")
 	}
 
+	if len(op.base().CoAuthors) > 0 && opType != CreateOp && opType != AddCommentOp {
+		return fmt.Errorf("co-authors are only supported on create and comment operations")
+	}
+
+	// only look at the ids when there are co-authors, as the author of a new
+	// bug can be an identity not committed yet
+	var seen map[entity.Id]struct{}
+	if len(op.base().CoAuthors) > 0 {
+		seen = map[entity.Id]struct{}{op.base().Author.Id(): {}}
+	}
+	for _, coAuthor := range op.base().CoAuthors {
+		if coAuthor == nil {
+			return fmt.Errorf("co-author not set")
+		}
+		if err := coAuthor.Validate(); err != nil {
+			return errors.Wrap(err, "co-author")
+		}
+		if _, ok := seen[coAuthor.Id()]; ok {
+			return fmt.Errorf("duplicated author %s", coAuthor.Id().Human())
+		}
+		seen[coAuthor.Id()] = struct{}{}
+	}
+
 	for _, hash := range op.GetFiles() {
 		if !hash.IsValid() {
 			return fmt.Errorf("file with invalid hash %v", hash)
@@ -217,3 +267,14 @@ func (op *OpBase) AllMetadata() map[string]string {
 func (op *OpBase) GetAuthor() identity.Interface {
 	return op.Author
 }
+
+// GetCoAuthors return the additional authors of the operation, if any
+func (op *OpBase) GetCoAuthors() []identity.Interface {
+	return op.CoAuthors
+}
+
+// SetCoAuthors set the additional authors of the operation
+func (op *OpBase) SetCoAuthors(coAuthors []identity.Interface) {
+	op.CoAuthors = coAuthors
+	op.id = entity.UnsetId
+}
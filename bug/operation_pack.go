@@ -7,6 +7,7 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/MichaelMure/git-bug/repository"
+	"github.com/MichaelMure/git-bug/util/lamport"
 )
 
 // 1: original format
@@ -24,6 +25,9 @@ type OperationPack struct {
 
 	// Private field so not serialized
 	commitHash repository.Hash
+	// the edit lamport time of the commit, to know which keys of the authors
+	// were valid at that time
+	editTime lamport.Time
 }
 
 func (opp *OperationPack) MarshalJSON() ([]byte, error) {
@@ -80,6 +84,18 @@ func (opp *OperationPack) unmarshalOp(raw []byte, _type OperationType) (Operatio
 		op := &AddCommentOperation{}
 		err := json.Unmarshal(raw, &op)
 		return op, err
+	case AddTimeSpentOp:
+		op := &AddTimeSpentOperation{}
+		err := json.Unmarshal(raw, &op)
+		return op, err
+	case AssignOp:
+		op := &AssignOperation{}
+		err := json.Unmarshal(raw, &op)
+		return op, err
+	case CodeRefOp:
+		op := &CodeRefOperation{}
+		err := json.Unmarshal(raw, &op)
+		return op, err
 	case CreateOp:
 		op := &CreateOperation{}
 		err := json.Unmarshal(raw, &op)
@@ -92,14 +108,50 @@ func (opp *OperationPack) unmarshalOp(raw []byte, _type OperationType) (Operatio
 		op := &LabelChangeOperation{}
 		err := json.Unmarshal(raw, &op)
 		return op, err
+	case MinimizeCommentOp:
+		op := &MinimizeCommentOperation{}
+		err := json.Unmarshal(raw, &op)
+		return op, err
 	case NoOpOp:
 		op := &NoOpOperation{}
 		err := json.Unmarshal(raw, &op)
 		return op, err
+	case PinCommentOp:
+		op := &PinCommentOperation{}
+		err := json.Unmarshal(raw, &op)
+		return op, err
+	case RedactOp:
+		op := &RedactOperation{}
+		err := json.Unmarshal(raw, &op)
+		return op, err
+	case RelateOp:
+		op := &RelateOperation{}
+		err := json.Unmarshal(raw, &op)
+		return op, err
+	case SetChecklistItemOp:
+		op := &SetChecklistItemOperation{}
+		err := json.Unmarshal(raw, &op)
+		return op, err
+	case SetDueDateOp:
+		op := &SetDueDateOperation{}
+		err := json.Unmarshal(raw, &op)
+		return op, err
+	case SetEstimateOp:
+		op := &SetEstimateOperation{}
+		err := json.Unmarshal(raw, &op)
+		return op, err
+	case SetFieldOp:
+		op := &SetFieldOperation{}
+		err := json.Unmarshal(raw, &op)
+		return op, err
 	case SetMetadataOp:
 		op := &SetMetadataOperation{}
 		err := json.Unmarshal(raw, &op)
 		return op, err
+	case SetMilestoneOp:
+		op := &SetMilestoneOperation{}
+		err := json.Unmarshal(raw, &op)
+		return op, err
 	case SetStatusOp:
 		op := &SetStatusOperation{}
 		err := json.Unmarshal(raw, &op)
@@ -108,6 +160,10 @@ func (opp *OperationPack) unmarshalOp(raw []byte, _type OperationType) (Operatio
 		op := &SetTitleOperation{}
 		err := json.Unmarshal(raw, &op)
 		return op, err
+	case SubscribeOp:
+		op := &SubscribeOperation{}
+		err := json.Unmarshal(raw, &op)
+		return op, err
 	default:
 		return nil, fmt.Errorf("unknown operation type %v", _type)
 	}
@@ -141,10 +197,26 @@ func (opp *OperationPack) Validate() error {
 // Write will serialize and store the OperationPack as a git blob and return
 // its hash
 func (opp *OperationPack) Write(repo repository.ClockedRepo) (repository.Hash, error) {
+	data, err := opp.serialize()
+	if err != nil {
+		return "", err
+	}
+
+	hash, err := repo.StoreData(data)
+
+	if err != nil {
+		return "", err
+	}
+
+	return hash, nil
+}
+
+// serialize validate the OperationPack and return its JSON representation
+func (opp *OperationPack) serialize() ([]byte, error) {
 	// make sure we don't write invalid data
 	err := opp.Validate()
 	if err != nil {
-		return "", errors.Wrap(err, "validation error")
+		return nil, errors.Wrap(err, "validation error")
 	}
 
 	// First, make sure that all the identities are properly Commit as well
@@ -152,23 +224,23 @@ func (opp *OperationPack) Write(repo repository.ClockedRepo) (repository.Hash, e
 	// sure no data is lost on identities ?
 	for _, op := range opp.Operations {
 		if op.base().Author.NeedCommit() {
-			return "", fmt.Errorf("identity need commmit")
+			return nil, fmt.Errorf("identity need commmit")
+		}
+		for _, coAuthor := range op.base().CoAuthors {
+			if coAuthor.NeedCommit() {
+				return nil, fmt.Errorf("identity need commmit")
+			}
+		}
+		if op, ok := op.(*AssignOperation); ok {
+			for _, i := range append(op.Added, op.Removed...) {
+				if i.NeedCommit() {
+					return nil, fmt.Errorf("identity need commmit")
+				}
+			}
 		}
 	}
 
-	data, err := json.Marshal(opp)
-
-	if err != nil {
-		return "", err
-	}
-
-	hash, err := repo.StoreData(data)
-
-	if err != nil {
-		return "", err
-	}
-
-	return hash, nil
+	return json.Marshal(opp)
 }
 
 // Make a deep copy
@@ -177,6 +249,7 @@ func (opp *OperationPack) Clone() OperationPack {
 	clone := OperationPack{
 		Operations: make([]Operation, len(opp.Operations)),
 		commitHash: opp.commitHash,
+		editTime:   opp.editTime,
 	}
 
 	for i, op := range opp.Operations {
@@ -11,7 +11,12 @@ import (
 )
 
 func TestValidate(t *testing.T) {
+	repo := repository.NewMockRepoForTest()
 	rene := identity.NewIdentity("René Descartes", "rene@descartes.fr")
+	// the assignees are told apart by id
+	err := rene.Commit(repo)
+	require.NoError(t, err)
+
 	unix := time.Now().Unix()
 
 	good := []Operation{
@@ -19,7 +24,18 @@ func TestValidate(t *testing.T) {
 		NewSetTitleOp(rene, unix, "title2", "title1"),
 		NewAddCommentOp(rene, unix, "message2", nil),
 		NewSetStatusOp(rene, unix, ClosedStatus),
+		NewSetExtendedStatusOp(rene, unix, ExtendedStatus{Name: "wontfix", Status: ClosedStatus}),
 		NewLabelChangeOperation(rene, unix, []Label{"added"}, []Label{"removed"}),
+		NewSetFieldOp(rene, unix, "env", "prod"),
+		NewAddTimeSpentOp(rene, unix, time.Hour),
+		NewAddTimeSpentOp(rene, unix, -time.Hour),
+		NewSetEstimateOp(rene, unix, 2*time.Hour),
+		NewSetEstimateOp(rene, unix, 0),
+		NewAssignOp(rene, unix, []identity.Interface{rene}, nil),
+		NewSetMilestoneOp(rene, unix, "v1.0"),
+		NewSetMilestoneOp(rene, unix, ""),
+		NewSetDueDateOp(rene, unix, unix),
+		NewSetDueDateOp(rene, unix, 0),
 	}
 
 	for _, op := range good {
@@ -56,8 +72,27 @@ func TestValidate(t *testing.T) {
 		NewAddCommentOp(rene, unix, "message", []repository.Hash{repository.Hash("invalid")}),
 		NewSetStatusOp(rene, unix, 1000),
 		NewSetStatusOp(rene, unix, 0),
+		NewSetExtendedStatusOp(rene, unix, ExtendedStatus{Name: "open", Status: OpenStatus}),
+		NewSetExtendedStatusOp(rene, unix, ExtendedStatus{Name: "won't fix", Status: ClosedStatus}),
 		NewLabelChangeOperation(rene, unix, []Label{}, []Label{}),
 		NewLabelChangeOperation(rene, unix, []Label{"multi\nline"}, []Label{}),
+		NewSetFieldOp(rene, unix, "", "prod"),
+		NewSetFieldOp(rene, unix, "env", "multi\nline"),
+		NewAddTimeSpentOp(rene, unix, 0),
+		NewSetEstimateOp(rene, unix, -time.Hour),
+		NewAssignOp(rene, unix, nil, nil),
+		NewAssignOp(rene, unix, []identity.Interface{rene}, []identity.Interface{rene}),
+		NewAssignOp(rene, unix, []identity.Interface{identity.NewIdentity("", "rene@descartes.fr")}, nil),
+		NewSetMilestoneOp(rene, unix, "multi\nline"),
+		NewSetMilestoneOp(rene, unix, " v1.0"),
+		NewSetDueDateOp(rene, unix, -1),
+		NewSetChecklistItemOp(rene, unix, "invalid", 0, true),
+		NewRelateOp(rene, unix, RelatedToRelation, "invalid", false),
+		NewRelateOp(rene, unix, 0, "invalid", false),
+		NewMinimizeCommentOp(rene, unix, "invalid", MinimizeResolved),
+		NewPinCommentOp(rene, unix, "invalid", false),
+		NewCodeRefOp(rene, unix, CodeRef{Kind: CommitCodeRef, Role: FixedByCodeRef, Target: "not-a-hash"}, false),
+		NewCodeRefOp(rene, unix, CodeRef{Kind: BranchCodeRef, Role: MentionedCodeRef, Target: "main", Line: 12}, false),
 	}
 
 	for i, op := range bad {
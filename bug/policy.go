@@ -0,0 +1,219 @@
+package bug
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+// the policy is stored in the repository config as:
+// git-bug.policy.<action> = <identity id>,<identity id>
+const policyConfigKeyPrefix = "git-bug.policy."
+
+// PolicyAction is a kind of change restricted by a Policy
+type PolicyAction string
+
+const (
+	// PolicyStatus restrict who can close or re-open a bug
+	PolicyStatus PolicyAction = "status"
+	// PolicyLabel restrict who can add or remove labels
+	PolicyLabel PolicyAction = "label"
+	// PolicyEditComment restrict who can edit, redact, minimize or check the
+	// checklist items of the comments of someone else. Doing so on your own
+	// comments is always allowed.
+	PolicyEditComment PolicyAction = "edit-comment"
+	// PolicyTitle restrict who can change the title of a bug
+	PolicyTitle PolicyAction = "title"
+	// PolicyPin restrict who can pin or unpin comments
+	PolicyPin PolicyAction = "pin"
+	// PolicyPlanning restrict who can change the assignees, the milestone,
+	// the due date or the estimate of a bug
+	PolicyPlanning PolicyAction = "planning"
+	// PolicyField restrict who can set the custom fields of a bug
+	PolicyField PolicyAction = "field"
+	// PolicyRelation restrict who can relate a bug to other bugs or to code
+	PolicyRelation PolicyAction = "relation"
+)
+
+var policyActions = []PolicyAction{
+	PolicyStatus, PolicyLabel, PolicyEditComment, PolicyTitle,
+	PolicyPin, PolicyPlanning, PolicyField, PolicyRelation,
+}
+
+func (pa PolicyAction) Validate() error {
+	for _, action := range policyActions {
+		if pa == action {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown policy action %s", pa)
+}
+
+// Policy define which identities are allowed to make some changes in the bugs
+// of a repository. An action that is not part of the policy is allowed for
+// everyone.
+//
+// The policy is evaluated when merging remote bugs. As identities are not
+// authenticated, it's only meaningful together with signed commits.
+type Policy map[PolicyAction][]entity.Id
+
+// ReadPolicy read the authorization policy from the repository configuration
+func ReadPolicy(config repository.ConfigRead) (Policy, error) {
+	pairs, err := config.ReadAll(policyConfigKeyPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	policy := make(Policy)
+
+	for key, value := range pairs {
+		action := PolicyAction(strings.TrimPrefix(key, policyConfigKeyPrefix))
+		if err := action.Validate(); err != nil {
+			return nil, err
+		}
+
+		// an empty list is legal and means that nobody is allowed
+		ids := make([]entity.Id, 0)
+
+		for _, raw := range strings.Split(value, ",") {
+			raw = strings.TrimSpace(raw)
+			if raw == "" {
+				continue
+			}
+
+			id := entity.Id(raw)
+			if err := id.Validate(); err != nil {
+				return nil, fmt.Errorf("policy %s: invalid identity id %s", action, raw)
+			}
+			ids = append(ids, id)
+		}
+
+		policy[action] = ids
+	}
+
+	return policy, nil
+}
+
+// Allowed return true if the given identity is allowed to do the action
+func (p Policy) Allowed(action PolicyAction, author entity.Id) bool {
+	allowed, ok := p[action]
+	if !ok {
+		return true
+	}
+
+	for _, id := range allowed {
+		if id == author {
+			return true
+		}
+	}
+
+	return false
+}
+
+// CheckOperation verify that an operation is allowed by the policy, given the
+// state of the bug just before this operation.
+func (p Policy) CheckOperation(snap *Snapshot, op Operation) error {
+	author := op.GetAuthor().Id()
+
+	var action PolicyAction
+	var target entity.Id
+
+	switch op := op.(type) {
+	// only adding content of the author, always allowed
+	case *CreateOperation, *AddCommentOperation, *AddTimeSpentOperation,
+		*SubscribeOperation, *SetMetadataOperation, *NoOpOperation:
+		return nil
+
+	case *SetStatusOperation:
+		action = PolicyStatus
+	case *LabelChangeOperation:
+		action = PolicyLabel
+	case *SetTitleOperation:
+		action = PolicyTitle
+	case *PinCommentOperation:
+		action = PolicyPin
+	case *AssignOperation, *SetMilestoneOperation, *SetDueDateOperation, *SetEstimateOperation:
+		action = PolicyPlanning
+	case *SetFieldOperation:
+		action = PolicyField
+	case *RelateOperation, *CodeRefOperation:
+		action = PolicyRelation
+
+	case *EditCommentOperation:
+		target = op.Target
+	case *RedactOperation:
+		target = op.Target
+	case *MinimizeCommentOperation:
+		target = op.Target
+	case *SetChecklistItemOperation:
+		target = op.Target
+
+	default:
+		// an operation unknown to the policy can't be checked, refuse it
+		// rather than letting it bypass the policy
+		return fmt.Errorf("operation %s from %s of type %d is not covered by the policy",
+			op.Id().Human(), author.Human(), op.base().OperationType)
+	}
+
+	if target != "" {
+		comment, err := snap.SearchComment(target)
+		if err != nil {
+			// the operation is invalid anyway and will be ignored
+			return nil
+		}
+		if comment.Author.Id() == author {
+			return nil
+		}
+		action = PolicyEditComment
+	}
+
+	if !p.Allowed(action, author) {
+		return fmt.Errorf("operation %s from %s violates the %s policy",
+			op.Id().Human(), author.Human(), action)
+	}
+
+	return nil
+}
+
+// CheckBug verify that the operations of a bug are allowed by the policy.
+// Operations already known (typically, already accepted locally) are skipped.
+func (p Policy) CheckBug(bug *Bug, known map[entity.Id]struct{}) error {
+	if len(p) == 0 {
+		return nil
+	}
+
+	snap := Snapshot{
+		id:     bug.id,
+		Status: OpenStatus,
+	}
+
+	it := NewOperationIterator(bug)
+	for it.Next() {
+		op := it.Value()
+
+		if _, ok := known[op.Id()]; !ok {
+			if err := p.CheckOperation(&snap, op); err != nil {
+				return err
+			}
+		}
+
+		op.Apply(&snap)
+		snap.Operations = append(snap.Operations, op)
+	}
+
+	return nil
+}
+
+// operationIds return the set of the ids of the operations of a bug
+func operationIds(bug *Bug) map[entity.Id]struct{} {
+	result := make(map[entity.Id]struct{})
+
+	it := NewOperationIterator(bug)
+	for it.Next() {
+		result[it.Value().Id()] = struct{}{}
+	}
+
+	return result
+}
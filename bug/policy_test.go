@@ -0,0 +1,155 @@
+package bug
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/identity"
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+func TestReadPolicy(t *testing.T) {
+	repo := repository.NewMockRepoForTest()
+	config := repo.LocalConfig()
+
+	policy, err := ReadPolicy(config)
+	require.NoError(t, err)
+	require.Empty(t, policy)
+
+	allowed := entity.Id("1111111111111111111111111111111111111111111111111111111111111111")
+	other := entity.Id("2222222222222222222222222222222222222222222222222222222222222222")
+
+	require.NoError(t, config.StoreString("git-bug.policy.status", allowed.String()))
+	require.NoError(t, config.StoreString("git-bug.policy.label", ""))
+
+	policy, err = ReadPolicy(config)
+	require.NoError(t, err)
+	require.Len(t, policy, 2)
+	require.True(t, policy.Allowed(PolicyStatus, allowed))
+	require.False(t, policy.Allowed(PolicyStatus, other))
+	require.False(t, policy.Allowed(PolicyLabel, allowed))
+	require.True(t, policy.Allowed(PolicyEditComment, other))
+
+	require.NoError(t, config.StoreString("git-bug.policy.unknown", allowed.String()))
+	_, err = ReadPolicy(config)
+	require.Error(t, err)
+
+	require.NoError(t, config.RemoveAll("git-bug.policy.unknown"))
+	require.NoError(t, config.StoreString("git-bug.policy.status", "abcd"))
+	_, err = ReadPolicy(config)
+	require.Error(t, err)
+}
+
+func TestPolicyCheckBug(t *testing.T) {
+	repo := repository.NewMockRepoForTest()
+	rene := identity.NewIdentity("René Descartes", "rene@descartes.fr")
+	err := rene.Commit(repo)
+	require.NoError(t, err)
+	isaac := identity.NewIdentity("Isaac Newton", "isaac@newton.uk")
+	err = isaac.Commit(repo)
+	require.NoError(t, err)
+
+	unix := time.Now().Unix()
+
+	policy := Policy{
+		PolicyStatus:      {rene.Id()},
+		PolicyEditComment: {rene.Id()},
+	}
+
+	b, _, err := Create(rene, unix, "title", "message")
+	require.NoError(t, err)
+	comment, err := AddComment(b, isaac, unix, "comment")
+	require.NoError(t, err)
+	_, err = EditComment(b, isaac, unix, comment.Id(), "edited comment")
+	require.NoError(t, err)
+	_, err = EditComment(b, rene, unix, comment.Id(), "moderated comment")
+	require.NoError(t, err)
+	_, err = Close(b, rene, unix)
+	require.NoError(t, err)
+	_, _, err = ChangeLabels(b, isaac, unix, []string{"bug"}, nil)
+	require.NoError(t, err)
+
+	require.NoError(t, policy.CheckBug(b, nil))
+
+	known := operationIds(b)
+
+	reopen, err := Open(b, isaac, unix)
+	require.NoError(t, err)
+	require.Error(t, policy.CheckBug(b, nil))
+	require.Error(t, policy.CheckBug(b, known))
+
+	// already accepted operations are not checked again
+	known[reopen.Id()] = struct{}{}
+	require.NoError(t, policy.CheckBug(b, known))
+
+	_, err = EditCreateComment(b, isaac, unix, "hijacked")
+	require.NoError(t, err)
+	require.Error(t, policy.CheckBug(b, known))
+}
+
+func TestPolicyCheckOperation(t *testing.T) {
+	repo := repository.NewMockRepoForTest()
+	rene := identity.NewIdentity("René Descartes", "rene@descartes.fr")
+	err := rene.Commit(repo)
+	require.NoError(t, err)
+	isaac := identity.NewIdentity("Isaac Newton", "isaac@newton.uk")
+	err = isaac.Commit(repo)
+	require.NoError(t, err)
+
+	unix := time.Now().Unix()
+
+	b, _, err := Create(rene, unix, "title", "message")
+	require.NoError(t, err)
+	comment, err := AddComment(b, rene, unix, "- [ ] item")
+	require.NoError(t, err)
+	snap := b.Compile()
+
+	// every action is restricted to rene
+	policy := make(Policy)
+	for _, action := range policyActions {
+		policy[action] = []entity.Id{rene.Id()}
+	}
+
+	restricted := []Operation{
+		NewSetStatusOp(isaac, unix, ClosedStatus),
+		NewSetTitleOp(isaac, unix, "new title", "title"),
+		NewLabelChangeOperation(isaac, unix, []Label{"bug"}, nil),
+		NewEditCommentOp(isaac, unix, comment.Id(), "edited", nil),
+		NewRedactOp(isaac, unix, comment.Id(), "spam"),
+		NewMinimizeCommentOp(isaac, unix, comment.Id(), MinimizeResolved),
+		NewSetChecklistItemOp(isaac, unix, comment.Id(), 0, true),
+		NewPinCommentOp(isaac, unix, comment.Id(), false),
+		NewAssignOp(isaac, unix, []identity.Interface{isaac}, nil),
+		NewSetMilestoneOp(isaac, unix, "v1.0"),
+		NewSetDueDateOp(isaac, unix, unix),
+		NewSetEstimateOp(isaac, unix, time.Hour),
+		NewSetFieldOp(isaac, unix, "priority", "high"),
+		NewRelateOp(isaac, unix, RelatedToRelation, entity.Id("1111111111111111111111111111111111111111111111111111111111111111"), false),
+		NewCodeRefOp(isaac, unix, CodeRef{Kind: CommitCodeRef, Target: "abcdef"}, false),
+	}
+	for _, op := range restricted {
+		require.Error(t, policy.CheckOperation(&snap, op), "%T", op)
+	}
+
+	allowed := []Operation{
+		NewAddCommentOp(isaac, unix, "comment", nil),
+		NewAddTimeSpentOp(isaac, unix, time.Hour),
+		NewSubscribeOp(isaac, unix, false),
+		NewNoOpOp(isaac, unix),
+		NewSetMetadataOp(isaac, unix, comment.Id(), map[string]string{"key": "value"}),
+		// rene is allowed everything
+		NewSetStatusOp(rene, unix, ClosedStatus),
+		NewPinCommentOp(rene, unix, comment.Id(), false),
+		NewAssignOp(rene, unix, []identity.Interface{isaac}, nil),
+	}
+	for _, op := range allowed {
+		require.NoError(t, policy.CheckOperation(&snap, op), "%T", op)
+	}
+
+	// the actions not part of the policy are allowed for everyone
+	delete(policy, PolicyPlanning)
+	require.NoError(t, policy.CheckOperation(&snap, NewSetMilestoneOp(isaac, unix, "v1.0")))
+}
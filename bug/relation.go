@@ -0,0 +1,77 @@
+package bug
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/MichaelMure/git-bug/entity"
+)
+
+// RelationType is the kind of link between two bugs
+type RelationType int
+
+const (
+	_ RelationType = iota
+	// the bug is a duplicate of the target, its status follow the target's one
+	DuplicateOfRelation
+	RelatedToRelation
+	// the bug is caused by the target
+	CausedByRelation
+)
+
+func (rt RelationType) String() string {
+	switch rt {
+	case DuplicateOfRelation:
+		return "duplicate-of"
+	case RelatedToRelation:
+		return "related-to"
+	case CausedByRelation:
+		return "caused-by"
+	default:
+		return "unknown relation"
+	}
+}
+
+func RelationTypeFromString(str string) (RelationType, error) {
+	cleaned := strings.ToLower(strings.TrimSpace(str))
+
+	switch cleaned {
+	case "duplicate-of", "duplicate":
+		return DuplicateOfRelation, nil
+	case "related-to", "related":
+		return RelatedToRelation, nil
+	case "caused-by":
+		return CausedByRelation, nil
+	default:
+		return 0, fmt.Errorf("unknown relation %s", str)
+	}
+}
+
+func (rt RelationType) Validate() error {
+	if rt < DuplicateOfRelation || rt > CausedByRelation {
+		return fmt.Errorf("invalid")
+	}
+
+	return nil
+}
+
+// Relation is a typed link from a bug to another one
+type Relation struct {
+	Type   RelationType
+	Target entity.Id
+}
+
+func (r Relation) String() string {
+	return fmt.Sprintf("%s %s", r.Type, r.Target.Human())
+}
+
+// RelatedTo return the targets of the relations of the given type
+func (snap *Snapshot) RelatedTo(relation RelationType) []entity.Id {
+	var result []entity.Id
+	for _, r := range snap.Relations {
+		if r.Type == relation {
+			result = append(result, r.Target)
+		}
+	}
+	return result
+}
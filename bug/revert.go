@@ -0,0 +1,108 @@
+package bug
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/identity"
+)
+
+// Revert append a new operation compensating the effect of a previous
+// operation of the bug. The compensating operation is computed from the type
+// of the reverted operation and the state of the bug just before it.
+// The history is not rewritten: both operations stay visible in the timeline.
+func Revert(b Interface, author identity.Interface, unixTime int64, target entity.Id) (Operation, error) {
+	snap := b.Compile()
+
+	index := -1
+	for i, op := range snap.Operations {
+		if op.Id() == target {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		return nil, fmt.Errorf("operation %s not found", target.Human())
+	}
+
+	// replay the history up to the reverted operation
+	before := Snapshot{
+		id:     snap.id,
+		Status: OpenStatus,
+	}
+	for _, op := range snap.Operations[:index] {
+		op.Apply(&before)
+		before.Operations = append(before.Operations, op)
+	}
+
+	var revert Operation
+
+	switch op := snap.Operations[index].(type) {
+	case *SetTitleOperation:
+		revert = NewSetTitleOp(author, unixTime, op.Was, snap.Title)
+
+	case *SetStatusOperation:
+		revert = NewSetExtendedStatusOp(author, unixTime, ExtendedStatus{
+			Name:   before.ExtendedStatus,
+			Status: before.Status,
+		})
+
+	case *LabelChangeOperation:
+		revert = NewLabelChangeOperation(author, unixTime, op.Removed, op.Added)
+
+	case *EditCommentOperation:
+		comment, err := before.SearchComment(op.Target)
+		if err != nil {
+			return nil, err
+		}
+		revert = NewEditCommentOp(author, unixTime, op.Target, comment.Message, comment.Files)
+
+	case *SetFieldOperation:
+		revert = NewSetFieldOp(author, unixTime, op.Name, before.Fields[op.Name])
+
+	case *AddTimeSpentOperation:
+		revert = NewAddTimeSpentOp(author, unixTime, -op.Duration)
+
+	case *SetEstimateOperation:
+		revert = NewSetEstimateOp(author, unixTime, before.Estimate)
+
+	case *SetChecklistItemOperation:
+		revert = NewSetChecklistItemOp(author, unixTime, op.Target, op.Item, !op.Checked)
+
+	case *RelateOperation:
+		revert = NewRelateOp(author, unixTime, op.Relation, op.Target, !op.Removed)
+
+	case *AssignOperation:
+		revert = NewAssignOp(author, unixTime, op.Removed, op.Added)
+
+	case *SetMilestoneOperation:
+		revert = NewSetMilestoneOp(author, unixTime, before.Milestone)
+
+	case *SetDueDateOperation:
+		var dueDate int64
+		if !before.DueDate.IsZero() {
+			dueDate = before.DueDate.Unix()
+		}
+		revert = NewSetDueDateOp(author, unixTime, dueDate)
+
+	case *SubscribeOperation:
+		// a subscription only affect its author
+		if op.Author.Id() != author.Id() {
+			return nil, fmt.Errorf("a subscription can only be reverted by its author")
+		}
+		revert = NewSubscribeOp(author, unixTime, !op.Unsubscribe)
+
+	default:
+		return nil, fmt.Errorf("operation %s can't be reverted", op.Id().Human())
+	}
+
+	if err := revert.Validate(); err != nil {
+		return nil, errors.Wrap(err, "revert")
+	}
+
+	b.Append(revert)
+
+	return revert, nil
+}
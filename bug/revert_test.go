@@ -0,0 +1,61 @@
+package bug
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/MichaelMure/git-bug/identity"
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+func TestRevert(t *testing.T) {
+	repo := repository.NewMockRepoForTest()
+	rene := identity.NewIdentity("René Descartes", "rene@descartes.fr")
+	err := rene.Commit(repo)
+	require.NoError(t, err)
+	isaac := identity.NewIdentity("Isaac Newton", "isaac@newton.uk")
+	err = isaac.Commit(repo)
+	require.NoError(t, err)
+
+	unix := time.Now().Unix()
+
+	b, create, err := Create(rene, unix, "title", "message")
+	require.NoError(t, err)
+
+	_, err = Revert(b, rene, unix, create.Id())
+	require.Error(t, err)
+
+	_, err = Revert(b, rene, unix, "unknown")
+	require.Error(t, err)
+
+	title, err := SetTitle(b, rene, unix, "new title")
+	require.NoError(t, err)
+	_, labels, err := ChangeLabels(b, rene, unix, []string{"bug"}, nil)
+	require.NoError(t, err)
+	closeOp, err := Close(b, rene, unix)
+	require.NoError(t, err)
+	edit, err := EditCreateComment(b, rene, unix, "edited message")
+	require.NoError(t, err)
+	spent, err := AddTimeSpent(b, rene, unix, time.Hour)
+	require.NoError(t, err)
+	subscribe, err := Subscribe(b, rene, unix)
+	require.NoError(t, err)
+
+	_, err = Revert(b, isaac, unix, subscribe.Id())
+	require.Error(t, err)
+
+	for _, op := range []Operation{title, labels, closeOp, edit, spent, subscribe} {
+		_, err = Revert(b, rene, unix, op.Id())
+		require.NoError(t, err)
+	}
+
+	snap := b.Compile()
+	require.Equal(t, "title", snap.Title)
+	require.Empty(t, snap.Labels)
+	require.Equal(t, OpenStatus, snap.Status)
+	require.Equal(t, "message", snap.Comments[0].Message)
+	require.Equal(t, time.Duration(0), snap.TimeSpent)
+	require.Empty(t, snap.Subscribers)
+}
@@ -0,0 +1,245 @@
+package bug
+
+import (
+	"fmt"
+
+	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/identity"
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+// AuthorStatus tell if the commit of an OperationPack is signed by the
+// authors of its operations, with a key registered on their identity
+type AuthorStatus int
+
+const (
+	// AuthorNoKey means that the authors had no registered key when the
+	// operations were made, so the signature can't be tied to them
+	AuthorNoKey AuthorStatus = iota
+	// AuthorVerified means that the commit is signed with a registered key
+	// of each author having one
+	AuthorVerified
+	// AuthorUnsigned means that an author had a registered key, but the commit
+	// is not signed
+	AuthorUnsigned
+	// AuthorMismatch means that the commit is signed with a key not registered
+	// for an author, or that the signature is bad
+	AuthorMismatch
+)
+
+func (s AuthorStatus) String() string {
+	switch s {
+	case AuthorNoKey:
+		return "no key"
+	case AuthorVerified:
+		return "verified"
+	case AuthorUnsigned:
+		return "unsigned"
+	case AuthorMismatch:
+		return "mismatch"
+	default:
+		return "unknown"
+	}
+}
+
+// PackSignature is the signature of a committed OperationPack, shared by all
+// its operations
+type PackSignature struct {
+	repository.CommitSignature
+	Commit repository.Hash
+	// AuthorStatus is the verification of the signature against the keys
+	// registered on the identities of the authors
+	AuthorStatus AuthorStatus
+	// AuthorKey is the fingerprint of the registered key that signed the
+	// commit, if verified
+	AuthorKey  string
+	Operations []Operation
+}
+
+// Signatures verify the signature of each commit of the bug, in chronological
+// order. The operations not committed yet are not included.
+func (bug *Bug) Signatures(repo repository.RepoData) ([]PackSignature, error) {
+	result := make([]PackSignature, 0, len(bug.packs))
+
+	for _, pack := range bug.packs {
+		signature, err := repo.ReadCommitSignature(pack.commitHash)
+		if err != nil {
+			return nil, err
+		}
+
+		status, key, err := verifyPackAuthors(repo, pack)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, PackSignature{
+			CommitSignature: signature,
+			Commit:          pack.commitHash,
+			AuthorStatus:    status,
+			AuthorKey:       key,
+			Operations:      pack.Operations,
+		})
+	}
+
+	return result, nil
+}
+
+// VerifySignatures return an error if any commit of the bug carry a bad
+// signature, or is not signed by an author having registered a key.
+// Unsigned commits from authors without key, or signatures that can't be
+// checked locally, are accepted.
+func (bug *Bug) VerifySignatures(repo repository.RepoData) error {
+	for _, pack := range bug.packs {
+		signature, err := repo.ReadCommitSignature(pack.commitHash)
+		if err != nil {
+			return err
+		}
+
+		if signature.Status == repository.SignatureBad {
+			return fmt.Errorf("commit %s has a bad signature from %s", pack.commitHash, signature.Signer)
+		}
+
+		status, _, err := verifyPackAuthors(repo, pack)
+		if err != nil {
+			return err
+		}
+
+		switch status {
+		case AuthorUnsigned:
+			return fmt.Errorf("commit %s is not signed by its author", pack.commitHash)
+		case AuthorMismatch:
+			return fmt.Errorf("commit %s is not signed with a key of its author", pack.commitHash)
+		}
+	}
+
+	return nil
+}
+
+// Authors return the distinct authors of the operations signed together
+func (ps PackSignature) Authors() []identity.Interface {
+	return packAuthors(OperationPack{Operations: ps.Operations})
+}
+
+// packAuthors return the distinct authors of the operations of a pack
+func packAuthors(pack OperationPack) []identity.Interface {
+	var result []identity.Interface
+	seen := make(map[entity.Id]bool)
+
+	for _, op := range pack.Operations {
+		author := op.GetAuthor()
+		if seen[author.Id()] {
+			continue
+		}
+		seen[author.Id()] = true
+		result = append(result, author)
+	}
+
+	return result
+}
+
+// verifyPackAuthors check the signature of the commit of a pack against the
+// keys the authors had registered at the time of the commit
+func verifyPackAuthors(repo repository.RepoData, pack OperationPack) (AuthorStatus, string, error) {
+	var raw []byte
+	status := AuthorNoKey
+	var signer string
+
+	// a version of an identity is stamped with the next edit time to be
+	// issued when it was committed, so its keys apply to the commits from
+	// this time on
+	for _, author := range packAuthors(pack) {
+		keys := author.ValidKeysAtTime(pack.editTime)
+		if len(keys) == 0 {
+			continue
+		}
+
+		if raw == nil {
+			var err error
+			raw, err = repo.ReadRawCommit(pack.commitHash)
+			if err != nil {
+				return 0, "", err
+			}
+		}
+
+		pubKeys := make([]string, len(keys))
+		for i, key := range keys {
+			pubKeys[i] = key.PubKey
+		}
+
+		fingerprint, err := repository.VerifyCommitSignature(raw, pubKeys)
+		switch {
+		case err == repository.ErrCommitNotSigned:
+			return AuthorUnsigned, "", nil
+		case err != nil:
+			return AuthorMismatch, "", nil
+		}
+
+		status = AuthorVerified
+		signer = fingerprint
+	}
+
+	return status, signer, nil
+}
+
+// hasPrivateKey tell if the private key of a signing key is available. It's
+// a variable to be replaced in the tests.
+var hasPrivateKey = repository.HasPrivateKey
+
+// storePackCommit write the commit of a pack, signed if packSigningKey find
+// a key to sign it with
+func storePackCommit(repo repository.Repo, treeHash repository.Hash, parent repository.Hash, pack OperationPack) (repository.Hash, error) {
+	keyId, sign := packSigningKey(repo, pack)
+	if sign {
+		return repo.StoreSignedCommit(treeHash, parent, keyId)
+	}
+
+	if parent != "" {
+		return repo.StoreCommitWithParent(treeHash, parent)
+	}
+	return repo.StoreCommit(treeHash)
+}
+
+// packSigningKey choose how to sign the commit of a pack. If the local user
+// authored all the operations of the pack, it's signed with the most recent
+// of the keys registered on their identity and valid for the pack, OpenPGP or
+// SSH, whose private key is available. Otherwise, if signing is enabled in
+// the git configuration, it's signed with the key configured there (an empty
+// key id). Otherwise, the commit is not signed.
+//
+// The operations of other authors, for instance when rebasing their packs
+// during a merge, are never signed with the key of the local user.
+func packSigningKey(repo repository.Repo, pack OperationPack) (string, bool) {
+	if key := userSigningKey(repo, pack); key != nil {
+		return key.SigningKeyId(), true
+	}
+	return "", repository.SigningEnabled(repo.AnyConfig())
+}
+
+// userSigningKey return the registered key of the local user to sign a pack
+// with, or nil
+func userSigningKey(repo repository.Repo, pack OperationPack) *identity.Key {
+	authors := packAuthors(pack)
+	if len(authors) != 1 {
+		return nil
+	}
+
+	userId, err := identity.GetUserIdentityId(repo)
+	if err != nil || userId != authors[0].Id() {
+		return nil
+	}
+
+	// the operation may hold an outdated version of the identity
+	user, err := identity.ReadLocal(repo, userId)
+	if err != nil {
+		return nil
+	}
+
+	keys := user.ValidKeysAtTime(pack.editTime)
+	for i := len(keys) - 1; i >= 0; i-- {
+		if hasPrivateKey(keys[i].SigningKeyId()) {
+			return keys[i]
+		}
+	}
+
+	return nil
+}
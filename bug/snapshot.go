@@ -16,14 +16,34 @@ type Snapshot struct {
 	Title        string
 	Comments     []Comment
 	Labels       []Label
+	Fields       map[string]string
+	Relations    []Relation
+	CodeRefs     []CodeRef
 	Author       identity.Interface
 	Actors       []identity.Interface
 	Participants []identity.Interface
+	Subscribers  []identity.Interface
 	CreateTime   time.Time
 
+	// repository defined status refining Status, if any
+	ExtendedStatus string
+
+	// time tracking
+	TimeSpent   time.Duration
+	TimeSpentBy map[entity.Id]time.Duration
+	Estimate    time.Duration
+
+	// planning
+	Assignees []identity.Interface
+	Milestone string
+	DueDate   time.Time
+
 	Timeline []TimelineItem
 
 	Operations []Operation
+
+	// the identities able to read the bug, if it's confidential
+	Recipients []entity.Id
 }
 
 // Return the Bug identifier
@@ -40,6 +60,12 @@ func (snap *Snapshot) EditTime() time.Time {
 	return snap.Operations[len(snap.Operations)-1].Time()
 }
 
+// IsConfidential return true if the bug operations are encrypted, in which
+// case its content must not be written anywhere in clear
+func (snap *Snapshot) IsConfidential() bool {
+	return len(snap.Recipients) > 0
+}
+
 // GetCreateMetadata return the creation metadata
 func (snap *Snapshot) GetCreateMetadata(key string) (string, bool) {
 	return snap.Operations[0].GetMetadata(key)
@@ -67,6 +93,66 @@ func (snap *Snapshot) SearchComment(id entity.Id) (*Comment, error) {
 	return nil, fmt.Errorf("comment item not found")
 }
 
+// PinnedComments return the comments pinned at the top of the bug
+func (snap *Snapshot) PinnedComments() []Comment {
+	var result []Comment
+	for _, c := range snap.Comments {
+		if c.Pinned {
+			result = append(result, c)
+		}
+	}
+	return result
+}
+
+// Clone return a deep copy of the snapshot, that can be compiled further
+// without altering the original.
+func (snap *Snapshot) Clone() *Snapshot {
+	clone := *snap
+
+	clone.Comments = append([]Comment(nil), snap.Comments...)
+	clone.Labels = append([]Label(nil), snap.Labels...)
+	clone.Relations = append([]Relation(nil), snap.Relations...)
+	clone.CodeRefs = append([]CodeRef(nil), snap.CodeRefs...)
+	clone.Actors = append([]identity.Interface(nil), snap.Actors...)
+	clone.Participants = append([]identity.Interface(nil), snap.Participants...)
+	clone.Subscribers = append([]identity.Interface(nil), snap.Subscribers...)
+	clone.Assignees = append([]identity.Interface(nil), snap.Assignees...)
+	clone.Operations = append([]Operation(nil), snap.Operations...)
+
+	if snap.Fields != nil {
+		clone.Fields = make(map[string]string, len(snap.Fields))
+		for name, value := range snap.Fields {
+			clone.Fields[name] = value
+		}
+	}
+
+	if snap.TimeSpentBy != nil {
+		clone.TimeSpentBy = make(map[entity.Id]time.Duration, len(snap.TimeSpentBy))
+		for id, spent := range snap.TimeSpentBy {
+			clone.TimeSpentBy[id] = spent
+		}
+	}
+
+	// only the comment items are modified after being added to the timeline
+	clone.Timeline = make([]TimelineItem, len(snap.Timeline))
+	for i, item := range snap.Timeline {
+		switch item := item.(type) {
+		case *CreateTimelineItem:
+			copied := *item
+			copied.History = append([]CommentHistoryStep(nil), item.History...)
+			clone.Timeline[i] = &copied
+		case *AddCommentTimelineItem:
+			copied := *item
+			copied.History = append([]CommentHistoryStep(nil), item.History...)
+			clone.Timeline[i] = &copied
+		default:
+			clone.Timeline[i] = item
+		}
+	}
+
+	return &clone
+}
+
 // append the operation author to the actors list
 func (snap *Snapshot) addActor(actor identity.Interface) {
 	for _, a := range snap.Actors {
@@ -109,6 +195,68 @@ func (snap *Snapshot) HasAnyParticipant(ids ...entity.Id) bool {
 	return false
 }
 
+// append the operation author to the subscribers list
+func (snap *Snapshot) addSubscriber(subscriber identity.Interface) {
+	for _, s := range snap.Subscribers {
+		if subscriber.Id() == s.Id() {
+			return
+		}
+	}
+
+	snap.Subscribers = append(snap.Subscribers, subscriber)
+}
+
+// remove the operation author from the subscribers list
+func (snap *Snapshot) removeSubscriber(subscriber identity.Interface) {
+	for i, s := range snap.Subscribers {
+		if subscriber.Id() == s.Id() {
+			snap.Subscribers = append(snap.Subscribers[:i], snap.Subscribers[i+1:]...)
+			return
+		}
+	}
+}
+
+// append an identity to the assignees list
+func (snap *Snapshot) addAssignee(assignee identity.Interface) {
+	for _, a := range snap.Assignees {
+		if assignee.Id() == a.Id() {
+			return
+		}
+	}
+
+	snap.Assignees = append(snap.Assignees, assignee)
+}
+
+// remove an identity from the assignees list
+func (snap *Snapshot) removeAssignee(assignee identity.Interface) {
+	for i, a := range snap.Assignees {
+		if assignee.Id() == a.Id() {
+			snap.Assignees = append(snap.Assignees[:i], snap.Assignees[i+1:]...)
+			return
+		}
+	}
+}
+
+// HasAssignee return true if the id is an assignee
+func (snap *Snapshot) HasAssignee(id entity.Id) bool {
+	for _, a := range snap.Assignees {
+		if a.Id() == id {
+			return true
+		}
+	}
+	return false
+}
+
+// HasSubscriber return true if the id is a subscriber
+func (snap *Snapshot) HasSubscriber(id entity.Id) bool {
+	for _, s := range snap.Subscribers {
+		if s.Id() == id {
+			return true
+		}
+	}
+	return false
+}
+
 // HasActor return true if the id is a actor
 func (snap *Snapshot) HasActor(id entity.Id) bool {
 	for _, p := range snap.Actors {
@@ -0,0 +1,46 @@
+package bug
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MichaelMure/git-bug/identity"
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+func TestSnapshotClone(t *testing.T) {
+	snapshot := Snapshot{}
+
+	repo := repository.NewMockRepoForTest()
+	rene := identity.NewIdentity("René Descartes", "rene@descartes.fr")
+	err := rene.Commit(repo)
+	require.NoError(t, err)
+
+	unix := time.Now().Unix()
+
+	create := NewCreateOp(rene, unix, "title", "create", nil)
+	create.Apply(&snapshot)
+	NewLabelChangeOperation(rene, unix, []Label{"bug", "urgent"}, nil).Apply(&snapshot)
+	NewSetFieldOp(rene, unix, "version", "1.0").Apply(&snapshot)
+
+	clone := snapshot.Clone()
+	require.Equal(t, snapshot, *clone)
+
+	// compiling further the clone doesn't alter the original
+	NewEditCommentOp(rene, unix, create.Id(), "create edited", nil).Apply(clone)
+	NewLabelChangeOperation(rene, unix, nil, []Label{"bug"}).Apply(clone)
+	NewSetFieldOp(rene, unix, "version", "2.0").Apply(clone)
+
+	assert.Equal(t, "create", snapshot.Comments[0].Message)
+	assert.Len(t, snapshot.Timeline[0].(*CreateTimelineItem).History, 1)
+	assert.Equal(t, []Label{"bug", "urgent"}, snapshot.Labels)
+	assert.Equal(t, "1.0", snapshot.Fields["version"])
+
+	assert.Equal(t, "create edited", clone.Comments[0].Message)
+	assert.Len(t, clone.Timeline[0].(*CreateTimelineItem).History, 2)
+	assert.Equal(t, []Label{"urgent"}, clone.Labels)
+	assert.Equal(t, "2.0", clone.Fields["version"])
+}
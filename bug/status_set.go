@@ -0,0 +1,127 @@
+package bug
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/MichaelMure/git-bug/repository"
+	"github.com/MichaelMure/git-bug/util/text"
+)
+
+// the extended statuses are stored in the repository config as an ordered list:
+// git-bug.statuses = new:open,triaged:open,in-progress:open,done:closed,wontfix:closed
+const statusSetConfigKey = "git-bug.statuses"
+
+// ExtendedStatus is a repository defined status refining the open/closed
+// status of a bug, to support triage workflows
+type ExtendedStatus struct {
+	Name   string
+	Status Status
+}
+
+func (es ExtendedStatus) Validate() error {
+	if err := ValidateExtendedStatusName(es.Name); err != nil {
+		return err
+	}
+
+	if err := es.Status.Validate(); err != nil {
+		return fmt.Errorf("status %s: %v", es.Name, err)
+	}
+
+	return nil
+}
+
+// ValidateExtendedStatusName check that the name of an extended status is usable
+func ValidateExtendedStatusName(name string) error {
+	if text.Empty(name) {
+		return fmt.Errorf("empty status name")
+	}
+
+	// open and closed are reserved for the base statuses in the query language
+	if _, err := StatusFromString(name); err == nil {
+		return fmt.Errorf("status name %s is reserved", name)
+	}
+
+	for _, r := range name {
+		if !(r == '-' || r == '_' ||
+			(r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9')) {
+			return fmt.Errorf("status name %s should only contain letters, digits, - and _", name)
+		}
+	}
+
+	return nil
+}
+
+// DisplayStatus return a human form of the status of the bug, including the
+// extended status if any. Ex: "open/in-progress"
+func (snap *Snapshot) DisplayStatus() string {
+	if snap.ExtendedStatus == "" {
+		return snap.Status.String()
+	}
+	return fmt.Sprintf("%s/%s", snap.Status, snap.ExtendedStatus)
+}
+
+// StatusSet is the ordered list of the extended statuses of a repository
+type StatusSet []ExtendedStatus
+
+// ReadStatusSet read the extended statuses from the repository configuration
+func ReadStatusSet(config repository.ConfigRead) (StatusSet, error) {
+	raw, err := config.ReadString(statusSetConfigKey)
+	if err == repository.ErrNoConfigEntry {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var set StatusSet
+
+	for _, entry := range strings.Split(raw, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		split := strings.Split(entry, ":")
+		if len(split) != 2 {
+			return nil, fmt.Errorf("invalid status %s, expected name:open or name:closed", entry)
+		}
+
+		status, err := StatusFromString(split[1])
+		if err != nil {
+			return nil, fmt.Errorf("status %s: %v", split[0], err)
+		}
+
+		es := ExtendedStatus{Name: strings.TrimSpace(split[0]), Status: status}
+		if err := es.Validate(); err != nil {
+			return nil, err
+		}
+
+		if _, err := set.Get(es.Name); err == nil {
+			return nil, fmt.Errorf("duplicated status %s", es.Name)
+		}
+
+		set = append(set, es)
+	}
+
+	return set, nil
+}
+
+// Get return the extended status with the given name
+func (ss StatusSet) Get(name string) (ExtendedStatus, error) {
+	for _, es := range ss {
+		if es.Name == name {
+			return es, nil
+		}
+	}
+	return ExtendedStatus{}, fmt.Errorf("unknown status %s", name)
+}
+
+// Names return the names of the statuses, in order
+func (ss StatusSet) Names() []string {
+	result := make([]string, len(ss))
+	for i, es := range ss {
+		result[i] = es.Name
+	}
+	return result
+}
@@ -0,0 +1,85 @@
+package bug
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/MichaelMure/git-bug/identity"
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+func TestReadStatusSet(t *testing.T) {
+	repo := repository.NewMockRepoForTest()
+	config := repo.LocalConfig()
+
+	set, err := ReadStatusSet(config)
+	require.NoError(t, err)
+	require.Empty(t, set)
+
+	require.NoError(t, config.StoreString("git-bug.statuses", "new:open, triaged:open,in-progress:open,done:closed,wontfix:closed"))
+
+	set, err = ReadStatusSet(config)
+	require.NoError(t, err)
+	require.Equal(t, []string{"new", "triaged", "in-progress", "done", "wontfix"}, set.Names())
+
+	done, err := set.Get("done")
+	require.NoError(t, err)
+	require.Equal(t, ExtendedStatus{Name: "done", Status: ClosedStatus}, done)
+
+	_, err = set.Get("unknown")
+	require.Error(t, err)
+
+	for _, raw := range []string{
+		"new",
+		"new:pending",
+		"open:open",
+		"in progress:open",
+		"new:open,new:closed",
+	} {
+		require.NoError(t, config.StoreString("git-bug.statuses", raw))
+		_, err = ReadStatusSet(config)
+		require.Error(t, err, raw)
+	}
+}
+
+func TestSetExtendedStatus(t *testing.T) {
+	repo := repository.NewMockRepoForTest()
+	rene := identity.NewIdentity("René Descartes", "rene@descartes.fr")
+	require.NoError(t, rene.Commit(repo))
+
+	unix := time.Now().Unix()
+
+	b := NewBug()
+	create := NewCreateOp(rene, unix, "title", "message", nil)
+	b.Append(create)
+
+	_, err := SetExtendedStatus(b, rene, unix, ExtendedStatus{Name: "in-progress", Status: OpenStatus})
+	require.NoError(t, err)
+
+	snap := b.Compile()
+	require.Equal(t, OpenStatus, snap.Status)
+	require.Equal(t, "in-progress", snap.ExtendedStatus)
+
+	_, err = SetExtendedStatus(b, rene, unix, ExtendedStatus{Name: "wontfix", Status: ClosedStatus})
+	require.NoError(t, err)
+
+	snap = b.Compile()
+	require.Equal(t, ClosedStatus, snap.Status)
+	require.Equal(t, "wontfix", snap.ExtendedStatus)
+
+	item := snap.Timeline[len(snap.Timeline)-1].(*SetStatusTimelineItem)
+	require.Equal(t, "wontfix", item.Extended)
+
+	// a plain status change reset the extended status
+	_, err = Open(b, rene, unix)
+	require.NoError(t, err)
+
+	snap = b.Compile()
+	require.Equal(t, OpenStatus, snap.Status)
+	require.Empty(t, snap.ExtendedStatus)
+
+	_, err = SetExtendedStatus(b, rene, unix, ExtendedStatus{Name: "closed", Status: ClosedStatus})
+	require.Error(t, err)
+}
@@ -0,0 +1,126 @@
+package bug
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// TemplateExtension is the file extension of the bug templates
+const TemplateExtension = ".md"
+
+// Template is a predefined structure for a new bug, so that reports follow a
+// consistent format. It is stored as a markdown file, with an optional
+// front-matter holding the title, labels and custom fields to start with:
+//
+//	---
+//	title: "[crash] "
+//	labels: bug, triage
+//	fields:
+//	  severity: medium
+//	---
+//	## Steps to reproduce
+type Template struct {
+	// Name is the file name of the template, without the extension
+	Name    string
+	Title   string
+	Message string
+	Labels  []string
+	Fields  map[string]string
+}
+
+// ParseTemplate parse the raw content of a template file
+func ParseTemplate(name string, raw string) (*Template, error) {
+	tmpl := &Template{Name: name}
+
+	header, content, err := SplitFrontMatter(raw)
+	if err != nil {
+		return nil, fmt.Errorf("template %s: %v", name, err)
+	}
+
+	if header != nil {
+		fm, err := ParseFrontMatter(header, "fields")
+		if err != nil {
+			return nil, fmt.Errorf("template %s: %v", name, err)
+		}
+		if err := fm.CheckKeys("title", "labels", "fields"); err != nil {
+			return nil, fmt.Errorf("template %s: %v", name, err)
+		}
+
+		tmpl.Title = fm.Values["title"]
+		tmpl.Labels = fm.List("labels")
+		tmpl.Fields = fm.Blocks["fields"]
+	}
+
+	tmpl.Message = strings.TrimSpace(content)
+
+	if err := tmpl.Validate(); err != nil {
+		return nil, fmt.Errorf("template %s: %v", name, err)
+	}
+
+	return tmpl, nil
+}
+
+func (t *Template) Validate() error {
+	if strings.Contains(t.Title, "\n") {
+		return fmt.Errorf("title should be a single line")
+	}
+
+	for _, label := range t.Labels {
+		if err := Label(label).Validate(); err != nil {
+			return fmt.Errorf("label %s: %v", label, err)
+		}
+	}
+
+	for name, value := range t.Fields {
+		if err := ValidateFieldName(name); err != nil {
+			return err
+		}
+		if err := validateFieldValueText(value); err != nil {
+			return fmt.Errorf("field %s: %v", name, err)
+		}
+	}
+
+	return nil
+}
+
+// ReadTemplates read all the templates stored in the given directory, sorted
+// by name. A missing directory simply means that there is no template.
+func ReadTemplates(dir string) ([]*Template, error) {
+	entries, err := ioutil.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*Template
+
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != TemplateExtension {
+			continue
+		}
+
+		raw, err := ioutil.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		tmpl, err := ParseTemplate(strings.TrimSuffix(entry.Name(), TemplateExtension), string(raw))
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, tmpl)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+
+	return result, nil
+}
@@ -0,0 +1,70 @@
+package bug
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTemplate(t *testing.T) {
+	raw := `---
+title: "[crash] "
+labels: bug, triage
+fields:
+  severity: medium
+  env: 'prod'
+---
+
+## Steps to reproduce
+`
+
+	tmpl, err := ParseTemplate("crash", raw)
+	require.NoError(t, err)
+
+	assert.Equal(t, &Template{
+		Name:    "crash",
+		Title:   "[crash] ",
+		Message: "## Steps to reproduce",
+		Labels:  []string{"bug", "triage"},
+		Fields:  map[string]string{"severity": "medium", "env": "prod"},
+	}, tmpl)
+
+	// no front-matter
+	tmpl, err = ParseTemplate("plain", "just a message\n")
+	require.NoError(t, err)
+	assert.Equal(t, &Template{Name: "plain", Message: "just a message"}, tmpl)
+
+	// bad templates
+	_, err = ParseTemplate("bad", "---\ntitle: foo\n")
+	assert.Error(t, err)
+	_, err = ParseTemplate("bad", "---\nassignee: foo\n---\n")
+	assert.Error(t, err)
+	_, err = ParseTemplate("bad", "---\n  severity: high\n---\n")
+	assert.Error(t, err)
+	_, err = ParseTemplate("bad", "---\nfields:\n  bad name: high\n---\n")
+	assert.Error(t, err)
+}
+
+func TestReadTemplates(t *testing.T) {
+	dir, err := ioutil.TempDir("", "git-bug-templates")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	templates, err := ReadTemplates(filepath.Join(dir, "missing"))
+	require.NoError(t, err)
+	assert.Empty(t, templates)
+
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "feature.md"), []byte("feature"), 0644))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "bug.md"), []byte("bug"), 0644))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "README"), []byte("ignored"), 0644))
+
+	templates, err = ReadTemplates(dir)
+	require.NoError(t, err)
+	require.Len(t, templates, 2)
+	assert.Equal(t, "bug", templates[0].Name)
+	assert.Equal(t, "feature", templates[1].Name)
+}
@@ -0,0 +1,62 @@
+package bug
+
+import (
+	"github.com/MichaelMure/git-bug/entity"
+)
+
+// ThreadedComment is a Comment placed in a discussion thread
+type ThreadedComment struct {
+	Comment
+	// Depth is the nesting level of the comment, 0 for a top level comment
+	Depth int
+}
+
+// Replies return the direct answers to a comment, in order
+func (snap *Snapshot) Replies(id entity.Id) []Comment {
+	var result []Comment
+
+	for _, c := range snap.Comments {
+		if c.ReplyTo == id {
+			result = append(result, c)
+		}
+	}
+
+	return result
+}
+
+// Threads return all the comments arranged in discussion threads: each comment
+// is directly followed by its replies, recursively. Replies to a comment that
+// doesn't exist are considered to be top level comments.
+func (snap *Snapshot) Threads() []ThreadedComment {
+	known := make(map[entity.Id]bool, len(snap.Comments))
+	for _, c := range snap.Comments {
+		known[c.id] = true
+	}
+
+	children := make(map[entity.Id][]Comment)
+	var roots []Comment
+
+	for _, c := range snap.Comments {
+		if c.ReplyTo == "" || !known[c.ReplyTo] {
+			roots = append(roots, c)
+			continue
+		}
+		children[c.ReplyTo] = append(children[c.ReplyTo], c)
+	}
+
+	result := make([]ThreadedComment, 0, len(snap.Comments))
+
+	var walk func(c Comment, depth int)
+	walk = func(c Comment, depth int) {
+		result = append(result, ThreadedComment{Comment: c, Depth: depth})
+		for _, child := range children[c.id] {
+			walk(child, depth+1)
+		}
+	}
+
+	for _, root := range roots {
+		walk(root, 0)
+	}
+
+	return result
+}
@@ -28,19 +28,26 @@ type CommentHistoryStep struct {
 type CommentTimelineItem struct {
 	id        entity.Id
 	Author    identity.Interface
+	CoAuthors []identity.Interface
 	Message   string
 	Files     []repository.Hash
+	ReplyTo   entity.Id
 	CreatedAt timestamp.Timestamp
 	LastEdit  timestamp.Timestamp
 	History   []CommentHistoryStep
+	Redaction *Redaction
+	Minimized MinimizeReason
+	Pinned    bool
 }
 
 func NewCommentTimelineItem(ID entity.Id, comment Comment) CommentTimelineItem {
 	return CommentTimelineItem{
 		id:        ID,
 		Author:    comment.Author,
+		CoAuthors: comment.CoAuthors,
 		Message:   comment.Message,
 		Files:     comment.Files,
+		ReplyTo:   comment.ReplyTo,
 		CreatedAt: comment.UnixTime,
 		LastEdit:  comment.UnixTime,
 		History: []CommentHistoryStep{
@@ -68,6 +75,26 @@ func (c *CommentTimelineItem) Append(comment Comment) {
 	})
 }
 
+// Redact remove the content of the comment, including its edition history
+func (c *CommentTimelineItem) Redact(redaction *Redaction) {
+	c.Message = ""
+	c.Files = nil
+	c.Redaction = redaction
+	for i := range c.History {
+		c.History[i].Message = ""
+	}
+}
+
+// Redacted say if the content of the comment has been removed
+func (c *CommentTimelineItem) Redacted() bool {
+	return c.Redaction != nil
+}
+
+// IsMinimized say if the comment should be displayed collapsed
+func (c *CommentTimelineItem) IsMinimized() bool {
+	return c.Minimized != ""
+}
+
 // Edited say if the comment was edited
 func (c *CommentTimelineItem) Edited() bool {
 	return len(c.History) > 1
@@ -0,0 +1,55 @@
+package bug
+
+import (
+	"regexp"
+	"strings"
+)
+
+// CommitTrailer is a reference to a bug found in the trailers of a commit
+// message, like "Fixes: 1a2b3c4" or "Refs: 1a2b3c4"
+type CommitTrailer struct {
+	// Prefix is the bug id, or a prefix of it
+	Prefix string
+	Role   CodeRefRole
+}
+
+var trailerRegexp = regexp.MustCompile(`(?i)^(fixes|fixed|closes|refs|references|see)\s*:\s*(.+)$`)
+
+// ParseCommitTrailers find the references to bugs in the trailers of a commit
+// message, that is in the last paragraph. Several bugs can be given on the
+// same line, separated by commas or spaces, and prefixed or not by "#".
+func ParseCommitTrailers(message string) []CommitTrailer {
+	message = strings.TrimSpace(strings.Replace(message, "\r\n", "\n", -1))
+
+	paragraphs := strings.Split(message, "\n\n")
+	// the subject alone is not a trailer
+	if len(paragraphs) < 2 {
+		return nil
+	}
+
+	var result []CommitTrailer
+
+	for _, line := range strings.Split(paragraphs[len(paragraphs)-1], "\n") {
+		matches := trailerRegexp.FindStringSubmatch(strings.TrimSpace(line))
+		if matches == nil {
+			continue
+		}
+
+		role := MentionedCodeRef
+		switch strings.ToLower(matches[1]) {
+		case "fixes", "fixed", "closes":
+			role = FixedByCodeRef
+		}
+
+		for _, prefix := range strings.FieldsFunc(matches[2], func(r rune) bool {
+			return r == ',' || r == ' ' || r == '\t'
+		}) {
+			prefix = strings.TrimPrefix(prefix, "#")
+			if prefix != "" {
+				result = append(result, CommitTrailer{Prefix: prefix, Role: role})
+			}
+		}
+	}
+
+	return result
+}
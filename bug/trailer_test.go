@@ -0,0 +1,22 @@
+package bug
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCommitTrailers(t *testing.T) {
+	message := "Fix the crash on startup\r\n\r\nFixes: 1a2b3c4 mentioned in the body is not a trailer\r\n\r\n" +
+		"Fixes: 1a2b3c4, #5d6e7f8\r\nRefs: 9a8b7c6\r\nSigned-off-by: René Descartes <rene@descartes.fr>\r\n"
+
+	assert.Equal(t, []CommitTrailer{
+		{Prefix: "1a2b3c4", Role: FixedByCodeRef},
+		{Prefix: "5d6e7f8", Role: FixedByCodeRef},
+		{Prefix: "9a8b7c6", Role: MentionedCodeRef},
+	}, ParseCommitTrailers(message))
+
+	// the subject alone
+	assert.Empty(t, ParseCommitTrailers("Fixes: 1a2b3c4"))
+	assert.Empty(t, ParseCommitTrailers("subject\n\nno trailer"))
+}
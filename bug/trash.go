@@ -0,0 +1,158 @@
+package bug
+
+import (
+	"fmt"
+
+	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/identity"
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+// The removed bugs are kept in the trash, outside of the namespaces that
+// are read, pushed and fetched, until they are restored or purged.
+const trashRefPattern = "refs/trash/bugs/"
+const trashDraftsRefPattern = "refs/trash/drafts/bugs/"
+
+// TrashBug move a bug to the trash. Like RemoveBug, the local bug and the
+// remote-tracking copies are removed.
+func TrashBug(repo repository.ClockedRepo, id entity.Id) error {
+	tip, trashRef, err := trashSource(repo, id)
+	if err != nil {
+		return err
+	}
+
+	err = repo.UpdateRef(trashRef, tip)
+	if err != nil {
+		return err
+	}
+
+	return RemoveBug(repo, id)
+}
+
+// trashSource return the version of the bug to keep in the trash: the local
+// one, or a remote one if the bug is not available locally
+func trashSource(repo repository.ClockedRepo, id entity.Id) (repository.Hash, string, error) {
+	for _, pair := range [][2]string{
+		{bugsRefPattern, trashRefPattern},
+		{draftsRefPattern, trashDraftsRefPattern},
+	} {
+		ref := pair[0] + id.String()
+		exist, err := repo.RefExist(ref)
+		if err != nil {
+			return "", "", err
+		}
+		if exist {
+			tip, err := repo.ResolveRef(ref)
+			return tip, pair[1] + id.String(), err
+		}
+	}
+
+	remotes, err := repo.GetRemotes()
+	if err != nil {
+		return "", "", err
+	}
+
+	for remote := range remotes {
+		ref := fmt.Sprintf(bugsRemoteRefPattern, remote) + id.String()
+		exist, err := repo.RefExist(ref)
+		if err != nil {
+			return "", "", err
+		}
+		if exist {
+			tip, err := repo.ResolveRef(ref)
+			return tip, trashRefPattern + id.String(), err
+		}
+	}
+
+	return "", "", ErrBugNotExist
+}
+
+// ListTrashedIds list the ids of the bugs in the trash
+func ListTrashedIds(repo repository.Repo) ([]entity.Id, error) {
+	var result []entity.Id
+
+	for _, prefix := range []string{trashRefPattern, trashDraftsRefPattern} {
+		refs, err := repo.ListRefs(prefix)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, refsToIds(refs)...)
+	}
+
+	return result, nil
+}
+
+// trashRef return the ref of a bug in the trash
+func trashRef(repo repository.Repo, id entity.Id) (string, error) {
+	for _, prefix := range []string{trashRefPattern, trashDraftsRefPattern} {
+		exist, err := repo.RefExist(prefix + id.String())
+		if err != nil {
+			return "", err
+		}
+		if exist {
+			return prefix + id.String(), nil
+		}
+	}
+	return "", ErrBugNotExist
+}
+
+// ReadTrashed read a bug from the trash
+func ReadTrashed(repo repository.ClockedRepo, id entity.Id) (*Bug, error) {
+	ref, err := trashRef(repo, id)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := read(repo, identity.NewSimpleResolver(repo), ref)
+	if err != nil {
+		return nil, err
+	}
+	b.draft = ref == trashDraftsRefPattern+id.String()
+
+	return b, nil
+}
+
+// RestoreBug move a bug from the trash back to the local bugs, or drafts
+func RestoreBug(repo repository.ClockedRepo, id entity.Id) error {
+	ref, err := trashRef(repo, id)
+	if err != nil {
+		return err
+	}
+
+	target := bugsRefPattern + id.String()
+	if ref == trashDraftsRefPattern+id.String() {
+		target = draftsRefPattern + id.String()
+	}
+
+	for _, prefix := range []string{bugsRefPattern, draftsRefPattern} {
+		exist, err := repo.RefExist(prefix + id.String())
+		if err != nil {
+			return err
+		}
+		if exist {
+			return fmt.Errorf("bug %s exists outside of the trash", id.Human())
+		}
+	}
+
+	tip, err := repo.ResolveRef(ref)
+	if err != nil {
+		return err
+	}
+
+	err = repo.UpdateRef(target, tip)
+	if err != nil {
+		return err
+	}
+
+	return repo.RemoveRef(ref)
+}
+
+// PurgeBug definitely remove a bug from the trash
+func PurgeBug(repo repository.ClockedRepo, id entity.Id) error {
+	ref, err := trashRef(repo, id)
+	if err != nil {
+		return err
+	}
+
+	return repo.RemoveRef(ref)
+}
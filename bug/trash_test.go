@@ -0,0 +1,65 @@
+package bug
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/identity"
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+func TestTrash(t *testing.T) {
+	repo := repository.NewMockRepoForTest()
+
+	rene := identity.NewIdentity("René Descartes", "rene@descartes.fr")
+	err := rene.Commit(repo)
+	require.NoError(t, err)
+
+	b, _, err := Create(rene, time.Now().Unix(), "title", "message")
+	require.NoError(t, err)
+	require.NoError(t, b.Commit(repo))
+
+	draft, _, err := CreateDraftWithFiles(rene, time.Now().Unix(), "title", "message", nil)
+	require.NoError(t, err)
+	require.NoError(t, draft.Commit(repo))
+
+	require.NoError(t, TrashBug(repo, b.Id()))
+	require.NoError(t, TrashBug(repo, draft.Id()))
+	require.Equal(t, ErrBugNotExist, TrashBug(repo, b.Id()))
+
+	_, err = ReadLocal(repo, b.Id())
+	require.Error(t, err)
+
+	ids, err := ListLocalIds(repo)
+	require.NoError(t, err)
+	require.Empty(t, ids)
+
+	ids, err = ListTrashedIds(repo)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []entity.Id{b.Id(), draft.Id()}, ids)
+
+	trashed, err := ReadTrashed(repo, draft.Id())
+	require.NoError(t, err)
+	require.True(t, trashed.IsDraft())
+
+	require.NoError(t, RestoreBug(repo, b.Id()))
+	require.NoError(t, RestoreBug(repo, draft.Id()))
+
+	restored, err := ReadLocal(repo, b.Id())
+	require.NoError(t, err)
+	require.False(t, restored.IsDraft())
+	restored, err = ReadLocal(repo, draft.Id())
+	require.NoError(t, err)
+	require.True(t, restored.IsDraft())
+
+	require.NoError(t, TrashBug(repo, b.Id()))
+	require.NoError(t, PurgeBug(repo, b.Id()))
+	require.Equal(t, ErrBugNotExist, RestoreBug(repo, b.Id()))
+
+	ids, err = ListTrashedIds(repo)
+	require.NoError(t, err)
+	require.Empty(t, ids)
+}
@@ -10,6 +10,16 @@ type WithSnapshot struct {
 	snap *Snapshot
 }
 
+// NewWithSnapshot wrap a Bug with an already compiled Snapshot of it
+func NewWithSnapshot(b *Bug, snap *Snapshot) *WithSnapshot {
+	return &WithSnapshot{Bug: b, snap: snap}
+}
+
+// HasSnapshot return true if the snapshot has already been compiled
+func (b *WithSnapshot) HasSnapshot() bool {
+	return b.snap != nil
+}
+
 // Snapshot return the current snapshot
 func (b *WithSnapshot) Snapshot() *Snapshot {
 	if b.snap == nil {
@@ -0,0 +1,112 @@
+package cache
+
+import (
+	"sync"
+	"time"
+
+	"github.com/MichaelMure/git-bug/board"
+	"github.com/MichaelMure/git-bug/entity"
+)
+
+// BoardCache is a wrapper around a Board. It provide a higher level API
+// and deal with concurrency. Unlike bugs, boards are few and small so they
+// are not kept in memory nor indexed.
+type BoardCache struct {
+	repoCache *RepoCache
+	mu        sync.RWMutex
+	board     *board.Board
+}
+
+func NewBoardCache(repoCache *RepoCache, b *board.Board) *BoardCache {
+	return &BoardCache{
+		repoCache: repoCache,
+		board:     b,
+	}
+}
+
+func (c *BoardCache) Id() entity.Id {
+	return c.board.Id()
+}
+
+func (c *BoardCache) Snapshot() board.Snapshot {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.board.Compile()
+}
+
+func (c *BoardCache) SetTitle(title string) (*board.SetTitleOperation, error) {
+	author, err := c.repoCache.GetUserIdentity()
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return board.SetTitle(c.board, author.Identity, time.Now().Unix(), title)
+}
+
+func (c *BoardCache) SetColumns(columns []string) (*board.SetColumnsOperation, error) {
+	author, err := c.repoCache.GetUserIdentity()
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return board.SetColumns(c.board, author.Identity, time.Now().Unix(), columns)
+}
+
+// MoveCard put a bug in a column at the given position, -1 meaning the end
+// of the column
+func (c *BoardCache) MoveCard(bugId entity.Id, column string, position int) (*board.MoveCardOperation, error) {
+	author, err := c.repoCache.GetUserIdentity()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.MoveCardRaw(author, time.Now().Unix(), bugId, column, position)
+}
+
+func (c *BoardCache) MoveCardRaw(author *IdentityCache, unixTime int64, bugId entity.Id, column string, position int) (*board.MoveCardOperation, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return board.MoveCard(c.board, author.Identity, unixTime, bugId, column, position)
+}
+
+func (c *BoardCache) RemoveCard(bugId entity.Id) (*board.RemoveCardOperation, error) {
+	author, err := c.repoCache.GetUserIdentity()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.RemoveCardRaw(author, time.Now().Unix(), bugId)
+}
+
+func (c *BoardCache) RemoveCardRaw(author *IdentityCache, unixTime int64, bugId entity.Id) (*board.RemoveCardOperation, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return board.RemoveCard(c.board, author.Identity, unixTime, bugId)
+}
+
+func (c *BoardCache) Commit() error {
+	if c.repoCache.readOnly {
+		return ErrReadOnly
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.board.Commit(c.repoCache.repo)
+}
+
+func (c *BoardCache) CommitAsNeeded() error {
+	if c.repoCache.readOnly {
+		return ErrReadOnly
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.board.NeedCommit() {
+		return nil
+	}
+	return c.board.Commit(c.repoCache.repo)
+}
@@ -2,11 +2,13 @@ package cache
 
 import (
 	"fmt"
+	"sort"
 	"sync"
 	"time"
 
 	"github.com/MichaelMure/git-bug/bug"
 	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/identity"
 	"github.com/MichaelMure/git-bug/repository"
 )
 
@@ -30,6 +32,13 @@ func NewBugCache(repoCache *RepoCache, b *bug.Bug) *BugCache {
 	}
 }
 
+func newBugCacheWithSnapshot(repoCache *RepoCache, b *bug.Bug, snap *bug.Snapshot) *BugCache {
+	return &BugCache{
+		repoCache: repoCache,
+		bug:       bug.NewWithSnapshot(b, snap),
+	}
+}
+
 func (c *BugCache) Snapshot() *bug.Snapshot {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
@@ -71,6 +80,32 @@ func (c *BugCache) ResolveOperationWithMetadata(key string, value string) (entit
 	return matching[0], nil
 }
 
+// ResolveOperationPrefix will find an operation from the prefix of its id
+func (c *BugCache) ResolveOperationPrefix(prefix string) (entity.Id, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	// preallocate but empty
+	matching := make([]entity.Id, 0, 5)
+
+	it := bug.NewOperationIterator(c.bug)
+	for it.Next() {
+		id := it.Value().Id()
+		if id.HasPrefix(prefix) {
+			matching = append(matching, id)
+		}
+	}
+
+	if len(matching) == 0 {
+		return "", ErrNoMatchingOp
+	}
+
+	if len(matching) > 1 {
+		return "", bug.NewErrMultipleMatchOp(matching)
+	}
+
+	return matching[0], nil
+}
+
 func (c *BugCache) AddComment(message string) (*bug.AddCommentOperation, error) {
 	return c.AddCommentWithFiles(message, nil)
 }
@@ -101,6 +136,199 @@ func (c *BugCache) AddCommentRaw(author *IdentityCache, unixTime int64, message
 	return op, c.notifyUpdated()
 }
 
+// AddCommentWithCoAuthors add a comment crediting additional authors
+func (c *BugCache) AddCommentWithCoAuthors(message string, coAuthors []*IdentityCache) (*bug.AddCommentOperation, error) {
+	author, err := c.repoCache.GetUserIdentity()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.AddCommentWithCoAuthorsRaw(author, coAuthors, time.Now().Unix(), message, nil, nil)
+}
+
+func (c *BugCache) AddCommentWithCoAuthorsRaw(author *IdentityCache, coAuthors []*IdentityCache, unixTime int64, message string, files []repository.Hash, metadata map[string]string) (*bug.AddCommentOperation, error) {
+	c.mu.Lock()
+	op, err := bug.AddCommentWithCoAuthors(c.bug, author.Identity, coAuthorIdentities(coAuthors), unixTime, message, files)
+	if err != nil {
+		c.mu.Unlock()
+		return nil, err
+	}
+
+	for key, value := range metadata {
+		op.SetMetadata(key, value)
+	}
+
+	c.mu.Unlock()
+
+	return op, c.notifyUpdated()
+}
+
+func coAuthorIdentities(coAuthors []*IdentityCache) []identity.Interface {
+	result := make([]identity.Interface, len(coAuthors))
+	for i, coAuthor := range coAuthors {
+		result[i] = coAuthor.Identity
+	}
+	return result
+}
+
+// AddReply add a comment answering to the comment with the given id
+func (c *BugCache) AddReply(replyTo entity.Id, message string) (*bug.AddCommentOperation, error) {
+	author, err := c.repoCache.GetUserIdentity()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.AddReplyRaw(author, time.Now().Unix(), replyTo, message, nil, nil)
+}
+
+func (c *BugCache) AddReplyRaw(author *IdentityCache, unixTime int64, replyTo entity.Id, message string, files []repository.Hash, metadata map[string]string) (*bug.AddCommentOperation, error) {
+	c.mu.Lock()
+	op, err := bug.ReplyToComment(c.bug, author.Identity, unixTime, replyTo, message, files)
+	if err != nil {
+		c.mu.Unlock()
+		return nil, err
+	}
+
+	for key, value := range metadata {
+		op.SetMetadata(key, value)
+	}
+
+	c.mu.Unlock()
+
+	return op, c.notifyUpdated()
+}
+
+// RedactComment remove the content of a comment, recording the reason
+func (c *BugCache) RedactComment(target entity.Id, reason string) (*bug.RedactOperation, error) {
+	author, err := c.repoCache.GetUserIdentity()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.RedactCommentRaw(author, time.Now().Unix(), target, reason, nil)
+}
+
+func (c *BugCache) RedactCommentRaw(author *IdentityCache, unixTime int64, target entity.Id, reason string, metadata map[string]string) (*bug.RedactOperation, error) {
+	c.mu.Lock()
+	op, err := bug.Redact(c.bug, author.Identity, unixTime, target, reason)
+	if err != nil {
+		c.mu.Unlock()
+		return nil, err
+	}
+
+	for key, value := range metadata {
+		op.SetMetadata(key, value)
+	}
+
+	c.mu.Unlock()
+
+	return op, c.notifyUpdated()
+}
+
+func (c *BugCache) MinimizeComment(target entity.Id, reason bug.MinimizeReason) (*bug.MinimizeCommentOperation, error) {
+	author, err := c.repoCache.GetUserIdentity()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.MinimizeCommentRaw(author, time.Now().Unix(), target, reason, nil)
+}
+
+func (c *BugCache) MinimizeCommentRaw(author *IdentityCache, unixTime int64, target entity.Id, reason bug.MinimizeReason, metadata map[string]string) (*bug.MinimizeCommentOperation, error) {
+	c.mu.Lock()
+	op, err := bug.MinimizeComment(c.bug, author.Identity, unixTime, target, reason)
+	if err != nil {
+		c.mu.Unlock()
+		return nil, err
+	}
+
+	for key, value := range metadata {
+		op.SetMetadata(key, value)
+	}
+
+	c.mu.Unlock()
+
+	return op, c.notifyUpdated()
+}
+
+func (c *BugCache) UnminimizeComment(target entity.Id) (*bug.MinimizeCommentOperation, error) {
+	author, err := c.repoCache.GetUserIdentity()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.UnminimizeCommentRaw(author, time.Now().Unix(), target, nil)
+}
+
+func (c *BugCache) UnminimizeCommentRaw(author *IdentityCache, unixTime int64, target entity.Id, metadata map[string]string) (*bug.MinimizeCommentOperation, error) {
+	c.mu.Lock()
+	op, err := bug.UnminimizeComment(c.bug, author.Identity, unixTime, target)
+	if err != nil {
+		c.mu.Unlock()
+		return nil, err
+	}
+
+	for key, value := range metadata {
+		op.SetMetadata(key, value)
+	}
+
+	c.mu.Unlock()
+
+	return op, c.notifyUpdated()
+}
+
+func (c *BugCache) PinComment(target entity.Id) (*bug.PinCommentOperation, error) {
+	author, err := c.repoCache.GetUserIdentity()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.PinCommentRaw(author, time.Now().Unix(), target, nil)
+}
+
+func (c *BugCache) PinCommentRaw(author *IdentityCache, unixTime int64, target entity.Id, metadata map[string]string) (*bug.PinCommentOperation, error) {
+	c.mu.Lock()
+	op, err := bug.PinComment(c.bug, author.Identity, unixTime, target)
+	if err != nil {
+		c.mu.Unlock()
+		return nil, err
+	}
+
+	for key, value := range metadata {
+		op.SetMetadata(key, value)
+	}
+
+	c.mu.Unlock()
+
+	return op, c.notifyUpdated()
+}
+
+func (c *BugCache) UnpinComment(target entity.Id) (*bug.PinCommentOperation, error) {
+	author, err := c.repoCache.GetUserIdentity()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.UnpinCommentRaw(author, time.Now().Unix(), target, nil)
+}
+
+func (c *BugCache) UnpinCommentRaw(author *IdentityCache, unixTime int64, target entity.Id, metadata map[string]string) (*bug.PinCommentOperation, error) {
+	c.mu.Lock()
+	op, err := bug.UnpinComment(c.bug, author.Identity, unixTime, target)
+	if err != nil {
+		c.mu.Unlock()
+		return nil, err
+	}
+
+	for key, value := range metadata {
+		op.SetMetadata(key, value)
+	}
+
+	c.mu.Unlock()
+
+	return op, c.notifyUpdated()
+}
+
 func (c *BugCache) ChangeLabels(added []string, removed []string) ([]bug.LabelChangeResult, *bug.LabelChangeOperation, error) {
 	author, err := c.repoCache.GetUserIdentity()
 	if err != nil {
@@ -111,6 +339,15 @@ func (c *BugCache) ChangeLabels(added []string, removed []string) ([]bug.LabelCh
 }
 
 func (c *BugCache) ChangeLabelsRaw(author *IdentityCache, unixTime int64, added []string, removed []string, metadata map[string]string) ([]bug.LabelChangeResult, *bug.LabelChangeOperation, error) {
+	taxonomy, err := c.repoCache.LabelTaxonomy()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := taxonomy.ValidateLabels(added); err != nil {
+		return nil, nil, err
+	}
+
 	c.mu.Lock()
 	changes, op, err := bug.ChangeLabels(c.bug, author.Identity, unixTime, added, removed)
 	if err != nil {
@@ -141,7 +378,15 @@ func (c *BugCache) ForceChangeLabels(added []string, removed []string) (*bug.Lab
 	return c.ForceChangeLabelsRaw(author, time.Now().Unix(), added, removed, nil)
 }
 
+// ForceChangeLabelsRaw change the labels without checking the current state
+// nor the label taxonomy. If labels outside of the taxonomy are added, the
+// operation is marked as such so that it's accepted when merged elsewhere.
 func (c *BugCache) ForceChangeLabelsRaw(author *IdentityCache, unixTime int64, added []string, removed []string, metadata map[string]string) (*bug.LabelChangeOperation, error) {
+	taxonomy, err := c.repoCache.LabelTaxonomy()
+	if err != nil {
+		return nil, err
+	}
+
 	c.mu.Lock()
 	op, err := bug.ForceChangeLabels(c.bug, author.Identity, unixTime, added, removed)
 	if err != nil {
@@ -153,6 +398,10 @@ func (c *BugCache) ForceChangeLabelsRaw(author *IdentityCache, unixTime int64, a
 		op.SetMetadata(key, value)
 	}
 
+	if taxonomy.CheckOperation(op) != nil {
+		op.SetMetadata(bug.ForcedLabelsMetaKey, "true")
+	}
+
 	c.mu.Unlock()
 	err = c.notifyUpdated()
 	if err != nil {
@@ -168,7 +417,12 @@ func (c *BugCache) Open() (*bug.SetStatusOperation, error) {
 		return nil, err
 	}
 
-	return c.OpenRaw(author, time.Now().Unix(), nil)
+	op, err := c.OpenRaw(author, time.Now().Unix(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return op, c.followDuplicates(author, bug.OpenStatus)
 }
 
 func (c *BugCache) OpenRaw(author *IdentityCache, unixTime int64, metadata map[string]string) (*bug.SetStatusOperation, error) {
@@ -193,7 +447,12 @@ func (c *BugCache) Close() (*bug.SetStatusOperation, error) {
 		return nil, err
 	}
 
-	return c.CloseRaw(author, time.Now().Unix(), nil)
+	op, err := c.CloseRaw(author, time.Now().Unix(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return op, c.followDuplicates(author, bug.ClosedStatus)
 }
 
 func (c *BugCache) CloseRaw(author *IdentityCache, unixTime int64, metadata map[string]string) (*bug.SetStatusOperation, error) {
@@ -212,6 +471,87 @@ func (c *BugCache) CloseRaw(author *IdentityCache, unixTime int64, metadata map[
 	return op, c.notifyUpdated()
 }
 
+// SetExtendedStatus change the status of the bug to one of the extended
+// statuses defined for the repository
+func (c *BugCache) SetExtendedStatus(name string) (*bug.SetStatusOperation, error) {
+	author, err := c.repoCache.GetUserIdentity()
+	if err != nil {
+		return nil, err
+	}
+
+	op, err := c.SetExtendedStatusRaw(author, time.Now().Unix(), name, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return op, c.followDuplicates(author, op.Status)
+}
+
+func (c *BugCache) SetExtendedStatusRaw(author *IdentityCache, unixTime int64, name string, metadata map[string]string) (*bug.SetStatusOperation, error) {
+	set, err := c.repoCache.StatusSet()
+	if err != nil {
+		return nil, err
+	}
+
+	status, err := set.Get(name)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	op, err := bug.SetExtendedStatus(c.bug, author.Identity, unixTime, status)
+	if err != nil {
+		c.mu.Unlock()
+		return nil, err
+	}
+
+	for key, value := range metadata {
+		op.SetMetadata(key, value)
+	}
+
+	c.mu.Unlock()
+	return op, c.notifyUpdated()
+}
+
+// followDuplicates apply the given status to the bugs marked as duplicate of
+// this one. Those bugs are committed right away.
+func (c *BugCache) followDuplicates(author *IdentityCache, status bug.Status) error {
+	for _, id := range c.repoCache.duplicatesOf(c.Id()) {
+		duplicate, err := c.repoCache.ResolveBug(id)
+		if err != nil {
+			return err
+		}
+
+		err = duplicate.followStatus(author, status)
+		if err != nil {
+			return err
+		}
+
+		err = duplicate.CommitAsNeeded()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// followStatus change the status of the bug, if needed
+func (c *BugCache) followStatus(author *IdentityCache, status bug.Status) error {
+	if c.Snapshot().Status == status {
+		return nil
+	}
+
+	var err error
+	switch status {
+	case bug.OpenStatus:
+		_, err = c.OpenRaw(author, time.Now().Unix(), nil)
+	case bug.ClosedStatus:
+		_, err = c.CloseRaw(author, time.Now().Unix(), nil)
+	}
+	return err
+}
+
 func (c *BugCache) SetTitle(title string) (*bug.SetTitleOperation, error) {
 	author, err := c.repoCache.GetUserIdentity()
 	if err != nil {
@@ -287,28 +627,428 @@ func (c *BugCache) EditCommentRaw(author *IdentityCache, unixTime int64, target
 	return op, c.notifyUpdated()
 }
 
-func (c *BugCache) SetMetadata(target entity.Id, newMetadata map[string]string) (*bug.SetMetadataOperation, error) {
+func (c *BugCache) SetField(name string, value string) (*bug.SetFieldOperation, error) {
 	author, err := c.repoCache.GetUserIdentity()
 	if err != nil {
 		return nil, err
 	}
 
-	return c.SetMetadataRaw(author, time.Now().Unix(), target, newMetadata)
+	return c.SetFieldRaw(author, time.Now().Unix(), name, value, nil)
 }
 
-func (c *BugCache) SetMetadataRaw(author *IdentityCache, unixTime int64, target entity.Id, newMetadata map[string]string) (*bug.SetMetadataOperation, error) {
-	c.mu.Lock()
-	op, err := bug.SetMetadata(c.bug, author.Identity, unixTime, target, newMetadata)
+func (c *BugCache) SetFieldRaw(author *IdentityCache, unixTime int64, name string, value string, metadata map[string]string) (*bug.SetFieldOperation, error) {
+	schema, err := c.repoCache.FieldSchema()
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	op, err := bug.SetField(c.bug, author.Identity, unixTime, schema, name, value)
+	if err != nil {
+		c.mu.Unlock()
+		return nil, err
+	}
+
+	for key, value := range metadata {
+		op.SetMetadata(key, value)
+	}
+
+	c.mu.Unlock()
+	return op, c.notifyUpdated()
+}
+
+func (c *BugCache) AddTimeSpent(duration time.Duration) (*bug.AddTimeSpentOperation, error) {
+	author, err := c.repoCache.GetUserIdentity()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.AddTimeSpentRaw(author, time.Now().Unix(), duration, nil)
+}
+
+func (c *BugCache) AddTimeSpentRaw(author *IdentityCache, unixTime int64, duration time.Duration, metadata map[string]string) (*bug.AddTimeSpentOperation, error) {
+	c.mu.Lock()
+	op, err := bug.AddTimeSpent(c.bug, author.Identity, unixTime, duration)
+	if err != nil {
+		c.mu.Unlock()
+		return nil, err
+	}
+
+	for key, value := range metadata {
+		op.SetMetadata(key, value)
+	}
+
+	c.mu.Unlock()
+	return op, c.notifyUpdated()
+}
+
+func (c *BugCache) SetEstimate(duration time.Duration) (*bug.SetEstimateOperation, error) {
+	author, err := c.repoCache.GetUserIdentity()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.SetEstimateRaw(author, time.Now().Unix(), duration, nil)
+}
+
+func (c *BugCache) SetEstimateRaw(author *IdentityCache, unixTime int64, duration time.Duration, metadata map[string]string) (*bug.SetEstimateOperation, error) {
+	c.mu.Lock()
+	op, err := bug.SetEstimate(c.bug, author.Identity, unixTime, duration)
+	if err != nil {
+		c.mu.Unlock()
+		return nil, err
+	}
+
+	for key, value := range metadata {
+		op.SetMetadata(key, value)
+	}
+
+	c.mu.Unlock()
+	return op, c.notifyUpdated()
+}
+
+func (c *BugCache) Assign(added []*IdentityCache, removed []*IdentityCache) (*bug.AssignOperation, error) {
+	author, err := c.repoCache.GetUserIdentity()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.AssignRaw(author, time.Now().Unix(), added, removed, nil)
+}
+
+func (c *BugCache) AssignRaw(author *IdentityCache, unixTime int64, added []*IdentityCache, removed []*IdentityCache, metadata map[string]string) (*bug.AssignOperation, error) {
+	addedIdentities := make([]identity.Interface, len(added))
+	for i, assignee := range added {
+		addedIdentities[i] = assignee.Identity
+	}
+	removedIdentities := make([]identity.Interface, len(removed))
+	for i, assignee := range removed {
+		removedIdentities[i] = assignee.Identity
+	}
+
+	c.mu.Lock()
+	op, err := bug.Assign(c.bug, author.Identity, unixTime, addedIdentities, removedIdentities)
+	if err != nil {
+		c.mu.Unlock()
+		return nil, err
+	}
+
+	for key, value := range metadata {
+		op.SetMetadata(key, value)
+	}
+
+	c.mu.Unlock()
+	return op, c.notifyUpdated()
+}
+
+func (c *BugCache) SetMilestone(milestone string) (*bug.SetMilestoneOperation, error) {
+	author, err := c.repoCache.GetUserIdentity()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.SetMilestoneRaw(author, time.Now().Unix(), milestone, nil)
+}
+
+func (c *BugCache) SetMilestoneRaw(author *IdentityCache, unixTime int64, milestone string, metadata map[string]string) (*bug.SetMilestoneOperation, error) {
+	c.mu.Lock()
+	op, err := bug.SetMilestone(c.bug, author.Identity, unixTime, milestone)
 	if err != nil {
 		c.mu.Unlock()
 		return nil, err
 	}
 
+	for key, value := range metadata {
+		op.SetMetadata(key, value)
+	}
+
+	c.mu.Unlock()
+	return op, c.notifyUpdated()
+}
+
+// SetDueDate change the due date of the bug. A zero time remove it.
+func (c *BugCache) SetDueDate(dueDate time.Time) (*bug.SetDueDateOperation, error) {
+	author, err := c.repoCache.GetUserIdentity()
+	if err != nil {
+		return nil, err
+	}
+
+	var unixDueDate int64
+	if !dueDate.IsZero() {
+		unixDueDate = dueDate.Unix()
+	}
+
+	return c.SetDueDateRaw(author, time.Now().Unix(), unixDueDate, nil)
+}
+
+func (c *BugCache) SetDueDateRaw(author *IdentityCache, unixTime int64, dueDate int64, metadata map[string]string) (*bug.SetDueDateOperation, error) {
+	c.mu.Lock()
+	op, err := bug.SetDueDate(c.bug, author.Identity, unixTime, dueDate)
+	if err != nil {
+		c.mu.Unlock()
+		return nil, err
+	}
+
+	for key, value := range metadata {
+		op.SetMetadata(key, value)
+	}
+
+	c.mu.Unlock()
+	return op, c.notifyUpdated()
+}
+
+func (c *BugCache) SetChecklistItem(target entity.Id, item int, checked bool) (*bug.SetChecklistItemOperation, error) {
+	author, err := c.repoCache.GetUserIdentity()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.SetChecklistItemRaw(author, time.Now().Unix(), target, item, checked, nil)
+}
+
+func (c *BugCache) SetChecklistItemRaw(author *IdentityCache, unixTime int64, target entity.Id, item int, checked bool, metadata map[string]string) (*bug.SetChecklistItemOperation, error) {
+	c.mu.Lock()
+	op, err := bug.SetChecklistItem(c.bug, author.Identity, unixTime, target, item, checked)
+	if err != nil {
+		c.mu.Unlock()
+		return nil, err
+	}
+
+	for key, value := range metadata {
+		op.SetMetadata(key, value)
+	}
+
+	c.mu.Unlock()
+	return op, c.notifyUpdated()
+}
+
+// Relate add a relation to another bug. When marked as a duplicate, the bug
+// takes the status of the target.
+func (c *BugCache) Relate(relation bug.RelationType, target entity.Id) (*bug.RelateOperation, error) {
+	author, err := c.repoCache.GetUserIdentity()
+	if err != nil {
+		return nil, err
+	}
+
+	if target == c.Id() {
+		return nil, fmt.Errorf("a bug can't be related to itself")
+	}
+
+	targetExcerpt, err := c.repoCache.ResolveBugExcerpt(target)
+	if err != nil {
+		return nil, err
+	}
+
+	op, err := c.RelateRaw(author, time.Now().Unix(), relation, target, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if relation == bug.DuplicateOfRelation {
+		err = c.followStatus(author, targetExcerpt.Status)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return op, nil
+}
+
+func (c *BugCache) RelateRaw(author *IdentityCache, unixTime int64, relation bug.RelationType, target entity.Id, metadata map[string]string) (*bug.RelateOperation, error) {
+	c.mu.Lock()
+	op, err := bug.Relate(c.bug, author.Identity, unixTime, relation, target)
+	if err != nil {
+		c.mu.Unlock()
+		return nil, err
+	}
+
+	for key, value := range metadata {
+		op.SetMetadata(key, value)
+	}
+
 	c.mu.Unlock()
 	return op, c.notifyUpdated()
 }
 
+// Unrelate remove a relation to another bug
+func (c *BugCache) Unrelate(relation bug.RelationType, target entity.Id) (*bug.RelateOperation, error) {
+	author, err := c.repoCache.GetUserIdentity()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.UnrelateRaw(author, time.Now().Unix(), relation, target, nil)
+}
+
+func (c *BugCache) UnrelateRaw(author *IdentityCache, unixTime int64, relation bug.RelationType, target entity.Id, metadata map[string]string) (*bug.RelateOperation, error) {
+	c.mu.Lock()
+	op, err := bug.Unrelate(c.bug, author.Identity, unixTime, relation, target)
+	if err != nil {
+		c.mu.Unlock()
+		return nil, err
+	}
+
+	for key, value := range metadata {
+		op.SetMetadata(key, value)
+	}
+
+	c.mu.Unlock()
+	return op, c.notifyUpdated()
+}
+
+func (c *BugCache) AddCodeRef(ref bug.CodeRef) (*bug.CodeRefOperation, error) {
+	author, err := c.repoCache.GetUserIdentity()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.AddCodeRefRaw(author, time.Now().Unix(), ref, nil)
+}
+
+func (c *BugCache) AddCodeRefRaw(author *IdentityCache, unixTime int64, ref bug.CodeRef, metadata map[string]string) (*bug.CodeRefOperation, error) {
+	c.mu.Lock()
+	op, err := bug.AddCodeRef(c.bug, author.Identity, unixTime, ref)
+	if err != nil {
+		c.mu.Unlock()
+		return nil, err
+	}
+
+	for key, value := range metadata {
+		op.SetMetadata(key, value)
+	}
+
+	c.mu.Unlock()
+	return op, c.notifyUpdated()
+}
+
+func (c *BugCache) RemoveCodeRef(ref bug.CodeRef) (*bug.CodeRefOperation, error) {
+	author, err := c.repoCache.GetUserIdentity()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.RemoveCodeRefRaw(author, time.Now().Unix(), ref, nil)
+}
+
+func (c *BugCache) RemoveCodeRefRaw(author *IdentityCache, unixTime int64, ref bug.CodeRef, metadata map[string]string) (*bug.CodeRefOperation, error) {
+	c.mu.Lock()
+	op, err := bug.RemoveCodeRef(c.bug, author.Identity, unixTime, ref)
+	if err != nil {
+		c.mu.Unlock()
+		return nil, err
+	}
+
+	for key, value := range metadata {
+		op.SetMetadata(key, value)
+	}
+
+	c.mu.Unlock()
+	return op, c.notifyUpdated()
+}
+
+func (c *BugCache) Subscribe() (*bug.SubscribeOperation, error) {
+	author, err := c.repoCache.GetUserIdentity()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.SubscribeRaw(author, time.Now().Unix(), nil)
+}
+
+func (c *BugCache) SubscribeRaw(author *IdentityCache, unixTime int64, metadata map[string]string) (*bug.SubscribeOperation, error) {
+	c.mu.Lock()
+	op, err := bug.Subscribe(c.bug, author.Identity, unixTime)
+	if err != nil {
+		c.mu.Unlock()
+		return nil, err
+	}
+
+	for key, value := range metadata {
+		op.SetMetadata(key, value)
+	}
+
+	c.mu.Unlock()
+	return op, c.notifyUpdated()
+}
+
+func (c *BugCache) Unsubscribe() (*bug.SubscribeOperation, error) {
+	author, err := c.repoCache.GetUserIdentity()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.UnsubscribeRaw(author, time.Now().Unix(), nil)
+}
+
+func (c *BugCache) UnsubscribeRaw(author *IdentityCache, unixTime int64, metadata map[string]string) (*bug.SubscribeOperation, error) {
+	c.mu.Lock()
+	op, err := bug.Unsubscribe(c.bug, author.Identity, unixTime)
+	if err != nil {
+		c.mu.Unlock()
+		return nil, err
+	}
+
+	for key, value := range metadata {
+		op.SetMetadata(key, value)
+	}
+
+	c.mu.Unlock()
+	return op, c.notifyUpdated()
+}
+
+func (c *BugCache) SetMetadata(target entity.Id, newMetadata map[string]string) (*bug.SetMetadataOperation, error) {
+	author, err := c.repoCache.GetUserIdentity()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.SetMetadataRaw(author, time.Now().Unix(), target, newMetadata)
+}
+
+func (c *BugCache) SetMetadataRaw(author *IdentityCache, unixTime int64, target entity.Id, newMetadata map[string]string) (*bug.SetMetadataOperation, error) {
+	c.mu.Lock()
+	op, err := bug.SetMetadata(c.bug, author.Identity, unixTime, target, newMetadata)
+	if err != nil {
+		c.mu.Unlock()
+		return nil, err
+	}
+
+	c.mu.Unlock()
+	return op, c.notifyUpdated()
+}
+
+// Revert append an operation compensating the effect of the given operation
+func (c *BugCache) Revert(target entity.Id) (bug.Operation, error) {
+	author, err := c.repoCache.GetUserIdentity()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.RevertRaw(author, time.Now().Unix(), target, nil)
+}
+
+func (c *BugCache) RevertRaw(author *IdentityCache, unixTime int64, target entity.Id, metadata map[string]string) (bug.Operation, error) {
+	c.mu.Lock()
+	op, err := bug.Revert(c.bug, author.Identity, unixTime, target)
+	if err != nil {
+		c.mu.Unlock()
+		return nil, err
+	}
+
+	for key, value := range metadata {
+		op.SetMetadata(key, value)
+	}
+
+	c.mu.Unlock()
+
+	return op, c.notifyUpdated()
+}
+
 func (c *BugCache) Commit() error {
+	if c.repoCache.readOnly {
+		return ErrReadOnly
+	}
+
 	c.mu.Lock()
 	err := c.bug.Commit(c.repoCache.repo)
 	if err != nil {
@@ -319,7 +1059,83 @@ func (c *BugCache) Commit() error {
 	return c.notifyUpdated()
 }
 
+// ApplyTemplate set the labels and custom fields of the given template
+// The changes are written in the repository (commit)
+func (c *BugCache) ApplyTemplate(tmpl *bug.Template) error {
+	author, err := c.repoCache.GetUserIdentity()
+	if err != nil {
+		return err
+	}
+
+	return c.ApplyTemplateRaw(author, time.Now().Unix(), tmpl)
+}
+
+func (c *BugCache) ApplyTemplateRaw(author *IdentityCache, unixTime int64, tmpl *bug.Template) error {
+	if len(tmpl.Labels) > 0 {
+		_, _, err := c.ChangeLabelsRaw(author, unixTime, tmpl.Labels, nil, nil)
+		if err != nil {
+			return err
+		}
+	}
+
+	names := make([]string, 0, len(tmpl.Fields))
+	for name := range tmpl.Fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		_, err := c.SetFieldRaw(author, unixTime, name, tmpl.Fields[name], nil)
+		if err != nil {
+			return err
+		}
+	}
+
+	return c.CommitAsNeeded()
+}
+
+// Signatures verify the signature of each commit of the bug
+func (c *BugCache) Signatures() ([]bug.PackSignature, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.bug.Signatures(c.repoCache.repo)
+}
+
+// Recipients return the identities able to read a confidential bug
+func (c *BugCache) Recipients() []entity.Id {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.bug.Recipients()
+}
+
+// IsDraft return true if the bug has not been published yet
+func (c *BugCache) IsDraft() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.bug.IsDraft()
+}
+
+// Publish turn a draft bug into a regular bug, that will be pushed
+func (c *BugCache) Publish() error {
+	if c.repoCache.readOnly {
+		return ErrReadOnly
+	}
+
+	c.mu.Lock()
+	err := c.bug.Publish(c.repoCache.repo)
+	if err != nil {
+		c.mu.Unlock()
+		return err
+	}
+	c.mu.Unlock()
+	return c.notifyUpdated()
+}
+
 func (c *BugCache) CommitAsNeeded() error {
+	if c.repoCache.readOnly {
+		return ErrReadOnly
+	}
+
 	c.mu.Lock()
 	err := c.bug.CommitAsNeeded(c.repoCache.repo)
 	if err != nil {
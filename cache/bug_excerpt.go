@@ -3,6 +3,8 @@ package cache
 import (
 	"encoding/gob"
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/MichaelMure/git-bug/bug"
@@ -29,10 +31,34 @@ type BugExcerpt struct {
 	AuthorId     entity.Id
 	Status       bug.Status
 	Labels       []bug.Label
+	Fields       map[string]string
 	Title        string
 	LenComments  int
 	Actors       []entity.Id
 	Participants []entity.Id
+	Subscribers  []entity.Id
+	Relations    []bug.Relation
+
+	// additional authors of the bug creation, if any
+	CoAuthorIds []entity.Id
+
+	// repository defined status refining Status, if any
+	ExtendedStatus string
+
+	// progress of the checklists found in the comments
+	ChecklistDone  int
+	ChecklistTotal int
+
+	// a draft bug is not pushed until published
+	Draft bool
+
+	// planning
+	AssigneeIds []entity.Id
+	Milestone   string
+	DueUnixTime int64
+
+	// time spent on the bug per identity
+	TimeSpent map[entity.Id]time.Duration
 
 	CreateMetadata map[string]string
 }
@@ -71,6 +97,13 @@ func NewBugExcerpt(b bug.Interface, snap *bug.Snapshot) *BugExcerpt {
 		}
 	}
 
+	subscribersIds := make([]entity.Id, 0, len(snap.Subscribers))
+	for _, subscriber := range snap.Subscribers {
+		if _, ok := subscriber.(*identity.Identity); ok {
+			subscribersIds = append(subscribersIds, subscriber.Id())
+		}
+	}
+
 	e := &BugExcerpt{
 		Id:                b.Id(),
 		CreateLamportTime: b.CreateLamportTime(),
@@ -78,12 +111,34 @@ func NewBugExcerpt(b bug.Interface, snap *bug.Snapshot) *BugExcerpt {
 		CreateUnixTime:    b.FirstOp().Time().Unix(),
 		EditUnixTime:      snap.EditTime().Unix(),
 		Status:            snap.Status,
+		ExtendedStatus:    snap.ExtendedStatus,
 		Labels:            snap.Labels,
+		Fields:            snap.Fields,
+		Relations:         snap.Relations,
 		Actors:            actorsIds,
 		Participants:      participantsIds,
+		Subscribers:       subscribersIds,
 		Title:             snap.Title,
 		LenComments:       len(snap.Comments),
 		CreateMetadata:    b.FirstOp().AllMetadata(),
+		Draft:             b.IsDraft(),
+		TimeSpent:         snap.TimeSpentBy,
+	}
+
+	e.ChecklistDone, e.ChecklistTotal = snap.ChecklistProgress()
+
+	e.Milestone = snap.Milestone
+	if !snap.DueDate.IsZero() {
+		e.DueUnixTime = snap.DueDate.Unix()
+	}
+
+	for _, assignee := range snap.Assignees {
+		switch assignee.(type) {
+		case *identity.Identity, *IdentityCache:
+			e.AssigneeIds = append(e.AssigneeIds, assignee.Id())
+		default:
+			panic("unhandled identity type")
+		}
 	}
 
 	switch snap.Author.(type) {
@@ -93,9 +148,26 @@ func NewBugExcerpt(b bug.Interface, snap *bug.Snapshot) *BugExcerpt {
 		panic("unhandled identity type")
 	}
 
+	for _, coAuthor := range snap.Comments[0].CoAuthors {
+		switch coAuthor.(type) {
+		case *identity.Identity, *IdentityCache:
+			e.CoAuthorIds = append(e.CoAuthorIds, coAuthor.Id())
+		default:
+			panic("unhandled identity type")
+		}
+	}
+
 	return e
 }
 
+// DueTime return the due date of the bug, or the zero time if there is none
+func (b *BugExcerpt) DueTime() time.Time {
+	if b.DueUnixTime == 0 {
+		return time.Time{}
+	}
+	return time.Unix(b.DueUnixTime, 0)
+}
+
 func (b *BugExcerpt) CreateTime() time.Time {
 	return time.Unix(b.CreateUnixTime, 0)
 }
@@ -104,6 +176,22 @@ func (b *BugExcerpt) EditTime() time.Time {
 	return time.Unix(b.EditUnixTime, 0)
 }
 
+// IsMergedDuplicate tell if the bug has been closed as a duplicate of
+// another bug
+func (b *BugExcerpt) IsMergedDuplicate() bool {
+	if b.Status != bug.ClosedStatus {
+		return false
+	}
+
+	for _, relation := range b.Relations {
+		if relation.Type == bug.DuplicateOfRelation {
+			return true
+		}
+	}
+
+	return false
+}
+
 /*
  * Sorting
  */
@@ -177,3 +265,114 @@ func (b BugsByEditTime) Less(i, j int) bool {
 func (b BugsByEditTime) Swap(i, j int) {
 	b[i], b[j] = b[j], b[i]
 }
+
+type BugsByCommentCount []*BugExcerpt
+
+func (b BugsByCommentCount) Len() int {
+	return len(b)
+}
+
+func (b BugsByCommentCount) Less(i, j int) bool {
+	if b[i].LenComments != b[j].LenComments {
+		return b[i].LenComments < b[j].LenComments
+	}
+	return b[i].Id < b[j].Id
+}
+
+func (b BugsByCommentCount) Swap(i, j int) {
+	b[i], b[j] = b[j], b[i]
+}
+
+// BugsByDueDate sort the bugs by due date. The bugs without one have to
+// be set apart.
+type BugsByDueDate []*BugExcerpt
+
+func (b BugsByDueDate) Len() int {
+	return len(b)
+}
+
+func (b BugsByDueDate) Less(i, j int) bool {
+	if b[i].DueUnixTime != b[j].DueUnixTime {
+		return b[i].DueUnixTime < b[j].DueUnixTime
+	}
+	return b[i].Id < b[j].Id
+}
+
+func (b BugsByDueDate) Swap(i, j int) {
+	b[i], b[j] = b[j], b[i]
+}
+
+// PriorityField is the custom field holding the priority of a bug
+const PriorityField = "priority"
+
+// BugsByPriority sort the bugs by the value of their priority custom field:
+// in the order of the values for an enum, numerically for a number and
+// alphabetically otherwise. The bugs without a priority have to be set apart.
+type BugsByPriority struct {
+	Excerpts   []*BugExcerpt
+	Definition bug.FieldDefinition
+}
+
+func (b BugsByPriority) Len() int {
+	return len(b.Excerpts)
+}
+
+func (b BugsByPriority) Less(i, j int) bool {
+	pi := b.Excerpts[i].Fields[PriorityField]
+	pj := b.Excerpts[j].Fields[PriorityField]
+
+	if pi == pj {
+		return b.Excerpts[i].Id < b.Excerpts[j].Id
+	}
+
+	return comparePriority(b.Definition, pi, pj) < 0
+}
+
+// comparePriority return -1, 0 or 1 if the priority a is before, the same
+// or after b: in the order of the values for an enum, numerically for a
+// number and alphabetically otherwise.
+func comparePriority(def bug.FieldDefinition, a, b string) int {
+	if a == b {
+		return 0
+	}
+
+	switch def.Type {
+	case bug.FieldEnum:
+		ra, rb := priorityRank(def, a), priorityRank(def, b)
+		switch {
+		case ra < rb:
+			return -1
+		case ra > rb:
+			return 1
+		}
+	case bug.FieldNumber:
+		na, erra := strconv.ParseFloat(a, 64)
+		nb, errb := strconv.ParseFloat(b, 64)
+		if erra == nil && errb == nil {
+			switch {
+			case na < nb:
+				return -1
+			case na > nb:
+				return 1
+			}
+			return 0
+		}
+	}
+
+	return strings.Compare(a, b)
+}
+
+// priorityRank return the position of a value in an enum, the unknown values
+// being last
+func priorityRank(def bug.FieldDefinition, value string) int {
+	for i, v := range def.Values {
+		if v == value {
+			return i
+		}
+	}
+	return len(def.Values)
+}
+
+func (b BugsByPriority) Swap(i, j int) {
+	b.Excerpts[i], b.Excerpts[j] = b.Excerpts[j], b.Excerpts[i]
+}
@@ -0,0 +1,151 @@
+package cache
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"sync"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/identity"
+)
+
+// BuildEventType is the kind of a BuildEvent
+type BuildEventType int
+
+const (
+	_ BuildEventType = iota
+	// the building of the cache for a kind of entity started
+	BuildEventStarted
+	// an entity has been processed
+	BuildEventProgress
+	// the building of the cache for a kind of entity is done
+	BuildEventFinished
+	// the cache on disk can't be used and need to be rebuilt
+	BuildEventOutdated
+	// the cache on disk has been migrated to the current format
+	BuildEventMigrated
+)
+
+// BuildEvent report the progress of the building of the cache
+type BuildEvent struct {
+	Typ BuildEventType
+	// the kind of entity being processed: "identities" or "bugs"
+	Target string
+	// the number of entities processed so far, and the total to process
+	Done  int
+	Total int
+	// for BuildEventOutdated and BuildEventMigrated, a description of what
+	// happened
+	Reason string
+}
+
+func (e BuildEvent) String() string {
+	switch e.Typ {
+	case BuildEventStarted:
+		return fmt.Sprintf("building the %s cache", e.Target)
+	case BuildEventFinished:
+		return fmt.Sprintf("built the %s cache (%d %s)", e.Target, e.Total, e.Target)
+	case BuildEventOutdated:
+		return fmt.Sprintf("rebuilding the cache: %s", e.Reason)
+	case BuildEventMigrated:
+		return e.Reason
+	default:
+		return fmt.Sprintf("built %d/%d %s", e.Done, e.Total, e.Target)
+	}
+}
+
+// defaultBuildProgress keep the historical terse messages on stderr
+func defaultBuildProgress(event BuildEvent) {
+	switch event.Typ {
+	case BuildEventStarted:
+		_, _ = fmt.Fprintf(os.Stderr, "Building %s cache... ", event.Target)
+	case BuildEventFinished:
+		_, _ = fmt.Fprintln(os.Stderr, "Done.")
+	case BuildEventOutdated, BuildEventMigrated:
+		_, _ = fmt.Fprintln(os.Stderr, event)
+	}
+}
+
+// the number of bugs read and compiled concurrently when building the cache
+var buildWorkers = runtime.NumCPU()
+
+// builtBug is the result of reading and compiling a bug for the cache
+type builtBug struct {
+	id   entity.Id
+	bug  *bug.Bug
+	snap bug.Snapshot
+	err  error
+}
+
+// buildBugs read and compile all the local bugs with a pool of workers,
+// and stream the result.
+func (c *RepoCache) buildBugs(ids []entity.Id) <-chan builtBug {
+	in := make(chan entity.Id)
+	out := make(chan builtBug)
+
+	go func() {
+		defer close(in)
+		for _, id := range ids {
+			in <- id
+		}
+	}()
+
+	resolver := newSyncedResolver(identity.NewSimpleResolver(c.repo))
+
+	var wg sync.WaitGroup
+	for i := 0; i < buildWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for id := range in {
+				b, err := bug.ReadLocalWithResolver(c.repo, resolver, id)
+				if err != nil {
+					out <- builtBug{id: id, err: err}
+					continue
+				}
+				out <- builtBug{id: id, bug: b, snap: b.Compile()}
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+
+	return out
+}
+
+// syncedResolver is an identity.Resolver safe for concurrent use, that
+// also avoid to read the same identity multiple times
+type syncedResolver struct {
+	mu         sync.Mutex
+	resolver   identity.Resolver
+	identities map[entity.Id]identity.Interface
+}
+
+func newSyncedResolver(resolver identity.Resolver) *syncedResolver {
+	return &syncedResolver{
+		resolver:   resolver,
+		identities: make(map[entity.Id]identity.Interface),
+	}
+}
+
+func (r *syncedResolver) ResolveIdentity(id entity.Id) (identity.Interface, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if i, ok := r.identities[id]; ok {
+		return i, nil
+	}
+
+	i, err := r.resolver.ResolveIdentity(id)
+	if err != nil {
+		return nil, err
+	}
+
+	r.identities[id] = i
+	return i, nil
+}
@@ -0,0 +1,189 @@
+package cache
+
+import (
+	"bytes"
+	"encoding/gob"
+	"os"
+	"path"
+	"sync"
+
+	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/repository"
+	"github.com/MichaelMure/git-bug/util/lamport"
+)
+
+const exportStateFile = "export-state"
+
+// 1: original format
+const exportStateVersion = 1
+
+func exportStateFilePath(repo repository.Repo) string {
+	return path.Join(repo.GetPath(), "git-bug", exportStateFile)
+}
+
+// ExportedBug record the export of a bug by a bridge
+type ExportedBug struct {
+	// the id of the bug on the remote bug tracker
+	RemoteId string
+	// the edit time of the bug once exported, including the metadata
+	// added during the export
+	EditLamportTime lamport.Time
+}
+
+// bridgeExportState is the persisted state of a bridge
+type bridgeExportState struct {
+	// a fingerprint of what influence the export beside the bugs, like
+	// the identities with credentials. A different context invalidate the
+	// state.
+	Context string
+	Bugs    map[entity.Id]ExportedBug
+}
+
+// ExportState track, for a bridge, the bugs already exported. It allow an
+// exporter to only visit the bugs changed since the last export.
+type ExportState struct {
+	cache  *RepoCache
+	bridge string
+
+	mu    sync.Mutex
+	state bridgeExportState
+}
+
+// ExportState return the export state of the given bridge
+func (c *RepoCache) ExportState(bridge string) (*ExportState, error) {
+	all, err := c.readExportStates()
+	if err != nil {
+		return nil, err
+	}
+
+	state, ok := all[bridge]
+	if !ok || state.Bugs == nil {
+		state.Bugs = make(map[entity.Id]ExportedBug)
+	}
+
+	return &ExportState{
+		cache:  c,
+		bridge: bridge,
+		state:  state,
+	}, nil
+}
+
+// SetContext define the fingerprint of the export context. If it changed
+// since the last export, all the bugs are considered as not exported.
+func (s *ExportState) SetContext(context string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.state.Context != context {
+		s.state.Context = context
+		s.state.Bugs = make(map[entity.Id]ExportedBug)
+	}
+}
+
+// DirtyBugs return the ids of the bugs that have never been exported, or
+// that have been modified since.
+func (s *ExportState) DirtyBugs() []entity.Id {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.cache.muBug.RLock()
+	defer s.cache.muBug.RUnlock()
+
+	var result []entity.Id
+	for id, excerpt := range s.cache.bugExcerpts {
+		exported, ok := s.state.Bugs[id]
+		if !ok || exported.EditLamportTime != excerpt.EditLamportTime {
+			result = append(result, id)
+		}
+	}
+
+	return result
+}
+
+// MarkExported record that a bug is fully exported in its current state
+func (s *ExportState) MarkExported(id entity.Id, remoteId string) error {
+	excerpt, err := s.cache.ResolveBugExcerpt(id)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.state.Bugs[id] = ExportedBug{
+		RemoteId:        remoteId,
+		EditLamportTime: excerpt.EditLamportTime,
+	}
+
+	return nil
+}
+
+// RemoteId return the id of an exported bug on the remote bug tracker
+func (s *ExportState) RemoteId(id entity.Id) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	exported, ok := s.state.Bugs[id]
+	return exported.RemoteId, ok
+}
+
+// Write persist the export state on disk
+func (s *ExportState) Write() error {
+	if s.cache.readOnly {
+		return ErrReadOnly
+	}
+
+	all, err := s.cache.readExportStates()
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	all[s.bridge] = s.state
+	s.mu.Unlock()
+
+	var data bytes.Buffer
+
+	encoder := gob.NewEncoder(&data)
+
+	err = writeCacheHeader(encoder, exportStateVersion)
+	if err != nil {
+		return err
+	}
+
+	err = encoder.Encode(all)
+	if err != nil {
+		return err
+	}
+
+	return writeFileAtomic(exportStateFilePath(s.cache.repo), data.Bytes())
+}
+
+// readExportStates read the export states of all the bridges. A missing or
+// outdated file result in an empty state, which only cost a full export.
+func (c *RepoCache) readExportStates() (map[string]bridgeExportState, error) {
+	result := make(map[string]bridgeExportState)
+
+	f, err := os.Open(exportStateFilePath(c.repo))
+	if os.IsNotExist(err) {
+		return result, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	decoder := gob.NewDecoder(f)
+
+	version, err := readCacheHeader(decoder)
+	if err != nil || version != exportStateVersion {
+		return result, nil
+	}
+
+	err = decoder.Decode(&result)
+	if err != nil {
+		return make(map[string]bridgeExportState), nil
+	}
+
+	return result, nil
+}
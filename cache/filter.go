@@ -1,6 +1,8 @@
 package cache
 
 import (
+	"path"
+	"regexp"
 	"strings"
 
 	"github.com/MichaelMure/git-bug/bug"
@@ -12,6 +14,8 @@ import (
 // This exist mainly to go through the functions of the cache with proper locking.
 type resolver interface {
 	ResolveIdentityExcerpt(id entity.Id) (*IdentityExcerpt, error)
+	ResolveTeamExcerpt(handle string) (*IdentityExcerpt, error)
+	searchBugs(text string) map[entity.Id]int
 }
 
 // Filter is a predicate that match a subset of bugs
@@ -24,7 +28,31 @@ func StatusFilter(status bug.Status) Filter {
 	}
 }
 
-// AuthorFilter return a Filter that match a bug author
+// ExtendedStatusFilter return a Filter that match a repository defined status
+func ExtendedStatusFilter(name string) Filter {
+	return func(excerpt *BugExcerpt, resolver resolver) bool {
+		return excerpt.ExtendedStatus == name
+	}
+}
+
+// SearchFilter return a Filter that match the bugs containing all the words
+// of the text, using the full-text index
+func SearchFilter(text string) Filter {
+	// the index is queried once for all the bugs
+	var matching map[entity.Id]int
+	return func(excerpt *BugExcerpt, resolver resolver) bool {
+		if matching == nil {
+			matching = resolver.searchBugs(text)
+			if matching == nil {
+				matching = make(map[entity.Id]int)
+			}
+		}
+		_, ok := matching[excerpt.Id]
+		return ok
+	}
+}
+
+// AuthorFilter return a Filter that match a bug author or co-author
 func AuthorFilter(query string) Filter {
 	return func(excerpt *BugExcerpt, resolver resolver) bool {
 		query = strings.ToLower(query)
@@ -34,17 +62,38 @@ func AuthorFilter(query string) Filter {
 			panic(err)
 		}
 
-		return author.Match(query)
+		if author.Match(query) {
+			return true
+		}
+
+		for _, id := range excerpt.CoAuthorIds {
+			coAuthor, err := resolver.ResolveIdentityExcerpt(id)
+			if err != nil {
+				panic(err)
+			}
+
+			if coAuthor.Match(query) {
+				return true
+			}
+		}
+		return false
 	}
 }
 
-// LabelFilter return a Filter that match a label
+// LabelFilter return a Filter that match a label, or a glob pattern of
+// labels like prio/*
 func LabelFilter(label string) Filter {
+	glob := strings.ContainsAny(label, `*?[\`)
 	return func(excerpt *BugExcerpt, resolver resolver) bool {
 		for _, l := range excerpt.Labels {
-			if string(l) == label {
+			if !glob && string(l) == label {
 				return true
 			}
+			if glob {
+				if match, _ := path.Match(label, string(l)); match {
+					return true
+				}
+			}
 		}
 		return false
 	}
@@ -88,6 +137,94 @@ func ParticipantFilter(query string) Filter {
 	}
 }
 
+// SubscriberFilter return a Filter that match a bug subscriber
+func SubscriberFilter(query string) Filter {
+	return func(excerpt *BugExcerpt, resolver resolver) bool {
+		query = strings.ToLower(query)
+
+		for _, id := range excerpt.Subscribers {
+			identityExcerpt, err := resolver.ResolveIdentityExcerpt(id)
+			if err != nil {
+				panic(err)
+			}
+
+			if identityExcerpt.Match(query) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// AssigneeFilter return a Filter that match a bug assignee. A query of the
+// form @handle match the identity with exactly this login, and for a team the
+// bugs assigned to one of its members as well.
+func AssigneeFilter(query string) Filter {
+	if strings.HasPrefix(query, "@") {
+		return assigneeHandleFilter(strings.TrimPrefix(query, "@"))
+	}
+
+	return func(excerpt *BugExcerpt, resolver resolver) bool {
+		query = strings.ToLower(query)
+
+		for _, id := range excerpt.AssigneeIds {
+			identityExcerpt, err := resolver.ResolveIdentityExcerpt(id)
+			if err != nil {
+				panic(err)
+			}
+
+			if identityExcerpt.Match(query) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+func assigneeHandleFilter(handle string) Filter {
+	return func(excerpt *BugExcerpt, resolver resolver) bool {
+		team, err := resolver.ResolveTeamExcerpt(handle)
+		if err != nil {
+			team = nil
+		}
+
+		for _, id := range excerpt.AssigneeIds {
+			identityExcerpt, err := resolver.ResolveIdentityExcerpt(id)
+			if err != nil {
+				panic(err)
+			}
+
+			if strings.EqualFold(identityExcerpt.Login, handle) {
+				return true
+			}
+			if team != nil && team.IsMember(id) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// MilestoneFilter return a Filter that match the milestone of a bug
+func MilestoneFilter(milestone string) Filter {
+	return func(excerpt *BugExcerpt, resolver resolver) bool {
+		return excerpt.Milestone == milestone
+	}
+}
+
+// PriorityFilter return a Filter that compare the priority of a bug with a
+// value, following the definition of the priority field if any. The bugs
+// without a priority never match.
+func PriorityFilter(filter query.PriorityFilter, definition bug.FieldDefinition) Filter {
+	return func(excerpt *BugExcerpt, resolver resolver) bool {
+		value, ok := excerpt.Fields[PriorityField]
+		if !ok || value == "" {
+			return false
+		}
+		return filter.Comparison.Compare(comparePriority(definition, value, filter.Value), 0)
+	}
+}
+
 // TitleFilter return a Filter that match if the title contains the given query
 func TitleFilter(query string) Filter {
 	return func(excerpt *BugExcerpt, resolver resolver) bool {
@@ -98,6 +235,94 @@ func TitleFilter(query string) Filter {
 	}
 }
 
+// TitleRegexpFilter return a Filter that match the title with a regular
+// expression. An invalid expression match nothing.
+func TitleRegexpFilter(pattern string) Filter {
+	re, err := regexp.Compile(pattern)
+	return func(excerpt *BugExcerpt, resolver resolver) bool {
+		return err == nil && re.MatchString(excerpt.Title)
+	}
+}
+
+// FieldFilter return a Filter that match a custom field value, ignoring the
+// case
+func FieldFilter(name string, value string) Filter {
+	return func(excerpt *BugExcerpt, resolver resolver) bool {
+		return strings.EqualFold(excerpt.Fields[name], value)
+	}
+}
+
+// MetadataFilter return a Filter that match a metadata of the bug creation
+func MetadataFilter(key string, value string) Filter {
+	return func(excerpt *BugExcerpt, resolver resolver) bool {
+		v, ok := excerpt.CreateMetadata[key]
+		return ok && v == value
+	}
+}
+
+// ExportedFilter return a Filter that match the bugs existing on the remote
+// of a bridge target, either exported or imported, as the bridges record the
+// id of the bug on the remote in the "<target>-id" metadata.
+func ExportedFilter(target string) Filter {
+	key := target + "-id"
+	return func(excerpt *BugExcerpt, resolver resolver) bool {
+		_, ok := excerpt.CreateMetadata[key]
+		return ok
+	}
+}
+
+// DateFilter return a Filter that match the creation or last edition time
+// of a bug against a limit
+func DateFilter(filter query.DateFilter) Filter {
+	limit := filter.Time.Unix()
+	return func(excerpt *BugExcerpt, resolver resolver) bool {
+		value := excerpt.CreateUnixTime
+		if filter.Field == query.DateEdited {
+			value = excerpt.EditUnixTime
+		}
+
+		if filter.Before {
+			return value < limit
+		}
+		return value > limit
+	}
+}
+
+// CountFilter return a Filter that compare a count of a bug, like the number
+// of comments, with a value
+func CountFilter(filter query.CountFilter) Filter {
+	return func(excerpt *BugExcerpt, resolver resolver) bool {
+		var count int
+		switch filter.Counter {
+		case query.CountComments:
+			// the first comment is the bug description
+			count = excerpt.LenComments - 1
+		case query.CountParticipants:
+			count = len(excerpt.Participants)
+		case query.CountActors:
+			count = len(excerpt.Actors)
+		default:
+			return false
+		}
+
+		return filter.Comparison.Compare(count, filter.Value)
+	}
+}
+
+// ChecklistFilter return a Filter that match the progress of the checklists
+func ChecklistFilter(status query.ChecklistStatus) Filter {
+	return func(excerpt *BugExcerpt, resolver resolver) bool {
+		switch status {
+		case query.ChecklistIncomplete:
+			return excerpt.ChecklistDone < excerpt.ChecklistTotal
+		case query.ChecklistComplete:
+			return excerpt.ChecklistTotal > 0 && excerpt.ChecklistDone == excerpt.ChecklistTotal
+		default:
+			return false
+		}
+	}
+}
+
 // NoLabelFilter return a Filter that match the absence of labels
 func NoLabelFilter() Filter {
 	return func(excerpt *BugExcerpt, resolver resolver) bool {
@@ -105,25 +330,92 @@ func NoLabelFilter() Filter {
 	}
 }
 
+// NoAssigneeFilter return a Filter that match the absence of assignee
+func NoAssigneeFilter() Filter {
+	return func(excerpt *BugExcerpt, resolver resolver) bool {
+		return len(excerpt.AssigneeIds) == 0
+	}
+}
+
+// NoMilestoneFilter return a Filter that match the absence of milestone
+func NoMilestoneFilter() Filter {
+	return func(excerpt *BugExcerpt, resolver resolver) bool {
+		return excerpt.Milestone == ""
+	}
+}
+
+// NotMergedDuplicateFilter return a Filter that exclude the bugs closed as duplicate
+func NotMergedDuplicateFilter() Filter {
+	return func(excerpt *BugExcerpt, resolver resolver) bool {
+		return !excerpt.IsMergedDuplicate()
+	}
+}
+
 // Matcher is a collection of Filter that implement a complex filter
 type Matcher struct {
 	Status      []Filter
 	Author      []Filter
 	Actor       []Filter
 	Participant []Filter
+	Subscriber  []Filter
+	Assignee    []Filter
+	Milestone   []Filter
 	Label       []Filter
+	LabelAny    []Filter
 	Title       []Filter
+	Field       []Filter
+	Metadata    []Filter
+	Exported    []Filter
+	Checklist   []Filter
+	Search      []Filter
+	Date        []Filter
+	Count       []Filter
+	Priority    []Filter
 	NoFilters   []Filter
+	Duplicates  []Filter
+	// the bugs matching any of the negated filters are excluded
+	Not *Matcher
+}
+
+// MeValue is the value of the assignee qualifier designating the user
+const MeValue = "me"
+
+// resolveMe replace the "me" assignee by the id of the given identity, if any
+func resolveMe(filters query.Filters, me entity.Id) query.Filters {
+	if me == "" {
+		return filters
+	}
+
+	if len(filters.Assignee) > 0 {
+		assignee := make([]string, len(filters.Assignee))
+		for i, value := range filters.Assignee {
+			if value == MeValue {
+				value = me.String()
+			}
+			assignee[i] = value
+		}
+		filters.Assignee = assignee
+	}
+
+	if filters.Not != nil {
+		not := resolveMe(*filters.Not, me)
+		filters.Not = &not
+	}
+
+	return filters
 }
 
 // compileMatcher transform a query.Filters into a specialized matcher
-// for the cache.
-func compileMatcher(filters query.Filters) *Matcher {
+// for the cache. The schema give the order of the priorities.
+func compileMatcher(filters query.Filters, schema bug.FieldSchema) *Matcher {
 	result := &Matcher{}
 
 	for _, value := range filters.Status {
 		result.Status = append(result.Status, StatusFilter(value))
 	}
+	for _, value := range filters.ExtendedStatus {
+		result.Status = append(result.Status, ExtendedStatusFilter(value))
+	}
 	for _, value := range filters.Author {
 		result.Author = append(result.Author, AuthorFilter(value))
 	}
@@ -133,12 +425,66 @@ func compileMatcher(filters query.Filters) *Matcher {
 	for _, value := range filters.Participant {
 		result.Participant = append(result.Participant, ParticipantFilter(value))
 	}
+	for _, value := range filters.Subscriber {
+		result.Subscriber = append(result.Subscriber, SubscriberFilter(value))
+	}
+	for _, value := range filters.Assignee {
+		result.Assignee = append(result.Assignee, AssigneeFilter(value))
+	}
+	for _, value := range filters.Milestone {
+		result.Milestone = append(result.Milestone, MilestoneFilter(value))
+	}
 	for _, value := range filters.Label {
 		result.Label = append(result.Label, LabelFilter(value))
 	}
+	for _, value := range filters.LabelAny {
+		result.LabelAny = append(result.LabelAny, LabelFilter(value))
+	}
 	for _, value := range filters.Title {
 		result.Title = append(result.Title, TitleFilter(value))
 	}
+	for _, value := range filters.TitleRegexp {
+		result.Title = append(result.Title, TitleRegexpFilter(value))
+	}
+	for _, value := range filters.Field {
+		result.Field = append(result.Field, FieldFilter(value.Name, value.Value))
+	}
+	for _, value := range filters.Metadata {
+		result.Metadata = append(result.Metadata, MetadataFilter(value.Key, value.Value))
+	}
+	for _, value := range filters.ExportedTo {
+		result.Exported = append(result.Exported, ExportedFilter(value))
+	}
+	for _, value := range filters.Checklist {
+		result.Checklist = append(result.Checklist, ChecklistFilter(value))
+	}
+	for _, value := range filters.Search {
+		result.Search = append(result.Search, SearchFilter(value))
+	}
+	for _, value := range filters.Date {
+		result.Date = append(result.Date, DateFilter(value))
+	}
+	for _, value := range filters.Count {
+		result.Count = append(result.Count, CountFilter(value))
+	}
+	for _, value := range filters.Priority {
+		result.Priority = append(result.Priority, PriorityFilter(value, schema[PriorityField]))
+	}
+	if filters.NoLabel {
+		result.NoFilters = append(result.NoFilters, NoLabelFilter())
+	}
+	if filters.NoAssignee {
+		result.NoFilters = append(result.NoFilters, NoAssigneeFilter())
+	}
+	if filters.NoMilestone {
+		result.NoFilters = append(result.NoFilters, NoMilestoneFilter())
+	}
+	if !filters.WithDuplicates {
+		result.Duplicates = append(result.Duplicates, NotMergedDuplicateFilter())
+	}
+	if filters.Not != nil {
+		result.Not = compileMatcher(*filters.Not, schema)
+	}
 
 	return result
 }
@@ -161,10 +507,26 @@ func (f *Matcher) Match(excerpt *BugExcerpt, resolver resolver) bool {
 		return false
 	}
 
+	if match := f.orMatch(f.Subscriber, excerpt, resolver); !match {
+		return false
+	}
+
+	if match := f.orMatch(f.Assignee, excerpt, resolver); !match {
+		return false
+	}
+
+	if match := f.orMatch(f.Milestone, excerpt, resolver); !match {
+		return false
+	}
+
 	if match := f.andMatch(f.Label, excerpt, resolver); !match {
 		return false
 	}
 
+	if match := f.orMatch(f.LabelAny, excerpt, resolver); !match {
+		return false
+	}
+
 	if match := f.andMatch(f.NoFilters, excerpt, resolver); !match {
 		return false
 	}
@@ -173,9 +535,70 @@ func (f *Matcher) Match(excerpt *BugExcerpt, resolver resolver) bool {
 		return false
 	}
 
+	if match := f.andMatch(f.Field, excerpt, resolver); !match {
+		return false
+	}
+
+	if match := f.andMatch(f.Metadata, excerpt, resolver); !match {
+		return false
+	}
+
+	if match := f.andMatch(f.Exported, excerpt, resolver); !match {
+		return false
+	}
+
+	if match := f.orMatch(f.Checklist, excerpt, resolver); !match {
+		return false
+	}
+
+	if match := f.andMatch(f.Search, excerpt, resolver); !match {
+		return false
+	}
+
+	if match := f.andMatch(f.Date, excerpt, resolver); !match {
+		return false
+	}
+
+	if match := f.andMatch(f.Count, excerpt, resolver); !match {
+		return false
+	}
+
+	if match := f.andMatch(f.Priority, excerpt, resolver); !match {
+		return false
+	}
+
+	if match := f.andMatch(f.Duplicates, excerpt, resolver); !match {
+		return false
+	}
+
+	if f.Not != nil && f.Not.anyMatch(excerpt, resolver) {
+		return false
+	}
+
 	return true
 }
 
+// Check if any filter, of any kind, match the bug. This is used for the
+// negated filters, where each filter exclude bugs on its own.
+func (f *Matcher) anyMatch(excerpt *BugExcerpt, resolver resolver) bool {
+	groups := [][]Filter{
+		f.Status, f.Author, f.Actor, f.Participant, f.Subscriber, f.Assignee,
+		f.Milestone, f.Label, f.LabelAny, f.Title, f.Field, f.Metadata,
+		f.Exported, f.Checklist, f.Search, f.Date, f.Count, f.Priority,
+		f.NoFilters,
+	}
+
+	for _, group := range groups {
+		for _, filter := range group {
+			if filter(excerpt, resolver) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
 // Check if any of the filters provided match the bug
 func (*Matcher) orMatch(filters []Filter, excerpt *BugExcerpt, resolver resolver) bool {
 	if len(filters) == 0 {
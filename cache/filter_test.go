@@ -2,8 +2,13 @@ package cache
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/query"
 )
 
 func TestTitleFilter(t *testing.T) {
@@ -32,3 +37,170 @@ func TestTitleFilter(t *testing.T) {
 		})
 	}
 }
+
+func TestDateFilter(t *testing.T) {
+	limit := time.Date(2020, 1, 31, 0, 0, 0, 0, time.UTC)
+	excerpt := &BugExcerpt{
+		CreateUnixTime: limit.Add(-24 * time.Hour).Unix(),
+		EditUnixTime:   limit.Add(24 * time.Hour).Unix(),
+	}
+
+	tests := []struct {
+		name   string
+		filter query.DateFilter
+		match  bool
+	}{
+		{name: "created before", filter: query.DateFilter{Field: query.DateCreated, Before: true, Time: limit}, match: true},
+		{name: "created after", filter: query.DateFilter{Field: query.DateCreated, Time: limit}, match: false},
+		{name: "edited before", filter: query.DateFilter{Field: query.DateEdited, Before: true, Time: limit}, match: false},
+		{name: "edited after", filter: query.DateFilter{Field: query.DateEdited, Time: limit}, match: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.match, DateFilter(tt.filter)(excerpt, nil))
+		})
+	}
+}
+
+func TestMetadataFilter(t *testing.T) {
+	excerpt := &BugExcerpt{CreateMetadata: map[string]string{"origin": "gitlab"}}
+
+	assert.True(t, MetadataFilter("origin", "gitlab")(excerpt, nil))
+	assert.False(t, MetadataFilter("origin", "github")(excerpt, nil))
+	assert.False(t, MetadataFilter("gitlab-id", "gitlab")(excerpt, nil))
+}
+
+func TestFieldFilter(t *testing.T) {
+	excerpt := &BugExcerpt{Fields: map[string]string{"env": "Prod"}}
+
+	assert.True(t, FieldFilter("env", "Prod")(excerpt, nil))
+	assert.True(t, FieldFilter("env", "prod")(excerpt, nil))
+	assert.True(t, FieldFilter("env", "PROD")(excerpt, nil))
+	assert.False(t, FieldFilter("env", "staging")(excerpt, nil))
+	assert.False(t, FieldFilter("version", "prod")(excerpt, nil))
+}
+
+func TestLabelFilter(t *testing.T) {
+	excerpt := &BugExcerpt{Labels: []bug.Label{"prio/high", "bug"}}
+
+	assert.True(t, LabelFilter("bug")(excerpt, nil))
+	assert.False(t, LabelFilter("bu")(excerpt, nil))
+	assert.True(t, LabelFilter("prio/*")(excerpt, nil))
+	assert.True(t, LabelFilter("prio/h?gh")(excerpt, nil))
+	assert.False(t, LabelFilter("prio/low*")(excerpt, nil))
+	assert.False(t, LabelFilter("*")(&BugExcerpt{}, nil))
+}
+
+func TestTitleRegexpFilter(t *testing.T) {
+	excerpt := &BugExcerpt{Title: "Crash on start"}
+
+	assert.True(t, TitleRegexpFilter("^Crash")(excerpt, nil))
+	assert.False(t, TitleRegexpFilter("^crash")(excerpt, nil))
+	assert.True(t, TitleRegexpFilter("(?i)^crash")(excerpt, nil))
+	assert.False(t, TitleRegexpFilter("crash(")(excerpt, nil))
+}
+
+func TestMatcherAnyAndNegation(t *testing.T) {
+	bugUi := &BugExcerpt{Labels: []bug.Label{"bug", "ui"}}
+	bugOnly := &BugExcerpt{Labels: []bug.Label{"bug"}}
+	noLabel := &BugExcerpt{}
+
+	tests := []struct {
+		name    string
+		filters query.Filters
+		match   []bool
+	}{
+		{name: "all labels", filters: query.Filters{Label: []string{"bug", "ui"}}, match: []bool{true, false, false}},
+		{name: "any label", filters: query.Filters{LabelAny: []string{"ui", "bug"}}, match: []bool{true, true, false}},
+		{name: "negated label", filters: query.Filters{Not: &query.Filters{Label: []string{"ui"}}}, match: []bool{false, true, true}},
+		{name: "negated labels", filters: query.Filters{Not: &query.Filters{Label: []string{"ui", "wontfix"}}}, match: []bool{false, true, true}},
+		{name: "no label", filters: query.Filters{NoLabel: true}, match: []bool{false, false, true}},
+		{name: "negated no label", filters: query.Filters{Not: &query.Filters{NoLabel: true}}, match: []bool{true, true, false}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matcher := compileMatcher(tt.filters, nil)
+			for i, excerpt := range []*BugExcerpt{bugUi, bugOnly, noLabel} {
+				assert.Equal(t, tt.match[i], matcher.Match(excerpt, nil), "excerpt %d", i)
+			}
+		})
+	}
+}
+
+func TestExportedFilter(t *testing.T) {
+	exported := &BugExcerpt{CreateMetadata: map[string]string{"github-id": "MDU6SXNzdWU="}}
+	local := &BugExcerpt{}
+
+	assert.True(t, ExportedFilter("github")(exported, nil))
+	assert.False(t, ExportedFilter("gitlab")(exported, nil))
+	assert.False(t, ExportedFilter("github")(local, nil))
+
+	notExported := compileMatcher(query.Filters{Not: &query.Filters{ExportedTo: []string{"github"}}}, nil)
+	assert.False(t, notExported.Match(exported, nil))
+	assert.True(t, notExported.Match(local, nil))
+}
+
+func TestCountFilter(t *testing.T) {
+	excerpt := &BugExcerpt{
+		LenComments:  12,
+		Participants: []entity.Id{"a", "b"},
+		Actors:       []entity.Id{"a"},
+	}
+
+	assert.True(t, CountFilter(query.CountFilter{Counter: query.CountComments, Comparison: query.Greater, Value: 10})(excerpt, nil))
+	assert.False(t, CountFilter(query.CountFilter{Counter: query.CountComments, Comparison: query.Greater, Value: 11})(excerpt, nil))
+	assert.True(t, CountFilter(query.CountFilter{Counter: query.CountParticipants, Comparison: query.LessOrEqual, Value: 2})(excerpt, nil))
+	assert.True(t, CountFilter(query.CountFilter{Counter: query.CountActors, Comparison: query.Equal, Value: 1})(excerpt, nil))
+	assert.False(t, CountFilter(query.CountFilter{Counter: query.CountActors, Comparison: query.GreaterOrEqual, Value: 2})(excerpt, nil))
+}
+
+func TestPriorityFilter(t *testing.T) {
+	def := bug.FieldDefinition{Name: PriorityField, Type: bug.FieldEnum, Values: []string{"P0", "P1", "P2", "P3"}}
+	p1 := &BugExcerpt{Fields: map[string]string{PriorityField: "P1"}}
+	p3 := &BugExcerpt{Fields: map[string]string{PriorityField: "P3"}}
+	none := &BugExcerpt{}
+
+	atLeastP2 := PriorityFilter(query.PriorityFilter{Comparison: query.GreaterOrEqual, Value: "P2"}, def)
+	assert.False(t, atLeastP2(p1, nil))
+	assert.True(t, atLeastP2(p3, nil))
+	assert.False(t, atLeastP2(none, nil))
+
+	exactly := PriorityFilter(query.PriorityFilter{Comparison: query.Equal, Value: "P1"}, def)
+	assert.True(t, exactly(p1, nil))
+	assert.False(t, exactly(p3, nil))
+
+	// without a definition, the priorities are compared alphabetically
+	alpha := PriorityFilter(query.PriorityFilter{Comparison: query.Less, Value: "P2"}, bug.FieldDefinition{})
+	assert.True(t, alpha(p1, nil))
+	assert.False(t, alpha(p3, nil))
+}
+
+func TestMilestoneAndNoAssignee(t *testing.T) {
+	planned := &BugExcerpt{Milestone: "v1.0", AssigneeIds: []entity.Id{"a"}}
+	unplanned := &BugExcerpt{}
+
+	assert.True(t, MilestoneFilter("v1.0")(planned, nil))
+	assert.False(t, MilestoneFilter("v1.0")(unplanned, nil))
+	assert.False(t, NoMilestoneFilter()(planned, nil))
+	assert.True(t, NoMilestoneFilter()(unplanned, nil))
+	assert.False(t, NoAssigneeFilter()(planned, nil))
+	assert.True(t, NoAssigneeFilter()(unplanned, nil))
+}
+
+func TestResolveMe(t *testing.T) {
+	filters := query.Filters{
+		Assignee: []string{"me", "rene"},
+		Not:      &query.Filters{Assignee: []string{"me"}},
+	}
+
+	resolved := resolveMe(filters, "abcdef")
+	assert.Equal(t, []string{"abcdef", "rene"}, resolved.Assignee)
+	assert.Equal(t, []string{"abcdef"}, resolved.Not.Assignee)
+
+	// the original filters are untouched
+	assert.Equal(t, []string{"me", "rene"}, filters.Assignee)
+	assert.Equal(t, []string{"me"}, filters.Not.Assignee)
+
+	// without user, "me" is a regular query
+	assert.Equal(t, filters, resolveMe(filters, ""))
+}
@@ -0,0 +1,115 @@
+package cache
+
+import (
+	"encoding/gob"
+	"fmt"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+// cacheMagic is written at the beginning of the cache files to recognize them
+const cacheMagic = "git-bug-cache"
+
+// cacheHeader is the first value encoded in a cache file, followed by the
+// actual data. It allow to know the format of the data before decoding it.
+type cacheHeader struct {
+	Magic   string
+	Version uint
+}
+
+// ErrFormatVersion is returned when a cache file has a format that can't be
+// used or migrated.
+type ErrFormatVersion struct {
+	File     string
+	Found    uint
+	Expected uint
+}
+
+func (e *ErrFormatVersion) Error() string {
+	if e.Found == 0 {
+		return fmt.Sprintf("the %s file has an unknown format", e.File)
+	}
+	return fmt.Sprintf("the %s file has the format version %d, expected %d",
+		e.File, e.Found, e.Expected)
+}
+
+func writeCacheHeader(encoder *gob.Encoder, version uint) error {
+	return encoder.Encode(cacheHeader{
+		Magic:   cacheMagic,
+		Version: version,
+	})
+}
+
+// readCacheHeader decode the header of a cache file and return the format
+// version. Files written before the header existed have the version 0.
+func readCacheHeader(decoder *gob.Decoder) (uint, error) {
+	var header cacheHeader
+
+	err := decoder.Decode(&header)
+	if err != nil {
+		return 0, err
+	}
+
+	if header.Magic != cacheMagic {
+		return 0, nil
+	}
+
+	return header.Version, nil
+}
+
+// bugExcerptMigrations upgrade the bug excerpts from the previous format
+// version to the version they are registered for. The excerpts have been
+// decoded with the current BugExcerpt, so the new fields are zero valued and
+// need to be filled.
+var bugExcerptMigrations = map[uint]func(repo repository.ClockedRepo, excerpts map[entity.Id]*BugExcerpt) error{
+	// bugs written before the planning operations existed have no assignee,
+	// milestone or due date, the zero values are correct.
+	12: func(repo repository.ClockedRepo, excerpts map[entity.Id]*BugExcerpt) error {
+		return nil
+	},
+	// the bug excerpts are unchanged. The identities written before the teams
+	// existed are neither teams nor members, so the identity excerpts loaded
+	// as is are correct too.
+	13: func(repo repository.ClockedRepo, excerpts map[entity.Id]*BugExcerpt) error {
+		return nil
+	},
+	// the time spent per identity is only known by compiling the bugs
+	14: func(repo repository.ClockedRepo, excerpts map[entity.Id]*BugExcerpt) error {
+		for id, excerpt := range excerpts {
+			b, err := bug.ReadLocal(repo, id)
+			if err != nil {
+				return err
+			}
+			excerpt.TimeSpent = b.Compile().TimeSpentBy
+		}
+		return nil
+	},
+}
+
+// canMigrate tell if the cache files can be migrated from the given
+// format version to the current one
+func canMigrate(from uint) bool {
+	if from == 0 || from >= formatVersion {
+		return false
+	}
+	for v := from + 1; v <= formatVersion; v++ {
+		if _, ok := bugExcerptMigrations[v]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// migrateBugExcerpts apply in order the migrations needed to upgrade the bug
+// excerpts to the current format version
+func migrateBugExcerpts(repo repository.ClockedRepo, from uint, excerpts map[entity.Id]*BugExcerpt) error {
+	for v := from + 1; v <= formatVersion; v++ {
+		err := bugExcerptMigrations[v](repo, excerpts)
+		if err != nil {
+			return fmt.Errorf("migration to the cache format version %d: %v", v, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,221 @@
+package cache
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/identity"
+)
+
+// FsckIssue is a problem found when verifying the integrity of a repository
+type FsckIssue struct {
+	// the kind of entity: "identities" or "bugs"
+	Target  string
+	Id      entity.Id
+	Message string
+	// true if the issue has been repaired
+	Repaired bool
+}
+
+func (i FsckIssue) String() string {
+	status := ""
+	if i.Repaired {
+		status = " (repaired)"
+	}
+	return fmt.Sprintf("%s %s: %s%s", i.Target, i.Id.Human(), i.Message, status)
+}
+
+// Fsck read again all the entities from git and verify their integrity:
+// that they can be read and are valid, that the signatures of their commits
+// are not bad, that the operations don't reference missing operations, and
+// that the cache is consistent with the data. If repair is true, the issues
+// that can be fixed, that is the inconsistencies of the cache, are repaired.
+func (c *RepoCache) Fsck(repair bool) ([]FsckIssue, error) {
+	var issues []FsckIssue
+
+	identityIssues, err := c.fsckIdentities()
+	if err != nil {
+		return nil, err
+	}
+	issues = append(issues, identityIssues...)
+
+	bugIssues, err := c.fsckBugs()
+	if err != nil {
+		return nil, err
+	}
+	issues = append(issues, bugIssues...)
+
+	cacheInconsistent := false
+	for _, issue := range issues {
+		if issue.Message == fsckCacheIssue {
+			cacheInconsistent = true
+		}
+	}
+
+	if repair && cacheInconsistent {
+		err = c.Rebuild()
+		if err != nil {
+			return issues, err
+		}
+		for i := range issues {
+			if issues[i].Message == fsckCacheIssue {
+				issues[i].Repaired = true
+			}
+		}
+	}
+
+	return issues, nil
+}
+
+const fsckCacheIssue = "the cache is inconsistent with the data"
+
+func (c *RepoCache) fsckIdentities() ([]FsckIssue, error) {
+	var issues []FsckIssue
+	report := func(id entity.Id, format string, args ...interface{}) {
+		issues = append(issues, FsckIssue{Target: "identities", Id: id, Message: fmt.Sprintf(format, args...)})
+	}
+
+	tips, err := identity.ListLocalRefTips(c.repo)
+	if err != nil {
+		return nil, err
+	}
+
+	c.muIdentity.RLock()
+	defer c.muIdentity.RUnlock()
+
+	ids := make([]entity.Id, 0, len(tips))
+	for id := range tips {
+		ids = append(ids, id)
+	}
+	sort.Sort(entity.Alphabetical(ids))
+
+	for _, id := range ids {
+		i, err := identity.ReadLocal(c.repo, id)
+		if err != nil {
+			report(id, "can't be read: %v", err)
+			continue
+		}
+
+		if err := i.Validate(); err != nil {
+			report(id, "invalid: %v", err)
+		}
+
+		if err := i.VerifyKeyChain(c.repo); err != nil {
+			report(id, "untrusted keys: %v", err)
+		}
+
+		if _, ok := c.identitiesExcerpts[id]; !ok {
+			report(id, fsckCacheIssue)
+		}
+	}
+
+	for id := range c.identitiesExcerpts {
+		if _, ok := tips[id]; !ok {
+			report(id, fsckCacheIssue)
+		}
+	}
+
+	return issues, nil
+}
+
+func (c *RepoCache) fsckBugs() ([]FsckIssue, error) {
+	var issues []FsckIssue
+	report := func(id entity.Id, format string, args ...interface{}) {
+		issues = append(issues, FsckIssue{Target: "bugs", Id: id, Message: fmt.Sprintf(format, args...)})
+	}
+
+	ids, err := bug.ListLocalIds(c.repo)
+	if err != nil {
+		return nil, err
+	}
+	sort.Sort(entity.Alphabetical(ids))
+
+	built := make(map[entity.Id]builtBug, len(ids))
+	for b := range c.buildBugs(ids) {
+		built[b.id] = b
+	}
+
+	c.muBug.RLock()
+	defer c.muBug.RUnlock()
+
+	for _, id := range ids {
+		b, ok := built[id]
+		if !ok {
+			continue
+		}
+
+		switch {
+		// confidential bugs we can't read can't be verified
+		case b.err == bug.ErrBugEncrypted:
+			continue
+		case b.err != nil:
+			report(id, "can't be read: %v", b.err)
+			continue
+		}
+
+		if err := b.bug.Validate(); err != nil {
+			report(id, "invalid: %v", err)
+		}
+
+		if err := b.bug.VerifySignatures(c.repo); err != nil {
+			report(id, "bad signature: %v", err)
+		}
+
+		for _, dangling := range danglingTargets(&b.snap) {
+			report(id, "operation %s reference the missing operation %s",
+				dangling[0].Human(), dangling[1].Human())
+		}
+
+		excerpt, ok := c.bugExcerpts[id]
+		if !ok || excerpt.EditLamportTime != b.bug.EditLamportTime() {
+			report(id, fsckCacheIssue)
+		}
+	}
+
+	for id := range c.bugExcerpts {
+		if _, ok := built[id]; !ok {
+			report(id, fsckCacheIssue)
+		}
+	}
+
+	return issues, nil
+}
+
+// danglingTargets return the pairs of (operation, target) for the operations
+// referencing an operation that doesn't exist in the bug
+func danglingTargets(snap *bug.Snapshot) [][2]entity.Id {
+	ids := make(map[entity.Id]struct{}, len(snap.Operations))
+	for _, op := range snap.Operations {
+		ids[op.Id()] = struct{}{}
+	}
+
+	var result [][2]entity.Id
+	for _, op := range snap.Operations {
+		var target entity.Id
+
+		switch op := op.(type) {
+		case *bug.EditCommentOperation:
+			target = op.Target
+		case *bug.MinimizeCommentOperation:
+			target = op.Target
+		case *bug.PinCommentOperation:
+			target = op.Target
+		case *bug.RedactOperation:
+			target = op.Target
+		case *bug.SetChecklistItemOperation:
+			target = op.Target
+		case *bug.SetMetadataOperation:
+			target = op.Target
+		default:
+			continue
+		}
+
+		if _, ok := ids[target]; !ok {
+			result = append(result, [2]entity.Id{op.Id(), target})
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,248 @@
+package cache
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/MichaelMure/git-bug/entity"
+)
+
+// idTree is a radix tree of entity ids, allowing to resolve an id prefix and
+// to compute the shortest unique prefix of an id without scanning all of them.
+// It's not safe for concurrent use, the caller is expected to hold the lock
+// of the data it index.
+type idTree struct {
+	root idTreeNode
+}
+
+type idTreeNode struct {
+	// the part of the id between the parent node and this node
+	label string
+	// children, sorted by the first byte of their label
+	children []*idTreeNode
+	// true if an id end at this node
+	leaf bool
+	// the number of ids in this subtree
+	count int
+}
+
+func newIdTree() *idTree {
+	return &idTree{}
+}
+
+// len return the number of ids in the tree
+func (t *idTree) len() int {
+	return t.root.count
+}
+
+// child return the child whose label start with the given byte and its
+// position, or the position where it should be inserted.
+func (n *idTreeNode) child(b byte) (*idTreeNode, int) {
+	i := sort.Search(len(n.children), func(i int) bool {
+		return n.children[i].label[0] >= b
+	})
+	if i < len(n.children) && n.children[i].label[0] == b {
+		return n.children[i], i
+	}
+	return nil, i
+}
+
+func (n *idTreeNode) insertChild(i int, child *idTreeNode) {
+	n.children = append(n.children, nil)
+	copy(n.children[i+1:], n.children[i:])
+	n.children[i] = child
+}
+
+// contains tell if the id is in the tree
+func (t *idTree) contains(id entity.Id) bool {
+	n := &t.root
+	key := string(id)
+
+	for key != "" {
+		child, _ := n.child(key[0])
+		if child == nil || !strings.HasPrefix(key, child.label) {
+			return false
+		}
+		key = key[len(child.label):]
+		n = child
+	}
+
+	return n.leaf
+}
+
+// insert add an id to the tree, if not already there
+func (t *idTree) insert(id entity.Id) {
+	if t.contains(id) {
+		return
+	}
+
+	n := &t.root
+	key := string(id)
+
+	for {
+		n.count++
+
+		if key == "" {
+			n.leaf = true
+			return
+		}
+
+		child, i := n.child(key[0])
+		if child == nil {
+			n.insertChild(i, &idTreeNode{label: key, leaf: true, count: 1})
+			return
+		}
+
+		common := commonPrefixLen(key, child.label)
+		if common < len(child.label) {
+			// split the edge to make room for the new branch
+			split := &idTreeNode{
+				label:    child.label[:common],
+				children: []*idTreeNode{child},
+				count:    child.count,
+			}
+			child.label = child.label[common:]
+			n.children[i] = split
+			child = split
+		}
+
+		key = key[common:]
+		n = child
+	}
+}
+
+// remove delete an id from the tree, if there
+func (t *idTree) remove(id entity.Id) {
+	if !t.contains(id) {
+		return
+	}
+
+	path := []*idTreeNode{&t.root}
+	n := &t.root
+	key := string(id)
+
+	for key != "" {
+		child, _ := n.child(key[0])
+		key = key[len(child.label):]
+		n = child
+		path = append(path, n)
+	}
+
+	n.leaf = false
+	for _, node := range path {
+		node.count--
+	}
+
+	// prune the empty nodes, and merge the nodes left with a single child
+	for i := len(path) - 1; i > 0; i-- {
+		node, parent := path[i], path[i-1]
+
+		switch {
+		case node.count == 0:
+			_, j := parent.child(node.label[0])
+			parent.children = append(parent.children[:j], parent.children[j+1:]...)
+		case !node.leaf && len(node.children) == 1:
+			only := node.children[0]
+			node.label += only.label
+			node.children = only.children
+			node.leaf = only.leaf
+		}
+	}
+}
+
+// find return the node holding all the ids starting with the prefix, and the
+// part of the id leading to it.
+func (t *idTree) find(prefix string) (*idTreeNode, string) {
+	n := &t.root
+	key := prefix
+	var path strings.Builder
+
+	for key != "" {
+		child, _ := n.child(key[0])
+		if child == nil {
+			return nil, ""
+		}
+		if len(key) < len(child.label) {
+			if !strings.HasPrefix(child.label, key) {
+				return nil, ""
+			}
+		} else if !strings.HasPrefix(key, child.label) {
+			return nil, ""
+		}
+
+		path.WriteString(child.label)
+		if len(key) < len(child.label) {
+			key = ""
+		} else {
+			key = key[len(child.label):]
+		}
+		n = child
+	}
+
+	return n, path.String()
+}
+
+// match return the ids starting with the given prefix, in lexicographic order
+func (t *idTree) match(prefix string) []entity.Id {
+	n, path := t.find(prefix)
+	if n == nil {
+		return nil
+	}
+
+	result := make([]entity.Id, 0, n.count)
+	var walk func(n *idTreeNode, path string)
+	walk = func(n *idTreeNode, path string) {
+		if n.leaf {
+			result = append(result, entity.Id(path))
+		}
+		for _, child := range n.children {
+			walk(child, path+child.label)
+		}
+	}
+	walk(n, path)
+
+	return result
+}
+
+// countPrefix return the number of ids starting with the given prefix
+func (t *idTree) countPrefix(prefix string) int {
+	n, _ := t.find(prefix)
+	if n == nil {
+		return 0
+	}
+	return n.count
+}
+
+// shortestUniquePrefix return the length of the shortest prefix matching
+// only the given id, or 0 if the id is not in the tree.
+func (t *idTree) shortestUniquePrefix(id entity.Id) int {
+	if !t.contains(id) {
+		return 0
+	}
+
+	n := &t.root
+	key := string(id)
+	consumed := 0
+
+	for key != "" {
+		child, _ := n.child(key[0])
+		if child.count == 1 {
+			// the first byte of the edge is enough to tell it apart
+			return consumed + 1
+		}
+		consumed += len(child.label)
+		key = key[len(child.label):]
+		n = child
+	}
+
+	// the id is a prefix of other ids, only the full id is unique
+	return len(id)
+}
+
+func commonPrefixLen(a, b string) int {
+	i := 0
+	for i < len(a) && i < len(b) && a[i] == b[i] {
+		i++
+	}
+	return i
+}
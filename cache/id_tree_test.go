@@ -0,0 +1,60 @@
+package cache
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/MichaelMure/git-bug/entity"
+)
+
+func TestIdTree(t *testing.T) {
+	ids := []entity.Id{
+		"a1b2c3d4e5f60718293a4b5c6d7e8f9012345678",
+		"a1b2c3ffffffffffffffffffffffffffffffffff",
+		"a1b2c3d4e500000000000000000000000000000a",
+		"b000000000000000000000000000000000000000",
+	}
+
+	tree := newIdTree()
+	for _, id := range ids {
+		tree.insert(id)
+	}
+	// inserting twice is a no-op
+	tree.insert(ids[0])
+
+	require.Equal(t, 4, tree.len())
+	for _, id := range ids {
+		require.True(t, tree.contains(id))
+	}
+	require.False(t, tree.contains("a1b2c3"))
+
+	require.Equal(t, []entity.Id{ids[2], ids[0], ids[1]}, tree.match("a1b2c3"))
+	require.Equal(t, []entity.Id{ids[2], ids[0]}, tree.match("a1b2c3d4"))
+	require.Equal(t, []entity.Id{ids[0]}, tree.match("a1b2c3d4e5f"))
+	require.Equal(t, []entity.Id{ids[3]}, tree.match("b"))
+	require.Empty(t, tree.match("c"))
+	require.Empty(t, tree.match("a1b2c3d4e5f7"))
+	require.Len(t, tree.match(""), 4)
+	require.Equal(t, 3, tree.countPrefix("a"))
+
+	require.Equal(t, 11, tree.shortestUniquePrefix(ids[0]))
+	require.Equal(t, 7, tree.shortestUniquePrefix(ids[1]))
+	require.Equal(t, 11, tree.shortestUniquePrefix(ids[2]))
+	require.Equal(t, 1, tree.shortestUniquePrefix(ids[3]))
+	require.Equal(t, 0, tree.shortestUniquePrefix("c000000000000000000000000000000000000000"))
+
+	tree.remove(ids[2])
+	tree.remove(ids[2])
+	require.Equal(t, 3, tree.len())
+	require.False(t, tree.contains(ids[2]))
+	require.Equal(t, 7, tree.shortestUniquePrefix(ids[0]))
+	require.Equal(t, []entity.Id{ids[0], ids[1]}, tree.match("a1b2c3"))
+
+	for _, id := range ids {
+		tree.remove(id)
+	}
+	require.Equal(t, 0, tree.len())
+	require.Empty(t, tree.match(""))
+	require.Empty(t, tree.root.children)
+}
@@ -28,7 +28,21 @@ func (i *IdentityCache) Mutate(f func(identity.Mutator) identity.Mutator) error
 	return i.notifyUpdated()
 }
 
+// Adopt attach the immutable metadata of another identity to this one, see
+// identity.Identity.Adopt
+func (i *IdentityCache) Adopt(other *IdentityCache) error {
+	err := i.Identity.Adopt(other.Identity)
+	if err != nil {
+		return err
+	}
+	return i.notifyUpdated()
+}
+
 func (i *IdentityCache) Commit() error {
+	if i.repoCache.readOnly {
+		return ErrReadOnly
+	}
+
 	err := i.Identity.Commit(i.repoCache.repo)
 	if err != nil {
 		return err
@@ -37,6 +51,10 @@ func (i *IdentityCache) Commit() error {
 }
 
 func (i *IdentityCache) CommitAsNeeded() error {
+	if i.repoCache.readOnly {
+		return ErrReadOnly
+	}
+
 	err := i.Identity.CommitAsNeeded(i.repoCache.repo)
 	if err != nil {
 		return err
@@ -23,6 +23,10 @@ type IdentityExcerpt struct {
 	Name              string
 	Login             string
 	ImmutableMetadata map[string]string
+
+	// for a team
+	Team    bool
+	Members []entity.Id
 }
 
 func NewIdentityExcerpt(i *identity.Identity) *IdentityExcerpt {
@@ -31,6 +35,8 @@ func NewIdentityExcerpt(i *identity.Identity) *IdentityExcerpt {
 		Name:              i.Name(),
 		Login:             i.Login(),
 		ImmutableMetadata: i.ImmutableMetadata(),
+		Team:              i.IsTeam(),
+		Members:           i.Members(),
 	}
 }
 
@@ -56,6 +62,16 @@ func (i *IdentityExcerpt) Match(query string) bool {
 		strings.Contains(strings.ToLower(i.Login), query)
 }
 
+// IsMember return whether an identity is a member of the team
+func (i *IdentityExcerpt) IsMember(id entity.Id) bool {
+	for _, member := range i.Members {
+		if member == id {
+			return true
+		}
+	}
+	return false
+}
+
 /*
  * Sorting
  */
@@ -0,0 +1,79 @@
+package cache
+
+import (
+	"bytes"
+	"encoding/gob"
+	"os"
+	"path"
+	"time"
+
+	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+const lastPullFile = "last-pull"
+
+// 1: original format
+const lastPullVersion = 1
+
+func lastPullFilePath(repo repository.Repo) string {
+	return path.Join(repo.GetPath(), "git-bug", lastPullFile)
+}
+
+// PullRecord describe the bugs changed by the last merge from a remote, so
+// that the changes can be shown afterward
+type PullRecord struct {
+	Remote string
+	Time   time.Time
+	// the bugs that didn't exist before the merge
+	New []entity.Id
+	// the state before the merge of the bugs that have been updated
+	Updated map[entity.Id]*BugExcerpt
+}
+
+// LastPull return the record of the last merge from a remote that changed
+// some bugs, or nil if there is none
+func (c *RepoCache) LastPull() (*PullRecord, error) {
+	f, err := os.Open(lastPullFilePath(c.repo))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	decoder := gob.NewDecoder(f)
+
+	version, err := readCacheHeader(decoder)
+	if err != nil || version != lastPullVersion {
+		return nil, nil
+	}
+
+	var record PullRecord
+	err = decoder.Decode(&record)
+	if err != nil {
+		// an outdated format of the excerpts
+		return nil, nil
+	}
+
+	return &record, nil
+}
+
+func (c *RepoCache) writeLastPull(record *PullRecord) error {
+	var data bytes.Buffer
+
+	encoder := gob.NewEncoder(&data)
+
+	err := writeCacheHeader(encoder, lastPullVersion)
+	if err != nil {
+		return err
+	}
+
+	err = encoder.Encode(record)
+	if err != nil {
+		return err
+	}
+
+	return writeFileAtomic(lastPullFilePath(c.repo), data.Bytes())
+}
@@ -0,0 +1,218 @@
+package cache
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/MichaelMure/git-bug/repository"
+	"github.com/MichaelMure/git-bug/util/process"
+)
+
+// The cache of a repository follow a single writer, multiple readers scheme:
+//
+// - a single RepoCache at a time, across all the processes, hold the lock of
+//   the repository and can modify the data and write the cache files,
+// - any number of read-only RepoCache can be opened at the same time without
+//   the lock. They read the cache files written by the writer, or build the
+//   cache in memory only, and can follow its changes with Watch.
+//
+// The lock file is only ever created exclusively, including when a stale one
+// is taken over, so that two writers can't both think they hold the lock.
+// The cache files are always replaced atomically, so that a reader never see
+// a partially written file. A writer can wait for the lock to be released
+// for the duration configured with lockTimeoutConfigKey.
+
+// the maximum time to wait for the lock held by another process, as a
+// duration like "10s", defaultLockTimeout if not configured.
+const lockTimeoutConfigKey = "git-bug.cache.lock-timeout"
+
+// by default, wait for the lock held by another short-lived command
+const defaultLockTimeout = 5 * time.Second
+
+// the interval between two attempts to take the lock
+const lockRetryInterval = 100 * time.Millisecond
+
+// ErrLocked is returned when the repository is locked by another process
+type ErrLocked struct {
+	// the pid of the process holding the lock, 0 if unknown
+	Pid int
+}
+
+func (e *ErrLocked) Error() string {
+	if e.Pid == 0 {
+		return "the repository you want to access is already locked by another process"
+	}
+	return fmt.Sprintf("the repository you want to access is already locked by the process pid %d", e.Pid)
+}
+
+// IsErrLocked tell if the error is an ErrLocked
+func IsErrLocked(err error) bool {
+	_, ok := err.(*ErrLocked)
+	return ok
+}
+
+// readLockTimeout read how long to wait for the lock held by another process
+func readLockTimeout(repo repository.Repo) (time.Duration, error) {
+	raw, err := repo.AnyConfig().ReadString(lockTimeoutConfigKey)
+	switch err {
+	case nil:
+		timeout, err := time.ParseDuration(raw)
+		if err != nil || timeout < 0 {
+			return 0, fmt.Errorf("invalid %s: %s", lockTimeoutConfigKey, raw)
+		}
+		return timeout, nil
+	case repository.ErrNoConfigEntry:
+		return defaultLockTimeout, nil
+	default:
+		return 0, err
+	}
+}
+
+// createLockFile atomically create the lock file holding the pid of the
+// current process. The file is written aside and linked in place, so that
+// the lock file is never seen empty. It fails with an error satisfying
+// os.IsExist if the file already exist.
+func createLockFile(lockPath string) error {
+	tmpPath, err := writeLockTemp(lockPath)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmpPath)
+
+	return os.Link(tmpPath, lockPath)
+}
+
+// acquireLockFile create the lock file holding the pid of the current
+// process, taking over a lock file left by a process not running anymore.
+// It returns an ErrLocked if the lock is held by a running process.
+func acquireLockFile(lockPath string) error {
+	err := createLockFile(lockPath)
+	if !os.IsExist(err) {
+		return err
+	}
+
+	pid, err := readLockFile(lockPath)
+	if os.IsNotExist(err) {
+		// released in between, the caller can retry
+		return &ErrLocked{}
+	}
+	if err != nil {
+		return err
+	}
+
+	// Todo: this will fail if somehow the filesystem is shared with another
+	// computer. Should add a configuration that prevent the cleaning of the
+	// lock file
+
+	if pid != 0 && process.IsRunning(pid) {
+		return &ErrLocked{Pid: pid}
+	}
+
+	return takeOverLockFile(lockPath, pid)
+}
+
+// takeOverLockFile replace a stale lock file left by the process stalePid.
+//
+// Removing the stale file and creating a new one can't be done in a single
+// atomic step, so the takeover is itself protected by a lock file dedicated
+// to this stale pid, acquired exclusively with acquireLockFile. Only one
+// process at a time can replace a given stale lock file, and it does so only
+// if the lock file still hold the stale pid. A process crashing during the
+// takeover leave a stale takeover file, which is taken over the same way.
+func takeOverLockFile(lockPath string, stalePid int) error {
+	takeoverPath := fmt.Sprintf("%s.%d", lockPath, stalePid)
+
+	err := acquireLockFile(takeoverPath)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(takeoverPath)
+
+	pid, err := readLockFile(lockPath)
+	switch {
+	case os.IsNotExist(err):
+	case err != nil:
+		return err
+	case pid != stalePid:
+		// another process took over the lock already
+		return &ErrLocked{Pid: pid}
+	default:
+		fmt.Println("A lock file is present but the corresponding process is not, replacing it.")
+		err = os.Remove(lockPath)
+		if err != nil {
+			return err
+		}
+	}
+
+	return createLockFile(lockPath)
+}
+
+// writeLockTemp write the pid of the current process in a temporary file
+// next to the lock file, and return its path.
+func writeLockTemp(lockPath string) (string, error) {
+	f, err := ioutil.TempFile(filepath.Dir(lockPath), filepath.Base(lockPath)+".tmp")
+	if err != nil {
+		return "", err
+	}
+
+	_, err = fmt.Fprintf(f, "%d", os.Getpid())
+	if err != nil {
+		_ = f.Close()
+		_ = os.Remove(f.Name())
+		return "", err
+	}
+
+	err = f.Close()
+	if err != nil {
+		_ = os.Remove(f.Name())
+		return "", err
+	}
+
+	return f.Name(), nil
+}
+
+// readLockFile return the pid of the process holding the lock file. A lock
+// file with an empty or unparsable content, for instance after a crash,
+// gives a pid of 0.
+func readLockFile(lockPath string) (int, error) {
+	raw, err := ioutil.ReadFile(lockPath)
+	if err != nil {
+		return 0, err
+	}
+
+	pid, err := strconv.Atoi(strings.TrimSpace(string(raw)))
+	if err != nil || pid <= 0 {
+		return 0, nil
+	}
+
+	return pid, nil
+}
+
+// writeFileAtomic write a file through a temporary file renamed in place, so
+// that a concurrent reader see either the old or the new content.
+func writeFileAtomic(filePath string, data []byte) error {
+	f, err := ioutil.TempFile(filepath.Dir(filePath), filepath.Base(filePath)+".tmp")
+	if err != nil {
+		return err
+	}
+
+	_, err = f.Write(data)
+	if err != nil {
+		_ = f.Close()
+		_ = os.Remove(f.Name())
+		return err
+	}
+
+	err = f.Close()
+	if err != nil {
+		_ = os.Remove(f.Name())
+		return err
+	}
+
+	return os.Rename(f.Name(), filePath)
+}
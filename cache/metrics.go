@@ -0,0 +1,152 @@
+package cache
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Metrics receive measurements of the activity of the cache, so they can be
+// exported, for instance to a monitoring system. The methods are called
+// synchronously, possibly concurrently: they must be fast and safe for
+// concurrent use.
+type Metrics interface {
+	// ObserveBuild is called when the cache of a repository is ready, with
+	// rebuilt true if it has been built from the repository data instead
+	// of being read from the disk.
+	ObserveBuild(repo string, rebuilt bool, d time.Duration)
+
+	// ObserveResolve is called when an entity ("bugs" or "identities") has
+	// been resolved, with hit true if it was already loaded in memory.
+	ObserveResolve(repo string, target string, hit bool, d time.Duration)
+}
+
+var _ Metrics = noopMetrics{}
+
+type noopMetrics struct{}
+
+func (noopMetrics) ObserveBuild(string, bool, time.Duration) {}
+
+func (noopMetrics) ObserveResolve(string, string, bool, time.Duration) {}
+
+var _ Metrics = &MetricsCollector{}
+
+// MetricsCollector is a Metrics aggregating the measurements in memory, that
+// can be exported in the Prometheus text format.
+type MetricsCollector struct {
+	mu       sync.Mutex
+	builds   map[string]buildMetric
+	resolves map[resolveKey]*resolveMetric
+}
+
+type buildMetric struct {
+	rebuilt  bool
+	duration time.Duration
+}
+
+type resolveKey struct {
+	repo   string
+	target string
+}
+
+type resolveMetric struct {
+	hits     uint64
+	misses   uint64
+	duration time.Duration
+}
+
+func NewMetricsCollector() *MetricsCollector {
+	return &MetricsCollector{
+		builds:   make(map[string]buildMetric),
+		resolves: make(map[resolveKey]*resolveMetric),
+	}
+}
+
+func (m *MetricsCollector) ObserveBuild(repo string, rebuilt bool, d time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.builds[repo] = buildMetric{rebuilt: rebuilt, duration: d}
+}
+
+func (m *MetricsCollector) ObserveResolve(repo string, target string, hit bool, d time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := resolveKey{repo: repo, target: target}
+	metric, ok := m.resolves[key]
+	if !ok {
+		metric = &resolveMetric{}
+		m.resolves[key] = metric
+	}
+
+	if hit {
+		metric.hits++
+	} else {
+		metric.misses++
+	}
+	metric.duration += d
+}
+
+// WritePrometheus write the collected metrics in the Prometheus text format
+func (m *MetricsCollector) WritePrometheus(w io.Writer) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var lines []string
+	printf := func(format string, args ...interface{}) {
+		lines = append(lines, fmt.Sprintf(format, args...))
+	}
+
+	repos := make([]string, 0, len(m.builds))
+	for repo := range m.builds {
+		repos = append(repos, repo)
+	}
+	sort.Strings(repos)
+
+	printf("# HELP git_bug_cache_build_seconds Time taken to make the cache ready, the last time.")
+	printf("# TYPE git_bug_cache_build_seconds gauge")
+	for _, repo := range repos {
+		build := m.builds[repo]
+		printf("git_bug_cache_build_seconds{repo=%q,rebuilt=\"%t\"} %f",
+			repo, build.rebuilt, build.duration.Seconds())
+	}
+
+	keys := make([]resolveKey, 0, len(m.resolves))
+	for key := range m.resolves {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].repo != keys[j].repo {
+			return keys[i].repo < keys[j].repo
+		}
+		return keys[i].target < keys[j].target
+	})
+
+	printf("# HELP git_bug_cache_resolve_total Number of resolved entities, by whether they were loaded in memory.")
+	printf("# TYPE git_bug_cache_resolve_total counter")
+	for _, key := range keys {
+		metric := m.resolves[key]
+		printf("git_bug_cache_resolve_total{repo=%q,target=%q,result=\"hit\"} %d",
+			key.repo, key.target, metric.hits)
+		printf("git_bug_cache_resolve_total{repo=%q,target=%q,result=\"miss\"} %d",
+			key.repo, key.target, metric.misses)
+	}
+
+	printf("# HELP git_bug_cache_resolve_seconds_total Total time spent resolving entities.")
+	printf("# TYPE git_bug_cache_resolve_seconds_total counter")
+	for _, key := range keys {
+		printf("git_bug_cache_resolve_seconds_total{repo=%q,target=%q} %f",
+			key.repo, key.target, m.resolves[key].duration.Seconds())
+	}
+
+	for _, line := range lines {
+		_, err := fmt.Fprintln(w, line)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -2,6 +2,8 @@ package cache
 
 import (
 	"fmt"
+	"sort"
+	"time"
 
 	"github.com/MichaelMure/git-bug/repository"
 )
@@ -10,18 +12,38 @@ const lockfile = "lock"
 
 // MultiRepoCache is the root cache, holding multiple RepoCache.
 type MultiRepoCache struct {
-	repos map[string]*RepoCache
+	repos   map[string]*RepoCache
+	metrics Metrics
 }
 
 func NewMultiRepoCache() *MultiRepoCache {
 	return &MultiRepoCache{
-		repos: make(map[string]*RepoCache),
+		repos:   make(map[string]*RepoCache),
+		metrics: noopMetrics{},
 	}
 }
 
+// SetMetrics set the receiver of the measurements of the activity of the
+// caches. It only apply to the repositories registered afterward.
+func (c *MultiRepoCache) SetMetrics(metrics Metrics) {
+	c.metrics = metrics
+}
+
 // RegisterRepository register a named repository. Use this for multi-repo setup
 func (c *MultiRepoCache) RegisterRepository(ref string, repo repository.ClockedRepo) (*RepoCache, error) {
-	r, err := NewRepoCache(repo)
+	r, err := newRepoCache(repo, ref, defaultBuildProgress, false, c.metrics)
+	if err != nil {
+		return nil, err
+	}
+
+	c.repos[ref] = r
+	return r, nil
+}
+
+// RegisterReadOnlyRepository register a named repository with a read-only cache,
+// that doesn't lock the repository.
+func (c *MultiRepoCache) RegisterReadOnlyRepository(ref string, repo repository.ClockedRepo) (*RepoCache, error) {
+	r, err := newRepoCache(repo, ref, nil, true, c.metrics)
 	if err != nil {
 		return nil, err
 	}
@@ -32,7 +54,7 @@ func (c *MultiRepoCache) RegisterRepository(ref string, repo repository.ClockedR
 
 // RegisterDefaultRepository register a unnamed repository. Use this for mono-repo setup
 func (c *MultiRepoCache) RegisterDefaultRepository(repo repository.ClockedRepo) (*RepoCache, error) {
-	r, err := NewRepoCache(repo)
+	r, err := newRepoCache(repo, "", defaultBuildProgress, false, c.metrics)
 	if err != nil {
 		return nil, err
 	}
@@ -43,6 +65,11 @@ func (c *MultiRepoCache) RegisterDefaultRepository(repo repository.ClockedRepo)
 
 // DefaultRepo retrieve the default repository
 func (c *MultiRepoCache) DefaultRepo() (*RepoCache, error) {
+	// in a workspace, the unnamed repository is the default one
+	if r, ok := c.repos[""]; ok {
+		return r, nil
+	}
+
 	if len(c.repos) != 1 {
 		return nil, fmt.Errorf("repository is not unique")
 	}
@@ -63,6 +90,38 @@ func (c *MultiRepoCache) ResolveRepo(ref string) (*RepoCache, error) {
 	return r, nil
 }
 
+// Names return the sorted names of the registered repositories
+func (c *MultiRepoCache) Names() []string {
+	result := make([]string, 0, len(c.repos))
+	for name := range c.repos {
+		result = append(result, name)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// Generation return a number incremented each time an entity of one of the
+// registered repositories change.
+func (c *MultiRepoCache) Generation() uint64 {
+	var result uint64
+	for _, cachedRepo := range c.repos {
+		result += cachedRepo.Generation()
+	}
+	return result
+}
+
+// Watch start watching the refs of all the registered repositories, to keep
+// the cache up to date with the changes made by other processes
+func (c *MultiRepoCache) Watch(interval time.Duration) error {
+	for _, cachedRepo := range c.repos {
+		err := cachedRepo.Watch(interval)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Close will do anything that is needed to close the cache properly
 func (c *MultiRepoCache) Close() error {
 	for _, cachedRepo := range c.repos {
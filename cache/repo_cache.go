@@ -1,30 +1,42 @@
 package cache
 
 import (
+	"errors"
 	"fmt"
-	"io"
-	"io/ioutil"
 	"os"
 	"path"
 	"path/filepath"
-	"strconv"
 	"sync"
+	"time"
 
 	"github.com/MichaelMure/git-bug/bug"
 	"github.com/MichaelMure/git-bug/entity"
 	"github.com/MichaelMure/git-bug/identity"
 	"github.com/MichaelMure/git-bug/repository"
-	"github.com/MichaelMure/git-bug/util/process"
 )
 
 // 1: original format
 // 2: added cache for identities with a reference in the bug cache
 // 3: no more legacy identity
-const formatVersion = 3
+// 4: custom fields in the bug excerpt
+// 5: checklist progress in the bug excerpt
+// 6: relations in the bug excerpt
+// 7: subscribers in the bug excerpt
+// 8: draft flag in the bug excerpt
+// 9: extended status in the bug excerpt
+// 10: co-authors in the bug excerpt
+// 11: explicit header in the cache files
+// 12: assignees, milestone and due date in the bug excerpt
+// 13: teams in the identity excerpt
+// 14: time spent per identity in the bug excerpt
+const formatVersion = 14
 
 // The maximum number of bugs loaded in memory. After that, eviction will be done.
 const defaultMaxLoadedBugs = 1000
 
+// ErrReadOnly is returned when trying to modify the data of a read-only cache
+var ErrReadOnly = errors.New("the cache is in read-only mode")
+
 var _ repository.RepoCommon = &RepoCache{}
 var _ repository.RepoConfig = &RepoCache{}
 var _ repository.RepoKeyring = &RepoCache{}
@@ -52,6 +64,8 @@ type RepoCache struct {
 
 	// maximum number of loaded bugs
 	maxLoadedBugs int
+	// approximate maximum memory used by the loaded bugs, 0 if unlimited
+	memoryBudget uint64
 
 	muBug sync.RWMutex
 	// excerpt of bugs data for all bugs
@@ -60,6 +74,15 @@ type RepoCache struct {
 	bugs map[entity.Id]*BugCache
 	// loadedBugs is an LRU cache that records which bugs the cache has loaded in
 	loadedBugs *LRUIdCache
+	// snapshots of the bugs evicted from memory, to load them again quickly
+	snapshots *snapshotMemo
+
+	// full-text index of the bugs titles and comments
+	search *searchIndex
+
+	// radix trees of the bug and identity ids, for the prefix resolution
+	bugIds      *idTree
+	identityIds *idTree
 
 	muIdentity sync.RWMutex
 	// excerpt of identities data for all identities
@@ -69,45 +92,130 @@ type RepoCache struct {
 
 	// the user identity's id, if known
 	userIdentityId entity.Id
+
+	// receive the progress of the building of the cache, if it happen
+	progress func(BuildEvent)
+
+	// in read-only mode, the repo is not locked, the cache files are never
+	// written and the data can't be modified
+	readOnly bool
+
+	// the format version the cache files have been migrated from when
+	// loaded, 0 if no migration happened
+	migratedFrom uint
+
+	// subscribers to the changes of the data, and the watcher of the refs
+	// if it's running
+	watch watchState
+
+	// receive the measurements of the activity of the cache
+	metrics Metrics
 }
 
 func NewRepoCache(r repository.ClockedRepo) (*RepoCache, error) {
 	return NewNamedRepoCache(r, "")
 }
 
+// NewRepoCacheWithProgress is like NewRepoCache but report the progress of
+// the building of the cache, if it need to be rebuilt, to the given function.
+func NewRepoCacheWithProgress(r repository.ClockedRepo, progress func(BuildEvent)) (*RepoCache, error) {
+	return newRepoCache(r, "", progress, false, noopMetrics{})
+}
+
+// NewReadOnlyRepoCache create a cache that doesn't lock the repository and
+// never write on the disk, so it can be used while another process hold the
+// lock. The cache on disk might be slightly stale. If it's missing or can't
+// be read, it's rebuilt in memory only.
+// Any attempt to modify the data will fail with ErrReadOnly.
+func NewReadOnlyRepoCache(r repository.ClockedRepo, progress func(BuildEvent)) (*RepoCache, error) {
+	return newRepoCache(r, "", progress, true, noopMetrics{})
+}
+
 func NewNamedRepoCache(r repository.ClockedRepo, name string) (*RepoCache, error) {
+	return newRepoCache(r, name, defaultBuildProgress, false, noopMetrics{})
+}
+
+func newRepoCache(r repository.ClockedRepo, name string, progress func(BuildEvent), readOnly bool, metrics Metrics) (*RepoCache, error) {
 	c := &RepoCache{
 		repo:          r,
 		name:          name,
+		progress:      progress,
+		readOnly:      readOnly,
 		maxLoadedBugs: defaultMaxLoadedBugs,
 		bugs:          make(map[entity.Id]*BugCache),
 		loadedBugs:    NewLRUIdCache(),
 		identities:    make(map[entity.Id]*IdentityCache),
+		search:        newSearchIndex(),
+		bugIds:        newIdTree(),
+		identityIds:   newIdTree(),
+		metrics:       metrics,
 	}
 
-	err := c.lock()
+	err := c.readMemoryConfig()
 	if err != nil {
 		return &RepoCache{}, err
 	}
 
+	c.snapshots = newSnapshotMemo(c.maxLoadedBugs)
+
+	if !c.readOnly {
+		err = c.lock()
+		if err != nil {
+			return &RepoCache{}, err
+		}
+	}
+
+	start := time.Now()
+
 	err = c.load()
+	if err == nil {
+		c.metrics.ObserveBuild(c.name, false, time.Since(start))
+	}
+	if err == nil && c.migratedFrom != 0 {
+		c.notifyBuild(BuildEvent{
+			Typ:    BuildEventMigrated,
+			Reason: fmt.Sprintf("migrated the cache from the format version %d to %d", c.migratedFrom, formatVersion),
+		})
+		if c.readOnly {
+			return c, nil
+		}
+		return c, c.write()
+	}
 	if err == nil {
 		return c, nil
 	}
 
 	// Cache is either missing, broken or outdated. Rebuilding.
+	if !os.IsNotExist(err) {
+		c.notifyBuild(BuildEvent{Typ: BuildEventOutdated, Reason: err.Error()})
+	}
+
+	start = time.Now()
+
 	err = c.buildCache()
 	if err != nil {
 		return nil, err
 	}
 
+	c.metrics.ObserveBuild(c.name, true, time.Since(start))
+
+	// the rebuilt cache is only kept in memory
+	if c.readOnly {
+		return c, nil
+	}
+
 	return c, c.write()
 }
 
+// IsReadOnly tell if the cache has been opened in read-only mode
+func (c *RepoCache) IsReadOnly() bool {
+	return c.readOnly
+}
+
 // setCacheSize change the maximum number of loaded bugs
 func (c *RepoCache) setCacheSize(size int) {
 	c.maxLoadedBugs = size
-	c.evictIfNeeded()
+	c.evictIfNeeded(entity.UnsetId)
 }
 
 // load will try to read from the disk all the cache files
@@ -116,46 +224,58 @@ func (c *RepoCache) load() error {
 	if err != nil {
 		return err
 	}
+	err = c.loadSearchIndex()
+	if err != nil {
+		return err
+	}
 	return c.loadIdentityCache()
 }
 
 // write will serialize on disk all the cache files
 func (c *RepoCache) write() error {
+	if c.readOnly {
+		return ErrReadOnly
+	}
 	err := c.writeBugCache()
 	if err != nil {
 		return err
 	}
+	err = c.writeSearchIndex()
+	if err != nil {
+		return err
+	}
 	return c.writeIdentityCache()
 }
 
 func (c *RepoCache) lock() error {
 	lockPath := repoLockFilePath(c.repo)
 
-	err := repoIsAvailable(c.repo)
+	err := os.MkdirAll(filepath.Dir(lockPath), 0777)
 	if err != nil {
 		return err
 	}
 
-	err = os.MkdirAll(filepath.Dir(lockPath), 0777)
+	timeout, err := readLockTimeout(c.repo)
 	if err != nil {
 		return err
 	}
+	deadline := time.Now().Add(timeout)
 
-	f, err := os.Create(lockPath)
-	if err != nil {
-		return err
-	}
-
-	pid := fmt.Sprintf("%d", os.Getpid())
-	_, err = f.WriteString(pid)
-	if err != nil {
-		return err
+	for {
+		err = acquireLockFile(lockPath)
+		if err == nil {
+			return nil
+		}
+		if !IsErrLocked(err) || !time.Now().Before(deadline) {
+			return err
+		}
+		time.Sleep(lockRetryInterval)
 	}
-
-	return f.Close()
 }
 
 func (c *RepoCache) Close() error {
+	c.StopWatching()
+
 	c.muBug.Lock()
 	defer c.muBug.Unlock()
 	c.muIdentity.Lock()
@@ -163,22 +283,48 @@ func (c *RepoCache) Close() error {
 
 	c.identities = make(map[entity.Id]*IdentityCache)
 	c.identitiesExcerpts = nil
+	c.identityIds = newIdTree()
 	c.bugs = make(map[entity.Id]*BugCache)
 	c.bugExcerpts = nil
+	c.bugIds = newIdTree()
+	c.snapshots.purge()
+
+	if c.readOnly {
+		return nil
+	}
 
 	lockPath := repoLockFilePath(c.repo)
 	return os.Remove(lockPath)
 }
 
+// Rebuild discard the cache and build it again from the repository data
+func (c *RepoCache) Rebuild() error {
+	if c.readOnly {
+		return ErrReadOnly
+	}
+
+	start := time.Now()
+
+	err := c.buildCache()
+	if err != nil {
+		return err
+	}
+
+	c.metrics.ObserveBuild(c.name, true, time.Since(start))
+
+	return c.write()
+}
+
 func (c *RepoCache) buildCache() error {
 	c.muBug.Lock()
 	defer c.muBug.Unlock()
 	c.muIdentity.Lock()
 	defer c.muIdentity.Unlock()
 
-	_, _ = fmt.Fprintf(os.Stderr, "Building identity cache... ")
-
 	c.identitiesExcerpts = make(map[entity.Id]*IdentityExcerpt)
+	c.identityIds = newIdTree()
+
+	c.notifyBuild(BuildEvent{Typ: BuildEventStarted, Target: "identities"})
 
 	allIdentities := identity.ReadAllLocal(c.repo)
 
@@ -188,89 +334,79 @@ func (c *RepoCache) buildCache() error {
 		}
 
 		c.identitiesExcerpts[i.Identity.Id()] = NewIdentityExcerpt(i.Identity)
+		c.identityIds.insert(i.Identity.Id())
+		c.notifyBuild(BuildEvent{
+			Typ:    BuildEventProgress,
+			Target: "identities",
+			Done:   len(c.identitiesExcerpts),
+		})
 	}
 
-	_, _ = fmt.Fprintln(os.Stderr, "Done.")
-
-	_, _ = fmt.Fprintf(os.Stderr, "Building bug cache... ")
+	c.notifyBuild(BuildEvent{
+		Typ:    BuildEventFinished,
+		Target: "identities",
+		Done:   len(c.identitiesExcerpts),
+		Total:  len(c.identitiesExcerpts),
+	})
 
 	c.bugExcerpts = make(map[entity.Id]*BugExcerpt)
+	c.bugIds = newIdTree()
+	c.search = newSearchIndex()
 
-	allBugs := bug.ReadAllLocal(c.repo)
+	ids, err := bug.ListLocalIds(c.repo)
+	if err != nil {
+		return err
+	}
 
-	for b := range allBugs {
-		if b.Err != nil {
-			return b.Err
+	c.notifyBuild(BuildEvent{Typ: BuildEventStarted, Target: "bugs", Total: len(ids)})
+
+	done := 0
+
+	// the channel is always drained to not leak the workers
+	for b := range c.buildBugs(ids) {
+		done++
+
+		switch {
+		case err != nil:
+			continue
+		// confidential bugs we can't read are simply not visible
+		case b.err == bug.ErrBugEncrypted:
+		case b.err != nil:
+			err = b.err
+			continue
+		default:
+			c.bugExcerpts[b.id] = NewBugExcerpt(b.bug, &b.snap)
+			c.bugIds.insert(b.id)
+			c.search.index(b.id, &b.snap)
 		}
 
-		snap := b.Bug.Compile()
-		c.bugExcerpts[b.Bug.Id()] = NewBugExcerpt(b.Bug, &snap)
+		c.notifyBuild(BuildEvent{
+			Typ:    BuildEventProgress,
+			Target: "bugs",
+			Done:   done,
+			Total:  len(ids),
+		})
 	}
-
-	_, _ = fmt.Fprintln(os.Stderr, "Done.")
-	return nil
-}
-
-func repoLockFilePath(repo repository.Repo) string {
-	return path.Join(repo.GetPath(), "git-bug", lockfile)
-}
-
-// repoIsAvailable check is the given repository is locked by a Cache.
-// Note: this is a smart function that will cleanup the lock file if the
-// corresponding process is not there anymore.
-// If no error is returned, the repo is free to edit.
-func repoIsAvailable(repo repository.Repo) error {
-	lockPath := repoLockFilePath(repo)
-
-	// Todo: this leave way for a racey access to the repo between the test
-	// if the file exist and the actual write. It's probably not a problem in
-	// practice because using a repository will be done from user interaction
-	// or in a context where a single instance of git-bug is already guaranteed
-	// (say, a server with the web UI running). But still, that might be nice to
-	// have a mutex or something to guard that.
-
-	// Todo: this will fail if somehow the filesystem is shared with another
-	// computer. Should add a configuration that prevent the cleaning of the
-	// lock file
-
-	f, err := os.Open(lockPath)
-
-	if err != nil && !os.IsNotExist(err) {
+	if err != nil {
 		return err
 	}
 
-	if err == nil {
-		// lock file already exist
-		buf, err := ioutil.ReadAll(io.LimitReader(f, 10))
-		if err != nil {
-			return err
-		}
-		if len(buf) == 10 {
-			return fmt.Errorf("the lock file should be < 10 bytes")
-		}
-
-		pid, err := strconv.Atoi(string(buf))
-		if err != nil {
-			return err
-		}
-
-		if process.IsRunning(pid) {
-			return fmt.Errorf("the repository you want to access is already locked by the process pid %d", pid)
-		}
-
-		// The lock file is just laying there after a crash, clean it
+	c.notifyBuild(BuildEvent{
+		Typ:    BuildEventFinished,
+		Target: "bugs",
+		Done:   done,
+		Total:  len(ids),
+	})
 
-		fmt.Println("A lock file is present but the corresponding process is not, removing it.")
-		err = f.Close()
-		if err != nil {
-			return err
-		}
+	return nil
+}
 
-		err = os.Remove(lockPath)
-		if err != nil {
-			return err
-		}
+func (c *RepoCache) notifyBuild(event BuildEvent) {
+	if c.progress != nil {
+		c.progress(event)
 	}
+}
 
-	return nil
+func repoLockFilePath(repo repository.Repo) string {
+	return path.Join(repo.GetPath(), "git-bug", lockfile)
 }
@@ -0,0 +1,88 @@
+package cache
+
+import (
+	"time"
+
+	"github.com/MichaelMure/git-bug/board"
+	"github.com/MichaelMure/git-bug/entity"
+)
+
+// DefaultBoardColumns are the columns of a new board, if none are given
+var DefaultBoardColumns = []string{"To do", "In progress", "Done"}
+
+// AllBoardIds return all known board ids
+func (c *RepoCache) AllBoardIds() ([]entity.Id, error) {
+	return board.ListLocalIds(c.repo)
+}
+
+// ResolveBoard retrieve a board matching the exact given id
+func (c *RepoCache) ResolveBoard(id entity.Id) (*BoardCache, error) {
+	b, err := board.ReadLocal(c.repo, newIdentityCacheResolver(c), id)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewBoardCache(c, b), nil
+}
+
+// ResolveBoardPrefix retrieve a board matching an id prefix. It fails if multiple
+// boards match.
+func (c *RepoCache) ResolveBoardPrefix(prefix string) (*BoardCache, error) {
+	ids, err := c.AllBoardIds()
+	if err != nil {
+		return nil, err
+	}
+
+	var matching []entity.Id
+	for _, id := range ids {
+		if id.HasPrefix(prefix) {
+			matching = append(matching, id)
+		}
+	}
+
+	if len(matching) > 1 {
+		return nil, board.NewErrMultipleMatchBoard(matching)
+	}
+
+	if len(matching) == 0 {
+		return nil, board.ErrBoardNotExist
+	}
+
+	return c.ResolveBoard(matching[0])
+}
+
+// NewBoard create a new board with the given columns, or the default ones
+// The new board is written in the repository (commit)
+func (c *RepoCache) NewBoard(title string, columns []string) (*BoardCache, error) {
+	author, err := c.GetUserIdentity()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.NewBoardRaw(author, time.Now().Unix(), title, columns)
+}
+
+// NewBoardRaw create a new board with the given columns, or the default ones,
+// with the given author and time
+// The new board is written in the repository (commit)
+func (c *RepoCache) NewBoardRaw(author *IdentityCache, unixTime int64, title string, columns []string) (*BoardCache, error) {
+	if c.readOnly {
+		return nil, ErrReadOnly
+	}
+
+	if len(columns) == 0 {
+		columns = DefaultBoardColumns
+	}
+
+	b, _, err := board.Create(author.Identity, unixTime, title, columns)
+	if err != nil {
+		return nil, err
+	}
+
+	err = b.Commit(c.repo)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewBoardCache(c, b), nil
+}
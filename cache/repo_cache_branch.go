@@ -0,0 +1,154 @@
+package cache
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+// the default branch can be configured, otherwise it's guessed
+const defaultBranchConfigKey = "git-bug.default-branch"
+
+const branchRefPrefix = "refs/heads/"
+
+// the commit a bug branch has been created from is recorded in the local
+// config as git-bug.branch.<name>.base, to not consider a branch without any
+// work as merged
+const branchBaseConfigKeyPattern = "git-bug.branch.%s.base"
+
+// the maximum length of the part of a branch name derived from the title
+const branchSlugLength = 40
+
+// BugBranchName return the name of the branch to work on a bug, derived from
+// its human id and title, like "bug/1a2b3c4-crash-on-startup"
+func BugBranchName(excerpt *BugExcerpt) string {
+	var slug strings.Builder
+	dash := false
+
+	for _, r := range strings.ToLower(excerpt.Title) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			if dash && slug.Len() > 0 {
+				slug.WriteRune('-')
+			}
+			dash = false
+			slug.WriteRune(r)
+		} else {
+			dash = true
+		}
+		if slug.Len() >= branchSlugLength {
+			break
+		}
+	}
+
+	name := "bug/" + excerpt.Id.Human()
+	if slug.Len() > 0 {
+		name += "-" + strings.TrimSuffix(slug.String(), "-")
+	}
+	return name
+}
+
+// BranchExist tell if a local branch exist
+func (c *RepoCache) BranchExist(name string) (bool, error) {
+	return c.repo.RefExist(branchRefPrefix + name)
+}
+
+// CreateBranch create a local branch pointing to the given git revision and
+// record it as the base of the branch
+func (c *RepoCache) CreateBranch(name string, rev string) error {
+	exist, err := c.BranchExist(name)
+	if err != nil {
+		return err
+	}
+	if exist {
+		return fmt.Errorf("branch %s already exist", name)
+	}
+
+	hash, err := c.repo.ResolveRevision(rev)
+	if err != nil {
+		return err
+	}
+
+	err = c.repo.UpdateRef(branchRefPrefix+name, hash)
+	if err != nil {
+		return err
+	}
+
+	return c.repo.LocalConfig().StoreString(fmt.Sprintf(branchBaseConfigKeyPattern, name), hash.String())
+}
+
+// DefaultBranch return the branch the work is merged into: the configured
+// one, the one of the default remote, or main or master.
+func (c *RepoCache) DefaultBranch() (string, error) {
+	configured, err := c.repo.AnyConfig().ReadString(defaultBranchConfigKey)
+	if err == nil {
+		return configured, nil
+	}
+	if err != repository.ErrNoConfigEntry {
+		return "", err
+	}
+
+	for _, candidate := range []string{"origin/HEAD", "main", "master"} {
+		if _, err := c.repo.ResolveRevision(candidate); err == nil {
+			return candidate, nil
+		}
+	}
+
+	return "", fmt.Errorf("no default branch found, configure it with \"git config %s <branch>\"", defaultBranchConfigKey)
+}
+
+// IsMerged tell if all the commits of a branch are reachable from another
+// git revision. A branch without any commit since its creation is not
+// considered as merged.
+func (c *RepoCache) IsMerged(branch string, into string) (bool, error) {
+	tip, err := c.repo.ResolveRef(branchRefPrefix + branch)
+	if err != nil {
+		return false, err
+	}
+
+	base, err := c.repo.LocalConfig().ReadString(fmt.Sprintf(branchBaseConfigKeyPattern, branch))
+	if err != nil && err != repository.ErrNoConfigEntry {
+		return false, err
+	}
+	if base == tip.String() {
+		return false, nil
+	}
+
+	return c.IsReachable(tip, into)
+}
+
+// IsReachable tell if a commit is reachable from a git revision, that is if
+// it has been merged into it
+func (c *RepoCache) IsReachable(commit repository.Hash, from string) (bool, error) {
+	target, err := c.repo.ResolveRevision(from)
+	if err != nil {
+		return false, err
+	}
+
+	if commit == target {
+		return true, nil
+	}
+
+	ancestor, err := c.repo.FindCommonAncestor(commit, target)
+	if err != nil {
+		return false, err
+	}
+
+	return ancestor == commit, nil
+}
+
+// CommitMessage return the message of a commit
+func (c *RepoCache) CommitMessage(commit repository.Hash) (string, error) {
+	raw, err := c.repo.ReadRawCommit(commit)
+	if err != nil {
+		return "", err
+	}
+
+	// the message follow the headers, after an empty line
+	split := strings.SplitN(string(raw), "\n\n", 2)
+	if len(split) != 2 {
+		return "", nil
+	}
+
+	return split[1], nil
+}
@@ -7,19 +7,38 @@ import (
 	"fmt"
 	"os"
 	"path"
+	"path/filepath"
 	"sort"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/dustin/go-humanize"
+
 	"github.com/MichaelMure/git-bug/bug"
 	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/identity"
 	"github.com/MichaelMure/git-bug/query"
 	"github.com/MichaelMure/git-bug/repository"
 )
 
 const bugCacheFile = "bug-cache"
 
+// metadata keys recording the origin of the comments copied when merging
+// duplicate bugs
+const MergedFromBugMetaKey = "merged-from-bug"
+const MergedFromCommentMetaKey = "merged-from-comment"
+
 var errBugNotInCache = errors.New("bug missing from cache")
 
+// config keys to bound the memory used by the loaded bugs
+const maxLoadedBugsConfigKey = "git-bug.cache.max-loaded-bugs"
+const memoryBudgetConfigKey = "git-bug.cache.memory-budget"
+
+// rough estimation of the memory used by a loaded operation, including its
+// share of the snapshot
+const estimatedOperationSize = 2 * 1024
+
 func bugCacheFilePath(repo repository.Repo) string {
 	return path.Join(repo.GetPath(), "git-bug", bugCacheFile)
 }
@@ -27,6 +46,10 @@ func bugCacheFilePath(repo repository.Repo) string {
 // bugUpdated is a callback to trigger when the excerpt of a bug changed,
 // that is each time a bug is updated
 func (c *RepoCache) bugUpdated(id entity.Id) error {
+	if c.readOnly {
+		return ErrReadOnly
+	}
+
 	c.muBug.Lock()
 	b, ok := c.bugs[id]
 	if !ok {
@@ -40,11 +63,24 @@ func (c *RepoCache) bugUpdated(id entity.Id) error {
 		return errBugNotInCache
 	}
 	c.loadedBugs.Get(id)
+	_, existed := c.bugExcerpts[id]
 	c.bugExcerpts[id] = NewBugExcerpt(b.bug, b.Snapshot())
+	c.bugIds.insert(id)
+	c.search.index(id, b.Snapshot())
 	c.muBug.Unlock()
 
-	// we only need to write the bug cache
-	return c.writeBugCache()
+	typ := ChangeEventUpdated
+	if !existed {
+		typ = ChangeEventAdded
+	}
+	c.notifyChange(ChangeEvent{Typ: typ, Target: "bugs", Id: id})
+
+	// we only need to write the bug cache and the search index
+	err := c.writeBugCache()
+	if err != nil {
+		return err
+	}
+	return c.writeSearchIndex()
 }
 
 // load will try to read from the disk the bug cache file
@@ -56,24 +92,39 @@ func (c *RepoCache) loadBugCache() error {
 	if err != nil {
 		return err
 	}
+	defer f.Close()
 
 	decoder := gob.NewDecoder(f)
 
-	aux := struct {
-		Version  uint
-		Excerpts map[entity.Id]*BugExcerpt
-	}{}
+	version, err := readCacheHeader(decoder)
+	if err != nil {
+		return err
+	}
+
+	if version != formatVersion && !canMigrate(version) {
+		return &ErrFormatVersion{File: bugCacheFile, Found: version, Expected: formatVersion}
+	}
 
-	err = decoder.Decode(&aux)
+	var excerpts map[entity.Id]*BugExcerpt
+
+	err = decoder.Decode(&excerpts)
 	if err != nil {
 		return err
 	}
 
-	if aux.Version != formatVersion {
-		return fmt.Errorf("unknown cache format version %v", aux.Version)
+	if version != formatVersion {
+		err = migrateBugExcerpts(c.repo, version, excerpts)
+		if err != nil {
+			return err
+		}
+		c.migratedFrom = version
 	}
 
-	c.bugExcerpts = aux.Excerpts
+	c.bugExcerpts = excerpts
+	c.bugIds = newIdTree()
+	for id := range excerpts {
+		c.bugIds.insert(id)
+	}
 	return nil
 }
 
@@ -84,32 +135,19 @@ func (c *RepoCache) writeBugCache() error {
 
 	var data bytes.Buffer
 
-	aux := struct {
-		Version  uint
-		Excerpts map[entity.Id]*BugExcerpt
-	}{
-		Version:  formatVersion,
-		Excerpts: c.bugExcerpts,
-	}
-
 	encoder := gob.NewEncoder(&data)
 
-	err := encoder.Encode(aux)
+	err := writeCacheHeader(encoder, formatVersion)
 	if err != nil {
 		return err
 	}
 
-	f, err := os.Create(bugCacheFilePath(c.repo))
+	err = encoder.Encode(c.bugExcerpts)
 	if err != nil {
 		return err
 	}
 
-	_, err = f.Write(data.Bytes())
-	if err != nil {
-		return err
-	}
-
-	return f.Close()
+	return writeFileAtomic(bugCacheFilePath(c.repo), data.Bytes())
 }
 
 // ResolveBugExcerpt retrieve a BugExcerpt matching the exact given id
@@ -127,11 +165,14 @@ func (c *RepoCache) ResolveBugExcerpt(id entity.Id) (*BugExcerpt, error) {
 
 // ResolveBug retrieve a bug matching the exact given id
 func (c *RepoCache) ResolveBug(id entity.Id) (*BugCache, error) {
+	start := time.Now()
+
 	c.muBug.RLock()
 	cached, ok := c.bugs[id]
 	if ok {
 		c.loadedBugs.Get(id)
 		c.muBug.RUnlock()
+		c.metrics.ObserveResolve(c.name, "bugs", true, time.Since(start))
 		return cached, nil
 	}
 	c.muBug.RUnlock()
@@ -141,38 +182,175 @@ func (c *RepoCache) ResolveBug(id entity.Id) (*BugCache, error) {
 		return nil, err
 	}
 
-	cached = NewBugCache(c, b)
+	if snap, ok := c.snapshots.compile(b); ok {
+		cached = newBugCacheWithSnapshot(c, b, snap)
+	} else {
+		cached = NewBugCache(c, b)
+	}
 
 	c.muBug.Lock()
 	c.bugs[id] = cached
 	c.loadedBugs.Add(id)
 	c.muBug.Unlock()
 
-	c.evictIfNeeded()
+	c.evictIfNeeded(id)
+
+	c.metrics.ObserveResolve(c.name, "bugs", false, time.Since(start))
 
 	return cached, nil
 }
 
+// compileUpdatedBug compile the snapshot of a bug updated in the repository,
+// for instance by a pull, and replace the loaded copy of the bug if any. When
+// possible, only the new operations are applied on top of the snapshot of
+// the previous version.
+func (c *RepoCache) compileUpdatedBug(b *bug.Bug) *bug.Snapshot {
+	c.muBug.RLock()
+	cached, loaded := c.bugs[b.Id()]
+	c.muBug.RUnlock()
+
+	if !loaded {
+		if snap, ok := c.snapshots.compile(b); ok {
+			return snap
+		}
+		snap := b.Compile()
+		return &snap
+	}
+
+	cached.mu.Lock()
+	defer cached.mu.Unlock()
+
+	var snap *bug.Snapshot
+	if cached.bug.HasSnapshot() && b.CompileOnTop(cached.bug.Snapshot()) {
+		snap = cached.bug.Snapshot()
+	} else {
+		compiled := b.Compile()
+		snap = &compiled
+	}
+
+	// the loaded copy of the bug is now outdated
+	cached.bug = bug.NewWithSnapshot(b, snap)
+
+	return snap
+}
+
+// readMemoryConfig read the optional limits on the number of loaded bugs
+// and on the memory they use
+func (c *RepoCache) readMemoryConfig() error {
+	raw, err := c.repo.AnyConfig().ReadString(maxLoadedBugsConfigKey)
+	switch err {
+	case nil:
+		max, err := strconv.Atoi(raw)
+		if err != nil || max < 1 {
+			return fmt.Errorf("invalid %s: %s", maxLoadedBugsConfigKey, raw)
+		}
+		c.maxLoadedBugs = max
+	case repository.ErrNoConfigEntry:
+	default:
+		return err
+	}
+
+	raw, err = c.repo.AnyConfig().ReadString(memoryBudgetConfigKey)
+	switch err {
+	case nil:
+		budget, err := humanize.ParseBytes(raw)
+		if err != nil {
+			return fmt.Errorf("invalid %s: %s", memoryBudgetConfigKey, raw)
+		}
+		c.memoryBudget = budget
+	case repository.ErrNoConfigEntry:
+	default:
+		return err
+	}
+
+	return nil
+}
+
+// estimateBugSize return the approximate memory used by a loaded bug
+func estimateBugSize(b *BugCache) uint64 {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return uint64(b.bug.OperationCount()) * estimatedOperationSize
+}
+
+// estimateSnapshotSize return the approximate memory used by a memoized
+// snapshot
+func estimateSnapshotSize(snap *bug.Snapshot) uint64 {
+	return uint64(len(snap.Operations)) * estimatedOperationSize
+}
+
 // evictIfNeeded will evict a bug from the cache if needed
 // it also removes references of the bug from the bugs
-func (c *RepoCache) evictIfNeeded() {
+// Bugs are evicted, oldest used first, until both the maximum number of
+// loaded bugs and the memory budget are respected. The rest of the bugs
+// are only accessible through their excerpt until loaded again. The bug
+// of the given id, if any, is never evicted as it is about to be returned
+// to the caller.
+// The snapshots of the evicted bugs are memoized to load them again
+// quickly. With a memory budget, the memoized snapshots count in the
+// budget: they are dropped first, and kept only if they fit.
+func (c *RepoCache) evictIfNeeded(keep entity.Id) {
 	c.muBug.Lock()
 	defer c.muBug.Unlock()
-	if c.loadedBugs.Len() <= c.maxLoadedBugs {
+
+	var size uint64
+	if c.memoryBudget > 0 {
+		for _, b := range c.bugs {
+			size += estimateBugSize(b)
+		}
+		size += c.snapshots.size()
+	}
+
+	overMemoryBudget := func() bool {
+		return c.memoryBudget > 0 && size > c.memoryBudget
+	}
+	overBudget := func() bool {
+		return c.loadedBugs.Len() > c.maxLoadedBugs || overMemoryBudget()
+	}
+
+	for overMemoryBudget() {
+		freed, ok := c.snapshots.removeOldest()
+		if !ok {
+			break
+		}
+		size -= freed
+	}
+
+	if !overBudget() {
 		return
 	}
 
 	for _, id := range c.loadedBugs.GetOldestToNewest() {
+		if id == keep {
+			continue
+		}
+
 		b := c.bugs[id]
 		if b.NeedCommit() {
 			continue
 		}
 
-		b.mu.Lock()
+		if c.memoryBudget > 0 {
+			size -= estimateBugSize(b)
+		}
+
+		b.mu.RLock()
+		if b.bug.HasSnapshot() {
+			snap := b.bug.Snapshot()
+			snapSize := estimateSnapshotSize(snap)
+			if c.memoryBudget == 0 || size+snapSize <= c.memoryBudget {
+				c.snapshots.put(id, b.bug.LastCommit(), snap)
+				if c.memoryBudget > 0 {
+					size += snapSize
+				}
+			}
+		}
+		b.mu.RUnlock()
+
 		c.loadedBugs.Remove(id)
 		delete(c.bugs, id)
 
-		if c.loadedBugs.Len() <= c.maxLoadedBugs {
+		if !overBudget() {
 			return
 		}
 	}
@@ -181,17 +359,48 @@ func (c *RepoCache) evictIfNeeded() {
 // ResolveBugExcerptPrefix retrieve a BugExcerpt matching an id prefix. It fails if multiple
 // bugs match.
 func (c *RepoCache) ResolveBugExcerptPrefix(prefix string) (*BugExcerpt, error) {
-	return c.ResolveBugExcerptMatcher(func(excerpt *BugExcerpt) bool {
-		return excerpt.Id.HasPrefix(prefix)
-	})
+	id, err := c.resolveBugPrefix(prefix)
+	if err != nil {
+		return nil, err
+	}
+	return c.ResolveBugExcerpt(id)
 }
 
 // ResolveBugPrefix retrieve a bug matching an id prefix. It fails if multiple
 // bugs match.
 func (c *RepoCache) ResolveBugPrefix(prefix string) (*BugCache, error) {
-	return c.ResolveBugMatcher(func(excerpt *BugExcerpt) bool {
-		return excerpt.Id.HasPrefix(prefix)
-	})
+	id, err := c.resolveBugPrefix(prefix)
+	if err != nil {
+		return nil, err
+	}
+	return c.ResolveBug(id)
+}
+
+func (c *RepoCache) resolveBugPrefix(prefix string) (entity.Id, error) {
+	c.muBug.RLock()
+	defer c.muBug.RUnlock()
+
+	switch c.bugIds.countPrefix(prefix) {
+	case 0:
+		return entity.UnsetId, bug.ErrBugNotExist
+	case 1:
+		return c.bugIds.match(prefix)[0], nil
+	default:
+		return entity.UnsetId, bug.NewErrMultipleMatchBug(c.bugIds.match(prefix))
+	}
+}
+
+// UniqueBugPrefix return the shortest prefix of the id of a bug that doesn't
+// match any other bug. It return the full id if the bug is unknown.
+func (c *RepoCache) UniqueBugPrefix(id entity.Id) string {
+	c.muBug.RLock()
+	defer c.muBug.RUnlock()
+
+	length := c.bugIds.shortestUniquePrefix(id)
+	if length == 0 {
+		return id.String()
+	}
+	return id.String()[:length]
 }
 
 // ResolveBugCreateMetadata retrieve a bug that has the exact given metadata on
@@ -252,7 +461,15 @@ func (c *RepoCache) QueryBugs(q *query.Query) []entity.Id {
 		return c.AllBugsIds()
 	}
 
-	matcher := compileMatcher(q.Filters)
+	// without a schema, the priorities are compared alphabetically
+	schema, _ := c.FieldSchema()
+
+	filters := q.Filters
+	if user, err := c.GetUserIdentity(); err == nil {
+		filters = resolveMe(filters, user.Id())
+	}
+
+	matcher := compileMatcher(filters, schema)
 
 	var filtered []*BugExcerpt
 
@@ -262,6 +479,21 @@ func (c *RepoCache) QueryBugs(q *query.Query) []entity.Id {
 		}
 	}
 
+	// the bugs without a value to sort with are listed last, whatever the
+	// direction
+	var missing []*BugExcerpt
+	setApart := func(hasValue func(excerpt *BugExcerpt) bool) {
+		var kept []*BugExcerpt
+		for _, excerpt := range filtered {
+			if hasValue(excerpt) {
+				kept = append(kept, excerpt)
+			} else {
+				missing = append(missing, excerpt)
+			}
+		}
+		filtered = kept
+	}
+
 	var sorter sort.Interface
 
 	switch q.OrderBy {
@@ -271,6 +503,14 @@ func (c *RepoCache) QueryBugs(q *query.Query) []entity.Id {
 		sorter = BugsByCreationTime(filtered)
 	case query.OrderByEdit:
 		sorter = BugsByEditTime(filtered)
+	case query.OrderByComments:
+		sorter = BugsByCommentCount(filtered)
+	case query.OrderByDueDate:
+		setApart(func(excerpt *BugExcerpt) bool { return excerpt.DueUnixTime != 0 })
+		sorter = BugsByDueDate(filtered)
+	case query.OrderByPriority:
+		setApart(func(excerpt *BugExcerpt) bool { return excerpt.Fields[PriorityField] != "" })
+		sorter = BugsByPriority{Excerpts: filtered, Definition: schema[PriorityField]}
 	default:
 		panic("missing sort type")
 	}
@@ -285,6 +525,8 @@ func (c *RepoCache) QueryBugs(q *query.Query) []entity.Id {
 	}
 
 	sort.Sort(sorter)
+	sort.Sort(BugsById(missing))
+	filtered = append(filtered, missing...)
 
 	result := make([]entity.Id, len(filtered))
 
@@ -295,6 +537,153 @@ func (c *RepoCache) QueryBugs(q *query.Query) []entity.Id {
 	return result
 }
 
+// MatchBug tell if a bug match the filters of a query. The ordering of the
+// query is ignored.
+func (c *RepoCache) MatchBug(q *query.Query, id entity.Id) (bool, error) {
+	c.muBug.RLock()
+	defer c.muBug.RUnlock()
+
+	excerpt, ok := c.bugExcerpts[id]
+	if !ok {
+		return false, bug.ErrBugNotExist
+	}
+
+	if q == nil {
+		return true, nil
+	}
+
+	schema, _ := c.FieldSchema()
+
+	filters := q.Filters
+	if user, err := c.GetUserIdentity(); err == nil {
+		filters = resolveMe(filters, user.Id())
+	}
+
+	return compileMatcher(filters, schema).Match(excerpt, c), nil
+}
+
+// SearchBugs return the bugs containing all the words of the given text in
+// their title or comments, the most relevant first
+func (c *RepoCache) SearchBugs(text string) []entity.Id {
+	scores := c.search.search(text)
+
+	result := make([]entity.Id, 0, len(scores))
+	for id := range scores {
+		result = append(result, id)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if scores[result[i]] != scores[result[j]] {
+			return scores[result[i]] > scores[result[j]]
+		}
+		return result[i] < result[j]
+	})
+
+	return result
+}
+
+func (c *RepoCache) searchBugs(text string) map[entity.Id]int {
+	return c.search.search(text)
+}
+
+// WatchLevel resolve the notification preferences of an identity for a bug.
+// The explicit preferences on the bug and its labels win over the queries,
+// and an ignoring query win over a watching one.
+func (c *RepoCache) WatchLevel(i *IdentityCache, id entity.Id) (identity.WatchLevel, error) {
+	excerpt, err := c.ResolveBugExcerpt(id)
+	if err != nil {
+		return identity.WatchLevelNone, err
+	}
+
+	prefs := i.NotificationPreferences()
+
+	labels := make([]string, len(excerpt.Labels))
+	for j, label := range excerpt.Labels {
+		labels[j] = label.String()
+	}
+
+	if level := prefs.ForBug(id, labels); level != identity.WatchLevelNone {
+		return level, nil
+	}
+
+	schema, _ := c.FieldSchema()
+
+	result := identity.WatchLevelNone
+	for raw, level := range prefs.Queries {
+		q, err := query.Parse(raw)
+		if err != nil {
+			return identity.WatchLevelNone, fmt.Errorf("watched query %s: %v", raw, err)
+		}
+
+		if !compileMatcher(resolveMe(q.Filters, i.Id()), schema).Match(excerpt, c) {
+			continue
+		}
+
+		if level == identity.WatchLevelIgnore {
+			return level, nil
+		}
+		result = level
+	}
+
+	return result, nil
+}
+
+// ShouldNotify return whether an identity should be notified of the last
+// change of a bug: the bug is watched or the change mention the identity,
+// directly or through one of its teams, and the change is neither made by the
+// identity itself nor by an identity it muted. An ignored bug is never
+// notified.
+func (c *RepoCache) ShouldNotify(i *IdentityCache, id entity.Id) (bool, error) {
+	level, err := c.WatchLevel(i, id)
+	if err != nil || level == identity.WatchLevelIgnore {
+		return false, err
+	}
+
+	b, err := c.ResolveBug(id)
+	if err != nil {
+		return false, err
+	}
+
+	ops := b.Snapshot().Operations
+	if len(ops) == 0 {
+		return false, nil
+	}
+	last := ops[len(ops)-1]
+	author := last.GetAuthor().Id()
+
+	if author == i.Id() || i.NotificationPreferences().IsMuted(author) {
+		return false, nil
+	}
+
+	return level == identity.WatchLevelWatch || c.isMentioned(i, last), nil
+}
+
+// isMentioned return whether the message of an operation mention an
+// identity with @login, or a team it's a member of
+func (c *RepoCache) isMentioned(i *IdentityCache, op bug.Operation) bool {
+	var message string
+	switch op := op.(type) {
+	case *bug.CreateOperation:
+		message = op.Message
+	case *bug.AddCommentOperation:
+		message = op.Message
+	default:
+		return false
+	}
+
+	for _, handle := range identity.Mentions(message) {
+		if i.Login() != "" && strings.EqualFold(i.Login(), handle) {
+			return true
+		}
+		team, err := c.ResolveTeamExcerpt(handle)
+		if err == nil && team.IsMember(i.Id()) {
+			return true
+		}
+	}
+
+	return false
+}
+
 // AllBugsIds return all known bug ids
 func (c *RepoCache) AllBugsIds() []entity.Id {
 	c.muBug.RLock()
@@ -311,6 +700,104 @@ func (c *RepoCache) AllBugsIds() []entity.Id {
 	return result
 }
 
+// MergeDuplicate close a bug as a duplicate of a canonical one. If
+// copyComments is true, the comments of the duplicate are copied in the
+// canonical bug, with their origin recorded in the metadata.
+// Both bugs are committed.
+func (c *RepoCache) MergeDuplicate(duplicate *BugCache, canonical *BugCache, copyComments bool) error {
+	if c.readOnly {
+		return ErrReadOnly
+	}
+
+	if duplicate.Id() == canonical.Id() {
+		return fmt.Errorf("a bug can't be merged into itself")
+	}
+
+	author, err := c.GetUserIdentity()
+	if err != nil {
+		return err
+	}
+
+	if copyComments {
+		for _, comment := range duplicate.Snapshot().Comments {
+			if comment.Redaction != nil || comment.Message == "" {
+				continue
+			}
+
+			// don't copy twice when merging again
+			_, err := canonical.ResolveOperationWithMetadata(MergedFromCommentMetaKey, comment.Id().String())
+			if err == nil {
+				continue
+			}
+			if err != ErrNoMatchingOp {
+				return err
+			}
+
+			// keep the original author if possible
+			commentAuthor, err := c.ResolveIdentity(comment.Author.Id())
+			if err != nil {
+				commentAuthor = author
+			}
+
+			_, err = canonical.AddCommentRaw(commentAuthor, comment.UnixTime.Time().Unix(), comment.Message, comment.Files,
+				map[string]string{
+					MergedFromBugMetaKey:     duplicate.Id().String(),
+					MergedFromCommentMetaKey: comment.Id().String(),
+				})
+			if err != nil {
+				return err
+			}
+		}
+
+		if err := canonical.CommitAsNeeded(); err != nil {
+			return err
+		}
+	}
+
+	relation := bug.Relation{Type: bug.DuplicateOfRelation, Target: canonical.Id()}
+	related := false
+	for _, r := range duplicate.Snapshot().Relations {
+		if r == relation {
+			related = true
+		}
+	}
+
+	if !related {
+		_, err = duplicate.RelateRaw(author, time.Now().Unix(), bug.DuplicateOfRelation, canonical.Id(), nil)
+		if err != nil {
+			return err
+		}
+	}
+
+	if duplicate.Snapshot().Status != bug.ClosedStatus {
+		_, err = duplicate.CloseRaw(author, time.Now().Unix(), nil)
+		if err != nil {
+			return err
+		}
+	}
+
+	return duplicate.CommitAsNeeded()
+}
+
+// duplicatesOf return the ids of the bugs marked as duplicate of the given one
+func (c *RepoCache) duplicatesOf(id entity.Id) []entity.Id {
+	c.muBug.RLock()
+	defer c.muBug.RUnlock()
+
+	var result []entity.Id
+
+	for _, excerpt := range c.bugExcerpts {
+		for _, relation := range excerpt.Relations {
+			if relation.Type == bug.DuplicateOfRelation && relation.Target == id {
+				result = append(result, excerpt.Id)
+				break
+			}
+		}
+	}
+
+	return result
+}
+
 // ValidLabels list valid labels
 //
 // Note: in the future, a proper label policy could be implemented where valid
@@ -344,6 +831,187 @@ func (c *RepoCache) ValidLabels() []bug.Label {
 	return result
 }
 
+// FieldSchema return the custom fields defined for this repository
+func (c *RepoCache) FieldSchema() (bug.FieldSchema, error) {
+	return bug.ReadFieldSchema(c.repo.LocalConfig())
+}
+
+// LabelTaxonomy return the patterns restricting the labels allowed in this repository
+func (c *RepoCache) LabelTaxonomy() (bug.LabelTaxonomy, error) {
+	return bug.ReadLabelTaxonomy(c.repo.LocalConfig())
+}
+
+// StatusSet return the extended statuses defined for this repository, in order
+func (c *RepoCache) StatusSet() (bug.StatusSet, error) {
+	return bug.ReadStatusSet(c.repo.LocalConfig())
+}
+
+// SetFieldDefinition add or replace a custom field in the repository schema
+func (c *RepoCache) SetFieldDefinition(def bug.FieldDefinition) error {
+	return bug.StoreFieldDefinition(c.repo.LocalConfig(), def)
+}
+
+// RemoveFieldDefinition remove a custom field from the repository schema.
+// Values already set on bugs are kept untouched.
+func (c *RepoCache) RemoveFieldDefinition(name string) error {
+	return bug.RemoveFieldDefinition(c.repo.LocalConfig(), name)
+}
+
+// LabelRegistry return the labels defined for this repository
+func (c *RepoCache) LabelRegistry() (bug.LabelRegistry, error) {
+	return bug.ReadLabelRegistry(c.repo.LocalConfig())
+}
+
+// SetLabelDefinition add or replace a label in the repository registry
+func (c *RepoCache) SetLabelDefinition(def bug.LabelDefinition) error {
+	return bug.StoreLabelDefinition(c.repo.LocalConfig(), def)
+}
+
+// RemoveLabelDefinition remove a label from the repository registry.
+// The bugs carrying this label are left untouched.
+func (c *RepoCache) RemoveLabelDefinition(name bug.Label) error {
+	return bug.RemoveLabelDefinition(c.repo.LocalConfig(), name)
+}
+
+// RenameLabel rename a label in the repository registry, and replace it on
+// all the bugs carrying it. The number of changed bugs is returned.
+func (c *RepoCache) RenameLabel(oldName bug.Label, newName bug.Label) (int, error) {
+	author, err := c.GetUserIdentity()
+	if err != nil {
+		return 0, err
+	}
+
+	return c.RenameLabelRaw(author, time.Now().Unix(), oldName, newName)
+}
+
+// RenameLabelRaw is the same as RenameLabel, with the author and time of the
+// label changes on the bugs given explicitly.
+func (c *RepoCache) RenameLabelRaw(author *IdentityCache, unixTime int64, oldName bug.Label, newName bug.Label) (int, error) {
+	registry, err := c.LabelRegistry()
+	if err != nil {
+		return 0, err
+	}
+
+	if _, ok := registry[newName]; ok {
+		return 0, fmt.Errorf("label %s already exist", newName)
+	}
+
+	def, ok := registry[oldName]
+	if !ok {
+		return 0, fmt.Errorf("unknown label %s", oldName)
+	}
+
+	taxonomy, err := c.LabelTaxonomy()
+	if err != nil {
+		return 0, err
+	}
+	if err := taxonomy.ValidateLabels([]string{newName.String()}); err != nil {
+		return 0, err
+	}
+
+	def.Name = newName
+	if err := c.SetLabelDefinition(def); err != nil {
+		return 0, err
+	}
+	if err := c.RemoveLabelDefinition(oldName); err != nil {
+		return 0, err
+	}
+
+	c.muBug.RLock()
+	var ids []entity.Id
+	for id, excerpt := range c.bugExcerpts {
+		for _, l := range excerpt.Labels {
+			if l == oldName {
+				ids = append(ids, id)
+				break
+			}
+		}
+	}
+	c.muBug.RUnlock()
+
+	for i, id := range ids {
+		b, err := c.ResolveBug(id)
+		if err != nil {
+			return i, err
+		}
+
+		_, _, err = b.ChangeLabelsRaw(author, unixTime, []string{newName.String()}, []string{oldName.String()}, nil)
+		if err != nil {
+			return i, fmt.Errorf("%s: %v", id.Human(), err)
+		}
+
+		if err := b.Commit(); err != nil {
+			return i, err
+		}
+	}
+
+	return len(ids), nil
+}
+
+// MilestoneRegistry return the milestones defined for this repository
+func (c *RepoCache) MilestoneRegistry() (bug.MilestoneRegistry, error) {
+	return bug.ReadMilestoneRegistry(c.repo.LocalConfig())
+}
+
+// SetMilestoneDefinition add or replace a milestone in the repository registry
+func (c *RepoCache) SetMilestoneDefinition(def bug.MilestoneDefinition) error {
+	return bug.StoreMilestoneDefinition(c.repo.LocalConfig(), def)
+}
+
+// templateDir return the directory holding the bug templates, at the root of
+// the working tree
+func (c *RepoCache) templateDir() string {
+	path := c.repo.GetPath()
+	if filepath.Base(filepath.Clean(path)) == ".git" {
+		path = filepath.Dir(filepath.Clean(path))
+	}
+	return filepath.Join(path, ".git-bug", "templates")
+}
+
+// Templates return the bug templates available in the repository
+func (c *RepoCache) Templates() ([]*bug.Template, error) {
+	return bug.ReadTemplates(c.templateDir())
+}
+
+// ResolveTemplate retrieve a bug template by its name
+func (c *RepoCache) ResolveTemplate(name string) (*bug.Template, error) {
+	templates, err := c.Templates()
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, len(templates))
+	for i, tmpl := range templates {
+		if tmpl.Name == name {
+			return tmpl, nil
+		}
+		names[i] = tmpl.Name
+	}
+
+	if len(names) == 0 {
+		return nil, fmt.Errorf("unknown template %s, no template found in %s", name, c.templateDir())
+	}
+
+	return nil, fmt.Errorf("unknown template %s, available templates: %s", name, strings.Join(names, ", "))
+}
+
+// NewBugFromTemplate create a new bug with the labels and custom fields of the
+// given template
+// The new bug is written in the repository (commit)
+func (c *RepoCache) NewBugFromTemplate(tmpl *bug.Template, title string, message string) (*BugCache, *bug.CreateOperation, error) {
+	b, op, err := c.NewBug(title, message)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = b.ApplyTemplate(tmpl)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return b, op, nil
+}
+
 // NewBug create a new bug
 // The new bug is written in the repository (commit)
 func (c *RepoCache) NewBug(title string, message string) (*BugCache, *bug.CreateOperation, error) {
@@ -361,15 +1029,73 @@ func (c *RepoCache) NewBugWithFiles(title string, message string, files []reposi
 	return c.NewBugRaw(author, time.Now().Unix(), title, message, files, nil)
 }
 
+// NewBugOptions hold the optional settings of a new bug
+type NewBugOptions struct {
+	// Draft create a bug that won't be pushed until published
+	Draft bool
+	// Recipients, if any, make the bug confidential and encrypted for
+	// the public keys of those identities
+	Recipients []*IdentityCache
+	// CoAuthors are credited along the author for the creation of the bug
+	CoAuthors []*IdentityCache
+}
+
+// NewDraftBug create a new draft bug, that won't be pushed until published
+// The new bug is written in the repository (commit)
+func (c *RepoCache) NewDraftBug(title string, message string) (*BugCache, *bug.CreateOperation, error) {
+	return c.NewBugWithOptions(title, message, NewBugOptions{Draft: true})
+}
+
+// NewBugWithOptions create a new bug with the given optional settings
+// The new bug is written in the repository (commit)
+func (c *RepoCache) NewBugWithOptions(title string, message string, opts NewBugOptions) (*BugCache, *bug.CreateOperation, error) {
+	author, err := c.GetUserIdentity()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return c.newBugRaw(author, time.Now().Unix(), title, message, nil, nil, opts)
+}
+
 // NewBugWithFilesMeta create a new bug with attached files for the message, as
 // well as metadata for the Create operation.
 // The new bug is written in the repository (commit)
 func (c *RepoCache) NewBugRaw(author *IdentityCache, unixTime int64, title string, message string, files []repository.Hash, metadata map[string]string) (*BugCache, *bug.CreateOperation, error) {
-	b, op, err := bug.CreateWithFiles(author.Identity, unixTime, title, message, files)
+	return c.newBugRaw(author, unixTime, title, message, files, metadata, NewBugOptions{})
+}
+
+func (c *RepoCache) newBugRaw(author *IdentityCache, unixTime int64, title string, message string, files []repository.Hash, metadata map[string]string, opts NewBugOptions) (*BugCache, *bug.CreateOperation, error) {
+	if c.readOnly {
+		return nil, nil, ErrReadOnly
+	}
+
+	create := bug.CreateWithFiles
+	if opts.Draft {
+		create = bug.CreateDraftWithFiles
+	}
+
+	b, op, err := create(author.Identity, unixTime, title, message, files)
 	if err != nil {
 		return nil, nil, err
 	}
 
+	if len(opts.Recipients) > 0 {
+		recipients := make([]identity.Interface, len(opts.Recipients))
+		for i, recipient := range opts.Recipients {
+			recipients[i] = recipient.Identity
+		}
+		if err := b.SetRecipients(recipients); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if len(opts.CoAuthors) > 0 {
+		op.SetCoAuthors(coAuthorIdentities(opts.CoAuthors))
+		if err := op.Validate(); err != nil {
+			return nil, nil, err
+		}
+	}
+
 	for key, value := range metadata {
 		op.SetMetadata(key, value)
 	}
@@ -390,7 +1116,7 @@ func (c *RepoCache) NewBugRaw(author *IdentityCache, unixTime int64, title strin
 	c.loadedBugs.Add(b.Id())
 	c.muBug.Unlock()
 
-	c.evictIfNeeded()
+	c.evictIfNeeded(b.Id())
 
 	// force the write of the excerpt
 	err = c.bugUpdated(b.Id())
@@ -401,8 +1127,13 @@ func (c *RepoCache) NewBugRaw(author *IdentityCache, unixTime int64, title strin
 	return cached, op, nil
 }
 
-// RemoveBug removes a bug from the cache and repo given a bug id prefix
+// RemoveBug move a bug to the trash and removes it from the cache, given a
+// bug id prefix. It can be restored with RestoreBug.
 func (c *RepoCache) RemoveBug(prefix string) error {
+	if c.readOnly {
+		return ErrReadOnly
+	}
+
 	c.muBug.RLock()
 
 	b, err := c.ResolveBugPrefix(prefix)
@@ -412,14 +1143,26 @@ func (c *RepoCache) RemoveBug(prefix string) error {
 	}
 	c.muBug.RUnlock()
 
-	c.muBug.Lock()
-	err = bug.RemoveBug(c.repo, b.Id())
+	err = bug.TrashBug(c.repo, b.Id())
+	if err != nil {
+		return err
+	}
 
+	c.muBug.Lock()
 	delete(c.bugs, b.Id())
 	delete(c.bugExcerpts, b.Id())
+	c.bugIds.remove(b.Id())
 	c.loadedBugs.Remove(b.Id())
+	c.snapshots.remove(b.Id())
+	c.search.remove(b.Id())
 
 	c.muBug.Unlock()
 
-	return c.writeBugCache()
+	c.notifyChange(ChangeEvent{Typ: ChangeEventRemoved, Target: "bugs", Id: b.Id()})
+
+	err = c.writeBugCache()
+	if err != nil {
+		return err
+	}
+	return c.writeSearchIndex()
 }
@@ -0,0 +1,107 @@
+package cache
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/identity"
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+// bundleRemote is the remote the content of a bundle is merged from, as if it
+// was fetched
+const bundleRemote = "bundle"
+
+// CreateBundle write a git bundle with the given bugs, or all of them if ids
+// is nil, along with all the identities
+func (c *RepoCache) CreateBundle(w io.Writer, ids []entity.Id) error {
+	bundler, ok := c.repo.(repository.RepoBundle)
+	if !ok {
+		return fmt.Errorf("the repository doesn't support bundles")
+	}
+
+	identityRefs, err := identity.BundleRefs(c.repo, nil)
+	if err != nil {
+		return err
+	}
+
+	bugRefs, err := bug.BundleRefs(c.repo, ids)
+	if err != nil {
+		return err
+	}
+
+	if len(bugRefs) == 0 {
+		return fmt.Errorf("no bug to bundle")
+	}
+
+	return bundler.WriteBundle(w, append(identityRefs, bugRefs...))
+}
+
+// CreateIdentityBundle write a git bundle with only the given identities, to
+// carry them to another repository with the same id, versions and keys
+func (c *RepoCache) CreateIdentityBundle(w io.Writer, ids []entity.Id) error {
+	bundler, ok := c.repo.(repository.RepoBundle)
+	if !ok {
+		return fmt.Errorf("the repository doesn't support bundles")
+	}
+
+	refs, err := identity.BundleRefs(c.repo, ids)
+	if err != nil {
+		return err
+	}
+
+	if len(refs) == 0 {
+		return fmt.Errorf("no identity to bundle")
+	}
+
+	return bundler.WriteBundle(w, refs)
+}
+
+// ApplyBundle merge the bugs and identities of a git bundle, as a pull from a
+// remote would do
+func (c *RepoCache) ApplyBundle(r io.Reader) (<-chan entity.MergeResult, error) {
+	if c.readOnly {
+		return nil, ErrReadOnly
+	}
+
+	bundler, ok := c.repo.(repository.RepoBundle)
+	if !ok {
+		return nil, fmt.Errorf("the repository doesn't support bundles")
+	}
+
+	refs, err := bundler.ReadBundle(r)
+	if err != nil {
+		return nil, err
+	}
+
+	err = identity.TrackBundledRefs(c.repo, bundleRemote, refs)
+	if err != nil {
+		return nil, err
+	}
+	err = bug.TrackBundledRefs(c.repo, bundleRemote, refs)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(chan entity.MergeResult)
+
+	go func() {
+		defer close(out)
+
+		for result := range c.MergeAll(bundleRemote) {
+			out <- result
+		}
+
+		// unlike a remote, a bundle is applied once
+		if err := bug.RemoveRemoteRefs(c.repo, bundleRemote); err != nil {
+			out <- entity.NewMergeError(err, "")
+		}
+		if err := identity.RemoveRemoteRefs(c.repo, bundleRemote); err != nil {
+			out <- entity.NewMergeError(err, "")
+		}
+	}()
+
+	return out, nil
+}
@@ -2,13 +2,18 @@ package cache
 
 import (
 	"fmt"
+	"io"
+	"time"
 
 	"github.com/pkg/errors"
 
+	"github.com/MichaelMure/git-bug/archive"
+	"github.com/MichaelMure/git-bug/board"
 	"github.com/MichaelMure/git-bug/bug"
 	"github.com/MichaelMure/git-bug/entity"
 	"github.com/MichaelMure/git-bug/identity"
 	"github.com/MichaelMure/git-bug/repository"
+	"github.com/MichaelMure/git-bug/review"
 )
 
 func (c *RepoCache) Name() string {
@@ -69,6 +74,37 @@ func (c *RepoCache) StoreData(data []byte) (repository.Hash, error) {
 	return c.repo.StoreData(data)
 }
 
+// the git remote used by default to push and pull the bugs
+const defaultRemoteConfigKey = "git-bug.remote"
+
+// DefaultRemote return the git remote to push and pull the bugs with by
+// default, "origin" if none is configured
+func (c *RepoCache) DefaultRemote() (string, error) {
+	remote, err := c.repo.AnyConfig().ReadString(defaultRemoteConfigKey)
+	switch err {
+	case nil:
+		return remote, nil
+	case repository.ErrNoConfigEntry:
+		return "origin", nil
+	default:
+		return "", err
+	}
+}
+
+// SetDefaultRemote change the git remote to push and pull the bugs with by
+// default
+func (c *RepoCache) SetDefaultRemote(remote string) error {
+	remotes, err := c.repo.GetRemotes()
+	if err != nil {
+		return err
+	}
+	if _, ok := remotes[remote]; !ok {
+		return fmt.Errorf("unknown remote %s", remote)
+	}
+
+	return c.repo.LocalConfig().StoreString(defaultRemoteConfigKey, remote)
+}
+
 // Fetch retrieve updates from a remote
 // This does not change the local bugs or identities state
 func (c *RepoCache) Fetch(remote string) (string, error) {
@@ -82,17 +118,36 @@ func (c *RepoCache) Fetch(remote string) (string, error) {
 		return stdout2, err
 	}
 
-	return stdout1 + stdout2, nil
+	stdout3, err := board.Fetch(c.repo, remote)
+	if err != nil {
+		return stdout3, err
+	}
+
+	stdout4, err := review.Fetch(c.repo, remote)
+	if err != nil {
+		return stdout4, err
+	}
+
+	return stdout1 + stdout2 + stdout3 + stdout4, nil
 }
 
-// MergeAll will merge all the available remote bug and identities
+// MergeAll will merge all the available remote bug, boards, reviews and identities
 func (c *RepoCache) MergeAll(remote string) <-chan entity.MergeResult {
 	out := make(chan entity.MergeResult)
 
-	// Intercept merge results to update the cache properly
+	// Intercept merge results to update the cache properly.
+	// Only the entities whose ref moved are updated, the rest of the cache
+	// is left untouched.
 	go func() {
 		defer close(out)
 
+		if c.readOnly {
+			out <- entity.MergeResult{Err: ErrReadOnly}
+			return
+		}
+
+		changed := false
+
 		results := identity.MergeAll(c.repo, remote)
 		for result := range results {
 			out <- result
@@ -106,10 +161,18 @@ func (c *RepoCache) MergeAll(remote string) <-chan entity.MergeResult {
 				i := result.Entity.(*identity.Identity)
 				c.muIdentity.Lock()
 				c.identitiesExcerpts[result.Id] = NewIdentityExcerpt(i)
+				c.identityIds.insert(result.Id)
 				c.muIdentity.Unlock()
+				changed = true
 			}
 		}
 
+		pull := &PullRecord{
+			Remote:  remote,
+			Time:    time.Now(),
+			Updated: make(map[entity.Id]*BugExcerpt),
+		}
+
 		results = bug.MergeAll(c.repo, remote)
 		for result := range results {
 			out <- result
@@ -121,24 +184,89 @@ func (c *RepoCache) MergeAll(remote string) <-chan entity.MergeResult {
 			switch result.Status {
 			case entity.MergeStatusNew, entity.MergeStatusUpdated:
 				b := result.Entity.(*bug.Bug)
-				snap := b.Compile()
+				snap := c.compileUpdatedBug(b)
 				c.muBug.Lock()
-				c.bugExcerpts[result.Id] = NewBugExcerpt(b, &snap)
+				if previous, ok := c.bugExcerpts[result.Id]; ok {
+					pull.Updated[result.Id] = previous
+				} else {
+					pull.New = append(pull.New, result.Id)
+				}
+				c.bugExcerpts[result.Id] = NewBugExcerpt(b, snap)
+				c.bugIds.insert(result.Id)
+				c.search.index(result.Id, snap)
 				c.muBug.Unlock()
+				changed = true
 			}
 		}
 
+		// boards and reviews are not cached, nothing to update
+		for result := range board.MergeAll(c.repo, remote) {
+			out <- result
+		}
+		for result := range review.MergeAll(c.repo, remote) {
+			out <- result
+		}
+
+		if !changed {
+			return
+		}
+
 		err := c.write()
 
 		// No easy way out here ..
 		if err != nil {
 			panic(err)
 		}
+
+		if len(pull.New) > 0 || len(pull.Updated) > 0 {
+			if err := c.writeLastPull(pull); err != nil {
+				out <- entity.NewMergeError(err, "")
+			}
+		}
 	}()
 
 	return out
 }
 
+// archiveRemote is the temporary remote under which an archive is imported
+// before being merged
+const archiveRemote = "git-bug-archive"
+
+// ExportArchive write in w an archive of the identities and of the given bugs,
+// or of all of them if ids is empty
+func (c *RepoCache) ExportArchive(w io.Writer, ids []entity.Id) (*archive.Archive, error) {
+	return archive.Export(c.repo, w, ids)
+}
+
+// ImportArchive read an archive from r and merge its identities and bugs,
+// like after a fetch
+func (c *RepoCache) ImportArchive(r io.Reader) (<-chan entity.MergeResult, error) {
+	a, err := archive.Import(c.repo, r, archiveRemote)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(chan entity.MergeResult)
+
+	go func() {
+		defer close(out)
+
+		for result := range c.MergeAll(archiveRemote) {
+			out <- result
+		}
+
+		// the temporary refs are not needed anymore
+		for _, e := range a.Identities {
+			_ = c.repo.RemoveRef(fmt.Sprintf("refs/remotes/%s/identities/%s", archiveRemote, e.Id))
+		}
+		for _, e := range a.Bugs {
+			_ = c.repo.RemoveRef(fmt.Sprintf("refs/remotes/%s/bugs/%s", archiveRemote, e.Id))
+		}
+	}()
+
+	return out, nil
+}
+
 // Push update a remote with the local changes
 func (c *RepoCache) Push(remote string) (string, error) {
 	stdout1, err := identity.Push(c.repo, remote)
@@ -151,7 +279,17 @@ func (c *RepoCache) Push(remote string) (string, error) {
 		return stdout2, err
 	}
 
-	return stdout1 + stdout2, nil
+	stdout3, err := board.Push(c.repo, remote)
+	if err != nil {
+		return stdout3, err
+	}
+
+	stdout4, err := review.Push(c.repo, remote)
+	if err != nil {
+		return stdout4, err
+	}
+
+	return stdout1 + stdout2 + stdout3 + stdout4, nil
 }
 
 // Pull will do a Fetch + MergeAll
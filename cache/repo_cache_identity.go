@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"os"
 	"path"
+	"strings"
+	"time"
 
 	"github.com/MichaelMure/git-bug/entity"
 	"github.com/MichaelMure/git-bug/identity"
@@ -21,6 +23,10 @@ func identityCacheFilePath(repo repository.Repo) string {
 // identityUpdated is a callback to trigger when the excerpt of an identity
 // changed, that is each time an identity is updated
 func (c *RepoCache) identityUpdated(id entity.Id) error {
+	if c.readOnly {
+		return ErrReadOnly
+	}
+
 	c.muIdentity.Lock()
 
 	i, ok := c.identities[id]
@@ -29,9 +35,17 @@ func (c *RepoCache) identityUpdated(id entity.Id) error {
 		panic("missing identity in the cache")
 	}
 
+	_, existed := c.identitiesExcerpts[id]
 	c.identitiesExcerpts[id] = NewIdentityExcerpt(i.Identity)
+	c.identityIds.insert(id)
 	c.muIdentity.Unlock()
 
+	typ := ChangeEventUpdated
+	if !existed {
+		typ = ChangeEventAdded
+	}
+	c.notifyChange(ChangeEvent{Typ: typ, Target: "identities", Id: id})
+
 	// we only need to write the identity cache
 	return c.writeIdentityCache()
 }
@@ -45,24 +59,33 @@ func (c *RepoCache) loadIdentityCache() error {
 	if err != nil {
 		return err
 	}
+	defer f.Close()
 
 	decoder := gob.NewDecoder(f)
 
-	aux := struct {
-		Version  uint
-		Excerpts map[entity.Id]*IdentityExcerpt
-	}{}
-
-	err = decoder.Decode(&aux)
+	version, err := readCacheHeader(decoder)
 	if err != nil {
 		return err
 	}
 
-	if aux.Version != formatVersion {
-		return fmt.Errorf("unknown cache format version %v", aux.Version)
+	// the identity excerpts have no migration, but they can be loaded as is
+	// when the bug excerpts are migrated
+	if version != formatVersion && !canMigrate(version) {
+		return &ErrFormatVersion{File: identityCacheFile, Found: version, Expected: formatVersion}
 	}
 
-	c.identitiesExcerpts = aux.Excerpts
+	var excerpts map[entity.Id]*IdentityExcerpt
+
+	err = decoder.Decode(&excerpts)
+	if err != nil {
+		return err
+	}
+
+	c.identitiesExcerpts = excerpts
+	c.identityIds = newIdTree()
+	for id := range excerpts {
+		c.identityIds.insert(id)
+	}
 	return nil
 }
 
@@ -73,32 +96,19 @@ func (c *RepoCache) writeIdentityCache() error {
 
 	var data bytes.Buffer
 
-	aux := struct {
-		Version  uint
-		Excerpts map[entity.Id]*IdentityExcerpt
-	}{
-		Version:  formatVersion,
-		Excerpts: c.identitiesExcerpts,
-	}
-
 	encoder := gob.NewEncoder(&data)
 
-	err := encoder.Encode(aux)
-	if err != nil {
-		return err
-	}
-
-	f, err := os.Create(identityCacheFilePath(c.repo))
+	err := writeCacheHeader(encoder, formatVersion)
 	if err != nil {
 		return err
 	}
 
-	_, err = f.Write(data.Bytes())
+	err = encoder.Encode(c.identitiesExcerpts)
 	if err != nil {
 		return err
 	}
 
-	return f.Close()
+	return writeFileAtomic(identityCacheFilePath(c.repo), data.Bytes())
 }
 
 // ResolveIdentityExcerpt retrieve a IdentityExcerpt matching the exact given id
@@ -116,10 +126,13 @@ func (c *RepoCache) ResolveIdentityExcerpt(id entity.Id) (*IdentityExcerpt, erro
 
 // ResolveIdentity retrieve an identity matching the exact given id
 func (c *RepoCache) ResolveIdentity(id entity.Id) (*IdentityCache, error) {
+	start := time.Now()
+
 	c.muIdentity.RLock()
 	cached, ok := c.identities[id]
 	c.muIdentity.RUnlock()
 	if ok {
+		c.metrics.ObserveResolve(c.name, "identities", true, time.Since(start))
 		return cached, nil
 	}
 
@@ -134,23 +147,57 @@ func (c *RepoCache) ResolveIdentity(id entity.Id) (*IdentityCache, error) {
 	c.identities[id] = cached
 	c.muIdentity.Unlock()
 
+	c.metrics.ObserveResolve(c.name, "identities", false, time.Since(start))
+
 	return cached, nil
 }
 
 // ResolveIdentityExcerptPrefix retrieve a IdentityExcerpt matching an id prefix.
 // It fails if multiple identities match.
 func (c *RepoCache) ResolveIdentityExcerptPrefix(prefix string) (*IdentityExcerpt, error) {
-	return c.ResolveIdentityExcerptMatcher(func(excerpt *IdentityExcerpt) bool {
-		return excerpt.Id.HasPrefix(prefix)
-	})
+	id, err := c.resolveIdentityPrefix(prefix)
+	if err != nil {
+		return nil, err
+	}
+	return c.ResolveIdentityExcerpt(id)
 }
 
 // ResolveIdentityPrefix retrieve an Identity matching an id prefix.
 // It fails if multiple identities match.
 func (c *RepoCache) ResolveIdentityPrefix(prefix string) (*IdentityCache, error) {
-	return c.ResolveIdentityMatcher(func(excerpt *IdentityExcerpt) bool {
-		return excerpt.Id.HasPrefix(prefix)
-	})
+	id, err := c.resolveIdentityPrefix(prefix)
+	if err != nil {
+		return nil, err
+	}
+	return c.ResolveIdentity(id)
+}
+
+func (c *RepoCache) resolveIdentityPrefix(prefix string) (entity.Id, error) {
+	c.muIdentity.RLock()
+	defer c.muIdentity.RUnlock()
+
+	switch c.identityIds.countPrefix(prefix) {
+	case 0:
+		return entity.UnsetId, identity.ErrIdentityNotExist
+	case 1:
+		return c.identityIds.match(prefix)[0], nil
+	default:
+		return entity.UnsetId, identity.NewErrMultipleMatch(c.identityIds.match(prefix))
+	}
+}
+
+// UniqueIdentityPrefix return the shortest prefix of the id of an identity
+// that doesn't match any other identity. It return the full id if the
+// identity is unknown.
+func (c *RepoCache) UniqueIdentityPrefix(id entity.Id) string {
+	c.muIdentity.RLock()
+	defer c.muIdentity.RUnlock()
+
+	length := c.identityIds.shortestUniquePrefix(id)
+	if length == 0 {
+		return id.String()
+	}
+	return id.String()[:length]
 }
 
 // ResolveIdentityImmutableMetadata retrieve an Identity that has the exact given metadata on
@@ -161,6 +208,25 @@ func (c *RepoCache) ResolveIdentityImmutableMetadata(key string, value string) (
 	})
 }
 
+// ResolveTeamExcerpt retrieve the excerpt of the team with the given handle,
+// with or without the @ prefix
+func (c *RepoCache) ResolveTeamExcerpt(handle string) (*IdentityExcerpt, error) {
+	handle = strings.TrimPrefix(handle, "@")
+	return c.ResolveIdentityExcerptMatcher(func(excerpt *IdentityExcerpt) bool {
+		return excerpt.Team && strings.EqualFold(excerpt.Login, handle)
+	})
+}
+
+// ResolveTeam retrieve the team with the given handle, with or without the @
+// prefix
+func (c *RepoCache) ResolveTeam(handle string) (*IdentityCache, error) {
+	excerpt, err := c.ResolveTeamExcerpt(handle)
+	if err != nil {
+		return nil, err
+	}
+	return c.ResolveIdentity(excerpt.Id)
+}
+
 func (c *RepoCache) ResolveIdentityExcerptMatcher(f func(*IdentityExcerpt) bool) (*IdentityExcerpt, error) {
 	id, err := c.resolveIdentityMatcher(f)
 	if err != nil {
@@ -190,6 +256,10 @@ func (c *RepoCache) resolveIdentityMatcher(f func(*IdentityExcerpt) bool) (entit
 		}
 	}
 
+	if len(matching) > 1 {
+		matching = c.withoutAdopted(matching)
+	}
+
 	if len(matching) > 1 {
 		return entity.UnsetId, identity.NewErrMultipleMatch(matching)
 	}
@@ -201,6 +271,26 @@ func (c *RepoCache) resolveIdentityMatcher(f func(*IdentityExcerpt) bool) (entit
 	return matching[0], nil
 }
 
+// withoutAdopted drop the identities adopted by another identity of the set,
+// as the adopting one stand for them
+func (c *RepoCache) withoutAdopted(ids []entity.Id) []entity.Id {
+	result := make([]entity.Id, 0, len(ids))
+	for _, id := range ids {
+		adopted := false
+		key := identity.AdoptedMetadataKey(id)
+		for _, other := range ids {
+			if _, ok := c.identitiesExcerpts[other].ImmutableMetadata[key]; ok {
+				adopted = true
+				break
+			}
+		}
+		if !adopted {
+			result = append(result, id)
+		}
+	}
+	return result
+}
+
 // AllIdentityIds return all known identity ids
 func (c *RepoCache) AllIdentityIds() []entity.Id {
 	c.muIdentity.RLock()
@@ -241,12 +331,29 @@ func (c *RepoCache) NewIdentityFull(name string, email string, login string, ava
 	return c.NewIdentityRaw(name, email, login, avatarUrl, nil)
 }
 
+// NewTeam create a new team, designated by its handle to be assigned or
+// mentioned as @handle
+// The new team is written in the repository (commit)
+func (c *RepoCache) NewTeam(name string, handle string) (*IdentityCache, error) {
+	if !identity.ValidTeamHandle(handle) {
+		return nil, fmt.Errorf("invalid team handle \"%s\"", handle)
+	}
+	if _, err := c.ResolveTeamExcerpt(handle); err != identity.ErrIdentityNotExist {
+		return nil, fmt.Errorf("a team @%s already exist", handle)
+	}
+	return c.finishIdentity(identity.NewTeam(name, handle), nil)
+}
+
 func (c *RepoCache) NewIdentityRaw(name string, email string, login string, avatarUrl string, metadata map[string]string) (*IdentityCache, error) {
 	i := identity.NewIdentityFull(name, email, login, avatarUrl)
 	return c.finishIdentity(i, metadata)
 }
 
 func (c *RepoCache) finishIdentity(i *identity.Identity, metadata map[string]string) (*IdentityCache, error) {
+	if c.readOnly {
+		return nil, ErrReadOnly
+	}
+
 	for key, value := range metadata {
 		i.SetMetadata(key, value)
 	}
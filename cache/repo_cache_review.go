@@ -0,0 +1,80 @@
+package cache
+
+import (
+	"time"
+
+	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/repository"
+	"github.com/MichaelMure/git-bug/review"
+)
+
+// AllReviewIds return all known review ids
+func (c *RepoCache) AllReviewIds() ([]entity.Id, error) {
+	return review.ListLocalIds(c.repo)
+}
+
+// ResolveReview retrieve a review matching the exact given id
+func (c *RepoCache) ResolveReview(id entity.Id) (*ReviewCache, error) {
+	r, err := review.ReadLocal(c.repo, newIdentityCacheResolver(c), id)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewReviewCache(c, r), nil
+}
+
+// ResolveReviewPrefix retrieve a review matching an id prefix. It fails if multiple
+// reviews match.
+func (c *RepoCache) ResolveReviewPrefix(prefix string) (*ReviewCache, error) {
+	ids, err := c.AllReviewIds()
+	if err != nil {
+		return nil, err
+	}
+
+	var matching []entity.Id
+	for _, id := range ids {
+		if id.HasPrefix(prefix) {
+			matching = append(matching, id)
+		}
+	}
+
+	if len(matching) > 1 {
+		return nil, review.NewErrMultipleMatchReview(matching)
+	}
+
+	if len(matching) == 0 {
+		return nil, review.ErrReviewNotExist
+	}
+
+	return c.ResolveReview(matching[0])
+}
+
+// ResolveRevision return the hash of the commit designated by a git revision
+func (c *RepoCache) ResolveRevision(rev string) (repository.Hash, error) {
+	return c.repo.ResolveRevision(rev)
+}
+
+// NewReview create a new review of the base..head range of commits
+// The new review is written in the repository (commit)
+func (c *RepoCache) NewReview(title string, description string, base, head repository.Hash) (*ReviewCache, error) {
+	if c.readOnly {
+		return nil, ErrReadOnly
+	}
+
+	author, err := c.GetUserIdentity()
+	if err != nil {
+		return nil, err
+	}
+
+	r, _, err := review.Create(author.Identity, time.Now().Unix(), title, description, base, head)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.Commit(c.repo)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewReviewCache(c, r), nil
+}
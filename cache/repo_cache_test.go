@@ -1,12 +1,23 @@
 package cache
 
 import (
+	"bytes"
+	"encoding/gob"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/openpgp"
+	"golang.org/x/crypto/openpgp/armor"
 
 	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/identity"
 	"github.com/MichaelMure/git-bug/query"
 	"github.com/MichaelMure/git-bug/repository"
 )
@@ -159,6 +170,14 @@ func TestPushPull(t *testing.T) {
 	require.NoError(t, err)
 
 	require.Len(t, cacheA.AllBugsIds(), 2)
+
+	// the changes of the last pull are recorded
+	pull, err := cacheA.LastPull()
+	require.NoError(t, err)
+	require.NotNil(t, pull)
+	require.Equal(t, "origin", pull.Remote)
+	require.Len(t, pull.New, 1)
+	require.Empty(t, pull.Updated)
 }
 
 func TestRemove(t *testing.T) {
@@ -208,6 +227,22 @@ func TestRemove(t *testing.T) {
 
 	_, err = repoCache.ResolveBug(b1.Id())
 	assert.Error(t, bug.ErrBugNotExist, err)
+
+	// the bug is kept in the trash
+	id, err := repoCache.ResolveTrashedBugPrefix(b1.Id().Human())
+	require.NoError(t, err)
+	require.Equal(t, b1.Id(), id)
+
+	err = repoCache.RestoreBug(id)
+	require.NoError(t, err)
+	assert.Equal(t, 2, len(repoCache.bugExcerpts))
+
+	_, err = repoCache.ResolveBug(b1.Id())
+	require.NoError(t, err)
+
+	trashed, err := repoCache.TrashedBugs()
+	require.NoError(t, err)
+	assert.Empty(t, trashed)
 }
 
 func TestCacheEviction(t *testing.T) {
@@ -263,6 +298,55 @@ func TestCacheEviction(t *testing.T) {
 	require.Equal(t, 2, len(repoCache.bugs))
 }
 
+func TestCacheMemoryBudget(t *testing.T) {
+	repo := repository.CreateGoGitTestRepo(false)
+	defer repository.CleanupTestRepos(repo)
+
+	// room for two bugs with a single operation
+	err := repo.LocalConfig().StoreString(memoryBudgetConfigKey, "5KiB")
+	require.NoError(t, err)
+
+	repoCache, err := NewRepoCache(repo)
+	require.NoError(t, err)
+	require.Equal(t, uint64(5*1024), repoCache.memoryBudget)
+
+	rene, err := repoCache.NewIdentity("René Descartes", "rene@descartes.fr")
+	require.NoError(t, err)
+	err = repoCache.SetUserIdentity(rene)
+	require.NoError(t, err)
+
+	bug1, _, err := repoCache.NewBug("title", "message")
+	require.NoError(t, err)
+	bug2, _, err := repoCache.NewBug("title", "message")
+	require.NoError(t, err)
+	bug3, _, err := repoCache.NewBug("title", "message")
+	require.NoError(t, err)
+
+	checkBugPresence(t, repoCache, bug1, false)
+	checkBugPresence(t, repoCache, bug2, true)
+	checkBugPresence(t, repoCache, bug3, true)
+
+	// evicted bugs are still queryable through their excerpt
+	require.Len(t, repoCache.AllBugsIds(), 3)
+	_, err = repoCache.ResolveBugExcerpt(bug1.Id())
+	require.NoError(t, err)
+
+	// the bug being returned is never evicted, even alone over the budget
+	repoCache.memoryBudget = 1
+	bug4, _, err := repoCache.NewBug("title", "message")
+	require.NoError(t, err)
+	checkBugPresence(t, repoCache, bug2, false)
+	checkBugPresence(t, repoCache, bug3, false)
+	checkBugPresence(t, repoCache, bug4, true)
+
+	// an invalid budget is rejected
+	require.NoError(t, repoCache.Close())
+	err = repo.LocalConfig().StoreString(memoryBudgetConfigKey, "a lot")
+	require.NoError(t, err)
+	_, err = NewRepoCache(repo)
+	require.Error(t, err)
+}
+
 func checkBugPresence(t *testing.T, cache *RepoCache, bug *BugCache, presence bool) {
 	id := bug.Id()
 	require.Equal(t, presence, cache.loadedBugs.Contains(id))
@@ -272,3 +356,1223 @@ func checkBugPresence(t *testing.T, cache *RepoCache, bug *BugCache, presence bo
 		require.Equal(t, bug, b)
 	}
 }
+
+func TestMergeDuplicate(t *testing.T) {
+	repo := repository.CreateGoGitTestRepo(false)
+	defer repository.CleanupTestRepos(repo)
+
+	cache, err := NewRepoCache(repo)
+	require.NoError(t, err)
+
+	iden1, err := cache.NewIdentity("René Descartes", "rene@descartes.fr")
+	require.NoError(t, err)
+	err = cache.SetUserIdentity(iden1)
+	require.NoError(t, err)
+
+	canonical, _, err := cache.NewBug("crash on start", "message")
+	require.NoError(t, err)
+
+	duplicate, _, err := cache.NewBug("crash", "stacktrace")
+	require.NoError(t, err)
+	_, err = duplicate.AddComment("same here")
+	require.NoError(t, err)
+	require.NoError(t, duplicate.Commit())
+
+	require.Error(t, cache.MergeDuplicate(canonical, canonical, true))
+
+	require.NoError(t, cache.MergeDuplicate(duplicate, canonical, true))
+
+	require.Equal(t, bug.ClosedStatus, duplicate.Snapshot().Status)
+	require.Equal(t, []bug.Relation{{Type: bug.DuplicateOfRelation, Target: canonical.Id()}},
+		duplicate.Snapshot().Relations)
+
+	comments := canonical.Snapshot().Comments
+	require.Len(t, comments, 3)
+	require.Equal(t, "stacktrace", comments[1].Message)
+	require.Equal(t, "same here", comments[2].Message)
+
+	// merging again doesn't copy the comments twice
+	require.NoError(t, cache.MergeDuplicate(duplicate, canonical, true))
+	require.Len(t, canonical.Snapshot().Comments, 3)
+
+	// the duplicate is hidden by default
+	q, err := query.Parse("status:closed")
+	require.NoError(t, err)
+	require.Empty(t, cache.QueryBugs(q))
+
+	q, err = query.Parse("status:closed with:duplicates")
+	require.NoError(t, err)
+	require.Equal(t, []entity.Id{duplicate.Id()}, cache.QueryBugs(q))
+}
+
+func TestWatchLevel(t *testing.T) {
+	repo := repository.CreateGoGitTestRepo(false)
+	defer repository.CleanupTestRepos(repo)
+
+	cache, err := NewRepoCache(repo)
+	require.NoError(t, err)
+
+	iden1, err := cache.NewIdentity("René Descartes", "rene@descartes.fr")
+	require.NoError(t, err)
+	err = cache.SetUserIdentity(iden1)
+	require.NoError(t, err)
+
+	bug1, _, err := cache.NewBug("crash on start", "message")
+	require.NoError(t, err)
+	_, _, err = bug1.ChangeLabels([]string{"security"}, nil)
+	require.NoError(t, err)
+
+	bug2, _, err := cache.NewBug("typo", "message")
+	require.NoError(t, err)
+	_, err = bug2.Close()
+	require.NoError(t, err)
+
+	bug3, _, err := cache.NewBug("slow", "message")
+	require.NoError(t, err)
+
+	err = iden1.Mutate(func(orig identity.Mutator) identity.Mutator {
+		orig.Notifications.SetLabel("security", identity.WatchLevelWatch)
+		orig.Notifications.SetQuery("status:closed", identity.WatchLevelIgnore)
+		orig.Notifications.SetBug(bug3.Id(), identity.WatchLevelWatch)
+		return orig
+	})
+	require.NoError(t, err)
+	require.NoError(t, iden1.Commit())
+
+	level, err := cache.WatchLevel(iden1, bug1.Id())
+	require.NoError(t, err)
+	require.Equal(t, identity.WatchLevelWatch, level)
+
+	level, err = cache.WatchLevel(iden1, bug2.Id())
+	require.NoError(t, err)
+	require.Equal(t, identity.WatchLevelIgnore, level)
+
+	level, err = cache.WatchLevel(iden1, bug3.Id())
+	require.NoError(t, err)
+	require.Equal(t, identity.WatchLevelWatch, level)
+
+	// the own changes of the identity are not notified
+	notify, err := cache.ShouldNotify(iden1, bug3.Id())
+	require.NoError(t, err)
+	require.False(t, notify)
+
+	iden2, err := cache.NewIdentity("Isaac Newton", "isaac@newton.uk")
+	require.NoError(t, err)
+	_, err = bug3.AddCommentRaw(iden2, time.Now().Unix(), "a comment", nil, nil)
+	require.NoError(t, err)
+
+	notify, err = cache.ShouldNotify(iden1, bug3.Id())
+	require.NoError(t, err)
+	require.True(t, notify)
+
+	notify, err = cache.ShouldNotify(iden1, bug2.Id())
+	require.NoError(t, err)
+	require.False(t, notify)
+
+	err = iden1.Mutate(func(orig identity.Mutator) identity.Mutator {
+		orig.Notifications.SetMuted(iden2.Id(), true)
+		return orig
+	})
+	require.NoError(t, err)
+	require.NoError(t, iden1.Commit())
+
+	notify, err = cache.ShouldNotify(iden1, bug3.Id())
+	require.NoError(t, err)
+	require.False(t, notify)
+}
+
+func TestTeams(t *testing.T) {
+	repo := repository.CreateGoGitTestRepo(false)
+	defer repository.CleanupTestRepos(repo)
+
+	cache, err := NewRepoCache(repo)
+	require.NoError(t, err)
+
+	iden1, err := cache.NewIdentity("René Descartes", "rene@descartes.fr")
+	require.NoError(t, err)
+	err = cache.SetUserIdentity(iden1)
+	require.NoError(t, err)
+
+	iden2, err := cache.NewIdentity("Isaac Newton", "isaac@newton.uk")
+	require.NoError(t, err)
+
+	team, err := cache.NewTeam("Backend team", "backend-team")
+	require.NoError(t, err)
+	require.True(t, team.IsTeam())
+
+	_, err = cache.NewTeam("", "backend-team")
+	require.Error(t, err)
+	_, err = cache.NewTeam("", "not a handle")
+	require.Error(t, err)
+
+	err = team.Mutate(func(orig identity.Mutator) identity.Mutator {
+		orig.Members = append(orig.Members, iden2.Id())
+		return orig
+	})
+	require.NoError(t, err)
+	require.NoError(t, team.Commit())
+
+	excerpt, err := cache.ResolveTeamExcerpt("@backend-team")
+	require.NoError(t, err)
+	require.True(t, excerpt.IsMember(iden2.Id()))
+
+	toTeam, _, err := cache.NewBug("assigned to the team", "message")
+	require.NoError(t, err)
+	_, err = toTeam.Assign([]*IdentityCache{team}, nil)
+	require.NoError(t, err)
+
+	toMember, _, err := cache.NewBug("assigned to a member", "message")
+	require.NoError(t, err)
+	_, err = toMember.Assign([]*IdentityCache{iden2}, nil)
+	require.NoError(t, err)
+
+	toOther, _, err := cache.NewBug("assigned to someone else", "message")
+	require.NoError(t, err)
+	_, err = toOther.Assign([]*IdentityCache{iden1}, nil)
+	require.NoError(t, err)
+
+	q, err := query.Parse("assignee:@backend-team")
+	require.NoError(t, err)
+	require.ElementsMatch(t, []entity.Id{toTeam.Id(), toMember.Id()}, cache.QueryBugs(q))
+
+	// the members of a mentioned team are notified
+	_, err = toOther.AddComment("@backend-team, could you have a look?")
+	require.NoError(t, err)
+
+	notify, err := cache.ShouldNotify(iden2, toOther.Id())
+	require.NoError(t, err)
+	require.True(t, notify)
+}
+
+func TestCoAuthorsQuery(t *testing.T) {
+	repo := repository.CreateGoGitTestRepo(false)
+	defer repository.CleanupTestRepos(repo)
+
+	cache, err := NewRepoCache(repo)
+	require.NoError(t, err)
+
+	iden1, err := cache.NewIdentity("René Descartes", "rene@descartes.fr")
+	require.NoError(t, err)
+	err = cache.SetUserIdentity(iden1)
+	require.NoError(t, err)
+
+	iden2, err := cache.NewIdentity("Isaac Newton", "isaac@newton.uk")
+	require.NoError(t, err)
+
+	iden3, err := cache.NewIdentity("Blaise Pascal", "blaise@pascal.fr")
+	require.NoError(t, err)
+
+	paired, _, err := cache.NewBugWithOptions("paired", "message", NewBugOptions{
+		CoAuthors: []*IdentityCache{iden2},
+	})
+	require.NoError(t, err)
+
+	alone, _, err := cache.NewBug("alone", "message")
+	require.NoError(t, err)
+	_, err = alone.AddCommentWithCoAuthors("with some help", []*IdentityCache{iden3})
+	require.NoError(t, err)
+
+	q, err := query.Parse("author:newton")
+	require.NoError(t, err)
+	require.Equal(t, []entity.Id{paired.Id()}, cache.QueryBugs(q))
+
+	q, err = query.Parse("actor:pascal")
+	require.NoError(t, err)
+	require.Equal(t, []entity.Id{alone.Id()}, cache.QueryBugs(q))
+}
+
+func TestPlanningExcerpt(t *testing.T) {
+	repo := repository.CreateGoGitTestRepo(false)
+	defer repository.CleanupTestRepos(repo)
+
+	cache, err := NewRepoCache(repo)
+	require.NoError(t, err)
+
+	iden1, err := cache.NewIdentity("René Descartes", "rene@descartes.fr")
+	require.NoError(t, err)
+	err = cache.SetUserIdentity(iden1)
+	require.NoError(t, err)
+
+	iden2, err := cache.NewIdentity("Isaac Newton", "isaac@newton.uk")
+	require.NoError(t, err)
+
+	bug1, _, err := cache.NewBug("title", "message")
+	require.NoError(t, err)
+
+	_, err = bug1.Assign([]*IdentityCache{iden1, iden2}, nil)
+	require.NoError(t, err)
+	_, err = bug1.Assign(nil, []*IdentityCache{iden1})
+	require.NoError(t, err)
+	_, err = bug1.SetMilestone("v1.0")
+	require.NoError(t, err)
+	due := time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC)
+	_, err = bug1.SetDueDate(due)
+	require.NoError(t, err)
+	_, err = bug1.AddComment("comment")
+	require.NoError(t, err)
+
+	check := func(cache *RepoCache) {
+		excerpt, err := cache.ResolveBugExcerpt(bug1.Id())
+		require.NoError(t, err)
+		require.Equal(t, []entity.Id{iden2.Id()}, excerpt.AssigneeIds)
+		require.Equal(t, "v1.0", excerpt.Milestone)
+		require.True(t, due.Equal(excerpt.DueTime()))
+		require.Equal(t, 2, excerpt.LenComments)
+	}
+
+	check(cache)
+
+	// the excerpt survive a round trip on disk
+	require.NoError(t, cache.Close())
+	cache, err = NewRepoCache(repo)
+	require.NoError(t, err)
+	check(cache)
+	require.NoError(t, cache.Close())
+}
+
+func TestTimeSpentExcerpt(t *testing.T) {
+	repo := repository.CreateGoGitTestRepo(false)
+	defer repository.CleanupTestRepos(repo)
+
+	cache, err := NewRepoCache(repo)
+	require.NoError(t, err)
+
+	iden1, err := cache.NewIdentity("René Descartes", "rene@descartes.fr")
+	require.NoError(t, err)
+	err = cache.SetUserIdentity(iden1)
+	require.NoError(t, err)
+
+	iden2, err := cache.NewIdentity("Isaac Newton", "isaac@newton.uk")
+	require.NoError(t, err)
+
+	bug1, _, err := cache.NewBug("title", "message")
+	require.NoError(t, err)
+
+	_, err = bug1.AddTimeSpent(2 * time.Hour)
+	require.NoError(t, err)
+	_, err = bug1.AddTimeSpentRaw(iden2, time.Now().Unix(), 30*time.Minute, nil)
+	require.NoError(t, err)
+	_, err = bug1.AddTimeSpent(-time.Hour)
+	require.NoError(t, err)
+
+	check := func(cache *RepoCache) {
+		excerpt, err := cache.ResolveBugExcerpt(bug1.Id())
+		require.NoError(t, err)
+		require.Equal(t, map[entity.Id]time.Duration{
+			iden1.Id(): time.Hour,
+			iden2.Id(): 30 * time.Minute,
+		}, excerpt.TimeSpent)
+	}
+
+	check(cache)
+
+	// the excerpt survive a round trip on disk
+	require.NoError(t, cache.Close())
+	cache, err = NewRepoCache(repo)
+	require.NoError(t, err)
+	check(cache)
+	require.NoError(t, cache.Close())
+}
+
+func TestSearch(t *testing.T) {
+	repo := repository.CreateGoGitTestRepo(false)
+	defer repository.CleanupTestRepos(repo)
+
+	cache, err := NewRepoCache(repo)
+	require.NoError(t, err)
+
+	iden1, err := cache.NewIdentity("René Descartes", "rene@descartes.fr")
+	require.NoError(t, err)
+	err = cache.SetUserIdentity(iden1)
+	require.NoError(t, err)
+
+	crash, _, err := cache.NewBug("Crash on start", "The app crash when the config is missing")
+	require.NoError(t, err)
+
+	typo, _, err := cache.NewBug("Typo in the README", "Missing word")
+	require.NoError(t, err)
+	_, err = typo.AddComment("It also crash, but only on Windows")
+	require.NoError(t, err)
+
+	// the confidential bugs are not indexed, as the index is stored in clear
+	pgpEntity, err := openpgp.NewEntity("René Descartes", "", "rene@descartes.fr", nil)
+	require.NoError(t, err)
+	var pubKey bytes.Buffer
+	w, err := armor.Encode(&pubKey, openpgp.PublicKeyType, nil)
+	require.NoError(t, err)
+	require.NoError(t, pgpEntity.Serialize(w))
+	require.NoError(t, w.Close())
+	key, err := identity.NewKeyFromArmored(pubKey.String())
+	require.NoError(t, err)
+	err = iden1.Mutate(func(orig identity.Mutator) identity.Mutator {
+		orig.Keys = []*identity.Key{key}
+		return orig
+	})
+	require.NoError(t, err)
+	require.NoError(t, iden1.Commit())
+
+	_, _, err = cache.NewBugWithOptions("Crash on login", "A security issue", NewBugOptions{
+		Recipients: []*IdentityCache{iden1},
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, []entity.Id{crash.Id(), typo.Id()}, cache.SearchBugs("CRASH"))
+	require.Empty(t, cache.SearchBugs("security"))
+	require.Equal(t, []entity.Id{typo.Id()}, cache.SearchBugs("crash windows"))
+	require.Empty(t, cache.SearchBugs("linux"))
+
+	snippets, err := cache.SearchSnippets(typo.Id(), "crash windows")
+	require.NoError(t, err)
+	require.Equal(t, []SearchSnippet{{
+		Comment:    1,
+		Text:       "It also crash, but only on Windows",
+		Highlights: [][2]int{{8, 13}, {27, 34}},
+	}}, snippets)
+
+	q, err := query.Parse(`search("missing config") status:open`)
+	require.NoError(t, err)
+	require.Equal(t, []entity.Id{crash.Id()}, cache.QueryBugs(q))
+
+	// the index is updated with the bugs
+	_, err = crash.SetTitle("Panic on start")
+	require.NoError(t, err)
+	_, err = crash.EditCreateComment("The app panic")
+	require.NoError(t, err)
+	require.Equal(t, []entity.Id{typo.Id()}, cache.SearchBugs("crash"))
+
+	// and persisted on disk
+	require.NoError(t, cache.Close())
+	cache, err = NewRepoCache(repo)
+	require.NoError(t, err)
+	require.Equal(t, []entity.Id{crash.Id()}, cache.SearchBugs("panic"))
+
+	index, err := ioutil.ReadFile(searchIndexFilePath(repo))
+	require.NoError(t, err)
+	require.NotContains(t, string(index), "security")
+	require.NoError(t, cache.Close())
+}
+
+func TestBuildProgress(t *testing.T) {
+	repo := repository.CreateGoGitTestRepo(false)
+	defer repository.CleanupTestRepos(repo)
+
+	cache, err := NewRepoCache(repo)
+	require.NoError(t, err)
+
+	iden1, err := cache.NewIdentity("René Descartes", "rene@descartes.fr")
+	require.NoError(t, err)
+	err = cache.SetUserIdentity(iden1)
+	require.NoError(t, err)
+
+	for i := 0; i < 10; i++ {
+		_, _, err := cache.NewBug("title", "message")
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, cache.Close())
+
+	// force a rebuild
+	require.NoError(t, os.Remove(bugCacheFilePath(repo)))
+
+	var events []BuildEvent
+	cache, err = NewRepoCacheWithProgress(repo, func(event BuildEvent) {
+		events = append(events, event)
+	})
+	require.NoError(t, err)
+	require.Len(t, cache.AllBugsIds(), 10)
+
+	var bugEvents []BuildEvent
+	for _, event := range events {
+		if event.Target == "bugs" {
+			bugEvents = append(bugEvents, event)
+		}
+	}
+
+	require.Len(t, bugEvents, 12)
+	require.Equal(t, BuildEvent{Typ: BuildEventStarted, Target: "bugs", Total: 10}, bugEvents[0])
+	for i, event := range bugEvents[1:11] {
+		require.Equal(t, BuildEventProgress, event.Typ)
+		require.Equal(t, i+1, event.Done)
+		require.Equal(t, 10, event.Total)
+	}
+	require.Equal(t, BuildEvent{Typ: BuildEventFinished, Target: "bugs", Done: 10, Total: 10}, bugEvents[11])
+}
+
+func TestReadOnly(t *testing.T) {
+	repo := repository.CreateGoGitTestRepo(false)
+	defer repository.CleanupTestRepos(repo)
+
+	cache, err := NewRepoCache(repo)
+	require.NoError(t, err)
+
+	iden1, err := cache.NewIdentity("René Descartes", "rene@descartes.fr")
+	require.NoError(t, err)
+	err = cache.SetUserIdentity(iden1)
+	require.NoError(t, err)
+
+	bug1, _, err := cache.NewBug("title", "message")
+	require.NoError(t, err)
+
+	// the repo is locked, but a read-only cache can still be opened
+	err = repo.LocalConfig().StoreString(lockTimeoutConfigKey, "0s")
+	require.NoError(t, err)
+	_, err = NewRepoCache(repo)
+	require.True(t, IsErrLocked(err))
+
+	roCache, err := NewReadOnlyRepoCache(repo, nil)
+	require.NoError(t, err)
+	require.True(t, roCache.IsReadOnly())
+	require.Len(t, roCache.AllBugsIds(), 1)
+
+	roBug, err := roCache.ResolveBug(bug1.Id())
+	require.NoError(t, err)
+	require.Equal(t, "title", roBug.Snapshot().Title)
+
+	// no modification is possible
+	_, _, err = roCache.NewBug("title", "message")
+	require.Equal(t, ErrReadOnly, err)
+	_, err = roCache.NewIdentity("John Doe", "jdoe@example.com")
+	require.Equal(t, ErrReadOnly, err)
+	_, err = roBug.SetTitle("new title")
+	require.Equal(t, ErrReadOnly, err)
+	require.Equal(t, ErrReadOnly, roBug.Commit())
+
+	// closing the read-only cache doesn't release the lock
+	require.NoError(t, roCache.Close())
+	_, err = NewRepoCache(repo)
+	require.Error(t, err)
+
+	require.NoError(t, cache.Close())
+}
+
+func TestFormatMigration(t *testing.T) {
+	repo := repository.CreateGoGitTestRepo(false)
+	defer repository.CleanupTestRepos(repo)
+
+	cache, err := NewRepoCache(repo)
+	require.NoError(t, err)
+
+	iden1, err := cache.NewIdentity("René Descartes", "rene@descartes.fr")
+	require.NoError(t, err)
+	err = cache.SetUserIdentity(iden1)
+	require.NoError(t, err)
+
+	bug1, _, err := cache.NewBug("title", "message")
+	require.NoError(t, err)
+
+	require.NoError(t, cache.Close())
+
+	// pretend the cache files have been written with the previous format,
+	// with a migration available to the current one
+	writeVersion := func(version uint) {
+		f, err := os.Create(bugCacheFilePath(repo))
+		require.NoError(t, err)
+		encoder := gob.NewEncoder(f)
+		require.NoError(t, writeCacheHeader(encoder, version))
+		require.NoError(t, encoder.Encode(map[entity.Id]*BugExcerpt{
+			bug1.Id(): {Id: bug1.Id(), Title: "old title"},
+		}))
+		require.NoError(t, f.Close())
+	}
+
+	writeVersion(formatVersion - 1)
+
+	previous := bugExcerptMigrations[formatVersion]
+	bugExcerptMigrations[formatVersion] = func(repo repository.ClockedRepo, excerpts map[entity.Id]*BugExcerpt) error {
+		for _, excerpt := range excerpts {
+			excerpt.Title = "migrated title"
+		}
+		return nil
+	}
+	defer func() { bugExcerptMigrations[formatVersion] = previous }()
+
+	var events []BuildEvent
+	collect := func(event BuildEvent) {
+		events = append(events, event)
+	}
+
+	cache, err = NewRepoCacheWithProgress(repo, collect)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	require.Equal(t, BuildEventMigrated, events[0].Typ)
+
+	excerpt, err := cache.ResolveBugExcerpt(bug1.Id())
+	require.NoError(t, err)
+	require.Equal(t, "migrated title", excerpt.Title)
+	require.NoError(t, cache.Close())
+
+	// the migrated cache has been written with the current format
+	events = nil
+	cache, err = NewRepoCacheWithProgress(repo, collect)
+	require.NoError(t, err)
+	require.Empty(t, events)
+	require.NoError(t, cache.Close())
+
+	// without a migration path, the cache is rebuilt
+	writeVersion(formatVersion + 1)
+
+	events = nil
+	cache, err = NewRepoCacheWithProgress(repo, collect)
+	require.NoError(t, err)
+	require.Equal(t, BuildEventOutdated, events[0].Typ)
+	require.Equal(t, fmt.Sprintf("the bug-cache file has the format version %d, expected %d",
+		formatVersion+1, formatVersion), events[0].Reason)
+
+	excerpt, err = cache.ResolveBugExcerpt(bug1.Id())
+	require.NoError(t, err)
+	require.Equal(t, "title", excerpt.Title)
+	require.NoError(t, cache.Close())
+}
+
+func TestWorkspace(t *testing.T) {
+	repoA := repository.CreateGoGitTestRepo(false)
+	repoB := repository.CreateGoGitTestRepo(false)
+	defer repository.CleanupTestRepos(repoA, repoB)
+
+	err := AddWorkspaceRepo(repoA.LocalConfig(), "backend", repoB.GetPath())
+	require.NoError(t, err)
+	require.Error(t, AddWorkspaceRepo(repoA.LocalConfig(), "back/end", repoB.GetPath()))
+
+	paths, err := ReadWorkspace(repoA.LocalConfig())
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"backend": repoB.GetPath()}, paths)
+
+	mrc := NewMultiRepoCache()
+	cacheA, err := mrc.RegisterRepository("", repoA)
+	require.NoError(t, err)
+	cacheB, err := mrc.RegisterRepository("backend", repoB)
+	require.NoError(t, err)
+	defer mrc.Close()
+
+	require.Equal(t, []string{"", "backend"}, mrc.Names())
+
+	defaultRepo, err := mrc.DefaultRepo()
+	require.NoError(t, err)
+	require.Equal(t, cacheA, defaultRepo)
+
+	idenA, err := cacheA.NewIdentity("René Descartes", "rene@descartes.fr")
+	require.NoError(t, err)
+	idenB, err := cacheB.NewIdentity("René Descartes", "rene@descartes.fr")
+	require.NoError(t, err)
+
+	bugA, _, err := cacheA.NewBugRaw(idenA, 1000, "frontend bug", "message", nil, nil)
+	require.NoError(t, err)
+	bugB, _, err := cacheB.NewBugRaw(idenB, 2000, "backend bug", "message", nil, nil)
+	require.NoError(t, err)
+
+	q, err := query.Parse("sort:creation-desc")
+	require.NoError(t, err)
+	ids, err := mrc.QueryBugs(q)
+	require.NoError(t, err)
+	require.Equal(t, []QualifiedId{
+		{Repo: "backend", Id: bugB.Id()},
+		{Repo: "", Id: bugA.Id()},
+	}, ids)
+	require.Equal(t, "backend/"+bugB.Id().Human(), ids[0].Human())
+	require.Equal(t, bugA.Id().Human(), ids[1].Human())
+
+	qid, b, err := mrc.ResolveBugPrefix("backend/" + bugB.Id().Human())
+	require.NoError(t, err)
+	require.Equal(t, QualifiedId{Repo: "backend", Id: bugB.Id()}, qid)
+	require.Equal(t, bugB, b)
+
+	qid, _, err = mrc.ResolveBugPrefix(bugA.Id().Human())
+	require.NoError(t, err)
+	require.Equal(t, QualifiedId{Repo: "", Id: bugA.Id()}, qid)
+
+	_, _, err = mrc.ResolveBugPrefix("unknown/" + bugB.Id().Human())
+	require.Error(t, err)
+
+	require.NoError(t, RemoveWorkspaceRepo(repoA.LocalConfig(), "backend"))
+	paths, err = ReadWorkspace(repoA.LocalConfig())
+	require.NoError(t, err)
+	require.Empty(t, paths)
+}
+
+func TestWatch(t *testing.T) {
+	repo := repository.CreateGoGitTestRepo(false)
+	defer repository.CleanupTestRepos(repo)
+
+	cache, err := NewRepoCache(repo)
+	require.NoError(t, err)
+
+	iden1, err := cache.NewIdentity("René Descartes", "rene@descartes.fr")
+	require.NoError(t, err)
+	err = cache.SetUserIdentity(iden1)
+	require.NoError(t, err)
+
+	bug1, _, err := cache.NewBug("title", "message")
+	require.NoError(t, err)
+
+	changes, unsubscribe := cache.Changes()
+	defer unsubscribe()
+
+	w, err := newRefWatcher(cache)
+	require.NoError(t, err)
+
+	// nothing changed
+	generation := cache.Generation()
+	require.NoError(t, w.check())
+	require.Len(t, changes, 0)
+	require.Equal(t, generation, cache.Generation())
+
+	// changes made by another process, directly in git
+	external, _, err := bug.Create(iden1.Identity, time.Now().Unix(), "external", "message")
+	require.NoError(t, err)
+	require.NoError(t, external.Commit(repo))
+	require.NoError(t, bug.RemoveBug(repo, bug1.Id()))
+
+	require.NoError(t, w.check())
+	require.Equal(t, ChangeEvent{Typ: ChangeEventAdded, Target: "bugs", Id: external.Id()}, <-changes)
+	require.Equal(t, ChangeEvent{Typ: ChangeEventRemoved, Target: "bugs", Id: bug1.Id()}, <-changes)
+	require.Equal(t, generation+2, cache.Generation())
+
+	excerpt, err := cache.ResolveBugExcerpt(external.Id())
+	require.NoError(t, err)
+	require.Equal(t, "external", excerpt.Title)
+	_, err = cache.ResolveBugExcerpt(bug1.Id())
+	require.Error(t, err)
+
+	// changes made through the cache are notified directly
+	_, err = cache.NewIdentity("Isaac Newton", "isaac@newton.uk")
+	require.NoError(t, err)
+	require.Equal(t, ChangeEventAdded, (<-changes).Typ)
+
+	require.NoError(t, cache.Close())
+}
+
+func TestLockTimeout(t *testing.T) {
+	repo := repository.CreateGoGitTestRepo(false)
+	defer repository.CleanupTestRepos(repo)
+
+	err := repo.LocalConfig().StoreString(lockTimeoutConfigKey, "10s")
+	require.NoError(t, err)
+
+	cache, err := NewRepoCache(repo)
+	require.NoError(t, err)
+
+	// the writer release the lock while the other one wait for it
+	go func(cache *RepoCache) {
+		time.Sleep(300 * time.Millisecond)
+		_ = cache.Close()
+	}(cache)
+
+	cache2, err := NewRepoCache(repo)
+	require.NoError(t, err)
+	require.NoError(t, cache2.Close())
+
+	err = repo.LocalConfig().StoreString(lockTimeoutConfigKey, "100ms")
+	require.NoError(t, err)
+
+	cache, err = NewRepoCache(repo)
+	require.NoError(t, err)
+	_, err = NewRepoCache(repo)
+	require.Equal(t, &ErrLocked{Pid: os.Getpid()}, err)
+	require.NoError(t, cache.Close())
+}
+
+func TestStaleLock(t *testing.T) {
+	repo := repository.CreateGoGitTestRepo(false)
+	defer repository.CleanupTestRepos(repo)
+
+	lockPath := repoLockFilePath(repo)
+	require.NoError(t, os.MkdirAll(filepath.Dir(lockPath), 0777))
+
+	// empty, unparsable or left by a process not running anymore
+	for _, content := range []string{"", "garbage", "0", "2147483647"} {
+		require.NoError(t, ioutil.WriteFile(lockPath, []byte(content), 0666))
+
+		cache, err := NewRepoCache(repo)
+		require.NoError(t, err, content)
+
+		pid, err := readLockFile(lockPath)
+		require.NoError(t, err)
+		require.Equal(t, os.Getpid(), pid)
+
+		require.NoError(t, cache.Close())
+	}
+}
+
+func TestStaleLockConcurrent(t *testing.T) {
+	repo := repository.CreateGoGitTestRepo(false)
+	defer repository.CleanupTestRepos(repo)
+
+	lockPath := repoLockFilePath(repo)
+	require.NoError(t, os.MkdirAll(filepath.Dir(lockPath), 0777))
+
+	for i := 0; i < 20; i++ {
+		require.NoError(t, ioutil.WriteFile(lockPath, []byte("2147483647"), 0666))
+
+		// many writers find the same stale lock at the same time, only one of
+		// them can take it over
+		const writers = 10
+		errs := make(chan error, writers)
+		start := make(chan struct{})
+		for j := 0; j < writers; j++ {
+			go func() {
+				<-start
+				errs <- acquireLockFile(lockPath)
+			}()
+		}
+		close(start)
+
+		acquired := 0
+		for j := 0; j < writers; j++ {
+			err := <-errs
+			if err == nil {
+				acquired++
+				continue
+			}
+			require.True(t, IsErrLocked(err), err)
+		}
+		require.Equal(t, 1, acquired)
+
+		pid, err := readLockFile(lockPath)
+		require.NoError(t, err)
+		require.Equal(t, os.Getpid(), pid)
+
+		require.NoError(t, os.Remove(lockPath))
+	}
+}
+
+func TestExportState(t *testing.T) {
+	repo := repository.CreateGoGitTestRepo(false)
+	defer repository.CleanupTestRepos(repo)
+
+	cache, err := NewRepoCache(repo)
+	require.NoError(t, err)
+
+	iden1, err := cache.NewIdentity("René Descartes", "rene@descartes.fr")
+	require.NoError(t, err)
+	err = cache.SetUserIdentity(iden1)
+	require.NoError(t, err)
+
+	bug1, _, err := cache.NewBug("title", "message")
+	require.NoError(t, err)
+	bug2, _, err := cache.NewBug("title", "message")
+	require.NoError(t, err)
+
+	state, err := cache.ExportState("default")
+	require.NoError(t, err)
+	state.SetContext("context")
+	require.ElementsMatch(t, []entity.Id{bug1.Id(), bug2.Id()}, state.DirtyBugs())
+
+	require.NoError(t, state.MarkExported(bug1.Id(), "42"))
+	require.Equal(t, []entity.Id{bug2.Id()}, state.DirtyBugs())
+	require.NoError(t, state.Write())
+
+	// the state is persisted, per bridge
+	state, err = cache.ExportState("default")
+	require.NoError(t, err)
+	state.SetContext("context")
+	require.Equal(t, []entity.Id{bug2.Id()}, state.DirtyBugs())
+	remoteId, ok := state.RemoteId(bug1.Id())
+	require.True(t, ok)
+	require.Equal(t, "42", remoteId)
+
+	other, err := cache.ExportState("other")
+	require.NoError(t, err)
+	require.Len(t, other.DirtyBugs(), 2)
+
+	// a modified bug need to be exported again
+	_, err = bug1.AddComment("comment")
+	require.NoError(t, err)
+	require.NoError(t, bug1.Commit())
+	require.Len(t, state.DirtyBugs(), 2)
+
+	// a different context invalidate the state
+	require.NoError(t, state.MarkExported(bug1.Id(), "42"))
+	require.NoError(t, state.MarkExported(bug2.Id(), "43"))
+	require.Empty(t, state.DirtyBugs())
+	state.SetContext("another context")
+	require.Len(t, state.DirtyBugs(), 2)
+
+	require.NoError(t, cache.Close())
+}
+
+func TestFsck(t *testing.T) {
+	repo := repository.CreateGoGitTestRepo(false)
+	defer repository.CleanupTestRepos(repo)
+
+	cache, err := NewRepoCache(repo)
+	require.NoError(t, err)
+
+	iden1, err := cache.NewIdentity("René Descartes", "rene@descartes.fr")
+	require.NoError(t, err)
+	err = cache.SetUserIdentity(iden1)
+	require.NoError(t, err)
+
+	bug1, _, err := cache.NewBug("title", "message")
+	require.NoError(t, err)
+	_, err = bug1.AddComment("comment")
+	require.NoError(t, err)
+	require.NoError(t, bug1.Commit())
+
+	issues, err := cache.Fsck(false)
+	require.NoError(t, err)
+	require.Empty(t, issues)
+
+	// desynchronize the cache
+	delete(cache.bugExcerpts, bug1.Id())
+
+	issues, err = cache.Fsck(false)
+	require.NoError(t, err)
+	require.Len(t, issues, 1)
+	require.Equal(t, bug1.Id(), issues[0].Id)
+	require.False(t, issues[0].Repaired)
+
+	issues, err = cache.Fsck(true)
+	require.NoError(t, err)
+	require.Len(t, issues, 1)
+	require.True(t, issues[0].Repaired)
+
+	issues, err = cache.Fsck(false)
+	require.NoError(t, err)
+	require.Empty(t, issues)
+
+	require.NoError(t, cache.Close())
+}
+
+func TestSnapshotMemo(t *testing.T) {
+	repo := repository.CreateGoGitTestRepo(false)
+	defer repository.CleanupTestRepos(repo)
+
+	repoCache, err := NewRepoCache(repo)
+	require.NoError(t, err)
+	repoCache.setCacheSize(1)
+
+	rene, err := repoCache.NewIdentity("René Descartes", "rene@descartes.fr")
+	require.NoError(t, err)
+	err = repoCache.SetUserIdentity(rene)
+	require.NoError(t, err)
+
+	bug1, _, err := repoCache.NewBug("title", "message")
+	require.NoError(t, err)
+	_, err = bug1.AddComment("comment")
+	require.NoError(t, err)
+	require.NoError(t, bug1.Commit())
+	expected := *bug1.Snapshot()
+
+	// loading another bug evict the first one, its snapshot is kept
+	_, _, err = repoCache.NewBug("title", "message")
+	require.NoError(t, err)
+	checkBugPresence(t, repoCache, bug1, false)
+	require.Equal(t, 1, repoCache.snapshots.entries.Len())
+
+	// loading it again reuse the snapshot
+	loaded, err := repoCache.ResolveBug(bug1.Id())
+	require.NoError(t, err)
+	require.True(t, loaded.bug.HasSnapshot())
+	require.Equal(t, expected, *loaded.Snapshot())
+	require.Equal(t, 1, repoCache.snapshots.entries.Len())
+
+	// with a memory budget, the memoized snapshots count in it: the oldest
+	// one is dropped to make room, and the one of bug1 is kept as it fits
+	repoCache.memoryBudget = 3 * estimatedOperationSize
+	_, _, err = repoCache.NewBug("title", "message")
+	require.NoError(t, err)
+	checkBugPresence(t, repoCache, loaded, false)
+	require.Equal(t, 1, repoCache.snapshots.entries.Len())
+	require.True(t, repoCache.snapshots.entries.Contains(bug1.Id()))
+	require.Equal(t, uint64(2*estimatedOperationSize), repoCache.snapshots.size())
+
+	require.NoError(t, repoCache.Close())
+}
+
+func TestMetrics(t *testing.T) {
+	repo := repository.CreateGoGitTestRepo(false)
+	defer repository.CleanupTestRepos(repo)
+
+	metrics := NewMetricsCollector()
+	mrc := NewMultiRepoCache()
+	mrc.SetMetrics(metrics)
+
+	cache, err := mrc.RegisterDefaultRepository(repo)
+	require.NoError(t, err)
+	require.True(t, metrics.builds[""].rebuilt)
+
+	iden1, err := cache.NewIdentity("René Descartes", "rene@descartes.fr")
+	require.NoError(t, err)
+	err = cache.SetUserIdentity(iden1)
+	require.NoError(t, err)
+
+	bug1, _, err := cache.NewBug("title", "message")
+	require.NoError(t, err)
+
+	_, err = cache.ResolveBug(bug1.Id())
+	require.NoError(t, err)
+	cache.setCacheSize(0)
+	_, err = cache.ResolveBug(bug1.Id())
+	require.NoError(t, err)
+
+	bugs := metrics.resolves[resolveKey{target: "bugs"}]
+	require.Equal(t, uint64(1), bugs.hits)
+	require.Equal(t, uint64(1), bugs.misses)
+
+	var out bytes.Buffer
+	require.NoError(t, metrics.WritePrometheus(&out))
+	require.Contains(t, out.String(), `git_bug_cache_resolve_total{repo="",target="bugs",result="hit"} 1`)
+	require.Contains(t, out.String(), `git_bug_cache_resolve_total{repo="",target="bugs",result="miss"} 1`)
+
+	require.NoError(t, cache.Close())
+}
+
+func TestMatchBug(t *testing.T) {
+	repo := repository.CreateGoGitTestRepo(false)
+	defer repository.CleanupTestRepos(repo)
+
+	cache, err := NewRepoCache(repo)
+	require.NoError(t, err)
+
+	iden1, err := cache.NewIdentity("René Descartes", "rene@descartes.fr")
+	require.NoError(t, err)
+	err = cache.SetUserIdentity(iden1)
+	require.NoError(t, err)
+
+	bug1, _, err := cache.NewBug("title", "message")
+	require.NoError(t, err)
+	_, _, err = bug1.ChangeLabels([]string{"ui"}, nil)
+	require.NoError(t, err)
+
+	q, err := query.Parse("status:open label:ui")
+	require.NoError(t, err)
+
+	match, err := cache.MatchBug(q, bug1.Id())
+	require.NoError(t, err)
+	require.True(t, match)
+
+	_, err = bug1.Close()
+	require.NoError(t, err)
+
+	match, err = cache.MatchBug(q, bug1.Id())
+	require.NoError(t, err)
+	require.False(t, match)
+
+	_, err = cache.MatchBug(q, entity.Id("unknown"))
+	require.Error(t, err)
+}
+
+func TestQuerySorting(t *testing.T) {
+	repo := repository.CreateGoGitTestRepo(false)
+	defer repository.CleanupTestRepos(repo)
+
+	cache, err := NewRepoCache(repo)
+	require.NoError(t, err)
+
+	iden1, err := cache.NewIdentity("René Descartes", "rene@descartes.fr")
+	require.NoError(t, err)
+	err = cache.SetUserIdentity(iden1)
+	require.NoError(t, err)
+
+	err = cache.SetFieldDefinition(bug.FieldDefinition{
+		Name:   PriorityField,
+		Type:   bug.FieldEnum,
+		Values: []string{"high", "medium", "low"},
+	})
+	require.NoError(t, err)
+
+	bug1, _, err := cache.NewBug("title", "message")
+	require.NoError(t, err)
+	_, err = bug1.SetField(PriorityField, "low")
+	require.NoError(t, err)
+	_, err = bug1.SetDueDate(time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+
+	bug2, _, err := cache.NewBug("title", "message")
+	require.NoError(t, err)
+	_, err = bug2.SetField(PriorityField, "high")
+	require.NoError(t, err)
+	_, err = bug2.AddComment("comment")
+	require.NoError(t, err)
+	_, err = bug2.AddComment("comment")
+	require.NoError(t, err)
+
+	bug3, _, err := cache.NewBug("title", "message")
+	require.NoError(t, err)
+	_, err = bug3.AddComment("comment")
+	require.NoError(t, err)
+	_, err = bug3.SetDueDate(time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+
+	sorted := func(raw string) []entity.Id {
+		q, err := query.Parse(raw)
+		require.NoError(t, err)
+		return cache.QueryBugs(q)
+	}
+
+	require.Equal(t, []entity.Id{bug2.Id(), bug3.Id(), bug1.Id()}, sorted("sort:comments"))
+	require.Equal(t, []entity.Id{bug1.Id(), bug3.Id(), bug2.Id()}, sorted("sort:comments-asc"))
+
+	// the bugs without a value come last in both directions
+	require.Equal(t, []entity.Id{bug2.Id(), bug1.Id(), bug3.Id()}, sorted("sort:priority"))
+	require.Equal(t, []entity.Id{bug1.Id(), bug2.Id(), bug3.Id()}, sorted("sort:priority-desc"))
+	require.Equal(t, []entity.Id{bug3.Id(), bug1.Id(), bug2.Id()}, sorted("sort:due"))
+	require.Equal(t, []entity.Id{bug1.Id(), bug3.Id(), bug2.Id()}, sorted("sort:due-desc"))
+
+	require.NoError(t, cache.Close())
+}
+
+func TestBugBranch(t *testing.T) {
+	repo := repository.CreateGoGitTestRepo(false)
+	defer repository.CleanupTestRepos(repo)
+
+	cache, err := NewRepoCache(repo)
+	require.NoError(t, err)
+
+	excerpt := &BugExcerpt{Id: entity.Id("1a2b3c4d5e6f"), Title: "Crash on startup, again!"}
+	require.Equal(t, "bug/"+excerpt.Id.Human()+"-crash-on-startup-again", BugBranchName(excerpt))
+
+	blob, err := repo.StoreData([]byte("content"))
+	require.NoError(t, err)
+	tree, err := repo.StoreTree([]repository.TreeEntry{
+		{ObjectType: repository.Blob, Hash: blob, Name: "file"},
+	})
+	require.NoError(t, err)
+	base, err := repo.StoreCommit(tree)
+	require.NoError(t, err)
+	require.NoError(t, repo.UpdateRef("refs/heads/main", base))
+
+	require.NoError(t, cache.CreateBranch("bug/fix", "main"))
+	require.Error(t, cache.CreateBranch("bug/fix", "main"))
+
+	// no work on the branch yet
+	merged, err := cache.IsMerged("bug/fix", "main")
+	require.NoError(t, err)
+	require.False(t, merged)
+
+	work, err := repo.StoreCommitWithParent(tree, base)
+	require.NoError(t, err)
+	require.NoError(t, repo.UpdateRef("refs/heads/bug/fix", work))
+
+	merged, err = cache.IsMerged("bug/fix", "main")
+	require.NoError(t, err)
+	require.False(t, merged)
+
+	require.NoError(t, repo.UpdateRef("refs/heads/main", work))
+
+	merged, err = cache.IsMerged("bug/fix", "main")
+	require.NoError(t, err)
+	require.True(t, merged)
+}
+
+func TestBundle(t *testing.T) {
+	repoA := repository.CreateGoGitTestRepo(false)
+	repoB := repository.CreateGoGitTestRepo(true)
+	defer repository.CleanupTestRepos(repoA, repoB)
+
+	cacheA, err := NewRepoCache(repoA)
+	require.NoError(t, err)
+
+	cacheB, err := NewRepoCache(repoB)
+	require.NoError(t, err)
+
+	reneA, err := cacheA.NewIdentity("René Descartes", "rene@descartes.fr")
+	require.NoError(t, err)
+	err = cacheA.SetUserIdentity(reneA)
+	require.NoError(t, err)
+
+	bug1, _, err := cacheA.NewBug("bug1", "message")
+	require.NoError(t, err)
+	_, _, err = cacheA.NewBug("bug2", "message")
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	err = cacheA.CreateBundle(&buf, []entity.Id{bug1.Id()})
+	require.NoError(t, err)
+
+	results, err := cacheB.ApplyBundle(&buf)
+	require.NoError(t, err)
+	for result := range results {
+		require.NoError(t, result.Err)
+	}
+
+	require.Equal(t, []entity.Id{bug1.Id()}, cacheB.AllBugsIds())
+	_, err = cacheB.ResolveIdentity(reneA.Id())
+	require.NoError(t, err)
+
+	// the bundle is not kept as a remote
+	refs, err := repoB.ListRefs("refs/remotes/")
+	require.NoError(t, err)
+	require.Empty(t, refs)
+
+	_, err = cacheB.ApplyBundle(bytes.NewBufferString("not a bundle"))
+	require.Error(t, err)
+}
+
+func TestIdentityBundle(t *testing.T) {
+	repoA := repository.CreateGoGitTestRepo(false)
+	repoB := repository.CreateGoGitTestRepo(false)
+	defer repository.CleanupTestRepos(repoA, repoB)
+
+	cacheA, err := NewRepoCache(repoA)
+	require.NoError(t, err)
+
+	cacheB, err := NewRepoCache(repoB)
+	require.NoError(t, err)
+
+	reneA, err := cacheA.NewIdentity("René Descartes", "rene@descartes.fr")
+	require.NoError(t, err)
+	reneA.SetMetadata("github-login", "rene")
+	require.NoError(t, reneA.Commit())
+	isaacA, err := cacheA.NewIdentity("Isaac Newton", "isaac@newton.uk")
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	err = cacheA.CreateIdentityBundle(&buf, []entity.Id{reneA.Id()})
+	require.NoError(t, err)
+
+	results, err := cacheB.ApplyBundle(&buf)
+	require.NoError(t, err)
+	for result := range results {
+		require.NoError(t, result.Err)
+	}
+
+	// same id and data in the other repository, and only this identity
+	reneB, err := cacheB.ResolveIdentity(reneA.Id())
+	require.NoError(t, err)
+	require.Equal(t, "rene", reneB.ImmutableMetadata()["github-login"])
+	_, err = cacheB.ResolveIdentity(isaacA.Id())
+	require.Error(t, err)
+
+	err = cacheA.CreateIdentityBundle(&buf, []entity.Id{"unknown"})
+	require.Error(t, err)
+}
+
+func TestAdoptIdentity(t *testing.T) {
+	repo := repository.CreateGoGitTestRepo(false)
+	defer repository.CleanupTestRepos(repo)
+
+	cache, err := NewRepoCache(repo)
+	require.NoError(t, err)
+
+	user, err := cache.NewIdentityFull("René Descartes", "rene@descartes.fr", "rene", "")
+	require.NoError(t, err)
+	err = cache.SetUserIdentity(user)
+	require.NoError(t, err)
+
+	// as created by a bridge import
+	imported, err := cache.NewIdentityRaw("", "", "rdescartes", "", map[string]string{
+		"github-login": "rdescartes",
+		"github-id":    "42",
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, user.Adopt(imported))
+	require.NoError(t, user.CommitAsNeeded())
+	require.Error(t, user.Adopt(imported))
+	require.Error(t, user.Adopt(user))
+
+	// the login is kept along the new metadata
+	require.Equal(t, "rene", user.Login())
+	require.Equal(t, "42", user.ImmutableMetadata()["github-id"])
+
+	resolved, err := cache.ResolveIdentityImmutableMetadata("github-login", "rdescartes")
+	require.NoError(t, err)
+	require.Equal(t, user.Id(), resolved.Id())
+
+	other, err := cache.NewIdentityRaw("", "", "inewton", "", map[string]string{
+		"github-id": "43",
+	})
+	require.NoError(t, err)
+	require.Error(t, user.Adopt(other))
+}
@@ -0,0 +1,87 @@
+package cache
+
+import (
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/entity"
+)
+
+// TrashedBugs return the ids of the bugs in the trash
+func (c *RepoCache) TrashedBugs() ([]entity.Id, error) {
+	return bug.ListTrashedIds(c.repo)
+}
+
+// ResolveTrashedBugPrefix return the id of the bug of the trash matching an
+// id prefix. It fails if multiple bugs match.
+func (c *RepoCache) ResolveTrashedBugPrefix(prefix string) (entity.Id, error) {
+	ids, err := bug.ListTrashedIds(c.repo)
+	if err != nil {
+		return entity.UnsetId, err
+	}
+
+	var matching []entity.Id
+	for _, id := range ids {
+		if id.HasPrefix(prefix) {
+			matching = append(matching, id)
+		}
+	}
+
+	switch len(matching) {
+	case 0:
+		return entity.UnsetId, bug.ErrBugNotExist
+	case 1:
+		return matching[0], nil
+	default:
+		return entity.UnsetId, bug.NewErrMultipleMatchBug(matching)
+	}
+}
+
+// ResolveTrashedBug read the snapshot of a bug of the trash
+func (c *RepoCache) ResolveTrashedBug(id entity.Id) (*bug.Snapshot, error) {
+	b, err := bug.ReadTrashed(c.repo, id)
+	if err != nil {
+		return nil, err
+	}
+	snap := b.Compile()
+	return &snap, nil
+}
+
+// RestoreBug move a bug from the trash back to the repository and the cache
+func (c *RepoCache) RestoreBug(id entity.Id) error {
+	if c.readOnly {
+		return ErrReadOnly
+	}
+
+	err := bug.RestoreBug(c.repo, id)
+	if err != nil {
+		return err
+	}
+
+	b, err := bug.ReadLocalWithResolver(c.repo, newIdentityCacheResolver(c), id)
+	if err != nil {
+		return err
+	}
+	snap := c.compileUpdatedBug(b)
+
+	c.muBug.Lock()
+	c.bugExcerpts[id] = NewBugExcerpt(b, snap)
+	c.bugIds.insert(id)
+	c.search.index(id, snap)
+	c.muBug.Unlock()
+
+	c.notifyChange(ChangeEvent{Typ: ChangeEventAdded, Target: "bugs", Id: id})
+
+	err = c.writeBugCache()
+	if err != nil {
+		return err
+	}
+	return c.writeSearchIndex()
+}
+
+// PurgeTrashedBug definitely remove a bug from the trash
+func (c *RepoCache) PurgeTrashedBug(id entity.Id) error {
+	if c.readOnly {
+		return ErrReadOnly
+	}
+
+	return bug.PurgeBug(c.repo, id)
+}
@@ -0,0 +1,106 @@
+package cache
+
+import (
+	"sync"
+	"time"
+
+	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/repository"
+	"github.com/MichaelMure/git-bug/review"
+)
+
+// ReviewCache is a wrapper around a Review. It provide a higher level API
+// and deal with concurrency. Like boards, reviews are not kept in memory
+// nor indexed.
+type ReviewCache struct {
+	repoCache *RepoCache
+	mu        sync.RWMutex
+	review    *review.Review
+}
+
+func NewReviewCache(repoCache *RepoCache, r *review.Review) *ReviewCache {
+	return &ReviewCache{
+		repoCache: repoCache,
+		review:    r,
+	}
+}
+
+func (c *ReviewCache) Id() entity.Id {
+	return c.review.Id()
+}
+
+func (c *ReviewCache) Snapshot() review.Snapshot {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.review.Compile()
+}
+
+func (c *ReviewCache) SetTitle(title string) (*review.SetTitleOperation, error) {
+	author, err := c.repoCache.GetUserIdentity()
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return review.SetTitle(c.review, author.Identity, time.Now().Unix(), title)
+}
+
+// UpdateRevision change the range of commits under review
+func (c *ReviewCache) UpdateRevision(base, head repository.Hash) (*review.UpdateRevisionOperation, error) {
+	author, err := c.repoCache.GetUserIdentity()
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return review.UpdateRevision(c.review, author.Identity, time.Now().Unix(), base, head)
+}
+
+// AddComment add a comment to the review. location can be nil for a general
+// comment.
+func (c *ReviewCache) AddComment(message string, location *review.Location, verdict review.Verdict) (*review.AddCommentOperation, error) {
+	author, err := c.repoCache.GetUserIdentity()
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return review.AddComment(c.review, author.Identity, time.Now().Unix(), message, location, verdict)
+}
+
+func (c *ReviewCache) SetStatus(status review.Status) (*review.SetStatusOperation, error) {
+	author, err := c.repoCache.GetUserIdentity()
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return review.SetStatus(c.review, author.Identity, time.Now().Unix(), status)
+}
+
+func (c *ReviewCache) Commit() error {
+	if c.repoCache.readOnly {
+		return ErrReadOnly
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.review.Commit(c.repoCache.repo)
+}
+
+func (c *ReviewCache) CommitAsNeeded() error {
+	if c.repoCache.readOnly {
+		return ErrReadOnly
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.review.NeedCommit() {
+		return nil
+	}
+	return c.review.Commit(c.repoCache.repo)
+}
@@ -0,0 +1,303 @@
+package cache
+
+import (
+	"bytes"
+	"encoding/gob"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+const searchIndexFile = "search-index"
+
+// 1: original format
+// 2: explicit header
+// 3: confidential bugs not indexed anymore
+const searchIndexVersion = 3
+
+// words shorter than that are not indexed
+const minSearchWordLen = 2
+
+func searchIndexFilePath(repo repository.Repo) string {
+	return path.Join(repo.GetPath(), "git-bug", searchIndexFile)
+}
+
+// searchIndex is an inverted index of the words found in the title and the
+// comments of the bugs, allowing a full-text search without reading the bugs.
+// It's maintained incrementally each time a bug is updated.
+type searchIndex struct {
+	mu sync.RWMutex
+	// word --> bug --> number of occurrences
+	postings map[string]map[entity.Id]int
+	// bug --> indexed words, to be able to remove a bug from the index
+	words map[entity.Id][]string
+}
+
+func newSearchIndex() *searchIndex {
+	return &searchIndex{
+		postings: make(map[string]map[entity.Id]int),
+		words:    make(map[entity.Id][]string),
+	}
+}
+
+// index add or replace a bug in the index. The confidential bugs are not
+// indexed, as the index is stored in clear on disk.
+func (si *searchIndex) index(id entity.Id, snap *bug.Snapshot) {
+	if snap.IsConfidential() {
+		si.remove(id)
+		return
+	}
+
+	counts := make(map[string]int)
+
+	for _, word := range searchWords(snap.Title) {
+		counts[word]++
+	}
+	for _, comment := range snap.Comments {
+		for _, word := range searchWords(comment.Message) {
+			counts[word]++
+		}
+	}
+
+	si.mu.Lock()
+	defer si.mu.Unlock()
+
+	si.removeLocked(id)
+
+	words := make([]string, 0, len(counts))
+	for word, count := range counts {
+		if si.postings[word] == nil {
+			si.postings[word] = make(map[entity.Id]int)
+		}
+		si.postings[word][id] = count
+		words = append(words, word)
+	}
+	si.words[id] = words
+}
+
+// remove a bug from the index
+func (si *searchIndex) remove(id entity.Id) {
+	si.mu.Lock()
+	defer si.mu.Unlock()
+
+	si.removeLocked(id)
+}
+
+func (si *searchIndex) removeLocked(id entity.Id) {
+	for _, word := range si.words[id] {
+		delete(si.postings[word], id)
+		if len(si.postings[word]) == 0 {
+			delete(si.postings, word)
+		}
+	}
+	delete(si.words, id)
+}
+
+// search return the bugs containing all the words of the query, with a score
+// being the number of occurrences of those words.
+func (si *searchIndex) search(query string) map[entity.Id]int {
+	words := searchWords(query)
+	if len(words) == 0 {
+		return nil
+	}
+
+	si.mu.RLock()
+	defer si.mu.RUnlock()
+
+	result := make(map[entity.Id]int)
+	for id, count := range si.postings[words[0]] {
+		result[id] = count
+	}
+
+	for _, word := range words[1:] {
+		postings := si.postings[word]
+		for id := range result {
+			count, ok := postings[id]
+			if !ok {
+				delete(result, id)
+				continue
+			}
+			result[id] += count
+		}
+	}
+
+	return result
+}
+
+// searchWords break a text into normalized words to index or search
+func searchWords(text string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	result := fields[:0]
+	for _, field := range fields {
+		if len([]rune(field)) >= minSearchWordLen {
+			result = append(result, field)
+		}
+	}
+	return result
+}
+
+// SearchHighlights return the byte ranges of the words of text matching one
+// of the words of the search query
+func SearchHighlights(text string, query string) [][2]int {
+	searched := make(map[string]bool)
+	for _, word := range searchWords(query) {
+		searched[word] = true
+	}
+
+	var result [][2]int
+
+	start := -1
+	for i, r := range text + " " {
+		isWord := unicode.IsLetter(r) || unicode.IsDigit(r)
+		switch {
+		case isWord && start < 0:
+			start = i
+		case !isWord && start >= 0:
+			if searched[strings.ToLower(text[start:i])] {
+				result = append(result, [2]int{start, i})
+			}
+			start = -1
+		}
+	}
+
+	return result
+}
+
+// the context kept around the first match of a snippet, in bytes
+const searchSnippetContext = 60
+
+// SearchSnippet is a single line excerpt of a comment matching a search
+type SearchSnippet struct {
+	// Index of the comment in the bug, the description being 0
+	Comment int
+	Text    string
+	// Highlights are the byte ranges of Text matching the search
+	Highlights [][2]int
+}
+
+// SearchSnippets return an excerpt of each comment of a bug matching one of
+// the words of the search query
+func (c *RepoCache) SearchSnippets(id entity.Id, query string) ([]SearchSnippet, error) {
+	b, err := c.ResolveBug(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []SearchSnippet
+
+	for i, comment := range b.Snapshot().Comments {
+		text := strings.Join(strings.Fields(comment.Message), " ")
+
+		highlights := SearchHighlights(text, query)
+		if len(highlights) == 0 {
+			continue
+		}
+
+		start := highlights[0][0] - searchSnippetContext
+		end := highlights[0][1] + searchSnippetContext
+		if start < 0 {
+			start = 0
+		}
+		if end > len(text) {
+			end = len(text)
+		}
+		// don't cut a multi-bytes character
+		for start > 0 && !utf8.RuneStart(text[start]) {
+			start--
+		}
+		for end < len(text) && !utf8.RuneStart(text[end]) {
+			end++
+		}
+
+		snippet := SearchSnippet{Comment: i, Text: text[start:end]}
+		prefix := 0
+		if start > 0 {
+			snippet.Text = "…" + snippet.Text
+			prefix = len("…") - start
+		}
+		if end < len(text) {
+			snippet.Text += "…"
+		}
+
+		for _, h := range highlights {
+			if h[0] >= start && h[1] <= end {
+				snippet.Highlights = append(snippet.Highlights, [2]int{h[0] + prefix, h[1] + prefix})
+			}
+		}
+
+		result = append(result, snippet)
+	}
+
+	return result, nil
+}
+
+// loadSearchIndex read from the disk the search index
+func (c *RepoCache) loadSearchIndex() error {
+	f, err := os.Open(searchIndexFilePath(c.repo))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	decoder := gob.NewDecoder(f)
+
+	version, err := readCacheHeader(decoder)
+	if err != nil {
+		return err
+	}
+
+	// no migration for the index, it's rebuilt with the cache
+	if version != searchIndexVersion {
+		return &ErrFormatVersion{File: searchIndexFile, Found: version, Expected: searchIndexVersion}
+	}
+
+	var postings map[string]map[entity.Id]int
+
+	err = decoder.Decode(&postings)
+	if err != nil {
+		return err
+	}
+
+	si := newSearchIndex()
+	si.postings = postings
+	for word, postings := range postings {
+		for id := range postings {
+			si.words[id] = append(si.words[id], word)
+		}
+	}
+
+	c.search = si
+	return nil
+}
+
+// writeSearchIndex serialize on disk the search index
+func (c *RepoCache) writeSearchIndex() error {
+	c.search.mu.RLock()
+	defer c.search.mu.RUnlock()
+
+	var data bytes.Buffer
+
+	encoder := gob.NewEncoder(&data)
+
+	err := writeCacheHeader(encoder, searchIndexVersion)
+	if err != nil {
+		return err
+	}
+
+	err = encoder.Encode(c.search.postings)
+	if err != nil {
+		return err
+	}
+
+	return writeFileAtomic(searchIndexFilePath(c.repo), data.Bytes())
+}
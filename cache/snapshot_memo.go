@@ -0,0 +1,91 @@
+package cache
+
+import (
+	lru "github.com/hashicorp/golang-lru"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+// snapshotMemo keep the compiled snapshots of the bugs evicted from memory,
+// keyed by the commit their ref pointed to when they were compiled. When
+// the bug is loaded again, the snapshot is reused as is if the bug didn't
+// change, or only the new operations are applied on top of it.
+type snapshotMemo struct {
+	entries *lru.Cache
+}
+
+type memoizedSnapshot struct {
+	tip  repository.Hash
+	snap *bug.Snapshot
+	size uint64
+}
+
+func newSnapshotMemo(size int) *snapshotMemo {
+	// we can ignore the error here as it would only fail if the size is negative.
+	entries, _ := lru.New(size)
+
+	return &snapshotMemo{entries: entries}
+}
+
+// put record the snapshot of a bug, compiled up to the given commit. The
+// snapshot is never modified by the memo.
+func (m *snapshotMemo) put(id entity.Id, tip repository.Hash, snap *bug.Snapshot) {
+	m.entries.Add(id, memoizedSnapshot{
+		tip:  tip,
+		snap: snap,
+		size: estimateSnapshotSize(snap),
+	})
+}
+
+func (m *snapshotMemo) remove(id entity.Id) {
+	m.entries.Remove(id)
+}
+
+func (m *snapshotMemo) purge() {
+	m.entries.Purge()
+}
+
+// size return the approximate memory used by the memoized snapshots
+func (m *snapshotMemo) size() uint64 {
+	var size uint64
+	for _, key := range m.entries.Keys() {
+		if raw, ok := m.entries.Peek(key); ok {
+			size += raw.(memoizedSnapshot).size
+		}
+	}
+	return size
+}
+
+// removeOldest drop the least recently memoized snapshot, and return the
+// memory it used. It returns false if the memo is empty.
+func (m *snapshotMemo) removeOldest() (uint64, bool) {
+	_, raw, ok := m.entries.RemoveOldest()
+	if !ok {
+		return 0, false
+	}
+	return raw.(memoizedSnapshot).size, true
+}
+
+// compile return the snapshot of the bug built from a copy of the memoized
+// one, which is removed from the memo. The memoized snapshot is copied as
+// the excerpts of the bug may still refer to its content. It returns false
+// if there is no usable snapshot for this bug.
+func (m *snapshotMemo) compile(b *bug.Bug) (*bug.Snapshot, bool) {
+	raw, ok := m.entries.Get(b.Id())
+	if !ok {
+		return nil, false
+	}
+	m.entries.Remove(b.Id())
+
+	entry := raw.(memoizedSnapshot)
+	snap := entry.snap.Clone()
+	if entry.tip == b.LastCommit() {
+		return snap, true
+	}
+	if b.CompileOnTop(snap) {
+		return snap, true
+	}
+	return nil, false
+}
@@ -0,0 +1,414 @@
+package cache
+
+import (
+	"fmt"
+	"hash/fnv"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/identity"
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+// the default interval between two checks of the refs
+const defaultWatchInterval = time.Second
+
+// the number of events a subscriber can lag behind before events are dropped
+const changeEventBuffer = 64
+
+// ChangeEventType is the kind of a ChangeEvent
+type ChangeEventType int
+
+const (
+	_ ChangeEventType = iota
+	// an entity has been created, or fetched for the first time
+	ChangeEventAdded
+	// an entity has been modified
+	ChangeEventUpdated
+	// an entity has been removed
+	ChangeEventRemoved
+)
+
+func (t ChangeEventType) String() string {
+	switch t {
+	case ChangeEventAdded:
+		return "added"
+	case ChangeEventUpdated:
+		return "updated"
+	case ChangeEventRemoved:
+		return "removed"
+	default:
+		return "unknown"
+	}
+}
+
+// ChangeEvent notify that an entity of the cache changed, either through
+// the cache itself or because another process updated the repository.
+type ChangeEvent struct {
+	Typ ChangeEventType
+	// the kind of entity: "identities" or "bugs"
+	Target string
+	Id     entity.Id
+}
+
+func (e ChangeEvent) String() string {
+	return fmt.Sprintf("%s %s %s", e.Target, e.Id.Human(), e.Typ)
+}
+
+// watchState hold the subscribers to the change events and the state of
+// the refs watcher
+type watchState struct {
+	mu          sync.Mutex
+	subscribers map[chan ChangeEvent]struct{}
+
+	// incremented at each change
+	generation uint64
+
+	// closing it stop the watcher, nil if not running
+	stop chan struct{}
+	done chan struct{}
+}
+
+// Changes return a channel receiving the changes of the cache, and a
+// function to unsubscribe. A subscriber is expected to read the events
+// promptly, events are dropped when it lag too far behind. As events can
+// also be duplicated, they should be considered as hints to refresh a view.
+func (c *RepoCache) Changes() (<-chan ChangeEvent, func()) {
+	ch := make(chan ChangeEvent, changeEventBuffer)
+
+	c.watch.mu.Lock()
+	if c.watch.subscribers == nil {
+		c.watch.subscribers = make(map[chan ChangeEvent]struct{})
+	}
+	c.watch.subscribers[ch] = struct{}{}
+	c.watch.mu.Unlock()
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			c.watch.mu.Lock()
+			delete(c.watch.subscribers, ch)
+			c.watch.mu.Unlock()
+			close(ch)
+		})
+	}
+
+	return ch, unsubscribe
+}
+
+// notifyChange send a change event to all the subscribers
+func (c *RepoCache) notifyChange(event ChangeEvent) {
+	c.watch.mu.Lock()
+	defer c.watch.mu.Unlock()
+
+	c.watch.generation++
+
+	for ch := range c.watch.subscribers {
+		select {
+		case ch <- event:
+		default:
+			// the subscriber is lagging, drop the event
+		}
+	}
+}
+
+// Generation return a number incremented each time an entity of the cache
+// change. Data derived from the cache stay valid as long as it doesn't move.
+func (c *RepoCache) Generation() uint64 {
+	c.watch.mu.Lock()
+	defer c.watch.mu.Unlock()
+	return c.watch.generation
+}
+
+// Watch start watching the refs of the repository to detect the changes
+// made by another process, like a git fetch or another git-bug instance.
+// The affected entities are refreshed in the cache and a ChangeEvent is
+// sent to the subscribers. A zero interval use the default one.
+func (c *RepoCache) Watch(interval time.Duration) error {
+	if interval <= 0 {
+		interval = defaultWatchInterval
+	}
+
+	c.watch.mu.Lock()
+	defer c.watch.mu.Unlock()
+
+	if c.watch.stop != nil {
+		return fmt.Errorf("the repository is already watched")
+	}
+
+	w, err := newRefWatcher(c)
+	if err != nil {
+		return err
+	}
+
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	c.watch.stop = stop
+	c.watch.done = done
+
+	go func() {
+		defer close(done)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				err := w.check()
+				if err != nil {
+					_, _ = fmt.Fprintf(os.Stderr, "watching the repository: %v\n", err)
+				}
+			}
+		}
+	}()
+
+	return nil
+}
+
+// StopWatching stop the watcher started with Watch, if any
+func (c *RepoCache) StopWatching() {
+	c.watch.mu.Lock()
+	stop, done := c.watch.stop, c.watch.done
+	c.watch.stop, c.watch.done = nil, nil
+	c.watch.mu.Unlock()
+
+	if stop == nil {
+		return
+	}
+
+	close(stop)
+	<-done
+}
+
+// refWatcher detect the changes of the refs of the repository
+type refWatcher struct {
+	cache *RepoCache
+
+	// a digest of the state of the ref files, to avoid listing the refs
+	// when nothing changed on the disk
+	signature uint64
+
+	bugTips      map[entity.Id]repository.Hash
+	identityTips map[entity.Id]repository.Hash
+}
+
+func newRefWatcher(c *RepoCache) (*refWatcher, error) {
+	w := &refWatcher{
+		cache:     c,
+		signature: refsSignature(c.repo.GetPath()),
+	}
+
+	var err error
+	w.bugTips, err = bug.ListLocalRefTips(c.repo)
+	if err != nil {
+		return nil, err
+	}
+	w.identityTips, err = identity.ListLocalRefTips(c.repo)
+	if err != nil {
+		return nil, err
+	}
+
+	return w, nil
+}
+
+// refsSignature compute a digest of the names, sizes and modification times
+// of the loose refs of git-bug and of the packed-refs file.
+func refsSignature(gitDir string) uint64 {
+	h := fnv.New64a()
+
+	record := func(path string, info os.FileInfo) {
+		_, _ = fmt.Fprintf(h, "%s %d %d\n", path, info.Size(), info.ModTime().UnixNano())
+	}
+
+	for _, dir := range []string{"refs/bugs", "refs/drafts", "refs/identities"} {
+		_ = filepath.Walk(filepath.Join(gitDir, dir), func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				// missing directory or ref deleted during the walk
+				return nil
+			}
+			record(path, info)
+			return nil
+		})
+	}
+
+	if info, err := os.Stat(filepath.Join(gitDir, "packed-refs")); err == nil {
+		record("packed-refs", info)
+	}
+
+	return h.Sum64()
+}
+
+// check look for the refs that moved since the last check and refresh the
+// corresponding entities in the cache
+func (w *refWatcher) check() error {
+	signature := refsSignature(w.cache.repo.GetPath())
+	if signature == w.signature {
+		return nil
+	}
+
+	identityTips, err := identity.ListLocalRefTips(w.cache.repo)
+	if err != nil {
+		return err
+	}
+	bugTips, err := bug.ListLocalRefTips(w.cache.repo)
+	if err != nil {
+		return err
+	}
+
+	// identities first, as bugs reference them
+	identitiesChanged, err := w.refreshIdentities(identityTips)
+	if err != nil {
+		return err
+	}
+	bugsChanged, err := w.refreshBugs(bugTips)
+	if err != nil {
+		return err
+	}
+
+	w.signature = signature
+
+	if w.cache.readOnly {
+		return nil
+	}
+
+	if identitiesChanged {
+		if err := w.cache.writeIdentityCache(); err != nil {
+			return err
+		}
+	}
+	if bugsChanged {
+		if err := w.cache.writeBugCache(); err != nil {
+			return err
+		}
+		return w.cache.writeSearchIndex()
+	}
+
+	return nil
+}
+
+func (w *refWatcher) refreshIdentities(tips map[entity.Id]repository.Hash) (bool, error) {
+	c := w.cache
+	changed := false
+
+	for id, tip := range tips {
+		old, ok := w.identityTips[id]
+		if ok && old == tip {
+			continue
+		}
+
+		i, err := identity.ReadLocal(c.repo, id)
+		if err != nil {
+			return changed, err
+		}
+
+		c.muIdentity.Lock()
+		c.identitiesExcerpts[id] = NewIdentityExcerpt(i)
+		c.identityIds.insert(id)
+		c.muIdentity.Unlock()
+
+		w.identityTips[id] = tip
+		changed = true
+
+		typ := ChangeEventUpdated
+		if !ok {
+			typ = ChangeEventAdded
+		}
+		c.notifyChange(ChangeEvent{Typ: typ, Target: "identities", Id: id})
+	}
+
+	for id := range w.identityTips {
+		if _, ok := tips[id]; ok {
+			continue
+		}
+
+		c.muIdentity.Lock()
+		delete(c.identitiesExcerpts, id)
+		c.identityIds.remove(id)
+		delete(c.identities, id)
+		c.muIdentity.Unlock()
+
+		delete(w.identityTips, id)
+		changed = true
+
+		c.notifyChange(ChangeEvent{Typ: ChangeEventRemoved, Target: "identities", Id: id})
+	}
+
+	return changed, nil
+}
+
+func (w *refWatcher) refreshBugs(tips map[entity.Id]repository.Hash) (bool, error) {
+	c := w.cache
+	changed := false
+
+	for id, tip := range tips {
+		old, ok := w.bugTips[id]
+		if ok && old == tip {
+			continue
+		}
+		w.bugTips[id] = tip
+
+		c.muBug.RLock()
+		cached, loaded := c.bugs[id]
+		c.muBug.RUnlock()
+
+		// the change has been made through this cache, it's already
+		// up to date and the change has been notified
+		if loaded {
+			cached.mu.RLock()
+			upToDate := cached.bug.LastCommit() == tip
+			cached.mu.RUnlock()
+			if upToDate {
+				continue
+			}
+		}
+
+		b, err := bug.ReadLocalWithResolver(c.repo, newIdentityCacheResolver(c), id)
+		if err != nil {
+			return changed, err
+		}
+		snap := c.compileUpdatedBug(b)
+
+		c.muBug.Lock()
+		c.bugExcerpts[id] = NewBugExcerpt(b, snap)
+		c.bugIds.insert(id)
+		c.search.index(id, snap)
+		c.muBug.Unlock()
+
+		changed = true
+
+		typ := ChangeEventUpdated
+		if !ok {
+			typ = ChangeEventAdded
+		}
+		c.notifyChange(ChangeEvent{Typ: typ, Target: "bugs", Id: id})
+	}
+
+	for id := range w.bugTips {
+		if _, ok := tips[id]; ok {
+			continue
+		}
+
+		c.muBug.Lock()
+		delete(c.bugs, id)
+		delete(c.bugExcerpts, id)
+		c.bugIds.remove(id)
+		c.loadedBugs.Remove(id)
+		c.snapshots.remove(id)
+		c.search.remove(id)
+		c.muBug.Unlock()
+
+		delete(w.bugTips, id)
+		changed = true
+
+		c.notifyChange(ChangeEvent{Typ: ChangeEventRemoved, Target: "bugs", Id: id})
+	}
+
+	return changed, nil
+}
@@ -0,0 +1,209 @@
+package cache
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/query"
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+// the other repositories of a workspace are stored in the repository config as:
+// git-bug.workspace.<name>.path = <path>
+const workspaceConfigKeyPrefix = "git-bug.workspace."
+
+// QualifiedId is the id of a bug namespaced by the name of its repository in
+// a workspace. The bugs of the default repository have no namespace.
+type QualifiedId struct {
+	Repo string
+	Id   entity.Id
+}
+
+func (qi QualifiedId) String() string {
+	if qi.Repo == "" {
+		return qi.Id.String()
+	}
+	return qi.Repo + "/" + qi.Id.String()
+}
+
+// Human return the qualified id with a truncated bug id, for human consumption
+func (qi QualifiedId) Human() string {
+	if qi.Repo == "" {
+		return qi.Id.Human()
+	}
+	return qi.Repo + "/" + qi.Id.Human()
+}
+
+// ValidateWorkspaceName check that a name can be used for a repository of
+// a workspace
+func ValidateWorkspaceName(name string) error {
+	if name == "" {
+		return fmt.Errorf("empty repository name")
+	}
+	if strings.ContainsAny(name, "/. \t\n") {
+		return fmt.Errorf("invalid repository name %s: it can't contain slashes, dots or spaces", name)
+	}
+	return nil
+}
+
+// ReadWorkspace read from the repository config the paths of the other
+// repositories of the workspace, by name
+func ReadWorkspace(config repository.ConfigRead) (map[string]string, error) {
+	pairs, err := config.ReadAll(workspaceConfigKeyPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]string)
+
+	for key, value := range pairs {
+		key = strings.TrimPrefix(key, workspaceConfigKeyPrefix)
+		split := strings.Split(key, ".")
+		if len(split) != 2 || split[1] != "path" {
+			return nil, fmt.Errorf("invalid workspace config key %s", key)
+		}
+		result[split[0]] = value
+	}
+
+	return result, nil
+}
+
+// AddWorkspaceRepo store in the repository config a repository of the workspace
+func AddWorkspaceRepo(config repository.Config, name string, path string) error {
+	if err := ValidateWorkspaceName(name); err != nil {
+		return err
+	}
+	return config.StoreString(workspaceConfigKeyPrefix+name+".path", path)
+}
+
+// RemoveWorkspaceRepo remove from the repository config a repository of the workspace
+func RemoveWorkspaceRepo(config repository.Config, name string) error {
+	return config.RemoveAll(workspaceConfigKeyPrefix + name)
+}
+
+// QueryBugs execute the query on all the repositories and merge the results.
+// As the logical clocks of different repositories can't be compared, the
+// results are ordered by timestamp.
+func (c *MultiRepoCache) QueryBugs(q *query.Query) ([]QualifiedId, error) {
+	type result struct {
+		id      QualifiedId
+		excerpt *BugExcerpt
+	}
+
+	var results []result
+
+	for name, r := range c.repos {
+		for _, id := range r.QueryBugs(q) {
+			excerpt, err := r.ResolveBugExcerpt(id)
+			if err != nil {
+				return nil, err
+			}
+			results = append(results, result{
+				id:      QualifiedId{Repo: name, Id: id},
+				excerpt: excerpt,
+			})
+		}
+	}
+
+	orderBy := query.OrderByCreation
+	direction := query.OrderAscending
+	if q != nil {
+		orderBy = q.OrderBy
+		direction = q.OrderDirection
+	}
+
+	less := func(i, j int) bool {
+		a, b := results[i], results[j]
+		switch orderBy {
+		case query.OrderByCreation:
+			if a.excerpt.CreateUnixTime != b.excerpt.CreateUnixTime {
+				return a.excerpt.CreateUnixTime < b.excerpt.CreateUnixTime
+			}
+		case query.OrderByEdit:
+			if a.excerpt.EditUnixTime != b.excerpt.EditUnixTime {
+				return a.excerpt.EditUnixTime < b.excerpt.EditUnixTime
+			}
+		case query.OrderByComments:
+			if a.excerpt.LenComments != b.excerpt.LenComments {
+				return a.excerpt.LenComments < b.excerpt.LenComments
+			}
+		case query.OrderByDueDate:
+			if a.excerpt.DueUnixTime != b.excerpt.DueUnixTime {
+				return a.excerpt.DueUnixTime < b.excerpt.DueUnixTime
+			}
+		}
+		return a.id.String() < b.id.String()
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if direction == query.OrderDescending {
+			return less(j, i)
+		}
+		return less(i, j)
+	})
+
+	ids := make([]QualifiedId, len(results))
+	for i, r := range results {
+		ids[i] = r.id
+	}
+
+	return ids, nil
+}
+
+// ResolveBugExcerpt retrieve the BugExcerpt of a bug of the workspace
+func (c *MultiRepoCache) ResolveBugExcerpt(id QualifiedId) (*BugExcerpt, error) {
+	r, err := c.ResolveRepo(id.Repo)
+	if err != nil {
+		return nil, err
+	}
+	return r.ResolveBugExcerpt(id.Id)
+}
+
+// ResolveBugPrefix retrieve a bug matching a qualified id prefix, that is
+// "<repo>/<prefix>". Without a repository name, all the repositories are
+// searched. It fails if multiple bugs match.
+func (c *MultiRepoCache) ResolveBugPrefix(prefix string) (QualifiedId, *BugCache, error) {
+	var repos []string
+
+	if split := strings.SplitN(prefix, "/", 2); len(split) == 2 {
+		if _, ok := c.repos[split[0]]; !ok {
+			return QualifiedId{}, nil, fmt.Errorf("unknown repository %s", split[0])
+		}
+		repos = []string{split[0]}
+		prefix = split[1]
+	} else {
+		repos = c.Names()
+	}
+
+	var matching []QualifiedId
+
+	for _, name := range repos {
+		excerpt, err := c.repos[name].ResolveBugExcerptPrefix(prefix)
+		if err == bug.ErrBugNotExist {
+			continue
+		}
+		if err != nil {
+			return QualifiedId{}, nil, err
+		}
+		matching = append(matching, QualifiedId{Repo: name, Id: excerpt.Id})
+	}
+
+	if len(matching) == 0 {
+		return QualifiedId{}, nil, bug.ErrBugNotExist
+	}
+
+	if len(matching) > 1 {
+		return QualifiedId{}, nil, fmt.Errorf("multiple bugs match the prefix %s in the workspace: %s and %s",
+			prefix, matching[0].Human(), matching[1].Human())
+	}
+
+	b, err := c.repos[matching[0].Repo].ResolveBug(matching[0].Id)
+	if err != nil {
+		return QualifiedId{}, nil, err
+	}
+
+	return matching[0], b, nil
+}
@@ -3,6 +3,8 @@ package commands
 import (
 	"github.com/spf13/cobra"
 
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/cache"
 	"github.com/MichaelMure/git-bug/input"
 )
 
@@ -10,6 +12,10 @@ type addOptions struct {
 	title       string
 	message     string
 	messageFile string
+	template    string
+	draft       bool
+	encryptFor  []string
+	coAuthors   []string
 }
 
 func newAddCommand() *cobra.Command {
@@ -17,8 +23,26 @@ func newAddCommand() *cobra.Command {
 	options := addOptions{}
 
 	cmd := &cobra.Command{
-		Use:      "add",
-		Short:    "Create a new bug.",
+		Use:   "add",
+		Short: "Create a new bug.",
+		Example: `Create a bug from a markdown file:
+git bug add --from-file report.md
+
+with report.md holding an optional front-matter:
+---
+title: Crash on startup
+labels: bug, crash
+assignee: me
+fields:
+  severity: high
+metadata:
+  ci-job: "1234"
+---
+## Stacktrace
+...
+
+Create a bug from the standard input:
+./crash-report.sh | git bug add -F -`,
 		PreRunE:  loadBackendEnsureUser(env),
 		PostRunE: closeBackend(env),
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -34,22 +58,60 @@ func newAddCommand() *cobra.Command {
 	flags.StringVarP(&options.message, "message", "m", "",
 		"Provide a message to describe the issue")
 	flags.StringVarP(&options.messageFile, "file", "F", "",
-		"Take the message from the given file. Use - to read the message from the standard input")
+		"Take the title and message from the given markdown file, with an optional front-matter setting the labels, assignees, custom fields and metadata. Use - to read the standard input")
+	flags.StringVar(&options.messageFile, "from-file", "",
+		"Same as --file")
+	flags.StringVarP(&options.template, "template", "T", "",
+		"Start from the given template of .git-bug/templates, applying its labels and custom fields")
+	flags.BoolVarP(&options.draft, "draft", "d", false,
+		"Create the bug as a draft, that won't be pushed until published")
+	flags.StringSliceVarP(&options.encryptFor, "encrypt-for", "e", nil,
+		"Create a confidential bug, encrypted for the public keys of the given identities. Include yourself to be able to read it.")
+	flags.StringSliceVarP(&options.coAuthors, "co-author", "c", nil,
+		"Credit the given identities (id or id prefix) as co-authors of the bug")
 
 	return cmd
 }
 
 func runAdd(env *Env, opts addOptions) error {
 	var err error
+
+	var tmpl *bug.Template
+	if opts.template != "" {
+		tmpl, err = env.backend.ResolveTemplate(opts.template)
+		if err != nil {
+			return err
+		}
+		if opts.title == "" {
+			opts.title = tmpl.Title
+		}
+	}
+
+	var assignees []*cache.IdentityCache
+	var metadata map[string]string
+
 	if opts.messageFile != "" && opts.message == "" {
-		opts.title, opts.message, err = input.BugCreateFileInput(opts.messageFile)
+		bf, err := input.BugFileInput(opts.messageFile)
+		if err != nil {
+			return err
+		}
+		opts.title, opts.message = bf.Title, bf.Message
+		tmpl = mergeTemplates(tmpl, bf.Template())
+		metadata = bf.Metadata
+
+		assignees, err = resolveAssignees(env, bf.Assignees)
 		if err != nil {
 			return err
 		}
 	}
 
 	if opts.messageFile == "" && (opts.message == "" || opts.title == "") {
-		opts.title, opts.message, err = input.BugCreateEditorInput(env.backend, opts.title, opts.message)
+		preMessage := opts.message
+		if tmpl != nil && preMessage == "" {
+			preMessage = tmpl.Message
+		}
+
+		opts.title, opts.message, err = input.BugCreateEditorInput(env.backend, opts.title, preMessage)
 
 		if err == input.ErrEmptyTitle {
 			env.out.Println("Empty title, aborting.")
@@ -60,12 +122,117 @@ func runAdd(env *Env, opts addOptions) error {
 		}
 	}
 
-	b, _, err := env.backend.NewBug(opts.title, opts.message)
+	recipients, err := resolveIdentityPrefixes(env, opts.encryptFor)
+	if err != nil {
+		return err
+	}
+
+	coAuthors, err := resolveIdentityPrefixes(env, opts.coAuthors)
+	if err != nil {
+		return err
+	}
+
+	var b *cache.BugCache
+	var op *bug.CreateOperation
+	switch {
+	case opts.draft || len(recipients) > 0 || len(coAuthors) > 0:
+		b, op, err = env.backend.NewBugWithOptions(opts.title, opts.message, cache.NewBugOptions{
+			Draft:      opts.draft,
+			Recipients: recipients,
+			CoAuthors:  coAuthors,
+		})
+		if err == nil && tmpl != nil {
+			err = b.ApplyTemplate(tmpl)
+		}
+	case tmpl != nil:
+		b, op, err = env.backend.NewBugFromTemplate(tmpl, opts.title, opts.message)
+	default:
+		b, op, err = env.backend.NewBug(opts.title, opts.message)
+	}
+	if err != nil {
+		return err
+	}
+
+	if len(assignees) > 0 {
+		_, err = b.Assign(assignees, nil)
+		if err != nil {
+			return err
+		}
+	}
+	if len(metadata) > 0 {
+		_, err = b.SetMetadata(op.Id(), metadata)
+		if err != nil {
+			return err
+		}
+	}
+	err = b.CommitAsNeeded()
 	if err != nil {
 		return err
 	}
 
+	if opts.draft {
+		env.out.Printf("%s created as a draft\n", b.Id().Human())
+		return nil
+	}
+
 	env.out.Printf("%s created\n", b.Id().Human())
 
 	return nil
 }
+
+// mergeTemplates combine the labels and custom fields of two templates, the
+// second one taking precedence
+func mergeTemplates(tmpl *bug.Template, other *bug.Template) *bug.Template {
+	if len(other.Labels) == 0 && len(other.Fields) == 0 {
+		return tmpl
+	}
+	if tmpl == nil {
+		return other
+	}
+
+	merged := *tmpl
+	merged.Labels = append(append([]string{}, tmpl.Labels...), other.Labels...)
+	merged.Fields = make(map[string]string)
+	for name, value := range tmpl.Fields {
+		merged.Fields[name] = value
+	}
+	for name, value := range other.Fields {
+		merged.Fields[name] = value
+	}
+
+	return &merged
+}
+
+// resolveAssignees resolve the given id prefixes, "me" being the user identity
+func resolveAssignees(env *Env, prefixes []string) ([]*cache.IdentityCache, error) {
+	var result []*cache.IdentityCache
+	for _, prefix := range prefixes {
+		if prefix == "me" {
+			user, err := env.backend.GetUserIdentity()
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, user)
+			continue
+		}
+
+		i, err := env.backend.ResolveIdentityPrefix(prefix)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, i)
+	}
+	return result, nil
+}
+
+func resolveIdentityPrefixes(env *Env, prefixes []string) ([]*cache.IdentityCache, error) {
+	var result []*cache.IdentityCache
+	for _, prefix := range prefixes {
+		i, err := env.backend.ResolveIdentityPrefix(prefix)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, i)
+	}
+	return result, nil
+}
@@ -0,0 +1,154 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+// the aliases are defined in the git config, local or global, as:
+// git-bug.alias.<name> = <command> [args...]
+const aliasConfigKeyPrefix = "git-bug.alias."
+
+// expandAliases replace the command name by its alias definition, if any.
+// Like with git, the built-in commands can't be overridden and the
+// remaining arguments are appended to the definition.
+func expandAliases(root *cobra.Command, args []string) ([]string, error) {
+	// skip the persistent flags given before the command name, the flags are
+	// not parsed yet but --repo is needed to read the aliases
+	flag := ""
+	i := 0
+	for i < len(args) && strings.HasPrefix(args[i], "-") {
+		switch {
+		case args[i] == "--repo" && i+1 < len(args):
+			flag = args[i+1]
+			i += 2
+		case args[i] == "--format" && i+1 < len(args):
+			i += 2
+		case strings.HasPrefix(args[i], "--repo="):
+			flag = strings.TrimPrefix(args[i], "--repo=")
+			i++
+		default:
+			i++
+		}
+	}
+	if i >= len(args) {
+		return args, nil
+	}
+
+	path, err := repoPath(flag)
+	if err != nil {
+		return args, nil
+	}
+
+	// the command reuse the repository instead of opening it again
+	repo, err := repository.NewGoGitRepo(path, []repository.ClockLoader{bug.ClockLoader})
+	if err != nil {
+		// outside of a repository, let the command report it
+		return args, nil
+	}
+	aliasRepo, aliasRepoPath = repo, path
+
+	expanded, err := expandAlias(root, repo, args[i:])
+	if err != nil {
+		return nil, err
+	}
+
+	return append(args[:i:i], expanded...), nil
+}
+
+// expandAlias expand the alias at the beginning of the arguments, and the
+// aliases it refers to
+func expandAlias(root *cobra.Command, repo repository.RepoConfig, args []string) ([]string, error) {
+	seen := make(map[string]bool)
+
+	for !isBuiltinCommand(root, args[0]) {
+		definition, err := repo.AnyConfig().ReadString(aliasConfigKeyPrefix + args[0])
+		if err == repository.ErrNoConfigEntry {
+			return args, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if seen[args[0]] {
+			return nil, errInvalidInput{err: fmt.Errorf("alias loop detected with %s", args[0])}
+		}
+		seen[args[0]] = true
+
+		expanded, err := splitAliasArgs(definition)
+		if err != nil {
+			return nil, errInvalidInput{err: fmt.Errorf("alias %s: %v", args[0], err)}
+		}
+		if len(expanded) == 0 {
+			return nil, errInvalidInput{err: fmt.Errorf("alias %s is empty", args[0])}
+		}
+
+		args = append(expanded, args[1:]...)
+	}
+
+	return args, nil
+}
+
+// isBuiltinCommand tell if name is a command, or a command alias, of the root
+func isBuiltinCommand(root *cobra.Command, name string) bool {
+	for _, cmd := range root.Commands() {
+		if cmd.Name() == name || cmd.HasAlias(name) {
+			return true
+		}
+	}
+	// added by cobra at execution
+	return name == "help"
+}
+
+// splitAliasArgs split an alias definition in arguments, honoring single
+// quotes, double quotes and backslash escapes
+func splitAliasArgs(definition string) ([]string, error) {
+	var result []string
+	var current strings.Builder
+	inArg := false
+	var quote rune
+	escaped := false
+
+	for _, r := range definition {
+		switch {
+		case escaped:
+			current.WriteRune(r)
+			escaped = false
+		case r == '\\' && quote != '\'':
+			escaped = true
+			inArg = true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				current.WriteRune(r)
+			}
+		case r == '"' || r == '\'':
+			quote = r
+			inArg = true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inArg {
+				result = append(result, current.String())
+				current.Reset()
+				inArg = false
+			}
+		default:
+			current.WriteRune(r)
+			inArg = true
+		}
+	}
+
+	if escaped || quote != 0 {
+		return nil, fmt.Errorf("unterminated quote or escape")
+	}
+	if inArg {
+		result = append(result, current.String())
+	}
+
+	return result, nil
+}
@@ -0,0 +1,132 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/MichaelMure/git-bug/cache"
+	_select "github.com/MichaelMure/git-bug/commands/select"
+	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/identity"
+)
+
+func newAssignCommand() *cobra.Command {
+	env := newEnv()
+
+	cmd := &cobra.Command{
+		Use:   "assign [ID] USER...",
+		Short: "Assign users to a bug.",
+		Long: `Assign users to a bug.
+
+A USER is designated by an id prefix, or by a prefix of its name or login. "me" designate yourself, and @handle a team or the user with this exact login.`,
+		Example:           `git bug assign 8f3a2c1 me descartes @backend-team`,
+		PreRunE:           loadBackendEnsureUser(env),
+		PostRunE:          closeBackend(env),
+		ValidArgsFunction: completeBugAndUser(env),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runAssign(env, args, true)
+		},
+	}
+
+	return cmd
+}
+
+func newUnassignCommand() *cobra.Command {
+	env := newEnv()
+
+	cmd := &cobra.Command{
+		Use:   "unassign [ID] USER...",
+		Short: "Remove assigned users from a bug.",
+		Long: `Remove assigned users from a bug.
+
+A USER is designated by an id prefix, or by a prefix of its name or login. "me" designate yourself, and @handle a team or the user with this exact login.`,
+		PreRunE:           loadBackendEnsureUser(env),
+		PostRunE:          closeBackend(env),
+		ValidArgsFunction: completeBugAndUser(env),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runAssign(env, args, false)
+		},
+	}
+
+	return cmd
+}
+
+func runAssign(env *Env, args []string, assign bool) error {
+	b, args, err := _select.ResolveBug(env.backend, args)
+	if err != nil {
+		return err
+	}
+
+	if len(args) == 0 {
+		return fmt.Errorf("no user given")
+	}
+
+	snap := b.Snapshot()
+
+	var changed []*cache.IdentityCache
+	for _, arg := range args {
+		user, err := resolveUser(env, arg)
+		if err != nil {
+			return err
+		}
+
+		// only keep the actual changes
+		if snap.HasAssignee(user.Id()) == assign {
+			continue
+		}
+		changed = append(changed, user)
+	}
+
+	if len(changed) == 0 {
+		env.out.Println("Nothing to change.")
+		return nil
+	}
+
+	if assign {
+		_, err = b.Assign(changed, nil)
+	} else {
+		_, err = b.Assign(nil, changed)
+	}
+	if err != nil {
+		return err
+	}
+
+	return b.Commit()
+}
+
+// resolveUser find the identity designated by "me", @login, an id prefix, or
+// a prefix of its name or login
+func resolveUser(env *Env, query string) (*cache.IdentityCache, error) {
+	if query == cache.MeValue {
+		return env.backend.GetUserIdentity()
+	}
+
+	if strings.HasPrefix(query, "@") {
+		login := strings.TrimPrefix(query, "@")
+		i, err := env.backend.ResolveIdentityMatcher(func(excerpt *cache.IdentityExcerpt) bool {
+			return strings.EqualFold(excerpt.Login, login)
+		})
+		if err == identity.ErrIdentityNotExist {
+			return nil, fmt.Errorf("no user or team %s", query)
+		}
+		return i, err
+	}
+
+	i, err := env.backend.ResolveIdentityPrefix(query)
+	if err == nil || entity.IsErrMultipleMatch(err) {
+		return i, err
+	}
+
+	lower := strings.ToLower(query)
+	i, err = env.backend.ResolveIdentityMatcher(func(excerpt *cache.IdentityExcerpt) bool {
+		return strings.HasPrefix(strings.ToLower(excerpt.Name), lower) ||
+			strings.HasPrefix(strings.ToLower(excerpt.Login), lower)
+	})
+	if err == identity.ErrIdentityNotExist {
+		return nil, fmt.Errorf("no user matching %s", query)
+	}
+
+	return i, err
+}
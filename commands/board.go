@@ -0,0 +1,59 @@
+package commands
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/MichaelMure/git-bug/util/colors"
+)
+
+func newBoardCommand() *cobra.Command {
+	env := newEnv()
+
+	cmd := &cobra.Command{
+		Use:      "board",
+		Short:    "List kanban boards.",
+		PreRunE:  loadBackend(env),
+		PostRunE: closeBackend(env),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runBoard(env)
+		},
+		Args: cobra.NoArgs,
+	}
+
+	cmd.AddCommand(newBoardColumnsCommand())
+	cmd.AddCommand(newBoardMoveCommand())
+	cmd.AddCommand(newBoardNewCommand())
+	cmd.AddCommand(newBoardRmCommand())
+	cmd.AddCommand(newBoardShowCommand())
+
+	return cmd
+}
+
+func runBoard(env *Env) error {
+	ids, err := env.backend.AllBoardIds()
+	if err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		b, err := env.backend.ResolveBoard(id)
+		if err != nil {
+			return err
+		}
+
+		snap := b.Snapshot()
+
+		cards := 0
+		for _, column := range snap.Columns {
+			cards += len(column.Cards)
+		}
+
+		env.out.Printf("%s %s (%d bugs)\n",
+			colors.Cyan(id.Human()),
+			snap.Title,
+			cards,
+		)
+	}
+
+	return nil
+}
@@ -0,0 +1,41 @@
+package commands
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func newBoardColumnsCommand() *cobra.Command {
+	env := newEnv()
+
+	cmd := &cobra.Command{
+		Use:   "columns BOARD COLUMN...",
+		Short: "Change the columns of a kanban board.",
+		Long: `Change the columns of a kanban board.
+
+The given columns replace the existing ones, in order. Bugs in a column that
+is kept stay there, bugs in a removed column are taken out of the board.`,
+		Example:  `git bug board columns 8d1c Backlog "In progress" Review Done`,
+		PreRunE:  loadBackendEnsureUser(env),
+		PostRunE: closeBackend(env),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runBoardColumns(env, args)
+		},
+		Args: cobra.MinimumNArgs(2),
+	}
+
+	return cmd
+}
+
+func runBoardColumns(env *Env, args []string) error {
+	b, err := env.backend.ResolveBoardPrefix(args[0])
+	if err != nil {
+		return err
+	}
+
+	_, err = b.SetColumns(args[1:])
+	if err != nil {
+		return err
+	}
+
+	return b.Commit()
+}
@@ -0,0 +1,58 @@
+package commands
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+)
+
+func newBoardMoveCommand() *cobra.Command {
+	env := newEnv()
+
+	cmd := &cobra.Command{
+		Use:   "move BOARD BUG COLUMN [POSITION]",
+		Short: "Put a bug in a column of a kanban board.",
+		Long: `Put a bug in a column of a kanban board, adding it to the board if needed.
+
+The position starts at 1 for the top of the column. By default, the bug is put at the bottom.`,
+		Example:  `git bug board move 8d1c 2f4a "In progress"`,
+		PreRunE:  loadBackendEnsureUser(env),
+		PostRunE: closeBackend(env),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runBoardMove(env, args)
+		},
+		Args: cobra.RangeArgs(3, 4),
+	}
+
+	return cmd
+}
+
+func runBoardMove(env *Env, args []string) error {
+	b, err := env.backend.ResolveBoardPrefix(args[0])
+	if err != nil {
+		return err
+	}
+
+	target, err := env.backend.ResolveBugPrefix(args[1])
+	if err != nil {
+		return err
+	}
+
+	position := -1
+	if len(args) == 4 {
+		position, err = strconv.Atoi(args[3])
+		if err != nil || position < 1 {
+			return fmt.Errorf("invalid position %s", args[3])
+		}
+		// from 1-based to 0-based
+		position--
+	}
+
+	_, err = b.MoveCard(target.Id(), args[2], position)
+	if err != nil {
+		return err
+	}
+
+	return b.Commit()
+}
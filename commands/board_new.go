@@ -0,0 +1,38 @@
+package commands
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func newBoardNewCommand() *cobra.Command {
+	env := newEnv()
+
+	cmd := &cobra.Command{
+		Use:   "new TITLE [COLUMN...]",
+		Short: "Create a new kanban board.",
+		Long: `Create a new kanban board.
+
+If no column is given, the board is created with the columns "To do",
+"In progress" and "Done".`,
+		Example:  `git bug board new "Release 1.0" Backlog Doing Review Done`,
+		PreRunE:  loadBackendEnsureUser(env),
+		PostRunE: closeBackend(env),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runBoardNew(env, args)
+		},
+		Args: cobra.MinimumNArgs(1),
+	}
+
+	return cmd
+}
+
+func runBoardNew(env *Env, args []string) error {
+	b, err := env.backend.NewBoard(args[0], args[1:])
+	if err != nil {
+		return err
+	}
+
+	env.out.Printf("%s created\n", b.Id().Human())
+
+	return nil
+}
@@ -0,0 +1,41 @@
+package commands
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func newBoardRmCommand() *cobra.Command {
+	env := newEnv()
+
+	cmd := &cobra.Command{
+		Use:      "rm BOARD BUG",
+		Short:    "Remove a bug from a kanban board.",
+		PreRunE:  loadBackendEnsureUser(env),
+		PostRunE: closeBackend(env),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runBoardRm(env, args)
+		},
+		Args: cobra.ExactArgs(2),
+	}
+
+	return cmd
+}
+
+func runBoardRm(env *Env, args []string) error {
+	b, err := env.backend.ResolveBoardPrefix(args[0])
+	if err != nil {
+		return err
+	}
+
+	target, err := env.backend.ResolveBugPrefix(args[1])
+	if err != nil {
+		return err
+	}
+
+	_, err = b.RemoveCard(target.Id())
+	if err != nil {
+		return err
+	}
+
+	return b.Commit()
+}
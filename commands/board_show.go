@@ -0,0 +1,59 @@
+package commands
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/MichaelMure/git-bug/util/colors"
+)
+
+func newBoardShowCommand() *cobra.Command {
+	env := newEnv()
+
+	cmd := &cobra.Command{
+		Use:      "show BOARD",
+		Short:    "Display the columns and bugs of a kanban board.",
+		PreRunE:  loadBackend(env),
+		PostRunE: closeBackend(env),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runBoardShow(env, args)
+		},
+		Args: cobra.ExactArgs(1),
+	}
+
+	return cmd
+}
+
+func runBoardShow(env *Env, args []string) error {
+	b, err := env.backend.ResolveBoardPrefix(args[0])
+	if err != nil {
+		return err
+	}
+
+	snap := b.Snapshot()
+
+	env.out.Printf("%s %s\n",
+		colors.Cyan(snap.Id().Human()),
+		snap.Title,
+	)
+
+	for _, column := range snap.Columns {
+		env.out.Printf("\n%s (%d)\n", colors.Bold(column.Name), len(column.Cards))
+
+		for _, card := range column.Cards {
+			excerpt, err := env.backend.ResolveBugExcerpt(card)
+			if err != nil {
+				// the bug might not be available locally
+				env.out.Printf("  %s\n", colors.Cyan(card.Human()))
+				continue
+			}
+
+			env.out.Printf("  %s %s\t%s\n",
+				colors.Cyan(card.Human()),
+				colors.Yellow(excerpt.Status),
+				excerpt.Title,
+			)
+		}
+	}
+
+	return nil
+}
@@ -3,6 +3,7 @@ package commands
 import (
 	"bufio"
 	"fmt"
+	"io/ioutil"
 	"os"
 	"strconv"
 	"strings"
@@ -16,11 +17,13 @@ import (
 )
 
 type bridgeConfigureOptions struct {
-	name       string
-	target     string
-	params     core.BridgeParams
-	token      string
-	tokenStdin bool
+	name           string
+	target         string
+	params         core.BridgeParams
+	token          string
+	tokenStdin     bool
+	tokenFile      string
+	nonInteractive bool
 }
 
 func newBridgeConfigureCommand() *cobra.Command {
@@ -30,7 +33,14 @@ func newBridgeConfigureCommand() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "configure",
 		Short: "Configure a new bridge.",
-		Long: `	Configure a new bridge by passing flags or/and using interactive terminal prompts. You can avoid all the terminal prompts by passing all the necessary flags to configure your bridge.`,
+		Long: `	Configure a new bridge by passing flags or/and using interactive terminal prompts. You can avoid all the terminal prompts by passing all the necessary flags to configure your bridge.
+
+The parameters can also be given with the environment variables GIT_BUG_BRIDGE_NAME, GIT_BUG_BRIDGE_TARGET,
+GIT_BUG_BRIDGE_URL, GIT_BUG_BRIDGE_BASE_URL, GIT_BUG_BRIDGE_LOGIN, GIT_BUG_BRIDGE_CREDENTIAL, GIT_BUG_BRIDGE_TOKEN,
+GIT_BUG_BRIDGE_OWNER and GIT_BUG_BRIDGE_PROJECT, the flags taking precedence.
+
+With --non-interactive, the configuration fails instead of prompting for a missing parameter, which is
+suited for provisioning scripts and CI.`,
 		Example: `# Interactive example
 [1]: github
 [2]: gitlab
@@ -83,7 +93,11 @@ git bug bridge configure \
     --name=default \
     --target=github \
     --url=https://github.com/michaelmure/git-bug \
-    --token=$(TOKEN)`,
+    --token=$(TOKEN)
+
+# In a CI
+GIT_BUG_BRIDGE_TARGET=github GIT_BUG_BRIDGE_URL=https://github.com/michaelmure/git-bug \
+    git bug bridge configure --non-interactive --token-file=/run/secrets/github-token`,
 		PreRunE:  loadBackend(env),
 		PostRunE: closeBackend(env),
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -103,16 +117,40 @@ git bug bridge configure \
 	flags.StringVarP(&options.params.CredPrefix, "credential", "c", "", "The identifier or prefix of an already known credential for your remote issue tracker (see \"git-bug bridge auth\")")
 	flags.StringVar(&options.token, "token", "", "A raw authentication token for the remote issue tracker")
 	flags.BoolVar(&options.tokenStdin, "token-stdin", false, "Will read the token from stdin and ignore --token")
+	flags.StringVar(&options.tokenFile, "token-file", "", "Will read the token from a file and ignore --token")
 	flags.StringVarP(&options.params.Owner, "owner", "o", "", "The owner of the remote repository")
 	flags.StringVarP(&options.params.Project, "project", "p", "", "The name of the remote repository")
+	flags.BoolVar(&options.nonInteractive, "non-interactive", false, "Fail instead of prompting for a missing parameter")
 
 	return cmd
 }
 
+// bridgeConfigureFromEnv fill the parameters not given with a flag from the
+// environment variables
+func bridgeConfigureFromEnv(opts *bridgeConfigureOptions) {
+	for name, value := range map[string]*string{
+		"GIT_BUG_BRIDGE_NAME":       &opts.name,
+		"GIT_BUG_BRIDGE_TARGET":     &opts.target,
+		"GIT_BUG_BRIDGE_URL":        &opts.params.URL,
+		"GIT_BUG_BRIDGE_BASE_URL":   &opts.params.BaseURL,
+		"GIT_BUG_BRIDGE_LOGIN":      &opts.params.Login,
+		"GIT_BUG_BRIDGE_CREDENTIAL": &opts.params.CredPrefix,
+		"GIT_BUG_BRIDGE_TOKEN":      &opts.token,
+		"GIT_BUG_BRIDGE_OWNER":      &opts.params.Owner,
+		"GIT_BUG_BRIDGE_PROJECT":    &opts.params.Project,
+	} {
+		if *value == "" {
+			*value = os.Getenv(name)
+		}
+	}
+}
+
 func runBridgeConfigure(env *Env, opts bridgeConfigureOptions) error {
 	var err error
 
-	if (opts.tokenStdin || opts.token != "" || opts.params.CredPrefix != "") &&
+	bridgeConfigureFromEnv(&opts)
+
+	if (opts.tokenStdin || opts.tokenFile != "" || opts.token != "" || opts.params.CredPrefix != "") &&
 		(opts.name == "" || opts.target == "") {
 		return fmt.Errorf("you must provide a bridge name and target to configure a bridge with a credential")
 	}
@@ -132,10 +170,20 @@ func runBridgeConfigure(env *Env, opts bridgeConfigureOptions) error {
 			return fmt.Errorf("reading from stdin: %v", err)
 		}
 		opts.params.TokenRaw = strings.TrimSpace(token)
+	case opts.tokenFile != "":
+		token, err := ioutil.ReadFile(opts.tokenFile)
+		if err != nil {
+			return fmt.Errorf("reading the token file: %v", err)
+		}
+		opts.params.TokenRaw = strings.TrimSpace(string(token))
 	case opts.token != "":
 		opts.params.TokenRaw = opts.token
 	}
 
+	if opts.target == "" && opts.nonInteractive {
+		return core.NewErrMissingParam("--target")
+	}
+
 	if opts.target == "" {
 		opts.target, err = promptTarget()
 		if err != nil {
@@ -143,6 +191,13 @@ func runBridgeConfigure(env *Env, opts bridgeConfigureOptions) error {
 		}
 	}
 
+	if opts.name == "" && opts.nonInteractive {
+		if core.BridgeExist(env.repo, defaultBridgeName) {
+			return core.NewErrMissingParam("--name")
+		}
+		opts.name = defaultBridgeName
+	}
+
 	if opts.name == "" {
 		opts.name, err = promptName(env.repo)
 		if err != nil {
@@ -155,7 +210,7 @@ func runBridgeConfigure(env *Env, opts bridgeConfigureOptions) error {
 		return err
 	}
 
-	err = b.Configure(opts.params)
+	err = b.Configure(opts.params, !opts.nonInteractive)
 	if err != nil {
 		return err
 	}
@@ -192,17 +247,17 @@ func promptTarget() (string, error) {
 	}
 }
 
+const defaultBridgeName = "default"
+
 func promptName(repo repository.RepoConfig) (string, error) {
 	// TODO: use the reusable prompt from the input package
-	const defaultName = "default"
-
-	defaultExist := core.BridgeExist(repo, defaultName)
+	defaultExist := core.BridgeExist(repo, defaultBridgeName)
 
 	for {
 		if defaultExist {
 			fmt.Printf("name: ")
 		} else {
-			fmt.Printf("name [%s]: ", defaultName)
+			fmt.Printf("name [%s]: ", defaultBridgeName)
 		}
 
 		line, err := bufio.NewReader(os.Stdin).ReadString('\n')
@@ -218,7 +273,7 @@ func promptName(repo repository.RepoConfig) (string, error) {
 		}
 
 		if name == "" {
-			name = defaultName
+			name = defaultBridgeName
 		}
 
 		if !core.BridgeExist(repo, name) {
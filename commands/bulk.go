@@ -0,0 +1,114 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/pflag"
+
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/input"
+	"github.com/MichaelMure/git-bug/query"
+	"github.com/MichaelMure/git-bug/util/colors"
+)
+
+// bulkOptions allow a command to change all the bugs matching a query,
+// instead of a single one
+type bulkOptions struct {
+	query string
+	yes   bool
+}
+
+func addBulkFlags(flags *pflag.FlagSet, opts *bulkOptions) {
+	flags.StringVarP(&opts.query, "query", "q", "",
+		"Apply to all the bugs matching the query, after confirmation")
+	flags.BoolVarP(&opts.yes, "yes", "y", false,
+		"Don't ask for confirmation when applying to a query")
+}
+
+// applyToBugs apply a change to the given bugs and commit them. With several
+// bugs, the result is reported for each one and all of them are processed even
+// if some fail. The change return a short description of what it did.
+func applyToBugs(env *Env, bugs []*cache.BugCache, change func(b *cache.BugCache) (string, error)) error {
+	if len(bugs) == 1 {
+		if _, err := change(bugs[0]); err != nil {
+			return err
+		}
+		return bugs[0].Commit()
+	}
+
+	failed := 0
+
+	for _, b := range bugs {
+		result, err := change(b)
+		if err == nil {
+			err = b.Commit()
+		}
+		if err != nil {
+			failed++
+			env.err.Printf("%s: %v\n", colors.Cyan(b.Id().Human()), err)
+			continue
+		}
+		env.out.Printf("%s: %s\n", colors.Cyan(b.Id().Human()), result)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d bugs failed", failed, len(bugs))
+	}
+
+	return nil
+}
+
+// resolveBulk return the bugs matching the query of the options, after
+// listing them and asking for confirmation. An empty list is returned if the
+// user declines.
+func resolveBulk(env *Env, opts bulkOptions, action string) ([]*cache.BugCache, error) {
+	saved, err := query.ReadSavedQueries(env.repo.LocalConfig())
+	if err != nil {
+		return nil, err
+	}
+
+	q, err := query.ParseWithSaved(opts.query, saved)
+	if err != nil {
+		return nil, queryError(err)
+	}
+
+	ids := env.backend.QueryBugs(q)
+	if len(ids) == 0 {
+		env.out.Println("No bug matching the query.")
+		return nil, nil
+	}
+
+	for _, id := range ids {
+		excerpt, err := env.backend.ResolveBugExcerpt(id)
+		if err != nil {
+			return nil, err
+		}
+		env.out.Printf("%s %s\t%s\n",
+			colors.Cyan(excerpt.Id.Human()),
+			colors.Yellow(excerpt.Status),
+			strings.TrimSpace(excerpt.Title),
+		)
+	}
+
+	if !opts.yes {
+		ok, err := input.PromptConfirm(fmt.Sprintf("%s %d bug(s)?", action, len(ids)))
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			env.out.Println("Aborted.")
+			return nil, nil
+		}
+	}
+
+	bugs := make([]*cache.BugCache, len(ids))
+	for i, id := range ids {
+		bugs[i], err = env.backend.ResolveBug(id)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return bugs, nil
+}
@@ -0,0 +1,22 @@
+package commands
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func newBundleCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "bundle",
+		Short: "Exchange bugs as git bundle files.",
+		Long: `Exchange bugs as git bundle files.
+
+A bundle hold the bugs and the identities in a single file, to synchronize repositories without a network
+connection between them, like air-gapped environments. The files are standard git bundles, that git itself can
+read with "git bundle list-heads".`,
+	}
+
+	cmd.AddCommand(newBundleApplyCommand())
+	cmd.AddCommand(newBundleCreateCommand())
+
+	return cmd
+}
@@ -0,0 +1,60 @@
+package commands
+
+import (
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/MichaelMure/git-bug/entity"
+)
+
+func newBundleApplyCommand() *cobra.Command {
+	env := newEnv()
+
+	cmd := &cobra.Command{
+		Use:   "apply FILE",
+		Short: "Merge the bugs and the identities of a git bundle.",
+		Long: `Merge the bugs and the identities of a git bundle.
+
+The bugs are merged as with "git bug pull". Use - as the file to read the standard input.`,
+		PreRunE:  loadBackend(env),
+		PostRunE: closeBackend(env),
+		Args:     cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runBundleApply(env, args[0])
+		},
+	}
+
+	return cmd
+}
+
+func runBundleApply(env *Env, fileName string) error {
+	var r io.Reader = os.Stdin
+
+	if fileName != "-" {
+		f, err := os.Open(fileName)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		r = f
+	}
+
+	results, err := env.backend.ApplyBundle(r)
+	if err != nil {
+		return err
+	}
+
+	for result := range results {
+		if result.Err != nil {
+			env.err.Println(result.Err)
+		}
+
+		if result.Status != entity.MergeStatusNothing {
+			env.out.Printf("%s: %s\n", result.Id.Human(), result)
+		}
+	}
+
+	return nil
+}
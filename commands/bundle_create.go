@@ -0,0 +1,89 @@
+package commands
+
+import (
+	"errors"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/query"
+)
+
+type bundleCreateOptions struct {
+	query string
+}
+
+func newBundleCreateCommand() *cobra.Command {
+	env := newEnv()
+	options := bundleCreateOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "create FILE",
+		Short: "Write the bugs and the identities in a git bundle.",
+		Long: `Write the bugs and the identities in a git bundle.
+
+All the identities are written, as the bugs refer to them. Use - as the file to write on the standard output.`,
+		Example:  `git bug bundle create bugs.bundle --query "status:open label:security"`,
+		PreRunE:  loadBackendReadOnly(env),
+		PostRunE: closeBackend(env),
+		Args:     cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runBundleCreate(env, options, args[0])
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.SortFlags = false
+
+	flags.StringVarP(&options.query, "query", "q", "",
+		"Only write the bugs matching the query")
+
+	return cmd
+}
+
+func runBundleCreate(env *Env, opts bundleCreateOptions, fileName string) error {
+	var ids []entity.Id
+
+	if opts.query != "" {
+		saved, err := query.ReadSavedQueries(env.repo.LocalConfig())
+		if err != nil {
+			return err
+		}
+
+		q, err := query.ParseWithSaved(opts.query, saved)
+		if err != nil {
+			return queryError(err)
+		}
+
+		ids = env.backend.QueryBugs(q)
+		if len(ids) == 0 {
+			return errors.New("no bug matching the query")
+		}
+	}
+
+	if fileName == "-" {
+		return env.backend.CreateBundle(env.out, ids)
+	}
+
+	f, err := os.Create(fileName)
+	if err != nil {
+		return err
+	}
+
+	err = env.backend.CreateBundle(f, ids)
+	if err != nil {
+		_ = f.Close()
+		_ = os.Remove(fileName)
+		return err
+	}
+
+	err = f.Close()
+	if err != nil {
+		return err
+	}
+
+	env.err.Printf("Bundle written to %s\n", fileName)
+
+	return nil
+}
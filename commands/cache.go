@@ -0,0 +1,20 @@
+package commands
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func newCacheCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cache",
+		Short: "Manage the local cache of git-bug.",
+		Long: `Manage the local cache of git-bug.
+
+The cache is automatically migrated or rebuilt when its format change, those commands allow to do it explicitly.`,
+	}
+
+	cmd.AddCommand(newCacheMigrateCommand())
+	cmd.AddCommand(newCacheRebuildCommand())
+
+	return cmd
+}
@@ -0,0 +1,47 @@
+package commands
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/MichaelMure/git-bug/cache"
+)
+
+func newCacheMigrateCommand() *cobra.Command {
+	env := newEnv()
+
+	cmd := &cobra.Command{
+		Use:      "migrate",
+		Short:    "Upgrade the cache to the current format, or rebuild it if that's not possible.",
+		PreRunE:  loadRepo(env),
+		PostRunE: closeBackend(env),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runCacheMigrate(env)
+		},
+		Args: cobra.NoArgs,
+	}
+
+	return cmd
+}
+
+func runCacheMigrate(env *Env) error {
+	progress := buildProgress(env)
+	changed := false
+
+	var err error
+	env.backend, err = cache.NewRepoCacheWithProgress(env.repo, func(event cache.BuildEvent) {
+		switch event.Typ {
+		case cache.BuildEventStarted, cache.BuildEventMigrated:
+			changed = true
+		}
+		progress(event)
+	})
+	if err != nil {
+		return err
+	}
+
+	if !changed {
+		env.out.Println("The cache is up to date.")
+	}
+
+	return nil
+}
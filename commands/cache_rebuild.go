@@ -0,0 +1,22 @@
+package commands
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func newCacheRebuildCommand() *cobra.Command {
+	env := newEnv()
+
+	cmd := &cobra.Command{
+		Use:      "rebuild",
+		Short:    "Discard the cache and build it again from the repository data.",
+		PreRunE:  loadBackend(env),
+		PostRunE: closeBackend(env),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return env.backend.Rebuild()
+		},
+		Args: cobra.NoArgs,
+	}
+
+	return cmd
+}
@@ -0,0 +1,146 @@
+package commands
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/MichaelMure/git-bug/bug"
+	_select "github.com/MichaelMure/git-bug/commands/select"
+	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/util/colors"
+)
+
+func newChecklistCommand() *cobra.Command {
+	env := newEnv()
+
+	cmd := &cobra.Command{
+		Use:               "checklist [ID]",
+		Short:             "Display, check or uncheck the checklist items of a bug.",
+		PreRunE:           loadBackend(env),
+		PostRunE:          closeBackend(env),
+		ValidArgsFunction: completeBug(env),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runChecklist(env, args)
+		},
+	}
+
+	cmd.AddCommand(newChecklistCheckCommand())
+	cmd.AddCommand(newChecklistUncheckCommand())
+
+	return cmd
+}
+
+func runChecklist(env *Env, args []string) error {
+	b, args, err := _select.ResolveBug(env.backend, args)
+	if err != nil {
+		return err
+	}
+
+	snap := b.Snapshot()
+
+	n := 1
+	for _, comment := range snap.Comments {
+		for _, item := range bug.ParseChecklist(comment.Message) {
+			mark := " "
+			if item.Checked {
+				mark = "x"
+			}
+			env.out.Printf("%s [%s] %s\n", colors.Cyan(fmt.Sprintf("%3d", n)), mark, item.Text)
+			n++
+		}
+	}
+
+	done, total := snap.ChecklistProgress()
+	if total > 0 {
+		env.out.Printf("%d/%d done\n", done, total)
+	}
+
+	return nil
+}
+
+func newChecklistCheckCommand() *cobra.Command {
+	env := newEnv()
+
+	cmd := &cobra.Command{
+		Use:               "check [ID] ITEM...",
+		Short:             "Check items of the checklists of a bug, by their number.",
+		PreRunE:           loadBackendEnsureUser(env),
+		PostRunE:          closeBackend(env),
+		ValidArgsFunction: completeBug(env),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runChecklistSet(env, args, true)
+		},
+	}
+
+	return cmd
+}
+
+func newChecklistUncheckCommand() *cobra.Command {
+	env := newEnv()
+
+	cmd := &cobra.Command{
+		Use:               "uncheck [ID] ITEM...",
+		Short:             "Uncheck items of the checklists of a bug, by their number.",
+		PreRunE:           loadBackendEnsureUser(env),
+		PostRunE:          closeBackend(env),
+		ValidArgsFunction: completeBug(env),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runChecklistSet(env, args, false)
+		},
+	}
+
+	return cmd
+}
+
+func runChecklistSet(env *Env, args []string, checked bool) error {
+	b, args, err := _select.ResolveBug(env.backend, args)
+	if err != nil {
+		return err
+	}
+
+	if len(args) == 0 {
+		return fmt.Errorf("you must provide the number of an item")
+	}
+
+	snap := b.Snapshot()
+
+	for _, arg := range args {
+		n, err := strconv.Atoi(arg)
+		if err != nil {
+			return fmt.Errorf("invalid item number %s", arg)
+		}
+
+		target, item, err := checklistItemTarget(snap, n)
+		if err != nil {
+			return err
+		}
+
+		_, err = b.SetChecklistItem(target, item, checked)
+		if err != nil {
+			return err
+		}
+	}
+
+	return b.Commit()
+}
+
+// checklistItemTarget find the comment and the index in that comment of the
+// n-th checklist item of a bug, numbered from 1
+func checklistItemTarget(snap *bug.Snapshot, n int) (entity.Id, int, error) {
+	if n < 1 {
+		return "", 0, fmt.Errorf("invalid item number %d", n)
+	}
+
+	i := n
+	for _, comment := range snap.Comments {
+		items := bug.ParseChecklist(comment.Message)
+		if i <= len(items) {
+			return comment.Id(), i - 1, nil
+		}
+		i -= len(items)
+	}
+
+	return "", 0, fmt.Errorf("no checklist item %d", n)
+}
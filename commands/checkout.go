@@ -0,0 +1,175 @@
+package commands
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/cache"
+	_select "github.com/MichaelMure/git-bug/commands/select"
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+type checkoutOptions struct {
+	branch string
+	from   string
+}
+
+func newCheckoutCommand() *cobra.Command {
+	env := newEnv()
+	options := checkoutOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "checkout [ID]",
+		Short: "Switch to the branch of a bug, creating it if needed.",
+		Long: `Switch to the branch of a bug, creating it if needed.
+
+The branch is named from the bug, like "bug/1a2b3c4-crash-on-startup", and recorded in the bug as a code
+reference with the fixed-by role. Once the branch is merged into the default branch, "git bug sweep" offer to
+close the bug.`,
+		Example: `git bug checkout 2f4a
+git bug checkout 2f4a --branch fix-crash --from origin/main`,
+		PreRunE:           loadBackendEnsureUser(env),
+		PostRunE:          closeBackend(env),
+		ValidArgsFunction: completeBug(env),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runCheckout(env, options, args)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.SortFlags = false
+
+	flags.StringVarP(&options.branch, "branch", "b", "",
+		"Name of the branch to create, instead of the one derived from the bug")
+	flags.StringVar(&options.from, "from", "HEAD",
+		"Git revision to create the branch from")
+
+	return cmd
+}
+
+func runCheckout(env *Env, opts checkoutOptions, args []string) error {
+	// the branch can only be switched to in a working tree, that git can
+	// only find when not in pure-Go mode
+	workTree := ""
+	if !repository.PureGo() {
+		var err error
+		workTree, err = repoWorkTree()
+		if err != nil {
+			return err
+		}
+	}
+
+	b, args, err := _select.ResolveBug(env.backend, args)
+	if err != nil {
+		return err
+	}
+
+	name := opts.branch
+	if name == "" {
+		// reuse the branch already associated with the bug, if any
+		name = bugBranch(b.Snapshot())
+	}
+	if name == "" {
+		excerpt, err := env.backend.ResolveBugExcerpt(b.Id())
+		if err != nil {
+			return err
+		}
+		name = cache.BugBranchName(excerpt)
+	}
+
+	exist, err := env.backend.BranchExist(name)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		err = env.backend.CreateBranch(name, opts.from)
+		if err != nil {
+			return err
+		}
+		env.out.Printf("Branch %s created\n", name)
+	}
+
+	ref, err := bug.ParseCodeRef(bug.BranchCodeRef, bug.FixedByCodeRef, name)
+	if err != nil {
+		return err
+	}
+	if !hasCodeRef(b.Snapshot(), ref) {
+		_, err = b.AddCodeRef(ref)
+		if err != nil {
+			return err
+		}
+		err = b.Commit()
+		if err != nil {
+			return err
+		}
+	}
+
+	if repository.PureGo() {
+		return fmt.Errorf("switching to the branch %s: %w", name, repository.ErrGitCliRequired)
+	}
+
+	checkout := exec.Command("git", "checkout", name)
+	checkout.Dir = workTree
+	checkout.Stdout = env.out
+	checkout.Stderr = env.err
+
+	return checkout.Run()
+}
+
+// repoWorkTree return the top-level directory of the working tree of the
+// repository, or fail if it's a bare one
+func repoWorkTree() (string, error) {
+	path, err := repoPath(repoFlag)
+	if err != nil {
+		return "", err
+	}
+
+	bare := exec.Command("git", "rev-parse", "--is-bare-repository")
+	bare.Dir = path
+	stdout, err := bare.Output()
+	if err != nil {
+		return "", gitError(err)
+	}
+	if strings.TrimSpace(string(stdout)) == "true" {
+		return "", fmt.Errorf("the repository has no working tree")
+	}
+
+	topLevel := exec.Command("git", "rev-parse", "--show-toplevel")
+	topLevel.Dir = path
+	stdout, err = topLevel.Output()
+	if err != nil {
+		return "", gitError(err)
+	}
+	return strings.TrimSpace(string(stdout)), nil
+}
+
+// gitError return the error output of a failed git command as an error
+func gitError(err error) error {
+	if exitErr, ok := err.(*exec.ExitError); ok && len(exitErr.Stderr) > 0 {
+		return fmt.Errorf("%s", strings.TrimSpace(string(exitErr.Stderr)))
+	}
+	return err
+}
+
+// bugBranch return the first branch recorded as fixing the bug
+func bugBranch(snap *bug.Snapshot) string {
+	for _, ref := range snap.CodeRefsWithRole(bug.FixedByCodeRef) {
+		if ref.Kind == bug.BranchCodeRef {
+			return ref.Target
+		}
+	}
+	return ""
+}
+
+func hasCodeRef(snap *bug.Snapshot, ref bug.CodeRef) bool {
+	for _, existing := range snap.CodeRefs {
+		if existing == ref {
+			return true
+		}
+	}
+	return false
+}
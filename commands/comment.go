@@ -1,9 +1,13 @@
 package commands
 
 import (
+	"fmt"
+	"strings"
+
 	text "github.com/MichaelMure/go-term-text"
 	"github.com/spf13/cobra"
 
+	"github.com/MichaelMure/git-bug/bug"
 	_select "github.com/MichaelMure/git-bug/commands/select"
 	"github.com/MichaelMure/git-bug/util/colors"
 )
@@ -12,16 +16,24 @@ func newCommentCommand() *cobra.Command {
 	env := newEnv()
 
 	cmd := &cobra.Command{
-		Use:      "comment [ID]",
-		Short:    "Display or add comments to a bug.",
-		PreRunE:  loadBackend(env),
-		PostRunE: closeBackend(env),
+		Use:               "comment [ID]",
+		Short:             "Display or add comments to a bug.",
+		PreRunE:           loadBackend(env),
+		PostRunE:          closeBackend(env),
+		ValidArgsFunction: completeBug(env),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runComment(env, args)
 		},
 	}
 
 	cmd.AddCommand(newCommentAddCommand())
+	cmd.AddCommand(newCommentEditCommand())
+	cmd.AddCommand(newCommentMinimizeCommand())
+	cmd.AddCommand(newCommentPinCommand())
+	cmd.AddCommand(newCommentRedactCommand())
+	cmd.AddCommand(newCommentRmCommand())
+	cmd.AddCommand(newCommentUnminimizeCommand())
+	cmd.AddCommand(newCommentUnpinCommand())
 
 	return cmd
 }
@@ -34,16 +46,44 @@ func runComment(env *Env, args []string) error {
 
 	snap := b.Snapshot()
 
-	for i, comment := range snap.Comments {
+	for i, comment := range snap.Threads() {
 		if i != 0 {
 			env.out.Println()
 		}
 
-		env.out.Printf("Author: %s\n", colors.Magenta(comment.Author.DisplayName()))
-		env.out.Printf("Id: %s\n", colors.Cyan(comment.Id().Human()))
-		env.out.Printf("Date: %s\n\n", comment.FormatTime())
-		env.out.Println(text.LeftPadLines(comment.Message, 4))
+		// replies are indented under the comment they answer to
+		indent := strings.Repeat(" ", 4*comment.Depth)
+
+		env.out.Printf("%sAuthor: %s\n", indent, colors.Magenta(comment.Author.DisplayName()))
+		env.out.Printf("%sId: %s\n", indent, colors.Cyan(comment.Id().Human()))
+		if comment.ReplyTo != "" {
+			env.out.Printf("%sIn reply to: %s\n", indent, colors.Cyan(comment.ReplyTo.Human()))
+		}
+		if comment.Pinned {
+			env.out.Printf("%sPinned\n", indent)
+		}
+		env.out.Printf("%sDate: %s\n\n", indent, comment.FormatTime())
+
+		if comment.Redaction != nil {
+			env.out.Println(text.LeftPadLines(redactedMessage(comment.Redaction), 4+len(indent)))
+			continue
+		}
+
+		if comment.Minimized != "" {
+			env.out.Println(text.LeftPadLines(minimizedMessage(comment.Minimized), 4+len(indent)))
+			continue
+		}
+
+		env.out.Println(text.LeftPadLines(comment.Message, 4+len(indent)))
 	}
 
 	return nil
 }
+
+func redactedMessage(redaction *bug.Redaction) string {
+	return fmt.Sprintf("[redacted by %s: %s]", redaction.Author.DisplayName(), redaction.Reason)
+}
+
+func minimizedMessage(reason bug.MinimizeReason) string {
+	return fmt.Sprintf("[minimized as %s]", reason)
+}
@@ -1,15 +1,23 @@
 package commands
 
 import (
+	"fmt"
+	"strconv"
+	"strings"
+
 	"github.com/spf13/cobra"
 
+	"github.com/MichaelMure/git-bug/bug"
 	_select "github.com/MichaelMure/git-bug/commands/select"
+	"github.com/MichaelMure/git-bug/entity"
 	"github.com/MichaelMure/git-bug/input"
 )
 
 type commentAddOptions struct {
 	messageFile string
 	message     string
+	replyTo     string
+	coAuthors   []string
 }
 
 func newCommentAddCommand() *cobra.Command {
@@ -17,10 +25,11 @@ func newCommentAddCommand() *cobra.Command {
 	options := commentAddOptions{}
 
 	cmd := &cobra.Command{
-		Use:      "add [ID]",
-		Short:    "Add a new comment to a bug.",
-		PreRunE:  loadBackendEnsureUser(env),
-		PostRunE: closeBackend(env),
+		Use:               "add [ID]",
+		Short:             "Add a new comment to a bug.",
+		PreRunE:           loadBackendEnsureUser(env),
+		PostRunE:          closeBackend(env),
+		ValidArgsFunction: completeBug(env),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runCommentAdd(env, options, args)
 		},
@@ -35,6 +44,12 @@ func newCommentAddCommand() *cobra.Command {
 	flags.StringVarP(&options.message, "message", "m", "",
 		"Provide the new message from the command line")
 
+	flags.StringVarP(&options.replyTo, "reply-to", "r", "",
+		"Answer to the comment with the given id (or id prefix)")
+
+	flags.StringSliceVarP(&options.coAuthors, "co-author", "c", nil,
+		"Credit the given identities (id or id prefix) as co-authors of the comment")
+
 	return cmd
 }
 
@@ -44,6 +59,15 @@ func runCommentAdd(env *Env, opts commentAddOptions, args []string) error {
 		return err
 	}
 
+	coAuthors, err := resolveIdentityPrefixes(env, opts.coAuthors)
+	if err != nil {
+		return err
+	}
+
+	if opts.replyTo != "" && len(coAuthors) > 0 {
+		return fmt.Errorf("co-authors can't be credited on a reply")
+	}
+
 	if opts.messageFile != "" && opts.message == "" {
 		opts.message, err = input.BugCommentFileInput(opts.messageFile)
 		if err != nil {
@@ -62,6 +86,29 @@ func runCommentAdd(env *Env, opts commentAddOptions, args []string) error {
 		}
 	}
 
+	if len(coAuthors) > 0 {
+		_, err = b.AddCommentWithCoAuthors(opts.message, coAuthors)
+		if err != nil {
+			return err
+		}
+
+		return b.Commit()
+	}
+
+	if opts.replyTo != "" {
+		target, err := resolveCommentPrefix(b.Snapshot(), opts.replyTo)
+		if err != nil {
+			return err
+		}
+
+		_, err = b.AddReply(target, opts.message)
+		if err != nil {
+			return err
+		}
+
+		return b.Commit()
+	}
+
 	_, err = b.AddComment(opts.message)
 	if err != nil {
 		return err
@@ -69,3 +116,49 @@ func runCommentAdd(env *Env, opts commentAddOptions, args []string) error {
 
 	return b.Commit()
 }
+
+// resolveCommentPrefix find the comment of a bug matching an id prefix
+func resolveCommentPrefix(snap *bug.Snapshot, prefix string) (entity.Id, error) {
+	var matching []entity.Id
+
+	for _, comment := range snap.Comments {
+		if comment.Id().HasPrefix(prefix) {
+			matching = append(matching, comment.Id())
+		}
+	}
+
+	if len(matching) > 1 {
+		return "", entity.NewErrMultipleMatch("comment", matching)
+	}
+
+	if len(matching) == 0 {
+		return "", fmt.Errorf("no comment matching %s", prefix)
+	}
+
+	return matching[0], nil
+}
+
+// resolveComment find the comment of a bug designated either by its index,
+// the description being 0, or by an id prefix
+func resolveComment(snap *bug.Snapshot, selector string) (*bug.Comment, error) {
+	// indexes are short, to not be mistaken for an id prefix
+	if index, err := strconv.Atoi(strings.TrimPrefix(selector, "#")); err == nil && len(selector) <= 3 {
+		if index < 0 || index >= len(snap.Comments) {
+			return nil, fmt.Errorf("no comment with index %d, the bug has %d comments", index, len(snap.Comments))
+		}
+		return &snap.Comments[index], nil
+	}
+
+	id, err := resolveCommentPrefix(snap, selector)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range snap.Comments {
+		if snap.Comments[i].Id() == id {
+			return &snap.Comments[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("no comment matching %s", selector)
+}
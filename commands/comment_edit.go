@@ -0,0 +1,100 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	_select "github.com/MichaelMure/git-bug/commands/select"
+	"github.com/MichaelMure/git-bug/input"
+)
+
+type commentEditOptions struct {
+	messageFile string
+	message     string
+}
+
+func newCommentEditCommand() *cobra.Command {
+	env := newEnv()
+	options := commentEditOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "edit [ID] COMMENT",
+		Short: "Edit an existing comment of a bug.",
+		Long: `Edit an existing comment of a bug, in the default editor unless a new message is given.
+
+COMMENT is either the index of the comment as displayed by "git bug show", the description being 0, or a prefix of its id.`,
+		Example: `Fix a typo in the second comment of the selected bug:
+git bug comment edit 2
+`,
+		PreRunE:           loadBackendEnsureUser(env),
+		PostRunE:          closeBackend(env),
+		ValidArgsFunction: completeBug(env),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runCommentEdit(env, options, args)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.SortFlags = false
+
+	flags.StringVarP(&options.messageFile, "file", "F", "",
+		"Take the message from the given file. Use - to read the message from the standard input")
+
+	flags.StringVarP(&options.message, "message", "m", "",
+		"Provide the new message from the command line")
+
+	return cmd
+}
+
+func runCommentEdit(env *Env, opts commentEditOptions, args []string) error {
+	b, args, err := _select.ResolveBug(env.backend, args)
+	if err != nil {
+		return err
+	}
+
+	if len(args) != 1 {
+		return fmt.Errorf("a single comment is expected")
+	}
+
+	snap := b.Snapshot()
+
+	comment, err := resolveComment(snap, args[0])
+	if err != nil {
+		return err
+	}
+
+	if comment.Redaction != nil {
+		return fmt.Errorf("a redacted comment can't be edited")
+	}
+
+	if opts.messageFile != "" && opts.message == "" {
+		opts.message, err = input.BugCommentFileInput(opts.messageFile)
+		if err != nil {
+			return err
+		}
+	}
+
+	if opts.messageFile == "" && opts.message == "" {
+		opts.message, err = input.BugCommentEditorInput(env.backend, comment.Message)
+		if err == input.ErrEmptyMessage {
+			env.err.Println("Empty message, aborting.")
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	if opts.message == comment.Message {
+		env.err.Println("No change, aborting.")
+		return nil
+	}
+
+	_, err = b.EditComment(comment.Id(), opts.message)
+	if err != nil {
+		return err
+	}
+
+	return b.Commit()
+}
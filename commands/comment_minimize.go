@@ -0,0 +1,83 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/MichaelMure/git-bug/bug"
+	_select "github.com/MichaelMure/git-bug/commands/select"
+)
+
+type commentMinimizeOptions struct {
+	reason string
+}
+
+func newCommentMinimizeCommand() *cobra.Command {
+	env := newEnv()
+	options := commentMinimizeOptions{}
+
+	cmd := &cobra.Command{
+		Use:               "minimize [ID] COMMENT_ID",
+		Short:             "Collapse a comment, as resolved or outdated.",
+		Example:           `git bug comment minimize 2f4a 8d1c --reason outdated`,
+		PreRunE:           loadBackendEnsureUser(env),
+		PostRunE:          closeBackend(env),
+		ValidArgsFunction: completeBug(env),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runCommentMinimize(env, args, bug.MinimizeReason(options.reason))
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.SortFlags = false
+
+	flags.StringVarP(&options.reason, "reason", "r", string(bug.MinimizeResolved),
+		"Why the comment is minimized, \"resolved\" or \"outdated\"")
+
+	return cmd
+}
+
+func newCommentUnminimizeCommand() *cobra.Command {
+	env := newEnv()
+
+	cmd := &cobra.Command{
+		Use:               "unminimize [ID] COMMENT_ID",
+		Short:             "Expand back a minimized comment.",
+		PreRunE:           loadBackendEnsureUser(env),
+		PostRunE:          closeBackend(env),
+		ValidArgsFunction: completeBug(env),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runCommentMinimize(env, args, "")
+		},
+	}
+
+	return cmd
+}
+
+func runCommentMinimize(env *Env, args []string, reason bug.MinimizeReason) error {
+	b, args, err := _select.ResolveBug(env.backend, args)
+	if err != nil {
+		return err
+	}
+
+	if len(args) != 1 {
+		return fmt.Errorf("a single comment id is expected")
+	}
+
+	target, err := resolveCommentPrefix(b.Snapshot(), args[0])
+	if err != nil {
+		return err
+	}
+
+	if reason == "" {
+		_, err = b.UnminimizeComment(target)
+	} else {
+		_, err = b.MinimizeComment(target, reason)
+	}
+	if err != nil {
+		return err
+	}
+
+	return b.Commit()
+}
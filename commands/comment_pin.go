@@ -0,0 +1,70 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	_select "github.com/MichaelMure/git-bug/commands/select"
+)
+
+func newCommentPinCommand() *cobra.Command {
+	env := newEnv()
+
+	cmd := &cobra.Command{
+		Use:               "pin [ID] COMMENT_ID",
+		Short:             "Pin a comment at the top of a bug.",
+		PreRunE:           loadBackendEnsureUser(env),
+		PostRunE:          closeBackend(env),
+		ValidArgsFunction: completeBug(env),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runCommentPin(env, args, true)
+		},
+	}
+
+	return cmd
+}
+
+func newCommentUnpinCommand() *cobra.Command {
+	env := newEnv()
+
+	cmd := &cobra.Command{
+		Use:               "unpin [ID] COMMENT_ID",
+		Short:             "Unpin a comment of a bug.",
+		PreRunE:           loadBackendEnsureUser(env),
+		PostRunE:          closeBackend(env),
+		ValidArgsFunction: completeBug(env),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runCommentPin(env, args, false)
+		},
+	}
+
+	return cmd
+}
+
+func runCommentPin(env *Env, args []string, pinned bool) error {
+	b, args, err := _select.ResolveBug(env.backend, args)
+	if err != nil {
+		return err
+	}
+
+	if len(args) != 1 {
+		return fmt.Errorf("a single comment id is expected")
+	}
+
+	target, err := resolveCommentPrefix(b.Snapshot(), args[0])
+	if err != nil {
+		return err
+	}
+
+	if pinned {
+		_, err = b.PinComment(target)
+	} else {
+		_, err = b.UnpinComment(target)
+	}
+	if err != nil {
+		return err
+	}
+
+	return b.Commit()
+}
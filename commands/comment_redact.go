@@ -0,0 +1,68 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	_select "github.com/MichaelMure/git-bug/commands/select"
+)
+
+type commentRedactOptions struct {
+	reason string
+}
+
+func newCommentRedactCommand() *cobra.Command {
+	env := newEnv()
+	options := commentRedactOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "redact [ID] COMMENT_ID",
+		Short: "Remove the content of a comment.",
+		Long: `Remove the content of a comment, including its edition history, recording who did it and why.
+
+Note that the content is only hidden from the bug's state: it's still present in the git history.`,
+		Example:           `git bug comment redact 2f4a 8d1c --reason "personal data"`,
+		PreRunE:           loadBackendEnsureUser(env),
+		PostRunE:          closeBackend(env),
+		ValidArgsFunction: completeBug(env),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runCommentRedact(env, options, args)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.SortFlags = false
+
+	flags.StringVarP(&options.reason, "reason", "r", "",
+		"Why the content is removed")
+
+	return cmd
+}
+
+func runCommentRedact(env *Env, opts commentRedactOptions, args []string) error {
+	b, args, err := _select.ResolveBug(env.backend, args)
+	if err != nil {
+		return err
+	}
+
+	if len(args) != 1 {
+		return fmt.Errorf("a single comment id is expected")
+	}
+
+	if opts.reason == "" {
+		return fmt.Errorf("a reason is required")
+	}
+
+	target, err := resolveCommentPrefix(b.Snapshot(), args[0])
+	if err != nil {
+		return err
+	}
+
+	_, err = b.RedactComment(target, opts.reason)
+	if err != nil {
+		return err
+	}
+
+	return b.Commit()
+}
@@ -0,0 +1,78 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	_select "github.com/MichaelMure/git-bug/commands/select"
+)
+
+type commentRmOptions struct {
+	reason string
+}
+
+func newCommentRmCommand() *cobra.Command {
+	env := newEnv()
+	options := commentRmOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "rm [ID] COMMENT",
+		Short: "Remove a comment of a bug.",
+		Long: `Remove a comment of a bug, by redacting its content.
+
+COMMENT is either the index of the comment as displayed by "git bug show", or a prefix of its id. The description of the bug can't be removed.
+
+Note that the content is only hidden from the bug's state: it's still present in the git history.`,
+		Example: `Remove the third comment of the selected bug:
+git bug comment rm 3
+`,
+		PreRunE:           loadBackendEnsureUser(env),
+		PostRunE:          closeBackend(env),
+		ValidArgsFunction: completeBug(env),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runCommentRm(env, options, args)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.SortFlags = false
+
+	flags.StringVarP(&options.reason, "reason", "r", "removed",
+		"Why the comment is removed")
+
+	return cmd
+}
+
+func runCommentRm(env *Env, opts commentRmOptions, args []string) error {
+	b, args, err := _select.ResolveBug(env.backend, args)
+	if err != nil {
+		return err
+	}
+
+	if len(args) != 1 {
+		return fmt.Errorf("a single comment is expected")
+	}
+
+	snap := b.Snapshot()
+
+	comment, err := resolveComment(snap, args[0])
+	if err != nil {
+		return err
+	}
+
+	if comment.Id() == snap.Comments[0].Id() {
+		return fmt.Errorf("the description of a bug can't be removed, edit it instead")
+	}
+
+	if comment.Redaction != nil {
+		return fmt.Errorf("the comment is already removed")
+	}
+
+	_, err = b.RedactComment(comment.Id(), opts.reason)
+	if err != nil {
+		return err
+	}
+
+	return b.Commit()
+}
@@ -0,0 +1,179 @@
+package commands
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/MichaelMure/git-bug/bug"
+)
+
+type completionFunc func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective)
+
+// completeWithBackend load the backend in read-only mode for the duration of
+// a completion. The candidates are given with a description after a tab,
+// displayed by the shells supporting it.
+func completeWithBackend(env *Env, candidates func(args []string, toComplete string) ([]string, error)) completionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if err := loadBackendReadOnly(env)(cmd, args); err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+		defer func() {
+			_ = closeBackend(env)(cmd, args)
+		}()
+
+		result, err := candidates(args, toComplete)
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+
+		return result, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// completeBug complete the first argument with the bug ids
+func completeBug(env *Env) completionFunc {
+	return completeWithBackend(env, func(args []string, toComplete string) ([]string, error) {
+		if len(args) > 0 {
+			return nil, nil
+		}
+		return bugCompletions(env, toComplete)
+	})
+}
+
+// completeBugAndLabel complete the first argument with the bug ids or the
+// labels, the next ones with the labels
+func completeBugAndLabel(env *Env) completionFunc {
+	return completeWithBackend(env, func(args []string, toComplete string) ([]string, error) {
+		result, err := labelCompletions(env, toComplete)
+		if err != nil || len(args) > 0 {
+			return result, err
+		}
+		bugs, err := bugCompletions(env, toComplete)
+		return append(bugs, result...), err
+	})
+}
+
+// completeLabel complete the first argument with the registered labels
+func completeLabel(env *Env) completionFunc {
+	return completeWithBackend(env, func(args []string, toComplete string) ([]string, error) {
+		if len(args) > 0 {
+			return nil, nil
+		}
+		registry, err := env.backend.LabelRegistry()
+		if err != nil {
+			return nil, err
+		}
+		var result []string
+		for _, name := range registry.Names() {
+			if strings.HasPrefix(name.String(), toComplete) {
+				result = append(result, name.String()+"\t"+registry[name].Description)
+			}
+		}
+		return result, nil
+	})
+}
+
+// completeBugAndUser complete the first argument with the bug ids or the
+// users, the next ones with the users
+func completeBugAndUser(env *Env) completionFunc {
+	return completeWithBackend(env, func(args []string, toComplete string) ([]string, error) {
+		result, err := userCompletions(env, toComplete, true)
+		if err != nil || len(args) > 0 {
+			return result, err
+		}
+		bugs, err := bugCompletions(env, toComplete)
+		return append(bugs, result...), err
+	})
+}
+
+// completeUserId complete the first argument with the identity ids
+func completeUserId(env *Env) completionFunc {
+	return completeWithBackend(env, func(args []string, toComplete string) ([]string, error) {
+		if len(args) > 0 {
+			return nil, nil
+		}
+		return userCompletions(env, toComplete, false)
+	})
+}
+
+// bugCompletions return the ids of the bugs matching a prefix, described by
+// their title
+func bugCompletions(env *Env, toComplete string) ([]string, error) {
+	var result []string
+
+	for _, id := range env.backend.AllBugsIds() {
+		if !id.HasPrefix(toComplete) {
+			continue
+		}
+
+		excerpt, err := env.backend.ResolveBugExcerpt(id)
+		if err != nil {
+			return nil, err
+		}
+
+		candidate := id.Human()
+		if len(toComplete) > len(candidate) {
+			candidate = id.String()
+		}
+		result = append(result, candidate+"\t"+strings.TrimSpace(excerpt.Title))
+	}
+
+	sort.Strings(result)
+	return result, nil
+}
+
+// labelCompletions return the labels in use or registered, archived excepted,
+// matching a prefix
+func labelCompletions(env *Env, toComplete string) ([]string, error) {
+	registry, err := env.backend.LabelRegistry()
+	if err != nil {
+		return nil, err
+	}
+
+	set := make(map[bug.Label]struct{})
+	for _, l := range env.backend.ValidLabels() {
+		set[l] = struct{}{}
+	}
+	for name, def := range registry {
+		if def.Archived {
+			delete(set, name)
+		} else {
+			set[name] = struct{}{}
+		}
+	}
+
+	var result []string
+	for l := range set {
+		if strings.HasPrefix(l.String(), toComplete) {
+			result = append(result, l.String()+"\t"+registry[l].Description)
+		}
+	}
+
+	sort.Strings(result)
+	return result, nil
+}
+
+// userCompletions return the identities matching a prefix of their id, or
+// of their login if byLogin is set, described by their name
+func userCompletions(env *Env, toComplete string, byLogin bool) ([]string, error) {
+	var result []string
+
+	for _, id := range env.backend.AllIdentityIds() {
+		excerpt, err := env.backend.ResolveIdentityExcerpt(id)
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case byLogin && excerpt.Login != "" && strings.HasPrefix(excerpt.Login, toComplete):
+			result = append(result, excerpt.Login+"\t"+excerpt.Name)
+		case id.HasPrefix(toComplete):
+			result = append(result, id.Human()+"\t"+excerpt.DisplayName())
+		}
+	}
+
+	sort.Strings(result)
+	return result, nil
+}
@@ -0,0 +1,408 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/spf13/cobra"
+
+	"github.com/MichaelMure/git-bug/api/auth"
+	"github.com/MichaelMure/git-bug/api/graphql"
+	grpcapi "github.com/MichaelMure/git-bug/api/grpc"
+	httpapi "github.com/MichaelMure/git-bug/api/http"
+	"github.com/MichaelMure/git-bug/bridge"
+	"github.com/MichaelMure/git-bug/bridge/core"
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/identity"
+	"github.com/MichaelMure/git-bug/repository"
+	"github.com/MichaelMure/git-bug/webhook"
+)
+
+const daemonSocketFile = "daemon.sock"
+const daemonGrpcSocketFile = "grpc.sock"
+
+type daemonOptions struct {
+	socket       string
+	syncInterval time.Duration
+	grpc         bool
+
+	maxComplexity int
+	maxDepth      int
+	rateLimit     float64
+	rateBurst     int
+
+	allowedQueries string
+	cacheTTL       time.Duration
+}
+
+// daemonStatus is served by the daemon on /status
+type daemonStatus struct {
+	Pid           int        `json:"pid"`
+	Repository    string     `json:"repository"`
+	Started       time.Time  `json:"started"`
+	SyncInterval  string     `json:"sync_interval,omitempty"`
+	LastSync      *time.Time `json:"last_sync,omitempty"`
+	LastSyncError string     `json:"last_sync_error,omitempty"`
+	GrpcSocket    string     `json:"grpc_socket,omitempty"`
+}
+
+func newDaemonCommand() *cobra.Command {
+	env := newEnv()
+	options := daemonOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "daemon",
+		Short: "Run a background service holding the repository.",
+		Long: `Run a background service holding the repository.
+
+The daemon keep the cache open and up to date, which make the read-only commands fast as they don't need to
+rebuild it. It serves the GraphQL API, as well as the file download and upload endpoints of the web UI, on
+a unix socket in the git directory, can synchronize periodically the configured bridges, and deliver the
+changes to the webhooks configured with "git bug webhook". With --grpc, a gRPC API is also served on another
+unix socket in the git directory, defined in api/grpc/pb/gitbug.proto.
+
+As the daemon hold the lock of the repository, the modifications have to go through it while it's running.
+The following commands are sent to the daemon and run with its cache, as long as they don't need the editor
+or a confirmation: add, comment add, title edit, label add, label rm, status open and status close. The
+other commands modifying the data have to use its API. Use "git bug daemon status" and "git bug daemon stop"
+to interact with a running daemon.`,
+		Example: `git bug daemon --sync-interval 15m
+curl --unix-socket .git/git-bug/daemon.sock http://daemon/graphql -d '{"query": "{ repository { allBugs { totalCount } } }"}'`,
+		PreRunE: loadRepo(env),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDaemon(env, options)
+		},
+		Args: cobra.NoArgs,
+	}
+
+	cmd.AddCommand(newDaemonStatusCommand())
+	cmd.AddCommand(newDaemonStopCommand())
+
+	flags := cmd.Flags()
+	flags.SortFlags = false
+
+	flags.StringVar(&options.socket, "socket", "", "The path of the unix socket to listen to (default is in the git directory)")
+	flags.DurationVarP(&options.syncInterval, "sync-interval", "i", 0,
+		"Pull and push the configured bridges at this interval (ex: \"15m\"), disabled by default")
+	flags.BoolVar(&options.grpc, "grpc", false, "Also serve the gRPC API, on the grpc.sock unix socket in the git directory")
+	flags.IntVar(&options.maxComplexity, "max-complexity", graphql.DefaultOptions.MaxComplexity, "Maximum complexity of a GraphQL query, 0 to disable")
+	flags.IntVar(&options.maxDepth, "max-depth", graphql.DefaultOptions.MaxDepth, "Maximum nesting depth of a GraphQL query, 0 to disable")
+	flags.StringVar(&options.allowedQueries, "allowed-queries", "", "Only accept the GraphQL queries of this JSON file, an object of the queries indexed by their id")
+	flags.DurationVar(&options.cacheTTL, "cache-ttl", 0, "Keep the responses of the GraphQL queries for this duration (ex: \"10s\"), until the data change, disabled by default")
+	flags.Float64Var(&options.rateLimit, "rate-limit", 0, "Maximum number of requests per second, disabled by default")
+	flags.IntVar(&options.rateBurst, "rate-burst", 50, "Number of requests that can be made in a burst above the rate limit")
+
+	return cmd
+}
+
+func daemonSocketPath(repo repository.Repo) string {
+	return filepath.Join(repo.GetPath(), "git-bug", daemonSocketFile)
+}
+
+func runDaemon(env *Env, opts daemonOptions) error {
+	if opts.socket == "" {
+		opts.socket = daemonSocketPath(env.repo)
+	}
+
+	if daemonRunning(opts.socket) {
+		return fmt.Errorf("a daemon is already running on %s", opts.socket)
+	}
+	// a socket left behind by a daemon that didn't stop properly
+	_ = os.Remove(opts.socket)
+
+	_, err := identity.GetUserIdentity(env.repo)
+	if err != nil {
+		return err
+	}
+
+	mrc := cache.NewMultiRepoCache()
+
+	repoCache, err := mrc.RegisterDefaultRepository(env.repo)
+	if err != nil {
+		return err
+	}
+
+	// keep the data up to date when the repository is modified by another
+	// process, like a git fetch
+	err = mrc.Watch(0)
+	if err != nil {
+		return err
+	}
+
+	// deliver the changes of the bugs to the webhooks while running
+	hooks, err := webhook.Read(env.repo.AnyConfig())
+	if err != nil {
+		return err
+	}
+	if len(hooks) > 0 {
+		dispatcher := webhook.NewDispatcher(repoCache, hooks)
+		err = dispatcher.Start()
+		if err != nil {
+			return err
+		}
+		defer dispatcher.Close()
+	}
+
+	// and to the webhook endpoints of the user, for the bugs they watch
+	user, err := repoCache.GetUserIdentity()
+	if err != nil {
+		return err
+	}
+	if len(user.NotificationPreferences().EndpointsOfType(identity.EndpointWebhook)) > 0 {
+		dispatcher := webhook.NewUserDispatcher(repoCache, user)
+		err = dispatcher.Start()
+		if err != nil {
+			return err
+		}
+		defer dispatcher.Close()
+	}
+
+	graphqlOpts := graphql.Options{
+		MaxComplexity: opts.maxComplexity,
+		MaxDepth:      opts.maxDepth,
+		CacheTTL:      opts.cacheTTL,
+	}
+	if opts.allowedQueries != "" {
+		graphqlOpts.AllowedQueries, err = graphql.ReadAllowedQueries(opts.allowedQueries)
+		if err != nil {
+			return err
+		}
+	}
+
+	graphqlHandler := graphql.NewHandler(mrc, graphqlOpts)
+
+	status := daemonStatus{
+		Pid:        os.Getpid(),
+		Repository: env.repo.GetPath(),
+		Started:    time.Now(),
+	}
+	if opts.syncInterval > 0 {
+		status.SyncInterval = opts.syncInterval.String()
+	}
+	var muStatus sync.Mutex
+
+	stop := make(chan struct{})
+	var stopOnce sync.Once
+	shutdown := func() {
+		stopOnce.Do(func() { close(stop) })
+	}
+
+	router := mux.NewRouter()
+	if opts.rateLimit > 0 {
+		router.Use(httpapi.NewRateLimiter(opts.rateLimit, opts.rateBurst).Middleware)
+	}
+	router.Use(auth.RepoUserMiddleware(repoCache))
+	router.Path("/graphql").Handler(graphqlHandler)
+	router.Path("/gitfile/{repo}/{hash}").Handler(httpapi.NewGitFileHandler(mrc))
+	router.Path("/upload/{repo}").Methods("POST").Handler(httpapi.NewGitUploadFileHandler(mrc))
+	router.Path("/exec").Methods("POST").Handler(newDaemonExecHandler(env.repo, repoCache))
+	router.Path("/status").HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		muStatus.Lock()
+		defer muStatus.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(status)
+	})
+	router.Path("/stop").Methods("POST").HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+		shutdown()
+	})
+
+	listener, err := net.Listen("unix", opts.socket)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(opts.socket)
+
+	srv := &http.Server{Handler: router}
+
+	stopGrpc := func() {}
+	if opts.grpc {
+		grpcSocket := filepath.Join(env.repo.GetPath(), "git-bug", daemonGrpcSocketFile)
+		// a socket left behind by a daemon that didn't stop properly
+		_ = os.Remove(grpcSocket)
+
+		grpcListener, err := net.Listen("unix", grpcSocket)
+		if err != nil {
+			return err
+		}
+		defer os.Remove(grpcSocket)
+
+		grpcServer := grpcapi.NewServer(repoCache)
+		stopGrpc = grpcServer.Stop
+		go func() {
+			err := grpcServer.Serve(grpcListener)
+			if err != nil {
+				env.err.Printf("gRPC server failed: %v\n", err)
+			}
+		}()
+
+		status.GrpcSocket = grpcSocket
+	}
+
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		select {
+		case <-quit:
+			shutdown()
+		case <-stop:
+		}
+	}()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var wg sync.WaitGroup
+
+	if opts.syncInterval > 0 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			ticker := time.NewTicker(opts.syncInterval)
+			defer ticker.Stop()
+
+			for {
+				err := daemonSync(ctx, env, repoCache)
+
+				now := time.Now()
+				muStatus.Lock()
+				status.LastSync = &now
+				status.LastSyncError = ""
+				if err != nil {
+					status.LastSyncError = err.Error()
+				}
+				muStatus.Unlock()
+
+				if err != nil {
+					env.err.Printf("%s sync failed: %v\n", now.Format(time.RFC3339), err)
+				}
+
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+				}
+			}
+		}()
+	}
+
+	go func() {
+		<-stop
+		env.out.Println("Daemon is shutting down...")
+
+		cancel()
+
+		shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer shutdownCancel()
+
+		// the streams of changes would prevent a graceful stop
+		stopGrpc()
+
+		srv.SetKeepAlivesEnabled(false)
+		if err := srv.Shutdown(shutdownCtx); err != nil {
+			env.err.Printf("Could not gracefully shutdown the daemon: %v\n", err)
+		}
+	}()
+
+	env.out.Printf("Daemon listening on %s\n", opts.socket)
+
+	err = srv.Serve(listener)
+	if err != nil && err != http.ErrServerClosed {
+		return err
+	}
+
+	// wait for a running sync to be canceled before closing the cache
+	wg.Wait()
+
+	return graphqlHandler.Close()
+}
+
+// daemonSync pull then push all the configured bridges
+func daemonSync(ctx context.Context, env *Env, repo *cache.RepoCache) error {
+	names, err := bridge.ConfiguredBridges(repo)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range names {
+		b, err := bridge.LoadBridge(repo, name)
+		if err != nil {
+			return err
+		}
+
+		importEvents, err := b.ImportAll(ctx)
+		if err != nil {
+			return fmt.Errorf("bridge %s: %v", name, err)
+		}
+
+		imported := 0
+		for result := range importEvents {
+			switch result.Event {
+			case core.ImportEventBug, core.ImportEventIdentity:
+				imported++
+			case core.ImportEventError:
+				err = result.Err
+			}
+		}
+		if err != nil && err != context.Canceled {
+			return fmt.Errorf("bridge %s: %v", name, err)
+		}
+
+		exportEvents, err := b.ExportAll(ctx, time.Time{})
+		if err != nil {
+			return fmt.Errorf("bridge %s: %v", name, err)
+		}
+
+		exported := 0
+		for result := range exportEvents {
+			switch result.Event {
+			case core.ExportEventBug:
+				exported++
+			case core.ExportEventError:
+				err = result.Err
+			}
+		}
+		if err != nil && err != context.Canceled {
+			return fmt.Errorf("bridge %s: %v", name, err)
+		}
+
+		env.out.Printf("%s synced bridge %s: %d imported, %d exported\n",
+			time.Now().Format(time.RFC3339), name, imported, exported)
+	}
+
+	return nil
+}
+
+// daemonClient return an http client talking to the daemon on its socket
+func daemonClient(socket string) *http.Client {
+	return &http.Client{
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				var d net.Dialer
+				return d.DialContext(ctx, "unix", socket)
+			},
+		},
+		Timeout: 5 * time.Second,
+	}
+}
+
+// daemonRunning tell if a daemon answer on the given socket
+func daemonRunning(socket string) bool {
+	resp, err := daemonClient(socket).Get("http://daemon/status")
+	if err != nil {
+		return false
+	}
+	_ = resp.Body.Close()
+	return resp.StatusCode == http.StatusOK
+}
@@ -0,0 +1,256 @@
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+// daemonCommands are the commands that the daemon can run on behalf of the
+// CLI while it holds the lock of the repository, with for each of them a
+// check that the given flags don't require the editor or a prompt, which
+// can't be used through the daemon.
+var daemonCommands = map[string]func(flags *pflag.FlagSet) bool{
+	"add": func(flags *pflag.FlagSet) bool {
+		return flags.Changed("file") || flags.Changed("from-file") ||
+			(flags.Changed("title") && flags.Changed("message"))
+	},
+	"comment add": func(flags *pflag.FlagSet) bool {
+		return flags.Changed("file") || flags.Changed("message")
+	},
+	"title edit": func(flags *pflag.FlagSet) bool {
+		return flags.Changed("title")
+	},
+	"label add":    noBulkConfirmation,
+	"label rm":     noBulkConfirmation,
+	"status open":  noBulkConfirmation,
+	"status close": noBulkConfirmation,
+}
+
+// the flags holding the path of a file to read, which is sent along the
+// command as the daemon doesn't share the working directory or the standard
+// input of the CLI
+var daemonFileFlags = []string{"file", "from-file"}
+
+func noBulkConfirmation(flags *pflag.FlagSet) bool {
+	return !flags.Changed("query") || flags.Changed("yes")
+}
+
+// daemonCanRun tell if a command, with its parsed flags, can be run by the
+// daemon
+func daemonCanRun(cmd *cobra.Command) bool {
+	path := strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" ")
+	check, ok := daemonCommands[path]
+	return ok && check(cmd.Flags())
+}
+
+// daemonExecRequest is a command sent to the daemon on /exec
+type daemonExecRequest struct {
+	// the arguments of the command, the file flags excepted
+	Args []string `json:"args"`
+	// the content of the file flags, by flag name
+	Files map[string][]byte `json:"files,omitempty"`
+}
+
+// daemonExecResponse is the result of a command run by the daemon
+type daemonExecResponse struct {
+	Stdout []byte `json:"stdout"`
+	Stderr []byte `json:"stderr"`
+	Code   int    `json:"code"`
+}
+
+// errRunByDaemon is returned by the pre-run of a command run by the daemon
+// instead, to exit with its code once its output has been written
+type errRunByDaemon struct {
+	code int
+}
+
+func (e errRunByDaemon) Error() string {
+	return fmt.Sprintf("run by the daemon, exit code %d", e.code)
+}
+
+// runOnDaemon send a command to the daemon, and write its output
+func runOnDaemon(env *Env, socket string, cmd *cobra.Command, args []string) error {
+	req := daemonExecRequest{
+		Args:  strings.Fields(cmd.CommandPath())[1:],
+		Files: make(map[string][]byte),
+	}
+
+	var err error
+	cmd.Flags().Visit(func(flag *pflag.Flag) {
+		switch {
+		case err != nil, flag.Name == "repo":
+		case isDaemonFileFlag(flag.Name):
+			req.Files[flag.Name], err = readFileFlag(flag.Value.String())
+		default:
+			if slice, ok := flag.Value.(pflag.SliceValue); ok {
+				for _, value := range slice.GetSlice() {
+					req.Args = append(req.Args, fmt.Sprintf("--%s=%s", flag.Name, value))
+				}
+				return
+			}
+			req.Args = append(req.Args, fmt.Sprintf("--%s=%s", flag.Name, flag.Value.String()))
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	// the positional arguments can't be taken for flags
+	req.Args = append(req.Args, "--")
+	req.Args = append(req.Args, args...)
+
+	body, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	client := daemonClient(socket)
+	client.Timeout = 0
+
+	resp, err := client.Post("http://daemon/exec", "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := ioutil.ReadAll(resp.Body)
+		return fmt.Errorf("the daemon refused the command: %s", strings.TrimSpace(string(msg)))
+	}
+
+	var result daemonExecResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return err
+	}
+
+	_, _ = env.out.Write(result.Stdout)
+	_, _ = env.err.Write(result.Stderr)
+
+	return errRunByDaemon{code: result.Code}
+}
+
+func isDaemonFileFlag(name string) bool {
+	for _, fileFlag := range daemonFileFlags {
+		if name == fileFlag {
+			return true
+		}
+	}
+	return false
+}
+
+// readFileFlag read the file given to a flag, - being the standard input
+func readFileFlag(path string) ([]byte, error) {
+	if path == "-" {
+		return ioutil.ReadAll(os.Stdin)
+	}
+	return ioutil.ReadFile(path)
+}
+
+// daemonExecHandler run the commands sent by the CLI with the repository and
+// the cache of the daemon. The commands are run one at a time, as they use
+// the global state of the package.
+type daemonExecHandler struct {
+	mu      sync.Mutex
+	repo    repository.ClockedRepo
+	backend *cache.RepoCache
+}
+
+func newDaemonExecHandler(repo repository.ClockedRepo, backend *cache.RepoCache) *daemonExecHandler {
+	return &daemonExecHandler{repo: repo, backend: backend}
+}
+
+func (h *daemonExecHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req daemonExecRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// the file flags are given before the positional arguments
+	flags := req.Args
+	positional := []string(nil)
+	for i, arg := range req.Args {
+		if arg == "--" {
+			flags, positional = req.Args[:i:i], req.Args[i:]
+			break
+		}
+	}
+
+	for name, content := range req.Files {
+		if !isDaemonFileFlag(name) {
+			http.Error(w, fmt.Sprintf("unexpected file flag %s", name), http.StatusBadRequest)
+			return
+		}
+
+		file, err := ioutil.TempFile("", "git-bug-daemon-")
+		if err == nil {
+			_, err = file.Write(content)
+			_ = file.Close()
+			defer os.Remove(file.Name())
+		}
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		flags = append(flags, fmt.Sprintf("--%s=%s", name, file.Name()))
+	}
+
+	args := append(flags, positional...)
+
+	// check the command on a separate tree, as the flags can only be parsed once
+	cmd, rest, err := NewRootCommand().Find(args)
+	if err == nil {
+		err = cmd.ParseFlags(rest)
+	}
+	if err != nil || !daemonCanRun(cmd) {
+		http.Error(w, "this command can't be run by the daemon", http.StatusForbidden)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(h.run(args))
+}
+
+func (h *daemonExecHandler) run(args []string) daemonExecResponse {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	var out, errOut bytes.Buffer
+
+	stdout, stderr = &out, &errOut
+	sharedRepo, sharedBackend = h.repo, h.backend
+	defer func() {
+		stdout, stderr = os.Stdout, os.Stderr
+		sharedRepo, sharedBackend = nil, nil
+	}()
+
+	root := NewRootCommand()
+	root.SetArgs(args)
+	root.SetOut(&out)
+	root.SetErr(&errOut)
+
+	code := 0
+	cmd, err := root.ExecuteC()
+	if err != nil {
+		code = handleError(&errOut, cmd, err)
+	}
+
+	return daemonExecResponse{
+		Stdout: out.Bytes(),
+		Stderr: errOut.Bytes(),
+		Code:   code,
+	}
+}
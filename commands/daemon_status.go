@@ -0,0 +1,66 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+func newDaemonStatusCommand() *cobra.Command {
+	env := newEnv()
+
+	cmd := &cobra.Command{
+		Use:     "status",
+		Short:   "Show the status of the daemon of the repository.",
+		PreRunE: loadRepo(env),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDaemonStatus(env)
+		},
+		Args: cobra.NoArgs,
+	}
+
+	return cmd
+}
+
+func runDaemonStatus(env *Env) error {
+	socket := daemonSocketPath(env.repo)
+
+	resp, err := daemonClient(socket).Get("http://daemon/status")
+	if err != nil {
+		env.out.Println("No daemon is running.")
+		return nil
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected response from the daemon: %s", resp.Status)
+	}
+
+	var status daemonStatus
+	if err := json.NewDecoder(resp.Body).Decode(&status); err != nil {
+		return err
+	}
+
+	env.out.Printf("pid: %d\n", status.Pid)
+	env.out.Printf("socket: %s\n", socket)
+	env.out.Printf("started: %s\n", status.Started.Format(time.RFC1123))
+	if status.GrpcSocket != "" {
+		env.out.Printf("grpc socket: %s\n", status.GrpcSocket)
+	}
+	if status.SyncInterval == "" {
+		env.out.Println("sync: disabled")
+		return nil
+	}
+	env.out.Printf("sync interval: %s\n", status.SyncInterval)
+	if status.LastSync != nil {
+		env.out.Printf("last sync: %s\n", status.LastSync.Format(time.RFC1123))
+	}
+	if status.LastSyncError != "" {
+		env.out.Printf("last sync error: %s\n", status.LastSyncError)
+	}
+
+	return nil
+}
@@ -0,0 +1,42 @@
+package commands
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/spf13/cobra"
+)
+
+func newDaemonStopCommand() *cobra.Command {
+	env := newEnv()
+
+	cmd := &cobra.Command{
+		Use:     "stop",
+		Short:   "Stop the daemon of the repository.",
+		PreRunE: loadRepo(env),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDaemonStop(env)
+		},
+		Args: cobra.NoArgs,
+	}
+
+	return cmd
+}
+
+func runDaemonStop(env *Env) error {
+	socket := daemonSocketPath(env.repo)
+
+	resp, err := daemonClient(socket).Post("http://daemon/stop", "", nil)
+	if err != nil {
+		return fmt.Errorf("no daemon is running")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusAccepted {
+		return fmt.Errorf("unexpected response from the daemon: %s", resp.Status)
+	}
+
+	env.out.Println("Daemon stopped.")
+
+	return nil
+}
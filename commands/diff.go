@@ -0,0 +1,305 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/query"
+	"github.com/MichaelMure/git-bug/util/colors"
+)
+
+type diffOptions struct {
+	since        string
+	until        string
+	outputFormat string
+}
+
+// bugChanges is the list of changes of a bug, as pairs of event and details
+type bugChanges struct {
+	excerpt *cache.BugExcerpt
+	events  [][2]string
+}
+
+type JSONBugChanges struct {
+	Id      string           `json:"id"`
+	HumanId string           `json:"human_id"`
+	Title   string           `json:"title"`
+	Status  string           `json:"status"`
+	Changes []JSONDiffChange `json:"changes"`
+}
+
+type JSONDiffChange struct {
+	Event   string `json:"event"`
+	Details string `json:"details,omitempty"`
+}
+
+func newDiffCommand() *cobra.Command {
+	env := newEnv()
+	options := diffOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "diff",
+		Short: "Show what changed in the bugs, by the last pull or during a period.",
+		Long: `Show what changed in the bugs: the new bugs, the new comments, and the changes of status, title and labels.
+
+By default, the changes brought by the last pull are shown. With --since and --until, the changes made during
+this period are shown instead, wherever they have been made.`,
+		Example: `What changed with the last pull:
+git bug pull && git bug diff
+
+What happened since yesterday, for a standup:
+git bug diff --since -1d`,
+		PreRunE:  loadBackendReadOnly(env),
+		PostRunE: closeBackend(env),
+		Args:     cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDiff(env, options)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.SortFlags = false
+
+	flags.StringVarP(&options.since, "since", "s", "",
+		"Show the changes made since this date (ex: \"2021-03-01\" or \"-7d\"), instead of the last pull")
+	flags.StringVarP(&options.until, "until", "u", "",
+		"Show the changes made before this date, with --since")
+	flags.StringVarP(&options.outputFormat, "format", "f", "default",
+		"Select the output formatting style. Valid values are [default,json]")
+
+	return cmd
+}
+
+func runDiff(env *Env, opts diffOptions) error {
+	switch opts.outputFormat {
+	case "default", "json":
+	default:
+		return fmt.Errorf("unknown format %s", opts.outputFormat)
+	}
+
+	if opts.until != "" && opts.since == "" {
+		return fmt.Errorf("--until requires --since")
+	}
+
+	var changes []bugChanges
+	var header string
+	var err error
+
+	if opts.since != "" {
+		var since, until time.Time
+		since, err = query.ParseDate(opts.since)
+		if err != nil {
+			return err
+		}
+		if opts.until != "" {
+			until, err = query.ParseDate(opts.until)
+			if err != nil {
+				return err
+			}
+		}
+		changes, err = diffPeriod(env, since, until)
+		header = fmt.Sprintf("Changes since %s", since.Format(time.RFC1123))
+		if !until.IsZero() {
+			header += fmt.Sprintf(" until %s", until.Format(time.RFC1123))
+		}
+	} else {
+		var pull *cache.PullRecord
+		pull, err = env.backend.LastPull()
+		if err != nil {
+			return err
+		}
+		if pull == nil {
+			env.err.Println("No pull recorded yet.")
+			return nil
+		}
+		changes, err = diffLastPull(env, pull)
+		header = fmt.Sprintf("Changes from the pull of %s, %s", pull.Remote, pull.Time.Format(time.RFC1123))
+	}
+	if err != nil {
+		return err
+	}
+
+	// most recently edited first
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].excerpt.EditUnixTime > changes[j].excerpt.EditUnixTime
+	})
+
+	if opts.outputFormat == "json" {
+		return diffJsonFormatter(env, changes)
+	}
+
+	env.out.Printf("%s:\n", header)
+
+	if len(changes) == 0 {
+		env.out.Println("nothing changed")
+		return nil
+	}
+
+	for _, c := range changes {
+		events := make([]string, len(c.events))
+		for i, event := range c.events {
+			events[i] = event[0]
+			if event[1] != "" {
+				events[i] += " (" + event[1] + ")"
+			}
+		}
+
+		env.out.Printf("%s %s\t%s\n\t%s\n",
+			colors.Cyan(c.excerpt.Id.Human()),
+			colors.Yellow(c.excerpt.Status),
+			strings.TrimSpace(c.excerpt.Title),
+			strings.Join(events, ", "),
+		)
+	}
+
+	return nil
+}
+
+// diffLastPull compare the bugs with their state before the last pull
+func diffLastPull(env *Env, pull *cache.PullRecord) ([]bugChanges, error) {
+	var result []bugChanges
+
+	add := func(id entity.Id, previous *cache.BugExcerpt) {
+		excerpt, err := env.backend.ResolveBugExcerpt(id)
+		if err != nil {
+			// removed since
+			return
+		}
+		events := diffBugExcerpts(previous, excerpt)
+		if len(events) > 0 {
+			result = append(result, bugChanges{excerpt: excerpt, events: events})
+		}
+	}
+
+	for _, id := range pull.New {
+		add(id, nil)
+	}
+	for id, previous := range pull.Updated {
+		add(id, previous)
+	}
+
+	return result, nil
+}
+
+// diffPeriod list the changes made by the operations of the given period. A
+// zero until means no upper bound.
+func diffPeriod(env *Env, since time.Time, until time.Time) ([]bugChanges, error) {
+	var result []bugChanges
+
+	inPeriod := func(t time.Time) bool {
+		return !t.Before(since) && (until.IsZero() || t.Before(until))
+	}
+
+	for _, id := range env.backend.AllBugsIds() {
+		excerpt, err := env.backend.ResolveBugExcerpt(id)
+		if err != nil {
+			return nil, err
+		}
+
+		// nothing happened after since
+		if time.Unix(excerpt.EditUnixTime, 0).Before(since) {
+			continue
+		}
+
+		b, err := env.backend.ResolveBug(id)
+		if err != nil {
+			return nil, err
+		}
+
+		var events [][2]string
+		comments := 0
+		status := ""
+		titleWas := ""
+		labels := make(map[bug.Label]int)
+
+		for _, op := range b.Snapshot().Operations {
+			if !inPeriod(op.Time()) {
+				continue
+			}
+
+			switch op := op.(type) {
+			case *bug.CreateOperation:
+				events = append(events, [2]string{"created", ""})
+			case *bug.AddCommentOperation:
+				comments++
+			case *bug.SetStatusOperation:
+				status = op.Status.Action()
+			case *bug.SetTitleOperation:
+				if titleWas == "" {
+					titleWas = op.Was
+				}
+			case *bug.LabelChangeOperation:
+				for _, l := range op.Added {
+					labels[l]++
+				}
+				for _, l := range op.Removed {
+					labels[l]--
+				}
+			}
+		}
+
+		if comments > 0 {
+			details := ""
+			if comments > 1 {
+				details = fmt.Sprintf("%d comments", comments)
+			}
+			events = append(events, [2]string{"commented", details})
+		}
+		if status != "" {
+			events = append(events, [2]string{status, ""})
+		}
+		if titleWas != "" {
+			events = append(events, [2]string{"title changed", fmt.Sprintf("was %q", titleWas)})
+		}
+
+		var labelChanges []string
+		for l, n := range labels {
+			switch {
+			case n > 0:
+				labelChanges = append(labelChanges, "+"+l.String())
+			case n < 0:
+				labelChanges = append(labelChanges, "-"+l.String())
+			}
+		}
+		if len(labelChanges) > 0 {
+			sort.Strings(labelChanges)
+			events = append(events, [2]string{"labels changed", strings.Join(labelChanges, " ")})
+		}
+
+		if len(events) > 0 {
+			result = append(result, bugChanges{excerpt: excerpt, events: events})
+		}
+	}
+
+	return result, nil
+}
+
+func diffJsonFormatter(env *Env, changes []bugChanges) error {
+	jsonChanges := make([]JSONBugChanges, len(changes))
+
+	for i, c := range changes {
+		jsonChanges[i] = JSONBugChanges{
+			Id:      c.excerpt.Id.String(),
+			HumanId: c.excerpt.Id.Human(),
+			Title:   c.excerpt.Title,
+			Status:  c.excerpt.Status.String(),
+			Changes: make([]JSONDiffChange, len(c.events)),
+		}
+		for j, event := range c.events {
+			jsonChanges[i].Changes[j] = JSONDiffChange{Event: event[0], Details: event[1]}
+		}
+	}
+
+	jsonObject, _ := json.MarshalIndent(jsonChanges, "", "    ")
+	env.out.Printf("%s\n", jsonObject)
+
+	return nil
+}
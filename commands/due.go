@@ -0,0 +1,81 @@
+package commands
+
+import (
+	"errors"
+
+	"github.com/spf13/cobra"
+
+	"github.com/MichaelMure/git-bug/bug"
+	_select "github.com/MichaelMure/git-bug/commands/select"
+	"github.com/MichaelMure/git-bug/query"
+)
+
+func newDueCommand() *cobra.Command {
+	env := newEnv()
+
+	cmd := &cobra.Command{
+		Use:   "due [ID] [DATE]",
+		Short: "Display or change the due date of a bug.",
+		Long: `Display or change the due date of a bug.
+
+The date is either absolute, like 2024-10-01, or relative to now, like 3d or 2w.`,
+		Example: `git bug due 2024-10-01
+git bug due 8f3a2c1 2w
+`,
+		PreRunE:           loadBackendEnsureUser(env),
+		PostRunE:          closeBackend(env),
+		ValidArgsFunction: completeBug(env),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDue(env, args)
+		},
+	}
+
+	cmd.AddCommand(newDueClearCommand())
+
+	return cmd
+}
+
+func runDue(env *Env, args []string) error {
+	// a lone date designate the selected bug, even if it could also be read
+	// as a bug id prefix, like 3d
+	resolveArgs := args
+	if len(args) == 1 {
+		if _, err := query.ParseDate(args[0]); err == nil {
+			resolveArgs = nil
+		}
+	}
+
+	b, rest, err := _select.ResolveBug(env.backend, resolveArgs)
+	if err != nil {
+		return err
+	}
+	if resolveArgs != nil {
+		args = rest
+	}
+
+	if len(args) > 1 {
+		return errors.New("only one date can be provided")
+	}
+
+	if len(args) == 0 {
+		snap := b.Snapshot()
+		if snap.DueDate.IsZero() {
+			env.out.Println("no due date")
+			return nil
+		}
+		env.out.Println(snap.DueDate.Format(bug.FieldDateLayout))
+		return nil
+	}
+
+	date, err := query.ParseDate(args[0])
+	if err != nil {
+		return err
+	}
+
+	_, err = b.SetDueDate(date)
+	if err != nil {
+		return err
+	}
+
+	return b.Commit()
+}
@@ -0,0 +1,45 @@
+package commands
+
+import (
+	"time"
+
+	"github.com/spf13/cobra"
+
+	_select "github.com/MichaelMure/git-bug/commands/select"
+)
+
+func newDueClearCommand() *cobra.Command {
+	env := newEnv()
+
+	cmd := &cobra.Command{
+		Use:               "clear [ID]",
+		Short:             "Remove the due date of a bug.",
+		PreRunE:           loadBackendEnsureUser(env),
+		PostRunE:          closeBackend(env),
+		ValidArgsFunction: completeBug(env),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDueClear(env, args)
+		},
+	}
+
+	return cmd
+}
+
+func runDueClear(env *Env, args []string) error {
+	b, _, err := _select.ResolveBug(env.backend, args)
+	if err != nil {
+		return err
+	}
+
+	if b.Snapshot().DueDate.IsZero() {
+		env.out.Println("no due date")
+		return nil
+	}
+
+	_, err = b.SetDueDate(time.Time{})
+	if err != nil {
+		return err
+	}
+
+	return b.Commit()
+}
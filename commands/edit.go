@@ -0,0 +1,226 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/input"
+	"github.com/MichaelMure/git-bug/query"
+)
+
+type editOptions struct {
+	query string
+}
+
+func newEditCommand() *cobra.Command {
+	env := newEnv()
+	options := editOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "edit",
+		Short: "Edit the bugs matching a query with a text editor.",
+		Long: `Edit the bugs matching a query with a text editor.
+
+The bugs are listed one per line with their status, labels and title, similarly to "git rebase -i".
+Once the file is saved, the changes are applied to the bugs: retitle, relabel, open or close.
+Removing a line leaves the bug untouched.`,
+		Example:  `git bug edit --query "status:open label:triage"`,
+		PreRunE:  loadBackendEnsureUser(env),
+		PostRunE: closeBackend(env),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runEdit(env, options)
+		},
+		Args: cobra.NoArgs,
+	}
+
+	flags := cmd.Flags()
+	flags.SortFlags = false
+
+	flags.StringVarP(&options.query, "query", "q", "status:open",
+		"Edit the bugs matching the query")
+
+	return cmd
+}
+
+// editChange is the changes to apply to a bug after a batch edition
+type editChange struct {
+	bug     *cache.BugCache
+	status  bug.Status
+	title   string
+	added   []string
+	removed []string
+}
+
+func runEdit(env *Env, opts editOptions) error {
+	saved, err := query.ReadSavedQueries(env.repo.LocalConfig())
+	if err != nil {
+		return err
+	}
+
+	q, err := query.ParseWithSaved(opts.query, saved)
+	if err != nil {
+		return queryError(err)
+	}
+
+	ids := env.backend.QueryBugs(q)
+	if len(ids) == 0 {
+		env.out.Println("No bug matching the query.")
+		return nil
+	}
+
+	lines := make([]input.BatchEditLine, len(ids))
+	for i, id := range ids {
+		excerpt, err := env.backend.ResolveBugExcerpt(id)
+		if err != nil {
+			return err
+		}
+		lines[i] = input.BatchEditLine{
+			Status: excerpt.Status.String(),
+			Id:     id.Human(),
+			Labels: make([]string, len(excerpt.Labels)),
+			Title:  strings.TrimSpace(excerpt.Title),
+		}
+		for j, label := range excerpt.Labels {
+			lines[i].Labels[j] = label.String()
+		}
+	}
+
+	edited, err := input.BugBatchEditorInput(env.backend, lines)
+	if err != nil {
+		return err
+	}
+
+	if len(edited) == 0 {
+		env.out.Println("Empty file, aborting.")
+		return nil
+	}
+
+	// check all the lines before changing anything
+	var changes []editChange
+	seen := make(map[entity.Id]bool)
+
+	for _, line := range edited {
+		id, err := resolveEditedId(ids, line.Id)
+		if err != nil {
+			return err
+		}
+		if seen[id] {
+			return fmt.Errorf("bug %s is listed multiple times", id.Human())
+		}
+		seen[id] = true
+
+		status, err := bug.StatusFromString(line.Status)
+		if err != nil {
+			return fmt.Errorf("bug %s: unknown status %s", id.Human(), line.Status)
+		}
+
+		b, err := env.backend.ResolveBug(id)
+		if err != nil {
+			return err
+		}
+		snap := b.Snapshot()
+
+		change := editChange{bug: b}
+
+		if status != snap.Status {
+			change.status = status
+		}
+		if line.Title != strings.TrimSpace(snap.Title) {
+			change.title = line.Title
+		}
+
+		current := make(map[string]bool)
+		for _, label := range snap.Labels {
+			current[label.String()] = true
+		}
+		wanted := make(map[string]bool)
+		for _, label := range line.Labels {
+			wanted[label] = true
+			if !current[label] {
+				change.added = append(change.added, label)
+			}
+		}
+		for _, label := range snap.Labels {
+			if !wanted[label.String()] {
+				change.removed = append(change.removed, label.String())
+			}
+		}
+
+		if change.status != 0 || change.title != "" || len(change.added) > 0 || len(change.removed) > 0 {
+			changes = append(changes, change)
+		}
+	}
+
+	if len(changes) == 0 {
+		env.out.Println("Nothing to change.")
+		return nil
+	}
+
+	for _, change := range changes {
+		var done []string
+
+		if change.title != "" {
+			if _, err := change.bug.SetTitle(change.title); err != nil {
+				return err
+			}
+			done = append(done, "title changed")
+		}
+
+		if len(change.added) > 0 || len(change.removed) > 0 {
+			if _, _, err := change.bug.ChangeLabels(change.added, change.removed); err != nil {
+				return err
+			}
+			for _, label := range change.added {
+				done = append(done, "+"+label)
+			}
+			for _, label := range change.removed {
+				done = append(done, "-"+label)
+			}
+		}
+
+		switch change.status {
+		case bug.OpenStatus:
+			_, err = change.bug.Open()
+		case bug.ClosedStatus:
+			_, err = change.bug.Close()
+		}
+		if err != nil {
+			return err
+		}
+		if change.status != 0 {
+			done = append(done, change.status.Action())
+		}
+
+		if err := change.bug.Commit(); err != nil {
+			return err
+		}
+
+		env.out.Printf("%s: %s\n", change.bug.Id().Human(), strings.Join(done, ", "))
+	}
+
+	return nil
+}
+
+// resolveEditedId find the bug of an edited line among the listed ones
+func resolveEditedId(ids []entity.Id, prefix string) (entity.Id, error) {
+	var matching []entity.Id
+	for _, id := range ids {
+		if id.HasPrefix(prefix) {
+			matching = append(matching, id)
+		}
+	}
+
+	switch len(matching) {
+	case 0:
+		return "", fmt.Errorf("bug %s was not listed for edition", prefix)
+	case 1:
+		return matching[0], nil
+	default:
+		return "", bug.NewErrMultipleMatchBug(matching)
+	}
+}
@@ -25,11 +25,27 @@ type Env struct {
 func newEnv() *Env {
 	return &Env{
 		repo: nil,
-		out:  out{Writer: os.Stdout},
-		err:  out{Writer: os.Stderr},
+		out:  out{Writer: stdout},
+		err:  out{Writer: stderr},
 	}
 }
 
+// stdout and stderr are where the commands write. They are replaced when the
+// daemon run a command on behalf of the CLI, see daemonExecHandler.
+var stdout io.Writer = os.Stdout
+var stderr io.Writer = os.Stderr
+
+// sharedRepo and sharedBackend are the repository and the cache held by the
+// daemon, used by the commands it run on behalf of the CLI instead of opening
+// the repository again.
+var sharedRepo repository.ClockedRepo
+var sharedBackend *cache.RepoCache
+
+// aliasRepo is the repository opened to expand the aliases, at aliasRepoPath,
+// reused by the command if it use the same path.
+var aliasRepo repository.ClockedRepo
+var aliasRepoPath string
+
 type out struct {
 	io.Writer
 }
@@ -46,17 +62,47 @@ func (o out) Println(a ...interface{}) {
 	_, _ = fmt.Fprintln(o, a...)
 }
 
+// repoFlag is the value of the --repo persistent flag
+var repoFlag string
+
+// repoPath return the path of the repository to use: the one given with
+// --repo, GIT_DIR, or the current directory
+func repoPath(flag string) (string, error) {
+	if flag != "" {
+		return flag, nil
+	}
+	if gitDir := os.Getenv("GIT_DIR"); gitDir != "" {
+		return gitDir, nil
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return "", fmt.Errorf("unable to get the current working directory: %q", err)
+	}
+	return cwd, nil
+}
+
 // loadRepo is a pre-run function that load the repository for use in a command
 func loadRepo(env *Env) func(*cobra.Command, []string) error {
 	return func(cmd *cobra.Command, args []string) error {
-		cwd, err := os.Getwd()
+		if sharedRepo != nil {
+			env.repo = sharedRepo
+			return nil
+		}
+
+		path, err := repoPath(repoFlag)
 		if err != nil {
-			return fmt.Errorf("unable to get the current working directory: %q", err)
+			return err
+		}
+
+		if aliasRepo != nil && path == aliasRepoPath {
+			env.repo = aliasRepo
+			return nil
 		}
 
-		env.repo, err = repository.NewGoGitRepo(cwd, []repository.ClockLoader{bug.ClockLoader})
+		env.repo, err = repository.NewGoGitRepo(path, []repository.ClockLoader{bug.ClockLoader})
 		if err == repository.ErrNotARepo {
-			return fmt.Errorf("%s must be run from within a git repo", rootCommandName)
+			return fmt.Errorf("%s must be run from within a git repo, or with --repo", rootCommandName)
 		}
 
 		if err != nil {
@@ -95,7 +141,25 @@ func loadBackend(env *Env) func(*cobra.Command, []string) error {
 			return err
 		}
 
-		env.backend, err = cache.NewRepoCache(env.repo)
+		if sharedBackend != nil {
+			env.backend = sharedBackend
+			return nil
+		}
+
+		// the daemon hold the lock, the command is run by the daemon if it can
+		socket := daemonSocketPath(env.repo)
+		if daemonRunning(socket) {
+			if !daemonCanRun(cmd) {
+				return fmt.Errorf("%w\nthe git-bug daemon is running and this command can't be run through it: use its API on %s, or stop it with \"git bug daemon stop\"",
+					&cache.ErrLocked{}, socket)
+			}
+			return runOnDaemon(env, socket, cmd, args)
+		}
+
+		env.backend, err = cache.NewRepoCacheWithProgress(env.repo, buildProgress(env))
+		if cache.IsErrLocked(err) {
+			return fmt.Errorf("%w\nread-only commands still work, and git-bug.cache.lock-timeout can be configured to wait longer for the lock", err)
+		}
 		if err != nil {
 			return err
 		}
@@ -117,6 +181,42 @@ func loadBackend(env *Env) func(*cobra.Command, []string) error {
 	}
 }
 
+// loadBackendReadOnly is a pre-run function that load the repository and the backend
+// in read-only mode: the repository is not locked, so it can be used while another
+// process use it, but the cache might be slightly stale and no data can be modified.
+// When using this function you also need to use closeBackend as a post-run
+func loadBackendReadOnly(env *Env) func(*cobra.Command, []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		err := loadRepo(env)(cmd, args)
+		if err != nil {
+			return err
+		}
+
+		env.backend, err = cache.NewReadOnlyRepoCache(env.repo, buildProgress(env))
+		if err != nil {
+			return err
+		}
+
+		return nil
+	}
+}
+
+// buildProgress report on stderr the progress of the building of the cache
+func buildProgress(env *Env) func(cache.BuildEvent) {
+	return func(event cache.BuildEvent) {
+		switch event.Typ {
+		case cache.BuildEventProgress:
+			if event.Total > 0 {
+				env.err.Printf("\rbuilt %d/%d %s", event.Done, event.Total, event.Target)
+			}
+		case cache.BuildEventFinished:
+			env.err.Printf("\r%s\n", event)
+		case cache.BuildEventOutdated, cache.BuildEventMigrated:
+			env.err.Println(event)
+		}
+	}
+}
+
 // loadBackendEnsureUser is the same as loadBackend, but also ensure that the user has configured
 // an identity. Use this pre-run function when an error after using the configured user won't
 // do.
@@ -143,6 +243,11 @@ func closeBackend(env *Env) func(*cobra.Command, []string) error {
 		if env.backend == nil {
 			return nil
 		}
+		// the cache of the daemon stay open
+		if env.backend == sharedBackend {
+			env.backend = nil
+			return nil
+		}
 		err := env.backend.Close()
 		env.backend = nil
 		return err
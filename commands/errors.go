@@ -0,0 +1,142 @@
+package commands
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/MichaelMure/git-bug/bridge/core"
+	"github.com/MichaelMure/git-bug/bridge/core/auth"
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/cache"
+	_select "github.com/MichaelMure/git-bug/commands/select"
+	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/identity"
+	"github.com/MichaelMure/git-bug/input"
+	"github.com/MichaelMure/git-bug/query"
+)
+
+// Exit codes of git-bug. Scripts rely on them, they should not change.
+const (
+	ExitError     = 1
+	ExitInvalid   = 2
+	ExitNotFound  = 3
+	ExitAmbiguous = 4
+	ExitAuth      = 5
+	ExitLocked    = 6
+)
+
+const exitCodesHelp = `Exit codes:
+  0  success
+  1  other error
+  2  invalid input: flags, arguments, query or missing parameter
+  3  bug, identity or credential not found
+  4  ambiguous id prefix
+  5  bridge authentication failure
+  6  repository locked by another process
+
+With --format json, errors are written on the standard error as a JSON object:
+{"error": {"kind": "not_found", "code": 3, "message": "bug doesn't exist"}}`
+
+// JSONError is the envelope of an error written with --format json
+type JSONError struct {
+	Kind    string `json:"kind"`
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	// the ids matching an ambiguous prefix
+	Matching []string `json:"matching,omitempty"`
+}
+
+// errInvalidInput mark an error caused by invalid flags or arguments
+type errInvalidInput struct {
+	err error
+}
+
+func (e errInvalidInput) Error() string {
+	return e.err.Error()
+}
+
+func (e errInvalidInput) Unwrap() error {
+	return e.err
+}
+
+func flagError(_ *cobra.Command, err error) error {
+	return errInvalidInput{err: err}
+}
+
+// classifyError return the exit code and the kind of an error
+func classifyError(err error) (int, string) {
+	var multipleMatch *entity.ErrMultipleMatch
+	var locked *cache.ErrLocked
+	var authentication *core.ErrAuthentication
+	var parse *query.ParseError
+	var missingParam *core.ErrMissingParam
+	var invalidInput errInvalidInput
+
+	switch {
+	case errors.As(err, &locked):
+		return ExitLocked, "locked"
+	case errors.As(err, &authentication):
+		return ExitAuth, "auth"
+	case errors.As(err, &multipleMatch):
+		return ExitAmbiguous, "ambiguous"
+	case errors.Is(err, bug.ErrBugNotExist),
+		errors.Is(err, identity.ErrIdentityNotExist),
+		errors.Is(err, auth.ErrCredentialNotExist):
+		return ExitNotFound, "not_found"
+	case errors.As(err, &parse),
+		errors.As(err, &missingParam),
+		errors.As(err, &invalidInput),
+		errors.Is(err, _select.ErrNoValidId),
+		errors.Is(err, input.ErrEmptyTitle),
+		errors.Is(err, input.ErrEmptyMessage):
+		return ExitInvalid, "invalid"
+	default:
+		return ExitError, "error"
+	}
+}
+
+// jsonErrors tell if the command has been asked for a JSON output
+func jsonErrors(cmd *cobra.Command) bool {
+	if cmd == nil {
+		return false
+	}
+	flag := cmd.Flags().Lookup("format")
+	if flag == nil {
+		return false
+	}
+	return flag.Value.String() == "json" || flag.Value.String() == "ndjson"
+}
+
+// handleError print the error of a command and return the exit code
+func handleError(w io.Writer, cmd *cobra.Command, err error) int {
+	code, kind := classifyError(err)
+
+	if !jsonErrors(cmd) {
+		_, _ = fmt.Fprintln(w, "Error:", err)
+		return code
+	}
+
+	jsonErr := JSONError{
+		Kind:    kind,
+		Code:    code,
+		Message: err.Error(),
+	}
+
+	var multipleMatch *entity.ErrMultipleMatch
+	if errors.As(err, &multipleMatch) {
+		for _, id := range multipleMatch.Matching {
+			jsonErr.Matching = append(jsonErr.Matching, id.String())
+		}
+	}
+
+	data, _ := json.Marshal(struct {
+		Error JSONError `json:"error"`
+	}{jsonErr})
+	_, _ = fmt.Fprintln(w, string(data))
+
+	return code
+}
@@ -0,0 +1,63 @@
+package commands
+
+import (
+	"errors"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	_select "github.com/MichaelMure/git-bug/commands/select"
+)
+
+func newEstimateCommand() *cobra.Command {
+	env := newEnv()
+
+	cmd := &cobra.Command{
+		Use:   "estimate [ID] [DURATION]",
+		Short: "Display or change the estimated time to resolve a bug.",
+		Long: `Display or change the estimated time to resolve a bug.
+
+The duration is expressed like "1h30m". A duration of 0 remove the estimate.`,
+		PreRunE:           loadBackendEnsureUser(env),
+		PostRunE:          closeBackend(env),
+		ValidArgsFunction: completeBug(env),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runEstimate(env, args)
+		},
+	}
+
+	return cmd
+}
+
+func runEstimate(env *Env, args []string) error {
+	b, args, err := _select.ResolveBug(env.backend, args)
+	if err != nil {
+		return err
+	}
+
+	if len(args) > 1 {
+		return errors.New("only one duration can be provided")
+	}
+
+	if len(args) == 0 {
+		snap := b.Snapshot()
+		if snap.Estimate == 0 {
+			env.out.Println("no estimate")
+			return nil
+		}
+		env.out.Println(snap.Estimate)
+		return nil
+	}
+
+	duration, err := time.ParseDuration(args[0])
+	if err != nil {
+		return err
+	}
+
+	_, err = b.SetEstimate(duration)
+	if err != nil {
+		return err
+	}
+
+	return b.Commit()
+}
@@ -0,0 +1,105 @@
+package commands
+
+import (
+	"compress/gzip"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/query"
+)
+
+type exportOptions struct {
+	query string
+}
+
+func newExportCommand() *cobra.Command {
+	env := newEnv()
+	options := exportOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "export FILE",
+		Short: "Export bugs and identities in a portable archive.",
+		Long: `Export bugs and identities in a portable archive.
+
+The archive is a self-contained and versioned JSON dump of the bugs, of all the identities and of
+the attached files. Once imported with "git bug import", in the same or in an unrelated repository,
+the bugs and their operations keep their ids.
+
+The archive is compressed if FILE ends with ".gz". Use "-" to write the archive on the standard output.`,
+		Example: `git bug export backup.json.gz
+git bug export --query "label:security" security.json`,
+		PreRunE:  loadBackendReadOnly(env),
+		PostRunE: closeBackend(env),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runExport(env, options, args)
+		},
+		Args: cobra.ExactArgs(1),
+	}
+
+	flags := cmd.Flags()
+	flags.SortFlags = false
+
+	flags.StringVarP(&options.query, "query", "q", "",
+		"Only export the bugs matching the query")
+
+	return cmd
+}
+
+func runExport(env *Env, opts exportOptions, args []string) error {
+	var ids []entity.Id
+
+	if opts.query != "" {
+		saved, err := query.ReadSavedQueries(env.repo.LocalConfig())
+		if err != nil {
+			return err
+		}
+
+		q, err := query.ParseWithSaved(opts.query, saved)
+		if err != nil {
+			return queryError(err)
+		}
+
+		ids = env.backend.QueryBugs(q)
+		if len(ids) == 0 {
+			env.err.Println("No bug matching the query.")
+			return nil
+		}
+	}
+
+	var w io.Writer = os.Stdout
+
+	if args[0] != "-" {
+		f, err := os.Create(args[0])
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+
+	var gz *gzip.Writer
+	if strings.HasSuffix(args[0], ".gz") {
+		gz = gzip.NewWriter(w)
+		w = gz
+	}
+
+	a, err := env.backend.ExportArchive(w, ids)
+	if err != nil {
+		return err
+	}
+
+	if gz != nil {
+		if err := gz.Close(); err != nil {
+			return err
+		}
+	}
+
+	// the archive may be written on the standard output
+	env.err.Printf("%d bug(s) and %d identities exported\n", len(a.Bugs), len(a.Identities))
+
+	return nil
+}
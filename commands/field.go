@@ -0,0 +1,51 @@
+package commands
+
+import (
+	"sort"
+
+	"github.com/spf13/cobra"
+
+	_select "github.com/MichaelMure/git-bug/commands/select"
+)
+
+func newFieldCommand() *cobra.Command {
+	env := newEnv()
+
+	cmd := &cobra.Command{
+		Use:               "field [ID]",
+		Short:             "Display or change the custom fields of a bug.",
+		PreRunE:           loadBackend(env),
+		PostRunE:          closeBackend(env),
+		ValidArgsFunction: completeBug(env),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runField(env, args)
+		},
+	}
+
+	cmd.AddCommand(newFieldSetCommand())
+	cmd.AddCommand(newFieldUnsetCommand())
+	cmd.AddCommand(newFieldSchemaCommand())
+
+	return cmd
+}
+
+func runField(env *Env, args []string) error {
+	b, args, err := _select.ResolveBug(env.backend, args)
+	if err != nil {
+		return err
+	}
+
+	snap := b.Snapshot()
+
+	names := make([]string, 0, len(snap.Fields))
+	for name := range snap.Fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		env.out.Printf("%s=%s\n", name, snap.Fields[name])
+	}
+
+	return nil
+}
@@ -0,0 +1,106 @@
+package commands
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/MichaelMure/git-bug/bug"
+)
+
+func newFieldSchemaCommand() *cobra.Command {
+	env := newEnv()
+
+	cmd := &cobra.Command{
+		Use:   "schema",
+		Short: "List the custom fields defined for this repository.",
+		Long: `List the custom fields defined for this repository.
+
+The schema is stored in the repository git config. Each field has a type: string, enum, number or date (YYYY-MM-DD).`,
+		PreRunE:  loadBackend(env),
+		PostRunE: closeBackend(env),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runFieldSchema(env)
+		},
+	}
+
+	cmd.AddCommand(newFieldSchemaAddCommand())
+	cmd.AddCommand(newFieldSchemaRmCommand())
+
+	return cmd
+}
+
+func runFieldSchema(env *Env) error {
+	schema, err := env.backend.FieldSchema()
+	if err != nil {
+		return err
+	}
+
+	for _, name := range schema.Names() {
+		def := schema[name]
+		if len(def.Values) > 0 {
+			env.out.Printf("%s: %s [%s]\n", def.Name, def.Type, strings.Join(def.Values, ", "))
+		} else {
+			env.out.Printf("%s: %s\n", def.Name, def.Type)
+		}
+	}
+
+	return nil
+}
+
+func newFieldSchemaAddCommand() *cobra.Command {
+	env := newEnv()
+
+	cmd := &cobra.Command{
+		Use:      "add NAME TYPE [VALUE]...",
+		Short:    "Define or replace a custom field. The values are required for an enum.",
+		PreRunE:  loadBackend(env),
+		PostRunE: closeBackend(env),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runFieldSchemaAdd(env, args)
+		},
+	}
+
+	return cmd
+}
+
+func runFieldSchemaAdd(env *Env, args []string) error {
+	if len(args) < 2 {
+		return errors.New("you must provide a field name and a type")
+	}
+
+	fieldType, err := bug.FieldTypeFromString(args[1])
+	if err != nil {
+		return err
+	}
+
+	def := bug.FieldDefinition{
+		Name:   args[0],
+		Type:   fieldType,
+		Values: args[2:],
+	}
+
+	return env.backend.SetFieldDefinition(def)
+}
+
+func newFieldSchemaRmCommand() *cobra.Command {
+	env := newEnv()
+
+	cmd := &cobra.Command{
+		Use:      "rm NAME",
+		Short:    "Remove a custom field from the schema. Values already set on bugs are kept.",
+		PreRunE:  loadBackend(env),
+		PostRunE: closeBackend(env),
+		Args:     cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runFieldSchemaRm(env, args)
+		},
+	}
+
+	return cmd
+}
+
+func runFieldSchemaRm(env *Env, args []string) error {
+	return env.backend.RemoveFieldDefinition(args[0])
+}
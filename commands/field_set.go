@@ -0,0 +1,87 @@
+package commands
+
+import (
+	"errors"
+
+	"github.com/spf13/cobra"
+
+	_select "github.com/MichaelMure/git-bug/commands/select"
+)
+
+func newFieldSetCommand() *cobra.Command {
+	env := newEnv()
+
+	cmd := &cobra.Command{
+		Use:               "set [ID] NAME VALUE",
+		Short:             "Set the value of a custom field of a bug.",
+		PreRunE:           loadBackendEnsureUser(env),
+		PostRunE:          closeBackend(env),
+		ValidArgsFunction: completeBug(env),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runFieldSet(env, args)
+		},
+	}
+
+	return cmd
+}
+
+func runFieldSet(env *Env, args []string) error {
+	b, args, err := _select.ResolveBug(env.backend, args)
+	if err != nil {
+		return err
+	}
+
+	if len(args) != 2 {
+		return errors.New("you must provide a field name and a value")
+	}
+
+	if args[1] == "" {
+		return errors.New("empty value, use \"field unset\" to remove a field")
+	}
+
+	_, err = b.SetField(args[0], args[1])
+	if err != nil {
+		return err
+	}
+
+	return b.Commit()
+}
+
+func newFieldUnsetCommand() *cobra.Command {
+	env := newEnv()
+
+	cmd := &cobra.Command{
+		Use:               "unset [ID] NAME",
+		Short:             "Remove a custom field from a bug.",
+		PreRunE:           loadBackendEnsureUser(env),
+		PostRunE:          closeBackend(env),
+		ValidArgsFunction: completeBug(env),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runFieldUnset(env, args)
+		},
+	}
+
+	return cmd
+}
+
+func runFieldUnset(env *Env, args []string) error {
+	b, args, err := _select.ResolveBug(env.backend, args)
+	if err != nil {
+		return err
+	}
+
+	if len(args) != 1 {
+		return errors.New("you must provide a field name")
+	}
+
+	if _, ok := b.Snapshot().Fields[args[0]]; !ok {
+		return errors.New("this field is not set on this bug")
+	}
+
+	_, err = b.SetField(args[0], "")
+	if err != nil {
+		return err
+	}
+
+	return b.Commit()
+}
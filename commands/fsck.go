@@ -0,0 +1,71 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+type fsckOptions struct {
+	repair bool
+}
+
+func newFsckCommand() *cobra.Command {
+	env := newEnv()
+	options := fsckOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "fsck",
+		Short: "Verify the integrity of the repository data.",
+		Long: `Read again all the bugs and identities from git and verify their integrity.
+
+This check that the entities can be read and are valid, that the signatures of their commits are correct, that no operation reference a missing operation and that the cache is consistent with the data.`,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			// repairing write the cache, which require the lock
+			if options.repair {
+				return loadBackend(env)(cmd, args)
+			}
+			return loadBackendReadOnly(env)(cmd, args)
+		},
+		PostRunE: closeBackend(env),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runFsck(env, options)
+		},
+		Args: cobra.NoArgs,
+	}
+
+	flags := cmd.Flags()
+	flags.SortFlags = false
+
+	flags.BoolVar(&options.repair, "repair", false,
+		"Repair the issues that can be, by rebuilding the cache")
+
+	return cmd
+}
+
+func runFsck(env *Env, opts fsckOptions) error {
+	issues, err := env.backend.Fsck(opts.repair)
+	if err != nil {
+		return err
+	}
+
+	unrepaired := 0
+	for _, issue := range issues {
+		env.out.Println(issue.String())
+		if !issue.Repaired {
+			unrepaired++
+		}
+	}
+
+	if len(issues) == 0 {
+		env.out.Println("no issue found")
+		return nil
+	}
+
+	if unrepaired > 0 {
+		return fmt.Errorf("%d issue(s) found, %d remaining", len(issues), unrepaired)
+	}
+
+	env.out.Printf("%d issue(s) found and repaired\n", len(issues))
+	return nil
+}
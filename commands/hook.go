@@ -0,0 +1,153 @@
+package commands
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/util/colors"
+)
+
+func newHookCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "hook",
+		Short: "Commands run by the git hooks installed with install-hooks.",
+		// not meant to be used directly
+		Hidden: true,
+	}
+
+	cmd.AddCommand(newHookCommitMsgCommand())
+	cmd.AddCommand(newHookPostCommitCommand())
+
+	return cmd
+}
+
+func newHookCommitMsgCommand() *cobra.Command {
+	env := newEnv()
+
+	cmd := &cobra.Command{
+		Use:      "commit-msg FILE",
+		Short:    "Check that the bugs referenced in the trailers of a commit message exist.",
+		PreRunE:  loadBackendReadOnly(env),
+		PostRunE: closeBackend(env),
+		Args:     cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runHookCommitMsg(env, args[0])
+		},
+	}
+
+	return cmd
+}
+
+func runHookCommitMsg(env *Env, fileName string) error {
+	raw, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return err
+	}
+
+	// the comments are removed by git after the hook
+	var lines []string
+	for _, line := range strings.Split(string(raw), "\n") {
+		if !strings.HasPrefix(line, "#") {
+			lines = append(lines, line)
+		}
+	}
+
+	for _, trailer := range bug.ParseCommitTrailers(strings.Join(lines, "\n")) {
+		_, err := env.backend.ResolveBugExcerptPrefix(trailer.Prefix)
+		if err != nil {
+			return fmt.Errorf("%s: %w (use --no-verify to commit anyway)", trailer.Prefix, err)
+		}
+	}
+
+	return nil
+}
+
+func newHookPostCommitCommand() *cobra.Command {
+	env := newEnv()
+
+	cmd := &cobra.Command{
+		Use:   "post-commit",
+		Short: "Link the last commit to the bugs referenced in its trailers.",
+		Long: `Link the last commit to the bugs referenced in its trailers.
+
+"Fixes: <id>" add a fixed-by reference to the commit, and close the bug if the commit is on the default
+branch. "Refs: <id>" add a mentioned reference.`,
+		PreRunE:  loadBackendEnsureUser(env),
+		PostRunE: closeBackend(env),
+		Args:     cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runHookPostCommit(env)
+		},
+	}
+
+	return cmd
+}
+
+func runHookPostCommit(env *Env) error {
+	hash, err := env.backend.ResolveRevision("HEAD")
+	if err != nil {
+		return err
+	}
+
+	message, err := env.backend.CommitMessage(hash)
+	if err != nil {
+		return err
+	}
+
+	trailers := bug.ParseCommitTrailers(message)
+	if len(trailers) == 0 {
+		return nil
+	}
+
+	// without a default branch, the bugs are only closed by a later sweep
+	into, intoErr := env.backend.DefaultBranch()
+
+	failed := 0
+
+	for _, trailer := range trailers {
+		b, err := env.backend.ResolveBugPrefix(trailer.Prefix)
+		if err != nil {
+			failed++
+			env.err.Printf("%s: %v\n", trailer.Prefix, err)
+			continue
+		}
+
+		ref := bug.CodeRef{Kind: bug.CommitCodeRef, Role: trailer.Role, Target: hash.String()}
+		if !hasCodeRef(b.Snapshot(), ref) {
+			_, err = b.AddCodeRef(ref)
+			if err != nil {
+				return err
+			}
+		}
+
+		result := fmt.Sprintf("commit %s linked as %s", hash.String()[:7], trailer.Role)
+
+		if trailer.Role == bug.FixedByCodeRef && intoErr == nil && b.Snapshot().Status == bug.OpenStatus {
+			reachable, err := env.backend.IsReachable(hash, into)
+			if err == nil && reachable {
+				_, err = b.Close()
+				if err != nil {
+					return err
+				}
+				result += ", closed"
+			}
+		}
+
+		err = b.CommitAsNeeded()
+		if err != nil {
+			return err
+		}
+
+		env.out.Printf("%s: %s\n", colors.Cyan(b.Id().Human()), result)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d referenced bugs not found", failed, len(trailers))
+	}
+
+	return nil
+}
@@ -0,0 +1,79 @@
+package commands
+
+import (
+	"bufio"
+	"compress/gzip"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/MichaelMure/git-bug/entity"
+)
+
+func newImportCommand() *cobra.Command {
+	env := newEnv()
+
+	cmd := &cobra.Command{
+		Use:   "import FILE",
+		Short: "Import bugs and identities from a portable archive.",
+		Long: `Import bugs and identities from a portable archive created with "git bug export".
+
+The bugs and identities are merged like when pulling from a remote: new ones are created, and the
+existing ones are updated with the new operations. Compressed archives are detected automatically.
+Use "-" to read the archive from the standard input.`,
+		Example:  `git bug import backup.json.gz`,
+		PreRunE:  loadBackend(env),
+		PostRunE: closeBackend(env),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runImport(env, args)
+		},
+		Args: cobra.ExactArgs(1),
+	}
+
+	return cmd
+}
+
+func runImport(env *Env, args []string) error {
+	var r io.Reader = os.Stdin
+
+	if args[0] != "-" {
+		f, err := os.Open(args[0])
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		r = f
+	}
+
+	buffered := bufio.NewReader(r)
+	r = buffered
+
+	// gzip magic number
+	magic, err := buffered.Peek(2)
+	if err == nil && magic[0] == 0x1f && magic[1] == 0x8b {
+		gz, err := gzip.NewReader(buffered)
+		if err != nil {
+			return err
+		}
+		defer gz.Close()
+		r = gz
+	}
+
+	results, err := env.backend.ImportArchive(r)
+	if err != nil {
+		return err
+	}
+
+	for result := range results {
+		if result.Err != nil {
+			env.err.Println(result.Err)
+		}
+
+		if result.Status != entity.MergeStatusNothing {
+			env.out.Printf("%s: %s\n", result.Id.Human(), result)
+		}
+	}
+
+	return nil
+}
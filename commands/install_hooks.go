@@ -0,0 +1,130 @@
+package commands
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// hookMarker identify the hooks installed by git-bug, to be able to update
+// them without overwriting the user's ones
+const hookMarker = "# installed by git-bug"
+
+var gitHooks = []struct {
+	name   string
+	script string
+}{
+	{
+		// run by git push, with the remote as first argument
+		name: "pre-push",
+		script: `#!/bin/sh
+` + hookMarker + `: push the bugs along with the code
+git bug push "$1" >/dev/null 2>&1 || echo "git-bug: failed to push the bugs to $1" >&2
+`,
+	},
+	{
+		// run by git commit, can abort the commit
+		name: "commit-msg",
+		script: `#!/bin/sh
+` + hookMarker + `: check the bugs referenced by "Fixes:" and "Refs:" trailers
+exec git bug hook commit-msg "$1"
+`,
+	},
+	{
+		// run by git commit, once the commit is done
+		name: "post-commit",
+		script: `#!/bin/sh
+` + hookMarker + `: link the commit to the bugs referenced by "Fixes:" and "Refs:" trailers
+git bug hook post-commit || echo "git-bug: failed to link the commit to the bugs" >&2
+`,
+	},
+	{
+		// run by git pull, once merged
+		name: "post-merge",
+		script: `#!/bin/sh
+` + hookMarker + `: pull the bugs along with the code
+git bug pull >/dev/null 2>&1 || echo "git-bug: failed to pull the bugs" >&2
+git bug sweep --commits --yes >/dev/null 2>&1
+git bug sweep --dry-run 2>/dev/null | grep -q . && echo "git-bug: some bug branches are merged, run \"git bug sweep\" to close the bugs" >&2
+exit 0
+`,
+	},
+}
+
+type installHooksOptions struct {
+	force bool
+}
+
+func newInstallHooksCommand() *cobra.Command {
+	env := newEnv()
+	options := installHooksOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "install-hooks",
+		Short: "Install git hooks to sync the bugs with the code.",
+		Long: `Install git hooks to sync the bugs with the code.
+
+A pre-push hook push the bugs to the same remote as the code, and a post-merge hook pull the bugs from the
+default remote after a "git pull". A failure to sync the bugs is reported but doesn't prevent the git command.
+The post-merge hook also close the bugs fixed by the merged commits, and report when the branches of some bugs
+are merged, as "git bug sweep" would find.
+
+The commit-msg and post-commit hooks handle the "Fixes: <id>" and "Refs: <id>" trailers of the commit messages:
+the referenced bugs must exist, and the commit is added to them as a fixed-by or mentioned code reference. A bug
+fixed by a commit is closed once the commit is on the default branch, either directly or by a merge.
+
+Existing hooks are not overwritten unless --force is given, except the ones previously installed by git-bug.`,
+		PreRunE: loadRepo(env),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runInstallHooks(env, options)
+		},
+		Args: cobra.NoArgs,
+	}
+
+	flags := cmd.Flags()
+	flags.SortFlags = false
+
+	flags.BoolVarP(&options.force, "force", "f", false,
+		"Overwrite the existing hooks")
+
+	return cmd
+}
+
+func runInstallHooks(env *Env, opts installHooksOptions) error {
+	hooksDir := filepath.Join(env.repo.GetPath(), "hooks")
+
+	err := os.MkdirAll(hooksDir, 0755)
+	if err != nil {
+		return err
+	}
+
+	for _, hook := range gitHooks {
+		path := filepath.Join(hooksDir, hook.name)
+
+		existing, err := ioutil.ReadFile(path)
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		if err == nil && !opts.force && !strings.Contains(string(existing), hookMarker) {
+			return fmt.Errorf("a %s hook already exist, use --force to overwrite it", hook.name)
+		}
+
+		err = ioutil.WriteFile(path, []byte(hook.script), 0755)
+		if err != nil {
+			return err
+		}
+		// the permissions of an existing file are not changed by WriteFile
+		err = os.Chmod(path, 0755)
+		if err != nil {
+			return err
+		}
+
+		env.out.Printf("%s hook installed\n", hook.name)
+	}
+
+	return nil
+}
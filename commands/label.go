@@ -1,8 +1,13 @@
 package commands
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/spf13/cobra"
 
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/cache"
 	_select "github.com/MichaelMure/git-bug/commands/select"
 )
 
@@ -10,17 +15,24 @@ func newLabelCommand() *cobra.Command {
 	env := newEnv()
 
 	cmd := &cobra.Command{
-		Use:      "label [ID]",
-		Short:    "Display, add or remove labels to/from a bug.",
-		PreRunE:  loadBackend(env),
-		PostRunE: closeBackend(env),
+		Use:               "label [ID]",
+		Short:             "Display, add or remove labels to/from a bug.",
+		PreRunE:           loadBackend(env),
+		PostRunE:          closeBackend(env),
+		ValidArgsFunction: completeBug(env),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runLabel(env, args)
 		},
 	}
 
 	cmd.AddCommand(newLabelAddCommand())
+	cmd.AddCommand(newLabelArchiveCommand())
+	cmd.AddCommand(newLabelLsCommand())
+	cmd.AddCommand(newLabelNewCommand())
+	cmd.AddCommand(newLabelRecolorCommand())
+	cmd.AddCommand(newLabelRenameCommand())
 	cmd.AddCommand(newLabelRmCommand())
+	cmd.AddCommand(newLabelUsageCommand())
 
 	return cmd
 }
@@ -39,3 +51,49 @@ func runLabel(env *Env, args []string) error {
 
 	return nil
 }
+
+// reportLabelChanges print the label changes of a single bug, or summarize
+// them on one line when changing several bugs
+func reportLabelChanges(env *Env, bugs []*cache.BugCache, changes []bug.LabelChangeResult) string {
+	if len(bugs) == 1 {
+		for _, change := range changes {
+			env.out.Println(change)
+		}
+		return ""
+	}
+
+	results := make([]string, len(changes))
+	for i, change := range changes {
+		results[i] = change.String()
+	}
+	return strings.Join(results, ", ")
+}
+
+// bulkChangeLabels add and remove labels on all the bugs matching a query.
+// The bugs already having the change are left untouched.
+func bulkChangeLabels(env *Env, opts bulkOptions, added []string, removed []string) error {
+	if len(added) == 0 && len(removed) == 0 {
+		return fmt.Errorf("no label given")
+	}
+
+	bugs, err := resolveBulk(env, opts, "Change the labels of")
+	if err != nil {
+		return err
+	}
+
+	for _, b := range bugs {
+		_, _, err := b.ChangeLabels(added, removed)
+		if err == bug.ErrNoLabelChange {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("%s: %v", b.Id().Human(), err)
+		}
+
+		if err := b.Commit(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -1,44 +1,72 @@
 package commands
 
 import (
+	"fmt"
+
 	"github.com/spf13/cobra"
 
+	"github.com/MichaelMure/git-bug/cache"
 	_select "github.com/MichaelMure/git-bug/commands/select"
 )
 
+type labelAddOptions struct {
+	force bool
+	bulk  bulkOptions
+}
+
 func newLabelAddCommand() *cobra.Command {
 	env := newEnv()
+	options := labelAddOptions{}
 
 	cmd := &cobra.Command{
-		Use:      "add [ID] LABEL...",
-		Short:    "Add a label to a bug.",
-		PreRunE:  loadBackendEnsureUser(env),
-		PostRunE: closeBackend(env),
+		Use:   "add [ID...] LABEL...",
+		Short: "Add a label to a bug.",
+		Example: `Add the ui and regression labels to several bugs:
+git bug label add 1234 5678 ui regression
+
+Add the ui label to all the open bugs with "button" in the title, after confirmation:
+git bug label add ui --query 'status:open title:button'
+`,
+		PreRunE:           loadBackendEnsureUser(env),
+		PostRunE:          closeBackend(env),
+		ValidArgsFunction: completeBugAndLabel(env),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runLabelAdd(env, args)
+			return runLabelAdd(env, options, args)
 		},
 	}
 
+	flags := cmd.Flags()
+	flags.SortFlags = false
+
+	flags.BoolVarP(&options.force, "force", "f", false,
+		"Add the labels even if they don't match the label taxonomy of the repository")
+	addBulkFlags(flags, &options.bulk)
+
 	return cmd
 }
 
-func runLabelAdd(env *Env, args []string) error {
-	b, args, err := _select.ResolveBug(env.backend, args)
+func runLabelAdd(env *Env, opts labelAddOptions, args []string) error {
+	if opts.bulk.query != "" {
+		if opts.force {
+			return fmt.Errorf("--force can't be used with --query")
+		}
+		return bulkChangeLabels(env, opts.bulk, args, nil)
+	}
+
+	bugs, args, err := _select.ResolveBugs(env.backend, args)
 	if err != nil {
 		return err
 	}
 
 	added := args
 
-	changes, _, err := b.ChangeLabels(added, nil)
-
-	for _, change := range changes {
-		env.out.Println(change)
-	}
-
-	if err != nil {
-		return err
-	}
+	return applyToBugs(env, bugs, func(b *cache.BugCache) (string, error) {
+		if opts.force {
+			_, err := b.ForceChangeLabels(added, nil)
+			return "labels added", err
+		}
 
-	return b.Commit()
+		changes, _, err := b.ChangeLabels(added, nil)
+		return reportLabelChanges(env, bugs, changes), err
+	})
 }
@@ -0,0 +1,57 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/MichaelMure/git-bug/bug"
+)
+
+type labelArchiveOptions struct {
+	restore bool
+}
+
+func newLabelArchiveCommand() *cobra.Command {
+	env := newEnv()
+	options := labelArchiveOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "archive NAME",
+		Short: "Archive a registered label.",
+		Long: `Archive a registered label.
+
+An archived label is kept on the bugs already carrying it, but is hidden from "git bug label ls".`,
+		PreRunE:           loadBackend(env),
+		PostRunE:          closeBackend(env),
+		ValidArgsFunction: completeLabel(env),
+		Args:              cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runLabelArchive(env, options, args)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.SortFlags = false
+
+	flags.BoolVar(&options.restore, "restore", false,
+		"Restore an archived label instead")
+
+	return cmd
+}
+
+func runLabelArchive(env *Env, opts labelArchiveOptions, args []string) error {
+	registry, err := env.backend.LabelRegistry()
+	if err != nil {
+		return err
+	}
+
+	def, ok := registry[bug.Label(args[0])]
+	if !ok {
+		return fmt.Errorf("unknown label %s", args[0])
+	}
+
+	def.Archived = !opts.restore
+
+	return env.backend.SetLabelDefinition(def)
+}
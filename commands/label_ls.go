@@ -0,0 +1,66 @@
+package commands
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/MichaelMure/git-bug/util/colors"
+)
+
+type labelLsOptions struct {
+	all bool
+}
+
+func newLabelLsCommand() *cobra.Command {
+	env := newEnv()
+	options := labelLsOptions{}
+
+	cmd := &cobra.Command{
+		Use:      "ls",
+		Short:    "List the labels registered for this repository.",
+		PreRunE:  loadBackendReadOnly(env),
+		PostRunE: closeBackend(env),
+		Args:     cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runLabelLs(env, options)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.SortFlags = false
+
+	flags.BoolVarP(&options.all, "all", "a", false,
+		"Also list the archived labels")
+
+	return cmd
+}
+
+func runLabelLs(env *Env, opts labelLsOptions) error {
+	registry, err := env.backend.LabelRegistry()
+	if err != nil {
+		return err
+	}
+
+	for _, name := range registry.Names() {
+		def := registry[name]
+		if def.Archived && !opts.all {
+			continue
+		}
+
+		lc256 := def.Color.Term256()
+		env.out.Printf("%s◼%s %s %s",
+			lc256.Escape(),
+			lc256.Unescape(),
+			def.Name,
+			def.Color.Hex(),
+		)
+		if def.Archived {
+			env.out.Print(colors.Yellow(" (archived)"))
+		}
+		if def.Description != "" {
+			env.out.Printf("\t%s", def.Description)
+		}
+		env.out.Println()
+	}
+
+	return nil
+}
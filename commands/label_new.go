@@ -0,0 +1,79 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/MichaelMure/git-bug/bug"
+)
+
+type labelNewOptions struct {
+	color       string
+	description string
+}
+
+func newLabelNewCommand() *cobra.Command {
+	env := newEnv()
+	options := labelNewOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "new NAME",
+		Short: "Register a new label for this repository.",
+		Long: `Register a new label for this repository.
+
+The label registry is stored in the repository git config. Without a color, the label keep the color computed from its name.`,
+		Example:  `git bug label new kind/bug --color '#d73a4a' --description "Something isn't working"`,
+		PreRunE:  loadBackend(env),
+		PostRunE: closeBackend(env),
+		Args:     cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runLabelNew(env, options, args)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.SortFlags = false
+
+	flags.StringVarP(&options.color, "color", "c", "",
+		"The color of the label, as #rrggbb")
+	flags.StringVarP(&options.description, "description", "d", "",
+		"A short description of the label")
+
+	return cmd
+}
+
+func runLabelNew(env *Env, opts labelNewOptions, args []string) error {
+	registry, err := env.backend.LabelRegistry()
+	if err != nil {
+		return err
+	}
+
+	name := bug.Label(args[0])
+	if _, ok := registry[name]; ok {
+		return fmt.Errorf("label %s already exist", name)
+	}
+
+	taxonomy, err := env.backend.LabelTaxonomy()
+	if err != nil {
+		return err
+	}
+	if err := taxonomy.ValidateLabels([]string{name.String()}); err != nil {
+		return err
+	}
+
+	def := bug.LabelDefinition{
+		Name:        name,
+		Color:       name.Color(),
+		Description: opts.description,
+	}
+
+	if opts.color != "" {
+		def.Color, err = bug.ParseLabelColor(opts.color)
+		if err != nil {
+			return err
+		}
+	}
+
+	return env.backend.SetLabelDefinition(def)
+}
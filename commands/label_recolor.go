@@ -0,0 +1,46 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/MichaelMure/git-bug/bug"
+)
+
+func newLabelRecolorCommand() *cobra.Command {
+	env := newEnv()
+
+	cmd := &cobra.Command{
+		Use:               "recolor NAME COLOR",
+		Short:             "Change the color of a registered label. The color is given as #rrggbb.",
+		PreRunE:           loadBackend(env),
+		PostRunE:          closeBackend(env),
+		ValidArgsFunction: completeLabel(env),
+		Args:              cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runLabelRecolor(env, args)
+		},
+	}
+
+	return cmd
+}
+
+func runLabelRecolor(env *Env, args []string) error {
+	registry, err := env.backend.LabelRegistry()
+	if err != nil {
+		return err
+	}
+
+	def, ok := registry[bug.Label(args[0])]
+	if !ok {
+		return fmt.Errorf("unknown label %s", args[0])
+	}
+
+	def.Color, err = bug.ParseLabelColor(args[1])
+	if err != nil {
+		return err
+	}
+
+	return env.backend.SetLabelDefinition(def)
+}
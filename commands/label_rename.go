@@ -0,0 +1,36 @@
+package commands
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/MichaelMure/git-bug/bug"
+)
+
+func newLabelRenameCommand() *cobra.Command {
+	env := newEnv()
+
+	cmd := &cobra.Command{
+		Use:               "rename OLD NEW",
+		Short:             "Rename a registered label, on all the bugs carrying it.",
+		PreRunE:           loadBackendEnsureUser(env),
+		PostRunE:          closeBackend(env),
+		ValidArgsFunction: completeLabel(env),
+		Args:              cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runLabelRename(env, args)
+		},
+	}
+
+	return cmd
+}
+
+func runLabelRename(env *Env, args []string) error {
+	changed, err := env.backend.RenameLabel(bug.Label(args[0]), bug.Label(args[1]))
+	if err != nil {
+		return err
+	}
+
+	env.out.Printf("label %s renamed to %s on %d bug(s)\n", args[0], args[1], changed)
+
+	return nil
+}
@@ -3,42 +3,50 @@ package commands
 import (
 	"github.com/spf13/cobra"
 
+	"github.com/MichaelMure/git-bug/cache"
 	_select "github.com/MichaelMure/git-bug/commands/select"
 )
 
 func newLabelRmCommand() *cobra.Command {
 	env := newEnv()
+	options := bulkOptions{}
 
 	cmd := &cobra.Command{
-		Use:      "rm [ID] LABEL...",
-		Short:    "Remove a label from a bug.",
-		PreRunE:  loadBackend(env),
-		PostRunE: closeBackend(env),
+		Use:   "rm [ID...] LABEL...",
+		Short: "Remove a label from a bug.",
+		Example: `Remove the needs-triage label from all the bugs having a priority, after confirmation:
+git bug label rm needs-triage --query 'label:needs-triage priority:>=P0'
+`,
+		PreRunE:           loadBackend(env),
+		PostRunE:          closeBackend(env),
+		ValidArgsFunction: completeBugAndLabel(env),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runLabelRm(env, args)
+			return runLabelRm(env, options, args)
 		},
 	}
 
-	return cmd
-}
+	flags := cmd.Flags()
+	flags.SortFlags = false
 
-func runLabelRm(env *Env, args []string) error {
-	b, args, err := _select.ResolveBug(env.backend, args)
-	if err != nil {
-		return err
-	}
+	addBulkFlags(flags, &options)
 
-	removed := args
-
-	changes, _, err := b.ChangeLabels(nil, removed)
+	return cmd
+}
 
-	for _, change := range changes {
-		env.out.Println(change)
+func runLabelRm(env *Env, opts bulkOptions, args []string) error {
+	if opts.query != "" {
+		return bulkChangeLabels(env, opts, nil, args)
 	}
 
+	bugs, args, err := _select.ResolveBugs(env.backend, args)
 	if err != nil {
 		return err
 	}
 
-	return b.Commit()
+	removed := args
+
+	return applyToBugs(env, bugs, func(b *cache.BugCache) (string, error) {
+		changes, _, err := b.ChangeLabels(nil, removed)
+		return reportLabelChanges(env, bugs, changes), err
+	})
 }
@@ -0,0 +1,77 @@
+package commands
+
+import (
+	"sort"
+
+	"github.com/spf13/cobra"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/query"
+)
+
+func newLabelUsageCommand() *cobra.Command {
+	env := newEnv()
+
+	cmd := &cobra.Command{
+		Use:   "usage",
+		Short: "Show how many open bugs carry each label.",
+		Long: `Show how many open bugs carry each label.
+
+The registered labels not used by any open bug are listed as well, the archived ones excepted.`,
+		PreRunE:  loadBackendReadOnly(env),
+		PostRunE: closeBackend(env),
+		Args:     cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runLabelUsage(env)
+		},
+	}
+
+	return cmd
+}
+
+func runLabelUsage(env *Env) error {
+	registry, err := env.backend.LabelRegistry()
+	if err != nil {
+		return err
+	}
+
+	q, err := query.Parse("status:open")
+	if err != nil {
+		return err
+	}
+
+	usage := make(map[bug.Label]int)
+	for name, def := range registry {
+		if !def.Archived {
+			usage[name] = 0
+		}
+	}
+
+	for _, id := range env.backend.QueryBugs(q) {
+		excerpt, err := env.backend.ResolveBugExcerpt(id)
+		if err != nil {
+			return err
+		}
+		for _, l := range excerpt.Labels {
+			usage[l]++
+		}
+	}
+
+	labels := make([]bug.Label, 0, len(usage))
+	for l := range usage {
+		labels = append(labels, l)
+	}
+	sort.Slice(labels, func(i, j int) bool {
+		if usage[labels[i]] != usage[labels[j]] {
+			return usage[labels[i]] > usage[labels[j]]
+		}
+		return labels[i] < labels[j]
+	})
+
+	for _, l := range labels {
+		lc256 := registry.Color(l).Term256()
+		env.out.Printf("%5d %s◼%s %s\n", usage[l], lc256.Escape(), lc256.Unescape(), l)
+	}
+
+	return nil
+}
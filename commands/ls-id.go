@@ -10,7 +10,7 @@ func newLsIdCommand() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:      "ls-id [PREFIX]",
 		Short:    "List bug identifiers.",
-		PreRunE:  loadBackend(env),
+		PreRunE:  loadBackendReadOnly(env),
 		PostRunE: closeBackend(env),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runLsId(env, args)
@@ -1,10 +1,13 @@
 package commands
 
 import (
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
 	"regexp"
+	"strconv"
 	"strings"
+	"text/template"
 	"time"
 
 	text "github.com/MichaelMure/go-term-text"
@@ -12,6 +15,7 @@ import (
 
 	"github.com/MichaelMure/git-bug/bug"
 	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/entity"
 	"github.com/MichaelMure/git-bug/query"
 	"github.com/MichaelMure/git-bug/util/colors"
 )
@@ -24,6 +28,8 @@ type lsOptions struct {
 	sortBy        string
 	sortDirection string
 	outputFormat  string
+	template      string
+	columns       []string
 }
 
 func newLsCommand() *cobra.Command {
@@ -41,8 +47,17 @@ git bug ls status:open sort:edit-desc
 
 List closed bugs sorted by creation with flags:
 git bug ls --status closed --by creation
+
+List the bugs of a saved query, with an additional filter:
+git bug ls @triage label:ui
+
+List the open bugs with a custom format:
+git bug ls status:open --format template --template '{{.Id.Human}} {{.Title}}'
+
+Export the open bugs for a spreadsheet:
+git bug ls status:open --format csv --columns id,title,assignees,milestone,due > bugs.csv
 `,
-		PreRunE:  loadBackend(env),
+		PreRunE:  loadBackendReadOnly(env),
 		PostRunE: closeBackend(env),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runLs(env, options, args)
@@ -53,7 +68,7 @@ git bug ls --status closed --by creation
 	flags.SortFlags = false
 
 	flags.StringSliceVarP(&options.statusQuery, "status", "s", nil,
-		"Filter by status. Valid values are [open,closed]")
+		"Filter by status. Valid values are [open,closed] or a status defined for the repository")
 	flags.StringSliceVarP(&options.query.Author, "author", "a", nil,
 		"Filter by author")
 	flags.StringSliceVarP(&options.query.Participant, "participant", "p", nil,
@@ -64,14 +79,22 @@ git bug ls --status closed --by creation
 		"Filter by label")
 	flags.StringSliceVarP(&options.query.Title, "title", "t", nil,
 		"Filter by title")
+	flags.StringSliceVar(&options.query.Search, "search", nil,
+		"Filter by words in the title or the comments")
 	flags.StringSliceVarP(&options.noQuery, "no", "n", nil,
 		"Filter by absence of something. Valid values are [label]")
+	flags.BoolVar(&options.query.WithDuplicates, "with-duplicates", false,
+		"Include the bugs closed as duplicate")
 	flags.StringVarP(&options.sortBy, "by", "b", "creation",
-		"Sort the results by a characteristic. Valid values are [id,creation,edit]")
+		"Sort the results by a characteristic. Valid values are [id,creation,edit,comments,priority,due]")
 	flags.StringVarP(&options.sortDirection, "direction", "d", "asc",
 		"Select the sorting direction. Valid values are [asc,desc]")
 	flags.StringVarP(&options.outputFormat, "format", "f", "default",
-		"Select the output formatting style. Valid values are [default,plain,json,org-mode]")
+		"Select the output formatting style. Valid values are [default,plain,json,ndjson,org-mode,csv,template]")
+	flags.StringVar(&options.template, "template", "",
+		"Go template used to display each bug with --format template")
+	flags.StringSliceVarP(&options.columns, "columns", "c", nil,
+		fmt.Sprintf("Columns of the output with --format csv. Valid values are [%s]", strings.Join(csvColumns, ",")))
 
 	return cmd
 }
@@ -81,11 +104,16 @@ func runLs(env *Env, opts lsOptions, args []string) error {
 	var err error
 
 	if len(args) >= 1 {
-		q, err = query.Parse(strings.Join(args, " "))
-
+		saved, err := query.ReadSavedQueries(env.repo.LocalConfig())
 		if err != nil {
 			return err
 		}
+
+		q, err = query.ParseWithSaved(strings.Join(args, " "), saved)
+
+		if err != nil {
+			return queryError(err)
+		}
 	} else {
 		err = completeQuery(&opts)
 		if err != nil {
@@ -94,8 +122,32 @@ func runLs(env *Env, opts lsOptions, args []string) error {
 		q = &opts.query
 	}
 
+	// fail early on an invalid template
+	var tmpl *template.Template
+	if opts.outputFormat == "template" {
+		tmpl, err = parseOutputTemplate(opts.template)
+		if err != nil {
+			return err
+		}
+	}
+
+	if opts.outputFormat == "csv" {
+		if len(opts.columns) == 0 {
+			opts.columns = csvDefaultColumns
+		}
+		err = validateCsvColumns(opts.columns)
+		if err != nil {
+			return err
+		}
+	}
+
 	allIds := env.backend.QueryBugs(q)
 
+	// streamed, without resolving all the bugs first
+	if opts.outputFormat == "ndjson" {
+		return lsNDJSONFormatter(env, allIds)
+	}
+
 	bugExcerpt := make([]*cache.BugExcerpt, len(allIds))
 	for i, id := range allIds {
 		b, err := env.backend.ResolveBugExcerpt(id)
@@ -112,6 +164,15 @@ func runLs(env *Env, opts lsOptions, args []string) error {
 		return lsPlainFormatter(env, bugExcerpt)
 	case "json":
 		return lsJsonFormatter(env, bugExcerpt)
+	case "csv":
+		return lsCsvFormatter(env, bugExcerpt, opts.columns)
+	case "template":
+		for _, b := range bugExcerpt {
+			if err := executeOutputTemplate(env, tmpl, b); err != nil {
+				return err
+			}
+		}
+		return nil
 	case "default":
 		return lsDefaultFormatter(env, bugExcerpt)
 	default:
@@ -126,60 +187,220 @@ type JSONBugExcerpt struct {
 	EditTime   JSONTime `json:"edit_time"`
 
 	Status       string         `json:"status"`
+	Extended     string         `json:"extended_status,omitempty"`
 	Labels       []bug.Label    `json:"labels"`
 	Title        string         `json:"title"`
 	Actors       []JSONIdentity `json:"actors"`
 	Participants []JSONIdentity `json:"participants"`
 	Author       JSONIdentity   `json:"author"`
 
-	Comments int               `json:"comments"`
-	Metadata map[string]string `json:"metadata"`
+	Comments      int               `json:"comments"`
+	ChecklistDone int               `json:"checklist_done"`
+	ChecklistSize int               `json:"checklist_size"`
+	Metadata      map[string]string `json:"metadata"`
+	Draft         bool              `json:"draft,omitempty"`
+
+	Assignees []JSONIdentity `json:"assignees"`
+	Milestone string         `json:"milestone,omitempty"`
+	DueDate   int64          `json:"due_date,omitempty"`
+}
+
+func NewJSONBugExcerpt(env *Env, b *cache.BugExcerpt) (JSONBugExcerpt, error) {
+	jsonBug := JSONBugExcerpt{
+		Id:         b.Id.String(),
+		HumanId:    b.Id.Human(),
+		CreateTime: NewJSONTime(b.CreateTime(), b.CreateLamportTime),
+		EditTime:   NewJSONTime(b.EditTime(), b.EditLamportTime),
+		Status:     b.Status.String(),
+		Extended:   b.ExtendedStatus,
+		Labels:     b.Labels,
+		Title:      b.Title,
+		Comments:   b.LenComments,
+		Metadata:   b.CreateMetadata,
+
+		ChecklistDone: b.ChecklistDone,
+		ChecklistSize: b.ChecklistTotal,
+		Draft:         b.Draft,
+
+		Milestone: b.Milestone,
+		DueDate:   b.DueUnixTime,
+	}
+
+	author, err := env.backend.ResolveIdentityExcerpt(b.AuthorId)
+	if err != nil {
+		return JSONBugExcerpt{}, err
+	}
+	jsonBug.Author = NewJSONIdentityFromExcerpt(author)
+
+	jsonBug.Actors = make([]JSONIdentity, len(b.Actors))
+	for i, element := range b.Actors {
+		actor, err := env.backend.ResolveIdentityExcerpt(element)
+		if err != nil {
+			return JSONBugExcerpt{}, err
+		}
+		jsonBug.Actors[i] = NewJSONIdentityFromExcerpt(actor)
+	}
+
+	jsonBug.Participants = make([]JSONIdentity, len(b.Participants))
+	for i, element := range b.Participants {
+		participant, err := env.backend.ResolveIdentityExcerpt(element)
+		if err != nil {
+			return JSONBugExcerpt{}, err
+		}
+		jsonBug.Participants[i] = NewJSONIdentityFromExcerpt(participant)
+	}
+
+	jsonBug.Assignees = make([]JSONIdentity, len(b.AssigneeIds))
+	for i, element := range b.AssigneeIds {
+		assignee, err := env.backend.ResolveIdentityExcerpt(element)
+		if err != nil {
+			return JSONBugExcerpt{}, err
+		}
+		jsonBug.Assignees[i] = NewJSONIdentityFromExcerpt(assignee)
+	}
+
+	return jsonBug, nil
 }
 
 func lsJsonFormatter(env *Env, bugExcerpts []*cache.BugExcerpt) error {
 	jsonBugs := make([]JSONBugExcerpt, len(bugExcerpts))
 	for i, b := range bugExcerpts {
-		jsonBug := JSONBugExcerpt{
-			Id:         b.Id.String(),
-			HumanId:    b.Id.Human(),
-			CreateTime: NewJSONTime(b.CreateTime(), b.CreateLamportTime),
-			EditTime:   NewJSONTime(b.EditTime(), b.EditLamportTime),
-			Status:     b.Status.String(),
-			Labels:     b.Labels,
-			Title:      b.Title,
-			Comments:   b.LenComments,
-			Metadata:   b.CreateMetadata,
+		jsonBug, err := NewJSONBugExcerpt(env, b)
+		if err != nil {
+			return err
 		}
+		jsonBugs[i] = jsonBug
+	}
+	jsonObject, _ := json.MarshalIndent(jsonBugs, "", "    ")
+	env.out.Printf("%s\n", jsonObject)
+	return nil
+}
 
-		author, err := env.backend.ResolveIdentityExcerpt(b.AuthorId)
+// lsNDJSONFormatter stream the bugs as one JSON object per line, each bug
+// being resolved only when written
+func lsNDJSONFormatter(env *Env, ids []entity.Id) error {
+	encoder := json.NewEncoder(env.out)
+	for _, id := range ids {
+		b, err := env.backend.ResolveBugExcerpt(id)
 		if err != nil {
 			return err
 		}
-		jsonBug.Author = NewJSONIdentityFromExcerpt(author)
 
-		jsonBug.Actors = make([]JSONIdentity, len(b.Actors))
-		for i, element := range b.Actors {
-			actor, err := env.backend.ResolveIdentityExcerpt(element)
+		jsonBug, err := NewJSONBugExcerpt(env, b)
+		if err != nil {
+			return err
+		}
+
+		if err := encoder.Encode(jsonBug); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// csvColumns are the columns available with --format csv
+var csvColumns = []string{
+	"id", "human-id", "title", "status", "labels", "author", "assignees",
+	"milestone", "create", "edit", "comments", "due",
+}
+
+var csvDefaultColumns = []string{"id", "title", "status", "labels", "author", "create", "edit", "comments"}
+
+func validateCsvColumns(columns []string) error {
+	for _, column := range columns {
+		valid := false
+		for _, candidate := range csvColumns {
+			if column == candidate {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return fmt.Errorf("unknown column %s, valid values are [%s]", column, strings.Join(csvColumns, ","))
+		}
+	}
+	return nil
+}
+
+// lsCsvFormatter write the bugs as CSV, with a header line, for spreadsheets
+func lsCsvFormatter(env *Env, bugExcerpts []*cache.BugExcerpt, columns []string) error {
+	w := csv.NewWriter(env.out)
+
+	if err := w.Write(columns); err != nil {
+		return err
+	}
+
+	// the dates are written in a format recognized by the spreadsheets
+	const dateTime = "2006-01-02 15:04:05"
+
+	identities := func(ids []entity.Id) (string, error) {
+		names := make([]string, len(ids))
+		for i, id := range ids {
+			excerpt, err := env.backend.ResolveIdentityExcerpt(id)
 			if err != nil {
-				return err
+				return "", err
 			}
-			jsonBug.Actors[i] = NewJSONIdentityFromExcerpt(actor)
+			names[i] = excerpt.DisplayName()
 		}
+		return strings.Join(names, ", "), nil
+	}
+
+	for _, b := range bugExcerpts {
+		record := make([]string, len(columns))
+
+		for i, column := range columns {
+			var err error
+
+			switch column {
+			case "id":
+				record[i] = b.Id.String()
+			case "human-id":
+				record[i] = b.Id.Human()
+			case "title":
+				record[i] = strings.TrimSpace(b.Title)
+			case "status":
+				record[i] = b.Status.String()
+			case "labels":
+				labels := make([]string, len(b.Labels))
+				for j, l := range b.Labels {
+					labels[j] = l.String()
+				}
+				record[i] = strings.Join(labels, ", ")
+			case "author":
+				record[i], err = identities([]entity.Id{b.AuthorId})
+			case "assignees":
+				record[i], err = identities(b.AssigneeIds)
+			case "milestone":
+				record[i] = b.Milestone
+			case "create":
+				record[i] = b.CreateTime().Format(dateTime)
+			case "edit":
+				record[i] = b.EditTime().Format(dateTime)
+			case "comments":
+				// the first comment is the description
+				comments := b.LenComments - 1
+				if comments < 0 {
+					comments = 0
+				}
+				record[i] = strconv.Itoa(comments)
+			case "due":
+				if b.DueUnixTime != 0 {
+					record[i] = time.Unix(b.DueUnixTime, 0).Format("2006-01-02")
+				}
+			}
 
-		jsonBug.Participants = make([]JSONIdentity, len(b.Participants))
-		for i, element := range b.Participants {
-			participant, err := env.backend.ResolveIdentityExcerpt(element)
 			if err != nil {
 				return err
 			}
-			jsonBug.Participants[i] = NewJSONIdentityFromExcerpt(participant)
 		}
 
-		jsonBugs[i] = jsonBug
+		if err := w.Write(record); err != nil {
+			return err
+		}
 	}
-	jsonObject, _ := json.MarshalIndent(jsonBugs, "", "    ")
-	env.out.Printf("%s\n", jsonObject)
-	return nil
+
+	w.Flush()
+	return w.Error()
 }
 
 func lsDefaultFormatter(env *Env, bugExcerpts []*cache.BugExcerpt) error {
@@ -199,7 +420,11 @@ func lsDefaultFormatter(env *Env, bugExcerpts []*cache.BugExcerpt) error {
 
 		// truncate + pad if needed
 		labelsFmt := text.TruncateMax(labelsTxt.String(), 10)
-		titleFmt := text.LeftPadMaxLine(strings.TrimSpace(b.Title), 50-text.Len(labelsFmt), 0)
+		title := strings.TrimSpace(b.Title)
+		if b.Draft {
+			title = "[draft] " + title
+		}
+		titleFmt := text.LeftPadMaxLine(title, 50-text.Len(labelsFmt), 0)
 		authorFmt := text.LeftPadMaxLine(author.DisplayName(), 15, 0)
 
 		comments := fmt.Sprintf("%3d 💬", b.LenComments-1)
@@ -210,12 +435,18 @@ func lsDefaultFormatter(env *Env, bugExcerpts []*cache.BugExcerpt) error {
 			comments = "  ∞ 💬"
 		}
 
-		env.out.Printf("%s %s\t%s\t%s\t%s\n",
+		checklist := ""
+		if b.ChecklistTotal > 0 {
+			checklist = fmt.Sprintf(" %d/%d ☑", b.ChecklistDone, b.ChecklistTotal)
+		}
+
+		env.out.Printf("%s %s\t%s\t%s\t%s%s\n",
 			colors.Cyan(b.Id.Human()),
 			colors.Yellow(b.Status),
 			titleFmt+labelsFmt,
 			colors.Magenta(authorFmt),
 			comments,
+			checklist,
 		)
 	}
 	return nil
@@ -223,7 +454,11 @@ func lsDefaultFormatter(env *Env, bugExcerpts []*cache.BugExcerpt) error {
 
 func lsPlainFormatter(env *Env, bugExcerpts []*cache.BugExcerpt) error {
 	for _, b := range bugExcerpts {
-		env.out.Printf("%s [%s] %s\n", b.Id.Human(), b.Status, strings.TrimSpace(b.Title))
+		title := strings.TrimSpace(b.Title)
+		if b.Draft {
+			title = "[draft] " + title
+		}
+		env.out.Printf("%s [%s] %s\n", b.Id.Human(), b.Status, title)
 	}
 	return nil
 }
@@ -311,10 +546,14 @@ func lsOrgmodeFormatter(env *Env, bugExcerpts []*cache.BugExcerpt) error {
 func completeQuery(opts *lsOptions) error {
 	for _, str := range opts.statusQuery {
 		status, err := bug.StatusFromString(str)
-		if err != nil {
+		if err == nil {
+			opts.query.Status = append(opts.query.Status, status)
+			continue
+		}
+		if err := bug.ValidateExtendedStatusName(str); err != nil {
 			return err
 		}
-		opts.query.Status = append(opts.query.Status, status)
+		opts.query.ExtendedStatus = append(opts.query.ExtendedStatus, str)
 	}
 
 	for _, no := range opts.noQuery {
@@ -333,6 +572,12 @@ func completeQuery(opts *lsOptions) error {
 		opts.query.OrderBy = query.OrderByCreation
 	case "edit":
 		opts.query.OrderBy = query.OrderByEdit
+	case "comments":
+		opts.query.OrderBy = query.OrderByComments
+	case "priority":
+		opts.query.OrderBy = query.OrderByPriority
+	case "due":
+		opts.query.OrderBy = query.OrderByDueDate
 	default:
 		return fmt.Errorf("unknown sort flag %s", opts.sortBy)
 	}
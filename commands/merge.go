@@ -0,0 +1,59 @@
+package commands
+
+import (
+	"github.com/spf13/cobra"
+)
+
+type mergeOptions struct {
+	copyComments bool
+}
+
+func newMergeCommand() *cobra.Command {
+	env := newEnv()
+	options := mergeOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "merge DUPLICATE CANONICAL",
+		Short: "Close a bug as a duplicate of another one.",
+		Long: `Close a bug as a duplicate of another one.
+
+The duplicate is closed with a duplicate-of relation to the canonical bug, and is then hidden from
+the queries unless "with:duplicates" is used. Optionally, its comments are copied in the canonical bug.`,
+		Example:  `git bug merge 2f4a 8d1c --copy-comments`,
+		PreRunE:  loadBackendEnsureUser(env),
+		PostRunE: closeBackend(env),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runMerge(env, options, args)
+		},
+		Args: cobra.ExactArgs(2),
+	}
+
+	flags := cmd.Flags()
+	flags.SortFlags = false
+
+	flags.BoolVarP(&options.copyComments, "copy-comments", "c", false,
+		"Copy the comments of the duplicate in the canonical bug")
+
+	return cmd
+}
+
+func runMerge(env *Env, opts mergeOptions, args []string) error {
+	duplicate, err := env.backend.ResolveBugPrefix(args[0])
+	if err != nil {
+		return err
+	}
+
+	canonical, err := env.backend.ResolveBugPrefix(args[1])
+	if err != nil {
+		return err
+	}
+
+	err = env.backend.MergeDuplicate(duplicate, canonical, opts.copyComments)
+	if err != nil {
+		return err
+	}
+
+	env.out.Printf("%s merged into %s\n", duplicate.Id().Human(), canonical.Id().Human())
+
+	return nil
+}
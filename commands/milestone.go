@@ -0,0 +1,46 @@
+package commands
+
+import (
+	"github.com/spf13/cobra"
+
+	_select "github.com/MichaelMure/git-bug/commands/select"
+)
+
+func newMilestoneCommand() *cobra.Command {
+	env := newEnv()
+
+	cmd := &cobra.Command{
+		Use:               "milestone [ID]",
+		Short:             "Display or change the milestone of a bug, or manage the milestones.",
+		PreRunE:           loadBackend(env),
+		PostRunE:          closeBackend(env),
+		ValidArgsFunction: completeBug(env),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runMilestone(env, args)
+		},
+	}
+
+	cmd.AddCommand(newMilestoneLsCommand())
+	cmd.AddCommand(newMilestoneNewCommand())
+	cmd.AddCommand(newMilestoneSetCommand())
+
+	return cmd
+}
+
+func runMilestone(env *Env, args []string) error {
+	b, _, err := _select.ResolveBug(env.backend, args)
+	if err != nil {
+		return err
+	}
+
+	snap := b.Snapshot()
+
+	if snap.Milestone == "" {
+		env.out.Println("no milestone")
+		return nil
+	}
+
+	env.out.Println(snap.Milestone)
+
+	return nil
+}
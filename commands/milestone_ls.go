@@ -0,0 +1,85 @@
+package commands
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/spf13/cobra"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/util/colors"
+)
+
+func newMilestoneLsCommand() *cobra.Command {
+	env := newEnv()
+
+	cmd := &cobra.Command{
+		Use:   "ls",
+		Short: "List the milestones, with the number of open and total bugs.",
+		Long: `List the milestones, with the number of open and total bugs.
+
+Both the registered milestones and the ones set on bugs are listed.`,
+		PreRunE:  loadBackendReadOnly(env),
+		PostRunE: closeBackend(env),
+		Args:     cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runMilestoneLs(env)
+		},
+	}
+
+	return cmd
+}
+
+func runMilestoneLs(env *Env) error {
+	registry, err := env.backend.MilestoneRegistry()
+	if err != nil {
+		return err
+	}
+
+	open := make(map[string]int)
+	total := make(map[string]int)
+	for name := range registry {
+		total[name] = 0
+	}
+
+	for _, id := range env.backend.AllBugsIds() {
+		excerpt, err := env.backend.ResolveBugExcerpt(id)
+		if err != nil {
+			return err
+		}
+		if excerpt.Milestone == "" {
+			continue
+		}
+		total[excerpt.Milestone]++
+		if excerpt.Status == bug.OpenStatus {
+			open[excerpt.Milestone]++
+		}
+	}
+
+	names := make([]string, 0, len(total))
+	for name := range total {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		def := registry[name]
+
+		due := "-"
+		if !def.DueDate.IsZero() {
+			due = def.DueDate.Format(bug.FieldDateLayout)
+		}
+
+		env.out.Printf("%s\t%s\t%s",
+			colors.Cyan(name),
+			colors.Yellow(due),
+			colors.Blue(fmt.Sprintf("%d/%d open", open[name], total[name])),
+		)
+		if def.Description != "" {
+			env.out.Printf("\t%s", def.Description)
+		}
+		env.out.Println()
+	}
+
+	return nil
+}
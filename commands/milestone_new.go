@@ -0,0 +1,70 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/query"
+)
+
+type milestoneNewOptions struct {
+	due         string
+	description string
+}
+
+func newMilestoneNewCommand() *cobra.Command {
+	env := newEnv()
+	options := milestoneNewOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "new NAME",
+		Short: "Register a new milestone for this repository.",
+		Long: `Register a new milestone for this repository.
+
+The milestone registry is stored in the repository git config. The due date is either absolute, like 2024-10-01, or relative to now, like 6w.`,
+		Example:  `git bug milestone new v1.0 --due 2024-10-01 --description "first stable release"`,
+		PreRunE:  loadBackend(env),
+		PostRunE: closeBackend(env),
+		Args:     cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runMilestoneNew(env, options, args)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.SortFlags = false
+
+	flags.StringVar(&options.due, "due", "",
+		"The planned date of the milestone")
+	flags.StringVarP(&options.description, "description", "d", "",
+		"A short description of the milestone")
+
+	return cmd
+}
+
+func runMilestoneNew(env *Env, opts milestoneNewOptions, args []string) error {
+	registry, err := env.backend.MilestoneRegistry()
+	if err != nil {
+		return err
+	}
+
+	if _, ok := registry[args[0]]; ok {
+		return fmt.Errorf("milestone %s already exist", args[0])
+	}
+
+	def := bug.MilestoneDefinition{
+		Name:        args[0],
+		Description: opts.description,
+	}
+
+	if opts.due != "" {
+		def.DueDate, err = query.ParseDate(opts.due)
+		if err != nil {
+			return err
+		}
+	}
+
+	return env.backend.SetMilestoneDefinition(def)
+}
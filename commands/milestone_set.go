@@ -0,0 +1,52 @@
+package commands
+
+import (
+	"errors"
+
+	"github.com/spf13/cobra"
+
+	_select "github.com/MichaelMure/git-bug/commands/select"
+)
+
+func newMilestoneSetCommand() *cobra.Command {
+	env := newEnv()
+
+	cmd := &cobra.Command{
+		Use:   "set [ID] MILESTONE",
+		Short: "Change the milestone of a bug. An empty milestone remove it.",
+		Example: `git bug milestone set v1.0
+git bug milestone set 8f3a2c1 ""
+`,
+		PreRunE:           loadBackendEnsureUser(env),
+		PostRunE:          closeBackend(env),
+		ValidArgsFunction: completeBug(env),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runMilestoneSet(env, args)
+		},
+	}
+
+	return cmd
+}
+
+func runMilestoneSet(env *Env, args []string) error {
+	b, args, err := _select.ResolveBug(env.backend, args)
+	if err != nil {
+		return err
+	}
+
+	if len(args) != 1 {
+		return errors.New("a single milestone is expected")
+	}
+
+	if b.Snapshot().Milestone == args[0] {
+		env.out.Println("Nothing to change.")
+		return nil
+	}
+
+	_, err = b.SetMilestone(args[0])
+	if err != nil {
+		return err
+	}
+
+	return b.Commit()
+}
@@ -0,0 +1,40 @@
+package commands
+
+import (
+	"github.com/spf13/cobra"
+
+	_select "github.com/MichaelMure/git-bug/commands/select"
+)
+
+func newPublishCommand() *cobra.Command {
+	env := newEnv()
+
+	cmd := &cobra.Command{
+		Use:               "publish [ID]",
+		Short:             "Publish a draft bug, so that it get pushed like any other bug.",
+		PreRunE:           loadBackend(env),
+		PostRunE:          closeBackend(env),
+		ValidArgsFunction: completeBug(env),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runPublish(env, args)
+		},
+	}
+
+	return cmd
+}
+
+func runPublish(env *Env, args []string) error {
+	b, _, err := _select.ResolveBug(env.backend, args)
+	if err != nil {
+		return err
+	}
+
+	err = b.Publish()
+	if err != nil {
+		return err
+	}
+
+	env.out.Printf("%s published\n", b.Id().Human())
+
+	return nil
+}
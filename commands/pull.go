@@ -8,8 +8,13 @@ import (
 	"github.com/MichaelMure/git-bug/entity"
 )
 
+type pullOptions struct {
+	remote string
+}
+
 func newPullCommand() *cobra.Command {
 	env := newEnv()
+	options := pullOptions{}
 
 	cmd := &cobra.Command{
 		Use:      "pull [REMOTE]",
@@ -17,21 +22,31 @@ func newPullCommand() *cobra.Command {
 		PreRunE:  loadBackend(env),
 		PostRunE: closeBackend(env),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runPull(env, args)
+			return runPull(env, options, args)
 		},
 	}
 
+	flags := cmd.Flags()
+	flags.SortFlags = false
+
+	flags.StringVarP(&options.remote, "remote", "r", "",
+		"The git remote to pull from, the default one if not given")
+
 	return cmd
 }
 
-func runPull(env *Env, args []string) error {
+func runPull(env *Env, opts pullOptions, args []string) error {
 	if len(args) > 1 {
 		return errors.New("Only pulling from one remote at a time is supported")
 	}
 
-	remote := "origin"
-	if len(args) == 1 {
-		remote = args[0]
+	if len(args) == 1 && opts.remote != "" {
+		return errors.New("The remote can't be given both as argument and with --remote")
+	}
+
+	remote, err := resolveRemote(env, opts.remote, args)
+	if err != nil {
+		return err
 	}
 
 	env.out.Println("Fetching remote ...")
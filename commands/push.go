@@ -6,8 +6,13 @@ import (
 	"github.com/spf13/cobra"
 )
 
+type pushOptions struct {
+	remote string
+}
+
 func newPushCommand() *cobra.Command {
 	env := newEnv()
+	options := pushOptions{}
 
 	cmd := &cobra.Command{
 		Use:      "push [REMOTE]",
@@ -15,21 +20,31 @@ func newPushCommand() *cobra.Command {
 		PreRunE:  loadBackend(env),
 		PostRunE: closeBackend(env),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runPush(env, args)
+			return runPush(env, options, args)
 		},
 	}
 
+	flags := cmd.Flags()
+	flags.SortFlags = false
+
+	flags.StringVarP(&options.remote, "remote", "r", "",
+		"The git remote to push to, the default one if not given")
+
 	return cmd
 }
 
-func runPush(env *Env, args []string) error {
+func runPush(env *Env, opts pushOptions, args []string) error {
 	if len(args) > 1 {
 		return errors.New("Only pushing to one remote at a time is supported")
 	}
 
-	remote := "origin"
-	if len(args) == 1 {
-		remote = args[0]
+	if len(args) == 1 && opts.remote != "" {
+		return errors.New("The remote can't be given both as argument and with --remote")
+	}
+
+	remote, err := resolveRemote(env, opts.remote, args)
+	if err != nil {
+		return err
 	}
 
 	stdout, err := env.backend.Push(remote)
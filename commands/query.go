@@ -0,0 +1,59 @@
+package commands
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/spf13/cobra"
+
+	"github.com/MichaelMure/git-bug/query"
+)
+
+func newQueryCommand() *cobra.Command {
+	env := newEnv()
+
+	cmd := &cobra.Command{
+		Use:   "query",
+		Short: "List the saved queries.",
+		Long: `List the saved queries.
+
+A saved query can be used by name in place of a query, for example "git bug ls @triage", and combined with other filters: "git bug ls @triage label:ui".`,
+		PreRunE: loadRepo(env),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runQuery(env)
+		},
+		Args: cobra.NoArgs,
+	}
+
+	cmd.AddCommand(newQueryRmCommand())
+	cmd.AddCommand(newQuerySaveCommand())
+
+	return cmd
+}
+
+func runQuery(env *Env) error {
+	saved, err := query.ReadSavedQueries(env.repo.LocalConfig())
+	if err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(saved))
+	for name := range saved {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		env.out.Printf("@%s\t%s\n", name, saved[name])
+	}
+
+	return nil
+}
+
+// queryError show where an invalid query is wrong, below the error message
+func queryError(err error) error {
+	if perr, ok := err.(*query.ParseError); ok {
+		return fmt.Errorf("%w\n\n%s", err, perr.Pointer())
+	}
+	return err
+}
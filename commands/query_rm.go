@@ -0,0 +1,37 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/MichaelMure/git-bug/query"
+)
+
+func newQueryRmCommand() *cobra.Command {
+	env := newEnv()
+
+	cmd := &cobra.Command{
+		Use:     "rm NAME",
+		Short:   "Remove a saved query.",
+		PreRunE: loadRepo(env),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runQueryRm(env, args)
+		},
+		Args: cobra.ExactArgs(1),
+	}
+
+	return cmd
+}
+
+func runQueryRm(env *Env, args []string) error {
+	saved, err := query.ReadSavedQueries(env.repo.LocalConfig())
+	if err != nil {
+		return err
+	}
+	if _, ok := saved[args[0]]; !ok {
+		return fmt.Errorf("there is no saved query named %s", args[0])
+	}
+
+	return query.RemoveSavedQuery(env.repo.LocalConfig(), args[0])
+}
@@ -0,0 +1,32 @@
+package commands
+
+import (
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/MichaelMure/git-bug/query"
+)
+
+func newQuerySaveCommand() *cobra.Command {
+	env := newEnv()
+
+	cmd := &cobra.Command{
+		Use:   "save NAME QUERY",
+		Short: "Save a query under a name.",
+		Example: `Save the open bugs without label as the "triage" query:
+git bug query save triage "status:open no:label"
+`,
+		PreRunE: loadRepo(env),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runQuerySave(env, args)
+		},
+		Args: cobra.MinimumNArgs(2),
+	}
+
+	return cmd
+}
+
+func runQuerySave(env *Env, args []string) error {
+	return queryError(query.SaveQuery(env.repo.LocalConfig(), args[0], strings.Join(args[1:], " ")))
+}
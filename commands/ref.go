@@ -0,0 +1,151 @@
+package commands
+
+import (
+	"errors"
+
+	"github.com/spf13/cobra"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/cache"
+	_select "github.com/MichaelMure/git-bug/commands/select"
+)
+
+type refOptions struct {
+	role string
+}
+
+func newRefCommand() *cobra.Command {
+	env := newEnv()
+	options := refOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "ref [ID] [KIND TARGET]",
+		Short: "Display or add references from a bug to the code.",
+		Long: `Display or add references from a bug to the code.
+
+The kind can be one of:
+- commit: a commit, given as a hash or any git revision
+- file: a file, optionally with a line as path:line
+- branch: a branch name
+
+The role can be one of:
+- mentioned: the code is related to the bug
+- introduced-by: the bug has been introduced by this code
+- fixed-by: the bug has been fixed by this code
+`,
+		Example:           `git bug ref 2f4a commit HEAD --role fixed-by`,
+		PreRunE:           loadBackendEnsureUser(env),
+		PostRunE:          closeBackend(env),
+		ValidArgsFunction: completeBug(env),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runRef(env, options, args)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.SortFlags = false
+
+	flags.StringVarP(&options.role, "role", "r", "mentioned",
+		"How the code relate to the bug: mentioned, introduced-by or fixed-by")
+
+	cmd.AddCommand(newRefRmCommand())
+
+	return cmd
+}
+
+func runRef(env *Env, opts refOptions, args []string) error {
+	b, args, err := _select.ResolveBug(env.backend, args)
+	if err != nil {
+		return err
+	}
+
+	if len(args) == 0 {
+		for _, ref := range b.Snapshot().CodeRefs {
+			env.out.Println(ref)
+		}
+		return nil
+	}
+
+	ref, err := parseCodeRef(env.backend, opts.role, args)
+	if err != nil {
+		return err
+	}
+
+	_, err = b.AddCodeRef(ref)
+	if err != nil {
+		return err
+	}
+
+	return b.Commit()
+}
+
+func newRefRmCommand() *cobra.Command {
+	env := newEnv()
+	options := refOptions{}
+
+	cmd := &cobra.Command{
+		Use:               "rm [ID] KIND TARGET",
+		Short:             "Remove a reference from a bug to the code.",
+		PreRunE:           loadBackendEnsureUser(env),
+		PostRunE:          closeBackend(env),
+		ValidArgsFunction: completeBug(env),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runRefRm(env, options, args)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.SortFlags = false
+
+	flags.StringVarP(&options.role, "role", "r", "mentioned",
+		"How the code relate to the bug: mentioned, introduced-by or fixed-by")
+
+	return cmd
+}
+
+func runRefRm(env *Env, opts refOptions, args []string) error {
+	b, args, err := _select.ResolveBug(env.backend, args)
+	if err != nil {
+		return err
+	}
+
+	ref, err := parseCodeRef(env.backend, opts.role, args)
+	if err != nil {
+		return err
+	}
+
+	_, err = b.RemoveCodeRef(ref)
+	if err != nil {
+		return err
+	}
+
+	return b.Commit()
+}
+
+func parseCodeRef(backend *cache.RepoCache, rawRole string, args []string) (bug.CodeRef, error) {
+	if len(args) != 2 {
+		return bug.CodeRef{}, errors.New("you must provide a kind and a target")
+	}
+
+	kind, err := bug.CodeRefKindFromString(args[0])
+	if err != nil {
+		return bug.CodeRef{}, err
+	}
+
+	role, err := bug.CodeRefRoleFromString(rawRole)
+	if err != nil {
+		return bug.CodeRef{}, err
+	}
+
+	target := args[1]
+
+	// accept any revision for a local commit, but also plain hashes of
+	// commits not available locally
+	if kind == bug.CommitCodeRef {
+		if hash, err := backend.ResolveRevision(target); err == nil {
+			target = hash.String()
+		}
+	}
+
+	return bug.ParseCodeRef(kind, role, target)
+}
@@ -0,0 +1,119 @@
+package commands
+
+import (
+	"errors"
+
+	"github.com/spf13/cobra"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/cache"
+	_select "github.com/MichaelMure/git-bug/commands/select"
+	"github.com/MichaelMure/git-bug/util/colors"
+)
+
+func newRelateCommand() *cobra.Command {
+	env := newEnv()
+
+	cmd := &cobra.Command{
+		Use:   "relate [ID] [RELATION TARGET]",
+		Short: "Display or add relations between bugs.",
+		Long: `Display or add relations between bugs.
+
+The relation can be one of:
+- duplicate-of: the bug is a duplicate of the target, its status follow the target's one
+- related-to: the bug is related to the target
+- caused-by: the bug is caused by the target
+`,
+		Example:           `git bug relate 2f4a duplicate-of 8d1c`,
+		PreRunE:           loadBackendEnsureUser(env),
+		PostRunE:          closeBackend(env),
+		ValidArgsFunction: completeBug(env),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runRelate(env, args)
+		},
+	}
+
+	cmd.AddCommand(newRelateRmCommand())
+
+	return cmd
+}
+
+func runRelate(env *Env, args []string) error {
+	b, args, err := _select.ResolveBug(env.backend, args)
+	if err != nil {
+		return err
+	}
+
+	if len(args) == 0 {
+		for _, relation := range b.Snapshot().Relations {
+			env.out.Printf("%s %s\n", relation.Type, colors.Cyan(relation.Target.Human()))
+		}
+		return nil
+	}
+
+	relation, target, err := parseRelation(env.backend, args)
+	if err != nil {
+		return err
+	}
+
+	_, err = b.Relate(relation, target.Id)
+	if err != nil {
+		return err
+	}
+
+	return b.Commit()
+}
+
+func newRelateRmCommand() *cobra.Command {
+	env := newEnv()
+
+	cmd := &cobra.Command{
+		Use:               "rm [ID] RELATION TARGET",
+		Short:             "Remove a relation between bugs.",
+		PreRunE:           loadBackendEnsureUser(env),
+		PostRunE:          closeBackend(env),
+		ValidArgsFunction: completeBug(env),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runRelateRm(env, args)
+		},
+	}
+
+	return cmd
+}
+
+func runRelateRm(env *Env, args []string) error {
+	b, args, err := _select.ResolveBug(env.backend, args)
+	if err != nil {
+		return err
+	}
+
+	relation, target, err := parseRelation(env.backend, args)
+	if err != nil {
+		return err
+	}
+
+	_, err = b.Unrelate(relation, target.Id)
+	if err != nil {
+		return err
+	}
+
+	return b.Commit()
+}
+
+func parseRelation(backend *cache.RepoCache, args []string) (bug.RelationType, *cache.BugExcerpt, error) {
+	if len(args) != 2 {
+		return 0, nil, errors.New("you must provide a relation and a target bug")
+	}
+
+	relation, err := bug.RelationTypeFromString(args[0])
+	if err != nil {
+		return 0, nil, err
+	}
+
+	target, err := backend.ResolveBugExcerptPrefix(args[1])
+	if err != nil {
+		return 0, nil, err
+	}
+
+	return relation, target, nil
+}
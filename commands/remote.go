@@ -0,0 +1,53 @@
+package commands
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func newRemoteCommand() *cobra.Command {
+	env := newEnv()
+
+	cmd := &cobra.Command{
+		Use:   "remote [REMOTE]",
+		Short: "Display or change the default git remote to push and pull the bugs.",
+		Long: `Display or change the default git remote to push and pull the bugs.
+
+The default remote is "origin", unless configured otherwise. It's stored in the "git-bug.remote" key of the
+repository configuration.`,
+		Example:  `git bug remote upstream`,
+		PreRunE:  loadBackend(env),
+		PostRunE: closeBackend(env),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runRemote(env, args)
+		},
+		Args: cobra.MaximumNArgs(1),
+	}
+
+	return cmd
+}
+
+func runRemote(env *Env, args []string) error {
+	if len(args) == 0 {
+		remote, err := env.backend.DefaultRemote()
+		if err != nil {
+			return err
+		}
+		env.out.Println(remote)
+		return nil
+	}
+
+	return env.backend.SetDefaultRemote(args[0])
+}
+
+// resolveRemote return the remote given as argument or with the --remote
+// flag, or the default one
+func resolveRemote(env *Env, flag string, args []string) (string, error) {
+	switch {
+	case len(args) == 1:
+		return args[0], nil
+	case flag != "":
+		return flag, nil
+	default:
+		return env.backend.DefaultRemote()
+	}
+}
@@ -0,0 +1,60 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	_select "github.com/MichaelMure/git-bug/commands/select"
+)
+
+func newRevertCommand() *cobra.Command {
+	env := newEnv()
+
+	cmd := &cobra.Command{
+		Use:   "revert [ID] OPERATION_ID",
+		Short: "Revert an operation of a bug.",
+		Long: `Append a new operation compensating the effect of a previous one, for example re-opening a bug closed by mistake or removing the labels added by a label change.
+
+The reverted operation stays in the history. Creations, comments and metadata can't be reverted.`,
+		Example:           `git bug revert 2f4a 8d1c`,
+		PreRunE:           loadBackendEnsureUser(env),
+		PostRunE:          closeBackend(env),
+		ValidArgsFunction: completeBug(env),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runRevert(env, args)
+		},
+	}
+
+	return cmd
+}
+
+func runRevert(env *Env, args []string) error {
+	b, args, err := _select.ResolveBug(env.backend, args)
+	if err != nil {
+		return err
+	}
+
+	if len(args) != 1 {
+		return fmt.Errorf("a single operation id is expected")
+	}
+
+	target, err := b.ResolveOperationPrefix(args[0])
+	if err != nil {
+		return err
+	}
+
+	op, err := b.Revert(target)
+	if err != nil {
+		return err
+	}
+
+	err = b.Commit()
+	if err != nil {
+		return err
+	}
+
+	env.out.Printf("%s reverted by %s\n", target.Human(), op.Id().Human())
+
+	return nil
+}
@@ -0,0 +1,55 @@
+package commands
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/MichaelMure/git-bug/util/colors"
+)
+
+func newReviewCommand() *cobra.Command {
+	env := newEnv()
+
+	cmd := &cobra.Command{
+		Use:      "review",
+		Short:    "List code reviews.",
+		PreRunE:  loadBackend(env),
+		PostRunE: closeBackend(env),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runReview(env)
+		},
+		Args: cobra.NoArgs,
+	}
+
+	cmd.AddCommand(newReviewCommentCommand())
+	cmd.AddCommand(newReviewNewCommand())
+	cmd.AddCommand(newReviewShowCommand())
+	cmd.AddCommand(newReviewStatusCommand())
+	cmd.AddCommand(newReviewUpdateCommand())
+
+	return cmd
+}
+
+func runReview(env *Env) error {
+	ids, err := env.backend.AllReviewIds()
+	if err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		r, err := env.backend.ResolveReview(id)
+		if err != nil {
+			return err
+		}
+
+		snap := r.Snapshot()
+
+		env.out.Printf("%s %s\t%s\t%s\n",
+			colors.Cyan(id.Human()),
+			colors.Yellow(snap.Status),
+			snap.Title,
+			colors.Magenta(snap.Author.DisplayName()),
+		)
+	}
+
+	return nil
+}
@@ -0,0 +1,126 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/MichaelMure/git-bug/input"
+	"github.com/MichaelMure/git-bug/review"
+)
+
+type reviewCommentOptions struct {
+	messageFile    string
+	message        string
+	path           string
+	line           int
+	commit         string
+	approve        bool
+	requestChanges bool
+}
+
+func newReviewCommentCommand() *cobra.Command {
+	env := newEnv()
+	options := reviewCommentOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "comment REVIEW",
+		Short: "Comment on a code review.",
+		Long: `Comment on a code review.
+
+With --path, the comment is anchored on a file, and with --line on a line of this file,
+as of the head of the review unless --commit is given.`,
+		Example:  `git bug review comment 8d1c --path query/parser.go --line 12 -m "this could overflow"`,
+		PreRunE:  loadBackendEnsureUser(env),
+		PostRunE: closeBackend(env),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runReviewComment(env, options, args)
+		},
+		Args: cobra.ExactArgs(1),
+	}
+
+	flags := cmd.Flags()
+	flags.SortFlags = false
+
+	flags.StringVarP(&options.messageFile, "file", "F", "",
+		"Take the message from the given file. Use - to read the message from the standard input")
+	flags.StringVarP(&options.message, "message", "m", "",
+		"Provide the message from the command line")
+	flags.StringVarP(&options.path, "path", "p", "",
+		"Anchor the comment on this file")
+	flags.IntVarP(&options.line, "line", "l", 0,
+		"Anchor the comment on this line of the file")
+	flags.StringVarP(&options.commit, "commit", "c", "",
+		"The revision the file and line refer to")
+	flags.BoolVar(&options.approve, "approve", false,
+		"Approve the changes")
+	flags.BoolVar(&options.requestChanges, "request-changes", false,
+		"Request changes before approval")
+
+	return cmd
+}
+
+func runReviewComment(env *Env, opts reviewCommentOptions, args []string) error {
+	if opts.approve && opts.requestChanges {
+		return fmt.Errorf("--approve and --request-changes are mutually exclusive")
+	}
+
+	if opts.path == "" && (opts.line != 0 || opts.commit != "") {
+		return fmt.Errorf("--line and --commit require --path")
+	}
+
+	r, err := env.backend.ResolveReviewPrefix(args[0])
+	if err != nil {
+		return err
+	}
+
+	verdict := review.NoVerdict
+	switch {
+	case opts.approve:
+		verdict = review.ApproveVerdict
+	case opts.requestChanges:
+		verdict = review.RequestChangesVerdict
+	}
+
+	var location *review.Location
+	if opts.path != "" {
+		commit := r.Snapshot().Head
+		if opts.commit != "" {
+			commit, err = env.backend.ResolveRevision(opts.commit)
+			if err != nil {
+				return fmt.Errorf("unknown revision %s", opts.commit)
+			}
+		}
+
+		location = &review.Location{
+			Commit: commit,
+			Path:   opts.path,
+			Line:   opts.line,
+		}
+	}
+
+	if opts.messageFile != "" && opts.message == "" {
+		opts.message, err = input.BugCommentFileInput(opts.messageFile)
+		if err != nil {
+			return err
+		}
+	}
+
+	if opts.messageFile == "" && opts.message == "" && verdict == review.NoVerdict {
+		opts.message, err = input.BugCommentEditorInput(env.backend, "")
+		if err == input.ErrEmptyMessage {
+			env.err.Println("Empty message, aborting.")
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = r.AddComment(opts.message, location, verdict)
+	if err != nil {
+		return err
+	}
+
+	return r.Commit()
+}
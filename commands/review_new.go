@@ -0,0 +1,87 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/MichaelMure/git-bug/input"
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+type reviewNewOptions struct {
+	messageFile string
+	message     string
+}
+
+func newReviewNewCommand() *cobra.Command {
+	env := newEnv()
+	options := reviewNewOptions{}
+
+	cmd := &cobra.Command{
+		Use:      "new BASE..HEAD TITLE",
+		Short:    "Start a code review of a range of commits.",
+		Example:  `git bug review new master..feature "Add the frobnicator"`,
+		PreRunE:  loadBackendEnsureUser(env),
+		PostRunE: closeBackend(env),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runReviewNew(env, options, args)
+		},
+		Args: cobra.ExactArgs(2),
+	}
+
+	flags := cmd.Flags()
+	flags.SortFlags = false
+
+	flags.StringVarP(&options.messageFile, "file", "F", "",
+		"Take the description from the given file. Use - to read the description from the standard input")
+
+	flags.StringVarP(&options.message, "message", "m", "",
+		"Provide a description from the command line")
+
+	return cmd
+}
+
+func runReviewNew(env *Env, opts reviewNewOptions, args []string) error {
+	base, head, err := resolveRange(env, args[0])
+	if err != nil {
+		return err
+	}
+
+	if opts.messageFile != "" && opts.message == "" {
+		opts.message, err = input.BugCommentFileInput(opts.messageFile)
+		if err != nil {
+			return err
+		}
+	}
+
+	r, err := env.backend.NewReview(args[1], opts.message, base, head)
+	if err != nil {
+		return err
+	}
+
+	env.out.Printf("%s created\n", r.Id().Human())
+
+	return nil
+}
+
+// resolveRange resolve a BASE..HEAD git revision range into commit hashes
+func resolveRange(env *Env, rangeStr string) (repository.Hash, repository.Hash, error) {
+	split := strings.Split(rangeStr, "..")
+	if len(split) != 2 || split[0] == "" || split[1] == "" {
+		return "", "", fmt.Errorf("invalid range %s, expected BASE..HEAD", rangeStr)
+	}
+
+	base, err := env.backend.ResolveRevision(split[0])
+	if err != nil {
+		return "", "", fmt.Errorf("unknown revision %s", split[0])
+	}
+
+	head, err := env.backend.ResolveRevision(split[1])
+	if err != nil {
+		return "", "", fmt.Errorf("unknown revision %s", split[1])
+	}
+
+	return base, head, nil
+}
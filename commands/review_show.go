@@ -0,0 +1,86 @@
+package commands
+
+import (
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/spf13/cobra"
+
+	"github.com/MichaelMure/git-bug/review"
+	"github.com/MichaelMure/git-bug/util/colors"
+)
+
+func newReviewShowCommand() *cobra.Command {
+	env := newEnv()
+
+	cmd := &cobra.Command{
+		Use:      "show REVIEW",
+		Short:    "Display the details and discussion of a code review.",
+		PreRunE:  loadBackend(env),
+		PostRunE: closeBackend(env),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runReviewShow(env, args)
+		},
+		Args: cobra.ExactArgs(1),
+	}
+
+	return cmd
+}
+
+func runReviewShow(env *Env, args []string) error {
+	r, err := env.backend.ResolveReviewPrefix(args[0])
+	if err != nil {
+		return err
+	}
+
+	snap := r.Snapshot()
+
+	env.out.Printf("%s [%s] %s\n\n",
+		colors.Cyan(snap.Id().Human()),
+		colors.Yellow(snap.Status),
+		snap.Title,
+	)
+
+	env.out.Printf("%s opened this review %s\n",
+		colors.Magenta(snap.Author.DisplayName()),
+		humanize.Time(snap.CreateTime),
+	)
+
+	env.out.Printf("range: %s\n", snap.Range())
+
+	for id, verdict := range snap.Verdicts() {
+		reviewer, err := env.backend.ResolveIdentityExcerpt(id)
+		if err != nil {
+			return err
+		}
+		env.out.Printf("%s: %s\n", reviewer.DisplayName(), verdict)
+	}
+
+	if snap.Description != "" {
+		env.out.Printf("\n%s\n", snap.Description)
+	}
+
+	for _, comment := range snap.Comments {
+		env.out.Printf("\n%s %s",
+			colors.Magenta(comment.Author.DisplayName()),
+			humanize.Time(time.Unix(comment.UnixTime, 0)),
+		)
+
+		if comment.Location != nil {
+			env.out.Printf(" on %s", colors.Cyan(comment.Location))
+		}
+
+		if comment.Verdict != review.NoVerdict {
+			env.out.Printf(" (%s)", colors.Yellow(comment.Verdict))
+		}
+
+		env.out.Println()
+
+		if comment.Message != "" {
+			env.out.Printf("  %s\n", strings.ReplaceAll(comment.Message, "\n", "\n  "))
+		}
+	}
+
+	return nil
+}
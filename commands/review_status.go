@@ -0,0 +1,51 @@
+package commands
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/MichaelMure/git-bug/review"
+)
+
+func newReviewStatusCommand() *cobra.Command {
+	env := newEnv()
+
+	cmd := &cobra.Command{
+		Use:   "status REVIEW [STATUS]",
+		Short: "Display or change the status of a code review.",
+		Long: `Display or change the status of a code review.
+
+The status can be "open", "merged" or "abandoned".`,
+		PreRunE:  loadBackendEnsureUser(env),
+		PostRunE: closeBackend(env),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runReviewStatus(env, args)
+		},
+		Args: cobra.RangeArgs(1, 2),
+	}
+
+	return cmd
+}
+
+func runReviewStatus(env *Env, args []string) error {
+	r, err := env.backend.ResolveReviewPrefix(args[0])
+	if err != nil {
+		return err
+	}
+
+	if len(args) == 1 {
+		env.out.Println(r.Snapshot().Status)
+		return nil
+	}
+
+	status, err := review.StatusFromString(args[1])
+	if err != nil {
+		return err
+	}
+
+	_, err = r.SetStatus(status)
+	if err != nil {
+		return err
+	}
+
+	return r.Commit()
+}
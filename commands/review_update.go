@@ -0,0 +1,41 @@
+package commands
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func newReviewUpdateCommand() *cobra.Command {
+	env := newEnv()
+
+	cmd := &cobra.Command{
+		Use:      "update REVIEW BASE..HEAD",
+		Short:    "Change the range of commits of a code review, after an amend or a rebase.",
+		PreRunE:  loadBackendEnsureUser(env),
+		PostRunE: closeBackend(env),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runReviewUpdate(env, args)
+		},
+		Args: cobra.ExactArgs(2),
+	}
+
+	return cmd
+}
+
+func runReviewUpdate(env *Env, args []string) error {
+	r, err := env.backend.ResolveReviewPrefix(args[0])
+	if err != nil {
+		return err
+	}
+
+	base, head, err := resolveRange(env, args[1])
+	if err != nil {
+		return err
+	}
+
+	_, err = r.UpdateRevision(base, head)
+	if err != nil {
+		return err
+	}
+
+	return r.Commit()
+}
@@ -8,25 +8,53 @@ import (
 
 func newRmCommand() *cobra.Command {
 	env := newEnv()
+	options := bulkOptions{}
 
 	cmd := &cobra.Command{
-		Use:      "rm ID",
-		Short:    "Remove an existing bug.",
-		Long:     "Remove an existing bug in the local repository. Note removing bugs that were imported from bridges will not remove the bug on the remote, and will only remove the local copy of the bug.",
-		PreRunE:  loadBackendEnsureUser(env),
-		PostRunE: closeBackend(env),
+		Use:   "rm [ID]",
+		Short: "Remove an existing bug.",
+		Long: `Remove an existing bug in the local repository. Note removing bugs that were imported from bridges will not remove the bug on the remote, and will only remove the local copy of the bug.
+
+The removed bugs are moved to the trash, from where they can be restored with "git bug trash restore", or definitely removed with "git bug trash purge".`,
+		Example: `Remove all the bugs labeled spam, after confirmation:
+git bug rm --query 'label:spam'
+`,
+		PreRunE:           loadBackendEnsureUser(env),
+		PostRunE:          closeBackend(env),
+		ValidArgsFunction: completeBug(env),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runRm(env, args)
+			return runRm(env, options, args)
 		},
 	}
 
 	flags := cmd.Flags()
 	flags.SortFlags = false
 
+	addBulkFlags(flags, &options)
+
 	return cmd
 }
 
-func runRm(env *Env, args []string) (err error) {
+func runRm(env *Env, opts bulkOptions, args []string) (err error) {
+	if opts.query != "" {
+		bugs, err := resolveBulk(env, opts, "Move to the trash")
+		if err != nil {
+			return err
+		}
+
+		for _, b := range bugs {
+			if err := env.backend.RemoveBug(b.Id().String()); err != nil {
+				return err
+			}
+		}
+
+		if len(bugs) > 0 {
+			env.out.Printf("%d bug(s) moved to the trash\n", len(bugs))
+		}
+
+		return nil
+	}
+
 	if len(args) == 0 {
 		return errors.New("you must provide a bug prefix to remove")
 	}
@@ -37,7 +65,7 @@ func runRm(env *Env, args []string) (err error) {
 		return
 	}
 
-	env.out.Printf("bug %s removed\n", args[0])
+	env.out.Printf("bug %s moved to the trash\n", args[0])
 
 	return
 }
@@ -25,7 +25,12 @@ git-bug use git objects to store the bug tracking separated from the files
 history. As bugs are regular git objects, they can be pushed and pulled from/to
 the same git remote you are already using to collaborate with other people.
 
-`,
+Like with git, aliases can be defined in the git config. The remaining arguments
+are appended to the alias definition:
+git config git-bug.alias.triage "ls status:open sort:edit no:label"
+git bug triage
+
+` + exitCodesHelp,
 
 		PersistentPreRun: func(cmd *cobra.Command, args []string) {
 			root := cmd.Root()
@@ -49,6 +54,7 @@ the same git remote you are already using to collaborate with other people.
 		},
 
 		SilenceUsage:      true,
+		SilenceErrors:     true,
 		DisableAutoGenTag: true,
 
 		// Custom bash code to connect the git completion for "git bug" to the
@@ -60,32 +66,89 @@ _git_bug() {
 `,
 	}
 
+	cmd.SetFlagErrorFunc(flagError)
+
+	cmd.PersistentFlags().String("format", "default",
+		"Select the output formatting style. With json, the errors are written as JSON on the standard error")
+	cmd.PersistentFlags().StringVar(&repoFlag, "repo", "",
+		"Path of the git repository to use, bare or not, instead of GIT_DIR or the current directory")
+
 	cmd.AddCommand(newAddCommand())
+	cmd.AddCommand(newAssignCommand())
+	cmd.AddCommand(newBoardCommand())
 	cmd.AddCommand(newBridgeCommand())
+	cmd.AddCommand(newBundleCommand())
+	cmd.AddCommand(newCacheCommand())
+	cmd.AddCommand(newChecklistCommand())
+	cmd.AddCommand(newCheckoutCommand())
 	cmd.AddCommand(newCommandsCommand())
 	cmd.AddCommand(newCommentCommand())
+	cmd.AddCommand(newDaemonCommand())
 	cmd.AddCommand(newDeselectCommand())
+	cmd.AddCommand(newDiffCommand())
+	cmd.AddCommand(newDueCommand())
+	cmd.AddCommand(newEditCommand())
+	cmd.AddCommand(newEstimateCommand())
+	cmd.AddCommand(newExportCommand())
+	cmd.AddCommand(newFieldCommand())
+	cmd.AddCommand(newFsckCommand())
+	cmd.AddCommand(newHookCommand())
+	cmd.AddCommand(newImportCommand())
+	cmd.AddCommand(newInstallHooksCommand())
 	cmd.AddCommand(newLabelCommand())
 	cmd.AddCommand(newLsCommand())
 	cmd.AddCommand(newLsIdCommand())
 	cmd.AddCommand(newLsLabelCommand())
+	cmd.AddCommand(newMergeCommand())
+	cmd.AddCommand(newMilestoneCommand())
+	cmd.AddCommand(newPublishCommand())
 	cmd.AddCommand(newPullCommand())
 	cmd.AddCommand(newPushCommand())
+	cmd.AddCommand(newQueryCommand())
+	cmd.AddCommand(newRefCommand())
+	cmd.AddCommand(newRelateCommand())
+	cmd.AddCommand(newRemoteCommand())
+	cmd.AddCommand(newRevertCommand())
+	cmd.AddCommand(newReviewCommand())
 	cmd.AddCommand(newRmCommand())
+	cmd.AddCommand(newSearchCommand())
 	cmd.AddCommand(newSelectCommand())
 	cmd.AddCommand(newShowCommand())
+	cmd.AddCommand(newSpendCommand())
 	cmd.AddCommand(newStatusCommand())
+	cmd.AddCommand(newSubscribeCommand())
+	cmd.AddCommand(newSweepCommand())
+	cmd.AddCommand(newTeamCommand())
 	cmd.AddCommand(newTermUICommand())
 	cmd.AddCommand(newTitleCommand())
+	cmd.AddCommand(newTrashCommand())
+	cmd.AddCommand(newUnassignCommand())
+	cmd.AddCommand(newUnsubscribeCommand())
 	cmd.AddCommand(newUserCommand())
+	cmd.AddCommand(newVerifyCommand())
 	cmd.AddCommand(newVersionCommand())
+	cmd.AddCommand(newWatchCommand())
 	cmd.AddCommand(newWebUICommand())
+	cmd.AddCommand(newWebhookCommand())
+	cmd.AddCommand(newWorkspaceCommand())
 
 	return cmd
 }
 
 func Execute() {
-	if err := NewRootCommand().Execute(); err != nil {
-		os.Exit(1)
+	root := NewRootCommand()
+
+	args, err := expandAliases(root, os.Args[1:])
+	if err != nil {
+		os.Exit(handleError(os.Stderr, nil, err))
+	}
+	root.SetArgs(args)
+
+	cmd, err := root.ExecuteC()
+	if byDaemon, ok := err.(errRunByDaemon); ok {
+		os.Exit(byDaemon.code)
+	}
+	if err != nil {
+		os.Exit(handleError(os.Stderr, cmd, err))
 	}
 }
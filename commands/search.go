@@ -0,0 +1,98 @@
+package commands
+
+import (
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/util/colors"
+)
+
+type searchOptions struct {
+	limit int
+}
+
+func newSearchCommand() *cobra.Command {
+	env := newEnv()
+	options := searchOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "search TEXT...",
+		Short: "Search the bugs with a full-text search.",
+		Long: `Search the title and the comments of the bugs with a full-text search.
+
+The bugs containing all the words are displayed, the most relevant first, with an excerpt of each
+matching comment. The search is backed by the index of the cache and doesn't need to read the bugs.`,
+		Example:  `git bug search "panic in parser"`,
+		PreRunE:  loadBackendReadOnly(env),
+		PostRunE: closeBackend(env),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runSearch(env, options, args)
+		},
+		Args: cobra.MinimumNArgs(1),
+	}
+
+	flags := cmd.Flags()
+	flags.SortFlags = false
+
+	flags.IntVarP(&options.limit, "limit", "n", 0,
+		"Only display the given number of bugs")
+
+	return cmd
+}
+
+func runSearch(env *Env, opts searchOptions, args []string) error {
+	text := strings.Join(args, " ")
+
+	ids := env.backend.SearchBugs(text)
+	if len(ids) == 0 {
+		env.out.Println("No bug matching the search.")
+		return nil
+	}
+
+	if opts.limit > 0 && len(ids) > opts.limit {
+		ids = ids[:opts.limit]
+	}
+
+	for _, id := range ids {
+		excerpt, err := env.backend.ResolveBugExcerpt(id)
+		if err != nil {
+			return err
+		}
+
+		title := strings.TrimSpace(excerpt.Title)
+
+		env.out.Printf("%s %s\t%s\n",
+			colors.Cyan(excerpt.Id.Human()),
+			colors.Yellow(excerpt.Status),
+			highlight(title, cache.SearchHighlights(title, text)),
+		)
+
+		snippets, err := env.backend.SearchSnippets(id, text)
+		if err != nil {
+			return err
+		}
+
+		for _, snippet := range snippets {
+			env.out.Printf("    #%d: %s\n", snippet.Comment, highlight(snippet.Text, snippet.Highlights))
+		}
+	}
+
+	return nil
+}
+
+// highlight emphasize the given byte ranges of a text
+func highlight(text string, ranges [][2]int) string {
+	var sb strings.Builder
+
+	last := 0
+	for _, r := range ranges {
+		sb.WriteString(text[last:r[0]])
+		sb.WriteString(colors.YellowBold(text[r[0]:r[1]]))
+		last = r[1]
+	}
+	sb.WriteString(text[last:])
+
+	return sb.String()
+}
@@ -27,8 +27,9 @@ instead of
 
 The complementary command is "git bug deselect" performing the opposite operation.
 `,
-		PreRunE:  loadBackend(env),
-		PostRunE: closeBackend(env),
+		PreRunE:           loadBackend(env),
+		PostRunE:          closeBackend(env),
+		ValidArgsFunction: completeBug(env),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runSelect(env, args)
 		},
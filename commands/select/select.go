@@ -7,12 +7,14 @@ import (
 	"os"
 	"path"
 
+	"github.com/mattn/go-isatty"
 	"github.com/pkg/errors"
 
 	"github.com/MichaelMure/git-bug/bug"
 	"github.com/MichaelMure/git-bug/cache"
 	"github.com/MichaelMure/git-bug/entity"
 	"github.com/MichaelMure/git-bug/repository"
+	"github.com/MichaelMure/git-bug/termui"
 )
 
 const selectFile = "select"
@@ -20,7 +22,8 @@ const selectFile = "select"
 var ErrNoValidId = errors.New("you must provide a bug id or use the \"select\" command first")
 
 // ResolveBug first try to resolve a bug using the first argument of the command
-// line. If it fails, it fallback to the select mechanism.
+// line. If it fails, it fallback to the select mechanism, and then to an
+// interactive fuzzy finder if running in a terminal.
 //
 // Returns:
 // - the bug if any
@@ -65,10 +68,61 @@ func ResolveBug(repo *cache.RepoCache, args []string) (*cache.BugCache, []string
 		return b, args, nil
 	}
 
-	// no selected bug and no valid first argument
+	// no selected bug and no valid first argument, let the user pick one if
+	// we are in an interactive terminal
+	if isatty.IsTerminal(os.Stdin.Fd()) && isatty.IsTerminal(os.Stdout.Fd()) {
+		id, err := termui.PickBug(repo)
+		if err != nil {
+			return nil, nil, err
+		}
+		if id != "" {
+			b, err := repo.ResolveBug(id)
+			if err != nil {
+				return nil, nil, err
+			}
+			return b, args, nil
+		}
+	}
+
 	return nil, nil, ErrNoValidId
 }
 
+// ResolveBugs resolve the leading arguments of the command line as bug
+// prefixes, all of them before returning. If none is a bug prefix, it
+// fallback to ResolveBug.
+//
+// Returns:
+// - the bugs
+// - the remaining command line arguments, starting at the first one that
+//   is not a bug prefix
+// - an error if the process failed, for instance for an ambiguous prefix
+func ResolveBugs(repo *cache.RepoCache, args []string) ([]*cache.BugCache, []string, error) {
+	var bugs []*cache.BugCache
+
+	for len(args) > 0 {
+		b, err := repo.ResolveBugPrefix(args[0])
+		if err == bug.ErrBugNotExist {
+			break
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		bugs = append(bugs, b)
+		args = args[1:]
+	}
+
+	if len(bugs) > 0 {
+		return bugs, args, nil
+	}
+
+	b, args, err := ResolveBug(repo, args)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return []*cache.BugCache{b}, args, nil
+}
+
 // Select will select a bug for future use
 func Select(repo *cache.RepoCache, id entity.Id) error {
 	selectPath := selectFilePath(repo)
@@ -4,18 +4,28 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"os"
+	"sort"
 	"strings"
 
+	"github.com/fatih/color"
+	"github.com/mattn/go-isatty"
 	"github.com/spf13/cobra"
+	"golang.org/x/crypto/ssh/terminal"
 
 	"github.com/MichaelMure/git-bug/bug"
 	_select "github.com/MichaelMure/git-bug/commands/select"
+	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/repository"
 	"github.com/MichaelMure/git-bug/util/colors"
+	"github.com/MichaelMure/git-bug/util/markdown"
 )
 
 type showOptions struct {
-	fields string
-	format string
+	fields   string
+	format   string
+	template string
+	markdown string
 }
 
 func newShowCommand() *cobra.Command {
@@ -23,10 +33,14 @@ func newShowCommand() *cobra.Command {
 	options := showOptions{}
 
 	cmd := &cobra.Command{
-		Use:      "show [ID]",
-		Short:    "Display the details of a bug.",
-		PreRunE:  loadBackend(env),
-		PostRunE: closeBackend(env),
+		Use:   "show [ID]",
+		Short: "Display the details of a bug.",
+		Example: `Display the title and the labels of a bug with a custom format:
+git bug show 2f15 --format template --template '{{.Title}}{{range .Labels}} #{{.}}{{end}}'
+`,
+		PreRunE:           loadBackendReadOnly(env),
+		PostRunE:          closeBackend(env),
+		ValidArgsFunction: completeBug(env),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runShow(env, options, args)
 		},
@@ -36,9 +50,13 @@ func newShowCommand() *cobra.Command {
 	flags.SortFlags = false
 
 	flags.StringVarP(&options.fields, "field", "", "",
-		"Select field to display. Valid values are [author,authorEmail,createTime,lastEdit,humanId,id,labels,shortId,status,title,actors,participants]")
+		"Select field to display. Valid values are [author,authorEmail,createTime,lastEdit,humanId,id,labels,shortId,status,title,actors,participants,subscribers]")
 	flags.StringVarP(&options.format, "format", "f", "default",
-		"Select the output formatting style. Valid values are [default,json,org-mode]")
+		"Select the output formatting style. Valid values are [default,json,ndjson,org-mode,template]")
+	flags.StringVar(&options.template, "template", "",
+		"Go template used to display the bug with --format template")
+	flags.StringVar(&options.markdown, "markdown", "auto",
+		"Render the markdown of the comments with the default format. Valid values are [auto,always,never], auto rendering only in a terminal")
 
 	return cmd
 }
@@ -81,10 +99,14 @@ func runShow(env *Env, opts showOptions, args []string) error {
 			for _, p := range snap.Participants {
 				env.out.Printf("%s\n", p.DisplayName())
 			}
+		case "subscribers":
+			for _, s := range snap.Subscribers {
+				env.out.Printf("%s\n", s.DisplayName())
+			}
 		case "shortId":
 			env.out.Printf("%s\n", snap.Id().Human())
 		case "status":
-			env.out.Printf("%s\n", snap.Status)
+			env.out.Printf("%s\n", snap.DisplayStatus())
 		case "title":
 			env.out.Printf("%s\n", snap.Title)
 		default:
@@ -98,19 +120,39 @@ func runShow(env *Env, opts showOptions, args []string) error {
 	case "org-mode":
 		return showOrgModeFormatter(env, snap)
 	case "json":
-		return showJsonFormatter(env, snap)
+		signatures, err := b.Signatures()
+		if err != nil {
+			return err
+		}
+		return showJsonFormatter(env, snap, signatures)
+	case "ndjson":
+		return showNDJSONFormatter(env, snap)
+	case "template":
+		tmpl, err := parseOutputTemplate(opts.template)
+		if err != nil {
+			return err
+		}
+		return executeOutputTemplate(env, tmpl, snap)
 	case "default":
-		return showDefaultFormatter(env, snap)
+		signatures, err := b.Signatures()
+		if err != nil {
+			return err
+		}
+		render, err := markdownRendering(opts.markdown)
+		if err != nil {
+			return err
+		}
+		return showDefaultFormatter(env, snap, signatures, b.Recipients(), render)
 	default:
 		return fmt.Errorf("unknown format %s", opts.format)
 	}
 }
 
-func showDefaultFormatter(env *Env, snapshot *bug.Snapshot) error {
+func showDefaultFormatter(env *Env, snapshot *bug.Snapshot, signatures []bug.PackSignature, recipients []entity.Id, render bool) error {
 	// Header
 	env.out.Printf("%s [%s] %s\n\n",
 		colors.Cyan(snapshot.Id().Human()),
-		colors.Yellow(snapshot.Status),
+		colors.Yellow(snapshot.DisplayStatus()),
 		snapshot.Title,
 	)
 
@@ -133,6 +175,49 @@ func showDefaultFormatter(env *Env, snapshot *bug.Snapshot) error {
 		strings.Join(labels, ", "),
 	)
 
+	// Custom fields
+	if len(snapshot.Fields) > 0 {
+		var fields = make([]string, 0, len(snapshot.Fields))
+		for name, value := range snapshot.Fields {
+			fields = append(fields, fmt.Sprintf("%s=%s", name, value))
+		}
+		sort.Strings(fields)
+
+		env.out.Printf("fields: %s\n",
+			strings.Join(fields, ", "),
+		)
+	}
+
+	// Relations
+	if len(snapshot.Relations) > 0 {
+		var relations = make([]string, len(snapshot.Relations))
+		for i, relation := range snapshot.Relations {
+			relations[i] = relation.String()
+		}
+
+		env.out.Printf("relations: %s\n",
+			strings.Join(relations, ", "),
+		)
+	}
+
+	// Code references
+	for _, role := range []bug.CodeRefRole{bug.IntroducedByCodeRef, bug.FixedByCodeRef, bug.MentionedCodeRef} {
+		refs := snapshot.CodeRefsWithRole(role)
+		if len(refs) == 0 {
+			continue
+		}
+
+		var locations = make([]string, len(refs))
+		for i, ref := range refs {
+			locations[i] = ref.Location()
+		}
+
+		env.out.Printf("%s: %s\n",
+			codeRefRoleTitle(role),
+			strings.Join(locations, ", "),
+		)
+	}
+
 	// Actors
 	var actors = make([]string, len(snapshot.Actors))
 	for i := range snapshot.Actors {
@@ -149,24 +234,75 @@ func showDefaultFormatter(env *Env, snapshot *bug.Snapshot) error {
 		participants[i] = snapshot.Participants[i].DisplayName()
 	}
 
-	env.out.Printf("participants: %s\n\n",
+	env.out.Printf("participants: %s\n",
 		strings.Join(participants, ", "),
 	)
 
+	// Subscribers
+	var subscribers = make([]string, len(snapshot.Subscribers))
+	for i := range snapshot.Subscribers {
+		subscribers[i] = snapshot.Subscribers[i].DisplayName()
+	}
+
+	env.out.Printf("subscribers: %s\n",
+		strings.Join(subscribers, ", "),
+	)
+
+	// Recipients of a confidential bug
+	if len(recipients) > 0 {
+		var names = make([]string, len(recipients))
+		for i, id := range recipients {
+			recipient, err := env.backend.ResolveIdentityExcerpt(id)
+			if err != nil {
+				return err
+			}
+			names[i] = recipient.DisplayName()
+		}
+
+		env.out.Printf("confidential, readable by: %s\n",
+			strings.Join(names, ", "),
+		)
+	}
+
+	// Signatures
+	env.out.Printf("signatures: %s\n\n",
+		signatureSummary(signatures),
+	)
+
 	// Comments
 	indent := "  "
 
-	for i, comment := range snapshot.Comments {
+	// pinned comments are displayed first
+	for _, i := range pinnedFirst(snapshot.Comments) {
+		comment := snapshot.Comments[i]
 		var message string
-		env.out.Printf("%s#%d %s <%s>\n\n",
+		var pinned string
+		if comment.Pinned {
+			pinned = colors.Yellow(" (pinned)")
+		}
+		env.out.Printf("%s#%d %s <%s>%s\n\n",
 			indent,
 			i,
 			comment.Author.DisplayName(),
 			comment.Author.Email(),
+			pinned,
 		)
 
-		if comment.Message == "" {
+		for _, coAuthor := range comment.CoAuthors {
+			env.out.Printf("%sCo-authored-by: %s <%s>\n", indent, coAuthor.DisplayName(), coAuthor.Email())
+		}
+		if len(comment.CoAuthors) > 0 {
+			env.out.Println()
+		}
+
+		if comment.Redaction != nil {
+			message = colors.BlackBold(colors.WhiteBg(redactedMessage(comment.Redaction)))
+		} else if comment.Minimized != "" {
+			message = colors.BlackBold(colors.WhiteBg(minimizedMessage(comment.Minimized)))
+		} else if comment.Message == "" {
 			message = colors.BlackBold(colors.WhiteBg("No description provided."))
+		} else if render {
+			message = strings.TrimPrefix(markdown.Render(comment.Message, terminalWidth(), len(indent)), indent)
 		} else {
 			message = comment.Message
 		}
@@ -180,48 +316,226 @@ func showDefaultFormatter(env *Env, snapshot *bug.Snapshot) error {
 	return nil
 }
 
+// markdownRendering tell if the markdown should be rendered, according to
+// the --markdown flag
+func markdownRendering(mode string) (bool, error) {
+	switch mode {
+	case "auto":
+		return isatty.IsTerminal(os.Stdout.Fd()), nil
+	case "always":
+		// the colors are disabled by default outside of a terminal
+		color.NoColor = false
+		return true, nil
+	case "never":
+		return false, nil
+	default:
+		return false, fmt.Errorf("unknown markdown rendering %s", mode)
+	}
+}
+
+// terminalWidth return the width of the terminal, or a sensible default
+// when not in a terminal
+func terminalWidth() int {
+	width, _, err := terminal.GetSize(int(os.Stdout.Fd()))
+	if err != nil || width <= 0 {
+		return 80
+	}
+	return width
+}
+
+func codeRefRoleTitle(role bug.CodeRefRole) string {
+	switch role {
+	case bug.IntroducedByCodeRef:
+		return "introduced by"
+	case bug.FixedByCodeRef:
+		return "fixed by"
+	default:
+		return "code"
+	}
+}
+
+// pinnedFirst return the indexes of the comments, pinned ones first
+func pinnedFirst(comments []bug.Comment) []int {
+	result := make([]int, 0, len(comments))
+	for i, comment := range comments {
+		if comment.Pinned {
+			result = append(result, i)
+		}
+	}
+	for i, comment := range comments {
+		if !comment.Pinned {
+			result = append(result, i)
+		}
+	}
+	return result
+}
+
 type JSONBugSnapshot struct {
-	Id           string         `json:"id"`
-	HumanId      string         `json:"human_id"`
-	CreateTime   JSONTime       `json:"create_time"`
-	EditTime     JSONTime       `json:"edit_time"`
-	Status       string         `json:"status"`
-	Labels       []bug.Label    `json:"labels"`
-	Title        string         `json:"title"`
-	Author       JSONIdentity   `json:"author"`
-	Actors       []JSONIdentity `json:"actors"`
-	Participants []JSONIdentity `json:"participants"`
-	Comments     []JSONComment  `json:"comments"`
+	Id           string            `json:"id"`
+	HumanId      string            `json:"human_id"`
+	CreateTime   JSONTime          `json:"create_time"`
+	EditTime     JSONTime          `json:"edit_time"`
+	Status       string            `json:"status"`
+	Extended     string            `json:"extended_status,omitempty"`
+	Labels       []bug.Label       `json:"labels"`
+	Fields       map[string]string `json:"fields,omitempty"`
+	Relations    []JSONRelation    `json:"relations,omitempty"`
+	CodeRefs     []JSONCodeRef     `json:"code_refs,omitempty"`
+	Title        string            `json:"title"`
+	Author       JSONIdentity      `json:"author"`
+	Actors       []JSONIdentity    `json:"actors"`
+	Participants []JSONIdentity    `json:"participants"`
+	Subscribers  []JSONIdentity    `json:"subscribers"`
+	Comments     []JSONComment     `json:"comments"`
+	Signatures   []JSONSignature   `json:"signatures"`
+}
+
+type JSONSignature struct {
+	Status       string `json:"status"`
+	Signer       string `json:"signer,omitempty"`
+	Key          string `json:"key,omitempty"`
+	AuthorStatus string `json:"author_status"`
+	AuthorKey    string `json:"author_key,omitempty"`
+	Operations   int    `json:"operations"`
+}
+
+// signatureSummary count the commits of a bug by signature status, and list
+// the signers
+func signatureSummary(signatures []bug.PackSignature) string {
+	statuses := []repository.SignatureStatus{
+		repository.SignatureGood,
+		repository.SignatureUntrusted,
+		repository.SignatureUnverifiable,
+		repository.SignatureBad,
+		repository.SignatureNone,
+	}
+
+	counts := make(map[repository.SignatureStatus]int)
+	authorCounts := make(map[bug.AuthorStatus]int)
+	signers := make(map[string]struct{})
+	for _, signature := range signatures {
+		counts[signature.Status]++
+		authorCounts[signature.AuthorStatus]++
+		if signature.Signer != "" {
+			signers[signature.Signer] = struct{}{}
+		}
+	}
+
+	var parts []string
+	for _, status := range statuses {
+		if counts[status] > 0 {
+			text := fmt.Sprintf("%d %s", counts[status], status)
+			if status == repository.SignatureBad {
+				text = colors.Red(text)
+			}
+			parts = append(parts, text)
+		}
+	}
+
+	result := strings.Join(parts, ", ")
+
+	if len(signers) > 0 {
+		names := make([]string, 0, len(signers))
+		for name := range signers {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		result += fmt.Sprintf(" (signed by %s)", strings.Join(names, ", "))
+	}
+
+	// the verification against the keys registered on the identities
+	if n := authorCounts[bug.AuthorVerified]; n > 0 {
+		result += fmt.Sprintf(", %d verified from the author's keys", n)
+	}
+	if n := authorCounts[bug.AuthorUnsigned] + authorCounts[bug.AuthorMismatch]; n > 0 {
+		result += colors.Red(fmt.Sprintf(", %d not signed by the author", n))
+	}
+
+	return result
+}
+
+type JSONRelation struct {
+	Relation string `json:"relation"`
+	Target   string `json:"target"`
+}
+
+type JSONCodeRef struct {
+	Kind   string `json:"kind"`
+	Role   string `json:"role"`
+	Target string `json:"target"`
+	Line   int    `json:"line,omitempty"`
 }
 
 type JSONComment struct {
-	Id      string       `json:"id"`
-	HumanId string       `json:"human_id"`
-	Author  JSONIdentity `json:"author"`
-	Message string       `json:"message"`
+	Id        string         `json:"id"`
+	HumanId   string         `json:"human_id"`
+	Author    JSONIdentity   `json:"author"`
+	CoAuthors []JSONIdentity `json:"co_authors,omitempty"`
+	Message   string         `json:"message"`
+	Redaction *JSONRedaction `json:"redaction,omitempty"`
+	Minimized string         `json:"minimized,omitempty"`
+	Pinned    bool           `json:"pinned,omitempty"`
+}
+
+type JSONRedaction struct {
+	Author JSONIdentity `json:"author"`
+	Reason string       `json:"reason"`
 }
 
 func NewJSONComment(comment bug.Comment) JSONComment {
-	return JSONComment{
-		Id:      comment.Id().String(),
-		HumanId: comment.Id().Human(),
-		Author:  NewJSONIdentity(comment.Author),
-		Message: comment.Message,
+	result := JSONComment{
+		Id:        comment.Id().String(),
+		HumanId:   comment.Id().Human(),
+		Author:    NewJSONIdentity(comment.Author),
+		Message:   comment.Message,
+		Minimized: string(comment.Minimized),
+		Pinned:    comment.Pinned,
+	}
+
+	for _, coAuthor := range comment.CoAuthors {
+		result.CoAuthors = append(result.CoAuthors, NewJSONIdentity(coAuthor))
+	}
+
+	if comment.Redaction != nil {
+		result.Redaction = &JSONRedaction{
+			Author: NewJSONIdentity(comment.Redaction.Author),
+			Reason: comment.Redaction.Reason,
+		}
 	}
+
+	return result
 }
 
-func showJsonFormatter(env *Env, snapshot *bug.Snapshot) error {
+func showJsonFormatter(env *Env, snapshot *bug.Snapshot, signatures []bug.PackSignature) error {
 	jsonBug := JSONBugSnapshot{
 		Id:         snapshot.Id().String(),
 		HumanId:    snapshot.Id().Human(),
 		CreateTime: NewJSONTime(snapshot.CreateTime, 0),
 		EditTime:   NewJSONTime(snapshot.EditTime(), 0),
 		Status:     snapshot.Status.String(),
+		Extended:   snapshot.ExtendedStatus,
 		Labels:     snapshot.Labels,
+		Fields:     snapshot.Fields,
 		Title:      snapshot.Title,
 		Author:     NewJSONIdentity(snapshot.Author),
 	}
 
+	for _, relation := range snapshot.Relations {
+		jsonBug.Relations = append(jsonBug.Relations, JSONRelation{
+			Relation: relation.Type.String(),
+			Target:   relation.Target.String(),
+		})
+	}
+
+	for _, ref := range snapshot.CodeRefs {
+		jsonBug.CodeRefs = append(jsonBug.CodeRefs, JSONCodeRef{
+			Kind:   ref.Kind.String(),
+			Role:   ref.Role.String(),
+			Target: ref.Target,
+			Line:   ref.Line,
+		})
+	}
+
 	jsonBug.Actors = make([]JSONIdentity, len(snapshot.Actors))
 	for i, element := range snapshot.Actors {
 		jsonBug.Actors[i] = NewJSONIdentity(element)
@@ -232,22 +546,69 @@ func showJsonFormatter(env *Env, snapshot *bug.Snapshot) error {
 		jsonBug.Participants[i] = NewJSONIdentity(element)
 	}
 
+	jsonBug.Subscribers = make([]JSONIdentity, len(snapshot.Subscribers))
+	for i, element := range snapshot.Subscribers {
+		jsonBug.Subscribers[i] = NewJSONIdentity(element)
+	}
+
 	jsonBug.Comments = make([]JSONComment, len(snapshot.Comments))
 	for i, comment := range snapshot.Comments {
 		jsonBug.Comments[i] = NewJSONComment(comment)
 	}
 
+	jsonBug.Signatures = make([]JSONSignature, len(signatures))
+	for i, signature := range signatures {
+		jsonBug.Signatures[i] = JSONSignature{
+			Status:       signature.Status.String(),
+			Signer:       signature.Signer,
+			Key:          signature.Key,
+			AuthorStatus: signature.AuthorStatus.String(),
+			AuthorKey:    signature.AuthorKey,
+			Operations:   len(signature.Operations),
+		}
+	}
+
 	jsonObject, _ := json.MarshalIndent(jsonBug, "", "    ")
 	env.out.Printf("%s\n", jsonObject)
 
 	return nil
 }
 
+type JSONOperation struct {
+	Bug       string        `json:"bug"`
+	Id        string        `json:"id"`
+	HumanId   string        `json:"human_id"`
+	Author    JSONIdentity  `json:"author"`
+	Time      JSONTime      `json:"time"`
+	Operation bug.Operation `json:"operation"`
+}
+
+// showNDJSONFormatter stream the operations of the bug as one JSON object
+// per line
+func showNDJSONFormatter(env *Env, snapshot *bug.Snapshot) error {
+	encoder := json.NewEncoder(env.out)
+	for _, op := range snapshot.Operations {
+		jsonOp := JSONOperation{
+			Bug:       snapshot.Id().String(),
+			Id:        op.Id().String(),
+			HumanId:   op.Id().Human(),
+			Author:    NewJSONIdentity(op.GetAuthor()),
+			Time:      NewJSONTime(op.Time(), 0),
+			Operation: op,
+		}
+
+		if err := encoder.Encode(jsonOp); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func showOrgModeFormatter(env *Env, snapshot *bug.Snapshot) error {
 	// Header
 	env.out.Printf("%s [%s] %s\n",
 		snapshot.Id().Human(),
-		snapshot.Status,
+		snapshot.DisplayStatus(),
 		snapshot.Title,
 	)
 
@@ -276,6 +637,24 @@ func showOrgModeFormatter(env *Env, snapshot *bug.Snapshot) error {
 		)
 	}
 
+	// Code references
+	for _, role := range []bug.CodeRefRole{bug.IntroducedByCodeRef, bug.FixedByCodeRef, bug.MentionedCodeRef} {
+		refs := snapshot.CodeRefsWithRole(role)
+		if len(refs) == 0 {
+			continue
+		}
+
+		var locations = make([]string, len(refs))
+		for i, ref := range refs {
+			locations[i] = ref.Location()
+		}
+
+		env.out.Printf("%s: %s\n",
+			codeRefRoleTitle(role),
+			strings.Join(locations, ", "),
+		)
+	}
+
 	// Actors
 	var actors = make([]string, len(snapshot.Actors))
 	for i, actor := range snapshot.Actors {
@@ -309,7 +688,11 @@ func showOrgModeFormatter(env *Env, snapshot *bug.Snapshot) error {
 		env.out.Printf("** #%d %s\n",
 			i, comment.Author.DisplayName())
 
-		if comment.Message == "" {
+		if comment.Redaction != nil {
+			message = redactedMessage(comment.Redaction)
+		} else if comment.Minimized != "" {
+			message = minimizedMessage(comment.Minimized)
+		} else if comment.Message == "" {
 			message = "No description provided."
 		} else {
 			message = strings.ReplaceAll(comment.Message, "\n", "\n: ")
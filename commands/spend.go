@@ -0,0 +1,95 @@
+package commands
+
+import (
+	"errors"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/MichaelMure/git-bug/bug"
+	_select "github.com/MichaelMure/git-bug/commands/select"
+	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/util/colors"
+)
+
+func newSpendCommand() *cobra.Command {
+	env := newEnv()
+
+	cmd := &cobra.Command{
+		Use:   "spend [ID] [DURATION]",
+		Short: "Display or record the time spent on a bug.",
+		Long: `Display or record the time spent on a bug.
+
+The duration is expressed like "1h30m". A negative duration can be used to correct a previous entry.
+Without a duration, display the time spent per identity.`,
+		PreRunE:           loadBackendEnsureUser(env),
+		PostRunE:          closeBackend(env),
+		ValidArgsFunction: completeBug(env),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runSpend(env, args)
+		},
+	}
+
+	return cmd
+}
+
+func runSpend(env *Env, args []string) error {
+	b, args, err := _select.ResolveBug(env.backend, args)
+	if err != nil {
+		return err
+	}
+
+	if len(args) > 1 {
+		return errors.New("only one duration can be provided")
+	}
+
+	if len(args) == 0 {
+		return showTimeSpent(env, b.Snapshot())
+	}
+
+	duration, err := time.ParseDuration(args[0])
+	if err != nil {
+		return err
+	}
+
+	_, err = b.AddTimeSpent(duration)
+	if err != nil {
+		return err
+	}
+
+	return b.Commit()
+}
+
+func showTimeSpent(env *Env, snap *bug.Snapshot) error {
+	// list the identities in the order they first spent time on the bug
+	var order []entity.Id
+	names := make(map[entity.Id]string)
+
+	for _, op := range snap.Operations {
+		if _, ok := op.(*bug.AddTimeSpentOperation); !ok {
+			continue
+		}
+
+		author := op.GetAuthor()
+		if _, ok := names[author.Id()]; !ok {
+			order = append(order, author.Id())
+			names[author.Id()] = author.DisplayName()
+		}
+	}
+
+	for _, id := range order {
+		env.out.Printf("%s %s\t%s\n",
+			colors.Cyan(id.Human()),
+			colors.Magenta(names[id]),
+			snap.TimeSpentBy[id],
+		)
+	}
+
+	env.out.Printf("total: %s\n", snap.TimeSpent)
+
+	if snap.Estimate > 0 {
+		env.out.Printf("estimate: %s\n", snap.Estimate)
+	}
+
+	return nil
+}
@@ -9,10 +9,11 @@ func newStatusCommand() *cobra.Command {
 	env := newEnv()
 
 	cmd := &cobra.Command{
-		Use:      "status [ID]",
-		Short:    "Display or change a bug status.",
-		PreRunE:  loadBackend(env),
-		PostRunE: closeBackend(env),
+		Use:               "status [ID]",
+		Short:             "Display or change a bug status.",
+		PreRunE:           loadBackendReadOnly(env),
+		PostRunE:          closeBackend(env),
+		ValidArgsFunction: completeBug(env),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runStatus(env, args)
 		},
@@ -20,6 +21,7 @@ func newStatusCommand() *cobra.Command {
 
 	cmd.AddCommand(newStatusCloseCommand())
 	cmd.AddCommand(newStatusOpenCommand())
+	cmd.AddCommand(newStatusSetCommand())
 
 	return cmd
 }
@@ -32,7 +34,7 @@ func runStatus(env *Env, args []string) error {
 
 	snap := b.Snapshot()
 
-	env.out.Println(snap.Status)
+	env.out.Println(snap.DisplayStatus())
 
 	return nil
 }
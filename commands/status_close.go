@@ -1,36 +1,73 @@
 package commands
 
 import (
-	_select "github.com/MichaelMure/git-bug/commands/select"
+	"fmt"
+
 	"github.com/spf13/cobra"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/cache"
+	_select "github.com/MichaelMure/git-bug/commands/select"
 )
 
 func newStatusCloseCommand() *cobra.Command {
 	env := newEnv()
+	options := bulkOptions{}
 
 	cmd := &cobra.Command{
-		Use:      "close [ID]",
-		Short:    "Mark a bug as closed.",
-		PreRunE:  loadBackendEnsureUser(env),
-		PostRunE: closeBackend(env),
+		Use:   "close [ID...]",
+		Short: "Mark a bug as closed.",
+		Example: `Mark as closed several bugs at once:
+git bug status close 1234 5678 9abc
+
+Mark as closed all the open bugs labeled wontfix, after confirmation:
+git bug status close --query 'label:wontfix status:open'
+`,
+		PreRunE:           loadBackendEnsureUser(env),
+		PostRunE:          closeBackend(env),
+		ValidArgsFunction: completeBug(env),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runStatusClose(env, args)
+			return runStatusClose(env, options, args)
 		},
 	}
 
+	flags := cmd.Flags()
+	flags.SortFlags = false
+
+	addBulkFlags(flags, &options)
+
 	return cmd
 }
 
-func runStatusClose(env *Env, args []string) error {
-	b, args, err := _select.ResolveBug(env.backend, args)
-	if err != nil {
-		return err
+func runStatusClose(env *Env, opts bulkOptions, args []string) error {
+	if opts.query != "" {
+		bugs, err := resolveBulk(env, opts, "Close")
+		if err != nil {
+			return err
+		}
+
+		for _, b := range bugs {
+			if _, err := b.Close(); err != nil {
+				return err
+			}
+			if err := b.Commit(); err != nil {
+				return err
+			}
+		}
+
+		return nil
 	}
 
-	_, err = b.Close()
+	bugs, args, err := _select.ResolveBugs(env.backend, args)
 	if err != nil {
 		return err
 	}
+	if len(args) > 0 {
+		return fmt.Errorf("%s: %v", args[0], bug.ErrBugNotExist)
+	}
 
-	return b.Commit()
+	return applyToBugs(env, bugs, func(b *cache.BugCache) (string, error) {
+		_, err := b.Close()
+		return "closed", err
+	})
 }
@@ -1,36 +1,73 @@
 package commands
 
 import (
-	_select "github.com/MichaelMure/git-bug/commands/select"
+	"fmt"
+
 	"github.com/spf13/cobra"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/cache"
+	_select "github.com/MichaelMure/git-bug/commands/select"
 )
 
 func newStatusOpenCommand() *cobra.Command {
 	env := newEnv()
+	options := bulkOptions{}
 
 	cmd := &cobra.Command{
-		Use:      "open [ID]",
-		Short:    "Mark a bug as open.",
-		PreRunE:  loadBackendEnsureUser(env),
-		PostRunE: closeBackend(env),
+		Use:   "open [ID...]",
+		Short: "Mark a bug as open.",
+		Example: `Mark as open several bugs at once:
+git bug status open 1234 5678 9abc
+
+Mark as open all the closed bugs labeled regression, after confirmation:
+git bug status open --query 'label:regression status:closed'
+`,
+		PreRunE:           loadBackendEnsureUser(env),
+		PostRunE:          closeBackend(env),
+		ValidArgsFunction: completeBug(env),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runStatusOpen(env, args)
+			return runStatusOpen(env, options, args)
 		},
 	}
 
+	flags := cmd.Flags()
+	flags.SortFlags = false
+
+	addBulkFlags(flags, &options)
+
 	return cmd
 }
 
-func runStatusOpen(env *Env, args []string) error {
-	b, args, err := _select.ResolveBug(env.backend, args)
-	if err != nil {
-		return err
+func runStatusOpen(env *Env, opts bulkOptions, args []string) error {
+	if opts.query != "" {
+		bugs, err := resolveBulk(env, opts, "Open")
+		if err != nil {
+			return err
+		}
+
+		for _, b := range bugs {
+			if _, err := b.Open(); err != nil {
+				return err
+			}
+			if err := b.Commit(); err != nil {
+				return err
+			}
+		}
+
+		return nil
 	}
 
-	_, err = b.Open()
+	bugs, args, err := _select.ResolveBugs(env.backend, args)
 	if err != nil {
 		return err
 	}
+	if len(args) > 0 {
+		return fmt.Errorf("%s: %v", args[0], bug.ErrBugNotExist)
+	}
 
-	return b.Commit()
+	return applyToBugs(env, bugs, func(b *cache.BugCache) (string, error) {
+		_, err := b.Open()
+		return "opened", err
+	})
 }
@@ -0,0 +1,75 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/MichaelMure/git-bug/cache"
+	_select "github.com/MichaelMure/git-bug/commands/select"
+)
+
+func newStatusSetCommand() *cobra.Command {
+	env := newEnv()
+	options := bulkOptions{}
+
+	cmd := &cobra.Command{
+		Use:               "set [ID...] STATUS",
+		Short:             "Set a bug to one of the statuses defined for the repository.",
+		PreRunE:           loadBackendEnsureUser(env),
+		PostRunE:          closeBackend(env),
+		ValidArgsFunction: completeBug(env),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runStatusSet(env, options, args)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.SortFlags = false
+
+	addBulkFlags(flags, &options)
+
+	return cmd
+}
+
+func runStatusSet(env *Env, opts bulkOptions, args []string) error {
+	var bugs []*cache.BugCache
+
+	if opts.query == "" {
+		var err error
+		bugs, args, err = _select.ResolveBugs(env.backend, args)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(args) != 1 {
+		return fmt.Errorf("a single status is required")
+	}
+
+	set, err := env.backend.StatusSet()
+	if err != nil {
+		return err
+	}
+
+	if len(set) == 0 {
+		return fmt.Errorf("no statuses defined for this repository, configure them with git config git-bug.statuses")
+	}
+
+	if _, err := set.Get(args[0]); err != nil {
+		return fmt.Errorf("unknown status %s, available statuses: %s", args[0], strings.Join(set.Names(), ", "))
+	}
+
+	if opts.query != "" {
+		bugs, err = resolveBulk(env, opts, "Set the status "+args[0]+" on")
+		if err != nil {
+			return err
+		}
+	}
+
+	return applyToBugs(env, bugs, func(b *cache.BugCache) (string, error) {
+		_, err := b.SetExtendedStatus(args[0])
+		return "status set to " + args[0], err
+	})
+}
@@ -0,0 +1,89 @@
+package commands
+
+import (
+	"errors"
+
+	"github.com/spf13/cobra"
+
+	_select "github.com/MichaelMure/git-bug/commands/select"
+)
+
+func newSubscribeCommand() *cobra.Command {
+	env := newEnv()
+
+	cmd := &cobra.Command{
+		Use:               "subscribe [ID]",
+		Short:             "Subscribe to a bug to watch it without commenting.",
+		PreRunE:           loadBackendEnsureUser(env),
+		PostRunE:          closeBackend(env),
+		ValidArgsFunction: completeBug(env),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runSubscribe(env, args)
+		},
+	}
+
+	return cmd
+}
+
+func runSubscribe(env *Env, args []string) error {
+	b, _, err := _select.ResolveBug(env.backend, args)
+	if err != nil {
+		return err
+	}
+
+	user, err := env.backend.GetUserIdentity()
+	if err != nil {
+		return err
+	}
+
+	if b.Snapshot().HasSubscriber(user.Id()) {
+		return errors.New("you are already subscribed to this bug")
+	}
+
+	_, err = b.Subscribe()
+	if err != nil {
+		return err
+	}
+
+	return b.Commit()
+}
+
+func newUnsubscribeCommand() *cobra.Command {
+	env := newEnv()
+
+	cmd := &cobra.Command{
+		Use:               "unsubscribe [ID]",
+		Short:             "Stop watching a bug.",
+		PreRunE:           loadBackendEnsureUser(env),
+		PostRunE:          closeBackend(env),
+		ValidArgsFunction: completeBug(env),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runUnsubscribe(env, args)
+		},
+	}
+
+	return cmd
+}
+
+func runUnsubscribe(env *Env, args []string) error {
+	b, _, err := _select.ResolveBug(env.backend, args)
+	if err != nil {
+		return err
+	}
+
+	user, err := env.backend.GetUserIdentity()
+	if err != nil {
+		return err
+	}
+
+	if !b.Snapshot().HasSubscriber(user.Id()) {
+		return errors.New("you are not subscribed to this bug")
+	}
+
+	_, err = b.Unsubscribe()
+	if err != nil {
+		return err
+	}
+
+	return b.Commit()
+}
@@ -0,0 +1,167 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/input"
+	"github.com/MichaelMure/git-bug/util/colors"
+)
+
+type sweepOptions struct {
+	into    string
+	commits bool
+	yes     bool
+	dryRun  bool
+}
+
+func newSweepCommand() *cobra.Command {
+	env := newEnv()
+	options := sweepOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "sweep",
+		Short: "Offer to close the open bugs whose fix has been merged.",
+		Long: `Offer to close the open bugs whose fix has been merged.
+
+The fixes are the branches and commits recorded in the bugs with the fixed-by role, for example by
+"git bug checkout" or by a "Fixes: <id>" commit trailer. They are checked against the default branch: the one
+configured with "git config git-bug.default-branch <branch>", or origin/HEAD, main or master.`,
+		PreRunE:  loadBackendEnsureUser(env),
+		PostRunE: closeBackend(env),
+		Args:     cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runSweep(env, options)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.SortFlags = false
+
+	flags.StringVarP(&options.into, "into", "i", "",
+		"Branch the fixes are merged into, instead of the default branch")
+	flags.BoolVarP(&options.commits, "commits", "c", false,
+		"Only consider the commits, not the branches")
+	flags.BoolVarP(&options.yes, "yes", "y", false,
+		"Close the bugs without asking for confirmation")
+	flags.BoolVarP(&options.dryRun, "dry-run", "n", false,
+		"Only list the bugs that can be closed")
+
+	return cmd
+}
+
+func runSweep(env *Env, opts sweepOptions) error {
+	into := opts.into
+	if into == "" {
+		var err error
+		into, err = env.backend.DefaultBranch()
+		if err != nil {
+			return err
+		}
+	}
+
+	swept := 0
+
+	for _, id := range env.backend.AllBugsIds() {
+		excerpt, err := env.backend.ResolveBugExcerpt(id)
+		if err != nil {
+			return err
+		}
+		if excerpt.Status != bug.OpenStatus {
+			continue
+		}
+
+		b, err := env.backend.ResolveBug(id)
+		if err != nil {
+			return err
+		}
+
+		fix, err := mergedFix(env.backend, b.Snapshot(), into, opts.commits)
+		if err != nil {
+			return err
+		}
+		if fix == "" {
+			continue
+		}
+
+		env.out.Printf("%s %s\t%s\n",
+			colors.Cyan(excerpt.Id.Human()),
+			strings.TrimSpace(excerpt.Title),
+			colors.Yellow(fmt.Sprintf("(%s merged into %s)", fix, into)),
+		)
+
+		if opts.dryRun {
+			continue
+		}
+
+		if !opts.yes {
+			ok, err := input.PromptConfirm(fmt.Sprintf("Close bug %s?", excerpt.Id.Human()))
+			if err != nil {
+				return err
+			}
+			if !ok {
+				continue
+			}
+		}
+
+		if _, err := b.Close(); err != nil {
+			return err
+		}
+		if err := b.Commit(); err != nil {
+			return err
+		}
+		swept++
+	}
+
+	if !opts.dryRun {
+		env.out.Printf("%d bug(s) closed\n", swept)
+	}
+
+	return nil
+}
+
+// mergedFix describe the first fix of the bug that has been merged, if any.
+// The branches that have been deleted and the commits not available locally
+// are ignored.
+func mergedFix(backend *cache.RepoCache, snap *bug.Snapshot, into string, commitsOnly bool) (string, error) {
+	for _, ref := range snap.CodeRefsWithRole(bug.FixedByCodeRef) {
+		switch {
+		case ref.Kind == bug.CommitCodeRef:
+			hash, err := backend.ResolveRevision(ref.Target)
+			if err != nil {
+				continue
+			}
+
+			merged, err := backend.IsReachable(hash, into)
+			if err != nil {
+				return "", err
+			}
+			if merged {
+				return "commit " + ref.Location(), nil
+			}
+
+		case ref.Kind == bug.BranchCodeRef && !commitsOnly:
+			exist, err := backend.BranchExist(ref.Target)
+			if err != nil {
+				return "", err
+			}
+			if !exist {
+				continue
+			}
+
+			merged, err := backend.IsMerged(ref.Target, into)
+			if err != nil {
+				return "", err
+			}
+			if merged {
+				return ref.Target, nil
+			}
+		}
+	}
+
+	return "", nil
+}
@@ -0,0 +1,67 @@
+package commands
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/util/colors"
+)
+
+func newTeamCommand() *cobra.Command {
+	env := newEnv()
+
+	cmd := &cobra.Command{
+		Use:   "team",
+		Short: "List the teams and their members, or manage them.",
+		Long: `List the teams and their members, or manage them.
+
+A team is a group identity designated by its handle. It can be assigned to bugs, mentioned in comments with @handle to notify its members, and queried with "assignee:@handle" to find the bugs assigned to the team or to one of its members. The membership is stored in the versions of the team identity, and shared as any identity.`,
+		PreRunE:  loadBackend(env),
+		PostRunE: closeBackend(env),
+		Args:     cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runTeam(env)
+		},
+	}
+
+	cmd.AddCommand(newTeamAddCommand())
+	cmd.AddCommand(newTeamNewCommand())
+	cmd.AddCommand(newTeamRmCommand())
+
+	return cmd
+}
+
+func runTeam(env *Env) error {
+	var teams []*cache.IdentityExcerpt
+	for _, id := range env.backend.AllIdentityIds() {
+		excerpt, err := env.backend.ResolveIdentityExcerpt(id)
+		if err != nil {
+			return err
+		}
+		if excerpt.Team {
+			teams = append(teams, excerpt)
+		}
+	}
+	sort.Slice(teams, func(i, j int) bool { return teams[i].Login < teams[j].Login })
+
+	for _, team := range teams {
+		members := make([]string, len(team.Members))
+		for i, id := range team.Members {
+			members[i] = id.Human()
+			if excerpt, err := env.backend.ResolveIdentityExcerpt(id); err == nil {
+				members[i] = excerpt.DisplayName()
+			}
+		}
+		env.out.Printf("%s %s %s\n\t%s\n",
+			colors.Cyan(team.Id.Human()),
+			colors.Yellow("@"+team.Login),
+			team.Name,
+			strings.Join(members, ", "),
+		)
+	}
+
+	return nil
+}
@@ -0,0 +1,76 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/identity"
+)
+
+func newTeamAddCommand() *cobra.Command {
+	env := newEnv()
+
+	cmd := &cobra.Command{
+		Use:      "add HANDLE USER...",
+		Short:    "Add members to a team.",
+		PreRunE:  loadBackend(env),
+		PostRunE: closeBackend(env),
+		Args:     cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runTeamMembers(env, args, true)
+		},
+	}
+
+	return cmd
+}
+
+// runTeamMembers add or remove members of the team given as the first
+// argument, in a new version of the team
+func runTeamMembers(env *Env, args []string, add bool) error {
+	team, err := env.backend.ResolveTeam(args[0])
+	if err == identity.ErrIdentityNotExist {
+		return fmt.Errorf("no team @%s", args[0])
+	}
+	if err != nil {
+		return err
+	}
+
+	ids := make([]entity.Id, len(args)-1)
+	for i, query := range args[1:] {
+		user, err := resolveUser(env, query)
+		if err != nil {
+			return err
+		}
+		if user.IsTeam() {
+			return fmt.Errorf("%s is a team, teams can't be nested", user.DisplayName())
+		}
+		ids[i] = user.Id()
+	}
+
+	err = team.Mutate(func(orig identity.Mutator) identity.Mutator {
+		for _, id := range ids {
+			orig.Members = withoutMember(orig.Members, id)
+			if add {
+				orig.Members = append(orig.Members, id)
+			}
+		}
+		return orig
+	})
+	if err != nil {
+		return err
+	}
+
+	return team.CommitAsNeeded()
+}
+
+func withoutMember(members []entity.Id, id entity.Id) []entity.Id {
+	var result []entity.Id
+	for _, member := range members {
+		if member != id {
+			result = append(result, member)
+		}
+	}
+	return result
+}
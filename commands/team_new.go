@@ -0,0 +1,39 @@
+package commands
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func newTeamNewCommand() *cobra.Command {
+	env := newEnv()
+
+	cmd := &cobra.Command{
+		Use:      "new HANDLE [NAME]",
+		Short:    "Create a new team.",
+		Example:  `git bug team new backend-team "Backend team"`,
+		PreRunE:  loadBackend(env),
+		PostRunE: closeBackend(env),
+		Args:     cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runTeamNew(env, args)
+		},
+	}
+
+	return cmd
+}
+
+func runTeamNew(env *Env, args []string) error {
+	name := ""
+	if len(args) > 1 {
+		name = args[1]
+	}
+
+	team, err := env.backend.NewTeam(name, args[0])
+	if err != nil {
+		return err
+	}
+
+	env.out.Printf("Team @%s created: %s\n", team.Login(), team.Id().Human())
+
+	return nil
+}
@@ -0,0 +1,30 @@
+package commands
+
+import (
+	"fmt"
+	"text/template"
+)
+
+// parseOutputTemplate parse a user provided go template, like git log --format
+func parseOutputTemplate(text string) (*template.Template, error) {
+	if text == "" {
+		return nil, fmt.Errorf("a template is required with --format template")
+	}
+
+	tmpl, err := template.New("output").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid template: %v", err)
+	}
+
+	return tmpl, nil
+}
+
+// executeOutputTemplate display the data with the template, followed by a
+// new line
+func executeOutputTemplate(env *Env, tmpl *template.Template, data interface{}) error {
+	if err := tmpl.Execute(env.out, data); err != nil {
+		return err
+	}
+	env.out.Println()
+	return nil
+}
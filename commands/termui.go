@@ -13,7 +13,7 @@ func newTermUICommand() *cobra.Command {
 		Use:      "termui",
 		Aliases:  []string{"tui"},
 		Short:    "Launch the terminal UI.",
-		PreRunE:  loadBackendEnsureUser(env),
+		PreRunE:  loadBackend(env),
 		PostRunE: closeBackend(env),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runTermUI(env)
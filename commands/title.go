@@ -1,18 +1,24 @@
 package commands
 
 import (
-	_select "github.com/MichaelMure/git-bug/commands/select"
+	"fmt"
+
 	"github.com/spf13/cobra"
+
+	"github.com/MichaelMure/git-bug/bug"
+	_select "github.com/MichaelMure/git-bug/commands/select"
+	"github.com/MichaelMure/git-bug/util/colors"
 )
 
 func newTitleCommand() *cobra.Command {
 	env := newEnv()
 
 	cmd := &cobra.Command{
-		Use:      "title [ID]",
-		Short:    "Display or change a title of a bug.",
-		PreRunE:  loadBackend(env),
-		PostRunE: closeBackend(env),
+		Use:               "title [ID...]",
+		Short:             "Display or change a title of a bug.",
+		PreRunE:           loadBackend(env),
+		PostRunE:          closeBackend(env),
+		ValidArgsFunction: completeBug(env),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runTitle(env, args)
 		},
@@ -24,14 +30,22 @@ func newTitleCommand() *cobra.Command {
 }
 
 func runTitle(env *Env, args []string) error {
-	b, args, err := _select.ResolveBug(env.backend, args)
+	bugs, args, err := _select.ResolveBugs(env.backend, args)
 	if err != nil {
 		return err
 	}
+	if len(args) > 0 {
+		return fmt.Errorf("%s: %v", args[0], bug.ErrBugNotExist)
+	}
 
-	snap := b.Snapshot()
+	if len(bugs) == 1 {
+		env.out.Println(bugs[0].Snapshot().Title)
+		return nil
+	}
 
-	env.out.Println(snap.Title)
+	for _, b := range bugs {
+		env.out.Printf("%s %s\n", colors.Cyan(b.Id().Human()), b.Snapshot().Title)
+	}
 
 	return nil
 }
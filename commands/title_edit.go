@@ -1,8 +1,12 @@
 package commands
 
 import (
+	"fmt"
+
 	"github.com/spf13/cobra"
 
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/cache"
 	_select "github.com/MichaelMure/git-bug/commands/select"
 	"github.com/MichaelMure/git-bug/input"
 )
@@ -16,10 +20,11 @@ func newTitleEditCommand() *cobra.Command {
 	options := titleEditOptions{}
 
 	cmd := &cobra.Command{
-		Use:      "edit [ID]",
-		Short:    "Edit a title of a bug.",
-		PreRunE:  loadBackendEnsureUser(env),
-		PostRunE: closeBackend(env),
+		Use:               "edit [ID...]",
+		Short:             "Edit a title of a bug.",
+		PreRunE:           loadBackendEnsureUser(env),
+		PostRunE:          closeBackend(env),
+		ValidArgsFunction: completeBug(env),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runTitleEdit(env, options, args)
 		},
@@ -36,11 +41,25 @@ func newTitleEditCommand() *cobra.Command {
 }
 
 func runTitleEdit(env *Env, opts titleEditOptions, args []string) error {
-	b, args, err := _select.ResolveBug(env.backend, args)
+	bugs, args, err := _select.ResolveBugs(env.backend, args)
 	if err != nil {
 		return err
 	}
+	if len(args) > 0 {
+		return fmt.Errorf("%s: %v", args[0], bug.ErrBugNotExist)
+	}
+
+	if len(bugs) > 1 {
+		if opts.title == "" {
+			return fmt.Errorf("a title must be given with --title to edit several bugs")
+		}
+		return applyToBugs(env, bugs, func(b *cache.BugCache) (string, error) {
+			_, err := b.SetTitle(opts.title)
+			return "title changed", err
+		})
+	}
 
+	b := bugs[0]
 	snap := b.Snapshot()
 
 	if opts.title == "" {
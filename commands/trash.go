@@ -0,0 +1,30 @@
+package commands
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func newTrashCommand() *cobra.Command {
+	env := newEnv()
+
+	cmd := &cobra.Command{
+		Use:   "trash",
+		Short: "List, restore or purge the removed bugs.",
+		Long: `List, restore or purge the removed bugs.
+
+The bugs removed with "git bug rm" are kept in the trash of the local repository until purged. The trash is
+never pushed.`,
+		PreRunE:  loadBackendReadOnly(env),
+		PostRunE: closeBackend(env),
+		Args:     cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runTrashLs(env)
+		},
+	}
+
+	cmd.AddCommand(newTrashLsCommand())
+	cmd.AddCommand(newTrashPurgeCommand())
+	cmd.AddCommand(newTrashRestoreCommand())
+
+	return cmd
+}
@@ -0,0 +1,63 @@
+package commands
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/util/colors"
+)
+
+func newTrashLsCommand() *cobra.Command {
+	env := newEnv()
+
+	cmd := &cobra.Command{
+		Use:      "ls",
+		Short:    "List the bugs in the trash.",
+		PreRunE:  loadBackendReadOnly(env),
+		PostRunE: closeBackend(env),
+		Args:     cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runTrashLs(env)
+		},
+	}
+
+	return cmd
+}
+
+func runTrashLs(env *Env) error {
+	ids, err := env.backend.TrashedBugs()
+	if err != nil {
+		return err
+	}
+
+	if len(ids) == 0 {
+		env.out.Println("The trash is empty.")
+		return nil
+	}
+
+	snaps := make([]*bug.Snapshot, len(ids))
+	for i, id := range ids {
+		snaps[i], err = env.backend.ResolveTrashedBug(id)
+		if err != nil {
+			return err
+		}
+	}
+
+	// most recently edited first
+	sort.Slice(snaps, func(i, j int) bool {
+		return snaps[i].EditTime().After(snaps[j].EditTime())
+	})
+
+	for _, snap := range snaps {
+		env.out.Printf("%s %s\t%s\n",
+			colors.Cyan(snap.Id().Human()),
+			colors.Yellow(snap.Status),
+			strings.TrimSpace(snap.Title),
+		)
+	}
+
+	return nil
+}
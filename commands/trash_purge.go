@@ -0,0 +1,96 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/input"
+)
+
+type trashPurgeOptions struct {
+	all bool
+	yes bool
+}
+
+func newTrashPurgeCommand() *cobra.Command {
+	env := newEnv()
+	options := trashPurgeOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "purge [ID...]",
+		Short: "Definitely remove bugs from the trash.",
+		Example: `Empty the trash, after confirmation:
+git bug trash purge --all
+`,
+		PreRunE:  loadBackendEnsureUser(env),
+		PostRunE: closeBackend(env),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runTrashPurge(env, options, args)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.SortFlags = false
+
+	flags.BoolVarP(&options.all, "all", "a", false,
+		"Purge all the bugs of the trash")
+	flags.BoolVarP(&options.yes, "yes", "y", false,
+		"Don't ask for confirmation")
+
+	return cmd
+}
+
+func runTrashPurge(env *Env, opts trashPurgeOptions, args []string) error {
+	var ids []entity.Id
+
+	switch {
+	case opts.all && len(args) > 0:
+		return errors.New("--all can't be used with bug ids")
+	case opts.all:
+		var err error
+		ids, err = env.backend.TrashedBugs()
+		if err != nil {
+			return err
+		}
+	case len(args) == 0:
+		return errors.New("you must provide the prefix of a bug of the trash, or --all")
+	default:
+		for _, prefix := range args {
+			id, err := env.backend.ResolveTrashedBugPrefix(prefix)
+			if err != nil {
+				return err
+			}
+			ids = append(ids, id)
+		}
+	}
+
+	if len(ids) == 0 {
+		env.out.Println("The trash is empty.")
+		return nil
+	}
+
+	if !opts.yes {
+		ok, err := input.PromptConfirm(fmt.Sprintf("Definitely remove %d bug(s)?", len(ids)))
+		if err != nil {
+			return err
+		}
+		if !ok {
+			env.out.Println("Aborted.")
+			return nil
+		}
+	}
+
+	for _, id := range ids {
+		err := env.backend.PurgeTrashedBug(id)
+		if err != nil {
+			return err
+		}
+	}
+
+	env.out.Printf("%d bug(s) purged\n", len(ids))
+
+	return nil
+}
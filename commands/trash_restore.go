@@ -0,0 +1,45 @@
+package commands
+
+import (
+	"errors"
+
+	"github.com/spf13/cobra"
+)
+
+func newTrashRestoreCommand() *cobra.Command {
+	env := newEnv()
+
+	cmd := &cobra.Command{
+		Use:      "restore ID...",
+		Short:    "Restore bugs from the trash.",
+		PreRunE:  loadBackendEnsureUser(env),
+		PostRunE: closeBackend(env),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runTrashRestore(env, args)
+		},
+	}
+
+	return cmd
+}
+
+func runTrashRestore(env *Env, args []string) error {
+	if len(args) == 0 {
+		return errors.New("you must provide the prefix of a bug of the trash")
+	}
+
+	for _, prefix := range args {
+		id, err := env.backend.ResolveTrashedBugPrefix(prefix)
+		if err != nil {
+			return err
+		}
+
+		err = env.backend.RestoreBug(id)
+		if err != nil {
+			return err
+		}
+
+		env.out.Printf("bug %s restored\n", id.Human())
+	}
+
+	return nil
+}
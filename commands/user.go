@@ -18,10 +18,11 @@ func newUserCommand() *cobra.Command {
 	options := userOptions{}
 
 	cmd := &cobra.Command{
-		Use:      "user [USER-ID]",
-		Short:    "Display or change the user identity.",
-		PreRunE:  loadBackendEnsureUser(env),
-		PostRunE: closeBackend(env),
+		Use:               "user [USER-ID]",
+		Short:             "Display or change the user identity.",
+		PreRunE:           loadBackendEnsureUser(env),
+		PostRunE:          closeBackend(env),
+		ValidArgsFunction: completeUserId(env),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runUser(env, options, args)
 		},
@@ -29,7 +30,12 @@ func newUserCommand() *cobra.Command {
 
 	cmd.AddCommand(newUserAdoptCommand())
 	cmd.AddCommand(newUserCreateCommand())
+	cmd.AddCommand(newUserExportCommand())
+	cmd.AddCommand(newUserImportCommand())
+	cmd.AddCommand(newUserKeyCommand())
 	cmd.AddCommand(newUserLsCommand())
+	cmd.AddCommand(newUserProofCommand())
+	cmd.AddCommand(newUserVerifyCommand())
 
 	flags := cmd.Flags()
 	flags.SortFlags = false
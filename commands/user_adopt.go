@@ -2,26 +2,45 @@ package commands
 
 import (
 	"github.com/spf13/cobra"
+
+	"github.com/MichaelMure/git-bug/identity"
 )
 
+type userAdoptOptions struct {
+	switchIdentity bool
+}
+
 func newUserAdoptCommand() *cobra.Command {
 	env := newEnv()
+	options := userAdoptOptions{}
 
 	cmd := &cobra.Command{
-		Use:      "adopt USER-ID",
-		Short:    "Adopt an existing identity as your own.",
-		Args:     cobra.ExactArgs(1),
-		PreRunE:  loadBackend(env),
-		PostRunE: closeBackend(env),
+		Use:   "adopt USER-ID",
+		Short: "Adopt an existing identity as your own.",
+		Long: `Adopt an existing identity as your own.
+
+If you don't have an identity yet, the given identity become yours. Otherwise, the immutable metadata of the given identity, like the login and the id on a remote bug tracker of an identity created by a bridge import, are attached to your identity, so that the future imports and exports resolve to you instead. Use --switch to make the given identity yours instead.`,
+		Example: `Claim the identity created by a GitHub import:
+git bug user adopt 5b3d1f9`,
+		Args:              cobra.ExactArgs(1),
+		PreRunE:           loadBackend(env),
+		PostRunE:          closeBackend(env),
+		ValidArgsFunction: completeUserId(env),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runUserAdopt(env, args)
+			return runUserAdopt(env, options, args)
 		},
 	}
 
+	flags := cmd.Flags()
+	flags.SortFlags = false
+
+	flags.BoolVarP(&options.switchIdentity, "switch", "s", false,
+		"Make the given identity yours, instead of attaching its metadata to yours")
+
 	return cmd
 }
 
-func runUserAdopt(env *Env, args []string) error {
+func runUserAdopt(env *Env, opts userAdoptOptions, args []string) error {
 	prefix := args[0]
 
 	i, err := env.backend.ResolveIdentityPrefix(prefix)
@@ -29,12 +48,33 @@ func runUserAdopt(env *Env, args []string) error {
 		return err
 	}
 
-	err = env.backend.SetUserIdentity(i)
+	user, err := env.backend.GetUserIdentity()
+	if err != nil && err != identity.ErrNoIdentitySet {
+		return err
+	}
+
+	if user == nil || opts.switchIdentity {
+		err = env.backend.SetUserIdentity(i)
+		if err != nil {
+			return err
+		}
+
+		env.out.Printf("Your identity is now: %s\n", i.DisplayName())
+
+		return nil
+	}
+
+	err = user.Adopt(i)
+	if err != nil {
+		return err
+	}
+
+	err = user.CommitAsNeeded()
 	if err != nil {
 		return err
 	}
 
-	env.out.Printf("Your identity is now: %s\n", i.DisplayName())
+	env.out.Printf("%s is now adopted by your identity %s\n", i.DisplayName(), user.DisplayName())
 
 	return nil
 }
@@ -0,0 +1,66 @@
+package commands
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/MichaelMure/git-bug/entity"
+)
+
+func newUserExportCommand() *cobra.Command {
+	env := newEnv()
+
+	cmd := &cobra.Command{
+		Use:   "export [USER-ID] FILE",
+		Short: "Write an identity in a git bundle, to import it in another repository.",
+		Long: `Write an identity in a git bundle, to import it in another repository with "git bug user import".
+
+The bundle holds the whole history of the identity, including its keys and metadata, so that it keep the same id in the other repository. Without USER-ID, the user identity is exported. Use - as the file to write on the standard output.`,
+		Example:  `git bug user export - | git -C ../other-repo bug user import --adopt -`,
+		PreRunE:  loadBackendReadOnly(env),
+		PostRunE: closeBackend(env),
+		Args:     cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runUserExport(env, args)
+		},
+	}
+
+	return cmd
+}
+
+func runUserExport(env *Env, args []string) error {
+	fileName := args[len(args)-1]
+
+	id, err := resolveUserOrSelf(env, args[:len(args)-1])
+	if err != nil {
+		return err
+	}
+
+	ids := []entity.Id{id.Id()}
+
+	if fileName == "-" {
+		return env.backend.CreateIdentityBundle(env.out, ids)
+	}
+
+	f, err := os.Create(fileName)
+	if err != nil {
+		return err
+	}
+
+	err = env.backend.CreateIdentityBundle(f, ids)
+	if err != nil {
+		_ = f.Close()
+		_ = os.Remove(fileName)
+		return err
+	}
+
+	err = f.Close()
+	if err != nil {
+		return err
+	}
+
+	env.err.Printf("Identity %s written to %s\n", id.DisplayName(), fileName)
+
+	return nil
+}
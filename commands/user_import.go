@@ -0,0 +1,100 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/MichaelMure/git-bug/entity"
+)
+
+type userImportOptions struct {
+	adopt bool
+}
+
+func newUserImportCommand() *cobra.Command {
+	env := newEnv()
+	options := userImportOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "import FILE",
+		Short: "Merge the identities of a git bundle written by \"git bug user export\".",
+		Long: `Merge the identities of a git bundle written by "git bug user export", keeping their id.
+
+An identity already in the repository is updated with the new versions of the bundle. Use - as the file to read the standard input.`,
+		PreRunE:  loadBackend(env),
+		PostRunE: closeBackend(env),
+		Args:     cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runUserImport(env, options, args[0])
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.SortFlags = false
+
+	flags.BoolVar(&options.adopt, "adopt", false,
+		"Set the imported identity as the user identity")
+
+	return cmd
+}
+
+func runUserImport(env *Env, opts userImportOptions, fileName string) error {
+	var r io.Reader = os.Stdin
+
+	if fileName != "-" {
+		f, err := os.Open(fileName)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		r = f
+	}
+
+	results, err := env.backend.ApplyBundle(r)
+	if err != nil {
+		return err
+	}
+
+	var imported []entity.Id
+	for result := range results {
+		if result.Err != nil {
+			env.err.Println(result.Err)
+			continue
+		}
+
+		if result.Status == entity.MergeStatusInvalid {
+			env.out.Printf("%s: %s\n", result.Id.Human(), result)
+			continue
+		}
+
+		imported = append(imported, result.Id)
+		if result.Status != entity.MergeStatusNothing {
+			env.out.Printf("%s: %s\n", result.Id.Human(), result)
+		}
+	}
+
+	if !opts.adopt {
+		return nil
+	}
+
+	if len(imported) != 1 {
+		return fmt.Errorf("can't adopt: expected one identity in the bundle, got %d", len(imported))
+	}
+
+	i, err := env.backend.ResolveIdentity(imported[0])
+	if err != nil {
+		return err
+	}
+
+	err = env.backend.SetUserIdentity(i)
+	if err != nil {
+		return err
+	}
+
+	env.out.Printf("Your identity is now: %s\n", i.DisplayName())
+
+	return nil
+}
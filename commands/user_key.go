@@ -0,0 +1,62 @@
+package commands
+
+import (
+	"errors"
+
+	"github.com/spf13/cobra"
+
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/identity"
+)
+
+func newUserKeyCommand() *cobra.Command {
+	env := newEnv()
+
+	cmd := &cobra.Command{
+		Use:               "key [USER-ID]",
+		Short:             "Display, add or remove the public keys of an identity.",
+		PreRunE:           loadBackendEnsureUser(env),
+		PostRunE:          closeBackend(env),
+		ValidArgsFunction: completeUserId(env),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runUserKey(env, args)
+		},
+	}
+
+	cmd.AddCommand(newUserKeyAddCommand())
+	cmd.AddCommand(newUserKeyAllowedSignersCommand())
+	cmd.AddCommand(newUserKeyRmCommand())
+	cmd.AddCommand(newUserKeyRotateCommand())
+
+	return cmd
+}
+
+func runUserKey(env *Env, args []string) error {
+	id, err := resolveUserOrSelf(env, args)
+	if err != nil {
+		return err
+	}
+
+	for _, key := range id.Keys() {
+		keyType := identity.KeyTypeOpenPGP
+		if key.IsSSH() {
+			keyType = identity.KeyTypeSSH
+		}
+		env.out.Printf("%s %s\n", keyType, key.Fingerprint)
+	}
+
+	return nil
+}
+
+// resolveUserOrSelf resolve the identity given as the only argument, or the
+// user identity if none
+func resolveUserOrSelf(env *Env, args []string) (*cache.IdentityCache, error) {
+	switch len(args) {
+	case 0:
+		return env.backend.GetUserIdentity()
+	case 1:
+		return env.backend.ResolveIdentityPrefix(args[0])
+	default:
+		return nil, errors.New("only one identity can be given at a time")
+	}
+}
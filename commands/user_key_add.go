@@ -0,0 +1,103 @@
+package commands
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/MichaelMure/git-bug/identity"
+)
+
+type userKeyAddOptions struct {
+	keyFile string
+}
+
+func newUserKeyAddCommand() *cobra.Command {
+	env := newEnv()
+	options := userKeyAddOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "add [USER-ID]",
+		Short: "Register an OpenPGP or SSH public key on an identity.",
+		Long: `Register a public key on an identity, either an armored OpenPGP key as exported by "gpg --export --armor <key-id>", or an SSH key as found in ~/.ssh/id_ed25519.pub.
+
+Once registered, the operations authored by this identity are signed with this key, and the operations pulled from a remote claiming this author are only accepted if signed with one of its registered keys. Signing with an SSH key require git 2.34 or later and the private key loaded in ssh-agent.
+
+Once an identity has keys, adding or removing one is signed with one of the keys already registered, so that only their owner can change them.`,
+		PreRunE:           loadBackendEnsureUser(env),
+		PostRunE:          closeBackend(env),
+		ValidArgsFunction: completeUserId(env),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runUserKeyAdd(env, options, args)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.SortFlags = false
+
+	flags.StringVarP(&options.keyFile, "file", "F", "",
+		"Take the public key from the given file. Use - to read it from the standard input")
+
+	return cmd
+}
+
+func runUserKeyAdd(env *Env, opts userKeyAddOptions, args []string) error {
+	id, err := resolveUserOrSelf(env, args)
+	if err != nil {
+		return err
+	}
+
+	key, err := readKeyFile(opts.keyFile)
+	if err != nil {
+		return err
+	}
+
+	for _, k := range id.Keys() {
+		if k.Fingerprint == key.Fingerprint {
+			return fmt.Errorf("key %s is already registered", key.Fingerprint)
+		}
+	}
+
+	err = id.Mutate(func(orig identity.Mutator) identity.Mutator {
+		orig.Keys = append(orig.Keys, key)
+		return orig
+	})
+	if err != nil {
+		return err
+	}
+
+	err = id.Commit()
+	if err != nil {
+		return err
+	}
+
+	env.out.Printf("Key %s added to %s\n", key.Fingerprint, id.DisplayName())
+
+	return nil
+}
+
+// readKeyFile read an armored OpenPGP public key or an SSH public key from a
+// file, or from the standard input for -
+func readKeyFile(path string) (*identity.Key, error) {
+	var raw []byte
+	var err error
+	switch path {
+	case "":
+		return nil, fmt.Errorf("a public key must be given with --file")
+	case "-":
+		raw, err = ioutil.ReadAll(os.Stdin)
+	default:
+		raw, err = ioutil.ReadFile(path)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if strings.HasPrefix(strings.TrimSpace(string(raw)), "-----BEGIN PGP") {
+		return identity.NewKeyFromArmored(string(raw))
+	}
+	return identity.NewKeyFromSSH(string(raw))
+}
@@ -0,0 +1,67 @@
+package commands
+
+import (
+	"bytes"
+	"io/ioutil"
+
+	"github.com/spf13/cobra"
+
+	"github.com/MichaelMure/git-bug/identity"
+)
+
+type userKeyAllowedSignersOptions struct {
+	output string
+}
+
+func newUserKeyAllowedSignersCommand() *cobra.Command {
+	env := newEnv()
+	options := userKeyAllowedSignersOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "allowed-signers",
+		Short: "Export the SSH keys of all the identities as git allowed signers.",
+		Long: `Export the SSH keys registered on all the identities in the format of the allowed signers file of git, so that git itself trust them when verifying the signatures.
+
+To use it, point git to the file:
+git config gpg.ssh.allowedSignersFile <file>`,
+		Example:  `git bug user key allowed-signers --output .git/git-bug/allowed_signers`,
+		PreRunE:  loadBackendReadOnly(env),
+		PostRunE: closeBackend(env),
+		Args:     cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runUserKeyAllowedSigners(env, options)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.SortFlags = false
+
+	flags.StringVarP(&options.output, "output", "o", "",
+		"Write the allowed signers to the given file instead of the standard output")
+
+	return cmd
+}
+
+func runUserKeyAllowedSigners(env *Env, opts userKeyAllowedSignersOptions) error {
+	var identities []identity.Interface
+	for _, id := range env.backend.AllIdentityIds() {
+		i, err := env.backend.ResolveIdentity(id)
+		if err != nil {
+			return err
+		}
+		identities = append(identities, i)
+	}
+
+	var buf bytes.Buffer
+	err := identity.WriteAllowedSigners(&buf, identities)
+	if err != nil {
+		return err
+	}
+
+	if opts.output == "" {
+		env.out.Print(buf.String())
+		return nil
+	}
+
+	return ioutil.WriteFile(opts.output, buf.Bytes(), 0644)
+}
@@ -0,0 +1,63 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/MichaelMure/git-bug/identity"
+)
+
+func newUserKeyRmCommand() *cobra.Command {
+	env := newEnv()
+
+	cmd := &cobra.Command{
+		Use:      "rm FINGERPRINT [USER-ID]",
+		Short:    "Remove a public key from an identity.",
+		Args:     cobra.RangeArgs(1, 2),
+		PreRunE:  loadBackendEnsureUser(env),
+		PostRunE: closeBackend(env),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runUserKeyRm(env, args)
+		},
+	}
+
+	return cmd
+}
+
+func runUserKeyRm(env *Env, args []string) error {
+	fingerprint := args[0]
+
+	id, err := resolveUserOrSelf(env, args[1:])
+	if err != nil {
+		return err
+	}
+
+	var keys []*identity.Key
+	for _, key := range id.Keys() {
+		if !strings.EqualFold(key.Fingerprint, fingerprint) {
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) == len(id.Keys()) {
+		return fmt.Errorf("no key %s registered for %s", fingerprint, id.DisplayName())
+	}
+
+	err = id.Mutate(func(orig identity.Mutator) identity.Mutator {
+		orig.Keys = keys
+		return orig
+	})
+	if err != nil {
+		return err
+	}
+
+	err = id.Commit()
+	if err != nil {
+		return err
+	}
+
+	env.out.Printf("Key %s removed from %s\n", fingerprint, id.DisplayName())
+
+	return nil
+}
@@ -0,0 +1,90 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/MichaelMure/git-bug/identity"
+)
+
+type userKeyRotateOptions struct {
+	keyFile string
+}
+
+func newUserKeyRotateCommand() *cobra.Command {
+	env := newEnv()
+	options := userKeyRotateOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "rotate FINGERPRINT [USER-ID]",
+		Short: "Replace a public key of an identity by a new one.",
+		Long: `Replace a public key of an identity by a new one, in a new version of the identity signed by the replaced key.
+
+The operations signed with the replaced key before the rotation stay valid, the new ones have to be signed with the new key.`,
+		Example:  `git bug user key rotate 6D2F5A6B8B9D3C1E --file new-key.asc`,
+		Args:     cobra.RangeArgs(1, 2),
+		PreRunE:  loadBackendEnsureUser(env),
+		PostRunE: closeBackend(env),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runUserKeyRotate(env, options, args)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.SortFlags = false
+
+	flags.StringVarP(&options.keyFile, "file", "F", "",
+		"Take the new public key from the given file. Use - to read it from the standard input")
+
+	return cmd
+}
+
+func runUserKeyRotate(env *Env, opts userKeyRotateOptions, args []string) error {
+	fingerprint := args[0]
+
+	id, err := resolveUserOrSelf(env, args[1:])
+	if err != nil {
+		return err
+	}
+
+	key, err := readKeyFile(opts.keyFile)
+	if err != nil {
+		return err
+	}
+
+	found := false
+	keys := make([]*identity.Key, 0, len(id.Keys()))
+	for _, k := range id.Keys() {
+		switch {
+		case k.Fingerprint == key.Fingerprint:
+			return fmt.Errorf("key %s is already registered", key.Fingerprint)
+		case strings.EqualFold(k.Fingerprint, fingerprint):
+			found = true
+			keys = append(keys, key)
+		default:
+			keys = append(keys, k)
+		}
+	}
+	if !found {
+		return fmt.Errorf("no key %s registered for %s", fingerprint, id.DisplayName())
+	}
+
+	err = id.Mutate(func(orig identity.Mutator) identity.Mutator {
+		orig.Keys = keys
+		return orig
+	})
+	if err != nil {
+		return err
+	}
+
+	err = id.Commit()
+	if err != nil {
+		return err
+	}
+
+	env.out.Printf("Key %s replaced by %s for %s\n", fingerprint, key.Fingerprint, id.DisplayName())
+
+	return nil
+}
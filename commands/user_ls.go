@@ -21,7 +21,7 @@ func newUserLsCommand() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:      "ls",
 		Short:    "List identities.",
-		PreRunE:  loadBackend(env),
+		PreRunE:  loadBackendReadOnly(env),
 		PostRunE: closeBackend(env),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runUserLs(env, options)
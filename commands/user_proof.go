@@ -0,0 +1,38 @@
+package commands
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func newUserProofCommand() *cobra.Command {
+	env := newEnv()
+
+	cmd := &cobra.Command{
+		Use:               "proof [USER-ID]",
+		Short:             "Display, add or remove the proofs of control of external accounts of an identity.",
+		PreRunE:           loadBackendEnsureUser(env),
+		PostRunE:          closeBackend(env),
+		ValidArgsFunction: completeUserId(env),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runUserProof(env, args)
+		},
+	}
+
+	cmd.AddCommand(newUserProofAddCommand())
+	cmd.AddCommand(newUserProofRmCommand())
+
+	return cmd
+}
+
+func runUserProof(env *Env, args []string) error {
+	id, err := resolveUserOrSelf(env, args)
+	if err != nil {
+		return err
+	}
+
+	for _, p := range id.Proofs() {
+		env.out.Printf("%s %s (key %s)\n", p.Service, p.Name, p.Key)
+	}
+
+	return nil
+}
@@ -0,0 +1,147 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/MichaelMure/git-bug/identity"
+	"github.com/MichaelMure/git-bug/proof"
+)
+
+type userProofAddOptions struct {
+	url string
+	key string
+}
+
+func newUserProofAddCommand() *cobra.Command {
+	env := newEnv()
+	options := userProofAddOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "add SERVICE NAME",
+		Short: "Prove that your identity controls a GitHub login, a domain or an email address.",
+		Long: `Prove that your identity controls a GitHub login, a domain or an email address, with a statement signed by one of its keys.
+
+The valid services are [github,domain,email]:
+- github: the signed statement is published in a public gist of the login, given with --url as its raw URL
+- domain: the signed statement is published at https://<domain>` + proof.DomainPath + `
+- email: the email address is a user id of the OpenPGP key signing the statement
+
+For github and domain, a first run prints the signed statement to publish, and running the same command once it is published records the proof.`,
+		Example: `git bug user proof add domain example.com
+git bug user proof add github rene --url https://gist.githubusercontent.com/rene/...`,
+		PreRunE:  loadBackendEnsureUser(env),
+		PostRunE: closeBackend(env),
+		Args:     cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runUserProofAdd(env, options, args)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.SortFlags = false
+
+	flags.StringVarP(&options.url, "url", "u", "",
+		"The raw URL of the gist holding the signed statement, for github")
+	flags.StringVarP(&options.key, "key", "k", "",
+		"The fingerprint of the key signing the statement, the first key of the identity by default")
+
+	return cmd
+}
+
+func runUserProofAdd(env *Env, opts userProofAddOptions, args []string) error {
+	id, err := env.backend.GetUserIdentity()
+	if err != nil {
+		return err
+	}
+
+	var key *identity.Key
+	for _, k := range id.Keys() {
+		if opts.key == "" || strings.EqualFold(k.Fingerprint, opts.key) {
+			key = k
+			break
+		}
+	}
+	if key == nil {
+		return fmt.Errorf("no key to sign the statement, register one with \"git bug user key add\"")
+	}
+
+	p := identity.Proof{
+		Service: identity.ProofService(args[0]),
+		Name:    args[1],
+		Key:     key.Fingerprint,
+		URL:     opts.url,
+	}
+	if err := p.Service.Validate(); err != nil {
+		return err
+	}
+	if p.Service == identity.ProofServiceGitHub && p.URL == "" {
+		return fmt.Errorf("the raw URL of the gist must be given with --url")
+	}
+
+	var result *identity.Proof
+
+	if p.Service == identity.ProofServiceEmail {
+		p.Signature, err = proof.Sign(key, p.Statement(id.Id()))
+		if err != nil {
+			return err
+		}
+		err = proof.Verify(context.Background(), nil, id, &p)
+		if err != nil {
+			return err
+		}
+		result = &p
+	} else {
+		client := &http.Client{Timeout: 30 * time.Second}
+		result, err = proof.FindPublished(context.Background(), client, id, p)
+		if err != nil {
+			// not published yet
+			signature, err := proof.Sign(key, p.Statement(id.Id()))
+			if err != nil {
+				return err
+			}
+			env.out.Printf("Publish this signed statement at %s, then run this command again:\n\n%s", publishHint(&p), signature)
+			return nil
+		}
+	}
+
+	err = id.Mutate(func(orig identity.Mutator) identity.Mutator {
+		orig.Proofs = append(withoutProof(orig.Proofs, result.Service, result.Name), result)
+		return orig
+	})
+	if err != nil {
+		return err
+	}
+
+	err = id.Commit()
+	if err != nil {
+		return err
+	}
+
+	env.out.Printf("Proof of the %s %s added to %s\n", result.Service, result.Name, id.DisplayName())
+
+	return nil
+}
+
+func publishHint(p *identity.Proof) string {
+	if p.Service == identity.ProofServiceGitHub {
+		return fmt.Sprintf("a public gist of %s (%s)", p.Name, p.URL)
+	}
+	return proof.PublishURL(p)
+}
+
+// withoutProof return the proofs except the one for the given account
+func withoutProof(proofs []*identity.Proof, service identity.ProofService, name string) []*identity.Proof {
+	var result []*identity.Proof
+	for _, p := range proofs {
+		if p.Service != service || !strings.EqualFold(p.Name, name) {
+			result = append(result, p)
+		}
+	}
+	return result
+}
@@ -0,0 +1,57 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/MichaelMure/git-bug/identity"
+)
+
+func newUserProofRmCommand() *cobra.Command {
+	env := newEnv()
+
+	cmd := &cobra.Command{
+		Use:      "rm SERVICE NAME",
+		Short:    "Remove a proof of control of an external account from your identity.",
+		PreRunE:  loadBackendEnsureUser(env),
+		PostRunE: closeBackend(env),
+		Args:     cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runUserProofRm(env, args)
+		},
+	}
+
+	return cmd
+}
+
+func runUserProofRm(env *Env, args []string) error {
+	service, name := identity.ProofService(args[0]), args[1]
+
+	id, err := env.backend.GetUserIdentity()
+	if err != nil {
+		return err
+	}
+
+	proofs := withoutProof(id.Proofs(), service, name)
+	if len(proofs) == len(id.Proofs()) {
+		return fmt.Errorf("no proof of the %s %s", service, name)
+	}
+
+	err = id.Mutate(func(orig identity.Mutator) identity.Mutator {
+		orig.Proofs = proofs
+		return orig
+	})
+	if err != nil {
+		return err
+	}
+
+	err = id.Commit()
+	if err != nil {
+		return err
+	}
+
+	env.out.Printf("Proof of the %s %s removed from %s\n", service, name, id.DisplayName())
+
+	return nil
+}
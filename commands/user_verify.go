@@ -0,0 +1,73 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/MichaelMure/git-bug/proof"
+	"github.com/MichaelMure/git-bug/util/colors"
+)
+
+type userVerifyOptions struct {
+	offline bool
+}
+
+func newUserVerifyCommand() *cobra.Command {
+	env := newEnv()
+	options := userVerifyOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "verify [USER-ID]",
+		Short: "Verify the proofs of control of external accounts of an identity.",
+		Long: `Verify the proofs of control of external accounts of an identity: that they are signed by one of its keys, and that the signed statements are still published by the claimed GitHub login or domain.
+
+Fail if any proof doesn't verify.`,
+		PreRunE:           loadBackendReadOnly(env),
+		PostRunE:          closeBackend(env),
+		ValidArgsFunction: completeUserId(env),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runUserVerify(env, options, args)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.SortFlags = false
+
+	flags.BoolVar(&options.offline, "offline", false,
+		"Only verify the signatures, without checking the publications")
+
+	return cmd
+}
+
+func runUserVerify(env *Env, opts userVerifyOptions, args []string) error {
+	id, err := resolveUserOrSelf(env, args)
+	if err != nil {
+		return err
+	}
+
+	var client *http.Client
+	if !opts.offline {
+		client = &http.Client{Timeout: 30 * time.Second}
+	}
+
+	failed := 0
+	for _, p := range id.Proofs() {
+		err := proof.Verify(context.Background(), client, id, p)
+		if err != nil {
+			failed++
+			env.out.Printf("%s %s %s: %v\n", colors.Red("✗"), p.Service, p.Name, err)
+			continue
+		}
+		env.out.Printf("%s %s %s\n", colors.Green("✓"), p.Service, p.Name)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d proof(s) failed the verification", failed)
+	}
+
+	return nil
+}
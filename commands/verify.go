@@ -0,0 +1,90 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/MichaelMure/git-bug/bug"
+	_select "github.com/MichaelMure/git-bug/commands/select"
+	"github.com/MichaelMure/git-bug/repository"
+	"github.com/MichaelMure/git-bug/util/colors"
+)
+
+func newVerifyCommand() *cobra.Command {
+	env := newEnv()
+
+	cmd := &cobra.Command{
+		Use:   "verify [ID]",
+		Short: "Verify the signatures of the operations of a bug.",
+		Long: `Verify the signature of each commit of a bug, both with git (keyring and trust of the user) and against the public keys registered on the identities of the authors.
+
+Fail if a signature is bad, or if an author with registered keys didn't sign their operations.`,
+		PreRunE:           loadBackendReadOnly(env),
+		PostRunE:          closeBackend(env),
+		ValidArgsFunction: completeBug(env),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runVerify(env, args)
+		},
+	}
+
+	return cmd
+}
+
+func runVerify(env *Env, args []string) error {
+	b, _, err := _select.ResolveBug(env.backend, args)
+	if err != nil {
+		return err
+	}
+
+	signatures, err := b.Signatures()
+	if err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, signature := range signatures {
+		authors := signature.Authors()
+		names := make([]string, len(authors))
+		for i, author := range authors {
+			names[i] = author.DisplayName()
+		}
+
+		author := signature.AuthorStatus.String()
+		switch signature.AuthorStatus {
+		case bug.AuthorVerified:
+			author = colors.Green(fmt.Sprintf("%s (%s)", author, signature.AuthorKey))
+		case bug.AuthorUnsigned, bug.AuthorMismatch:
+			author = colors.Red(author)
+		}
+
+		status := signature.Status.String()
+		if signature.Status == repository.SignatureBad {
+			status = colors.Red(status)
+		}
+		if signature.Signer != "" {
+			status += fmt.Sprintf(" (%s)", signature.Signer)
+		}
+
+		if signature.Status == repository.SignatureBad ||
+			signature.AuthorStatus == bug.AuthorUnsigned ||
+			signature.AuthorStatus == bug.AuthorMismatch {
+			failed++
+		}
+
+		env.out.Printf("%s %s, %d operation(s)\n\tgit: %s\n\tauthor: %s\n",
+			colors.Cyan(signature.Commit.String()[:7]),
+			strings.Join(names, ", "),
+			len(signature.Operations),
+			status,
+			author,
+		)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d commit(s) failed the verification", failed)
+	}
+
+	return nil
+}
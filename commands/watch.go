@@ -0,0 +1,95 @@
+package commands
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	_select "github.com/MichaelMure/git-bug/commands/select"
+	"github.com/MichaelMure/git-bug/identity"
+)
+
+type watchOptions struct {
+	ignore bool
+	remove bool
+}
+
+func newWatchCommand() *cobra.Command {
+	env := newEnv()
+	options := watchOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "watch [ID]",
+		Short: "Watch or ignore a bug, the bugs with a label or the bugs matching a query.",
+		Long: `Watch or ignore a bug, the bugs with a label or the bugs matching a query.
+
+The changes made by the identities you muted are not notified, and the notifications are sent to your endpoints.
+The preferences are stored along your identity, so they follow you across machines once pushed.`,
+		PreRunE:           loadBackendEnsureUser(env),
+		PostRunE:          closeBackend(env),
+		ValidArgsFunction: completeBug(env),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runWatch(env, options, args)
+		},
+	}
+
+	addWatchFlags(cmd.Flags(), &options)
+
+	cmd.AddCommand(newWatchEndpointCommand())
+	cmd.AddCommand(newWatchLabelCommand())
+	cmd.AddCommand(newWatchLsCommand())
+	cmd.AddCommand(newWatchMuteCommand())
+	cmd.AddCommand(newWatchQueryCommand())
+	cmd.AddCommand(newWatchStreamCommand())
+
+	return cmd
+}
+
+func addWatchFlags(flags *pflag.FlagSet, options *watchOptions) {
+	flags.SortFlags = false
+
+	flags.BoolVarP(&options.ignore, "ignore", "i", false,
+		"Ignore instead of watching")
+	flags.BoolVarP(&options.remove, "remove", "r", false,
+		"Remove the preference instead of setting it")
+}
+
+func (opts watchOptions) level() identity.WatchLevel {
+	switch {
+	case opts.remove:
+		return identity.WatchLevelNone
+	case opts.ignore:
+		return identity.WatchLevelIgnore
+	default:
+		return identity.WatchLevelWatch
+	}
+}
+
+func runWatch(env *Env, opts watchOptions, args []string) error {
+	b, _, err := _select.ResolveBug(env.backend, args)
+	if err != nil {
+		return err
+	}
+
+	return updateNotifications(env, func(prefs *identity.NotificationPreferences) {
+		prefs.SetBug(b.Id(), opts.level())
+	})
+}
+
+// updateNotifications change the notification preferences of the user and
+// commit the new version of the identity
+func updateNotifications(env *Env, f func(prefs *identity.NotificationPreferences)) error {
+	user, err := env.backend.GetUserIdentity()
+	if err != nil {
+		return err
+	}
+
+	err = user.Mutate(func(orig identity.Mutator) identity.Mutator {
+		f(&orig.Notifications)
+		return orig
+	})
+	if err != nil {
+		return err
+	}
+
+	return user.CommitAsNeeded()
+}
@@ -0,0 +1,69 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/MichaelMure/git-bug/identity"
+)
+
+type watchEndpointOptions struct {
+	remove bool
+}
+
+func newWatchEndpointCommand() *cobra.Command {
+	env := newEnv()
+	options := watchEndpointOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "endpoint TYPE TARGET",
+		Short: "Add or remove a destination of your notifications.",
+		Long: `Add or remove a destination of your notifications, an email address or the URL of a webhook.
+
+The valid types are [email,webhook]. The webhook endpoints receive the changes of the bugs you watch when "git bug daemon" is running, the same way as the webhooks of the repository.`,
+		Example: `git bug watch endpoint webhook https://chat.example.com/hooks/rene
+git bug watch endpoint email rene@descartes.fr`,
+		PreRunE:  loadBackendEnsureUser(env),
+		PostRunE: closeBackend(env),
+		Args:     cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runWatchEndpoint(env, options, args)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.SortFlags = false
+
+	flags.BoolVarP(&options.remove, "remove", "r", false,
+		"Remove the endpoint instead of adding it")
+
+	return cmd
+}
+
+func runWatchEndpoint(env *Env, opts watchEndpointOptions, args []string) error {
+	endpoint := identity.NotificationEndpoint{
+		Type:   identity.EndpointType(args[0]),
+		Target: args[1],
+	}
+	if err := endpoint.Validate(); err != nil {
+		return err
+	}
+
+	found := true
+	err := updateNotifications(env, func(prefs *identity.NotificationPreferences) {
+		if opts.remove {
+			found = prefs.RemoveEndpoint(endpoint)
+		} else {
+			prefs.AddEndpoint(endpoint)
+		}
+	})
+	if err != nil {
+		return err
+	}
+	if !found {
+		return fmt.Errorf("no %s endpoint %s", endpoint.Type, endpoint.Target)
+	}
+
+	return nil
+}
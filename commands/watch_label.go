@@ -0,0 +1,40 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/MichaelMure/git-bug/identity"
+)
+
+func newWatchLabelCommand() *cobra.Command {
+	env := newEnv()
+	options := watchOptions{}
+
+	cmd := &cobra.Command{
+		Use:      "label LABEL...",
+		Short:    "Watch or ignore the bugs with a label.",
+		PreRunE:  loadBackendEnsureUser(env),
+		PostRunE: closeBackend(env),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runWatchLabel(env, options, args)
+		},
+	}
+
+	addWatchFlags(cmd.Flags(), &options)
+
+	return cmd
+}
+
+func runWatchLabel(env *Env, opts watchOptions, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("no label given")
+	}
+
+	return updateNotifications(env, func(prefs *identity.NotificationPreferences) {
+		for _, label := range args {
+			prefs.SetLabel(label, opts.level())
+		}
+	})
+}
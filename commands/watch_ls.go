@@ -0,0 +1,88 @@
+package commands
+
+import (
+	"sort"
+
+	"github.com/spf13/cobra"
+
+	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/identity"
+	"github.com/MichaelMure/git-bug/util/colors"
+)
+
+func newWatchLsCommand() *cobra.Command {
+	env := newEnv()
+
+	cmd := &cobra.Command{
+		Use:      "ls",
+		Short:    "List your notification preferences.",
+		PreRunE:  loadBackendEnsureUser(env),
+		PostRunE: closeBackend(env),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runWatchLs(env)
+		},
+	}
+
+	return cmd
+}
+
+func runWatchLs(env *Env) error {
+	user, err := env.backend.GetUserIdentity()
+	if err != nil {
+		return err
+	}
+
+	prefs := user.NotificationPreferences()
+
+	ids := make([]entity.Id, 0, len(prefs.Bugs))
+	for id := range prefs.Bugs {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	for _, id := range ids {
+		title := ""
+		if excerpt, err := env.backend.ResolveBugExcerpt(id); err == nil {
+			title = excerpt.Title
+		}
+		env.out.Printf("%s bug   %s %s\n", watchLevelText(prefs.Bugs[id]), colors.Cyan(id.Human()), title)
+	}
+
+	for _, label := range sortedWatchKeys(prefs.Labels) {
+		env.out.Printf("%s label %s\n", watchLevelText(prefs.Labels[label]), label)
+	}
+
+	for _, q := range sortedWatchKeys(prefs.Queries) {
+		env.out.Printf("%s query %s\n", watchLevelText(prefs.Queries[q]), q)
+	}
+
+	for _, id := range prefs.Muted {
+		name := ""
+		if excerpt, err := env.backend.ResolveIdentityExcerpt(id); err == nil {
+			name = excerpt.DisplayName()
+		}
+		env.out.Printf("%s user  %s %s\n", colors.Red("mute  "), colors.Cyan(id.Human()), name)
+	}
+
+	for _, endpoint := range prefs.Endpoints {
+		env.out.Printf("%s %-5s %s\n", colors.Blue("send  "), endpoint.Type, endpoint.Target)
+	}
+
+	return nil
+}
+
+func watchLevelText(level identity.WatchLevel) string {
+	if level == identity.WatchLevelIgnore {
+		return colors.Red("ignore")
+	}
+	return colors.Green("watch ")
+}
+
+func sortedWatchKeys(m map[string]identity.WatchLevel) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
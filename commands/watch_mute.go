@@ -0,0 +1,59 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/identity"
+)
+
+type watchMuteOptions struct {
+	remove bool
+}
+
+func newWatchMuteCommand() *cobra.Command {
+	env := newEnv()
+	options := watchMuteOptions{}
+
+	cmd := &cobra.Command{
+		Use:               "mute USER-ID...",
+		Short:             "Stop being notified of the changes made by some identities.",
+		PreRunE:           loadBackendEnsureUser(env),
+		PostRunE:          closeBackend(env),
+		ValidArgsFunction: completeUserId(env),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runWatchMute(env, options, args)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.SortFlags = false
+
+	flags.BoolVarP(&options.remove, "remove", "r", false,
+		"Unmute instead of muting")
+
+	return cmd
+}
+
+func runWatchMute(env *Env, opts watchMuteOptions, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("no identity given")
+	}
+
+	ids := make([]entity.Id, len(args))
+	for i, prefix := range args {
+		id, err := env.backend.ResolveIdentityPrefix(prefix)
+		if err != nil {
+			return err
+		}
+		ids[i] = id.Id()
+	}
+
+	return updateNotifications(env, func(prefs *identity.NotificationPreferences) {
+		for _, id := range ids {
+			prefs.SetMuted(id, !opts.remove)
+		}
+	})
+}
@@ -0,0 +1,49 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/MichaelMure/git-bug/identity"
+	"github.com/MichaelMure/git-bug/query"
+)
+
+func newWatchQueryCommand() *cobra.Command {
+	env := newEnv()
+	options := watchOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "query QUERY",
+		Short: "Watch or ignore the bugs matching a query.",
+		Example: `Watch the open bugs about the UI:
+git bug watch query "status:open label:ui"`,
+		PreRunE:  loadBackendEnsureUser(env),
+		PostRunE: closeBackend(env),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runWatchQuery(env, options, args)
+		},
+	}
+
+	addWatchFlags(cmd.Flags(), &options)
+
+	return cmd
+}
+
+func runWatchQuery(env *Env, opts watchOptions, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("no query given")
+	}
+
+	raw := strings.Join(args, " ")
+
+	// only store valid queries
+	if _, err := query.Parse(raw); err != nil {
+		return queryError(err)
+	}
+
+	return updateNotifications(env, func(prefs *identity.NotificationPreferences) {
+		prefs.SetQuery(raw, opts.level())
+	})
+}
@@ -0,0 +1,278 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/query"
+	"github.com/MichaelMure/git-bug/util/colors"
+)
+
+type watchStreamOptions struct {
+	interval     time.Duration
+	watched      bool
+	outputFormat string
+}
+
+// JSONWatchEvent is a change of a bug, as streamed by "watch stream --format json"
+type JSONWatchEvent struct {
+	Id      string    `json:"id"`
+	HumanId string    `json:"human_id"`
+	Event   string    `json:"event"`
+	Details string    `json:"details,omitempty"`
+	Title   string    `json:"title"`
+	Status  string    `json:"status"`
+	Time    time.Time `json:"time"`
+}
+
+func newWatchStreamCommand() *cobra.Command {
+	env := newEnv()
+	options := watchStreamOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "stream [QUERY]",
+		Short: "Print the changes of the bugs as they happen.",
+		Long: `Print the changes of the bugs as they happen.
+
+The new bugs, the comments, and the changes of status, title and labels are printed as they are made, either
+locally or by another process like a pull. If a query is given, only the bugs matching it, or matching it
+before the change, are reported. With --watched, only the changes you should be notified of according to your
+preferences are reported: on the bugs you watch, and not made by you or by an identity you muted.
+
+With --format json, each change is printed as a JSON object on its own line.`,
+		Example: `Get notified of the new comments on the open bugs:
+git bug watch stream status:open --format json | while read -r event; do
+    notify-send "git-bug" "$(echo "$event" | jq -r '.event + ": " + .title')"
+done`,
+		PreRunE:  loadBackendReadOnly(env),
+		PostRunE: closeBackend(env),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runWatchStream(env, options, args)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.SortFlags = false
+
+	flags.DurationVarP(&options.interval, "interval", "i", 0,
+		"How often to check the repository for changes made by other processes (default 1s)")
+	flags.BoolVarP(&options.watched, "watched", "w", false,
+		"Only report the changes you should be notified of according to your preferences")
+	flags.StringVarP(&options.outputFormat, "format", "f", "default",
+		"Select the output formatting style. Valid values are [default,json]")
+
+	return cmd
+}
+
+func runWatchStream(env *Env, opts watchStreamOptions, args []string) error {
+	switch opts.outputFormat {
+	case "default", "json":
+	default:
+		return fmt.Errorf("unknown format %s", opts.outputFormat)
+	}
+
+	var q *query.Query
+	if len(args) > 0 {
+		saved, err := query.ReadSavedQueries(env.repo.LocalConfig())
+		if err != nil {
+			return err
+		}
+		q, err = query.ParseWithSaved(strings.Join(args, " "), saved)
+		if err != nil {
+			return queryError(err)
+		}
+	}
+
+	var user *cache.IdentityCache
+	if opts.watched {
+		var err error
+		user, err = env.backend.GetUserIdentity()
+		if err != nil {
+			return err
+		}
+	}
+
+	// subscribe before taking the snapshot, to not miss a change in between
+	changes, unsubscribe := env.backend.Changes()
+	defer unsubscribe()
+
+	known := make(map[entity.Id]*cache.BugExcerpt)
+	matching := make(map[entity.Id]bool)
+	for _, id := range env.backend.AllBugsIds() {
+		excerpt, err := env.backend.ResolveBugExcerpt(id)
+		if err != nil {
+			return err
+		}
+		known[id] = excerpt
+		matching[id], err = env.backend.MatchBug(q, id)
+		if err != nil {
+			return err
+		}
+	}
+
+	err := env.backend.Watch(opts.interval)
+	if err != nil {
+		return err
+	}
+	defer env.backend.StopWatching()
+
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(quit)
+
+	for {
+		var change cache.ChangeEvent
+		select {
+		case <-quit:
+			return nil
+		case change = <-changes:
+		}
+
+		if change.Target != "bugs" {
+			continue
+		}
+
+		previous := known[change.Id]
+		matched := matching[change.Id]
+
+		if change.Typ == cache.ChangeEventRemoved {
+			delete(known, change.Id)
+			delete(matching, change.Id)
+			if previous != nil && matched {
+				err = printWatchEvent(env, opts, previous, "removed", "")
+				if err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		excerpt, err := env.backend.ResolveBugExcerpt(change.Id)
+		if err != nil {
+			// removed in the meantime
+			continue
+		}
+		match, err := env.backend.MatchBug(q, change.Id)
+		if err != nil {
+			continue
+		}
+
+		known[change.Id] = excerpt
+		matching[change.Id] = match
+
+		if !match && !matched {
+			continue
+		}
+
+		if user != nil {
+			notify, err := env.backend.ShouldNotify(user, change.Id)
+			if err != nil || !notify {
+				continue
+			}
+		}
+
+		for _, event := range diffBugExcerpts(previous, excerpt) {
+			err = printWatchEvent(env, opts, excerpt, event[0], event[1])
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// diffBugExcerpts list the changes between two states of a bug, as pairs of
+// event name and details. A nil previous state means a new bug.
+func diffBugExcerpts(previous, current *cache.BugExcerpt) [][2]string {
+	if previous == nil {
+		return [][2]string{{"created", ""}}
+	}
+
+	var events [][2]string
+
+	// the first comment is the description of the bug
+	if n := current.LenComments - previous.LenComments; n > 0 {
+		details := ""
+		if n > 1 {
+			details = fmt.Sprintf("%d comments", n)
+		}
+		events = append(events, [2]string{"commented", details})
+	}
+
+	if current.Status != previous.Status {
+		events = append(events, [2]string{current.Status.Action(), ""})
+	}
+
+	if current.Title != previous.Title {
+		events = append(events, [2]string{"title changed", fmt.Sprintf("was %q", previous.Title)})
+	}
+
+	old := make(map[string]bool)
+	for _, l := range previous.Labels {
+		old[l.String()] = true
+	}
+	var changes []string
+	for _, l := range current.Labels {
+		if !old[l.String()] {
+			changes = append(changes, "+"+l.String())
+		}
+		delete(old, l.String())
+	}
+	for _, l := range previous.Labels {
+		if old[l.String()] {
+			changes = append(changes, "-"+l.String())
+		}
+	}
+	if len(changes) > 0 {
+		events = append(events, [2]string{"labels changed", strings.Join(changes, " ")})
+	}
+
+	if len(events) == 0 && current.EditLamportTime != previous.EditLamportTime {
+		events = append(events, [2]string{"updated", ""})
+	}
+
+	return events
+}
+
+func printWatchEvent(env *Env, opts watchStreamOptions, excerpt *cache.BugExcerpt, event string, details string) error {
+	editTime := time.Unix(excerpt.EditUnixTime, 0)
+
+	if opts.outputFormat == "json" {
+		data, err := json.Marshal(JSONWatchEvent{
+			Id:      excerpt.Id.String(),
+			HumanId: excerpt.Id.Human(),
+			Event:   event,
+			Details: details,
+			Title:   excerpt.Title,
+			Status:  excerpt.Status.String(),
+			Time:    editTime,
+		})
+		if err != nil {
+			return err
+		}
+		env.out.Println(string(data))
+		return nil
+	}
+
+	if details != "" {
+		details = " (" + details + ")"
+	}
+
+	env.out.Printf("%s %s %s%s: %s\n",
+		editTime.Format(time.RFC3339),
+		colors.Cyan(excerpt.Id.Human()),
+		colors.Yellow(event),
+		details,
+		excerpt.Title,
+	)
+
+	return nil
+}
@@ -0,0 +1,66 @@
+package commands
+
+import (
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/MichaelMure/git-bug/util/colors"
+	"github.com/MichaelMure/git-bug/webhook"
+)
+
+func newWebhookCommand() *cobra.Command {
+	env := newEnv()
+
+	cmd := &cobra.Command{
+		Use:   "webhook",
+		Short: "List the webhooks receiving the changes of the bugs.",
+		Long: `List the webhooks receiving the changes of the bugs.
+
+The webhooks are sent by "git bug daemon" and "git bug webui" while they run, as a POST of a JSON payload
+for each new bug, comment, change of status, title or labels. If the webhook has a secret, the payload is
+signed in the X-Git-Bug-Signature header with "sha256=" followed by the hex encoded HMAC-SHA256 of the body.
+A failed delivery is retried for a few minutes.
+
+Available git config:
+  git-bug.webhook.<name>.url [string]: the URL receiving the events
+  git-bug.webhook.<name>.secret [string]: the key of the signature of the payloads
+  git-bug.webhook.<name>.events [string]: the comma separated events sent, all of them if not set`,
+		PreRunE: loadRepo(env),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runWebhook(env)
+		},
+		Args: cobra.NoArgs,
+	}
+
+	cmd.AddCommand(newWebhookAddCommand())
+	cmd.AddCommand(newWebhookRmCommand())
+
+	return cmd
+}
+
+func runWebhook(env *Env) error {
+	hooks, err := webhook.Read(env.repo.AnyConfig())
+	if err != nil {
+		return err
+	}
+
+	for _, hook := range hooks {
+		events := "all events"
+		if len(hook.Events) > 0 {
+			names := make([]string, len(hook.Events))
+			for i, event := range hook.Events {
+				names[i] = string(event)
+			}
+			events = strings.Join(names, ",")
+		}
+
+		env.out.Printf("%s %s %s\n",
+			colors.Cyan(hook.Name),
+			hook.URL,
+			colors.Yellow(events),
+		)
+	}
+
+	return nil
+}
@@ -0,0 +1,65 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/MichaelMure/git-bug/webhook"
+)
+
+type webhookAddOptions struct {
+	secret string
+	events []string
+}
+
+func newWebhookAddCommand() *cobra.Command {
+	env := newEnv()
+	options := webhookAddOptions{}
+
+	var events []string
+	for _, event := range webhook.AllEvents {
+		events = append(events, string(event))
+	}
+
+	cmd := &cobra.Command{
+		Use:     "add NAME URL",
+		Short:   "Add a webhook, or replace the one with the same name.",
+		Example: `git bug webhook add ci https://ci.example.com/git-bug --secret "$SECRET" --event status_changed`,
+		PreRunE: loadRepo(env),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runWebhookAdd(env, options, args)
+		},
+		Args: cobra.ExactArgs(2),
+	}
+
+	flags := cmd.Flags()
+	flags.SortFlags = false
+
+	flags.StringVarP(&options.secret, "secret", "s", "",
+		"The key of the HMAC signature of the payloads")
+	flags.StringSliceVarP(&options.events, "event", "e", nil,
+		fmt.Sprintf("The events to send, all of them by default. Valid values are [%s]", strings.Join(events, ",")))
+
+	return cmd
+}
+
+func runWebhookAdd(env *Env, opts webhookAddOptions, args []string) error {
+	hook := webhook.Webhook{
+		Name:   args[0],
+		URL:    args[1],
+		Secret: opts.secret,
+	}
+	for _, event := range opts.events {
+		hook.Events = append(hook.Events, webhook.EventType(event))
+	}
+
+	err := webhook.Store(env.repo.LocalConfig(), hook)
+	if err != nil {
+		return err
+	}
+
+	env.out.Printf("webhook %s added\n", hook.Name)
+	return nil
+}
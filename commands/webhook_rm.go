@@ -0,0 +1,33 @@
+package commands
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/MichaelMure/git-bug/webhook"
+)
+
+func newWebhookRmCommand() *cobra.Command {
+	env := newEnv()
+
+	cmd := &cobra.Command{
+		Use:     "rm NAME",
+		Short:   "Remove a webhook.",
+		PreRunE: loadRepo(env),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runWebhookRm(env, args)
+		},
+		Args: cobra.ExactArgs(1),
+	}
+
+	return cmd
+}
+
+func runWebhookRm(env *Env, args []string) error {
+	err := webhook.Remove(env.repo.LocalConfig(), args[0])
+	if err != nil {
+		return err
+	}
+
+	env.out.Printf("webhook %s removed\n", args[0])
+	return nil
+}
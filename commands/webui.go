@@ -4,9 +4,13 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/99designs/gqlgen/graphql/playground"
@@ -14,6 +18,7 @@ import (
 	"github.com/phayes/freeport"
 	"github.com/skratchdot/open-golang/open"
 	"github.com/spf13/cobra"
+	"golang.org/x/crypto/acme/autocert"
 
 	"github.com/MichaelMure/git-bug/api/auth"
 	"github.com/MichaelMure/git-bug/api/graphql"
@@ -21,16 +26,37 @@ import (
 	"github.com/MichaelMure/git-bug/cache"
 	"github.com/MichaelMure/git-bug/identity"
 	"github.com/MichaelMure/git-bug/repository"
+	"github.com/MichaelMure/git-bug/webhook"
 	"github.com/MichaelMure/git-bug/webui"
+	"github.com/MichaelMure/git-bug/webui/static"
 )
 
 const webUIOpenConfigKey = "git-bug.webui.open"
 
 type webUIOptions struct {
-	port     int
-	open     bool
-	noOpen   bool
-	readOnly bool
+	host       string
+	port       int
+	unixSocket string
+	basePath   string
+	public     bool
+	tlsCert    string
+	tlsKey     string
+	autocert   []string
+	cors       []string
+	open       bool
+	noOpen     bool
+	readOnly   bool
+	metrics    bool
+
+	maxComplexity int
+	maxDepth      int
+	rateLimit     float64
+	rateBurst     int
+
+	allowedQueries string
+	cacheTTL       time.Duration
+
+	exportStatic string
 }
 
 func newWebUICommand() *cobra.Command {
@@ -42,8 +68,38 @@ func newWebUICommand() *cobra.Command {
 		Short: "Launch the web UI.",
 		Long: `Launch the web UI.
 
+By default, the web UI act as the user identity of the repository. With
+authentication tokens, the visitors are anonymous and can only read, unless
+they log in with a token, on /login, or give it as a bearer token to the API.
+The tokens are generated with "git bug webui token create", and a token with
+the read scope can't modify the data.
+
+Behind a reverse proxy, the web UI can be served under a URL prefix with
+--base-path, and listen on a unix socket with --unix-socket.
+
+To serve a team without a proxy, --public allow to listen on a non-local
+address, which require authentication tokens or --read-only. The web UI is
+served over https with a certificate given with --tls-cert and --tls-key, or
+obtained automatically from Let's Encrypt for the domains of --autocert, which
+need to reach the web UI on the port 443. Other web sites can use the API
+from the origins allowed with --cors-origin, which also require
+authentication tokens or --read-only.
+
+The cost of the GraphQL queries is bounded with --max-complexity and
+--max-depth, and the number of requests of each client with --rate-limit.
+With --allowed-queries, only a known set of queries is accepted, which has to
+include the ones of the web UI to keep it working. --cache-ttl keep the
+responses of the queries for a short time, as long as the data don't change.
+
+With --export-static, the bugs are instead rendered as a static HTML site in
+the given directory, searchable in the browser and hostable anywhere.
+
 Available git config:
   git-bug.webui.open [bool]: control the automatic opening of the web UI in the default browser
+  git-bug.webui.token.<name>.hash [string]: the sha256 of the secret of an authentication token
+  git-bug.webui.token.<name>.secret [string]: the secret of an authentication token, in clear
+  git-bug.webui.token.<name>.identity [string]: the id of the identity authenticated by this token
+  git-bug.webui.token.<name>.scope [string]: read or write, what the token allow to do (default write)
 `,
 		PreRunE: loadRepo(env),
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -51,19 +107,56 @@ Available git config:
 		},
 	}
 
+	cmd.AddCommand(newWebUITokenCommand())
+
 	flags := cmd.Flags()
 	flags.SortFlags = false
 
 	flags.BoolVar(&options.open, "open", false, "Automatically open the web UI in the default browser")
 	flags.BoolVar(&options.noOpen, "no-open", false, "Prevent the automatic opening of the web UI in the default browser")
+	flags.StringVar(&options.host, "host", "127.0.0.1", "Network address or hostname to listen to")
 	flags.IntVarP(&options.port, "port", "p", 0, "Port to listen to (default is random)")
+	flags.StringVar(&options.unixSocket, "unix-socket", "", "Listen to a unix socket instead of a network address")
+	flags.BoolVar(&options.public, "public", false, "Allow to listen to a non-local network address, exposing the web UI to the network")
+	flags.StringVar(&options.tlsCert, "tls-cert", "", "Serve over https with this certificate file, in the PEM format")
+	flags.StringVar(&options.tlsKey, "tls-key", "", "The private key file of the certificate given with --tls-cert, in the PEM format")
+	flags.StringSliceVar(&options.autocert, "autocert", nil, "Serve over https with a certificate obtained automatically from Let's Encrypt for these domains")
+	flags.StringSliceVar(&options.cors, "cors-origin", nil, "Allow the web pages of these origins, like https://example.com, to use the API. \"*\" allow any origin")
+	flags.StringVar(&options.basePath, "base-path", "/", "URL path prefix the web UI is served under, like /bugs/ behind a reverse proxy")
 	flags.BoolVar(&options.readOnly, "read-only", false, "Whether to run the web UI in read-only mode")
+	flags.BoolVar(&options.metrics, "metrics", false, "Expose the metrics of the cache in the Prometheus format on /metrics")
+	flags.IntVar(&options.maxComplexity, "max-complexity", graphql.DefaultOptions.MaxComplexity, "Maximum complexity of a GraphQL query, 0 to disable")
+	flags.IntVar(&options.maxDepth, "max-depth", graphql.DefaultOptions.MaxDepth, "Maximum nesting depth of a GraphQL query, 0 to disable")
+	flags.StringVar(&options.allowedQueries, "allowed-queries", "", "Only accept the GraphQL queries of this JSON file, an object of the queries indexed by their id")
+	flags.DurationVar(&options.cacheTTL, "cache-ttl", 0, "Keep the responses of the GraphQL queries for this duration (ex: \"10s\"), until the data change, disabled by default")
+	flags.Float64Var(&options.rateLimit, "rate-limit", 0, "Maximum number of requests per second of each client, disabled by default")
+	flags.IntVar(&options.rateBurst, "rate-burst", 50, "Number of requests a client can make in a burst above the rate limit")
+	flags.StringVar(&options.exportStatic, "export-static", "", "Render the bugs as a static HTML site in the given directory, instead of serving the web UI")
 
 	return cmd
 }
 
 func runWebUI(env *Env, opts webUIOptions, args []string) error {
-	if opts.port == 0 {
+	if opts.exportStatic != "" {
+		return runWebUIExport(env, opts.exportStatic)
+	}
+
+	if (opts.tlsCert == "") != (opts.tlsKey == "") {
+		return fmt.Errorf("--tls-cert and --tls-key need to be given together")
+	}
+	if len(opts.autocert) > 0 && opts.tlsCert != "" {
+		return fmt.Errorf("--autocert and --tls-cert are mutually exclusive")
+	}
+	useTLS := len(opts.autocert) > 0 || opts.tlsCert != ""
+	if useTLS && opts.unixSocket != "" {
+		return fmt.Errorf("a unix socket can't be served over https, the reverse proxy is expected to do it")
+	}
+
+	if opts.unixSocket == "" && !isLoopback(opts.host) && !opts.public {
+		return fmt.Errorf("listening to %s would expose the web UI to the network, confirm it with --public", opts.host)
+	}
+
+	if opts.port == 0 && opts.unixSocket == "" {
 		var err error
 		opts.port, err = freeport.GetFreePort()
 		if err != nil {
@@ -71,40 +164,173 @@ func runWebUI(env *Env, opts webUIOptions, args []string) error {
 		}
 	}
 
-	addr := fmt.Sprintf("127.0.0.1:%d", opts.port)
-	webUiAddr := fmt.Sprintf("http://%s", addr)
+	basePath := normalizeBasePath(opts.basePath)
+
+	scheme := "http"
+	if useTLS {
+		scheme = "https"
+	}
+
+	addr := net.JoinHostPort(opts.host, strconv.Itoa(opts.port))
+	publicAddr := addr
+	if len(opts.autocert) > 0 {
+		publicAddr = opts.autocert[0]
+		if opts.port != 443 {
+			publicAddr = net.JoinHostPort(publicAddr, strconv.Itoa(opts.port))
+		}
+	}
+	webUiAddr := fmt.Sprintf("%s://%s%s", scheme, publicAddr, basePath)
+	if opts.unixSocket != "" {
+		webUiAddr = fmt.Sprintf("unix:%s, under %s", opts.unixSocket, basePath)
+	}
 
 	router := mux.NewRouter()
 
-	// If the webUI is not read-only, use an authentication middleware with a
-	// fixed identity: the default user of the repo
-	// TODO: support dynamic authentication with OAuth
-	if !opts.readOnly {
-		author, err := identity.GetUserIdentity(env.repo)
+	if opts.rateLimit > 0 {
+		router.Use(httpapi.NewRateLimiter(opts.rateLimit, opts.rateBurst).Middleware)
+	}
+
+	tokens, err := auth.ReadTokens(env.repo.AnyConfig())
+	if err != nil {
+		return err
+	}
+
+	// whether anyone act as the user identity of the repository
+	localUser := false
+
+	switch {
+	case opts.readOnly:
+		// no authentication at all
+
+	case len(tokens) > 0:
+		// the visitors are anonymous, unless they give a token
+		router.Use(auth.TokenMiddleware(tokens))
+		router.Path("/login").Methods("GET", "POST").Handler(auth.NewLoginHandler(tokens, basePath))
+		router.Path("/logout").Handler(auth.NewLogoutHandler(basePath))
+
+	default:
+		// anyone reaching the web UI act as the user identity of the repo,
+		// which is only acceptable locally. A unix socket is meant to be
+		// exposed by a reverse proxy.
+		if opts.unixSocket != "" {
+			return fmt.Errorf("the web UI can only listen to a unix socket with authentication tokens, or with --read-only")
+		}
+		if !isLoopback(opts.host) {
+			return fmt.Errorf("the web UI can only be exposed on %s with authentication tokens, or with --read-only", opts.host)
+		}
+		// otherwise, any page of these origins would act as the user identity
+		if len(opts.cors) > 0 {
+			return fmt.Errorf("--cors-origin can only be used with authentication tokens, or with --read-only")
+		}
+
+		_, err := identity.GetUserIdentity(env.repo)
 		if err != nil {
 			return err
 		}
-		router.Use(auth.Middleware(author.Id()))
+		localUser = true
 	}
 
 	mrc := cache.NewMultiRepoCache()
-	_, err := mrc.RegisterDefaultRepository(env.repo)
+
+	var metrics *cache.MetricsCollector
+	if opts.metrics {
+		metrics = cache.NewMetricsCollector()
+		mrc.SetMetrics(metrics)
+	}
+
+	repoCache, err := mrc.RegisterDefaultRepository(env.repo)
+	if err != nil {
+		return err
+	}
+
+	if localUser {
+		router.Use(auth.RepoUserMiddleware(repoCache))
+	}
+
+	// the other repositories of the workspace are available by name
+	paths, err := cache.ReadWorkspace(env.repo.LocalConfig())
+	if err != nil {
+		return err
+	}
+	repos, err := openWorkspaceRepos(paths)
+	if err != nil {
+		return err
+	}
+	for name, repo := range repos {
+		_, err = mrc.RegisterRepository(name, repo)
+		if err != nil {
+			return err
+		}
+	}
+
+	// keep the data up to date when the repositories are modified by
+	// another process, like a git fetch
+	err = mrc.Watch(0)
+	if err != nil {
+		return err
+	}
+
+	// deliver the changes of the bugs to the webhooks while running
+	hooks, err := webhook.Read(env.repo.AnyConfig())
 	if err != nil {
 		return err
 	}
+	if len(hooks) > 0 {
+		dispatcher := webhook.NewDispatcher(repoCache, hooks)
+		err = dispatcher.Start()
+		if err != nil {
+			return err
+		}
+		defer dispatcher.Close()
+	}
+
+	graphqlOpts := graphql.Options{
+		MaxComplexity: opts.maxComplexity,
+		MaxDepth:      opts.maxDepth,
+		CacheTTL:      opts.cacheTTL,
+	}
+	if opts.allowedQueries != "" {
+		graphqlOpts.AllowedQueries, err = graphql.ReadAllowedQueries(opts.allowedQueries)
+		if err != nil {
+			return err
+		}
+	}
 
-	graphqlHandler := graphql.NewHandler(mrc)
+	graphqlHandler := graphql.NewHandler(mrc, graphqlOpts)
 
 	// Routes
-	router.Path("/playground").Handler(playground.Handler("git-bug", "/graphql"))
+	router.Path("/playground").Handler(playground.Handler("git-bug", basePath+"graphql"))
 	router.Path("/graphql").Handler(graphqlHandler)
+	router.Path("/gitfile/{hash}").Handler(httpapi.NewGitFileHandler(mrc))
 	router.Path("/gitfile/{repo}/{hash}").Handler(httpapi.NewGitFileHandler(mrc))
+	router.Path("/upload").Methods("POST").Handler(httpapi.NewGitUploadFileHandler(mrc))
 	router.Path("/upload/{repo}").Methods("POST").Handler(httpapi.NewGitUploadFileHandler(mrc))
-	router.PathPrefix("/").Handler(webui.NewHandler())
+	if metrics != nil {
+		router.Path("/metrics").Handler(httpapi.NewMetricsHandler(metrics))
+	}
+	router.PathPrefix("/").Handler(webui.NewHandler(basePath))
+
+	handler := withBasePath(basePath, router)
+	if len(opts.cors) > 0 {
+		handler = httpapi.NewCORSMiddleware(opts.cors)(handler)
+	}
 
 	srv := &http.Server{
-		Addr:    addr,
-		Handler: router,
+		Handler: handler,
+	}
+
+	if len(opts.autocert) > 0 {
+		manager := &autocert.Manager{
+			Prompt:     autocert.AcceptTOS,
+			HostPolicy: autocert.HostWhitelist(opts.autocert...),
+			Cache:      autocert.DirCache(filepath.Join(env.repo.GetPath(), "git-bug", "autocert")),
+		}
+		srv.TLSConfig = manager.TLSConfig()
+	}
+
+	listener, err := listenWebUI(opts.unixSocket, addr)
+	if err != nil {
+		return err
 	}
 
 	done := make(chan bool)
@@ -135,8 +361,13 @@ func runWebUI(env *Env, opts webUIOptions, args []string) error {
 	}()
 
 	env.out.Printf("Web UI: %s\n", webUiAddr)
-	env.out.Printf("Graphql API: http://%s/graphql\n", addr)
-	env.out.Printf("Graphql Playground: http://%s/playground\n", addr)
+	if opts.unixSocket == "" {
+		env.out.Printf("Graphql API: %s://%s%sgraphql\n", scheme, publicAddr, basePath)
+		env.out.Printf("Graphql Playground: %s://%s%splayground\n", scheme, publicAddr, basePath)
+	}
+	if opts.public && !useTLS {
+		env.out.Println("Warning: without https, the authentication tokens are sent in clear over the network")
+	}
 	env.out.Println("Press Ctrl+c to quit")
 
 	configOpen, err := env.repo.AnyConfig().ReadBool(webUIOpenConfigKey)
@@ -147,7 +378,8 @@ func runWebUI(env *Env, opts webUIOptions, args []string) error {
 		return err
 	}
 
-	shouldOpen := (configOpen && !opts.noOpen) || opts.open
+	// there is nothing to open in a browser for a unix socket
+	shouldOpen := ((configOpen && !opts.noOpen) || opts.open) && opts.unixSocket == ""
 
 	if shouldOpen {
 		err = open.Run(webUiAddr)
@@ -156,7 +388,15 @@ func runWebUI(env *Env, opts webUIOptions, args []string) error {
 		}
 	}
 
-	err = srv.ListenAndServe()
+	switch {
+	case len(opts.autocert) > 0:
+		// the certificates come from the TLS config
+		err = srv.ServeTLS(listener, "", "")
+	case opts.tlsCert != "":
+		err = srv.ServeTLS(listener, opts.tlsCert, opts.tlsKey)
+	default:
+		err = srv.Serve(listener)
+	}
 	if err != nil && err != http.ErrServerClosed {
 		return err
 	}
@@ -166,3 +406,78 @@ func runWebUI(env *Env, opts webUIOptions, args []string) error {
 	env.out.Println("WebUI stopped")
 	return nil
 }
+
+// runWebUIExport render the bugs as a static site in dir. The cache is opened
+// read-only, so it works while the web UI or the daemon is running.
+func runWebUIExport(env *Env, dir string) error {
+	repoCache, err := cache.NewReadOnlyRepoCache(env.repo, buildProgress(env))
+	if err != nil {
+		return err
+	}
+	defer repoCache.Close()
+
+	count, err := static.Export(repoCache, dir, repoCache.AllBugsIds())
+	if err != nil {
+		return err
+	}
+
+	env.out.Printf("%d bugs exported in %s\n", count, dir)
+	return nil
+}
+
+// isLoopback tell if a host only accept local connections
+func isLoopback(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// normalizeBasePath return a URL path prefix with a leading and a trailing
+// slash, like "/bugs/"
+func normalizeBasePath(basePath string) string {
+	basePath = strings.Trim(basePath, "/")
+	if basePath == "" {
+		return "/"
+	}
+	return "/" + basePath + "/"
+}
+
+// withBasePath serve a handler under a URL path prefix
+func withBasePath(basePath string, handler http.Handler) http.Handler {
+	if basePath == "/" {
+		return handler
+	}
+
+	prefix := strings.TrimSuffix(basePath, "/")
+	stripped := http.StripPrefix(prefix, handler)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == prefix {
+			http.Redirect(w, r, basePath, http.StatusMovedPermanently)
+			return
+		}
+		stripped.ServeHTTP(w, r)
+	})
+}
+
+// listenWebUI listen to a unix socket if given, or to a network address
+func listenWebUI(unixSocket string, addr string) (net.Listener, error) {
+	if unixSocket == "" {
+		return net.Listen("tcp", addr)
+	}
+
+	// a socket left by a previous run that didn't stop cleanly would prevent
+	// to listen, but don't remove anything else
+	if stat, err := os.Stat(unixSocket); err == nil {
+		if stat.Mode()&os.ModeSocket == 0 {
+			return nil, fmt.Errorf("%s already exists and is not a socket", unixSocket)
+		}
+		if err := os.Remove(unixSocket); err != nil {
+			return nil, err
+		}
+	}
+
+	return net.Listen("unix", unixSocket)
+}
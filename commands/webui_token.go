@@ -0,0 +1,51 @@
+package commands
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/MichaelMure/git-bug/api/auth"
+	"github.com/MichaelMure/git-bug/util/colors"
+)
+
+func newWebUITokenCommand() *cobra.Command {
+	env := newEnv()
+
+	cmd := &cobra.Command{
+		Use:      "token",
+		Short:    "List the authentication tokens of the web UI and the API.",
+		PreRunE:  loadBackendReadOnly(env),
+		PostRunE: closeBackend(env),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runWebUIToken(env)
+		},
+		Args: cobra.NoArgs,
+	}
+
+	cmd.AddCommand(newWebUITokenCreateCommand())
+	cmd.AddCommand(newWebUITokenRmCommand())
+
+	return cmd
+}
+
+func runWebUIToken(env *Env) error {
+	tokens, err := auth.ReadTokens(env.repo.AnyConfig())
+	if err != nil {
+		return err
+	}
+
+	for _, token := range tokens {
+		user := token.Identity.Human()
+		identity, err := env.backend.ResolveIdentityExcerpt(token.Identity)
+		if err == nil {
+			user = identity.DisplayName()
+		}
+
+		env.out.Printf("%s %s %s\n",
+			colors.Cyan(token.Name),
+			colors.Yellow(token.Scope),
+			user,
+		)
+	}
+
+	return nil
+}
@@ -0,0 +1,77 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/MichaelMure/git-bug/api/auth"
+	"github.com/MichaelMure/git-bug/cache"
+)
+
+type webUITokenCreateOptions struct {
+	scope string
+	user  string
+}
+
+func newWebUITokenCreateCommand() *cobra.Command {
+	env := newEnv()
+	options := webUITokenCreateOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "create NAME",
+		Short: "Generate a new authentication token.",
+		Long: `Generate a new authentication token for the web UI and the API.
+
+The token is only shown once: only its hash is stored in the git config of the
+repository. A token with the read scope can't modify the data.`,
+		PreRunE:  loadBackendReadOnly(env),
+		PostRunE: closeBackend(env),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runWebUITokenCreate(env, options, args)
+		},
+		Args: cobra.ExactArgs(1),
+	}
+
+	flags := cmd.Flags()
+	flags.SortFlags = false
+
+	flags.StringVarP(&options.scope, "scope", "s", string(auth.ScopeWrite),
+		"What the token allow to do. Valid values are [read,write]")
+	flags.StringVarP(&options.user, "user", "u", "",
+		"The identity authenticated by the token. Default is the current user")
+
+	return cmd
+}
+
+func runWebUITokenCreate(env *Env, opts webUITokenCreateOptions, args []string) error {
+	scope := auth.Scope(opts.scope)
+	if scope != auth.ScopeRead && scope != auth.ScopeWrite {
+		return fmt.Errorf("unknown scope %s", opts.scope)
+	}
+
+	var user *cache.IdentityCache
+	var err error
+	if opts.user == "" {
+		user, err = env.backend.GetUserIdentity()
+	} else {
+		user, err = env.backend.ResolveIdentityPrefix(opts.user)
+	}
+	if err != nil {
+		return err
+	}
+
+	token, secret, err := auth.NewToken(args[0], user.Id(), scope)
+	if err != nil {
+		return err
+	}
+
+	err = auth.StoreToken(env.repo.LocalConfig(), token)
+	if err != nil {
+		return err
+	}
+
+	env.err.Printf("token %s created for %s, with the %s scope\n", token.Name, user.DisplayName(), scope)
+	env.out.Println(secret)
+	return nil
+}
@@ -0,0 +1,33 @@
+package commands
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/MichaelMure/git-bug/api/auth"
+)
+
+func newWebUITokenRmCommand() *cobra.Command {
+	env := newEnv()
+
+	cmd := &cobra.Command{
+		Use:     "rm NAME",
+		Short:   "Remove an authentication token.",
+		PreRunE: loadRepo(env),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runWebUITokenRm(env, args)
+		},
+		Args: cobra.ExactArgs(1),
+	}
+
+	return cmd
+}
+
+func runWebUITokenRm(env *Env, args []string) error {
+	err := auth.RemoveToken(env.repo.LocalConfig(), args[0])
+	if err != nil {
+		return err
+	}
+
+	env.out.Printf("token %s removed\n", args[0])
+	return nil
+}
@@ -0,0 +1,99 @@
+package commands
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/spf13/cobra"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+func newWorkspaceCommand() *cobra.Command {
+	env := newEnv()
+
+	cmd := &cobra.Command{
+		Use:   "workspace",
+		Short: "List the other repositories of the workspace.",
+		Long: `List the other repositories of the workspace.
+
+A workspace aggregate the bugs of multiple repositories in a single view. In a workspace, the bugs of the other repositories are identified by the name of their repository followed by their id, for example "backend/1a2b3c4".`,
+		PreRunE: loadRepo(env),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runWorkspace(env)
+		},
+		Args: cobra.NoArgs,
+	}
+
+	cmd.AddCommand(newWorkspaceAddCommand())
+	cmd.AddCommand(newWorkspaceLsCommand())
+	cmd.AddCommand(newWorkspaceRmCommand())
+
+	return cmd
+}
+
+func runWorkspace(env *Env) error {
+	paths, err := cache.ReadWorkspace(env.repo.LocalConfig())
+	if err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(paths))
+	for name := range paths {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		env.out.Printf("%s\t%s\n", name, paths[name])
+	}
+
+	return nil
+}
+
+// openWorkspace create a read-only MultiRepoCache holding the current
+// repository as the default one, and the other repositories of the workspace
+func openWorkspace(env *Env) (*cache.MultiRepoCache, error) {
+	paths, err := cache.ReadWorkspace(env.repo.LocalConfig())
+	if err != nil {
+		return nil, err
+	}
+
+	mrc := cache.NewMultiRepoCache()
+
+	_, err = mrc.RegisterReadOnlyRepository("", env.repo)
+	if err != nil {
+		return nil, err
+	}
+
+	repos, err := openWorkspaceRepos(paths)
+	if err != nil {
+		return nil, err
+	}
+
+	for name, repo := range repos {
+		_, err = mrc.RegisterReadOnlyRepository(name, repo)
+		if err != nil {
+			return nil, fmt.Errorf("workspace repository %s: %v", name, err)
+		}
+	}
+
+	return mrc, nil
+}
+
+// openWorkspaceRepos open the repositories of a workspace, by name
+func openWorkspaceRepos(paths map[string]string) (map[string]repository.ClockedRepo, error) {
+	repos := make(map[string]repository.ClockedRepo, len(paths))
+
+	for name, path := range paths {
+		repo, err := repository.NewGoGitRepo(path, []repository.ClockLoader{bug.ClockLoader})
+		if err != nil {
+			return nil, fmt.Errorf("workspace repository %s: %v", name, err)
+		}
+		repos[name] = repo
+	}
+
+	return repos, nil
+}
@@ -0,0 +1,56 @@
+package commands
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+func newWorkspaceAddCommand() *cobra.Command {
+	env := newEnv()
+
+	cmd := &cobra.Command{
+		Use:     "add NAME PATH",
+		Short:   "Add a repository to the workspace.",
+		PreRunE: loadRepo(env),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runWorkspaceAdd(env, args)
+		},
+		Args: cobra.ExactArgs(2),
+	}
+
+	return cmd
+}
+
+func runWorkspaceAdd(env *Env, args []string) error {
+	name := args[0]
+
+	path, err := filepath.Abs(args[1])
+	if err != nil {
+		return err
+	}
+
+	// make sure the path point to an actual repository
+	_, err = repository.NewGoGitRepo(path, []repository.ClockLoader{bug.ClockLoader})
+	if err == repository.ErrNotARepo {
+		return fmt.Errorf("%s is not a git repository", path)
+	}
+	if err != nil {
+		return err
+	}
+
+	paths, err := cache.ReadWorkspace(env.repo.LocalConfig())
+	if err != nil {
+		return err
+	}
+	if _, ok := paths[name]; ok {
+		return fmt.Errorf("the workspace already has a repository named %s", name)
+	}
+
+	return cache.AddWorkspaceRepo(env.repo.LocalConfig(), name, path)
+}
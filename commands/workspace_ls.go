@@ -0,0 +1,113 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	text "github.com/MichaelMure/go-term-text"
+	"github.com/spf13/cobra"
+
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/query"
+	"github.com/MichaelMure/git-bug/util/colors"
+)
+
+type workspaceLsOptions struct {
+	outputFormat string
+}
+
+func newWorkspaceLsCommand() *cobra.Command {
+	env := newEnv()
+	options := workspaceLsOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "ls [QUERY]",
+		Short: "List the bugs of all the repositories of the workspace.",
+		Long: `List the bugs of all the repositories of the workspace.
+
+The query is expressed with the same query language as "git bug ls". As the bugs come from different repositories, they are ordered by timestamp.`,
+		Example: `List the open bugs of the workspace, the most recently edited first:
+git bug workspace ls status:open sort:edit-desc
+`,
+		PreRunE: loadRepo(env),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runWorkspaceLs(env, options, args)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.SortFlags = false
+
+	flags.StringVarP(&options.outputFormat, "format", "f", "default",
+		"Select the output formatting style. Valid values are [default,plain]")
+
+	return cmd
+}
+
+func runWorkspaceLs(env *Env, opts workspaceLsOptions, args []string) error {
+	saved, err := query.ReadSavedQueries(env.repo.LocalConfig())
+	if err != nil {
+		return err
+	}
+
+	q, err := query.ParseWithSaved(strings.Join(args, " "), saved)
+	if err != nil {
+		return queryError(err)
+	}
+
+	mrc, err := openWorkspace(env)
+	if err != nil {
+		return err
+	}
+	defer mrc.Close()
+
+	ids, err := mrc.QueryBugs(q)
+	if err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		excerpt, err := mrc.ResolveBugExcerpt(id)
+		if err != nil {
+			return err
+		}
+
+		switch opts.outputFormat {
+		case "plain":
+			env.out.Printf("%s [%s] %s\n", id.Human(), excerpt.Status, strings.TrimSpace(excerpt.Title))
+		case "default":
+			err = workspaceLsDefaultFormatter(env, mrc, id, excerpt)
+			if err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("unknown format %s", opts.outputFormat)
+		}
+	}
+
+	return nil
+}
+
+func workspaceLsDefaultFormatter(env *Env, mrc *cache.MultiRepoCache, id cache.QualifiedId, excerpt *cache.BugExcerpt) error {
+	repo, err := mrc.ResolveRepo(id.Repo)
+	if err != nil {
+		return err
+	}
+
+	author, err := repo.ResolveIdentityExcerpt(excerpt.AuthorId)
+	if err != nil {
+		return err
+	}
+
+	titleFmt := text.LeftPadMaxLine(strings.TrimSpace(excerpt.Title), 50, 0)
+	authorFmt := text.LeftPadMaxLine(author.DisplayName(), 15, 0)
+
+	env.out.Printf("%s %s\t%s\t%s\n",
+		colors.Cyan(id.Human()),
+		colors.Yellow(excerpt.Status),
+		titleFmt,
+		colors.Magenta(authorFmt),
+	)
+
+	return nil
+}
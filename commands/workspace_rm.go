@@ -0,0 +1,37 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/MichaelMure/git-bug/cache"
+)
+
+func newWorkspaceRmCommand() *cobra.Command {
+	env := newEnv()
+
+	cmd := &cobra.Command{
+		Use:     "rm NAME",
+		Short:   "Remove a repository from the workspace.",
+		PreRunE: loadRepo(env),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runWorkspaceRm(env, args)
+		},
+		Args: cobra.ExactArgs(1),
+	}
+
+	return cmd
+}
+
+func runWorkspaceRm(env *Env, args []string) error {
+	paths, err := cache.ReadWorkspace(env.repo.LocalConfig())
+	if err != nil {
+		return err
+	}
+	if _, ok := paths[args[0]]; !ok {
+		return fmt.Errorf("the workspace has no repository named %s", args[0])
+	}
+
+	return cache.RemoveWorkspaceRepo(env.repo.LocalConfig(), args[0])
+}
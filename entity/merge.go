@@ -14,6 +14,7 @@ const (
 	MergeStatusUpdated
 	MergeStatusNothing
 	MergeStatusError
+	MergeStatusQuarantined
 )
 
 type MergeResult struct {
@@ -23,7 +24,7 @@ type MergeResult struct {
 	Id     Id
 	Status MergeStatus
 
-	// Only set for invalid status
+	// Only set for invalid and quarantined status
 	Reason string
 
 	// Not set for invalid status
@@ -42,6 +43,8 @@ func (mr MergeResult) String() string {
 		return "nothing to do"
 	case MergeStatusError:
 		return fmt.Sprintf("merge error on %s: %s", mr.Id, mr.Err.Error())
+	case MergeStatusQuarantined:
+		return fmt.Sprintf("quarantined: %s", mr.Reason)
 	default:
 		panic("unknown merge status")
 	}
@@ -72,3 +75,14 @@ func NewMergeInvalidStatus(id Id, reason string) MergeResult {
 		Reason: reason,
 	}
 }
+
+// NewMergeQuarantinedStatus is used when the remote data is valid but can't be
+// accepted, for example because of a policy. The remote data is left untouched
+// and not merged.
+func NewMergeQuarantinedStatus(id Id, reason string) MergeResult {
+	return MergeResult{
+		Id:     id,
+		Status: MergeStatusQuarantined,
+		Reason: reason,
+	}
+}
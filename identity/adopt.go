@@ -0,0 +1,48 @@
+package identity
+
+import (
+	"fmt"
+
+	"github.com/MichaelMure/git-bug/entity"
+)
+
+// the prefix of the metadata recording that an identity adopted another one
+const adoptedMetadataPrefix = "git-bug-adopted-"
+
+// AdoptedMetadataKey return the metadata key recording that an identity
+// adopted another one
+func AdoptedMetadataKey(adopted entity.Id) string {
+	return adoptedMetadataPrefix + adopted.String()
+}
+
+// Adopt attach the immutable metadata of another identity, like the login
+// and the id on a remote bug tracker of an identity created by a bridge, to
+// this one. The bridges then resolve this identity instead of the other one.
+// The adoption is recorded in the metadata as well, to prefer this identity
+// when both match.
+func (i *Identity) Adopt(other *Identity) error {
+	if other.Id() == i.Id() {
+		return fmt.Errorf("an identity can't adopt itself")
+	}
+
+	mine := i.ImmutableMetadata()
+	if _, ok := mine[AdoptedMetadataKey(other.Id())]; ok {
+		return fmt.Errorf("%s is already adopted", other.DisplayName())
+	}
+
+	theirs := other.ImmutableMetadata()
+	for key, value := range theirs {
+		if current, ok := mine[key]; ok && current != value {
+			return fmt.Errorf("metadata %s is already set to a different value: %s instead of %s", key, current, value)
+		}
+	}
+
+	for key, value := range theirs {
+		if _, ok := mine[key]; !ok {
+			i.SetMetadata(key, value)
+		}
+	}
+	i.SetMetadata(AdoptedMetadataKey(other.Id()), "true")
+
+	return nil
+}
@@ -0,0 +1,27 @@
+package identity
+
+import (
+	"fmt"
+	"io"
+)
+
+// WriteAllowedSigners write the SSH keys of the identities in the format of
+// git's gpg.ssh.allowedSignersFile, so that git itself can verify the commits
+// signed with them. The principal of a key is the email of its identity.
+func WriteAllowedSigners(w io.Writer, identities []Interface) error {
+	for _, i := range identities {
+		if i.Email() == "" {
+			continue
+		}
+		for _, key := range i.Keys() {
+			if !key.IsSSH() {
+				continue
+			}
+			_, err := fmt.Fprintf(w, "%s namespaces=\"git\" %s\n", i.Email(), key.PubKey)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package identity
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+// BundleRefs return the refs of the given local identities, or all of them if
+// ids is nil, to put in a git bundle
+func BundleRefs(repo repository.Repo, ids []entity.Id) ([]string, error) {
+	if ids == nil {
+		return repo.ListRefs(identityRefPattern)
+	}
+
+	var refs []string
+	for _, id := range ids {
+		exist, err := repo.RefExist(identityRefPattern + id.String())
+		if err != nil {
+			return nil, err
+		}
+		if exist {
+			refs = append(refs, identityRefPattern+id.String())
+		}
+	}
+	return refs, nil
+}
+
+// TrackBundledRefs point the remote-tracking refs of a remote to the
+// identities of a git bundle, as a Fetch would do, to merge them with MergeAll
+func TrackBundledRefs(repo repository.Repo, remote string, refs map[string]repository.Hash) error {
+	remoteRefSpec := fmt.Sprintf(identityRemoteRefPattern, remote)
+
+	for ref, hash := range refs {
+		if !strings.HasPrefix(ref, identityRefPattern) {
+			continue
+		}
+		err := repo.UpdateRef(remoteRefSpec+strings.TrimPrefix(ref, identityRefPattern), hash)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RemoveRemoteRefs remove all the remote-tracking refs of a remote
+func RemoveRemoteRefs(repo repository.Repo, remote string) error {
+	refs, err := repo.ListRefs(fmt.Sprintf(identityRemoteRefPattern, remote))
+	if err != nil {
+		return err
+	}
+
+	for _, ref := range refs {
+		err = repo.RemoveRef(ref)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
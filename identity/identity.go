@@ -185,6 +185,27 @@ func ReadAllLocal(repo repository.ClockedRepo) <-chan StreamedIdentity {
 	return readAll(repo, identityRefPattern)
 }
 
+// ListLocalRefTips return the commit each local identity ref point to. It
+// allow to detect cheaply which identities changed.
+func ListLocalRefTips(repo repository.Repo) (map[entity.Id]repository.Hash, error) {
+	refs, err := repo.ListRefs(identityRefPattern)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[entity.Id]repository.Hash, len(refs))
+	for _, ref := range refs {
+		hash, err := repo.ResolveRef(ref)
+		if err != nil {
+			return nil, err
+		}
+		refSplit := strings.Split(ref, "/")
+		result[entity.Id(refSplit[len(refSplit)-1])] = hash
+	}
+
+	return result, nil
+}
+
 // ReadAllRemote read and parse all remote Identity for a given remote
 func ReadAllRemote(repo repository.ClockedRepo, remote string) <-chan StreamedIdentity {
 	refPrefix := fmt.Sprintf(identityRemoteRefPattern, remote)
@@ -225,6 +246,12 @@ type Mutator struct {
 	Email     string
 	AvatarUrl string
 	Keys      []*Key
+	Proofs    []*Proof
+
+	// only for a team
+	Members []entity.Id
+
+	Notifications NotificationPreferences
 }
 
 // Mutate allow to create a new version of the Identity in one go
@@ -235,8 +262,15 @@ func (i *Identity) Mutate(f func(orig Mutator) Mutator) {
 		Login:     i.Login(),
 		AvatarUrl: i.AvatarUrl(),
 		Keys:      i.Keys(),
+		Proofs:    i.Proofs(),
+		Members:   i.Members(),
+
+		Notifications: i.NotificationPreferences(),
 	}
-	mutated := f(orig)
+	// the preferences are given as a copy, to be able to detect an in-place change
+	copied := orig
+	copied.Notifications = orig.Notifications.Clone()
+	mutated := f(copied)
 	if reflect.DeepEqual(orig, mutated) {
 		return
 	}
@@ -246,6 +280,11 @@ func (i *Identity) Mutate(f func(orig Mutator) Mutator) {
 		login:     mutated.Login,
 		avatarURL: mutated.AvatarUrl,
 		keys:      mutated.Keys,
+		proofs:    mutated.Proofs,
+		team:      i.IsTeam(),
+		members:   mutated.Members,
+
+		notifications: mutated.Notifications,
 	})
 }
 
@@ -262,7 +301,7 @@ func (i *Identity) Commit(repo repository.ClockedRepo) error {
 		return errors.Wrap(err, "can't commit an identity with invalid data")
 	}
 
-	for _, v := range i.versions {
+	for j, v := range i.versions {
 		if v.commitHash != "" {
 			i.lastCommit = v.commitHash
 			// ignore already commit versions
@@ -295,11 +334,20 @@ func (i *Identity) Commit(repo repository.ClockedRepo) error {
 			return err
 		}
 
+		// a change of the keys is signed by a previous key
+		var signingKey *Key
+		if j > 0 {
+			signingKey = chainSigningKey(i.versions[j-1], v)
+		}
+
 		var commitHash repository.Hash
-		if i.lastCommit != "" {
-			commitHash, err = repo.StoreCommitWithParent(treeHash, i.lastCommit)
-		} else {
+		switch {
+		case i.lastCommit == "":
 			commitHash, err = repo.StoreCommit(treeHash)
+		case signingKey != nil:
+			commitHash, err = repo.StoreSignedCommit(treeHash, i.lastCommit, signingKey.SigningKeyId())
+		default:
+			commitHash, err = repo.StoreCommitWithParent(treeHash, i.lastCommit)
 		}
 
 		if err != nil {
@@ -474,7 +522,20 @@ func (i *Identity) Keys() []*Key {
 	return i.lastVersion().keys
 }
 
-// ValidKeysAtTime return the set of keys valid at a given lamport time
+// Proofs return the last version of the proofs of control of external
+// accounts or resources
+func (i *Identity) Proofs() []*Proof {
+	return i.lastVersion().proofs
+}
+
+// NotificationPreferences return the last version of the notification preferences
+func (i *Identity) NotificationPreferences() NotificationPreferences {
+	return i.lastVersion().notifications.Clone()
+}
+
+// ValidKeysAtTime return the set of keys valid at a given lamport time. As
+// the keys rotate, an operation stay valid if it was signed by any of the keys
+// valid at its time.
 func (i *Identity) ValidKeysAtTime(time lamport.Time) []*Key {
 	var result []*Key
 
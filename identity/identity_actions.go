@@ -72,6 +72,18 @@ func MergeAll(repo repository.ClockedRepo, remote string) <-chan entity.MergeRes
 				continue
 			}
 
+			// the remote ref didn't move since the last merge, there is no
+			// need to read and check the identity again
+			upToDate, err := repository.SameRefTip(repo, identityRefPattern+id.String(), remoteRef)
+			if err != nil {
+				out <- entity.NewMergeError(err, id)
+				continue
+			}
+			if upToDate {
+				out <- entity.NewMergeStatus(entity.MergeStatusNothing, id, nil)
+				continue
+			}
+
 			remoteIdentity, err := read(repo, remoteRef)
 
 			if err != nil {
@@ -85,6 +97,12 @@ func MergeAll(repo repository.ClockedRepo, remote string) <-chan entity.MergeRes
 				continue
 			}
 
+			// only the owner of the keys can change them
+			if err := remoteIdentity.VerifyKeyChain(repo); err != nil {
+				out <- entity.NewMergeInvalidStatus(id, errors.Wrap(err, "remote identity is invalid").Error())
+				continue
+			}
+
 			localRef := identityRefPattern + remoteIdentity.Id().String()
 			localExist, err := repo.RefExist(localRef)
 
@@ -1,9 +1,78 @@
 package identity
 
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/openpgp"
+	"golang.org/x/crypto/ssh"
+
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+type KeyType string
+
+const (
+	KeyTypeOpenPGP KeyType = "openpgp"
+	KeyTypeSSH     KeyType = "ssh"
+)
+
 type Key struct {
-	// The GPG fingerprint of the key
+	// The type of the key, OpenPGP if not set
+	Type KeyType `json:"type,omitempty"`
+	// The GPG fingerprint of the key, or the SHA256 fingerprint of an SSH key
 	Fingerprint string `json:"fingerprint"`
-	PubKey      string `json:"pub_key"`
+	// The armored OpenPGP public key, or the SSH public key in the
+	// authorized_keys format
+	PubKey string `json:"pub_key"`
+}
+
+// NewKeyFromArmored create a Key from an armored OpenPGP public key, as
+// exported by `gpg --export --armor`
+func NewKeyFromArmored(armored string) (*Key, error) {
+	entities, err := openpgp.ReadArmoredKeyRing(strings.NewReader(armored))
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid public key")
+	}
+	if len(entities) != 1 {
+		return nil, fmt.Errorf("expected exactly one public key, got %d", len(entities))
+	}
+
+	return &Key{
+		Type:        KeyTypeOpenPGP,
+		Fingerprint: fmt.Sprintf("%X", entities[0].PrimaryKey.Fingerprint),
+		PubKey:      armored,
+	}, nil
+}
+
+// NewKeyFromSSH create a Key from an SSH public key in the authorized_keys
+// format, as found in ~/.ssh/id_ed25519.pub
+func NewKeyFromSSH(authorizedKey string) (*Key, error) {
+	pub, _, _, _, err := ssh.ParseAuthorizedKey([]byte(authorizedKey))
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid SSH public key")
+	}
+
+	return &Key{
+		Type:        KeyTypeSSH,
+		Fingerprint: ssh.FingerprintSHA256(pub),
+		// drop the comment and the options
+		PubKey: strings.TrimSpace(string(ssh.MarshalAuthorizedKey(pub))),
+	}, nil
+}
+
+// IsSSH return true if the key is an SSH public key
+func (k *Key) IsSSH() bool {
+	return k.Type == KeyTypeSSH
+}
+
+// SigningKeyId return the key to give to git to sign a commit with this key
+func (k *Key) SigningKeyId() string {
+	if k.IsSSH() {
+		return repository.SSHSigningKey(k.PubKey)
+	}
+	return k.Fingerprint
 }
 
 func (k *Key) Validate() error {
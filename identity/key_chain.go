@@ -0,0 +1,75 @@
+package identity
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+// The keys of an identity form a trust chain: once an identity has keys, a
+// version changing them must be signed by one of the keys of the previous
+// version. A new key can then only be added, or an old one revoked, by the
+// owner of the identity, while the operations signed with a key stay valid
+// after the key is rotated or lost.
+
+// keysChanged return whether two sets of keys differ
+func keysChanged(previous, next []*Key) bool {
+	if len(previous) != len(next) {
+		return true
+	}
+	fingerprints := make(map[string]bool, len(previous))
+	for _, key := range previous {
+		fingerprints[key.Fingerprint] = true
+	}
+	for _, key := range next {
+		if !fingerprints[key.Fingerprint] {
+			return true
+		}
+	}
+	return false
+}
+
+// chainSigningKey return the key to sign a new version with, or nil if it
+// doesn't need to be signed. A key of the previous version kept in the new one
+// is preferred, as it's the one most likely still held by the owner.
+func chainSigningKey(previous, next *Version) *Key {
+	if len(previous.keys) == 0 || !keysChanged(previous.keys, next.keys) {
+		return nil
+	}
+	for _, key := range previous.keys {
+		for _, kept := range next.keys {
+			if key.Fingerprint == kept.Fingerprint {
+				return key
+			}
+		}
+	}
+	return previous.keys[0]
+}
+
+// VerifyKeyChain check that every committed version changing the keys of the
+// identity is signed by one of the keys of the previous version
+func (i *Identity) VerifyKeyChain(repo repository.RepoData) error {
+	for j := 1; j < len(i.versions); j++ {
+		previous, v := i.versions[j-1], i.versions[j]
+		if v.commitHash == "" || chainSigningKey(previous, v) == nil {
+			continue
+		}
+
+		raw, err := repo.ReadRawCommit(v.commitHash)
+		if err != nil {
+			return err
+		}
+
+		pubKeys := make([]string, len(previous.keys))
+		for k, key := range previous.keys {
+			pubKeys[k] = key.PubKey
+		}
+
+		_, err = repository.VerifyCommitSignature(raw, pubKeys)
+		if err != nil {
+			return errors.Wrapf(err, "keys changed at %s without the signature of a previous key", v.commitHash)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,80 @@
+package identity
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+func TestSSHKey(t *testing.T) {
+	pub, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	sshPub, err := ssh.NewPublicKey(pub)
+	require.NoError(t, err)
+
+	authorized := string(bytes.TrimSpace(ssh.MarshalAuthorizedKey(sshPub)))
+
+	key, err := NewKeyFromSSH(authorized + " rene@laptop\n")
+	require.NoError(t, err)
+	require.True(t, key.IsSSH())
+	require.Equal(t, ssh.FingerprintSHA256(sshPub), key.Fingerprint)
+	require.Equal(t, authorized, key.PubKey)
+
+	_, err = NewKeyFromSSH("not a key")
+	require.Error(t, err)
+
+	rene := NewIdentity("René Descartes", "rene@descartes.fr")
+	rene.Mutate(func(orig Mutator) Mutator {
+		orig.Keys = []*Key{key, {Fingerprint: "ABCD", PubKey: "pgp key"}}
+		return orig
+	})
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteAllowedSigners(&buf, []Interface{rene}))
+	require.Equal(t, "rene@descartes.fr namespaces=\"git\" "+authorized+"\n", buf.String())
+}
+
+func TestKeyChain(t *testing.T) {
+	repo := repository.NewMockRepoForTest()
+
+	keyA := &Key{Fingerprint: "AAAA", PubKey: "key A"}
+	keyB := &Key{Fingerprint: "BBBB", PubKey: "key B"}
+
+	rene := NewIdentity("René Descartes", "rene@descartes.fr")
+	require.NoError(t, rene.Commit(repo))
+
+	// the first keys don't need a signature
+	rene.Mutate(func(orig Mutator) Mutator {
+		orig.Keys = []*Key{keyA}
+		return orig
+	})
+	require.NoError(t, rene.Commit(repo))
+
+	// nor the changes not touching the keys
+	rene.Mutate(func(orig Mutator) Mutator {
+		orig.Login = "rene"
+		return orig
+	})
+	require.NoError(t, rene.Commit(repo))
+	require.NoError(t, rene.VerifyKeyChain(repo))
+
+	v := func(keys ...*Key) *Version { return &Version{keys: keys} }
+	require.Nil(t, chainSigningKey(v(), v(keyA)))
+	require.Nil(t, chainSigningKey(v(keyA, keyB), v(keyB, keyA)))
+	require.Equal(t, keyA, chainSigningKey(v(keyA), v(keyB)))
+	require.Equal(t, keyB, chainSigningKey(v(keyA, keyB), v(keyB)))
+
+	// the mock repo doesn't sign, so the rotation is not trusted
+	rene.Mutate(func(orig Mutator) Mutator {
+		orig.Keys = []*Key{keyB}
+		return orig
+	})
+	require.NoError(t, rene.Commit(repo))
+	require.Error(t, rene.VerifyKeyChain(repo))
+}
@@ -0,0 +1,276 @@
+package identity
+
+import (
+	"fmt"
+	"net/mail"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/util/text"
+)
+
+// WatchLevel express the interest of an identity for some bugs
+type WatchLevel string
+
+const (
+	// WatchLevelNone means that there is no explicit preference
+	WatchLevelNone   WatchLevel = ""
+	WatchLevelWatch  WatchLevel = "watch"
+	WatchLevelIgnore WatchLevel = "ignore"
+)
+
+func (l WatchLevel) Validate() error {
+	switch l {
+	case WatchLevelWatch, WatchLevelIgnore:
+		return nil
+	default:
+		return fmt.Errorf("unknown watch level %s", l)
+	}
+}
+
+// EndpointType is the kind of destination of the notifications
+type EndpointType string
+
+const (
+	EndpointEmail   EndpointType = "email"
+	EndpointWebhook EndpointType = "webhook"
+)
+
+// NotificationEndpoint is a destination of the notifications of an identity
+type NotificationEndpoint struct {
+	Type EndpointType `json:"type"`
+	// the email address or the URL of the webhook
+	Target string `json:"target"`
+}
+
+func (e NotificationEndpoint) Validate() error {
+	switch e.Type {
+	case EndpointEmail:
+		addr, err := mail.ParseAddress(e.Target)
+		if err != nil || addr.Address != e.Target {
+			return fmt.Errorf("invalid email address \"%s\"", e.Target)
+		}
+	case EndpointWebhook:
+		u, err := url.Parse(e.Target)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("invalid webhook url \"%s\"", e.Target)
+		}
+	default:
+		return fmt.Errorf("unknown endpoint type %s", e.Type)
+	}
+	return nil
+}
+
+// NotificationPreferences hold what an identity want to be notified about,
+// per bug, per label or per query, whose activity it doesn't want to hear
+// about, and where the notifications are sent. They are stored along the
+// identity so they follow the user across machines.
+type NotificationPreferences struct {
+	Bugs    map[entity.Id]WatchLevel `json:"bugs,omitempty"`
+	Labels  map[string]WatchLevel    `json:"labels,omitempty"`
+	Queries map[string]WatchLevel    `json:"queries,omitempty"`
+
+	// the identities whose changes are not notified
+	Muted []entity.Id `json:"muted,omitempty"`
+
+	Endpoints []NotificationEndpoint `json:"endpoints,omitempty"`
+}
+
+// Clone make a deep copy
+func (np NotificationPreferences) Clone() NotificationPreferences {
+	clone := NotificationPreferences{}
+
+	for id, level := range np.Bugs {
+		clone.SetBug(id, level)
+	}
+	for label, level := range np.Labels {
+		clone.SetLabel(label, level)
+	}
+	for query, level := range np.Queries {
+		clone.SetQuery(query, level)
+	}
+	if len(np.Muted) > 0 {
+		clone.Muted = append([]entity.Id{}, np.Muted...)
+	}
+	if len(np.Endpoints) > 0 {
+		clone.Endpoints = append([]NotificationEndpoint{}, np.Endpoints...)
+	}
+
+	return clone
+}
+
+func (np NotificationPreferences) IsEmpty() bool {
+	return len(np.Bugs) == 0 && len(np.Labels) == 0 && len(np.Queries) == 0 &&
+		len(np.Muted) == 0 && len(np.Endpoints) == 0
+}
+
+func (np NotificationPreferences) Validate() error {
+	for id, level := range np.Bugs {
+		if err := id.Validate(); err != nil {
+			return errors.Wrap(err, "bug id")
+		}
+		if err := level.Validate(); err != nil {
+			return err
+		}
+	}
+
+	for label, level := range np.Labels {
+		if err := validatePreferenceKey(label); err != nil {
+			return errors.Wrap(err, "label")
+		}
+		if err := level.Validate(); err != nil {
+			return err
+		}
+	}
+
+	for query, level := range np.Queries {
+		if err := validatePreferenceKey(query); err != nil {
+			return errors.Wrap(err, "query")
+		}
+		if err := level.Validate(); err != nil {
+			return err
+		}
+	}
+
+	for _, id := range np.Muted {
+		if err := id.Validate(); err != nil {
+			return errors.Wrap(err, "muted identity id")
+		}
+	}
+
+	for _, endpoint := range np.Endpoints {
+		if err := endpoint.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func validatePreferenceKey(key string) error {
+	if text.Empty(key) {
+		return fmt.Errorf("empty")
+	}
+	if strings.Contains(key, "\n") || !text.Safe(key) {
+		return fmt.Errorf("should be a single printable line")
+	}
+	return nil
+}
+
+// SetBug set the preference for a bug. WatchLevelNone remove the preference.
+func (np *NotificationPreferences) SetBug(id entity.Id, level WatchLevel) {
+	if level == WatchLevelNone {
+		delete(np.Bugs, id)
+		return
+	}
+	if np.Bugs == nil {
+		np.Bugs = make(map[entity.Id]WatchLevel)
+	}
+	np.Bugs[id] = level
+}
+
+// SetLabel set the preference for the bugs with a label. WatchLevelNone remove the preference.
+func (np *NotificationPreferences) SetLabel(label string, level WatchLevel) {
+	if level == WatchLevelNone {
+		delete(np.Labels, label)
+		return
+	}
+	if np.Labels == nil {
+		np.Labels = make(map[string]WatchLevel)
+	}
+	np.Labels[label] = level
+}
+
+// SetQuery set the preference for the bugs matching a query. WatchLevelNone remove the preference.
+func (np *NotificationPreferences) SetQuery(query string, level WatchLevel) {
+	if level == WatchLevelNone {
+		delete(np.Queries, query)
+		return
+	}
+	if np.Queries == nil {
+		np.Queries = make(map[string]WatchLevel)
+	}
+	np.Queries[query] = level
+}
+
+// SetMuted mute or unmute the changes made by an identity
+func (np *NotificationPreferences) SetMuted(id entity.Id, muted bool) {
+	for i, m := range np.Muted {
+		if m == id {
+			if !muted {
+				np.Muted = append(np.Muted[:i:i], np.Muted[i+1:]...)
+			}
+			return
+		}
+	}
+	if muted {
+		np.Muted = append(np.Muted, id)
+	}
+}
+
+// IsMuted return whether the changes made by an identity are muted
+func (np NotificationPreferences) IsMuted(id entity.Id) bool {
+	for _, m := range np.Muted {
+		if m == id {
+			return true
+		}
+	}
+	return false
+}
+
+// AddEndpoint add a destination of the notifications, if not already there
+func (np *NotificationPreferences) AddEndpoint(endpoint NotificationEndpoint) {
+	for _, e := range np.Endpoints {
+		if e == endpoint {
+			return
+		}
+	}
+	np.Endpoints = append(np.Endpoints, endpoint)
+}
+
+// RemoveEndpoint remove a destination of the notifications, and return
+// whether it was there
+func (np *NotificationPreferences) RemoveEndpoint(endpoint NotificationEndpoint) bool {
+	for i, e := range np.Endpoints {
+		if e == endpoint {
+			np.Endpoints = append(np.Endpoints[:i:i], np.Endpoints[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// EndpointsOfType return the destinations of the notifications of a kind
+func (np NotificationPreferences) EndpointsOfType(t EndpointType) []string {
+	var result []string
+	for _, e := range np.Endpoints {
+		if e.Type == t {
+			result = append(result, e.Target)
+		}
+	}
+	return result
+}
+
+// ForBug resolve the preference for a bug from the bug and label preferences.
+// An explicit preference on the bug win, then an ignored label win over a
+// watched one. Queries are not resolved here as they need the query engine.
+func (np NotificationPreferences) ForBug(id entity.Id, labels []string) WatchLevel {
+	if level, ok := np.Bugs[id]; ok {
+		return level
+	}
+
+	result := WatchLevelNone
+	for _, label := range labels {
+		switch np.Labels[label] {
+		case WatchLevelIgnore:
+			return WatchLevelIgnore
+		case WatchLevelWatch:
+			result = WatchLevelWatch
+		}
+	}
+
+	return result
+}
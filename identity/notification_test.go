@@ -0,0 +1,95 @@
+package identity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+func TestNotificationPreferencesForBug(t *testing.T) {
+	watched := entity.Id("4fa0d3b1e2c9a0c4f2d3f6b7e8a9c0d1e2f3a4b5c6d7e8f9a0b1c2d3e4f5a6b7")
+	ignored := entity.Id("5fa0d3b1e2c9a0c4f2d3f6b7e8a9c0d1e2f3a4b5c6d7e8f9a0b1c2d3e4f5a6b7")
+	other := entity.Id("6fa0d3b1e2c9a0c4f2d3f6b7e8a9c0d1e2f3a4b5c6d7e8f9a0b1c2d3e4f5a6b7")
+
+	var np NotificationPreferences
+	np.SetBug(watched, WatchLevelWatch)
+	np.SetBug(ignored, WatchLevelIgnore)
+	np.SetLabel("security", WatchLevelWatch)
+	np.SetLabel("wontfix", WatchLevelIgnore)
+	require.NoError(t, np.Validate())
+
+	require.Equal(t, WatchLevelWatch, np.ForBug(watched, []string{"wontfix"}))
+	require.Equal(t, WatchLevelIgnore, np.ForBug(ignored, []string{"security"}))
+	require.Equal(t, WatchLevelWatch, np.ForBug(other, []string{"security"}))
+	require.Equal(t, WatchLevelIgnore, np.ForBug(other, []string{"security", "wontfix"}))
+	require.Equal(t, WatchLevelNone, np.ForBug(other, []string{"bug"}))
+
+	np.SetBug(watched, WatchLevelNone)
+	require.Equal(t, WatchLevelNone, np.ForBug(watched, nil))
+
+	np.SetQuery("label:ui\nstatus:open", WatchLevelWatch)
+	require.Error(t, np.Validate())
+}
+
+func TestNotificationPreferencesCommitLoad(t *testing.T) {
+	mockRepo := repository.NewMockRepoForTest()
+
+	identity := NewIdentity("René Descartes", "rene.descartes@example.com")
+	require.NoError(t, identity.Commit(mockRepo))
+
+	identity.Mutate(func(orig Mutator) Mutator {
+		orig.Notifications.SetLabel("security", WatchLevelWatch)
+		orig.Notifications.SetQuery("status:open label:ui", WatchLevelIgnore)
+		orig.Notifications.AddEndpoint(NotificationEndpoint{Type: EndpointWebhook, Target: "https://example.com/hook"})
+		return orig
+	})
+	require.True(t, identity.NeedCommit())
+	require.NoError(t, identity.Commit(mockRepo))
+
+	// an unrelated change keep the preferences
+	identity.Mutate(func(orig Mutator) Mutator {
+		orig.Login = "rene"
+		return orig
+	})
+	require.NoError(t, identity.Commit(mockRepo))
+
+	loaded, err := ReadLocal(mockRepo, identity.Id())
+	require.NoError(t, err)
+
+	np := loaded.NotificationPreferences()
+	require.Equal(t, WatchLevelWatch, np.Labels["security"])
+	require.Equal(t, WatchLevelIgnore, np.Queries["status:open label:ui"])
+	require.Equal(t, []string{"https://example.com/hook"}, np.EndpointsOfType(EndpointWebhook))
+}
+
+func TestNotificationPreferencesMutedEndpoints(t *testing.T) {
+	muted := entity.Id("4fa0d3b1e2c9a0c4f2d3f6b7e8a9c0d1e2f3a4b5c6d7e8f9a0b1c2d3e4f5a6b7")
+	other := entity.Id("5fa0d3b1e2c9a0c4f2d3f6b7e8a9c0d1e2f3a4b5c6d7e8f9a0b1c2d3e4f5a6b7")
+
+	var np NotificationPreferences
+	np.SetMuted(muted, true)
+	np.SetMuted(muted, true)
+	require.Len(t, np.Muted, 1)
+	require.True(t, np.IsMuted(muted))
+	require.False(t, np.IsMuted(other))
+
+	email := NotificationEndpoint{Type: EndpointEmail, Target: "rene@descartes.fr"}
+	np.AddEndpoint(email)
+	np.AddEndpoint(email)
+	require.Len(t, np.Endpoints, 1)
+	require.NoError(t, np.Validate())
+
+	clone := np.Clone()
+	clone.SetMuted(muted, false)
+	require.True(t, clone.RemoveEndpoint(email))
+	require.False(t, clone.RemoveEndpoint(email))
+	require.True(t, clone.IsEmpty())
+	require.True(t, np.IsMuted(muted))
+	require.Equal(t, []string{"rene@descartes.fr"}, np.EndpointsOfType(EndpointEmail))
+
+	np.AddEndpoint(NotificationEndpoint{Type: EndpointWebhook, Target: "ftp://example.com"})
+	require.Error(t, np.Validate())
+}
@@ -0,0 +1,88 @@
+package identity
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/util/text"
+)
+
+// ProofService is the kind of account or resource a proof claim the control of
+type ProofService string
+
+const (
+	// ProofServiceGitHub claim a GitHub login, with a statement published in
+	// a gist of that login
+	ProofServiceGitHub ProofService = "github"
+	// ProofServiceDomain claim a domain, with a statement published at
+	// https://<domain>/.well-known/git-bug-proof.txt
+	ProofServiceDomain ProofService = "domain"
+	// ProofServiceEmail claim an email address, bound to the OpenPGP key that
+	// signed the statement
+	ProofServiceEmail ProofService = "email"
+)
+
+func (s ProofService) Validate() error {
+	switch s {
+	case ProofServiceGitHub, ProofServiceDomain, ProofServiceEmail:
+		return nil
+	default:
+		return fmt.Errorf("unknown proof service %s", s)
+	}
+}
+
+// Proof is a statement, signed with a key of an identity, that the identity
+// control an external account or resource. Publishing the signed statement
+// from that account let anyone check the claim.
+type Proof struct {
+	Service ProofService `json:"service"`
+	// The GitHub login, the domain or the email address
+	Name string `json:"name"`
+	// The fingerprint of the key of the identity that signed the statement
+	Key string `json:"key"`
+	// Where the signed statement is published, for the services needing it
+	URL string `json:"url,omitempty"`
+	// The armored detached signature of the statement
+	Signature string `json:"signature"`
+}
+
+// ProofStatement is the text signed in a proof, binding an identity to an
+// external account or resource
+func ProofStatement(id entity.Id, service ProofService, name string, key string) string {
+	return fmt.Sprintf("I am the git-bug identity %s, signing with the key %s, and I control the %s %s.\n",
+		id, key, service, name)
+}
+
+// Statement return the text signed in the proof
+func (p *Proof) Statement(id entity.Id) string {
+	return ProofStatement(id, p.Service, p.Name, p.Key)
+}
+
+func (p *Proof) Validate() error {
+	if err := p.Service.Validate(); err != nil {
+		return err
+	}
+	if text.Empty(p.Name) || strings.ContainsAny(p.Name, " \n") || !text.Safe(p.Name) {
+		return fmt.Errorf("invalid proof name \"%s\"", p.Name)
+	}
+	if p.Key == "" {
+		return fmt.Errorf("proof without key")
+	}
+	if p.Signature == "" {
+		return fmt.Errorf("proof without signature")
+	}
+	if p.Service == ProofServiceGitHub {
+		u, err := url.Parse(p.URL)
+		if err != nil || u.Scheme != "https" || u.Host == "" {
+			return fmt.Errorf("invalid proof url \"%s\"", p.URL)
+		}
+	}
+	return nil
+}
+
+func (p *Proof) Clone() *Proof {
+	clone := *p
+	return &clone
+}
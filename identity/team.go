@@ -0,0 +1,65 @@
+package identity
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/MichaelMure/git-bug/entity"
+)
+
+// the handle of a team, without the @ prefix used to mention it
+var teamHandleRegexp = regexp.MustCompile(`^\w([\w.-]*\w)?$`)
+
+// the mentions of an identity in a text, like "@backend-team"
+var mentionRegexp = regexp.MustCompile(`(?:^|[^\w@])@([\w][\w.-]*[\w]|[\w])`)
+
+// NewTeam create a new team, a group identity designated by its login, the
+// handle used to assign it or mention it with @handle
+func NewTeam(name string, login string) *Identity {
+	i := NewIdentityFull(name, "", login, "")
+	i.versions[0].team = true
+	return i
+}
+
+// ValidTeamHandle return whether a login can be used as the handle of a team
+func ValidTeamHandle(handle string) bool {
+	return teamHandleRegexp.MatchString(handle)
+}
+
+// IsTeam return whether the identity is a team
+func (i *Identity) IsTeam() bool {
+	return i.lastVersion().team
+}
+
+// Members return the identities member of a team, as of the last version
+func (i *Identity) Members() []entity.Id {
+	members := i.lastVersion().members
+	if len(members) == 0 {
+		return nil
+	}
+	return append([]entity.Id{}, members...)
+}
+
+// IsMember return whether an identity is a member of the team
+func (i *Identity) IsMember(id entity.Id) bool {
+	for _, member := range i.lastVersion().members {
+		if member == id {
+			return true
+		}
+	}
+	return false
+}
+
+// Mentions return the handles mentioned in a text with @handle, lowercased
+func Mentions(text string) []string {
+	var result []string
+	seen := make(map[string]bool)
+	for _, match := range mentionRegexp.FindAllStringSubmatch(text, -1) {
+		handle := strings.ToLower(match[1])
+		if !seen[handle] {
+			seen[handle] = true
+			result = append(result, handle)
+		}
+	}
+	return result
+}
@@ -0,0 +1,45 @@
+package identity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+func TestTeam(t *testing.T) {
+	mockRepo := repository.NewMockRepoForTest()
+
+	rene := NewIdentity("René Descartes", "rene.descartes@example.com")
+	require.NoError(t, rene.Commit(mockRepo))
+
+	// only a team can have members
+	rene.Mutate(func(orig Mutator) Mutator {
+		orig.Members = append(orig.Members, rene.Id())
+		return orig
+	})
+	require.Error(t, rene.Validate())
+
+	team := NewTeam("Backend team", "backend-team")
+	require.NoError(t, team.Commit(mockRepo))
+
+	team.Mutate(func(orig Mutator) Mutator {
+		orig.Members = append(orig.Members, rene.Id())
+		return orig
+	})
+	require.NoError(t, team.Commit(mockRepo))
+
+	loaded, err := ReadLocal(mockRepo, team.Id())
+	require.NoError(t, err)
+	require.True(t, loaded.IsTeam())
+	require.True(t, loaded.IsMember(rene.Id()))
+
+	require.Error(t, NewTeam("", "not a handle").Validate())
+}
+
+func TestMentions(t *testing.T) {
+	require.Equal(t, []string{"backend-team", "rene"},
+		Mentions("@Backend-Team, could you and @rene have a look? cc @backend-team."))
+	require.Empty(t, Mentions("mail rene@descartes.fr"))
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/pkg/errors"
 
+	"github.com/MichaelMure/git-bug/entity"
 	"github.com/MichaelMure/git-bug/repository"
 	"github.com/MichaelMure/git-bug/util/lamport"
 	"github.com/MichaelMure/git-bug/util/text"
@@ -37,6 +38,19 @@ type Version struct {
 	// device) as well as revoke key.
 	keys []*Key
 
+	// The proofs that the identity control external accounts or resources.
+	// As for the keys, they are carried from one version to the next.
+	proofs []*Proof
+
+	// A team is a group identity, with member identities. Whether an identity
+	// is a team is decided at its creation, the members change with the versions.
+	team    bool
+	members []entity.Id
+
+	// What the identity want to be notified about. As for the keys, the preferences
+	// are carried from one version to the next.
+	notifications NotificationPreferences
+
 	// This optional array is here to ensure a better randomness of the identity id to avoid collisions.
 	// It has no functional purpose and should be ignored.
 	// It is advised to fill this array if there is not enough entropy, e.g. if there is no keys.
@@ -60,8 +74,13 @@ type VersionJSON struct {
 	Login     string            `json:"login,omitempty"`
 	AvatarUrl string            `json:"avatar_url,omitempty"`
 	Keys      []*Key            `json:"pub_keys,omitempty"`
+	Proofs    []*Proof          `json:"proofs,omitempty"`
+	Team      bool              `json:"team,omitempty"`
+	Members   []entity.Id       `json:"members,omitempty"`
 	Nonce     []byte            `json:"nonce,omitempty"`
 	Metadata  map[string]string `json:"metadata,omitempty"`
+
+	Notifications *NotificationPreferences `json:"notifications,omitempty"`
 }
 
 // Make a deep copy
@@ -69,18 +88,38 @@ func (v *Version) Clone() *Version {
 	clone := &Version{
 		name:      v.name,
 		email:     v.email,
+		login:     v.login,
 		avatarURL: v.avatarURL,
 		keys:      make([]*Key, len(v.keys)),
+		team:      v.team,
+	}
+
+	if len(v.members) > 0 {
+		clone.members = append([]entity.Id{}, v.members...)
 	}
 
 	for i, key := range v.keys {
 		clone.keys[i] = key.Clone()
 	}
 
+	if len(v.proofs) > 0 {
+		clone.proofs = make([]*Proof, len(v.proofs))
+		for i, proof := range v.proofs {
+			clone.proofs[i] = proof.Clone()
+		}
+	}
+
+	clone.notifications = v.notifications.Clone()
+
 	return clone
 }
 
 func (v *Version) MarshalJSON() ([]byte, error) {
+	var notifications *NotificationPreferences
+	if !v.notifications.IsEmpty() {
+		notifications = &v.notifications
+	}
+
 	return json.Marshal(VersionJSON{
 		FormatVersion: formatVersion,
 		Time:          v.time,
@@ -90,8 +129,12 @@ func (v *Version) MarshalJSON() ([]byte, error) {
 		Login:         v.login,
 		AvatarUrl:     v.avatarURL,
 		Keys:          v.keys,
+		Proofs:        v.proofs,
+		Team:          v.team,
+		Members:       v.members,
 		Nonce:         v.nonce,
 		Metadata:      v.metadata,
+		Notifications: notifications,
 	})
 }
 
@@ -113,8 +156,14 @@ func (v *Version) UnmarshalJSON(data []byte) error {
 	v.login = aux.Login
 	v.avatarURL = aux.AvatarUrl
 	v.keys = aux.Keys
+	v.proofs = aux.Proofs
+	v.team = aux.Team
+	v.members = aux.Members
 	v.nonce = aux.Nonce
 	v.metadata = aux.Metadata
+	if aux.Notifications != nil {
+		v.notifications = *aux.Notifications
+	}
 
 	return nil
 }
@@ -170,6 +219,30 @@ func (v *Version) Validate() error {
 		}
 	}
 
+	for _, p := range v.proofs {
+		if err := p.Validate(); err != nil {
+			return errors.Wrap(err, "invalid proof")
+		}
+	}
+
+	if v.team && !ValidTeamHandle(v.login) {
+		return fmt.Errorf("a team should have a login without spaces or @ to be mentioned")
+	}
+
+	if !v.team && len(v.members) > 0 {
+		return fmt.Errorf("only a team can have members")
+	}
+
+	for _, member := range v.members {
+		if err := member.Validate(); err != nil {
+			return errors.Wrap(err, "invalid member")
+		}
+	}
+
+	if err := v.notifications.Validate(); err != nil {
+		return errors.Wrap(err, "invalid notification preferences")
+	}
+
 	return nil
 }
 
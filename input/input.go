@@ -13,6 +13,7 @@ import (
 	"os/exec"
 	"strings"
 
+	"github.com/MichaelMure/git-bug/bug"
 	"github.com/MichaelMure/git-bug/repository"
 	"github.com/pkg/errors"
 )
@@ -93,6 +94,102 @@ func processCreate(raw string) (string, string, error) {
 	return title, message, nil
 }
 
+// BugFile is a new bug described by a markdown file. An optional front-matter
+// can set the title, labels, assignees, custom fields and metadata:
+//
+//	---
+//	title: Crash on startup
+//	labels: bug, crash
+//	assignee: me
+//	fields:
+//	  severity: high
+//	metadata:
+//	  ci-job: "1234"
+//	---
+//	## Stacktrace
+type BugFile struct {
+	Title   string
+	Message string
+	Labels  []string
+	// id prefixes of the identities to assign, or "me"
+	Assignees []string
+	Fields    map[string]string
+	Metadata  map[string]string
+}
+
+// Template return a template applying the labels and custom fields of the file
+func (bf *BugFile) Template() *bug.Template {
+	return &bug.Template{Labels: bf.Labels, Fields: bf.Fields}
+}
+
+// BugFileInput read a bug from either a file or the standard input. Without
+// front-matter, the file is processed like BugCreateFileInput. Otherwise, the
+// title is taken from the front-matter, or from the first non-empty line.
+func BugFileInput(fileName string) (*BugFile, error) {
+	raw, err := fromFile(fileName)
+	if err != nil {
+		return nil, err
+	}
+
+	return processBugFile(raw)
+}
+
+func processBugFile(raw string) (*BugFile, error) {
+	header, content, err := bug.SplitFrontMatter(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	if header == nil {
+		title, message, err := processCreate(raw)
+		if err != nil {
+			return nil, err
+		}
+		return &BugFile{Title: title, Message: message}, nil
+	}
+
+	fm, err := bug.ParseFrontMatter(header, "fields", "metadata")
+	if err != nil {
+		return nil, err
+	}
+	err = fm.CheckKeys("title", "labels", "assignee", "assignees", "fields", "metadata")
+	if err != nil {
+		return nil, err
+	}
+
+	bf := &BugFile{
+		Title:     fm.Values["title"],
+		Labels:    fm.List("labels"),
+		Assignees: append(fm.List("assignee"), fm.List("assignees")...),
+		Fields:    fm.Blocks["fields"],
+		Metadata:  fm.Blocks["metadata"],
+	}
+
+	content = strings.TrimSpace(content)
+
+	if bf.Title == "" {
+		// the first line, usually a markdown heading, is the title
+		split := strings.SplitN(content, "\n", 2)
+		bf.Title = strings.TrimSpace(strings.TrimLeft(split[0], "#"))
+		content = ""
+		if len(split) == 2 {
+			content = strings.TrimSpace(split[1])
+		}
+	}
+
+	if bf.Title == "" {
+		return nil, ErrEmptyTitle
+	}
+
+	bf.Message = content
+
+	if err := bf.Template().Validate(); err != nil {
+		return nil, err
+	}
+
+	return bf, nil
+}
+
 const bugCommentTemplate = `%s
 
 # Please enter the comment message. Lines starting with '#' will be ignored,
@@ -196,6 +293,7 @@ const queryTemplate = `%s
 # - title:<title>
 # - label:<label>
 # - no:label
+# - with:duplicates
 #
 # Sorting
 #
@@ -236,6 +334,108 @@ func QueryEditorInput(repo repository.RepoCommon, preQuery string) (string, erro
 	return "", nil
 }
 
+const batchEditTemplate = `%s
+# Please edit the status, the labels or the title of the bugs above, one bug
+# per line:
+#
+#   <open|closed> <id> [<label>, <label>] <title>
+#
+# Removing a line leaves the bug untouched, and removing all of them aborts
+# the operation. Lines starting with '#' will be ignored.
+`
+
+// BatchEditLine is the description of a bug for a batch edition
+type BatchEditLine struct {
+	Status string
+	Id     string
+	Labels []string
+	Title  string
+}
+
+// BugBatchEditorInput will open the default editor in the terminal with the
+// given bugs, one per line, for the user to edit. The file is then processed
+// to extract the edited bugs.
+func BugBatchEditorInput(repo repository.RepoCommon, lines []BatchEditLine) ([]BatchEditLine, error) {
+	var buf bytes.Buffer
+	for _, line := range lines {
+		buf.WriteString(line.Format())
+		buf.WriteString("\n")
+	}
+
+	template := fmt.Sprintf(batchEditTemplate, buf.String())
+	raw, err := launchEditorWithTemplate(repo, messageFilename, template)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return processBatchEdit(raw)
+}
+
+func processBatchEdit(raw string) ([]BatchEditLine, error) {
+	var result []BatchEditLine
+
+	for i, line := range strings.Split(raw, "\n") {
+		if strings.HasPrefix(line, "#") || strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		parsed, err := ParseBatchEditLine(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", i+1, err)
+		}
+
+		result = append(result, parsed)
+	}
+
+	return result, nil
+}
+
+// Format the line as presented in the editor
+func (bel BatchEditLine) Format() string {
+	return fmt.Sprintf("%-6s %s [%s] %s", bel.Status, bel.Id, strings.Join(bel.Labels, ", "), bel.Title)
+}
+
+// ParseBatchEditLine parse a line formatted with BatchEditLine.Format
+func ParseBatchEditLine(line string) (BatchEditLine, error) {
+	var result BatchEditLine
+
+	result.Status, line = cutField(line)
+	result.Id, line = cutField(line)
+	if result.Id == "" {
+		return BatchEditLine{}, fmt.Errorf("missing bug id")
+	}
+
+	line = strings.TrimSpace(line)
+	end := strings.Index(line, "]")
+	if !strings.HasPrefix(line, "[") || end < 0 {
+		return BatchEditLine{}, fmt.Errorf("missing labels, use [] for none")
+	}
+
+	for _, label := range strings.Split(line[1:end], ",") {
+		if label = strings.TrimSpace(label); label != "" {
+			result.Labels = append(result.Labels, label)
+		}
+	}
+
+	result.Title = strings.TrimSpace(line[end+1:])
+	if result.Title == "" {
+		return BatchEditLine{}, ErrEmptyTitle
+	}
+
+	return result, nil
+}
+
+// cutField return the first space separated field of a string, and the rest
+func cutField(str string) (string, string) {
+	str = strings.TrimLeft(str, " \t")
+	i := strings.IndexAny(str, " \t")
+	if i < 0 {
+		return str, ""
+	}
+	return str[:i], str[i:]
+}
+
 // launchEditorWithTemplate will launch an editor as launchEditor do, but with a
 // provided template.
 func launchEditorWithTemplate(repo repository.RepoCommon, fileName string, template string) (string, error) {
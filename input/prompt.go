@@ -131,6 +131,23 @@ loop:
 	}
 }
 
+// PromptConfirm ask a yes/no question, no being the default.
+func PromptConfirm(prompt string) (bool, error) {
+	_, _ = fmt.Fprintf(os.Stderr, "%s [y/N]: ", prompt)
+
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil {
+		return false, err
+	}
+
+	switch strings.ToLower(strings.TrimSpace(line)) {
+	case "y", "yes":
+		return true, nil
+	default:
+		return false, nil
+	}
+}
+
 // PromptChoice is a prompt giving possible choices
 // Return the index starting at zero of the choice selected.
 func PromptChoice(prompt string, choices []string) (int, error) {
@@ -2,6 +2,7 @@ package main
 
 import (
 	"fmt"
+	"io/ioutil"
 	"os"
 	"path"
 	"sync"
@@ -57,8 +58,58 @@ func genPowerShell(root *cobra.Command) error {
 	return root.GenPowerShellCompletionFile(filepath)
 }
 
+// the zsh completion of cobra doesn't support the dynamic completions, so
+// this script delegate everything to "git-bug __complete"
+const zshCompletion = `#compdef _git-bug git-bug
+
+# zsh completion for git-bug, relying on the dynamic completion of the
+# binary itself ("git-bug __complete ...")
+
+_git-bug()
+{
+    local -a completions lines
+    local out directive comp
+
+    # an empty word being completed is kept as an empty argument
+    out=$(git-bug __complete "${(@)words[2,CURRENT]}" 2>/dev/null) || return 1
+
+    lines=("${(@f)out}")
+    directive=${lines[-1]#:}
+    lines=("${(@)lines[1,-2]}")
+
+    # ShellCompDirectiveError
+    if (( directive & 1 )); then
+        return 1
+    fi
+
+    for comp in $lines; do
+        # the value and its description are separated by a ":" for zsh
+        comp=${comp//:/\\:}
+        comp=${comp/$'\t'/:}
+        completions+=("$comp")
+    done
+
+    if (( ${#completions} > 0 )); then
+        # ShellCompDirectiveNoSpace
+        if (( directive & 2 )); then
+            _describe 'completions' completions -S ''
+        else
+            _describe 'completions' completions
+        fi
+    elif (( ! (directive & 4) )); then
+        # no ShellCompDirectiveNoFileComp
+        _files
+    fi
+}
+
+# don't run the completion function when being sourced
+if [ "$funcstack[1]" = "_git-bug" ]; then
+    _git-bug
+fi
+`
+
 func genZsh(root *cobra.Command) error {
 	cwd, _ := os.Getwd()
 	filepath := path.Join(cwd, "misc", "zsh_completion", "git-bug")
-	return root.GenZshCompletionFile(filepath)
+	return ioutil.WriteFile(filepath, []byte(zshCompletion), 0644)
 }
@@ -0,0 +1,237 @@
+// Package proof sign and verify the proofs that an identity control external
+// accounts or resources, like a GitHub login or a domain.
+package proof
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"strings"
+
+	"golang.org/x/crypto/openpgp"
+
+	"github.com/MichaelMure/git-bug/identity"
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+// SSHNamespace is the namespace of the SSH signatures of the statements, so
+// that they can't be mistaken for the signature of something else
+const SSHNamespace = "git-bug-proof"
+
+// DomainPath is where the signed statement of a domain proof is published
+const DomainPath = "/.well-known/git-bug-proof.txt"
+
+// the maximum size of a published statement
+const maxBodySize = 1 << 20
+
+// Sign produce the armored detached signature of a statement, with gpg for an
+// OpenPGP key or ssh-keygen for an SSH key, which both can reach the private
+// key in their agent
+func Sign(key *identity.Key, statement string) (string, error) {
+	var cmd *exec.Cmd
+
+	if key.IsSSH() {
+		// ssh-keygen find the private key in ssh-agent from the public one
+		f, err := ioutil.TempFile("", "git-bug-proof-*.pub")
+		if err != nil {
+			return "", err
+		}
+		defer os.Remove(f.Name())
+		_, err = f.WriteString(key.PubKey + "\n")
+		if err == nil {
+			err = f.Close()
+		}
+		if err != nil {
+			return "", err
+		}
+		cmd = exec.Command("ssh-keygen", "-Y", "sign", "-n", SSHNamespace, "-f", f.Name())
+	} else {
+		cmd = exec.Command("gpg", "--armor", "--detach-sign", "--local-user", key.Fingerprint)
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdin = strings.NewReader(statement)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	err := cmd.Run()
+	if err != nil {
+		return "", fmt.Errorf("signing the statement: %v: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	return stdout.String(), nil
+}
+
+// Verify check a proof of an identity: that it is signed by one of its
+// registered keys, and that the signed statement is published by the claimed
+// account or resource. Without client, only the signature is checked.
+func Verify(ctx context.Context, client *http.Client, i identity.Interface, p *identity.Proof) error {
+	if err := p.Validate(); err != nil {
+		return err
+	}
+
+	var key *identity.Key
+	for _, k := range i.Keys() {
+		if strings.EqualFold(k.Fingerprint, p.Key) {
+			key = k
+			break
+		}
+	}
+	if key == nil {
+		return fmt.Errorf("the key %s is not registered on the identity", p.Key)
+	}
+
+	_, err := repository.VerifyDetachedSignature([]byte(p.Statement(i.Id())), p.Signature, []string{key.PubKey}, SSHNamespace)
+	if err != nil {
+		return err
+	}
+
+	if p.Service == identity.ProofServiceEmail {
+		return verifyEmail(key, p.Name)
+	}
+
+	if client == nil {
+		return nil
+	}
+
+	published, err := fetchPublished(ctx, client, p)
+	if err != nil {
+		return err
+	}
+
+	// the line breaks and indentation might differ once published
+	if !strings.Contains(normalize(published), normalize(p.Signature)) {
+		return fmt.Errorf("the signed statement is not published at %s", PublishURL(p))
+	}
+
+	return nil
+}
+
+// FindPublished look for a signature of the statement of a proof where it
+// should be published, and return the proof completed with it
+func FindPublished(ctx context.Context, client *http.Client, i identity.Interface, p identity.Proof) (*identity.Proof, error) {
+	published, err := fetchPublished(ctx, client, &p)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, signature := range armoredBlocks(published) {
+		p.Signature = signature
+		if Verify(ctx, nil, i, &p) == nil {
+			return &p, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no signed statement found at %s", PublishURL(&p))
+}
+
+// PublishURL return where the signed statement of a proof must be published,
+// if any
+func PublishURL(p *identity.Proof) string {
+	switch p.Service {
+	case identity.ProofServiceGitHub:
+		return p.URL
+	case identity.ProofServiceDomain:
+		return "https://" + p.Name + DomainPath
+	default:
+		return ""
+	}
+}
+
+// fetchPublished fetch the content where the statement of a proof is
+// published
+func fetchPublished(ctx context.Context, client *http.Client, p *identity.Proof) (string, error) {
+	target := PublishURL(p)
+	if target == "" {
+		return "", fmt.Errorf("a %s proof is not published", p.Service)
+	}
+
+	if p.Service == identity.ProofServiceGitHub {
+		// only the raw content of a gist of the login is trusted
+		u, err := url.Parse(target)
+		if err != nil {
+			return "", err
+		}
+		owner := strings.SplitN(strings.TrimPrefix(u.Path, "/"), "/", 2)[0]
+		if u.Host != "gist.githubusercontent.com" || !strings.EqualFold(owner, p.Name) {
+			return "", fmt.Errorf("the statement must be published in a gist of %s, at https://gist.githubusercontent.com/%s/...", p.Name, p.Name)
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("fetching %s: unexpected status %s", target, resp.Status)
+	}
+
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxBodySize))
+	if err != nil {
+		return "", err
+	}
+
+	return string(body), nil
+}
+
+// armoredBlocks extract the armored OpenPGP and SSH signatures of a text
+func armoredBlocks(text string) []string {
+	var result []string
+	var block []string
+
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "-----BEGIN PGP SIGNATURE-----" || line == "-----BEGIN SSH SIGNATURE-----":
+			block = []string{line}
+		case block == nil:
+		case line == "-----END PGP SIGNATURE-----" || line == "-----END SSH SIGNATURE-----":
+			block = append(block, line)
+			result = append(result, strings.Join(block, "\n")+"\n")
+			block = nil
+		default:
+			block = append(block, line)
+		}
+	}
+
+	return result
+}
+
+// verifyEmail check that an email address is bound to an OpenPGP key by one
+// of its user ids
+func verifyEmail(key *identity.Key, email string) error {
+	if key.IsSSH() {
+		return fmt.Errorf("an email proof must be signed with an OpenPGP key")
+	}
+
+	entities, err := openpgp.ReadArmoredKeyRing(strings.NewReader(key.PubKey))
+	if err != nil {
+		return err
+	}
+	for _, entity := range entities {
+		for _, id := range entity.Identities {
+			if id.UserId != nil && strings.EqualFold(id.UserId.Email, email) {
+				return nil
+			}
+		}
+	}
+
+	return fmt.Errorf("the email %s is not a user id of the key %s", email, key.Fingerprint)
+}
+
+func normalize(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
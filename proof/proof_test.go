@@ -0,0 +1,94 @@
+package proof
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/openpgp"
+	"golang.org/x/crypto/openpgp/armor"
+
+	"github.com/MichaelMure/git-bug/identity"
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+func TestVerify(t *testing.T) {
+	repo := repository.NewMockRepoForTest()
+
+	entity, err := openpgp.NewEntity("René Descartes", "", "rene@descartes.fr", nil)
+	require.NoError(t, err)
+	var pubKey bytes.Buffer
+	w, err := armor.Encode(&pubKey, openpgp.PublicKeyType, nil)
+	require.NoError(t, err)
+	require.NoError(t, entity.Serialize(w))
+	require.NoError(t, w.Close())
+	key, err := identity.NewKeyFromArmored(pubKey.String())
+	require.NoError(t, err)
+
+	rene := identity.NewIdentity("René Descartes", "rene@descartes.fr")
+	rene.Mutate(func(orig identity.Mutator) identity.Mutator {
+		orig.Keys = []*identity.Key{key}
+		return orig
+	})
+	require.NoError(t, rene.Commit(repo))
+
+	sign := func(service identity.ProofService, name string) *identity.Proof {
+		p := &identity.Proof{Service: service, Name: name, Key: key.Fingerprint}
+		var sig bytes.Buffer
+		err := openpgp.ArmoredDetachSign(&sig, entity, strings.NewReader(p.Statement(rene.Id())), nil)
+		require.NoError(t, err)
+		p.Signature = sig.String()
+		return p
+	}
+
+	ctx := context.Background()
+
+	// email, bound to the key
+	require.NoError(t, Verify(ctx, nil, rene, sign(identity.ProofServiceEmail, "rene@descartes.fr")))
+	require.Error(t, Verify(ctx, nil, rene, sign(identity.ProofServiceEmail, "isaac@newton.uk")))
+
+	// a statement signed for another claim
+	tampered := sign(identity.ProofServiceEmail, "rene@descartes.fr")
+	tampered.Name = "RENE@descartes.fr"
+	require.Error(t, Verify(ctx, nil, rene, tampered))
+
+	// domain, published on the server
+	var published atomic.Value
+	published.Store("")
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		content := published.Load().(string)
+		if r.URL.Path != DomainPath || content == "" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(content))
+	}))
+	defer server.Close()
+
+	u, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	domain := sign(identity.ProofServiceDomain, u.Host)
+
+	require.Error(t, Verify(ctx, server.Client(), rene, domain))
+	published.Store("other proofs\n" + domain.Signature)
+	require.NoError(t, Verify(ctx, server.Client(), rene, domain))
+
+	found, err := FindPublished(ctx, server.Client(), rene, identity.Proof{
+		Service: identity.ProofServiceDomain,
+		Name:    u.Host,
+		Key:     key.Fingerprint,
+	})
+	require.NoError(t, err)
+	require.Equal(t, normalize(domain.Signature), normalize(found.Signature))
+
+	// github, only from a gist of the login
+	github := sign(identity.ProofServiceGitHub, "rene")
+	github.URL = server.URL + "/rene/proof.txt"
+	require.Error(t, Verify(ctx, server.Client(), rene, github))
+}
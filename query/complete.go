@@ -0,0 +1,85 @@
+package query
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/MichaelMure/git-bug/bug"
+)
+
+// Complete return the possible completions of the last word of a query being
+// typed, as the byte offset where this word start and the words it can be
+// replaced by. Without qualifier, the word is completed as a qualifier.
+// Otherwise, the value is completed from the fixed values of the qualifier,
+// or from the given labels and extended statuses of the repository.
+func Complete(query string, labels []string, statuses []string) (int, []string) {
+	start := lastWordOffset(query)
+	word := query[start:]
+
+	negation := ""
+	if strings.HasPrefix(word, "-") {
+		negation = "-"
+		word = word[1:]
+	}
+
+	split := strings.SplitN(word, ":", 2)
+
+	if len(split) == 1 {
+		var result []string
+		for _, q := range qualifiers {
+			if strings.HasPrefix(q, word) {
+				result = append(result, negation+q+":")
+			}
+		}
+		return start, result
+	}
+
+	qualifier, value := split[0], strings.TrimLeft(split[1], `"'`)
+
+	var values []string
+	switch qualifier {
+	case "label", "label-all", "label-any":
+		values = labels
+	case "status", "state":
+		values = append([]string{bug.OpenStatus.String(), bug.ClosedStatus.String()}, statuses...)
+	default:
+		values = qualifierValues[qualifier]
+	}
+
+	var result []string
+	for _, v := range values {
+		if !strings.HasPrefix(v, value) {
+			continue
+		}
+		if strings.IndexFunc(v, unicode.IsSpace) >= 0 {
+			v = `"` + v + `"`
+		}
+		result = append(result, negation+qualifier+":"+v)
+	}
+	sort.Strings(result)
+
+	return start, result
+}
+
+// lastWordOffset return the byte offset of the last word of a query, ignoring
+// the spaces inside quotes
+func lastWordOffset(query string) int {
+	start := 0
+	quote := rune(0)
+
+	for i, r := range query {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case isQuote(r):
+			quote = r
+		case unicode.IsSpace(r):
+			start = i + 1
+		}
+	}
+
+	return start
+}
@@ -0,0 +1,37 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComplete(t *testing.T) {
+	labels := []string{"bug", "prio/high", "prio/low", "needs review"}
+	statuses := []string{"in-progress"}
+
+	var tests = []struct {
+		input       string
+		start       int
+		completions []string
+	}{
+		{"wi", 0, []string{"with:"}},
+		{"auth", 0, []string{"author:"}},
+		{"status:open lab", 12, []string{"label:", "label-all:", "label-any:"}},
+		{"-lab", 0, []string{"-label:", "-label-all:", "-label-any:"}},
+		{"label:prio", 0, []string{"label:prio/high", "label:prio/low"}},
+		{"label:", 0, []string{`label:"needs review"`, "label:bug", "label:prio/high", "label:prio/low"}},
+		{`label:"needs`, 0, []string{`label:"needs review"`}},
+		{`author:"René Descartes" -label:b`, 25, []string{"-label:bug"}},
+		{"status:", 0, []string{"status:closed", "status:in-progress", "status:open"}},
+		{"sort:edit", 0, []string{"sort:edit", "sort:edit-asc", "sort:edit-desc"}},
+		{"author:r", 0, nil},
+		{"unknown", 0, nil},
+	}
+
+	for _, tc := range tests {
+		start, completions := Complete(tc.input, labels, statuses)
+		assert.Equal(t, tc.start, start, tc.input)
+		assert.Equal(t, tc.completions, completions, tc.input)
+	}
+}
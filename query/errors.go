@@ -0,0 +1,161 @@
+package query
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// ParseError is an error in a query, located on the offending token
+type ParseError struct {
+	Query string
+	// Offset is the byte offset of the offending token in the query
+	Offset int
+	// Token is the offending token, as written in the query
+	Token   string
+	Message string
+	// Suggestions hold the valid alternatives to the offending token, if any
+	Suggestions []string
+}
+
+func (e *ParseError) Error() string {
+	msg := fmt.Sprintf("invalid query at offset %d (%s): %s", e.Offset, e.Token, e.Message)
+
+	if len(e.Suggestions) > 0 {
+		quoted := make([]string, len(e.Suggestions))
+		for i, s := range e.Suggestions {
+			quoted[i] = fmt.Sprintf("\"%s\"", s)
+		}
+		msg += fmt.Sprintf(", did you mean %s?", strings.Join(quoted, " or "))
+	}
+
+	return msg
+}
+
+// Pointer return the query with the offending token underlined on the
+// next line, to show the error in a terminal
+func (e *ParseError) Pointer() string {
+	offset := e.Offset
+	if offset > len(e.Query) {
+		offset = len(e.Query)
+	}
+
+	column := utf8.RuneCountInString(e.Query[:offset])
+	length := utf8.RuneCountInString(e.Token)
+	if length < 1 {
+		length = 1
+	}
+
+	return fmt.Sprintf("%s\n%s^%s", e.Query, strings.Repeat(" ", column), strings.Repeat("~", length-1))
+}
+
+func newParseError(query string, t positionedToken, format string, a ...interface{}) *ParseError {
+	return &ParseError{
+		Query:   query,
+		Offset:  t.offset,
+		Token:   t.raw,
+		Message: fmt.Sprintf(format, a...),
+	}
+}
+
+// qualifiers are the valid qualifiers of a query, negated or not
+var qualifiers = []string{
+	"actor", "actors", "assignee", "author", "checklist", "comments",
+	"created-after", "created-before", "edited-after", "edited-before",
+	"exported-to", "field", "label", "label-all", "label-any", "metadata",
+	"milestone", "no", "not-exported", "participant", "participants",
+	"priority", "search", "sort", "state", "status", "subscriber", "title",
+	"title~", "with",
+}
+
+// qualifierValues are the valid values of the qualifiers accepting only a
+// fixed set of them
+var qualifierValues = map[string][]string{
+	"checklist": {"complete", "incomplete"},
+	"no":        {"assignee", "label", "milestone"},
+	"with":      {"duplicates"},
+	"sort": {
+		"comments", "comments-asc", "comments-desc",
+		"creation", "creation-asc", "creation-desc",
+		"due", "due-asc", "due-desc",
+		"edit", "edit-asc", "edit-desc",
+		"id", "id-asc", "id-desc",
+		"priority", "priority-asc", "priority-desc",
+	},
+}
+
+func isQualifier(qualifier string) bool {
+	for _, q := range qualifiers {
+		if q == qualifier {
+			return true
+		}
+	}
+	return false
+}
+
+// suggest return the candidates close to a misspelled word, the closest
+// first
+func suggest(word string, candidates []string) []string {
+	// allow roughly one typo every three characters
+	maxDistance := 1 + len(word)/3
+
+	type scored struct {
+		candidate string
+		distance  int
+	}
+	var matches []scored
+	for _, c := range candidates {
+		d := editDistance(word, c)
+		if d <= maxDistance || (len(word) > 1 && strings.HasPrefix(c, word)) {
+			matches = append(matches, scored{c, d})
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].distance < matches[j].distance
+	})
+
+	// only a few suggestions are helpful
+	if len(matches) > 3 {
+		matches = matches[:3]
+	}
+
+	result := make([]string, len(matches))
+	for i, m := range matches {
+		result[i] = m.candidate
+	}
+	return result
+}
+
+// editDistance compute the Levenshtein distance between two strings
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = minInt(minInt(prev[j]+1, curr[j-1]+1), prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(rb)]
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
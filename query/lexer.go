@@ -1,7 +1,6 @@
 package query
 
 import (
-	"fmt"
 	"strings"
 	"unicode"
 )
@@ -11,39 +10,111 @@ type token struct {
 	value     string
 }
 
+// positionedToken is a token along with its location in the query, to
+// report errors precisely
+type positionedToken struct {
+	token
+	// byte offset of the token in the query
+	offset int
+	// the token as written in the query
+	raw string
+}
+
 // tokenize parse and break a input into tokens ready to be
 // interpreted later by a parser to get the semantic.
 func tokenize(query string) ([]token, error) {
-	fields, err := splitQuery(query)
+	positioned, err := tokenizeWithPosition(query)
 	if err != nil {
 		return nil, err
 	}
 
 	var tokens []token
-	for _, field := range fields {
-		split := strings.Split(field, ":")
+	for _, t := range positioned {
+		tokens = append(tokens, t.token)
+	}
+	return tokens, nil
+}
+
+// tokenizeWithPosition is like tokenize, but keep the location of the tokens
+func tokenizeWithPosition(query string) ([]positionedToken, error) {
+	fields, err := splitQuery(query)
+	if err != nil {
+		return nil, err
+	}
+
+	var tokens []positionedToken
+	for _, f := range fields {
+		field := f.text
+		located := func(qualifier, value string) positionedToken {
+			return positionedToken{
+				token:  token{qualifier: qualifier, value: value},
+				offset: f.offset,
+				raw:    field,
+			}
+		}
+		fail := func(format string, a ...interface{}) error {
+			return newParseError(query, located("", ""), format, a...)
+		}
+
+		// a qualifier is everything before the first colon, so the function
+		// form only apply to a field without one, like search("some words"),
+		// and not to a value like title:fix(parser)
+		colon := strings.Index(field, ":")
+		if i := strings.Index(field, "("); i > 0 && (colon < 0 || i < colon) &&
+			!isQuote(rune(field[0])) && strings.HasSuffix(field, ")") {
+			value := removeQuote(field[i+1 : len(field)-1])
+			if len(value) == 0 {
+				return nil, fail("empty value for function \"%s\"", field[:i])
+			}
+			tokens = append(tokens, located(field[:i], value))
+			continue
+		}
+
+		// bare words and quoted text are a full-text search
+		if isQuote(rune(field[0])) || !strings.Contains(field, ":") {
+			qualifier := "search"
+			text := field
+			if len(text) > 1 && text[0] == '-' {
+				qualifier = "-search"
+				text = text[1:]
+			}
+			value := removeQuote(text)
+			if len(value) == 0 {
+				return nil, fail("empty search text")
+			}
+			tokens = append(tokens, located(qualifier, value))
+			continue
+		}
+
+		// the value can contain colons, for instance in a date or an url
+		split := strings.SplitN(field, ":", 2)
 		if len(split) != 2 {
-			return nil, fmt.Errorf("can't tokenize \"%s\"", field)
+			return nil, fail("can't tokenize \"%s\"", field)
 		}
 
 		if len(split[0]) == 0 {
-			return nil, fmt.Errorf("can't tokenize \"%s\": empty qualifier", field)
+			return nil, fail("can't tokenize \"%s\": empty qualifier", field)
 		}
 		if len(split[1]) == 0 {
-			return nil, fmt.Errorf("empty value for qualifier \"%s\"", split[0])
+			return nil, fail("empty value for qualifier \"%s\"", split[0])
 		}
 
-		tokens = append(tokens, token{
-			qualifier: split[0],
-			value:     removeQuote(split[1]),
-		})
+		tokens = append(tokens, located(split[0], removeQuote(split[1])))
 	}
 	return tokens, nil
 }
 
-func splitQuery(query string) ([]string, error) {
+// queryField is a whitespace separated part of a query, quotes included
+type queryField struct {
+	text string
+	// byte offset of the field in the query
+	offset int
+}
+
+func splitQuery(query string) ([]queryField, error) {
 	lastQuote := rune(0)
 	inQuote := false
+	quoteOffset := 0
 
 	isToken := func(r rune) bool {
 		switch {
@@ -62,25 +133,38 @@ func splitQuery(query string) ([]string, error) {
 		}
 	}
 
-	var result []string
+	var result []queryField
 	var token strings.Builder
-	for _, r := range query {
+	start := 0
+	for i, r := range query {
+		wasInQuote := inQuote
 		if isToken(r) {
+			if token.Len() == 0 {
+				start = i
+			}
+			if !wasInQuote && inQuote {
+				quoteOffset = i
+			}
 			token.WriteRune(r)
 		} else {
 			if token.Len() > 0 {
-				result = append(result, token.String())
+				result = append(result, queryField{text: token.String(), offset: start})
 				token.Reset()
 			}
 		}
 	}
 
 	if inQuote {
-		return nil, fmt.Errorf("unmatched quote")
+		return nil, &ParseError{
+			Query:   query,
+			Offset:  quoteOffset,
+			Token:   query[quoteOffset:],
+			Message: "unmatched quote",
+		}
 	}
 
 	if token.Len() > 0 {
-		result = append(result, token.String())
+		result = append(result, queryField{text: token.String(), offset: start})
 	}
 
 	return result, nil
@@ -11,7 +11,7 @@ func TestTokenize(t *testing.T) {
 		input  string
 		tokens []token
 	}{
-		{"gibberish", nil},
+		{"gibberish", []token{{"search", "gibberish"}}},
 		{"status:", nil},
 		{":value", nil},
 
@@ -37,6 +37,30 @@ func TestTokenize(t *testing.T) {
 		// unmatched quotes
 		{`key:'value value`, nil},
 		{`key:value value'`, nil},
+
+		// colons in the value
+		{`key:a:b`, []token{{"key", "a:b"}}},
+		{`key:"2020-01-31T10:00:00Z"`, []token{{"key", "2020-01-31T10:00:00Z"}}},
+
+		// function form
+		{`search("crash on start")`, []token{{"search", "crash on start"}}},
+		{`search(crash) status:open`, []token{{"search", "crash"}, {"status", "open"}}},
+		{`search("")`, nil},
+		{`search("key:value")`, []token{{"search", "key:value"}}},
+		{`title:fix(parser)`, []token{{"title", "fix(parser)"}}},
+		{`label:area(ui)`, []token{{"label", "area(ui)"}}},
+
+		// full-text search
+		{`search:crash`, []token{{"search", "crash"}}},
+		{`crash status:open`, []token{{"search", "crash"}, {"status", "open"}}},
+		{`"crash on start"`, []token{{"search", "crash on start"}}},
+		{`"key:value"`, []token{{"search", "key:value"}}},
+		{`""`, nil},
+
+		// negation
+		{`-label:bug`, []token{{"-label", "bug"}}},
+		{`-crash`, []token{{"-search", "crash"}}},
+		{`-"crash on start"`, []token{{"-search", "crash on start"}}},
 	}
 
 	for _, tc := range tests {
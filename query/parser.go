@@ -2,6 +2,11 @@ package query
 
 import (
 	"fmt"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/MichaelMure/git-bug/bug"
 )
@@ -11,8 +16,10 @@ import (
 // Ex: "status:open author:descartes sort:edit-asc"
 //
 // Supported filter qualifiers and syntax are described in docs/queries.md
+//
+// The errors are a *ParseError locating the offending token.
 func Parse(query string) (*Query, error) {
-	tokens, err := tokenize(query)
+	tokens, err := tokenizeWithPosition(query)
 	if err != nil {
 		return nil, err
 	}
@@ -24,47 +31,305 @@ func Parse(query string) (*Query, error) {
 	sortingDone := false
 
 	for _, t := range tokens {
-		switch t.qualifier {
-		case "status", "state":
-			status, err := bug.StatusFromString(t.value)
-			if err != nil {
-				return nil, err
-			}
-			q.Status = append(q.Status, status)
-		case "author":
-			q.Author = append(q.Author, t.value)
-		case "actor":
-			q.Actor = append(q.Actor, t.value)
-		case "participant":
-			q.Participant = append(q.Participant, t.value)
-		case "label":
-			q.Label = append(q.Label, t.value)
-		case "title":
-			q.Title = append(q.Title, t.value)
-		case "no":
-			switch t.value {
-			case "label":
-				q.NoLabel = true
-			default:
-				return nil, fmt.Errorf("unknown \"no\" filter \"%s\"", t.value)
-			}
-		case "sort":
+		if t.qualifier == "not-exported" {
+			t.qualifier = "-exported-to"
+		}
+
+		if err := checkToken(query, t); err != nil {
+			return nil, err
+		}
+
+		switch {
+		case t.qualifier == "sort":
 			if sortingDone {
-				return nil, fmt.Errorf("multiple sorting")
+				return nil, newParseError(query, t, "multiple sorting")
 			}
 			err = parseSorting(q, t.value)
 			if err != nil {
-				return nil, err
+				return nil, tokenError(query, t, err)
 			}
 			sortingDone = true
 
+		// negation: -label:bug exclude the bugs with the label bug
+		case strings.HasPrefix(t.qualifier, "-"):
+			t.qualifier = strings.TrimPrefix(t.qualifier, "-")
+			if t.qualifier == "sort" || t.qualifier == "with" {
+				return nil, newParseError(query, t, "\"%s\" can't be negated", t.qualifier)
+			}
+			if q.Not == nil {
+				q.Not = &Filters{}
+			}
+			err = parseFilter(q.Not, t.token)
+			if err != nil {
+				return nil, tokenError(query, t, err)
+			}
+
 		default:
-			return nil, fmt.Errorf("unknown qualifier \"%s\"", t.qualifier)
+			err = parseFilter(&q.Filters, t.token)
+			if err != nil {
+				return nil, tokenError(query, t, err)
+			}
 		}
 	}
+
 	return q, nil
 }
 
+// checkToken check that the qualifier of a token exist, as well as its value
+// when only a fixed set is allowed, to suggest the valid ones otherwise
+func checkToken(query string, t positionedToken) error {
+	qualifier := strings.TrimPrefix(t.qualifier, "-")
+
+	if !isQualifier(qualifier) {
+		err := newParseError(query, t, "unknown qualifier \"%s\"", qualifier)
+		err.Suggestions = suggest(qualifier, qualifiers)
+		return err
+	}
+
+	values, ok := qualifierValues[qualifier]
+	if !ok {
+		return nil
+	}
+	for _, v := range values {
+		if v == t.value {
+			return nil
+		}
+	}
+
+	err := newParseError(query, t, "unknown value \"%s\" for \"%s\"", t.value, qualifier)
+	err.Suggestions = suggest(t.value, values)
+	if len(err.Suggestions) == 0 && len(values) <= 3 {
+		err.Suggestions = values
+	}
+	return err
+}
+
+// tokenError locate the error of a token
+func tokenError(query string, t positionedToken, err error) error {
+	return newParseError(query, t, "%v", err)
+}
+
+// parseFilter add to the filters the one expressed by a token
+func parseFilter(f *Filters, t token) error {
+	switch t.qualifier {
+	case "status", "state":
+		status, err := bug.StatusFromString(t.value)
+		if err == nil {
+			f.Status = append(f.Status, status)
+			break
+		}
+		// not open/closed, try as a repository defined status
+		if err := bug.ValidateExtendedStatusName(t.value); err != nil {
+			return err
+		}
+		f.ExtendedStatus = append(f.ExtendedStatus, t.value)
+	case "author":
+		f.Author = append(f.Author, t.value)
+	case "actor":
+		f.Actor = append(f.Actor, t.value)
+	case "participant":
+		f.Participant = append(f.Participant, t.value)
+	case "subscriber":
+		f.Subscriber = append(f.Subscriber, t.value)
+	case "assignee":
+		f.Assignee = append(f.Assignee, t.value)
+	case "milestone":
+		f.Milestone = append(f.Milestone, t.value)
+	case "priority":
+		comparison, value := splitComparison(t.value)
+		if len(value) == 0 {
+			return fmt.Errorf("empty priority in \"%s\"", t.value)
+		}
+		f.Priority = append(f.Priority, PriorityFilter{Comparison: comparison, Value: value})
+	case "label", "label-all", "label-any":
+		// a glob pattern, like prio/*
+		if _, err := path.Match(t.value, ""); err != nil {
+			return fmt.Errorf("invalid label pattern \"%s\"", t.value)
+		}
+		if t.qualifier == "label-any" {
+			f.LabelAny = append(f.LabelAny, t.value)
+		} else {
+			f.Label = append(f.Label, t.value)
+		}
+	case "title":
+		f.Title = append(f.Title, t.value)
+	case "title~":
+		pattern := strings.TrimSuffix(strings.TrimPrefix(t.value, "/"), "/")
+		if _, err := regexp.Compile(pattern); err != nil {
+			return fmt.Errorf("invalid title regexp \"%s\": %v", t.value, err)
+		}
+		f.TitleRegexp = append(f.TitleRegexp, pattern)
+	case "search":
+		f.Search = append(f.Search, t.value)
+	case "field":
+		field, err := parseField(t.value)
+		if err != nil {
+			return err
+		}
+		f.Field = append(f.Field, field)
+	case "metadata":
+		metadata, err := parseMetadata(t.value)
+		if err != nil {
+			return err
+		}
+		f.Metadata = append(f.Metadata, metadata)
+	case "exported-to":
+		f.ExportedTo = append(f.ExportedTo, t.value)
+	case "created-after", "created-before", "edited-after", "edited-before":
+		date, err := parseDate(t.value)
+		if err != nil {
+			return err
+		}
+		field := DateCreated
+		if strings.HasPrefix(t.qualifier, "edited") {
+			field = DateEdited
+		}
+		f.Date = append(f.Date, DateFilter{
+			Field:  field,
+			Before: strings.HasSuffix(t.qualifier, "-before"),
+			Time:   date,
+		})
+	case "comments", "participants", "actors":
+		count, err := parseCount(t.qualifier, t.value)
+		if err != nil {
+			return err
+		}
+		f.Count = append(f.Count, count)
+	case "checklist":
+		switch t.value {
+		case "incomplete":
+			f.Checklist = append(f.Checklist, ChecklistIncomplete)
+		case "complete":
+			f.Checklist = append(f.Checklist, ChecklistComplete)
+		default:
+			return fmt.Errorf("unknown checklist filter \"%s\"", t.value)
+		}
+	case "no":
+		switch t.value {
+		case "label":
+			f.NoLabel = true
+		case "assignee":
+			f.NoAssignee = true
+		case "milestone":
+			f.NoMilestone = true
+		default:
+			return fmt.Errorf("unknown \"no\" filter \"%s\"", t.value)
+		}
+	case "with":
+		switch t.value {
+		case "duplicates":
+			f.WithDuplicates = true
+		default:
+			return fmt.Errorf("unknown \"with\" filter \"%s\"", t.value)
+		}
+	default:
+		return fmt.Errorf("unknown qualifier \"%s\"", t.qualifier)
+	}
+
+	return nil
+}
+
+func parseField(value string) (FieldFilter, error) {
+	split := strings.SplitN(value, "=", 2)
+	if len(split) != 2 || len(split[0]) == 0 || len(split[1]) == 0 {
+		return FieldFilter{}, fmt.Errorf("invalid field filter \"%s\", expected field:NAME=VALUE", value)
+	}
+
+	return FieldFilter{Name: split[0], Value: removeQuote(split[1])}, nil
+}
+
+func parseMetadata(value string) (MetadataFilter, error) {
+	split := strings.SplitN(value, "=", 2)
+	if len(split) != 2 || len(split[0]) == 0 || len(split[1]) == 0 {
+		return MetadataFilter{}, fmt.Errorf("invalid metadata filter \"%s\", expected metadata:KEY=VALUE", value)
+	}
+
+	return MetadataFilter{Key: split[0], Value: removeQuote(split[1])}, nil
+}
+
+func parseCount(qualifier string, value string) (CountFilter, error) {
+	counter := CountComments
+	switch qualifier {
+	case "participants":
+		counter = CountParticipants
+	case "actors":
+		counter = CountActors
+	}
+
+	comparison, number := splitComparison(value)
+	n, err := strconv.Atoi(number)
+	if err != nil || n < 0 {
+		return CountFilter{}, fmt.Errorf("invalid count \"%s\", expected a number optionally prefixed with >, >=, < or <=", value)
+	}
+
+	return CountFilter{Counter: counter, Comparison: comparison, Value: n}, nil
+}
+
+// splitComparison split the comparison operator prefixing a value, if any
+func splitComparison(value string) (Comparison, string) {
+	// the two characters operators first
+	for _, op := range []struct {
+		prefix     string
+		comparison Comparison
+	}{
+		{">=", GreaterOrEqual},
+		{"<=", LessOrEqual},
+		{">", Greater},
+		{"<", Less},
+		{"=", Equal},
+	} {
+		if strings.HasPrefix(value, op.prefix) {
+			return op.comparison, strings.TrimPrefix(value, op.prefix)
+		}
+	}
+	return Equal, value
+}
+
+// now is the reference for the relative dates, replaceable for testing
+var now = time.Now
+
+var relativeDateRegexp = regexp.MustCompile(`^([+-]?)(\d+)([hdw])$`)
+
+// ParseDate parse a date the same way as the date qualifiers of a query:
+// either an absolute date, or a duration relative to now like 2w.
+func ParseDate(value string) (time.Time, error) {
+	return parseDate(value)
+}
+
+// parseDate parse either an absolute date, with or without the time, or
+// a duration relative to now, like -7d for seven days ago.
+func parseDate(value string) (time.Time, error) {
+	if match := relativeDateRegexp.FindStringSubmatch(value); match != nil {
+		n, err := strconv.Atoi(match[2])
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid date \"%s\"", value)
+		}
+
+		unit := time.Hour
+		switch match[3] {
+		case "d":
+			unit = 24 * time.Hour
+		case "w":
+			unit = 7 * 24 * time.Hour
+		}
+
+		d := time.Duration(n) * unit
+		if match[1] == "-" {
+			d = -d
+		}
+		return now().Add(d), nil
+	}
+
+	for _, layout := range []string{time.RFC3339, "2006-01-02T15:04", "2006-01-02"} {
+		date, err := time.ParseInLocation(layout, value, time.Local)
+		if err == nil {
+			return date, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("invalid date \"%s\", expected YYYY-MM-DD, an RFC3339 time or a relative duration like -7d", value)
+}
+
 func parseSorting(q *Query, value string) error {
 	switch value {
 	// default ASC
@@ -91,6 +356,30 @@ func parseSorting(q *Query, value string) error {
 		q.OrderBy = OrderByEdit
 		q.OrderDirection = OrderAscending
 
+	// default DESC
+	case "comments", "comments-desc":
+		q.OrderBy = OrderByComments
+		q.OrderDirection = OrderDescending
+	case "comments-asc":
+		q.OrderBy = OrderByComments
+		q.OrderDirection = OrderAscending
+
+	// default ASC
+	case "priority", "priority-asc":
+		q.OrderBy = OrderByPriority
+		q.OrderDirection = OrderAscending
+	case "priority-desc":
+		q.OrderBy = OrderByPriority
+		q.OrderDirection = OrderDescending
+
+	// default ASC
+	case "due", "due-asc":
+		q.OrderBy = OrderByDueDate
+		q.OrderDirection = OrderAscending
+	case "due-desc":
+		q.OrderBy = OrderByDueDate
+		q.OrderDirection = OrderDescending
+
 	default:
 		return fmt.Errorf("unknown sorting %s", value)
 	}
@@ -2,6 +2,7 @@ package query
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -9,11 +10,17 @@ import (
 )
 
 func TestParse(t *testing.T) {
+	reference := time.Date(2020, 3, 15, 12, 0, 0, 0, time.UTC)
+	now = func() time.Time { return reference }
+	defer func() { now = time.Now }()
+
 	var tests = []struct {
 		input  string
 		output *Query
 	}{
-		{"gibberish", nil},
+		{"gibberish", &Query{
+			Filters: Filters{Search: []string{"gibberish"}},
+		}},
 		{"status:", nil},
 		{":value", nil},
 
@@ -23,7 +30,20 @@ func TestParse(t *testing.T) {
 		{"status:closed", &Query{
 			Filters: Filters{Status: []bug.Status{bug.ClosedStatus}},
 		}},
-		{"status:unknown", nil},
+		{"status:in-progress", &Query{
+			Filters: Filters{ExtendedStatus: []string{"in-progress"}},
+		}},
+		{`status:"in progress"`, nil},
+
+		{`search("crash on start")`, &Query{
+			Filters: Filters{Search: []string{"crash on start"}},
+		}},
+		{`search:"crash on start"`, &Query{
+			Filters: Filters{Search: []string{"crash on start"}},
+		}},
+		{`crash start`, &Query{
+			Filters: Filters{Search: []string{"crash", "start"}},
+		}},
 
 		{"author:rene", &Query{
 			Filters: Filters{Author: []string{"rene"}},
@@ -38,6 +58,9 @@ func TestParse(t *testing.T) {
 		{"participant:leonhard", &Query{
 			Filters: Filters{Participant: []string{"leonhard"}},
 		}},
+		{"subscriber:blaise", &Query{
+			Filters: Filters{Subscriber: []string{"blaise"}},
+		}},
 
 		{"label:hello", &Query{
 			Filters: Filters{Label: []string{"hello"}},
@@ -45,6 +68,35 @@ func TestParse(t *testing.T) {
 		{`label:"Good first issue"`, &Query{
 			Filters: Filters{Label: []string{"Good first issue"}},
 		}},
+		{"label:prio/*", &Query{
+			Filters: Filters{Label: []string{"prio/*"}},
+		}},
+		{"label:prio/[", nil},
+		{"label-all:bug label-all:ui", &Query{
+			Filters: Filters{Label: []string{"bug", "ui"}},
+		}},
+		{"label-any:bug label-any:ui", &Query{
+			Filters: Filters{LabelAny: []string{"bug", "ui"}},
+		}},
+
+		{"-label:wontfix -author:rene", &Query{
+			Filters: Filters{Not: &Filters{
+				Label:  []string{"wontfix"},
+				Author: []string{"rene"},
+			}},
+		}},
+		{"status:open -crash", &Query{
+			Filters: Filters{
+				Status: []bug.Status{bug.OpenStatus},
+				Not:    &Filters{Search: []string{"crash"}},
+			},
+		}},
+		{"-no:label", &Query{
+			Filters: Filters{Not: &Filters{NoLabel: true}},
+		}},
+		{"-sort:edit", nil},
+		{"-with:duplicates", nil},
+		{"-unknown:value", nil},
 
 		{"title:titleOne", &Query{
 			Filters: Filters{Title: []string{"titleOne"}},
@@ -52,14 +104,130 @@ func TestParse(t *testing.T) {
 		{`title:"Bug titleTwo"`, &Query{
 			Filters: Filters{Title: []string{"Bug titleTwo"}},
 		}},
+		{`title~:/^crash.*start$/`, &Query{
+			Filters: Filters{TitleRegexp: []string{"^crash.*start$"}},
+		}},
+		{`title~:"/(?i)crash on start/"`, &Query{
+			Filters: Filters{TitleRegexp: []string{"(?i)crash on start"}},
+		}},
+		{`title~:/crash(/`, nil},
+
+		{"field:env=prod", &Query{
+			Filters: Filters{Field: []FieldFilter{{Name: "env", Value: "prod"}}},
+		}},
+		{`field:"version=1.2 beta"`, &Query{
+			Filters: Filters{Field: []FieldFilter{{Name: "version", Value: "1.2 beta"}}},
+		}},
+		{"field:env", nil},
+		{"field:env=", nil},
+		{"field:=prod", nil},
+
+		{"metadata:origin=gitea", &Query{
+			Filters: Filters{Metadata: []MetadataFilter{{Key: "origin", Value: "gitea"}}},
+		}},
+		{"metadata:github-url=https://github.com/MichaelMure/git-bug/issues/1", &Query{
+			Filters: Filters{Metadata: []MetadataFilter{{Key: "github-url", Value: "https://github.com/MichaelMure/git-bug/issues/1"}}},
+		}},
+		{"metadata:origin", nil},
+
+		{"exported-to:github", &Query{
+			Filters: Filters{ExportedTo: []string{"github"}},
+		}},
+		{"not-exported:gitlab", &Query{
+			Filters: Filters{Not: &Filters{ExportedTo: []string{"gitlab"}}},
+		}},
+		{"metadata:=gitea", nil},
+
+		{"checklist:incomplete", &Query{
+			Filters: Filters{Checklist: []ChecklistStatus{ChecklistIncomplete}},
+		}},
+		{"checklist:complete", &Query{
+			Filters: Filters{Checklist: []ChecklistStatus{ChecklistComplete}},
+		}},
+		{"checklist:unknown", nil},
+
+		{"comments:>10", &Query{
+			Filters: Filters{Count: []CountFilter{{Counter: CountComments, Comparison: Greater, Value: 10}}},
+		}},
+		{"participants:>=3", &Query{
+			Filters: Filters{Count: []CountFilter{{Counter: CountParticipants, Comparison: GreaterOrEqual, Value: 3}}},
+		}},
+		{"actors:1", &Query{
+			Filters: Filters{Count: []CountFilter{{Counter: CountActors, Comparison: Equal, Value: 1}}},
+		}},
+		{"comments:<=2", &Query{
+			Filters: Filters{Count: []CountFilter{{Counter: CountComments, Comparison: LessOrEqual, Value: 2}}},
+		}},
+		{"comments:many", nil},
+
+		{"assignee:rene", &Query{
+			Filters: Filters{Assignee: []string{"rene"}},
+		}},
+		{"no:assignee", &Query{
+			Filters: Filters{NoAssignee: true},
+		}},
+		{`milestone:"v1.0 beta"`, &Query{
+			Filters: Filters{Milestone: []string{"v1.0 beta"}},
+		}},
+		{"no:milestone", &Query{
+			Filters: Filters{NoMilestone: true},
+		}},
+		{"priority:>=P2", &Query{
+			Filters: Filters{Priority: []PriorityFilter{{Comparison: GreaterOrEqual, Value: "P2"}}},
+		}},
+		{"priority:high", &Query{
+			Filters: Filters{Priority: []PriorityFilter{{Comparison: Equal, Value: "high"}}},
+		}},
+		{"priority:>=", nil},
+		{"comments:>-1", nil},
+
+		{"created-after:2020-01-31", &Query{
+			Filters: Filters{Date: []DateFilter{
+				{Field: DateCreated, Time: time.Date(2020, 1, 31, 0, 0, 0, 0, time.Local)},
+			}},
+		}},
+		{`edited-before:"2020-01-31T10:00:00Z"`, &Query{
+			Filters: Filters{Date: []DateFilter{
+				{Field: DateEdited, Before: true, Time: time.Date(2020, 1, 31, 10, 0, 0, 0, time.UTC)},
+			}},
+		}},
+		{"edited-before:-90d", &Query{
+			Filters: Filters{Date: []DateFilter{
+				{Field: DateEdited, Before: true, Time: reference.Add(-90 * 24 * time.Hour)},
+			}},
+		}},
+		{"created-before:2w", &Query{
+			Filters: Filters{Date: []DateFilter{
+				{Field: DateCreated, Before: true, Time: reference.Add(14 * 24 * time.Hour)},
+			}},
+		}},
+		{"created-after:yesterday", nil},
+		{"created-after:-7y", nil},
 
 		{"no:label", &Query{
 			Filters: Filters{NoLabel: true},
 		}},
 
+		{"with:duplicates", &Query{
+			Filters: Filters{WithDuplicates: true},
+		}},
+		{"with:unknown", nil},
+
 		{"sort:edit", &Query{
 			OrderBy: OrderByEdit,
 		}},
+		{"sort:comments", &Query{
+			OrderBy:        OrderByComments,
+			OrderDirection: OrderDescending,
+		}},
+		{"sort:priority", &Query{
+			OrderBy:        OrderByPriority,
+			OrderDirection: OrderAscending,
+		}},
+		{"sort:due-desc", &Query{
+			OrderBy:        OrderByDueDate,
+			OrderDirection: OrderDescending,
+		}},
 		{"sort:unknown", nil},
 
 		{`status:open author:"René Descartes" participant:leonhard label:hello label:"Good first issue" sort:edit-desc`,
@@ -95,3 +263,42 @@ func TestParse(t *testing.T) {
 		})
 	}
 }
+
+func TestParseError(t *testing.T) {
+	var tests = []struct {
+		input       string
+		offset      int
+		token       string
+		suggestions []string
+	}{
+		{"status:open lable:bug", 12, "lable:bug", []string{"label"}},
+		{"status:open -asignee:rene", 12, "-asignee:rene", []string{"assignee"}},
+		{"sort:creaton", 0, "sort:creaton", []string{"creation"}},
+		{"no:lbl", 0, "no:lbl", []string{"label"}},
+		{"with:everything", 0, "with:everything", []string{"duplicates"}},
+		{"label:bug comments:many", 10, "comments:many", nil},
+		{`author:"René D" "crash`, 17, `"crash`, nil},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.input, func(t *testing.T) {
+			_, err := Parse(tc.input)
+			assert.Error(t, err)
+
+			perr, ok := err.(*ParseError)
+			assert.True(t, ok)
+			assert.Equal(t, tc.input, perr.Query)
+			assert.Equal(t, tc.offset, perr.Offset)
+			assert.Equal(t, tc.token, perr.Token)
+			assert.Equal(t, tc.suggestions, perr.Suggestions)
+		})
+	}
+}
+
+func TestParseErrorPointer(t *testing.T) {
+	_, err := Parse("René lable:bug")
+	perr := err.(*ParseError)
+
+	assert.Equal(t, "René lable:bug\n     ^~~~~~~~~", perr.Pointer())
+	assert.Equal(t, `invalid query at offset 6 (lable:bug): unknown qualifier "lable", did you mean "label"?`, perr.Error())
+}
@@ -1,6 +1,10 @@
 package query
 
-import "github.com/MichaelMure/git-bug/bug"
+import (
+	"time"
+
+	"github.com/MichaelMure/git-bug/bug"
+)
 
 // Query is the intermediary representation of a Bug's query. It is either
 // produced by parsing a query string (ex: "status:open author:rene") or created
@@ -20,17 +24,138 @@ func NewQuery() *Query {
 	}
 }
 
-// Filters is a collection of Filter that implement a complex filter
+// Filters is a collection of Filter that implement a complex filter.
+// The repeated status, author, actor, participant, subscriber, label-any and
+// checklist filters match if any of them match, all the others need to all
+// match.
 type Filters struct {
 	Status      []bug.Status
 	Author      []string
 	Actor       []string
 	Participant []string
+	Subscriber  []string
+	Assignee    []string
+	Milestone   []string
 	Label       []string
+	LabelAny    []string
 	Title       []string
+	// TitleRegexp match the title with regular expressions
+	TitleRegexp []string
+	Field       []FieldFilter
+	Metadata    []MetadataFilter
+	ExportedTo  []string
+	Checklist   []ChecklistStatus
+	Search      []string
+	Date        []DateFilter
+	Count       []CountFilter
+	Priority    []PriorityFilter
 	NoLabel     bool
+	NoAssignee  bool
+	NoMilestone bool
+	// ExtendedStatus match the repository defined statuses, or'ed with Status
+	ExtendedStatus []string
+	// WithDuplicates include the bugs closed as duplicate, hidden otherwise
+	WithDuplicates bool
+	// Not hold the negated filters: the bugs matching any of them are excluded
+	Not *Filters
+}
+
+// FieldFilter match a custom field with a given value
+type FieldFilter struct {
+	Name  string
+	Value string
+}
+
+// MetadataFilter match a metadata of the bug creation with a given value,
+// like the ones set by the bridges when importing a bug
+type MetadataFilter struct {
+	Key   string
+	Value string
+}
+
+// DateField is the time of a bug a DateFilter apply to
+type DateField int
+
+const (
+	_ DateField = iota
+	DateCreated
+	DateEdited
+)
+
+// DateFilter match the bugs created or edited before or after a given time
+type DateFilter struct {
+	Field DateField
+	// match the bugs before the time if true, after it otherwise
+	Before bool
+	Time   time.Time
 }
 
+// Comparison is the operator of a numeric comparison
+type Comparison int
+
+const (
+	_ Comparison = iota
+	Equal
+	Greater
+	GreaterOrEqual
+	Less
+	LessOrEqual
+)
+
+// Compare apply the comparison to two values
+func (c Comparison) Compare(a, b int) bool {
+	switch c {
+	case Equal:
+		return a == b
+	case Greater:
+		return a > b
+	case GreaterOrEqual:
+		return a >= b
+	case Less:
+		return a < b
+	case LessOrEqual:
+		return a <= b
+	default:
+		return false
+	}
+}
+
+// Counter is a count of a bug a CountFilter apply to
+type Counter int
+
+const (
+	_ Counter = iota
+	// the comments, without the bug description
+	CountComments
+	CountParticipants
+	CountActors
+)
+
+// CountFilter compare a count of a bug with a value, like comments:>10
+type CountFilter struct {
+	Counter    Counter
+	Comparison Comparison
+	Value      int
+}
+
+// PriorityFilter compare the priority of a bug with a value, like
+// priority:>=P2. The priorities are ordered like when sorting by priority.
+type PriorityFilter struct {
+	Comparison Comparison
+	Value      string
+}
+
+// ChecklistStatus match the progress of the checklists of a bug
+type ChecklistStatus int
+
+const (
+	_ ChecklistStatus = iota
+	// at least one checklist item is not checked
+	ChecklistIncomplete
+	// there is at least one checklist item and they are all checked
+	ChecklistComplete
+)
+
 type OrderBy int
 
 const (
@@ -38,6 +163,9 @@ const (
 	OrderById
 	OrderByCreation
 	OrderByEdit
+	OrderByComments
+	OrderByPriority
+	OrderByDueDate
 )
 
 type OrderDirection int
@@ -0,0 +1,142 @@
+package query
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+// the named queries are stored in the repository config as:
+// git-bug.query.<name>.query = <query>
+const savedQueryConfigKeyPrefix = "git-bug.query."
+
+// ValidateSavedQueryName check that a name can be used for a saved query
+func ValidateSavedQueryName(name string) error {
+	if name == "" {
+		return fmt.Errorf("empty query name")
+	}
+
+	// the name is used as a config key and in the query language
+	for _, r := range name {
+		if !(r == '-' || r == '_' ||
+			(r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9')) {
+			return fmt.Errorf("query name %s should only contain letters, digits, - and _", name)
+		}
+	}
+
+	return nil
+}
+
+// ReadSavedQueries read from the repository config the saved queries, by name
+func ReadSavedQueries(config repository.ConfigRead) (map[string]string, error) {
+	pairs, err := config.ReadAll(savedQueryConfigKeyPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]string, len(pairs))
+	for key, value := range pairs {
+		key = strings.TrimPrefix(key, savedQueryConfigKeyPrefix)
+		split := strings.Split(key, ".")
+		if len(split) != 2 || split[1] != "query" {
+			return nil, fmt.Errorf("invalid saved query config key %s", key)
+		}
+		result[split[0]] = value
+	}
+
+	return result, nil
+}
+
+// SaveQuery store a named query in the repository config, after making sure
+// it's valid
+func SaveQuery(config repository.Config, name string, query string) error {
+	if err := ValidateSavedQueryName(name); err != nil {
+		return err
+	}
+
+	if err := validateSaved(query); err != nil {
+		return err
+	}
+
+	return config.StoreString(savedQueryConfigKeyPrefix+name+".query", query)
+}
+
+// RemoveSavedQuery remove a named query from the repository config
+func RemoveSavedQuery(config repository.Config, name string) error {
+	return config.RemoveAll(savedQueryConfigKeyPrefix + name)
+}
+
+// ParseWithSaved parse a query where the saved queries can be referred to
+// by name with @name, for example "@triage label:ui"
+//
+// The errors of the expanded saved queries are located in the expanded query.
+func ParseWithSaved(query string, saved map[string]string) (*Query, error) {
+	expanded, err := expandSaved(query, saved)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(expanded)
+}
+
+// expandSaved replace the references to saved queries by their content.
+// A saved query can't refer to another one.
+func expandSaved(query string, saved map[string]string) (string, error) {
+	fields, err := splitQuery(query)
+	if err != nil {
+		return "", err
+	}
+
+	result := make([]string, len(fields))
+	for i, field := range fields {
+		result[i] = field.text
+
+		if !strings.HasPrefix(field.text, "@") {
+			continue
+		}
+
+		name := strings.TrimPrefix(field.text, "@")
+		value, ok := saved[name]
+		if !ok {
+			names := make([]string, 0, len(saved))
+			for n := range saved {
+				names = append(names, "@"+n)
+			}
+			sort.Strings(names)
+
+			return "", &ParseError{
+				Query:       query,
+				Offset:      field.offset,
+				Token:       field.text,
+				Message:     fmt.Sprintf("unknown saved query \"%s\"", name),
+				Suggestions: suggest(field.text, names),
+			}
+		}
+
+		if err := validateSaved(value); err != nil {
+			return "", fmt.Errorf("saved query %s: %v", name, err)
+		}
+
+		result[i] = value
+	}
+
+	return strings.Join(result, " "), nil
+}
+
+// validateSaved check that a query can be saved: it must be valid on its
+// own, and not refer to another saved query
+func validateSaved(query string) error {
+	fields, err := splitQuery(query)
+	if err != nil {
+		return err
+	}
+	for _, field := range fields {
+		if strings.HasPrefix(field.text, "@") {
+			return fmt.Errorf("a saved query can't refer to another saved query")
+		}
+	}
+
+	_, err = Parse(query)
+	return err
+}
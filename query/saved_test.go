@@ -0,0 +1,44 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+func TestSavedQueries(t *testing.T) {
+	config := repository.NewMemConfig()
+
+	require.NoError(t, SaveQuery(config, "triage", "status:open no:label"))
+	require.NoError(t, SaveQuery(config, "mine", `author:"René Descartes"`))
+	require.Error(t, SaveQuery(config, "bad name", "status:open"))
+	require.Error(t, SaveQuery(config, "invalid", "status:"))
+	require.Error(t, SaveQuery(config, "nested", "@triage"))
+
+	saved, err := ReadSavedQueries(config)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{
+		"triage": "status:open no:label",
+		"mine":   `author:"René Descartes"`,
+	}, saved)
+
+	q, err := ParseWithSaved("@triage @mine label:ui", saved)
+	require.NoError(t, err)
+	require.Equal(t, Filters{
+		Status:  []bug.Status{bug.OpenStatus},
+		Author:  []string{"René Descartes"},
+		Label:   []string{"ui"},
+		NoLabel: true,
+	}, q.Filters)
+
+	_, err = ParseWithSaved("@unknown", saved)
+	require.Error(t, err)
+
+	require.NoError(t, RemoveSavedQuery(config, "triage"))
+	saved, err = ReadSavedQueries(config)
+	require.NoError(t, err)
+	require.Len(t, saved, 1)
+}
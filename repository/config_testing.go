@@ -113,4 +113,12 @@ func testConfig(t *testing.T, config Config) {
 		"section.subsection.subsection.opt1": "foo5",
 		"section.subsection.subsection.opt2": "foo6",
 	}, all)
+
+	val, err = config.ReadString("section.subsection.opt1")
+	require.NoError(t, err)
+	require.Equal(t, "foo3", val)
+
+	val, err = config.ReadString("section.subsection.subsection.opt2")
+	require.NoError(t, err)
+	require.Equal(t, "foo6", val)
 }
@@ -233,6 +233,10 @@ func (repo *GitRepo) StoreTree(entries []TreeEntry) (Hash, error) {
 
 // StoreCommit will store a Git commit with the given Git tree
 func (repo *GitRepo) StoreCommit(treeHash Hash) (Hash, error) {
+	if SigningEnabled(repo.AnyConfig()) {
+		return repo.storeSignedCommit(treeHash, "", "")
+	}
+
 	stdout, err := repo.runGitCommand("commit-tree", string(treeHash))
 
 	if err != nil {
@@ -244,6 +248,10 @@ func (repo *GitRepo) StoreCommit(treeHash Hash) (Hash, error) {
 
 // StoreCommitWithParent will store a Git commit with the given Git tree
 func (repo *GitRepo) StoreCommitWithParent(treeHash Hash, parent Hash) (Hash, error) {
+	if SigningEnabled(repo.AnyConfig()) {
+		return repo.storeSignedCommit(treeHash, parent, "")
+	}
+
 	stdout, err := repo.runGitCommand("commit-tree", string(treeHash),
 		"-p", string(parent))
 
@@ -254,6 +262,40 @@ func (repo *GitRepo) StoreCommitWithParent(treeHash Hash, parent Hash) (Hash, er
 	return Hash(stdout), nil
 }
 
+// StoreSignedCommit will store a Git commit with the given Git tree, signed
+// with the given key
+func (repo *GitRepo) StoreSignedCommit(treeHash Hash, parent Hash, keyId string) (Hash, error) {
+	return repo.storeSignedCommit(treeHash, parent, keyId)
+}
+
+// ReadCommitSignature will verify the signature of a commit
+func (repo *GitRepo) ReadCommitSignature(commit Hash) (CommitSignature, error) {
+	return repo.readCommitSignature(commit)
+}
+
+// ReadRawCommit return the raw content of a Git commit
+func (repo *GitRepo) ReadRawCommit(commit Hash) ([]byte, error) {
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+
+	err := repo.runGitCommandWithIO(nil, &stdout, &stderr, "cat-file", "commit", string(commit))
+
+	if err != nil {
+		return []byte{}, err
+	}
+
+	return stdout.Bytes(), nil
+}
+
+// StoreRawCommit will store a Git commit from its raw content, keeping its hash
+func (repo *GitRepo) StoreRawCommit(data []byte) (Hash, error) {
+	var stdin = bytes.NewReader(data)
+
+	stdout, err := repo.runGitCommandWithStdin(stdin, "hash-object", "-t", "commit", "--stdin", "-w")
+
+	return Hash(stdout), err
+}
+
 // UpdateRef will create or update a Git reference
 func (repo *GitRepo) UpdateRef(ref string, hash Hash) error {
 	_, err := repo.runGitCommand("update-ref", ref, string(hash))
@@ -296,6 +338,17 @@ func (repo *GitRepo) RefExist(ref string) (bool, error) {
 	return stdout != "", nil
 }
 
+// ResolveRef return the hash of the commit a Git reference point to
+func (repo *GitRepo) ResolveRef(ref string) (Hash, error) {
+	stdout, err := repo.runGitCommand("rev-parse", "--verify", ref)
+
+	if err != nil {
+		return "", err
+	}
+
+	return Hash(stdout), nil
+}
+
 // CopyRef will create a new reference with the same value as another one
 func (repo *GitRepo) CopyRef(source string, dest string) error {
 	_, err := repo.runGitCommand("update-ref", dest, source)
@@ -355,6 +408,17 @@ func (repo *GitRepo) GetTreeHash(commit Hash) (Hash, error) {
 	return Hash(stdout), nil
 }
 
+// ResolveRevision return the hash of the commit designated by a git revision
+func (repo *GitRepo) ResolveRevision(rev string) (Hash, error) {
+	stdout, err := repo.runGitCommand("rev-parse", "--verify", rev+"^{commit}")
+
+	if err != nil {
+		return "", err
+	}
+
+	return Hash(stdout), nil
+}
+
 // GetOrCreateClock return a Lamport clock stored in the Repo.
 // If the clock doesn't exist, it's created.
 func (repo *GitRepo) GetOrCreateClock(name string) (lamport.Clock, error) {
@@ -2,12 +2,34 @@ package repository
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"io"
+	"os"
 	"os/exec"
+	"path/filepath"
 	"strings"
 )
 
+// pureGoEnv is the environment variable disabling at runtime the use of the
+// git binary by GoGitRepo, like the nogitcli build tag
+const pureGoEnv = "GIT_BUG_PURE_GO"
+
+// ErrGitCliRequired is returned in pure-Go mode by the operations that go-git
+// can't do
+var ErrGitCliRequired = errors.New("this operation requires the git binary, not used in pure-Go mode")
+
+// PureGo tell if the repository operations are done with go-git only, without
+// ever running the git binary. This is the case when built with the nogitcli
+// tag, or when GIT_BUG_PURE_GO is set to a value other than 0.
+func PureGo() bool {
+	if !gitCliAvailable {
+		return true
+	}
+	value := os.Getenv(pureGoEnv)
+	return value != "" && value != "0"
+}
+
 // gitCli is a helper to launch CLI git commands
 type gitCli struct {
 	path string
@@ -17,7 +39,11 @@ type gitCli struct {
 func (cli gitCli) runGitCommandWithIO(stdin io.Reader, stdout, stderr io.Writer, args ...string) error {
 	// make sure that the working directory for the command
 	// always exist, in particular when running "git init".
-	path := strings.TrimSuffix(cli.path, ".git")
+	// A bare repository is its own working directory.
+	path := cli.path
+	if filepath.Base(filepath.Clean(path)) == ".git" {
+		path = filepath.Dir(filepath.Clean(path))
+	}
 
 	// fmt.Printf("[%s] Running git %s\n", path, strings.Join(args, " "))
 
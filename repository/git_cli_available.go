@@ -0,0 +1,8 @@
+// +build !nogitcli
+
+package repository
+
+// gitCliAvailable tell if git-bug can fall back on the git binary for the
+// operations go-git doesn't support. Build with the nogitcli tag to never use
+// it.
+const gitCliAvailable = true
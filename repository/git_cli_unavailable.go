@@ -0,0 +1,7 @@
+// +build nogitcli
+
+package repository
+
+// gitCliAvailable tell if git-bug can fall back on the git binary for the
+// operations go-git doesn't support.
+const gitCliAvailable = false
@@ -35,6 +35,10 @@ func CreateTestRepo(bare bool) TestedRepo {
 	if err := config.StoreString("user.email", "testuser@example.com"); err != nil {
 		log.Fatal("failed to set user.email for test repository: ", err)
 	}
+	// don't inherit the signing setup of the user
+	if err := config.StoreBool("commit.gpgsign", false); err != nil {
+		log.Fatal("failed to set commit.gpgsign for test repository: ", err)
+	}
 
 	// make sure we use a mock keyring for testing to not interact with the global system
 	return &replaceKeyring{
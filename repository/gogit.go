@@ -25,8 +25,11 @@ import (
 var _ ClockedRepo = &GoGitRepo{}
 
 type GoGitRepo struct {
-	r    *gogit.Repository
-	path string
+	// go-git is not safe for concurrent use, so the read access used when
+	// reading entities concurrently are serialized
+	rMutex sync.Mutex
+	r      *gogit.Repository
+	path   string
 
 	clocksMutex sync.Mutex
 	clocks      map[string]lamport.Clock
@@ -106,7 +109,7 @@ func detectGitPath(path string) (string, error) {
 		}
 
 		if parent := filepath.Dir(path); parent == path {
-			return "", fmt.Errorf(".git not found")
+			return "", ErrNotARepo
 		} else {
 			path = parent
 		}
@@ -343,6 +346,9 @@ func (repo *GoGitRepo) StoreData(data []byte) (Hash, error) {
 
 // ReadData will attempt to read arbitrary data from the given hash
 func (repo *GoGitRepo) ReadData(hash Hash) ([]byte, error) {
+	repo.rMutex.Lock()
+	defer repo.rMutex.Unlock()
+
 	obj, err := repo.r.BlobObject(plumbing.NewHash(hash.String()))
 	if err != nil {
 		return nil, err
@@ -405,6 +411,9 @@ func (repo *GoGitRepo) StoreTree(mapping []TreeEntry) (Hash, error) {
 
 // ReadTree will return the list of entries in a Git tree
 func (repo *GoGitRepo) ReadTree(hash Hash) ([]TreeEntry, error) {
+	repo.rMutex.Lock()
+	defer repo.rMutex.Unlock()
+
 	h := plumbing.NewHash(hash.String())
 
 	// the given hash could be a tree or a commit
@@ -453,8 +462,60 @@ func (repo *GoGitRepo) StoreCommit(treeHash Hash) (Hash, error) {
 	return repo.StoreCommitWithParent(treeHash, "")
 }
 
+// ReadRawCommit return the raw content of a Git commit
+func (repo *GoGitRepo) ReadRawCommit(commit Hash) ([]byte, error) {
+	repo.rMutex.Lock()
+	defer repo.rMutex.Unlock()
+
+	obj, err := repo.r.Storer.EncodedObject(plumbing.CommitObject, plumbing.NewHash(commit.String()))
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := obj.Reader()
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	return ioutil.ReadAll(r)
+}
+
+// StoreRawCommit will store a Git commit from its raw content, keeping its hash
+func (repo *GoGitRepo) StoreRawCommit(data []byte) (Hash, error) {
+	obj := repo.r.Storer.NewEncodedObject()
+	obj.SetType(plumbing.CommitObject)
+
+	w, err := obj.Writer()
+	if err != nil {
+		return "", err
+	}
+
+	_, err = w.Write(data)
+	if err != nil {
+		return "", err
+	}
+
+	h, err := repo.r.Storer.SetEncodedObject(obj)
+	if err != nil {
+		return "", err
+	}
+
+	return Hash(h.String()), nil
+}
+
 // StoreCommit will store a Git commit with the given Git tree
 func (repo *GoGitRepo) StoreCommitWithParent(treeHash Hash, parent Hash) (Hash, error) {
+	// go-git can only sign with an OpenPGP key loaded in memory, so fallback
+	// on git to reuse the user's signing setup (gpg-agent, SSH keys ...)
+	if SigningEnabled(repo.AnyConfig()) {
+		if PureGo() {
+			// rather than silently creating unsigned commits
+			return "", fmt.Errorf("signing the commit: %w", ErrGitCliRequired)
+		}
+		return gitCli{path: repo.path}.storeSignedCommit(treeHash, parent, "")
+	}
+
 	cfg, err := repo.r.Config()
 	if err != nil {
 		return "", err
@@ -494,8 +555,44 @@ func (repo *GoGitRepo) StoreCommitWithParent(treeHash Hash, parent Hash) (Hash,
 	return Hash(hash.String()), nil
 }
 
+// StoreSignedCommit will store a Git commit with the given Git tree, signed
+// with the given key
+func (repo *GoGitRepo) StoreSignedCommit(treeHash Hash, parent Hash, keyId string) (Hash, error) {
+	// the key is held by gpg-agent, out of reach of go-git
+	if PureGo() {
+		return "", fmt.Errorf("signing the commit: %w", ErrGitCliRequired)
+	}
+	return gitCli{path: repo.path}.storeSignedCommit(treeHash, parent, keyId)
+}
+
+// ReadCommitSignature will verify the signature of a commit
+func (repo *GoGitRepo) ReadCommitSignature(commit Hash) (CommitSignature, error) {
+	// go-git can't verify a signature without being given the keyring, so
+	// fallback on git and the user's trust database
+	if !PureGo() {
+		return gitCli{path: repo.path}.readCommitSignature(commit)
+	}
+
+	// without git, a signature can be detected but not verified
+	repo.rMutex.Lock()
+	defer repo.rMutex.Unlock()
+
+	obj, err := repo.r.CommitObject(plumbing.NewHash(commit.String()))
+	if err != nil {
+		return CommitSignature{}, err
+	}
+
+	if obj.PGPSignature == "" {
+		return CommitSignature{Status: SignatureNone}, nil
+	}
+	return CommitSignature{Status: SignatureUnverifiable}, nil
+}
+
 // GetTreeHash return the git tree hash referenced in a commit
 func (repo *GoGitRepo) GetTreeHash(commit Hash) (Hash, error) {
+	repo.rMutex.Lock()
+	defer repo.rMutex.Unlock()
+
 	obj, err := repo.r.CommitObject(plumbing.NewHash(commit.String()))
 	if err != nil {
 		return "", err
@@ -504,6 +601,19 @@ func (repo *GoGitRepo) GetTreeHash(commit Hash) (Hash, error) {
 	return Hash(obj.TreeHash.String()), nil
 }
 
+// ResolveRevision return the hash of the commit designated by a git revision
+func (repo *GoGitRepo) ResolveRevision(rev string) (Hash, error) {
+	repo.rMutex.Lock()
+	defer repo.rMutex.Unlock()
+
+	hash, err := repo.r.ResolveRevision(plumbing.Revision(rev))
+	if err != nil {
+		return "", err
+	}
+
+	return Hash(hash.String()), nil
+}
+
 // FindCommonAncestor will return the last common ancestor of two chain of commit
 func (repo *GoGitRepo) FindCommonAncestor(commit1 Hash, commit2 Hash) (Hash, error) {
 	obj1, err := repo.r.CommitObject(plumbing.NewHash(commit1.String()))
@@ -519,6 +629,9 @@ func (repo *GoGitRepo) FindCommonAncestor(commit1 Hash, commit2 Hash) (Hash, err
 	if err != nil {
 		return "", err
 	}
+	if len(commits) == 0 {
+		return "", fmt.Errorf("no common ancestor between %s and %s", commit1, commit2)
+	}
 
 	return Hash(commits[0].Hash.String()), nil
 }
@@ -535,6 +648,9 @@ func (repo *GoGitRepo) RemoveRef(ref string) error {
 
 // ListRefs will return a list of Git ref matching the given refspec
 func (repo *GoGitRepo) ListRefs(refPrefix string) ([]string, error) {
+	repo.rMutex.Lock()
+	defer repo.rMutex.Unlock()
+
 	refIter, err := repo.r.References()
 	if err != nil {
 		return nil, err
@@ -557,6 +673,9 @@ func (repo *GoGitRepo) ListRefs(refPrefix string) ([]string, error) {
 
 // RefExist will check if a reference exist in Git
 func (repo *GoGitRepo) RefExist(ref string) (bool, error) {
+	repo.rMutex.Lock()
+	defer repo.rMutex.Unlock()
+
 	_, err := repo.r.Reference(plumbing.ReferenceName(ref), false)
 	if err == nil {
 		return true, nil
@@ -566,6 +685,19 @@ func (repo *GoGitRepo) RefExist(ref string) (bool, error) {
 	return false, err
 }
 
+// ResolveRef return the hash of the commit a Git reference point to
+func (repo *GoGitRepo) ResolveRef(ref string) (Hash, error) {
+	repo.rMutex.Lock()
+	defer repo.rMutex.Unlock()
+
+	r, err := repo.r.Reference(plumbing.ReferenceName(ref), false)
+	if err != nil {
+		return "", err
+	}
+
+	return Hash(r.Hash().String()), nil
+}
+
 // CopyRef will create a new reference with the same value as another one
 func (repo *GoGitRepo) CopyRef(source string, dest string) error {
 	r, err := repo.r.Reference(plumbing.ReferenceName(source), false)
@@ -577,6 +709,9 @@ func (repo *GoGitRepo) CopyRef(source string, dest string) error {
 
 // ListCommits will return the list of tree hashes of a ref, in chronological order
 func (repo *GoGitRepo) ListCommits(ref string) ([]Hash, error) {
+	repo.rMutex.Lock()
+	defer repo.rMutex.Unlock()
+
 	r, err := repo.r.Reference(plumbing.ReferenceName(ref), false)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/format/packfile"
+	"github.com/go-git/go-git/v5/plumbing/revlist"
+)
+
+var _ RepoBundle = &GoGitRepo{}
+
+// the first line of a git bundle, as written by "git bundle create"
+const bundleSignature = "# v2 git bundle"
+
+// WriteBundle write a git bundle holding the given refs and all the objects
+// reachable from them. The bundle has no prerequisite.
+func (repo *GoGitRepo) WriteBundle(w io.Writer, refs []string) error {
+	repo.rMutex.Lock()
+	defer repo.rMutex.Unlock()
+
+	bw := bufio.NewWriter(w)
+
+	_, err := fmt.Fprintf(bw, "%s\n", bundleSignature)
+	if err != nil {
+		return err
+	}
+
+	tips := make([]plumbing.Hash, len(refs))
+	for i, name := range refs {
+		ref, err := repo.r.Reference(plumbing.ReferenceName(name), true)
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		tips[i] = ref.Hash()
+
+		_, err = fmt.Fprintf(bw, "%s %s\n", ref.Hash(), name)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = bw.WriteString("\n")
+	if err != nil {
+		return err
+	}
+
+	objects, err := revlist.Objects(repo.r.Storer, tips, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = packfile.NewEncoder(bw, repo.r.Storer, false).Encode(objects, 10)
+	if err != nil {
+		return err
+	}
+
+	return bw.Flush()
+}
+
+// ReadBundle store the objects of a git bundle and return the refs it holds,
+// without updating them in the repository.
+func (repo *GoGitRepo) ReadBundle(r io.Reader) (map[string]Hash, error) {
+	br := bufio.NewReader(r)
+
+	line, err := br.ReadString('\n')
+	if err != nil || strings.TrimSuffix(line, "\n") != bundleSignature {
+		return nil, fmt.Errorf("not a v2 git bundle")
+	}
+
+	repo.rMutex.Lock()
+	defer repo.rMutex.Unlock()
+
+	refs := make(map[string]Hash)
+
+	for {
+		line, err := br.ReadString('\n')
+		if err != nil {
+			return nil, fmt.Errorf("truncated bundle header")
+		}
+		line = strings.TrimSuffix(line, "\n")
+
+		// the header end with an empty line
+		if line == "" {
+			break
+		}
+
+		// a prerequisite, that must already be available
+		if strings.HasPrefix(line, "-") {
+			fields := strings.Fields(line[1:])
+			if len(fields) == 0 {
+				return nil, fmt.Errorf("invalid bundle prerequisite")
+			}
+			_, err := repo.r.CommitObject(plumbing.NewHash(fields[0]))
+			if err != nil {
+				return nil, fmt.Errorf("missing prerequisite commit %s", fields[0])
+			}
+			continue
+		}
+
+		split := strings.SplitN(line, " ", 2)
+		if len(split) != 2 {
+			return nil, fmt.Errorf("invalid bundle ref line \"%s\"", line)
+		}
+		refs[split[1]] = Hash(split[0])
+	}
+
+	err = packfile.UpdateObjectStorage(repo.r.Storer, br)
+	if err != nil {
+		return nil, err
+	}
+
+	return refs, nil
+}
@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"errors"
 	"io/ioutil"
 	"os"
 	"path"
@@ -61,8 +62,35 @@ func TestNewGoGitRepo(t *testing.T) {
 			assert.Equal(t, filepath.ToSlash(tc.outPath), filepath.ToSlash(r.GetPath()), i)
 		}
 	}
+
+	_, err = NewGoGitRepo("/", nil)
+	require.Equal(t, ErrNotARepo, err)
 }
 
 func TestGoGitRepo(t *testing.T) {
 	RepoTest(t, CreateGoGitTestRepo, CleanupTestRepos)
 }
+
+func TestGoGitRepoPureGo(t *testing.T) {
+	require.NoError(t, os.Setenv(pureGoEnv, "1"))
+	defer os.Unsetenv(pureGoEnv)
+	require.True(t, PureGo())
+
+	repo := CreateGoGitTestRepo(false)
+	defer CleanupTestRepos(repo)
+
+	tree, err := repo.StoreTree(nil)
+	require.NoError(t, err)
+
+	commit, err := repo.StoreCommit(tree)
+	require.NoError(t, err)
+
+	signature, err := repo.ReadCommitSignature(commit)
+	require.NoError(t, err)
+	require.Equal(t, SignatureNone, signature.Status)
+
+	// signing need the git binary
+	require.NoError(t, repo.LocalConfig().StoreBool(signingConfigKey, true))
+	_, err = repo.StoreCommit(tree)
+	require.True(t, errors.Is(err, ErrGitCliRequired))
+}
@@ -33,6 +33,10 @@ func CreateGoGitTestRepo(bare bool) TestedRepo {
 	if err := config.StoreString("user.email", "testuser@example.com"); err != nil {
 		log.Fatal("failed to set user.email for test repository: ", err)
 	}
+	// don't inherit the signing setup of the user
+	if err := config.StoreBool("commit.gpgsign", false); err != nil {
+		log.Fatal("failed to set commit.gpgsign for test repository: ", err)
+	}
 
 	return repo
 }
@@ -189,6 +189,56 @@ func (r *mockRepoData) StoreCommitWithParent(treeHash Hash, parent Hash) (Hash,
 	return hash, nil
 }
 
+func (r *mockRepoData) StoreSignedCommit(treeHash Hash, parent Hash, _ string) (Hash, error) {
+	// the mock repo doesn't sign commits
+	if parent == "" {
+		return r.StoreCommit(treeHash)
+	}
+	return r.StoreCommitWithParent(treeHash, parent)
+}
+
+func (r *mockRepoData) ReadCommitSignature(commit Hash) (CommitSignature, error) {
+	if _, ok := r.commits[commit]; !ok {
+		return CommitSignature{}, fmt.Errorf("unknown commit")
+	}
+
+	// the mock repo doesn't sign commits
+	return CommitSignature{Status: SignatureNone}, nil
+}
+
+func (r *mockRepoData) ReadRawCommit(hash Hash) ([]byte, error) {
+	c, ok := r.commits[hash]
+	if !ok {
+		return nil, fmt.Errorf("unknown commit")
+	}
+
+	// the mock commits only hold a tree and a parent
+	raw := fmt.Sprintf("tree %s\n", c.treeHash)
+	if c.parent != "" {
+		raw += fmt.Sprintf("parent %s\n", c.parent)
+	}
+	return []byte(raw), nil
+}
+
+func (r *mockRepoData) StoreRawCommit(data []byte) (Hash, error) {
+	var c commit
+	for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		switch {
+		case strings.HasPrefix(line, "tree "):
+			c.treeHash = Hash(strings.TrimPrefix(line, "tree "))
+		case strings.HasPrefix(line, "parent "):
+			c.parent = Hash(strings.TrimPrefix(line, "parent "))
+		default:
+			return "", fmt.Errorf("invalid raw commit")
+		}
+	}
+
+	if c.parent == "" {
+		return r.StoreCommit(c.treeHash)
+	}
+	return r.StoreCommitWithParent(c.treeHash, c.parent)
+}
+
 func (r *mockRepoData) UpdateRef(ref string, hash Hash) error {
 	r.refs[ref] = hash
 	return nil
@@ -204,6 +254,16 @@ func (r *mockRepoData) RefExist(ref string) (bool, error) {
 	return exist, nil
 }
 
+func (r *mockRepoData) ResolveRef(ref string) (Hash, error) {
+	hash, exist := r.refs[ref]
+
+	if !exist {
+		return "", fmt.Errorf("Unknown ref")
+	}
+
+	return hash, nil
+}
+
 func (r *mockRepoData) CopyRef(source string, dest string) error {
 	hash, exist := r.refs[source]
 
@@ -230,7 +290,10 @@ func (r *mockRepoData) ListRefs(refPrefix string) ([]string, error) {
 func (r *mockRepoData) ListCommits(ref string) ([]Hash, error) {
 	var hashes []Hash
 
-	hash := r.refs[ref]
+	hash, exist := r.refs[ref]
+	if !exist {
+		return nil, fmt.Errorf("Unknown ref")
+	}
 
 	for {
 		commit, ok := r.commits[hash]
@@ -310,6 +373,15 @@ func (r *mockRepoData) GetTreeHash(commit Hash) (Hash, error) {
 	return c.treeHash, nil
 }
 
+func (r *mockRepoData) ResolveRevision(rev string) (Hash, error) {
+	// the mock repo has no branches, only commit hashes can be resolved
+	if _, ok := r.commits[Hash(rev)]; !ok {
+		return "", fmt.Errorf("unknown revision")
+	}
+
+	return Hash(rev), nil
+}
+
 func (r *mockRepoData) AddRemote(name string, url string) error {
 	panic("implement me")
 }
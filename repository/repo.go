@@ -3,6 +3,7 @@ package repository
 
 import (
 	"errors"
+	"io"
 
 	"github.com/MichaelMure/git-bug/util/lamport"
 )
@@ -91,9 +92,27 @@ type RepoData interface {
 	// StoreCommit will store a Git commit with the given Git tree
 	StoreCommitWithParent(treeHash Hash, parent Hash) (Hash, error)
 
+	// StoreSignedCommit will store a Git commit with the given Git tree, and
+	// the given parent if not empty, signed with the given key
+	StoreSignedCommit(treeHash Hash, parent Hash, keyId string) (Hash, error)
+
+	// ReadCommitSignature will verify the signature of a commit
+	ReadCommitSignature(commit Hash) (CommitSignature, error)
+
+	// ReadRawCommit return the raw content of a Git commit
+	ReadRawCommit(commit Hash) ([]byte, error)
+
+	// StoreRawCommit will store a Git commit from its raw content, as returned
+	// by ReadRawCommit, keeping its hash
+	StoreRawCommit(data []byte) (Hash, error)
+
 	// GetTreeHash return the git tree hash referenced in a commit
 	GetTreeHash(commit Hash) (Hash, error)
 
+	// ResolveRevision return the hash of the commit designated by a git
+	// revision (branch, tag, HEAD~2 ...)
+	ResolveRevision(rev string) (Hash, error)
+
 	// FindCommonAncestor will return the last common ancestor of two chain of commit
 	FindCommonAncestor(commit1 Hash, commit2 Hash) (Hash, error)
 
@@ -109,6 +128,9 @@ type RepoData interface {
 	// RefExist will check if a reference exist in Git
 	RefExist(ref string) (bool, error)
 
+	// ResolveRef return the hash of the commit a Git reference point to
+	ResolveRef(ref string) (Hash, error)
+
 	// CopyRef will create a new reference with the same value as another one
 	CopyRef(source string, dest string) error
 
@@ -135,6 +157,18 @@ type ClockLoader struct {
 	Witnesser func(repo ClockedRepo) error
 }
 
+// RepoBundle is implemented by the repositories able to exchange refs and
+// their objects as git bundles, the files of "git bundle"
+type RepoBundle interface {
+	// WriteBundle write a git bundle holding the given refs and all the
+	// objects reachable from them
+	WriteBundle(w io.Writer, refs []string) error
+
+	// ReadBundle store the objects of a git bundle and return the refs it
+	// holds, without updating them
+	ReadBundle(r io.Reader) (map[string]Hash, error)
+}
+
 // TestedRepo is an extended ClockedRepo with function for testing only
 type TestedRepo interface {
 	ClockedRepo
@@ -146,3 +180,24 @@ type repoTest interface {
 	// AddRemote add a new remote to the repository
 	AddRemote(name string, url string) error
 }
+
+// SameRefTip tell if a local ref exist and point to the same commit as a
+// remote ref, that is if there is nothing new to merge from the remote.
+func SameRefTip(repo RepoData, localRef string, remoteRef string) (bool, error) {
+	localExist, err := repo.RefExist(localRef)
+	if err != nil || !localExist {
+		return false, err
+	}
+
+	localTip, err := repo.ResolveRef(localRef)
+	if err != nil {
+		return false, err
+	}
+
+	remoteTip, err := repo.ResolveRef(remoteRef)
+	if err != nil {
+		return false, err
+	}
+
+	return localTip == remoteTip, nil
+}
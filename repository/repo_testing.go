@@ -145,6 +145,10 @@ func RepoDataTest(t *testing.T, repo RepoData) {
 	require.NoError(t, err)
 	require.True(t, commit1.IsValid())
 
+	signature1, err := repo.ReadCommitSignature(commit1)
+	require.NoError(t, err)
+	require.Equal(t, SignatureNone, signature1.Status)
+
 	treeHash1Read, err := repo.GetTreeHash(commit1)
 	require.NoError(t, err)
 	require.Equal(t, treeHash1, treeHash1Read)
@@ -157,6 +161,17 @@ func RepoDataTest(t *testing.T, repo RepoData) {
 	require.NoError(t, err)
 	require.Equal(t, treeHash2, treeHash2Read)
 
+	// a raw commit is stored again with the same hash
+	raw, err := repo.ReadRawCommit(commit2)
+	require.NoError(t, err)
+	commit2Raw, err := repo.StoreRawCommit(raw)
+	require.NoError(t, err)
+	require.Equal(t, commit2, commit2Raw)
+
+	resolved, err := repo.ResolveRevision(commit2.String())
+	require.NoError(t, err)
+	require.Equal(t, commit2, resolved)
+
 	// ReadTree should accept tree and commit hashes
 	tree1read, err := repo.ReadTree(commit1)
 	require.NoError(t, err)
@@ -175,6 +190,10 @@ func RepoDataTest(t *testing.T, repo RepoData) {
 	require.NoError(t, err)
 	require.True(t, exist1)
 
+	tip, err := repo.ResolveRef("refs/bugs/ref1")
+	require.NoError(t, err)
+	require.Equal(t, commit2, tip)
+
 	ls, err := repo.ListRefs("refs/bugs")
 	require.NoError(t, err)
 	require.ElementsMatch(t, []string{"refs/bugs/ref1"}, ls)
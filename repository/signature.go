@@ -0,0 +1,253 @@
+package repository
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"golang.org/x/crypto/openpgp"
+	pgperrors "golang.org/x/crypto/openpgp/errors"
+)
+
+// signingConfigKey is the git configuration key enabling the signing of commits.
+// git-bug follow the same setting as git itself, with the same key (GPG or SSH).
+const signingConfigKey = "commit.gpgsign"
+
+// SignatureStatus is the result of the verification of a commit signature
+type SignatureStatus int
+
+const (
+	// SignatureNone means that the commit is not signed
+	SignatureNone SignatureStatus = iota
+	// SignatureGood means that the signature is valid and the key trusted
+	SignatureGood
+	// SignatureUntrusted means that the signature is valid, but made with a key
+	// of unknown validity, expired or from an expired key
+	SignatureUntrusted
+	// SignatureUnverifiable means that the signature can't be checked, typically
+	// because the public key is missing
+	SignatureUnverifiable
+	// SignatureBad means that the signature doesn't match the commit, or is
+	// made with a revoked key
+	SignatureBad
+)
+
+func (s SignatureStatus) String() string {
+	switch s {
+	case SignatureNone:
+		return "unsigned"
+	case SignatureGood:
+		return "good"
+	case SignatureUntrusted:
+		return "untrusted"
+	case SignatureUnverifiable:
+		return "unverifiable"
+	case SignatureBad:
+		return "bad"
+	default:
+		return "unknown"
+	}
+}
+
+// CommitSignature describe the signature of a commit
+type CommitSignature struct {
+	Status SignatureStatus
+	// Signer is the name of the signer, as provided by the key
+	Signer string
+	// Key is the fingerprint of the key used to sign
+	Key string
+}
+
+// ErrCommitNotSigned is returned when verifying a commit without signature
+var ErrCommitNotSigned = errors.New("commit is not signed")
+
+// ErrUnknownSigner is returned when a commit is signed with a key not in the
+// given set
+var ErrUnknownSigner = errors.New("commit is signed by an unknown key")
+
+// SigningEnabled return true if the user configured git to sign commits
+func SigningEnabled(config ConfigRead) bool {
+	sign, err := config.ReadBool(signingConfigKey)
+	if err != nil {
+		return false
+	}
+	return sign
+}
+
+// sshLiteralKeyPrefix mark a literal SSH public key given as signing key, the
+// same way as in git's user.signingkey
+const sshLiteralKeyPrefix = "key::"
+
+// SSHSigningKey return the key id to give to StoreSignedCommit to sign with
+// the private key matching an SSH public key, held by ssh-agent
+func SSHSigningKey(pubKey string) string {
+	return sshLiteralKeyPrefix + strings.TrimSpace(pubKey)
+}
+
+// HasPrivateKey return true if the private key of a key id given to
+// StoreSignedCommit is available to sign: in gpg for an OpenPGP key, or in
+// ssh-agent for an SSH key.
+func HasPrivateKey(keyId string) bool {
+	// the signing always goes through git
+	if PureGo() {
+		return false
+	}
+
+	if !strings.HasPrefix(keyId, sshLiteralKeyPrefix) {
+		return exec.Command("gpg", "--batch", "--list-secret-keys", keyId).Run() == nil
+	}
+
+	// the type and the base64 content of the key, without comment
+	wanted := strings.Fields(strings.TrimPrefix(keyId, sshLiteralKeyPrefix))
+	if len(wanted) < 2 {
+		return false
+	}
+
+	stdout, err := exec.Command("ssh-add", "-L").Output()
+	if err != nil {
+		return false
+	}
+	for _, line := range strings.Split(string(stdout), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) >= 2 && fields[0] == wanted[0] && fields[1] == wanted[1] {
+			return true
+		}
+	}
+
+	return false
+}
+
+// storeSignedCommit store a commit signed with the given key, or with the key
+// configured in git if empty, either GPG or SSH depending on gpg.format
+func (cli gitCli) storeSignedCommit(treeHash Hash, parent Hash, keyId string) (Hash, error) {
+	var args []string
+	if strings.HasPrefix(keyId, sshLiteralKeyPrefix) {
+		// whatever the format configured for the other commits
+		args = append(args, "-c", "gpg.format=ssh")
+	}
+	args = append(args, "commit-tree", "-S"+keyId, "-m", "", string(treeHash))
+	if parent != "" {
+		args = append(args, "-p", string(parent))
+	}
+
+	stdout, err := cli.runGitCommand(args...)
+	if err != nil {
+		return "", err
+	}
+
+	return Hash(stdout), nil
+}
+
+// readCommitSignature verify the signature of a commit with git
+func (cli gitCli) readCommitSignature(commit Hash) (CommitSignature, error) {
+	stdout, err := cli.runGitCommand("log", "-1", "--format=%G?%n%GS%n%GK", string(commit))
+	if err != nil {
+		return CommitSignature{}, err
+	}
+
+	// status, signer and key are on their own line, but the last ones can be empty
+	lines := strings.SplitN(stdout, "\n", 3)
+	for len(lines) < 3 {
+		lines = append(lines, "")
+	}
+
+	result := CommitSignature{
+		Signer: strings.TrimSpace(lines[1]),
+		Key:    strings.TrimSpace(lines[2]),
+	}
+
+	switch strings.TrimSpace(lines[0]) {
+	case "G":
+		result.Status = SignatureGood
+	case "U", "X", "Y":
+		result.Status = SignatureUntrusted
+	case "E":
+		result.Status = SignatureUnverifiable
+	case "B", "R":
+		result.Status = SignatureBad
+	default:
+		result.Status = SignatureNone
+	}
+
+	return result, nil
+}
+
+// VerifyCommitSignature check the signature of a raw commit, as returned by
+// ReadRawCommit, against a set of public keys, either armored OpenPGP keys
+// or SSH keys in the authorized_keys format, and return the fingerprint of
+// the signing key. Unlike ReadCommitSignature, it doesn't depend on the
+// keyring, the allowed signers or the trust settings of the user.
+func VerifyCommitSignature(raw []byte, keys []string) (string, error) {
+	payload, signature := splitCommitSignature(raw)
+	if signature == "" {
+		return "", ErrCommitNotSigned
+	}
+
+	return VerifyDetachedSignature(payload, signature, keys, sshSignatureNamespace)
+}
+
+// VerifyDetachedSignature check an armored OpenPGP or SSH detached signature
+// of a payload against a set of public keys, and return the fingerprint of
+// the signing key. The SSH signatures must be made for the given namespace.
+func VerifyDetachedSignature(payload []byte, signature string, keys []string, sshNamespace string) (string, error) {
+	if strings.HasPrefix(strings.TrimSpace(signature), sshSignatureArmorStart) {
+		return verifySSHSignature(payload, signature, keys, sshNamespace)
+	}
+
+	var keyring openpgp.EntityList
+	for _, armored := range keys {
+		if !strings.HasPrefix(strings.TrimSpace(armored), "-----BEGIN PGP") {
+			// an SSH key
+			continue
+		}
+		entities, err := openpgp.ReadArmoredKeyRing(strings.NewReader(armored))
+		if err != nil {
+			return "", fmt.Errorf("invalid public key: %w", err)
+		}
+		keyring = append(keyring, entities...)
+	}
+
+	signer, err := openpgp.CheckArmoredDetachedSignature(keyring, bytes.NewReader(payload), strings.NewReader(signature))
+	if err == pgperrors.ErrUnknownIssuer {
+		return "", ErrUnknownSigner
+	}
+	if err != nil {
+		return "", fmt.Errorf("bad signature: %w", err)
+	}
+
+	return fmt.Sprintf("%X", signer.PrimaryKey.Fingerprint), nil
+}
+
+// splitCommitSignature extract the gpgsig header of a raw commit, and return
+// the commit without it, which is the signed payload
+func splitCommitSignature(raw []byte) ([]byte, string) {
+	var payload bytes.Buffer
+	var signature strings.Builder
+
+	lines := strings.SplitAfter(string(raw), "\n")
+	inHeaders := true
+	inSignature := false
+
+	for _, line := range lines {
+		switch {
+		case !inHeaders:
+			payload.WriteString(line)
+		case inSignature && strings.HasPrefix(line, " "):
+			// continuation of the multi-line header
+			signature.WriteString(line[1:])
+		case strings.HasPrefix(line, "gpgsig "):
+			inSignature = true
+			signature.WriteString(strings.TrimPrefix(line, "gpgsig "))
+		default:
+			inSignature = false
+			if line == "\n" {
+				inHeaders = false
+			}
+			payload.WriteString(line)
+		}
+	}
+
+	return payload.Bytes(), signature.String()
+}
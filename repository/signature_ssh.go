@@ -0,0 +1,168 @@
+package repository
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"fmt"
+	"hash"
+	"strings"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// The SSH signatures made by git follow the SSHSIG format of OpenSSH, as
+// produced by "ssh-keygen -Y sign -n git":
+// https://github.com/openssh/openssh-portable/blob/master/PROTOCOL.sshsig
+const (
+	sshSignatureArmorStart = "-----BEGIN SSH SIGNATURE-----"
+	sshSignatureArmorEnd   = "-----END SSH SIGNATURE-----"
+	sshSignatureMagic      = "SSHSIG"
+	// the namespace of the signatures of the commits
+	sshSignatureNamespace = "git"
+)
+
+// sshSignature is the blob of an SSH signature, after the magic preamble
+type sshSignature struct {
+	Version       uint32
+	PublicKey     []byte
+	Namespace     string
+	Reserved      string
+	HashAlgorithm string
+	Signature     []byte
+}
+
+// sshSignedData is the data actually signed by the key, after the magic
+// preamble
+type sshSignedData struct {
+	Namespace     string
+	Reserved      string
+	HashAlgorithm string
+	Hash          []byte
+}
+
+// verifySSHSignature check an armored SSH signature of a payload against a
+// set of SSH public keys, and return the SHA256 fingerprint of the signing key
+func verifySSHSignature(payload []byte, armored string, keys []string, namespace string) (string, error) {
+	sig, err := parseSSHSignature(armored)
+	if err != nil {
+		return "", fmt.Errorf("bad signature: %w", err)
+	}
+
+	signer, err := ssh.ParsePublicKey(sig.PublicKey)
+	if err != nil {
+		return "", fmt.Errorf("bad signature: %w", err)
+	}
+
+	known := false
+	for _, key := range keys {
+		pub, _, _, _, err := ssh.ParseAuthorizedKey([]byte(key))
+		if err != nil {
+			// an OpenPGP key
+			continue
+		}
+		if bytes.Equal(pub.Marshal(), signer.Marshal()) {
+			known = true
+			break
+		}
+	}
+	if !known {
+		return "", ErrUnknownSigner
+	}
+
+	if sig.Namespace != namespace {
+		return "", fmt.Errorf("bad signature: unexpected namespace %s", sig.Namespace)
+	}
+
+	var h hash.Hash
+	switch sig.HashAlgorithm {
+	case "sha256":
+		h = sha256.New()
+	case "sha512":
+		h = sha512.New()
+	default:
+		return "", fmt.Errorf("bad signature: unsupported hash algorithm %s", sig.HashAlgorithm)
+	}
+	_, _ = h.Write(payload)
+
+	signed := append([]byte(sshSignatureMagic), ssh.Marshal(sshSignedData{
+		Namespace:     sig.Namespace,
+		Reserved:      sig.Reserved,
+		HashAlgorithm: sig.HashAlgorithm,
+		Hash:          h.Sum(nil),
+	})...)
+
+	var signature ssh.Signature
+	err = ssh.Unmarshal(sig.Signature, &signature)
+	if err != nil {
+		return "", fmt.Errorf("bad signature: %w", err)
+	}
+
+	err = verifySSH(signer, signed, &signature)
+	if err != nil {
+		return "", fmt.Errorf("bad signature: %w", err)
+	}
+
+	return ssh.FingerprintSHA256(signer), nil
+}
+
+// verifySSH verify a signature made with an SSH key. SSHSIG mandate the SHA-2
+// variants of the RSA signatures, checked directly to not depend on the
+// support of ssh.PublicKey.
+func verifySSH(key ssh.PublicKey, data []byte, sig *ssh.Signature) error {
+	var hashFunc crypto.Hash
+	switch sig.Format {
+	case ssh.SigAlgoRSASHA2256:
+		hashFunc = crypto.SHA256
+	case ssh.SigAlgoRSASHA2512:
+		hashFunc = crypto.SHA512
+	default:
+		return key.Verify(data, sig)
+	}
+
+	cryptoKey, ok := key.(ssh.CryptoPublicKey)
+	if !ok {
+		return fmt.Errorf("unexpected key type %s", key.Type())
+	}
+	rsaKey, ok := cryptoKey.CryptoPublicKey().(*rsa.PublicKey)
+	if !ok {
+		return fmt.Errorf("unexpected key type %s", key.Type())
+	}
+
+	h := hashFunc.New()
+	_, _ = h.Write(data)
+	return rsa.VerifyPKCS1v15(rsaKey, hashFunc, h.Sum(nil), sig.Blob)
+}
+
+// parseSSHSignature decode an armored SSH signature
+func parseSSHSignature(armored string) (*sshSignature, error) {
+	armored = strings.TrimSpace(armored)
+	if !strings.HasPrefix(armored, sshSignatureArmorStart) || !strings.HasSuffix(armored, sshSignatureArmorEnd) {
+		return nil, fmt.Errorf("invalid armor")
+	}
+	body := strings.TrimSuffix(strings.TrimPrefix(armored, sshSignatureArmorStart), sshSignatureArmorEnd)
+	body = strings.Join(strings.Fields(body), "")
+
+	blob, err := base64.StdEncoding.DecodeString(body)
+	if err != nil {
+		return nil, err
+	}
+
+	if !bytes.HasPrefix(blob, []byte(sshSignatureMagic)) {
+		return nil, fmt.Errorf("invalid magic preamble")
+	}
+
+	var sig sshSignature
+	err = ssh.Unmarshal(blob[len(sshSignatureMagic):], &sig)
+	if err != nil {
+		return nil, err
+	}
+	if sig.Version != 1 {
+		return nil, fmt.Errorf("unsupported version %d", sig.Version)
+	}
+
+	return &sig, nil
+}
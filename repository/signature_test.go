@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha512"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/openpgp"
+	"golang.org/x/crypto/openpgp/armor"
+	"golang.org/x/crypto/ssh"
+)
+
+const testCommitPayload = "tree 4b825dc642cb6eb9a060e54bf8d69288fbee4904\n" +
+	"author René Descartes <rene@descartes.fr> 1600000000 +0200\n" +
+	"committer René Descartes <rene@descartes.fr> 1600000000 +0200\n" +
+	"\n"
+
+// withSignatureHeader insert an armored signature in a commit as a multi-line
+// header, as git does
+func withSignatureHeader(payload string, signature string) string {
+	header := "gpgsig " + strings.ReplaceAll(strings.TrimSpace(signature), "\n", "\n ") + "\n"
+	split := strings.Index(payload, "\n\n") + 1
+	return payload[:split] + header + payload[split:]
+}
+
+func TestVerifyCommitSignature(t *testing.T) {
+	signer, err := openpgp.NewEntity("René Descartes", "", "rene@descartes.fr", nil)
+	require.NoError(t, err)
+	other, err := openpgp.NewEntity("Isaac Newton", "", "isaac@newton.uk", nil)
+	require.NoError(t, err)
+
+	armored := func(e *openpgp.Entity) string {
+		var buf bytes.Buffer
+		w, err := armor.Encode(&buf, openpgp.PublicKeyType, nil)
+		require.NoError(t, err)
+		require.NoError(t, e.Serialize(w))
+		require.NoError(t, w.Close())
+		return buf.String()
+	}
+
+	payload := testCommitPayload
+
+	var signature bytes.Buffer
+	err = openpgp.ArmoredDetachSign(&signature, signer, strings.NewReader(payload), nil)
+	require.NoError(t, err)
+
+	raw := withSignatureHeader(payload, signature.String())
+
+	fingerprint, err := VerifyCommitSignature([]byte(raw), []string{armored(signer)})
+	require.NoError(t, err)
+	require.Equal(t, fmt.Sprintf("%X", signer.PrimaryKey.Fingerprint), fingerprint)
+
+	_, err = VerifyCommitSignature([]byte(raw), []string{armored(other)})
+	require.Equal(t, ErrUnknownSigner, err)
+
+	_, err = VerifyCommitSignature([]byte(payload), []string{armored(signer)})
+	require.Equal(t, ErrCommitNotSigned, err)
+
+	tampered := strings.Replace(raw, "1600000000", "1600000001", 1)
+	_, err = VerifyCommitSignature([]byte(tampered), []string{armored(signer)})
+	require.Error(t, err)
+	require.NotEqual(t, ErrUnknownSigner, err)
+}
+
+func TestVerifyCommitSSHSignature(t *testing.T) {
+	_, signerKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	signer, err := ssh.NewSignerFromKey(signerKey)
+	require.NoError(t, err)
+
+	_, otherKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	other, err := ssh.NewSignerFromKey(otherKey)
+	require.NoError(t, err)
+
+	authorized := func(s ssh.Signer) string {
+		return string(ssh.MarshalAuthorizedKey(s.PublicKey()))
+	}
+
+	payload := testCommitPayload
+
+	// sign as "ssh-keygen -Y sign -n git" does
+	hash := sha512.Sum512([]byte(payload))
+	signed := append([]byte(sshSignatureMagic), ssh.Marshal(sshSignedData{
+		Namespace:     sshSignatureNamespace,
+		HashAlgorithm: "sha512",
+		Hash:          hash[:],
+	})...)
+	sig, err := signer.Sign(rand.Reader, signed)
+	require.NoError(t, err)
+
+	blob := append([]byte(sshSignatureMagic), ssh.Marshal(sshSignature{
+		Version:       1,
+		PublicKey:     signer.PublicKey().Marshal(),
+		Namespace:     sshSignatureNamespace,
+		HashAlgorithm: "sha512",
+		Signature:     ssh.Marshal(sig),
+	})...)
+	armored := sshSignatureArmorStart + "\n" + base64.StdEncoding.EncodeToString(blob) + "\n" + sshSignatureArmorEnd
+
+	raw := withSignatureHeader(payload, armored)
+
+	fingerprint, err := VerifyCommitSignature([]byte(raw), []string{authorized(other), authorized(signer)})
+	require.NoError(t, err)
+	require.Equal(t, ssh.FingerprintSHA256(signer.PublicKey()), fingerprint)
+
+	_, err = VerifyCommitSignature([]byte(raw), []string{authorized(other)})
+	require.Equal(t, ErrUnknownSigner, err)
+
+	tampered := strings.Replace(raw, "1600000000", "1600000001", 1)
+	_, err = VerifyCommitSignature([]byte(tampered), []string{authorized(signer)})
+	require.Error(t, err)
+	require.NotEqual(t, ErrUnknownSigner, err)
+}
@@ -0,0 +1,113 @@
+package review
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/MichaelMure/git-bug/identity"
+	"github.com/MichaelMure/git-bug/util/text"
+)
+
+var _ Operation = &AddCommentOperation{}
+
+// AddCommentOperation will add a new comment in the review, either general
+// or anchored on a line of a file
+type AddCommentOperation struct {
+	OpBase
+	Message  string    `json:"message"`
+	Location *Location `json:"location,omitempty"`
+	Verdict  Verdict   `json:"verdict,omitempty"`
+}
+
+func (op *AddCommentOperation) base() *OpBase {
+	return &op.OpBase
+}
+
+func (op *AddCommentOperation) Apply(snapshot *Snapshot) {
+	snapshot.Comments = append(snapshot.Comments, Comment{
+		Author:   op.Author,
+		Message:  op.Message,
+		Location: op.Location,
+		Verdict:  op.Verdict,
+		UnixTime: op.UnixTime,
+	})
+}
+
+func (op *AddCommentOperation) Validate() error {
+	if err := opBaseValidate(op, AddCommentOp); err != nil {
+		return err
+	}
+
+	if text.Empty(op.Message) && op.Verdict == NoVerdict {
+		return fmt.Errorf("message is empty")
+	}
+
+	if !text.Safe(op.Message) {
+		return fmt.Errorf("message is not fully printable")
+	}
+
+	if op.Location != nil {
+		if err := op.Location.Validate(); err != nil {
+			return errors.Wrap(err, "location")
+		}
+	}
+
+	if err := op.Verdict.Validate(); err != nil {
+		return errors.Wrap(err, "verdict")
+	}
+
+	return nil
+}
+
+// UnmarshalJSON is a two step JSON unmarshaling
+// This workaround is necessary to avoid the inner OpBase.MarshalJSON
+// overriding the outer op's MarshalJSON
+func (op *AddCommentOperation) UnmarshalJSON(data []byte) error {
+	// Unmarshal OpBase and the op separately
+
+	base := OpBase{}
+	err := json.Unmarshal(data, &base)
+	if err != nil {
+		return err
+	}
+
+	aux := struct {
+		Message  string    `json:"message"`
+		Location *Location `json:"location"`
+		Verdict  Verdict   `json:"verdict"`
+	}{}
+
+	err = json.Unmarshal(data, &aux)
+	if err != nil {
+		return err
+	}
+
+	op.OpBase = base
+	op.Message = aux.Message
+	op.Location = aux.Location
+	op.Verdict = aux.Verdict
+
+	return nil
+}
+
+func NewAddCommentOp(author identity.Interface, unixTime int64, message string, location *Location, verdict Verdict) *AddCommentOperation {
+	return &AddCommentOperation{
+		OpBase:   newOpBase(AddCommentOp, author, unixTime),
+		Message:  message,
+		Location: location,
+		Verdict:  verdict,
+	}
+}
+
+// Convenience function to apply the operation. location can be nil for a
+// general comment.
+func AddComment(r *Review, author identity.Interface, unixTime int64, message string, location *Location, verdict Verdict) (*AddCommentOperation, error) {
+	op := NewAddCommentOp(author, unixTime, message, location, verdict)
+	if err := op.Validate(); err != nil {
+		return nil, err
+	}
+	r.Append(op)
+	return op, nil
+}
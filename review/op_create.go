@@ -0,0 +1,125 @@
+package review
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/MichaelMure/git-bug/identity"
+	"github.com/MichaelMure/git-bug/repository"
+	"github.com/MichaelMure/git-bug/util/text"
+)
+
+var _ Operation = &CreateOperation{}
+
+// CreateOperation define the initial creation of a review
+type CreateOperation struct {
+	OpBase
+	Title       string          `json:"title"`
+	Description string          `json:"description"`
+	Base        repository.Hash `json:"base"`
+	Head        repository.Hash `json:"head"`
+}
+
+func (op *CreateOperation) base() *OpBase {
+	return &op.OpBase
+}
+
+func (op *CreateOperation) Apply(snapshot *Snapshot) {
+	snapshot.Title = op.Title
+	snapshot.Description = op.Description
+	snapshot.Status = OpenStatus
+	snapshot.Base = op.Base
+	snapshot.Head = op.Head
+	snapshot.Author = op.Author
+	snapshot.CreateTime = op.Time()
+}
+
+func (op *CreateOperation) Validate() error {
+	if err := opBaseValidate(op, CreateOp); err != nil {
+		return err
+	}
+
+	if err := validateTitle(op.Title); err != nil {
+		return err
+	}
+
+	if !text.Safe(op.Description) {
+		return fmt.Errorf("description is not fully printable")
+	}
+
+	return validateRange(op.Base, op.Head)
+}
+
+// UnmarshalJSON is a two step JSON unmarshaling
+// This workaround is necessary to avoid the inner OpBase.MarshalJSON
+// overriding the outer op's MarshalJSON
+func (op *CreateOperation) UnmarshalJSON(data []byte) error {
+	// Unmarshal OpBase and the op separately
+
+	base := OpBase{}
+	err := json.Unmarshal(data, &base)
+	if err != nil {
+		return err
+	}
+
+	aux := struct {
+		Title       string          `json:"title"`
+		Description string          `json:"description"`
+		Base        repository.Hash `json:"base"`
+		Head        repository.Hash `json:"head"`
+	}{}
+
+	err = json.Unmarshal(data, &aux)
+	if err != nil {
+		return err
+	}
+
+	op.OpBase = base
+	op.Title = aux.Title
+	op.Description = aux.Description
+	op.Base = aux.Base
+	op.Head = aux.Head
+
+	return nil
+}
+
+func NewCreateOp(author identity.Interface, unixTime int64, title, description string, base, head repository.Hash) *CreateOperation {
+	return &CreateOperation{
+		OpBase:      newOpBase(CreateOp, author, unixTime),
+		Title:       title,
+		Description: description,
+		Base:        base,
+		Head:        head,
+	}
+}
+
+// Convenience function to create a new review of the base..head range of commits
+func Create(author identity.Interface, unixTime int64, title, description string, base, head repository.Hash) (*Review, *CreateOperation, error) {
+	newReview := NewReview()
+	createOp := NewCreateOp(author, unixTime, title, description, base, head)
+
+	if err := createOp.Validate(); err != nil {
+		return nil, createOp, err
+	}
+
+	newReview.Append(createOp)
+
+	return newReview, createOp, nil
+}
+
+func validateTitle(title string) error {
+	if text.Empty(title) {
+		return fmt.Errorf("title is empty")
+	}
+
+	if strings.Contains(title, "\n") {
+		return fmt.Errorf("title should be a single line")
+	}
+
+	if !text.Safe(title) {
+		return fmt.Errorf("title should be fully printable")
+	}
+
+	return nil
+}
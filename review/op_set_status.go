@@ -0,0 +1,81 @@
+package review
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+
+	"github.com/MichaelMure/git-bug/identity"
+)
+
+var _ Operation = &SetStatusOperation{}
+
+// SetStatusOperation will change the status of a review
+type SetStatusOperation struct {
+	OpBase
+	Status Status `json:"status"`
+}
+
+func (op *SetStatusOperation) base() *OpBase {
+	return &op.OpBase
+}
+
+func (op *SetStatusOperation) Apply(snapshot *Snapshot) {
+	snapshot.Status = op.Status
+}
+
+func (op *SetStatusOperation) Validate() error {
+	if err := opBaseValidate(op, SetStatusOp); err != nil {
+		return err
+	}
+
+	if err := op.Status.Validate(); err != nil {
+		return errors.Wrap(err, "status")
+	}
+
+	return nil
+}
+
+// UnmarshalJSON is a two step JSON unmarshaling
+// This workaround is necessary to avoid the inner OpBase.MarshalJSON
+// overriding the outer op's MarshalJSON
+func (op *SetStatusOperation) UnmarshalJSON(data []byte) error {
+	// Unmarshal OpBase and the op separately
+
+	base := OpBase{}
+	err := json.Unmarshal(data, &base)
+	if err != nil {
+		return err
+	}
+
+	aux := struct {
+		Status Status `json:"status"`
+	}{}
+
+	err = json.Unmarshal(data, &aux)
+	if err != nil {
+		return err
+	}
+
+	op.OpBase = base
+	op.Status = aux.Status
+
+	return nil
+}
+
+func NewSetStatusOp(author identity.Interface, unixTime int64, status Status) *SetStatusOperation {
+	return &SetStatusOperation{
+		OpBase: newOpBase(SetStatusOp, author, unixTime),
+		Status: status,
+	}
+}
+
+// Convenience function to apply the operation
+func SetStatus(r *Review, author identity.Interface, unixTime int64, status Status) (*SetStatusOperation, error) {
+	op := NewSetStatusOp(author, unixTime, status)
+	if err := op.Validate(); err != nil {
+		return nil, err
+	}
+	r.Append(op)
+	return op, nil
+}
@@ -0,0 +1,75 @@
+package review
+
+import (
+	"encoding/json"
+
+	"github.com/MichaelMure/git-bug/identity"
+)
+
+var _ Operation = &SetTitleOperation{}
+
+// SetTitleOperation will change the title of a review
+type SetTitleOperation struct {
+	OpBase
+	Title string `json:"title"`
+}
+
+func (op *SetTitleOperation) base() *OpBase {
+	return &op.OpBase
+}
+
+func (op *SetTitleOperation) Apply(snapshot *Snapshot) {
+	snapshot.Title = op.Title
+}
+
+func (op *SetTitleOperation) Validate() error {
+	if err := opBaseValidate(op, SetTitleOp); err != nil {
+		return err
+	}
+
+	return validateTitle(op.Title)
+}
+
+// UnmarshalJSON is a two step JSON unmarshaling
+// This workaround is necessary to avoid the inner OpBase.MarshalJSON
+// overriding the outer op's MarshalJSON
+func (op *SetTitleOperation) UnmarshalJSON(data []byte) error {
+	// Unmarshal OpBase and the op separately
+
+	base := OpBase{}
+	err := json.Unmarshal(data, &base)
+	if err != nil {
+		return err
+	}
+
+	aux := struct {
+		Title string `json:"title"`
+	}{}
+
+	err = json.Unmarshal(data, &aux)
+	if err != nil {
+		return err
+	}
+
+	op.OpBase = base
+	op.Title = aux.Title
+
+	return nil
+}
+
+func NewSetTitleOp(author identity.Interface, unixTime int64, title string) *SetTitleOperation {
+	return &SetTitleOperation{
+		OpBase: newOpBase(SetTitleOp, author, unixTime),
+		Title:  title,
+	}
+}
+
+// Convenience function to apply the operation
+func SetTitle(r *Review, author identity.Interface, unixTime int64, title string) (*SetTitleOperation, error) {
+	op := NewSetTitleOp(author, unixTime, title)
+	if err := op.Validate(); err != nil {
+		return nil, err
+	}
+	r.Append(op)
+	return op, nil
+}
@@ -0,0 +1,82 @@
+package review
+
+import (
+	"encoding/json"
+
+	"github.com/MichaelMure/git-bug/identity"
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+var _ Operation = &UpdateRevisionOperation{}
+
+// UpdateRevisionOperation will change the range of commits under review,
+// typically after the changes have been amended or rebased
+type UpdateRevisionOperation struct {
+	OpBase
+	Base repository.Hash `json:"base"`
+	Head repository.Hash `json:"head"`
+}
+
+func (op *UpdateRevisionOperation) base() *OpBase {
+	return &op.OpBase
+}
+
+func (op *UpdateRevisionOperation) Apply(snapshot *Snapshot) {
+	snapshot.Base = op.Base
+	snapshot.Head = op.Head
+}
+
+func (op *UpdateRevisionOperation) Validate() error {
+	if err := opBaseValidate(op, UpdateRevisionOp); err != nil {
+		return err
+	}
+
+	return validateRange(op.Base, op.Head)
+}
+
+// UnmarshalJSON is a two step JSON unmarshaling
+// This workaround is necessary to avoid the inner OpBase.MarshalJSON
+// overriding the outer op's MarshalJSON
+func (op *UpdateRevisionOperation) UnmarshalJSON(data []byte) error {
+	// Unmarshal OpBase and the op separately
+
+	base := OpBase{}
+	err := json.Unmarshal(data, &base)
+	if err != nil {
+		return err
+	}
+
+	aux := struct {
+		Base repository.Hash `json:"base"`
+		Head repository.Hash `json:"head"`
+	}{}
+
+	err = json.Unmarshal(data, &aux)
+	if err != nil {
+		return err
+	}
+
+	op.OpBase = base
+	op.Base = aux.Base
+	op.Head = aux.Head
+
+	return nil
+}
+
+func NewUpdateRevisionOp(author identity.Interface, unixTime int64, base, head repository.Hash) *UpdateRevisionOperation {
+	return &UpdateRevisionOperation{
+		OpBase: newOpBase(UpdateRevisionOp, author, unixTime),
+		Base:   base,
+		Head:   head,
+	}
+}
+
+// Convenience function to apply the operation
+func UpdateRevision(r *Review, author identity.Interface, unixTime int64, base, head repository.Hash) (*UpdateRevisionOperation, error) {
+	op := NewUpdateRevisionOp(author, unixTime, base, head)
+	if err := op.Validate(); err != nil {
+		return nil, err
+	}
+	r.Append(op)
+	return op, nil
+}
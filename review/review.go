@@ -0,0 +1,390 @@
+// Package review contains the code review data model and low-level related functions
+package review
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/identity"
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+const reviewsRefPattern = "refs/reviews/"
+const reviewsRemoteRefPattern = "refs/remotes/%s/reviews/"
+
+const opsEntryName = "ops"
+
+var ErrReviewNotExist = errors.New("review doesn't exist")
+
+func NewErrMultipleMatchReview(matching []entity.Id) *entity.ErrMultipleMatch {
+	return entity.NewErrMultipleMatch("review", matching)
+}
+
+var _ entity.Interface = &Review{}
+
+// Review hold the data of a code review: a range of commits to be reviewed
+// and the discussion about it, possibly anchored on the code.
+// Like a bug, a review is a chain of OperationPack stored as git commits.
+type Review struct {
+	// Id used as unique identifier
+	id entity.Id
+
+	lastCommit repository.Hash
+
+	// all the committed operations
+	packs []OperationPack
+
+	// a temporary pack of operations used for convenience to pile up new operations
+	// before a commit
+	staging OperationPack
+}
+
+// NewReview create a new Review
+func NewReview() *Review {
+	// No id yet
+	return &Review{}
+}
+
+// ReadLocal will read a local review from its hash
+func ReadLocal(repo repository.Repo, identityResolver identity.Resolver, id entity.Id) (*Review, error) {
+	ref := reviewsRefPattern + id.String()
+	return read(repo, identityResolver, ref)
+}
+
+// ReadRemote will read a remote review from its hash
+func ReadRemote(repo repository.Repo, identityResolver identity.Resolver, remote string, id entity.Id) (*Review, error) {
+	ref := fmt.Sprintf(reviewsRemoteRefPattern, remote) + id.String()
+	return read(repo, identityResolver, ref)
+}
+
+// read will read and parse a Review from git
+func read(repo repository.Repo, identityResolver identity.Resolver, ref string) (*Review, error) {
+	refSplit := strings.Split(ref, "/")
+	id := entity.Id(refSplit[len(refSplit)-1])
+
+	if err := id.Validate(); err != nil {
+		return nil, errors.Wrap(err, "invalid ref ")
+	}
+
+	hashes, err := repo.ListCommits(ref)
+	if err != nil {
+		return nil, ErrReviewNotExist
+	}
+
+	review := Review{id: id}
+
+	for _, hash := range hashes {
+		entries, err := repo.ReadTree(hash)
+		if err != nil {
+			return nil, errors.Wrap(err, "can't list git tree entries")
+		}
+
+		review.lastCommit = hash
+
+		var opsEntry repository.TreeEntry
+		opsFound := false
+		for _, entry := range entries {
+			if entry.Name == opsEntryName {
+				opsEntry = entry
+				opsFound = true
+			}
+		}
+		if !opsFound {
+			return nil, errors.New("invalid tree, missing the ops entry")
+		}
+
+		data, err := repo.ReadData(opsEntry.Hash)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read git blob data")
+		}
+
+		opp := &OperationPack{}
+		err = json.Unmarshal(data, &opp)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to decode OperationPack json")
+		}
+
+		// tag the pack with the commit hash
+		opp.commitHash = hash
+
+		review.packs = append(review.packs, *opp)
+	}
+
+	// Make sure that the identities are properly loaded
+	for _, pack := range review.packs {
+		for _, op := range pack.Operations {
+			base := op.base()
+			if stub, ok := base.Author.(*identity.IdentityStub); ok {
+				i, err := identityResolver.ResolveIdentity(stub.Id())
+				if err != nil {
+					return nil, err
+				}
+				base.Author = i
+			}
+		}
+	}
+
+	return &review, nil
+}
+
+// ListLocalIds list all the available local review ids
+func ListLocalIds(repo repository.Repo) ([]entity.Id, error) {
+	refs, err := repo.ListRefs(reviewsRefPattern)
+	if err != nil {
+		return nil, err
+	}
+
+	return refsToIds(refs), nil
+}
+
+func refsToIds(refs []string) []entity.Id {
+	ids := make([]entity.Id, len(refs))
+
+	for i, ref := range refs {
+		split := strings.Split(ref, "/")
+		ids[i] = entity.Id(split[len(split)-1])
+	}
+
+	return ids
+}
+
+// Validate check if the Review data is valid
+func (review *Review) Validate() error {
+	// non-empty
+	if len(review.packs) == 0 && review.staging.IsEmpty() {
+		return fmt.Errorf("review has no operations")
+	}
+
+	// check if each pack and operations are valid
+	for _, pack := range review.packs {
+		if err := pack.Validate(); err != nil {
+			return err
+		}
+	}
+
+	// check if staging is valid if needed
+	if !review.staging.IsEmpty() {
+		if err := review.staging.Validate(); err != nil {
+			return errors.Wrap(err, "staging")
+		}
+	}
+
+	// The very first Op should be a CreateOp
+	firstOp := review.FirstOp()
+	if firstOp == nil || firstOp.base().OperationType != CreateOp {
+		return fmt.Errorf("first operation should be a Create op")
+	}
+
+	// Check that there is no more CreateOp op
+	createCount := 0
+	for _, op := range review.operations() {
+		if op.base().OperationType == CreateOp {
+			createCount++
+		}
+	}
+	if createCount != 1 {
+		return fmt.Errorf("only one Create op allowed")
+	}
+
+	return nil
+}
+
+// Append an operation into the staging area, to be committed later
+func (review *Review) Append(op Operation) {
+	review.staging.Append(op)
+}
+
+// NeedCommit indicate if the in-memory state changed and need to be commit in the repository
+func (review *Review) NeedCommit() bool {
+	return !review.staging.IsEmpty()
+}
+
+// Commit write the staging area in Git and move the operations to the packs
+func (review *Review) Commit(repo repository.Repo) error {
+	if !review.NeedCommit() {
+		return fmt.Errorf("can't commit a review with no pending operation")
+	}
+
+	if err := review.Validate(); err != nil {
+		return errors.Wrap(err, "can't commit a review with invalid data")
+	}
+
+	// Write the Ops as a Git blob containing the serialized array
+	hash, err := review.staging.Write(repo)
+	if err != nil {
+		return err
+	}
+
+	// Make a Git tree referencing this blob
+	hash, err = repo.StoreTree([]repository.TreeEntry{
+		{ObjectType: repository.Blob, Hash: hash, Name: opsEntryName},
+	})
+	if err != nil {
+		return err
+	}
+
+	// Write a Git commit referencing the tree, with the previous commit as parent
+	if review.lastCommit != "" {
+		hash, err = repo.StoreCommitWithParent(hash, review.lastCommit)
+	} else {
+		hash, err = repo.StoreCommit(hash)
+	}
+	if err != nil {
+		return err
+	}
+
+	review.lastCommit = hash
+
+	// if it was the first commit, use the commit hash as review id
+	if review.id == "" {
+		review.id = entity.Id(hash)
+	}
+
+	// Create or update the Git reference for this review
+	err = repo.UpdateRef(reviewsRefPattern+review.id.String(), hash)
+	if err != nil {
+		return err
+	}
+
+	review.staging.commitHash = hash
+	review.packs = append(review.packs, review.staging)
+	review.staging = OperationPack{}
+
+	return nil
+}
+
+// Merge a different version of the same review by rebasing operations of this
+// review that are not present in the other on top of the chain of operations
+// of the other version.
+func (review *Review) Merge(repo repository.Repo, other *Review) (bool, error) {
+	if review.id != other.id {
+		return false, errors.New("merging unrelated reviews is not supported")
+	}
+
+	if len(other.staging.Operations) > 0 {
+		return false, errors.New("merging a review with a non-empty staging is not supported")
+	}
+
+	if review.lastCommit == "" || other.lastCommit == "" {
+		return false, errors.New("can't merge a review that has never been stored")
+	}
+
+	ancestor, err := repo.FindCommonAncestor(review.lastCommit, other.lastCommit)
+	if err != nil {
+		return false, errors.Wrap(err, "can't find common ancestor")
+	}
+
+	ancestorIndex := 0
+	newPacks := make([]OperationPack, 0, len(review.packs))
+
+	// Find the root of the rebase
+	for i, pack := range review.packs {
+		newPacks = append(newPacks, pack)
+
+		if pack.commitHash == ancestor {
+			ancestorIndex = i
+			break
+		}
+	}
+
+	if len(other.packs) == ancestorIndex+1 {
+		// Nothing to rebase, return early
+		return false, nil
+	}
+
+	// get other review's extra packs
+	for i := ancestorIndex + 1; i < len(other.packs); i++ {
+		newPack := other.packs[i].Clone()
+
+		newPacks = append(newPacks, newPack)
+		review.lastCommit = newPack.commitHash
+	}
+
+	// rebase our extra packs
+	for i := ancestorIndex + 1; i < len(review.packs); i++ {
+		pack := review.packs[i]
+
+		// get the referenced git tree
+		treeHash, err := repo.GetTreeHash(pack.commitHash)
+		if err != nil {
+			return false, err
+		}
+
+		// create a new commit with the correct ancestor
+		hash, err := repo.StoreCommitWithParent(treeHash, review.lastCommit)
+		if err != nil {
+			return false, err
+		}
+
+		// replace the pack
+		newPack := pack.Clone()
+		newPack.commitHash = hash
+		newPacks = append(newPacks, newPack)
+
+		// update the review
+		review.lastCommit = hash
+	}
+
+	review.packs = newPacks
+
+	// Update the git ref
+	err = repo.UpdateRef(reviewsRefPattern+review.id.String(), review.lastCommit)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// Id return the Review identifier
+func (review *Review) Id() entity.Id {
+	if review.id == "" {
+		// simply panic as it would be a coding error
+		// (using an id of a review not stored yet)
+		panic("no id yet")
+	}
+	return review.id
+}
+
+// operations return all the operations, committed or not, in order
+func (review *Review) operations() []Operation {
+	var result []Operation
+	for _, pack := range review.packs {
+		result = append(result, pack.Operations...)
+	}
+	return append(result, review.staging.Operations...)
+}
+
+// FirstOp lookup for the very first operation of the review.
+// For a valid Review, this operation should be a CreateOp
+func (review *Review) FirstOp() Operation {
+	for _, pack := range review.packs {
+		for _, op := range pack.Operations {
+			return op
+		}
+	}
+
+	if !review.staging.IsEmpty() {
+		return review.staging.Operations[0]
+	}
+
+	return nil
+}
+
+// Compile a review in a easily usable snapshot
+func (review *Review) Compile() Snapshot {
+	snap := Snapshot{
+		id: review.id,
+	}
+
+	for _, op := range review.operations() {
+		op.Apply(&snap)
+		snap.Operations = append(snap.Operations, op)
+	}
+
+	return snap
+}
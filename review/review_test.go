@@ -0,0 +1,81 @@
+package review
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/identity"
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+func TestReviewCommitLoad(t *testing.T) {
+	repo := repository.NewMockRepoForTest()
+
+	rene := identity.NewIdentity("René Descartes", "rene@descartes.fr")
+	err := rene.Commit(repo)
+	require.NoError(t, err)
+
+	isaac := identity.NewIdentity("Isaac Newton", "isaac@newton.uk")
+	err = isaac.Commit(repo)
+	require.NoError(t, err)
+
+	unix := time.Now().Unix()
+
+	base := repository.Hash("1111111111111111111111111111111111111111")
+	head := repository.Hash("2222222222222222222222222222222222222222")
+	amended := repository.Hash("3333333333333333333333333333333333333333")
+
+	_, _, err = Create(rene, unix, "fix the parser", "", base, base)
+	require.Error(t, err)
+
+	r, _, err := Create(rene, unix, "fix the parser", "this fix #42", base, head)
+	require.NoError(t, err)
+
+	_, err = AddComment(r, isaac, unix, "missing a test", nil, RequestChangesVerdict)
+	require.NoError(t, err)
+
+	err = r.Commit(repo)
+	require.NoError(t, err)
+
+	_, err = UpdateRevision(r, rene, unix, base, amended)
+	require.NoError(t, err)
+
+	_, err = AddComment(r, isaac, unix, "this could overflow", &Location{
+		Commit: amended,
+		Path:   "query/parser.go",
+		Line:   12,
+	}, NoVerdict)
+	require.NoError(t, err)
+
+	_, err = AddComment(r, isaac, unix, "", &Location{Path: "query/parser.go"}, NoVerdict)
+	require.Error(t, err)
+
+	_, err = AddComment(r, isaac, unix, "", nil, ApproveVerdict)
+	require.NoError(t, err)
+
+	_, err = SetStatus(r, rene, unix, MergedStatus)
+	require.NoError(t, err)
+
+	err = r.Commit(repo)
+	require.NoError(t, err)
+
+	read, err := ReadLocal(repo, identity.NewSimpleResolver(repo), r.Id())
+	require.NoError(t, err)
+
+	snap := read.Compile()
+	require.Equal(t, "fix the parser", snap.Title)
+	require.Equal(t, MergedStatus, snap.Status)
+	require.Equal(t, base, snap.Base)
+	require.Equal(t, amended, snap.Head)
+	require.Len(t, snap.Comments, 3)
+	require.Len(t, snap.CommentsAt("query/parser.go"), 1)
+	require.Equal(t, 12, snap.Comments[1].Location.Line)
+	require.Equal(t, map[entity.Id]Verdict{isaac.Id(): ApproveVerdict}, snap.Verdicts())
+
+	ids, err := ListLocalIds(repo)
+	require.NoError(t, err)
+	require.Equal(t, []entity.Id{r.Id()}, ids)
+}
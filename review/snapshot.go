@@ -0,0 +1,139 @@
+package review
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/identity"
+	"github.com/MichaelMure/git-bug/repository"
+	"github.com/MichaelMure/git-bug/util/text"
+)
+
+// Location anchor a comment on a line of a file, as of a given commit
+type Location struct {
+	Commit repository.Hash `json:"commit"`
+	Path   string          `json:"path"`
+	// Line is 1-based, 0 meaning the whole file
+	Line int `json:"line,omitempty"`
+}
+
+func (l Location) String() string {
+	if l.Line == 0 {
+		return fmt.Sprintf("%s@%s", l.Path, l.Commit.String()[:7])
+	}
+	return fmt.Sprintf("%s:%d@%s", l.Path, l.Line, l.Commit.String()[:7])
+}
+
+func (l *Location) Validate() error {
+	if !l.Commit.IsValid() {
+		return fmt.Errorf("invalid commit hash")
+	}
+
+	if text.Empty(l.Path) {
+		return fmt.Errorf("path is empty")
+	}
+
+	if strings.Contains(l.Path, "\n") {
+		return fmt.Errorf("path should be a single line")
+	}
+
+	if l.Line < 0 {
+		return fmt.Errorf("invalid line %d", l.Line)
+	}
+
+	return nil
+}
+
+// Comment is a message of the review discussion, optionally anchored on the code
+type Comment struct {
+	Author  identity.Interface
+	Message string
+	// Location is nil for a general comment
+	Location *Location
+	Verdict  Verdict
+
+	// Creation time of the comment.
+	// Should be used only for human display, never for ordering as we can't rely on it in a distributed system.
+	UnixTime int64
+}
+
+// Snapshot is a compiled form of the Review data structure used for storage and merge
+type Snapshot struct {
+	id entity.Id
+
+	Title       string
+	Description string
+	Status      Status
+	// Base is the commit the changes are based on, excluded from the review
+	Base repository.Hash
+	// Head is the last commit of the changes under review
+	Head       repository.Hash
+	Comments   []Comment
+	Author     identity.Interface
+	CreateTime time.Time
+
+	Operations []Operation
+}
+
+// Return the Review identifier
+func (snap *Snapshot) Id() entity.Id {
+	return snap.id
+}
+
+// EditTime return the last time the review was modified
+func (snap *Snapshot) EditTime() time.Time {
+	if len(snap.Operations) == 0 {
+		return time.Unix(0, 0)
+	}
+
+	return snap.Operations[len(snap.Operations)-1].Time()
+}
+
+// Range return the git revision range under review
+func (snap *Snapshot) Range() string {
+	return fmt.Sprintf("%s..%s", snap.Base, snap.Head)
+}
+
+// Verdicts return the latest verdict of each reviewer that gave one
+func (snap *Snapshot) Verdicts() map[entity.Id]Verdict {
+	result := make(map[entity.Id]Verdict)
+
+	for _, comment := range snap.Comments {
+		if comment.Verdict != NoVerdict {
+			result[comment.Author.Id()] = comment.Verdict
+		}
+	}
+
+	return result
+}
+
+// CommentsAt return the comments anchored on the given file
+func (snap *Snapshot) CommentsAt(path string) []Comment {
+	var result []Comment
+
+	for _, comment := range snap.Comments {
+		if comment.Location != nil && comment.Location.Path == path {
+			result = append(result, comment)
+		}
+	}
+
+	return result
+}
+
+func validateRange(base, head repository.Hash) error {
+	if !base.IsValid() {
+		return fmt.Errorf("invalid base commit hash")
+	}
+
+	if !head.IsValid() {
+		return fmt.Errorf("invalid head commit hash")
+	}
+
+	if base == head {
+		return fmt.Errorf("empty range")
+	}
+
+	return nil
+}
@@ -0,0 +1,96 @@
+package review
+
+import (
+	"fmt"
+	"strings"
+)
+
+type Status int
+
+const (
+	_ Status = iota
+	OpenStatus
+	MergedStatus
+	AbandonedStatus
+)
+
+func (s Status) String() string {
+	switch s {
+	case OpenStatus:
+		return "open"
+	case MergedStatus:
+		return "merged"
+	case AbandonedStatus:
+		return "abandoned"
+	default:
+		return "unknown status"
+	}
+}
+
+func StatusFromString(str string) (Status, error) {
+	cleaned := strings.ToLower(strings.TrimSpace(str))
+
+	switch cleaned {
+	case "open":
+		return OpenStatus, nil
+	case "merged":
+		return MergedStatus, nil
+	case "abandoned":
+		return AbandonedStatus, nil
+	default:
+		return 0, fmt.Errorf("unknown status")
+	}
+}
+
+func (s Status) Validate() error {
+	if s != OpenStatus && s != MergedStatus && s != AbandonedStatus {
+		return fmt.Errorf("invalid")
+	}
+
+	return nil
+}
+
+// Verdict is the optional conclusion of a reviewer attached to a comment
+type Verdict int
+
+const (
+	NoVerdict Verdict = iota
+	ApproveVerdict
+	RequestChangesVerdict
+)
+
+func (v Verdict) String() string {
+	switch v {
+	case NoVerdict:
+		return ""
+	case ApproveVerdict:
+		return "approve"
+	case RequestChangesVerdict:
+		return "request-changes"
+	default:
+		return "unknown verdict"
+	}
+}
+
+func VerdictFromString(str string) (Verdict, error) {
+	cleaned := strings.ToLower(strings.TrimSpace(str))
+
+	switch cleaned {
+	case "":
+		return NoVerdict, nil
+	case "approve":
+		return ApproveVerdict, nil
+	case "request-changes":
+		return RequestChangesVerdict, nil
+	default:
+		return 0, fmt.Errorf("unknown verdict")
+	}
+}
+
+func (v Verdict) Validate() error {
+	if v != NoVerdict && v != ApproveVerdict && v != RequestChangesVerdict {
+		return fmt.Errorf("invalid")
+	}
+
+	return nil
+}
@@ -0,0 +1,562 @@
+package termui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	text "github.com/MichaelMure/go-term-text"
+	"github.com/awesome-gocui/gocui"
+
+	"github.com/MichaelMure/git-bug/board"
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/query"
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+const boardView = "boardView"
+const boardHeaderView = "boardHeaderView"
+const boardInstructionView = "boardInstructionView"
+
+// the label namespaces displayed as boards are configured as:
+// git-bug.board.label-namespaces = prio,kind
+// A label "prio/high" is then in the column "high" of the "prio" board.
+const boardNamespacesConfigKey = "git-bug.board.label-namespaces"
+
+// the column of the bugs without a label in the namespace
+const noLabelColumn = "(none)"
+
+var boardViewHelp = helpBar{
+	{"back cancel", "Return"},
+	{"left down up right", "Navigation"},
+	{"move-left move-right", "Move card"},
+	{"open", "Open bug"},
+	{"next-board", "Next board"},
+}
+
+// displayedBoard is a board shown by the boardViewer: a kanban board of the
+// repository, or a board computed from the bugs, by status or by label
+// namespace.
+type displayedBoard struct {
+	title   string
+	columns []board.Column
+	// move a card to another column, with the corresponding operations
+	move func(id entity.Id, column string) error
+}
+
+// boardViewer display the boards, one at a time, with a column per board
+// column. The first board sort the bugs by status, followed by the label
+// namespace boards and the kanban boards of the repository.
+type boardViewer struct {
+	repo       *cache.RepoCache
+	namespaces []string
+	boardIds   []entity.Id
+	current    int
+	board      *displayedBoard
+	column     int
+	card       int
+	childViews []string
+}
+
+func newBoardViewer(c *cache.RepoCache) *boardViewer {
+	return &boardViewer{
+		repo: c,
+	}
+}
+
+func (bv *boardViewer) boardCount() int {
+	return 1 + len(bv.namespaces) + len(bv.boardIds)
+}
+
+// load (re)read the list of boards and the currently displayed one
+func (bv *boardViewer) load() error {
+	namespaces, err := readBoardNamespaces(bv.repo.AnyConfig())
+	if err != nil {
+		return err
+	}
+	bv.namespaces = namespaces
+
+	ids, err := bv.repo.AllBoardIds()
+	if err != nil {
+		return err
+	}
+	bv.boardIds = ids
+
+	bv.current = minInt(bv.current, bv.boardCount()-1)
+
+	switch {
+	case bv.current == 0:
+		bv.board, err = bv.statusBoard()
+	case bv.current <= len(bv.namespaces):
+		bv.board, err = bv.labelBoard(bv.namespaces[bv.current-1])
+	default:
+		bv.board, err = bv.kanbanBoard(bv.boardIds[bv.current-1-len(bv.namespaces)])
+	}
+	if err != nil {
+		return err
+	}
+
+	bv.column = maxInt(minInt(bv.column, len(bv.board.columns)-1), 0)
+	bv.card = maxInt(minInt(bv.card, bv.columnLen()-1), 0)
+
+	return nil
+}
+
+func readBoardNamespaces(config repository.ConfigRead) ([]string, error) {
+	raw, err := config.ReadString(boardNamespacesConfigKey)
+	if err == repository.ErrNoConfigEntry {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var namespaces []string
+	for _, namespace := range strings.Split(raw, ",") {
+		namespace = strings.TrimSpace(namespace)
+		if namespace != "" {
+			namespaces = append(namespaces, namespace)
+		}
+	}
+	return namespaces, nil
+}
+
+// boardBugs return the bugs matching a query, most recently edited first
+func (bv *boardViewer) boardBugs(rawQuery string) ([]*cache.BugExcerpt, error) {
+	q, err := query.Parse(rawQuery + " sort:edit-desc")
+	if err != nil {
+		return nil, err
+	}
+
+	ids := bv.repo.QueryBugs(q)
+	excerpts := make([]*cache.BugExcerpt, len(ids))
+	for i, id := range ids {
+		excerpts[i], err = bv.repo.ResolveBugExcerpt(id)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return excerpts, nil
+}
+
+// statusBoard has a column per status: open, the extended statuses of the
+// repository, and closed
+func (bv *boardViewer) statusBoard() (*displayedBoard, error) {
+	set, err := bv.repo.StatusSet()
+	if err != nil {
+		return nil, err
+	}
+
+	names := append([]string{bug.OpenStatus.String()}, set.Names()...)
+	names = append(names, bug.ClosedStatus.String())
+
+	columns := make([]board.Column, len(names))
+	index := make(map[string]int, len(names))
+	for i, name := range names {
+		columns[i].Name = name
+		index[name] = i
+	}
+
+	excerpts, err := bv.boardBugs("")
+	if err != nil {
+		return nil, err
+	}
+
+	for _, excerpt := range excerpts {
+		name := excerpt.Status.String()
+		if excerpt.ExtendedStatus != "" {
+			name = excerpt.ExtendedStatus
+		}
+		if i, ok := index[name]; ok {
+			columns[i].Cards = append(columns[i].Cards, excerpt.Id)
+		}
+	}
+
+	return &displayedBoard{
+		title:   "Status",
+		columns: columns,
+		move: func(id entity.Id, column string) error {
+			b, err := bv.repo.ResolveBug(id)
+			if err != nil {
+				return err
+			}
+
+			switch column {
+			case bug.OpenStatus.String():
+				_, err = b.Open()
+			case bug.ClosedStatus.String():
+				_, err = b.Close()
+			default:
+				_, err = b.SetExtendedStatus(column)
+			}
+			if err != nil {
+				return err
+			}
+
+			return b.Commit()
+		},
+	}, nil
+}
+
+// labelBoard has a column per label of a namespace, for the open bugs
+func (bv *boardViewer) labelBoard(namespace string) (*displayedBoard, error) {
+	prefix := namespace + "/"
+
+	var values []string
+	for _, l := range bv.repo.ValidLabels() {
+		if strings.HasPrefix(l.String(), prefix) {
+			values = append(values, strings.TrimPrefix(l.String(), prefix))
+		}
+	}
+	sort.Strings(values)
+
+	columns := make([]board.Column, len(values)+1)
+	index := make(map[string]int, len(values)+1)
+	columns[0].Name = noLabelColumn
+	index[noLabelColumn] = 0
+	for i, value := range values {
+		columns[i+1].Name = value
+		index[value] = i + 1
+	}
+
+	excerpts, err := bv.boardBugs("status:open")
+	if err != nil {
+		return nil, err
+	}
+
+	for _, excerpt := range excerpts {
+		column := noLabelColumn
+		for _, l := range excerpt.Labels {
+			if strings.HasPrefix(l.String(), prefix) {
+				column = strings.TrimPrefix(l.String(), prefix)
+				break
+			}
+		}
+		i := index[column]
+		columns[i].Cards = append(columns[i].Cards, excerpt.Id)
+	}
+
+	return &displayedBoard{
+		title:   fmt.Sprintf("Label %s", namespace),
+		columns: columns,
+		move: func(id entity.Id, column string) error {
+			b, err := bv.repo.ResolveBug(id)
+			if err != nil {
+				return err
+			}
+
+			// a bug has at most one label of the namespace
+			var removed []string
+			for _, l := range b.Snapshot().Labels {
+				if strings.HasPrefix(l.String(), prefix) {
+					removed = append(removed, l.String())
+				}
+			}
+
+			var added []string
+			if column != noLabelColumn {
+				added = []string{prefix + column}
+			}
+
+			_, _, err = b.ChangeLabels(added, removed)
+			if err != nil {
+				return err
+			}
+
+			return b.Commit()
+		},
+	}, nil
+}
+
+// kanbanBoard is a board of the repository, with its own columns
+func (bv *boardViewer) kanbanBoard(id entity.Id) (*displayedBoard, error) {
+	b, err := bv.repo.ResolveBoard(id)
+	if err != nil {
+		return nil, err
+	}
+
+	snap := b.Snapshot()
+
+	return &displayedBoard{
+		title:   fmt.Sprintf("%s %s", currentTheme.id.Sprint(snap.Id().Human()), snap.Title),
+		columns: snap.Columns,
+		move: func(id entity.Id, column string) error {
+			_, err := b.MoveCard(id, column, -1)
+			if err != nil {
+				return err
+			}
+
+			return b.Commit()
+		},
+	}, nil
+}
+
+func (bv *boardViewer) layout(g *gocui.Gui) error {
+	maxX, maxY := g.Size()
+	bv.childViews = nil
+
+	if maxY < 4 {
+		// window too small !
+		return nil
+	}
+
+	v, err := g.SetView(boardHeaderView, -1, -1, maxX, 1, 0)
+	if err != nil {
+		if !gocui.IsUnknownView(err) {
+			return err
+		}
+
+		v.Frame = false
+	}
+	bv.childViews = append(bv.childViews, boardHeaderView)
+
+	v.Clear()
+	if bv.board != nil {
+		_, _ = fmt.Fprintf(v, "%s (%d/%d)", bv.board.title, bv.current+1, bv.boardCount())
+	}
+
+	// the main view only exist to receive the keybindings
+	v, err = g.SetView(boardView, -1, 0, maxX, maxY-3, 0)
+	if err != nil {
+		if !gocui.IsUnknownView(err) {
+			return err
+		}
+
+		v.Frame = false
+	}
+	bv.childViews = append(bv.childViews, boardView)
+
+	if bv.board != nil && len(bv.board.columns) > 0 {
+		width := maxX / len(bv.board.columns)
+
+		for i, column := range bv.board.columns {
+			viewName := fmt.Sprintf("boardcolumn%d", i)
+			x0 := i * width
+			v, err := g.SetView(viewName, x0, 1, x0+width-1, maxY-3, 0)
+			if err != nil && !gocui.IsUnknownView(err) {
+				return err
+			}
+			bv.childViews = append(bv.childViews, viewName)
+
+			v.Title = fmt.Sprintf("%s (%d)", column.Name, len(column.Cards))
+			v.Highlight = i == bv.column
+			v.SelBgColor = currentTheme.selection.bgAttribute()
+			v.SelFgColor = currentTheme.selection.fgAttribute()
+
+			v.Clear()
+			bv.renderColumn(v, column, width-2)
+
+			if i == bv.column {
+				_ = v.SetCursor(0, bv.card)
+			}
+		}
+	}
+
+	v, err = g.SetView(boardInstructionView, -1, maxY-2, maxX, maxY, 0)
+	if err != nil {
+		if !gocui.IsUnknownView(err) {
+			return err
+		}
+
+		v.Frame = false
+		v.FgColor = currentTheme.text.fgAttribute()
+	}
+	bv.childViews = append(bv.childViews, boardInstructionView)
+
+	v.Clear()
+	_, _ = fmt.Fprint(v, boardViewHelp.Render(maxX))
+
+	_, err = g.SetCurrentView(boardView)
+	return err
+}
+
+func (bv *boardViewer) renderColumn(v *gocui.View, column board.Column, width int) {
+	for _, card := range column.Cards {
+		excerpt, err := bv.repo.ResolveBugExcerpt(card)
+		if err != nil {
+			// the bug might not be available locally
+			_, _ = fmt.Fprintln(v, currentTheme.id.Sprint(card.Human()))
+			continue
+		}
+
+		title := text.LeftPadMaxLine(strings.TrimSpace(excerpt.Title), width-len(card.Human())-1, 0)
+		_, _ = fmt.Fprintf(v, "%s %s\n", currentTheme.id.Sprint(card.Human()), title)
+	}
+}
+
+func (bv *boardViewer) keybindings(g *gocui.Gui) error {
+	// Return
+	if err := bindAction(g, boardView, "back", bv.back); err != nil {
+		return err
+	}
+	if err := bindAction(g, boardView, "cancel", bv.back); err != nil {
+		return err
+	}
+
+	// Down
+	if err := bindAction(g, boardView, "down", bv.cardDown); err != nil {
+		return err
+	}
+
+	// Up
+	if err := bindAction(g, boardView, "up", bv.cardUp); err != nil {
+		return err
+	}
+
+	// Left
+	if err := bindAction(g, boardView, "left", bv.columnLeft); err != nil {
+		return err
+	}
+
+	// Right
+	if err := bindAction(g, boardView, "right", bv.columnRight); err != nil {
+		return err
+	}
+
+	// Move card left
+	if err := bindAction(g, boardView, "move-left", bv.moveCardLeft); err != nil {
+		return err
+	}
+
+	// Move card right
+	if err := bindAction(g, boardView, "move-right", bv.moveCardRight); err != nil {
+		return err
+	}
+
+	// Open bug
+	if err := bindAction(g, boardView, "open", bv.openBug); err != nil {
+		return err
+	}
+
+	// Next board
+	if err := bindAction(g, boardView, "next-board", bv.nextBoard); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (bv *boardViewer) disable(g *gocui.Gui) error {
+	for _, view := range bv.childViews {
+		if err := g.DeleteView(view); err != nil && !gocui.IsUnknownView(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+func (bv *boardViewer) columnLen() int {
+	if bv.board == nil || len(bv.board.columns) == 0 {
+		return 0
+	}
+	return len(bv.board.columns[bv.column].Cards)
+}
+
+func (bv *boardViewer) cardDown(g *gocui.Gui, v *gocui.View) error {
+	bv.card = maxInt(minInt(bv.card+1, bv.columnLen()-1), 0)
+	return nil
+}
+
+func (bv *boardViewer) cardUp(g *gocui.Gui, v *gocui.View) error {
+	bv.card = maxInt(bv.card-1, 0)
+	return nil
+}
+
+func (bv *boardViewer) columnLeft(g *gocui.Gui, v *gocui.View) error {
+	if bv.board == nil {
+		return nil
+	}
+	bv.column = maxInt(bv.column-1, 0)
+	bv.card = maxInt(minInt(bv.card, bv.columnLen()-1), 0)
+	return nil
+}
+
+func (bv *boardViewer) columnRight(g *gocui.Gui, v *gocui.View) error {
+	if bv.board == nil {
+		return nil
+	}
+	bv.column = minInt(bv.column+1, len(bv.board.columns)-1)
+	bv.card = maxInt(minInt(bv.card, bv.columnLen()-1), 0)
+	return nil
+}
+
+func (bv *boardViewer) moveCardLeft(g *gocui.Gui, v *gocui.View) error {
+	return bv.moveCard(g, -1)
+}
+
+func (bv *boardViewer) moveCardRight(g *gocui.Gui, v *gocui.View) error {
+	return bv.moveCard(g, 1)
+}
+
+// moveCard move the selected card to the next column in the given direction,
+// and follow it there
+func (bv *boardViewer) moveCard(g *gocui.Gui, direction int) error {
+	if bv.columnLen() == 0 {
+		return nil
+	}
+
+	target := bv.column + direction
+	if target < 0 || target >= len(bv.board.columns) {
+		return nil
+	}
+
+	id := bv.board.columns[bv.column].Cards[bv.card]
+
+	err := bv.board.move(id, bv.board.columns[target].Name)
+	if err != nil {
+		ui.msgPopup.Activate(msgPopupErrorTitle, err.Error())
+		return nil
+	}
+
+	if err := bv.disable(g); err != nil {
+		return err
+	}
+	if err := bv.load(); err != nil {
+		return err
+	}
+
+	bv.column = target
+	bv.card = 0
+	for i, card := range bv.board.columns[target].Cards {
+		if card == id {
+			bv.card = i
+			break
+		}
+	}
+
+	return nil
+}
+
+func (bv *boardViewer) nextBoard(g *gocui.Gui, v *gocui.View) error {
+	if err := bv.disable(g); err != nil {
+		return err
+	}
+
+	bv.current = (bv.current + 1) % bv.boardCount()
+	bv.column = 0
+	bv.card = 0
+
+	return bv.load()
+}
+
+func (bv *boardViewer) openBug(g *gocui.Gui, v *gocui.View) error {
+	if bv.columnLen() == 0 {
+		return nil
+	}
+
+	id := bv.board.columns[bv.column].Cards[bv.card]
+	b, err := bv.repo.ResolveBug(id)
+	if err != nil {
+		ui.msgPopup.Activate(msgPopupErrorTitle, err.Error())
+		return nil
+	}
+
+	ui.showBug.SetBug(b)
+	return ui.activateWindow(ui.showBug)
+}
+
+func (bv *boardViewer) back(g *gocui.Gui, v *gocui.View) error {
+	return ui.activateWindow(ui.bugTable)
+}
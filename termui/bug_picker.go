@@ -0,0 +1,285 @@
+package termui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/awesome-gocui/gocui"
+
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/query"
+)
+
+const bugPickerInputView = "bugPickerInputView"
+const bugPickerListView = "bugPickerListView"
+
+type bugPickerCandidate struct {
+	id entity.Id
+	// the text matched against the pattern
+	text    string
+	display string
+}
+
+// bugPicker is a standalone fuzzy finder over the bugs, used by the commands
+// to select a bug when none is given
+type bugPicker struct {
+	candidates []bugPickerCandidate
+	pattern    string
+	// index of the candidates matching the pattern, best first
+	matches  []int
+	selected int
+	picked   entity.Id
+}
+
+// PickBug open a fuzzy finder listing the bugs by their id, title and labels,
+// and return the id of the chosen one. An empty id is returned if the user
+// aborted.
+func PickBug(repo *cache.RepoCache) (entity.Id, error) {
+	t, err := loadTheme(repo.AnyConfig())
+	if err != nil {
+		return "", err
+	}
+	currentTheme = t
+
+	q := query.NewQuery()
+	q.OrderBy = query.OrderByEdit
+
+	bp := &bugPicker{}
+
+	for _, id := range repo.QueryBugs(q) {
+		excerpt, err := repo.ResolveBugExcerpt(id)
+		if err != nil {
+			return "", err
+		}
+
+		title := strings.TrimSpace(excerpt.Title)
+
+		var labels, labelsFmt []string
+		for _, l := range excerpt.Labels {
+			lc256 := l.Color().Term256()
+			labels = append(labels, l.String())
+			labelsFmt = append(labelsFmt, lc256.Escape()+"◼ "+lc256.Unescape()+l.String())
+		}
+
+		bp.candidates = append(bp.candidates, bugPickerCandidate{
+			id:   id,
+			text: strings.Join(append([]string{id.Human(), title}, labels...), " "),
+			display: fmt.Sprintf("%s %s %s %s",
+				currentTheme.id.Sprint(id.Human()),
+				currentTheme.status.Sprint(excerpt.Status),
+				title,
+				strings.Join(labelsFmt, " "),
+			),
+		})
+	}
+
+	if len(bp.candidates) == 0 {
+		return "", nil
+	}
+
+	bp.filter("")
+
+	g, err := gocui.NewGui(gocui.Output256, false)
+	if err != nil {
+		return "", err
+	}
+	defer g.Close()
+
+	g.Cursor = true
+	g.InputEsc = true
+	g.SetManagerFunc(bp.layout)
+
+	if err := bp.keybindings(g); err != nil {
+		return "", err
+	}
+
+	err = g.MainLoop()
+	if err != nil && err != gocui.ErrQuit {
+		return "", err
+	}
+
+	return bp.picked, nil
+}
+
+func (bp *bugPicker) keybindings(g *gocui.Gui) error {
+	// Abort
+	if err := g.SetKeybinding("", gocui.KeyCtrlC, gocui.ModNone, bp.abort); err != nil {
+		return err
+	}
+	if err := g.SetKeybinding(bugPickerInputView, gocui.KeyEsc, gocui.ModNone, bp.abort); err != nil {
+		return err
+	}
+	// Up
+	if err := g.SetKeybinding(bugPickerInputView, gocui.KeyArrowUp, gocui.ModNone, bp.selectPrevious); err != nil {
+		return err
+	}
+	if err := g.SetKeybinding(bugPickerInputView, gocui.KeyCtrlP, gocui.ModNone, bp.selectPrevious); err != nil {
+		return err
+	}
+	// Down
+	if err := g.SetKeybinding(bugPickerInputView, gocui.KeyArrowDown, gocui.ModNone, bp.selectNext); err != nil {
+		return err
+	}
+	if err := g.SetKeybinding(bugPickerInputView, gocui.KeyCtrlN, gocui.ModNone, bp.selectNext); err != nil {
+		return err
+	}
+	// Pick
+	if err := g.SetKeybinding(bugPickerInputView, gocui.KeyEnter, gocui.ModNone, bp.pick); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (bp *bugPicker) layout(g *gocui.Gui) error {
+	maxX, maxY := g.Size()
+
+	input, err := g.SetView(bugPickerInputView, 0, 0, maxX-1, 2, 0)
+	if err != nil {
+		if !gocui.IsUnknownView(err) {
+			return err
+		}
+
+		input.Frame = true
+		input.Editable = true
+		input.Title = "Pick a bug (↓↑ to navigate, enter to select, esc to abort)"
+	}
+
+	if _, err := g.SetCurrentView(bugPickerInputView); err != nil {
+		return err
+	}
+
+	// the layout is done again after each key press, filter with the new pattern
+	bp.filter(strings.TrimSpace(input.Buffer()))
+
+	list, err := g.SetView(bugPickerListView, -1, 2, maxX, maxY, 0)
+	if err != nil {
+		if !gocui.IsUnknownView(err) {
+			return err
+		}
+
+		list.Frame = false
+	}
+
+	list.Clear()
+
+	height := maxY - 3
+	start := 0
+	if bp.selected >= height {
+		start = bp.selected - height + 1
+	}
+
+	for i := start; i < len(bp.matches) && i < start+height; i++ {
+		marker := "  "
+		if i == bp.selected {
+			marker = currentTheme.marker.Sprint("> ")
+		}
+		_, _ = fmt.Fprintln(list, marker+bp.candidates[bp.matches[i]].display)
+	}
+
+	return nil
+}
+
+// filter update the matching candidates if the pattern changed
+func (bp *bugPicker) filter(pattern string) {
+	if pattern == bp.pattern && bp.matches != nil {
+		return
+	}
+
+	bp.pattern = pattern
+	bp.selected = 0
+	bp.matches = make([]int, 0, len(bp.candidates))
+
+	scores := make(map[int]int)
+
+	for i, c := range bp.candidates {
+		total := 0
+		for _, term := range strings.Fields(pattern) {
+			score := fuzzyScore(c.text, term)
+			if score < 0 {
+				total = -1
+				break
+			}
+			total += score
+		}
+		if total < 0 {
+			continue
+		}
+		scores[i] = total
+		bp.matches = append(bp.matches, i)
+	}
+
+	// keep the most recently edited bugs first for equal scores
+	sort.SliceStable(bp.matches, func(i, j int) bool {
+		return scores[bp.matches[i]] < scores[bp.matches[j]]
+	})
+}
+
+// fuzzyScore match the pattern as a case-insensitive subsequence of the text,
+// and return the number of characters interleaved in the tightest match, or -1
+// if the pattern doesn't match.
+func fuzzyScore(text string, pattern string) int {
+	t := []rune(strings.ToLower(text))
+	p := []rune(strings.ToLower(pattern))
+
+	if len(p) == 0 {
+		return 0
+	}
+
+	best := -1
+
+	for start := range t {
+		if t[start] != p[0] {
+			continue
+		}
+
+		j := 1
+		end := start
+		for k := start + 1; k < len(t) && j < len(p); k++ {
+			if t[k] == p[j] {
+				j++
+				end = k
+			}
+		}
+		if j < len(p) {
+			// no match from here, and so neither from further
+			break
+		}
+
+		gap := end - start + 1 - len(p)
+		// prefer the matches at the beginning of a word
+		if start > 0 && (unicode.IsLetter(t[start-1]) || unicode.IsDigit(t[start-1])) {
+			gap++
+		}
+		if best < 0 || gap < best {
+			best = gap
+		}
+	}
+
+	return best
+}
+
+func (bp *bugPicker) selectPrevious(g *gocui.Gui, v *gocui.View) error {
+	bp.selected = maxInt(0, bp.selected-1)
+	return nil
+}
+
+func (bp *bugPicker) selectNext(g *gocui.Gui, v *gocui.View) error {
+	bp.selected = maxInt(0, minInt(len(bp.matches)-1, bp.selected+1))
+	return nil
+}
+
+func (bp *bugPicker) pick(g *gocui.Gui, v *gocui.View) error {
+	if len(bp.matches) == 0 {
+		return nil
+	}
+	bp.picked = bp.candidates[bp.matches[bp.selected]].id
+	return gocui.ErrQuit
+}
+
+func (bp *bugPicker) abort(g *gocui.Gui, v *gocui.View) error {
+	bp.picked = ""
+	return gocui.ErrQuit
+}
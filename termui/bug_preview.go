@@ -0,0 +1,183 @@
+package termui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/MichaelMure/go-term-text"
+	"github.com/awesome-gocui/gocui"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/repository"
+	"github.com/MichaelMure/git-bug/util/markdown"
+)
+
+const bugPreviewView = "bugPreviewView"
+
+// the bug table start with the preview of the selected bug with:
+// git-bug.termui.layout = split
+const layoutConfigKey = "git-bug.termui.layout"
+
+const (
+	fullLayout  = "full"
+	splitLayout = "split"
+)
+
+// bugPreview show the thread of the selected bug next to the bug table, in
+// the split layout
+type bugPreview struct {
+	repo   *cache.RepoCache
+	id     entity.Id
+	scroll int
+	lines  int
+}
+
+func newBugPreview(repo *cache.RepoCache) *bugPreview {
+	return &bugPreview{repo: repo}
+}
+
+func (bp *bugPreview) layout(g *gocui.Gui, id entity.Id, x0, y0, x1, y1 int) error {
+	v, err := g.SetView(bugPreviewView, x0, y0, x1, y1, 0)
+
+	if err != nil {
+		if !gocui.IsUnknownView(err) {
+			return err
+		}
+
+		v.Frame = true
+		v.Wrap = false
+	}
+
+	v.Clear()
+
+	if id != bp.id {
+		bp.id = id
+		bp.scroll = 0
+	}
+
+	if id == "" {
+		bp.lines = 0
+		v.Title = ""
+		_, _ = fmt.Fprint(v, currentTheme.notice.Sprint("No bug selected."))
+		return nil
+	}
+
+	b, err := bp.repo.ResolveBug(id)
+	if err != nil {
+		return err
+	}
+
+	maxX, _ := v.Size()
+	content := renderThread(b.Snapshot(), maxX)
+	bp.lines = strings.Count(content, "\n") + 1
+
+	v.Title = id.Human()
+	_, _ = fmt.Fprint(v, content)
+
+	return v.SetOrigin(0, bp.scroll)
+}
+
+func (bp *bugPreview) disable(g *gocui.Gui) error {
+	if err := g.DeleteView(bugPreviewView); err != nil && !gocui.IsUnknownView(err) {
+		return err
+	}
+	return nil
+}
+
+// loadSplitLayout read if the bug table start in the split layout
+func loadSplitLayout(config repository.ConfigRead) (bool, error) {
+	layout, err := config.ReadString(layoutConfigKey)
+	if err == repository.ErrNoConfigEntry {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	switch layout {
+	case fullLayout:
+		return false, nil
+	case splitLayout:
+		return true, nil
+	default:
+		return false, fmt.Errorf("unknown layout %s, expected %s or %s", layout, fullLayout, splitLayout)
+	}
+}
+
+// wheel scroll the thread by a few lines
+func (bp *bugPreview) wheel(g *gocui.Gui, direction int) error {
+	v, err := g.View(bugPreviewView)
+	if err != nil {
+		return err
+	}
+
+	_, maxY := v.Size()
+
+	bp.scroll += direction * wheelLines
+
+	bp.scroll = minInt(bp.scroll, bp.lines-maxY)
+	bp.scroll = maxInt(bp.scroll, 0)
+
+	return nil
+}
+
+// renderThread render the header of a bug and its comments arranged in
+// threads, in a single block of text
+func renderThread(snap *bug.Snapshot, maxX int) string {
+	var builder strings.Builder
+
+	header := fmt.Sprintf("%s\n\n[%s] %s opened this bug on %s",
+		currentTheme.emphasis.Sprint(snap.Title),
+		currentTheme.status.Sprint(snap.DisplayStatus()),
+		currentTheme.author.Sprint(snap.Author.DisplayName()),
+		snap.CreateTime.Format(timeLayout),
+	)
+	header, _ = text.Wrap(header, maxX, text.WrapIndent("   "))
+	builder.WriteString(header)
+
+	if len(snap.Labels) > 0 {
+		labels := make([]string, len(snap.Labels))
+		for i, l := range snap.Labels {
+			labels[i] = l.String()
+		}
+		builder.WriteString("\n")
+		builder.WriteString(text.LeftPadMaxLine("labels: "+strings.Join(labels, ", "), maxX, 0))
+	}
+
+	for i, comment := range snap.Threads() {
+		pad := 2 + 4*comment.Depth
+
+		var message string
+		switch {
+		case comment.Redaction != nil:
+			message, _ = text.WrapLeftPadded(redactedPlaceholder(comment.Redaction), maxX, pad)
+		case comment.Minimized != "":
+			message, _ = text.WrapLeftPadded(minimizedPlaceholder(comment.Minimized), maxX, pad)
+		case strings.TrimSpace(comment.Message) == "":
+			message, _ = text.WrapLeftPadded(emptyMessagePlaceholder(), maxX, pad)
+		default:
+			message = markdown.Render(comment.Message, maxX, pad)
+		}
+
+		action := "commented"
+		if i == 0 {
+			action = "wrote"
+		}
+
+		title := fmt.Sprintf("%s %s on %s",
+			currentTheme.author.Sprint(authorsDisplayName(comment.Author, comment.CoAuthors)),
+			action,
+			comment.UnixTime.Time().Format(timeLayout),
+		)
+		title, _ = text.WrapLeftPadded(title, maxX, pad-2)
+
+		builder.WriteString("\n\n")
+		builder.WriteString(title)
+		builder.WriteString("\n\n")
+		builder.WriteString(message)
+	}
+
+	return builder.String()
+}
@@ -3,34 +3,42 @@ package termui
 import (
 	"bytes"
 	"fmt"
+	"sort"
 	"strings"
+	"unicode/utf8"
 
 	text "github.com/MichaelMure/go-term-text"
 	"github.com/awesome-gocui/gocui"
 	"github.com/dustin/go-humanize"
 
+	"github.com/MichaelMure/git-bug/bug"
 	"github.com/MichaelMure/git-bug/cache"
 	"github.com/MichaelMure/git-bug/entity"
 	"github.com/MichaelMure/git-bug/query"
-	"github.com/MichaelMure/git-bug/util/colors"
 )
 
 const bugTableView = "bugTableView"
 const bugTableHeaderView = "bugTableHeaderView"
 const bugTableFooterView = "bugTableFooterView"
 const bugTableInstructionView = "bugTableInstructionView"
+const bugTableSearchView = "bugTableSearchView"
 
-const defaultRemote = "origin"
 const defaultQuery = "status:open"
 
 var bugTableHelp = helpBar{
-	{"q", "Quit"},
-	{"s", "Search"},
-	{"←↓↑→,hjkl", "Navigation"},
-	{"↵", "Open bug"},
-	{"n", "New bug"},
-	{"i", "Pull"},
-	{"o", "Push"},
+	{"quit", "Quit"},
+	{"filter", "Filter"},
+	{"search", "Search"},
+	{"next-view", "Next view"},
+	{"left down up right", "Navigation"},
+	{"open", "Open bug"},
+	{"new-bug", "New bug"},
+	{"new-from-template", "New from template"},
+	{"boards", "Boards"},
+	{"split", "Split view"},
+	{"identity", "Identity"},
+	{"pull", "Pull"},
+	{"push", "Push"},
 }
 
 type bugTable struct {
@@ -41,6 +49,21 @@ type bugTable struct {
 	excerpts     []*cache.BugExcerpt
 	pageCursor   int
 	selectCursor int
+	// the filter bar is open
+	searching bool
+	// the completions of the last word typed in the filter bar
+	completions []string
+	registry    bug.LabelRegistry
+	// the labels of the bugs matching the query, most used first
+	labelCounts []labelCount
+	// the selected bug is shown next to the table
+	split   bool
+	preview *bugPreview
+}
+
+type labelCount struct {
+	label bug.Label
+	count int
 }
 
 func newBugTable(c *cache.RepoCache) *bugTable {
@@ -55,6 +78,7 @@ func newBugTable(c *cache.RepoCache) *bugTable {
 		queryStr:     defaultQuery,
 		pageCursor:   0,
 		selectCursor: 0,
+		preview:      newBugPreview(c),
 	}
 }
 
@@ -66,7 +90,13 @@ func (bt *bugTable) layout(g *gocui.Gui) error {
 		return nil
 	}
 
-	v, err := g.SetView(bugTableHeaderView, -1, -1, maxX, 1, 0)
+	// in the split layout, the table keep enough room for its columns
+	tableWidth := maxX
+	if bt.split {
+		tableWidth = maxInt(maxX*2/5, minInt(60, maxX))
+	}
+
+	v, err := g.SetView(bugTableHeaderView, -1, -1, tableWidth, 1, 0)
 
 	if err != nil {
 		if !gocui.IsUnknownView(err) {
@@ -77,9 +107,9 @@ func (bt *bugTable) layout(g *gocui.Gui) error {
 	}
 
 	v.Clear()
-	bt.renderHeader(v, maxX)
+	bt.renderHeader(v, tableWidth)
 
-	v, err = g.SetView(bugTableView, -1, 0, maxX, maxY-3, 0)
+	v, err = g.SetView(bugTableView, -1, 0, tableWidth, maxY-3, 0)
 
 	if err != nil {
 		if !gocui.IsUnknownView(err) {
@@ -87,8 +117,8 @@ func (bt *bugTable) layout(g *gocui.Gui) error {
 		}
 
 		v.Frame = false
-		v.SelBgColor = gocui.ColorWhite
-		v.SelFgColor = gocui.ColorBlack
+		v.SelBgColor = currentTheme.selection.bgAttribute()
+		v.SelFgColor = currentTheme.selection.fgAttribute()
 	}
 
 	viewWidth, viewHeight := v.Size()
@@ -105,6 +135,19 @@ func (bt *bugTable) layout(g *gocui.Gui) error {
 	v.Clear()
 	bt.render(v, viewWidth)
 
+	if bt.split && tableWidth < maxX-2 {
+		var selected entity.Id
+		if len(bt.excerpts) > 0 {
+			selected = bt.excerpts[bt.selectCursor].Id
+		}
+		err = bt.preview.layout(g, selected, tableWidth, 0, maxX-1, maxY-3)
+	} else {
+		err = bt.preview.disable(g)
+	}
+	if err != nil {
+		return err
+	}
+
 	v, err = g.SetView(bugTableFooterView, -1, maxY-4, maxX, maxY, 0)
 
 	if err != nil {
@@ -126,94 +169,130 @@ func (bt *bugTable) layout(g *gocui.Gui) error {
 		}
 
 		v.Frame = false
-		v.FgColor = gocui.ColorWhite
+		v.FgColor = currentTheme.text.fgAttribute()
 	}
 	v.Clear()
 	bt.renderHelp(v, maxX)
 
+	if bt.searching {
+		v, err = g.SetView(bugTableSearchView, -1, maxY-3, maxX, maxY-1, 0)
+
+		if err != nil {
+			if !gocui.IsUnknownView(err) {
+				return err
+			}
+
+			v.Frame = false
+			v.Editable = true
+			_, _ = fmt.Fprint(v, bt.queryStr)
+			_ = v.SetCursor(len([]rune(bt.queryStr)), 0)
+		}
+
+		g.Cursor = true
+		_, err = g.SetCurrentView(bugTableSearchView)
+		return err
+	}
+
 	_, err = g.SetCurrentView(bugTableView)
 	return err
 }
 
 func (bt *bugTable) keybindings(g *gocui.Gui) error {
 	// Quit
-	if err := g.SetKeybinding(bugTableView, 'q', gocui.ModNone, quit); err != nil {
+	if err := bindAction(g, bugTableView, "quit", quit); err != nil {
 		return err
 	}
 
 	// Down
-	if err := g.SetKeybinding(bugTableView, 'j', gocui.ModNone,
-		bt.cursorDown); err != nil {
+	if err := bindAction(g, bugTableView, "down", bt.cursorDown); err != nil {
 		return err
 	}
-	if err := g.SetKeybinding(bugTableView, gocui.KeyArrowDown, gocui.ModNone,
-		bt.cursorDown); err != nil {
+
+	// Up
+	if err := bindAction(g, bugTableView, "up", bt.cursorUp); err != nil {
 		return err
 	}
-	// Up
-	if err := g.SetKeybinding(bugTableView, 'k', gocui.ModNone,
-		bt.cursorUp); err != nil {
+
+	// Previous page
+	if err := bindAction(g, bugTableView, "left", bt.previousPage); err != nil {
 		return err
 	}
-	if err := g.SetKeybinding(bugTableView, gocui.KeyArrowUp, gocui.ModNone,
-		bt.cursorUp); err != nil {
+	if err := bindAction(g, bugTableView, "page-up", bt.previousPage); err != nil {
 		return err
 	}
 
-	// Previous page
-	if err := g.SetKeybinding(bugTableView, 'h', gocui.ModNone,
-		bt.previousPage); err != nil {
+	// Next page
+	if err := bindAction(g, bugTableView, "right", bt.nextPage); err != nil {
 		return err
 	}
-	if err := g.SetKeybinding(bugTableView, gocui.KeyArrowLeft, gocui.ModNone,
-		bt.previousPage); err != nil {
+	if err := bindAction(g, bugTableView, "page-down", bt.nextPage); err != nil {
 		return err
 	}
-	if err := g.SetKeybinding(bugTableView, gocui.KeyPgup, gocui.ModNone,
-		bt.previousPage); err != nil {
+
+	// New bug
+	if err := bindAction(g, bugTableView, "new-bug", bt.newBug); err != nil {
 		return err
 	}
-	// Next page
-	if err := g.SetKeybinding(bugTableView, 'l', gocui.ModNone,
-		bt.nextPage); err != nil {
+
+	// New bug from a template
+	if err := bindAction(g, bugTableView, "new-from-template", bt.newBugFromTemplate); err != nil {
 		return err
 	}
-	if err := g.SetKeybinding(bugTableView, gocui.KeyArrowRight, gocui.ModNone,
-		bt.nextPage); err != nil {
+
+	// Boards
+	if err := bindAction(g, bugTableView, "boards", bt.openBoards); err != nil {
 		return err
 	}
-	if err := g.SetKeybinding(bugTableView, gocui.KeyPgdn, gocui.ModNone,
-		bt.nextPage); err != nil {
+
+	// Identities
+	if err := bindAction(g, bugTableView, "identity", bt.openIdentities); err != nil {
 		return err
 	}
 
-	// New bug
-	if err := g.SetKeybinding(bugTableView, 'n', gocui.ModNone,
-		bt.newBug); err != nil {
+	// Split layout
+	if err := bindAction(g, bugTableView, "split", bt.toggleSplit); err != nil {
 		return err
 	}
 
 	// Open bug
-	if err := g.SetKeybinding(bugTableView, gocui.KeyEnter, gocui.ModNone,
-		bt.openBug); err != nil {
+	if err := bindAction(g, bugTableView, "open", bt.openBug); err != nil {
 		return err
 	}
 
 	// Pull
-	if err := g.SetKeybinding(bugTableView, 'i', gocui.ModNone,
-		bt.pull); err != nil {
+	if err := bindAction(g, bugTableView, "pull", bt.pull); err != nil {
 		return err
 	}
 
 	// Push
-	if err := g.SetKeybinding(bugTableView, 'o', gocui.ModNone,
-		bt.push); err != nil {
+	if err := bindAction(g, bugTableView, "push", bt.push); err != nil {
 		return err
 	}
 
 	// Query
-	if err := g.SetKeybinding(bugTableView, 's', gocui.ModNone,
-		bt.changeQuery); err != nil {
+	if err := bindAction(g, bugTableView, "search", bt.changeQuery); err != nil {
+		return err
+	}
+
+	// Switch to the next saved query
+	if err := bindAction(g, bugTableView, "next-view", bt.nextView); err != nil {
+		return err
+	}
+
+	// Filter bar
+	if err := bindAction(g, bugTableView, "filter", bt.openSearch); err != nil {
+		return err
+	}
+	if err := g.SetKeybinding(bugTableSearchView, gocui.KeyEnter, gocui.ModNone,
+		bt.applySearch); err != nil {
+		return err
+	}
+	if err := g.SetKeybinding(bugTableSearchView, gocui.KeyEsc, gocui.ModNone,
+		bt.closeSearch); err != nil {
+		return err
+	}
+	if err := g.SetKeybinding(bugTableSearchView, gocui.KeyTab, gocui.ModNone,
+		bt.completeSearch); err != nil {
 		return err
 	}
 
@@ -233,15 +312,59 @@ func (bt *bugTable) disable(g *gocui.Gui) error {
 	if err := g.DeleteView(bugTableInstructionView); err != nil && !gocui.IsUnknownView(err) {
 		return err
 	}
+	if err := g.DeleteView(bugTableSearchView); err != nil && !gocui.IsUnknownView(err) {
+		return err
+	}
+	if err := bt.preview.disable(g); err != nil {
+		return err
+	}
 	return nil
 }
 
 func (bt *bugTable) paginate(max int) error {
 	bt.allIds = bt.repo.QueryBugs(bt.query)
 
+	registry, err := bt.repo.LabelRegistry()
+	if err != nil {
+		return err
+	}
+	bt.registry = registry
+
+	err = bt.countLabels()
+	if err != nil {
+		return err
+	}
+
 	return bt.doPaginate(max)
 }
 
+// countLabels count the labels of all the bugs matching the query
+func (bt *bugTable) countLabels() error {
+	counts := make(map[bug.Label]int)
+	for _, id := range bt.allIds {
+		excerpt, err := bt.repo.ResolveBugExcerpt(id)
+		if err != nil {
+			return err
+		}
+		for _, l := range excerpt.Labels {
+			counts[l]++
+		}
+	}
+
+	bt.labelCounts = make([]labelCount, 0, len(counts))
+	for l, count := range counts {
+		bt.labelCounts = append(bt.labelCounts, labelCount{label: l, count: count})
+	}
+	sort.Slice(bt.labelCounts, func(i, j int) bool {
+		if bt.labelCounts[i].count != bt.labelCounts[j].count {
+			return bt.labelCounts[i].count > bt.labelCounts[j].count
+		}
+		return bt.labelCounts[i].label < bt.labelCounts[j].label
+	})
+
+	return nil
+}
+
 func (bt *bugTable) doPaginate(max int) error {
 	// clamp the cursor
 	bt.pageCursor = maxInt(bt.pageCursor, 0)
@@ -305,13 +428,15 @@ func (bt *bugTable) render(v *gocui.View, maxX int) {
 			summaryTxt = "  ∞"
 		}
 
-		var labelsTxt strings.Builder
+		// the labels take at most half of the title column
+		var labels string
 		for _, l := range excerpt.Labels {
-			labelsTxt.WriteString(" ")
-			lc256 := l.Color().Term256()
-			labelsTxt.WriteString(lc256.Escape())
-			labelsTxt.WriteString("◼")
-			labelsTxt.WriteString(lc256.Unescape())
+			chip := " " + labelChip(l, bt.registry.Color(l))
+			if text.Len(labels)+text.Len(chip) > columnWidths["title"]/2 {
+				labels += " …"
+				break
+			}
+			labels += chip
 		}
 
 		author, err := bt.repo.ResolveIdentityExcerpt(excerpt.AuthorId)
@@ -321,18 +446,17 @@ func (bt *bugTable) render(v *gocui.View, maxX int) {
 
 		id := text.LeftPadMaxLine(excerpt.Id.Human(), columnWidths["id"], 0)
 		status := text.LeftPadMaxLine(excerpt.Status.String(), columnWidths["status"], 0)
-		labels := text.TruncateMax(labelsTxt.String(), minInt(columnWidths["title"]-2, 10))
 		title := text.LeftPadMaxLine(strings.TrimSpace(excerpt.Title), columnWidths["title"]-text.Len(labels), 0)
 		authorTxt := text.LeftPadMaxLine(author.DisplayName(), columnWidths["author"], 0)
 		comments := text.LeftPadMaxLine(summaryTxt, columnWidths["comments"], 0)
 		lastEdit := text.LeftPadMaxLine(humanize.Time(excerpt.EditTime()), columnWidths["lastEdit"], 1)
 
 		_, _ = fmt.Fprintf(v, "%s %s %s%s %s %s %s\n",
-			colors.Cyan(id),
-			colors.Yellow(status),
+			currentTheme.id.Sprint(id),
+			currentTheme.status.Sprint(status),
 			title,
 			labels,
-			colors.Magenta(authorTxt),
+			currentTheme.author.Sprint(authorTxt),
 			comments,
 			lastEdit,
 		)
@@ -355,7 +479,50 @@ func (bt *bugTable) renderHeader(v *gocui.View, maxX int) {
 }
 
 func (bt *bugTable) renderFooter(v *gocui.View, maxX int) {
-	_, _ = fmt.Fprintf(v, " \nShowing %d of %d bugs", len(bt.excerpts), len(bt.allIds))
+	if bt.searching {
+		// the query is edited on the second line
+		if len(bt.completions) > 0 {
+			_, _ = fmt.Fprint(v, text.LeftPadMaxLine(strings.Join(bt.completions, "  "), maxX, 1))
+		} else {
+			_, _ = fmt.Fprint(v, currentTheme.text.Sprint(" Filter (tab to complete, enter to apply, esc to cancel)"))
+		}
+		return
+	}
+
+	_, _ = fmt.Fprintf(v, "%s\nShowing %d of %d bugs for %s, as %s",
+		bt.renderLabelCounts(maxX), len(bt.excerpts), len(bt.allIds), bt.queryStr, bt.userName())
+}
+
+// userName return the name of the identity authoring the changes
+func (bt *bugTable) userName() string {
+	user, err := bt.repo.GetUserIdentityExcerpt()
+	if err != nil {
+		return currentTheme.notice.Sprint("no identity")
+	}
+	return currentTheme.author.Sprint(user.DisplayName())
+}
+
+// renderLabelCounts render the labels of the bugs matching the query with
+// their number of bugs, as many as the width allow
+func (bt *bugTable) renderLabelCounts(maxX int) string {
+	result := " "
+	for _, lc := range bt.labelCounts {
+		entry := fmt.Sprintf("%s %d ", labelChip(lc.label, bt.registry.Color(lc.label)), lc.count)
+		if text.Len(result)+text.Len(entry) > maxX {
+			break
+		}
+		result += entry
+	}
+	return result
+}
+
+// labelChip render a label as a colored chip, with a readable text color
+func labelChip(label bug.Label, color bug.LabelColor) string {
+	fg := bug.Term256(231)
+	if color.IsLight() {
+		fg = bug.Term256(16)
+	}
+	return color.Term256().EscapeBg() + fg.Escape() + " " + label.String() + " " + fg.Unescape()
 }
 
 func (bt *bugTable) renderHelp(v *gocui.View, maxX int) {
@@ -402,6 +569,46 @@ func (bt *bugTable) cursorUp(g *gocui.Gui, v *gocui.View) error {
 	return nil
 }
 
+// click select the bug under the mouse, or open it if it's already selected
+func (bt *bugTable) click(g *gocui.Gui, v *gocui.View) error {
+	if v.Name() != bugTableView {
+		return nil
+	}
+
+	_, y := v.Cursor()
+	_, oy := v.Origin()
+	y += oy
+
+	if y < 0 || y >= bt.getTableLength() {
+		return nil
+	}
+
+	if y == bt.selectCursor {
+		return bt.openBug(g, v)
+	}
+
+	bt.selectCursor = y
+	return nil
+}
+
+// wheel move the selection, changing of page when needed, or scroll the
+// preview of the selected bug
+func (bt *bugTable) wheel(g *gocui.Gui, v *gocui.View, direction int) error {
+	if v.Name() == bugPreviewView {
+		return bt.preview.wheel(g, direction)
+	}
+
+	tableView, err := g.View(bugTableView)
+	if err != nil {
+		return err
+	}
+
+	if direction < 0 {
+		return bt.cursorUp(g, tableView)
+	}
+	return bt.cursorDown(g, tableView)
+}
+
 func (bt *bugTable) cursorClamp(v *gocui.View) error {
 	y := bt.selectCursor
 
@@ -438,7 +645,43 @@ func (bt *bugTable) previousPage(g *gocui.Gui, v *gocui.View) error {
 }
 
 func (bt *bugTable) newBug(g *gocui.Gui, v *gocui.View) error {
-	return newBugWithEditor(bt.repo)
+	return newBugWithEditor(bt.repo, nil)
+}
+
+func (bt *bugTable) newBugFromTemplate(g *gocui.Gui, v *gocui.View) error {
+	templates, err := bt.repo.Templates()
+	if err != nil {
+		ui.msgPopup.Activate(msgPopupErrorTitle, err.Error())
+		return nil
+	}
+
+	if len(templates) == 0 {
+		ui.msgPopup.Activate(msgPopupErrorTitle, "No template found in .git-bug/templates")
+		return nil
+	}
+
+	names := make([]string, len(templates))
+	for i, tmpl := range templates {
+		names[i] = tmpl.Name
+	}
+
+	c := ui.inputPopup.ActivateWithContent("Template: "+strings.Join(names, ", "), names[0])
+
+	go func() {
+		name := strings.TrimSpace(<-c)
+
+		g.Update(func(g *gocui.Gui) error {
+			tmpl, err := bt.repo.ResolveTemplate(name)
+			if err != nil {
+				ui.msgPopup.Activate(msgPopupErrorTitle, err.Error())
+				return nil
+			}
+
+			return newBugWithEditor(bt.repo, tmpl)
+		})
+	}()
+
+	return nil
 }
 
 func (bt *bugTable) openBug(g *gocui.Gui, v *gocui.View) error {
@@ -455,11 +698,37 @@ func (bt *bugTable) openBug(g *gocui.Gui, v *gocui.View) error {
 	return ui.activateWindow(ui.showBug)
 }
 
+func (bt *bugTable) openIdentities(g *gocui.Gui, v *gocui.View) error {
+	if err := ui.identities.load(); err != nil {
+		return err
+	}
+	return ui.activateWindow(ui.identities)
+}
+
+// toggleSplit show or hide the preview of the selected bug
+func (bt *bugTable) toggleSplit(g *gocui.Gui, v *gocui.View) error {
+	bt.split = !bt.split
+	return nil
+}
+
+func (bt *bugTable) openBoards(g *gocui.Gui, v *gocui.View) error {
+	if err := ui.boardViewer.load(); err != nil {
+		ui.msgPopup.Activate(msgPopupErrorTitle, err.Error())
+		return nil
+	}
+	return ui.activateWindow(ui.boardViewer)
+}
+
 func (bt *bugTable) pull(g *gocui.Gui, v *gocui.View) error {
-	ui.msgPopup.Activate("Pull from remote "+defaultRemote, "...")
+	remote, err := bt.repo.DefaultRemote()
+	if err != nil {
+		return err
+	}
+
+	ui.msgPopup.Activate("Pull from remote "+remote, "...")
 
 	go func() {
-		stdout, err := bt.repo.Fetch(defaultRemote)
+		stdout, err := bt.repo.Fetch(remote)
 
 		if err != nil {
 			g.Update(func(gui *gocui.Gui) error {
@@ -476,7 +745,7 @@ func (bt *bugTable) pull(g *gocui.Gui, v *gocui.View) error {
 		var buffer bytes.Buffer
 		beginLine := ""
 
-		for result := range bt.repo.MergeAll(defaultRemote) {
+		for result := range bt.repo.MergeAll(remote) {
 			if result.Status == entity.MergeStatusNothing {
 				continue
 			}
@@ -488,7 +757,7 @@ func (bt *bugTable) pull(g *gocui.Gui, v *gocui.View) error {
 				})
 			} else {
 				_, _ = fmt.Fprintf(&buffer, "%s%s: %s",
-					beginLine, colors.Cyan(result.Entity.Id().Human()), result,
+					beginLine, currentTheme.id.Sprint(result.Id.Human()), result,
 				)
 
 				beginLine = "\n"
@@ -513,11 +782,15 @@ func (bt *bugTable) pull(g *gocui.Gui, v *gocui.View) error {
 }
 
 func (bt *bugTable) push(g *gocui.Gui, v *gocui.View) error {
-	ui.msgPopup.Activate("Push to remote "+defaultRemote, "...")
+	remote, err := bt.repo.DefaultRemote()
+	if err != nil {
+		return err
+	}
+
+	ui.msgPopup.Activate("Push to remote "+remote, "...")
 
 	go func() {
-		// TODO: make the remote configurable
-		stdout, err := bt.repo.Push(defaultRemote)
+		stdout, err := bt.repo.Push(remote)
 
 		if err != nil {
 			g.Update(func(gui *gocui.Gui) error {
@@ -538,3 +811,129 @@ func (bt *bugTable) push(g *gocui.Gui, v *gocui.View) error {
 func (bt *bugTable) changeQuery(g *gocui.Gui, v *gocui.View) error {
 	return editQueryWithEditor(bt)
 }
+
+// parseQuery parse a query where the saved queries can be used with @name
+func (bt *bugTable) parseQuery(queryStr string) (*query.Query, error) {
+	saved, err := query.ReadSavedQueries(bt.repo.LocalConfig())
+	if err != nil {
+		return nil, err
+	}
+	return query.ParseWithSaved(queryStr, saved)
+}
+
+// nextView cycle through the default query and the saved queries
+func (bt *bugTable) nextView(g *gocui.Gui, v *gocui.View) error {
+	saved, err := query.ReadSavedQueries(bt.repo.LocalConfig())
+	if err != nil {
+		ui.msgPopup.Activate(msgPopupErrorTitle, err.Error())
+		return nil
+	}
+
+	views := make([]string, 0, len(saved)+1)
+	for name := range saved {
+		views = append(views, "@"+name)
+	}
+	sort.Strings(views)
+	views = append([]string{defaultQuery}, views...)
+
+	next := views[0]
+	for i, view := range views {
+		if view == bt.queryStr && i+1 < len(views) {
+			next = views[i+1]
+		}
+	}
+
+	q, err := bt.parseQuery(next)
+	if err != nil {
+		ui.msgPopup.Activate(msgPopupErrorTitle, err.Error())
+		return nil
+	}
+
+	bt.queryStr = next
+	bt.query = q
+	bt.pageCursor = 0
+	bt.selectCursor = 0
+
+	_, max := v.Size()
+	return bt.paginate(max)
+}
+
+func (bt *bugTable) openSearch(g *gocui.Gui, v *gocui.View) error {
+	bt.searching = true
+	bt.completions = nil
+	return nil
+}
+
+func (bt *bugTable) closeSearch(g *gocui.Gui, v *gocui.View) error {
+	bt.searching = false
+	bt.completions = nil
+	return g.DeleteView(bugTableSearchView)
+}
+
+// applySearch replace the listing by the bugs matching the query typed in
+// the filter bar
+func (bt *bugTable) applySearch(g *gocui.Gui, v *gocui.View) error {
+	queryStr := strings.TrimSpace(v.Buffer())
+
+	q, err := bt.parseQuery(queryStr)
+	if err != nil {
+		// keep the filter bar open to fix the query
+		ui.msgPopup.Activate(msgPopupErrorTitle, err.Error())
+		return nil
+	}
+
+	bt.queryStr = queryStr
+	bt.query = q
+	bt.pageCursor = 0
+	bt.selectCursor = 0
+
+	return bt.closeSearch(g, v)
+}
+
+// completeSearch complete the last word of the filter bar with a qualifier,
+// a label or a status, up to the longest common prefix of the candidates
+func (bt *bugTable) completeSearch(g *gocui.Gui, v *gocui.View) error {
+	queryStr := strings.TrimRight(v.Buffer(), "\n")
+
+	labels := bt.repo.ValidLabels()
+	labelNames := make([]string, len(labels))
+	for i, l := range labels {
+		labelNames[i] = l.String()
+	}
+
+	statuses, err := bt.repo.StatusSet()
+	if err != nil {
+		return err
+	}
+
+	start, completions := query.Complete(queryStr, labelNames, statuses.Names())
+	if len(completions) == 0 {
+		bt.completions = nil
+		return nil
+	}
+
+	completed := completions[0]
+	for _, c := range completions[1:] {
+		for !strings.HasPrefix(c, completed) {
+			_, size := utf8.DecodeLastRuneInString(completed)
+			completed = completed[:len(completed)-size]
+		}
+	}
+	if len(completed) < len(queryStr)-start {
+		// the candidates differ in quoting, keep what was typed
+		completed = queryStr[start:]
+	}
+	if len(completions) == 1 && !strings.HasSuffix(completed, ":") {
+		completed += " "
+	}
+
+	bt.completions = nil
+	if len(completions) > 1 {
+		bt.completions = completions
+	}
+
+	queryStr = queryStr[:start] + completed
+	v.Clear()
+	_, _ = fmt.Fprint(v, queryStr)
+	return v.SetCursor(len([]rune(queryStr)), 0)
+}
@@ -5,26 +5,40 @@ import (
 	"strings"
 
 	text "github.com/MichaelMure/go-term-text"
-
-	"github.com/MichaelMure/git-bug/util/colors"
 )
 
+// helpBar list some actions of a view, with their keys in the current keymap
 type helpBar []struct {
-	keys string
-	text string
+	// the space separated actions, sharing the description
+	actions string
+	text    string
 }
 
 func (hb helpBar) Render(maxX int) string {
 	var builder strings.Builder
 	for _, entry := range hb {
-		builder.WriteString(colors.White(colors.BlueBg(fmt.Sprintf("[%s] %s", entry.keys, entry.text))))
+		builder.WriteString(currentTheme.help.Sprint(fmt.Sprintf("[%s] %s", describeKeys(strings.Fields(entry.actions)...), entry.text)))
 		builder.WriteByte(' ')
 	}
 
 	l := text.Len(builder.String())
 	if l < maxX {
-		builder.WriteString(colors.White(colors.BlueBg(strings.Repeat(" ", maxX-l))))
+		builder.WriteString(currentTheme.help.Sprint(strings.Repeat(" ", maxX-l)))
 	}
 
 	return builder.String()
 }
+
+// actionAt return the first action of the entry rendered at a column, if any
+func (hb helpBar) actionAt(x int) string {
+	start := 0
+	for _, entry := range hb {
+		actions := strings.Fields(entry.actions)
+		width := text.Len(fmt.Sprintf("[%s] %s", describeKeys(actions...), entry.text))
+		if x >= start && x < start+width && len(actions) > 0 {
+			return actions[0]
+		}
+		start += width + 1
+	}
+	return ""
+}
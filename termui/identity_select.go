@@ -0,0 +1,344 @@
+package termui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/MichaelMure/go-term-text"
+	"github.com/awesome-gocui/gocui"
+
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/entity"
+)
+
+const identitySelectHeaderView = "identitySelectHeaderView"
+const identitySelectView = "identitySelectView"
+const identitySelectInstructionView = "identitySelectInstructionView"
+
+var identitySelectHelp = helpBar{
+	{"back", "Return"},
+	{"down up", "Nav"},
+	{"open", "Adopt"},
+	{"add", "New identity"},
+}
+
+// identitySelect list the local identities and allow to adopt one as the
+// author of the operations
+type identitySelect struct {
+	repo       *cache.RepoCache
+	identities []*cache.IdentityExcerpt
+	selected   int
+	childViews []string
+}
+
+func newIdentitySelect(repo *cache.RepoCache) *identitySelect {
+	return &identitySelect{repo: repo}
+}
+
+// load read the identities, and select the one of the user
+func (is *identitySelect) load() error {
+	is.identities = nil
+	for _, id := range is.repo.AllIdentityIds() {
+		excerpt, err := is.repo.ResolveIdentityExcerpt(id)
+		if err != nil {
+			return err
+		}
+		is.identities = append(is.identities, excerpt)
+	}
+
+	sort.Slice(is.identities, func(i, j int) bool {
+		return strings.ToLower(is.identities[i].DisplayName()) < strings.ToLower(is.identities[j].DisplayName())
+	})
+
+	is.selected = 0
+	user := is.userId()
+	for i, excerpt := range is.identities {
+		if excerpt.Id == user {
+			is.selected = i
+		}
+	}
+
+	return nil
+}
+
+// userId return the id of the identity of the user, if any
+func (is *identitySelect) userId() entity.Id {
+	set, err := is.repo.IsUserIdentitySet()
+	if err != nil || !set {
+		return ""
+	}
+
+	user, err := is.repo.GetUserIdentityExcerpt()
+	if err != nil {
+		return ""
+	}
+
+	return user.Id
+}
+
+func (is *identitySelect) keybindings(g *gocui.Gui) error {
+	// Return
+	if err := bindAction(g, identitySelectView, "back", is.back); err != nil {
+		return err
+	}
+	if err := bindAction(g, identitySelectView, "cancel", is.back); err != nil {
+		return err
+	}
+
+	// Navigation
+	if err := bindAction(g, identitySelectView, "up", is.selectPrevious); err != nil {
+		return err
+	}
+	if err := bindAction(g, identitySelectView, "down", is.selectNext); err != nil {
+		return err
+	}
+
+	// Adopt
+	if err := bindAction(g, identitySelectView, "open", is.adopt); err != nil {
+		return err
+	}
+
+	// New identity
+	if err := bindAction(g, identitySelectView, "add", is.create); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (is *identitySelect) layout(g *gocui.Gui) error {
+	maxX, maxY := g.Size()
+	is.childViews = nil
+
+	if maxY < 4 {
+		// window too small !
+		return nil
+	}
+
+	v, err := g.SetView(identitySelectHeaderView, -1, -1, maxX, 1, 0)
+	is.childViews = append(is.childViews, identitySelectHeaderView)
+
+	if err != nil {
+		if !gocui.IsUnknownView(err) {
+			return err
+		}
+
+		v.Frame = false
+	}
+
+	v.Clear()
+
+	user := is.userId()
+	if user == "" {
+		_, _ = fmt.Fprint(v, currentTheme.notice.Sprint(" No identity is set yet: adopt or create one to author the changes "))
+	} else {
+		excerpt, err := is.repo.ResolveIdentityExcerpt(user)
+		if err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintf(v, "The changes are authored by %s", currentTheme.author.Sprint(excerpt.DisplayName()))
+	}
+
+	v, err = g.SetView(identitySelectView, -1, 1, maxX, maxY-2, 0)
+	is.childViews = append(is.childViews, identitySelectView)
+
+	if err != nil {
+		if !gocui.IsUnknownView(err) {
+			return err
+		}
+
+		v.Frame = false
+		v.SelBgColor = currentTheme.selection.bgAttribute()
+		v.SelFgColor = currentTheme.selection.fgAttribute()
+	}
+
+	v.Clear()
+
+	width, _ := v.Size()
+	for _, excerpt := range is.identities {
+		marker := "  "
+		if excerpt.Id == user {
+			marker = currentTheme.marker.Sprint("● ")
+		}
+
+		line := fmt.Sprintf("%s%s %s", marker, currentTheme.id.Sprint(excerpt.Id.Human()), excerpt.DisplayName())
+		_, _ = fmt.Fprintln(v, text.LeftPadMaxLine(line, width, 0))
+	}
+
+	if len(is.identities) > 0 {
+		is.selected = minInt(is.selected, len(is.identities)-1)
+		_ = v.SetHighlight(is.selected, true)
+	}
+
+	v, err = g.SetView(identitySelectInstructionView, -1, maxY-2, maxX, maxY, 0)
+	is.childViews = append(is.childViews, identitySelectInstructionView)
+
+	if err != nil {
+		if !gocui.IsUnknownView(err) {
+			return err
+		}
+
+		v.Frame = false
+		v.FgColor = currentTheme.text.fgAttribute()
+	}
+
+	v.Clear()
+	_, _ = fmt.Fprint(v, identitySelectHelp.Render(maxX))
+
+	_, err = g.SetCurrentView(identitySelectView)
+	return err
+}
+
+func (is *identitySelect) disable(g *gocui.Gui) error {
+	for _, view := range is.childViews {
+		if err := g.DeleteView(view); err != nil && !gocui.IsUnknownView(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+func (is *identitySelect) selectPrevious(g *gocui.Gui, v *gocui.View) error {
+	is.selected = maxInt(is.selected-1, 0)
+	return nil
+}
+
+func (is *identitySelect) selectNext(g *gocui.Gui, v *gocui.View) error {
+	is.selected = minInt(is.selected+1, maxInt(len(is.identities)-1, 0))
+	return nil
+}
+
+// click select the identity under the mouse, or adopt it if it's already
+// selected
+func (is *identitySelect) click(g *gocui.Gui, v *gocui.View) error {
+	if v.Name() != identitySelectView {
+		return nil
+	}
+
+	_, y := v.Cursor()
+	if y < 0 || y >= len(is.identities) {
+		return nil
+	}
+
+	if y == is.selected {
+		return is.adopt(g, v)
+	}
+
+	is.selected = y
+	return nil
+}
+
+func (is *identitySelect) wheel(g *gocui.Gui, v *gocui.View, direction int) error {
+	if direction < 0 {
+		return is.selectPrevious(g, v)
+	}
+	return is.selectNext(g, v)
+}
+
+// adopt set the selected identity as the author of the operations, like
+// "git bug user adopt"
+func (is *identitySelect) adopt(g *gocui.Gui, v *gocui.View) error {
+	if len(is.identities) == 0 {
+		return nil
+	}
+
+	i, err := is.repo.ResolveIdentity(is.identities[is.selected].Id)
+	if err != nil {
+		return err
+	}
+
+	err = is.repo.SetUserIdentity(i)
+	if err != nil {
+		ui.msgPopup.Activate(msgPopupErrorTitle, err.Error())
+	}
+
+	return nil
+}
+
+// create ask for a name and an email to create a new identity, adopted if
+// none is set yet, like "git bug user create"
+func (is *identitySelect) create(g *gocui.Gui, v *gocui.View) error {
+	preName, err := is.repo.GetUserName()
+	if err != nil {
+		return err
+	}
+	preEmail, err := is.repo.GetUserEmail()
+	if err != nil {
+		return err
+	}
+
+	c := ui.inputPopup.ActivateWithContent("Name", preName)
+
+	go func() {
+		name := strings.TrimSpace(<-c)
+
+		g.Update(func(gui *gocui.Gui) error {
+			if name == "" {
+				ui.msgPopup.Activate(msgPopupErrorTitle, "A name is required.")
+				return nil
+			}
+
+			c := ui.inputPopup.ActivateWithContent("Email", preEmail)
+
+			go func() {
+				email := strings.TrimSpace(<-c)
+
+				g.Update(func(gui *gocui.Gui) error {
+					return is.createIdentity(name, email)
+				})
+			}()
+
+			return nil
+		})
+	}()
+
+	return nil
+}
+
+func (is *identitySelect) createIdentity(name string, email string) error {
+	if email == "" {
+		ui.msgPopup.Activate(msgPopupErrorTitle, "An email is required.")
+		return nil
+	}
+
+	i, err := is.repo.NewIdentity(name, email)
+	if err != nil {
+		ui.msgPopup.Activate(msgPopupErrorTitle, err.Error())
+		return nil
+	}
+
+	err = i.CommitAsNeeded()
+	if err != nil {
+		return err
+	}
+
+	if is.userId() == "" {
+		err = is.repo.SetUserIdentity(i)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = is.load()
+	if err != nil {
+		return err
+	}
+	for j, excerpt := range is.identities {
+		if excerpt.Id == i.Id() {
+			is.selected = j
+		}
+	}
+
+	return nil
+}
+
+// back return to the bug table, once an identity is set
+func (is *identitySelect) back(g *gocui.Gui, v *gocui.View) error {
+	if is.userId() == "" {
+		ui.msgPopup.Activate(msgPopupErrorTitle, "An identity is required to author the changes: adopt or create one first.")
+		return nil
+	}
+
+	return ui.activateWindow(ui.bugTable)
+}
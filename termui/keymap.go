@@ -0,0 +1,350 @@
+package termui
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/awesome-gocui/gocui"
+
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+// the keymap is configured with the name of a built-in keymap, or the path
+// of a JSON keymap file:
+// git-bug.termui.keymap = vim
+const keymapConfigKey = "git-bug.termui.keymap"
+
+const defaultKeymapName = "default"
+
+// keymapSpec associate each action of the UI to its keys. A key is a
+// character, a named key like "enter", "esc", "tab", "space", "up", "pgdn",
+// or a combination like "ctrl-n" or "alt-v".
+//
+// In a keymap file, the "base" entry name the built-in keymap providing the
+// actions not given.
+type keymapSpec map[string][]string
+
+// keymapActions are the actions that can be bound, in every view
+var keymapActions = []string{
+	// navigation
+	"up", "down", "left", "right", "page-up", "page-down",
+	"open", "back", "cancel", "quit",
+	// bug table
+	"new-bug", "new-from-template", "boards", "split", "identity", "pull", "push",
+	"search", "filter", "next-view",
+	// bug view
+	"comment", "reply", "edit", "minimize", "redact", "pin", "toggle-status",
+	"title", "toggle-markdown", "open-remote", "pull-remote",
+	// board view
+	"move-left", "move-right", "next-board",
+	// label selection
+	"toggle", "add",
+}
+
+var defaultKeymap = keymapSpec{
+	"up":                {"up", "k"},
+	"down":              {"down", "j"},
+	"left":              {"left", "h"},
+	"right":             {"right", "l"},
+	"page-up":           {"pgup"},
+	"page-down":         {"pgdn"},
+	"open":              {"enter"},
+	"back":              {"q"},
+	"cancel":            {"esc"},
+	"quit":              {"q"},
+	"new-bug":           {"n"},
+	"new-from-template": {"N"},
+	"boards":            {"b"},
+	"split":             {"|"},
+	"identity":          {"u"},
+	"pull":              {"i"},
+	"push":              {"o"},
+	"search":            {"s"},
+	"filter":            {"/"},
+	"next-view":         {"v"},
+	"comment":           {"c"},
+	"reply":             {"r"},
+	"edit":              {"e"},
+	"minimize":          {"m"},
+	"redact":            {"d"},
+	"pin":               {"p"},
+	"toggle-status":     {"o"},
+	"title":             {"t"},
+	"toggle-markdown":   {"M"},
+	"open-remote":       {"w"},
+	"pull-remote":       {"i"},
+	"move-left":         {"H", "<"},
+	"move-right":        {"L", ">"},
+	"next-board":        {"b"},
+	"toggle":            {"space", "x", "enter"},
+	"add":               {"a"},
+}
+
+var builtinKeymaps = map[string]keymapSpec{
+	defaultKeymapName: defaultKeymap,
+	"vim": defaultKeymap.with(keymapSpec{
+		"page-up":   {"pgup", "ctrl-b", "ctrl-u"},
+		"page-down": {"pgdn", "ctrl-f", "ctrl-d"},
+		"back":      {"q", "ctrl-o"},
+		"cancel":    {"esc"},
+	}),
+	"emacs": defaultKeymap.with(keymapSpec{
+		"up":        {"up", "ctrl-p"},
+		"down":      {"down", "ctrl-n"},
+		"left":      {"left", "ctrl-b"},
+		"right":     {"right", "ctrl-f"},
+		"page-up":   {"pgup", "alt-v"},
+		"page-down": {"pgdn", "ctrl-v"},
+		"back":      {"q"},
+		"cancel":    {"ctrl-g", "esc"},
+		"filter":    {"ctrl-s", "/"},
+		"toggle":    {"space", "enter"},
+	}),
+}
+
+// binding is a key of a keymap, usable with gocui
+type binding struct {
+	key  interface{}
+	mod  gocui.Modifier
+	name string
+}
+
+type keymap map[string][]binding
+
+// currentKeymap is the keymap used to bind the actions of the UI
+var currentKeymap = mustCompileKeymap(defaultKeymap)
+
+// loadKeymap read the keymap configured for the repository, the default one
+// if none is
+func loadKeymap(config repository.ConfigRead) (keymap, error) {
+	name, err := config.ReadString(keymapConfigKey)
+	if err == repository.ErrNoConfigEntry {
+		return compileKeymap(defaultKeymap)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	spec, err := readKeymapSpec(name)
+	if err != nil {
+		return nil, err
+	}
+
+	km, err := compileKeymap(spec)
+	if err != nil {
+		return nil, fmt.Errorf("keymap %s: %v", name, err)
+	}
+	return km, nil
+}
+
+// readKeymapSpec return a built-in keymap, or read a keymap file
+func readKeymapSpec(name string) (keymapSpec, error) {
+	if spec, ok := builtinKeymaps[name]; ok {
+		return spec, nil
+	}
+
+	data, err := ioutil.ReadFile(name)
+	if err != nil {
+		names := make([]string, 0, len(builtinKeymaps))
+		for n := range builtinKeymaps {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		return nil, fmt.Errorf("unknown keymap %s, expected one of %s or a keymap file",
+			name, strings.Join(names, ", "))
+	}
+
+	var raw map[string]interface{}
+	err = json.Unmarshal(data, &raw)
+	if err != nil {
+		return nil, fmt.Errorf("keymap file %s: %v", name, err)
+	}
+
+	baseName := defaultKeymapName
+	spec := make(keymapSpec)
+	for action, value := range raw {
+		if action == "base" {
+			str, ok := value.(string)
+			if !ok {
+				return nil, fmt.Errorf("keymap file %s: the base should be a keymap name", name)
+			}
+			baseName = str
+			continue
+		}
+
+		keys, ok := value.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("keymap file %s: the keys of %s should be a list", name, action)
+		}
+		for _, key := range keys {
+			str, ok := key.(string)
+			if !ok {
+				return nil, fmt.Errorf("keymap file %s: the keys of %s should be strings", name, action)
+			}
+			spec[action] = append(spec[action], str)
+		}
+	}
+
+	base, ok := builtinKeymaps[baseName]
+	if !ok {
+		return nil, fmt.Errorf("keymap file %s: unknown base keymap %s", name, baseName)
+	}
+
+	return base.with(spec), nil
+}
+
+// with return the keymap with the keys of some actions replaced
+func (ks keymapSpec) with(overrides keymapSpec) keymapSpec {
+	result := make(keymapSpec, len(ks))
+	for action, keys := range ks {
+		result[action] = keys
+	}
+	for action, keys := range overrides {
+		result[action] = keys
+	}
+	return result
+}
+
+func compileKeymap(spec keymapSpec) (keymap, error) {
+	known := make(map[string]bool, len(keymapActions))
+	for _, action := range keymapActions {
+		known[action] = true
+	}
+
+	km := make(keymap, len(spec))
+	for action, keys := range spec {
+		if !known[action] {
+			return nil, fmt.Errorf("unknown action %s", action)
+		}
+		for _, key := range keys {
+			b, err := parseBinding(key)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %v", action, err)
+			}
+			km[action] = append(km[action], b)
+		}
+	}
+
+	return km, nil
+}
+
+func mustCompileKeymap(spec keymapSpec) keymap {
+	km, err := compileKeymap(spec)
+	if err != nil {
+		panic(err)
+	}
+	return km
+}
+
+var namedKeys = map[string]struct {
+	key  gocui.Key
+	name string
+}{
+	"enter":     {gocui.KeyEnter, "↵"},
+	"esc":       {gocui.KeyEsc, "esc"},
+	"tab":       {gocui.KeyTab, "tab"},
+	"space":     {gocui.KeySpace, "space"},
+	"backspace": {gocui.KeyBackspace2, "backspace"},
+	"delete":    {gocui.KeyDelete, "del"},
+	"up":        {gocui.KeyArrowUp, "↑"},
+	"down":      {gocui.KeyArrowDown, "↓"},
+	"left":      {gocui.KeyArrowLeft, "←"},
+	"right":     {gocui.KeyArrowRight, "→"},
+	"pgup":      {gocui.KeyPgup, "pgup"},
+	"pgdn":      {gocui.KeyPgdn, "pgdn"},
+	"home":      {gocui.KeyHome, "home"},
+	"end":       {gocui.KeyEnd, "end"},
+}
+
+func parseBinding(key string) (binding, error) {
+	if named, ok := namedKeys[key]; ok {
+		return binding{key: named.key, mod: gocui.ModNone, name: named.name}, nil
+	}
+
+	if utf8.RuneCountInString(key) == 1 {
+		r, _ := utf8.DecodeRuneInString(key)
+		return binding{key: r, mod: gocui.ModNone, name: key}, nil
+	}
+
+	if strings.HasPrefix(key, "ctrl-") && len(key) == len("ctrl-")+1 {
+		c := key[len(key)-1]
+		if c >= 'a' && c <= 'z' {
+			// the control keys follow the alphabet, from ctrl-a = 0x01
+			return binding{key: gocui.Key(c - 'a' + 1), mod: gocui.ModNone, name: "^" + string(c)}, nil
+		}
+	}
+
+	if strings.HasPrefix(key, "alt-") && utf8.RuneCountInString(key) == len("alt-")+1 {
+		r, _ := utf8.DecodeRuneInString(key[len("alt-"):])
+		return binding{key: r, mod: gocui.ModAlt, name: "M-" + string(r)}, nil
+	}
+
+	return binding{}, fmt.Errorf("unknown key \"%s\"", key)
+}
+
+// boundActions hold the handlers of the actions bound on each view, to run
+// them without their keys
+var boundActions = make(map[string]map[string]func(*gocui.Gui, *gocui.View) error)
+
+// bindAction bind the keys of an action on a view
+func bindAction(g *gocui.Gui, view string, action string, handler func(*gocui.Gui, *gocui.View) error) error {
+	if boundActions[view] == nil {
+		boundActions[view] = make(map[string]func(*gocui.Gui, *gocui.View) error)
+	}
+	if _, ok := boundActions[view][action]; !ok {
+		boundActions[view][action] = handler
+	}
+
+	for _, b := range currentKeymap[action] {
+		if err := g.SetKeybinding(view, b.key, b.mod, handler); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// runAction run the handler of an action bound on a view, as if its key was
+// pressed
+func runAction(g *gocui.Gui, view string, action string) error {
+	handler, ok := boundActions[view][action]
+	if !ok {
+		return nil
+	}
+
+	v, err := g.View(view)
+	if err != nil {
+		return err
+	}
+
+	return handler(g, v)
+}
+
+// describeKeys render the keys of some actions for the help bars, like
+// "←↓↑→,hjkl" for the navigation
+func describeKeys(actions ...string) string {
+	var groups []string
+	for i := 0; ; i++ {
+		var names []string
+		single := true
+		for _, action := range actions {
+			bindings := currentKeymap[action]
+			if i < len(bindings) {
+				names = append(names, bindings[i].name)
+				single = single && utf8.RuneCountInString(bindings[i].name) == 1
+			}
+		}
+		if len(names) == 0 {
+			break
+		}
+		if single {
+			groups = append(groups, strings.Join(names, ""))
+		} else {
+			groups = append(groups, strings.Join(names, "/"))
+		}
+	}
+	return strings.Join(groups, ",")
+}
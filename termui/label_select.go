@@ -14,9 +14,9 @@ const labelSelectView = "labelSelectView"
 const labelSelectInstructionsView = "labelSelectInstructionsView"
 
 var labelSelectHelp = helpBar{
-	{"q", "Save and close"},
-	{"↓↑,jk", "Nav"},
-	{"a", "Add item"},
+	{"back", "Save and close"},
+	{"down up", "Nav"},
+	{"add", "Add item"},
 }
 
 type labelSelect struct {
@@ -62,41 +62,35 @@ func (ls *labelSelect) SetBug(cache *cache.RepoCache, bug *cache.BugCache) {
 
 func (ls *labelSelect) keybindings(g *gocui.Gui) error {
 	// Abort
-	if err := g.SetKeybinding(labelSelectView, gocui.KeyEsc, gocui.ModNone, ls.abort); err != nil {
+	if err := bindAction(g, labelSelectView, "cancel", ls.abort); err != nil {
 		return err
 	}
+
 	// Save and return
-	if err := g.SetKeybinding(labelSelectView, 'q', gocui.ModNone, ls.saveAndReturn); err != nil {
+	if err := bindAction(g, labelSelectView, "back", ls.saveAndReturn); err != nil {
 		return err
 	}
+
 	// Up
-	if err := g.SetKeybinding(labelSelectView, gocui.KeyArrowUp, gocui.ModNone, ls.selectPrevious); err != nil {
-		return err
-	}
-	if err := g.SetKeybinding(labelSelectView, 'k', gocui.ModNone, ls.selectPrevious); err != nil {
+	if err := bindAction(g, labelSelectView, "up", ls.selectPrevious); err != nil {
 		return err
 	}
+
 	// Down
-	if err := g.SetKeybinding(labelSelectView, gocui.KeyArrowDown, gocui.ModNone, ls.selectNext); err != nil {
-		return err
-	}
-	if err := g.SetKeybinding(labelSelectView, 'j', gocui.ModNone, ls.selectNext); err != nil {
+	if err := bindAction(g, labelSelectView, "down", ls.selectNext); err != nil {
 		return err
 	}
+
 	// Select
-	if err := g.SetKeybinding(labelSelectView, gocui.KeySpace, gocui.ModNone, ls.selectItem); err != nil {
-		return err
-	}
-	if err := g.SetKeybinding(labelSelectView, 'x', gocui.ModNone, ls.selectItem); err != nil {
-		return err
-	}
-	if err := g.SetKeybinding(labelSelectView, gocui.KeyEnter, gocui.ModNone, ls.selectItem); err != nil {
+	if err := bindAction(g, labelSelectView, "toggle", ls.selectItem); err != nil {
 		return err
 	}
+
 	// Add
-	if err := g.SetKeybinding(labelSelectView, 'a', gocui.ModNone, ls.addItem); err != nil {
+	if err := bindAction(g, labelSelectView, "add", ls.addItem); err != nil {
 		return err
 	}
+
 	return nil
 }
 
@@ -150,7 +144,7 @@ func (ls *labelSelect) layout(g *gocui.Gui) error {
 			return err
 		}
 		v.Frame = false
-		v.FgColor = gocui.ColorWhite
+		v.FgColor = currentTheme.text.fgAttribute()
 	}
 	v.Clear()
 	_, _ = fmt.Fprint(v, labelSelectHelp.Render(maxX))
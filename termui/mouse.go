@@ -0,0 +1,103 @@
+package termui
+
+import (
+	"github.com/awesome-gocui/gocui"
+
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+// the mouse is enabled by default, but can be disabled to keep the text
+// selection of the terminal:
+// git-bug.termui.mouse = false
+const mouseConfigKey = "git-bug.termui.mouse"
+
+// wheelLines is the number of lines scrolled by a step of the mouse wheel
+const wheelLines = 3
+
+// mouseEnabled tell if the UI react to the mouse
+var mouseEnabled = true
+
+// mouseWindow is implemented by the windows reacting to the mouse, besides
+// the clicks on their help bar
+type mouseWindow interface {
+	// click handle a click of the left button on a view of the window
+	click(g *gocui.Gui, v *gocui.View) error
+	// wheel handle a step of the mouse wheel on a view of the window,
+	// -1 upward and 1 downward
+	wheel(g *gocui.Gui, v *gocui.View, direction int) error
+}
+
+// helpBarViews associate the views showing a help bar to the view where
+// the actions of the bar are bound
+var helpBarViews = map[string]struct {
+	bar  helpBar
+	view string
+}{
+	bugTableInstructionView:       {bugTableHelp, bugTableView},
+	showBugInstructionView:        {showBugHelp, showBugView},
+	boardInstructionView:          {boardViewHelp, boardView},
+	labelSelectInstructionsView:   {labelSelectHelp, labelSelectView},
+	identitySelectInstructionView: {identitySelectHelp, identitySelectView},
+}
+
+// loadMouse read if the mouse is enabled for the repository
+func loadMouse(config repository.ConfigRead) (bool, error) {
+	enabled, err := config.ReadBool(mouseConfigKey)
+	if err == repository.ErrNoConfigEntry {
+		return true, nil
+	}
+	return enabled, err
+}
+
+func mouseKeybindings(g *gocui.Gui) error {
+	if err := g.SetKeybinding("", gocui.MouseLeft, gocui.ModNone, mouseClick); err != nil {
+		return err
+	}
+
+	if err := g.SetKeybinding("", gocui.MouseWheelUp, gocui.ModNone, mouseWheel(-1)); err != nil {
+		return err
+	}
+
+	if err := g.SetKeybinding("", gocui.MouseWheelDown, gocui.ModNone, mouseWheel(1)); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// mouseClick run the action of a help bar entry, or let the active window
+// handle the click
+func mouseClick(g *gocui.Gui, v *gocui.View) error {
+	if v == nil || ui.msgPopup.active || ui.inputPopup.active {
+		return nil
+	}
+
+	if hb, ok := helpBarViews[v.Name()]; ok {
+		x, _ := v.Cursor()
+		action := hb.bar.actionAt(x)
+		if action == "" {
+			return nil
+		}
+		return runAction(g, hb.view, action)
+	}
+
+	if mw, ok := ui.activeWindow.(mouseWindow); ok {
+		return mw.click(g, v)
+	}
+
+	return nil
+}
+
+func mouseWheel(direction int) func(g *gocui.Gui, v *gocui.View) error {
+	return func(g *gocui.Gui, v *gocui.View) error {
+		if v == nil || ui.msgPopup.active || ui.inputPopup.active {
+			return nil
+		}
+
+		if mw, ok := ui.activeWindow.(mouseWindow); ok {
+			return mw.wheel(g, v, direction)
+		}
+
+		return nil
+	}
+}
@@ -2,16 +2,21 @@ package termui
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"strings"
 
 	"github.com/MichaelMure/go-term-text"
 	"github.com/awesome-gocui/gocui"
+	"github.com/skratchdot/open-golang/open"
 
+	"github.com/MichaelMure/git-bug/bridge"
+	"github.com/MichaelMure/git-bug/bridge/core"
 	"github.com/MichaelMure/git-bug/bug"
 	"github.com/MichaelMure/git-bug/cache"
 	"github.com/MichaelMure/git-bug/entity"
-	"github.com/MichaelMure/git-bug/util/colors"
+	"github.com/MichaelMure/git-bug/identity"
+	"github.com/MichaelMure/git-bug/util/markdown"
 )
 
 const showBugView = "showBugView"
@@ -22,12 +27,19 @@ const showBugHeaderView = "showBugHeaderView"
 const timeLayout = "Jan 2 2006"
 
 var showBugHelp = helpBar{
-	{"q", "Save and return"},
-	{"←↓↑→,hjkl", "Navigation"},
-	{"o", "Toggle open/close"},
-	{"e", "Edit"},
-	{"c", "Comment"},
-	{"t", "Change title"},
+	{"back", "Save and return"},
+	{"left down up right", "Navigation"},
+	{"toggle-status", "Toggle open/close"},
+	{"edit", "Edit"},
+	{"comment", "Comment"},
+	{"reply", "Reply"},
+	{"minimize", "Minimize"},
+	{"redact", "Redact"},
+	{"pin", "Pin"},
+	{"title", "Change title"},
+	{"toggle-markdown", "Toggle markdown"},
+	{"open-remote", "Open remote"},
+	{"pull-remote", "Pull remote"},
 }
 
 type showBug struct {
@@ -39,6 +51,8 @@ type showBug struct {
 	selected           string
 	isOnSide           bool
 	scroll             int
+	// display the messages as written, without rendering the markdown
+	raw bool
 }
 
 func newShowBug(cache *cache.RepoCache) *showBug {
@@ -101,7 +115,7 @@ func (sb *showBug) layout(g *gocui.Gui) error {
 
 		sb.childViews = append(sb.childViews, showBugInstructionView)
 		v.Frame = false
-		v.FgColor = gocui.ColorWhite
+		v.FgColor = currentTheme.text.fgAttribute()
 	}
 
 	v.Clear()
@@ -118,79 +132,87 @@ func (sb *showBug) layout(g *gocui.Gui) error {
 
 func (sb *showBug) keybindings(g *gocui.Gui) error {
 	// Return
-	if err := g.SetKeybinding(showBugView, 'q', gocui.ModNone, sb.saveAndBack); err != nil {
+	if err := bindAction(g, showBugView, "back", sb.saveAndBack); err != nil {
 		return err
 	}
 
 	// Scrolling
-	if err := g.SetKeybinding(showBugView, gocui.KeyPgup, gocui.ModNone,
-		sb.scrollUp); err != nil {
+	if err := bindAction(g, showBugView, "page-up", sb.scrollUp); err != nil {
 		return err
 	}
-	if err := g.SetKeybinding(showBugView, gocui.KeyPgdn, gocui.ModNone,
-		sb.scrollDown); err != nil {
+	if err := bindAction(g, showBugView, "page-down", sb.scrollDown); err != nil {
 		return err
 	}
 
 	// Down
-	if err := g.SetKeybinding(showBugView, 'j', gocui.ModNone,
-		sb.selectNext); err != nil {
+	if err := bindAction(g, showBugView, "down", sb.selectNext); err != nil {
 		return err
 	}
-	if err := g.SetKeybinding(showBugView, gocui.KeyArrowDown, gocui.ModNone,
-		sb.selectNext); err != nil {
+
+	// Up
+	if err := bindAction(g, showBugView, "up", sb.selectPrevious); err != nil {
 		return err
 	}
-	// Up
-	if err := g.SetKeybinding(showBugView, 'k', gocui.ModNone,
-		sb.selectPrevious); err != nil {
+
+	// Left
+	if err := bindAction(g, showBugView, "left", sb.left); err != nil {
 		return err
 	}
-	if err := g.SetKeybinding(showBugView, gocui.KeyArrowUp, gocui.ModNone,
-		sb.selectPrevious); err != nil {
+
+	// Right
+	if err := bindAction(g, showBugView, "right", sb.right); err != nil {
 		return err
 	}
 
-	// Left
-	if err := g.SetKeybinding(showBugView, 'h', gocui.ModNone,
-		sb.left); err != nil {
+	// Comment
+	if err := bindAction(g, showBugView, "comment", sb.comment); err != nil {
 		return err
 	}
-	if err := g.SetKeybinding(showBugView, gocui.KeyArrowLeft, gocui.ModNone,
-		sb.left); err != nil {
+
+	// Reply
+	if err := bindAction(g, showBugView, "reply", sb.reply); err != nil {
 		return err
 	}
-	// Right
-	if err := g.SetKeybinding(showBugView, 'l', gocui.ModNone,
-		sb.right); err != nil {
+
+	// Minimize
+	if err := bindAction(g, showBugView, "minimize", sb.toggleMinimize); err != nil {
 		return err
 	}
-	if err := g.SetKeybinding(showBugView, gocui.KeyArrowRight, gocui.ModNone,
-		sb.right); err != nil {
+
+	// Pin
+	if err := bindAction(g, showBugView, "redact", sb.redact); err != nil {
 		return err
 	}
 
-	// Comment
-	if err := g.SetKeybinding(showBugView, 'c', gocui.ModNone,
-		sb.comment); err != nil {
+	if err := bindAction(g, showBugView, "pin", sb.togglePin); err != nil {
 		return err
 	}
 
 	// Open/close
-	if err := g.SetKeybinding(showBugView, 'o', gocui.ModNone,
-		sb.toggleOpenClose); err != nil {
+	if err := bindAction(g, showBugView, "toggle-status", sb.toggleOpenClose); err != nil {
 		return err
 	}
 
 	// Title
-	if err := g.SetKeybinding(showBugView, 't', gocui.ModNone,
-		sb.setTitle); err != nil {
+	if err := bindAction(g, showBugView, "title", sb.setTitle); err != nil {
+		return err
+	}
+
+	// Markdown
+	if err := bindAction(g, showBugView, "toggle-markdown", sb.toggleMarkdown); err != nil {
 		return err
 	}
 
 	// Edit
-	if err := g.SetKeybinding(showBugView, 'e', gocui.ModNone,
-		sb.edit); err != nil {
+	if err := bindAction(g, showBugView, "edit", sb.edit); err != nil {
+		return err
+	}
+
+	// Remote bug
+	if err := bindAction(g, showBugView, "open-remote", sb.openRemote); err != nil {
+		return err
+	}
+	if err := bindAction(g, showBugView, "pull-remote", sb.pullRemote); err != nil {
 		return err
 	}
 
@@ -224,10 +246,10 @@ func (sb *showBug) renderMain(g *gocui.Gui, mainView *gocui.View) error {
 	}
 
 	bugHeader := fmt.Sprintf("[%s] %s\n\n[%s] %s opened this bug on %s%s",
-		colors.Cyan(snap.Id().Human()),
-		colors.Bold(snap.Title),
-		colors.Yellow(snap.Status),
-		colors.Magenta(snap.Author.DisplayName()),
+		currentTheme.id.Sprint(snap.Id().Human()),
+		currentTheme.emphasis.Sprint(snap.Title),
+		currentTheme.status.Sprint(snap.DisplayStatus()),
+		currentTheme.author.Sprint(authorsDisplayName(snap.Author, snap.Comments[0].CoAuthors)),
 		snap.CreateTime.Format(timeLayout),
 		edited,
 	)
@@ -241,7 +263,12 @@ func (sb *showBug) renderMain(g *gocui.Gui, mainView *gocui.View) error {
 	_, _ = fmt.Fprint(v, bugHeader)
 	y0 += lines + 1
 
-	for _, op := range snap.Timeline {
+	depths := make(map[entity.Id]int)
+	for _, comment := range snap.Threads() {
+		depths[comment.Id()] = comment.Depth
+	}
+
+	for _, op := range pinnedFirst(snap.Timeline) {
 		viewName := op.Id().String()
 
 		// TODO: me might skip the rendering of blocks that are outside of the view
@@ -253,10 +280,12 @@ func (sb *showBug) renderMain(g *gocui.Gui, mainView *gocui.View) error {
 			var content string
 			var lines int
 
-			if op.MessageIsEmpty() {
+			if op.Redacted() {
+				content, lines = text.WrapLeftPadded(redactedPlaceholder(op.Redaction), maxX-1, 4)
+			} else if op.MessageIsEmpty() {
 				content, lines = text.WrapLeftPadded(emptyMessagePlaceholder(), maxX-1, 4)
 			} else {
-				content, lines = text.WrapLeftPadded(op.Message, maxX-1, 4)
+				content, lines = sb.renderMessage(op.Message, maxX-1, 4)
 			}
 
 			v, err := sb.createOpView(g, viewName, x0, y0, maxX+1, lines, true)
@@ -272,15 +301,35 @@ func (sb *showBug) renderMain(g *gocui.Gui, mainView *gocui.View) error {
 				edited = " (edited)"
 			}
 
+			// replies are indented according to their depth in the thread
+			pad := 4 + 4*depths[op.Id()]
+
+			if op.Pinned {
+				edited += " (pinned)"
+			}
+
 			var message string
-			if op.MessageIsEmpty() {
-				message, _ = text.WrapLeftPadded(emptyMessagePlaceholder(), maxX-1, 4)
+			if op.Redacted() {
+				message, _ = text.WrapLeftPadded(redactedPlaceholder(op.Redaction), maxX-1, pad)
+			} else if op.IsMinimized() {
+				message, _ = text.WrapLeftPadded(minimizedPlaceholder(op.Minimized), maxX-1, pad)
+			} else if op.MessageIsEmpty() {
+				message, _ = text.WrapLeftPadded(emptyMessagePlaceholder(), maxX-1, pad)
 			} else {
-				message, _ = text.WrapLeftPadded(op.Message, maxX-1, 4)
+				message, _ = sb.renderMessage(op.Message, maxX-1, pad)
 			}
 
-			content := fmt.Sprintf("%s commented on %s%s\n\n%s",
-				colors.Magenta(op.Author.DisplayName()),
+			action := "commented"
+			if op.ReplyTo != "" {
+				action = "replied"
+				if target, err := snap.SearchComment(op.ReplyTo); err == nil {
+					action = fmt.Sprintf("replied to %s", target.Author.DisplayName())
+				}
+			}
+
+			content := fmt.Sprintf("%s %s on %s%s\n\n%s",
+				currentTheme.author.Sprint(authorsDisplayName(op.Author, op.CoAuthors)),
+				action,
 				op.CreatedAt.Time().Format(timeLayout),
 				edited,
 				message,
@@ -296,8 +345,8 @@ func (sb *showBug) renderMain(g *gocui.Gui, mainView *gocui.View) error {
 
 		case *bug.SetTitleTimelineItem:
 			content := fmt.Sprintf("%s changed the title to %s on %s",
-				colors.Magenta(op.Author.DisplayName()),
-				colors.Bold(op.Title),
+				currentTheme.author.Sprint(op.Author.DisplayName()),
+				currentTheme.emphasis.Sprint(op.Title),
 				op.UnixTime.Time().Format(timeLayout),
 			)
 			content, lines := text.Wrap(content, maxX)
@@ -311,10 +360,17 @@ func (sb *showBug) renderMain(g *gocui.Gui, mainView *gocui.View) error {
 
 		case *bug.SetStatusTimelineItem:
 			content := fmt.Sprintf("%s %s the bug on %s",
-				colors.Magenta(op.Author.DisplayName()),
-				colors.Bold(op.Status.Action()),
+				currentTheme.author.Sprint(op.Author.DisplayName()),
+				currentTheme.emphasis.Sprint(op.Status.Action()),
 				op.UnixTime.Time().Format(timeLayout),
 			)
+			if op.Extended != "" {
+				content = fmt.Sprintf("%s set the status to %s on %s",
+					currentTheme.author.Sprint(op.Author.DisplayName()),
+					currentTheme.emphasis.Sprint(op.Extended),
+					op.UnixTime.Time().Format(timeLayout),
+				)
+			}
 			content, lines := text.Wrap(content, maxX)
 
 			v, err := sb.createOpView(g, viewName, x0, y0, maxX+1, lines, true)
@@ -327,12 +383,12 @@ func (sb *showBug) renderMain(g *gocui.Gui, mainView *gocui.View) error {
 		case *bug.LabelChangeTimelineItem:
 			var added []string
 			for _, label := range op.Added {
-				added = append(added, colors.Bold("\""+label+"\""))
+				added = append(added, currentTheme.emphasis.Sprint("\""+label+"\""))
 			}
 
 			var removed []string
 			for _, label := range op.Removed {
-				removed = append(removed, colors.Bold("\""+label+"\""))
+				removed = append(removed, currentTheme.emphasis.Sprint("\""+label+"\""))
 			}
 
 			var action bytes.Buffer
@@ -358,7 +414,7 @@ func (sb *showBug) renderMain(g *gocui.Gui, mainView *gocui.View) error {
 			}
 
 			content := fmt.Sprintf("%s %s on %s",
-				colors.Magenta(op.Author.DisplayName()),
+				currentTheme.author.Sprint(op.Author.DisplayName()),
 				action.String(),
 				op.UnixTime.Time().Format(timeLayout),
 			)
@@ -376,9 +432,67 @@ func (sb *showBug) renderMain(g *gocui.Gui, mainView *gocui.View) error {
 	return nil
 }
 
+// renderMessage wrap a message to the width, rendering its markdown unless
+// the raw mode is on, and return the number of lines
+func (sb *showBug) renderMessage(message string, maxX int, pad int) (string, int) {
+	if sb.raw {
+		return text.WrapLeftPadded(message, maxX, pad)
+	}
+
+	content := markdown.Render(message, maxX, pad)
+	return content, strings.Count(content, "\n") + 1
+}
+
 // emptyMessagePlaceholder return a formatted placeholder for an empty message
 func emptyMessagePlaceholder() string {
-	return colors.BlackBold(colors.WhiteBg("No description provided."))
+	return currentTheme.notice.Sprint("No description provided.")
+}
+
+// authorsDisplayName join the name of an author and its co-authors
+func authorsDisplayName(author identity.Interface, coAuthors []identity.Interface) string {
+	if len(coAuthors) == 0 {
+		return author.DisplayName()
+	}
+
+	names := make([]string, len(coAuthors))
+	for i, coAuthor := range coAuthors {
+		names[i] = coAuthor.DisplayName()
+	}
+
+	return fmt.Sprintf("%s with %s", author.DisplayName(), strings.Join(names, ", "))
+}
+
+func redactedPlaceholder(redaction *bug.Redaction) string {
+	return currentTheme.notice.Sprint(
+		fmt.Sprintf("Redacted by %s: %s", redaction.Author.DisplayName(), redaction.Reason),
+	)
+}
+
+func minimizedPlaceholder(reason bug.MinimizeReason) string {
+	return currentTheme.notice.Sprint(
+		fmt.Sprintf("Minimized as %s", reason),
+	)
+}
+
+// pinnedFirst reorder the timeline to have the pinned comments just after
+// the bug creation
+func pinnedFirst(timeline []bug.TimelineItem) []bug.TimelineItem {
+	result := make([]bug.TimelineItem, 0, len(timeline))
+	var others []bug.TimelineItem
+
+	for i, item := range timeline {
+		comment, ok := item.(*bug.AddCommentTimelineItem)
+		switch {
+		case i == 0:
+			result = append(result, item)
+		case ok && comment.Pinned:
+			result = append(result, item)
+		default:
+			others = append(others, item)
+		}
+	}
+
+	return append(result, others...)
 }
 
 func (sb *showBug) createOpView(g *gocui.Gui, name string, x0 int, y0 int, maxX int, height int, selectable bool) (*gocui.View, error) {
@@ -437,7 +551,7 @@ func (sb *showBug) renderSidebar(g *gocui.Gui, sideView *gocui.View) error {
 	labels := strings.Join(labelStr, "\n")
 	labels, lines := text.WrapLeftPadded(labels, maxX, 2)
 
-	content := fmt.Sprintf("%s\n\n%s", colors.Bold("  Labels"), labels)
+	content := fmt.Sprintf("%s\n\n%s", currentTheme.emphasis.Sprint("  Labels"), labels)
 
 	v, err := sb.createSideView(g, "sideLabels", x0, y0, maxX, lines+2)
 	if err != nil {
@@ -446,6 +560,29 @@ func (sb *showBug) renderSidebar(g *gocui.Gui, sideView *gocui.View) error {
 
 	_, _ = fmt.Fprint(v, content)
 
+	// the bug in the remote bug-tracker, for the bugs coming from a bridge
+	target, remoteId, url, ok := bridge.RemoteBug(snap.Operations[0].AllMetadata())
+	if !ok {
+		return nil
+	}
+
+	y0 += lines + 4
+
+	remote := fmt.Sprintf("%s %s", target, currentTheme.id.Sprint(remoteId))
+	if url != "" {
+		remote += "\n" + url
+	}
+	remote, lines = text.WrapLeftPadded(remote, maxX, 2)
+
+	content = fmt.Sprintf("%s\n\n%s", currentTheme.emphasis.Sprint("  Remote"), remote)
+
+	v, err = sb.createSideView(g, "sideRemote", x0, y0, maxX, lines+2)
+	if err != nil {
+		return err
+	}
+
+	_, _ = fmt.Fprint(v, content)
+
 	return nil
 }
 
@@ -479,25 +616,77 @@ func (sb *showBug) scrollUp(g *gocui.Gui, v *gocui.View) error {
 func (sb *showBug) scrollDown(g *gocui.Gui, v *gocui.View) error {
 	_, maxY := v.Size()
 
+	maxScroll, err := sb.maxScroll(g, maxY)
+	if err != nil {
+		return err
+	}
+
+	sb.scroll += maxY / 2
+
+	sb.scroll = minInt(sb.scroll, maxScroll)
+
+	return nil
+}
+
+// maxScroll return the scroll showing the end of the timeline at the bottom
+// of the main view
+func (sb *showBug) maxScroll(g *gocui.Gui, maxY int) (int, error) {
 	lastViewName := sb.mainSelectableView[len(sb.mainSelectableView)-1]
 
 	lastView, err := g.View(lastViewName)
 	if err != nil {
-		return err
+		return 0, err
 	}
 
 	_, vMaxY := lastView.Size()
 
 	_, vy0, _, _, err := g.ViewPosition(lastViewName)
+	if err != nil {
+		return 0, err
+	}
+
+	return vy0 + sb.scroll + vMaxY - maxY, nil
+}
+
+// wheel scroll the timeline by a few lines
+func (sb *showBug) wheel(g *gocui.Gui, v *gocui.View, direction int) error {
+	mainView, err := g.View(showBugView)
 	if err != nil {
 		return err
 	}
 
-	maxScroll := vy0 + sb.scroll + vMaxY - maxY
+	_, maxY := mainView.Size()
 
-	sb.scroll += maxY / 2
+	maxScroll, err := sb.maxScroll(g, maxY)
+	if err != nil {
+		return err
+	}
+
+	sb.scroll += direction * wheelLines
 
 	sb.scroll = minInt(sb.scroll, maxScroll)
+	sb.scroll = maxInt(sb.scroll, 0)
+
+	return nil
+}
+
+// click select the item of the timeline or of the sidebar under the mouse
+func (sb *showBug) click(g *gocui.Gui, v *gocui.View) error {
+	for _, name := range sb.mainSelectableView {
+		if name == v.Name() {
+			sb.isOnSide = false
+			sb.selected = name
+			return sb.focusView(g)
+		}
+	}
+
+	for _, name := range sb.sideSelectableView {
+		if name == v.Name() {
+			sb.isOnSide = true
+			sb.selected = name
+			return sb.focusView(g)
+		}
+	}
 
 	return nil
 }
@@ -617,10 +806,202 @@ func (sb *showBug) comment(g *gocui.Gui, v *gocui.View) error {
 	return addCommentWithEditor(sb.bug)
 }
 
+func (sb *showBug) reply(g *gocui.Gui, v *gocui.View) error {
+	if sb.isOnSide || sb.selected == "" {
+		return nil
+	}
+
+	snap := sb.bug.Snapshot()
+
+	target, err := snap.SearchComment(entity.Id(sb.selected))
+	if err != nil {
+		ui.msgPopup.Activate(msgPopupErrorTitle, "Selected item is not a comment.")
+		return nil
+	}
+
+	return replyWithEditor(sb.bug, target.Id())
+}
+
+func (sb *showBug) toggleMinimize(g *gocui.Gui, v *gocui.View) error {
+	if sb.isOnSide || sb.selected == "" {
+		return nil
+	}
+
+	target, err := sb.bug.Snapshot().SearchComment(entity.Id(sb.selected))
+	if err != nil {
+		ui.msgPopup.Activate(msgPopupErrorTitle, "Selected item is not a comment.")
+		return nil
+	}
+
+	if target.Minimized != "" {
+		_, err = sb.bug.UnminimizeComment(target.Id())
+	} else {
+		_, err = sb.bug.MinimizeComment(target.Id(), bug.MinimizeResolved)
+	}
+	return err
+}
+
+// redact remove the content of the selected comment, asking for the reason
+func (sb *showBug) redact(g *gocui.Gui, v *gocui.View) error {
+	if sb.isOnSide || sb.selected == "" {
+		return nil
+	}
+
+	target, err := sb.bug.Snapshot().SearchComment(entity.Id(sb.selected))
+	if err != nil {
+		ui.msgPopup.Activate(msgPopupErrorTitle, "Selected item is not a comment.")
+		return nil
+	}
+
+	if target.Redaction != nil {
+		ui.msgPopup.Activate(msgPopupErrorTitle, "This comment is already redacted.")
+		return nil
+	}
+
+	c := ui.inputPopup.Activate("Reason of the redaction")
+
+	go func() {
+		reason := strings.TrimSpace(<-c)
+
+		g.Update(func(gui *gocui.Gui) error {
+			if reason == "" {
+				ui.msgPopup.Activate(msgPopupErrorTitle, "A reason is required.")
+				return nil
+			}
+
+			_, err := sb.bug.RedactComment(target.Id(), reason)
+			if err != nil {
+				ui.msgPopup.Activate(msgPopupErrorTitle, err.Error())
+			}
+			return nil
+		})
+	}()
+
+	return nil
+}
+
+func (sb *showBug) togglePin(g *gocui.Gui, v *gocui.View) error {
+	if sb.isOnSide || sb.selected == "" {
+		return nil
+	}
+
+	target, err := sb.bug.Snapshot().SearchComment(entity.Id(sb.selected))
+	if err != nil {
+		ui.msgPopup.Activate(msgPopupErrorTitle, "Selected item is not a comment.")
+		return nil
+	}
+
+	if target.Pinned {
+		_, err = sb.bug.UnpinComment(target.Id())
+	} else {
+		_, err = sb.bug.PinComment(target.Id())
+	}
+	return err
+}
+
 func (sb *showBug) setTitle(g *gocui.Gui, v *gocui.View) error {
 	return setTitleWithEditor(sb.bug)
 }
 
+// openRemote open the bug of the remote bug-tracker in the browser
+func (sb *showBug) openRemote(g *gocui.Gui, v *gocui.View) error {
+	_, _, url, ok := bridge.RemoteBug(sb.bug.Snapshot().Operations[0].AllMetadata())
+	if !ok || url == "" {
+		ui.msgPopup.Activate(msgPopupErrorTitle, "No known remote URL for this bug.")
+		return nil
+	}
+
+	if err := open.Run(url); err != nil {
+		ui.msgPopup.Activate(msgPopupErrorTitle, err.Error())
+	}
+	return nil
+}
+
+// pullRemote update the bug from its remote bug-tracker, with an incremental
+// import of the bridge it comes from, as the bridges can't import a single
+// bug
+func (sb *showBug) pullRemote(g *gocui.Gui, v *gocui.View) error {
+	target, remoteId, _, ok := bridge.RemoteBug(sb.bug.Snapshot().Operations[0].AllMetadata())
+	if !ok {
+		ui.msgPopup.Activate(msgPopupErrorTitle, "This bug doesn't come from a bridge.")
+		return nil
+	}
+
+	b, err := sb.findBridge(target)
+	if err != nil {
+		ui.msgPopup.Activate(msgPopupErrorTitle, err.Error())
+		return nil
+	}
+
+	ui.msgPopup.Activate(fmt.Sprintf("Pull %s from bridge %s", remoteId, b.Name), "...")
+
+	before := len(sb.bug.Snapshot().Operations)
+
+	go func() {
+		events, err := b.ImportAll(context.Background())
+		if err != nil {
+			g.Update(func(gui *gocui.Gui) error {
+				ui.msgPopup.Activate(msgPopupErrorTitle, err.Error())
+				return nil
+			})
+			return
+		}
+
+		var buffer bytes.Buffer
+		for result := range events {
+			if result.Event == core.ImportEventError {
+				_, _ = fmt.Fprintln(&buffer, result.String())
+			}
+		}
+
+		_, _ = fmt.Fprintf(&buffer, "%d new operation(s) on this bug",
+			len(sb.bug.Snapshot().Operations)-before)
+
+		g.Update(func(gui *gocui.Gui) error {
+			ui.msgPopup.UpdateMessage(buffer.String())
+			return nil
+		})
+	}()
+
+	return nil
+}
+
+// findBridge return the configured bridge of a target, if there is exactly
+// one
+func (sb *showBug) findBridge(target string) (*core.Bridge, error) {
+	names, err := bridge.ConfiguredBridges(sb.cache)
+	if err != nil {
+		return nil, err
+	}
+
+	var found *core.Bridge
+	for _, name := range names {
+		b, err := bridge.LoadBridge(sb.cache, name)
+		if err != nil {
+			return nil, err
+		}
+		if b.Target() != target {
+			continue
+		}
+		if found != nil {
+			return nil, fmt.Errorf("multiple %s bridges are configured, use \"git bug bridge pull\"", target)
+		}
+		found = b
+	}
+
+	if found == nil {
+		return nil, fmt.Errorf("no %s bridge configured", target)
+	}
+	return found, nil
+}
+
+// toggleMarkdown switch between the rendered markdown of the messages and
+// the raw text
+func (sb *showBug) toggleMarkdown(g *gocui.Gui, v *gocui.View) error {
+	sb.raw = !sb.raw
+	return nil
+}
+
 func (sb *showBug) toggleOpenClose(g *gocui.Gui, v *gocui.View) error {
 	switch sb.bug.Snapshot().Status {
 	case bug.OpenStatus:
@@ -652,8 +1033,16 @@ func (sb *showBug) edit(g *gocui.Gui, v *gocui.View) error {
 
 	switch op := op.(type) {
 	case *bug.AddCommentTimelineItem:
+		if op.Redacted() {
+			ui.msgPopup.Activate(msgPopupErrorTitle, "A redacted comment can't be edited.")
+			return nil
+		}
 		return editCommentWithEditor(sb.bug, op.Id(), op.Message)
 	case *bug.CreateTimelineItem:
+		if op.Redacted() {
+			ui.msgPopup.Activate(msgPopupErrorTitle, "A redacted comment can't be edited.")
+			return nil
+		}
 		return editCommentWithEditor(sb.bug, op.Id(), op.Message)
 	case *bug.LabelChangeTimelineItem:
 		return sb.editLabels(g, snap)
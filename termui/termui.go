@@ -7,10 +7,10 @@ import (
 	"github.com/awesome-gocui/gocui"
 	"github.com/pkg/errors"
 
+	"github.com/MichaelMure/git-bug/bug"
 	"github.com/MichaelMure/git-bug/cache"
 	"github.com/MichaelMure/git-bug/entity"
 	"github.com/MichaelMure/git-bug/input"
-	"github.com/MichaelMure/git-bug/query"
 )
 
 var errTerminateMainloop = errors.New("terminate gocui mainloop")
@@ -24,7 +24,9 @@ type termUI struct {
 
 	bugTable    *bugTable
 	showBug     *showBug
+	boardViewer *boardViewer
 	labelSelect *labelSelect
+	identities  *identitySelect
 	msgPopup    *msgPopup
 	inputPopup  *inputPopup
 }
@@ -49,21 +51,66 @@ type window interface {
 
 // Run will launch the termUI in the terminal
 func Run(cache *cache.RepoCache) error {
+	t, err := loadTheme(cache.AnyConfig())
+	if err != nil {
+		return err
+	}
+	currentTheme = t
+
+	km, err := loadKeymap(cache.AnyConfig())
+	if err != nil {
+		return err
+	}
+	currentKeymap = km
+
+	mouseEnabled, err = loadMouse(cache.AnyConfig())
+	if err != nil {
+		return err
+	}
+
 	ui = &termUI{
 		gError:      make(chan error, 1),
 		cache:       cache,
 		bugTable:    newBugTable(cache),
 		showBug:     newShowBug(cache),
+		boardViewer: newBoardViewer(cache),
 		labelSelect: newLabelSelect(),
+		identities:  newIdentitySelect(cache),
 		msgPopup:    newMsgPopup(),
 		inputPopup:  newInputPopup(),
 	}
 
 	ui.activeWindow = ui.bugTable
 
+	ui.bugTable.split, err = loadSplitLayout(cache.AnyConfig())
+	if err != nil {
+		return err
+	}
+
+	// without identity, one has to be adopted or created before anything else
+	set, err := cache.IsUserIdentitySet()
+	if err != nil {
+		return err
+	}
+	if !set {
+		if err := ui.identities.load(); err != nil {
+			return err
+		}
+		ui.activeWindow = ui.identities
+	}
+
+	// refresh the views when the data change, including from another process
+	err = cache.Watch(0)
+	if err != nil {
+		return err
+	}
+	changes, unsubscribe := cache.Changes()
+	defer unsubscribe()
+	go refreshOnChange(changes)
+
 	initGui(nil)
 
-	err := <-ui.gError
+	err = <-ui.gError
 
 	type errorStack interface {
 		ErrorStack() string
@@ -79,6 +126,21 @@ func Run(cache *cache.RepoCache) error {
 	return nil
 }
 
+// refreshOnChange trigger a new layout of the views when the cache change,
+// which read again the data to display
+func refreshOnChange(changes <-chan cache.ChangeEvent) {
+	for range changes {
+		g := ui.g
+		if g == nil {
+			// the gui is suspended, it will be laid out again when restored
+			continue
+		}
+		g.Update(func(g *gocui.Gui) error {
+			return nil
+		})
+	}
+}
+
 func initGui(action func(ui *termUI) error) {
 	g, err := gocui.NewGui(gocui.Output256, false)
 
@@ -93,6 +155,8 @@ func initGui(action func(ui *termUI) error) {
 
 	ui.g.InputEsc = true
 
+	ui.g.Mouse = mouseEnabled
+
 	err = keybindings(ui.g)
 
 	if err != nil {
@@ -154,10 +218,18 @@ func keybindings(g *gocui.Gui) error {
 		return err
 	}
 
+	if err := ui.boardViewer.keybindings(g); err != nil {
+		return err
+	}
+
 	if err := ui.labelSelect.keybindings(g); err != nil {
 		return err
 	}
 
+	if err := ui.identities.keybindings(g); err != nil {
+		return err
+	}
+
 	if err := ui.msgPopup.keybindings(g); err != nil {
 		return err
 	}
@@ -166,6 +238,10 @@ func keybindings(g *gocui.Gui) error {
 		return err
 	}
 
+	if err := mouseKeybindings(g); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -173,7 +249,7 @@ func quit(g *gocui.Gui, v *gocui.View) error {
 	return gocui.ErrQuit
 }
 
-func newBugWithEditor(repo *cache.RepoCache) error {
+func newBugWithEditor(repo *cache.RepoCache, tmpl *bug.Template) error {
 	// This is somewhat hacky.
 	// As there is no way to pause gocui, run the editor and restart gocui,
 	// we have to stop it entirely and start a new one later.
@@ -186,7 +262,12 @@ func newBugWithEditor(repo *cache.RepoCache) error {
 	ui.g.Close()
 	ui.g = nil
 
-	title, message, err := input.BugCreateEditorInput(ui.cache, "", "")
+	var preTitle, preMessage string
+	if tmpl != nil {
+		preTitle, preMessage = tmpl.Title, tmpl.Message
+	}
+
+	title, message, err := input.BugCreateEditorInput(ui.cache, preTitle, preMessage)
 
 	if err != nil && err != input.ErrEmptyTitle {
 		return err
@@ -199,7 +280,11 @@ func newBugWithEditor(repo *cache.RepoCache) error {
 
 		return errTerminateMainloop
 	} else {
-		b, _, err = repo.NewBug(title, message)
+		if tmpl != nil {
+			b, _, err = repo.NewBugFromTemplate(tmpl, title, message)
+		} else {
+			b, _, err = repo.NewBug(title, message)
+		}
 		if err != nil {
 			return err
 		}
@@ -246,6 +331,39 @@ func addCommentWithEditor(bug *cache.BugCache) error {
 	return errTerminateMainloop
 }
 
+func replyWithEditor(bug *cache.BugCache, target entity.Id) error {
+	// This is somewhat hacky.
+	// As there is no way to pause gocui, run the editor and restart gocui,
+	// we have to stop it entirely and start a new one later.
+	//
+	// - an error channel is used to route the returned error of this new
+	// 		instance into the original launch function
+	// - a custom error (errTerminateMainloop) is used to terminate the original
+	//		instance's mainLoop. This error is then filtered.
+
+	ui.g.Close()
+	ui.g = nil
+
+	message, err := input.BugCommentEditorInput(ui.cache, "")
+
+	if err != nil && err != input.ErrEmptyMessage {
+		return err
+	}
+
+	if err == input.ErrEmptyMessage {
+		ui.msgPopup.Activate(msgPopupErrorTitle, "Empty message, aborting.")
+	} else {
+		_, err := bug.AddReply(target, message)
+		if err != nil {
+			return err
+		}
+	}
+
+	initGui(nil)
+
+	return errTerminateMainloop
+}
+
 func editCommentWithEditor(bug *cache.BugCache, target entity.Id, preMessage string) error {
 	// This is somewhat hacky.
 	// As there is no way to pause gocui, run the editor and restart gocui,
@@ -339,7 +457,7 @@ func editQueryWithEditor(bt *bugTable) error {
 
 	bt.queryStr = queryStr
 
-	q, err := query.Parse(queryStr)
+	q, err := bt.parseQuery(queryStr)
 
 	if err != nil {
 		ui.msgPopup.Activate(msgPopupErrorTitle, err.Error())
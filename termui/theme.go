@@ -0,0 +1,346 @@
+package termui
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/awesome-gocui/gocui"
+	"github.com/fatih/color"
+
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+// the theme is configured with the name of a built-in theme, or the path of
+// a JSON theme file:
+// git-bug.termui.theme = light
+const themeConfigKey = "git-bug.termui.theme"
+
+const defaultThemeName = "dark"
+
+// themeSpec describe the style of each element of the UI, as a foreground
+// color and text attributes, followed by "on" and a background color, like
+// "black bold on white". A color is one of the 8 ANSI colors, "default" or an
+// index in the 256 colors palette.
+//
+// In a theme file, the base theme provide the styles not given.
+type themeSpec struct {
+	Base string `json:"base,omitempty"`
+
+	// the bug ids
+	Id string `json:"id,omitempty"`
+	// the bug status
+	Status string `json:"status,omitempty"`
+	// the author names
+	Author string `json:"author,omitempty"`
+	// the bug titles and changed values in the timeline
+	Emphasis string `json:"emphasis,omitempty"`
+	// the placeholders of empty, redacted or minimized messages
+	Notice string `json:"notice,omitempty"`
+	// the entries of the help bars
+	Help string `json:"help,omitempty"`
+	// the selected line of a list
+	Selection string `json:"selection,omitempty"`
+	// the text of the instruction lines and of the popups
+	Text string `json:"text,omitempty"`
+	// the marker of the selected bug in the bug picker
+	Marker string `json:"marker,omitempty"`
+}
+
+var builtinThemes = map[string]themeSpec{
+	"dark": {
+		Id:        "cyan",
+		Status:    "yellow",
+		Author:    "magenta",
+		Emphasis:  "bold",
+		Notice:    "black bold on white",
+		Help:      "white on blue",
+		Selection: "black on white",
+		Text:      "white",
+		Marker:    "yellow bold",
+	},
+	"light": {
+		Id:        "blue",
+		Status:    "green",
+		Author:    "magenta",
+		Emphasis:  "bold",
+		Notice:    "white bold on black",
+		Help:      "white on blue",
+		Selection: "white on black",
+		Text:      "black",
+		Marker:    "blue bold",
+	},
+	"solarized": {
+		Id:        "33",
+		Status:    "136",
+		Author:    "125",
+		Emphasis:  "bold",
+		Notice:    "245 bold on 235",
+		Help:      "230 on 33",
+		Selection: "230 on 240",
+		Text:      "244",
+		Marker:    "166 bold",
+	},
+}
+
+type theme struct {
+	id        style
+	status    style
+	author    style
+	emphasis  style
+	notice    style
+	help      style
+	selection style
+	text      style
+	marker    style
+}
+
+// currentTheme is the theme used to render the UI
+var currentTheme = mustCompileTheme(builtinThemes[defaultThemeName])
+
+// loadTheme read the theme configured for the repository, the default one
+// if none is
+func loadTheme(config repository.ConfigRead) (theme, error) {
+	name, err := config.ReadString(themeConfigKey)
+	if err == repository.ErrNoConfigEntry {
+		return compileTheme(builtinThemes[defaultThemeName])
+	}
+	if err != nil {
+		return theme{}, err
+	}
+
+	spec, err := readThemeSpec(name)
+	if err != nil {
+		return theme{}, err
+	}
+
+	t, err := compileTheme(spec)
+	if err != nil {
+		return theme{}, fmt.Errorf("theme %s: %v", name, err)
+	}
+	return t, nil
+}
+
+// readThemeSpec return a built-in theme, or read a theme file
+func readThemeSpec(name string) (themeSpec, error) {
+	if spec, ok := builtinThemes[name]; ok {
+		return spec, nil
+	}
+
+	data, err := ioutil.ReadFile(name)
+	if err != nil {
+		names := make([]string, 0, len(builtinThemes))
+		for n := range builtinThemes {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		return themeSpec{}, fmt.Errorf("unknown theme %s, expected one of %s or a theme file",
+			name, strings.Join(names, ", "))
+	}
+
+	var spec themeSpec
+	err = json.Unmarshal(data, &spec)
+	if err != nil {
+		return themeSpec{}, fmt.Errorf("theme file %s: %v", name, err)
+	}
+
+	if spec.Base == "" {
+		spec.Base = defaultThemeName
+	}
+	base, ok := builtinThemes[spec.Base]
+	if !ok {
+		return themeSpec{}, fmt.Errorf("theme file %s: unknown base theme %s", name, spec.Base)
+	}
+
+	return spec.withDefaults(base), nil
+}
+
+// withDefaults fill the missing styles from another theme
+func (ts themeSpec) withDefaults(base themeSpec) themeSpec {
+	pick := func(s, fallback string) string {
+		if s == "" {
+			return fallback
+		}
+		return s
+	}
+
+	return themeSpec{
+		Id:        pick(ts.Id, base.Id),
+		Status:    pick(ts.Status, base.Status),
+		Author:    pick(ts.Author, base.Author),
+		Emphasis:  pick(ts.Emphasis, base.Emphasis),
+		Notice:    pick(ts.Notice, base.Notice),
+		Help:      pick(ts.Help, base.Help),
+		Selection: pick(ts.Selection, base.Selection),
+		Text:      pick(ts.Text, base.Text),
+		Marker:    pick(ts.Marker, base.Marker),
+	}
+}
+
+func compileTheme(spec themeSpec) (theme, error) {
+	var t theme
+	styles := []struct {
+		name   string
+		spec   string
+		target *style
+	}{
+		{"id", spec.Id, &t.id},
+		{"status", spec.Status, &t.status},
+		{"author", spec.Author, &t.author},
+		{"emphasis", spec.Emphasis, &t.emphasis},
+		{"notice", spec.Notice, &t.notice},
+		{"help", spec.Help, &t.help},
+		{"selection", spec.Selection, &t.selection},
+		{"text", spec.Text, &t.text},
+		{"marker", spec.Marker, &t.marker},
+	}
+
+	for _, s := range styles {
+		parsed, err := parseStyle(s.spec)
+		if err != nil {
+			return theme{}, fmt.Errorf("%s: %v", s.name, err)
+		}
+		*s.target = parsed
+	}
+
+	return t, nil
+}
+
+func mustCompileTheme(spec themeSpec) theme {
+	t, err := compileTheme(spec)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+var ansiColors = map[string]int{
+	"black":   0,
+	"red":     1,
+	"green":   2,
+	"yellow":  3,
+	"blue":    4,
+	"magenta": 5,
+	"cyan":    6,
+	"white":   7,
+}
+
+var styleAttributes = map[string]color.Attribute{
+	"bold":      color.Bold,
+	"faint":     color.Faint,
+	"italic":    color.Italic,
+	"underline": color.Underline,
+	"reverse":   color.ReverseVideo,
+}
+
+// style is a parsed style of a theme, usable in the rendered text as well as
+// for the colors of the views
+type style struct {
+	// index of the colors in the 256 colors palette, -1 for the default one
+	fg, bg int
+	bold   bool
+	color  *color.Color
+}
+
+func parseStyle(spec string) (style, error) {
+	s := style{fg: -1, bg: -1}
+	var attributes []color.Attribute
+
+	words := strings.Fields(spec)
+	fgDone := false
+	for i := 0; i < len(words); i++ {
+		word := words[i]
+
+		if word == "on" {
+			if i+1 >= len(words) {
+				return style{}, fmt.Errorf("missing background color in \"%s\"", spec)
+			}
+			i++
+			bg, err := parseColor(words[i])
+			if err != nil {
+				return style{}, err
+			}
+			s.bg = bg
+			continue
+		}
+
+		if attr, ok := styleAttributes[word]; ok {
+			attributes = append(attributes, attr)
+			s.bold = s.bold || attr == color.Bold
+			continue
+		}
+
+		if fgDone {
+			return style{}, fmt.Errorf("unexpected \"%s\" in \"%s\"", word, spec)
+		}
+		fg, err := parseColor(word)
+		if err != nil {
+			return style{}, err
+		}
+		s.fg = fg
+		fgDone = true
+	}
+
+	switch {
+	case s.fg < 0:
+	case s.fg < 8:
+		attributes = append(attributes, color.FgBlack+color.Attribute(s.fg))
+	default:
+		attributes = append(attributes, 38, 5, color.Attribute(s.fg))
+	}
+
+	switch {
+	case s.bg < 0:
+	case s.bg < 8:
+		attributes = append(attributes, color.BgBlack+color.Attribute(s.bg))
+	default:
+		attributes = append(attributes, 48, 5, color.Attribute(s.bg))
+	}
+
+	s.color = color.New(attributes...)
+
+	return s, nil
+}
+
+func parseColor(word string) (int, error) {
+	if word == "default" {
+		return -1, nil
+	}
+	if c, ok := ansiColors[word]; ok {
+		return c, nil
+	}
+	c, err := strconv.Atoi(word)
+	if err != nil || c < 0 || c > 255 {
+		return 0, fmt.Errorf("unknown color \"%s\"", word)
+	}
+	return c, nil
+}
+
+// Sprint render the values with the style
+func (s style) Sprint(a ...interface{}) string {
+	return s.color.Sprint(a...)
+}
+
+// fgAttribute return the foreground of the style, for a view
+func (s style) fgAttribute() gocui.Attribute {
+	attr := gocui.ColorDefault
+	if s.fg >= 0 {
+		// the palette is shifted by one for the default color
+		attr = gocui.Attribute(s.fg + 1)
+	}
+	if s.bold {
+		attr |= gocui.AttrBold
+	}
+	return attr
+}
+
+// bgAttribute return the background of the style, for a view
+func (s style) bgAttribute() gocui.Attribute {
+	if s.bg < 0 {
+		return gocui.ColorDefault
+	}
+	return gocui.Attribute(s.bg + 1)
+}
@@ -6,6 +6,7 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"sync"
 )
 
 var ErrClockNotExist = errors.New("clock doesn't exist")
@@ -13,6 +14,9 @@ var ErrClockNotExist = errors.New("clock doesn't exist")
 type PersistedClock struct {
 	*MemClock
 	filePath string
+
+	// protect the file against concurrent writes
+	mu sync.Mutex
 }
 
 // NewPersistedClock create a new persisted Lamport clock
@@ -95,6 +99,9 @@ func (pc *PersistedClock) read() error {
 }
 
 func (pc *PersistedClock) Write() error {
-	data := []byte(fmt.Sprintf("%d", pc.counter))
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+
+	data := []byte(fmt.Sprintf("%d", pc.Time()))
 	return ioutil.WriteFile(pc.filePath, data, 0644)
 }
@@ -0,0 +1,125 @@
+package markdown
+
+import (
+	"strings"
+	"unicode"
+)
+
+// lineComments are the line comment markers of the languages of the
+// code blocks
+var lineComments = map[string][]string{
+	"go":         {"//"},
+	"c":          {"//"},
+	"cpp":        {"//"},
+	"c++":        {"//"},
+	"java":       {"//"},
+	"javascript": {"//"},
+	"js":         {"//"},
+	"typescript": {"//"},
+	"ts":         {"//"},
+	"rust":       {"//"},
+	"kotlin":     {"//"},
+	"swift":      {"//"},
+	"graphql":    {"#"},
+	"python":     {"#"},
+	"py":         {"#"},
+	"ruby":       {"#"},
+	"rb":         {"#"},
+	"sh":         {"#"},
+	"bash":       {"#"},
+	"shell":      {"#"},
+	"zsh":        {"#"},
+	"yaml":       {"#"},
+	"yml":        {"#"},
+	"toml":       {"#"},
+	"sql":        {"--"},
+	"lua":        {"--"},
+}
+
+// keywords are the common keywords of the languages above, highlighted
+// whatever the language
+var keywords = map[string]bool{
+	"break": true, "case": true, "catch": true, "class": true, "const": true,
+	"continue": true, "def": true, "default": true, "defer": true, "do": true,
+	"elif": true, "else": true, "enum": true, "export": true, "extends": true,
+	"false": true, "fn": true, "for": true, "from": true, "func": true,
+	"function": true, "go": true, "if": true, "impl": true, "import": true,
+	"in": true, "interface": true, "let": true, "match": true, "mut": true,
+	"new": true, "nil": true, "none": true, "null": true, "package": true,
+	"pub": true, "range": true, "return": true, "select": true, "self": true,
+	"static": true, "struct": true, "switch": true, "this": true, "throw": true,
+	"true": true, "try": true, "type": true, "use": true, "var": true,
+	"while": true, "with": true, "yield": true,
+}
+
+// highlight color a line of a code block. Only the languages with known
+// comment markers are highlighted, the others are displayed plainly.
+func highlight(line string, lang string) string {
+	comments, ok := lineComments[lang]
+	if !ok {
+		return plainCodeStyle(line)
+	}
+
+	var result strings.Builder
+	runes := []rune(line)
+
+	for i := 0; i < len(runes); {
+		rest := string(runes[i:])
+		r := runes[i]
+
+		switch {
+		case hasAnyPrefix(rest, comments):
+			result.WriteString(commentStyle(rest))
+			return result.String()
+
+		case r == '"' || r == '\'' || r == '`':
+			end := i + 1
+			for end < len(runes) && runes[end] != r {
+				if runes[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			if end >= len(runes) {
+				end = len(runes) - 1
+			}
+			result.WriteString(stringStyle(string(runes[i : end+1])))
+			i = end + 1
+
+		case unicode.IsDigit(r):
+			end := i
+			for end < len(runes) && (unicode.IsDigit(runes[end]) || runes[end] == '.' || runes[end] == '_' || unicode.IsLetter(runes[end])) {
+				end++
+			}
+			result.WriteString(numberStyle(string(runes[i:end])))
+			i = end
+
+		case unicode.IsLetter(r) || r == '_':
+			end := i
+			for end < len(runes) && (unicode.IsLetter(runes[end]) || unicode.IsDigit(runes[end]) || runes[end] == '_') {
+				end++
+			}
+			word := string(runes[i:end])
+			if keywords[strings.ToLower(word)] {
+				word = keywordStyle(word)
+			}
+			result.WriteString(word)
+			i = end
+
+		default:
+			result.WriteRune(r)
+			i++
+		}
+	}
+
+	return result.String()
+}
+
+func hasAnyPrefix(s string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(s, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,344 @@
+// Package markdown render the markdown of the bugs for a terminal, with
+// colors and wrapped paragraphs.
+//
+// This is not a complete CommonMark implementation, but handle the subset
+// commonly found in issues: headings, paragraphs, lists, quotes, fenced code
+// blocks and the inline emphasis, code and links.
+package markdown
+
+import (
+	"regexp"
+	"strings"
+
+	text "github.com/MichaelMure/go-term-text"
+	"github.com/fatih/color"
+)
+
+var (
+	headingStyle   = color.New(color.FgYellow, color.Bold).SprintFunc()
+	boldStyle      = color.New(color.Bold).SprintFunc()
+	italicStyle    = color.New(color.Italic).SprintFunc()
+	strikeStyle    = color.New(color.CrossedOut).SprintFunc()
+	codeStyle      = color.New(color.FgCyan).SprintFunc()
+	linkStyle      = color.New(color.Underline).SprintFunc()
+	urlStyle       = color.New(color.FgBlue).SprintFunc()
+	quoteStyle     = color.New(color.FgHiBlack).SprintFunc()
+	markerStyle    = color.New(color.FgMagenta).SprintFunc()
+	keywordStyle   = color.New(color.FgBlue, color.Bold).SprintFunc()
+	stringStyle    = color.New(color.FgGreen).SprintFunc()
+	numberStyle    = color.New(color.FgMagenta).SprintFunc()
+	commentStyle   = color.New(color.FgHiBlack).SprintFunc()
+	plainCodeStyle = color.New(color.FgYellow).SprintFunc()
+	ruleStyle      = color.New(color.FgHiBlack).SprintFunc()
+	checkedStyle   = color.New(color.FgGreen).SprintFunc()
+	uncheckedStyle = color.New(color.FgHiBlack).SprintFunc()
+	tableLineStyle = color.New(color.FgHiBlack).SprintFunc()
+	imageAltStyle  = color.New(color.Italic).SprintFunc()
+)
+
+var (
+	headingRegexp   = regexp.MustCompile(`^\s{0,3}(#{1,6})\s+(.*?)\s*#*\s*$`)
+	ruleRegexp      = regexp.MustCompile(`^\s{0,3}((\*\s*){3,}|(-\s*){3,}|(_\s*){3,})$`)
+	fenceRegexp     = regexp.MustCompile("^\\s*(```+|~~~+)\\s*([\\w+#-]*)")
+	quoteRegexp     = regexp.MustCompile(`^\s{0,3}>\s?(.*)$`)
+	listItemRegexp  = regexp.MustCompile(`^(\s*)([-*+]|\d{1,9}[.)])\s+(.*)$`)
+	taskRegexp      = regexp.MustCompile(`^\[([ xX])\]\s+(.*)$`)
+	codeSpanRegexp  = regexp.MustCompile("`+([^`]+)`+")
+	imageRegexp     = regexp.MustCompile(`!\[([^\]]*)\]\(([^)\s]+)[^)]*\)`)
+	linkRegexp      = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)[^)]*\)`)
+	boldRegexp      = regexp.MustCompile(`\*\*([^*]+)\*\*|__([^_]+)__`)
+	italicRegexp    = regexp.MustCompile(`\*([^*\s][^*]*)\*|(?:^|\s)_([^_\s][^_]*)_(?:$|\s|[.,;:!?)])`)
+	strikeRegexp    = regexp.MustCompile(`~~([^~]+)~~`)
+	hardBreakRegexp = regexp.MustCompile(`( {2,}|\\)$`)
+)
+
+// the indentation of a nested list item
+const listIndentLength = 2
+
+// Render transform a markdown text into a text decorated with ANSI escape
+// sequences, wrapped to lineWidth and left padded by leftPad spaces.
+func Render(source string, lineWidth int, leftPad int) string {
+	r := &renderer{lineWidth: lineWidth, leftPad: leftPad}
+	r.render(strings.Split(strings.Replace(source, "\r\n", "\n", -1), "\n"))
+	return strings.TrimRight(r.out.String(), "\n")
+}
+
+type renderer struct {
+	lineWidth int
+	leftPad   int
+	out       strings.Builder
+}
+
+func (r *renderer) render(lines []string) {
+	for i := 0; i < len(lines); {
+		line := lines[i]
+
+		switch {
+		case strings.TrimSpace(line) == "":
+			i++
+
+		case fenceRegexp.MatchString(line):
+			i = r.codeBlock(lines, i)
+
+		case headingRegexp.MatchString(line):
+			match := headingRegexp.FindStringSubmatch(line)
+			r.heading(len(match[1]), match[2])
+			i++
+
+		case ruleRegexp.MatchString(line):
+			r.rule()
+			i++
+
+		case quoteRegexp.MatchString(line):
+			i = r.quote(lines, i)
+
+		case listItemRegexp.MatchString(line):
+			i = r.list(lines, i)
+
+		case strings.HasPrefix(strings.TrimSpace(line), "|"):
+			i = r.table(lines, i)
+
+		default:
+			i = r.paragraph(lines, i)
+		}
+	}
+}
+
+// block write a rendered block, separated from the previous one by an
+// empty line
+func (r *renderer) block(content string) {
+	if r.out.Len() > 0 {
+		r.out.WriteString("\n")
+	}
+	r.out.WriteString(content)
+	r.out.WriteString("\n")
+}
+
+func (r *renderer) pad(n int) string {
+	return strings.Repeat(" ", r.leftPad+n)
+}
+
+func (r *renderer) heading(level int, title string) {
+	prefix := strings.Repeat("#", level) + " "
+	wrapped, _ := text.WrapLeftPadded(headingStyle(prefix+inline(title)), r.lineWidth, r.leftPad)
+	r.block(wrapped)
+}
+
+func (r *renderer) rule() {
+	width := r.lineWidth - r.leftPad
+	if width < 3 {
+		width = 3
+	}
+	r.block(r.pad(0) + ruleStyle(strings.Repeat("─", width)))
+}
+
+func (r *renderer) paragraph(lines []string, i int) int {
+	var content []string
+	for ; i < len(lines); i++ {
+		line := lines[i]
+		if strings.TrimSpace(line) == "" || startsBlock(line) {
+			break
+		}
+		content = append(content, line)
+	}
+
+	r.block(r.wrap(content, r.leftPad))
+	return i
+}
+
+// wrap join the lines of a paragraph, keep its hard line breaks and wrap it
+func (r *renderer) wrap(lines []string, pad int) string {
+	var paragraphs []string
+	var current []string
+	for _, line := range lines {
+		hardBreak := hardBreakRegexp.MatchString(line)
+		current = append(current, strings.TrimSpace(strings.TrimSuffix(line, `\`)))
+		if hardBreak {
+			paragraphs = append(paragraphs, strings.Join(current, " "))
+			current = nil
+		}
+	}
+	if len(current) > 0 {
+		paragraphs = append(paragraphs, strings.Join(current, " "))
+	}
+
+	wrapped := make([]string, len(paragraphs))
+	for i, p := range paragraphs {
+		wrapped[i], _ = text.WrapLeftPadded(inline(p), r.lineWidth, pad)
+	}
+	return strings.Join(wrapped, "\n")
+}
+
+func (r *renderer) quote(lines []string, i int) int {
+	var content []string
+	for ; i < len(lines); i++ {
+		match := quoteRegexp.FindStringSubmatch(lines[i])
+		if match == nil {
+			break
+		}
+		content = append(content, match[1])
+	}
+
+	// quotes can hold any markdown
+	inner := &renderer{lineWidth: r.lineWidth - r.leftPad - 2, leftPad: 0}
+	inner.render(content)
+
+	var result []string
+	for _, line := range strings.Split(strings.TrimRight(inner.out.String(), "\n"), "\n") {
+		result = append(result, r.pad(0)+quoteStyle("│ ")+line)
+	}
+	r.block(strings.Join(result, "\n"))
+	return i
+}
+
+func (r *renderer) list(lines []string, i int) int {
+	var result []string
+
+	for i < len(lines) {
+		match := listItemRegexp.FindStringSubmatch(lines[i])
+		if match == nil {
+			break
+		}
+
+		level := len(strings.Replace(match[1], "\t", "    ", -1)) / listIndentLength
+		marker := match[2]
+		content := []string{match[3]}
+		i++
+
+		// continuation lines of the item, indented or lazy
+		for ; i < len(lines); i++ {
+			line := lines[i]
+			if strings.TrimSpace(line) == "" || listItemRegexp.MatchString(line) || startsBlock(line) {
+				break
+			}
+			content = append(content, line)
+		}
+
+		if marker == "-" || marker == "*" || marker == "+" {
+			marker = "•"
+		}
+
+		if task := taskRegexp.FindStringSubmatch(content[0]); task != nil {
+			if task[1] == " " {
+				marker = uncheckedStyle("☐")
+			} else {
+				marker = checkedStyle("☑")
+			}
+			content[0] = task[2]
+		} else {
+			marker = markerStyle(marker)
+		}
+
+		indent := level * listIndentLength
+		markerWidth := text.Len(marker) + 1
+		wrapped := r.wrap(content, r.leftPad+indent+markerWidth)
+
+		// put the marker in the padding of the first line
+		prefix := r.pad(indent) + marker + " "
+		wrapped = prefix + strings.TrimPrefix(wrapped, r.pad(indent+markerWidth))
+
+		result = append(result, wrapped)
+
+		// a blank line between the items doesn't end the list
+		if i+1 < len(lines) && strings.TrimSpace(lines[i]) == "" && listItemRegexp.MatchString(lines[i+1]) {
+			i++
+		}
+	}
+
+	r.block(strings.Join(result, "\n"))
+	return i
+}
+
+func (r *renderer) table(lines []string, i int) int {
+	var result []string
+	for ; i < len(lines); i++ {
+		line := strings.TrimSpace(lines[i])
+		if !strings.HasPrefix(line, "|") {
+			break
+		}
+		// the separator between the header and the rows
+		if strings.Trim(line, "|-: ") == "" {
+			result = append(result, r.pad(0)+tableLineStyle(line))
+			continue
+		}
+		result = append(result, r.pad(0)+inline(line))
+	}
+
+	r.block(strings.Join(result, "\n"))
+	return i
+}
+
+func (r *renderer) codeBlock(lines []string, i int) int {
+	match := fenceRegexp.FindStringSubmatch(lines[i])
+	fence, lang := match[1], strings.ToLower(match[2])
+	i++
+
+	var result []string
+	for ; i < len(lines); i++ {
+		if strings.HasPrefix(strings.TrimSpace(lines[i]), fence) {
+			i++
+			break
+		}
+		result = append(result, r.pad(2)+highlight(strings.Replace(lines[i], "\t", "    ", -1), lang))
+	}
+
+	r.block(strings.Join(result, "\n"))
+	return i
+}
+
+// startsBlock tell if a line interrupt a paragraph to start another block
+func startsBlock(line string) bool {
+	return fenceRegexp.MatchString(line) ||
+		headingRegexp.MatchString(line) ||
+		ruleRegexp.MatchString(line) ||
+		quoteRegexp.MatchString(line)
+}
+
+// inline render the inline markdown of a text: code spans, links, emphasis
+func inline(s string) string {
+	// the code spans are kept verbatim
+	var result strings.Builder
+	last := 0
+	for _, loc := range codeSpanRegexp.FindAllStringSubmatchIndex(s, -1) {
+		result.WriteString(emphasis(s[last:loc[0]]))
+		result.WriteString(codeStyle(s[loc[2]:loc[3]]))
+		last = loc[1]
+	}
+	result.WriteString(emphasis(s[last:]))
+	return result.String()
+}
+
+func emphasis(s string) string {
+	s = imageRegexp.ReplaceAllStringFunc(s, func(m string) string {
+		match := imageRegexp.FindStringSubmatch(m)
+		alt := match[1]
+		if alt == "" {
+			alt = "image"
+		}
+		return imageAltStyle("["+alt+"]") + " " + urlStyle(match[2])
+	})
+	s = linkRegexp.ReplaceAllStringFunc(s, func(m string) string {
+		match := linkRegexp.FindStringSubmatch(m)
+		if match[1] == match[2] {
+			return urlStyle(match[2])
+		}
+		return linkStyle(match[1]) + " (" + urlStyle(match[2]) + ")"
+	})
+	s = boldRegexp.ReplaceAllStringFunc(s, func(m string) string {
+		match := boldRegexp.FindStringSubmatch(m)
+		return boldStyle(match[1] + match[2])
+	})
+	s = italicRegexp.ReplaceAllStringFunc(s, func(m string) string {
+		match := italicRegexp.FindStringSubmatchIndex(m)
+		for g := 1; g <= 2; g++ {
+			if match[2*g] >= 0 {
+				return m[:match[2*g]-1] + italicStyle(m[match[2*g]:match[2*g+1]]) + m[match[2*g+1]+1:]
+			}
+		}
+		return m
+	})
+	s = strikeRegexp.ReplaceAllStringFunc(s, func(m string) string {
+		return strikeStyle(strikeRegexp.FindStringSubmatch(m)[1])
+	})
+	return s
+}
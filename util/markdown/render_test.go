@@ -0,0 +1,55 @@
+package markdown
+
+import (
+	"testing"
+
+	"github.com/fatih/color"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRender(t *testing.T) {
+	color.NoColor = true
+
+	tests := []struct {
+		name     string
+		source   string
+		expected string
+	}{
+		{
+			name:     "paragraph",
+			source:   "Some **bold** and `code`\nsame paragraph\n\nanother one",
+			expected: "  Some bold and code same paragraph\n\n  another one",
+		},
+		{
+			name:     "heading",
+			source:   "## Steps to reproduce",
+			expected: "  ## Steps to reproduce",
+		},
+		{
+			name:     "link",
+			source:   "see [the log](https://example.com/log)",
+			expected: "  see the log (https://example.com/log)",
+		},
+		{
+			name:     "lists",
+			source:   "- one\n- [ ] todo\n- [x] done\n  - nested\n\n1. first",
+			expected: "  • one\n  ☐ todo\n  ☑ done\n    • nested\n  1. first",
+		},
+		{
+			name:     "quote",
+			source:   "> quoted\n> text",
+			expected: "  │ quoted text",
+		},
+		{
+			name:     "code block",
+			source:   "```go\nfunc main() {}\n```",
+			expected: "    func main() {}",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Render(tt.source, 80, 2))
+		})
+	}
+}
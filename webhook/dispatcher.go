@@ -0,0 +1,281 @@
+package webhook
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/identity"
+)
+
+// the number of deliveries waiting for a webhook before new ones are dropped
+const queueSize = 256
+
+// the delays between the attempts of a delivery, before giving up
+var defaultRetryDelays = []time.Duration{
+	5 * time.Second,
+	30 * time.Second,
+	2 * time.Minute,
+	10 * time.Minute,
+}
+
+// the HTTP headers of a delivery
+const (
+	HeaderEvent     = "X-Git-Bug-Event"
+	HeaderDelivery  = "X-Git-Bug-Delivery"
+	HeaderSignature = "X-Git-Bug-Signature"
+)
+
+// Sign compute the signature of a payload, sent in the X-Git-Bug-Signature
+// header: "sha256=" followed by the hex encoded HMAC-SHA256 of the body
+// keyed with the secret of the webhook.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	_, _ = mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+type delivery struct {
+	event EventType
+	body  []byte
+}
+
+// Dispatcher watch the changes of a repository and deliver the events to its
+// webhooks. The deliveries of a webhook are made in order, and retried when
+// the endpoint is not reachable or fail.
+type Dispatcher struct {
+	repo   *cache.RepoCache
+	hooks  []Webhook
+	client *http.Client
+
+	retryDelays []time.Duration
+
+	// if set, only the changes of the bugs it accepts are delivered
+	filter func(id entity.Id) bool
+
+	queues []chan delivery
+	stop   chan struct{}
+	wg     sync.WaitGroup
+}
+
+func NewDispatcher(repo *cache.RepoCache, hooks []Webhook) *Dispatcher {
+	return &Dispatcher{
+		repo:        repo,
+		hooks:       hooks,
+		client:      &http.Client{Timeout: 30 * time.Second},
+		retryDelays: defaultRetryDelays,
+	}
+}
+
+// NewUserDispatcher create a dispatcher delivering to the webhook endpoints
+// of the notification preferences of an identity, only the changes it should
+// be notified of. The endpoints are read once, the other preferences at each
+// change.
+func NewUserDispatcher(repo *cache.RepoCache, user *cache.IdentityCache) *Dispatcher {
+	var hooks []Webhook
+	for i, target := range user.NotificationPreferences().EndpointsOfType(identity.EndpointWebhook) {
+		hooks = append(hooks, Webhook{
+			Name: fmt.Sprintf("%s-%d", user.Id().Human(), i+1),
+			URL:  target,
+		})
+	}
+
+	d := NewDispatcher(repo, hooks)
+	d.filter = func(id entity.Id) bool {
+		notify, err := repo.ShouldNotify(user, id)
+		return err == nil && notify
+	}
+	return d
+}
+
+// Start deliver the changes made from now on, until Close is called. To
+// also get the changes made by other processes, the repository has to be
+// watched.
+func (d *Dispatcher) Start() error {
+	// subscribe before taking the snapshot, to not miss a change in between
+	changes, unsubscribe := d.repo.Changes()
+
+	known := make(map[entity.Id]*cache.BugExcerpt)
+	for _, id := range d.repo.AllBugsIds() {
+		excerpt, err := d.repo.ResolveBugExcerpt(id)
+		if err != nil {
+			unsubscribe()
+			return err
+		}
+		known[id] = excerpt
+	}
+
+	d.stop = make(chan struct{})
+	d.queues = make([]chan delivery, len(d.hooks))
+	for i, hook := range d.hooks {
+		d.queues[i] = make(chan delivery, queueSize)
+		d.wg.Add(1)
+		go d.deliverAll(hook, d.queues[i])
+	}
+
+	d.wg.Add(1)
+	go func() {
+		defer d.wg.Done()
+		defer unsubscribe()
+
+		for {
+			var change cache.ChangeEvent
+			select {
+			case <-d.stop:
+				return
+			case change = <-changes:
+			}
+
+			if change.Target != "bugs" {
+				continue
+			}
+
+			if change.Typ == cache.ChangeEventRemoved {
+				delete(known, change.Id)
+				continue
+			}
+
+			excerpt, err := d.repo.ResolveBugExcerpt(change.Id)
+			if err != nil {
+				// removed in the meantime
+				continue
+			}
+			previous := known[change.Id]
+			known[change.Id] = excerpt
+
+			if d.filter != nil && !d.filter(change.Id) {
+				continue
+			}
+
+			payloads, err := diffBug(d.repo, previous, excerpt)
+			if err != nil {
+				_, _ = fmt.Fprintf(os.Stderr, "webhook: %v\n", err)
+				continue
+			}
+			for _, payload := range payloads {
+				d.enqueue(payload)
+			}
+		}
+	}()
+
+	return nil
+}
+
+// Close stop the dispatcher, abandoning the pending deliveries
+func (d *Dispatcher) Close() {
+	if d.stop == nil {
+		return
+	}
+	close(d.stop)
+	d.wg.Wait()
+	d.stop = nil
+}
+
+func (d *Dispatcher) enqueue(payload Payload) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "webhook: %v\n", err)
+		return
+	}
+
+	for i, hook := range d.hooks {
+		if !hook.Wants(payload.Event) {
+			continue
+		}
+		select {
+		case d.queues[i] <- delivery{event: payload.Event, body: body}:
+		default:
+			_, _ = fmt.Fprintf(os.Stderr, "webhook %s: too many pending deliveries, dropping a %s event\n", hook.Name, payload.Event)
+		}
+	}
+}
+
+func (d *Dispatcher) deliverAll(hook Webhook, queue chan delivery) {
+	defer d.wg.Done()
+
+	for {
+		select {
+		case <-d.stop:
+			return
+		case del := <-queue:
+			err := d.deliver(hook, del)
+			if err != nil {
+				_, _ = fmt.Fprintf(os.Stderr, "webhook %s: giving up a %s event: %v\n", hook.Name, del.event, err)
+			}
+		}
+	}
+}
+
+// deliver send an event, retrying after a delay when it fail temporarily
+func (d *Dispatcher) deliver(hook Webhook, del delivery) error {
+	raw := make([]byte, 16)
+	_, err := rand.Read(raw)
+	if err != nil {
+		return err
+	}
+	deliveryId := hex.EncodeToString(raw)
+
+	for attempt := 0; ; attempt++ {
+		retry, err := d.send(hook, del, deliveryId)
+		if err == nil {
+			return nil
+		}
+		if !retry || attempt >= len(d.retryDelays) {
+			return err
+		}
+
+		select {
+		case <-d.stop:
+			return err
+		case <-time.After(d.retryDelays[attempt]):
+		}
+	}
+}
+
+// send make one attempt of delivery, and return whether it's worth retrying
+// if it failed
+func (d *Dispatcher) send(hook Webhook, del delivery, deliveryId string) (bool, error) {
+	req, err := http.NewRequest(http.MethodPost, hook.URL, bytes.NewReader(del.body))
+	if err != nil {
+		return false, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "git-bug-webhook")
+	req.Header.Set(HeaderEvent, string(del.event))
+	// the same for all the attempts, so that the receiver can deduplicate
+	req.Header.Set(HeaderDelivery, deliveryId)
+	if hook.Secret != "" {
+		req.Header.Set(HeaderSignature, Sign(hook.Secret, del.body))
+	}
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	_, _ = io.Copy(ioutil.Discard, resp.Body)
+	_ = resp.Body.Close()
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return false, nil
+	case resp.StatusCode == http.StatusRequestTimeout,
+		resp.StatusCode == http.StatusTooManyRequests,
+		resp.StatusCode >= 500:
+		return true, fmt.Errorf("unexpected status %s", resp.Status)
+	default:
+		return false, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+}
@@ -0,0 +1,182 @@
+package webhook
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/entity"
+)
+
+// EventType is the kind of change sent to the webhooks
+type EventType string
+
+const (
+	EventBugCreated    EventType = "bug_created"
+	EventCommentAdded  EventType = "comment_added"
+	EventStatusChanged EventType = "status_changed"
+	EventTitleChanged  EventType = "title_changed"
+	EventLabelsChanged EventType = "labels_changed"
+)
+
+// AllEvents list the events a webhook can receive
+var AllEvents = []EventType{
+	EventBugCreated,
+	EventCommentAdded,
+	EventStatusChanged,
+	EventTitleChanged,
+	EventLabelsChanged,
+}
+
+func (t EventType) Validate() error {
+	for _, event := range AllEvents {
+		if t == event {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown event %s", t)
+}
+
+// Payload is the JSON body POSTed to the webhooks for an event
+type Payload struct {
+	Event      EventType `json:"event"`
+	Repository string    `json:"repository,omitempty"`
+	Time       time.Time `json:"time"`
+
+	Bug BugPayload `json:"bug"`
+
+	// for comment_added
+	Comment *CommentPayload `json:"comment,omitempty"`
+	// for status_changed
+	PreviousStatus string `json:"previous_status,omitempty"`
+	// for title_changed
+	PreviousTitle string `json:"previous_title,omitempty"`
+	// for labels_changed
+	AddedLabels   []string `json:"added_labels,omitempty"`
+	RemovedLabels []string `json:"removed_labels,omitempty"`
+}
+
+// BugPayload is the state of a bug after an event
+type BugPayload struct {
+	Id      string   `json:"id"`
+	HumanId string   `json:"human_id"`
+	Title   string   `json:"title"`
+	Status  string   `json:"status"`
+	Labels  []string `json:"labels"`
+	Author  string   `json:"author"`
+}
+
+type CommentPayload struct {
+	Id      string `json:"id"`
+	Author  string `json:"author"`
+	Message string `json:"message"`
+}
+
+// diffBug compute the events between two states of a bug. A nil previous
+// state means a new bug.
+func diffBug(repo *cache.RepoCache, previous, current *cache.BugExcerpt) ([]Payload, error) {
+	// the drafts are private until published
+	if current.Draft {
+		return nil, nil
+	}
+
+	b, err := repo.ResolveBug(current.Id)
+	if err != nil {
+		return nil, err
+	}
+	snap := b.Snapshot()
+
+	// the confidential bugs would be sent in clear
+	if snap.IsConfidential() {
+		return nil, nil
+	}
+
+	base := Payload{
+		Repository: repo.Name(),
+		Time:       time.Unix(current.EditUnixTime, 0),
+		Bug: BugPayload{
+			Id:      current.Id.String(),
+			HumanId: current.Id.Human(),
+			Title:   current.Title,
+			Status:  current.Status.String(),
+			Labels:  make([]string, len(current.Labels)),
+			Author:  displayName(repo, current.AuthorId),
+		},
+	}
+	for i, label := range current.Labels {
+		base.Bug.Labels[i] = label.String()
+	}
+
+	event := func(t EventType) Payload {
+		p := base
+		p.Event = t
+		return p
+	}
+
+	// a published draft is reported as a new bug
+	if previous == nil || previous.Draft {
+		return []Payload{event(EventBugCreated)}, nil
+	}
+
+	var result []Payload
+
+	if current.LenComments > previous.LenComments {
+		comments := snap.Comments
+		if previous.LenComments < len(comments) {
+			for _, comment := range comments[previous.LenComments:] {
+				p := event(EventCommentAdded)
+				p.Comment = &CommentPayload{
+					Id:      comment.Id().String(),
+					Author:  comment.Author.DisplayName(),
+					Message: comment.Message,
+				}
+				result = append(result, p)
+			}
+		}
+	}
+
+	if current.Status != previous.Status {
+		p := event(EventStatusChanged)
+		p.PreviousStatus = previous.Status.String()
+		result = append(result, p)
+	}
+
+	if current.Title != previous.Title {
+		p := event(EventTitleChanged)
+		p.PreviousTitle = previous.Title
+		result = append(result, p)
+	}
+
+	old := make(map[string]bool)
+	for _, l := range previous.Labels {
+		old[l.String()] = true
+	}
+	var added, removed []string
+	for _, l := range current.Labels {
+		if !old[l.String()] {
+			added = append(added, l.String())
+		}
+		delete(old, l.String())
+	}
+	for _, l := range previous.Labels {
+		if old[l.String()] {
+			removed = append(removed, l.String())
+		}
+	}
+	if len(added) > 0 || len(removed) > 0 {
+		p := event(EventLabelsChanged)
+		p.AddedLabels = added
+		p.RemovedLabels = removed
+		result = append(result, p)
+	}
+
+	return result, nil
+}
+
+func displayName(repo *cache.RepoCache, id entity.Id) string {
+	excerpt, err := repo.ResolveIdentityExcerpt(id)
+	if err != nil {
+		return id.Human()
+	}
+	return excerpt.DisplayName()
+}
@@ -0,0 +1,161 @@
+// Package webhook send the changes of the bugs to HTTP endpoints, so that
+// chat bots or CI can react to the activity of the tracker.
+package webhook
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+// the webhooks are stored in the git config:
+// git-bug.webhook.<name>.url = <url receiving the events>
+// git-bug.webhook.<name>.secret = <key of the HMAC signature of the payloads>
+// git-bug.webhook.<name>.events = <comma separated events, all if not set>
+const webhookConfigKeyPrefix = "git-bug.webhook."
+
+var ErrWebhookNotExist = errors.New("webhook doesn't exist")
+
+// Webhook is an HTTP endpoint receiving the events of a repository
+type Webhook struct {
+	Name string
+	URL  string
+	// the key of the HMAC signature of the payloads, no signature if empty
+	Secret string
+	// the events sent to this webhook, all of them if empty
+	Events []EventType
+}
+
+// Wants return whether an event should be sent to the webhook
+func (w Webhook) Wants(event EventType) bool {
+	if len(w.Events) == 0 {
+		return true
+	}
+	for _, e := range w.Events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+func (w Webhook) Validate() error {
+	if w.Name == "" || strings.ContainsAny(w.Name, ". ") {
+		return fmt.Errorf("invalid webhook name \"%s\"", w.Name)
+	}
+	u, err := url.Parse(w.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("webhook %s: invalid url \"%s\"", w.Name, w.URL)
+	}
+	for _, event := range w.Events {
+		if err := event.Validate(); err != nil {
+			return fmt.Errorf("webhook %s: %v", w.Name, err)
+		}
+	}
+	return nil
+}
+
+// Store write a webhook in the config, replacing the one with the same name
+// if any
+func Store(config repository.Config, hook Webhook) error {
+	if err := hook.Validate(); err != nil {
+		return err
+	}
+
+	err := Remove(config, hook.Name)
+	if err != nil && err != ErrWebhookNotExist {
+		return err
+	}
+
+	prefix := webhookConfigKeyPrefix + hook.Name + "."
+	err = config.StoreString(prefix+"url", hook.URL)
+	if err != nil {
+		return err
+	}
+	if hook.Secret != "" {
+		err = config.StoreString(prefix+"secret", hook.Secret)
+		if err != nil {
+			return err
+		}
+	}
+	if len(hook.Events) > 0 {
+		events := make([]string, len(hook.Events))
+		for i, event := range hook.Events {
+			events[i] = string(event)
+		}
+		err = config.StoreString(prefix+"events", strings.Join(events, ","))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Remove remove a webhook from the config
+func Remove(config repository.Config, name string) error {
+	hooks, err := Read(config)
+	if err != nil {
+		return err
+	}
+	for _, hook := range hooks {
+		if hook.Name == name {
+			return config.RemoveAll(webhookConfigKeyPrefix + name + ".")
+		}
+	}
+	return ErrWebhookNotExist
+}
+
+// Read read the webhooks stored in the config
+func Read(config repository.ConfigRead) ([]Webhook, error) {
+	pairs, err := config.ReadAll(webhookConfigKeyPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	byName := make(map[string]*Webhook)
+	var result []*Webhook
+
+	for key, value := range pairs {
+		key = strings.TrimPrefix(key, webhookConfigKeyPrefix)
+		split := strings.Split(key, ".")
+		if len(split) != 2 {
+			return nil, fmt.Errorf("invalid webhook config key %s", key)
+		}
+
+		hook, ok := byName[split[0]]
+		if !ok {
+			hook = &Webhook{Name: split[0]}
+			byName[split[0]] = hook
+			result = append(result, hook)
+		}
+
+		switch split[1] {
+		case "url":
+			hook.URL = value
+		case "secret":
+			hook.Secret = value
+		case "events":
+			for _, event := range strings.Split(value, ",") {
+				event = strings.TrimSpace(event)
+				if event != "" {
+					hook.Events = append(hook.Events, EventType(event))
+				}
+			}
+		default:
+			return nil, fmt.Errorf("invalid webhook config key %s", key)
+		}
+	}
+
+	hooks := make([]Webhook, len(result))
+	for i, hook := range result {
+		if err := hook.Validate(); err != nil {
+			return nil, err
+		}
+		hooks[i] = *hook
+	}
+
+	return hooks, nil
+}
@@ -0,0 +1,153 @@
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/openpgp"
+	"golang.org/x/crypto/openpgp/armor"
+
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/identity"
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+func TestStoreRead(t *testing.T) {
+	config := repository.NewMemConfig()
+
+	hook := Webhook{
+		Name:   "chat",
+		URL:    "https://chat.example.com/hook",
+		Secret: "s3cr3t",
+		Events: []EventType{EventBugCreated, EventCommentAdded},
+	}
+	require.NoError(t, Store(config, hook))
+
+	hooks, err := Read(config)
+	require.NoError(t, err)
+	assert.Equal(t, []Webhook{hook}, hooks)
+
+	assert.True(t, hooks[0].Wants(EventCommentAdded))
+	assert.False(t, hooks[0].Wants(EventStatusChanged))
+
+	assert.Error(t, Store(config, Webhook{Name: "ci", URL: "ftp://example.com"}))
+	assert.Error(t, Store(config, Webhook{Name: "ci", URL: "https://example.com", Events: []EventType{"foo"}}))
+
+	require.NoError(t, Remove(config, "chat"))
+	assert.Equal(t, ErrWebhookNotExist, Remove(config, "chat"))
+
+	hooks, err = Read(config)
+	require.NoError(t, err)
+	assert.Empty(t, hooks)
+}
+
+type received struct {
+	event     string
+	signature string
+	payload   Payload
+	body      []byte
+}
+
+func TestDispatcher(t *testing.T) {
+	repo := repository.CreateGoGitTestRepo(false)
+	defer repository.CleanupTestRepos(repo)
+
+	backend, err := cache.NewRepoCache(repo)
+	require.NoError(t, err)
+	defer backend.Close()
+
+	iden, err := backend.NewIdentity("René Descartes", "rene@descartes.fr")
+	require.NoError(t, err)
+	require.NoError(t, backend.SetUserIdentity(iden))
+
+	requests := make(chan received, 10)
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// the first delivery fail, and is retried
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		body, _ := ioutil.ReadAll(r.Body)
+		var payload Payload
+		assert.NoError(t, json.Unmarshal(body, &payload))
+		requests <- received{
+			event:     r.Header.Get(HeaderEvent),
+			signature: r.Header.Get(HeaderSignature),
+			payload:   payload,
+			body:      body,
+		}
+	}))
+	defer server.Close()
+
+	d := NewDispatcher(backend, []Webhook{{Name: "test", URL: server.URL, Secret: "s3cr3t"}})
+	d.retryDelays = []time.Duration{10 * time.Millisecond}
+	require.NoError(t, d.Start())
+	defer d.Close()
+
+	next := func() received {
+		select {
+		case r := <-requests:
+			return r
+		case <-time.After(5 * time.Second):
+			t.Fatal("no delivery")
+			return received{}
+		}
+	}
+
+	// the confidential bugs are not sent
+	pgpEntity, err := openpgp.NewEntity("René Descartes", "", "rene@descartes.fr", nil)
+	require.NoError(t, err)
+	var pubKey bytes.Buffer
+	w, err := armor.Encode(&pubKey, openpgp.PublicKeyType, nil)
+	require.NoError(t, err)
+	require.NoError(t, pgpEntity.Serialize(w))
+	require.NoError(t, w.Close())
+	key, err := identity.NewKeyFromArmored(pubKey.String())
+	require.NoError(t, err)
+	err = iden.Mutate(func(orig identity.Mutator) identity.Mutator {
+		orig.Keys = []*identity.Key{key}
+		return orig
+	})
+	require.NoError(t, err)
+	require.NoError(t, iden.Commit())
+
+	_, _, err = backend.NewBugWithOptions("secret", "a security issue", cache.NewBugOptions{
+		Recipients: []*cache.IdentityCache{iden},
+	})
+	require.NoError(t, err)
+
+	b, _, err := backend.NewBug("title", "message")
+	require.NoError(t, err)
+
+	r := next()
+	assert.Equal(t, string(EventBugCreated), r.event)
+	assert.Equal(t, Sign("s3cr3t", r.body), r.signature)
+	assert.Equal(t, "title", r.payload.Bug.Title)
+	assert.Equal(t, "René Descartes", r.payload.Bug.Author)
+
+	_, err = b.AddComment("a comment")
+	require.NoError(t, err)
+
+	r = next()
+	assert.Equal(t, EventCommentAdded, r.payload.Event)
+	require.NotNil(t, r.payload.Comment)
+	assert.Equal(t, "a comment", r.payload.Comment.Message)
+
+	_, err = b.Close()
+	require.NoError(t, err)
+
+	r = next()
+	assert.Equal(t, EventStatusChanged, r.payload.Event)
+	assert.Equal(t, "open", r.payload.PreviousStatus)
+	assert.Equal(t, "closed", r.payload.Bug.Status)
+}
@@ -0,0 +1,42 @@
+// Extract the messages of the web UI into the English catalog. The messages
+// are given to FormattedMessage or intl.formatMessage() with their id, then
+// their English text:
+//   <FormattedMessage id="list.title" defaultMessage="Issues" />
+//   intl.formatMessage({ id: 'sort.id', defaultMessage: 'ID' })
+const fs = require('fs');
+const path = require('path');
+
+const catalog = path.join('src', 'i18n', 'locales', 'en.json');
+
+const message = /\bid(?:=|:\s*)(['"])((?:\\.|(?!\1).)*)\1,?\s*defaultMessage(?:=|:\s*)(['"])((?:\\.|(?!\3).)*)\3/g;
+
+function sources(dir) {
+  return fs.readdirSync(dir, { withFileTypes: true }).flatMap((entry) => {
+    const file = path.join(dir, entry.name);
+    if (entry.isDirectory()) {
+      return sources(file);
+    }
+    return /\.tsx?$/.test(file) && !/\.generated\./.test(file) ? [file] : [];
+  });
+}
+
+const unescape = (s) => s.replace(/\\(.)/g, '$1');
+
+const messages = {};
+for (const file of sources('src')) {
+  const content = fs.readFileSync(file, 'utf8');
+  for (const [, , id, , defaultMessage] of content.matchAll(message)) {
+    if (id in messages && messages[id] !== unescape(defaultMessage)) {
+      console.error(`${file}: ${id} has another message elsewhere`);
+      process.exit(1);
+    }
+    messages[id] = unescape(defaultMessage);
+  }
+}
+
+const sorted = {};
+for (const id of Object.keys(messages).sort()) {
+  sorted[id] = messages[id];
+}
+
+fs.writeFileSync(catalog, JSON.stringify(sorted, null, 2) + '\n');
@@ -1,8 +1,12 @@
 package webui
 
 import (
+	"bytes"
+	"html"
+	"io/ioutil"
 	"net/http"
 	"os"
+	"path"
 )
 
 // implement a http.FileSystem that will serve a default file when the looked up
@@ -21,11 +25,57 @@ func (fswd *fileSystemWithDefault) Open(name string) (http.File, error) {
 	return f, err
 }
 
-func NewHandler() http.Handler {
+// NewHandler serve the files of the web UI. The web UI is told the URL path
+// it is served under, like "/bugs/" behind a reverse proxy, with a <base>
+// element added to the index.html file. The assets are then referenced
+// relatively to this base.
+func NewHandler(basePath string) http.Handler {
 	assetsHandler := &fileSystemWithDefault{
 		FileSystem:  WebUIAssets,
 		defaultFile: "index.html",
 	}
 
-	return http.FileServer(assetsHandler)
+	files := http.FileServer(assetsHandler)
+	base := []byte(`<head><base href="` + html.EscapeString(basePath) + `">`)
+
+	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if isAsset(r.URL.Path) {
+			files.ServeHTTP(rw, r)
+			return
+		}
+
+		f, err := WebUIAssets.Open("index.html")
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		defer f.Close()
+
+		index, err := ioutil.ReadAll(f)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		rw.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = rw.Write(bytes.Replace(index, []byte("<head>"), base, 1))
+	})
+}
+
+// isAsset tell if a path is a file of the web UI, other than the index.html
+// file served for all the routes of the application
+func isAsset(name string) bool {
+	name = path.Clean("/" + name)
+	if name == "/" || name == "/index.html" {
+		return false
+	}
+
+	f, err := WebUIAssets.Open(name)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+
+	stat, err := f.Stat()
+	return err == nil && !stat.IsDir()
 }
@@ -2,15 +2,29 @@ import React from 'react';
 import { Route, Switch } from 'react-router';
 
 import Layout from './layout';
+import BoardPage from './pages/board';
+import BridgesPage from './pages/bridges';
 import BugPage from './pages/bug';
+import GraphPage from './pages/graph';
+import IdentitiesPage from './pages/identities';
+import LabelsPage from './pages/labels';
 import ListPage from './pages/list';
+import NewBugPage from './pages/new';
+import ProfilePage from './pages/profile';
 
 export default function App() {
   return (
     <Layout>
       <Switch>
         <Route path="/" exact component={ListPage} />
+        <Route path="/new" exact component={NewBugPage} />
         <Route path="/bug/:id" exact component={BugPage} />
+        <Route path="/board" exact component={BoardPage} />
+        <Route path="/labels" exact component={LabelsPage} />
+        <Route path="/graph" exact component={GraphPage} />
+        <Route path="/bridges" exact component={BridgesPage} />
+        <Route path="/identities" exact component={IdentitiesPage} />
+        <Route path="/profile" exact component={ProfilePage} />
       </Switch>
     </Layout>
   );
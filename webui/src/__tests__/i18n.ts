@@ -0,0 +1,40 @@
+import { pickLocale } from 'src/i18n/locale';
+import { formatMessage, parseMessage } from 'src/i18n/message';
+
+it('uses the locale chosen by the user', () => {
+  expect(pickLocale('fr', ['en-US'])).toEqual('fr');
+});
+
+it('ignores an unknown chosen locale', () => {
+  expect(pickLocale('xx', ['fr-FR', 'en'])).toEqual('fr');
+});
+
+it('falls back to the base language of the browser', () => {
+  expect(pickLocale(null, ['de-DE', 'fr-CA'])).toEqual('fr');
+});
+
+it('defaults to english', () => {
+  expect(pickLocale(null, ['de-DE'])).toEqual('en');
+  expect(pickLocale(null, [])).toEqual('en');
+});
+
+it('formats the arguments of a message', () => {
+  expect(
+    formatMessage('en', 'Invalid query: {reason}', { reason: 'x' })
+  ).toEqual(['Invalid query: ', 'x']);
+});
+
+it('formats the plural choices in the locale', () => {
+  const message = '{count, plural, one {# bug} other {# bugs}}';
+  expect(formatMessage('en', message, { count: 1 }).join('')).toEqual('1 bug');
+  expect(formatMessage('en', message, { count: 0 }).join('')).toEqual('0 bugs');
+  expect(formatMessage('fr', message, { count: 0 }).join('')).toEqual('0 bug');
+  expect(formatMessage('en', message, { count: 1200 }).join('')).toEqual(
+    '1,200 bugs'
+  );
+});
+
+it('rejects the invalid messages', () => {
+  expect(() => parseMessage('{count, plural, one {# bug}}')).toThrow();
+  expect(() => parseMessage('{reason')).toThrow();
+});
@@ -1,9 +1,58 @@
-import { ApolloClient, InMemoryCache } from '@apollo/client';
+import {
+  ApolloClient,
+  ApolloLink,
+  HttpLink,
+  InMemoryCache,
+  split,
+} from '@apollo/client';
+import { createPersistedQueryLink } from '@apollo/client/link/persisted-queries';
+import { WebSocketLink } from '@apollo/client/link/ws';
+import { getMainDefinition } from '@apollo/client/utilities';
 
+import basePath from './basePath';
 import introspectionResult from './fragmentTypes';
 
+async function sha256(query: string): Promise<string> {
+  const data = new TextEncoder().encode(query);
+  const digest = await window.crypto.subtle.digest('SHA-256', data);
+  return Array.from(new Uint8Array(digest))
+    .map((b) => b.toString(16).padStart(2, '0'))
+    .join('');
+}
+
+// once known by the server, the queries are only sent by their hash. The
+// hashing API is only available on https or on localhost.
+let httpLink: ApolloLink = new HttpLink({
+  uri: `${basePath}graphql`,
+});
+if (window.crypto && window.crypto.subtle) {
+  httpLink = createPersistedQueryLink({ sha256 }).concat(httpLink);
+}
+
+// the subscriptions are served on the same endpoint, through a websocket
+const wsProtocol = window.location.protocol === 'https:' ? 'wss:' : 'ws:';
+const wsLink = new WebSocketLink({
+  uri: `${wsProtocol}//${window.location.host}${basePath}graphql`,
+  options: {
+    lazy: true,
+    reconnect: true,
+  },
+});
+
+const link = split(
+  ({ query }) => {
+    const definition = getMainDefinition(query);
+    return (
+      definition.kind === 'OperationDefinition' &&
+      definition.operation === 'subscription'
+    );
+  },
+  wsLink,
+  httpLink
+);
+
 const client = new ApolloClient({
-  uri: '/graphql',
+  link,
   cache: new InMemoryCache({
     possibleTypes: introspectionResult.possibleTypes,
   }),
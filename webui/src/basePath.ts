@@ -0,0 +1,6 @@
+// The URL path the webui is served under, like "/bugs/" behind a reverse
+// proxy. The server gives it as the <base> of the document.
+const base = document.querySelector('base')?.getAttribute('href') || '/';
+const basePath = base.endsWith('/') ? base : base + '/';
+
+export default basePath;
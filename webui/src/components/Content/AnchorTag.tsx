@@ -0,0 +1,18 @@
+import React from 'react';
+
+import basePath from 'src/basePath';
+
+// the files stored in git are referenced from the root of the webui
+const resolve = (href?: string) =>
+  href?.startsWith('/gitfile/') ? basePath + href.slice(1) : href;
+
+const AnchorTag = ({
+  children,
+  ...props
+}: React.AnchorHTMLAttributes<HTMLAnchorElement>) => (
+  <a {...props} href={resolve(props.href)} rel="noopener noreferrer nofollow">
+    {children}
+  </a>
+);
+
+export default AnchorTag;
@@ -2,20 +2,27 @@ import React from 'react';
 
 import { makeStyles } from '@material-ui/styles';
 
+import basePath from 'src/basePath';
+
 const useStyles = makeStyles({
   tag: {
     maxWidth: '100%',
   },
 });
 
+// the files stored in git are referenced from the root of the webui
+const resolve = (src?: string) =>
+  src?.startsWith('/gitfile/') ? basePath + src.slice(1) : src;
+
 const ImageTag = ({
   alt,
   ...props
 }: React.ImgHTMLAttributes<HTMLImageElement>) => {
   const classes = useStyles();
+  const src = resolve(props.src);
   return (
-    <a href={props.src} target="_blank" rel="noopener noreferrer nofollow">
-      <img className={classes.tag} alt={alt} {...props} />
+    <a href={src} target="_blank" rel="noopener noreferrer nofollow">
+      <img className={classes.tag} alt={alt} {...props} src={src} />
     </a>
   );
 };
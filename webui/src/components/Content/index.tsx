@@ -4,6 +4,7 @@ import parse from 'remark-parse';
 import remark2react from 'remark-react';
 import unified from 'unified';
 
+import AnchorTag from './AnchorTag';
 import ImageTag from './ImageTag';
 import PreTag from './PreTag';
 
@@ -14,6 +15,7 @@ const Content: React.FC<Props> = ({ markdown }: Props) => {
     .use(html)
     .use(remark2react, {
       remarkReactComponents: {
+        a: AnchorTag,
         img: ImageTag,
         pre: PreTag,
       },
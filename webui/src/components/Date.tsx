@@ -4,15 +4,33 @@ import Moment from 'react-moment';
 
 import Tooltip from '@material-ui/core/Tooltip/Tooltip';
 
+import { FormattedMessage, useLocale } from 'src/i18n';
+
 const HOUR = 1000 * 3600;
 const DAY = 24 * HOUR;
 const WEEK = 7 * DAY;
 
+// A date relative to now during a week, absolute after, in the locale of
+// the user
 type Props = { date: string };
-const Date = ({ date }: Props) => (
-  <Tooltip title={moment(date).format('LLLL')}>
-    <Moment date={date} format="on ll" fromNowDuring={WEEK} />
-  </Tooltip>
-);
+const Date = ({ date }: Props) => {
+  const { locale } = useLocale();
+  const m = moment(date).locale(locale);
+  return (
+    <Tooltip title={m.format('LLLL')}>
+      {moment().diff(m) < WEEK ? (
+        <Moment date={date} fromNow locale={locale} />
+      ) : (
+        <span>
+          <FormattedMessage
+            id="date.on"
+            defaultMessage="on {date}"
+            values={{ date: m.format('ll') }}
+          />
+        </span>
+      )}
+    </Tooltip>
+  );
+};
 
 export default Date;
@@ -0,0 +1,106 @@
+import React from 'react';
+
+import IconButton from '@material-ui/core/IconButton';
+import Tooltip from '@material-ui/core/Tooltip';
+import { makeStyles } from '@material-ui/core/styles';
+import AttachFileIcon from '@material-ui/icons/AttachFile';
+import CodeIcon from '@material-ui/icons/Code';
+import FormatBoldIcon from '@material-ui/icons/FormatBold';
+import FormatItalicIcon from '@material-ui/icons/FormatItalic';
+import FormatListBulletedIcon from '@material-ui/icons/FormatListBulleted';
+import FormatQuoteIcon from '@material-ui/icons/FormatQuote';
+import LinkIcon from '@material-ui/icons/Link';
+
+const useStyles = makeStyles((theme) => ({
+  toolbar: {
+    display: 'flex',
+    justifyContent: 'flex-end',
+    marginBottom: theme.spacing(1),
+  },
+  file: {
+    display: 'none',
+  },
+}));
+
+// A markdown formatting: the text inserted before and after the selection,
+// or at the beginning of each selected line
+export type Format = { wrap: [string, string] } | { line: string };
+
+const formats: { title: string; icon: React.ReactNode; format: Format }[] = [
+  {
+    title: 'Bold',
+    icon: <FormatBoldIcon />,
+    format: { wrap: ['**', '**'] },
+  },
+  {
+    title: 'Italic',
+    icon: <FormatItalicIcon />,
+    format: { wrap: ['_', '_'] },
+  },
+  {
+    title: 'Code',
+    icon: <CodeIcon />,
+    format: { wrap: ['`', '`'] },
+  },
+  {
+    title: 'Link',
+    icon: <LinkIcon />,
+    format: { wrap: ['[', '](url)'] },
+  },
+  {
+    title: 'Quote',
+    icon: <FormatQuoteIcon />,
+    format: { line: '> ' },
+  },
+  {
+    title: 'Bulleted list',
+    icon: <FormatListBulletedIcon />,
+    format: { line: '- ' },
+  },
+];
+
+type Props = {
+  onFormat: (format: Format) => void;
+  onFiles: (files: File[]) => void;
+  disabled: boolean;
+};
+
+function Toolbar({ onFormat, onFiles, disabled }: Props) {
+  const classes = useStyles();
+
+  return (
+    <div className={classes.toolbar}>
+      {formats.map(({ title, icon, format }) => (
+        <Tooltip title={title} key={title}>
+          <span>
+            <IconButton
+              size="small"
+              onClick={() => onFormat(format)}
+              disabled={disabled}
+            >
+              {icon}
+            </IconButton>
+          </span>
+        </Tooltip>
+      ))}
+      <Tooltip title="Attach files">
+        <span>
+          <IconButton size="small" component="label" disabled={disabled}>
+            <AttachFileIcon />
+            <input
+              type="file"
+              multiple
+              className={classes.file}
+              onChange={(e) => {
+                onFiles(Array.from(e.target.files || []));
+                e.target.value = '';
+              }}
+            />
+          </IconButton>
+        </span>
+      </Tooltip>
+    </div>
+  );
+}
+
+export default Toolbar;
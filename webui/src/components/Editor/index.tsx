@@ -0,0 +1,229 @@
+import React, { useState, useRef } from 'react';
+
+import Tab from '@material-ui/core/Tab';
+import Tabs from '@material-ui/core/Tabs';
+import TextField from '@material-ui/core/TextField';
+import { makeStyles } from '@material-ui/core/styles';
+
+import Content from 'src/components/Content';
+import { useShortcuts } from 'src/components/Shortcuts';
+
+import Toolbar, { Format } from './Toolbar';
+import { uploadFile, fileUrl } from './upload';
+
+const useStyles = makeStyles((theme) => ({
+  header: {
+    display: 'flex',
+    alignItems: 'center',
+    justifyContent: 'space-between',
+  },
+  tabContent: {
+    margin: theme.spacing(2, 0),
+  },
+  preview: {
+    borderBottom: `solid 3px ${theme.palette.grey['200']}`,
+    minHeight: '5rem',
+  },
+  error: {
+    ...theme.typography.body2,
+    color: theme.palette.error.main,
+  },
+}));
+
+type TabPanelProps = {
+  children: React.ReactNode;
+  value: number;
+  index: number;
+} & React.HTMLProps<HTMLDivElement>;
+function TabPanel({ children, value, index, ...props }: TabPanelProps) {
+  return (
+    <div
+      role="tabpanel"
+      hidden={value !== index}
+      id={`editor-tabpanel-${index}`}
+      aria-labelledby={`editor-tab-${index}`}
+      {...props}
+    >
+      {value === index && children}
+    </div>
+  );
+}
+
+const a11yProps = (index: number) => ({
+  id: `editor-tab-${index}`,
+  'aria-controls': `editor-tabpanel-${index}`,
+});
+
+// Apply a markdown format to the selected part of a text
+function applyFormat(
+  text: string,
+  start: number,
+  end: number,
+  format: Format
+): string {
+  const before = text.slice(0, start);
+  const selected = text.slice(start, end);
+  const after = text.slice(end);
+
+  if ('wrap' in format) {
+    return before + format.wrap[0] + selected + format.wrap[1] + after;
+  }
+
+  const lineStart = before.lastIndexOf('\n') + 1;
+  const lines = text
+    .slice(lineStart, end)
+    .split('\n')
+    .map((line) => format.line + line)
+    .join('\n');
+  return text.slice(0, lineStart) + lines + after;
+}
+
+type Props = {
+  value: string;
+  onChange: (value: string) => void;
+  // called with the hash of each file attached to the text
+  onAttach: (hash: string) => void;
+  onKeyDown?: (e: React.KeyboardEvent<HTMLElement>) => void;
+  label: string;
+  placeholder: string;
+  disabled: boolean;
+  // a key focusing the editor
+  focusShortcut?: string;
+};
+
+// A markdown editor with a preview, a formatting toolbar and images attached
+// by pasting or dropping them
+function Editor({
+  value,
+  onChange,
+  onAttach,
+  onKeyDown,
+  label,
+  placeholder,
+  disabled,
+  focusShortcut,
+}: Props) {
+  const classes = useStyles();
+  const [tab, setTab] = useState(0);
+  const [uploading, setUploading] = useState(0);
+  const [error, setError] = useState<string | null>(null);
+  const input = useRef<HTMLTextAreaElement>(null);
+
+  // the value is held by the parent, keep track of it for the uploads
+  // finishing after other changes
+  const current = useRef(value);
+  current.current = value;
+  const update = (text: string) => {
+    current.current = text;
+    onChange(text);
+  };
+
+  useShortcuts(
+    focusShortcut
+      ? {
+          [focusShortcut]: () => {
+            setTab(0);
+            // wait for the write tab to be rendered
+            setTimeout(() => input.current?.focus());
+          },
+        }
+      : {}
+  );
+
+  const format = (f: Format) => {
+    const el = input.current;
+    const start = el ? el.selectionStart : value.length;
+    const end = el ? el.selectionEnd : value.length;
+    onChange(applyFormat(value, start, end, f));
+    el?.focus();
+  };
+
+  const attach = (files: File[]) => {
+    if (files.length === 0) return;
+
+    setError(null);
+    files.forEach((file) => {
+      // the images are shown in the comment, the other files are linked
+      const image = file.type.startsWith('image/') ? '!' : '';
+      const placeholder = `${image}[Uploading ${file.name}...]()`;
+      update(current.current + (current.current ? '\n' : '') + placeholder);
+      setUploading((n) => n + 1);
+
+      uploadFile(file)
+        .then((hash) => {
+          onAttach(hash);
+          update(
+            current.current.replace(
+              placeholder,
+              `${image}[${file.name}](${fileUrl(hash)})`
+            )
+          );
+        })
+        .catch((err) => {
+          setError(`${file.name}: ${err.message}`);
+          update(current.current.replace(placeholder, ''));
+        })
+        .finally(() => setUploading((n) => n - 1));
+    });
+  };
+
+  const handlePaste = (e: React.ClipboardEvent<HTMLDivElement>) => {
+    const files = Array.from(e.clipboardData.files);
+    if (files.length > 0) {
+      e.preventDefault();
+      attach(files);
+    }
+  };
+
+  const handleDrop = (e: React.DragEvent<HTMLDivElement>) => {
+    const files = Array.from(e.dataTransfer.files);
+    if (files.length > 0) {
+      e.preventDefault();
+      attach(files);
+    }
+  };
+
+  return (
+    <>
+      <div className={classes.header}>
+        <Tabs value={tab} onChange={(_, t) => setTab(t)}>
+          <Tab label="Write" {...a11yProps(0)} />
+          <Tab label="Preview" {...a11yProps(1)} />
+        </Tabs>
+        {tab === 0 && (
+          <Toolbar onFormat={format} onFiles={attach} disabled={disabled} />
+        )}
+      </div>
+      <div className={classes.tabContent}>
+        <TabPanel value={tab} index={0}>
+          <TextField
+            inputRef={input}
+            onKeyDown={onKeyDown}
+            onPaste={handlePaste}
+            onDrop={handleDrop}
+            fullWidth
+            label={label}
+            placeholder={placeholder}
+            multiline
+            value={value}
+            variant="filled"
+            rows="4" // TODO: rowsMin support
+            onChange={(e: any) => onChange(e.target.value)}
+            disabled={disabled}
+            helperText={
+              uploading > 0
+                ? 'Uploading...'
+                : 'Attach images by dragging & dropping or pasting them.'
+            }
+          />
+          {error && <div className={classes.error}>{error}</div>}
+        </TabPanel>
+        <TabPanel value={tab} index={1} className={classes.preview}>
+          <Content markdown={value} />
+        </TabPanel>
+      </div>
+    </>
+  );
+}
+
+export default Editor;
@@ -0,0 +1,22 @@
+import basePath from 'src/basePath';
+
+// Store a file as a git blob of the default repository, and return its hash
+export async function uploadFile(file: File): Promise<string> {
+  const body = new FormData();
+  body.append('uploadfile', file);
+
+  const response = await fetch(`${basePath}upload`, {
+    method: 'POST',
+    body,
+    credentials: 'same-origin',
+  });
+  if (!response.ok) {
+    throw new Error((await response.text()).trim() || response.statusText);
+  }
+
+  const { hash } = await response.json();
+  return hash;
+}
+
+// the URL stored in the comments, independent of the base path
+export const fileUrl = (hash: string) => `/gitfile/${hash}`;
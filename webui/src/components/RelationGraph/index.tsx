@@ -0,0 +1,225 @@
+import React, { useMemo, useState } from 'react';
+import { useHistory } from 'react-router-dom';
+
+import CircularProgress from '@material-ui/core/CircularProgress';
+import { makeStyles, useTheme } from '@material-ui/core/styles';
+
+import { RelationType, Status } from 'src/gqlTypes';
+
+import { layout, Point } from './layout';
+import { useRelationGraphQuery } from './RelationGraph.generated';
+
+const useStyles = makeStyles((theme) => ({
+  svg: {
+    display: 'block',
+    userSelect: 'none',
+  },
+  node: {
+    cursor: 'pointer',
+  },
+  label: {
+    ...theme.typography.caption,
+    pointerEvents: 'none',
+  },
+  legend: {
+    ...theme.typography.caption,
+    display: 'flex',
+    flexWrap: 'wrap',
+    '& > label': {
+      display: 'flex',
+      alignItems: 'center',
+      marginRight: theme.spacing(2),
+    },
+  },
+  swatch: {
+    display: 'inline-block',
+    width: 16,
+    height: 3,
+    marginRight: theme.spacing(0.5),
+  },
+  message: {
+    ...theme.typography.body2,
+    color: theme.palette.text.secondary,
+  },
+}));
+
+const relationNames: Record<RelationType, string> = {
+  [RelationType.DuplicateOf]: 'duplicate of',
+  [RelationType.RelatedTo]: 'related to',
+  [RelationType.CausedBy]: 'caused by',
+};
+
+const relationColors: Record<RelationType, string> = {
+  [RelationType.DuplicateOf]: '#9e9e9e',
+  [RelationType.RelatedTo]: '#2196f3',
+  [RelationType.CausedBy]: '#f44336',
+};
+
+type Props = {
+  // only show the bugs linked to this one, and highlight it
+  prefix?: string;
+  width: number;
+  height: number;
+};
+
+// An interactive graph of the relations between bugs: a click on a bug opens
+// it, and a bug can be dragged around.
+function RelationGraph({ prefix, width, height }: Props) {
+  const classes = useStyles();
+  const theme = useTheme();
+  const history = useHistory();
+  const { loading, error, data } = useRelationGraphQuery({
+    variables: { prefix },
+  });
+  const [hidden, setHidden] = useState<RelationType[]>([]);
+  const [hovered, setHovered] = useState<string | null>(null);
+  const [dragged, setDragged] = useState<{ id: string; moved: boolean }>();
+  const [moved, setMoved] = useState<Map<string, Point>>(new Map());
+
+  const graph = data?.repository?.relationGraph;
+  const edges = (graph?.edges || []).filter((e) => !hidden.includes(e.type));
+  const positions = useMemo(
+    () =>
+      graph
+        ? layout(
+            graph.nodes.map((n) => n.id),
+            graph.edges,
+            width,
+            height
+          )
+        : new Map<string, Point>(),
+    [graph, width, height]
+  );
+
+  if (loading) return <CircularProgress />;
+  if (error) return <p>Error: {error.message}</p>;
+  if (!graph || graph.nodes.length === 0 || graph.edges.length === 0) {
+    return <div className={classes.message}>No relation yet.</div>;
+  }
+
+  const position = (id: string) => moved.get(id) || positions.get(id)!;
+
+  const toggle = (type: RelationType) =>
+    setHidden(
+      hidden.includes(type)
+        ? hidden.filter((t) => t !== type)
+        : [...hidden, type]
+    );
+
+  const onMouseMove = (e: React.MouseEvent<SVGSVGElement>) => {
+    if (!dragged) return;
+    const rect = e.currentTarget.getBoundingClientRect();
+    setMoved(
+      new Map(moved).set(dragged.id, {
+        x: e.clientX - rect.left,
+        y: e.clientY - rect.top,
+      })
+    );
+    setDragged({ ...dragged, moved: true });
+  };
+
+  const onMouseUp = (humanId: string) => {
+    // a click without a drag opens the bug
+    if (dragged && !dragged.moved) {
+      history.push(`/bug/${humanId}`);
+    }
+    setDragged(undefined);
+  };
+
+  const isRoot = (id: string) => !!prefix && id.startsWith(prefix);
+
+  return (
+    <div>
+      <svg
+        className={classes.svg}
+        width={width}
+        height={height}
+        onMouseMove={onMouseMove}
+        onMouseLeave={() => setDragged(undefined)}
+      >
+        <defs>
+          {Object.values(RelationType).map((type) => (
+            <marker
+              key={type}
+              id={`arrow-${type}`}
+              viewBox="0 0 10 10"
+              refX="22"
+              refY="5"
+              markerWidth="6"
+              markerHeight="6"
+              orient="auto"
+            >
+              <path d="M 0 0 L 10 5 L 0 10 z" fill={relationColors[type]} />
+            </marker>
+          ))}
+        </defs>
+        {edges.map((edge) => {
+          const source = position(edge.source);
+          const target = position(edge.target);
+          const active = hovered === edge.source || hovered === edge.target;
+          return (
+            <line
+              key={`${edge.source}-${edge.type}-${edge.target}`}
+              x1={source.x}
+              y1={source.y}
+              x2={target.x}
+              y2={target.y}
+              stroke={relationColors[edge.type]}
+              strokeWidth={active ? 3 : 1.5}
+              markerEnd={`url(#arrow-${edge.type})`}
+            >
+              <title>{relationNames[edge.type]}</title>
+            </line>
+          );
+        })}
+        {graph.nodes.map((node) => {
+          const p = position(node.id);
+          const open = node.status === Status.Open;
+          return (
+            <g
+              key={node.id}
+              className={classes.node}
+              onMouseEnter={() => setHovered(node.id)}
+              onMouseLeave={() => setHovered(null)}
+              onMouseDown={() => setDragged({ id: node.id, moved: false })}
+              onMouseUp={() => onMouseUp(node.humanId)}
+            >
+              <title>{node.title}</title>
+              <circle
+                cx={p.x}
+                cy={p.y}
+                r={isRoot(node.id) ? 12 : 9}
+                fill={open ? '#28a745' : '#cb2431'}
+                stroke={theme.palette.text.primary}
+                strokeWidth={hovered === node.id ? 2 : 0}
+              />
+              <text className={classes.label} x={p.x + 14} y={p.y + 4}>
+                {hovered === node.id
+                  ? `${node.humanId} ${node.title}`
+                  : node.humanId}
+              </text>
+            </g>
+          );
+        })}
+      </svg>
+      <div className={classes.legend}>
+        {Object.values(RelationType).map((type) => (
+          <label key={type}>
+            <input
+              type="checkbox"
+              checked={!hidden.includes(type)}
+              onChange={() => toggle(type)}
+            />
+            <span
+              className={classes.swatch}
+              style={{ backgroundColor: relationColors[type] }}
+            />
+            {relationNames[type]}
+          </label>
+        ))}
+      </div>
+    </div>
+  );
+}
+
+export default RelationGraph;
@@ -0,0 +1,79 @@
+// A small force-directed layout: the linked nodes attract each others, all
+// the nodes repel each others, and a weak gravity keeps them centered.
+
+export type Point = { x: number; y: number };
+type Link = { source: string; target: string };
+
+const iterations = 300;
+const linkLength = 120;
+
+export function layout(
+  ids: string[],
+  links: Link[],
+  width: number,
+  height: number
+): Map<string, Point> {
+  const positions = new Map<string, Point>();
+  // start on a circle, so that the result is stable between renders
+  ids.forEach((id, i) => {
+    const angle = (2 * Math.PI * i) / ids.length;
+    positions.set(id, {
+      x: width / 2 + (Math.cos(angle) * width) / 3,
+      y: height / 2 + (Math.sin(angle) * height) / 3,
+    });
+  });
+
+  for (let step = 0; step < iterations; step++) {
+    const cooling = 1 - step / iterations;
+    const forces = new Map<string, Point>(
+      ids.map((id) => [id, { x: 0, y: 0 }])
+    );
+
+    for (let i = 0; i < ids.length; i++) {
+      for (let j = i + 1; j < ids.length; j++) {
+        const a = positions.get(ids[i])!;
+        const b = positions.get(ids[j])!;
+        const dx = a.x - b.x || 0.01;
+        const dy = a.y - b.y || 0.01;
+        const dist2 = dx * dx + dy * dy;
+        const repulsion = (linkLength * linkLength) / dist2;
+        forces.get(ids[i])!.x += dx * repulsion;
+        forces.get(ids[i])!.y += dy * repulsion;
+        forces.get(ids[j])!.x -= dx * repulsion;
+        forces.get(ids[j])!.y -= dy * repulsion;
+      }
+    }
+
+    for (const link of links) {
+      const a = positions.get(link.source);
+      const b = positions.get(link.target);
+      if (!a || !b) continue;
+      const dx = b.x - a.x;
+      const dy = b.y - a.y;
+      const dist = Math.sqrt(dx * dx + dy * dy) || 0.01;
+      const attraction = (dist - linkLength) / dist / 2;
+      forces.get(link.source)!.x += dx * attraction;
+      forces.get(link.source)!.y += dy * attraction;
+      forces.get(link.target)!.x -= dx * attraction;
+      forces.get(link.target)!.y -= dy * attraction;
+    }
+
+    for (const id of ids) {
+      const p = positions.get(id)!;
+      const f = forces.get(id)!;
+      f.x += (width / 2 - p.x) * 0.01;
+      f.y += (height / 2 - p.y) * 0.01;
+      // limit the move of a step, less and less
+      const norm = Math.sqrt(f.x * f.x + f.y * f.y) || 1;
+      const move = Math.min(norm, 20 * cooling);
+      p.x = clamp(p.x + (f.x / norm) * move, 20, width - 20);
+      p.y = clamp(p.y + (f.y / norm) * move, 20, height - 20);
+    }
+  }
+
+  return positions;
+}
+
+function clamp(value: number, min: number, max: number) {
+  return Math.max(min, Math.min(max, value));
+}
@@ -0,0 +1,85 @@
+import React, { useState } from 'react';
+
+import Dialog from '@material-ui/core/Dialog';
+import DialogContent from '@material-ui/core/DialogContent';
+import DialogTitle from '@material-ui/core/DialogTitle';
+import { makeStyles } from '@material-ui/core/styles';
+
+import useShortcuts from './useShortcuts';
+
+const useStyles = makeStyles((theme) => ({
+  section: {
+    ...theme.typography.subtitle2,
+    marginTop: theme.spacing(2),
+  },
+  table: {
+    ...theme.typography.body2,
+    borderSpacing: theme.spacing(2, 0.5),
+    marginLeft: theme.spacing(-2),
+  },
+  key: {
+    fontFamily: 'monospace',
+    padding: theme.spacing(0, 0.5),
+    border: `solid 1px ${theme.palette.grey['400']}`,
+    borderRadius: theme.shape.borderRadius,
+    backgroundColor: theme.palette.grey['100'],
+  },
+}));
+
+const sections: { title: string; shortcuts: [string, string][] }[] = [
+  {
+    title: 'Everywhere',
+    shortcuts: [['?', 'Show this help']],
+  },
+  {
+    title: 'Bug list',
+    shortcuts: [
+      ['j', 'Select the next bug'],
+      ['k', 'Select the previous bug'],
+      ['o', 'Open the selected bug'],
+      ['/', 'Focus the search'],
+    ],
+  },
+  {
+    title: 'Bug',
+    shortcuts: [
+      ['l', 'Edit the labels'],
+      ['c', 'Write a comment'],
+    ],
+  },
+];
+
+// A dialog listing the keyboard shortcuts, opened with "?"
+function ShortcutsHelp() {
+  const classes = useStyles();
+  const [open, setOpen] = useState(false);
+
+  useShortcuts({ '?': () => setOpen((o) => !o) });
+
+  return (
+    <Dialog open={open} onClose={() => setOpen(false)}>
+      <DialogTitle>Keyboard shortcuts</DialogTitle>
+      <DialogContent>
+        {sections.map(({ title, shortcuts }) => (
+          <React.Fragment key={title}>
+            <div className={classes.section}>{title}</div>
+            <table className={classes.table}>
+              <tbody>
+                {shortcuts.map(([key, description]) => (
+                  <tr key={key}>
+                    <td>
+                      <kbd className={classes.key}>{key}</kbd>
+                    </td>
+                    <td>{description}</td>
+                  </tr>
+                ))}
+              </tbody>
+            </table>
+          </React.Fragment>
+        ))}
+      </DialogContent>
+    </Dialog>
+  );
+}
+
+export default ShortcutsHelp;
@@ -0,0 +1,2 @@
+export { default as useShortcuts } from './useShortcuts';
+export { default as ShortcutsHelp } from './ShortcutsHelp';
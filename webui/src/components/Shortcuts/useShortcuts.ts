@@ -0,0 +1,38 @@
+import { useEffect, useRef } from 'react';
+
+type Handlers = { [key: string]: () => void };
+
+// the shortcuts don't apply while typing in a field
+function isEditable(target: EventTarget | null): boolean {
+  if (!(target instanceof HTMLElement)) return false;
+  return (
+    target.isContentEditable ||
+    ['INPUT', 'TEXTAREA', 'SELECT'].includes(target.tagName)
+  );
+}
+
+// Bind single key shortcuts, like "j" or "?", for as long as the calling
+// component is mounted
+function useShortcuts(handlers: Handlers) {
+  // keep the latest handlers without binding the listener again on each
+  // render
+  const current = useRef(handlers);
+  current.current = handlers;
+
+  useEffect(() => {
+    const listener = (e: KeyboardEvent) => {
+      if (e.ctrlKey || e.metaKey || e.altKey || isEditable(e.target)) return;
+
+      const handler = current.current[e.key];
+      if (handler) {
+        e.preventDefault();
+        handler();
+      }
+    };
+
+    document.addEventListener('keydown', listener);
+    return () => document.removeEventListener('keydown', listener);
+  }, []);
+}
+
+export default useShortcuts;
@@ -0,0 +1,131 @@
+import moment from 'moment';
+import 'moment/locale/fr';
+import React, { ReactNode, useContext, useEffect, useState } from 'react';
+
+import {
+  defaultLocale,
+  localeNames,
+  locales,
+  pickLocale,
+  storageKey,
+} from './locale';
+import en from './locales/en.json';
+import fr from './locales/fr.json';
+import { formatMessage, MessageValues } from './message';
+
+const catalogs: Record<string, Record<string, string>> = { en, fr };
+
+type LocaleContextValue = {
+  locale: string;
+  setLocale: (locale: string) => void;
+};
+
+const LocaleContext = React.createContext<LocaleContextValue>({
+  locale: defaultLocale,
+  setLocale: () => {},
+});
+
+// useLocale give the current locale, and a way to change it
+export const useLocale = () => useContext(LocaleContext);
+
+type Props = { children: React.ReactNode };
+
+// LocaleProvider translate the messages of the web UI, and format the
+// dates in the locale of the user
+export function LocaleProvider({ children }: Props) {
+  const [locale, setLocale] = useState(() =>
+    pickLocale(localStorage.getItem(storageKey), navigator.languages || [])
+  );
+
+  // set before rendering, for the dates to be formatted in this locale
+  moment.locale(locale);
+
+  useEffect(() => {
+    localStorage.setItem(storageKey, locale);
+    document.documentElement.lang = locale;
+  }, [locale]);
+
+  return (
+    <LocaleContext.Provider value={{ locale, setLocale }}>
+      {children}
+    </LocaleContext.Provider>
+  );
+}
+
+// A message to translate, with its id in the catalogs, and the English
+// text used to extract the English catalog and when a translation is missing
+type MessageDescriptor = { id: string; defaultMessage: string };
+
+function translate(
+  locale: string,
+  { id, defaultMessage }: MessageDescriptor,
+  values?: MessageValues
+): ReactNode[] {
+  const message = catalogs[locale][id] || defaultMessage;
+  return formatMessage(locale, message, values);
+}
+
+// useIntl give a way to translate the messages given as a string, such as
+// the attributes of the elements
+export function useIntl() {
+  const { locale } = useLocale();
+  return {
+    locale,
+    formatMessage: (descriptor: MessageDescriptor, values?: MessageValues) =>
+      translate(locale, descriptor, values).join(''),
+  };
+}
+
+type FormattedMessageProps = MessageDescriptor & { values?: MessageValues };
+
+// FormattedMessage render a translated message, where the values can be
+// React elements
+export function FormattedMessage({
+  values,
+  ...descriptor
+}: FormattedMessageProps) {
+  const { locale } = useLocale();
+  return <>{React.Children.toArray(translate(locale, descriptor, values))}</>;
+}
+
+type FormattedNumberProps = Intl.NumberFormatOptions & { value: number };
+
+export function FormattedNumber({ value, ...options }: FormattedNumberProps) {
+  const { locale } = useLocale();
+  return <>{new Intl.NumberFormat(locale, options).format(value)}</>;
+}
+
+// Intl.ListFormat is not known by this version of typescript
+type ListFormatPart = { type: 'element' | 'literal'; value: string };
+type ListFormat = new (
+  locale: string,
+  options: { type: 'conjunction' | 'disjunction' }
+) => { formatToParts: (list: string[]) => ListFormatPart[] };
+
+type FormattedListProps = {
+  type: 'conjunction' | 'disjunction';
+  value: ReactNode[];
+};
+
+// FormattedList render a list of elements, such as "a, b or c", falling back
+// to "a, b, c" in the browsers without Intl.ListFormat
+export function FormattedList({ type, value }: FormattedListProps) {
+  const { locale } = useLocale();
+  const ListFormat: ListFormat | undefined = (Intl as any).ListFormat;
+  if (!ListFormat) {
+    const list: ReactNode[] = [];
+    value.forEach((v, i) => list.push(i > 0 ? ', ' : null, v));
+    return <>{React.Children.toArray(list)}</>;
+  }
+
+  // format the indexes, to place the elements between the literals
+  const parts = new ListFormat(locale, { type }).formatToParts(
+    value.map((_, i) => String(i))
+  );
+  const list = parts.map((part) =>
+    part.type === 'element' ? value[Number(part.value)] : part.value
+  );
+  return <>{React.Children.toArray(list)}</>;
+}
+
+export { locales, localeNames };
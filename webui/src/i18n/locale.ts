@@ -0,0 +1,30 @@
+// The locales with a message catalog in ./locales. English is the source
+// language, its catalog is extracted from the code with `npm run extract`.
+export const locales = ['en', 'fr'];
+export const defaultLocale = 'en';
+
+export const localeNames: Record<string, string> = {
+  en: 'English',
+  fr: 'Français',
+};
+
+// localStorage key holding the locale chosen by the user
+export const storageKey = 'git-bug-locale';
+
+// pickLocale choose the locale to use, from the one chosen by the user or
+// else the preferred languages of the browser: "fr-CA" falls back to "fr".
+export function pickLocale(
+  chosen: string | null,
+  preferred: readonly string[]
+): string {
+  if (chosen && locales.includes(chosen)) {
+    return chosen;
+  }
+  for (const language of preferred) {
+    const base = language.toLowerCase().split('-')[0];
+    if (locales.includes(base)) {
+      return base;
+    }
+  }
+  return defaultLocale;
+}
@@ -0,0 +1,145 @@
+import { ReactNode } from 'react';
+
+export type MessageValues = Record<string, ReactNode>;
+
+// A parsed message: text, {name} arguments, {name, plural, ...} choices, and
+// the # standing for the number inside a choice.
+type Part =
+  | string
+  | { arg: string }
+  | { arg: string; plural: Record<string, Part[]> }
+  | { number: true };
+
+// parseMessage parse the subset of the ICU message syntax used by the
+// catalogs: the {name} arguments, and the plural choices, such as
+// "{count, plural, one {# bug} other {# bugs}}" with =N for an exact value.
+export function parseMessage(message: string): Part[] {
+  let pos = 0;
+
+  const fail = (reason: string): never => {
+    throw new Error(`invalid message "${message}": ${reason}`);
+  };
+
+  const skipSpaces = () => {
+    while (pos < message.length && /\s/.test(message[pos])) pos++;
+  };
+
+  const readWord = (): string => {
+    skipSpaces();
+    const start = pos;
+    while (pos < message.length && /[^\s,{}]/.test(message[pos])) pos++;
+    if (pos === start) fail(`a name is expected at ${pos}`);
+    return message.slice(start, pos);
+  };
+
+  const expect = (c: string) => {
+    skipSpaces();
+    if (message[pos] !== c) fail(`"${c}" is expected at ${pos}`);
+    pos++;
+  };
+
+  const parseArgument = (): Part => {
+    expect('{');
+    const arg = readWord();
+    skipSpaces();
+    if (message[pos] === '}') {
+      pos++;
+      return { arg };
+    }
+
+    expect(',');
+    const type = readWord();
+    if (type !== 'plural') fail(`unsupported argument type ${type}`);
+    expect(',');
+
+    const plural: Record<string, Part[]> = {};
+    skipSpaces();
+    while (message[pos] !== '}') {
+      const key = readWord();
+      expect('{');
+      plural[key] = parseParts(true);
+      expect('}');
+      skipSpaces();
+      if (pos >= message.length) fail('unterminated plural');
+    }
+    pos++;
+
+    if (!plural.other) fail(`the plural of ${arg} has no "other" case`);
+    return { arg, plural };
+  };
+
+  const parseParts = (inPlural: boolean): Part[] => {
+    const parts: Part[] = [];
+    let text = '';
+    const flush = () => {
+      if (text) parts.push(text);
+      text = '';
+    };
+
+    while (pos < message.length && message[pos] !== '}') {
+      const c = message[pos];
+      if (c === '{') {
+        flush();
+        parts.push(parseArgument());
+      } else if (c === '#' && inPlural) {
+        flush();
+        parts.push({ number: true });
+        pos++;
+      } else {
+        text += c;
+        pos++;
+      }
+    }
+    flush();
+    return parts;
+  };
+
+  const parts = parseParts(false);
+  if (pos < message.length) fail(`unexpected "}" at ${pos}`);
+  return parts;
+}
+
+const parsed = new Map<string, Part[]>();
+
+// formatMessage replace the arguments of a message by their values. The
+// values can be React elements, the result is then to be rendered as is.
+export function formatMessage(
+  locale: string,
+  message: string,
+  values: MessageValues = {}
+): ReactNode[] {
+  let parts = parsed.get(message);
+  if (!parts) {
+    parts = parseMessage(message);
+    parsed.set(message, parts);
+  }
+  return formatParts(locale, parts, values);
+}
+
+function formatParts(
+  locale: string,
+  parts: Part[],
+  values: MessageValues,
+  count?: number
+): ReactNode[] {
+  const result: ReactNode[] = [];
+  for (const part of parts) {
+    if (typeof part === 'string') {
+      result.push(part);
+    } else if ('number' in part) {
+      result.push(new Intl.NumberFormat(locale).format(count ?? 0));
+    } else if ('plural' in part) {
+      const n = Number(values[part.arg]);
+      const choice =
+        part.plural[`=${n}`] ||
+        part.plural[new Intl.PluralRules(locale).select(n)] ||
+        part.plural.other;
+      result.push(...formatParts(locale, choice, values, n));
+    } else if (part.arg in values) {
+      result.push(values[part.arg]);
+    } else {
+      result.push(`{${part.arg}}`);
+    }
+  }
+  return result;
+}
@@ -7,14 +7,18 @@ import ThemeProvider from '@material-ui/styles/ThemeProvider';
 
 import App from './App';
 import apolloClient from './apollo';
+import basePath from './basePath';
+import { LocaleProvider } from './i18n';
 import theme from './theme';
 
 ReactDOM.render(
   <ApolloProvider client={apolloClient}>
-    <BrowserRouter>
-      <ThemeProvider theme={theme}>
-        <App />
-      </ThemeProvider>
+    <BrowserRouter basename={basePath}>
+      <LocaleProvider>
+        <ThemeProvider theme={theme}>
+          <App />
+        </ThemeProvider>
+      </LocaleProvider>
     </BrowserRouter>
   </ApolloProvider>,
   document.getElementById('root')
@@ -1,4 +1,5 @@
 import React from 'react';
+import { Link } from 'react-router-dom';
 
 import Avatar from '@material-ui/core/Avatar';
 import { makeStyles } from '@material-ui/core/styles';
@@ -6,6 +7,12 @@ import { makeStyles } from '@material-ui/core/styles';
 import { useCurrentIdentityQuery } from './CurrentIdentity.generated';
 
 const useStyles = makeStyles((theme) => ({
+  profile: {
+    display: 'flex',
+    alignItems: 'center',
+    color: 'inherit',
+    textDecoration: 'none',
+  },
   displayName: {
     marginLeft: theme.spacing(2),
   },
@@ -19,12 +26,12 @@ const CurrentIdentity = () => {
 
   const user = data.repository.userIdentity;
   return (
-    <>
+    <Link to="/profile" className={classes.profile} title="Edit my profile">
       <Avatar src={user.avatarUrl ? user.avatarUrl : undefined}>
         {user.displayName.charAt(0).toUpperCase()}
       </Avatar>
       <div className={classes.displayName}>{user.displayName}</div>
-    </>
+    </Link>
   );
 };
 
@@ -5,7 +5,11 @@ import AppBar from '@material-ui/core/AppBar';
 import Toolbar from '@material-ui/core/Toolbar';
 import { makeStyles } from '@material-ui/core/styles';
 
+import basePath from 'src/basePath';
+import { FormattedMessage } from 'src/i18n';
+
 import CurrentIdentity from './CurrentIdentity';
+import LocalePicker from './LocalePicker';
 
 const useStyles = makeStyles((theme) => ({
   offset: {
@@ -21,6 +25,12 @@ const useStyles = makeStyles((theme) => ({
     display: 'flex',
     alignItems: 'center',
   },
+  link: {
+    ...theme.typography.button,
+    color: 'white',
+    textDecoration: 'none',
+    marginRight: theme.spacing(2),
+  },
   logo: {
     height: '42px',
     marginRight: theme.spacing(2),
@@ -35,10 +45,33 @@ function Header() {
       <AppBar position="fixed" color="primary">
         <Toolbar>
           <Link to="/" className={classes.appTitle}>
-            <img src="/logo.svg" className={classes.logo} alt="git-bug" />
+            <img
+              src={`${basePath}logo.svg`}
+              className={classes.logo}
+              alt="git-bug"
+            />
             git-bug
           </Link>
           <div className={classes.filler}></div>
+          <Link to="/board" className={classes.link}>
+            <FormattedMessage id="header.board" defaultMessage="Board" />
+          </Link>
+          <Link to="/graph" className={classes.link}>
+            <FormattedMessage id="header.graph" defaultMessage="Graph" />
+          </Link>
+          <Link to="/labels" className={classes.link}>
+            <FormattedMessage id="header.labels" defaultMessage="Labels" />
+          </Link>
+          <Link to="/bridges" className={classes.link}>
+            <FormattedMessage id="header.bridges" defaultMessage="Bridges" />
+          </Link>
+          <Link to="/identities" className={classes.link}>
+            <FormattedMessage
+              id="header.identities"
+              defaultMessage="Identities"
+            />
+          </Link>
+          <LocalePicker />
           <CurrentIdentity />
         </Toolbar>
       </AppBar>
@@ -0,0 +1,46 @@
+import React from 'react';
+
+import NativeSelect from '@material-ui/core/NativeSelect';
+import { makeStyles } from '@material-ui/core/styles';
+
+import { localeNames, locales, useIntl, useLocale } from 'src/i18n';
+
+const useStyles = makeStyles((theme) => ({
+  select: {
+    color: 'white',
+    marginRight: theme.spacing(2),
+    '& option': {
+      color: theme.palette.text.primary,
+    },
+  },
+}));
+
+// Choose the language of the web UI
+function LocalePicker() {
+  const classes = useStyles();
+  const intl = useIntl();
+  const { locale, setLocale } = useLocale();
+
+  return (
+    <NativeSelect
+      className={classes.select}
+      value={locale}
+      onChange={(e) => setLocale(e.target.value)}
+      disableUnderline
+      inputProps={{
+        'aria-label': intl.formatMessage({
+          id: 'header.language',
+          defaultMessage: 'Language',
+        }),
+      }}
+    >
+      {locales.map((l) => (
+        <option key={l} value={l}>
+          {localeNames[l]}
+        </option>
+      ))}
+    </NativeSelect>
+  );
+}
+
+export default LocalePicker;
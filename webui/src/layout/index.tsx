@@ -2,6 +2,8 @@ import React from 'react';
 
 import CssBaseline from '@material-ui/core/CssBaseline';
 
+import { ShortcutsHelp } from 'src/components/Shortcuts';
+
 import Header from './Header';
 
 type Props = { children: React.ReactNode };
@@ -10,6 +12,7 @@ function Layout({ children }: Props) {
     <>
       <CssBaseline />
       <Header />
+      <ShortcutsHelp />
       {children}
     </>
   );
@@ -0,0 +1,534 @@
+import React, { useState } from 'react';
+import { Link, useHistory, useLocation } from 'react-router-dom';
+
+import Button from '@material-ui/core/Button';
+import CircularProgress from '@material-ui/core/CircularProgress';
+import IconButton from '@material-ui/core/IconButton';
+import Paper from '@material-ui/core/Paper';
+import Tab from '@material-ui/core/Tab';
+import Tabs from '@material-ui/core/Tabs';
+import TextField from '@material-ui/core/TextField';
+import { makeStyles } from '@material-ui/core/styles';
+import CloseIcon from '@material-ui/icons/Close';
+
+import { useBugChangesSubscription } from 'src/components/BugChanges.generated';
+import Label from 'src/components/Label';
+import { FormattedMessage, useIntl } from 'src/i18n';
+import IfLoggedIn from 'src/layout/IfLoggedIn';
+
+import {
+  BoardCardFragment,
+  useBoardBugsQuery,
+  useEntityBoardQuery,
+  useBoardOpenBugMutation,
+  useBoardCloseBugMutation,
+  useBoardChangeLabelsMutation,
+  useNewBoardMutation,
+  useMoveBoardCardMutation,
+  useRemoveBoardCardMutation,
+} from './Board.generated';
+
+const useStyles = makeStyles((theme) => ({
+  main: {
+    margin: theme.spacing(4, 2),
+  },
+  header: {
+    display: 'flex',
+    alignItems: 'center',
+    justifyContent: 'space-between',
+  },
+  newBoard: {
+    display: 'flex',
+    alignItems: 'center',
+    '& > *': {
+      marginLeft: theme.spacing(1),
+    },
+  },
+  columns: {
+    display: 'flex',
+    alignItems: 'flex-start',
+    overflowX: 'auto',
+    marginTop: theme.spacing(2),
+  },
+  column: {
+    flex: '0 0 280px',
+    marginRight: theme.spacing(2),
+    padding: theme.spacing(1),
+    backgroundColor: theme.palette.grey['100'],
+    minHeight: 200,
+  },
+  dropTarget: {
+    backgroundColor: theme.palette.grey['300'],
+  },
+  columnTitle: {
+    ...theme.typography.subtitle2,
+    padding: theme.spacing(1),
+  },
+  backlog: {
+    backgroundColor: theme.palette.background.default,
+    border: `1px dashed ${theme.palette.divider}`,
+  },
+  card: {
+    position: 'relative',
+    padding: theme.spacing(1),
+    marginBottom: theme.spacing(1),
+    cursor: 'grab',
+    '& a': {
+      ...theme.typography.body2,
+      color: theme.palette.text.primary,
+      textDecoration: 'none',
+    },
+  },
+  remove: {
+    position: 'absolute',
+    top: 0,
+    right: 0,
+  },
+  humanId: {
+    ...theme.typography.caption,
+    color: theme.palette.text.secondary,
+    marginRight: theme.spacing(1),
+  },
+  labels: {
+    marginTop: theme.spacing(0.5),
+    '& > *': {
+      marginRight: theme.spacing(0.5),
+    },
+  },
+  error: {
+    ...theme.typography.body2,
+    color: theme.palette.error.main,
+  },
+}));
+
+// the column of the bugs without a label in the namespace
+const noLabelColumn = '(none)';
+
+type Column = {
+  name: string;
+  cards: BoardCardFragment[];
+  // the open bugs not on a kanban board, to drag on and off the board
+  backlog?: boolean;
+};
+
+// the namespaces of the labels named "<namespace>/<value>"
+function labelNamespaces(labels: { name: string }[]): string[] {
+  const namespaces = new Set<string>();
+  labels.forEach(({ name }) => {
+    const i = name.indexOf('/');
+    if (i > 0) namespaces.add(name.slice(0, i));
+  });
+  return Array.from(namespaces).sort();
+}
+
+function statusColumns(bugs: BoardCardFragment[]): Column[] {
+  return [
+    { name: 'OPEN', cards: bugs.filter((b) => b.status === 'OPEN') },
+    { name: 'CLOSED', cards: bugs.filter((b) => b.status === 'CLOSED') },
+  ];
+}
+
+function labelColumns(
+  namespace: string,
+  labels: { name: string }[],
+  bugs: BoardCardFragment[]
+): Column[] {
+  const prefix = namespace + '/';
+  const values = labels
+    .map((l) => l.name)
+    .filter((name) => name.startsWith(prefix))
+    .map((name) => name.slice(prefix.length))
+    .sort();
+
+  const columns: Column[] = [noLabelColumn, ...values].map((name) => ({
+    name,
+    cards: [],
+  }));
+
+  bugs.forEach((bug) => {
+    const label = bug.labels.find((l) => l.name.startsWith(prefix));
+    const name = label ? label.name.slice(prefix.length) : noLabelColumn;
+    const column = columns.find((c) => c.name === name);
+    if (column) column.cards.push(bug);
+  });
+
+  return columns;
+}
+
+// the columns of a kanban board, after the backlog of the open bugs which
+// are not on the board
+function entityColumns(
+  board: { columns: Column[] },
+  bugs: BoardCardFragment[]
+): Column[] {
+  const onBoard = new Set<string>();
+  board.columns.forEach((c) => c.cards.forEach((b) => onBoard.add(b.id)));
+
+  return [
+    {
+      name: '',
+      cards: bugs.filter((b) => !onBoard.has(b.id)),
+      backlog: true,
+    },
+    ...board.columns,
+  ];
+}
+
+type CardProps = {
+  bug: BoardCardFragment;
+  onDrop?: (bugId: string) => void;
+  onRemove?: () => void;
+};
+
+function Card({ bug, onDrop, onRemove }: CardProps) {
+  const classes = useStyles();
+  const intl = useIntl();
+
+  return (
+    <Paper
+      className={classes.card}
+      draggable
+      onDragStart={(e) => e.dataTransfer.setData('text/plain', bug.id)}
+      onDragOver={onDrop && ((e) => e.preventDefault())}
+      onDrop={
+        onDrop &&
+        ((e) => {
+          // dropped on a card, the bug is inserted before it, and the
+          // column doesn't handle the drop
+          e.preventDefault();
+          onDrop(e.dataTransfer.getData('text/plain'));
+        })
+      }
+    >
+      {onRemove && (
+        <IconButton
+          size="small"
+          className={classes.remove}
+          onClick={onRemove}
+          title={intl.formatMessage({
+            id: 'board.removeCard',
+            defaultMessage: 'Remove from the board',
+          })}
+        >
+          <CloseIcon fontSize="inherit" />
+        </IconButton>
+      )}
+      <span className={classes.humanId}>{bug.humanId}</span>
+      <Link to={'/bug/' + bug.humanId}>{bug.title}</Link>
+      {bug.labels.length > 0 && (
+        <div className={classes.labels}>
+          {bug.labels.map((l) => (
+            <Label key={l.name} label={l} />
+          ))}
+        </div>
+      )}
+    </Paper>
+  );
+}
+
+type ColumnProps = {
+  column: Column;
+  // position is given when the bug is dropped on a card, and is the index of
+  // that card in the column
+  onDrop: (bugId: string, position?: number) => void;
+  ordered?: boolean;
+  onRemove?: (bugId: string) => void;
+};
+
+function BoardColumn({ column, onDrop, ordered, onRemove }: ColumnProps) {
+  const classes = useStyles();
+  const [over, setOver] = useState(false);
+
+  const className = [
+    classes.column,
+    column.backlog && classes.backlog,
+    over && classes.dropTarget,
+  ]
+    .filter(Boolean)
+    .join(' ');
+
+  return (
+    <div
+      className={className}
+      onDragOver={(e) => {
+        e.preventDefault();
+        setOver(true);
+      }}
+      onDragLeave={() => setOver(false)}
+      onDrop={(e) => {
+        const handled = e.isDefaultPrevented();
+        e.preventDefault();
+        setOver(false);
+        if (!handled) onDrop(e.dataTransfer.getData('text/plain'));
+      }}
+    >
+      <div className={classes.columnTitle}>
+        {column.backlog ? (
+          <FormattedMessage id="board.backlog" defaultMessage="Backlog" />
+        ) : (
+          column.name
+        )}{' '}
+        ({column.cards.length})
+      </div>
+      {column.cards.map((bug, i) => (
+        <Card
+          key={bug.id}
+          bug={bug}
+          onDrop={ordered ? (bugId) => onDrop(bugId, i) : undefined}
+          onRemove={onRemove && (() => onRemove(bug.id))}
+        />
+      ))}
+    </div>
+  );
+}
+
+type NewBoardProps = {
+  onCreated: (humanId: string) => void;
+  onError: (message: string) => void;
+};
+
+// Create a kanban board, with the default columns
+function NewBoard({ onCreated, onError }: NewBoardProps) {
+  const classes = useStyles();
+  const intl = useIntl();
+  const [title, setTitle] = useState('');
+  const [newBoard, { loading }] = useNewBoardMutation();
+
+  const submit = (e: React.FormEvent<HTMLFormElement>) => {
+    e.preventDefault();
+    newBoard({ variables: { input: { title } } })
+      .then((result) => {
+        const board = result.data?.newBoard.board;
+        setTitle('');
+        if (board) onCreated(board.humanId);
+      })
+      .catch((err) => onError(err.message));
+  };
+
+  return (
+    <form className={classes.newBoard} onSubmit={submit}>
+      <TextField
+        size="small"
+        placeholder={intl.formatMessage({
+          id: 'board.newTitle',
+          defaultMessage: 'Title of the new board',
+        })}
+        value={title}
+        onChange={(e) => setTitle(e.target.value)}
+        disabled={loading}
+      />
+      <Button
+        type="submit"
+        variant="outlined"
+        size="small"
+        disabled={loading || !title.trim()}
+      >
+        <FormattedMessage id="board.new" defaultMessage="New board" />
+      </Button>
+    </form>
+  );
+}
+
+function Board() {
+  const classes = useStyles();
+  const intl = useIntl();
+  const location = useLocation();
+  const history = useHistory();
+  const [error, setError] = useState<string | null>(null);
+
+  // a board is either by status, or by the labels of a namespace: a label
+  // "prio/high" is in the column "high" of the "prio" board, or one of the
+  // kanban boards of the repository, where the bugs are placed by hand
+  const params = new URLSearchParams(location.search);
+  const namespace = params.get('namespace');
+  const boardId = params.get('board');
+
+  // the label and kanban boards only show the open bugs, like the termui
+  const query =
+    namespace === null && boardId === null
+      ? 'sort:edit-desc'
+      : 'status:open sort:edit-desc';
+  const { loading, error: queryError, data, refetch } = useBoardBugsQuery({
+    variables: { query },
+  });
+  const entityBoard = useEntityBoardQuery({
+    variables: { prefix: boardId || '' },
+    skip: boardId === null,
+  });
+
+  // reload the board when any bug changes, including the moves of the
+  // other users
+  useBugChangesSubscription({ onSubscriptionData: () => refetch() });
+
+  const [openBug] = useBoardOpenBugMutation();
+  const [closeBug] = useBoardCloseBugMutation();
+  const [changeLabels] = useBoardChangeLabelsMutation();
+  const [moveCard] = useMoveBoardCardMutation();
+  const [removeCard] = useRemoveBoardCardMutation();
+
+  if (loading || entityBoard.loading) return <CircularProgress />;
+  if (queryError) return <p>Error: {queryError.message}</p>;
+  if (entityBoard.error) return <p>Error: {entityBoard.error.message}</p>;
+  if (!data?.repository) return <p>404.</p>;
+
+  const bugs = data.repository.bugs.nodes;
+  const labels = data.repository.validLabels.nodes;
+  const boards = data.repository.boards;
+  const namespaces = labelNamespaces(labels);
+
+  const board = entityBoard.data?.repository?.board;
+  if (boardId !== null && !board) return <p>404.</p>;
+
+  let columns: Column[];
+  if (board) {
+    columns = entityColumns(board, bugs);
+  } else if (namespace === null) {
+    columns = statusColumns(bugs);
+  } else {
+    columns = labelColumns(namespace, labels, bugs);
+  }
+
+  const run = (mutation: Promise<unknown>) => {
+    setError(null);
+    mutation
+      .then(() => Promise.all([refetch(), board && entityBoard.refetch()]))
+      .catch((err) => setError(err.message));
+  };
+
+  const removeFromBoard = (prefix: string, bugId: string) =>
+    run(removeCard({ variables: { input: { prefix, bug: bugId } } }));
+
+  // emit the operation moving a bug on a kanban board, at the end of the
+  // column or before the card it was dropped on
+  const moveOnBoard = (bugId: string, column: Column, position?: number) => {
+    if (!board) return;
+
+    const from = board.columns.find((c) => c.cards.some((b) => b.id === bugId));
+    if (column.backlog) {
+      if (from) removeFromBoard(board.id, bugId);
+      return;
+    }
+
+    // the position is in the column once the card has been removed from it
+    if (from === column && position !== undefined) {
+      const current = column.cards.findIndex((b) => b.id === bugId);
+      if (current === position) return;
+      if (current < position) position--;
+    }
+
+    run(
+      moveCard({
+        variables: {
+          input: {
+            prefix: board.id,
+            bug: bugId,
+            column: column.name,
+            position: position ?? -1,
+          },
+        },
+      })
+    );
+  };
+
+  // emit the operation moving a bug to a column
+  const move = (bugId: string, column: Column, position?: number) => {
+    if (board) return moveOnBoard(bugId, column, position);
+
+    const bug = bugs.find((b) => b.id === bugId);
+    if (!bug) return;
+
+    if (namespace === null) {
+      if (bug.status === column.name) return;
+      const input = { prefix: bugId };
+      run(
+        column.name === 'OPEN'
+          ? openBug({ variables: { input } })
+          : closeBug({ variables: { input } })
+      );
+    } else {
+      // a bug has at most one label of the namespace
+      const prefix = namespace + '/';
+      const removed = bug.labels
+        .map((l) => l.name)
+        .filter((name) => name.startsWith(prefix));
+      const added = column.name === noLabelColumn ? [] : [prefix + column.name];
+      if (removed.length === added.length && removed[0] === added[0]) return;
+      run(
+        changeLabels({
+          variables: { input: { prefix: bugId, added, removed } },
+        })
+      );
+    }
+  };
+
+  // the tabs are "" for the status, "label:<namespace>" and
+  // "board:<humanId>"
+  const selectBoard = (value: string) => {
+    let search = '';
+    if (value.startsWith('label:')) {
+      search = `?namespace=${encodeURIComponent(value.slice(6))}`;
+    } else if (value.startsWith('board:')) {
+      search = `?board=${encodeURIComponent(value.slice(6))}`;
+    }
+    history.push({ pathname: '/board', search });
+  };
+
+  let tab = '';
+  if (board) {
+    tab = `board:${board.humanId}`;
+  } else if (namespace !== null) {
+    tab = `label:${namespace}`;
+  }
+
+  return (
+    <main className={classes.main}>
+      <div className={classes.header}>
+        <Tabs
+          value={tab}
+          onChange={(_, value) => selectBoard(value)}
+          variant="scrollable"
+        >
+          <Tab
+            label={intl.formatMessage({
+              id: 'board.status',
+              defaultMessage: 'Status',
+            })}
+            value=""
+          />
+          {namespaces.map((ns) => (
+            <Tab label={ns} value={`label:${ns}`} key={ns} />
+          ))}
+          {boards.map((b) => (
+            <Tab label={b.title} value={`board:${b.humanId}`} key={b.id} />
+          ))}
+        </Tabs>
+        <IfLoggedIn>
+          {() => (
+            <NewBoard
+              onCreated={(humanId) => selectBoard(`board:${humanId}`)}
+              onError={setError}
+            />
+          )}
+        </IfLoggedIn>
+      </div>
+      {error && <p className={classes.error}>{error}</p>}
+      <div className={classes.columns}>
+        {columns.map((column) => (
+          <BoardColumn
+            key={column.name}
+            column={column}
+            onDrop={(bugId, position) => move(bugId, column, position)}
+            ordered={!!board && !column.backlog}
+            onRemove={
+              board && !column.backlog
+                ? (bugId) => removeFromBoard(board.id, bugId)
+                : undefined
+            }
+          />
+        ))}
+      </div>
+    </main>
+  );
+}
+
+export default Board;
@@ -0,0 +1,206 @@
+import React, { useState } from 'react';
+
+import Button from '@material-ui/core/Button';
+import CircularProgress from '@material-ui/core/CircularProgress';
+import LinearProgress from '@material-ui/core/LinearProgress';
+import Paper from '@material-ui/core/Paper';
+import { makeStyles } from '@material-ui/core/styles';
+
+import IfLoggedIn from 'src/layout/IfLoggedIn';
+
+import {
+  BridgeInfoFragment,
+  BridgeSyncInfoFragment,
+  BridgesDocument,
+  useBridgesQuery,
+  useBridgePullMutation,
+  useBridgePushMutation,
+  useBridgeSyncProgressSubscription,
+} from './Bridges.generated';
+
+const useStyles = makeStyles((theme) => ({
+  main: {
+    maxWidth: 800,
+    margin: 'auto',
+    marginTop: theme.spacing(4),
+    marginBottom: theme.spacing(4),
+  },
+  header: {
+    ...theme.typography.h6,
+    padding: theme.spacing(2),
+  },
+  bridge: {
+    padding: theme.spacing(2),
+    borderTopColor: theme.palette.grey['300'],
+    borderTopWidth: '1px',
+    borderTopStyle: 'solid',
+  },
+  title: {
+    display: 'flex',
+    alignItems: 'center',
+    '& > *': {
+      marginRight: theme.spacing(1),
+    },
+  },
+  name: {
+    ...theme.typography.subtitle1,
+    fontWeight: 'bold',
+  },
+  target: {
+    ...theme.typography.body2,
+    color: theme.palette.text.secondary,
+    flex: 1,
+  },
+  config: {
+    ...theme.typography.body2,
+    marginTop: theme.spacing(1),
+    '& td': {
+      paddingRight: theme.spacing(2),
+    },
+    '& td:first-child': {
+      color: theme.palette.text.secondary,
+    },
+  },
+  warning: {
+    ...theme.typography.body2,
+    color: theme.palette.warning.dark,
+  },
+  error: {
+    ...theme.typography.body2,
+    color: theme.palette.error.main,
+  },
+  sync: {
+    ...theme.typography.body2,
+    marginTop: theme.spacing(1),
+  },
+  events: {
+    maxHeight: 200,
+    overflowY: 'auto',
+    backgroundColor: theme.palette.grey['100'],
+    padding: theme.spacing(1),
+    margin: theme.spacing(1, 0, 0, 0),
+  },
+}));
+
+type SyncProps = { sync: BridgeSyncInfoFragment };
+
+function SyncProgress({ sync }: SyncProps) {
+  const classes = useStyles();
+  // the synchronization is updated in the cache as it progress
+  useBridgeSyncProgressSubscription({
+    variables: { id: sync.id },
+    skip: !sync.running,
+  });
+  const action = sync.kind === 'PULL' ? 'pull' : 'push';
+  const counted = sync.kind === 'PULL' ? 'imported' : 'exported';
+
+  return (
+    <div className={classes.sync}>
+      {sync.running ? (
+        <>
+          {`Running ${action}, ${sync.count} ${counted} so far`}
+          <LinearProgress />
+        </>
+      ) : (
+        `Last ${action}: ${sync.count} ${counted}, ${sync.errors.length} errors`
+      )}
+      {sync.events.length > 0 && (
+        <pre className={classes.events}>{sync.events.join('\n')}</pre>
+      )}
+    </div>
+  );
+}
+
+type BridgeProps = { bridge: BridgeInfoFragment };
+
+function Bridge({ bridge }: BridgeProps) {
+  const classes = useStyles();
+  const [error, setError] = useState<string | null>(null);
+  const [pull, pullState] = useBridgePullMutation();
+  const [push, pushState] = useBridgePushMutation();
+
+  const running =
+    pullState.loading || pushState.loading || !!bridge.lastSync?.running;
+
+  const options = {
+    variables: { input: { name: bridge.name } },
+    refetchQueries: [{ query: BridgesDocument }],
+  };
+
+  const start = (mutation: () => Promise<unknown>) => {
+    setError(null);
+    mutation().catch((err) => setError(err.message));
+  };
+
+  return (
+    <div className={classes.bridge}>
+      <div className={classes.title}>
+        <span className={classes.name}>{bridge.name}</span>
+        <span className={classes.target}>{bridge.target}</span>
+        <IfLoggedIn>
+          {() => (
+            <>
+              <Button
+                onClick={() => start(() => pull(options))}
+                disabled={running}
+              >
+                Pull
+              </Button>
+              <Button
+                onClick={() => start(() => push(options))}
+                disabled={running}
+              >
+                Push
+              </Button>
+            </>
+          )}
+        </IfLoggedIn>
+      </div>
+      <table className={classes.config}>
+        <tbody>
+          {bridge.configuration.map(({ key, value }) => (
+            <tr key={key}>
+              <td>{key}</td>
+              <td>{value}</td>
+            </tr>
+          ))}
+        </tbody>
+      </table>
+      {bridge.credentials === 0 && (
+        <div className={classes.warning}>
+          No credentials for {bridge.target}, configure them with "git bug
+          bridge auth".
+        </div>
+      )}
+      {error && <div className={classes.error}>{error}</div>}
+      {bridge.lastSync && <SyncProgress sync={bridge.lastSync} />}
+    </div>
+  );
+}
+
+function Bridges() {
+  const classes = useStyles();
+  const { loading, error, data } = useBridgesQuery();
+
+  const bridges = data?.repository?.bridges || [];
+
+  if (loading && !data) return <CircularProgress />;
+  if (error) return <p>Error: {error.message}</p>;
+  if (!data?.repository) return <p>404.</p>;
+
+  return (
+    <Paper className={classes.main}>
+      <div className={classes.header}>Bridges</div>
+      {bridges.length === 0 && (
+        <div className={classes.bridge}>
+          No bridge configured, configure one with "git bug bridge configure".
+        </div>
+      )}
+      {bridges.map((bridge) => (
+        <Bridge bridge={bridge} key={bridge.name} />
+      ))}
+    </Paper>
+  );
+}
+
+export default Bridges;
@@ -0,0 +1,118 @@
+import React from 'react';
+
+import { makeStyles } from '@material-ui/core/styles';
+import GetAppIcon from '@material-ui/icons/GetApp';
+import InsertDriveFileIcon from '@material-ui/icons/InsertDriveFile';
+
+import basePath from 'src/basePath';
+import { FormattedNumber, useIntl } from 'src/i18n';
+
+import { BugFragment } from './Bug.generated';
+
+const useStyles = makeStyles((theme) => ({
+  list: {
+    listStyle: 'none',
+    padding: 0,
+    margin: 0,
+  },
+  attachment: {
+    marginTop: theme.spacing(1),
+    marginBottom: theme.spacing(1),
+  },
+  preview: {
+    display: 'block',
+    maxWidth: '100%',
+    maxHeight: 150,
+    border: '1px solid #ddd',
+    borderRadius: 3,
+  },
+  file: {
+    display: 'flex',
+    alignItems: 'center',
+    color: theme.palette.text.secondary,
+    '& svg': {
+      marginRight: theme.spacing(0.5),
+    },
+  },
+  details: {
+    ...theme.typography.body2,
+    display: 'flex',
+    alignItems: 'center',
+    justifyContent: 'space-between',
+    color: theme.palette.text.secondary,
+  },
+  download: {
+    display: 'flex',
+    color: 'inherit',
+  },
+}));
+
+const units = ['B', 'KB', 'MB', 'GB'];
+
+function Size({ bytes }: { bytes: number }) {
+  let value = bytes;
+  let unit = 0;
+  while (value >= 1024 && unit < units.length - 1) {
+    value /= 1024;
+    unit++;
+  }
+  return (
+    <>
+      <FormattedNumber
+        value={value}
+        maximumFractionDigits={unit === 0 ? 0 : 1}
+      />{' '}
+      {units[unit]}
+    </>
+  );
+}
+
+type Props = {
+  attachments: BugFragment['attachments'];
+};
+
+function Attachments({ attachments }: Props) {
+  const classes = useStyles();
+  const intl = useIntl();
+
+  if (attachments.length === 0) {
+    return null;
+  }
+
+  return (
+    <ul className={classes.list}>
+      {attachments.map(({ hash, size, contentType }) => {
+        const url = `${basePath}gitfile/${hash}`;
+        return (
+          <li className={classes.attachment} key={hash}>
+            <a href={url} target="_blank" rel="noopener noreferrer nofollow">
+              {contentType.startsWith('image/') ? (
+                <img className={classes.preview} src={url} alt={hash} />
+              ) : (
+                <span className={classes.file}>
+                  <InsertDriveFileIcon fontSize="small" />
+                  {contentType.split(';')[0]}
+                </span>
+              )}
+            </a>
+            <div className={classes.details}>
+              <Size bytes={size} />
+              <a
+                className={classes.download}
+                href={`${url}?download`}
+                title={intl.formatMessage({
+                  id: 'bug.download',
+                  defaultMessage: 'Download',
+                })}
+              >
+                <GetAppIcon fontSize="small" />
+              </a>
+            </div>
+          </li>
+        );
+      })}
+    </ul>
+  );
+}
+
+export default Attachments;
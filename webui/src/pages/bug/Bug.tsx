@@ -1,4 +1,5 @@
 import React from 'react';
+import { Link } from 'react-router-dom';
 
 import Typography from '@material-ui/core/Typography/Typography';
 import { makeStyles } from '@material-ui/core/styles';
@@ -6,10 +7,17 @@ import { makeStyles } from '@material-ui/core/styles';
 import Author from 'src/components/Author';
 import Date from 'src/components/Date';
 import Label from 'src/components/Label';
+import RelationGraph from 'src/components/RelationGraph';
+import { Status } from 'src/gqlTypes';
+import { FormattedMessage } from 'src/i18n';
 import IfLoggedIn from 'src/layout/IfLoggedIn';
 
+import Attachments from './Attachments';
 import { BugFragment } from './Bug.generated';
 import CommentForm from './CommentForm';
+import LabelPicker from './LabelPicker';
+import Signatures from './Signatures';
+import StatusPicker from './StatusPicker';
 import TimelineQuery from './TimelineQuery';
 
 const useStyles = makeStyles((theme) => ({
@@ -47,6 +55,13 @@ const useStyles = makeStyles((theme) => ({
   sidebarTitle: {
     fontWeight: 'bold',
   },
+  status: {
+    marginBottom: theme.spacing(2),
+  },
+  statusValue: {
+    ...theme.typography.body2,
+    marginTop: theme.spacing(1),
+  },
   labelList: {
     listStyle: 'none',
     padding: 0,
@@ -62,6 +77,19 @@ const useStyles = makeStyles((theme) => ({
   noLabel: {
     ...theme.typography.body2,
   },
+  relations: {
+    marginTop: theme.spacing(2),
+    '& a': {
+      ...theme.typography.body2,
+      display: 'block',
+    },
+  },
+  attachments: {
+    marginTop: theme.spacing(2),
+  },
+  signatures: {
+    marginTop: theme.spacing(2),
+  },
   commentForm: {
     marginLeft: 48,
   },
@@ -80,9 +108,14 @@ function Bug({ bug }: Props) {
         <span className={classes.id}>{bug.humanId}</span>
 
         <Typography color={'textSecondary'}>
-          <Author author={bug.author} />
-          {' opened this bug '}
-          <Date date={bug.createdAt} />
+          <FormattedMessage
+            id="bug.opened"
+            defaultMessage="{author} opened this bug {date}"
+            values={{
+              author: <Author author={bug.author} />,
+              date: <Date date={bug.createdAt} />,
+            }}
+          />
         </Typography>
       </div>
 
@@ -98,10 +131,28 @@ function Bug({ bug }: Props) {
           </IfLoggedIn>
         </div>
         <div className={classes.sidebar}>
-          <span className={classes.sidebarTitle}>Labels</span>
+          <div className={classes.status}>
+            <span className={classes.sidebarTitle}>
+              <FormattedMessage id="bug.status" defaultMessage="Status" />
+            </span>
+            <div className={classes.statusValue}>
+              {bug.status === Status.Open ? (
+                <FormattedMessage id="bug.open" defaultMessage="open" />
+              ) : (
+                <FormattedMessage id="bug.closed" defaultMessage="closed" />
+              )}
+              {bug.extendedStatus && ` / ${bug.extendedStatus}`}
+            </div>
+            <IfLoggedIn>{() => <StatusPicker bug={bug} />}</IfLoggedIn>
+          </div>
+          <span className={classes.sidebarTitle}>
+            <FormattedMessage id="bug.labels" defaultMessage="Labels" />
+          </span>
           <ul className={classes.labelList}>
             {bug.labels.length === 0 && (
-              <span className={classes.noLabel}>None yet</span>
+              <span className={classes.noLabel}>
+                <FormattedMessage id="bug.noLabel" defaultMessage="None yet" />
+              </span>
             )}
             {bug.labels.map((l) => (
               <li className={classes.label} key={l.name}>
@@ -109,6 +160,39 @@ function Bug({ bug }: Props) {
               </li>
             ))}
           </ul>
+          <IfLoggedIn>{() => <LabelPicker bug={bug} />}</IfLoggedIn>
+          <div className={classes.relations}>
+            <span className={classes.sidebarTitle}>
+              <FormattedMessage id="bug.relations" defaultMessage="Relations" />
+            </span>
+            <RelationGraph prefix={bug.id} width={200} height={200} />
+            <Link to={`/graph?bug=${bug.humanId}`}>
+              <FormattedMessage
+                id="bug.openGraph"
+                defaultMessage="Open the graph"
+              />
+            </Link>
+          </div>
+          {bug.attachments.length > 0 && (
+            <div className={classes.attachments}>
+              <span className={classes.sidebarTitle}>
+                <FormattedMessage
+                  id="bug.attachments"
+                  defaultMessage="Attachments"
+                />
+              </span>
+              <Attachments attachments={bug.attachments} />
+            </div>
+          )}
+          <div className={classes.signatures}>
+            <span className={classes.sidebarTitle}>
+              <FormattedMessage
+                id="bug.signatures"
+                defaultMessage="Signatures"
+              />
+            </span>
+            <Signatures id={bug.id} />
+          </div>
         </div>
       </div>
     </main>
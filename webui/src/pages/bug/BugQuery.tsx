@@ -3,6 +3,8 @@ import { RouteComponentProps } from 'react-router-dom';
 
 import CircularProgress from '@material-ui/core/CircularProgress';
 
+import { useBugChangesSubscription } from 'src/components/BugChanges.generated';
+
 import Bug from './Bug';
 import { useGetBugQuery } from './BugQuery.generated';
 
@@ -11,9 +13,14 @@ type Props = RouteComponentProps<{
 }>;
 
 const BugQuery: React.FC<Props> = ({ match }: Props) => {
-  const { loading, error, data } = useGetBugQuery({
+  const { loading, error, data, refetch } = useGetBugQuery({
     variables: { id: match.params.id },
   });
+  // reload the bug when it is changed elsewhere
+  useBugChangesSubscription({
+    variables: { prefix: match.params.id },
+    onSubscriptionData: () => refetch(),
+  });
   if (loading) return <CircularProgress />;
   if (error) return <p>Error: {error}</p>;
   if (!data?.repository?.bug) return <p>404.</p>;
@@ -0,0 +1,106 @@
+import React, { useState } from 'react';
+
+import IconButton from '@material-ui/core/IconButton';
+import Menu from '@material-ui/core/Menu';
+import MenuItem from '@material-ui/core/MenuItem';
+import MoreVertIcon from '@material-ui/icons/MoreVert';
+
+import { FormattedMessage, useIntl } from 'src/i18n';
+
+import {
+  useMinimizeCommentMutation,
+  usePinCommentMutation,
+} from './CommentActions.generated';
+import { TimelineDocument } from './TimelineQuery.generated';
+
+type Props = {
+  bugId: string;
+  comment: {
+    id: string;
+    minimized?: string | null;
+    pinned: boolean;
+  };
+  className?: string;
+};
+
+// The menu to minimize a comment as resolved or outdated and to pin it to the
+// top of the bug, as "git bug comment minimize" and "git bug comment pin"
+function CommentActions({ bugId, comment, className }: Props) {
+  const intl = useIntl();
+  const [anchor, setAnchor] = useState<HTMLElement | null>(null);
+  const [minimizeComment] = useMinimizeCommentMutation();
+  const [pinComment] = usePinCommentMutation();
+
+  const options = {
+    refetchQueries: [
+      {
+        query: TimelineDocument,
+        variables: { id: bugId, first: 100 },
+      },
+    ],
+  };
+  const input = { prefix: bugId, target: comment.id };
+
+  const minimize = (reason: string | null) => {
+    setAnchor(null);
+    minimizeComment({ variables: { input: { ...input, reason } }, ...options });
+  };
+
+  const pin = (unpin: boolean) => {
+    setAnchor(null);
+    pinComment({ variables: { input: { ...input, unpin } }, ...options });
+  };
+
+  return (
+    <>
+      <IconButton
+        size="small"
+        className={className}
+        onClick={(e) => setAnchor(e.currentTarget)}
+        title={intl.formatMessage({
+          id: 'comment.actions',
+          defaultMessage: 'Actions',
+        })}
+      >
+        <MoreVertIcon fontSize="small" />
+      </IconButton>
+      <Menu
+        anchorEl={anchor}
+        open={Boolean(anchor)}
+        onClose={() => setAnchor(null)}
+      >
+        {comment.pinned ? (
+          <MenuItem onClick={() => pin(true)}>
+            <FormattedMessage id="comment.unpin" defaultMessage="Unpin" />
+          </MenuItem>
+        ) : (
+          <MenuItem onClick={() => pin(false)}>
+            <FormattedMessage id="comment.pin" defaultMessage="Pin" />
+          </MenuItem>
+        )}
+        {comment.minimized ? (
+          <MenuItem onClick={() => minimize(null)}>
+            <FormattedMessage id="comment.restore" defaultMessage="Restore" />
+          </MenuItem>
+        ) : (
+          [
+            <MenuItem key="resolved" onClick={() => minimize('resolved')}>
+              <FormattedMessage
+                id="comment.minimizeResolved"
+                defaultMessage="Minimize as resolved"
+              />
+            </MenuItem>,
+            <MenuItem key="outdated" onClick={() => minimize('outdated')}>
+              <FormattedMessage
+                id="comment.minimizeOutdated"
+                defaultMessage="Minimize as outdated"
+              />
+            </MenuItem>,
+          ]
+        )}
+      </Menu>
+    </>
+  );
+}
+
+export default CommentActions;
@@ -2,12 +2,10 @@ import React, { useState, useRef } from 'react';
 
 import Button from '@material-ui/core/Button';
 import Paper from '@material-ui/core/Paper';
-import Tab from '@material-ui/core/Tab';
-import Tabs from '@material-ui/core/Tabs';
-import TextField from '@material-ui/core/TextField';
 import { makeStyles, Theme } from '@material-ui/core/styles';
 
-import Content from 'src/components/Content';
+import Editor from 'src/components/Editor';
+import { FormattedMessage, useIntl } from 'src/i18n';
 
 import { useAddCommentMutation } from './CommentForm.generated';
 import { TimelineDocument } from './TimelineQuery.generated';
@@ -18,52 +16,27 @@ const useStyles = makeStyles<Theme, StyleProps>((theme) => ({
     margin: theme.spacing(2, 0),
     padding: theme.spacing(0, 2, 2, 2),
   },
-  textarea: {},
-  tabContent: {
-    margin: theme.spacing(2, 0),
-  },
-  preview: {
-    borderBottom: `solid 3px ${theme.palette.grey['200']}`,
-    minHeight: '5rem',
-  },
   actions: {
     display: 'flex',
     justifyContent: 'flex-end',
+    '& > *': {
+      marginLeft: theme.spacing(1),
+    },
   },
 }));
 
-type TabPanelProps = {
-  children: React.ReactNode;
-  value: number;
-  index: number;
-} & React.HTMLProps<HTMLDivElement>;
-function TabPanel({ children, value, index, ...props }: TabPanelProps) {
-  return (
-    <div
-      role="tabpanel"
-      hidden={value !== index}
-      id={`editor-tabpanel-${index}`}
-      aria-labelledby={`editor-tab-${index}`}
-      {...props}
-    >
-      {value === index && children}
-    </div>
-  );
-}
-
-const a11yProps = (index: number) => ({
-  id: `editor-tab-${index}`,
-  'aria-controls': `editor-tabpanel-${index}`,
-});
-
 type Props = {
   bugId: string;
+  // the comment answered to, with a form closed by onClose once done
+  replyTo?: string;
+  onClose?: () => void;
 };
 
-function CommentForm({ bugId }: Props) {
+function CommentForm({ bugId, replyTo, onClose }: Props) {
+  const intl = useIntl();
   const [addComment, { loading }] = useAddCommentMutation();
   const [input, setInput] = useState<string>('');
-  const [tab, setTab] = useState(0);
+  const [files, setFiles] = useState<string[]>([]);
   const classes = useStyles({ loading });
   const form = useRef<HTMLFormElement>(null);
 
@@ -73,6 +46,9 @@ function CommentForm({ bugId }: Props) {
         input: {
           prefix: bugId,
           message: input,
+          // only keep the files still referenced in the message
+          files: files.filter((hash) => input.includes(hash)),
+          replyTo,
         },
       },
       refetchQueries: [
@@ -86,7 +62,11 @@ function CommentForm({ bugId }: Props) {
         },
       ],
       awaitRefetchQueries: true,
-    }).then(() => setInput(''));
+    }).then(() => {
+      setInput('');
+      setFiles([]);
+      if (onClose) onClose();
+    });
   };
 
   const handleSubmit = (e: React.FormEvent<HTMLFormElement>) => {
@@ -104,38 +84,53 @@ function CommentForm({ bugId }: Props) {
   return (
     <Paper className={classes.container}>
       <form onSubmit={handleSubmit} ref={form}>
-        <Tabs value={tab} onChange={(_, t) => setTab(t)}>
-          <Tab label="Write" {...a11yProps(0)} />
-          <Tab label="Preview" {...a11yProps(1)} />
-        </Tabs>
-        <div className={classes.tabContent}>
-          <TabPanel value={tab} index={0}>
-            <TextField
-              onKeyDown={handleKeyDown}
-              fullWidth
-              label="Comment"
-              placeholder="Leave a comment"
-              className={classes.textarea}
-              multiline
-              value={input}
-              variant="filled"
-              rows="4" // TODO: rowsMin support
-              onChange={(e: any) => setInput(e.target.value)}
-              disabled={loading}
-            />
-          </TabPanel>
-          <TabPanel value={tab} index={1} className={classes.preview}>
-            <Content markdown={input} />
-          </TabPanel>
-        </div>
+        <Editor
+          value={input}
+          onChange={setInput}
+          onAttach={(hash) => setFiles((f) => [...f, hash])}
+          onKeyDown={handleKeyDown}
+          label={
+            replyTo
+              ? intl.formatMessage({
+                  id: 'comment.reply',
+                  defaultMessage: 'Reply',
+                })
+              : intl.formatMessage({
+                  id: 'comment.label',
+                  defaultMessage: 'Comment',
+                })
+          }
+          placeholder={
+            replyTo
+              ? intl.formatMessage({
+                  id: 'comment.replyPlaceholder',
+                  defaultMessage: 'Leave a reply',
+                })
+              : intl.formatMessage({
+                  id: 'comment.placeholder',
+                  defaultMessage: 'Leave a comment',
+                })
+          }
+          disabled={loading}
+          focusShortcut={replyTo ? undefined : 'c'}
+        />
         <div className={classes.actions}>
+          {onClose && (
+            <Button onClick={onClose} disabled={loading}>
+              <FormattedMessage id="comment.cancel" defaultMessage="Cancel" />
+            </Button>
+          )}
           <Button
             variant="contained"
             color="primary"
             type="submit"
             disabled={loading}
           >
-            Comment
+            {replyTo ? (
+              <FormattedMessage id="comment.reply" defaultMessage="Reply" />
+            ) : (
+              <FormattedMessage id="comment.submit" defaultMessage="Comment" />
+            )}
           </Button>
         </div>
       </form>
@@ -5,6 +5,7 @@ import { makeStyles } from '@material-ui/core/styles';
 import Author from 'src/components/Author';
 import Date from 'src/components/Date';
 import Label from 'src/components/Label';
+import { FormattedMessage } from 'src/i18n';
 
 import { LabelChangeFragment } from './LabelChangeFragment.generated';
 
@@ -25,24 +26,42 @@ type Props = {
 function LabelChange({ op }: Props) {
   const { added, removed } = op;
   const classes = useStyles();
+  const values = {
+    author: <Author author={op.author} className={classes.author} />,
+    date: <Date date={op.date} />,
+  };
+  const labels = (list: typeof added) =>
+    list.map((label, index) => <Label key={index} label={label} />);
+
   return (
     <div className={classes.main}>
-      <Author author={op.author} className={classes.author} />
-      {added.length > 0 && <span> added the </span>}
-      {added.map((label, index) => (
-        <Label key={index} label={label} />
-      ))}
-      {added.length > 0 && removed.length > 0 && <span> and</span>}
-      {removed.length > 0 && <span> removed the </span>}
-      {removed.map((label, index) => (
-        <Label key={index} label={label} />
-      ))}
-      <span>
-        {' '}
-        label
-        {added.length + removed.length > 1 && 's'}{' '}
-      </span>
-      <Date date={op.date} />
+      {added.length > 0 && removed.length > 0 ? (
+        <FormattedMessage
+          id="timeline.labelsChanged"
+          defaultMessage="{author} added the {added} and removed the {removed} labels {date}"
+          values={{
+            ...values,
+            added: labels(added),
+            removed: labels(removed),
+          }}
+        />
+      ) : added.length > 0 ? (
+        <FormattedMessage
+          id="timeline.labelsAdded"
+          defaultMessage="{author} added the {labels} {count, plural, one {label} other {labels}} {date}"
+          values={{ ...values, labels: labels(added), count: added.length }}
+        />
+      ) : (
+        <FormattedMessage
+          id="timeline.labelsRemoved"
+          defaultMessage="{author} removed the {labels} {count, plural, one {label} other {labels}} {date}"
+          values={{
+            ...values,
+            labels: labels(removed),
+            count: removed.length,
+          }}
+        />
+      )}
     </div>
   );
 }
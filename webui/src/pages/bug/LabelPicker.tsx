@@ -0,0 +1,108 @@
+import React, { useState, useRef } from 'react';
+
+import Button from '@material-ui/core/Button';
+import ListItemIcon from '@material-ui/core/ListItemIcon';
+import ListItemText from '@material-ui/core/ListItemText';
+import Menu from '@material-ui/core/Menu';
+import MenuItem from '@material-ui/core/MenuItem';
+import { makeStyles } from '@material-ui/core/styles';
+import CheckIcon from '@material-ui/icons/Check';
+
+import Label from 'src/components/Label';
+import { useShortcuts } from 'src/components/Shortcuts';
+
+import { BugFragment } from './Bug.generated';
+import { GetBugDocument } from './BugQuery.generated';
+import {
+  useLabelPickerRegistryQuery,
+  useChangeLabelsMutation,
+} from './LabelPicker.generated';
+import { TimelineDocument } from './TimelineQuery.generated';
+
+const useStyles = makeStyles((theme) => ({
+  button: {
+    marginTop: theme.spacing(1),
+  },
+  item: {
+    maxWidth: 360,
+  },
+  description: {
+    whiteSpace: 'normal',
+  },
+}));
+
+type Props = {
+  bug: BugFragment;
+};
+
+// Add or remove the labels of the registry on a bug
+function LabelPicker({ bug }: Props) {
+  const classes = useStyles();
+  const [anchor, setAnchor] = useState<HTMLElement | null>(null);
+  const button = useRef<HTMLButtonElement>(null);
+  const { data } = useLabelPickerRegistryQuery();
+  const [changeLabels, { loading }] = useChangeLabelsMutation();
+
+  const current = new Set(bug.labels.map((l) => l.name));
+  const labels = (data?.repository?.labelRegistry || []).filter(
+    (l) => !l.archived || current.has(l.name)
+  );
+
+  useShortcuts({
+    l: () => {
+      if (!loading && labels.length > 0) setAnchor(button.current);
+    },
+  });
+
+  const toggle = (name: string) => {
+    const input = current.has(name)
+      ? { prefix: bug.id, removed: [name] }
+      : { prefix: bug.id, added: [name] };
+    changeLabels({
+      variables: { input },
+      refetchQueries: [
+        { query: GetBugDocument, variables: { id: bug.id } },
+        { query: TimelineDocument, variables: { id: bug.id, first: 100 } },
+      ],
+    });
+  };
+
+  return (
+    <>
+      <Button
+        size="small"
+        ref={button}
+        className={classes.button}
+        onClick={(e) => setAnchor(e.currentTarget)}
+        disabled={loading || labels.length === 0}
+      >
+        Edit labels
+      </Button>
+      <Menu
+        anchorEl={anchor}
+        open={Boolean(anchor)}
+        onClose={() => setAnchor(null)}
+      >
+        {labels.map((l) => (
+          <MenuItem
+            key={l.name}
+            className={classes.item}
+            onClick={() => toggle(l.name)}
+            disabled={loading}
+          >
+            <ListItemIcon>
+              {current.has(l.name) ? <CheckIcon /> : <span />}
+            </ListItemIcon>
+            <ListItemText
+              primary={<Label label={l} />}
+              secondary={l.description}
+              secondaryTypographyProps={{ className: classes.description }}
+            />
+          </MenuItem>
+        ))}
+      </Menu>
+    </>
+  );
+}
+
+export default LabelPicker;
@@ -1,12 +1,16 @@
-import React from 'react';
+import React, { useState } from 'react';
 
+import Button from '@material-ui/core/Button';
 import Paper from '@material-ui/core/Paper';
 import { makeStyles } from '@material-ui/core/styles';
 
 import Author, { Avatar } from 'src/components/Author';
 import Content from 'src/components/Content';
 import Date from 'src/components/Date';
+import { FormattedMessage } from 'src/i18n';
+import IfLoggedIn from 'src/layout/IfLoggedIn';
 
+import CommentActions from './CommentActions';
 import { AddCommentFragment } from './MessageCommentFragment.generated';
 import { CreateFragment } from './MessageCreateFragment.generated';
 
@@ -45,33 +49,121 @@ const useStyles = makeStyles((theme) => ({
     borderRadius: 2,
     marginLeft: '0.5rem',
   },
+  reply: {
+    marginLeft: '0.5rem',
+    padding: '0 0.5rem',
+    minWidth: 0,
+    lineHeight: 'inherit',
+  },
+  actions: {
+    marginLeft: '0.5rem',
+    padding: 0,
+  },
   body: {
     ...theme.typography.body2,
     padding: '0 1rem',
   },
 }));
 
+// the tags of the reasons to minimize a comment
+const minimizedTags: Record<string, React.ReactNode> = {
+  resolved: (
+    <FormattedMessage id="timeline.resolved" defaultMessage="Resolved" />
+  ),
+  outdated: (
+    <FormattedMessage id="timeline.outdated" defaultMessage="Outdated" />
+  ),
+};
+
 type Props = {
   op: AddCommentFragment | CreateFragment;
+  // the bug of the comment, to show the actions on the comment
+  bugId?: string;
+  onReply?: () => void;
 };
 
-function Message({ op }: Props) {
+function Message({ op, bugId, onReply }: Props) {
   const classes = useStyles();
+  // the minimized comments are collapsed until asked for
+  const [expanded, setExpanded] = useState(false);
+  const collapsed = !!op.minimized && !expanded;
+
   return (
     <article className={classes.container}>
       <Avatar author={op.author} className={classes.avatar} />
       <Paper elevation={1} className={classes.bubble}>
         <header className={classes.header}>
           <div className={classes.title}>
-            <Author className={classes.author} author={op.author} />
-            <span> commented </span>
-            <Date date={op.createdAt} />
+            <FormattedMessage
+              id="timeline.commented"
+              defaultMessage="{author} commented {date}"
+              values={{
+                author: (
+                  <Author className={classes.author} author={op.author} />
+                ),
+                date: <Date date={op.createdAt} />,
+              }}
+            />
           </div>
-          {op.edited && <div className={classes.tag}>Edited</div>}
+          {op.edited && <div className={classes.tag}>
+              <FormattedMessage id="timeline.edited" defaultMessage="Edited" />
+            </div>}
+          {op.pinned && (
+            <div className={classes.tag}>
+              <FormattedMessage id="timeline.pinned" defaultMessage="Pinned" />
+            </div>
+          )}
+          {op.minimized && (
+            <div className={classes.tag}>
+              {minimizedTags[op.minimized] || op.minimized}
+            </div>
+          )}
+          {op.minimized && (
+            <Button
+              size="small"
+              className={classes.reply}
+              onClick={() => setExpanded(!expanded)}
+            >
+              {collapsed ? (
+                <FormattedMessage id="timeline.show" defaultMessage="Show" />
+              ) : (
+                <FormattedMessage id="timeline.hide" defaultMessage="Hide" />
+              )}
+            </Button>
+          )}
+          {onReply && (
+            <IfLoggedIn>
+              {() => (
+                <Button
+                  size="small"
+                  className={classes.reply}
+                  onClick={onReply}
+                >
+                  <FormattedMessage
+                    id="timeline.reply"
+                    defaultMessage="Reply"
+                  />
+                </Button>
+              )}
+            </IfLoggedIn>
+          )}
+          {bugId && (
+            <IfLoggedIn>
+              {() => (
+                <CommentActions
+                  bugId={bugId}
+                  comment={op}
+                  className={classes.actions}
+                />
+              )}
+            </IfLoggedIn>
+          )}
         </header>
-        <section className={classes.body}>
-          <Content markdown={op.message} />
-        </section>
+        {!collapsed && (
+          <section className={classes.body}>
+            <Content markdown={op.message} />
+          </section>
+        )}
       </Paper>
     </article>
   );
@@ -5,6 +5,7 @@ import { makeStyles } from '@material-ui/core/styles';
 import { Status } from '../../gqlTypes';
 import Author from 'src/components/Author';
 import Date from 'src/components/Date';
+import { FormattedMessage } from 'src/i18n';
 
 import { SetStatusFragment } from './SetStatusFragment.generated';
 
@@ -24,15 +25,39 @@ type Props = {
 
 function SetStatus({ op }: Props) {
   const classes = useStyles();
-  const status = { [Status.Open]: 'reopened', [Status.Closed]: 'closed' }[
-    op.status
-  ];
+  const values = {
+    author: <Author author={op.author} className={classes.author} />,
+    date: <Date date={op.date} />,
+  };
+
+  // an extended status is shown by name, as it may not change the status
+  if (op.extended) {
+    return (
+      <div className={classes.main}>
+        <FormattedMessage
+          id="timeline.setExtendedStatus"
+          defaultMessage="{author} set the status to {status} {date}"
+          values={{ ...values, status: <strong>{op.extended}</strong> }}
+        />
+      </div>
+    );
+  }
 
   return (
     <div className={classes.main}>
-      <Author author={op.author} className={classes.author} />
-      <span> {status} this </span>
-      <Date date={op.date} />
+      {op.status === Status.Open ? (
+        <FormattedMessage
+          id="timeline.reopened"
+          defaultMessage="{author} reopened this {date}"
+          values={values}
+        />
+      ) : (
+        <FormattedMessage
+          id="timeline.closed"
+          defaultMessage="{author} closed this {date}"
+          values={values}
+        />
+      )}
     </div>
   );
 }
@@ -4,6 +4,7 @@ import { makeStyles } from '@material-ui/core/styles';
 
 import Author from 'src/components/Author';
 import Date from 'src/components/Date';
+import { FormattedMessage } from 'src/i18n';
 
 import { SetTitleFragment } from './SetTitleFragment.generated';
 
@@ -32,12 +33,16 @@ function SetTitle({ op }: Props) {
   const classes = useStyles();
   return (
     <div className={classes.main}>
-      <Author author={op.author} className={classes.author} />
-      <span> changed the title from </span>
-      <span className={classes.before}>{op.was}</span>
-      <span> to </span>
-      <span className={classes.after}>{op.title}</span>&nbsp;
-      <Date date={op.date} />
+      <FormattedMessage
+        id="timeline.setTitle"
+        defaultMessage="{author} changed the title from {was} to {title} {date}"
+        values={{
+          author: <Author author={op.author} className={classes.author} />,
+          was: <span className={classes.before}>{op.was}</span>,
+          title: <span className={classes.after}>{op.title}</span>,
+          date: <Date date={op.date} />,
+        }}
+      />
     </div>
   );
 }
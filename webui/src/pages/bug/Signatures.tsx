@@ -0,0 +1,152 @@
+import React from 'react';
+
+import Tooltip from '@material-ui/core/Tooltip';
+import { makeStyles } from '@material-ui/core/styles';
+import CheckCircleIcon from '@material-ui/icons/CheckCircle';
+import ErrorIcon from '@material-ui/icons/Error';
+import HelpIcon from '@material-ui/icons/Help';
+import RemoveCircleOutlineIcon from '@material-ui/icons/RemoveCircleOutline';
+
+import { AuthorSignatureStatus, SignatureStatus } from 'src/gqlTypes';
+import { FormattedMessage, useIntl } from 'src/i18n';
+
+import { useBugSignaturesQuery } from './Signatures.generated';
+
+const useStyles = makeStyles((theme) => ({
+  list: {
+    listStyle: 'none',
+    padding: 0,
+    margin: 0,
+  },
+  signature: {
+    ...theme.typography.body2,
+    display: 'flex',
+    alignItems: 'center',
+    marginTop: theme.spacing(1),
+    '& svg': {
+      marginRight: theme.spacing(0.5),
+    },
+  },
+  commit: {
+    fontFamily: 'monospace',
+    marginRight: theme.spacing(1),
+  },
+  good: {
+    color: theme.palette.success.main,
+  },
+  warning: {
+    color: theme.palette.warning.main,
+  },
+  bad: {
+    color: theme.palette.error.main,
+  },
+  none: {
+    color: theme.palette.text.secondary,
+  },
+}));
+
+type Props = { id: string };
+
+// The verification of the signature of each commit of a bug, against the
+// keyring of the user and against the keys registered on the identities of
+// the authors, as shown by "git bug show"
+function Signatures({ id }: Props) {
+  const classes = useStyles();
+  const intl = useIntl();
+  const { loading, error, data } = useBugSignaturesQuery({
+    variables: { id },
+  });
+
+  if (loading || error || !data?.repository?.bug) {
+    return null;
+  }
+
+  const statusText: Record<SignatureStatus, string> = {
+    [SignatureStatus.Good]: intl.formatMessage({
+      id: 'signature.good',
+      defaultMessage: 'Good signature',
+    }),
+    [SignatureStatus.Untrusted]: intl.formatMessage({
+      id: 'signature.untrusted',
+      defaultMessage: 'Good signature, with an untrusted key',
+    }),
+    [SignatureStatus.Unverifiable]: intl.formatMessage({
+      id: 'signature.unverifiable',
+      defaultMessage: 'Signed with an unknown key',
+    }),
+    [SignatureStatus.Bad]: intl.formatMessage({
+      id: 'signature.bad',
+      defaultMessage: 'Bad signature',
+    }),
+    [SignatureStatus.Unsigned]: intl.formatMessage({
+      id: 'signature.unsigned',
+      defaultMessage: 'Not signed',
+    }),
+  };
+
+  const authorStatusText: Record<AuthorSignatureStatus, string> = {
+    [AuthorSignatureStatus.Verified]: intl.formatMessage({
+      id: 'signature.authorVerified',
+      defaultMessage: 'signed with a key registered on the author',
+    }),
+    [AuthorSignatureStatus.Mismatch]: intl.formatMessage({
+      id: 'signature.authorMismatch',
+      defaultMessage: 'not signed with a key registered on the author',
+    }),
+    [AuthorSignatureStatus.Unsigned]: intl.formatMessage({
+      id: 'signature.authorUnsigned',
+      defaultMessage: 'not signed, while the author has a registered key',
+    }),
+    [AuthorSignatureStatus.NoKey]: intl.formatMessage({
+      id: 'signature.authorNoKey',
+      defaultMessage: 'the author has no registered key',
+    }),
+  };
+
+  return (
+    <ul className={classes.list}>
+      {data.repository.bug.signatures.map((s) => {
+        // a mismatch with the keys of the author is as bad as a bad signature
+        const mismatch = s.authorStatus === AuthorSignatureStatus.Mismatch;
+        let icon;
+        if (s.status === SignatureStatus.Bad || mismatch) {
+          icon = <ErrorIcon fontSize="small" className={classes.bad} />;
+        } else if (s.status === SignatureStatus.Good) {
+          icon = <CheckCircleIcon fontSize="small" className={classes.good} />;
+        } else if (s.status === SignatureStatus.Unsigned) {
+          icon = (
+            <RemoveCircleOutlineIcon
+              fontSize="small"
+              className={classes.none}
+            />
+          );
+        } else {
+          icon = <HelpIcon fontSize="small" className={classes.warning} />;
+        }
+
+        const details = [
+          statusText[s.status],
+          authorStatusText[s.authorStatus],
+          s.signer,
+          s.key,
+        ].filter(Boolean);
+
+        return (
+          <Tooltip key={s.commit} title={details.join(' — ')}>
+            <li className={classes.signature}>
+              {icon}
+              <span className={classes.commit}>{s.commit.slice(0, 7)}</span>
+              <FormattedMessage
+                id="signature.operations"
+                defaultMessage="{count, plural, one {# operation} other {# operations}}"
+                values={{ count: s.operationCount }}
+              />
+            </li>
+          </Tooltip>
+        );
+      })}
+    </ul>
+  );
+}
+
+export default Signatures;
@@ -0,0 +1,96 @@
+import React, { useState } from 'react';
+
+import Button from '@material-ui/core/Button';
+import ListItemIcon from '@material-ui/core/ListItemIcon';
+import ListItemText from '@material-ui/core/ListItemText';
+import Menu from '@material-ui/core/Menu';
+import MenuItem from '@material-ui/core/MenuItem';
+import { makeStyles } from '@material-ui/core/styles';
+import CheckIcon from '@material-ui/icons/Check';
+
+import { Status } from 'src/gqlTypes';
+import { FormattedMessage } from 'src/i18n';
+
+import { BugFragment } from './Bug.generated';
+import { GetBugDocument } from './BugQuery.generated';
+import {
+  useStatusPickerStatusesQuery,
+  useSetExtendedStatusMutation,
+} from './StatusPicker.generated';
+import { TimelineDocument } from './TimelineQuery.generated';
+
+const useStyles = makeStyles((theme) => ({
+  button: {
+    marginTop: theme.spacing(1),
+  },
+}));
+
+type Props = {
+  bug: BugFragment;
+};
+
+// Change the status of a bug to one of the extended statuses defined for the
+// repository, as "git bug status set"
+function StatusPicker({ bug }: Props) {
+  const classes = useStyles();
+  const [anchor, setAnchor] = useState<HTMLElement | null>(null);
+  const { data } = useStatusPickerStatusesQuery();
+  const [setExtendedStatus, { loading }] = useSetExtendedStatusMutation();
+
+  const statuses = data?.repository?.extendedStatuses || [];
+  if (statuses.length === 0) return null;
+
+  const select = (name: string) => {
+    setAnchor(null);
+    if (name === bug.extendedStatus) return;
+    setExtendedStatus({
+      variables: { input: { prefix: bug.id, status: name } },
+      refetchQueries: [
+        { query: GetBugDocument, variables: { id: bug.id } },
+        { query: TimelineDocument, variables: { id: bug.id, first: 100 } },
+      ],
+    });
+  };
+
+  return (
+    <>
+      <Button
+        size="small"
+        className={classes.button}
+        onClick={(e) => setAnchor(e.currentTarget)}
+        disabled={loading}
+      >
+        <FormattedMessage id="bug.setStatus" defaultMessage="Set status" />
+      </Button>
+      <Menu
+        anchorEl={anchor}
+        open={Boolean(anchor)}
+        onClose={() => setAnchor(null)}
+      >
+        {statuses.map((s) => (
+          <MenuItem
+            key={s.name}
+            onClick={() => select(s.name)}
+            disabled={loading}
+          >
+            <ListItemIcon>
+              {s.name === bug.extendedStatus ? <CheckIcon /> : <span />}
+            </ListItemIcon>
+            <ListItemText
+              primary={s.name}
+              secondary={
+                s.status === Status.Open ? (
+                  <FormattedMessage id="bug.open" defaultMessage="open" />
+                ) : (
+                  <FormattedMessage id="bug.closed" defaultMessage="closed" />
+                )
+              }
+            />
+          </MenuItem>
+        ))}
+      </Menu>
+    </>
+  );
+}
+
+export default StatusPicker;
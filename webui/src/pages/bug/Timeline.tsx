@@ -1,9 +1,14 @@
-import React from 'react';
+import React, { useState } from 'react';
 
 import { makeStyles } from '@material-ui/core/styles';
 
+import { FormattedMessage } from 'src/i18n';
+
+import CommentForm from './CommentForm';
 import LabelChange from './LabelChange';
 import Message from './Message';
+import { AddCommentFragment } from './MessageCommentFragment.generated';
+import { CreateFragment } from './MessageCreateFragment.generated';
 import SetStatus from './SetStatus';
 import SetTitle from './SetTitle';
 import { TimelineItemFragment } from './TimelineQuery.generated';
@@ -14,23 +19,134 @@ const useStyles = makeStyles((theme) => ({
       marginBottom: theme.spacing(2),
     },
   },
+  pinned: {
+    '& > *': {
+      marginBottom: theme.spacing(2),
+    },
+  },
+  pinnedTitle: {
+    ...theme.typography.subtitle2,
+    color: theme.palette.text.secondary,
+  },
+  replies: {
+    marginLeft: theme.spacing(5),
+    '& > *': {
+      marginTop: theme.spacing(2),
+    },
+  },
 }));
 
+type Replies = Map<string, AddCommentFragment[]>;
+
+// the replies to the comments of the timeline, by the id of the comment they
+// answer to. Like "git bug show", the replies to a comment that doesn't exist
+// are kept as top level comments.
+function replies(ops: Array<TimelineItemFragment>): Replies {
+  const known = new Set<string>();
+  ops.forEach((op) => {
+    if (
+      op.__typename === 'CreateTimelineItem' ||
+      op.__typename === 'AddCommentTimelineItem'
+    ) {
+      known.add(op.id);
+    }
+  });
+
+  const result: Replies = new Map();
+  ops.forEach((op) => {
+    if (op.__typename !== 'AddCommentTimelineItem') return;
+    if (!op.replyTo || !known.has(op.replyTo)) return;
+    result.set(op.replyTo, [...(result.get(op.replyTo) || []), op]);
+  });
+  return result;
+}
+
+type ThreadProps = {
+  bugId: string;
+  op: AddCommentFragment | CreateFragment;
+  replies: Replies;
+};
+
+// A comment, followed by the thread of its replies and the form to answer
+function Thread({ bugId, op, replies }: ThreadProps) {
+  const classes = useStyles();
+  const [replying, setReplying] = useState(false);
+  const answers = replies.get(op.id) || [];
+
+  return (
+    <div>
+      <Message op={op} bugId={bugId} onReply={() => setReplying(true)} />
+      {(answers.length > 0 || replying) && (
+        <div className={classes.replies}>
+          {answers.map((answer) => (
+            <Thread
+              key={answer.id}
+              bugId={bugId}
+              op={answer}
+              replies={replies}
+            />
+          ))}
+          {replying && (
+            <CommentForm
+              bugId={bugId}
+              replyTo={op.id}
+              onClose={() => setReplying(false)}
+            />
+          )}
+        </div>
+      )}
+    </div>
+  );
+}
+
 type Props = {
+  bugId: string;
   ops: Array<TimelineItemFragment>;
 };
 
-function Timeline({ ops }: Props) {
+function Timeline({ bugId, ops }: Props) {
   const classes = useStyles();
+  const threads = replies(ops);
+
+  // the pinned comments are repeated at the top of the bug
+  const pinned: Array<AddCommentFragment | CreateFragment> = [];
+  ops.forEach((op) => {
+    if (
+      (op.__typename === 'CreateTimelineItem' ||
+        op.__typename === 'AddCommentTimelineItem') &&
+      op.pinned
+    ) {
+      pinned.push(op);
+    }
+  });
 
   return (
     <div className={classes.main}>
+      {pinned.length > 0 && (
+        <section className={classes.pinned}>
+          <div className={classes.pinnedTitle}>
+            <FormattedMessage
+              id="timeline.pinnedComments"
+              defaultMessage="Pinned comments"
+            />
+          </div>
+          {pinned.map((op) => (
+            <Message key={op.id} op={op} bugId={bugId} />
+          ))}
+        </section>
+      )}
       {ops.map((op, index) => {
         switch (op.__typename) {
           case 'CreateTimelineItem':
-            return <Message key={index} op={op} />;
+            return (
+              <Thread key={index} bugId={bugId} op={op} replies={threads} />
+            );
           case 'AddCommentTimelineItem':
-            return <Message key={index} op={op} />;
+            // the replies are shown in the thread of their comment
+            if (op.replyTo && threads.has(op.replyTo)) return null;
+            return (
+              <Thread key={index} bugId={bugId} op={op} replies={threads} />
+            );
           case 'LabelChangeTimelineItem':
             return <LabelChange key={index} op={op} />;
           case 'SetTitleTimelineItem':
@@ -2,6 +2,8 @@ import React from 'react';
 
 import CircularProgress from '@material-ui/core/CircularProgress';
 
+import { useBugChangesSubscription } from 'src/components/BugChanges.generated';
+
 import Timeline from './Timeline';
 import { useTimelineQuery } from './TimelineQuery.generated';
 
@@ -10,12 +12,17 @@ type Props = {
 };
 
 const TimelineQuery = ({ id }: Props) => {
-  const { loading, error, data } = useTimelineQuery({
+  const { loading, error, data, refetch } = useTimelineQuery({
     variables: {
       id,
       first: 100,
     },
   });
+  // reload the timeline when the bug is changed elsewhere
+  useBugChangesSubscription({
+    variables: { prefix: id },
+    onSubscriptionData: () => refetch(),
+  });
 
   if (loading) return <CircularProgress />;
   if (error) return <p>Error: {error}</p>;
@@ -25,7 +32,7 @@ const TimelineQuery = ({ id }: Props) => {
     return null;
   }
 
-  return <Timeline ops={nodes} />;
+  return <Timeline bugId={id} ops={nodes} />;
 };
 
 export default TimelineQuery;
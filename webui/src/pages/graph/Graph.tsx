@@ -0,0 +1,45 @@
+import React from 'react';
+import { Link, useLocation } from 'react-router-dom';
+
+import Paper from '@material-ui/core/Paper';
+import { makeStyles } from '@material-ui/core/styles';
+
+import RelationGraph from 'src/components/RelationGraph';
+
+const useStyles = makeStyles((theme) => ({
+  main: {
+    maxWidth: 1000,
+    margin: 'auto',
+    marginTop: theme.spacing(4),
+    marginBottom: theme.spacing(4),
+    padding: theme.spacing(2),
+  },
+  header: {
+    ...theme.typography.h6,
+    marginBottom: theme.spacing(2),
+    '& > a': {
+      ...theme.typography.body2,
+      marginLeft: theme.spacing(2),
+    },
+  },
+}));
+
+// The relations between all the bugs, or the ones linked to the bug given
+// with ?bug=<prefix>
+function Graph() {
+  const classes = useStyles();
+  const location = useLocation();
+  const prefix = new URLSearchParams(location.search).get('bug') || undefined;
+
+  return (
+    <Paper className={classes.main}>
+      <div className={classes.header}>
+        {prefix ? `Relations of ${prefix}` : 'Relations'}
+        {prefix && <Link to="/graph">Show all the relations</Link>}
+      </div>
+      <RelationGraph prefix={prefix} width={960} height={600} />
+    </Paper>
+  );
+}
+
+export default Graph;
@@ -0,0 +1,244 @@
+import { useApolloClient } from '@apollo/client';
+import React, { useState } from 'react';
+
+import Avatar from '@material-ui/core/Avatar';
+import Button from '@material-ui/core/Button';
+import Chip from '@material-ui/core/Chip';
+import CircularProgress from '@material-ui/core/CircularProgress';
+import Paper from '@material-ui/core/Paper';
+import TextField from '@material-ui/core/TextField';
+import { makeStyles } from '@material-ui/core/styles';
+
+import IfLoggedIn from 'src/layout/IfLoggedIn';
+
+import {
+  useIdentitiesQuery,
+  useCreateIdentityMutation,
+  useSetActiveIdentityMutation,
+  IdentitiesDocument,
+  IdentityRowFragment,
+} from './Identities.generated';
+
+const useStyles = makeStyles((theme) => ({
+  main: {
+    maxWidth: 800,
+    margin: 'auto',
+    marginTop: theme.spacing(4),
+    marginBottom: theme.spacing(4),
+  },
+  header: {
+    ...theme.typography.h6,
+    padding: theme.spacing(2),
+  },
+  row: {
+    display: 'flex',
+    alignItems: 'center',
+    padding: theme.spacing(1, 2),
+    borderTopColor: theme.palette.grey['300'],
+    borderTopWidth: '1px',
+    borderTopStyle: 'solid',
+    '& > *': {
+      marginRight: theme.spacing(2),
+    },
+  },
+  details: {
+    flex: 1,
+  },
+  secondary: {
+    ...theme.typography.body2,
+    color: theme.palette.text.secondary,
+  },
+  humanId: {
+    ...theme.typography.body2,
+    fontFamily: 'monospace',
+    color: theme.palette.text.secondary,
+  },
+  form: {
+    display: 'flex',
+    alignItems: 'center',
+    flex: 1,
+    '& > *': {
+      marginRight: theme.spacing(1),
+    },
+  },
+  error: {
+    ...theme.typography.body2,
+    color: theme.palette.error.main,
+  },
+}));
+
+const refetchQueries = [{ query: IdentitiesDocument }];
+
+type CreatorProps = { onDone: () => void };
+
+// Create a new identity, for example for a contributor without git-bug
+function IdentityCreator({ onDone }: CreatorProps) {
+  const classes = useStyles();
+  const [name, setName] = useState('');
+  const [email, setEmail] = useState('');
+  const [avatarUrl, setAvatarUrl] = useState('');
+  const [error, setError] = useState<string | null>(null);
+  const [createIdentity, { loading }] = useCreateIdentityMutation();
+
+  const submit = async (e: React.FormEvent<HTMLFormElement>) => {
+    e.preventDefault();
+    setError(null);
+    try {
+      await createIdentity({
+        variables: { input: { name, email, avatarUrl: avatarUrl || null } },
+        refetchQueries,
+        awaitRefetchQueries: true,
+      });
+      onDone();
+    } catch (err) {
+      setError(err.message);
+    }
+  };
+
+  return (
+    <form className={classes.form} onSubmit={submit}>
+      <TextField
+        label="Name"
+        value={name}
+        onChange={(e) => setName(e.target.value)}
+        disabled={loading}
+        required
+      />
+      <TextField
+        label="Email"
+        type="email"
+        value={email}
+        onChange={(e) => setEmail(e.target.value)}
+        disabled={loading}
+        required
+      />
+      <TextField
+        label="Avatar URL"
+        value={avatarUrl}
+        onChange={(e) => setAvatarUrl(e.target.value)}
+        disabled={loading}
+        fullWidth
+      />
+      <Button
+        type="submit"
+        color="primary"
+        disabled={loading || !name || !email}
+      >
+        Create
+      </Button>
+      <Button onClick={onDone} disabled={loading}>
+        Cancel
+      </Button>
+      {error && <span className={classes.error}>{error}</span>}
+    </form>
+  );
+}
+
+type SwitchProps = { identity: IdentityRowFragment };
+
+// Act as another identity, in the web UI and the command line
+function SwitchButton({ identity }: SwitchProps) {
+  const client = useApolloClient();
+  const [error, setError] = useState<string | null>(null);
+  const [setActiveIdentity, { loading }] = useSetActiveIdentityMutation();
+
+  const onClick = async () => {
+    setError(null);
+    try {
+      await setActiveIdentity({
+        variables: { input: { prefix: identity.id } },
+      });
+      // everything shown as the user might have changed
+      await client.resetStore();
+    } catch (err) {
+      setError(err.message);
+    }
+  };
+
+  return (
+    <Button
+      size="small"
+      onClick={onClick}
+      disabled={loading}
+      title={error || ''}
+    >
+      {error ? 'Failed, retry' : 'Use'}
+    </Button>
+  );
+}
+
+type RowProps = {
+  identity: IdentityRowFragment;
+  active: boolean;
+  canSwitch: boolean;
+};
+
+function IdentityRow({ identity, active, canSwitch }: RowProps) {
+  const classes = useStyles();
+  const secondary = [identity.email, identity.login && `@${identity.login}`]
+    .filter(Boolean)
+    .join(' · ');
+
+  return (
+    <div className={classes.row}>
+      <Avatar src={identity.avatarUrl ? identity.avatarUrl : undefined}>
+        {identity.displayName.charAt(0).toUpperCase()}
+      </Avatar>
+      <div className={classes.details}>
+        <div>{identity.displayName}</div>
+        {secondary && <div className={classes.secondary}>{secondary}</div>}
+      </div>
+      {active && <Chip size="small" color="primary" label="You" />}
+      {!active && canSwitch && <SwitchButton identity={identity} />}
+      <span className={classes.humanId} title={identity.id}>
+        {identity.humanId}
+      </span>
+    </div>
+  );
+}
+
+function Identities() {
+  const classes = useStyles();
+  const [creating, setCreating] = useState(false);
+  const { loading, error, data } = useIdentitiesQuery();
+
+  if (loading) return <CircularProgress />;
+  if (error) return <p>Error: {error.message}</p>;
+  if (!data?.repository) return <p>404.</p>;
+
+  const identities = data.repository.allIdentities.nodes;
+  const userId = data.repository.userIdentity?.id;
+
+  return (
+    <Paper className={classes.main}>
+      <div className={classes.header}>Identities</div>
+      <IfLoggedIn>
+        {() => (
+          <div className={classes.row}>
+            {creating ? (
+              <IdentityCreator onDone={() => setCreating(false)} />
+            ) : (
+              <Button
+                variant="contained"
+                color="primary"
+                onClick={() => setCreating(true)}
+              >
+                New identity
+              </Button>
+            )}
+          </div>
+        )}
+      </IfLoggedIn>
+      {identities.map((identity) => (
+        <IdentityRow
+          identity={identity}
+          active={identity.id === userId}
+          canSwitch={!!userId}
+          key={identity.id}
+        />
+      ))}
+    </Paper>
+  );
+}
+
+export default Identities;
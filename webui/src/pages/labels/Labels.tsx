@@ -0,0 +1,243 @@
+import React, { useState } from 'react';
+
+import Button from '@material-ui/core/Button';
+import CircularProgress from '@material-ui/core/CircularProgress';
+import Paper from '@material-ui/core/Paper';
+import TextField from '@material-ui/core/TextField';
+import { makeStyles } from '@material-ui/core/styles';
+
+import Label from 'src/components/Label';
+import { LabelDefinitionFragment } from 'src/components/fragments.generated';
+import { Color } from 'src/gqlTypes';
+import IfLoggedIn from 'src/layout/IfLoggedIn';
+
+import {
+  useLabelRegistryQuery,
+  useSetLabelDefinitionMutation,
+  useRenameLabelMutation,
+  LabelRegistryDocument,
+} from './Labels.generated';
+
+const useStyles = makeStyles((theme) => ({
+  main: {
+    maxWidth: 800,
+    margin: 'auto',
+    marginTop: theme.spacing(4),
+    marginBottom: theme.spacing(4),
+  },
+  header: {
+    ...theme.typography.h6,
+    padding: theme.spacing(2),
+  },
+  row: {
+    display: 'flex',
+    alignItems: 'center',
+    padding: theme.spacing(1, 2),
+    borderTopColor: theme.palette.grey['300'],
+    borderTopWidth: '1px',
+    borderTopStyle: 'solid',
+  },
+  archived: {
+    opacity: 0.5,
+  },
+  name: {
+    flex: '0 0 200px',
+  },
+  description: {
+    ...theme.typography.body2,
+    flex: 1,
+    color: theme.palette.text.secondary,
+  },
+  form: {
+    display: 'flex',
+    alignItems: 'center',
+    flex: 1,
+    '& > *': {
+      marginRight: theme.spacing(1),
+    },
+  },
+  color: {
+    width: 48,
+    height: 32,
+    padding: 0,
+    border: 'none',
+    background: 'none',
+  },
+  error: {
+    ...theme.typography.body2,
+    color: theme.palette.error.main,
+    padding: theme.spacing(1, 2),
+  },
+}));
+
+const toHex = (color: Color) =>
+  '#' +
+  [color.R, color.G, color.B]
+    .map((c) => c.toString(16).padStart(2, '0'))
+    .join('');
+
+const refetchQueries = [{ query: LabelRegistryDocument }];
+
+type EditorProps = {
+  label?: LabelDefinitionFragment;
+  onDone: () => void;
+};
+
+// Create a label, or rename, recolor and describe an existing one
+function LabelEditor({ label, onDone }: EditorProps) {
+  const classes = useStyles();
+  const [name, setName] = useState(label ? label.name : '');
+  const [color, setColor] = useState(label ? toHex(label.color) : '#cccccc');
+  const [description, setDescription] = useState(
+    label ? label.description : ''
+  );
+  const [error, setError] = useState<string | null>(null);
+  const [setDefinition, setState] = useSetLabelDefinitionMutation();
+  const [renameLabel, renameState] = useRenameLabelMutation();
+  const loading = setState.loading || renameState.loading;
+
+  const submit = async (e: React.FormEvent<HTMLFormElement>) => {
+    e.preventDefault();
+    setError(null);
+    try {
+      if (label && label.name !== name) {
+        await renameLabel({
+          variables: { input: { name: label.name, newName: name } },
+        });
+      }
+      await setDefinition({
+        variables: { input: { name, color, description } },
+        refetchQueries,
+        awaitRefetchQueries: true,
+      });
+      onDone();
+    } catch (err) {
+      setError(err.message);
+    }
+  };
+
+  return (
+    <form className={classes.form} onSubmit={submit}>
+      <input
+        type="color"
+        className={classes.color}
+        value={color}
+        onChange={(e) => setColor(e.target.value)}
+        disabled={loading}
+        aria-label="Color"
+      />
+      <TextField
+        label="Name"
+        value={name}
+        onChange={(e) => setName(e.target.value)}
+        disabled={loading}
+        required
+      />
+      <TextField
+        label="Description"
+        value={description}
+        onChange={(e) => setDescription(e.target.value)}
+        disabled={loading}
+        fullWidth
+      />
+      <Button type="submit" color="primary" disabled={loading || !name}>
+        Save
+      </Button>
+      <Button onClick={onDone} disabled={loading}>
+        Cancel
+      </Button>
+      {error && <span className={classes.error}>{error}</span>}
+    </form>
+  );
+}
+
+type RowProps = { label: LabelDefinitionFragment };
+
+function LabelRow({ label }: RowProps) {
+  const classes = useStyles();
+  const [editing, setEditing] = useState(false);
+  const [setDefinition, { loading }] = useSetLabelDefinitionMutation();
+
+  const toggleArchived = () =>
+    setDefinition({
+      variables: { input: { name: label.name, archived: !label.archived } },
+      refetchQueries,
+    });
+
+  if (editing) {
+    return (
+      <div className={classes.row}>
+        <LabelEditor label={label} onDone={() => setEditing(false)} />
+      </div>
+    );
+  }
+
+  return (
+    <div
+      className={
+        label.archived ? `${classes.row} ${classes.archived}` : classes.row
+      }
+    >
+      <div className={classes.name}>
+        <Label label={label} />
+      </div>
+      <div className={classes.description}>
+        {label.description}
+        {label.archived && ' (archived)'}
+      </div>
+      <IfLoggedIn>
+        {() => (
+          <>
+            <Button onClick={() => setEditing(true)}>Edit</Button>
+            <Button onClick={toggleArchived} disabled={loading}>
+              {label.archived ? 'Restore' : 'Archive'}
+            </Button>
+          </>
+        )}
+      </IfLoggedIn>
+    </div>
+  );
+}
+
+function Labels() {
+  const classes = useStyles();
+  const [creating, setCreating] = useState(false);
+  const { loading, error, data } = useLabelRegistryQuery();
+
+  if (loading) return <CircularProgress />;
+  if (error) return <p>Error: {error.message}</p>;
+  if (!data?.repository) return <p>404.</p>;
+
+  const labels = data.repository.labelRegistry;
+
+  return (
+    <Paper className={classes.main}>
+      <div className={classes.header}>Labels</div>
+      <IfLoggedIn>
+        {() => (
+          <div className={classes.row}>
+            {creating ? (
+              <LabelEditor onDone={() => setCreating(false)} />
+            ) : (
+              <Button
+                variant="contained"
+                color="primary"
+                onClick={() => setCreating(true)}
+              >
+                New label
+              </Button>
+            )}
+          </div>
+        )}
+      </IfLoggedIn>
+      {labels.length === 0 && (
+        <div className={classes.row}>No label registered yet.</div>
+      )}
+      {labels.map((label) => (
+        <LabelRow label={label} key={label.name} />
+      ))}
+    </Paper>
+  );
+}
+
+export default Labels;
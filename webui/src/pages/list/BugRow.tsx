@@ -1,6 +1,7 @@
-import React from 'react';
+import React, { useEffect, useRef } from 'react';
 import { Link } from 'react-router-dom';
 
+import Checkbox from '@material-ui/core/Checkbox';
 import TableCell from '@material-ui/core/TableCell/TableCell';
 import TableRow from '@material-ui/core/TableRow/TableRow';
 import Tooltip from '@material-ui/core/Tooltip/Tooltip';
@@ -11,6 +12,7 @@ import ErrorOutline from '@material-ui/icons/ErrorOutline';
 import Date from 'src/components/Date';
 import Label from 'src/components/Label';
 import { Status } from 'src/gqlTypes';
+import { FormattedMessage } from 'src/i18n';
 
 import { BugRowFragment } from './BugRow.generated';
 
@@ -68,6 +70,14 @@ const useStyles = makeStyles((theme) => ({
     lineHeight: '1.5rem',
     color: theme.palette.text.secondary,
   },
+  extendedStatus: {
+    ...theme.typography.caption,
+    color: theme.palette.text.secondary,
+    border: `1px solid ${theme.palette.divider}`,
+    borderRadius: 2,
+    padding: theme.spacing(0, 0.5),
+    marginLeft: theme.spacing(1),
+  },
   labels: {
     paddingLeft: theme.spacing(1),
     '& > *': {
@@ -78,18 +88,41 @@ const useStyles = makeStyles((theme) => ({
 
 type Props = {
   bug: BugRowFragment;
+  selected?: boolean;
+  // checked for a bulk edit, the checkbox is only shown with onCheck
+  checked?: boolean;
+  onCheck?: (checked: boolean) => void;
 };
 
-function BugRow({ bug }: Props) {
+function BugRow({ bug, selected = false, checked = false, onCheck }: Props) {
   const classes = useStyles();
+  const row = useRef<HTMLTableRowElement>(null);
+
+  // keep the row selected with the keyboard visible
+  useEffect(() => {
+    if (selected) row.current?.scrollIntoView({ block: 'nearest' });
+  }, [selected]);
+
   return (
-    <TableRow hover>
+    <TableRow hover selected={selected} ref={row}>
       <TableCell className={classes.cell}>
+        {onCheck && (
+          <Checkbox
+            checked={checked}
+            onChange={(e) => onCheck(e.target.checked)}
+            inputProps={{ 'aria-label': bug.humanId }}
+          />
+        )}
         <BugStatus status={bug.status} className={classes.status} />
         <div className={classes.expand}>
           <Link to={'bug/' + bug.humanId}>
             <div className={classes.expand}>
               <span className={classes.title}>{bug.title}</span>
+              {bug.extendedStatus && (
+                <span className={classes.extendedStatus}>
+                  {bug.extendedStatus}
+                </span>
+              )}
               {bug.labels.length > 0 && (
                 <span className={classes.labels}>
                   {bug.labels.map((l) => (
@@ -100,9 +133,15 @@ function BugRow({ bug }: Props) {
             </div>
           </Link>
           <div className={classes.details}>
-            {bug.humanId} opened&nbsp;
-            <Date date={bug.createdAt} />
-            &nbsp;by {bug.author.displayName}
+            <FormattedMessage
+              id="list.opened"
+              defaultMessage="{id} opened {date} by {author}"
+              values={{
+                id: bug.humanId,
+                date: <Date date={bug.createdAt} />,
+                author: bug.author.displayName,
+              }}
+            />
           </div>
         </div>
       </TableCell>
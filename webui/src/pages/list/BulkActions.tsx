@@ -0,0 +1,242 @@
+import React, { useState } from 'react';
+
+import Button from '@material-ui/core/Button';
+import LinearProgress from '@material-ui/core/LinearProgress';
+import Menu from '@material-ui/core/Menu';
+import MenuItem from '@material-ui/core/MenuItem';
+import Toolbar from '@material-ui/core/Toolbar';
+import { makeStyles } from '@material-ui/core/styles';
+
+import Label from 'src/components/Label';
+import { FormattedMessage, useIntl } from 'src/i18n';
+
+import {
+  useBulkOptionsQuery,
+  useBulkOpenBugMutation,
+  useBulkCloseBugMutation,
+  useBulkChangeLabelsMutation,
+  useBulkChangeAssigneesMutation,
+  useBulkSetMilestoneMutation,
+} from './BulkActions.generated';
+
+const useStyles = makeStyles((theme) => ({
+  toolbar: {
+    backgroundColor: theme.palette.primary.light,
+    color: theme.palette.primary.contrastText,
+    margin: theme.spacing(0, -1),
+    '& > button': {
+      color: 'inherit',
+      marginRight: theme.spacing(1),
+    },
+  },
+  count: {
+    ...theme.typography.subtitle2,
+    marginRight: theme.spacing(2),
+  },
+  spacer: {
+    flex: 1,
+  },
+  errors: {
+    ...theme.typography.body2,
+    color: theme.palette.error.main,
+    padding: theme.spacing(1, 2),
+    margin: 0,
+  },
+}));
+
+type MenuItemProps = { key: string; label: React.ReactNode };
+type ActionMenuProps = {
+  label: React.ReactNode;
+  items: MenuItemProps[];
+  disabled: boolean;
+  onSelect: (key: string) => void;
+};
+
+// A button opening a menu to choose the argument of an action
+function ActionMenu({ label, items, disabled, onSelect }: ActionMenuProps) {
+  const [anchor, setAnchor] = useState<HTMLElement | null>(null);
+  return (
+    <>
+      <Button
+        onClick={(e) => setAnchor(e.currentTarget)}
+        disabled={disabled || items.length === 0}
+      >
+        {label}
+      </Button>
+      <Menu
+        anchorEl={anchor}
+        open={Boolean(anchor)}
+        onClose={() => setAnchor(null)}
+      >
+        {items.map((item) => (
+          <MenuItem
+            key={item.key}
+            onClick={() => {
+              setAnchor(null);
+              onSelect(item.key);
+            }}
+          >
+            {item.label}
+          </MenuItem>
+        ))}
+      </Menu>
+    </>
+  );
+}
+
+type Props = {
+  // the ids of the checked bugs
+  checked: string[];
+  onSelectAll: () => void;
+  onClear: () => void;
+  onDone: () => void;
+};
+
+// A bar of actions applied to all the checked bugs, one mutation per bug
+function BulkActions({ checked, onSelectAll, onClear, onDone }: Props) {
+  const classes = useStyles();
+  const intl = useIntl();
+  const { data } = useBulkOptionsQuery();
+  const [openBug] = useBulkOpenBugMutation();
+  const [closeBug] = useBulkCloseBugMutation();
+  const [changeLabels] = useBulkChangeLabelsMutation();
+  const [changeAssignees] = useBulkChangeAssigneesMutation();
+  const [setMilestone] = useBulkSetMilestoneMutation();
+  const [progress, setProgress] = useState<number | null>(null);
+  const [errors, setErrors] = useState<string[]>([]);
+
+  const labels = data?.repository?.validLabels.nodes || [];
+  const milestones = data?.repository?.milestones || [];
+  const identities = data?.repository?.allIdentities.nodes || [];
+  const running = progress !== null;
+
+  // run the action on the bugs one by one, and keep going on errors
+  const run = async (action: (prefix: string) => Promise<unknown>) => {
+    const failed: string[] = [];
+    setErrors([]);
+    for (let i = 0; i < checked.length; i++) {
+      setProgress((i / checked.length) * 100);
+      try {
+        await action(checked[i]);
+      } catch (err) {
+        failed.push(`${checked[i].slice(0, 7)}: ${err.message}`);
+      }
+    }
+    setProgress(null);
+    setErrors(failed);
+    onDone();
+  };
+
+  return (
+    <>
+      <Toolbar className={classes.toolbar} variant="dense">
+        <span className={classes.count}>
+          <FormattedMessage
+            id="bulk.selected"
+            defaultMessage="{count, plural, one {# bug selected} other {# bugs selected}}"
+            values={{ count: checked.length }}
+          />
+        </span>
+        <Button
+          disabled={running}
+          onClick={() =>
+            run((prefix) => closeBug({ variables: { input: { prefix } } }))
+          }
+        >
+          <FormattedMessage id="bulk.close" defaultMessage="Close" />
+        </Button>
+        <Button
+          disabled={running}
+          onClick={() =>
+            run((prefix) => openBug({ variables: { input: { prefix } } }))
+          }
+        >
+          <FormattedMessage id="bulk.reopen" defaultMessage="Reopen" />
+        </Button>
+        <ActionMenu
+          label={
+            <FormattedMessage id="bulk.addLabel" defaultMessage="Add label" />
+          }
+          items={labels.map((l) => ({
+            key: l.name,
+            label: <Label label={l} />,
+          }))}
+          disabled={running}
+          onSelect={(name) =>
+            run((prefix) =>
+              changeLabels({ variables: { input: { prefix, added: [name] } } })
+            )
+          }
+        />
+        <ActionMenu
+          label={
+            <FormattedMessage
+              id="bulk.removeLabel"
+              defaultMessage="Remove label"
+            />
+          }
+          items={labels.map((l) => ({
+            key: l.name,
+            label: <Label label={l} />,
+          }))}
+          disabled={running}
+          onSelect={(name) =>
+            run((prefix) =>
+              changeLabels({
+                variables: { input: { prefix, Removed: [name] } },
+              })
+            )
+          }
+        />
+        <ActionMenu
+          label={<FormattedMessage id="bulk.assign" defaultMessage="Assign" />}
+          items={identities.map((i) => ({ key: i.id, label: i.displayName }))}
+          disabled={running}
+          onSelect={(id) =>
+            run((prefix) =>
+              changeAssignees({ variables: { input: { prefix, added: [id] } } })
+            )
+          }
+        />
+        <ActionMenu
+          label={
+            <FormattedMessage id="bulk.milestone" defaultMessage="Milestone" />
+          }
+          items={[
+            {
+              key: '',
+              label: intl.formatMessage({
+                id: 'bulk.noMilestone',
+                defaultMessage: 'No milestone',
+              }),
+            },
+            ...milestones.map((m) => ({ key: m, label: m })),
+          ]}
+          disabled={running}
+          onSelect={(milestone) =>
+            run((prefix) =>
+              setMilestone({ variables: { input: { prefix, milestone } } })
+            )
+          }
+        />
+        <div className={classes.spacer} />
+        <Button disabled={running} onClick={onSelectAll}>
+          <FormattedMessage id="bulk.selectAll" defaultMessage="Select all" />
+        </Button>
+        <Button disabled={running} onClick={onClear}>
+          <FormattedMessage id="bulk.clear" defaultMessage="Clear selection" />
+        </Button>
+      </Toolbar>
+      {running && <LinearProgress variant="determinate" value={progress!} />}
+      {errors.length > 0 && (
+        <ul className={classes.errors}>
+          {errors.map((e, i) => (
+            <li key={i}>{e}</li>
+          ))}
+        </ul>
+      )}
+    </>
+  );
+}
+
+export default BulkActions;
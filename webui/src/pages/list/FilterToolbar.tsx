@@ -7,6 +7,8 @@ import { makeStyles } from '@material-ui/core/styles';
 import CheckCircleOutline from '@material-ui/icons/CheckCircleOutline';
 import ErrorOutline from '@material-ui/icons/ErrorOutline';
 
+import { FormattedMessage, useIntl } from 'src/i18n';
+
 import {
   FilterDropdown,
   FilterProps,
@@ -59,6 +61,7 @@ type Props = {
 };
 function FilterToolbar({ query, queryLocation }: Props) {
   const classes = useStyles();
+  const intl = useIntl();
   const params: Query = parse(query);
 
   const hasKey = (key: string): boolean =>
@@ -96,7 +99,7 @@ function FilterToolbar({ query, queryLocation }: Props) {
         to={pipe(toggleParam('status', 'open'), loc)(params)}
         icon={ErrorOutline}
       >
-        open
+        <FormattedMessage id="list.open" defaultMessage="open" />
       </CountingFilter>
       <CountingFilter
         active={hasValue('status', 'closed')}
@@ -108,7 +111,7 @@ function FilterToolbar({ query, queryLocation }: Props) {
         to={pipe(toggleParam('status', 'closed'), loc)(params)}
         icon={CheckCircleOutline}
       >
-        closed
+        <FormattedMessage id="list.closed" defaultMessage="closed" />
       </CountingFilter>
       <div className={classes.spacer} />
       {/*
@@ -117,16 +120,34 @@ function FilterToolbar({ query, queryLocation }: Props) {
       */}
       <FilterDropdown
         dropdown={[
-          ['id', 'ID'],
-          ['creation', 'Newest'],
-          ['creation-asc', 'Oldest'],
-          ['edit', 'Recently updated'],
-          ['edit-asc', 'Least recently updated'],
+          ['id', intl.formatMessage({ id: 'sort.id', defaultMessage: 'ID' })],
+          [
+            'creation',
+            intl.formatMessage({ id: 'sort.newest', defaultMessage: 'Newest' }),
+          ],
+          [
+            'creation-asc',
+            intl.formatMessage({ id: 'sort.oldest', defaultMessage: 'Oldest' }),
+          ],
+          [
+            'edit',
+            intl.formatMessage({
+              id: 'sort.recentlyUpdated',
+              defaultMessage: 'Recently updated',
+            }),
+          ],
+          [
+            'edit-asc',
+            intl.formatMessage({
+              id: 'sort.leastRecentlyUpdated',
+              defaultMessage: 'Least recently updated',
+            }),
+          ],
         ]}
         itemActive={(key) => hasValue('sort', key)}
         to={(key) => pipe(replaceParam('sort', key), loc)(params)}
       >
-        Sort
+        <FormattedMessage id="list.sort" defaultMessage="Sort" />
       </FilterDropdown>
     </Toolbar>
   );
@@ -1,18 +1,57 @@
-import React from 'react';
+import React, { useState } from 'react';
+import { useHistory } from 'react-router-dom';
 
 import Table from '@material-ui/core/Table/Table';
 import TableBody from '@material-ui/core/TableBody/TableBody';
 
+import { useShortcuts } from 'src/components/Shortcuts';
+
 import BugRow from './BugRow';
 import { BugListFragment } from './ListQuery.generated';
 
-type Props = { bugs: BugListFragment };
-function List({ bugs }: Props) {
+type Props = {
+  bugs: BugListFragment;
+  // the ids of the bugs checked for a bulk edit, if enabled
+  checked?: string[];
+  onCheck?: (checked: string[]) => void;
+};
+function List({ bugs, checked, onCheck }: Props) {
+  const history = useHistory();
+  // the row selected with the keyboard, if any
+  const [selected, setSelected] = useState<number | null>(null);
+  const count = bugs.edges.length;
+
+  useShortcuts({
+    j: () =>
+      setSelected((s) => (s === null ? 0 : Math.min(s + 1, count - 1))),
+    k: () => setSelected((s) => (s === null ? 0 : Math.max(s - 1, 0))),
+    o: () => {
+      if (selected !== null && selected < count) {
+        history.push('/bug/' + bugs.edges[selected].node.humanId);
+      }
+    },
+  });
+
   return (
     <Table>
       <TableBody>
-        {bugs.edges.map(({ cursor, node }) => (
-          <BugRow bug={node} key={cursor} />
+        {bugs.edges.map(({ cursor, node }, i) => (
+          <BugRow
+            bug={node}
+            key={cursor}
+            selected={i === selected}
+            checked={checked?.includes(node.id)}
+            onCheck={
+              onCheck &&
+              checked &&
+              ((c) =>
+                onCheck(
+                  c
+                    ? [...checked, node.id]
+                    : checked.filter((id) => id !== node.id)
+                ))
+            }
+          />
         ))}
       </TableBody>
     </Table>
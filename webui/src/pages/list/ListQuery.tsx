@@ -2,6 +2,7 @@ import { ApolloError } from '@apollo/client';
 import React, { useState, useEffect, useRef } from 'react';
 import { useLocation, useHistory, Link } from 'react-router-dom';
 
+import Button from '@material-ui/core/Button';
 import IconButton from '@material-ui/core/IconButton';
 import InputBase from '@material-ui/core/InputBase';
 import Paper from '@material-ui/core/Paper';
@@ -11,13 +12,27 @@ import KeyboardArrowLeft from '@material-ui/icons/KeyboardArrowLeft';
 import KeyboardArrowRight from '@material-ui/icons/KeyboardArrowRight';
 import Skeleton from '@material-ui/lab/Skeleton';
 
+import { useBugChangesSubscription } from 'src/components/BugChanges.generated';
+import { useShortcuts } from 'src/components/Shortcuts';
+import { FormattedList, FormattedMessage, useIntl } from 'src/i18n';
+import { useCurrentIdentityQuery } from 'src/layout/CurrentIdentity.generated';
+import IfLoggedIn from 'src/layout/IfLoggedIn';
+
+import BulkActions from './BulkActions';
 import FilterToolbar from './FilterToolbar';
 import List from './List';
 import { useListBugsQuery } from './ListQuery.generated';
+import SavedQueries from './SavedQueries';
 
 type StylesProps = { searching?: boolean };
 const useStyles = makeStyles<Theme, StylesProps>((theme) => ({
+  layout: {
+    display: 'flex',
+    justifyContent: 'center',
+    padding: theme.spacing(0, 2),
+  },
   main: {
+    flex: 1,
     maxWidth: 800,
     margin: 'auto',
     marginTop: theme.spacing(4),
@@ -138,7 +153,86 @@ const NoBug = () => {
   return (
     <div className={classes.message}>
       <ErrorOutline fontSize="large" />
-      <p>No results matched your search.</p>
+      <p>
+        <FormattedMessage
+          id="list.noResult"
+          defaultMessage="No results matched your search."
+        />
+      </p>
+    </div>
+  );
+};
+
+// Location of an error in a query, as given by the GraphQL error extensions
+type QueryParseError = {
+  code: string;
+  query: string;
+  offset: number;
+  token: string;
+  reason: string;
+  suggestions: string[];
+};
+
+function findQueryParseError(
+  error: ApolloError
+): QueryParseError | undefined {
+  const found = error.graphQLErrors.find(
+    (e) => e.extensions?.code === 'QUERY_PARSE_ERROR'
+  );
+  return found?.extensions as QueryParseError | undefined;
+}
+
+// The offset is in bytes of the UTF-8 query, convert it to characters
+function queryColumn(query: string, offset: number) {
+  const bytes = new TextEncoder().encode(query).slice(0, offset);
+  return new TextDecoder().decode(bytes).length;
+}
+
+type QueryErrorProps = { parseError: QueryParseError };
+const QueryError: React.FC<QueryErrorProps> = ({
+  parseError,
+}: QueryErrorProps) => {
+  const classes = useStyles({});
+  const column = queryColumn(parseError.query, parseError.offset);
+  const pointer =
+    ' '.repeat(column) +
+    '^' +
+    '~'.repeat(Math.max(parseError.token.length - 1, 0));
+  return (
+    <div className={[classes.errorBox, classes.message].join(' ')}>
+      <ErrorOutline fontSize="large" />
+      <p>
+        <FormattedMessage
+          id="list.invalidQuery"
+          defaultMessage="Invalid query: {reason}"
+          values={{ reason: parseError.reason }}
+        />
+      </p>
+      {parseError.suggestions.length > 0 && (
+        <p>
+          <FormattedMessage
+            id="list.didYouMean"
+            defaultMessage="Did you mean {suggestions}?"
+            values={{
+              suggestions: (
+                <FormattedList
+                  type="disjunction"
+                  value={parseError.suggestions.map((s) => (
+                    <code key={s}>{s}</code>
+                  ))}
+                />
+              ),
+            }}
+          />
+        </p>
+      )}
+      <pre>
+        <code>
+          {parseError.query}
+          {'\n'}
+          {pointer}
+        </code>
+      </pre>
     </div>
   );
 };
@@ -146,10 +240,19 @@ const NoBug = () => {
 type ErrorProps = { error: ApolloError };
 const Error: React.FC<ErrorProps> = ({ error }: ErrorProps) => {
   const classes = useStyles({});
+  const parseError = findQueryParseError(error);
+  if (parseError) {
+    return <QueryError parseError={parseError} />;
+  }
   return (
     <div className={[classes.errorBox, classes.message].join(' ')}>
       <ErrorOutline fontSize="large" />
-      <p>There was an error while fetching bug.</p>
+      <p>
+        <FormattedMessage
+          id="list.error"
+          defaultMessage="There was an error while fetching bug."
+        />
+      </p>
       <p>
         <em>{error.message}</em>
       </p>
@@ -161,12 +264,20 @@ const Error: React.FC<ErrorProps> = ({ error }: ErrorProps) => {
 };
 
 function ListQuery() {
+  const intl = useIntl();
   const location = useLocation();
   const history = useHistory();
   const params = new URLSearchParams(location.search);
   const query = params.has('q') ? params.get('q') || '' : 'status:open';
 
   const [input, setInput] = useState(query);
+  // the bugs checked for a bulk edit, only possible when logged in
+  const [checked, setChecked] = useState<string[]>([]);
+  const identity = useCurrentIdentityQuery();
+  const canEdit = !!identity.data?.repository?.userIdentity;
+  const search = useRef<HTMLInputElement>(null);
+
+  useShortcuts({ '/': () => search.current?.focus() });
 
   const classes = useStyles({ searching: !!input });
 
@@ -175,6 +286,7 @@ function ListQuery() {
   useEffect(() => {
     if (query !== lastQuery.current) {
       setInput(query);
+      setChecked([]);
     }
     lastQuery.current = query;
   }, [query, input, lastQuery]);
@@ -194,12 +306,14 @@ function ListQuery() {
 
   const perPage = (page.first || page.last || 10).toString();
 
-  const { loading, error, data } = useListBugsQuery({
+  const { loading, error, data, refetch } = useListBugsQuery({
     variables: {
       ...page,
       query,
     },
   });
+  // reload the list when any bug changes
+  useBugChangesSubscription({ onSubscriptionData: () => refetch() });
 
   let nextPage = null;
   let previousPage = null;
@@ -259,7 +373,11 @@ function ListQuery() {
     if (bugs.totalCount === 0) {
       content = <NoBug />;
     } else {
-      content = <List bugs={bugs} />;
+      content = canEdit ? (
+        <List bugs={bugs} checked={checked} onCheck={setChecked} />
+      ) : (
+        <List bugs={bugs} />
+      );
     }
   }
 
@@ -269,48 +387,91 @@ function ListQuery() {
   };
 
   return (
-    <Paper className={classes.main}>
-      <header className={classes.header}>
-        <h1>Issues</h1>
-        <form onSubmit={formSubmit}>
-          <InputBase
-            placeholder="Filter"
-            value={input}
-            onInput={(e: any) => setInput(e.target.value)}
-            classes={{
-              root: classes.search,
-              focused: classes.searchFocused,
-            }}
+    <div className={classes.layout}>
+      <SavedQueries query={query} queryLocation={queryLocation} />
+      <Paper className={classes.main}>
+        <header className={classes.header}>
+          <h1>
+            <FormattedMessage id="list.title" defaultMessage="Issues" />
+          </h1>
+          <form onSubmit={formSubmit}>
+            <InputBase
+              placeholder={intl.formatMessage({
+                id: 'list.filter',
+                defaultMessage: 'Filter',
+              })}
+              inputRef={search}
+              value={input}
+              onInput={(e: any) => setInput(e.target.value)}
+              classes={{
+                root: classes.search,
+                focused: classes.searchFocused,
+              }}
+            />
+            <button type="submit" hidden>
+              Search
+            </button>
+          </form>
+          <IfLoggedIn>
+            {() => (
+              <Button
+                component={Link}
+                to="/new"
+                variant="contained"
+                color="primary"
+              >
+                <FormattedMessage id="list.newBug" defaultMessage="New bug" />
+              </Button>
+            )}
+          </IfLoggedIn>
+        </header>
+        <FilterToolbar query={query} queryLocation={queryLocation} />
+        {checked.length > 0 && (
+          <BulkActions
+            checked={checked}
+            onSelectAll={() =>
+              setChecked(
+                data?.repository?.bugs.edges.map((e) => e.node.id) || []
+              )
+            }
+            onClear={() => setChecked([])}
+            onDone={() => refetch()}
           />
-          <button type="submit" hidden>
-            Search
-          </button>
-        </form>
-      </header>
-      <FilterToolbar query={query} queryLocation={queryLocation} />
-      {content}
-      <div className={classes.pagination}>
-        {previousPage ? (
-          <IconButton component={Link} to={previousPage}>
-            <KeyboardArrowLeft />
-          </IconButton>
-        ) : (
-          <IconButton disabled>
-            <KeyboardArrowLeft />
-          </IconButton>
         )}
-        <div>{loading ? 'Loading' : `Total: ${count}`}</div>
-        {nextPage ? (
-          <IconButton component={Link} to={nextPage}>
-            <KeyboardArrowRight />
-          </IconButton>
-        ) : (
-          <IconButton disabled>
-            <KeyboardArrowRight />
-          </IconButton>
-        )}
-      </div>
-    </Paper>
+        {content}
+        <div className={classes.pagination}>
+          {previousPage ? (
+            <IconButton component={Link} to={previousPage}>
+              <KeyboardArrowLeft />
+            </IconButton>
+          ) : (
+            <IconButton disabled>
+              <KeyboardArrowLeft />
+            </IconButton>
+          )}
+          <div>
+            {loading ? (
+              <FormattedMessage id="list.loading" defaultMessage="Loading" />
+            ) : (
+              <FormattedMessage
+                id="list.total"
+                defaultMessage="Total: {count}"
+                values={{ count }}
+              />
+            )}
+          </div>
+          {nextPage ? (
+            <IconButton component={Link} to={nextPage}>
+              <KeyboardArrowRight />
+            </IconButton>
+          ) : (
+            <IconButton disabled>
+              <KeyboardArrowRight />
+            </IconButton>
+          )}
+        </div>
+      </Paper>
+    </div>
   );
 }
 
@@ -0,0 +1,210 @@
+import { LocationDescriptor } from 'history';
+import React, { useState, useEffect } from 'react';
+import { Link } from 'react-router-dom';
+
+import Button from '@material-ui/core/Button';
+import IconButton from '@material-ui/core/IconButton';
+import Paper from '@material-ui/core/Paper';
+import TextField from '@material-ui/core/TextField';
+import { makeStyles } from '@material-ui/core/styles';
+import Close from '@material-ui/icons/Close';
+
+import { FormattedMessage, useIntl } from 'src/i18n';
+import IfLoggedIn from 'src/layout/IfLoggedIn';
+
+import {
+  useSavedQueriesQuery,
+  useSaveQueryMutation,
+  useRemoveSavedQueryMutation,
+  SavedQueriesDocument,
+} from './SavedQueries.generated';
+
+const useStyles = makeStyles((theme) => ({
+  sidebar: {
+    width: 220,
+    flexShrink: 0,
+    alignSelf: 'flex-start',
+    marginTop: theme.spacing(4),
+    marginRight: theme.spacing(2),
+  },
+  header: {
+    ...theme.typography.overline,
+    padding: theme.spacing(1, 2),
+  },
+  item: {
+    display: 'flex',
+    alignItems: 'center',
+    padding: theme.spacing(0, 1, 0, 2),
+    borderTopColor: theme.palette.grey['300'],
+    borderTopWidth: '1px',
+    borderTopStyle: 'solid',
+  },
+  link: {
+    ...theme.typography.body2,
+    flex: 1,
+    padding: theme.spacing(1, 0),
+    color: theme.palette.text.primary,
+    textDecoration: 'none',
+    overflow: 'hidden',
+    textOverflow: 'ellipsis',
+  },
+  active: {
+    fontWeight: 'bold',
+  },
+  form: {
+    display: 'flex',
+    alignItems: 'flex-end',
+    padding: theme.spacing(1, 2),
+    borderTopColor: theme.palette.grey['300'],
+    borderTopWidth: '1px',
+    borderTopStyle: 'solid',
+  },
+  error: {
+    ...theme.typography.caption,
+    color: theme.palette.error.main,
+    padding: theme.spacing(0, 2, 1),
+  },
+  actions: {
+    padding: theme.spacing(1, 2),
+    borderTopColor: theme.palette.grey['300'],
+    borderTopWidth: '1px',
+    borderTopStyle: 'solid',
+  },
+}));
+
+const refetchQueries = [{ query: SavedQueriesDocument }];
+
+type SaveFormProps = { query: string };
+
+// Save the current query under a name, or replace a saved query
+function SaveForm({ query }: SaveFormProps) {
+  const classes = useStyles();
+  const intl = useIntl();
+  const [name, setName] = useState('');
+  const [error, setError] = useState<string | null>(null);
+  const [saveQuery, { loading }] = useSaveQueryMutation();
+
+  const submit = async (e: React.FormEvent<HTMLFormElement>) => {
+    e.preventDefault();
+    setError(null);
+    try {
+      await saveQuery({
+        variables: { input: { name, query } },
+        refetchQueries,
+        awaitRefetchQueries: true,
+      });
+      setName('');
+    } catch (err) {
+      setError(err.message);
+    }
+  };
+
+  return (
+    <>
+      <form className={classes.form} onSubmit={submit}>
+        <TextField
+          label={intl.formatMessage({
+            id: 'saved.saveAs',
+            defaultMessage: 'Save this filter as',
+          })}
+          value={name}
+          onChange={(e) => setName(e.target.value)}
+          disabled={loading}
+          size="small"
+        />
+        <Button type="submit" color="primary" disabled={loading || !name}>
+          <FormattedMessage id="saved.save" defaultMessage="Save" />
+        </Button>
+      </form>
+      {error && <div className={classes.error}>{error}</div>}
+    </>
+  );
+}
+
+type Props = {
+  query: string;
+  queryLocation: (query: string) => LocationDescriptor;
+};
+
+// The saved queries, shared with the command line, as a sidebar of filters
+function SavedQueries({ query, queryLocation }: Props) {
+  const classes = useStyles();
+  const intl = useIntl();
+  const [copied, setCopied] = useState(false);
+  useEffect(() => setCopied(false), [query]);
+  const { data } = useSavedQueriesQuery();
+  const [removeSavedQuery] = useRemoveSavedQueryMutation();
+
+  const saved = data?.repository?.savedQueries || [];
+
+  const remove = (name: string) =>
+    removeSavedQuery({ variables: { input: { name } }, refetchQueries });
+
+  // The URL holds the full state of the list: query, sort and page
+  const copyLink = async () => {
+    await navigator.clipboard.writeText(window.location.href);
+    setCopied(true);
+  };
+
+  return (
+    <Paper className={classes.sidebar}>
+      <div className={classes.header}>
+        <FormattedMessage id="saved.title" defaultMessage="Saved filters" />
+      </div>
+      {saved.length === 0 && (
+        <div className={classes.item}>
+          <span className={classes.link}>
+            <FormattedMessage
+              id="saved.none"
+              defaultMessage="No saved filter yet."
+            />
+          </span>
+        </div>
+      )}
+      {saved.map((s) => (
+        <div className={classes.item} key={s.name}>
+          <Link
+            to={queryLocation(`@${s.name}`)}
+            title={s.query}
+            className={
+              query === `@${s.name}`
+                ? `${classes.link} ${classes.active}`
+                : classes.link
+            }
+          >
+            @{s.name}
+          </Link>
+          <IfLoggedIn>
+            {() => (
+              <IconButton
+                size="small"
+                aria-label={intl.formatMessage(
+                  { id: 'saved.remove', defaultMessage: 'Remove {name}' },
+                  { name: `@${s.name}` }
+                )}
+                onClick={() => remove(s.name)}
+              >
+                <Close fontSize="small" />
+              </IconButton>
+            )}
+          </IfLoggedIn>
+        </div>
+      ))}
+      <IfLoggedIn>{() => <SaveForm query={query} />}</IfLoggedIn>
+      <div className={classes.actions}>
+        <Button size="small" onClick={copyLink}>
+          {copied ? (
+            <FormattedMessage id="saved.copied" defaultMessage="Link copied" />
+          ) : (
+            <FormattedMessage
+              id="saved.copyLink"
+              defaultMessage="Copy link to this view"
+            />
+          )}
+        </Button>
+      </div>
+    </Paper>
+  );
+}
+
+export default SavedQueries;
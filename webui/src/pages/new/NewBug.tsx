@@ -0,0 +1,203 @@
+import React, { useEffect, useState } from 'react';
+import { useHistory, useLocation } from 'react-router-dom';
+
+import Button from '@material-ui/core/Button';
+import CircularProgress from '@material-ui/core/CircularProgress';
+import MenuItem from '@material-ui/core/MenuItem';
+import Paper from '@material-ui/core/Paper';
+import TextField from '@material-ui/core/TextField';
+import { makeStyles } from '@material-ui/core/styles';
+
+import Editor from 'src/components/Editor';
+import { FormattedMessage, useIntl } from 'src/i18n';
+import { useCurrentIdentityQuery } from 'src/layout/CurrentIdentity.generated';
+
+import { useNewBugMutation, useNewBugTemplatesQuery } from './NewBug.generated';
+
+const useStyles = makeStyles((theme) => ({
+  main: {
+    maxWidth: 800,
+    margin: 'auto',
+    marginTop: theme.spacing(4),
+    marginBottom: theme.spacing(4),
+    padding: theme.spacing(2),
+  },
+  header: {
+    ...theme.typography.h6,
+    marginBottom: theme.spacing(2),
+  },
+  form: {
+    display: 'flex',
+    flexDirection: 'column',
+    '& > *': {
+      marginBottom: theme.spacing(2),
+    },
+  },
+  actions: {
+    display: 'flex',
+    alignItems: 'center',
+    '& > *': {
+      marginRight: theme.spacing(2),
+    },
+  },
+  error: {
+    ...theme.typography.body2,
+    color: theme.palette.error.main,
+  },
+}));
+
+// Create a new bug, optionally starting from a template of the repository,
+// preselected with ?template=name
+function NewBug() {
+  const classes = useStyles();
+  const intl = useIntl();
+  const history = useHistory();
+  const location = useLocation();
+  const user = useCurrentIdentityQuery();
+  const { loading, error, data } = useNewBugTemplatesQuery();
+  const [newBug, newBugState] = useNewBugMutation();
+  const [template, setTemplate] = useState('');
+  const [title, setTitle] = useState('');
+  const [message, setMessage] = useState('');
+  const [files, setFiles] = useState<string[]>([]);
+  const [createError, setCreateError] = useState<string | null>(null);
+
+  const templates = data?.repository?.templates || [];
+  const selected = templates.find((t) => t.name === template);
+
+  const selectTemplate = (name: string) => {
+    const tmpl = templates.find((t) => t.name === name);
+    setTemplate(name);
+    setTitle(tmpl ? tmpl.title : '');
+    setMessage(tmpl ? tmpl.message : '');
+  };
+
+  const preselected = new URLSearchParams(location.search).get('template');
+  useEffect(() => {
+    const tmpl = data?.repository?.templates.find(
+      (t) => t.name === preselected
+    );
+    if (tmpl) {
+      setTemplate(tmpl.name);
+      setTitle(tmpl.title);
+      setMessage(tmpl.message);
+    }
+  }, [data, preselected]);
+
+  if (loading || user.loading) return <CircularProgress />;
+  if (error) return <p>Error: {error.message}</p>;
+  if (!user.data?.repository?.userIdentity) {
+    return (
+      <Paper className={classes.main}>
+        <FormattedMessage
+          id="new.loggedOut"
+          defaultMessage="You need to be logged in to create a bug."
+        />
+      </Paper>
+    );
+  }
+
+  const submit = async (e: React.FormEvent<HTMLFormElement>) => {
+    e.preventDefault();
+    setCreateError(null);
+    try {
+      const result = await newBug({
+        variables: {
+          input: {
+            title,
+            message,
+            // only keep the files still referenced in the message
+            files: files.filter((hash) => message.includes(hash)),
+            template: template || null,
+          },
+        },
+      });
+      const bug = result.data?.newBug.bug;
+      if (bug) {
+        history.push(`/bug/${bug.humanId}`);
+      }
+    } catch (err) {
+      setCreateError(err.message);
+    }
+  };
+
+  return (
+    <Paper className={classes.main}>
+      <div className={classes.header}>
+        <FormattedMessage id="new.title" defaultMessage="New bug" />
+      </div>
+      <form className={classes.form} onSubmit={submit}>
+        {templates.length > 0 && (
+          <TextField
+            select
+            label={intl.formatMessage({
+              id: 'new.template',
+              defaultMessage: 'Template',
+            })}
+            value={template}
+            onChange={(e) => selectTemplate(e.target.value)}
+            disabled={newBugState.loading}
+            helperText={
+              selected && selected.labels.length > 0
+                ? intl.formatMessage(
+                    {
+                      id: 'new.templateLabels',
+                      defaultMessage: 'Labels added: {labels}',
+                    },
+                    { labels: selected.labels.join(', ') }
+                  )
+                : undefined
+            }
+          >
+            <MenuItem value="">
+              <FormattedMessage id="new.noTemplate" defaultMessage="None" />
+            </MenuItem>
+            {templates.map((t) => (
+              <MenuItem key={t.name} value={t.name}>
+                {t.name}
+              </MenuItem>
+            ))}
+          </TextField>
+        )}
+        <TextField
+          label={intl.formatMessage({
+            id: 'new.bugTitle',
+            defaultMessage: 'Title',
+          })}
+          value={title}
+          onChange={(e) => setTitle(e.target.value)}
+          disabled={newBugState.loading}
+          required
+          autoFocus
+        />
+        <Editor
+          value={message}
+          onChange={setMessage}
+          onAttach={(hash) => setFiles((f) => [...f, hash])}
+          label={intl.formatMessage({
+            id: 'new.message',
+            defaultMessage: 'Message',
+          })}
+          placeholder={intl.formatMessage({
+            id: 'new.messagePlaceholder',
+            defaultMessage: 'Describe the bug',
+          })}
+          disabled={newBugState.loading}
+        />
+        <div className={classes.actions}>
+          <Button
+            type="submit"
+            variant="contained"
+            color="primary"
+            disabled={newBugState.loading || !title.trim()}
+          >
+            <FormattedMessage id="new.submit" defaultMessage="Create" />
+          </Button>
+          {createError && <span className={classes.error}>{createError}</span>}
+        </div>
+      </form>
+    </Paper>
+  );
+}
+
+export default NewBug;
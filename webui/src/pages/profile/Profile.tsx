@@ -0,0 +1,154 @@
+import React, { useState, useEffect } from 'react';
+
+import Avatar from '@material-ui/core/Avatar';
+import Button from '@material-ui/core/Button';
+import CircularProgress from '@material-ui/core/CircularProgress';
+import Paper from '@material-ui/core/Paper';
+import TextField from '@material-ui/core/TextField';
+import { makeStyles } from '@material-ui/core/styles';
+
+import { CurrentIdentityDocument } from 'src/layout/CurrentIdentity.generated';
+
+import { useProfileQuery, useUpdateProfileMutation } from './Profile.generated';
+
+const useStyles = makeStyles((theme) => ({
+  main: {
+    maxWidth: 600,
+    margin: 'auto',
+    marginTop: theme.spacing(4),
+    marginBottom: theme.spacing(4),
+    padding: theme.spacing(2),
+  },
+  header: {
+    ...theme.typography.h6,
+    display: 'flex',
+    alignItems: 'center',
+    marginBottom: theme.spacing(2),
+    '& > *': {
+      marginRight: theme.spacing(2),
+    },
+  },
+  humanId: {
+    ...theme.typography.body2,
+    fontFamily: 'monospace',
+    color: theme.palette.text.secondary,
+  },
+  form: {
+    display: 'flex',
+    flexDirection: 'column',
+    '& > *': {
+      marginBottom: theme.spacing(2),
+    },
+  },
+  actions: {
+    display: 'flex',
+    alignItems: 'center',
+    '& > *': {
+      marginRight: theme.spacing(2),
+    },
+  },
+  error: {
+    ...theme.typography.body2,
+    color: theme.palette.error.main,
+  },
+  message: {
+    ...theme.typography.body2,
+    color: theme.palette.text.secondary,
+  },
+}));
+
+// Edit the name, email and avatar of the identity of the user
+function Profile() {
+  const classes = useStyles();
+  const { loading, error, data } = useProfileQuery();
+  const [updateProfile, updateState] = useUpdateProfileMutation();
+  const [name, setName] = useState('');
+  const [email, setEmail] = useState('');
+  const [avatarUrl, setAvatarUrl] = useState('');
+  const [message, setMessage] = useState<string | null>(null);
+  const [updateError, setUpdateError] = useState<string | null>(null);
+
+  const user = data?.repository?.userIdentity;
+  useEffect(() => {
+    if (user) {
+      setName(user.name || '');
+      setEmail(user.email || '');
+      setAvatarUrl(user.avatarUrl || '');
+    }
+  }, [user]);
+
+  if (loading) return <CircularProgress />;
+  if (error) return <p>Error: {error.message}</p>;
+  if (!user) {
+    return (
+      <Paper className={classes.main}>
+        You need to be logged in to edit your profile.
+      </Paper>
+    );
+  }
+
+  const submit = async (e: React.FormEvent<HTMLFormElement>) => {
+    e.preventDefault();
+    setMessage(null);
+    setUpdateError(null);
+    try {
+      await updateProfile({
+        variables: { input: { name, email, avatarUrl } },
+        refetchQueries: [{ query: CurrentIdentityDocument }],
+      });
+      setMessage('Profile updated.');
+    } catch (err) {
+      setUpdateError(err.message);
+    }
+  };
+
+  return (
+    <Paper className={classes.main}>
+      <div className={classes.header}>
+        <Avatar src={user.avatarUrl ? user.avatarUrl : undefined}>
+          {user.displayName.charAt(0).toUpperCase()}
+        </Avatar>
+        <span>{user.displayName}</span>
+        <span className={classes.humanId} title={user.id}>
+          {user.humanId}
+        </span>
+      </div>
+      <form className={classes.form} onSubmit={submit}>
+        <TextField
+          label="Name"
+          value={name}
+          onChange={(e) => setName(e.target.value)}
+          disabled={updateState.loading}
+          required
+        />
+        <TextField
+          label="Email"
+          type="email"
+          value={email}
+          onChange={(e) => setEmail(e.target.value)}
+          disabled={updateState.loading}
+        />
+        <TextField
+          label="Avatar URL"
+          value={avatarUrl}
+          onChange={(e) => setAvatarUrl(e.target.value)}
+          disabled={updateState.loading}
+        />
+        <div className={classes.actions}>
+          <Button
+            type="submit"
+            variant="contained"
+            color="primary"
+            disabled={updateState.loading || !name}
+          >
+            Save
+          </Button>
+          {message && <span className={classes.message}>{message}</span>}
+          {updateError && <span className={classes.error}>{updateError}</span>}
+        </div>
+      </form>
+    </Paper>
+  );
+}
+
+export default Profile;
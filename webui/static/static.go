@@ -0,0 +1,198 @@
+// Package static render the bugs of a repository as a static HTML site, that
+// can be browsed and searched without a server.
+package static
+
+import (
+	"encoding/json"
+	"html/template"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/entity"
+)
+
+// Export write in dir an index of the given bugs, searchable in the browser,
+// and a page per bug. The drafts are not exported, nor the confidential bugs
+// as they would be written in clear. It returns the number of exported bugs.
+func Export(repo *cache.RepoCache, dir string, ids []entity.Id) (int, error) {
+	registry, err := repo.LabelRegistry()
+	if err != nil {
+		return 0, err
+	}
+
+	var bugs []bugPage
+	for _, id := range ids {
+		excerpt, err := repo.ResolveBugExcerpt(id)
+		if err != nil {
+			return 0, err
+		}
+		if excerpt.Draft {
+			continue
+		}
+
+		b, err := repo.ResolveBug(id)
+		if err != nil {
+			return 0, err
+		}
+		snap := b.Snapshot()
+		if snap.IsConfidential() {
+			continue
+		}
+		bugs = append(bugs, newBugPage(snap, registry))
+	}
+
+	// most recent first, like the web UI
+	sort.Slice(bugs, func(i, j int) bool {
+		return bugs[i].Created.After(bugs[j].Created)
+	})
+
+	err = os.MkdirAll(filepath.Join(dir, "bug"), 0755)
+	if err != nil {
+		return 0, err
+	}
+
+	err = ioutil.WriteFile(filepath.Join(dir, "style.css"), []byte(styleCSS), 0644)
+	if err != nil {
+		return 0, err
+	}
+
+	err = writeIndex(filepath.Join(dir, "index.html"), repo.Name(), bugs)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, b := range bugs {
+		err = writeTemplate(filepath.Join(dir, "bug", b.Id+".html"), bugTemplate, b)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	return len(bugs), nil
+}
+
+type labelView struct {
+	Name  string
+	Color template.CSS
+}
+
+type commentView struct {
+	Author  string
+	Date    time.Time
+	Message string
+	// Redacted or minimized, the message is not shown
+	Hidden string
+}
+
+type bugPage struct {
+	Id        string
+	HumanId   string
+	Title     string
+	Open      bool
+	Author    string
+	Created   time.Time
+	Labels    []labelView
+	Assignees []string
+	Milestone string
+	Comments  []commentView
+}
+
+func newBugPage(snap *bug.Snapshot, registry bug.LabelRegistry) bugPage {
+	page := bugPage{
+		Id:        snap.Id().String(),
+		HumanId:   snap.Id().Human(),
+		Title:     snap.Title,
+		Open:      snap.Status == bug.OpenStatus,
+		Author:    snap.Author.DisplayName(),
+		Created:   snap.CreateTime,
+		Milestone: snap.Milestone,
+	}
+
+	for _, label := range snap.Labels {
+		page.Labels = append(page.Labels, labelView{
+			Name:  label.String(),
+			Color: template.CSS(registry.Color(label).Hex()),
+		})
+	}
+
+	for _, assignee := range snap.Assignees {
+		page.Assignees = append(page.Assignees, assignee.DisplayName())
+	}
+
+	for _, comment := range snap.Comments {
+		view := commentView{
+			Author:  comment.Author.DisplayName(),
+			Date:    comment.UnixTime.Time(),
+			Message: comment.Message,
+		}
+		switch {
+		case comment.Redaction != nil:
+			view.Hidden = "redacted"
+			view.Message = ""
+		case comment.Minimized != "":
+			view.Hidden = string(comment.Minimized)
+		}
+		page.Comments = append(page.Comments, view)
+	}
+
+	return page
+}
+
+// searchEntry is the searchable text of a bug, given to the script of the
+// index
+type searchEntry struct {
+	Id   string `json:"id"`
+	Text string `json:"text"`
+}
+
+func writeIndex(path string, name string, bugs []bugPage) error {
+	entries := make([]searchEntry, len(bugs))
+	for i, b := range bugs {
+		texts := []string{b.HumanId, b.Title, b.Author, b.Milestone}
+		for _, label := range b.Labels {
+			texts = append(texts, label.Name)
+		}
+		for _, comment := range b.Comments {
+			if comment.Hidden == "" {
+				texts = append(texts, comment.Message)
+			}
+		}
+		entries[i] = searchEntry{Id: b.Id, Text: strings.ToLower(strings.Join(texts, "\n"))}
+	}
+
+	index, err := json.Marshal(entries)
+	if err != nil {
+		return err
+	}
+
+	return writeTemplate(path, indexTemplate, struct {
+		Name        string
+		Bugs        []bugPage
+		SearchIndex template.JS
+	}{
+		Name:        name,
+		Bugs:        bugs,
+		SearchIndex: template.JS(index),
+	})
+}
+
+func writeTemplate(path string, tmpl *template.Template, data interface{}) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	err = tmpl.Execute(f, data)
+	if err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	return f.Close()
+}
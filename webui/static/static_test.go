@@ -0,0 +1,84 @@
+package static
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/openpgp"
+	"golang.org/x/crypto/openpgp/armor"
+
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/identity"
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+func TestExport(t *testing.T) {
+	repo := repository.CreateGoGitTestRepo(false)
+	defer repository.CleanupTestRepos(repo)
+
+	repoCache, err := cache.NewRepoCache(repo)
+	require.NoError(t, err)
+	defer repoCache.Close()
+
+	author, err := repoCache.NewIdentity("René Descartes", "rene@descartes.fr")
+	require.NoError(t, err)
+	err = repoCache.SetUserIdentity(author)
+	require.NoError(t, err)
+
+	entity, err := openpgp.NewEntity("René Descartes", "", "rene@descartes.fr", nil)
+	require.NoError(t, err)
+	var pubKey bytes.Buffer
+	w, err := armor.Encode(&pubKey, openpgp.PublicKeyType, nil)
+	require.NoError(t, err)
+	require.NoError(t, entity.Serialize(w))
+	require.NoError(t, w.Close())
+	key, err := identity.NewKeyFromArmored(pubKey.String())
+	require.NoError(t, err)
+	err = author.Mutate(func(orig identity.Mutator) identity.Mutator {
+		orig.Keys = []*identity.Key{key}
+		return orig
+	})
+	require.NoError(t, err)
+	require.NoError(t, author.Commit())
+
+	// the confidential bugs are not exported in clear
+	_, _, err = repoCache.NewBugWithOptions("secret bug", "a security issue", cache.NewBugOptions{
+		Recipients: []*cache.IdentityCache{author},
+	})
+	require.NoError(t, err)
+
+	b, _, err := repoCache.NewBug("first bug", "a <b>message</b>")
+	require.NoError(t, err)
+	_, err = b.AddComment("second message")
+	require.NoError(t, err)
+	require.NoError(t, b.Commit())
+
+	dir, err := ioutil.TempDir("", "")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	count, err := Export(repoCache, dir, repoCache.AllBugsIds())
+	require.NoError(t, err)
+	require.Equal(t, 1, count)
+
+	index, err := ioutil.ReadFile(filepath.Join(dir, "index.html"))
+	require.NoError(t, err)
+	require.Contains(t, string(index), "bug/"+b.Id().String()+".html")
+	require.Contains(t, string(index), "second message")
+
+	page, err := ioutil.ReadFile(filepath.Join(dir, "bug", b.Id().String()+".html"))
+	require.NoError(t, err)
+	require.Contains(t, string(page), "first bug")
+	require.Contains(t, string(page), "a &lt;b&gt;message&lt;/b&gt;")
+	require.Contains(t, string(page), "second message")
+
+	require.NotContains(t, string(index), "secret bug")
+	require.NotContains(t, string(index), "a security issue")
+
+	_, err = os.Stat(filepath.Join(dir, "style.css"))
+	require.NoError(t, err)
+}
@@ -0,0 +1,200 @@
+package static
+
+import (
+	"html/template"
+	"time"
+)
+
+var funcs = template.FuncMap{
+	"date": func(t time.Time) string {
+		return t.Format("Jan 2, 2006")
+	},
+}
+
+var layout = `{{define "labels"}}{{range .}}<span class="label" style="background-color: {{.Color}}">{{.Name}}</span>{{end}}{{end}}
+{{define "status"}}{{if .}}<span class="status open">open</span>{{else}}<span class="status closed">closed</span>{{end}}{{end}}`
+
+var indexTemplate = template.Must(template.New("index").Funcs(funcs).Parse(layout + `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Name}} bugs</title>
+<link rel="stylesheet" href="style.css">
+</head>
+<body>
+<header><h1>{{.Name}} bugs</h1></header>
+<main>
+<div class="search">
+<input id="search" type="search" placeholder="Search the titles, labels and comments" autofocus>
+<select id="status">
+<option value="">All</option>
+<option value="open" selected>Open</option>
+<option value="closed">Closed</option>
+</select>
+<span id="count"></span>
+</div>
+<table>
+{{range .Bugs}}<tr data-id="{{.Id}}" data-status="{{if .Open}}open{{else}}closed{{end}}">
+<td>{{template "status" .Open}}</td>
+<td><a href="bug/{{.Id}}.html">{{.Title}}</a> {{template "labels" .Labels}}
+<div class="details">{{.HumanId}} opened on {{date .Created}} by {{.Author}}</div></td>
+</tr>
+{{end}}</table>
+</main>
+<script>
+var index = {{.SearchIndex}};
+var text = {};
+index.forEach(function (e) { text[e.id] = e.text; });
+
+var search = document.getElementById("search");
+var statusSelect = document.getElementById("status");
+var count = document.getElementById("count");
+var rows = document.querySelectorAll("tr[data-id]");
+
+// all the words of the search need to be found in the bug
+function filter() {
+  var words = search.value.toLowerCase().split(/\s+/).filter(Boolean);
+  var shown = 0;
+  rows.forEach(function (row) {
+    var t = text[row.dataset.id];
+    var match = (!statusSelect.value || row.dataset.status === statusSelect.value) &&
+      words.every(function (w) { return t.indexOf(w) !== -1; });
+    row.hidden = !match;
+    if (match) shown++;
+  });
+  count.textContent = shown + " bug" + (shown === 1 ? "" : "s");
+}
+
+search.addEventListener("input", filter);
+statusSelect.addEventListener("change", filter);
+filter();
+</script>
+</body>
+</html>
+`))
+
+var bugTemplate = template.Must(template.New("bug").Funcs(funcs).Parse(layout + `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}}</title>
+<link rel="stylesheet" href="../style.css">
+</head>
+<body>
+<header><a href="../index.html">&larr; All the bugs</a></header>
+<main>
+<h1>{{.Title}} <span class="id">{{.HumanId}}</span></h1>
+<p>{{template "status" .Open}} {{.Author}} opened this bug on {{date .Created}}</p>
+<p>{{template "labels" .Labels}}</p>
+{{if .Assignees}}<p>Assigned to {{range $i, $a := .Assignees}}{{if $i}}, {{end}}{{$a}}{{end}}</p>{{end}}
+{{if .Milestone}}<p>Milestone: {{.Milestone}}</p>{{end}}
+{{range .Comments}}<article>
+<div class="details"><strong>{{.Author}}</strong> commented on {{date .Date}}</div>
+{{if .Hidden}}<div class="hidden">This comment is {{.Hidden}}.</div>{{end}}
+{{if .Message}}<div class="message{{if .Hidden}} minimized{{end}}">{{.Message}}</div>{{end}}
+</article>
+{{end}}</main>
+</body>
+</html>
+`))
+
+const styleCSS = `body {
+  font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", Roboto, sans-serif;
+  margin: 0;
+  color: #24292e;
+}
+header {
+  background-color: #263238;
+  color: white;
+  padding: 0.5em 1em;
+}
+header a {
+  color: white;
+}
+header h1 {
+  font-size: 1.3em;
+}
+main {
+  max-width: 900px;
+  margin: 2em auto;
+  padding: 0 1em;
+}
+.search {
+  display: flex;
+  align-items: center;
+  margin-bottom: 1em;
+}
+.search > * {
+  margin-right: 0.5em;
+}
+#search {
+  flex: 1;
+  padding: 0.4em;
+}
+table {
+  width: 100%;
+  border-collapse: collapse;
+}
+td {
+  border-top: 1px solid #e1e4e8;
+  padding: 0.5em;
+  vertical-align: top;
+}
+td a {
+  color: #24292e;
+  font-weight: 500;
+  text-decoration: none;
+}
+.details {
+  color: #586069;
+  font-size: 0.9em;
+}
+.id {
+  color: #586069;
+  font-weight: normal;
+}
+.status {
+  border-radius: 1em;
+  color: white;
+  font-size: 0.8em;
+  padding: 0.2em 0.6em;
+}
+.status.open {
+  background-color: #28a745;
+}
+.status.closed {
+  background-color: #cb2431;
+}
+.label {
+  border-radius: 0.2em;
+  color: white;
+  font-size: 0.8em;
+  margin-right: 0.3em;
+  padding: 0.1em 0.4em;
+}
+article {
+  border: 1px solid #e1e4e8;
+  border-radius: 0.3em;
+  margin: 1em 0;
+}
+article .details {
+  background-color: #f6f8fa;
+  border-bottom: 1px solid #e1e4e8;
+  padding: 0.5em 1em;
+}
+.message {
+  padding: 0 1em;
+  white-space: pre-wrap;
+  word-wrap: break-word;
+}
+.message.minimized {
+  color: #586069;
+}
+.hidden {
+  color: #586069;
+  font-style: italic;
+  padding: 0.5em 1em;
+}
+`